@@ -16,10 +16,13 @@ Key components:
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 
 	"github.com/gururuby/shortener/internal/app"
 	"github.com/gururuby/shortener/internal/config"
+	"github.com/gururuby/shortener/internal/infra/logger"
 )
 
 // Global variables storing build information.
@@ -34,8 +37,9 @@ var (
 //
 // It performs:
 //  1. Configuration initialization
-//  2. Application instance creation and setup
-//  3. HTTP server startup
+//  2. Database migration and exit, if a migration flag is passed
+//  3. Application instance creation and setup
+//  4. HTTP server startup
 //
 // If any step fails, it logs the error and terminates.
 func main() {
@@ -44,7 +48,18 @@ func main() {
 	if err != nil {
 		log.Fatalf("cannot setup config: %s", err)
 	}
-	app.New(cfg).Setup().Run()
+	if migrationRequested() {
+		logger.Setup(cfg.App.Env, cfg.Log.Level,
+			logger.WithFormat(cfg.Log.Format),
+			logger.WithOutputPath(cfg.Log.OutputPath),
+			logger.WithAppInfo(cfg.App.Name, cfg.App.Version),
+		)
+		if err = runMigrationCommand(context.Background(), cfg, os.Stdout); err != nil {
+			log.Fatalf("cannot run migrations: %s", err)
+		}
+		return
+	}
+	app.New(cfg).WithBuildInfo(buildVersion, buildDate, buildCommit).Setup().Run()
 }
 
 // logBuildInfo logs the build version, date and commit information.
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gururuby/shortener/internal/config"
+	postgresqlDB "github.com/gururuby/shortener/internal/infra/db/postgresql"
+)
+
+// migrateFlagPrefix is the common prefix of migration flags.
+const migrateFlagPrefix = "migrate-"
+
+// Migration flags, when any of them is passed the migration is run instead of the service.
+var (
+	migrateUp     = flag.Bool("migrate-up", false, "Apply pending database migrations and exit")
+	migrateDown   = flag.Int("migrate-down", 0, "Roll back N last applied database migrations and exit")
+	migrateStatus = flag.Bool("migrate-status", false, "Print applied and pending database migrations and exit")
+	migrateDryRun = flag.Bool("migrate-dry-run", false, "Print SQL of pending database migrations without applying them and exit")
+	migrateReset  = flag.Bool("migrate-reset", false, "Roll back all applied database migrations and exit")
+)
+
+// errMigrationsDSNRequired is returned when migrations are requested without PostgreSQL DSN.
+var errMigrationsDSNRequired = errors.New("migrations require PostgreSQL DSN, pass -d flag or DATABASE_DSN")
+
+// migrationRequested reports whether any migration flag was passed on the command line.
+func migrationRequested() bool {
+	requested := false
+	flag.CommandLine.Visit(func(f *flag.Flag) {
+		if strings.HasPrefix(f.Name, migrateFlagPrefix) {
+			requested = true
+		}
+	})
+	return requested
+}
+
+// runMigrationCommand runs the migration operation selected by command-line flags and prints its results.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - cfg: Configuration with database DSN
+// - out: Writer of the results
+// Returns:
+// - error: If DSN is missing, connection fails or the operation fails
+func runMigrationCommand(ctx context.Context, cfg *config.Config, out io.Writer) (err error) {
+	if cfg.Database.DSN == "" {
+		return errMigrationsDSNRequired
+	}
+
+	db, err := postgresqlDB.OpenMigrationDB(ctx, cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, db.Close())
+	}()
+
+	var migrations []*postgresqlDB.Migration
+	switch {
+	case *migrateStatus:
+		if migrations, err = postgresqlDB.MigrationStatus(ctx, db); err != nil {
+			return err
+		}
+		return printMigrationStatus(out, migrations)
+	case *migrateDryRun:
+		if migrations, err = postgresqlDB.DryRunMigrations(ctx, db); err != nil {
+			return err
+		}
+		for _, migration := range migrations {
+			_, _ = fmt.Fprintf(out, "-- %s\n%s\n\n", migration.Name, migration.SQL)
+		}
+		if len(migrations) == 0 {
+			_, _ = fmt.Fprintln(out, "no pending migrations")
+		}
+		return nil
+	case *migrateReset:
+		migrations, err = postgresqlDB.ResetMigrations(ctx, db)
+	case isFlagPassed("migrate-down"):
+		migrations, err = postgresqlDB.RollbackMigrations(ctx, db, *migrateDown)
+	case *migrateUp:
+		migrations, err = postgresqlDB.RunMigrations(ctx, db)
+	}
+
+	for _, migration := range migrations {
+		action := "rolled back"
+		if migration.Applied {
+			action = "applied"
+		}
+		_, _ = fmt.Fprintf(out, "%s %s\n", action, migration.Name)
+	}
+	if err == nil && len(migrations) == 0 {
+		_, _ = fmt.Fprintln(out, "no migrations to run")
+	}
+	return err
+}
+
+// printMigrationStatus prints migrations as a table of states, application times and names.
+// Parameters:
+// - out: Writer of the table
+// - migrations: Migrations ordered by version
+// Returns:
+// - error: If the table cannot be written
+func printMigrationStatus(out io.Writer, migrations []*postgresqlDB.Migration) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "STATE\tAPPLIED AT\tMIGRATION")
+	for _, migration := range migrations {
+		state, appliedAt := "pending", "-"
+		if migration.Applied {
+			state, appliedAt = "applied", migration.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", state, appliedAt, migration.Name)
+	}
+	return w.Flush()
+}
+
+// isFlagPassed reports whether the flag was passed on the command line, also with its default value.
+func isFlagPassed(name string) bool {
+	passed := false
+	flag.CommandLine.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			passed = true
+		}
+	})
+	return passed
+}
@@ -0,0 +1,126 @@
+// Package defercheck provides a static analysis tool that reports deferred calls
+// discarding a returned error.
+//
+// A deferred call is executed as a statement, so whatever it returns is lost.
+// When the function returns an error, e.g. Close of a file opened for writing,
+// a failure indicating lost or corrupted data is silently ignored.
+//
+// Calls of functions without results, or with results not including an error,
+// are not reported. Deferred function literals are not reported either, they are
+// the way to handle the error explicitly:
+//
+//	defer func() {
+//	    if err := f.Close(); err != nil {
+//	        log.Printf("close: %v", err)
+//	    }
+//	}()
+//
+// Methods whose error is known to be redundant are excluded, e.g. (*sql.Rows).Close,
+// whose failures are reported by (*sql.Rows).Err.
+//
+// Example violations:
+//
+//	package main
+//
+//	import "os"
+//
+//	func main() {
+//	    f, _ := os.Create("out.txt")
+//	    defer f.Close() // will be flagged by the analyzer
+//	}
+//
+// The analyzer will report:
+//
+//	main.go:7:8: error returned by deferred call to f.Close is not checked
+package defercheck
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// excludedFuncs lists full names of functions whose errors may be discarded in defer.
+var excludedFuncs = map[string]bool{
+	"(*database/sql.Rows).Close": true, // Failures are reported by (*sql.Rows).Err
+}
+
+// Analyzer is the analyzer variable that checks deferred calls for discarded errors.
+// It implements the analysis.Analyzer interface and can be used with analysis tools.
+var Analyzer = &analysis.Analyzer{
+	Name:     "defercheck",
+	Doc:      "report deferred calls discarding a returned error",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// run is the analysis function that implements the check logic.
+// It visits defer statements and reports calls whose results include an error.
+func run(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	insp.Preorder([]ast.Node{(*ast.DeferStmt)(nil)}, func(n ast.Node) {
+		call := n.(*ast.DeferStmt).Call
+		if !returnsError(pass.TypesInfo, call) {
+			return
+		}
+
+		if excludedFuncs[calledFuncName(pass.TypesInfo, call)] {
+			return
+		}
+
+		pass.Reportf(call.Pos(), "error returned by deferred call to %s is not checked", types.ExprString(call.Fun))
+	})
+
+	return nil, nil
+}
+
+// returnsError reports whether any result of the call is of error type.
+// Calls without results, builtins and type conversions are not.
+func returnsError(info *types.Info, call *ast.CallExpr) bool {
+	tv, ok := info.Types[call]
+	if !ok || tv.Type == nil {
+		return false
+	}
+
+	if tuple, ok := tv.Type.(*types.Tuple); ok {
+		for i := 0; i < tuple.Len(); i++ {
+			if isError(tuple.At(i).Type()) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return isError(tv.Type)
+}
+
+// isError reports whether the type is the predeclared error interface.
+func isError(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+// calledFuncName returns full name of the called function or method,
+// e.g. "(*os.File).Close". Returns empty string for calls of function values.
+func calledFuncName(info *types.Info, call *ast.CallExpr) string {
+	var ident *ast.Ident
+
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return ""
+	}
+
+	fn, ok := info.Uses[ident].(*types.Func)
+	if !ok {
+		return ""
+	}
+
+	return fn.Origin().FullName()
+}
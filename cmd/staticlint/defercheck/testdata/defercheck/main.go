@@ -0,0 +1,59 @@
+// Package main demonstrates deferred calls checked by the defercheck analyzer.
+//
+// Deferred calls discarding a returned error are flagged, calls of functions
+// without error result and deferred function literals handling the error are not.
+package main
+
+import (
+	"database/sql"
+	"log"
+	"os"
+	"sync"
+)
+
+type rows struct{}
+
+func (*rows) Close() error { return nil }
+
+func openRows() *rows { return &rows{} }
+
+func pair() (int, error) { return 0, nil }
+
+func flagged() {
+	rows := openRows()
+	defer rows.Close() // want `error returned by deferred call to rows.Close is not checked`
+
+	f, _ := os.Create("out.txt")
+	defer f.Close() // want `error returned by deferred call to f.Close is not checked`
+
+	defer pair() // want `error returned by deferred call to pair is not checked`
+
+	closeFn := f.Close
+	defer closeFn() // want `error returned by deferred call to closeFn is not checked`
+}
+
+func notFlagged(db *sql.DB) {
+	rows := openRows()
+	defer func() { _ = rows.Close() }()
+
+	f, _ := os.Open("in.txt")
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Printf("close: %v", err)
+		}
+	}()
+
+	var mu sync.Mutex
+	mu.Lock()
+	defer mu.Unlock()
+
+	sqlRows, _ := db.Query("SELECT 1")
+	defer sqlRows.Close()
+
+	defer log.Println("done")
+}
+
+func main() {
+	flagged()
+	notFlagged(nil)
+}
@@ -1,6 +1,6 @@
 // Package main implements a custom static analysis tool that combines multiple Go analyzers
 // into a single executable. It includes standard go/analysis passes, selected staticcheck
-// analyzers, style checks, and custom analyzers like the noexit checker.
+// analyzers, style checks, and custom analyzers like the noexit and defercheck checkers.
 //
 // The tool is designed to enforce code quality standards and catch potential issues by running
 // multiple analyzers simultaneously through the multichecker framework.
@@ -24,7 +24,9 @@
 //    - ST1001: Enforces naming style conventions
 //
 // 4. Custom analyzers:
-//    - noexit: Forbids direct calls to os.Exit in main functions
+//    - noexit: Forbids calls to os.Exit in main functions and helpers called from them,
+//      and calls to log.Fatal* and log.Panic* in main functions
+//    - defercheck: Reports deferred calls discarding a returned error, e.g. defer f.Close()
 //
 // # Usage
 //
@@ -57,6 +59,7 @@
 package main
 
 import (
+	"github.com/gururuby/shortener/cmd/staticlint/defercheck"
 	"github.com/gururuby/shortener/cmd/staticlint/noexit"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/multichecker"
@@ -138,7 +141,7 @@ func main() {
 		st1001.SCAnalyzer.Analyzer, // Naming style
 	)
 
-	checks = append(checks, noexit.Analyzer)
+	checks = append(checks, noexit.Analyzer, defercheck.Analyzer)
 
 	multichecker.Main(checks...)
 }
@@ -1,9 +1,17 @@
-// Package noexit provides a static analysis tool that forbids direct calls to os.Exit
-// in the main function of the main package.
+// Package noexit provides a static analysis tool that forbids calls to os.Exit
+// in the main function of the main package and in functions reachable from it.
 //
 // The analyzer helps enforce better program termination practices by requiring
 // proper error handling and cleanup before program exit.
 //
+// Starting from main, the analyzer follows calls to functions and methods declared
+// in the main package itself and reports os.Exit calls in any of them.
+// Calls to functions of other packages are not followed.
+//
+// Calls to log.Fatal, log.Fatalf, log.Fatalln, log.Panic, log.Panicf and log.Panicln
+// are reported when made directly in the main function, since they terminate the
+// program the same way and skip deferred cleanup of main.
+//
 // Usage:
 //
 // To use this analyzer with go vet:
@@ -14,45 +22,74 @@
 //
 //	analyzer := noexit.NoExitAnalyzer
 //
-// Example violation:
+// Example violations:
 //
 //	package main
 //
-//	import "os"
+//	import (
+//	    "log"
+//	    "os"
+//	)
 //
 //	func main() {
 //	    os.Exit(1) // will be flagged by the analyzer
+//	    log.Fatal("failed") // will be flagged too
+//	    helper()
+//	}
+//
+//	func helper() {
+//	    os.Exit(1) // will be flagged too, helper is called from main
 //	}
 //
 // The analyzer will report:
 //
-//	main.go:6:2: direct call to os.Exit in main function of main package is forbidden
+//	main.go:9:2: direct call to os.Exit in main function of main package is forbidden
+//	main.go:10:2: direct call to log.Fatal in main function of main package is forbidden
+//	main.go:15:2: call to os.Exit in helper reachable from main function of main package is forbidden
 package noexit
 
 import (
 	"go/ast"
+	"go/types"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 )
 
+// terminatingLogFuncs lists functions of log package which terminate the program.
+var terminatingLogFuncs = map[string]bool{
+	"Fatal":   true,
+	"Fatalf":  true,
+	"Fatalln": true,
+	"Panic":   true,
+	"Panicf":  true,
+	"Panicln": true,
+}
+
 // Analyzer is the analyzer variable that checks for forbidden os.Exit calls.
 // It implements the analysis.Analyzer interface and can be used with analysis tools.
 //
-// The analyzer checks all files in the main package named "main.go" for a main()
-// function containing direct calls to os.Exit().
+// The analyzer checks the main function of the main package and all functions
+// of the main package transitively called from it for calls to os.Exit().
+// The main function itself is also checked for calls to log.Fatal* and log.Panic*.
 var Analyzer = &analysis.Analyzer{
 	Name:     "noexit",
-	Doc:      "forbid direct calls to os.Exit in main function of main package",
+	Doc:      "forbid calls to os.Exit in main function of main package and functions called from it, and calls to log.Fatal* and log.Panic* in main function",
 	Requires: []*analysis.Analyzer{inspect.Analyzer},
 	Run:      run,
 }
 
 // run is the analysis function that implements the check logic.
-// It examines each file in the package, looking for main packages and checking
-// their main functions for os.Exit calls.
+// It collects function declarations of the main package, then walks the call graph
+// from main and reports os.Exit calls in every reachable function.
 func run(pass *analysis.Pass) (interface{}, error) {
+	if pass.Pkg.Name() != "main" {
+		return nil, nil
+	}
+
+	decls := make(map[*types.Func]*ast.FuncDecl)
+
 	for _, file := range pass.Files {
 
 		// Ignore cache go-build files
@@ -60,34 +97,121 @@ func run(pass *analysis.Pass) (interface{}, error) {
 			continue
 		}
 
-		if pass.Pkg.Name() == "main" {
-			ast.Inspect(file, func(n ast.Node) bool {
-				// Find main function
-				if fn, ok := n.(*ast.FuncDecl); ok && fn.Name.Name == "main" {
-					// Detect os.Exit
-					ast.Inspect(fn.Body, func(n ast.Node) bool {
-						call, ok := n.(*ast.CallExpr)
-						if !ok {
-							return true
-						}
-
-						sel, ok := call.Fun.(*ast.SelectorExpr)
-						if !ok {
-							return true
-						}
-
-						ident, ok := sel.X.(*ast.Ident)
-
-						if ok && ident.Name == "os" && sel.Sel.Name == "Exit" {
-							pass.Reportf(call.Pos(), "direct call to os.Exit in main function of main package is forbidden")
-						}
-
-						return true
-					})
-				}
-				return true
-			})
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			if obj, ok := pass.TypesInfo.Defs[fn.Name].(*types.Func); ok {
+				decls[obj] = fn
+			}
 		}
 	}
+
+	mainFn, ok := pass.Pkg.Scope().Lookup("main").(*types.Func)
+	if !ok || decls[mainFn] == nil {
+		return nil, nil
+	}
+
+	visited := map[*types.Func]bool{mainFn: true}
+	queue := []*types.Func{mainFn}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		ast.Inspect(decls[current].Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			callee := calledFunc(pass.TypesInfo, call)
+			if callee == nil || callee.Pkg() == nil {
+				return true
+			}
+
+			// Detect os.Exit
+			if callee.Pkg().Path() == "os" && callee.Name() == "Exit" {
+				reportExit(pass, call, current, mainFn)
+				return true
+			}
+
+			// Detect log.Fatal* and log.Panic* in main itself
+			if current == mainFn && isTerminatingLogCall(callee) {
+				pass.Reportf(call.Pos(), "direct call to log.%s in main function of main package is forbidden", callee.Name())
+				return true
+			}
+
+			// Follow calls only within the analyzed package
+			if callee.Pkg() == pass.Pkg && decls[callee] != nil && !visited[callee] {
+				visited[callee] = true
+				queue = append(queue, callee)
+			}
+
+			return true
+		})
+	}
+
 	return nil, nil
 }
+
+// calledFunc resolves the function or method called by the call expression.
+// Returns nil for calls of function values, builtins and type conversions.
+func calledFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	var ident *ast.Ident
+
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	case *ast.IndexExpr: // Generic function instantiation: f[T](...)
+		ident = identOf(fun.X)
+	case *ast.IndexListExpr: // Generic function instantiation: f[T1, T2](...)
+		ident = identOf(fun.X)
+	default:
+		return nil
+	}
+
+	if ident == nil {
+		return nil
+	}
+
+	fn, _ := info.Uses[ident].(*types.Func)
+	if fn == nil {
+		return nil
+	}
+
+	return fn.Origin()
+}
+
+// isTerminatingLogCall reports whether fn is a package-level log function terminating the program.
+// Methods of log.Logger are not reported.
+func isTerminatingLogCall(fn *types.Func) bool {
+	if fn.Pkg().Path() != "log" || !terminatingLogFuncs[fn.Name()] {
+		return false
+	}
+	sig, ok := fn.Type().(*types.Signature)
+	return ok && sig.Recv() == nil
+}
+
+// identOf returns identifier of a plain or qualified name expression.
+func identOf(expr ast.Expr) *ast.Ident {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e
+	case *ast.SelectorExpr:
+		return e.Sel
+	}
+	return nil
+}
+
+// reportExit reports os.Exit call found in fn.
+func reportExit(pass *analysis.Pass, call *ast.CallExpr, fn, mainFn *types.Func) {
+	if fn == mainFn {
+		pass.Reportf(call.Pos(), "direct call to os.Exit in main function of main package is forbidden")
+		return
+	}
+	pass.Reportf(call.Pos(), "call to os.Exit in %s reachable from main function of main package is forbidden", fn.Name())
+}
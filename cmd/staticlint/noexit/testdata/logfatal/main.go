@@ -0,0 +1,18 @@
+// Package main demonstrates violations of the noexit analyzer rule
+// with log functions terminating the program from main.
+//
+// log.Fatal* calls os.Exit and log.Panic* panics, so deferred cleanup
+// of main is skipped the same way as with a direct os.Exit call.
+package main
+
+import "log"
+
+func main() {
+	log.Fatal("failed")         // want "direct call to log.Fatal in main function of main package is forbidden"
+	log.Fatalf("failed: %d", 1) // want "direct call to log.Fatalf in main function of main package is forbidden"
+	log.Fatalln("failed")       // want "direct call to log.Fatalln in main function of main package is forbidden"
+	log.Panic("failed")         // want "direct call to log.Panic in main function of main package is forbidden"
+	log.Panicf("failed: %d", 1) // want "direct call to log.Panicf in main function of main package is forbidden"
+	log.Panicln("failed")       // want "direct call to log.Panicln in main function of main package is forbidden"
+	log.Println("not terminating")
+}
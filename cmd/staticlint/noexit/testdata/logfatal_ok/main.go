@@ -0,0 +1,16 @@
+// Package main demonstrates log.Fatal allowed outside of main function.
+//
+// Only main itself is checked for log.Fatal* and log.Panic* calls.
+package main
+
+import "log"
+
+func main() {
+	log.Println("starting")
+}
+
+func mustStart(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,10 @@
+// Package exithelper provides a helper terminating the program,
+// it is used by transitivecall_ok test case.
+package exithelper
+
+import "os"
+
+// Exit terminates the program.
+func Exit() {
+	os.Exit(1)
+}
@@ -0,0 +1,21 @@
+// Package main demonstrates a violation of the noexit analyzer rule
+// through a helper function called from main.
+//
+// Helpers declared in the main package are followed transitively,
+// so os.Exit is flagged even if it is not called from main directly.
+package main
+
+import "os"
+
+func main() {
+	helper()
+}
+
+func helper() {
+	deeper()
+}
+
+func deeper() {
+	defer helper() // recursive calls must not hang the analyzer
+	os.Exit(1)     // want "call to os.Exit in deeper reachable from main function of main package is forbidden"
+}
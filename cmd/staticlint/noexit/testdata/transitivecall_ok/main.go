@@ -0,0 +1,10 @@
+// Package main demonstrates a helper from another package calling os.Exit.
+//
+// The analyzer does not cross package boundaries, so it is not flagged.
+package main
+
+import "exithelper"
+
+func main() {
+	exithelper.Exit()
+}
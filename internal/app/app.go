@@ -8,24 +8,66 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/gururuby/shortener/internal/config"
 	"github.com/gururuby/shortener/internal/domain/entity/shorturl"
 	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	analyticsStorage "github.com/gururuby/shortener/internal/domain/storage/analytics"
+	namespaceStorage "github.com/gururuby/shortener/internal/domain/storage/namespace"
 	shortURLStorage "github.com/gururuby/shortener/internal/domain/storage/shorturl"
+	splitStorage "github.com/gururuby/shortener/internal/domain/storage/split"
+	statsStorage "github.com/gururuby/shortener/internal/domain/storage/stats"
+	tagStorage "github.com/gururuby/shortener/internal/domain/storage/tag"
 	userStorage "github.com/gururuby/shortener/internal/domain/storage/user"
+	webhookStorage "github.com/gururuby/shortener/internal/domain/storage/webhook"
+	analyticsUseCase "github.com/gururuby/shortener/internal/domain/usecase/analytics"
 	appUseCase "github.com/gururuby/shortener/internal/domain/usecase/app"
+	namespaceUseCase "github.com/gururuby/shortener/internal/domain/usecase/namespace"
+	previewUseCase "github.com/gururuby/shortener/internal/domain/usecase/preview"
+	qrUseCase "github.com/gururuby/shortener/internal/domain/usecase/qr"
 	shortURLUseCase "github.com/gururuby/shortener/internal/domain/usecase/shorturl"
+	splitUseCase "github.com/gururuby/shortener/internal/domain/usecase/split"
+	statsUseCase "github.com/gururuby/shortener/internal/domain/usecase/stats"
+	tagUseCase "github.com/gururuby/shortener/internal/domain/usecase/tag"
 	userUseCase "github.com/gururuby/shortener/internal/domain/usecase/user"
+	webhookUseCase "github.com/gururuby/shortener/internal/domain/usecase/webhook"
+	apiAdminHandler "github.com/gururuby/shortener/internal/handler/http/api/admin"
+	apiAnalyticsHandler "github.com/gururuby/shortener/internal/handler/http/api/analytics"
+	apiEventsHandler "github.com/gururuby/shortener/internal/handler/http/api/events"
+	apiNamespaceHandler "github.com/gururuby/shortener/internal/handler/http/api/namespace"
+	apiPreviewHandler "github.com/gururuby/shortener/internal/handler/http/api/preview"
+	apiQRHandler "github.com/gururuby/shortener/internal/handler/http/api/qr"
 	apiShortURLHandler "github.com/gururuby/shortener/internal/handler/http/api/shorturl"
+	apiSplitHandler "github.com/gururuby/shortener/internal/handler/http/api/split"
+	apiStatsHandler "github.com/gururuby/shortener/internal/handler/http/api/stats"
 	apiUserHandler "github.com/gururuby/shortener/internal/handler/http/api/user"
 	appHandler "github.com/gururuby/shortener/internal/handler/http/app"
 	shortURLHandler "github.com/gururuby/shortener/internal/handler/http/shorturl"
 	database "github.com/gururuby/shortener/internal/infra/db"
+	"github.com/gururuby/shortener/internal/infra/eventbus"
+	"github.com/gururuby/shortener/internal/infra/geoip"
+	"github.com/gururuby/shortener/internal/infra/idempotency"
+	"github.com/gururuby/shortener/internal/infra/janitor"
 	"github.com/gururuby/shortener/internal/infra/jwt"
 	"github.com/gururuby/shortener/internal/infra/logger"
+	"github.com/gururuby/shortener/internal/infra/metrics"
 	"github.com/gururuby/shortener/internal/infra/router"
 	"github.com/gururuby/shortener/internal/infra/server"
+	"github.com/gururuby/shortener/internal/infra/telemetry"
+	"github.com/gururuby/shortener/internal/infra/urlvalidator"
+	"github.com/gururuby/shortener/internal/middleware"
+	"github.com/gururuby/shortener/pkg/domainfilter"
+	"github.com/gururuby/shortener/pkg/preview"
+	"github.com/gururuby/shortener/pkg/safebrowsing"
+	"github.com/gururuby/shortener/pkg/validator"
+	"github.com/redis/go-redis/v9"
+)
+
+// Cache of page previews
+const (
+	previewCacheSize = 1000      // Maximum number of cached page previews
+	previewCacheTTL  = time.Hour // Time to keep a page preview
 )
 
 // Router defines the interface for HTTP request routing.
@@ -39,10 +81,23 @@ type DB interface {
 	Shutdown(context.Context) error
 }
 
+// Telemetry defines the interface for tracing shutdown.
+type Telemetry interface {
+	// Shutdown flushes pending spans
+	Shutdown(context.Context) error
+}
+
+// GeoResolver defines the interface for resolving client locations opened on startup.
+type GeoResolver interface {
+	analyticsUseCase.GeoResolver
+	// Close releases the geolocation database
+	Close() error
+}
+
 // ShortURLStorage defines the interface for short URL persistence operations.
 type ShortURLStorage interface {
-	FindShortURL(ctx context.Context, alias string) (*entity.ShortURL, error)
-	SaveShortURL(ctx context.Context, user *userEntity.User, sourceURL string) (*entity.ShortURL, error)
+	FindShortURL(ctx context.Context, namespace, alias string) (*entity.ShortURL, error)
+	SaveShortURL(ctx context.Context, user *userEntity.User, sourceURL, normalizedURL string, opts entity.CreateOptions) (*entity.ShortURL, error)
 	IsDBReady(ctx context.Context) error
 }
 
@@ -51,7 +106,7 @@ type UserStorage interface {
 	FindUser(ctx context.Context, userID int) (*userEntity.User, error)
 	FindURLs(ctx context.Context, userID int) ([]*entity.ShortURL, error)
 	SaveUser(ctx context.Context) (*userEntity.User, error)
-	MarkURLAsDeleted(ctx context.Context, userID int, aliases []string) error
+	MarkURLAsDeleted(ctx context.Context, userID int, namespace string, aliases []string) error
 }
 
 // App represents the main application container with all dependencies.
@@ -61,6 +116,14 @@ type App struct {
 	Config           *config.Config
 	Router           Router
 	DB               DB
+	Telemetry        Telemetry
+	BuildInfo        appUseCase.BuildInfo
+	Janitor          *janitor.Janitor           // Removes expired short URLs in background
+	URLValidator     *urlvalidator.URLValidator // Checks health of source URLs in background
+	Events           *apiEventsHandler.Hub      // Streams created short URLs to connected clients
+	GeoResolver      GeoResolver                // Resolves locations of clicks
+	EventBus         *eventbus.EventBus         // Delivers short URL lifecycle events to webhooks and analytics
+	ConfigWatcher    *config.ConfigWatcher      // Applies settings changed in config file, nil without config file
 }
 
 // New creates a new App instance with the given configuration.
@@ -68,42 +131,279 @@ func New(cfg *config.Config) *App {
 	return &App{Config: cfg}
 }
 
+// WithBuildInfo sets build information reported by GET /api/info.
+// Must be called before Setup.
+// Parameters:
+// - version: Version number of the build
+// - date: Date when the build was created
+// - commit: Git commit hash of the build
+// Returns:
+// - *App: The same App instance for chaining
+func (a *App) WithBuildInfo(version, date, commit string) *App {
+	a.BuildInfo = appUseCase.BuildInfo{Version: version, Date: date, Commit: commit}
+	return a
+}
+
 // Setup initializes all application dependencies in the correct order.
 func (a *App) Setup() *App {
 	ctx := context.Background()
-	logger.Setup(a.Config.App.Env, a.Config.Log.Level)
+	logger.Setup(a.Config.App.Env, a.Config.Log.Level,
+		logger.WithFormat(a.Config.Log.Format),
+		logger.WithOutputPath(a.Config.Log.OutputPath),
+		logger.WithAppInfo(a.Config.App.Name, a.Config.App.Version),
+	)
 
 	db, err := database.Setup(ctx, a.Config)
 	if err != nil {
 		log.Fatalf("cannot setup database: %s", err)
 	}
 
-	shortURLStg := shortURLStorage.Setup(db, a.Config)
+	shortURLStg, err := shortURLStorage.Setup(db, a.Config)
+	if err != nil {
+		log.Fatalf("cannot setup short URL storage: %s", err)
+	}
+
+	tp, err := telemetry.Setup(ctx, a.Config)
+	if err != nil {
+		log.Fatalf("cannot setup telemetry: %s", err)
+	}
+
 	userStg := userStorage.Setup(db)
-	r := router.Setup()
-	auth := jwt.New(a.Config.Auth.SecretKey, a.Config.Auth.TokenTTL)
+	reg := metrics.NewRegistry()
+	metrics.RegisterDBPoolStats(reg, shortURLStg.DBPoolStats)
+	bodyLimiter := middleware.NewBodyLimiter(a.Config.Server.MaxBodyBytes)
+	r := router.Setup(a.Config, reg, tp, bodyLimiter)
+	auth := jwt.New(a.Config.Auth.SecretKey, a.Config.Auth.TokenTTL, setupRevocationStore(a.Config))
+
+	webhookUC := webhookUseCase.NewWebhookUseCase(webhookStorage.Setup(db))
+	bus := eventbus.New(eventbus.DefaultQueueSize)
+	metrics.RegisterEventBusStats(reg, bus.Dropped)
+	eventsHub := apiEventsHandler.NewHub(a.Config.App.EventsMaxConns)
+	userUC := metrics.InstrumentUserUseCase(userUseCase.NewUserUseCase(auth, userStg, webhookUC, a.Config.App.BaseURL), reg)
+	urlChecker := setupURLChecker(a.Config)
+	domainFilter := domainfilter.New(a.Config.App.DomainBlacklist, a.Config.App.DomainWhitelist)
+	shortURLOpts := []shortURLUseCase.Option{
+		shortURLUseCase.WithUserStorage(userStg),
+		shortURLUseCase.WithURLQuota(a.Config.App.MaxURLsPerUser, a.Config.App.MaxAnonymousURLs),
+	}
+	if a.Config.App.CheckRedirectChain {
+		shortURLOpts = append(shortURLOpts, shortURLUseCase.WithRedirectChainCheck(validator.CheckRedirectChain, a.Config.App.MaxRedirectHops))
+	}
+	rawURLUC := shortURLUseCase.NewShortURLUseCase(
+		shortURLStg,
+		urlChecker,
+		domainFilter,
+		bus,
+		eventsHub,
+		a.Config.App.BaseURL,
+		shortURLOpts...,
+	)
+	urlUC := metrics.InstrumentShortURLUseCase(rawURLUC, reg)
+	appUC := appUseCase.NewAppUseCase(shortURLStg, a.Config.Database.Type, a.Config.App.Name, a.Config.App.Env, a.BuildInfo)
+	qrUC := qrUseCase.NewQRUseCase(a.Config.App.BaseURL)
+	statsUC := statsUseCase.NewStatsUseCase(statsStorage.Setup(db))
+	tagUC := tagUseCase.NewTagUseCase(tagStorage.Setup(db), a.Config.App.BaseURL)
+	geoResolver, err := setupGeoResolver(a.Config)
+	if err != nil {
+		log.Fatalf("cannot open geolocation database: %s", err)
+	}
+	analyticsUC := analyticsUseCase.NewAnalyticsUseCase(analyticsStorage.Setup(db), geoResolver)
+	subscribeEvents(bus, webhookUC, analyticsUC, a.Config.App.BaseURL)
+	splitUC := splitUseCase.NewSplitURLUseCase(splitStorage.Setup(db), urlChecker, domainFilter, a.Config.App.BaseURL)
+	namespaceUC := namespaceUseCase.NewNamespaceUseCase(namespaceStorage.Setup(db), a.Config.App.DefaultNamespace)
+	previewUC := previewUseCase.NewPreviewUseCase(preview.NewCachedScraper(preview.NewHTTPScraper(), previewCacheSize, previewCacheTTL), domainFilter)
 
-	userUC := userUseCase.NewUserUseCase(auth, userStg, a.Config.App.BaseURL)
-	urlUC := shortURLUseCase.NewShortURLUseCase(shortURLStg, a.Config.App.BaseURL)
-	appUC := appUseCase.NewAppUseCase(shortURLStg)
+	var redirectPage shortURLHandler.RedirectPage
+	if a.Config.App.UseRedirectPage {
+		redirectPage = appHandler.RegisterRedirectPage(r)
+	}
 
-	shortURLHandler.Register(r, urlUC, userUC)
+	trackingPage := appHandler.NewTrackingPage(a.Config.App.TrackingPixel)
+
+	clickLimiter := middleware.NewAliasRateLimiter(a.Config.Analytics.MaxClicksPerSecond)
+	shortURLHandler.Register(r, urlUC, userUC, bus, splitUC, redirectPage, trackingPage, clickLimiter.Middleware, a.Config.App.BaseURL, a.Config.Server)
 	appHandler.Register(r, appUC)
-	apiShortURLHandler.Register(r, userUC, urlUC)
-	apiUserHandler.Register(r, userUC)
+	apiShortURLHandler.Register(r, userUC, urlUC, setupIdempotencyStore(a.Config), a.Config.Server)
+	apiUserHandler.Register(r, userUC, urlUC, tagUC, webhookUC)
+	// QR and analytics lookups are not redirects, so they are not tracked by redirect timing
+	apiQRHandler.Register(r, rawURLUC, qrUC)
+	apiAnalyticsHandler.Register(r, userUC, rawURLUC, analyticsUC)
+	apiStatsHandler.Register(r, statsUC)
+	apiEventsHandler.Register(r, userUC, eventsHub)
+	apiSplitHandler.Register(r, userUC, splitUC)
+	apiNamespaceHandler.Register(r, namespaceUC, a.Config.Auth.AdminToken)
+	apiAdminHandler.Register(r, userUC, a.Config.Auth.AdminToken)
+	apiPreviewHandler.Register(r, previewUC)
 
 	a.ShortURLSStorage = shortURLStg
 	a.UserStorage = userStg
 	a.Router = r
 	a.DB = db
+	a.Telemetry = tp
+	a.Janitor = janitor.New(shortURLStg, a.Config.App.JanitorInterval)
+	a.URLValidator = urlvalidator.New(shortURLStg, a.Config.App.ValidatorInterval)
+	a.Events = eventsHub
+	a.GeoResolver = geoResolver
+	a.EventBus = bus
+	a.ConfigWatcher = setupConfigWatcher(a.Config, logger.Reloader{}, domainFilter, bodyLimiter, clickLimiter)
 
 	return a
 }
 
-// Run starts the application server.
+// setupURLChecker returns Google Safe Browsing checker if it is enabled and configured,
+// otherwise every URL is treated as safe.
+func setupURLChecker(cfg *config.Config) shortURLUseCase.URLChecker {
+	if cfg.SafeBrowsing.Enabled && cfg.SafeBrowsing.APIKey != "" {
+		return safebrowsing.NewGoogleSafeBrowsingChecker(cfg.SafeBrowsing.APIKey)
+	}
+	return safebrowsing.NoOpChecker{}
+}
+
+// setupGeoResolver opens MaxMind database if it is configured,
+// otherwise locations of clicks are not resolved.
+func setupGeoResolver(cfg *config.Config) (GeoResolver, error) {
+	if cfg.Analytics.GeoIPDB == "" {
+		return geoip.NullResolver{}, nil
+	}
+
+	resolver, err := geoip.Open(cfg.Analytics.GeoIPDB)
+	if err != nil {
+		return nil, err
+	}
+	return resolver, nil
+}
+
+// setupRevocationStore returns Redis-backed store of revoked tokens if Redis address is configured,
+// otherwise revoked tokens are kept in memory of the current instance.
+func setupRevocationStore(cfg *config.Config) jwt.RevocationStore {
+	if cfg.Auth.RevocationRedisAddr != "" {
+		return jwt.NewRedisRevocationStore(redis.NewClient(&redis.Options{Addr: cfg.Auth.RevocationRedisAddr}))
+	}
+	return jwt.NewMemoryRevocationStore(cfg.Auth.RevocationCleanupInterval)
+}
+
+// setupConfigWatcher returns watcher of the config file applying changed log level,
+// domain lists, body size limit and click rate limit at runtime.
+// Parameters:
+// - cfg: Configuration the application is running with
+// - reloadables: Components applying changed settings
+// Returns:
+// - *config.ConfigWatcher: Watcher, nil if configuration is not loaded from file or the file cannot be watched
+func setupConfigWatcher(cfg *config.Config, reloadables ...config.Reloadable) *config.ConfigWatcher {
+	if config.FilePath() == "" {
+		return nil
+	}
+
+	w, err := config.NewConfigWatcher(config.FilePath(), cfg)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("cannot watch config file, settings are applied on restart only: %s", err))
+		return nil
+	}
+	w.Register(reloadables...)
+	return w
+}
+
+// setupIdempotencyStore returns Redis-backed store of replayed responses if Redis address is configured,
+// otherwise responses are kept in memory of the current instance.
+func setupIdempotencyStore(cfg *config.Config) idempotency.IdempotencyStore {
+	if cfg.Server.IdempotencyRedisAddr != "" {
+		return idempotency.NewRedisStore(redis.NewClient(&redis.Options{Addr: cfg.Server.IdempotencyRedisAddr}))
+	}
+	return idempotency.NewMemoryStore(cfg.Server.IdempotencyTTL)
+}
+
+// Run starts the application server, background janitor and validator of source URLs.
+// The janitor, validator and config file watcher are stopped when the server shuts down,
+// event streams are ended as soon as shutdown starts.
+// Queued lifecycle events are handled before the geolocation database is closed.
 func (a *App) Run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	janitorDone := a.startJanitor(ctx)
+	validatorDone := a.startURLValidator(ctx)
+	if a.ConfigWatcher != nil {
+		go a.ConfigWatcher.Run(ctx)
+	}
+
 	a.printWelcomeMessage()
-	server.New(a.Router, a.Config, a.DB).Run()
+	srv := server.New(a.Router, a.Config, a.DB)
+	if a.Events != nil {
+		srv.OnShutdown(a.Events.Close)
+	}
+	srv.Run()
+
+	cancel()
+	<-janitorDone
+	<-validatorDone
+	if a.EventBus != nil {
+		a.EventBus.Close()
+	}
+	a.shutdownTelemetry()
+	a.closeGeoResolver()
+}
+
+// startJanitor runs removal of expired short URLs in background until ctx is cancelled.
+// The janitor is disabled if it is not set up or its interval is not positive.
+// Parameters:
+// - ctx: Context whose cancellation stops the janitor
+// Returns:
+// - <-chan struct{}: Channel closed when the janitor stops
+func (a *App) startJanitor(ctx context.Context) <-chan struct{} {
+	done := make(chan struct{})
+	if a.Janitor == nil || a.Config.App.JanitorInterval <= 0 {
+		close(done)
+		return done
+	}
+
+	go func() {
+		defer close(done)
+		a.Janitor.Run(ctx)
+	}()
+	return done
+}
+
+// startURLValidator runs health checks of source URLs in background until ctx is cancelled.
+// The validator is disabled if it is not set up or its interval is not positive.
+// Parameters:
+// - ctx: Context whose cancellation stops the validator
+// Returns:
+// - <-chan struct{}: Channel closed when the validator stops
+func (a *App) startURLValidator(ctx context.Context) <-chan struct{} {
+	done := make(chan struct{})
+	if a.URLValidator == nil || a.Config.App.ValidatorInterval <= 0 {
+		close(done)
+		return done
+	}
+
+	go func() {
+		defer close(done)
+		a.URLValidator.Run(ctx)
+	}()
+	return done
+}
+
+// shutdownTelemetry flushes spans of the last requests before exit.
+func (a *App) shutdownTelemetry() {
+	if a.Telemetry == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.Config.App.ShutdownTimeout)
+	defer cancel()
+
+	if err := a.Telemetry.Shutdown(ctx); err != nil {
+		logger.Log.Error(fmt.Sprintf("cannot shutdown telemetry: %s", err))
+	}
+}
+
+// closeGeoResolver releases the geolocation database after the last click is recorded.
+func (a *App) closeGeoResolver() {
+	if a.GeoResolver == nil {
+		return
+	}
+
+	if err := a.GeoResolver.Close(); err != nil {
+		logger.Log.Error(fmt.Sprintf("cannot close geolocation database: %s", err))
+	}
 }
 
 func (a *App) printWelcomeMessage() {
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"bufio"
 	"bytes"
 	"compress/gzip"
 	"context"
@@ -8,8 +9,11 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/gururuby/shortener/internal/config"
@@ -48,6 +52,16 @@ type (
 	}
 )
 
+// newConfig returns default configuration with file storage in a temporary directory of the test.
+func newConfig(t *testing.T) *config.Config {
+	t.Helper()
+
+	cfg, err := config.New()
+	require.NoError(t, err)
+	cfg.FileStorage.Path = filepath.Join(t.TempDir(), "db.json")
+	return cfg
+}
+
 func Test_App_OK(t *testing.T) {
 	var (
 		cfg              *config.Config
@@ -57,15 +71,16 @@ func Test_App_OK(t *testing.T) {
 		authToken        string
 	)
 
-	cfg, err = config.New()
+	cfg = newConfig(t)
 	ctx := context.Background()
-	require.NoError(t, err)
 
 	app := New(cfg).Setup()
 	ts := httptest.NewServer(app.Router)
 	defer ts.Close()
 
-	auth := jwt.New(cfg.Auth.SecretKey, cfg.Auth.TokenTTL)
+	revocationStore := jwt.NewMemoryRevocationStore(time.Minute)
+	defer revocationStore.Close()
+	auth := jwt.New(cfg.Auth.SecretKey, cfg.Auth.TokenTTL, revocationStore)
 
 	user, err = app.UserStorage.SaveUser(ctx)
 	require.NoError(t, err)
@@ -74,7 +89,7 @@ func Test_App_OK(t *testing.T) {
 	require.NoError(t, err)
 
 	sourceURL := "https://ya.ru"
-	existingShortURL, err = app.ShortURLSStorage.SaveShortURL(ctx, user, sourceURL)
+	existingShortURL, err = app.ShortURLSStorage.SaveShortURL(ctx, user, sourceURL, sourceURL, entity.CreateOptions{})
 
 	var tests = []struct {
 		name     string
@@ -161,7 +176,7 @@ func Test_App_OK(t *testing.T) {
 				headers: headers{contentType: "application/json"},
 				status:  http.StatusOK,
 			},
-			want: `[{"short_url":"http://localhost:8080/\w{5}","original_url:"https://ya.ru"}]`,
+			want: `\{"items":\[.*\{"short_url":"http://localhost:8080/\w{5}","original_url":"https://ya.ru"(,"region":"\w+")?\}.*\],"total":\d+,"page":1,"total_pages":1\}`,
 		},
 	}
 	for _, tt := range tests {
@@ -183,8 +198,7 @@ func Test_App_OK(t *testing.T) {
 }
 
 func Test_App_Compress_OK(t *testing.T) {
-	cfg, err := config.New()
-	require.NoError(t, err)
+	cfg := newConfig(t)
 
 	app := New(cfg).Setup()
 
@@ -271,10 +285,296 @@ func Test_App_Compress_OK(t *testing.T) {
 	}
 }
 
-func Test_App_Errors(t *testing.T) {
-	cfg, err := config.New()
+func Test_App_QR_OK(t *testing.T) {
+	cfg := newConfig(t)
+
+	app := New(cfg).Setup()
+
+	ts := httptest.NewServer(app.Router)
+	defer ts.Close()
+
+	sourceURL := gofakeit.URL()
+	shortURL, err := app.ShortURLSStorage.SaveShortURL(context.Background(), nil, sourceURL, sourceURL, entity.CreateOptions{})
+	require.NoError(t, err)
+
+	res, body := testRequest(t, ts, request{
+		method: http.MethodGet,
+		path:   "/api/shorturl/" + shortURL.Alias + "/qr?size=128",
+	})
+	err = res.Body.Close()
 	require.NoError(t, err)
 
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "image/png", res.Header.Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(body, "\x89PNG\r\n\x1a\n"))
+}
+
+func Test_App_OneTimeURL(t *testing.T) {
+	cfg := newConfig(t)
+
+	app := New(cfg).Setup()
+
+	ts := httptest.NewServer(app.Router)
+	defer ts.Close()
+
+	ts.Client().CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	sourceURL := gofakeit.URL()
+
+	res, body := testRequest(t, ts, request{
+		body:    []byte(fmt.Sprintf(`{"url":"%s","one_time_use":true}`, sourceURL)),
+		headers: headers{contentType: "application/json"},
+		method:  http.MethodPost,
+		path:    "/api/shorten",
+	})
+	require.NoError(t, res.Body.Close())
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+
+	alias := regexp.MustCompile(`http://localhost:8080/(\w{5})`).FindStringSubmatch(body)
+	require.Len(t, alias, 2)
+
+	res, _ = testRequest(t, ts, request{method: http.MethodGet, path: "/" + alias[1]})
+	require.NoError(t, res.Body.Close())
+	assert.Equal(t, http.StatusTemporaryRedirect, res.StatusCode)
+	assert.Equal(t, sourceURL, res.Header.Get("Location"))
+
+	res, _ = testRequest(t, ts, request{method: http.MethodGet, path: "/" + alias[1]})
+	require.NoError(t, res.Body.Close())
+	assert.Equal(t, http.StatusGone, res.StatusCode)
+}
+
+func Test_App_Events(t *testing.T) {
+	cfg := newConfig(t)
+
+	app := New(cfg).Setup()
+	ts := httptest.NewServer(app.Router)
+	defer ts.Close()
+	defer app.Events.Close()
+
+	revocationStore := jwt.NewMemoryRevocationStore(time.Minute)
+	defer revocationStore.Close()
+	auth := jwt.New(cfg.Auth.SecretKey, cfg.Auth.TokenTTL, revocationStore)
+
+	user, err := app.UserStorage.SaveUser(context.Background())
+	require.NoError(t, err)
+	authToken, err := auth.SignUserID(user.ID)
+	require.NoError(t, err)
+
+	subscribe := func() <-chan string {
+		req, err := http.NewRequest(http.MethodGet, ts.URL+"/api/events", nil)
+		require.NoError(t, err)
+		req.AddCookie(&http.Cookie{Name: "Authorization", Value: authToken})
+
+		res, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = res.Body.Close() })
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+		lines := make(chan string, 1)
+		go func() {
+			scanner := bufio.NewScanner(res.Body)
+			for scanner.Scan() {
+				if line := scanner.Text(); strings.HasPrefix(line, "data: ") {
+					lines <- line
+					return
+				}
+			}
+		}()
+		return lines
+	}
+
+	// Clients are subscribed before response headers are sent, so the event reaches both
+	clients := []<-chan string{subscribe(), subscribe()}
+	res, _ := testRequest(t, ts, request{
+		authToken: authToken,
+		body:      []byte(fmt.Sprintf(`{"url":"%s"}`, gofakeit.URL())),
+		headers:   headers{contentType: "application/json"},
+		method:    http.MethodPost,
+		path:      "/api/shorten",
+	})
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+
+	for _, lines := range clients {
+		select {
+		case line := <-lines:
+			assert.Regexp(t, `^data: \{"alias":"\w{5}","original_url":"[^"]+","created_at":"[^"]+"\}$`, line)
+		case <-time.After(time.Second):
+			require.FailNow(t, "event is not received within a second")
+		}
+	}
+}
+
+func Test_App_Split(t *testing.T) {
+	cfg := newConfig(t)
+
+	app := New(cfg).Setup()
+	ts := httptest.NewServer(app.Router)
+	defer ts.Close()
+
+	ts.Client().CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	revocationStore := jwt.NewMemoryRevocationStore(time.Minute)
+	defer revocationStore.Close()
+	auth := jwt.New(cfg.Auth.SecretKey, cfg.Auth.TokenTTL, revocationStore)
+
+	user, err := app.UserStorage.SaveUser(context.Background())
+	require.NoError(t, err)
+	authToken, err := auth.SignUserID(user.ID)
+	require.NoError(t, err)
+
+	res, body := testRequest(t, ts, request{
+		authToken: authToken,
+		body:      []byte(`{"alias":"ab-test","destinations":[{"url":"https://v1.com","weight":70},{"url":"https://v2.com","weight":30}]}`),
+		headers:   headers{contentType: "application/json"},
+		method:    http.MethodPost,
+		path:      "/api/user/urls/split",
+	})
+	require.Equal(t, http.StatusCreated, res.StatusCode, body)
+
+	res, _ = testRequest(t, ts, request{method: http.MethodGet, path: "/ab-test"})
+	require.Equal(t, http.StatusTemporaryRedirect, res.StatusCode)
+	assert.Contains(t, []string{"https://v1.com", "https://v2.com"}, res.Header.Get("Location"))
+
+	// Clicks are counted in background
+	require.Eventually(t, func() bool {
+		res, body = testRequest(t, ts, request{authToken: authToken, method: http.MethodGet, path: "/api/shorturl/ab-test/split-stats"})
+		return res.StatusCode == http.StatusOK && strings.Contains(body, `"total_clicks":1`)
+	}, time.Second, time.Millisecond*10)
+}
+
+func Test_App_Namespaces(t *testing.T) {
+	cfg := newConfig(t)
+	cfg.Database.Type = "memory"
+	cfg.Auth.AdminToken = "admin-secret"
+
+	app := New(cfg).Setup()
+	ts := httptest.NewServer(app.Router)
+	defer ts.Close()
+
+	ts.Client().CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	req, err := http.NewRequest(http.MethodPost, ts.URL+"/api/namespaces", strings.NewReader(`{"name":"team-a"}`))
+	require.NoError(t, err)
+	req.Header.Set("X-Admin-Token", cfg.Auth.AdminToken)
+	res, err := ts.Client().Do(req)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+
+	sourceURL := gofakeit.URL()
+
+	res, defaultShortURL := testRequest(t, ts, request{method: http.MethodPost, path: "/", body: []byte(sourceURL)})
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+	defaultAlias := strings.TrimPrefix(defaultShortURL, cfg.App.BaseURL+"/")
+
+	// The same URL is not deduplicated across namespaces
+	req, err = http.NewRequest(http.MethodPost, ts.URL+"/", strings.NewReader(sourceURL))
+	require.NoError(t, err)
+	req.Header.Set("X-Namespace", "team-a")
+	res, err = ts.Client().Do(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+	require.True(t, strings.HasPrefix(string(body), cfg.App.BaseURL+"/team-a/"), string(body))
+	namespacedAlias := strings.TrimPrefix(string(body), cfg.App.BaseURL+"/team-a/")
+
+	for _, path := range []string{"/" + defaultAlias, "/team-a/" + namespacedAlias} {
+		res, _ = testRequest(t, ts, request{method: http.MethodGet, path: path})
+		require.Equal(t, http.StatusTemporaryRedirect, res.StatusCode, path)
+		assert.Equal(t, sourceURL, res.Header.Get("Location"))
+	}
+
+	res, _ = testRequest(t, ts, request{method: http.MethodGet, path: "/team-a/" + defaultAlias})
+	assert.Equal(t, http.StatusUnprocessableEntity, res.StatusCode)
+}
+
+func Test_App_UserDeactivation(t *testing.T) {
+	cfg := newConfig(t)
+	cfg.Database.Type = "memory"
+	cfg.Auth.AdminToken = "admin-secret"
+
+	app := New(cfg).Setup()
+	ts := httptest.NewServer(app.Router)
+	defer ts.Close()
+
+	ts.Client().CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	revocationStore := jwt.NewMemoryRevocationStore(time.Minute)
+	defer revocationStore.Close()
+	auth := jwt.New(cfg.Auth.SecretKey, cfg.Auth.TokenTTL, revocationStore)
+
+	user, err := app.UserStorage.SaveUser(context.Background())
+	require.NoError(t, err)
+	authToken, err := auth.SignUserID(user.ID)
+	require.NoError(t, err)
+
+	sourceURL := gofakeit.URL()
+	shorten := func() (*http.Response, string) {
+		return testRequest(t, ts, request{
+			authToken: authToken,
+			body:      []byte(fmt.Sprintf(`{"url":"%s"}`, sourceURL)),
+			headers:   headers{contentType: "application/json"},
+			method:    http.MethodPost,
+			path:      "/api/shorten",
+		})
+	}
+	setActive := func(action string) {
+		req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/api/admin/users/%d/%s", ts.URL, user.ID, action), nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Admin-Token", cfg.Auth.AdminToken)
+		res, err := ts.Client().Do(req)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		require.Equal(t, http.StatusNoContent, res.StatusCode)
+	}
+
+	res, body := shorten()
+	require.Equal(t, http.StatusCreated, res.StatusCode, body)
+	alias := regexp.MustCompile(`http://localhost:8080/(\w{5})`).FindStringSubmatch(body)
+	require.Len(t, alias, 2)
+
+	setActive("deactivate")
+
+	res, body = shorten()
+	assert.Equal(t, http.StatusForbidden, res.StatusCode, body)
+	assert.Empty(t, res.Cookies(), "deactivated user must not be registered again")
+
+	res, body = testRequest(t, ts, request{
+		authToken: authToken,
+		body:      []byte(fmt.Sprintf(`["%s"]`, alias[1])),
+		headers:   headers{contentType: "application/json"},
+		method:    http.MethodDelete,
+		path:      "/api/user/urls",
+	})
+	assert.Equal(t, http.StatusForbidden, res.StatusCode, body)
+
+	res, _ = testRequest(t, ts, request{method: http.MethodGet, path: "/" + alias[1]})
+	assert.Equal(t, http.StatusTemporaryRedirect, res.StatusCode, "short URLs of deactivated user keep redirecting")
+	assert.Equal(t, sourceURL, res.Header.Get("Location"))
+
+	setActive("reactivate")
+
+	sourceURL = gofakeit.URL()
+	res, body = shorten()
+	assert.Equal(t, http.StatusCreated, res.StatusCode, body)
+	require.Len(t, res.Cookies(), 1)
+	assert.Equal(t, authToken, res.Cookies()[0].Value, "reactivated user is authenticated")
+}
+
+func Test_App_Errors(t *testing.T) {
+	cfg := newConfig(t)
+
 	app := New(cfg).Setup()
 
 	ts := httptest.NewServer(app.Router)
@@ -297,7 +597,7 @@ func Test_App_Errors(t *testing.T) {
 				headers: headers{contentType: "text/plain; charset=utf-8"},
 				status:  http.StatusUnprocessableEntity,
 			},
-			want: "record not found\n",
+			want: "source URL not found\n",
 		},
 		{
 			name: "when passed incorrect url via API",
@@ -315,7 +615,7 @@ func Test_App_Errors(t *testing.T) {
 				headers: headers{contentType: "application/json"},
 				status:  http.StatusUnprocessableEntity,
 			},
-			want: `{"Error":"invalid source URL, please specify valid URL","StatusCode":422}`,
+			want: `{"Error":"invalid source URL, please specify valid URL","Code":"ERR_INVALID_URL","StatusCode":422,"ValidationErrors":[{"Field":"url","Code":"INVALID_URL","Message":"must be a valid http/https URL"}]}`,
 		},
 	}
 	for _, tt := range tests {
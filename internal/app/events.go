@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+
+	"github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	webhookEntity "github.com/gururuby/shortener/internal/domain/entity/webhook"
+	"github.com/gururuby/shortener/internal/infra/eventbus"
+	"github.com/gururuby/shortener/internal/infra/logger"
+)
+
+// WebhookDeliverer defines the interface for delivering events to user webhooks.
+type WebhookDeliverer interface {
+	Deliver(ctx context.Context, userID int, event string, data any) error
+}
+
+// ClickRecorder defines the interface for recording short URL clicks.
+type ClickRecorder interface {
+	RecordClick(ctx context.Context, namespace, alias, ip, userAgent string) error
+}
+
+// subscribeEvents subscribes webhook delivery and click recording to short URL lifecycle events.
+// Parameters:
+// - bus: Event bus short URL events are published to
+// - webhooks: Webhook delivery service
+// - clicks: Click recording service
+// - baseURL: Base URL of short URLs reported to webhooks
+func subscribeEvents(bus *eventbus.EventBus, webhooks WebhookDeliverer, clicks ClickRecorder, baseURL string) {
+	deliver := func(userID int, event string, data any) {
+		if userID == 0 {
+			return
+		}
+		// Delivery retries failed attempts, so it must not hold up other events of the subscriber
+		go func() {
+			if err := webhooks.Deliver(context.Background(), userID, event, data); err != nil {
+				logger.Log.Error(err.Error())
+			}
+		}()
+	}
+	shortURL := func(alias, namespace string) string {
+		return baseURL + "/" + (&entity.ShortURL{Alias: alias, Namespace: namespace}).Path()
+	}
+
+	bus.Subscribe(eventbus.TypeURLCreated, func(event eventbus.Event) {
+		e := event.(eventbus.URLCreated)
+		deliver(e.UserID, webhookEntity.EventURLCreated, &webhookEntity.URLEventData{ShortURL: shortURL(e.Alias, e.Namespace), OriginalURL: e.OriginalURL})
+	})
+	bus.Subscribe(eventbus.TypeURLClicked, func(event eventbus.Event) {
+		e := event.(eventbus.URLClicked)
+		deliver(e.UserID, webhookEntity.EventURLClicked, &webhookEntity.URLEventData{ShortURL: shortURL(e.Alias, e.Namespace), OriginalURL: e.OriginalURL})
+	})
+	bus.Subscribe(eventbus.TypeURLDeleted, func(event eventbus.Event) {
+		e := event.(eventbus.URLDeleted)
+		deliver(e.UserID, webhookEntity.EventURLDeleted, &webhookEntity.URLsDeletedData{Aliases: []string{e.Alias}})
+	})
+
+	bus.Subscribe(eventbus.TypeURLClicked, func(event eventbus.Event) {
+		e := event.(eventbus.URLClicked)
+		if err := clicks.RecordClick(context.Background(), e.Namespace, e.Alias, e.IP, e.UserAgent); err != nil {
+			logger.Log.Error(err.Error())
+		}
+	})
+}
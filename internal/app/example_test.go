@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"regexp"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/gururuby/shortener/internal/config"
@@ -31,14 +32,16 @@ func Example() {
 	ts := httptest.NewServer(app.Router)
 	defer ts.Close()
 
-	auth := jwt.New(cfg.Auth.SecretKey, cfg.Auth.TokenTTL)
+	revocationStore := jwt.NewMemoryRevocationStore(time.Minute)
+	defer revocationStore.Close()
+	auth := jwt.New(cfg.Auth.SecretKey, cfg.Auth.TokenTTL, revocationStore)
 
 	user, _ = app.UserStorage.SaveUser(ctx)
 
 	authToken, _ = auth.SignUserID(user.ID)
 
 	sourceURL := "https://ya.ru"
-	existingShortURL, _ = app.ShortURLSStorage.SaveShortURL(ctx, user, sourceURL)
+	existingShortURL, _ = app.ShortURLSStorage.SaveShortURL(ctx, user, sourceURL, sourceURL, entity.CreateOptions{})
 	urls := []string{
 		gofakeit.URL(),
 		gofakeit.URL(),
@@ -107,7 +110,7 @@ func Example() {
 				headers:   headers{contentType: "application/json"},
 				path:      "/api/user/urls",
 			},
-			match: `[{"short_url":"http://localhost:8080/\w{5}","original_url:"https://ya.ru"}]`,
+			match: `\{"items":\[.*\{"short_url":"http://localhost:8080/\w{5}","original_url":"https://ya.ru"\}.*\],"total":\d+,"page":1,"total_pages":1\}`,
 		},
 	}
 
@@ -123,7 +126,7 @@ func Example() {
 	// Response matched with: {"Result":"http://localhost:8080/\w{5}"} true
 	// Response matched with: {"correlation_id":"1","short_url":"http://localhost:8080/\w{5}"},{"correlation_id":"2","short_url":"http://localhost:8080/\w{5}"} true
 	// Response matched with: <!doctype html> true
-	// Response matched with: [{"short_url":"http://localhost:8080/\w{5}","original_url:"https://ya.ru"}] true
+	// Response matched with: \{"items":\[.*\{"short_url":"http://localhost:8080/\w{5}","original_url":"https://ya.ru"\}.*\],"total":\d+,"page":1,"total_pages":1\} true
 }
 
 func testExampleRequest(ts *httptest.Server, r request) string {
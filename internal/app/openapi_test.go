@@ -0,0 +1,708 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/gururuby/shortener/internal/config"
+	"github.com/gururuby/shortener/internal/infra/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type specRequest struct {
+	method         string
+	path           string
+	contentType    string
+	body           string
+	authToken      string
+	apiKey         string
+	idempotencyKey string
+	namespace      string
+	adminToken     string
+	accept         string
+}
+
+// Test_App_MatchesOpenAPISpec sends requests to every documented endpoint
+// and checks that responses are described by OpenAPI specification.
+func Test_App_MatchesOpenAPISpec(t *testing.T) {
+	openapi3filter.RegisterBodyDecoder("image/png", openapi3filter.FileBodyDecoder)
+	defer openapi3filter.UnregisterBodyDecoder("image/png")
+	openapi3filter.RegisterBodyDecoder("image/gif", openapi3filter.FileBodyDecoder)
+	defer openapi3filter.UnregisterBodyDecoder("image/gif")
+	openapi3filter.RegisterBodyDecoder("text/html", openapi3filter.FileBodyDecoder)
+	defer openapi3filter.UnregisterBodyDecoder("text/html")
+	openapi3filter.RegisterBodyDecoder("application/zip", openapi3filter.FileBodyDecoder)
+	defer openapi3filter.UnregisterBodyDecoder("application/zip")
+
+	cfg, err := config.New()
+	require.NoError(t, err)
+	cfg.Database.Type = "memory"
+	cfg.Auth.AdminToken = "admin-secret"
+
+	app := New(cfg).Setup()
+	ts := httptest.NewServer(app.Router)
+	defer ts.Close()
+
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+	specRouter, err := legacy.NewRouter(doc)
+	require.NoError(t, err)
+
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	sourceURL := gofakeit.URL()
+	res, shortURL := sendSpecRequest(t, client, specRouter, ts.URL, specRequest{method: http.MethodPost, path: "/", contentType: "text/plain", body: sourceURL}, http.StatusCreated)
+	alias := path.Base(shortURL)
+
+	var authToken string
+	for _, cookie := range res.Cookies() {
+		if cookie.Name == "Authorization" {
+			authToken = cookie.Value
+		}
+	}
+	require.NotEmpty(t, authToken)
+
+	_, privateBody := sendSpecRequest(t, client, specRouter, ts.URL, specRequest{method: http.MethodPost, path: "/api/shorten", contentType: "application/json", authToken: authToken, body: fmt.Sprintf(`{"url":"%s","visibility":"private"}`, gofakeit.URL())}, http.StatusCreated)
+	var privateRes struct{ Result string }
+	require.NoError(t, json.Unmarshal([]byte(privateBody), &privateRes))
+	privateAlias := path.Base(privateRes.Result)
+
+	_, trackedBody := sendSpecRequest(t, client, specRouter, ts.URL, specRequest{method: http.MethodPost, path: "/api/shorten", contentType: "application/json", body: fmt.Sprintf(`{"url":"%s","tracking":true}`, gofakeit.URL())}, http.StatusCreated)
+	var trackedRes struct{ Result string }
+	require.NoError(t, json.Unmarshal([]byte(trackedBody), &trackedRes))
+	trackedAlias := path.Base(trackedRes.Result)
+
+	_, apiKeyBody := sendSpecRequest(t, client, specRouter, ts.URL, specRequest{method: http.MethodPost, path: "/api/user/api-keys", authToken: authToken}, http.StatusCreated)
+	var apiKeyRes struct{ Key string }
+	require.NoError(t, json.Unmarshal([]byte(apiKeyBody), &apiKeyRes))
+	apiKey := apiKeyRes.Key
+
+	sendSpecRequest(t, client, specRouter, ts.URL, specRequest{method: http.MethodPost, path: "/api/namespaces", contentType: "application/json", body: `{"name":"team-a"}`, adminToken: cfg.Auth.AdminToken}, http.StatusCreated)
+	_, namespacedShortURL := sendSpecRequest(t, client, specRouter, ts.URL, specRequest{method: http.MethodPost, path: "/", contentType: "text/plain", body: sourceURL, namespace: "team-a"}, http.StatusCreated)
+	namespacedAlias := path.Base(namespacedShortURL)
+
+	importBody, importContentType := specMultipartCSV(t, "original_url\n"+gofakeit.URL()+"\n")
+
+	tests := []struct {
+		name   string
+		req    specRequest
+		status int
+	}{
+		{
+			name:   "when create existing ShortURL via http",
+			req:    specRequest{method: http.MethodPost, path: "/", contentType: "text/plain", body: sourceURL},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "when create ShortURL via API",
+			req:    specRequest{method: http.MethodPost, path: "/api/shorten", contentType: "application/json", body: fmt.Sprintf(`{"url":"%s"}`, gofakeit.URL())},
+			status: http.StatusCreated,
+		},
+		{
+			name:   "when create ShortURL via API with idempotency key",
+			req:    specRequest{method: http.MethodPost, path: "/api/shorten", contentType: "application/json", body: `{"url":"https://example.com/idempotent"}`, authToken: authToken, idempotencyKey: "spec-key"},
+			status: http.StatusCreated,
+		},
+		{
+			name:   "when retry ShortURL creation via API with idempotency key",
+			req:    specRequest{method: http.MethodPost, path: "/api/shorten", contentType: "application/json", body: `{"url":"https://example.com/idempotent"}`, authToken: authToken, idempotencyKey: "spec-key"},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when create existing ShortURL via API",
+			req:    specRequest{method: http.MethodPost, path: "/api/shorten", contentType: "application/json", body: fmt.Sprintf(`{"url":"%s"}`, sourceURL)},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "when create ShortURL via API with malformed body",
+			req:    specRequest{method: http.MethodPost, path: "/api/shorten", contentType: "application/json", body: `{"url":`},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "when create ShortURL via API with invalid URL",
+			req:    specRequest{method: http.MethodPost, path: "/api/shorten", contentType: "application/json", body: `{"url":"not a url"}`},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "when create ShortURL via API with too large body",
+			req:    specRequest{method: http.MethodPost, path: "/api/shorten", contentType: "application/json", body: fmt.Sprintf(`{"url":"https://example.com/%s"}`, strings.Repeat("a", 1<<20))},
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:   "when batch creating via API",
+			req:    specRequest{method: http.MethodPost, path: "/api/shorten/batch", contentType: "application/json", body: fmt.Sprintf(`[{"correlation_id":"1","original_url":"%s"}]`, gofakeit.URL())},
+			status: http.StatusCreated,
+		},
+		{
+			name:   "when batch creating via API with invalid URL",
+			req:    specRequest{method: http.MethodPost, path: "/api/shorten/batch", contentType: "application/json", body: `[{"correlation_id":"1","original_url":"not-a-url"}]`},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "when validating batch via API",
+			req:    specRequest{method: http.MethodPost, path: "/api/shorten/validate", contentType: "application/json", body: `[{"correlation_id":"1","original_url":"https://example.com"},{"correlation_id":"2","original_url":"not-a-url"}]`},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when redirect",
+			req:    specRequest{method: http.MethodGet, path: "/" + alias},
+			status: http.StatusTemporaryRedirect,
+		},
+		{
+			name:   "when look up ShortURL in JSON",
+			req:    specRequest{method: http.MethodGet, path: "/" + alias, accept: "application/json"},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when look up ShortURL in namespace in plain text",
+			req:    specRequest{method: http.MethodGet, path: "/team-a/" + namespacedAlias, accept: "text/plain"},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when redirect to tracked ShortURL",
+			req:    specRequest{method: http.MethodGet, path: "/" + trackedAlias},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when load tracking pixel",
+			req:    specRequest{method: http.MethodGet, path: "/t/" + trackedAlias},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when owner redirects to private ShortURL",
+			req:    specRequest{method: http.MethodGet, path: "/" + privateAlias, authToken: authToken},
+			status: http.StatusTemporaryRedirect,
+		},
+		{
+			name:   "when anonymous user redirects to private ShortURL",
+			req:    specRequest{method: http.MethodGet, path: "/" + privateAlias},
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "when anonymous user checks private ShortURL",
+			req:    specRequest{method: http.MethodHead, path: "/" + privateAlias},
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "when anonymous user gets private ShortURL info",
+			req:    specRequest{method: http.MethodHead, path: "/api/shorturl/" + privateAlias},
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "when anonymous user gets private ShortURL metadata",
+			req:    specRequest{method: http.MethodGet, path: "/api/shorturl/" + privateAlias + "/metadata"},
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "when create ShortURL via API with invalid visibility",
+			req:    specRequest{method: http.MethodPost, path: "/api/shorten", contentType: "application/json", body: fmt.Sprintf(`{"url":"%s","visibility":"secret"}`, gofakeit.URL())},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "when redirect to unknown ShortURL",
+			req:    specRequest{method: http.MethodGet, path: "/unknown"},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "when redirect in namespace",
+			req:    specRequest{method: http.MethodGet, path: "/team-a/" + namespacedAlias},
+			status: http.StatusTemporaryRedirect,
+		},
+		{
+			name:   "when redirect to unknown ShortURL in namespace",
+			req:    specRequest{method: http.MethodGet, path: "/team-a/unknown"},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "when create ShortURL via API in namespace",
+			req:    specRequest{method: http.MethodPost, path: "/api/shorten", contentType: "application/json", body: fmt.Sprintf(`{"url":"%s"}`, gofakeit.URL()), namespace: "team-a"},
+			status: http.StatusCreated,
+		},
+		{
+			name:   "when create ShortURL via API in unknown namespace",
+			req:    specRequest{method: http.MethodPost, path: "/api/shorten", contentType: "application/json", body: fmt.Sprintf(`{"url":"%s"}`, gofakeit.URL()), namespace: "unknown"},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "when check redirect",
+			req:    specRequest{method: http.MethodHead, path: "/" + alias},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when get ShortURL info",
+			req:    specRequest{method: http.MethodHead, path: "/api/shorturl/" + alias},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when get ShortURL metadata",
+			req:    specRequest{method: http.MethodGet, path: "/api/shorturl/" + alias + "/metadata"},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when get unknown ShortURL metadata",
+			req:    specRequest{method: http.MethodGet, path: "/api/shorturl/unknown/metadata"},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "when get ShortURL health",
+			req:    specRequest{method: http.MethodGet, path: "/api/shorturl/" + alias + "/health"},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when get unknown ShortURL health",
+			req:    specRequest{method: http.MethodGet, path: "/api/shorturl/unknown/health"},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "when get unknown ShortURL info",
+			req:    specRequest{method: http.MethodHead, path: "/api/shorturl/unknown"},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "when get QR code",
+			req:    specRequest{method: http.MethodGet, path: "/api/shorturl/" + alias + "/qr"},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when get ShortURL analytics",
+			req:    specRequest{method: http.MethodGet, path: "/api/shorturl/" + alias + "/analytics?from=2024-01-01&to=2024-01-31&granularity=week", authToken: authToken},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when get ShortURL geographic breakdown",
+			req:    specRequest{method: http.MethodGet, path: "/api/shorturl/" + alias + "/analytics/geo?from=2024-01-01&to=2024-01-31", authToken: authToken},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when get geographic breakdown of unknown ShortURL",
+			req:    specRequest{method: http.MethodGet, path: "/api/shorturl/unknown/analytics/geo", authToken: authToken},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "when get ShortURL analytics without credentials",
+			req:    specRequest{method: http.MethodGet, path: "/api/shorturl/" + alias + "/analytics"},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "when get ShortURL analytics with invalid granularity",
+			req:    specRequest{method: http.MethodGet, path: "/api/shorturl/" + alias + "/analytics?granularity=minute", apiKey: apiKey},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "when create split",
+			req:    specRequest{method: http.MethodPost, path: "/api/user/urls/split", contentType: "application/json", body: `{"alias":"ab-test","destinations":[{"url":"https://v1.com","weight":70},{"url":"https://v2.com","weight":30}]}`, authToken: authToken},
+			status: http.StatusCreated,
+		},
+		{
+			name:   "when create split with taken alias",
+			req:    specRequest{method: http.MethodPost, path: "/api/user/urls/split", contentType: "application/json", body: `{"alias":"ab-test","destinations":[{"url":"https://v1.com","weight":50},{"url":"https://v2.com","weight":50}]}`, apiKey: apiKey},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "when create split with invalid weights",
+			req:    specRequest{method: http.MethodPost, path: "/api/user/urls/split", contentType: "application/json", body: `{"alias":"ab-other","destinations":[{"url":"https://v1.com","weight":70},{"url":"https://v2.com","weight":70}]}`, authToken: authToken},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "when create split without credentials",
+			req:    specRequest{method: http.MethodPost, path: "/api/user/urls/split", contentType: "application/json", body: `{"alias":"ab-other","destinations":[]}`},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "when redirect via split",
+			req:    specRequest{method: http.MethodGet, path: "/ab-test"},
+			status: http.StatusTemporaryRedirect,
+		},
+		{
+			name:   "when get split stats",
+			req:    specRequest{method: http.MethodGet, path: "/api/shorturl/ab-test/split-stats", authToken: authToken},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when get unknown split stats",
+			req:    specRequest{method: http.MethodGet, path: "/api/shorturl/unknown/split-stats", authToken: authToken},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "when get user URLs",
+			req:    specRequest{method: http.MethodGet, path: "/api/user/urls", authToken: authToken},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when get user URLs with cursor pagination",
+			req:    specRequest{method: http.MethodGet, path: "/api/user/urls?limit=1", authToken: authToken},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when get user URLs with invalid cursor",
+			req:    specRequest{method: http.MethodGet, path: "/api/user/urls?cursor=invalid", authToken: authToken},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "when get user URLs beyond the last page",
+			req:    specRequest{method: http.MethodGet, path: "/api/user/urls?page=100", authToken: authToken},
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "when import user URLs",
+			req:    specRequest{method: http.MethodPost, path: "/api/user/urls/import", contentType: importContentType, body: importBody, authToken: authToken},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when export user URLs",
+			req:    specRequest{method: http.MethodGet, path: "/api/user/urls/export?format=json", authToken: authToken},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when export user URLs again",
+			req:    specRequest{method: http.MethodGet, path: "/api/user/urls/export?format=csv", authToken: authToken},
+			status: http.StatusTooManyRequests,
+		},
+		{
+			name:   "when export user URLs in unknown format",
+			req:    specRequest{method: http.MethodGet, path: "/api/user/urls/export?format=xml", authToken: authToken},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "when export user data",
+			req:    specRequest{method: http.MethodGet, path: "/api/user/export", authToken: authToken},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when export user data again",
+			req:    specRequest{method: http.MethodGet, path: "/api/user/export", authToken: authToken},
+			status: http.StatusTooManyRequests,
+		},
+		{
+			name:   "when set custom domain",
+			req:    specRequest{method: http.MethodPatch, path: "/api/user/profile", contentType: "application/json", body: `{"custom_domain":"https://short.acme.com"}`, authToken: authToken},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when set invalid custom domain",
+			req:    specRequest{method: http.MethodPatch, path: "/api/user/profile", contentType: "application/json", body: `{"custom_domain":"http://short.acme.com"}`, authToken: authToken},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "when remove custom domain",
+			req:    specRequest{method: http.MethodPatch, path: "/api/user/profile", contentType: "application/json", body: `{"custom_domain":""}`, authToken: authToken},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when create tag",
+			req:    specRequest{method: http.MethodPost, path: "/api/user/tags", contentType: "application/json", body: `{"name":"work"}`, authToken: authToken},
+			status: http.StatusCreated,
+		},
+		{
+			name:   "when create existing tag",
+			req:    specRequest{method: http.MethodPost, path: "/api/user/tags", contentType: "application/json", body: `{"name":"work"}`, authToken: authToken},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "when create tag with invalid name",
+			req:    specRequest{method: http.MethodPost, path: "/api/user/tags", contentType: "application/json", body: `{"name":" "}`, authToken: authToken},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "when get tags",
+			req:    specRequest{method: http.MethodGet, path: "/api/user/tags", authToken: authToken},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when assign tag",
+			req:    specRequest{method: http.MethodPost, path: "/api/user/urls/" + alias + "/tags", contentType: "application/json", body: `{"tag":"work"}`, authToken: authToken},
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "when assign unknown tag",
+			req:    specRequest{method: http.MethodPost, path: "/api/user/urls/" + alias + "/tags", contentType: "application/json", body: `{"tag":"home"}`, authToken: authToken},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "when get user URLs by tag",
+			req:    specRequest{method: http.MethodGet, path: "/api/user/urls?tag=work", authToken: authToken},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when remove tag",
+			req:    specRequest{method: http.MethodDelete, path: "/api/user/urls/" + alias + "/tags/work", authToken: authToken},
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "when get user URLs by tag without URLs",
+			req:    specRequest{method: http.MethodGet, path: "/api/user/urls?tag=work", authToken: authToken},
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "when update user URL",
+			req:    specRequest{method: http.MethodPatch, path: "/api/user/urls/" + alias, contentType: "application/json", body: `{"url":"https://example.com/new"}`, authToken: authToken},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when update user URL with invalid URL",
+			req:    specRequest{method: http.MethodPatch, path: "/api/user/urls/" + alias, contentType: "application/json", body: `{"url":"invalid"}`, authToken: authToken},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "when get user URL history",
+			req:    specRequest{method: http.MethodGet, path: "/api/user/urls/" + alias + "/history", authToken: authToken},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when get user URL quota",
+			req:    specRequest{method: http.MethodGet, path: "/api/user/quota", authToken: authToken},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when search user URLs",
+			req:    specRequest{method: http.MethodGet, path: "/api/user/urls/search?q=EXAMPLE.com", authToken: authToken},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when search user URLs without query",
+			req:    specRequest{method: http.MethodGet, path: "/api/user/urls/search", authToken: authToken},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "when get archived user URLs",
+			req:    specRequest{method: http.MethodGet, path: "/api/user/urls/archived?page=1&per_page=10", authToken: authToken},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when restore not archived user URL",
+			req:    specRequest{method: http.MethodPut, path: "/api/user/urls/archived/" + alias + "/restore", authToken: authToken},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "when create webhook",
+			req:    specRequest{method: http.MethodPost, path: "/api/user/webhooks", contentType: "application/json", body: `{"url":"https://example.com/hook","events":["url.created"]}`, authToken: authToken},
+			status: http.StatusCreated,
+		},
+		{
+			name:   "when create webhook with unknown event",
+			req:    specRequest{method: http.MethodPost, path: "/api/user/webhooks", contentType: "application/json", body: `{"url":"https://example.com/hook","events":["url.updated"]}`, authToken: authToken},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "when delete webhook",
+			req:    specRequest{method: http.MethodDelete, path: "/api/user/webhooks/1", authToken: authToken},
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "when delete unknown webhook",
+			req:    specRequest{method: http.MethodDelete, path: "/api/user/webhooks/1", authToken: authToken},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "when get user URLs with API key",
+			req:    specRequest{method: http.MethodGet, path: "/api/user/urls", apiKey: apiKey},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when revoke API key",
+			req:    specRequest{method: http.MethodDelete, path: "/api/user/api-keys/" + apiKey, apiKey: apiKey},
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "when get user tags with revoked API key",
+			req:    specRequest{method: http.MethodGet, path: "/api/user/tags", apiKey: apiKey},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "when revoke unknown API key",
+			req:    specRequest{method: http.MethodDelete, path: "/api/user/api-keys/" + apiKey, authToken: authToken},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "when delete user URLs",
+			req:    specRequest{method: http.MethodDelete, path: "/api/user/urls", contentType: "application/json", body: fmt.Sprintf(`["%s"]`, alias), authToken: authToken},
+			status: http.StatusAccepted,
+		},
+		{
+			name:   "when get namespaces",
+			req:    specRequest{method: http.MethodGet, path: "/api/namespaces"},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when create existing namespace",
+			req:    specRequest{method: http.MethodPost, path: "/api/namespaces", contentType: "application/json", body: `{"name":"team-a"}`, adminToken: cfg.Auth.AdminToken},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "when create namespace with reserved name",
+			req:    specRequest{method: http.MethodPost, path: "/api/namespaces", contentType: "application/json", body: `{"name":"api"}`, adminToken: cfg.Auth.AdminToken},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "when create namespace without admin token",
+			req:    specRequest{method: http.MethodPost, path: "/api/namespaces", contentType: "application/json", body: `{"name":"team-b"}`},
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "when delete namespace with short URLs",
+			req:    specRequest{method: http.MethodDelete, path: "/api/namespaces/team-a", adminToken: cfg.Auth.AdminToken},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "when delete default namespace",
+			req:    specRequest{method: http.MethodDelete, path: "/api/namespaces/default", adminToken: cfg.Auth.AdminToken},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "when delete unknown namespace",
+			req:    specRequest{method: http.MethodDelete, path: "/api/namespaces/unknown", adminToken: cfg.Auth.AdminToken},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "when create namespace",
+			req:    specRequest{method: http.MethodPost, path: "/api/namespaces", contentType: "application/json", body: `{"name":"team-b"}`, adminToken: cfg.Auth.AdminToken},
+			status: http.StatusCreated,
+		},
+		{
+			name:   "when delete empty namespace",
+			req:    specRequest{method: http.MethodDelete, path: "/api/namespaces/team-b", adminToken: cfg.Auth.AdminToken},
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "when reactivate active user",
+			req:    specRequest{method: http.MethodPatch, path: "/api/admin/users/1/reactivate", adminToken: cfg.Auth.AdminToken},
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "when deactivate unknown user",
+			req:    specRequest{method: http.MethodPatch, path: "/api/admin/users/1000000/deactivate", adminToken: cfg.Auth.AdminToken},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "when deactivate user without admin token",
+			req:    specRequest{method: http.MethodPatch, path: "/api/admin/users/1/deactivate"},
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "when get preview of invalid URL",
+			req:    specRequest{method: http.MethodGet, path: "/api/preview?url=invalid"},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "when get app info",
+			req:    specRequest{method: http.MethodGet, path: "/api/info"},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when ping DB",
+			req:    specRequest{method: http.MethodGet, path: "/ping"},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when get stats",
+			req:    specRequest{method: http.MethodGet, path: "/api/internal/stats"},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when log out",
+			req:    specRequest{method: http.MethodDelete, path: "/api/user/session", authToken: authToken},
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "when log out without token",
+			req:    specRequest{method: http.MethodDelete, path: "/api/user/session"},
+			status: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sendSpecRequest(t, client, specRouter, ts.URL, tt.req, tt.status)
+		})
+	}
+}
+
+// specMultipartCSV builds multipart form body with CSV file for import endpoint.
+func specMultipartCSV(t *testing.T, content string) (string, string) {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "urls.csv")
+	require.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	return body.String(), writer.FormDataContentType()
+}
+
+// sendSpecRequest sends request, checks response status and validates response against specification.
+func sendSpecRequest(t *testing.T, client *http.Client, specRouter routers.Router, baseURL string, req specRequest, wantStatus int) (*http.Response, string) {
+	t.Helper()
+
+	httpReq, err := http.NewRequest(req.method, baseURL+req.path, strings.NewReader(req.body))
+	require.NoError(t, err)
+	if req.contentType != "" {
+		httpReq.Header.Set("Content-Type", req.contentType)
+	}
+	if req.authToken != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+req.authToken)
+	}
+	if req.apiKey != "" {
+		httpReq.Header.Set("X-API-Key", req.apiKey)
+	}
+	if req.idempotencyKey != "" {
+		httpReq.Header.Set("Idempotency-Key", req.idempotencyKey)
+	}
+	if req.namespace != "" {
+		httpReq.Header.Set("X-Namespace", req.namespace)
+	}
+	if req.adminToken != "" {
+		httpReq.Header.Set("X-Admin-Token", req.adminToken)
+	}
+	if req.accept != "" {
+		httpReq.Header.Set("Accept", req.accept)
+	}
+	// Payloads are checked uncompressed, compression is covered by Test_App_Compress_OK
+	httpReq.Header.Set("Accept-Encoding", "identity")
+
+	res, err := client.Do(httpReq)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, res.Body.Close())
+	}()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, wantStatus, res.StatusCode, string(body))
+
+	route, pathParams, err := specRouter.FindRoute(httpReq)
+	require.NoError(t, err, "route is not documented")
+
+	err = openapi3filter.ValidateResponse(httpReq.Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    httpReq,
+			PathParams: pathParams,
+			Route:      route,
+		},
+		Status:  res.StatusCode,
+		Header:  res.Header,
+		Body:    io.NopCloser(bytes.NewReader(body)),
+		Options: &openapi3filter.Options{IncludeResponseStatus: true},
+	})
+	assert.NoError(t, err)
+
+	return res, string(body)
+}
@@ -5,12 +5,13 @@ Package config handles application configuration management including:
 - Configuration defaults
 - Configuration validation
 
-The package supports configuration from multiple sources:
-1. .env files
+The package supports configuration from multiple sources
+in order of decreasing priority:
+1. Command-line flags
 2. Environment variables
-3. Command-line flags
-4. Default values
-5. JSON configuration files
+3. .env files
+4. JSON, YAML or TOML configuration files
+5. Default values
 
 Configuration is organized into logical sections (App, Auth, Server, etc.)
 for better maintainability.
@@ -23,119 +24,233 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"time"
 
+	"github.com/BurntSushi/toml"
 	"github.com/caarlos0/env/v6"
+	"github.com/gururuby/shortener/pkg/generator"
 	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
 )
 
 // Config represents the complete application configuration.
 // It aggregates all configuration subsections including server settings,
 // authentication parameters, database configuration and logging setup.
 type Config struct {
-	Server      Server      // HTTP/HTTPS server configuration
-	FileStorage FileStorage // File storage settings
-	Log         Log         // Logging configuration
-	App         App         // Application metadata
-	Auth        Auth        // Authentication settings
-	Database    Database    // Database connection parameters
+	Server       Server       `yaml:"server" toml:"server"`               // HTTP/HTTPS server configuration
+	FileStorage  FileStorage  `yaml:"file_storage" toml:"file_storage"`   // File storage settings
+	Log          Log          `yaml:"log" toml:"log"`                     // Logging configuration
+	App          App          `yaml:"app" toml:"app"`                     // Application metadata
+	Auth         Auth         `yaml:"auth" toml:"auth"`                   // Authentication settings
+	Database     Database     `yaml:"database" toml:"database"`           // Database connection parameters
+	SafeBrowsing SafeBrowsing `yaml:"safe_browsing" toml:"safe_browsing"` // URL safety check settings
+	Telemetry    Telemetry    `yaml:"telemetry" toml:"telemetry"`         // Distributed tracing settings
+	Analytics    Analytics    `yaml:"analytics" toml:"analytics"`         // Click analytics settings
 }
 
 // App contains application metadata and general settings.
 type App struct {
-	Env             string        `env:"APP_ENV" envDefault:"development"`      // Application environment (development/production)
-	Name            string        `env:"APP_NAME" envDefault:"Shortener"`       // Application name
-	Version         string        `env:"APP_VERSION" envDefault:"0.0.1"`        // Application version
-	BaseURL         string        `env:"APP_BASE_URL"`                          // Base URL for generated links
-	AliasLength     int           `env:"APP_ALIAS_LENGTH" envDefault:"5"`       // Default length for generated aliases
-	ShutdownTimeout time.Duration `env:"APP_SHUTDOWN_TIMEOUT" envDefault:"30s"` // Graceful shutdown timeout
+	Env                string        `env:"APP_ENV" envDefault:"development" yaml:"app_env" toml:"app_env"`                                              // Application environment (development/production)
+	Name               string        `env:"APP_NAME" envDefault:"Shortener" yaml:"app_name" toml:"app_name"`                                             // Application name
+	Version            string        `env:"APP_VERSION" envDefault:"0.0.1" yaml:"app_version" toml:"app_version"`                                        // Application version
+	BaseURL            string        `env:"APP_BASE_URL" yaml:"app_base_url" toml:"app_base_url"`                                                        // Base URL for generated links
+	AliasLength        int           `env:"APP_ALIAS_LENGTH" envDefault:"5" yaml:"app_alias_length" toml:"app_alias_length"`                             // Default length for generated aliases
+	AliasAlphabet      string        `env:"APP_ALIAS_ALPHABET" yaml:"app_alias_alphabet" toml:"app_alias_alphabet"`                                      // Characters used in generated aliases (generator.DefaultAlphabet if empty)
+	AliasStrategy      string        `env:"APP_ALIAS_STRATEGY" envDefault:"random" yaml:"app_alias_strategy" toml:"app_alias_strategy"`                  // Alias generation strategy: random, word or sequential
+	MaxAliasRetries    int           `env:"APP_MAX_ALIAS_RETRIES" envDefault:"3" yaml:"app_max_alias_retries" toml:"app_max_alias_retries"`              // Attempts to regenerate alias of a short URL when it is already taken
+	ShutdownTimeout    time.Duration `env:"APP_SHUTDOWN_TIMEOUT" envDefault:"30s" yaml:"app_shutdown_timeout" toml:"app_shutdown_timeout"`               // Graceful shutdown timeout
+	MaxDrainDuration   time.Duration `env:"APP_MAX_DRAIN_DURATION" envDefault:"15s" yaml:"app_max_drain_duration" toml:"app_max_drain_duration"`         // Time to wait for in-flight requests before closing connections on shutdown
+	DomainBlacklist    string        `env:"APP_DOMAIN_BLACKLIST" yaml:"app_domain_blacklist" toml:"app_domain_blacklist"`                                // Comma-separated domains which can't be shortened
+	DomainWhitelist    string        `env:"APP_DOMAIN_WHITELIST" yaml:"app_domain_whitelist" toml:"app_domain_whitelist"`                                // Comma-separated domains which only can be shortened (any if empty)
+	Region             string        `env:"APP_REGION" envDefault:"default" yaml:"app_region" toml:"app_region"`                                         // Region of the instance stored in created short URLs
+	JanitorInterval    time.Duration `env:"APP_JANITOR_INTERVAL" envDefault:"1h" yaml:"app_janitor_interval" toml:"app_janitor_interval"`                // Interval between removals of expired short URLs
+	ValidatorInterval  time.Duration `env:"APP_VALIDATOR_INTERVAL" envDefault:"6h" yaml:"app_validator_interval" toml:"app_validator_interval"`          // Interval between health checks of source URLs (0 = disabled)
+	EventsMaxConns     int           `env:"APP_EVENTS_MAX_CONNS" envDefault:"100" yaml:"app_events_max_conns" toml:"app_events_max_conns"`               // Limit of simultaneous event streams per user
+	UseRedirectPage    bool          `env:"APP_USE_REDIRECT_PAGE" envDefault:"false" yaml:"app_use_redirect_page" toml:"app_use_redirect_page"`          // Serve HTML page with countdown instead of redirect status
+	TrackingPixel      bool          `env:"APP_TRACKING_PIXEL" envDefault:"false" yaml:"app_tracking_pixel" toml:"app_tracking_pixel"`                   // Serve tracking page recording clicks by pixel for all short URLs
+	CheckRedirectChain bool          `env:"APP_CHECK_REDIRECT_CHAIN" envDefault:"false" yaml:"app_check_redirect_chain" toml:"app_check_redirect_chain"` // Follow redirects of source URLs on creation, rejecting loops and unreachable pages
+	MaxRedirectHops    int           `env:"APP_MAX_REDIRECT_HOPS" envDefault:"5" yaml:"app_max_redirect_hops" toml:"app_max_redirect_hops"`              // Limit of redirects followed by the check
+
+	DefaultNamespace string `env:"APP_DEFAULT_NAMESPACE" envDefault:"default" yaml:"app_default_namespace" toml:"app_default_namespace"` // Namespace of short URLs created without X-Namespace header
+
+	MaxURLsPerUser   int `env:"APP_MAX_URLS_PER_USER" envDefault:"10000" yaml:"app_max_urls_per_user" toml:"app_max_urls_per_user"` // Limit of not deleted short URLs of a user (0 = unlimited)
+	MaxAnonymousURLs int `env:"APP_MAX_ANONYMOUS_URLS" envDefault:"0" yaml:"app_max_anonymous_urls" toml:"app_max_anonymous_urls"`  // Limit of not deleted short URLs created without a user, shared by all anonymous clients (0 = unlimited)
 }
 
 // Auth contains JWT authentication settings.
 type Auth struct {
-	SecretKey string        `env:"AUTH_SECRET_KEY" envDefault:"secret"` // Secret key for JWT tokens
-	TokenTTL  time.Duration `env:"AUTH_TOKEN_TTL" envDefault:"24h"`     // Token time-to-live duration
+	SecretKey                 string        `env:"AUTH_SECRET_KEY" envDefault:"secret" yaml:"auth_secret_key" toml:"auth_secret_key"`                                                // Secret key for JWT tokens
+	TokenTTL                  time.Duration `env:"AUTH_TOKEN_TTL" envDefault:"24h" yaml:"auth_token_ttl" toml:"auth_token_ttl"`                                                      // Token time-to-live duration
+	RevocationRedisAddr       string        `env:"AUTH_REVOCATION_REDIS_ADDR" yaml:"auth_revocation_redis_addr" toml:"auth_revocation_redis_addr"`                                   // Redis address for revoked tokens (in-memory store if empty)
+	RevocationCleanupInterval time.Duration `env:"AUTH_REVOCATION_CLEANUP_INTERVAL" envDefault:"1m" yaml:"auth_revocation_cleanup_interval" toml:"auth_revocation_cleanup_interval"` // Interval between removals of expired revoked tokens from memory
+	AdminToken                string        `env:"AUTH_ADMIN_TOKEN" yaml:"auth_admin_token" toml:"auth_admin_token"`                                                                 // Token required to manage namespaces and users (administration is disabled if empty)
 }
 
 // HTTPS contains HTTPS server configuration.
 type HTTPS struct {
-	Enabled  bool   `env:"ENABLE_HTTPS" envDefault:"false"` // Enable HTTPS server
-	CertFile string `env:"HTTPS_CERT_FILE"`                 // Path to SSL certificate file
-	KeyFile  string `env:"HTTPS_KEY_FILE"`                  // Path to SSL private key file
+	Enabled  bool   `env:"ENABLE_HTTPS" envDefault:"false" yaml:"enable_https" toml:"enable_https"` // Enable HTTPS server
+	CertFile string `env:"HTTPS_CERT_FILE" yaml:"https_cert_file" toml:"https_cert_file"`           // Path to SSL certificate file
+	KeyFile  string `env:"HTTPS_KEY_FILE" yaml:"https_key_file" toml:"https_key_file"`              // Path to SSL private key file
+	CertPEM  string `env:"HTTPS_CERT_PEM" yaml:"https_cert_pem" toml:"https_cert_pem"`              // SSL certificate as PEM or base64-encoded PEM/DER, used instead of CertFile
+	KeyPEM   string `env:"HTTPS_KEY_PEM" yaml:"https_key_pem" toml:"https_key_pem"`                 // SSL private key as PEM or base64-encoded PEM/DER, used instead of KeyFile
 }
 
 // Server contains HTTP server configuration.
 type Server struct {
-	Address      string        `env:"SERVER_ADDRESS"`                        // Server listen address (host:port)
-	ReadTimeout  time.Duration `env:"SERVER_READ_TIMEOUT" envDefault:"5s"`   // Maximum duration for reading request
-	WriteTimeout time.Duration `env:"SERVER_WRITE_TIMEOUT" envDefault:"10s"` // Maximum duration for writing response
-	IdleTimeout  time.Duration `env:"SERVER_IDLE_TIMEOUT" envDefault:"120s"` // Maximum idle connection duration
-	HTTPS        HTTPS         // HTTPS-specific configuration
+	Address          string        `env:"SERVER_ADDRESS" yaml:"server_address" toml:"server_address"`                                                  // Server listen address (host:port)
+	ReadTimeout      time.Duration `env:"SERVER_READ_TIMEOUT" envDefault:"5s" yaml:"server_read_timeout" toml:"server_read_timeout"`                   // Maximum duration for reading request
+	WriteTimeout     time.Duration `env:"SERVER_WRITE_TIMEOUT" envDefault:"10s" yaml:"server_write_timeout" toml:"server_write_timeout"`               // Maximum duration for writing response
+	IdleTimeout      time.Duration `env:"SERVER_IDLE_TIMEOUT" envDefault:"120s" yaml:"server_idle_timeout" toml:"server_idle_timeout"`                 // Maximum idle connection duration
+	AllowedOrigins   []string      `env:"CORS_ALLOWED_ORIGINS" envSeparator:"," yaml:"cors_allowed_origins" toml:"cors_allowed_origins"`               // Origins allowed for cross-origin requests
+	ValidateRequests bool          `env:"SERVER_VALIDATE_REQUESTS" envDefault:"false" yaml:"server_validate_requests" toml:"server_validate_requests"` // Reject requests not matching OpenAPI specification
+	MaxBodyBytes     int64         `env:"SERVER_MAX_BODY_BYTES" envDefault:"1048576" yaml:"server_max_body_bytes" toml:"server_max_body_bytes"`        // Maximum request body size in bytes
+	TrustedSubnet    string        `env:"TRUSTED_SUBNET" yaml:"trusted_subnet" toml:"trusted_subnet"`                                                  // CIDR of clients allowed to internal endpoints (no restriction if empty)
+	TrustedProxy     string        `env:"TRUSTED_PROXY" yaml:"trusted_proxy" toml:"trusted_proxy"`                                                     // CIDR of reverse proxy whose X-Real-IP and X-Forwarded-For headers are used by trusted subnet check (headers are ignored if empty)
+
+	ExposeClientCertFingerprint bool   `env:"SERVER_EXPOSE_CLIENT_CERT_FINGERPRINT" envDefault:"false" yaml:"server_expose_client_cert_fingerprint" toml:"server_expose_client_cert_fingerprint"` // Return fingerprint of TLS client certificate in X-TLS-Client-Fingerprint header
+	PreferredEncoding           string `env:"SERVER_PREFERRED_ENCODING" envDefault:"auto" yaml:"server_preferred_encoding" toml:"server_preferred_encoding"`                                      // Response encoding used whenever accepted by client (gzip/brotli/zstd/auto)
+
+	CreateURLTimeout time.Duration `env:"SERVER_CREATE_URL_TIMEOUT" envDefault:"30s" yaml:"server_create_url_timeout" toml:"server_create_url_timeout"` // Maximum duration of short URL creation
+	BatchURLTimeout  time.Duration `env:"SERVER_BATCH_URL_TIMEOUT" envDefault:"60s" yaml:"server_batch_url_timeout" toml:"server_batch_url_timeout"`    // Maximum duration of batch short URL creation
+	ReadURLTimeout   time.Duration `env:"SERVER_READ_URL_TIMEOUT" envDefault:"10s" yaml:"server_read_url_timeout" toml:"server_read_url_timeout"`       // Maximum duration of short URL lookup
+
+	IdempotencyTTL       time.Duration `env:"SERVER_IDEMPOTENCY_TTL" envDefault:"5m" yaml:"server_idempotency_ttl" toml:"server_idempotency_ttl"`      // Time to keep responses replayed to retried creation requests
+	IdempotencyRedisAddr string        `env:"SERVER_IDEMPOTENCY_REDIS_ADDR" yaml:"server_idempotency_redis_addr" toml:"server_idempotency_redis_addr"` // Redis address for replayed responses (in-memory store if empty)
+	HTTPS                HTTPS         `yaml:"https" toml:"https"`                                                                                     // HTTPS-specific configuration
 }
 
 // Database contains database connection settings.
 type Database struct {
-	Type         string        `env:"DATABASE_TYPE"`                           // Database type (postgresql/mysql/file/memory)
-	DSN          string        `env:"DATABASE_DSN"`                            // Data Source Name (connection string)
-	ConnTryDelay time.Duration `env:"DATABASE_CONN_TRY_DELAY" envDefault:"5s"` // Delay between connection attempts
-	ConnTryTimes int           `env:"DATABASE_CONN_TRY_TIMES" envDefault:"5"`  // Number of connection attempts
+	Type         string        `env:"DATABASE_TYPE" yaml:"database_type" toml:"database_type"`                                               // Database type (postgresql/mysql/file/memory)
+	DSN          string        `env:"DATABASE_DSN" yaml:"database_dsn" toml:"database_dsn"`                                                  // Data Source Name (connection string)
+	ConnTryDelay time.Duration `env:"DATABASE_CONN_TRY_DELAY" envDefault:"5s" yaml:"database_conn_try_delay" toml:"database_conn_try_delay"` // Delay between connection attempts
+	ConnTryTimes int           `env:"DATABASE_CONN_TRY_TIMES" envDefault:"5" yaml:"database_conn_try_times" toml:"database_conn_try_times"`  // Number of connection attempts
+
+	MaxConns          int32         `env:"DATABASE_MAX_CONNS" envDefault:"10" yaml:"database_max_conns" toml:"database_max_conns"`                               // Maximum size of connection pool
+	MinConns          int32         `env:"DATABASE_MIN_CONNS" envDefault:"2" yaml:"database_min_conns" toml:"database_min_conns"`                                // Minimum number of connections kept open
+	MaxConnLifetime   time.Duration `env:"DATABASE_MAX_CONN_LIFETIME" envDefault:"1h" yaml:"database_max_conn_lifetime" toml:"database_max_conn_lifetime"`       // Time after which a connection is closed
+	MaxConnIdleTime   time.Duration `env:"DATABASE_MAX_CONN_IDLE_TIME" envDefault:"30m" yaml:"database_max_conn_idle_time" toml:"database_max_conn_idle_time"`   // Time after which an idle connection is closed
+	HealthCheckPeriod time.Duration `env:"DATABASE_HEALTH_CHECK_PERIOD" envDefault:"1m" yaml:"database_health_check_period" toml:"database_health_check_period"` // Interval of idle connections health check
+	QueryTimeout      time.Duration `env:"DATABASE_QUERY_TIMEOUT" envDefault:"10s" yaml:"database_query_timeout" toml:"database_query_timeout"`                  // Maximum duration of a single query, no limit if zero
+
+	ReadReplicaEnabled bool   `env:"DATABASE_READ_REPLICA_ENABLED" envDefault:"false" yaml:"database_read_replica_enabled" toml:"database_read_replica_enabled"` // Send read-heavy queries to the read replica
+	ReadReplicaDSN     string `env:"DATABASE_READ_REPLICA_DSN" yaml:"database_read_replica_dsn" toml:"database_read_replica_dsn"`                                // Data Source Name of the read replica, pool settings are shared with DSN
 }
 
 // FileStorage contains settings for file-based storage.
 type FileStorage struct {
-	Path string `env:"FILE_STORAGE_PATH"` // Path to storage file
+	Path string `env:"FILE_STORAGE_PATH" yaml:"file_storage_path" toml:"file_storage_path"` // Path to storage file
+}
+
+// SafeBrowsing contains Google Safe Browsing settings.
+type SafeBrowsing struct {
+	APIKey  string `env:"SAFE_BROWSING_API_KEY" yaml:"safe_browsing_api_key" toml:"safe_browsing_api_key"`                    // Google API key with Safe Browsing API enabled
+	Enabled bool   `env:"SAFE_BROWSING_ENABLED" envDefault:"false" yaml:"safe_browsing_enabled" toml:"safe_browsing_enabled"` // Check source URLs before shortening
+}
+
+// Telemetry contains OpenTelemetry tracing settings.
+type Telemetry struct {
+	OTLPEndpoint string `env:"TELEMETRY_OTLP_ENDPOINT" envDefault:"http://localhost:4318" yaml:"telemetry_otlp_endpoint" toml:"telemetry_otlp_endpoint"` // URL of OTLP/HTTP collector receiving spans
+	Enabled      bool   `env:"TELEMETRY_ENABLED" envDefault:"false" yaml:"telemetry_enabled" toml:"telemetry_enabled"`                                   // Export request traces
+}
+
+// Analytics contains click analytics settings.
+type Analytics struct {
+	GeoIPDB            string `env:"ANALYTICS_GEOIP_DB" yaml:"analytics_geoip_db" toml:"analytics_geoip_db"`                                                          // Path to MaxMind GeoLite2 City database (locations of clicks are not resolved if empty)
+	MaxClicksPerSecond int    `env:"ANALYTICS_MAX_CLICKS_PER_SECOND" envDefault:"1000" yaml:"analytics_max_clicks_per_second" toml:"analytics_max_clicks_per_second"` // Clicks of a single alias allowed per second, above it redirects are rejected (no limit if not positive)
 }
 
 // Log contains logging configuration.
 type Log struct {
-	Level string `env:"LOG_LEVEL" envDefault:"info"` // Logging level (debug/info/warn/error)
+	Level      string `env:"LOG_LEVEL" envDefault:"info" yaml:"log_level" toml:"log_level"`                     // Logging level (debug/info/warn/error)
+	Format     string `env:"LOG_FORMAT" envDefault:"json" yaml:"log_format" toml:"log_format"`                  // Log format (json/console)
+	OutputPath string `env:"LOG_OUTPUT_PATH" envDefault:"stderr" yaml:"log_output_path" toml:"log_output_path"` // Log output: stderr, stdout or file path
+
+	AccessSamplingRate float64 `env:"LOG_ACCESS_SAMPLING_RATE" envDefault:"1" yaml:"log_access_sampling_rate" toml:"log_access_sampling_rate"` // Share of successful requests written to access log (0-1)
 }
 
-var (
-	cfg         Config // Global configuration instance
-	jsonCfgName string // Name of JSON config file
-)
+var cfgFile string // Name of config file
 
 // New loads and initializes application configuration from multiple sources:
-// 1. .env file (if present)
-// 2. Environment variables
-// 3. Command-line flags
-// 4. JSON configuration file (if specified)
+// 1. Default values
+// 2. Configuration file (if specified with -c flag)
+// 3. .env file (if present) and environment variables
+// 4. Command-line flags
 //
-// The loading order follows the priority:
+// Each source overrides only the values it explicitly sets, so the priority is:
 // 1. Command-line flags (highest priority)
-// 2. Environment variables
-// 3. .env file
-// 4. JSON config file
-// 5. Default values (lowest priority)
+// 2. Environment variables and .env file
+// 3. Config file
+// 4. Default values (lowest priority)
 //
 // Returns:
 // - *Config: Loaded configuration
 // - error: Any error that occurred during loading
 func New() (*Config, error) {
-	var err error
-
-	// Load from JSON config file if specified
-	if jsonCfgName != "" {
-		err = loadConfigFromJSON(jsonCfgName, cfg)
-		if err != nil {
-			log.Printf("Error loading config from %s file", jsonCfgName)
-		}
+	// Parse command-line flags, the config file name is passed as flag
+	if !flag.Parsed() {
+		flag.Parse()
 	}
 
 	// Try loading .env file (ignore if not found)
-	err = godotenv.Load(".env")
-	if err != nil {
+	if err := godotenv.Load(".env"); err != nil {
 		log.Print("Error loading .env file")
 	}
 
-	// Parse environment variables
-	if err = env.Parse(&cfg); err != nil {
+	return build(cfgFile, false)
+}
+
+// FilePath returns the name of config file passed with -c flag.
+// Returns:
+// - string: Name of the file, empty if configuration is not loaded from file
+func FilePath() string {
+	return cfgFile
+}
+
+// build assembles configuration from defaults, config file, environment variables and flags.
+// Parameters:
+// - path: Name of config file, empty if there is none
+// - strict: Whether the file must be valid, otherwise its errors are logged and the file is skipped
+// Returns:
+// - *Config: Loaded configuration
+// - error: Any error that occurred during loading
+func build(path string, strict bool) (*Config, error) {
+	var (
+		cfg    Config
+		envCfg Config
+		err    error
+	)
+
+	// Apply default values only, no environment variables are visible here
+	if err = env.Parse(&cfg, env.Options{Environment: map[string]string{}}); err != nil {
+		return nil, fmt.Errorf("config error: %v", err)
+	}
+
+	// Load from config file if specified
+	if path != "" {
+		err = loadConfigFromFile(path, &cfg)
+		if err != nil {
+			if strict {
+				return nil, fmt.Errorf("config error: %w", err)
+			}
+			log.Printf("Error loading config from %s file: %s", path, err)
+		}
+	}
+
+	// Parse environment variables and apply the ones which are set
+	if err = env.Parse(&envCfg); err != nil {
 		return nil, fmt.Errorf("config error: %v", err)
 	}
+	overrideFromEnv(reflect.ValueOf(&cfg).Elem(), reflect.ValueOf(&envCfg).Elem())
 
-	// Parse command-line flags
-	flag.Parse()
+	applyFlags(&cfg)
+
+	if cfg.App.AliasAlphabet == "" {
+		cfg.App.AliasAlphabet = generator.DefaultAlphabet
+	}
 
 	// Determine storage type based on provided configuration
 	if cfg.Database.DSN == "" {
@@ -148,25 +263,164 @@ func New() (*Config, error) {
 		cfg.Database.Type = "postgresql"
 	}
 
+	if err = validateTimeouts(&cfg.Server); err != nil {
+		return nil, fmt.Errorf("config error: %w", err)
+	}
+
+	if err = validatePreferredEncoding(cfg.Server.PreferredEncoding); err != nil {
+		return nil, fmt.Errorf("config error: %w", err)
+	}
+
 	return &cfg, nil
 }
 
+// validateTimeouts checks that every endpoint timeout is positive,
+// as zero or negative timeout would fail every request to the endpoint.
+// Parameters:
+// - server: Server configuration to check
+// Returns:
+// - error: Description of the first invalid timeout, nil if all timeouts are valid
+func validateTimeouts(server *Server) error {
+	for name, timeout := range map[string]time.Duration{
+		"SERVER_CREATE_URL_TIMEOUT": server.CreateURLTimeout,
+		"SERVER_BATCH_URL_TIMEOUT":  server.BatchURLTimeout,
+		"SERVER_READ_URL_TIMEOUT":   server.ReadURLTimeout,
+	} {
+		if timeout <= 0 {
+			return fmt.Errorf("%s must be positive, got %s", name, timeout)
+		}
+	}
+	return nil
+}
+
+// validatePreferredEncoding checks that the preferred response encoding is supported.
+// Parameters:
+// - encoding: Value of SERVER_PREFERRED_ENCODING
+// Returns:
+// - error: Description of the unsupported value, nil if it is supported or empty
+func validatePreferredEncoding(encoding string) error {
+	switch encoding {
+	case "", "auto", "gzip", "brotli", "zstd":
+		return nil
+	default:
+		return fmt.Errorf("SERVER_PREFERRED_ENCODING must be one of gzip, brotli, zstd, auto, got %q", encoding)
+	}
+}
+
+// loadConfigFromFile reads configuration file in the format given by its extension:
+// .json for JSON, .yaml or .yml for YAML and .toml for TOML.
+// Fields missing in the file keep their current values.
+// Returns error if the extension is not supported or the file cannot be loaded.
+func loadConfigFromFile(path string, cfg *Config) error {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		return loadConfigFromJSON(path, cfg)
+	case ".yaml", ".yml":
+		return loadConfigFromYAML(path, cfg)
+	case ".toml":
+		return loadConfigFromTOML(path, cfg)
+	default:
+		return fmt.Errorf("unsupported config file extension %q, expected .json, .yaml, .yml or .toml", ext)
+	}
+}
+
 // loadConfigFromJSON reads and parses JSON configuration file into Config struct.
 // The function expects the path to a valid JSON file matching the Config structure.
+// Fields missing in the file keep their current values.
+// Returns error if file cannot be read or contains invalid configuration.
+func loadConfigFromJSON(path string, cfg *Config) error {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := json.Unmarshal(file, cfg); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	return nil
+}
+
+// loadConfigFromYAML reads and parses YAML configuration file into Config struct.
+// Keys are lowercased names of environment variables grouped by sections,
+// e.g. server_address under server, durations are written as "5s".
+// Fields missing in the file keep their current values.
 // Returns error if file cannot be read or contains invalid configuration.
-func loadConfigFromJSON(path string, cfg Config) error {
+func loadConfigFromYAML(path string, cfg *Config) error {
 	file, err := os.ReadFile(path)
 	if err != nil {
 		return fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	if err := json.Unmarshal(file, &cfg); err != nil {
+	if err := yaml.Unmarshal(file, cfg); err != nil {
 		return fmt.Errorf("failed to parse config file: %w", err)
 	}
 
 	return nil
 }
 
+// loadConfigFromTOML reads and parses TOML configuration file into Config struct.
+// Keys are the same as in YAML, sections are TOML tables, e.g. [server].
+// Fields missing in the file keep their current values.
+// Returns error if file cannot be read or contains invalid configuration.
+func loadConfigFromTOML(path string, cfg *Config) error {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := toml.Unmarshal(file, cfg); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	return nil
+}
+
+// overrideFromEnv copies fields whose environment variable is set from src to dst.
+// Nested structs without env tag are processed recursively.
+func overrideFromEnv(dst, src reflect.Value) {
+	for i := range dst.NumField() {
+		field := dst.Type().Field(i)
+		key, ok := field.Tag.Lookup("env")
+
+		if !ok {
+			if field.Type.Kind() == reflect.Struct {
+				overrideFromEnv(dst.Field(i), src.Field(i))
+			}
+			continue
+		}
+
+		if _, set := os.LookupEnv(key); set {
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
+}
+
+// applyFlags overrides configuration with explicitly set command-line flags.
+// Defaults of string flags are used only for values left empty by other sources.
+func applyFlags(cfg *Config) {
+	explicit := make(map[string]bool)
+	flag.CommandLine.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	for name, value := range map[string]*string{
+		"a": &cfg.Server.Address,
+		"b": &cfg.App.BaseURL,
+		"d": &cfg.Database.DSN,
+		"f": &cfg.FileStorage.Path,
+		"t": &cfg.Server.TrustedSubnet,
+	} {
+		if f := flag.CommandLine.Lookup(name); f != nil && (explicit[name] || *value == "") {
+			*value = f.Value.String()
+		}
+	}
+
+	if f := flag.CommandLine.Lookup("s"); f != nil && explicit["s"] {
+		cfg.Server.HTTPS.Enabled = f.Value.(flag.Getter).Get().(bool)
+	}
+}
+
 // AppInfo generates a formatted string with application information.
 // The format is: "<Name> v<Version> (<Env>)"
 // Example: "Shortener v1.0.0 (production)"
@@ -177,10 +431,17 @@ func (c *Config) AppInfo() string {
 // init registers command-line flags with their default values.
 // The flags are registered when the package is initialized.
 func init() {
-	flag.StringVar(&cfg.Server.Address, "a", "localhost:8080", "Server address (host:port)")
-	flag.StringVar(&cfg.App.BaseURL, "b", "http://localhost:8080", "Base URL for shortened links")
-	flag.StringVar(&jsonCfgName, "c", "", "Name of config file")
-	flag.StringVar(&cfg.Database.DSN, "d", "", "Database connection string (DSN)")
-	flag.StringVar(&cfg.FileStorage.Path, "f", "/tmp/db.json", "Path to file storage")
-	flag.BoolVar(&cfg.Server.HTTPS.Enabled, "s", true, "Run HTTPS server")
+	registerFlags(flag.CommandLine)
+}
+
+// registerFlags registers configuration flags in the flag set.
+// Flag values are applied to configuration by New.
+func registerFlags(fs *flag.FlagSet) {
+	fs.String("a", "localhost:8080", "Server address (host:port)")
+	fs.String("b", "http://localhost:8080", "Base URL for shortened links")
+	fs.StringVar(&cfgFile, "c", "", "Name of config file (.json, .yaml, .yml or .toml)")
+	fs.String("d", "", "Database connection string (DSN)")
+	fs.String("f", "/tmp/db.json", "Path to file storage")
+	fs.Bool("s", false, "Run HTTPS server")
+	fs.String("t", "", "Trusted subnet in CIDR notation for internal endpoints")
 }
@@ -1,10 +1,14 @@
 package config
 
 import (
+	"flag"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConfig(t *testing.T) {
@@ -17,22 +21,46 @@ func TestConfig(t *testing.T) {
 			name: "setup default values",
 			want: &Config{
 				App: App{
-					AliasLength:     5,
-					Env:             "development",
-					Name:            "Shortener",
-					ShutdownTimeout: 30 * time.Second,
-					Version:         "0.0.1",
-					BaseURL:         "http://localhost:8080",
+					AliasLength:       5,
+					AliasAlphabet:     "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789",
+					AliasStrategy:     "random",
+					MaxAliasRetries:   3,
+					Env:               "development",
+					Name:              "Shortener",
+					ShutdownTimeout:   30 * time.Second,
+					MaxDrainDuration:  15 * time.Second,
+					Version:           "0.0.1",
+					BaseURL:           "http://localhost:8080",
+					Region:            "default",
+					JanitorInterval:   time.Hour,
+					ValidatorInterval: 6 * time.Hour,
+					EventsMaxConns:    100,
+					MaxRedirectHops:   5,
+
+					DefaultNamespace: "default",
+
+					MaxURLsPerUser:   10000,
+					MaxAnonymousURLs: 0,
 				},
 				Auth: Auth{
-					TokenTTL:  24 * time.Hour,
-					SecretKey: "secret",
+					TokenTTL:                  24 * time.Hour,
+					SecretKey:                 "secret",
+					RevocationCleanupInterval: time.Minute,
 				},
 				Server: Server{
 					Address:      "localhost:8080",
 					ReadTimeout:  5 * time.Second,
 					WriteTimeout: 10 * time.Second,
 					IdleTimeout:  120 * time.Second,
+					MaxBodyBytes: 1 << 20,
+
+					PreferredEncoding: "auto",
+
+					CreateURLTimeout: 30 * time.Second,
+					BatchURLTimeout:  60 * time.Second,
+					ReadURLTimeout:   10 * time.Second,
+
+					IdempotencyTTL: 5 * time.Minute,
 					HTTPS: HTTPS{
 						Enabled: false,
 					},
@@ -42,12 +70,29 @@ func TestConfig(t *testing.T) {
 					DSN:          "",
 					ConnTryDelay: 5 * time.Second,
 					ConnTryTimes: 5,
+
+					MaxConns:          10,
+					MinConns:          2,
+					MaxConnLifetime:   time.Hour,
+					MaxConnIdleTime:   30 * time.Minute,
+					HealthCheckPeriod: time.Minute,
+					QueryTimeout:      10 * time.Second,
 				},
 				FileStorage: FileStorage{
 					Path: "/tmp/db.json",
 				},
 				Log: Log{
-					Level: "info",
+					Level:      "info",
+					Format:     "json",
+					OutputPath: "stderr",
+
+					AccessSamplingRate: 1,
+				},
+				Telemetry: Telemetry{
+					OTLPEndpoint: "http://localhost:4318",
+				},
+				Analytics: Analytics{
+					MaxClicksPerSecond: 1000,
 				},
 			},
 		},
@@ -60,3 +105,206 @@ func TestConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_Priority(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"server":{"address":"json:8080"},"app":{"name":"JSON Shortener"}}`), 0o600))
+
+	tests := []struct {
+		env         map[string]string
+		flags       map[string]string
+		wantAddress string
+		wantName    string
+		name        string
+	}{
+		{
+			name:        "when value is set in JSON only",
+			wantAddress: "json:8080",
+			wantName:    "JSON Shortener",
+		},
+		{
+			name:        "when env overrides JSON",
+			env:         map[string]string{"SERVER_ADDRESS": "env:8080", "APP_NAME": "Env Shortener"},
+			wantAddress: "env:8080",
+			wantName:    "Env Shortener",
+		},
+		{
+			name:        "when flag overrides env",
+			env:         map[string]string{"SERVER_ADDRESS": "env:8080"},
+			flags:       map[string]string{"a": "flag:8080"},
+			wantAddress: "flag:8080",
+			wantName:    "JSON Shortener",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commandLine := flag.CommandLine
+			t.Cleanup(func() {
+				flag.CommandLine = commandLine
+				cfgFile = ""
+			})
+
+			flag.CommandLine = flag.NewFlagSet("shortener", flag.ContinueOnError)
+			registerFlags(flag.CommandLine)
+			require.NoError(t, flag.CommandLine.Parse(nil))
+			require.NoError(t, flag.CommandLine.Set("c", path))
+
+			for name, value := range tt.flags {
+				require.NoError(t, flag.CommandLine.Set(name, value))
+			}
+
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			got, err := New()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAddress, got.Server.Address)
+			assert.Equal(t, tt.wantName, got.App.Name)
+		})
+	}
+}
+
+func TestConfig_InvalidTimeouts(t *testing.T) {
+	tests := []struct {
+		env     map[string]string
+		name    string
+		wantErr string
+	}{
+		{
+			name:    "when create URL timeout is zero",
+			env:     map[string]string{"SERVER_CREATE_URL_TIMEOUT": "0s"},
+			wantErr: "config error: SERVER_CREATE_URL_TIMEOUT must be positive, got 0s",
+		},
+		{
+			name:    "when batch URL timeout is negative",
+			env:     map[string]string{"SERVER_BATCH_URL_TIMEOUT": "-1s"},
+			wantErr: "config error: SERVER_BATCH_URL_TIMEOUT must be positive, got -1s",
+		},
+		{
+			name:    "when read URL timeout is zero",
+			env:     map[string]string{"SERVER_READ_URL_TIMEOUT": "0"},
+			wantErr: "config error: SERVER_READ_URL_TIMEOUT must be positive, got 0s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			got, err := New()
+			assert.Nil(t, got)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestConfig_PreferredEncoding(t *testing.T) {
+	t.Run("when preferred encoding is supported", func(t *testing.T) {
+		t.Setenv("SERVER_PREFERRED_ENCODING", "zstd")
+
+		got, err := New()
+		require.NoError(t, err)
+		assert.Equal(t, "zstd", got.Server.PreferredEncoding)
+	})
+
+	t.Run("when preferred encoding is not supported", func(t *testing.T) {
+		t.Setenv("SERVER_PREFERRED_ENCODING", "lzma")
+
+		got, err := New()
+		assert.Nil(t, got)
+		assert.EqualError(t, err, `config error: SERVER_PREFERRED_ENCODING must be one of gzip, brotli, zstd, auto, got "lzma"`)
+	})
+}
+
+func TestConfig_FileFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name: "when config file is JSON",
+			file: "config.json",
+			content: `{
+  "Server": {"Address": "file:8080", "ReadTimeout": 7000000000, "AllowedOrigins": ["https://a.ru", "https://b.ru"], "HTTPS": {"Enabled": true}},
+  "App": {"BaseURL": "https://sho.rt", "AliasLength": 8},
+  "Database": {"MaxConns": 20}
+}`,
+		},
+		{
+			name: "when config file is YAML",
+			file: "config.yaml",
+			content: `server:
+  server_address: file:8080
+  server_read_timeout: 7s
+  cors_allowed_origins: [https://a.ru, https://b.ru]
+  https:
+    enable_https: true
+app:
+  app_base_url: https://sho.rt
+  app_alias_length: 8
+database:
+  database_max_conns: 20
+`,
+		},
+		{
+			name: "when config file is YAML with .yml extension",
+			file: "config.yml",
+			content: `server: {server_address: "file:8080", server_read_timeout: 7s, cors_allowed_origins: [https://a.ru, https://b.ru], https: {enable_https: true}}
+app: {app_base_url: https://sho.rt, app_alias_length: 8}
+database: {database_max_conns: 20}
+`,
+		},
+		{
+			name: "when config file is TOML",
+			file: "config.toml",
+			content: `[server]
+server_address = "file:8080"
+server_read_timeout = "7s"
+cors_allowed_origins = ["https://a.ru", "https://b.ru"]
+
+[server.https]
+enable_https = true
+
+[app]
+app_base_url = "https://sho.rt"
+app_alias_length = 8
+
+[database]
+database_max_conns = 20
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			got, err := build(path, true)
+			require.NoError(t, err)
+
+			assert.Equal(t, "file:8080", got.Server.Address)
+			assert.Equal(t, 7*time.Second, got.Server.ReadTimeout)
+			assert.Equal(t, []string{"https://a.ru", "https://b.ru"}, got.Server.AllowedOrigins)
+			assert.True(t, got.Server.HTTPS.Enabled)
+			assert.Equal(t, "https://sho.rt", got.App.BaseURL)
+			assert.Equal(t, 8, got.App.AliasLength)
+			assert.Equal(t, int32(20), got.Database.MaxConns)
+
+			// Fields missing in the file keep default values
+			assert.Equal(t, 10*time.Second, got.Server.WriteTimeout)
+			assert.Equal(t, "Shortener", got.App.Name)
+		})
+	}
+}
+
+func TestConfig_UnsupportedFileFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.ini")
+	require.NoError(t, os.WriteFile(path, []byte("server_address = file:8080"), 0o600))
+
+	got, err := build(path, true)
+	assert.Nil(t, got)
+	assert.EqualError(t, err, `config error: unsupported config file extension ".ini", expected .json, .yaml, .yml or .toml`)
+}
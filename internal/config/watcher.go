@@ -0,0 +1,171 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDelay is how long the watcher waits for the burst of file events of a single save to end.
+const reloadDelay = 100 * time.Millisecond
+
+// Reloadable defines the interface for components applying settings changed at runtime.
+type Reloadable interface {
+	// Reload applies hot-reloadable settings of the configuration
+	Reload(cfg *Config) error
+}
+
+// ReloadFunc adapts an ordinary function to Reloadable.
+type ReloadFunc func(cfg *Config) error
+
+// Reload calls f(cfg).
+func (f ReloadFunc) Reload(cfg *Config) error {
+	return f(cfg)
+}
+
+// ConfigWatcher reloads settings which can be changed without restart when the config file changes:
+// - Log.Level
+// - App.DomainBlacklist and App.DomainWhitelist
+// - Server.MaxBodyBytes
+// - Analytics.MaxClicksPerSecond
+// Changes of other settings are logged as warnings and applied on the next restart only.
+// File system events of the file directory are watched, so files replaced by editors are followed too.
+// Environment variables and flags keep their priority over the file.
+type ConfigWatcher struct {
+	current     Config            // Configuration with the last applied settings
+	path        string            // Name of the config file
+	reloadables []Reloadable      // Components notified about changed settings
+	watcher     *fsnotify.Watcher // Watcher of the config file directory
+	delay       time.Duration     // Delay of reload after the last event of the file
+}
+
+// NewConfigWatcher creates a watcher of the config file.
+// The configuration is copied, so the one passed is never changed.
+// Parameters:
+// - path: Name of the config file
+// - cfg: Configuration the application is running with
+// Returns:
+// - *ConfigWatcher: Watcher ready to run
+// - error: If the directory of the file cannot be watched
+func NewConfigWatcher(path string, cfg *Config) (*ConfigWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	path = filepath.Clean(path)
+	if err = watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+
+	return &ConfigWatcher{current: *cfg, path: path, watcher: watcher, delay: reloadDelay}, nil
+}
+
+// Register adds components notified about changed settings.
+// Must be called before Run.
+// Parameters:
+// - reloadables: Components to notify
+func (w *ConfigWatcher) Register(reloadables ...Reloadable) {
+	w.reloadables = append(w.reloadables, reloadables...)
+}
+
+// Run reloads the configuration on changes of the file until ctx is cancelled.
+// The file system watcher is closed when Run returns.
+// Parameters:
+// - ctx: Context whose cancellation stops the watcher
+func (w *ConfigWatcher) Run(ctx context.Context) {
+	defer func() { _ = w.watcher.Close() }()
+
+	timer := time.NewTimer(w.delay)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if w.isChange(event) {
+				timer.Reset(w.delay)
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("Error watching config file %s: %s", w.path, err)
+		case <-timer.C:
+			if err := w.reload(); err != nil {
+				log.Printf("Error reloading config from %s file: %s", w.path, err)
+			}
+		}
+	}
+}
+
+// isChange reports whether the event changes content of the config file.
+// Parameters:
+// - event: File system event of the file directory
+// Returns:
+// - bool: true if the file is written, or created when an editor replaces it
+func (w *ConfigWatcher) isChange(event fsnotify.Event) bool {
+	return filepath.Clean(event.Name) == w.path && (event.Has(fsnotify.Write) || event.Has(fsnotify.Create))
+}
+
+// reload reads the file and applies its settings.
+// Returns:
+// - error: If the file cannot be read or parsed, or reloadables fail to apply settings
+func (w *ConfigWatcher) reload() error {
+	next, err := build(w.path, true)
+	if err != nil {
+		return err
+	}
+	if err = w.apply(next); err != nil {
+		return err
+	}
+	log.Printf("Configuration reloaded from %s file", w.path)
+	return nil
+}
+
+// apply copies hot-reloadable settings of the loaded configuration and notifies reloadables.
+// Parameters:
+// - next: Configuration loaded from the changed file
+// Returns:
+// - error: Joined errors of reloadables
+func (w *ConfigWatcher) apply(next *Config) error {
+	w.warnStructuralChanges(next)
+
+	w.current.Log.Level = next.Log.Level
+	w.current.App.DomainBlacklist = next.App.DomainBlacklist
+	w.current.App.DomainWhitelist = next.App.DomainWhitelist
+	w.current.Server.MaxBodyBytes = next.Server.MaxBodyBytes
+	w.current.Analytics.MaxClicksPerSecond = next.Analytics.MaxClicksPerSecond
+
+	var errs []error
+	for _, r := range w.reloadables {
+		cfg := w.current
+		errs = append(errs, r.Reload(&cfg))
+	}
+	return errors.Join(errs...)
+}
+
+// warnStructuralChanges logs settings which were changed but require restart.
+// Parameters:
+// - next: Configuration loaded from the changed file
+func (w *ConfigWatcher) warnStructuralChanges(next *Config) {
+	if next.Database.DSN != w.current.Database.DSN {
+		log.Print("Database DSN was changed in config file, restart is required to apply it")
+	}
+	if next.Server.Address != w.current.Server.Address {
+		log.Print("Server address was changed in config file, restart is required to apply it")
+	}
+	if next.Server.HTTPS != w.current.Server.HTTPS {
+		log.Print("HTTPS settings were changed in config file, restart is required to apply them")
+	}
+}
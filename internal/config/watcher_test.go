@@ -0,0 +1,194 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfigFile writes the JSON config file.
+func writeConfigFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+// runWatcher runs the watcher until the test ends.
+func runWatcher(t *testing.T, w *ConfigWatcher) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+// waitReload returns configuration passed to reloadables or fails the test if it is not reloaded in time.
+func waitReload(t *testing.T, reloaded <-chan *Config) *Config {
+	t.Helper()
+
+	select {
+	case cfg := <-reloaded:
+		return cfg
+	case <-time.After(2 * time.Second):
+		t.Fatal("config was not reloaded")
+		return nil
+	}
+}
+
+func TestConfigWatcher_Run(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeConfigFile(t, path, `{"log": {"level": "info"}}`)
+
+	cfg, err := build(path, true)
+	require.NoError(t, err)
+
+	reloaded := make(chan *Config, 1)
+	w, err := NewConfigWatcher(path, cfg)
+	require.NoError(t, err)
+	w.Register(ReloadFunc(func(cfg *Config) error {
+		reloaded <- cfg
+		return nil
+	}))
+	runWatcher(t, w)
+
+	writeConfigFile(t, path, `{
+		"log": {"level": "debug"},
+		"app": {"domainBlacklist": "evil.com"},
+		"server": {"address": "0.0.0.0:9090", "maxBodyBytes": 2048},
+		"database": {"dsn": "postgres://localhost/shortener"}
+	}`)
+
+	got := waitReload(t, reloaded)
+	assert.Equal(t, "debug", got.Log.Level)
+	assert.Equal(t, "evil.com", got.App.DomainBlacklist)
+	assert.Equal(t, int64(2048), got.Server.MaxBodyBytes)
+	assert.Equal(t, cfg.Server.Address, got.Server.Address, "structural change must not be applied")
+	assert.Equal(t, cfg.Database.DSN, got.Database.DSN, "structural change must not be applied")
+	assert.Equal(t, "info", cfg.Log.Level, "running configuration must not be changed")
+
+	t.Run("when file is replaced by editor", func(t *testing.T) {
+		tmp := filepath.Join(filepath.Dir(path), "config.json.tmp")
+		writeConfigFile(t, tmp, `{"log": {"level": "warn"}}`)
+		require.NoError(t, os.Rename(tmp, path))
+
+		assert.Equal(t, "warn", waitReload(t, reloaded).Log.Level)
+	})
+}
+
+func TestConfigWatcher_isChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	w, err := NewConfigWatcher(path, &Config{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = w.watcher.Close() })
+
+	tests := []struct {
+		name  string
+		event fsnotify.Event
+		want  bool
+	}{
+		{
+			name:  "when file is written",
+			event: fsnotify.Event{Name: path, Op: fsnotify.Write},
+			want:  true,
+		},
+		{
+			name:  "when file is created",
+			event: fsnotify.Event{Name: path, Op: fsnotify.Create},
+			want:  true,
+		},
+		{
+			name:  "when file permissions are changed",
+			event: fsnotify.Event{Name: path, Op: fsnotify.Chmod},
+		},
+		{
+			name:  "when file is removed",
+			event: fsnotify.Event{Name: path, Op: fsnotify.Remove},
+		},
+		{
+			name:  "when another file of directory is written",
+			event: fsnotify.Event{Name: filepath.Join(filepath.Dir(path), "other.json"), Op: fsnotify.Write},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, w.isChange(tt.event))
+		})
+	}
+}
+
+func TestConfigWatcher_reload(t *testing.T) {
+	errReload := errors.New("cannot reload")
+
+	tests := []struct {
+		wantErr   error
+		name      string
+		reloadErr error
+	}{
+		{
+			name: "when file is modified",
+		},
+		{
+			name:      "when reloadable fails",
+			reloadErr: errReload,
+			wantErr:   errReload,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.json")
+			writeConfigFile(t, path, `{"log": {"level": "info"}}`)
+
+			cfg, err := build(path, true)
+			require.NoError(t, err)
+
+			var calls []*Config
+			w, err := NewConfigWatcher(path, cfg)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = w.watcher.Close() })
+			w.Register(ReloadFunc(func(cfg *Config) error {
+				calls = append(calls, cfg)
+				return tt.reloadErr
+			}))
+
+			writeConfigFile(t, path, `{"log": {"level": "warn"}}`)
+
+			assert.ErrorIs(t, w.reload(), tt.wantErr)
+			require.Len(t, calls, 1)
+			assert.Equal(t, "warn", calls[0].Log.Level)
+		})
+	}
+
+	t.Run("when file is invalid", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		writeConfigFile(t, path, `{}`)
+
+		w, err := NewConfigWatcher(path, &Config{})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = w.watcher.Close() })
+		w.Register(ReloadFunc(func(*Config) error {
+			t.Error("reloadable is called for invalid file")
+			return nil
+		}))
+		writeConfigFile(t, path, `{"log":`)
+
+		assert.Error(t, w.reload())
+	})
+
+	t.Run("when directory of file doesn't exist", func(t *testing.T) {
+		_, err := NewConfigWatcher(filepath.Join(t.TempDir(), "missing", "config.json"), &Config{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,166 @@
+// Package entity defines the core domain models for the application.
+// These models represent the fundamental business entities and their relationships.
+package entity
+
+import (
+	"net"
+	"sort"
+	"time"
+)
+
+// Number of leading bits kept by AnonymizeIP.
+const (
+	anonymizedIPv4Bits = 24  // The last octet is zeroed
+	anonymizedIPv6Bits = 48  // The last 80 bits are zeroed
+	ipv4Bits           = 32  // Length of IPv4 address
+	ipv6Bits           = 128 // Length of IPv6 address
+)
+
+// Granularities of click time series, named as DATE_TRUNC fields of PostgreSQL.
+const (
+	GranularityHour  = "hour"
+	GranularityDay   = "day"
+	GranularityWeek  = "week"
+	GranularityMonth = "month"
+)
+
+// Click represents a served redirect of a short URL.
+// Client IP and User-Agent are stored hashed only, location is resolved before hashing.
+// AnonymizedIP keeps the network part of client IP only, see AnonymizeIP.
+// Namespace is the namespace of the clicked short URL, empty for the default one.
+type Click struct {
+	ClickedAt     time.Time
+	Alias         string
+	Namespace     string
+	IPHash        string
+	AnonymizedIP  string
+	UserAgentHash string
+	Location      GeoLocation
+}
+
+// GeoLocation is the location of a client resolved by its IP.
+// Fields are empty if the location is unknown.
+type GeoLocation struct {
+	Country string // ISO 3166-1 alpha-2 country code
+	City    string // City name in English
+	Lat     string // Latitude in decimal degrees
+	Lon     string // Longitude in decimal degrees
+}
+
+// LocationCount is the number of clicks from a city.
+// Clicks of unknown location are counted with empty country and city.
+type LocationCount struct {
+	Country string `json:"country"`
+	City    string `json:"city"`
+	Clicks  int64  `json:"clicks"`
+}
+
+// GeoBreakdown represents clicks of a short URL grouped by locations.
+type GeoBreakdown struct {
+	Alias string          `json:"alias"`
+	Data  []LocationCount `json:"data"`
+	Total int64           `json:"total"`
+}
+
+// ClickCount is the number of clicks in the period starting at PeriodStart.
+type ClickCount struct {
+	PeriodStart time.Time
+	Clicks      int64
+}
+
+// DataPoint is the number of clicks in a period of time series.
+type DataPoint struct {
+	Period string `json:"period"`
+	Clicks int64  `json:"clicks"`
+}
+
+// TimeSeries represents clicks of a short URL grouped by periods.
+// Periods without clicks are omitted from Data.
+type TimeSeries struct {
+	Alias string      `json:"alias"`
+	Data  []DataPoint `json:"data"`
+	Total int64       `json:"total"`
+}
+
+// SortLocationCounts orders counts by clicks descending, then by country and city.
+// Parameters:
+// - counts: Counts to sort in place
+func SortLocationCounts(counts []LocationCount) {
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Clicks != counts[j].Clicks {
+			return counts[i].Clicks > counts[j].Clicks
+		}
+		if counts[i].Country != counts[j].Country {
+			return counts[i].Country < counts[j].Country
+		}
+		return counts[i].City < counts[j].City
+	})
+}
+
+// IsValidGranularity reports whether time series can be grouped by the granularity.
+// Parameters:
+// - granularity: Granularity name
+// Returns:
+// - bool: true for hour, day, week and month
+func IsValidGranularity(granularity string) bool {
+	switch granularity {
+	case GranularityHour, GranularityDay, GranularityWeek, GranularityMonth:
+		return true
+	}
+	return false
+}
+
+// TruncateTime returns the start of the period containing t in UTC.
+// Weeks start on Monday, like in DATE_TRUNC of PostgreSQL.
+// Parameters:
+// - t: Time to truncate
+// - granularity: Granularity name
+// Returns:
+// - time.Time: Period start in UTC, t in UTC for unknown granularity
+func TruncateTime(t time.Time, granularity string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch granularity {
+	case GranularityHour:
+		return t.Truncate(time.Hour)
+	case GranularityDay:
+		return day
+	case GranularityWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case GranularityMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return t
+}
+
+// FormatPeriod formats the period start for time series output.
+// Parameters:
+// - start: Period start in UTC
+// - granularity: Granularity name
+// Returns:
+// - string: RFC 3339 time for hours, date otherwise
+func FormatPeriod(start time.Time, granularity string) string {
+	if granularity == GranularityHour {
+		return start.UTC().Format(time.RFC3339)
+	}
+	return start.UTC().Format(time.DateOnly)
+}
+
+// AnonymizeIP zeroes the host part of an IP so it no longer identifies a client.
+// The last octet of IPv4 and the last 80 bits of IPv6 addresses are zeroed.
+// Parameters:
+// - ip: Client IP
+// Returns:
+// - string: Anonymized IP, empty if ip is not a valid IP
+func AnonymizeIP(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ""
+	}
+
+	if v4 := parsed.To4(); v4 != nil {
+		return v4.Mask(net.CIDRMask(anonymizedIPv4Bits, ipv4Bits)).String()
+	}
+	return parsed.Mask(net.CIDRMask(anonymizedIPv6Bits, ipv6Bits)).String()
+}
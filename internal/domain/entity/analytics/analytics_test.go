@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AnonymizeIP(t *testing.T) {
+	tests := []struct {
+		name string
+		ip   string
+		want string
+	}{
+		{name: "when IP is IPv4", ip: "192.168.17.254", want: "192.168.17.0"},
+		{name: "when IPv4 has zero last octet", ip: "10.0.0.0", want: "10.0.0.0"},
+		{name: "when IP is IPv4-mapped IPv6", ip: "::ffff:203.0.113.77", want: "203.0.113.0"},
+		{name: "when IP is IPv6", ip: "2001:db8:85a3:8d3:1319:8a2e:370:7348", want: "2001:db8:85a3::"},
+		{name: "when IPv6 is loopback", ip: "::1", want: "::"},
+		{name: "when IP is invalid", ip: "not-an-ip"},
+		{name: "when IP is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, AnonymizeIP(tt.ip))
+		})
+	}
+}
@@ -0,0 +1,20 @@
+// Package entity defines the core domain models for the application.
+// These models represent the fundamental business entities and their relationships.
+package entity
+
+// DBPoolStats represents connection pool statistics of a database.
+// Only databases working over a connection pool provide them.
+type DBPoolStats struct {
+	MaxConnections      int32 // Maximum size of the pool
+	OpenConnections     int32 // Number of connections currently open, both idle and in use
+	IdleConnections     int32 // Number of idle connections
+	AcquiredConnections int32 // Number of connections currently in use
+	WaitCount           int64 // Number of acquires which had to wait for a free connection
+}
+
+// DBReplicaHealth represents health of a read replica of a database.
+// Only databases with a configured read replica provide it.
+type DBReplicaHealth struct {
+	Available bool         // Whether the replica answers, reads fall back to the primary otherwise
+	Pool      *DBPoolStats // Connection pool statistics of the replica
+}
@@ -0,0 +1,29 @@
+// Package entity defines the core domain models for the application.
+// These models represent the fundamental business entities and their relationships.
+package entity
+
+import "time"
+
+// Default is the namespace of short URLs created without namespace.
+// It always exists and cannot be deleted.
+const Default = "default"
+
+// Namespace represents an isolated set of short URL aliases, e.g. of one team
+// sharing the instance with others. The same alias may lead to different
+// original URLs in different namespaces.
+type Namespace struct {
+	CreatedAt time.Time `json:"created_at"` // Creation time, set by database on save
+	Name      string    `json:"name"`       // Name used as the first segment of short URL path
+}
+
+// OrDefault returns the namespace name, Default for empty name.
+// Parameters:
+// - name: Namespace name, empty if not set
+// Returns:
+// - string: Name of the namespace
+func OrDefault(name string) string {
+	if name == "" {
+		return Default
+	}
+	return name
+}
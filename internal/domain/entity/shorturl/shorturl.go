@@ -11,7 +11,12 @@ It includes:
 */
 package entity
 
-import userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+import (
+	"time"
+
+	namespaceEntity "github.com/gururuby/shortener/internal/domain/entity/namespace"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+)
 
 // Generator defines the interface for generating unique identifiers and URL aliases.
 // Implementations should ensure generated values are sufficiently unique.
@@ -26,14 +31,161 @@ type Generator interface {
 	Alias() (string, error)
 }
 
+// Visibility values of short URLs.
+const (
+	VisibilityPublic  = "public"  // Redirect is available to everyone
+	VisibilityPrivate = "private" // Redirect is available to the owner only
+)
+
+// Redirect types of short URLs, i.e. HTTP status codes of redirect responses.
+const (
+	RedirectPermanent = 301 // Moved Permanently, clients may cache the redirect
+	RedirectTemporary = 307 // Temporary Redirect, default
+)
+
 // ShortURL represents a shortened URL entity in the system.
 // It tracks the relationship between original URLs and their shortened versions.
 type ShortURL struct {
-	UUID      string
-	SourceURL string
-	Alias     string
-	UserID    int
-	IsDeleted bool
+	ID              int // Sequential identifier used as pagination cursor
+	UUID            string
+	SourceURL       string
+	NormalizedURL   string // Normalized form of SourceURL used as deduplication key
+	Alias           string
+	UserID          int
+	IsDeleted       bool
+	IsOneTimeUse    bool      // Short URL is deleted after the first successful redirect
+	Visibility      string    // VisibilityPublic or VisibilityPrivate, empty means public
+	RedirectType    int       // RedirectPermanent or RedirectTemporary, zero means temporary
+	CreatedAt       time.Time // Creation time, set by database on save
+	CreatedInRegion string    // Region of the instance which created the short URL, empty if unknown
+	ExpiresAt       time.Time // Expiration time, zero if the short URL never expires
+	Namespace       string    // Namespace scoping the alias, empty means the default namespace
+	IsTracked       bool      // Redirect is served as tracking page, the click is recorded when its pixel loads
+	IsHealthy       bool      // Source URL was reachable on the last health check, meaningful only if CheckedAt is set
+	CheckedAt       time.Time // Time of the last health check of the source URL, zero if never checked
+}
+
+// DeduplicationKey returns the value used to detect duplicate short URLs.
+// Returns:
+// - string: NormalizedURL, or SourceURL if the URL was not normalized
+func (s *ShortURL) DeduplicationKey() string {
+	if s.NormalizedURL != "" {
+		return s.NormalizedURL
+	}
+	return s.SourceURL
+}
+
+// IsPrivate reports whether the short URL is available to its owner only.
+func (s *ShortURL) IsPrivate() bool {
+	return s.Visibility == VisibilityPrivate
+}
+
+// IsAccessibleBy reports whether the user may follow the short URL.
+// Public URLs are accessible by everyone, private ones by the owner only.
+// Parameters:
+// - user: The requesting user (can be nil for anonymous)
+// Returns:
+// - bool: true if the user may follow the short URL
+func (s *ShortURL) IsAccessibleBy(user *userEntity.User) bool {
+	if !s.IsPrivate() {
+		return true
+	}
+	return user != nil && user.ID != 0 && user.ID == s.UserID
+}
+
+// IsPermanent reports whether the short URL redirects with 301 Moved Permanently.
+func (s *ShortURL) IsPermanent() bool {
+	return s.RedirectType == RedirectPermanent
+}
+
+// RedirectStatus returns HTTP status code of the redirect to the source URL.
+// Returns:
+// - int: RedirectPermanent for permanent URLs, RedirectTemporary otherwise
+func (s *ShortURL) RedirectStatus() int {
+	if s.IsPermanent() {
+		return RedirectPermanent
+	}
+	return RedirectTemporary
+}
+
+// IsDeduplicated reports whether the short URL may be reused for the same source URL.
+// One-time, private, permanent and tracked URLs always get their own alias.
+func (s *ShortURL) IsDeduplicated() bool {
+	return !s.IsOneTimeUse && !s.IsPrivate() && !s.IsPermanent() && !s.IsTracked
+}
+
+// IsExpired reports whether the short URL has expired by the given time.
+// Short URLs without expiration time never expire.
+// Parameters:
+// - now: Time to check expiration at
+// Returns:
+// - bool: true if ExpiresAt is set and is before now
+func (s *ShortURL) IsExpired(now time.Time) bool {
+	return !s.ExpiresAt.IsZero() && s.ExpiresAt.Before(now)
+}
+
+// IsSourceHealthy reports whether the source URL is considered reachable.
+// Source URLs which were never checked are considered healthy.
+func (s *ShortURL) IsSourceHealthy() bool {
+	return s.CheckedAt.IsZero() || s.IsHealthy
+}
+
+// Path returns the path of the short URL relative to the base URL.
+// Short URLs of the default namespace are served by alias, others are prefixed with the namespace.
+// Returns:
+// - string: Alias or namespace and alias separated by slash
+func (s *ShortURL) Path() string {
+	if s.Namespace == "" || s.Namespace == namespaceEntity.Default {
+		return s.Alias
+	}
+	return s.Namespace + "/" + s.Alias
+}
+
+// CreateOptions defines optional properties of a created short URL.
+// The zero value creates a public deduplicated short URL redirecting with 307.
+type CreateOptions struct {
+	ExpiresAt    time.Time // Expiration time, zero if the short URL never expires
+	Alias        string    // Custom alias, generated if empty
+	Visibility   string    // VisibilityPublic or VisibilityPrivate, empty means public
+	RedirectType int       // RedirectPermanent or RedirectTemporary, zero means temporary
+	OneTimeUse   bool      // Short URL is deleted after the first successful redirect
+	Tracked      bool      // Redirect is served as tracking page
+}
+
+// Apply sets the options on the short URL.
+// Parameters:
+// - s: Short URL being created
+func (o CreateOptions) Apply(s *ShortURL) {
+	s.IsOneTimeUse = o.OneTimeUse
+	s.Visibility = o.Visibility
+	s.RedirectType = o.RedirectType
+	s.IsTracked = o.Tracked
+	s.ExpiresAt = o.ExpiresAt
+	if o.Alias != "" {
+		s.Alias = o.Alias
+	}
+}
+
+// HistoryEntry represents a previous original URL of a short URL.
+// Entries are saved when the owner changes the original URL.
+type HistoryEntry struct {
+	ChangedAt   time.Time `json:"changed_at"`   // Time the original URL was replaced
+	OriginalURL string    `json:"original_url"` // Replaced original URL
+}
+
+// ArchivedURL represents a deleted short URL moved out of the table of active short URLs.
+// Short URLs are archived some time after deletion and may be restored by the owner.
+type ArchivedURL struct {
+	ShortURL   *ShortURL // Archived short URL, IsDeleted is always true
+	ArchivedAt time.Time // Time the short URL was archived
+}
+
+// ShortURLCreatedEvent represents a notification about a newly created short URL
+// streamed to connected clients of its owner.
+type ShortURLCreatedEvent struct {
+	Alias       string    `json:"alias"`        // Alias of the created short URL
+	OriginalURL string    `json:"original_url"` // URL which was shortened
+	CreatedAt   time.Time `json:"created_at"`   // Creation time of the short URL
 }
 
 // BatchShortURLInput represents the input structure for batch URL shortening operations.
@@ -41,20 +193,45 @@ type ShortURL struct {
 type BatchShortURLInput struct {
 	CorrelationID string `json:"correlation_id"` // Client-provided ID for matching requests to responses
 	OriginalURL   string `json:"original_url"`   // URL to be shortened
+	NormalizedURL string `json:"-"`              // Normalized form of OriginalURL, filled by use case
 }
 
 // BatchShortURLOutput represents the output structure for batch URL shortening operations.
 // Contains the results of creating multiple short URLs.
 type BatchShortURLOutput struct {
-	CorrelationID string `json:"correlation_id"` // Echoes the client-provided correlation ID
-	ShortURL      string `json:"short_url"`      // Generated shortened URL
+	CorrelationID string `json:"correlation_id"`  // Echoes the client-provided correlation ID
+	ShortURL      string `json:"short_url"`       // Generated shortened URL, empty on failure
+	Error         string `json:"error,omitempty"` // Reason the URL was not shortened
+}
+
+// BatchValidationResult represents the result of checking a URL of a batch without shortening it.
+type BatchValidationResult struct {
+	CorrelationID string `json:"correlation_id"`   // Echoes the client-provided correlation ID
+	Reason        string `json:"reason,omitempty"` // Reason the URL cannot be shortened, empty if it is valid
+	Valid         bool   `json:"valid"`            // Whether the URL may be shortened
+}
+
+// URLQuota represents usage of the limit of short URLs a user may own.
+// Limit and Remaining are 0 if the number of URLs is not limited.
+type URLQuota struct {
+	Limit     int64 `json:"limit"`     // Maximum number of not deleted short URLs
+	Used      int64 `json:"used"`      // Number of not deleted short URLs of the user
+	Remaining int64 `json:"remaining"` // Number of short URLs the user may still create
+}
+
+// BatchSaveResult represents the result of saving a batch of short URLs.
+// On failure it lists the URLs inserted before the failing one; such inserts are rolled back
+// when the database supports transactions.
+type BatchSaveResult struct {
+	SucceededIDs []string    // Correlation IDs of saved URLs in input order
+	ShortURLs    []*ShortURL // Saved short URLs, matching SucceededIDs by index
 }
 
 // NewShortURL creates and initializes a new ShortURL entity.
 //
 // Parameters:
 // - g: Generator implementation for creating IDs and aliases
-// - user: User entity creating the short URL (can be nil for anonymous)
+// - user: User entity creating the short URL in its namespace (can be nil for anonymous)
 // - sourceURL: Original URL to be shortened
 //
 // Returns:
@@ -73,6 +250,7 @@ func NewShortURL(g Generator, user *userEntity.User, sourceURL string) (*ShortUR
 
 	if user != nil {
 		shortURL.UserID = user.ID
+		shortURL.Namespace = user.Namespace
 	}
 	return shortURL, err
 }
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/gururuby/shortener/internal/domain/entity/shorturl/mocks"
 	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
@@ -19,11 +20,12 @@ func Test_NewShortURL_OK(t *testing.T) {
 		generator.EXPECT().UUID().Return("UUID").Times(1)
 		generator.EXPECT().Alias().Return("alias", nil).Times(1)
 
-		user := &userEntity.User{ID: 1}
+		user := &userEntity.User{ID: 1, Namespace: "team-a"}
 		got, _ := NewShortURL(generator, user, sourceURL)
 
 		assert.Equal(t, got.SourceURL, sourceURL)
 		assert.Equal(t, got.UserID, 1)
+		assert.Equal(t, "team-a", got.Namespace)
 		assert.Equal(t, got.IsDeleted, false)
 		assert.Equal(t, "UUID", got.UUID)
 		assert.Equal(t, "alias", got.Alias)
@@ -35,7 +37,7 @@ func Test_NewShortURL_Errors(t *testing.T) {
 		sourceURL := "https://ya.ru"
 		ctrl := gomock.NewController(t)
 		generator := mocks.NewMockGenerator(ctrl)
-		generator.EXPECT().Alias().Return("", errors.ErrGeneratorEmptyAliasLength).Times(1)
+		generator.EXPECT().Alias().Return("", errors.ErrGeneratorInvalidLength).Times(1)
 
 		user := &userEntity.User{ID: 1}
 		_, err := NewShortURL(generator, user, sourceURL)
@@ -43,3 +45,182 @@ func Test_NewShortURL_Errors(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func Test_ShortURL_IsAccessibleBy(t *testing.T) {
+	owner := &userEntity.User{ID: 1}
+
+	tests := []struct {
+		user       *userEntity.User
+		name       string
+		visibility string
+		want       bool
+	}{
+		{
+			name:       "when URL is public and user is anonymous",
+			visibility: VisibilityPublic,
+			want:       true,
+		},
+		{
+			name: "when URL visibility is not set",
+			user: &userEntity.User{ID: 2},
+			want: true,
+		},
+		{
+			name:       "when URL is private and user is owner",
+			visibility: VisibilityPrivate,
+			user:       owner,
+			want:       true,
+		},
+		{
+			name:       "when URL is private and user is not owner",
+			visibility: VisibilityPrivate,
+			user:       &userEntity.User{ID: 2},
+			want:       false,
+		},
+		{
+			name:       "when URL is private and user is anonymous",
+			visibility: VisibilityPrivate,
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shortURL := &ShortURL{UserID: owner.ID, Visibility: tt.visibility}
+			assert.Equal(t, tt.want, shortURL.IsAccessibleBy(tt.user))
+		})
+	}
+}
+
+func Test_ShortURL_RedirectStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		redirectType int
+		want         int
+		deduplicated bool
+		tracked      bool
+	}{
+		{
+			name:         "when redirect type is not set",
+			want:         RedirectTemporary,
+			deduplicated: true,
+		},
+		{
+			name:         "when URL is temporary",
+			redirectType: RedirectTemporary,
+			want:         RedirectTemporary,
+			deduplicated: true,
+		},
+		{
+			name:         "when URL is permanent",
+			redirectType: RedirectPermanent,
+			want:         RedirectPermanent,
+		},
+		{
+			name:    "when URL is tracked",
+			tracked: true,
+			want:    RedirectTemporary,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shortURL := &ShortURL{RedirectType: tt.redirectType, IsTracked: tt.tracked}
+			assert.Equal(t, tt.want, shortURL.RedirectStatus())
+			assert.Equal(t, tt.deduplicated, shortURL.IsDeduplicated())
+		})
+	}
+}
+
+func Test_ShortURL_IsExpired(t *testing.T) {
+	now := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		want      bool
+	}{
+		{
+			name: "when expiration time is not set",
+		},
+		{
+			name:      "when expiration time is in the future",
+			expiresAt: now.Add(time.Minute),
+		},
+		{
+			name:      "when expiration time is in the past",
+			expiresAt: now.Add(-time.Minute),
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shortURL := &ShortURL{ExpiresAt: tt.expiresAt}
+			assert.Equal(t, tt.want, shortURL.IsExpired(now))
+		})
+	}
+}
+
+func Test_ShortURL_IsSourceHealthy(t *testing.T) {
+	checkedAt := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		checkedAt time.Time
+		name      string
+		isHealthy bool
+		want      bool
+	}{
+		{
+			name: "when source URL was never checked",
+			want: true,
+		},
+		{
+			name:      "when source URL was reachable",
+			checkedAt: checkedAt,
+			isHealthy: true,
+			want:      true,
+		},
+		{
+			name:      "when source URL was unreachable",
+			checkedAt: checkedAt,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shortURL := &ShortURL{IsHealthy: tt.isHealthy, CheckedAt: tt.checkedAt}
+			assert.Equal(t, tt.want, shortURL.IsSourceHealthy())
+		})
+	}
+}
+
+func Test_ShortURL_Path(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		want      string
+	}{
+		{
+			name: "when namespace is not set",
+			want: "abc",
+		},
+		{
+			name:      "when namespace is default",
+			namespace: "default",
+			want:      "abc",
+		},
+		{
+			name:      "when namespace is custom",
+			namespace: "team-a",
+			want:      "team-a/abc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shortURL := &ShortURL{Alias: "abc", Namespace: tt.namespace}
+			assert.Equal(t, tt.want, shortURL.Path())
+		})
+	}
+}
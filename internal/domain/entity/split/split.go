@@ -0,0 +1,36 @@
+// Package entity defines the core domain models for the application.
+// These models represent the fundamental business entities and their relationships.
+package entity
+
+import namespaceEntity "github.com/gururuby/shortener/internal/domain/entity/namespace"
+
+// TotalWeight is the sum of weights of all destinations of a split.
+const TotalWeight = 100
+
+// Destination represents one of original URLs a split short URL routes traffic to.
+type Destination struct {
+	URL    string `json:"url"`    // Original URL
+	Weight int    `json:"weight"` // Percentage of traffic routed to the URL
+	Clicks int64  `json:"clicks"` // Number of redirects to the URL
+}
+
+// MultiDestination represents a short URL splitting traffic between several original URLs,
+// e.g. for A/B testing. Its alias never coincides with an alias of a regular short URL
+// of the same namespace. Namespace is empty for the default namespace.
+type MultiDestination struct {
+	Alias        string        `json:"alias"`
+	Namespace    string        `json:"-"`
+	Destinations []Destination `json:"destinations"`
+	UserID       int           `json:"-"`
+}
+
+// Path returns the path of the split short URL relative to the base URL.
+// Splits of the default namespace are served by alias, others are prefixed with the namespace.
+// Returns:
+// - string: Alias or namespace and alias separated by slash
+func (s *MultiDestination) Path() string {
+	if s.Namespace == "" || s.Namespace == namespaceEntity.Default {
+		return s.Alias
+	}
+	return s.Namespace + "/" + s.Alias
+}
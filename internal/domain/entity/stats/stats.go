@@ -0,0 +1,12 @@
+// Package entity defines the core domain models for the application.
+// These models represent the fundamental business entities and their relationships.
+package entity
+
+// Stats represents aggregated service statistics.
+// Deleted URLs are included into URLsCount, ActiveURLsCount excludes them.
+type Stats struct {
+	URLsCount        int64 `json:"urls"`
+	UsersCount       int64 `json:"users"`
+	DeletedURLsCount int64 `json:"deleted_urls"`
+	ActiveURLsCount  int64 `json:"active_urls"`
+}
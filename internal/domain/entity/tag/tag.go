@@ -0,0 +1,11 @@
+// Package entity defines the core domain models for the application.
+// These models represent the fundamental business entities and their relationships.
+package entity
+
+// Tag represents a user-defined label used to organize short URLs.
+// Tag names are unique per user.
+type Tag struct {
+	Name   string `json:"name"`
+	ID     int    `json:"id"`
+	UserID int    `json:"-"`
+}
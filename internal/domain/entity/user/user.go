@@ -2,9 +2,35 @@
 // These models represent the fundamental business entities and their relationships.
 package entity
 
+import "time"
+
 // User represents an application user in the system.
 // It contains the basic authentication information and identifier.
+// AuthToken is set for users authenticated with JWT, APIKey for users
+// authenticated with API key. Namespace is the namespace of short URLs
+// created by the user within the current request, empty for the configured default.
+// Deactivated users (IsActive is false) cannot authenticate, their short URLs keep redirecting.
+// CreatedAt is the registration time, zero if it is unknown.
+// CustomDomain is the base URL of short URLs of the user, e.g. https://short.acme.com,
+// empty to use the configured one.
 type User struct {
-	AuthToken string
-	ID        int
+	CreatedAt    time.Time
+	AuthToken    string
+	APIKey       string
+	Namespace    string
+	CustomDomain string
+	ID           int
+	IsActive     bool
+}
+
+// BaseURL returns the base URL of short URLs of the user.
+// Parameters:
+// - defaultBaseURL: Configured base URL
+// Returns:
+// - string: CustomDomain of the user if set, defaultBaseURL for users without it and anonymous users
+func (u *User) BaseURL(defaultBaseURL string) string {
+	if u == nil || u.CustomDomain == "" {
+		return defaultBaseURL
+	}
+	return u.CustomDomain
 }
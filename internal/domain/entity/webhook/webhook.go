@@ -0,0 +1,45 @@
+// Package entity defines the core domain models for the application.
+// These models represent the fundamental business entities and their relationships.
+package entity
+
+// Events of short URL lifecycle delivered to webhooks.
+const (
+	EventURLCreated = "url.created" // Short URL is created
+	EventURLDeleted = "url.deleted" // Short URLs are deleted by their owner
+	EventURLClicked = "url.clicked" // Short URL redirect is served
+)
+
+// Webhook represents a user callback notified about lifecycle events of user's short URLs.
+// Payloads are signed with Secret, so the receiver can verify their origin.
+type Webhook struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+	ID     int      `json:"id"`
+	UserID int      `json:"-"`
+}
+
+// URLEventData is the data of url.created and url.clicked events.
+type URLEventData struct {
+	ShortURL    string `json:"short_url"`
+	OriginalURL string `json:"original_url"`
+}
+
+// URLsDeletedData is the data of url.deleted event.
+type URLsDeletedData struct {
+	Aliases []string `json:"aliases"`
+}
+
+// IsSubscribed reports whether the webhook is notified about the event.
+// Parameters:
+// - event: Event name
+// Returns:
+// - bool: true if event is one of webhook events
+func (w *Webhook) IsSubscribed(event string) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
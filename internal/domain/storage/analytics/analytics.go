@@ -0,0 +1,105 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . DB
+
+/*
+Package storage provides data persistence implementations for short URL analytics.
+
+It includes:
+- Database interface for clicks
+- Storage layer implementation
+*/
+package storage
+
+import (
+	"context"
+	"time"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+)
+
+// DB defines the interface for click database operations.
+type DB interface {
+	// SaveClick stores a served redirect of a short URL.
+	// Returns:
+	// - error: If database operation fails
+	SaveClick(ctx context.Context, click *entity.Click) error
+
+	// CountClicks counts clicks of a short URL in [from, to) grouped by periods of the granularity in UTC.
+	// Returns:
+	// - []entity.ClickCount: Non-zero counts ordered by period start
+	// - error: If database operation fails
+	CountClicks(ctx context.Context, namespace, alias string, from, to time.Time, granularity string) ([]entity.ClickCount, error)
+
+	// CountClicksByLocation counts clicks of a short URL in [from, to) grouped by country and city.
+	// Returns:
+	// - []entity.LocationCount: Non-zero counts ordered by clicks descending
+	// - error: If database operation fails
+	CountClicksByLocation(ctx context.Context, namespace, alias string, from, to time.Time) ([]entity.LocationCount, error)
+}
+
+// AnalyticsStorage implements the storage layer for analytics operations.
+// It acts as an intermediary between the domain and database layers.
+type AnalyticsStorage struct {
+	db DB // Database interface implementation
+}
+
+// Setup creates and initializes a new AnalyticsStorage instance.
+// Parameters:
+// - db: The database implementation to use
+// Returns:
+// - *AnalyticsStorage: Initialized storage instance
+func Setup(db DB) *AnalyticsStorage {
+	return &AnalyticsStorage{db: db}
+}
+
+// Record stores a click of a short URL.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - namespace: Namespace of the clicked short URL, empty for the default one
+// - alias: Clicked short URL alias
+// - ipHash: Hash of client IP
+// - anonymizedIP: Client IP with zeroed host part
+// - userAgentHash: Hash of client User-Agent
+// - location: Location of the client, empty if unknown
+// - clickedAt: Time of the click
+// Returns:
+// - error: If operation fails
+func (s *AnalyticsStorage) Record(ctx context.Context, namespace, alias, ipHash, anonymizedIP, userAgentHash string, location entity.GeoLocation, clickedAt time.Time) error {
+	return s.db.SaveClick(ctx, &entity.Click{
+		Alias:         alias,
+		Namespace:     namespace,
+		IPHash:        ipHash,
+		AnonymizedIP:  anonymizedIP,
+		UserAgentHash: userAgentHash,
+		Location:      location,
+		ClickedAt:     clickedAt,
+	})
+}
+
+// CountClicks counts clicks of a short URL in [from, to) grouped by periods of the granularity.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL alias
+// - from: Start of the time range, inclusive
+// - to: End of the time range, exclusive
+// - granularity: Period length, one of hour, day, week or month
+// Returns:
+// - []entity.ClickCount: Non-zero counts ordered by period start
+// - error: If operation fails
+func (s *AnalyticsStorage) CountClicks(ctx context.Context, namespace, alias string, from, to time.Time, granularity string) ([]entity.ClickCount, error) {
+	return s.db.CountClicks(ctx, namespace, alias, from, to, granularity)
+}
+
+// CountClicksByLocation counts clicks of a short URL in [from, to) grouped by country and city.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL alias
+// - from: Start of the time range, inclusive
+// - to: End of the time range, exclusive
+// Returns:
+// - []entity.LocationCount: Non-zero counts ordered by clicks descending
+// - error: If operation fails
+func (s *AnalyticsStorage) CountClicksByLocation(ctx context.Context, namespace, alias string, from, to time.Time) ([]entity.LocationCount, error) {
+	return s.db.CountClicksByLocation(ctx, namespace, alias, from, to)
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	storageMock "github.com/gururuby/shortener/internal/domain/storage/analytics/mocks"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Storage_Analytics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := storageMock.NewMockDB(ctrl)
+	ctx := context.Background()
+	clickedAt := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	storage := Setup(db)
+
+	t.Run("when calls are passed to db", func(t *testing.T) {
+		counts := []entity.ClickCount{{PeriodStart: from, Clicks: 2}}
+		location := entity.GeoLocation{Country: "GB", City: "London", Lat: "51.5142", Lon: "-0.0931"}
+		locationCounts := []entity.LocationCount{{Country: "GB", City: "London", Clicks: 2}}
+
+		db.EXPECT().SaveClick(ctx, &entity.Click{Namespace: "team", Alias: "abc", IPHash: "ip", AnonymizedIP: "127.0.0.0", UserAgentHash: "ua", Location: location, ClickedAt: clickedAt}).Return(nil)
+		db.EXPECT().CountClicks(ctx, "team", "abc", from, to, entity.GranularityDay).Return(counts, nil)
+		db.EXPECT().CountClicksByLocation(ctx, "team", "abc", from, to).Return(locationCounts, nil)
+
+		require.NoError(t, storage.Record(ctx, "team", "abc", "ip", "127.0.0.0", "ua", location, clickedAt))
+
+		res, err := storage.CountClicks(ctx, "team", "abc", from, to, entity.GranularityDay)
+		require.NoError(t, err)
+		require.Equal(t, counts, res)
+
+		byLocation, err := storage.CountClicksByLocation(ctx, "team", "abc", from, to)
+		require.NoError(t, err)
+		require.Equal(t, locationCounts, byLocation)
+	})
+
+	t.Run("when db query fails", func(t *testing.T) {
+		db.EXPECT().SaveClick(ctx, gomock.Any()).Return(dbErrors.ErrDBQuery)
+
+		err := storage.Record(ctx, "team", "abc", "ip", "127.0.0.0", "ua", entity.GeoLocation{}, clickedAt)
+		require.ErrorIs(t, err, dbErrors.ErrDBQuery)
+	})
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gururuby/shortener/internal/domain/storage/analytics (interfaces: DB)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock.go -package=mocks . DB
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDB is a mock of DB interface.
+type MockDB struct {
+	ctrl     *gomock.Controller
+	recorder *MockDBMockRecorder
+	isgomock struct{}
+}
+
+// MockDBMockRecorder is the mock recorder for MockDB.
+type MockDBMockRecorder struct {
+	mock *MockDB
+}
+
+// NewMockDB creates a new mock instance.
+func NewMockDB(ctrl *gomock.Controller) *MockDB {
+	mock := &MockDB{ctrl: ctrl}
+	mock.recorder = &MockDBMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDB) EXPECT() *MockDBMockRecorder {
+	return m.recorder
+}
+
+// CountClicks mocks base method.
+func (m *MockDB) CountClicks(ctx context.Context, namespace, alias string, from, to time.Time, granularity string) ([]entity.ClickCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountClicks", ctx, namespace, alias, from, to, granularity)
+	ret0, _ := ret[0].([]entity.ClickCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountClicks indicates an expected call of CountClicks.
+func (mr *MockDBMockRecorder) CountClicks(ctx, namespace, alias, from, to, granularity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountClicks", reflect.TypeOf((*MockDB)(nil).CountClicks), ctx, namespace, alias, from, to, granularity)
+}
+
+// CountClicksByLocation mocks base method.
+func (m *MockDB) CountClicksByLocation(ctx context.Context, namespace, alias string, from, to time.Time) ([]entity.LocationCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountClicksByLocation", ctx, namespace, alias, from, to)
+	ret0, _ := ret[0].([]entity.LocationCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountClicksByLocation indicates an expected call of CountClicksByLocation.
+func (mr *MockDBMockRecorder) CountClicksByLocation(ctx, namespace, alias, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountClicksByLocation", reflect.TypeOf((*MockDB)(nil).CountClicksByLocation), ctx, namespace, alias, from, to)
+}
+
+// SaveClick mocks base method.
+func (m *MockDB) SaveClick(ctx context.Context, click *entity.Click) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveClick", ctx, click)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveClick indicates an expected call of SaveClick.
+func (mr *MockDBMockRecorder) SaveClick(ctx, click any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveClick", reflect.TypeOf((*MockDB)(nil).SaveClick), ctx, click)
+}
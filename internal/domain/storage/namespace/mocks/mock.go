@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gururuby/shortener/internal/domain/storage/namespace (interfaces: DB)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock.go -package=mocks . DB
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/namespace"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDB is a mock of DB interface.
+type MockDB struct {
+	ctrl     *gomock.Controller
+	recorder *MockDBMockRecorder
+	isgomock struct{}
+}
+
+// MockDBMockRecorder is the mock recorder for MockDB.
+type MockDBMockRecorder struct {
+	mock *MockDB
+}
+
+// NewMockDB creates a new mock instance.
+func NewMockDB(ctrl *gomock.Controller) *MockDB {
+	mock := &MockDB{ctrl: ctrl}
+	mock.recorder = &MockDBMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDB) EXPECT() *MockDBMockRecorder {
+	return m.recorder
+}
+
+// DeleteNamespace mocks base method.
+func (m *MockDB) DeleteNamespace(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNamespace", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNamespace indicates an expected call of DeleteNamespace.
+func (mr *MockDBMockRecorder) DeleteNamespace(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNamespace", reflect.TypeOf((*MockDB)(nil).DeleteNamespace), ctx, name)
+}
+
+// FindNamespaces mocks base method.
+func (m *MockDB) FindNamespaces(ctx context.Context) ([]*entity.Namespace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindNamespaces", ctx)
+	ret0, _ := ret[0].([]*entity.Namespace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindNamespaces indicates an expected call of FindNamespaces.
+func (mr *MockDBMockRecorder) FindNamespaces(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindNamespaces", reflect.TypeOf((*MockDB)(nil).FindNamespaces), ctx)
+}
+
+// SaveNamespace mocks base method.
+func (m *MockDB) SaveNamespace(ctx context.Context, namespace *entity.Namespace) (*entity.Namespace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveNamespace", ctx, namespace)
+	ret0, _ := ret[0].(*entity.Namespace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveNamespace indicates an expected call of SaveNamespace.
+func (mr *MockDBMockRecorder) SaveNamespace(ctx, namespace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveNamespace", reflect.TypeOf((*MockDB)(nil).SaveNamespace), ctx, namespace)
+}
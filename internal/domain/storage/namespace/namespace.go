@@ -0,0 +1,82 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . DB
+
+/*
+Package storage provides data persistence implementations for namespaces of short URLs.
+
+It includes:
+- Database interface for namespaces
+- Storage layer implementation
+*/
+package storage
+
+import (
+	"context"
+
+	namespaceEntity "github.com/gururuby/shortener/internal/domain/entity/namespace"
+)
+
+// DB defines the interface for namespace database operations.
+type DB interface {
+	// SaveNamespace stores a new namespace.
+	// Returns:
+	// - *namespaceEntity.Namespace: Saved namespace with creation time
+	// - error: If namespace already exists or database operation fails
+	SaveNamespace(ctx context.Context, namespace *namespaceEntity.Namespace) (*namespaceEntity.Namespace, error)
+
+	// FindNamespaces retrieves all namespaces.
+	// Returns:
+	// - []*namespaceEntity.Namespace: Namespaces ordered by name
+	// - error: If database operation fails
+	FindNamespaces(ctx context.Context) ([]*namespaceEntity.Namespace, error)
+
+	// DeleteNamespace removes a namespace without short URLs.
+	// Returns:
+	// - error: If namespace doesn't exist, still has short URLs or database operation fails
+	DeleteNamespace(ctx context.Context, name string) error
+}
+
+// NamespaceStorage implements the storage layer for namespace operations.
+// It acts as an intermediary between the domain and database layers.
+type NamespaceStorage struct {
+	db DB // Database interface implementation
+}
+
+// Setup creates and initializes a new NamespaceStorage instance.
+// Parameters:
+// - db: The database implementation to use
+// Returns:
+// - *NamespaceStorage: Initialized storage instance
+func Setup(db DB) *NamespaceStorage {
+	return &NamespaceStorage{db: db}
+}
+
+// SaveNamespace stores a new namespace.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - namespace: Namespace to save
+// Returns:
+// - *namespaceEntity.Namespace: Saved namespace with creation time
+// - error: If operation fails
+func (s *NamespaceStorage) SaveNamespace(ctx context.Context, namespace *namespaceEntity.Namespace) (*namespaceEntity.Namespace, error) {
+	return s.db.SaveNamespace(ctx, namespace)
+}
+
+// FindNamespaces retrieves all namespaces.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// Returns:
+// - []*namespaceEntity.Namespace: Namespaces ordered by name
+// - error: If operation fails
+func (s *NamespaceStorage) FindNamespaces(ctx context.Context) ([]*namespaceEntity.Namespace, error) {
+	return s.db.FindNamespaces(ctx)
+}
+
+// DeleteNamespace removes a namespace without short URLs.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - name: Namespace name
+// Returns:
+// - error: If operation fails
+func (s *NamespaceStorage) DeleteNamespace(ctx context.Context, name string) error {
+	return s.db.DeleteNamespace(ctx, name)
+}
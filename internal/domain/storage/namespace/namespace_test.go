@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	namespaceEntity "github.com/gururuby/shortener/internal/domain/entity/namespace"
+	storageMock "github.com/gururuby/shortener/internal/domain/storage/namespace/mocks"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Storage_Namespaces(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := storageMock.NewMockDB(ctrl)
+	ctx := context.Background()
+
+	storage := Setup(db)
+
+	t.Run("when calls are passed to db", func(t *testing.T) {
+		namespace := &namespaceEntity.Namespace{Name: "team-a"}
+
+		db.EXPECT().SaveNamespace(ctx, namespace).Return(namespace, nil)
+		db.EXPECT().FindNamespaces(ctx).Return([]*namespaceEntity.Namespace{namespace}, nil)
+		db.EXPECT().DeleteNamespace(ctx, "team-a").Return(nil)
+
+		res, err := storage.SaveNamespace(ctx, namespace)
+		require.NoError(t, err)
+		require.Equal(t, namespace, res)
+
+		namespaces, err := storage.FindNamespaces(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []*namespaceEntity.Namespace{namespace}, namespaces)
+
+		require.NoError(t, storage.DeleteNamespace(ctx, "team-a"))
+	})
+
+	t.Run("when db query fails", func(t *testing.T) {
+		db.EXPECT().DeleteNamespace(ctx, "team-a").Return(dbErrors.ErrDBRecordIsReferenced)
+
+		err := storage.DeleteNamespace(ctx, "team-a")
+		require.ErrorIs(t, err, dbErrors.ErrDBRecordIsReferenced)
+	})
+}
@@ -42,18 +42,32 @@ func (m *MockDB) EXPECT() *MockDBMockRecorder {
 }
 
 // FindShortURL mocks base method.
-func (m *MockDB) FindShortURL(ctx context.Context, alias string) (*entity.ShortURL, error) {
+func (m *MockDB) FindShortURL(ctx context.Context, namespace, alias string) (*entity.ShortURL, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindShortURL", ctx, alias)
+	ret := m.ctrl.Call(m, "FindShortURL", ctx, namespace, alias)
 	ret0, _ := ret[0].(*entity.ShortURL)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindShortURL indicates an expected call of FindShortURL.
-func (mr *MockDBMockRecorder) FindShortURL(ctx, alias any) *gomock.Call {
+func (mr *MockDBMockRecorder) FindShortURL(ctx, namespace, alias any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShortURL", reflect.TypeOf((*MockDB)(nil).FindShortURL), ctx, alias)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShortURL", reflect.TypeOf((*MockDB)(nil).FindShortURL), ctx, namespace, alias)
+}
+
+// MarkURLAsDeleted mocks base method.
+func (m *MockDB) MarkURLAsDeleted(ctx context.Context, userID int, namespace string, aliases []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkURLAsDeleted", ctx, userID, namespace, aliases)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkURLAsDeleted indicates an expected call of MarkURLAsDeleted.
+func (mr *MockDBMockRecorder) MarkURLAsDeleted(ctx, userID, namespace, aliases any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkURLAsDeleted", reflect.TypeOf((*MockDB)(nil).MarkURLAsDeleted), ctx, userID, namespace, aliases)
 }
 
 // Ping mocks base method.
@@ -13,22 +13,33 @@ package storage
 import (
 	"context"
 	"errors"
+	"sync/atomic"
+	"time"
 
 	"github.com/gururuby/shortener/internal/config"
+	healthEntity "github.com/gururuby/shortener/internal/domain/entity/health"
+	namespaceEntity "github.com/gururuby/shortener/internal/domain/entity/namespace"
 	"github.com/gururuby/shortener/internal/domain/entity/shorturl"
 	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
 	storageErrors "github.com/gururuby/shortener/internal/domain/storage/errors"
 	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/gururuby/shortener/internal/infra/logger"
 	"github.com/gururuby/shortener/pkg/generator"
+	genErrors "github.com/gururuby/shortener/pkg/generator/errors"
+	"go.uber.org/zap"
 )
 
+// defaultAliasRetryDelay is the delay before the first save with regenerated alias,
+// it is doubled before each next one.
+const defaultAliasRetryDelay = 10 * time.Millisecond
+
 // ShortURLDB defines the interface for short URL database operations.
 type ShortURLDB interface {
-	// FindShortURL retrieves a short URL by its alias.
+	// FindShortURL retrieves a short URL by its namespace and alias.
 	// Returns:
 	// - *entity.ShortURL: The found short URL
 	// - error: Any error that occurred during lookup
-	FindShortURL(ctx context.Context, alias string) (*entity.ShortURL, error)
+	FindShortURL(ctx context.Context, namespace, alias string) (*entity.ShortURL, error)
 
 	// SaveShortURL persists a short URL record.
 	// Returns:
@@ -36,12 +47,85 @@ type ShortURLDB interface {
 	// - error: Any error that occurred during save
 	SaveShortURL(ctx context.Context, shortURL *entity.ShortURL) (*entity.ShortURL, error)
 
+	// MarkURLAsDeleted soft-deletes short URLs of a user, or of any owner if userID is 0.
+	// Returns:
+	// - error: Any error that occurred during update
+	MarkURLAsDeleted(ctx context.Context, userID int, namespace string, aliases []string) error
+
 	// Ping checks the database connection health.
 	// Returns:
 	// - error: Any connection error
 	Ping(ctx context.Context) error
 }
 
+// BatchDB defines the optional interface for databases able to save
+// several short URLs atomically.
+type BatchDB interface {
+	// BatchSaveShortURLs persists short URL records in a single transaction.
+	// Returns:
+	// - []*entity.ShortURL: Saved short URLs; on failure, the ones inserted before it
+	// - error: Any error that occurred during save
+	BatchSaveShortURLs(ctx context.Context, shortURLs []*entity.ShortURL) ([]*entity.ShortURL, error)
+}
+
+// PoolStatsDB defines the optional interface for databases working over a connection pool.
+type PoolStatsDB interface {
+	// PoolStats returns statistics of pool connections.
+	// Returns:
+	// - *healthEntity.DBPoolStats: Open and idle connections, number of waited acquires
+	PoolStats() *healthEntity.DBPoolStats
+}
+
+// ReplicaDB defines the optional interface for databases serving reads from a read replica.
+type ReplicaDB interface {
+	// ReplicaHealth pings the read replica and collects statistics of its connection pool.
+	// Returns:
+	// - *healthEntity.DBReplicaHealth: Replica availability and pool statistics, nil if the replica is disabled
+	ReplicaHealth(ctx context.Context) *healthEntity.DBReplicaHealth
+}
+
+// DuplicateDB defines the optional interface for databases able to look up
+// deduplicated short URLs by source URL.
+type DuplicateDB interface {
+	// FindShortURLBySourceURL retrieves a deduplicated short URL of a namespace by deduplication key of its source URL.
+	// Returns:
+	// - *entity.ShortURL: The found short URL
+	// - error: dbErrors.ErrDBRecordNotFound if there is no such short URL, or any other error that occurred during lookup
+	FindShortURLBySourceURL(ctx context.Context, namespace, sourceURL string) (*entity.ShortURL, error)
+}
+
+// ExpiringDB defines the optional interface for databases able to remove expired short URLs.
+type ExpiringDB interface {
+	// DeleteExpiredURLs removes short URLs whose expiration time has passed.
+	// Returns:
+	// - int64: Number of removed short URLs
+	// - error: Any error that occurred during removal
+	DeleteExpiredURLs(ctx context.Context) (int64, error)
+}
+
+// ArchivingDB defines the optional interface for databases able to archive deleted short URLs.
+type ArchivingDB interface {
+	// ArchiveDeletedURLs moves short URLs deleted long ago out of the table of active short URLs.
+	// Returns:
+	// - int64: Number of archived short URLs
+	// - error: Any error that occurred during archiving
+	ArchiveDeletedURLs(ctx context.Context) (int64, error)
+}
+
+// HealthCheckingDB defines the optional interface for databases able to store health of source URLs.
+type HealthCheckingDB interface {
+	// FindURLsToCheck retrieves not deleted and not expired short URLs not checked since checkedBefore.
+	// Returns:
+	// - []*entity.ShortURL: Up to limit URLs with ID greater than afterID, ordered by ID
+	// - error: Any error that occurred during lookup
+	FindURLsToCheck(ctx context.Context, checkedBefore time.Time, afterID, limit int) ([]*entity.ShortURL, error)
+
+	// SaveURLHealth stores result of health check of a short URL's source URL.
+	// Returns:
+	// - error: Any error that occurred during save
+	SaveURLHealth(ctx context.Context, namespace, alias string, healthy bool, checkedAt time.Time) error
+}
+
 // Generator defines the interface for generating unique identifiers.
 type Generator interface {
 	// UUID generates a universally unique identifier.
@@ -57,8 +141,12 @@ type Generator interface {
 // ShortURLStorage implements the storage layer for short URLs.
 // It combines database operations with ID generation.
 type ShortURLStorage struct {
-	gen Generator  // ID generator
-	db  ShortURLDB // Database interface
+	gen              Generator     // ID generator
+	db               ShortURLDB    // Database interface
+	region           string        // Region stored in created short URLs
+	defaultNamespace string        // Namespace of short URLs created without one
+	maxAliasRetries  int           // Attempts to regenerate alias which is already taken
+	aliasRetryDelay  time.Duration // Delay before the first save with regenerated alias
 }
 
 // Setup creates and initializes a new ShortURLStorage instance.
@@ -67,35 +155,109 @@ type ShortURLStorage struct {
 // - cfg: Application configuration
 // Returns:
 // - *ShortURLStorage: Initialized storage instance
-func Setup(db ShortURLDB, cfg *config.Config) *ShortURLStorage {
-	return &ShortURLStorage{gen: generator.New(cfg.App.AliasLength), db: db}
+// - error: Any error of alias generator configuration
+func Setup(db ShortURLDB, cfg *config.Config) (*ShortURLStorage, error) {
+	gen, err := newGenerator(&cfg.App)
+	if err != nil {
+		return nil, err
+	}
+	return &ShortURLStorage{
+		gen:              gen,
+		db:               db,
+		region:           cfg.App.Region,
+		defaultNamespace: namespaceEntity.OrDefault(cfg.App.DefaultNamespace),
+		maxAliasRetries:  cfg.App.MaxAliasRetries,
+		aliasRetryDelay:  defaultAliasRetryDelay,
+	}, nil
+}
+
+// newGenerator creates alias generator with the configured strategy.
+// Sequential aliases start from the current Unix time, so they don't repeat
+// aliases generated before restart unless more than one alias per second was created.
+// Parameters:
+// - cfg: Application settings
+// Returns:
+// - *generator.Generator: Generator of aliases
+// - error: Error of alias alphabet configuration, or ErrGeneratorUnknownStrategy
+func newGenerator(cfg *config.App) (*generator.Generator, error) {
+	switch cfg.AliasStrategy {
+	case "", generator.StrategyRandom:
+		return generator.NewWithAlphabet(cfg.AliasLength, cfg.AliasAlphabet)
+	case generator.StrategyWord:
+		return generator.NewWithStrategy(generator.NewWordStrategy(generator.DefaultWordCount)), nil
+	case generator.StrategySequential:
+		counter := &atomic.Int64{}
+		counter.Store(time.Now().Unix())
+		return generator.NewWithStrategy(&generator.SequentialStrategy{Counter: counter}), nil
+	default:
+		return nil, genErrors.ErrGeneratorUnknownStrategy
+	}
 }
 
-// FindShortURL retrieves a short URL by its alias.
+// newShortURL builds a short URL created in the region of the storage.
+// Short URLs of users without namespace are placed in the default one.
+// Parameters:
+// - user: The user creating the short URL (can be nil for anonymous)
+// - sourceURL: The original URL to shorten
+// Returns:
+// - *entity.ShortURL: Short URL with generated UUID and alias
+// - error: Any alias generation error
+func (s *ShortURLStorage) newShortURL(user *userEntity.User, sourceURL string) (*entity.ShortURL, error) {
+	shortURL, err := entity.NewShortURL(s.gen, user, sourceURL)
+	if err != nil {
+		return nil, err
+	}
+	shortURL.CreatedInRegion = s.region
+	if shortURL.Namespace == "" {
+		shortURL.Namespace = s.defaultNamespace
+	}
+	return shortURL, nil
+}
+
+// FindShortURL retrieves a short URL by its namespace and alias.
 // Parameters:
 // - ctx: Context for cancellation and timeouts
+// - namespace: Namespace of the short URL, empty for the default one
 // - alias: The short URL identifier to look up
 // Returns:
 // - *entity.ShortURL: The found short URL
 // - error: Any error that occurred during lookup
-func (s *ShortURLStorage) FindShortURL(ctx context.Context, alias string) (*entity.ShortURL, error) {
-	return s.db.FindShortURL(ctx, alias)
+func (s *ShortURLStorage) FindShortURL(ctx context.Context, namespace, alias string) (*entity.ShortURL, error) {
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+	return s.db.FindShortURL(ctx, namespace, alias)
 }
 
-// SaveShortURL creates and persists a new short URL.
+// SaveShortURL creates and persists a new short URL with the given options.
+// Public short URLs redirecting with 307 are deduplicated by normalized URL,
+// the others always get their own alias.
+// If the generated alias is already taken, the short URL is saved again
+// with a new alias, see saveWithAliasRetry. Custom alias is never replaced.
 // Parameters:
 // - ctx: Context for cancellation and timeouts
 // - user: The user creating the short URL (can be nil for anonymous)
 // - sourceURL: The original URL to shorten
+// - normalizedURL: Normalized form of sourceURL used to detect duplicates
+// - opts: Optional properties of the short URL
 // Returns:
-// - *entity.ShortURL: The created short URL
-// - error: Any error that occurred during creation or save
-func (s *ShortURLStorage) SaveShortURL(ctx context.Context, user *userEntity.User, sourceURL string) (*entity.ShortURL, error) {
-	shortURL, err := entity.NewShortURL(s.gen, user, sourceURL)
+// - *entity.ShortURL: The created short URL, or the existing one if sourceURL is already shortened
+// - error: storageErrors.ErrStorageRecordIsNotUnique if sourceURL is already shortened,
+// dbErrors.ErrDBAliasTaken if custom alias is taken, or any other error that occurred during creation or save
+func (s *ShortURLStorage) SaveShortURL(ctx context.Context, user *userEntity.User, sourceURL, normalizedURL string, opts entity.CreateOptions) (*entity.ShortURL, error) {
+	shortURL, err := s.newShortURL(user, sourceURL)
 	if err != nil {
 		return nil, err
 	}
-	res, err := s.db.SaveShortURL(ctx, shortURL)
+	shortURL.NormalizedURL = normalizedURL
+	opts.Apply(shortURL)
+
+	var res *entity.ShortURL
+	if opts.Alias != "" {
+		res, err = s.db.SaveShortURL(ctx, shortURL)
+	} else {
+		res, err = s.saveWithAliasRetry(ctx, shortURL)
+	}
 	if err != nil {
 		if errors.Is(err, dbErrors.ErrDBIsNotUnique) {
 			return res, storageErrors.ErrStorageRecordIsNotUnique
@@ -104,6 +266,243 @@ func (s *ShortURLStorage) SaveShortURL(ctx context.Context, user *userEntity.Use
 	return res, err
 }
 
+// FindDuplicate looks up the existing short URL SaveShortURL would return
+// instead of creating a new one for the same source URL and options.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: The user creating the short URL (can be nil for anonymous)
+// - sourceURL: The original URL to shorten
+// - normalizedURL: Normalized form of sourceURL used to detect duplicates
+// - opts: Optional properties of the short URL
+// Returns:
+// - *entity.ShortURL: The existing short URL
+// - error: storageErrors.ErrStorageRecordNotFound if a new short URL would be created
+// or the database does not implement DuplicateDB, or any other error that occurred during lookup
+func (s *ShortURLStorage) FindDuplicate(ctx context.Context, user *userEntity.User, sourceURL, normalizedURL string, opts entity.CreateOptions) (*entity.ShortURL, error) {
+	dupDB, ok := s.db.(DuplicateDB)
+	if !ok {
+		return nil, storageErrors.ErrStorageRecordNotFound
+	}
+
+	shortURL := &entity.ShortURL{SourceURL: sourceURL, NormalizedURL: normalizedURL, Namespace: s.defaultNamespace}
+	if user != nil && user.Namespace != "" {
+		shortURL.Namespace = user.Namespace
+	}
+	opts.Apply(shortURL)
+	if !shortURL.IsDeduplicated() {
+		return nil, storageErrors.ErrStorageRecordNotFound
+	}
+
+	res, err := dupDB.FindShortURLBySourceURL(ctx, shortURL.Namespace, shortURL.DeduplicationKey())
+	if errors.Is(err, dbErrors.ErrDBRecordNotFound) {
+		return nil, storageErrors.ErrStorageRecordNotFound
+	}
+	return res, err
+}
+
+// saveWithAliasRetry saves a short URL regenerating its alias while it is taken by another one.
+// Up to maxAliasRetries new aliases are tried, waiting with exponential backoff between attempts.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - shortURL: The short URL to save, its alias is replaced on retry
+// Returns:
+// - *entity.ShortURL: The saved short URL, or the existing one on duplicate source URL
+// - error: dbErrors.ErrDBAliasTaken if all aliases are taken, context error if it is done
+// while waiting, or any other error of alias generation or save
+func (s *ShortURLStorage) saveWithAliasRetry(ctx context.Context, shortURL *entity.ShortURL) (*entity.ShortURL, error) {
+	delay := s.aliasRetryDelay
+
+	for attempt := 1; ; attempt++ {
+		res, err := s.db.SaveShortURL(ctx, shortURL)
+		if !errors.Is(err, dbErrors.ErrDBAliasTaken) || attempt > s.maxAliasRetries {
+			return res, err
+		}
+
+		logger.Log.Warn("alias is taken, retrying with new one",
+			zap.String("alias", shortURL.Alias),
+			zap.Int("attempt", attempt))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		delay *= 2
+
+		if shortURL.Alias, err = s.gen.Alias(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// MarkURLAsDeleted soft-deletes the specified short URLs.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: Owner's user ID, or 0 to delete regardless of owner
+// - namespace: Namespace of the short URLs, empty for the default one
+// - aliases: Short URL identifiers to delete
+// Returns:
+// - error: Any error that occurred during update
+func (s *ShortURLStorage) MarkURLAsDeleted(ctx context.Context, userID int, namespace string, aliases []string) error {
+	if namespace == "" {
+		namespace = s.defaultNamespace
+	}
+	return s.db.MarkURLAsDeleted(ctx, userID, namespace, aliases)
+}
+
+// SaveShortURLBatch creates and persists several short URLs.
+// The batch is saved in a single transaction if the database implements BatchDB,
+// otherwise URLs are saved one by one. Already existing URLs are skipped.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: The user creating the short URLs (can be nil for anonymous)
+// - urls: The original URLs with correlation IDs and normalized forms
+// Returns:
+// - *entity.BatchSaveResult: Correlation IDs and saved short URLs
+// - error: Any error that occurred during creation or save
+func (s *ShortURLStorage) SaveShortURLBatch(ctx context.Context, user *userEntity.User, urls []entity.BatchShortURLInput) (*entity.BatchSaveResult, error) {
+	var (
+		saved []*entity.ShortURL
+		err   error
+	)
+
+	shortURLs := make([]*entity.ShortURL, 0, len(urls))
+	correlationIDs := make(map[*entity.ShortURL]string, len(urls))
+
+	for _, url := range urls {
+		shortURL, genErr := s.newShortURL(user, url.OriginalURL)
+		if genErr != nil {
+			return nil, genErr
+		}
+		shortURL.NormalizedURL = url.NormalizedURL
+		shortURLs = append(shortURLs, shortURL)
+		correlationIDs[shortURL] = url.CorrelationID
+	}
+
+	if batchDB, ok := s.db.(BatchDB); ok {
+		saved, err = batchDB.BatchSaveShortURLs(ctx, shortURLs)
+	} else {
+		saved, err = s.saveShortURLs(ctx, shortURLs)
+	}
+
+	result := &entity.BatchSaveResult{
+		SucceededIDs: make([]string, 0, len(saved)),
+		ShortURLs:    saved,
+	}
+	for _, shortURL := range saved {
+		result.SucceededIDs = append(result.SucceededIDs, correlationIDs[shortURL])
+	}
+
+	return result, err
+}
+
+// saveShortURLs saves short URLs one by one skipping already existing ones.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - shortURLs: Short URLs to save
+// Returns:
+// - []*entity.ShortURL: Saved short URLs; on failure, the ones saved before it
+// - error: Any error that occurred during save
+func (s *ShortURLStorage) saveShortURLs(ctx context.Context, shortURLs []*entity.ShortURL) ([]*entity.ShortURL, error) {
+	saved := make([]*entity.ShortURL, 0, len(shortURLs))
+
+	for _, shortURL := range shortURLs {
+		if _, err := s.db.SaveShortURL(ctx, shortURL); err != nil {
+			if errors.Is(err, dbErrors.ErrDBIsNotUnique) {
+				continue
+			}
+			return saved, err
+		}
+		saved = append(saved, shortURL)
+	}
+
+	return saved, nil
+}
+
+// DBPoolStats returns statistics of database connection pool.
+// Returns:
+// - *healthEntity.DBPoolStats: Pool statistics, nil if the database does not implement PoolStatsDB
+func (s *ShortURLStorage) DBPoolStats() *healthEntity.DBPoolStats {
+	if poolDB, ok := s.db.(PoolStatsDB); ok {
+		return poolDB.PoolStats()
+	}
+	return nil
+}
+
+// DBReplicaHealth returns health of the read replica of the database.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// Returns:
+// - *healthEntity.DBReplicaHealth: Replica health, nil if the database does not implement ReplicaDB
+// or has no replica configured
+func (s *ShortURLStorage) DBReplicaHealth(ctx context.Context) *healthEntity.DBReplicaHealth {
+	if replicaDB, ok := s.db.(ReplicaDB); ok {
+		return replicaDB.ReplicaHealth(ctx)
+	}
+	return nil
+}
+
+// DeleteExpiredURLs removes short URLs whose expiration time has passed.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// Returns:
+// - int64: Number of removed short URLs, 0 if the database does not implement ExpiringDB
+// - error: Any error that occurred during removal
+func (s *ShortURLStorage) DeleteExpiredURLs(ctx context.Context) (int64, error) {
+	if expiringDB, ok := s.db.(ExpiringDB); ok {
+		return expiringDB.DeleteExpiredURLs(ctx)
+	}
+	return 0, nil
+}
+
+// ArchiveDeletedURLs moves short URLs deleted long ago to the archive.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// Returns:
+// - int64: Number of archived short URLs, 0 if the database does not implement ArchivingDB
+// - error: Any error that occurred during archiving
+func (s *ShortURLStorage) ArchiveDeletedURLs(ctx context.Context) (int64, error) {
+	if archivingDB, ok := s.db.(ArchivingDB); ok {
+		return archivingDB.ArchiveDeletedURLs(ctx)
+	}
+	return 0, nil
+}
+
+// FindURLsToCheck retrieves short URLs whose source URLs should be checked.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - checkedBefore: URLs checked at or after this time are skipped
+// - afterID: ID of the last URL of the previous batch, 0 for the first batch
+// - limit: Maximum number of URLs to return
+// Returns:
+// - []*entity.ShortURL: Not deleted and not expired URLs ordered by ID,
+// none if the database does not implement HealthCheckingDB
+// - error: Any error that occurred during lookup
+func (s *ShortURLStorage) FindURLsToCheck(ctx context.Context, checkedBefore time.Time, afterID, limit int) ([]*entity.ShortURL, error) {
+	if healthDB, ok := s.db.(HealthCheckingDB); ok {
+		return healthDB.FindURLsToCheck(ctx, checkedBefore, afterID, limit)
+	}
+	return nil, nil
+}
+
+// SaveURLHealth stores result of health check of a short URL's source URL.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// - healthy: Whether the source URL was reachable
+// - checkedAt: Time of the check
+// Returns:
+// - error: Any error that occurred during save, nil if the database does not implement HealthCheckingDB
+func (s *ShortURLStorage) SaveURLHealth(ctx context.Context, namespace, alias string, healthy bool, checkedAt time.Time) error {
+	if healthDB, ok := s.db.(HealthCheckingDB); ok {
+		return healthDB.SaveURLHealth(ctx, namespace, alias, healthy, checkedAt)
+	}
+	return nil
+}
+
 // IsDBReady checks if the database connection is healthy.
 // Parameters:
 // - ctx: Context for cancellation and timeouts
@@ -3,12 +3,19 @@ package storage
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/gururuby/shortener/internal/config"
+	healthEntity "github.com/gururuby/shortener/internal/domain/entity/health"
 	"github.com/gururuby/shortener/internal/domain/entity/shorturl"
 	entityMock "github.com/gururuby/shortener/internal/domain/entity/shorturl/mocks"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
 	storageErrors "github.com/gururuby/shortener/internal/domain/storage/errors"
 	storageMock "github.com/gururuby/shortener/internal/domain/storage/shorturl/mocks"
 	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/gururuby/shortener/internal/infra/logger"
+	"github.com/gururuby/shortener/pkg/generator"
+	genErrors "github.com/gururuby/shortener/pkg/generator/errors"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -19,7 +26,7 @@ func Test_Storage_FindShortURL_OK(t *testing.T) {
 	gen := entityMock.NewMockGenerator(ctrl)
 	ctx := context.Background()
 
-	storage := ShortURLStorage{gen: gen, db: db}
+	storage := ShortURLStorage{gen: gen, db: db, defaultNamespace: "default"}
 
 	type dbRecord struct {
 		value *entity.ShortURL
@@ -27,21 +34,31 @@ func Test_Storage_FindShortURL_OK(t *testing.T) {
 	}
 
 	tests := []struct {
-		dbRecord dbRecord
-		name     string
-		alias    string
+		dbRecord    dbRecord
+		name        string
+		namespace   string
+		dbNamespace string
+		alias       string
 	}{
 		{
-			name:     "when find short URL in db by alias",
-			alias:    "alias",
-			dbRecord: dbRecord{value: &entity.ShortURL{SourceURL: "https://ya.ru"}},
+			name:        "when find short URL in db by alias",
+			alias:       "alias",
+			dbNamespace: "default",
+			dbRecord:    dbRecord{value: &entity.ShortURL{SourceURL: "https://ya.ru"}},
+		},
+		{
+			name:        "when find short URL in db by namespace and alias",
+			namespace:   "team-a",
+			alias:       "alias",
+			dbNamespace: "team-a",
+			dbRecord:    dbRecord{value: &entity.ShortURL{SourceURL: "https://go.dev", Namespace: "team-a"}},
 		},
 	}
 	for _, tt := range tests {
-		db.EXPECT().FindShortURL(ctx, tt.alias).Return(tt.dbRecord.value, tt.dbRecord.err).Times(1)
+		db.EXPECT().FindShortURL(ctx, tt.dbNamespace, tt.alias).Return(tt.dbRecord.value, tt.dbRecord.err).Times(1)
 
 		t.Run(tt.name, func(t *testing.T) {
-			res, err := storage.FindShortURL(ctx, tt.alias)
+			res, err := storage.FindShortURL(ctx, tt.namespace, tt.alias)
 			require.NoError(t, err)
 			require.Equal(t, tt.dbRecord.value, res)
 		})
@@ -73,10 +90,10 @@ func Test_Storage_FindShortURL_Errors(t *testing.T) {
 		},
 	}
 	for _, tt := range tests {
-		db.EXPECT().FindShortURL(ctx, tt.alias).Return(tt.result.value, tt.result.err).Times(1)
+		db.EXPECT().FindShortURL(ctx, "", tt.alias).Return(tt.result.value, tt.result.err).Times(1)
 
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := storage.FindShortURL(ctx, tt.alias)
+			_, err := storage.FindShortURL(ctx, "", tt.alias)
 			require.Error(t, tt.result.err, err)
 		})
 	}
@@ -94,24 +111,27 @@ func Test_Storage_SaveShortURL_OK(t *testing.T) {
 	storage := ShortURLStorage{gen: gen, db: db}
 
 	tests := []struct {
-		res       *entity.ShortURL
-		name      string
-		sourceURL string
+		res           *entity.ShortURL
+		name          string
+		sourceURL     string
+		normalizedURL string
 	}{
 		{
-			name:      "when save short URL in db",
-			sourceURL: "https://ya.ru",
+			name:          "when save short URL in db",
+			sourceURL:     "HTTPS://ya.ru/",
+			normalizedURL: "https://ya.ru",
 			res: &entity.ShortURL{
-				UUID:      "UUID",
-				SourceURL: "https://ya.ru",
-				Alias:     "alias",
+				UUID:          "UUID",
+				SourceURL:     "HTTPS://ya.ru/",
+				NormalizedURL: "https://ya.ru",
+				Alias:         "alias",
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			db.EXPECT().SaveShortURL(ctx, tt.res).Return(tt.res, nil)
-			res, err := storage.SaveShortURL(ctx, nil, tt.sourceURL)
+			res, err := storage.SaveShortURL(ctx, nil, tt.sourceURL, tt.normalizedURL, entity.CreateOptions{})
 			require.NoError(t, err)
 			require.Equal(t, tt.res, res)
 		})
@@ -139,9 +159,10 @@ func Test_Storage_SaveShortURL_Errors(t *testing.T) {
 			name:      "when db return non unique record error",
 			sourceURL: "https://ya.ru",
 			res: &entity.ShortURL{
-				UUID:      "UUID",
-				SourceURL: "https://ya.ru",
-				Alias:     "alias",
+				UUID:          "UUID",
+				SourceURL:     "https://ya.ru",
+				NormalizedURL: "https://ya.ru",
+				Alias:         "alias",
 			},
 			err: dbErrors.ErrDBRecordNotFound,
 		},
@@ -149,12 +170,207 @@ func Test_Storage_SaveShortURL_Errors(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			db.EXPECT().SaveShortURL(ctx, tt.res).Return(nil, tt.err)
-			_, err := storage.SaveShortURL(ctx, nil, tt.sourceURL)
+			_, err := storage.SaveShortURL(ctx, nil, tt.sourceURL, tt.sourceURL, entity.CreateOptions{})
 			require.Error(t, err)
 		})
 	}
 }
 
+func Test_Storage_SaveShortURL_AliasTaken(t *testing.T) {
+	logger.Setup("test", "error")
+	ctx := context.Background()
+
+	t.Run("when alias is taken twice", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		db := storageMock.NewMockDB(ctrl)
+		gen := entityMock.NewMockGenerator(ctrl)
+		storage := ShortURLStorage{gen: gen, db: db, maxAliasRetries: 3}
+
+		gen.EXPECT().UUID().Return("UUID")
+		gen.EXPECT().Alias().Return("alias1", nil)
+		gen.EXPECT().Alias().Return("alias2", nil)
+		gen.EXPECT().Alias().Return("alias3", nil)
+
+		var aliases []string
+		db.EXPECT().SaveShortURL(ctx, gomock.Any()).Times(3).DoAndReturn(
+			func(_ context.Context, shortURL *entity.ShortURL) (*entity.ShortURL, error) {
+				aliases = append(aliases, shortURL.Alias)
+				if len(aliases) < 3 {
+					return nil, dbErrors.ErrDBAliasTaken
+				}
+				return shortURL, nil
+			})
+
+		res, err := storage.SaveShortURL(ctx, nil, "https://ya.ru", "https://ya.ru", entity.CreateOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "alias3", res.Alias)
+		require.Equal(t, []string{"alias1", "alias2", "alias3"}, aliases)
+	})
+
+	t.Run("when all retries are exhausted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		db := storageMock.NewMockDB(ctrl)
+		gen := entityMock.NewMockGenerator(ctrl)
+		storage := ShortURLStorage{gen: gen, db: db, maxAliasRetries: 2}
+
+		gen.EXPECT().UUID().Return("UUID")
+		gen.EXPECT().Alias().Return("alias", nil).Times(3)
+		db.EXPECT().SaveShortURL(ctx, gomock.Any()).Return(nil, dbErrors.ErrDBAliasTaken).Times(3)
+
+		_, err := storage.SaveShortURL(ctx, nil, "https://ya.ru", "https://ya.ru", entity.CreateOptions{})
+		require.ErrorIs(t, err, dbErrors.ErrDBAliasTaken)
+	})
+
+	t.Run("when context is done while waiting", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		db := storageMock.NewMockDB(ctrl)
+		gen := entityMock.NewMockGenerator(ctrl)
+		storage := ShortURLStorage{gen: gen, db: db, maxAliasRetries: 3, aliasRetryDelay: time.Hour}
+
+		canceledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		gen.EXPECT().UUID().Return("UUID")
+		gen.EXPECT().Alias().Return("alias", nil)
+		db.EXPECT().SaveShortURL(canceledCtx, gomock.Any()).Return(nil, dbErrors.ErrDBAliasTaken)
+
+		_, err := storage.SaveShortURL(canceledCtx, nil, "https://ya.ru", "https://ya.ru", entity.CreateOptions{})
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("when custom alias is taken", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		db := storageMock.NewMockDB(ctrl)
+		gen := entityMock.NewMockGenerator(ctrl)
+		storage := ShortURLStorage{gen: gen, db: db, maxAliasRetries: 3}
+
+		gen.EXPECT().UUID().Return("UUID")
+		gen.EXPECT().Alias().Return("alias", nil)
+		db.EXPECT().SaveShortURL(ctx, gomock.Cond(func(shortURL *entity.ShortURL) bool {
+			return shortURL.Alias == "my-alias"
+		})).Return(nil, dbErrors.ErrDBAliasTaken)
+
+		_, err := storage.SaveShortURL(ctx, nil, "https://ya.ru", "https://ya.ru", entity.CreateOptions{Alias: "my-alias"})
+		require.ErrorIs(t, err, dbErrors.ErrDBAliasTaken)
+	})
+}
+
+// batchDB is a ShortURLDB mock implementing BatchDB.
+type batchDB struct {
+	*storageMock.MockDB
+	err   error
+	saved int
+}
+
+func (db batchDB) BatchSaveShortURLs(_ context.Context, shortURLs []*entity.ShortURL) ([]*entity.ShortURL, error) {
+	return shortURLs[:db.saved], db.err
+}
+
+func Test_Storage_SaveShortURLBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	urls := []entity.BatchShortURLInput{
+		{CorrelationID: "1", OriginalURL: "https://ya.ru/", NormalizedURL: "https://ya.ru"},
+		{CorrelationID: "2", OriginalURL: "https://ya.com", NormalizedURL: "https://ya.com"},
+		{CorrelationID: "3", OriginalURL: "https://ya.org", NormalizedURL: "https://ya.org"},
+	}
+
+	gen := entityMock.NewMockGenerator(ctrl)
+	gen.EXPECT().UUID().Return("UUID").AnyTimes()
+	gen.EXPECT().Alias().Return("alias", nil).AnyTimes()
+
+	t.Run("when db saves batch in transaction", func(t *testing.T) {
+		storage := ShortURLStorage{gen: gen, db: batchDB{MockDB: storageMock.NewMockDB(ctrl), saved: 3}}
+
+		res, err := storage.SaveShortURLBatch(ctx, nil, urls)
+		require.NoError(t, err)
+		require.Equal(t, []string{"1", "2", "3"}, res.SucceededIDs)
+		require.Len(t, res.ShortURLs, 3)
+		require.Equal(t, "https://ya.ru", res.ShortURLs[0].NormalizedURL)
+	})
+
+	t.Run("when db transaction fails", func(t *testing.T) {
+		storage := ShortURLStorage{gen: gen, db: batchDB{MockDB: storageMock.NewMockDB(ctrl), saved: 1, err: dbErrors.ErrDBQuery}}
+
+		res, err := storage.SaveShortURLBatch(ctx, nil, urls)
+		require.ErrorIs(t, err, dbErrors.ErrDBQuery)
+		require.Equal(t, []string{"1"}, res.SucceededIDs)
+	})
+
+	t.Run("when db saves URLs one by one", func(t *testing.T) {
+		db := storageMock.NewMockDB(ctrl)
+		storage := ShortURLStorage{gen: gen, db: db}
+
+		db.EXPECT().SaveShortURL(ctx, gomock.Any()).Return(nil, nil)
+		db.EXPECT().SaveShortURL(ctx, gomock.Any()).Return(nil, dbErrors.ErrDBIsNotUnique)
+		db.EXPECT().SaveShortURL(ctx, gomock.Any()).Return(nil, nil)
+
+		res, err := storage.SaveShortURLBatch(ctx, nil, urls)
+		require.NoError(t, err)
+		require.Equal(t, []string{"1", "3"}, res.SucceededIDs)
+	})
+
+	t.Run("when db fails to save URL", func(t *testing.T) {
+		db := storageMock.NewMockDB(ctrl)
+		storage := ShortURLStorage{gen: gen, db: db}
+
+		db.EXPECT().SaveShortURL(ctx, gomock.Any()).Return(nil, nil)
+		db.EXPECT().SaveShortURL(ctx, gomock.Any()).Return(nil, dbErrors.ErrDBQuery)
+
+		res, err := storage.SaveShortURLBatch(ctx, nil, urls)
+		require.ErrorIs(t, err, dbErrors.ErrDBQuery)
+		require.Equal(t, []string{"1"}, res.SucceededIDs)
+	})
+}
+
+func Test_Storage_SaveShortURL_WithOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := storageMock.NewMockDB(ctrl)
+	ctx := context.Background()
+
+	gen := entityMock.NewMockGenerator(ctrl)
+	gen.EXPECT().UUID().Return("UUID")
+	gen.EXPECT().Alias().Return("alias", nil)
+
+	storage := ShortURLStorage{gen: gen, db: db}
+
+	shortURL := &entity.ShortURL{
+		UUID:          "UUID",
+		SourceURL:     "https://ya.ru",
+		NormalizedURL: "https://ya.ru",
+		Alias:         "alias",
+		IsOneTimeUse:  true,
+		Visibility:    entity.VisibilityPublic,
+		IsTracked:     true,
+	}
+
+	db.EXPECT().SaveShortURL(ctx, shortURL).Return(shortURL, nil)
+	res, err := storage.SaveShortURL(ctx, nil, "https://ya.ru", "https://ya.ru", entity.CreateOptions{OneTimeUse: true, Visibility: entity.VisibilityPublic, Tracked: true})
+	require.NoError(t, err)
+	require.Equal(t, shortURL, res)
+}
+
+func Test_Storage_MarkURLAsDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := storageMock.NewMockDB(ctrl)
+	ctx := context.Background()
+
+	storage := ShortURLStorage{db: db, defaultNamespace: "team"}
+
+	t.Run("in default namespace", func(t *testing.T) {
+		db.EXPECT().MarkURLAsDeleted(ctx, 0, "team", []string{"alias"}).Return(dbErrors.ErrDBRecordIsDeleted)
+		err := storage.MarkURLAsDeleted(ctx, 0, "", []string{"alias"})
+		require.ErrorIs(t, err, dbErrors.ErrDBRecordIsDeleted)
+	})
+
+	t.Run("in passed namespace", func(t *testing.T) {
+		db.EXPECT().MarkURLAsDeleted(ctx, 1, "other", []string{"alias"}).Return(nil)
+		err := storage.MarkURLAsDeleted(ctx, 1, "other", []string{"alias"})
+		require.NoError(t, err)
+	})
+}
+
 func Test_IsDBReady(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	db := storageMock.NewMockDB(ctrl)
@@ -175,3 +391,340 @@ func Test_IsDBReady(t *testing.T) {
 		require.Error(t, storageErrors.ErrStorageIsNotReadyDB, err)
 	})
 }
+
+// poolDB is a ShortURLDB mock implementing PoolStatsDB.
+type poolDB struct {
+	*storageMock.MockDB
+	stats *healthEntity.DBPoolStats
+}
+
+func (db poolDB) PoolStats() *healthEntity.DBPoolStats {
+	return db.stats
+}
+
+func Test_DBPoolStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	t.Run("when DB works over connection pool", func(t *testing.T) {
+		stats := &healthEntity.DBPoolStats{OpenConnections: 5, IdleConnections: 2, WaitCount: 1}
+		storage := ShortURLStorage{db: poolDB{MockDB: storageMock.NewMockDB(ctrl), stats: stats}}
+		require.Equal(t, stats, storage.DBPoolStats())
+	})
+
+	t.Run("when DB has no connection pool", func(t *testing.T) {
+		storage := ShortURLStorage{db: storageMock.NewMockDB(ctrl)}
+		require.Nil(t, storage.DBPoolStats())
+	})
+}
+
+// replicaDB is a ShortURLDB mock implementing ReplicaDB.
+type replicaDB struct {
+	*storageMock.MockDB
+	health *healthEntity.DBReplicaHealth
+}
+
+func (db replicaDB) ReplicaHealth(context.Context) *healthEntity.DBReplicaHealth {
+	return db.health
+}
+
+func Test_DBReplicaHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	t.Run("when DB has read replica", func(t *testing.T) {
+		health := &healthEntity.DBReplicaHealth{Available: true, Pool: &healthEntity.DBPoolStats{OpenConnections: 2}}
+		storage := ShortURLStorage{db: replicaDB{MockDB: storageMock.NewMockDB(ctrl), health: health}}
+		require.Equal(t, health, storage.DBReplicaHealth(ctx))
+	})
+
+	t.Run("when DB has no read replica", func(t *testing.T) {
+		storage := ShortURLStorage{db: storageMock.NewMockDB(ctrl)}
+		require.Nil(t, storage.DBReplicaHealth(ctx))
+	})
+}
+
+// duplicateDB is a ShortURLDB mock implementing DuplicateDB.
+type duplicateDB struct {
+	*storageMock.MockDB
+	found  *entity.ShortURL
+	err    error
+	lookup *string
+}
+
+func (db duplicateDB) FindShortURLBySourceURL(_ context.Context, namespace, sourceURL string) (*entity.ShortURL, error) {
+	*db.lookup = namespace + " " + sourceURL
+	return db.found, db.err
+}
+
+func Test_FindDuplicate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	existing := &entity.ShortURL{Alias: "abc", SourceURL: "https://ya.ru/"}
+
+	tests := []struct {
+		found      *entity.ShortURL
+		dbErr      error
+		user       *userEntity.User
+		wantErr    error
+		want       *entity.ShortURL
+		name       string
+		wantLookup string
+		opts       entity.CreateOptions
+	}{
+		{
+			name:       "when short URL exists",
+			found:      existing,
+			want:       existing,
+			wantLookup: "default https://ya.ru",
+		},
+		{
+			name:       "when short URL exists in namespace of user",
+			user:       &userEntity.User{ID: 1, Namespace: "team"},
+			found:      existing,
+			want:       existing,
+			wantLookup: "team https://ya.ru",
+		},
+		{
+			name:       "when short URL doesn't exist",
+			dbErr:      dbErrors.ErrDBRecordNotFound,
+			wantErr:    storageErrors.ErrStorageRecordNotFound,
+			wantLookup: "default https://ya.ru",
+		},
+		{
+			name:       "when DB fails",
+			dbErr:      dbErrors.ErrDBQuery,
+			wantErr:    dbErrors.ErrDBQuery,
+			wantLookup: "default https://ya.ru",
+		},
+		{
+			name:    "when short URL is never deduplicated",
+			opts:    entity.CreateOptions{OneTimeUse: true},
+			found:   existing,
+			wantErr: storageErrors.ErrStorageRecordNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lookup string
+			storage := ShortURLStorage{
+				db:               duplicateDB{MockDB: storageMock.NewMockDB(ctrl), found: tt.found, err: tt.dbErr, lookup: &lookup},
+				defaultNamespace: "default",
+			}
+
+			got, err := storage.FindDuplicate(ctx, tt.user, "https://ya.ru/", "https://ya.ru", tt.opts)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantLookup, lookup)
+		})
+	}
+
+	t.Run("when DB does not support lookup by source URL", func(t *testing.T) {
+		storage := ShortURLStorage{db: storageMock.NewMockDB(ctrl)}
+		_, err := storage.FindDuplicate(ctx, nil, "https://ya.ru/", "https://ya.ru", entity.CreateOptions{})
+		require.ErrorIs(t, err, storageErrors.ErrStorageRecordNotFound)
+	})
+}
+
+// expiringDB is a ShortURLDB mock implementing ExpiringDB.
+type expiringDB struct {
+	*storageMock.MockDB
+	deleted int64
+	err     error
+}
+
+func (db expiringDB) DeleteExpiredURLs(_ context.Context) (int64, error) {
+	return db.deleted, db.err
+}
+
+func Test_DeleteExpiredURLs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	t.Run("when DB removes expired URLs", func(t *testing.T) {
+		storage := ShortURLStorage{db: expiringDB{MockDB: storageMock.NewMockDB(ctrl), deleted: 3}}
+		n, err := storage.DeleteExpiredURLs(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), n)
+	})
+
+	t.Run("when DB fails to remove expired URLs", func(t *testing.T) {
+		storage := ShortURLStorage{db: expiringDB{MockDB: storageMock.NewMockDB(ctrl), err: dbErrors.ErrDBQuery}}
+		_, err := storage.DeleteExpiredURLs(ctx)
+		require.ErrorIs(t, err, dbErrors.ErrDBQuery)
+	})
+
+	t.Run("when DB does not support expiration", func(t *testing.T) {
+		storage := ShortURLStorage{db: storageMock.NewMockDB(ctrl)}
+		n, err := storage.DeleteExpiredURLs(ctx)
+		require.NoError(t, err)
+		require.Zero(t, n)
+	})
+}
+
+// healthCheckingDB is a ShortURLDB mock implementing HealthCheckingDB.
+type healthCheckingDB struct {
+	*storageMock.MockDB
+	saved *bool
+	urls  []*entity.ShortURL
+	err   error
+}
+
+func (db healthCheckingDB) FindURLsToCheck(_ context.Context, _ time.Time, _, _ int) ([]*entity.ShortURL, error) {
+	return db.urls, db.err
+}
+
+func (db healthCheckingDB) SaveURLHealth(_ context.Context, _, _ string, healthy bool, _ time.Time) error {
+	*db.saved = healthy
+	return db.err
+}
+
+func Test_URLHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("when DB stores health of URLs", func(t *testing.T) {
+		saved := true
+		urls := []*entity.ShortURL{{ID: 1, Alias: "abc", SourceURL: "https://ya.ru"}}
+		storage := ShortURLStorage{db: healthCheckingDB{MockDB: storageMock.NewMockDB(ctrl), urls: urls, saved: &saved}}
+
+		found, err := storage.FindURLsToCheck(ctx, now, 0, 10)
+		require.NoError(t, err)
+		require.Equal(t, urls, found)
+
+		require.NoError(t, storage.SaveURLHealth(ctx, "", "abc", false, now))
+		require.False(t, saved)
+	})
+
+	t.Run("when DB fails", func(t *testing.T) {
+		saved := false
+		storage := ShortURLStorage{db: healthCheckingDB{MockDB: storageMock.NewMockDB(ctrl), err: dbErrors.ErrDBQuery, saved: &saved}}
+
+		_, err := storage.FindURLsToCheck(ctx, now, 0, 10)
+		require.ErrorIs(t, err, dbErrors.ErrDBQuery)
+		require.ErrorIs(t, storage.SaveURLHealth(ctx, "", "abc", true, now), dbErrors.ErrDBQuery)
+	})
+
+	t.Run("when DB does not support health checks", func(t *testing.T) {
+		storage := ShortURLStorage{db: storageMock.NewMockDB(ctrl)}
+
+		found, err := storage.FindURLsToCheck(ctx, now, 0, 10)
+		require.NoError(t, err)
+		require.Empty(t, found)
+		require.NoError(t, storage.SaveURLHealth(ctx, "", "abc", false, now))
+	})
+}
+
+// archivingDB is a ShortURLDB mock implementing ArchivingDB.
+type archivingDB struct {
+	*storageMock.MockDB
+	archived int64
+	err      error
+}
+
+func (db archivingDB) ArchiveDeletedURLs(_ context.Context) (int64, error) {
+	return db.archived, db.err
+}
+
+func Test_ArchiveDeletedURLs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	t.Run("when DB archives deleted URLs", func(t *testing.T) {
+		storage := ShortURLStorage{db: archivingDB{MockDB: storageMock.NewMockDB(ctrl), archived: 2}}
+		n, err := storage.ArchiveDeletedURLs(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), n)
+	})
+
+	t.Run("when DB fails to archive deleted URLs", func(t *testing.T) {
+		storage := ShortURLStorage{db: archivingDB{MockDB: storageMock.NewMockDB(ctrl), err: dbErrors.ErrDBQuery}}
+		_, err := storage.ArchiveDeletedURLs(ctx)
+		require.ErrorIs(t, err, dbErrors.ErrDBQuery)
+	})
+
+	t.Run("when DB does not support archiving", func(t *testing.T) {
+		storage := ShortURLStorage{db: storageMock.NewMockDB(ctrl)}
+		n, err := storage.ArchiveDeletedURLs(ctx)
+		require.NoError(t, err)
+		require.Zero(t, n)
+	})
+}
+
+func Test_Setup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := storageMock.NewMockDB(ctrl)
+
+	t.Run("when alias alphabet is valid", func(t *testing.T) {
+		cfg := &config.Config{App: config.App{AliasLength: 5, AliasAlphabet: generator.NumericAlphabet}}
+		storage, err := Setup(db, cfg)
+		require.NoError(t, err)
+		require.NotNil(t, storage)
+	})
+
+	t.Run("when alias alphabet is invalid", func(t *testing.T) {
+		cfg := &config.Config{App: config.App{AliasLength: 5, AliasAlphabet: "abc"}}
+		_, err := Setup(db, cfg)
+		require.ErrorIs(t, err, genErrors.ErrGeneratorInvalidAlphabet)
+	})
+
+	t.Run("when alias strategy is configured", func(t *testing.T) {
+		for strategy, pattern := range map[string]string{
+			generator.StrategyRandom:     `\A[0-9]{5}\z`,
+			generator.StrategyWord:       `\A[a-z]+-[a-z]+-[0-9]{1,2}\z`,
+			generator.StrategySequential: `\A[0-9]+\z`,
+		} {
+			cfg := &config.Config{App: config.App{AliasLength: 5, AliasAlphabet: generator.NumericAlphabet, AliasStrategy: strategy}}
+			storage, err := Setup(db, cfg)
+			require.NoError(t, err)
+
+			alias, err := storage.gen.Alias()
+			require.NoError(t, err)
+			require.Regexp(t, pattern, alias, strategy)
+		}
+	})
+
+	t.Run("when alias strategy is unknown", func(t *testing.T) {
+		cfg := &config.Config{App: config.App{AliasLength: 5, AliasAlphabet: generator.NumericAlphabet, AliasStrategy: "uuid"}}
+		_, err := Setup(db, cfg)
+		require.ErrorIs(t, err, genErrors.ErrGeneratorUnknownStrategy)
+	})
+
+	t.Run("when short URLs are created in configured region", func(t *testing.T) {
+		ctx := context.Background()
+		cfg := &config.Config{App: config.App{AliasLength: 5, AliasAlphabet: generator.NumericAlphabet, Region: "us-east-1"}}
+		storage, err := Setup(db, cfg)
+		require.NoError(t, err)
+
+		db.EXPECT().SaveShortURL(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, shortURL *entity.ShortURL) (*entity.ShortURL, error) {
+			return shortURL, nil
+		}).Times(2)
+
+		res, err := storage.SaveShortURL(ctx, nil, "https://ya.ru", "https://ya.ru", entity.CreateOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "us-east-1", res.CreatedInRegion)
+
+		res, err = storage.SaveShortURL(ctx, nil, "https://ya.ru", "https://ya.ru", entity.CreateOptions{Visibility: entity.VisibilityPrivate})
+		require.NoError(t, err)
+		require.Equal(t, "us-east-1", res.CreatedInRegion)
+	})
+
+	t.Run("when short URLs are created in configured namespace", func(t *testing.T) {
+		ctx := context.Background()
+		cfg := &config.Config{App: config.App{AliasLength: 5, AliasAlphabet: generator.NumericAlphabet, DefaultNamespace: "marketing"}}
+		storage, err := Setup(db, cfg)
+		require.NoError(t, err)
+
+		db.EXPECT().SaveShortURL(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, shortURL *entity.ShortURL) (*entity.ShortURL, error) {
+			return shortURL, nil
+		}).Times(2)
+
+		res, err := storage.SaveShortURL(ctx, nil, "https://ya.ru", "https://ya.ru", entity.CreateOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "marketing", res.Namespace)
+
+		res, err = storage.SaveShortURL(ctx, &userEntity.User{ID: 1, Namespace: "team-a"}, "https://ya.ru", "https://ya.ru", entity.CreateOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "team-a", res.Namespace)
+	})
+}
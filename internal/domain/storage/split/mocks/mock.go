@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gururuby/shortener/internal/domain/storage/split (interfaces: DB)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock.go -package=mocks . DB
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/split"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDB is a mock of DB interface.
+type MockDB struct {
+	ctrl     *gomock.Controller
+	recorder *MockDBMockRecorder
+	isgomock struct{}
+}
+
+// MockDBMockRecorder is the mock recorder for MockDB.
+type MockDBMockRecorder struct {
+	mock *MockDB
+}
+
+// NewMockDB creates a new mock instance.
+func NewMockDB(ctrl *gomock.Controller) *MockDB {
+	mock := &MockDB{ctrl: ctrl}
+	mock.recorder = &MockDBMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDB) EXPECT() *MockDBMockRecorder {
+	return m.recorder
+}
+
+// FindSplit mocks base method.
+func (m *MockDB) FindSplit(ctx context.Context, namespace, alias string) (*entity.MultiDestination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSplit", ctx, namespace, alias)
+	ret0, _ := ret[0].(*entity.MultiDestination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSplit indicates an expected call of FindSplit.
+func (mr *MockDBMockRecorder) FindSplit(ctx, namespace, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSplit", reflect.TypeOf((*MockDB)(nil).FindSplit), ctx, namespace, alias)
+}
+
+// SaveSplit mocks base method.
+func (m *MockDB) SaveSplit(ctx context.Context, split *entity.MultiDestination) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSplit", ctx, split)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSplit indicates an expected call of SaveSplit.
+func (mr *MockDBMockRecorder) SaveSplit(ctx, split any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSplit", reflect.TypeOf((*MockDB)(nil).SaveSplit), ctx, split)
+}
+
+// SaveSplitClick mocks base method.
+func (m *MockDB) SaveSplitClick(ctx context.Context, namespace, alias, url string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSplitClick", ctx, namespace, alias, url)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSplitClick indicates an expected call of SaveSplitClick.
+func (mr *MockDBMockRecorder) SaveSplitClick(ctx, namespace, alias, url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSplitClick", reflect.TypeOf((*MockDB)(nil).SaveSplitClick), ctx, namespace, alias, url)
+}
@@ -0,0 +1,84 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . DB
+
+/*
+Package storage provides data persistence implementations for split short URLs.
+
+It includes:
+- Database interface for short URLs splitting traffic between several original URLs
+- Storage layer implementation
+*/
+package storage
+
+import (
+	"context"
+
+	splitEntity "github.com/gururuby/shortener/internal/domain/entity/split"
+)
+
+// DB defines the interface for split database operations.
+type DB interface {
+	// SaveSplit stores a new split short URL.
+	// Returns:
+	// - error: If a short URL or a split with the alias already exists or database operation fails
+	SaveSplit(ctx context.Context, split *splitEntity.MultiDestination) error
+
+	// FindSplit retrieves a split short URL by its namespace and alias.
+	// Returns:
+	// - *splitEntity.MultiDestination: Split with destination click counts
+	// - error: If split doesn't exist or database operation fails
+	FindSplit(ctx context.Context, namespace, alias string) (*splitEntity.MultiDestination, error)
+
+	// SaveSplitClick increments click count of a destination of a split short URL.
+	// Returns:
+	// - error: If split has no such destination or database operation fails
+	SaveSplitClick(ctx context.Context, namespace, alias, url string) error
+}
+
+// SplitStorage implements the storage layer for split operations.
+// It acts as an intermediary between the domain and database layers.
+type SplitStorage struct {
+	db DB // Database interface implementation
+}
+
+// Setup creates and initializes a new SplitStorage instance.
+// Parameters:
+// - db: The database implementation to use
+// Returns:
+// - *SplitStorage: Initialized storage instance
+func Setup(db DB) *SplitStorage {
+	return &SplitStorage{db: db}
+}
+
+// SaveSplit stores a new split short URL.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - split: Split to save
+// Returns:
+// - error: If operation fails
+func (s *SplitStorage) SaveSplit(ctx context.Context, split *splitEntity.MultiDestination) error {
+	return s.db.SaveSplit(ctx, split)
+}
+
+// FindSplit retrieves a split short URL by its namespace and alias.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - namespace: Namespace of the split, empty for the default one
+// - alias: Split alias
+// Returns:
+// - *splitEntity.MultiDestination: Found split
+// - error: If operation fails
+func (s *SplitStorage) FindSplit(ctx context.Context, namespace, alias string) (*splitEntity.MultiDestination, error) {
+	return s.db.FindSplit(ctx, namespace, alias)
+}
+
+// SaveSplitClick increments click count of a destination of a split short URL.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - namespace: Namespace of the split, empty for the default one
+// - alias: Split alias
+// - url: Original URL of the destination
+// Returns:
+// - error: If operation fails
+func (s *SplitStorage) SaveSplitClick(ctx context.Context, namespace, alias, url string) error {
+	return s.db.SaveSplitClick(ctx, namespace, alias, url)
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	splitEntity "github.com/gururuby/shortener/internal/domain/entity/split"
+	storageMock "github.com/gururuby/shortener/internal/domain/storage/split/mocks"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Storage_Splits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := storageMock.NewMockDB(ctrl)
+	ctx := context.Background()
+
+	storage := Setup(db)
+
+	t.Run("when calls are passed to db", func(t *testing.T) {
+		split := &splitEntity.MultiDestination{
+			Alias:  "ab-test",
+			UserID: 1,
+			Destinations: []splitEntity.Destination{
+				{URL: "https://v1.com", Weight: 70},
+				{URL: "https://v2.com", Weight: 30},
+			},
+		}
+
+		db.EXPECT().SaveSplit(ctx, split).Return(nil)
+		db.EXPECT().FindSplit(ctx, "team", "ab-test").Return(split, nil)
+		db.EXPECT().SaveSplitClick(ctx, "team", "ab-test", "https://v1.com").Return(nil)
+
+		require.NoError(t, storage.SaveSplit(ctx, split))
+
+		found, err := storage.FindSplit(ctx, "team", "ab-test")
+		require.NoError(t, err)
+		require.Equal(t, split, found)
+
+		require.NoError(t, storage.SaveSplitClick(ctx, "team", "ab-test", "https://v1.com"))
+	})
+
+	t.Run("when db query fails", func(t *testing.T) {
+		db.EXPECT().FindSplit(ctx, "team", "unknown").Return(nil, dbErrors.ErrDBRecordNotFound)
+
+		_, err := storage.FindSplit(ctx, "team", "unknown")
+		require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+	})
+}
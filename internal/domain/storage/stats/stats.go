@@ -0,0 +1,80 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . DB
+
+/*
+Package storage provides data persistence implementations for service statistics.
+
+It includes:
+- Database interface for aggregate counters
+- Storage layer implementation
+*/
+package storage
+
+import (
+	"context"
+)
+
+// DB defines the interface for statistics database operations.
+type DB interface {
+	// CountURLs returns the number of all short URLs, including deleted ones.
+	// Returns:
+	// - int64: Number of short URLs
+	// - error: If database operation fails
+	CountURLs(ctx context.Context) (int64, error)
+
+	// CountDeletedURLs returns the number of short URLs marked as deleted.
+	// Returns:
+	// - int64: Number of deleted short URLs
+	// - error: If database operation fails
+	CountDeletedURLs(ctx context.Context) (int64, error)
+
+	// CountUsers returns the number of registered users.
+	// Returns:
+	// - int64: Number of users
+	// - error: If database operation fails
+	CountUsers(ctx context.Context) (int64, error)
+}
+
+// StatsStorage implements the storage layer for statistics operations.
+// It acts as an intermediary between the domain and database layers.
+type StatsStorage struct {
+	db DB // Database interface implementation
+}
+
+// Setup creates and initializes a new StatsStorage instance.
+// Parameters:
+// - db: The database implementation to use
+// Returns:
+// - *StatsStorage: Initialized storage instance
+func Setup(db DB) *StatsStorage {
+	return &StatsStorage{db: db}
+}
+
+// CountURLs returns the number of all short URLs, including deleted ones.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// Returns:
+// - int64: Number of short URLs
+// - error: If operation fails
+func (s *StatsStorage) CountURLs(ctx context.Context) (int64, error) {
+	return s.db.CountURLs(ctx)
+}
+
+// CountDeletedURLs returns the number of short URLs marked as deleted.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// Returns:
+// - int64: Number of deleted short URLs
+// - error: If operation fails
+func (s *StatsStorage) CountDeletedURLs(ctx context.Context) (int64, error) {
+	return s.db.CountDeletedURLs(ctx)
+}
+
+// CountUsers returns the number of registered users.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// Returns:
+// - int64: Number of users
+// - error: If operation fails
+func (s *StatsStorage) CountUsers(ctx context.Context) (int64, error) {
+	return s.db.CountUsers(ctx)
+}
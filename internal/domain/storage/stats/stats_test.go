@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	storageMock "github.com/gururuby/shortener/internal/domain/storage/stats/mocks"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Storage_Counts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := storageMock.NewMockDB(ctrl)
+	ctx := context.Background()
+
+	storage := Setup(db)
+
+	t.Run("when counts are returned by db", func(t *testing.T) {
+		db.EXPECT().CountURLs(ctx).Return(int64(10), nil)
+		db.EXPECT().CountDeletedURLs(ctx).Return(int64(3), nil)
+		db.EXPECT().CountUsers(ctx).Return(int64(2), nil)
+
+		urls, err := storage.CountURLs(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(10), urls)
+
+		deleted, err := storage.CountDeletedURLs(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), deleted)
+
+		users, err := storage.CountUsers(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), users)
+	})
+
+	t.Run("when db query fails", func(t *testing.T) {
+		db.EXPECT().CountURLs(ctx).Return(int64(0), dbErrors.ErrDBQuery)
+
+		_, err := storage.CountURLs(ctx)
+		require.ErrorIs(t, err, dbErrors.ErrDBQuery)
+	})
+}
@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gururuby/shortener/internal/domain/storage/tag (interfaces: DB)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock.go -package=mocks . DB
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	entity0 "github.com/gururuby/shortener/internal/domain/entity/tag"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDB is a mock of DB interface.
+type MockDB struct {
+	ctrl     *gomock.Controller
+	recorder *MockDBMockRecorder
+	isgomock struct{}
+}
+
+// MockDBMockRecorder is the mock recorder for MockDB.
+type MockDBMockRecorder struct {
+	mock *MockDB
+}
+
+// NewMockDB creates a new mock instance.
+func NewMockDB(ctrl *gomock.Controller) *MockDB {
+	mock := &MockDB{ctrl: ctrl}
+	mock.recorder = &MockDBMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDB) EXPECT() *MockDBMockRecorder {
+	return m.recorder
+}
+
+// AssignTag mocks base method.
+func (m *MockDB) AssignTag(ctx context.Context, userID int, namespace, alias, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignTag", ctx, userID, namespace, alias, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignTag indicates an expected call of AssignTag.
+func (mr *MockDBMockRecorder) AssignTag(ctx, userID, namespace, alias, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTag", reflect.TypeOf((*MockDB)(nil).AssignTag), ctx, userID, namespace, alias, name)
+}
+
+// FindTagsByUser mocks base method.
+func (m *MockDB) FindTagsByUser(ctx context.Context, userID int) ([]*entity0.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTagsByUser", ctx, userID)
+	ret0, _ := ret[0].([]*entity0.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTagsByUser indicates an expected call of FindTagsByUser.
+func (mr *MockDBMockRecorder) FindTagsByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTagsByUser", reflect.TypeOf((*MockDB)(nil).FindTagsByUser), ctx, userID)
+}
+
+// FindURLTags mocks base method.
+func (m *MockDB) FindURLTags(ctx context.Context, userID int, namespace, alias string) ([]*entity0.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindURLTags", ctx, userID, namespace, alias)
+	ret0, _ := ret[0].([]*entity0.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindURLTags indicates an expected call of FindURLTags.
+func (mr *MockDBMockRecorder) FindURLTags(ctx, userID, namespace, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindURLTags", reflect.TypeOf((*MockDB)(nil).FindURLTags), ctx, userID, namespace, alias)
+}
+
+// FindURLsByTag mocks base method.
+func (m *MockDB) FindURLsByTag(ctx context.Context, userID int, name string) ([]*entity.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindURLsByTag", ctx, userID, name)
+	ret0, _ := ret[0].([]*entity.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindURLsByTag indicates an expected call of FindURLsByTag.
+func (mr *MockDBMockRecorder) FindURLsByTag(ctx, userID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindURLsByTag", reflect.TypeOf((*MockDB)(nil).FindURLsByTag), ctx, userID, name)
+}
+
+// RemoveTag mocks base method.
+func (m *MockDB) RemoveTag(ctx context.Context, userID int, namespace, alias, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTag", ctx, userID, namespace, alias, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTag indicates an expected call of RemoveTag.
+func (mr *MockDBMockRecorder) RemoveTag(ctx, userID, namespace, alias, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTag", reflect.TypeOf((*MockDB)(nil).RemoveTag), ctx, userID, namespace, alias, name)
+}
+
+// SaveTag mocks base method.
+func (m *MockDB) SaveTag(ctx context.Context, tag *entity0.Tag) (*entity0.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTag", ctx, tag)
+	ret0, _ := ret[0].(*entity0.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveTag indicates an expected call of SaveTag.
+func (mr *MockDBMockRecorder) SaveTag(ctx, tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTag", reflect.TypeOf((*MockDB)(nil).SaveTag), ctx, tag)
+}
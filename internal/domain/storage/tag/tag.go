@@ -0,0 +1,142 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . DB
+
+/*
+Package storage provides data persistence implementations for URL tags.
+
+It includes:
+- Database interface for tags and their assignment to short URLs
+- Storage layer implementation
+*/
+package storage
+
+import (
+	"context"
+
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	tagEntity "github.com/gururuby/shortener/internal/domain/entity/tag"
+)
+
+// DB defines the interface for tag database operations.
+type DB interface {
+	// SaveTag stores a new tag of a user.
+	// Returns:
+	// - *tagEntity.Tag: Saved tag with ID
+	// - error: If user already has a tag with the same name or database operation fails
+	SaveTag(ctx context.Context, tag *tagEntity.Tag) (*tagEntity.Tag, error)
+
+	// FindTagsByUser retrieves all tags of a user.
+	// Returns:
+	// - []*tagEntity.Tag: User's tags ordered by name
+	// - error: If database operation fails
+	FindTagsByUser(ctx context.Context, userID int) ([]*tagEntity.Tag, error)
+
+	// FindURLTags retrieves tags assigned to a short URL of a user.
+	// Returns:
+	// - []*tagEntity.Tag: Assigned tags ordered by name
+	// - error: If user has no such URL or database operation fails
+	FindURLTags(ctx context.Context, userID int, namespace, alias string) ([]*tagEntity.Tag, error)
+
+	// AssignTag associates a tag of a user with a short URL of the same user.
+	// Returns:
+	// - error: If user has no such URL or tag or database operation fails
+	AssignTag(ctx context.Context, userID int, namespace, alias, name string) error
+
+	// RemoveTag removes association of a tag of a user with a short URL.
+	// Returns:
+	// - error: If database operation fails
+	RemoveTag(ctx context.Context, userID int, namespace, alias, name string) error
+
+	// FindURLsByTag retrieves short URLs of a user having the tag assigned.
+	// Returns:
+	// - []*shortURLEntity.ShortURL: Tagged URLs ordered by ID
+	// - error: If database operation fails
+	FindURLsByTag(ctx context.Context, userID int, name string) ([]*shortURLEntity.ShortURL, error)
+}
+
+// TagStorage implements the storage layer for tag operations.
+// It acts as an intermediary between the domain and database layers.
+type TagStorage struct {
+	db DB // Database interface implementation
+}
+
+// Setup creates and initializes a new TagStorage instance.
+// Parameters:
+// - db: The database implementation to use
+// Returns:
+// - *TagStorage: Initialized storage instance
+func Setup(db DB) *TagStorage {
+	return &TagStorage{db: db}
+}
+
+// SaveTag stores a new tag of a user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - tag: Tag to save
+// Returns:
+// - *tagEntity.Tag: Saved tag with ID
+// - error: If operation fails
+func (s *TagStorage) SaveTag(ctx context.Context, tag *tagEntity.Tag) (*tagEntity.Tag, error) {
+	return s.db.SaveTag(ctx, tag)
+}
+
+// FindTagsByUser retrieves all tags of a user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: Owner of the tags
+// Returns:
+// - []*tagEntity.Tag: User's tags ordered by name
+// - error: If operation fails
+func (s *TagStorage) FindTagsByUser(ctx context.Context, userID int) ([]*tagEntity.Tag, error) {
+	return s.db.FindTagsByUser(ctx, userID)
+}
+
+// FindURLTags retrieves tags assigned to a short URL of a user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: Owner of the URL
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// Returns:
+// - []*tagEntity.Tag: Assigned tags ordered by name
+// - error: If operation fails
+func (s *TagStorage) FindURLTags(ctx context.Context, userID int, namespace, alias string) ([]*tagEntity.Tag, error) {
+	return s.db.FindURLTags(ctx, userID, namespace, alias)
+}
+
+// AssignTag associates a tag of a user with a short URL of the same user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: Owner of the URL and the tag
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// - name: Tag name
+// Returns:
+// - error: If operation fails
+func (s *TagStorage) AssignTag(ctx context.Context, userID int, namespace, alias, name string) error {
+	return s.db.AssignTag(ctx, userID, namespace, alias, name)
+}
+
+// RemoveTag removes association of a tag of a user with a short URL.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: Owner of the URL and the tag
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// - name: Tag name
+// Returns:
+// - error: If operation fails
+func (s *TagStorage) RemoveTag(ctx context.Context, userID int, namespace, alias, name string) error {
+	return s.db.RemoveTag(ctx, userID, namespace, alias, name)
+}
+
+// FindURLsByTag retrieves short URLs of a user having the tag assigned.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: Owner of the URLs
+// - name: Tag name
+// Returns:
+// - []*shortURLEntity.ShortURL: Tagged URLs ordered by ID
+// - error: If operation fails
+func (s *TagStorage) FindURLsByTag(ctx context.Context, userID int, name string) ([]*shortURLEntity.ShortURL, error) {
+	return s.db.FindURLsByTag(ctx, userID, name)
+}
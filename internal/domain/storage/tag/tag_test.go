@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	tagEntity "github.com/gururuby/shortener/internal/domain/entity/tag"
+	storageMock "github.com/gururuby/shortener/internal/domain/storage/tag/mocks"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Storage_Tags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := storageMock.NewMockDB(ctrl)
+	ctx := context.Background()
+
+	storage := Setup(db)
+
+	t.Run("when calls are passed to db", func(t *testing.T) {
+		tag := &tagEntity.Tag{UserID: 1, Name: "work"}
+		saved := &tagEntity.Tag{ID: 1, UserID: 1, Name: "work"}
+		urls := []*shortURLEntity.ShortURL{{ID: 1, Alias: "abc", SourceURL: "https://ya.ru"}}
+
+		db.EXPECT().SaveTag(ctx, tag).Return(saved, nil)
+		db.EXPECT().FindTagsByUser(ctx, 1).Return([]*tagEntity.Tag{saved}, nil)
+		db.EXPECT().FindURLTags(ctx, 1, "team", "abc").Return([]*tagEntity.Tag{saved}, nil)
+		db.EXPECT().AssignTag(ctx, 1, "team", "abc", "work").Return(nil)
+		db.EXPECT().RemoveTag(ctx, 1, "team", "abc", "work").Return(nil)
+		db.EXPECT().FindURLsByTag(ctx, 1, "work").Return(urls, nil)
+
+		res, err := storage.SaveTag(ctx, tag)
+		require.NoError(t, err)
+		require.Equal(t, saved, res)
+
+		tags, err := storage.FindTagsByUser(ctx, 1)
+		require.NoError(t, err)
+		require.Equal(t, []*tagEntity.Tag{saved}, tags)
+
+		tags, err = storage.FindURLTags(ctx, 1, "team", "abc")
+		require.NoError(t, err)
+		require.Equal(t, []*tagEntity.Tag{saved}, tags)
+
+		require.NoError(t, storage.AssignTag(ctx, 1, "team", "abc", "work"))
+		require.NoError(t, storage.RemoveTag(ctx, 1, "team", "abc", "work"))
+
+		found, err := storage.FindURLsByTag(ctx, 1, "work")
+		require.NoError(t, err)
+		require.Equal(t, urls, found)
+	})
+
+	t.Run("when db query fails", func(t *testing.T) {
+		db.EXPECT().AssignTag(ctx, 1, "team", "abc", "work").Return(dbErrors.ErrDBRecordNotFound)
+
+		err := storage.AssignTag(ctx, 1, "team", "abc", "work")
+		require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+	})
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/gururuby/shortener/internal/domain/storage/user (interfaces: DB)
+// Source: github.com/gururuby/shortener/internal/domain/storage/user (interfaces: UserDB,ArchiveDB)
 //
 // Generated by this command:
 //
-//	mockgen -destination=./mocks/mock.go -package=mocks . DB
+//	mockgen -destination=./mocks/mock.go -package=mocks -mock_names UserDB=MockDB . UserDB,ArchiveDB
 //
 
 // Package mocks is a generated GoMock package.
@@ -13,16 +13,17 @@ import (
 	context "context"
 	reflect "reflect"
 
-	entity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
-	entity0 "github.com/gururuby/shortener/internal/domain/entity/user"
+	entity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	entity0 "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	entity1 "github.com/gururuby/shortener/internal/domain/entity/user"
 	gomock "go.uber.org/mock/gomock"
 )
 
-// MockDB is a mock of DB interface.
+// MockDB is a mock of UserDB interface.
 type MockDB struct {
-	isgomock struct{}
 	ctrl     *gomock.Controller
 	recorder *MockDBMockRecorder
+	isgomock struct{}
 }
 
 // MockDBMockRecorder is the mock recorder for MockDB.
@@ -42,11 +43,56 @@ func (m *MockDB) EXPECT() *MockDBMockRecorder {
 	return m.recorder
 }
 
+// CountUserURLs mocks base method.
+func (m *MockDB) CountUserURLs(ctx context.Context, id int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserURLs", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserURLs indicates an expected call of CountUserURLs.
+func (mr *MockDBMockRecorder) CountUserURLs(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserURLs", reflect.TypeOf((*MockDB)(nil).CountUserURLs), ctx, id)
+}
+
+// FindShortURL mocks base method.
+func (m *MockDB) FindShortURL(ctx context.Context, namespace, alias string) (*entity0.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindShortURL", ctx, namespace, alias)
+	ret0, _ := ret[0].(*entity0.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindShortURL indicates an expected call of FindShortURL.
+func (mr *MockDBMockRecorder) FindShortURL(ctx, namespace, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShortURL", reflect.TypeOf((*MockDB)(nil).FindShortURL), ctx, namespace, alias)
+}
+
+// FindURLHistory mocks base method.
+func (m *MockDB) FindURLHistory(ctx context.Context, namespace, alias string) ([]*entity0.HistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindURLHistory", ctx, namespace, alias)
+	ret0, _ := ret[0].([]*entity0.HistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindURLHistory indicates an expected call of FindURLHistory.
+func (mr *MockDBMockRecorder) FindURLHistory(ctx, namespace, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindURLHistory", reflect.TypeOf((*MockDB)(nil).FindURLHistory), ctx, namespace, alias)
+}
+
 // FindUser mocks base method.
-func (m *MockDB) FindUser(ctx context.Context, id int) (*entity0.User, error) {
+func (m *MockDB) FindUser(ctx context.Context, id int) (*entity1.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindUser", ctx, id)
-	ret0, _ := ret[0].(*entity0.User)
+	ret0, _ := ret[0].(*entity1.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -57,11 +103,41 @@ func (mr *MockDBMockRecorder) FindUser(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUser", reflect.TypeOf((*MockDB)(nil).FindUser), ctx, id)
 }
 
+// FindUserByAPIKey mocks base method.
+func (m *MockDB) FindUserByAPIKey(ctx context.Context, keyHash string) (*entity1.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserByAPIKey", ctx, keyHash)
+	ret0, _ := ret[0].(*entity1.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserByAPIKey indicates an expected call of FindUserByAPIKey.
+func (mr *MockDBMockRecorder) FindUserByAPIKey(ctx, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByAPIKey", reflect.TypeOf((*MockDB)(nil).FindUserByAPIKey), ctx, keyHash)
+}
+
+// FindUserClicks mocks base method.
+func (m *MockDB) FindUserClicks(ctx context.Context, userID int) ([]*entity.Click, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserClicks", ctx, userID)
+	ret0, _ := ret[0].([]*entity.Click)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserClicks indicates an expected call of FindUserClicks.
+func (mr *MockDBMockRecorder) FindUserClicks(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserClicks", reflect.TypeOf((*MockDB)(nil).FindUserClicks), ctx, userID)
+}
+
 // FindUserURLs mocks base method.
-func (m *MockDB) FindUserURLs(ctx context.Context, id int) ([]*entity.ShortURL, error) {
+func (m *MockDB) FindUserURLs(ctx context.Context, id int) ([]*entity0.ShortURL, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindUserURLs", ctx, id)
-	ret0, _ := ret[0].([]*entity.ShortURL)
+	ret0, _ := ret[0].([]*entity0.ShortURL)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -72,25 +148,99 @@ func (mr *MockDBMockRecorder) FindUserURLs(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserURLs", reflect.TypeOf((*MockDB)(nil).FindUserURLs), ctx, id)
 }
 
+// FindUserURLsCursor mocks base method.
+func (m *MockDB) FindUserURLsCursor(ctx context.Context, id, afterID, limit int) ([]*entity0.ShortURL, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserURLsCursor", ctx, id, afterID, limit)
+	ret0, _ := ret[0].([]*entity0.ShortURL)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindUserURLsCursor indicates an expected call of FindUserURLsCursor.
+func (mr *MockDBMockRecorder) FindUserURLsCursor(ctx, id, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserURLsCursor", reflect.TypeOf((*MockDB)(nil).FindUserURLsCursor), ctx, id, afterID, limit)
+}
+
+// FindUserURLsPaginated mocks base method.
+func (m *MockDB) FindUserURLsPaginated(ctx context.Context, id, offset, limit int) ([]*entity0.ShortURL, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserURLsPaginated", ctx, id, offset, limit)
+	ret0, _ := ret[0].([]*entity0.ShortURL)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindUserURLsPaginated indicates an expected call of FindUserURLsPaginated.
+func (mr *MockDBMockRecorder) FindUserURLsPaginated(ctx, id, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserURLsPaginated", reflect.TypeOf((*MockDB)(nil).FindUserURLsPaginated), ctx, id, offset, limit)
+}
+
 // MarkURLAsDeleted mocks base method.
-func (m *MockDB) MarkURLAsDeleted(ctx context.Context, userID int, aliases []string) error {
+func (m *MockDB) MarkURLAsDeleted(ctx context.Context, userID int, namespace string, aliases []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkURLAsDeleted", ctx, userID, aliases)
+	ret := m.ctrl.Call(m, "MarkURLAsDeleted", ctx, userID, namespace, aliases)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // MarkURLAsDeleted indicates an expected call of MarkURLAsDeleted.
-func (mr *MockDBMockRecorder) MarkURLAsDeleted(ctx, userID, aliases any) *gomock.Call {
+func (mr *MockDBMockRecorder) MarkURLAsDeleted(ctx, userID, namespace, aliases any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkURLAsDeleted", reflect.TypeOf((*MockDB)(nil).MarkURLAsDeleted), ctx, userID, aliases)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkURLAsDeleted", reflect.TypeOf((*MockDB)(nil).MarkURLAsDeleted), ctx, userID, namespace, aliases)
+}
+
+// RestoreURL mocks base method.
+func (m *MockDB) RestoreURL(ctx context.Context, userID int, namespace, alias string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreURL", ctx, userID, namespace, alias)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreURL indicates an expected call of RestoreURL.
+func (mr *MockDBMockRecorder) RestoreURL(ctx, userID, namespace, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreURL", reflect.TypeOf((*MockDB)(nil).RestoreURL), ctx, userID, namespace, alias)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockDB) RevokeAPIKey(ctx context.Context, userID int, keyHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, userID, keyHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockDBMockRecorder) RevokeAPIKey(ctx, userID, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockDB)(nil).RevokeAPIKey), ctx, userID, keyHash)
+}
+
+// SaveAPIKey mocks base method.
+func (m *MockDB) SaveAPIKey(ctx context.Context, userID int, keyHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAPIKey", ctx, userID, keyHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAPIKey indicates an expected call of SaveAPIKey.
+func (mr *MockDBMockRecorder) SaveAPIKey(ctx, userID, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAPIKey", reflect.TypeOf((*MockDB)(nil).SaveAPIKey), ctx, userID, keyHash)
 }
 
 // SaveUser mocks base method.
-func (m *MockDB) SaveUser(ctx context.Context) (*entity0.User, error) {
+func (m *MockDB) SaveUser(ctx context.Context) (*entity1.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SaveUser", ctx)
-	ret0, _ := ret[0].(*entity0.User)
+	ret0, _ := ret[0].(*entity1.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -100,3 +250,114 @@ func (mr *MockDBMockRecorder) SaveUser(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUser", reflect.TypeOf((*MockDB)(nil).SaveUser), ctx)
 }
+
+// SearchUserURLs mocks base method.
+func (m *MockDB) SearchUserURLs(ctx context.Context, userID int, query string, offset, limit int) ([]*entity0.ShortURL, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchUserURLs", ctx, userID, query, offset, limit)
+	ret0, _ := ret[0].([]*entity0.ShortURL)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchUserURLs indicates an expected call of SearchUserURLs.
+func (mr *MockDBMockRecorder) SearchUserURLs(ctx, userID, query, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUserURLs", reflect.TypeOf((*MockDB)(nil).SearchUserURLs), ctx, userID, query, offset, limit)
+}
+
+// SetUserActive mocks base method.
+func (m *MockDB) SetUserActive(ctx context.Context, userID int, isActive bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserActive", ctx, userID, isActive)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserActive indicates an expected call of SetUserActive.
+func (mr *MockDBMockRecorder) SetUserActive(ctx, userID, isActive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserActive", reflect.TypeOf((*MockDB)(nil).SetUserActive), ctx, userID, isActive)
+}
+
+// SetUserCustomDomain mocks base method.
+func (m *MockDB) SetUserCustomDomain(ctx context.Context, userID int, customDomain string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserCustomDomain", ctx, userID, customDomain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserCustomDomain indicates an expected call of SetUserCustomDomain.
+func (mr *MockDBMockRecorder) SetUserCustomDomain(ctx, userID, customDomain any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserCustomDomain", reflect.TypeOf((*MockDB)(nil).SetUserCustomDomain), ctx, userID, customDomain)
+}
+
+// UpdateURLTarget mocks base method.
+func (m *MockDB) UpdateURLTarget(ctx context.Context, userID int, namespace, alias, newURL string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateURLTarget", ctx, userID, namespace, alias, newURL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateURLTarget indicates an expected call of UpdateURLTarget.
+func (mr *MockDBMockRecorder) UpdateURLTarget(ctx, userID, namespace, alias, newURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateURLTarget", reflect.TypeOf((*MockDB)(nil).UpdateURLTarget), ctx, userID, namespace, alias, newURL)
+}
+
+// MockArchiveDB is a mock of ArchiveDB interface.
+type MockArchiveDB struct {
+	ctrl     *gomock.Controller
+	recorder *MockArchiveDBMockRecorder
+	isgomock struct{}
+}
+
+// MockArchiveDBMockRecorder is the mock recorder for MockArchiveDB.
+type MockArchiveDBMockRecorder struct {
+	mock *MockArchiveDB
+}
+
+// NewMockArchiveDB creates a new mock instance.
+func NewMockArchiveDB(ctrl *gomock.Controller) *MockArchiveDB {
+	mock := &MockArchiveDB{ctrl: ctrl}
+	mock.recorder = &MockArchiveDBMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArchiveDB) EXPECT() *MockArchiveDBMockRecorder {
+	return m.recorder
+}
+
+// FindArchivedURLs mocks base method.
+func (m *MockArchiveDB) FindArchivedURLs(ctx context.Context, userID, limit, offset int) ([]*entity0.ArchivedURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindArchivedURLs", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]*entity0.ArchivedURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindArchivedURLs indicates an expected call of FindArchivedURLs.
+func (mr *MockArchiveDBMockRecorder) FindArchivedURLs(ctx, userID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindArchivedURLs", reflect.TypeOf((*MockArchiveDB)(nil).FindArchivedURLs), ctx, userID, limit, offset)
+}
+
+// RestoreArchivedURL mocks base method.
+func (m *MockArchiveDB) RestoreArchivedURL(ctx context.Context, userID int, namespace, alias string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreArchivedURL", ctx, userID, namespace, alias)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreArchivedURL indicates an expected call of RestoreArchivedURL.
+func (mr *MockArchiveDBMockRecorder) RestoreArchivedURL(ctx, userID, namespace, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreArchivedURL", reflect.TypeOf((*MockArchiveDB)(nil).RestoreArchivedURL), ctx, userID, namespace, alias)
+}
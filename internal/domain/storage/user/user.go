@@ -1,4 +1,4 @@
-//go:generate mockgen -destination=./mocks/mock.go -package=mocks . DB
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks -mock_names UserDB=MockDB . UserDB,ArchiveDB
 
 /*
 Package storage provides data persistence implementations for user-related operations.
@@ -13,6 +13,9 @@ package storage
 import (
 	"context"
 
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+
+	analyticsEntity "github.com/gururuby/shortener/internal/domain/entity/analytics"
 	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
 	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
 )
@@ -31,6 +34,39 @@ type UserDB interface {
 	// - error: If database operation fails
 	FindUserURLs(ctx context.Context, id int) ([]*shortURLEntity.ShortURL, error)
 
+	// FindUserURLsPaginated retrieves a page of short URLs belonging to a user.
+	// Returns:
+	// - []*shortURLEntity.ShortURL: Requested page of user's short URLs
+	// - int64: Total number of user's short URLs
+	// - error: If database operation fails
+	FindUserURLsPaginated(ctx context.Context, id, offset, limit int) ([]*shortURLEntity.ShortURL, int64, error)
+
+	// SearchUserURLs retrieves a page of short URLs of a user whose original URL contains the query.
+	// Returns:
+	// - []*shortURLEntity.ShortURL: Requested page of found short URLs
+	// - int64: Total number of found short URLs
+	// - error: If database operation fails
+	SearchUserURLs(ctx context.Context, userID int, query string, offset, limit int) ([]*shortURLEntity.ShortURL, int64, error)
+
+	// FindUserClicks retrieves clicks of all short URLs of a user.
+	// Returns:
+	// - []*analyticsEntity.Click: Clicks ordered by click time
+	// - error: If database operation fails
+	FindUserClicks(ctx context.Context, userID int) ([]*analyticsEntity.Click, error)
+
+	// CountUserURLs returns the number of not deleted short URLs of a user.
+	// Returns:
+	// - int64: Number of user's short URLs, of anonymous ones if id is 0
+	// - error: If database operation fails
+	CountUserURLs(ctx context.Context, id int) (int64, error)
+
+	// FindUserURLsCursor retrieves short URLs belonging to a user with ID greater than afterID.
+	// Returns:
+	// - []*shortURLEntity.ShortURL: Requested page of user's short URLs ordered by ID
+	// - int: ID of the last returned URL, 0 if there are no more URLs
+	// - error: If database operation fails
+	FindUserURLsCursor(ctx context.Context, id, afterID, limit int) ([]*shortURLEntity.ShortURL, int, error)
+
 	// SaveUser creates and persists a new user.
 	// Returns:
 	// - *userEntity.User: The created user
@@ -40,7 +76,69 @@ type UserDB interface {
 	// MarkURLAsDeleted soft-deletes the specified URLs for a user.
 	// Returns:
 	// - error: If database operation fails or URLs don't belong to user
-	MarkURLAsDeleted(ctx context.Context, userID int, aliases []string) error
+	MarkURLAsDeleted(ctx context.Context, userID int, namespace string, aliases []string) error
+
+	// FindShortURL retrieves a short URL of any owner by its namespace and alias, including deleted one.
+	// Returns:
+	// - *shortURLEntity.ShortURL: The found short URL
+	// - error: If URL is not found or database operation fails
+	FindShortURL(ctx context.Context, namespace, alias string) (*shortURLEntity.ShortURL, error)
+
+	// RestoreURL clears the deletion mark of a short URL of a user.
+	// Returns:
+	// - error: If database operation fails or URL doesn't belong to user
+	RestoreURL(ctx context.Context, userID int, namespace, alias string) error
+
+	// UpdateURLTarget replaces the original URL of a short URL of a user saving the previous one to history.
+	// Returns:
+	// - error: If database operation fails or URL doesn't belong to user
+	UpdateURLTarget(ctx context.Context, userID int, namespace, alias, newURL string) error
+
+	// FindURLHistory retrieves previous original URLs of a short URL.
+	// Returns:
+	// - []*shortURLEntity.HistoryEntry: Previous original URLs, the most recent first
+	// - error: If database operation fails
+	FindURLHistory(ctx context.Context, namespace, alias string) ([]*shortURLEntity.HistoryEntry, error)
+
+	// SaveAPIKey stores SHA-256 hash of a new API key of a user.
+	// Returns:
+	// - error: If database operation fails
+	SaveAPIKey(ctx context.Context, userID int, keyHash string) error
+
+	// FindUserByAPIKey retrieves the owner of a not revoked API key by the key hash.
+	// Returns:
+	// - *userEntity.User: The found user
+	// - error: If key is not found, revoked or database operation fails
+	FindUserByAPIKey(ctx context.Context, keyHash string) (*userEntity.User, error)
+
+	// RevokeAPIKey marks an API key of a user as revoked.
+	// Returns:
+	// - error: If key is not found, already revoked or database operation fails
+	RevokeAPIKey(ctx context.Context, userID int, keyHash string) error
+
+	// SetUserActive activates or deactivates a user.
+	// Returns:
+	// - error: If user is not found or database operation fails
+	SetUserActive(ctx context.Context, userID int, isActive bool) error
+
+	// SetUserCustomDomain sets the custom domain of a user, empty to remove it.
+	// Returns:
+	// - error: If user is not found, domain belongs to another user or database operation fails
+	SetUserCustomDomain(ctx context.Context, userID int, customDomain string) error
+}
+
+// ArchiveDB defines the optional interface for databases keeping archived short URLs.
+type ArchiveDB interface {
+	// FindArchivedURLs retrieves a page of archived short URLs of a user.
+	// Returns:
+	// - []*shortURLEntity.ArchivedURL: Archived URLs, the most recently archived first
+	// - error: If database operation fails
+	FindArchivedURLs(ctx context.Context, userID, limit, offset int) ([]*shortURLEntity.ArchivedURL, error)
+
+	// RestoreArchivedURL moves an archived short URL of a user back to active short URLs as not deleted.
+	// Returns:
+	// - error: If URL is not archived, its alias is taken or database operation fails
+	RestoreArchivedURL(ctx context.Context, userID int, namespace, alias string) error
 }
 
 // UserStorage implements the storage layer for user operations.
@@ -69,15 +167,163 @@ func (s *UserStorage) FindURLs(ctx context.Context, id int) ([]*shortURLEntity.S
 	return s.db.FindUserURLs(ctx, id)
 }
 
+// FindURLsPaginated retrieves a page of short URLs belonging to a user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: User ID to look up
+// - offset: Number of URLs to skip
+// - limit: Maximum number of URLs to return
+// Returns:
+// - []*shortURLEntity.ShortURL: Requested page of user's short URLs
+// - int64: Total number of user's short URLs
+// - error: If operation fails
+func (s *UserStorage) FindURLsPaginated(ctx context.Context, userID, offset, limit int) ([]*shortURLEntity.ShortURL, int64, error) {
+	return s.db.FindUserURLsPaginated(ctx, userID, offset, limit)
+}
+
+// SearchURLs retrieves a page of short URLs of a user whose original URL contains the query.
+// The query is matched case-insensitively.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: User ID to look up
+// - query: Substring of original URLs to find
+// - offset: Number of URLs to skip
+// - limit: Maximum number of URLs to return
+// Returns:
+// - []*shortURLEntity.ShortURL: Requested page of found short URLs
+// - int64: Total number of found short URLs
+// - error: If operation fails
+func (s *UserStorage) SearchURLs(ctx context.Context, userID int, query string, offset, limit int) ([]*shortURLEntity.ShortURL, int64, error) {
+	return s.db.SearchUserURLs(ctx, userID, query, offset, limit)
+}
+
+// FindClicks retrieves clicks of all short URLs of a user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: Owner's user ID
+// Returns:
+// - []*analyticsEntity.Click: Clicks ordered by click time
+// - error: If operation fails
+func (s *UserStorage) FindClicks(ctx context.Context, userID int) ([]*analyticsEntity.Click, error) {
+	return s.db.FindUserClicks(ctx, userID)
+}
+
+// CountURLsByUser returns the number of not deleted short URLs of a user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: User ID to look up, 0 for URLs created anonymously
+// Returns:
+// - int64: Number of user's short URLs
+// - error: If operation fails
+func (s *UserStorage) CountURLsByUser(ctx context.Context, userID int) (int64, error) {
+	return s.db.CountUserURLs(ctx, userID)
+}
+
+// FindURLsCursor retrieves a page of short URLs belonging to a user after the cursor.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: User ID to look up
+// - afterID: ID of the last URL on the previous page, 0 for the first page
+// - limit: Maximum number of URLs to return
+// Returns:
+// - []*shortURLEntity.ShortURL: Requested page of user's short URLs ordered by ID
+// - int: ID of the last returned URL, 0 if there are no more URLs
+// - error: If operation fails
+func (s *UserStorage) FindURLsCursor(ctx context.Context, userID, afterID, limit int) ([]*shortURLEntity.ShortURL, int, error) {
+	return s.db.FindUserURLsCursor(ctx, userID, afterID, limit)
+}
+
 // MarkURLAsDeleted marks the specified URLs as deleted for a user.
 // Parameters:
 // - ctx: Context for cancellation and timeouts
 // - userID: Owner of the URLs
+// - namespace: Namespace of the URLs, empty for the default one
 // - aliases: List of URL aliases to mark as deleted
 // Returns:
 // - error: If operation fails or URLs don't belong to user
-func (s *UserStorage) MarkURLAsDeleted(ctx context.Context, userID int, aliases []string) error {
-	return s.db.MarkURLAsDeleted(ctx, userID, aliases)
+func (s *UserStorage) MarkURLAsDeleted(ctx context.Context, userID int, namespace string, aliases []string) error {
+	return s.db.MarkURLAsDeleted(ctx, userID, namespace, aliases)
+}
+
+// FindURL retrieves a short URL of any owner by its namespace and alias, including deleted one.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - namespace: Namespace of the URL, empty for the default one
+// - alias: Short URL identifier
+// Returns:
+// - *shortURLEntity.ShortURL: The found short URL
+// - error: If URL is not found or operation fails
+func (s *UserStorage) FindURL(ctx context.Context, namespace, alias string) (*shortURLEntity.ShortURL, error) {
+	return s.db.FindShortURL(ctx, namespace, alias)
+}
+
+// RestoreURL clears the deletion mark of a short URL of a user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: Owner of the URL
+// - namespace: Namespace of the URL, empty for the default one
+// - alias: Short URL identifier
+// Returns:
+// - error: If operation fails or URL doesn't belong to user
+func (s *UserStorage) RestoreURL(ctx context.Context, userID int, namespace, alias string) error {
+	return s.db.RestoreURL(ctx, userID, namespace, alias)
+}
+
+// FindArchivedURLs retrieves a page of archived short URLs of a user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: Owner of the URLs
+// - limit: Maximum number of URLs to return
+// - offset: Number of URLs to skip
+// Returns:
+// - []*shortURLEntity.ArchivedURL: Archived URLs, empty if the database does not implement ArchiveDB
+// - error: If operation fails
+func (s *UserStorage) FindArchivedURLs(ctx context.Context, userID, limit, offset int) ([]*shortURLEntity.ArchivedURL, error) {
+	if archiveDB, ok := s.db.(ArchiveDB); ok {
+		return archiveDB.FindArchivedURLs(ctx, userID, limit, offset)
+	}
+	return nil, nil
+}
+
+// RestoreArchivedURL moves an archived short URL of a user back to active short URLs as not deleted.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: Owner of the URL
+// - namespace: Namespace of the URL, empty for the default one
+// - alias: Short URL identifier
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if the URL is not archived or the database does not implement ArchiveDB,
+// other error if operation fails
+func (s *UserStorage) RestoreArchivedURL(ctx context.Context, userID int, namespace, alias string) error {
+	if archiveDB, ok := s.db.(ArchiveDB); ok {
+		return archiveDB.RestoreArchivedURL(ctx, userID, namespace, alias)
+	}
+	return dbErrors.ErrDBRecordNotFound
+}
+
+// UpdateURLTarget replaces the original URL of a short URL of a user saving the previous one to history.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: Owner of the URL
+// - namespace: Namespace of the URL, empty for the default one
+// - alias: Short URL identifier
+// - newURL: New original URL
+// Returns:
+// - error: If operation fails or URL doesn't belong to user
+func (s *UserStorage) UpdateURLTarget(ctx context.Context, userID int, namespace, alias, newURL string) error {
+	return s.db.UpdateURLTarget(ctx, userID, namespace, alias, newURL)
+}
+
+// FindURLHistory retrieves previous original URLs of a short URL.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - namespace: Namespace of the URL, empty for the default one
+// - alias: Short URL identifier
+// Returns:
+// - []*shortURLEntity.HistoryEntry: Previous original URLs, the most recent first
+// - error: If operation fails
+func (s *UserStorage) FindURLHistory(ctx context.Context, namespace, alias string) ([]*shortURLEntity.HistoryEntry, error) {
+	return s.db.FindURLHistory(ctx, namespace, alias)
 }
 
 // FindUser retrieves a user by their ID.
@@ -98,3 +344,58 @@ func (s *UserStorage) FindUser(ctx context.Context, id int) (*userEntity.User, e
 func (s *UserStorage) SaveUser(ctx context.Context) (*userEntity.User, error) {
 	return s.db.SaveUser(ctx)
 }
+
+// SaveAPIKey stores SHA-256 hash of a new API key of a user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: Owner of the key
+// - keyHash: Hex encoded SHA-256 hash of the key
+// Returns:
+// - error: If operation fails
+func (s *UserStorage) SaveAPIKey(ctx context.Context, userID int, keyHash string) error {
+	return s.db.SaveAPIKey(ctx, userID, keyHash)
+}
+
+// FindUserByAPIKey retrieves the owner of a not revoked API key.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - keyHash: Hex encoded SHA-256 hash of the key
+// Returns:
+// - *userEntity.User: The found user
+// - error: If key is not found, revoked or operation fails
+func (s *UserStorage) FindUserByAPIKey(ctx context.Context, keyHash string) (*userEntity.User, error) {
+	return s.db.FindUserByAPIKey(ctx, keyHash)
+}
+
+// RevokeAPIKey marks an API key of a user as revoked.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: Owner of the key
+// - keyHash: Hex encoded SHA-256 hash of the key
+// Returns:
+// - error: If key is not found, already revoked or operation fails
+func (s *UserStorage) RevokeAPIKey(ctx context.Context, userID int, keyHash string) error {
+	return s.db.RevokeAPIKey(ctx, userID, keyHash)
+}
+
+// SetUserActive activates or deactivates a user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: User ID
+// - isActive: Whether the user may authenticate
+// Returns:
+// - error: If user is not found or operation fails
+func (s *UserStorage) SetUserActive(ctx context.Context, userID int, isActive bool) error {
+	return s.db.SetUserActive(ctx, userID, isActive)
+}
+
+// SetCustomDomain sets the custom domain of a user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: User ID
+// - customDomain: Base URL of short URLs of the user, empty to remove it
+// Returns:
+// - error: If user is not found, domain belongs to another user or operation fails
+func (s *UserStorage) SetCustomDomain(ctx context.Context, userID int, customDomain string) error {
+	return s.db.SetUserCustomDomain(ctx, userID, customDomain)
+}
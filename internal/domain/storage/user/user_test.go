@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	analyticsEntity "github.com/gururuby/shortener/internal/domain/entity/analytics"
 	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
 	"github.com/gururuby/shortener/internal/domain/entity/user"
 	"github.com/gururuby/shortener/internal/domain/storage/errors"
@@ -188,6 +189,95 @@ func Test_Storage_FindURLs_Errors(t *testing.T) {
 	}
 }
 
+func Test_Storage_FindURLsPaginated_OK(t *testing.T) {
+	urls := []*shortURLEntity.ShortURL{{Alias: "alias", SourceURL: "https://ya.ru"}}
+
+	ctrl := gomock.NewController(t)
+	db := storageMock.NewMockDB(ctrl)
+	ctx := context.Background()
+	storage := UserStorage{db: db}
+
+	tests := []struct {
+		name   string
+		res    []*shortURLEntity.ShortURL
+		total  int64
+		userID int
+	}{
+		{
+			name:   "when find page of user URLs in db",
+			userID: 1,
+			res:    urls,
+			total:  3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db.EXPECT().FindUserURLsPaginated(ctx, 1, 2, 1).Return(tt.res, tt.total, nil)
+			res, total, err := storage.FindURLsPaginated(ctx, tt.userID, 2, 1)
+			require.NoError(t, err)
+			require.Equal(t, tt.res, res)
+			require.Equal(t, tt.total, total)
+		})
+	}
+}
+
+func Test_Storage_FindURLsPaginated_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := storageMock.NewMockDB(ctrl)
+	ctx := context.Background()
+
+	storage := UserStorage{db: db}
+
+	tests := []struct {
+		err    error
+		name   string
+		userID int
+	}{
+		{
+			name:   "when something went wrong with db query",
+			userID: 1,
+			err:    dbErrors.ErrDBQuery,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db.EXPECT().FindUserURLsPaginated(ctx, 1, 0, 10).Return(nil, int64(0), tt.err)
+			_, _, err := storage.FindURLsPaginated(ctx, tt.userID, 0, 10)
+			require.Error(t, err)
+		})
+	}
+}
+
+func Test_Storage_CountURLsByUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := storageMock.NewMockDB(ctrl)
+	ctx := context.Background()
+	storage := UserStorage{db: db}
+
+	tests := []struct {
+		err   error
+		name  string
+		count int64
+	}{
+		{
+			name:  "when count user URLs in db",
+			count: 42,
+		},
+		{
+			name: "when something went wrong with db query",
+			err:  dbErrors.ErrDBQuery,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db.EXPECT().CountUserURLs(ctx, 1).Return(tt.count, tt.err)
+			count, err := storage.CountURLsByUser(ctx, 1)
+			require.ErrorIs(t, err, tt.err)
+			require.Equal(t, tt.count, count)
+		})
+	}
+}
+
 func Test_Storage_MarkURLAsDeleted_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	db := storageMock.NewMockDB(ctrl)
@@ -209,8 +299,8 @@ func Test_Storage_MarkURLAsDeleted_OK(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			db.EXPECT().MarkURLAsDeleted(ctx, 1, tt.aliases).Return(tt.err)
-			err := storage.MarkURLAsDeleted(ctx, tt.userID, tt.aliases)
+			db.EXPECT().MarkURLAsDeleted(ctx, 1, "team", tt.aliases).Return(tt.err)
+			err := storage.MarkURLAsDeleted(ctx, tt.userID, "team", tt.aliases)
 			require.NoError(t, err)
 		})
 	}
@@ -237,9 +327,159 @@ func Test_Storage_MarkURLAsDeleted_Errors(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			db.EXPECT().MarkURLAsDeleted(ctx, 1, tt.aliases).Return(tt.err)
-			err := storage.MarkURLAsDeleted(ctx, tt.userID, tt.aliases)
+			db.EXPECT().MarkURLAsDeleted(ctx, 1, "team", tt.aliases).Return(tt.err)
+			err := storage.MarkURLAsDeleted(ctx, tt.userID, "team", tt.aliases)
 			require.Error(t, err)
 		})
 	}
 }
+
+func Test_Storage_FindURLsCursor(t *testing.T) {
+	urls := []*shortURLEntity.ShortURL{{ID: 3, Alias: "alias", SourceURL: "https://ya.ru"}}
+
+	ctrl := gomock.NewController(t)
+	db := storageMock.NewMockDB(ctrl)
+	ctx := context.Background()
+	storage := UserStorage{db: db}
+
+	tests := []struct {
+		err        error
+		name       string
+		res        []*shortURLEntity.ShortURL
+		nextCursor int
+	}{
+		{
+			name:       "when find page of user URLs after cursor in db",
+			res:        urls,
+			nextCursor: 3,
+		},
+		{
+			name: "when something went wrong with db query",
+			err:  dbErrors.ErrDBQuery,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db.EXPECT().FindUserURLsCursor(ctx, 1, 2, 1).Return(tt.res, tt.nextCursor, tt.err)
+			res, nextCursor, err := storage.FindURLsCursor(ctx, 1, 2, 1)
+			require.ErrorIs(t, err, tt.err)
+			require.Equal(t, tt.res, res)
+			require.Equal(t, tt.nextCursor, nextCursor)
+		})
+	}
+}
+
+func Test_Storage_APIKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := storageMock.NewMockDB(ctrl)
+	ctx := context.Background()
+	storage := UserStorage{db: db}
+
+	db.EXPECT().SaveAPIKey(ctx, 1, "hash").Return(nil)
+	require.NoError(t, storage.SaveAPIKey(ctx, 1, "hash"))
+
+	db.EXPECT().FindUserByAPIKey(ctx, "hash").Return(&entity.User{ID: 1}, nil)
+	res, err := storage.FindUserByAPIKey(ctx, "hash")
+	require.NoError(t, err)
+	require.Equal(t, &entity.User{ID: 1}, res)
+
+	db.EXPECT().RevokeAPIKey(ctx, 1, "hash").Return(dbErrors.ErrDBRecordNotFound)
+	require.ErrorIs(t, storage.RevokeAPIKey(ctx, 1, "hash"), dbErrors.ErrDBRecordNotFound)
+}
+
+func Test_Storage_SetUserActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := storageMock.NewMockDB(ctrl)
+	ctx := context.Background()
+	storage := UserStorage{db: db}
+
+	db.EXPECT().SetUserActive(ctx, 1, false).Return(nil)
+	require.NoError(t, storage.SetUserActive(ctx, 1, false))
+
+	db.EXPECT().SetUserActive(ctx, 2, true).Return(dbErrors.ErrDBRecordNotFound)
+	require.ErrorIs(t, storage.SetUserActive(ctx, 2, true), dbErrors.ErrDBRecordNotFound)
+}
+
+func Test_Storage_SetCustomDomain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := storageMock.NewMockDB(ctrl)
+	ctx := context.Background()
+	storage := UserStorage{db: db}
+
+	db.EXPECT().SetUserCustomDomain(ctx, 1, "https://short.acme.com").Return(nil)
+	require.NoError(t, storage.SetCustomDomain(ctx, 1, "https://short.acme.com"))
+
+	db.EXPECT().SetUserCustomDomain(ctx, 2, "https://short.acme.com").Return(dbErrors.ErrDBIsNotUnique)
+	require.ErrorIs(t, storage.SetCustomDomain(ctx, 2, "https://short.acme.com"), dbErrors.ErrDBIsNotUnique)
+}
+
+func Test_Storage_SearchURLs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := storageMock.NewMockDB(ctrl)
+	ctx := context.Background()
+	storage := UserStorage{db: db}
+
+	found := []*shortURLEntity.ShortURL{{Alias: "alias", SourceURL: "https://github.com"}}
+	db.EXPECT().SearchUserURLs(ctx, 1, "github", 10, 20).Return(found, int64(11), nil)
+	res, total, err := storage.SearchURLs(ctx, 1, "github", 10, 20)
+	require.NoError(t, err)
+	require.Equal(t, found, res)
+	require.Equal(t, int64(11), total)
+
+	db.EXPECT().SearchUserURLs(ctx, 1, "github", 0, 20).Return(nil, int64(0), dbErrors.ErrDBQuery)
+	_, _, err = storage.SearchURLs(ctx, 1, "github", 0, 20)
+	require.ErrorIs(t, err, dbErrors.ErrDBQuery)
+}
+
+func Test_Storage_FindClicks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := storageMock.NewMockDB(ctrl)
+	ctx := context.Background()
+	storage := UserStorage{db: db}
+
+	clicks := []*analyticsEntity.Click{{Alias: "alias", AnonymizedIP: "127.0.0.0"}}
+	db.EXPECT().FindUserClicks(ctx, 1).Return(clicks, nil)
+	res, err := storage.FindClicks(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, clicks, res)
+
+	db.EXPECT().FindUserClicks(ctx, 1).Return(nil, dbErrors.ErrDBQuery)
+	_, err = storage.FindClicks(ctx, 1)
+	require.ErrorIs(t, err, dbErrors.ErrDBQuery)
+}
+
+// archiveDB is a UserDB mock implementing ArchiveDB.
+type archiveDB struct {
+	*storageMock.MockDB
+	*storageMock.MockArchiveDB
+}
+
+func Test_Storage_ArchivedURLs(t *testing.T) {
+	archived := []*shortURLEntity.ArchivedURL{{ShortURL: &shortURLEntity.ShortURL{Alias: "alias", SourceURL: "https://ya.ru", IsDeleted: true}}}
+
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	t.Run("when DB keeps archived URLs", func(t *testing.T) {
+		db := archiveDB{MockDB: storageMock.NewMockDB(ctrl), MockArchiveDB: storageMock.NewMockArchiveDB(ctrl)}
+		storage := UserStorage{db: db}
+
+		db.MockArchiveDB.EXPECT().FindArchivedURLs(ctx, 1, 10, 20).Return(archived, nil)
+		res, err := storage.FindArchivedURLs(ctx, 1, 10, 20)
+		require.NoError(t, err)
+		require.Equal(t, archived, res)
+
+		db.MockArchiveDB.EXPECT().RestoreArchivedURL(ctx, 1, "team", "alias").Return(dbErrors.ErrDBIsNotUnique)
+		require.ErrorIs(t, storage.RestoreArchivedURL(ctx, 1, "team", "alias"), dbErrors.ErrDBIsNotUnique)
+	})
+
+	t.Run("when DB does not support archiving", func(t *testing.T) {
+		storage := UserStorage{db: storageMock.NewMockDB(ctrl)}
+
+		res, err := storage.FindArchivedURLs(ctx, 1, 10, 0)
+		require.NoError(t, err)
+		require.Empty(t, res)
+
+		require.ErrorIs(t, storage.RestoreArchivedURL(ctx, 1, "", "alias"), dbErrors.ErrDBRecordNotFound)
+	})
+}
@@ -0,0 +1,84 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . DB
+
+/*
+Package storage provides data persistence implementations for user webhooks.
+
+It includes:
+- Database interface for webhooks
+- Storage layer implementation
+*/
+package storage
+
+import (
+	"context"
+
+	webhookEntity "github.com/gururuby/shortener/internal/domain/entity/webhook"
+)
+
+// DB defines the interface for webhook database operations.
+type DB interface {
+	// SaveWebhook stores a new webhook of a user.
+	// Returns:
+	// - *webhookEntity.Webhook: Saved webhook with ID
+	// - error: If database operation fails
+	SaveWebhook(ctx context.Context, webhook *webhookEntity.Webhook) (*webhookEntity.Webhook, error)
+
+	// FindWebhooksByUser retrieves all webhooks of a user.
+	// Returns:
+	// - []*webhookEntity.Webhook: User's webhooks ordered by ID
+	// - error: If database operation fails
+	FindWebhooksByUser(ctx context.Context, userID int) ([]*webhookEntity.Webhook, error)
+
+	// DeleteWebhook removes a webhook of a user.
+	// Returns:
+	// - error: If user has no such webhook or database operation fails
+	DeleteWebhook(ctx context.Context, userID, id int) error
+}
+
+// WebhookStorage implements the storage layer for webhook operations.
+// It acts as an intermediary between the domain and database layers.
+type WebhookStorage struct {
+	db DB // Database interface implementation
+}
+
+// Setup creates and initializes a new WebhookStorage instance.
+// Parameters:
+// - db: The database implementation to use
+// Returns:
+// - *WebhookStorage: Initialized storage instance
+func Setup(db DB) *WebhookStorage {
+	return &WebhookStorage{db: db}
+}
+
+// SaveWebhook stores a new webhook of a user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - webhook: Webhook to save
+// Returns:
+// - *webhookEntity.Webhook: Saved webhook with ID
+// - error: If operation fails
+func (s *WebhookStorage) SaveWebhook(ctx context.Context, webhook *webhookEntity.Webhook) (*webhookEntity.Webhook, error) {
+	return s.db.SaveWebhook(ctx, webhook)
+}
+
+// FindWebhooksByUser retrieves all webhooks of a user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: Owner of the webhooks
+// Returns:
+// - []*webhookEntity.Webhook: User's webhooks ordered by ID
+// - error: If operation fails
+func (s *WebhookStorage) FindWebhooksByUser(ctx context.Context, userID int) ([]*webhookEntity.Webhook, error) {
+	return s.db.FindWebhooksByUser(ctx, userID)
+}
+
+// DeleteWebhook removes a webhook of a user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: Owner of the webhook
+// - id: Webhook ID
+// Returns:
+// - error: If operation fails
+func (s *WebhookStorage) DeleteWebhook(ctx context.Context, userID, id int) error {
+	return s.db.DeleteWebhook(ctx, userID, id)
+}
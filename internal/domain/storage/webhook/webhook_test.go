@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	webhookEntity "github.com/gururuby/shortener/internal/domain/entity/webhook"
+	storageMock "github.com/gururuby/shortener/internal/domain/storage/webhook/mocks"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Storage_Webhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := storageMock.NewMockDB(ctrl)
+	ctx := context.Background()
+
+	storage := Setup(db)
+
+	t.Run("when calls are passed to db", func(t *testing.T) {
+		webhook := &webhookEntity.Webhook{UserID: 1, URL: "https://example.com/hook", Events: []string{webhookEntity.EventURLCreated}}
+		saved := &webhookEntity.Webhook{ID: 1, UserID: 1, URL: "https://example.com/hook", Events: []string{webhookEntity.EventURLCreated}}
+
+		db.EXPECT().SaveWebhook(ctx, webhook).Return(saved, nil)
+		db.EXPECT().FindWebhooksByUser(ctx, 1).Return([]*webhookEntity.Webhook{saved}, nil)
+		db.EXPECT().DeleteWebhook(ctx, 1, 1).Return(nil)
+
+		res, err := storage.SaveWebhook(ctx, webhook)
+		require.NoError(t, err)
+		require.Equal(t, saved, res)
+
+		webhooks, err := storage.FindWebhooksByUser(ctx, 1)
+		require.NoError(t, err)
+		require.Equal(t, []*webhookEntity.Webhook{saved}, webhooks)
+
+		require.NoError(t, storage.DeleteWebhook(ctx, 1, 1))
+	})
+
+	t.Run("when db query fails", func(t *testing.T) {
+		db.EXPECT().DeleteWebhook(ctx, 1, 2).Return(dbErrors.ErrDBRecordNotFound)
+
+		err := storage.DeleteWebhook(ctx, 1, 2)
+		require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+	})
+}
@@ -0,0 +1,154 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . Storage,GeoResolver
+
+/*
+Package usecase implements the application's business logic layer.
+
+It contains:
+- Recording of short URL clicks with hashed client data and client location
+- Click time series and geographic breakdown of short URLs
+- Error handling specific to analytics operations
+*/
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/analytics/errors"
+)
+
+// Storage defines the interface for storage operations required by analytics use cases.
+type Storage interface {
+	// Record stores a click of a short URL
+	Record(ctx context.Context, namespace, alias, ipHash, anonymizedIP, userAgentHash string, location entity.GeoLocation, clickedAt time.Time) error
+	// CountClicks counts clicks of a short URL in [from, to) grouped by periods of the granularity
+	CountClicks(ctx context.Context, namespace, alias string, from, to time.Time, granularity string) ([]entity.ClickCount, error)
+	// CountClicksByLocation counts clicks of a short URL in [from, to) grouped by country and city
+	CountClicksByLocation(ctx context.Context, namespace, alias string, from, to time.Time) ([]entity.LocationCount, error)
+}
+
+// GeoResolver defines the interface for resolving client locations by IP.
+type GeoResolver interface {
+	// Resolve returns the location of the IP, empty if it is unknown
+	Resolve(ip string) (*entity.GeoLocation, error)
+}
+
+// AnalyticsUseCase implements short URL analytics use cases.
+type AnalyticsUseCase struct {
+	storage  Storage     // Storage layer interface
+	resolver GeoResolver // Client location resolver
+}
+
+// NewAnalyticsUseCase creates a new instance of AnalyticsUseCase.
+// Parameters:
+// - storage: Implementation of the Storage interface
+// - resolver: Client location resolver
+// Returns:
+// - *AnalyticsUseCase: Initialized analytics use case instance
+func NewAnalyticsUseCase(storage Storage, resolver GeoResolver) *AnalyticsUseCase {
+	return &AnalyticsUseCase{storage: storage, resolver: resolver}
+}
+
+// RecordClick stores a click of a short URL at the current time.
+// Client location is resolved before the IP is hashed, clicks of unresolved IPs
+// are stored with unknown location.
+// Client IP and User-Agent are hashed, so they cannot be restored from storage,
+// client IP is also stored anonymized for exports of user data.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - namespace: Namespace of the clicked short URL, empty for the default one
+// - alias: Clicked short URL alias
+// - ip: Client IP
+// - userAgent: Client User-Agent
+// Returns:
+// - error: ErrAnalyticsCannotRecord if the click cannot be stored
+func (uc *AnalyticsUseCase) RecordClick(ctx context.Context, namespace, alias, ip, userAgent string) error {
+	var location entity.GeoLocation
+	if resolved, err := uc.resolver.Resolve(ip); err == nil {
+		location = *resolved
+	}
+
+	if err := uc.storage.Record(ctx, namespace, alias, hash(ip), entity.AnonymizeIP(ip), hash(userAgent), location, time.Now().UTC()); err != nil {
+		return ucErrors.ErrAnalyticsCannotRecord
+	}
+	return nil
+}
+
+// GetTimeSeries counts clicks of a short URL in [from, to) grouped by periods in UTC.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL alias
+// - from: Start of the time range, inclusive
+// - to: End of the time range, exclusive
+// - granularity: Period length, one of hour, day, week or month
+// Returns:
+// - *entity.TimeSeries: Periods with clicks and total number of clicks
+// - error: ErrAnalyticsInvalidGranularity, ErrAnalyticsInvalidPeriod or ErrAnalyticsCannotGet
+func (uc *AnalyticsUseCase) GetTimeSeries(ctx context.Context, namespace, alias string, from, to time.Time, granularity string) (*entity.TimeSeries, error) {
+	if !entity.IsValidGranularity(granularity) {
+		return nil, ucErrors.ErrAnalyticsInvalidGranularity
+	}
+
+	if !from.Before(to) {
+		return nil, ucErrors.ErrAnalyticsInvalidPeriod
+	}
+
+	counts, err := uc.storage.CountClicks(ctx, namespace, alias, from, to, granularity)
+	if err != nil {
+		return nil, ucErrors.ErrAnalyticsCannotGet
+	}
+
+	series := &entity.TimeSeries{Alias: alias, Data: make([]entity.DataPoint, 0, len(counts))}
+	for _, count := range counts {
+		series.Data = append(series.Data, entity.DataPoint{
+			Period: entity.FormatPeriod(count.PeriodStart, granularity),
+			Clicks: count.Clicks,
+		})
+		series.Total += count.Clicks
+	}
+
+	return series, nil
+}
+
+// GetGeoBreakdown counts clicks of a short URL in [from, to) grouped by country and city.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL alias
+// - from: Start of the time range, inclusive
+// - to: End of the time range, exclusive
+// Returns:
+// - *entity.GeoBreakdown: Locations ordered by clicks descending and total number of clicks
+// - error: ErrAnalyticsInvalidPeriod or ErrAnalyticsCannotGet
+func (uc *AnalyticsUseCase) GetGeoBreakdown(ctx context.Context, namespace, alias string, from, to time.Time) (*entity.GeoBreakdown, error) {
+	if !from.Before(to) {
+		return nil, ucErrors.ErrAnalyticsInvalidPeriod
+	}
+
+	counts, err := uc.storage.CountClicksByLocation(ctx, namespace, alias, from, to)
+	if err != nil {
+		return nil, ucErrors.ErrAnalyticsCannotGet
+	}
+
+	breakdown := &entity.GeoBreakdown{Alias: alias, Data: make([]entity.LocationCount, 0, len(counts))}
+	for _, count := range counts {
+		breakdown.Data = append(breakdown.Data, count)
+		breakdown.Total += count.Clicks
+	}
+
+	return breakdown, nil
+}
+
+// hash computes the hash client data is stored by.
+// Parameters:
+// - value: Client IP or User-Agent
+// Returns:
+// - string: Hex encoded SHA-256 hash of the value
+func hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
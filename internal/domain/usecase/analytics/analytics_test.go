@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/analytics/errors"
+	"github.com/gururuby/shortener/internal/domain/usecase/analytics/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_RecordClick(t *testing.T) {
+	ctx := context.Background()
+	london := &entity.GeoLocation{Country: "GB", City: "London", Lat: "51.5142", Lon: "-0.0931"}
+
+	tests := []struct {
+		location     *entity.GeoLocation
+		resolveErr   error
+		storageErr   error
+		wantErr      error
+		name         string
+		wantLocation entity.GeoLocation
+	}{
+		{name: "when click is recorded", location: london, wantLocation: *london},
+		{name: "when location is unknown", location: &entity.GeoLocation{}},
+		{name: "when location cannot be resolved", resolveErr: errors.New("invalid IP")},
+		{name: "when storage fails", location: london, wantLocation: *london, storageErr: errors.New("connection refused"), wantErr: ucErrors.ErrAnalyticsCannotRecord},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockStorage(ctrl)
+			resolver := mocks.NewMockGeoResolver(ctrl)
+			before := time.Now()
+
+			resolver.EXPECT().Resolve("127.0.0.1").Return(tt.location, tt.resolveErr)
+			storage.EXPECT().Record(ctx, "team", "abc", hash("127.0.0.1"), "127.0.0.0", hash("curl/8.0"), tt.wantLocation, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, _, _, _, _ string, _ entity.GeoLocation, clickedAt time.Time) error {
+					assert.WithinDuration(t, before, clickedAt, time.Second)
+					return tt.storageErr
+				})
+
+			err := NewAnalyticsUseCase(storage, resolver).RecordClick(ctx, "team", "abc", "127.0.0.1", "curl/8.0")
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	assert.Regexp(t, `^[0-9a-f]{64}$`, hash("127.0.0.1"))
+}
+
+func Test_GetTimeSeries(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		setup       func(storage *mocks.MockStorage)
+		want        *entity.TimeSeries
+		wantErr     error
+		from        time.Time
+		to          time.Time
+		name        string
+		granularity string
+	}{
+		{
+			name:        "when clicks are grouped by day",
+			granularity: entity.GranularityDay,
+			from:        from,
+			to:          to,
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().CountClicks(ctx, "team", "abc", from, to, entity.GranularityDay).Return([]entity.ClickCount{
+					{PeriodStart: from, Clicks: 42},
+					{PeriodStart: from.AddDate(0, 0, 2), Clicks: 81},
+				}, nil)
+			},
+			want: &entity.TimeSeries{
+				Alias: "abc",
+				Data:  []entity.DataPoint{{Period: "2024-01-01", Clicks: 42}, {Period: "2024-01-03", Clicks: 81}},
+				Total: 123,
+			},
+		},
+		{
+			name:        "when clicks are grouped by hour",
+			granularity: entity.GranularityHour,
+			from:        from,
+			to:          to,
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().CountClicks(ctx, "team", "abc", from, to, entity.GranularityHour).Return([]entity.ClickCount{
+					{PeriodStart: from.Add(5 * time.Hour), Clicks: 1},
+				}, nil)
+			},
+			want: &entity.TimeSeries{
+				Alias: "abc",
+				Data:  []entity.DataPoint{{Period: "2024-01-01T05:00:00Z", Clicks: 1}},
+				Total: 1,
+			},
+		},
+		{
+			name:        "when there are no clicks",
+			granularity: entity.GranularityMonth,
+			from:        from,
+			to:          to,
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().CountClicks(ctx, "team", "abc", from, to, entity.GranularityMonth).Return(nil, nil)
+			},
+			want: &entity.TimeSeries{Alias: "abc", Data: []entity.DataPoint{}},
+		},
+		{
+			name:        "when granularity is not supported",
+			granularity: "minute",
+			from:        from,
+			to:          to,
+			setup:       func(_ *mocks.MockStorage) {},
+			wantErr:     ucErrors.ErrAnalyticsInvalidGranularity,
+		},
+		{
+			name:        "when from is after to",
+			granularity: entity.GranularityDay,
+			from:        to,
+			to:          from,
+			setup:       func(_ *mocks.MockStorage) {},
+			wantErr:     ucErrors.ErrAnalyticsInvalidPeriod,
+		},
+		{
+			name:        "when storage fails",
+			granularity: entity.GranularityWeek,
+			from:        from,
+			to:          to,
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().CountClicks(ctx, "team", "abc", from, to, entity.GranularityWeek).Return(nil, errors.New("connection refused"))
+			},
+			wantErr: ucErrors.ErrAnalyticsCannotGet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockStorage(ctrl)
+			tt.setup(storage)
+
+			res, err := NewAnalyticsUseCase(storage, mocks.NewMockGeoResolver(ctrl)).GetTimeSeries(ctx, "team", "abc", tt.from, tt.to, tt.granularity)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func Test_GetGeoBreakdown(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		setup   func(storage *mocks.MockStorage)
+		want    *entity.GeoBreakdown
+		wantErr error
+		from    time.Time
+		to      time.Time
+		name    string
+	}{
+		{
+			name: "when clicks are grouped by location",
+			from: from,
+			to:   to,
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().CountClicksByLocation(ctx, "team", "abc", from, to).Return([]entity.LocationCount{
+					{Country: "US", City: "New York", Clicks: 10},
+					{Country: "GB", City: "London", Clicks: 3},
+				}, nil)
+			},
+			want: &entity.GeoBreakdown{
+				Alias: "abc",
+				Data: []entity.LocationCount{
+					{Country: "US", City: "New York", Clicks: 10},
+					{Country: "GB", City: "London", Clicks: 3},
+				},
+				Total: 13,
+			},
+		},
+		{
+			name: "when there are no clicks",
+			from: from,
+			to:   to,
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().CountClicksByLocation(ctx, "team", "abc", from, to).Return(nil, nil)
+			},
+			want: &entity.GeoBreakdown{Alias: "abc", Data: []entity.LocationCount{}},
+		},
+		{
+			name:    "when from is after to",
+			from:    to,
+			to:      from,
+			setup:   func(_ *mocks.MockStorage) {},
+			wantErr: ucErrors.ErrAnalyticsInvalidPeriod,
+		},
+		{
+			name: "when storage fails",
+			from: from,
+			to:   to,
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().CountClicksByLocation(ctx, "team", "abc", from, to).Return(nil, errors.New("connection refused"))
+			},
+			wantErr: ucErrors.ErrAnalyticsCannotGet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockStorage(ctrl)
+			tt.setup(storage)
+
+			res, err := NewAnalyticsUseCase(storage, mocks.NewMockGeoResolver(ctrl)).GetGeoBreakdown(ctx, "team", "abc", tt.from, tt.to)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, res)
+		})
+	}
+}
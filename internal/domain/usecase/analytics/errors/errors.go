@@ -0,0 +1,43 @@
+// Package usecase contains application business logic and acts as an intermediary
+// between the presentation layer (e.g., HTTP handlers) and the data layer (e.g., database).
+// It defines analytics-specific errors.
+package usecase
+
+import "errors"
+
+// Errors list
+var (
+	// ErrAnalyticsInvalidGranularity indicates time series is requested with unsupported granularity.
+	//
+	// Supported granularities:
+	// - hour
+	// - day
+	// - week
+	// - month
+	//
+	// Handling recommendations:
+	// - Return HTTP 400 (Bad Request) in web handlers
+	ErrAnalyticsInvalidGranularity = errors.New("granularity must be one of hour, day, week, month")
+
+	// ErrAnalyticsInvalidPeriod indicates time series is requested for an empty time range.
+	//
+	// Typical cases:
+	// - `from` is after `to`
+	//
+	// Handling recommendations:
+	// - Return HTTP 400 (Bad Request) in web handlers
+	ErrAnalyticsInvalidPeriod = errors.New("from must be before to")
+
+	// ErrAnalyticsCannotGet indicates that clicks could not be counted.
+	//
+	// Handling recommendations:
+	// - Return HTTP 500 (Internal Server Error) in web handlers
+	// - Check database logs for the failed query
+	ErrAnalyticsCannotGet = errors.New("cannot get analytics")
+
+	// ErrAnalyticsCannotRecord indicates that a click could not be stored.
+	//
+	// Handling recommendations:
+	// - Log the error, redirects must not fail because of analytics
+	ErrAnalyticsCannotRecord = errors.New("cannot record click")
+)
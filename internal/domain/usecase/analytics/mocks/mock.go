@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gururuby/shortener/internal/domain/usecase/analytics (interfaces: Storage,GeoResolver)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock.go -package=mocks . Storage,GeoResolver
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStorage is a mock of Storage interface.
+type MockStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockStorageMockRecorder is the mock recorder for MockStorage.
+type MockStorageMockRecorder struct {
+	mock *MockStorage
+}
+
+// NewMockStorage creates a new mock instance.
+func NewMockStorage(ctrl *gomock.Controller) *MockStorage {
+	mock := &MockStorage{ctrl: ctrl}
+	mock.recorder = &MockStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
+	return m.recorder
+}
+
+// CountClicks mocks base method.
+func (m *MockStorage) CountClicks(ctx context.Context, namespace, alias string, from, to time.Time, granularity string) ([]entity.ClickCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountClicks", ctx, namespace, alias, from, to, granularity)
+	ret0, _ := ret[0].([]entity.ClickCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountClicks indicates an expected call of CountClicks.
+func (mr *MockStorageMockRecorder) CountClicks(ctx, namespace, alias, from, to, granularity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountClicks", reflect.TypeOf((*MockStorage)(nil).CountClicks), ctx, namespace, alias, from, to, granularity)
+}
+
+// CountClicksByLocation mocks base method.
+func (m *MockStorage) CountClicksByLocation(ctx context.Context, namespace, alias string, from, to time.Time) ([]entity.LocationCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountClicksByLocation", ctx, namespace, alias, from, to)
+	ret0, _ := ret[0].([]entity.LocationCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountClicksByLocation indicates an expected call of CountClicksByLocation.
+func (mr *MockStorageMockRecorder) CountClicksByLocation(ctx, namespace, alias, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountClicksByLocation", reflect.TypeOf((*MockStorage)(nil).CountClicksByLocation), ctx, namespace, alias, from, to)
+}
+
+// Record mocks base method.
+func (m *MockStorage) Record(ctx context.Context, namespace, alias, ipHash, anonymizedIP, userAgentHash string, location entity.GeoLocation, clickedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, namespace, alias, ipHash, anonymizedIP, userAgentHash, location, clickedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockStorageMockRecorder) Record(ctx, namespace, alias, ipHash, anonymizedIP, userAgentHash, location, clickedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockStorage)(nil).Record), ctx, namespace, alias, ipHash, anonymizedIP, userAgentHash, location, clickedAt)
+}
+
+// MockGeoResolver is a mock of GeoResolver interface.
+type MockGeoResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockGeoResolverMockRecorder
+	isgomock struct{}
+}
+
+// MockGeoResolverMockRecorder is the mock recorder for MockGeoResolver.
+type MockGeoResolverMockRecorder struct {
+	mock *MockGeoResolver
+}
+
+// NewMockGeoResolver creates a new mock instance.
+func NewMockGeoResolver(ctrl *gomock.Controller) *MockGeoResolver {
+	mock := &MockGeoResolver{ctrl: ctrl}
+	mock.recorder = &MockGeoResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGeoResolver) EXPECT() *MockGeoResolverMockRecorder {
+	return m.recorder
+}
+
+// Resolve mocks base method.
+func (m *MockGeoResolver) Resolve(ip string) (*entity.GeoLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ip)
+	ret0, _ := ret[0].(*entity.GeoLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockGeoResolverMockRecorder) Resolve(ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockGeoResolver)(nil).Resolve), ip)
+}
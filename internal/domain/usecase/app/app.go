@@ -12,7 +12,9 @@ package usecase
 
 import (
 	"context"
+	"time"
 
+	healthEntity "github.com/gururuby/shortener/internal/domain/entity/health"
 	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/app/errors"
 )
 
@@ -22,25 +24,100 @@ type Storage interface {
 	// Returns:
 	// - error: If database is not ready or connection fails
 	IsDBReady(ctx context.Context) error
+
+	// DBPoolStats returns statistics of database connection pool.
+	// Returns:
+	// - *healthEntity.DBPoolStats: Pool statistics, nil if database has no connection pool
+	DBPoolStats() *healthEntity.DBPoolStats
+
+	// DBReplicaHealth returns health of the read replica of the database.
+	// Returns:
+	// - *healthEntity.DBReplicaHealth: Replica health, nil if database has no read replica
+	DBReplicaHealth(ctx context.Context) *healthEntity.DBReplicaHealth
+}
+
+// DBHealthReport represents health of the database.
+// Ping timing and connection statistics are filled for databases working over a connection pool only.
+type DBHealthReport struct {
+	Type            string // Database type, e.g. memory or postgresql
+	PingMs          int64  // Duration of the ping in milliseconds
+	OpenConnections int32  // Number of connections currently open, both idle and in use
+	IdleConnections int32  // Number of idle connections
+	WaitCount       int64  // Number of acquires which had to wait for a free connection
+	HasPool         bool   // Whether database works over a connection pool
+
+	Replica *healthEntity.DBReplicaHealth // Health of the read replica, nil if database has no replica
+}
+
+// notAvailable replaces build information which was not set during the build.
+const notAvailable = "N/A"
+
+// BuildInfo represents build information set during the build process using ldflags.
+type BuildInfo struct {
+	Version string // Version number of the build
+	Date    string // Date when the build was created
+	Commit  string // Git commit hash of the build
+}
+
+// AppInfo represents public information about the running application.
+type AppInfo struct {
+	Name      string // Application name
+	Env       string // Application environment
+	Version   string // Version number of the build, N/A if not set
+	BuildDate string // Date when the build was created, N/A if not set
+	Commit    string // Git commit hash of the build, N/A if not set
 }
 
 // AppUseCase implements application-level use cases.
 // It coordinates between the application and storage layers.
 type AppUseCase struct {
-	storage Storage // Storage layer interface
+	storage Storage   // Storage layer interface
+	dbType  string    // Configured database type
+	name    string    // Application name
+	env     string    // Application environment
+	build   BuildInfo // Build information
 }
 
 // NewAppUseCase creates a new instance of AppUseCase.
 // Parameters:
 // - storage: Implementation of the Storage interface
+// - dbType: Configured database type reported by DBStats
+// - name: Application name reported by Info
+// - env: Application environment reported by Info
+// - build: Build information reported by Info
 // Returns:
 // - *AppUseCase: Initialized application use case instance
-func NewAppUseCase(storage Storage) *AppUseCase {
+func NewAppUseCase(storage Storage, dbType, name, env string, build BuildInfo) *AppUseCase {
 	return &AppUseCase{
 		storage: storage,
+		dbType:  dbType,
+		name:    name,
+		env:     env,
+		build:   build,
+	}
+}
+
+// Info returns public information about the running application.
+// Returns:
+// - AppInfo: Application name, environment and build information, N/A for unset build values
+func (uc *AppUseCase) Info() AppInfo {
+	return AppInfo{
+		Name:      uc.name,
+		Env:       uc.env,
+		Version:   orNotAvailable(uc.build.Version),
+		BuildDate: orNotAvailable(uc.build.Date),
+		Commit:    orNotAvailable(uc.build.Commit),
 	}
 }
 
+// orNotAvailable returns "N/A" if the build value is empty, otherwise the value itself.
+func orNotAvailable(v string) string {
+	if v == "" {
+		return notAvailable
+	}
+	return v
+}
+
 // PingDB checks the database connection status.
 // Parameters:
 // - ctx: Context for cancellation and timeouts
@@ -52,3 +129,31 @@ func (uc *AppUseCase) PingDB(ctx context.Context) error {
 	}
 	return nil
 }
+
+// DBStats pings the database and collects statistics of its connection pool and of its read replica.
+// Unavailable replica doesn't make the database not ready, reads fall back to the primary.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// Returns:
+// - *DBHealthReport: Database health, filled even if database is not ready
+// - error: Returns ErrAppDBIsNotReady if database is unavailable, nil otherwise
+func (uc *AppUseCase) DBStats(ctx context.Context) (*DBHealthReport, error) {
+	report := &DBHealthReport{Type: uc.dbType}
+
+	start := time.Now()
+	if err := uc.storage.IsDBReady(ctx); err != nil {
+		return report, ucErrors.ErrAppDBIsNotReady
+	}
+	pingDuration := time.Since(start)
+
+	if stats := uc.storage.DBPoolStats(); stats != nil {
+		report.HasPool = true
+		report.PingMs = pingDuration.Milliseconds()
+		report.OpenConnections = stats.OpenConnections
+		report.IdleConnections = stats.IdleConnections
+		report.WaitCount = stats.WaitCount
+	}
+	report.Replica = uc.storage.DBReplicaHealth(ctx)
+
+	return report, nil
+}
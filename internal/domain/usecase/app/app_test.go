@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	healthEntity "github.com/gururuby/shortener/internal/domain/entity/health"
 	storageErrors "github.com/gururuby/shortener/internal/domain/storage/errors"
 	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/app/errors"
 	"github.com/gururuby/shortener/internal/domain/usecase/app/mocks"
@@ -15,7 +16,7 @@ func Test_PingDB(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	storage := mocks.NewMockStorage(ctrl)
 	ctx := context.Background()
-	uc := NewAppUseCase(storage)
+	uc := NewAppUseCase(storage, "memory", "Shortener", "test", BuildInfo{})
 
 	t.Run("when all is ok with db", func(t *testing.T) {
 		storage.EXPECT().IsDBReady(ctx).Return(nil)
@@ -29,3 +30,78 @@ func Test_PingDB(t *testing.T) {
 		require.ErrorIs(t, ucErrors.ErrAppDBIsNotReady, err)
 	})
 }
+
+func Test_DBStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockStorage(ctrl)
+	ctx := context.Background()
+
+	t.Run("when db works over connection pool", func(t *testing.T) {
+		uc := NewAppUseCase(storage, "postgresql", "Shortener", "test", BuildInfo{})
+		storage.EXPECT().IsDBReady(ctx).Return(nil)
+		storage.EXPECT().DBPoolStats().Return(&healthEntity.DBPoolStats{OpenConnections: 5, IdleConnections: 2, WaitCount: 1})
+		storage.EXPECT().DBReplicaHealth(ctx).Return(nil)
+
+		report, err := uc.DBStats(ctx)
+		require.NoError(t, err)
+		require.Equal(t, &DBHealthReport{Type: "postgresql", OpenConnections: 5, IdleConnections: 2, WaitCount: 1, HasPool: true}, report)
+	})
+
+	t.Run("when db has read replica", func(t *testing.T) {
+		uc := NewAppUseCase(storage, "postgresql", "Shortener", "test", BuildInfo{})
+		replica := &healthEntity.DBReplicaHealth{Pool: &healthEntity.DBPoolStats{OpenConnections: 3}}
+		storage.EXPECT().IsDBReady(ctx).Return(nil)
+		storage.EXPECT().DBPoolStats().Return(&healthEntity.DBPoolStats{OpenConnections: 5})
+		storage.EXPECT().DBReplicaHealth(ctx).Return(replica)
+
+		report, err := uc.DBStats(ctx)
+		require.NoError(t, err)
+		require.Equal(t, &DBHealthReport{Type: "postgresql", OpenConnections: 5, HasPool: true, Replica: replica}, report)
+	})
+
+	t.Run("when db has no connection pool", func(t *testing.T) {
+		uc := NewAppUseCase(storage, "memory", "Shortener", "test", BuildInfo{})
+		storage.EXPECT().IsDBReady(ctx).Return(nil)
+		storage.EXPECT().DBPoolStats().Return(nil)
+		storage.EXPECT().DBReplicaHealth(ctx).Return(nil)
+
+		report, err := uc.DBStats(ctx)
+		require.NoError(t, err)
+		require.Equal(t, &DBHealthReport{Type: "memory"}, report)
+	})
+
+	t.Run("when something wrong with db", func(t *testing.T) {
+		uc := NewAppUseCase(storage, "postgresql", "Shortener", "test", BuildInfo{})
+		storage.EXPECT().IsDBReady(ctx).Return(storageErrors.ErrStorageIsNotReadyDB)
+
+		report, err := uc.DBStats(ctx)
+		require.ErrorIs(t, err, ucErrors.ErrAppDBIsNotReady)
+		require.Equal(t, &DBHealthReport{Type: "postgresql"}, report)
+	})
+}
+
+func Test_Info(t *testing.T) {
+	tests := []struct {
+		name  string
+		build BuildInfo
+		want  AppInfo
+	}{
+		{
+			name:  "when build info is set",
+			build: BuildInfo{Version: "1.2.3", Date: "2024-01-15", Commit: "abc123"},
+			want:  AppInfo{Name: "Shortener", Env: "production", Version: "1.2.3", BuildDate: "2024-01-15", Commit: "abc123"},
+		},
+		{
+			name:  "when build info is not set",
+			build: BuildInfo{},
+			want:  AppInfo{Name: "Shortener", Env: "production", Version: "N/A", BuildDate: "N/A", Commit: "N/A"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewAppUseCase(mocks.NewMockStorage(gomock.NewController(t)), "memory", "Shortener", "production", tt.build)
+			require.Equal(t, tt.want, uc.Info())
+		})
+	}
+}
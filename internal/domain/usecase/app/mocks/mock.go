@@ -13,14 +13,15 @@ import (
 	context "context"
 	reflect "reflect"
 
+	entity "github.com/gururuby/shortener/internal/domain/entity/health"
 	gomock "go.uber.org/mock/gomock"
 )
 
 // MockStorage is a mock of Storage interface.
 type MockStorage struct {
-	isgomock struct{}
 	ctrl     *gomock.Controller
 	recorder *MockStorageMockRecorder
+	isgomock struct{}
 }
 
 // MockStorageMockRecorder is the mock recorder for MockStorage.
@@ -40,6 +41,34 @@ func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
 	return m.recorder
 }
 
+// DBPoolStats mocks base method.
+func (m *MockStorage) DBPoolStats() *entity.DBPoolStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DBPoolStats")
+	ret0, _ := ret[0].(*entity.DBPoolStats)
+	return ret0
+}
+
+// DBPoolStats indicates an expected call of DBPoolStats.
+func (mr *MockStorageMockRecorder) DBPoolStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DBPoolStats", reflect.TypeOf((*MockStorage)(nil).DBPoolStats))
+}
+
+// DBReplicaHealth mocks base method.
+func (m *MockStorage) DBReplicaHealth(ctx context.Context) *entity.DBReplicaHealth {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DBReplicaHealth", ctx)
+	ret0, _ := ret[0].(*entity.DBReplicaHealth)
+	return ret0
+}
+
+// DBReplicaHealth indicates an expected call of DBReplicaHealth.
+func (mr *MockStorageMockRecorder) DBReplicaHealth(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DBReplicaHealth", reflect.TypeOf((*MockStorage)(nil).DBReplicaHealth), ctx)
+}
+
 // IsDBReady mocks base method.
 func (m *MockStorage) IsDBReady(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,55 @@
+// Package usecase contains application business logic and acts as an intermediary
+// between the presentation layer (e.g., HTTP handlers) and the data layer (e.g., database).
+// It defines namespace-specific errors.
+package usecase
+
+import "errors"
+
+// Errors list
+var (
+	// ErrNamespaceInvalidName indicates the namespace name cannot be used as a path segment.
+	//
+	// Typical cases:
+	// - Name is empty or longer than 64 characters
+	// - Name contains characters other than lowercase latin letters, digits, "-" and "_"
+	// - Name is reserved by service paths, e.g. "api" or "static"
+	//
+	// Handling recommendations:
+	// - Return HTTP 422 (Unprocessable Entity) in web handlers
+	ErrNamespaceInvalidName = errors.New("namespace name must be 1 to 64 lowercase letters, digits, - or _ and must not be reserved")
+
+	// ErrNamespaceAlreadyExist indicates a namespace with the same name already exists.
+	//
+	// Handling recommendations:
+	// - Return HTTP 409 (Conflict) in web handlers
+	ErrNamespaceAlreadyExist = errors.New("namespace already exist")
+
+	// ErrNamespaceNotFound indicates there is no namespace with the passed name.
+	//
+	// Handling recommendations:
+	// - Return HTTP 404 (Not Found) in web handlers
+	ErrNamespaceNotFound = errors.New("namespace is not found")
+
+	// ErrNamespaceNotEmpty indicates an attempt to delete a namespace which still has short URLs.
+	//
+	// Resolution:
+	// - Delete short URLs of the namespace first
+	//
+	// Handling recommendations:
+	// - Return HTTP 409 (Conflict) in web handlers
+	ErrNamespaceNotEmpty = errors.New("namespace has short URLs")
+
+	// ErrNamespaceDefault indicates an attempt to delete the default namespace,
+	// which serves short URLs created without namespace.
+	//
+	// Handling recommendations:
+	// - Return HTTP 422 (Unprocessable Entity) in web handlers
+	ErrNamespaceDefault = errors.New("default namespace cannot be deleted")
+
+	// ErrNamespaceStorageNotWorking indicates failure of the storage holding namespaces.
+	//
+	// Handling recommendations:
+	// - Return HTTP 500 (Internal Server Error) in web handlers
+	// - Check database logs for the failed query
+	ErrNamespaceStorageNotWorking = errors.New("namespace storage is not working")
+)
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gururuby/shortener/internal/domain/usecase/namespace (interfaces: Storage)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock.go -package=mocks . Storage
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/namespace"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStorage is a mock of Storage interface.
+type MockStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockStorageMockRecorder is the mock recorder for MockStorage.
+type MockStorageMockRecorder struct {
+	mock *MockStorage
+}
+
+// NewMockStorage creates a new mock instance.
+func NewMockStorage(ctrl *gomock.Controller) *MockStorage {
+	mock := &MockStorage{ctrl: ctrl}
+	mock.recorder = &MockStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
+	return m.recorder
+}
+
+// DeleteNamespace mocks base method.
+func (m *MockStorage) DeleteNamespace(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNamespace", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNamespace indicates an expected call of DeleteNamespace.
+func (mr *MockStorageMockRecorder) DeleteNamespace(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNamespace", reflect.TypeOf((*MockStorage)(nil).DeleteNamespace), ctx, name)
+}
+
+// FindNamespaces mocks base method.
+func (m *MockStorage) FindNamespaces(ctx context.Context) ([]*entity.Namespace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindNamespaces", ctx)
+	ret0, _ := ret[0].([]*entity.Namespace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindNamespaces indicates an expected call of FindNamespaces.
+func (mr *MockStorageMockRecorder) FindNamespaces(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindNamespaces", reflect.TypeOf((*MockStorage)(nil).FindNamespaces), ctx)
+}
+
+// SaveNamespace mocks base method.
+func (m *MockStorage) SaveNamespace(ctx context.Context, namespace *entity.Namespace) (*entity.Namespace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveNamespace", ctx, namespace)
+	ret0, _ := ret[0].(*entity.Namespace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveNamespace indicates an expected call of SaveNamespace.
+func (mr *MockStorageMockRecorder) SaveNamespace(ctx, namespace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveNamespace", reflect.TypeOf((*MockStorage)(nil).SaveNamespace), ctx, namespace)
+}
@@ -0,0 +1,139 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . Storage
+
+/*
+Package usecase implements the application's business logic layer.
+
+It contains:
+- Management of namespaces isolating short URL aliases
+- Validation of namespace names used as path segments
+- Error handling specific to namespace operations
+*/
+package usecase
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	namespaceEntity "github.com/gururuby/shortener/internal/domain/entity/namespace"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/namespace/errors"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+)
+
+// namePattern matches names usable as the first segment of short URL path.
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// reservedNames are first path segments of service endpoints, which cannot be namespaces.
+var reservedNames = map[string]struct{}{
+	"api":     {},
+	"debug":   {},
+	"metrics": {},
+	"ping":    {},
+	"static":  {},
+	"t":       {},
+}
+
+// Storage defines the interface for storage operations required by namespace use cases.
+type Storage interface {
+	// SaveNamespace stores a new namespace
+	SaveNamespace(ctx context.Context, namespace *namespaceEntity.Namespace) (*namespaceEntity.Namespace, error)
+	// FindNamespaces retrieves all namespaces
+	FindNamespaces(ctx context.Context) ([]*namespaceEntity.Namespace, error)
+	// DeleteNamespace removes a namespace without short URLs
+	DeleteNamespace(ctx context.Context, name string) error
+}
+
+// NamespaceUseCase implements namespace use cases.
+type NamespaceUseCase struct {
+	storage          Storage // Storage layer interface
+	defaultNamespace string  // Namespace of short URLs created without one
+}
+
+// NewNamespaceUseCase creates a new instance of NamespaceUseCase.
+// Parameters:
+// - storage: Implementation of the Storage interface
+// - defaultNamespace: Configured namespace of short URLs created without one
+// Returns:
+// - *NamespaceUseCase: Initialized namespace use case instance
+func NewNamespaceUseCase(storage Storage, defaultNamespace string) *NamespaceUseCase {
+	return &NamespaceUseCase{storage: storage, defaultNamespace: namespaceEntity.OrDefault(defaultNamespace)}
+}
+
+// CreateNamespace creates a new namespace.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - name: Namespace name
+// Returns:
+// - *namespaceEntity.Namespace: Created namespace
+// - error: ErrNamespaceInvalidName, ErrNamespaceAlreadyExist or ErrNamespaceStorageNotWorking
+func (uc *NamespaceUseCase) CreateNamespace(ctx context.Context, name string) (*namespaceEntity.Namespace, error) {
+	if !isValidName(name) {
+		return nil, ucErrors.ErrNamespaceInvalidName
+	}
+
+	namespace, err := uc.storage.SaveNamespace(ctx, &namespaceEntity.Namespace{Name: name})
+	if err != nil {
+		if errors.Is(err, dbErrors.ErrDBIsNotUnique) {
+			return nil, ucErrors.ErrNamespaceAlreadyExist
+		}
+		return nil, ucErrors.ErrNamespaceStorageNotWorking
+	}
+
+	return namespace, nil
+}
+
+// GetNamespaces retrieves all namespaces.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// Returns:
+// - []*namespaceEntity.Namespace: Namespaces ordered by name, empty if there are none
+// - error: ErrNamespaceStorageNotWorking if storage fails
+func (uc *NamespaceUseCase) GetNamespaces(ctx context.Context) ([]*namespaceEntity.Namespace, error) {
+	namespaces, err := uc.storage.FindNamespaces(ctx)
+	if err != nil {
+		return nil, ucErrors.ErrNamespaceStorageNotWorking
+	}
+
+	if namespaces == nil {
+		namespaces = make([]*namespaceEntity.Namespace, 0)
+	}
+
+	return namespaces, nil
+}
+
+// DeleteNamespace deletes a namespace without short URLs.
+// Neither the built-in default namespace nor the configured one can be deleted.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - name: Namespace name
+// Returns:
+// - error: ErrNamespaceDefault, ErrNamespaceNotFound, ErrNamespaceNotEmpty or ErrNamespaceStorageNotWorking
+func (uc *NamespaceUseCase) DeleteNamespace(ctx context.Context, name string) error {
+	if name == namespaceEntity.Default || name == uc.defaultNamespace {
+		return ucErrors.ErrNamespaceDefault
+	}
+
+	if err := uc.storage.DeleteNamespace(ctx, name); err != nil {
+		switch {
+		case errors.Is(err, dbErrors.ErrDBRecordNotFound):
+			return ucErrors.ErrNamespaceNotFound
+		case errors.Is(err, dbErrors.ErrDBRecordIsReferenced):
+			return ucErrors.ErrNamespaceNotEmpty
+		}
+		return ucErrors.ErrNamespaceStorageNotWorking
+	}
+
+	return nil
+}
+
+// isValidName reports whether the name may be used as a namespace.
+// Parameters:
+// - name: Namespace name
+// Returns:
+// - bool: true if name matches namePattern and is not reserved
+func isValidName(name string) bool {
+	if _, ok := reservedNames[name]; ok {
+		return false
+	}
+	return namePattern.MatchString(name)
+}
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	namespaceEntity "github.com/gururuby/shortener/internal/domain/entity/namespace"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/namespace/errors"
+	"github.com/gururuby/shortener/internal/domain/usecase/namespace/mocks"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_CreateNamespace(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		setup         func(storage *mocks.MockStorage)
+		want          *namespaceEntity.Namespace
+		wantErr       error
+		name          string
+		namespaceName string
+	}{
+		{
+			name:          "when namespace is created",
+			namespaceName: "team-a",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().SaveNamespace(ctx, &namespaceEntity.Namespace{Name: "team-a"}).Return(&namespaceEntity.Namespace{Name: "team-a"}, nil)
+			},
+			want: &namespaceEntity.Namespace{Name: "team-a"},
+		},
+		{
+			name:          "when name is empty",
+			namespaceName: "",
+			wantErr:       ucErrors.ErrNamespaceInvalidName,
+		},
+		{
+			name:          "when name contains slash",
+			namespaceName: "team/a",
+			wantErr:       ucErrors.ErrNamespaceInvalidName,
+		},
+		{
+			name:          "when name contains uppercase letters",
+			namespaceName: "Team",
+			wantErr:       ucErrors.ErrNamespaceInvalidName,
+		},
+		{
+			name:          "when name is too long",
+			namespaceName: strings.Repeat("a", 65),
+			wantErr:       ucErrors.ErrNamespaceInvalidName,
+		},
+		{
+			name:          "when name is reserved",
+			namespaceName: "api",
+			wantErr:       ucErrors.ErrNamespaceInvalidName,
+		},
+		{
+			name:          "when namespace already exists",
+			namespaceName: "team-a",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().SaveNamespace(ctx, gomock.Any()).Return(nil, dbErrors.ErrDBIsNotUnique)
+			},
+			wantErr: ucErrors.ErrNamespaceAlreadyExist,
+		},
+		{
+			name:          "when storage fails",
+			namespaceName: "team-a",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().SaveNamespace(ctx, gomock.Any()).Return(nil, dbErrors.ErrDBQuery)
+			},
+			wantErr: ucErrors.ErrNamespaceStorageNotWorking,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockStorage(ctrl)
+			if tt.setup != nil {
+				tt.setup(storage)
+			}
+
+			namespace, err := NewNamespaceUseCase(storage, "").CreateNamespace(ctx, tt.namespaceName)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, namespace)
+		})
+	}
+}
+
+func Test_GetNamespaces(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("when namespaces are found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		storage := mocks.NewMockStorage(ctrl)
+		namespaces := []*namespaceEntity.Namespace{{Name: "default"}, {Name: "team-a"}}
+		storage.EXPECT().FindNamespaces(ctx).Return(namespaces, nil)
+
+		res, err := NewNamespaceUseCase(storage, "").GetNamespaces(ctx)
+		require.NoError(t, err)
+		require.Equal(t, namespaces, res)
+	})
+
+	t.Run("when there are no namespaces", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		storage := mocks.NewMockStorage(ctrl)
+		storage.EXPECT().FindNamespaces(ctx).Return(nil, nil)
+
+		res, err := NewNamespaceUseCase(storage, "").GetNamespaces(ctx)
+		require.NoError(t, err)
+		require.Empty(t, res)
+		require.NotNil(t, res)
+	})
+
+	t.Run("when storage fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		storage := mocks.NewMockStorage(ctrl)
+		storage.EXPECT().FindNamespaces(ctx).Return(nil, dbErrors.ErrDBQuery)
+
+		_, err := NewNamespaceUseCase(storage, "").GetNamespaces(ctx)
+		require.ErrorIs(t, err, ucErrors.ErrNamespaceStorageNotWorking)
+	})
+}
+
+func Test_DeleteNamespace(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		setup         func(storage *mocks.MockStorage)
+		wantErr       error
+		name          string
+		namespaceName string
+	}{
+		{
+			name:          "when namespace is deleted",
+			namespaceName: "team-a",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().DeleteNamespace(ctx, "team-a").Return(nil)
+			},
+		},
+		{
+			name:          "when namespace is built-in default",
+			namespaceName: "default",
+			wantErr:       ucErrors.ErrNamespaceDefault,
+		},
+		{
+			name:          "when namespace is configured default",
+			namespaceName: "marketing",
+			wantErr:       ucErrors.ErrNamespaceDefault,
+		},
+		{
+			name:          "when namespace does not exist",
+			namespaceName: "team-a",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().DeleteNamespace(ctx, "team-a").Return(dbErrors.ErrDBRecordNotFound)
+			},
+			wantErr: ucErrors.ErrNamespaceNotFound,
+		},
+		{
+			name:          "when namespace has short URLs",
+			namespaceName: "team-a",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().DeleteNamespace(ctx, "team-a").Return(dbErrors.ErrDBRecordIsReferenced)
+			},
+			wantErr: ucErrors.ErrNamespaceNotEmpty,
+		},
+		{
+			name:          "when storage fails",
+			namespaceName: "team-a",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().DeleteNamespace(ctx, "team-a").Return(dbErrors.ErrDBQuery)
+			},
+			wantErr: ucErrors.ErrNamespaceStorageNotWorking,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockStorage(ctrl)
+			if tt.setup != nil {
+				tt.setup(storage)
+			}
+
+			err := NewNamespaceUseCase(storage, "marketing").DeleteNamespace(ctx, tt.namespaceName)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
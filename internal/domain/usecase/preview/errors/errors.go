@@ -0,0 +1,32 @@
+// Package usecase contains application business logic and acts as an intermediary
+// between the presentation layer (e.g., HTTP handlers) and the data layer (e.g., database).
+// It defines preview-specific errors.
+package usecase
+
+import "errors"
+
+// Errors list
+var (
+	// ErrPreviewInvalidURL indicates the URL to preview is not a valid http(s) URL.
+	//
+	// Handling recommendations:
+	// - Return HTTP 422 (Unprocessable Entity) in web handlers
+	ErrPreviewInvalidURL = errors.New("invalid URL, please specify valid URL")
+
+	// ErrPreviewDomainNotPermitted indicates the URL domain is rejected by domain filter
+	// or resolves to a private address.
+	//
+	// Handling recommendations:
+	// - Return HTTP 422 (Unprocessable Entity) in web handlers
+	ErrPreviewDomainNotPermitted = errors.New("URL domain is not permitted")
+
+	// ErrPreviewUnavailable indicates the page cannot be fetched or is not an HTML document.
+	//
+	// Typical cases:
+	// - Page host is unreachable or responds too slowly
+	// - Page responds with error status
+	//
+	// Handling recommendations:
+	// - Return HTTP 502 (Bad Gateway) in web handlers
+	ErrPreviewUnavailable = errors.New("cannot fetch page preview")
+)
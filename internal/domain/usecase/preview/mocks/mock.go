@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gururuby/shortener/internal/domain/usecase/preview (interfaces: Scraper,DomainFilter)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock.go -package=mocks . Scraper,DomainFilter
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	preview "github.com/gururuby/shortener/pkg/preview"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockScraper is a mock of Scraper interface.
+type MockScraper struct {
+	ctrl     *gomock.Controller
+	recorder *MockScraperMockRecorder
+	isgomock struct{}
+}
+
+// MockScraperMockRecorder is the mock recorder for MockScraper.
+type MockScraperMockRecorder struct {
+	mock *MockScraper
+}
+
+// NewMockScraper creates a new mock instance.
+func NewMockScraper(ctrl *gomock.Controller) *MockScraper {
+	mock := &MockScraper{ctrl: ctrl}
+	mock.recorder = &MockScraperMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScraper) EXPECT() *MockScraperMockRecorder {
+	return m.recorder
+}
+
+// Fetch mocks base method.
+func (m *MockScraper) Fetch(ctx context.Context, url string) (*preview.Metadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fetch", ctx, url)
+	ret0, _ := ret[0].(*preview.Metadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Fetch indicates an expected call of Fetch.
+func (mr *MockScraperMockRecorder) Fetch(ctx, url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fetch", reflect.TypeOf((*MockScraper)(nil).Fetch), ctx, url)
+}
+
+// MockDomainFilter is a mock of DomainFilter interface.
+type MockDomainFilter struct {
+	ctrl     *gomock.Controller
+	recorder *MockDomainFilterMockRecorder
+	isgomock struct{}
+}
+
+// MockDomainFilterMockRecorder is the mock recorder for MockDomainFilter.
+type MockDomainFilterMockRecorder struct {
+	mock *MockDomainFilter
+}
+
+// NewMockDomainFilter creates a new mock instance.
+func NewMockDomainFilter(ctrl *gomock.Controller) *MockDomainFilter {
+	mock := &MockDomainFilter{ctrl: ctrl}
+	mock.recorder = &MockDomainFilterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDomainFilter) EXPECT() *MockDomainFilterMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockDomainFilter) Allow(rawURL string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", rawURL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockDomainFilterMockRecorder) Allow(rawURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockDomainFilter)(nil).Allow), rawURL)
+}
@@ -0,0 +1,82 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . Scraper,DomainFilter
+
+/*
+Package usecase implements the application's business logic layer.
+
+It contains:
+- Page previews with title, description, image and favicon shown before shortening a URL
+- Validation of URLs to preview against domain filter
+- Error handling specific to preview operations
+*/
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/preview/errors"
+	"github.com/gururuby/shortener/pkg/preview"
+	previewErrors "github.com/gururuby/shortener/pkg/preview/errors"
+	"github.com/gururuby/shortener/pkg/validator"
+)
+
+// Scraper defines the interface for fetching page metadata.
+type Scraper interface {
+	// Fetch returns metadata of the page at the URL
+	Fetch(ctx context.Context, url string) (*preview.Metadata, error)
+}
+
+// DomainFilter defines the interface for restricting domains of previewed URLs.
+type DomainFilter interface {
+	// Allow checks whether the URL domain is permitted.
+	// Returns:
+	// - error: Reason of rejection or nil if the domain is permitted
+	Allow(rawURL string) error
+}
+
+// PreviewUseCase implements page preview use cases.
+type PreviewUseCase struct {
+	scraper Scraper      // Fetcher of page metadata
+	filter  DomainFilter // Filter of previewed URL domains
+}
+
+// NewPreviewUseCase creates a new preview use case instance.
+// Parameters:
+// - scraper: Fetcher of page metadata
+// - filter: Filter of previewed URL domains, the same as used for shortening
+// Returns:
+// - *PreviewUseCase: Initialized use case
+func NewPreviewUseCase(scraper Scraper, filter DomainFilter) *PreviewUseCase {
+	return &PreviewUseCase{scraper: scraper, filter: filter}
+}
+
+// Preview returns metadata of the page at the URL.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - rawURL: URL of the page, internationalized domain names are allowed
+// Returns:
+// - *preview.Metadata: Page title, description, image and favicon
+// - error: ucErrors.ErrPreviewInvalidURL, ucErrors.ErrPreviewDomainNotPermitted
+// or ucErrors.ErrPreviewUnavailable
+func (uc *PreviewUseCase) Preview(ctx context.Context, rawURL string) (*preview.Metadata, error) {
+	// Internationalized domain names are fetched in ASCII-compatible encoding
+	normalized, err := validator.NormalizeIDN(rawURL)
+	if err != nil || validator.IsInvalidURL(normalized) {
+		return nil, ucErrors.ErrPreviewInvalidURL
+	}
+
+	if err = uc.filter.Allow(normalized); err != nil {
+		return nil, fmt.Errorf("%w: %w", ucErrors.ErrPreviewDomainNotPermitted, err)
+	}
+
+	md, err := uc.scraper.Fetch(ctx, normalized)
+	if err != nil {
+		if errors.Is(err, previewErrors.ErrPreviewPrivateAddress) {
+			return nil, fmt.Errorf("%w: %w", ucErrors.ErrPreviewDomainNotPermitted, err)
+		}
+		return nil, fmt.Errorf("%w: %w", ucErrors.ErrPreviewUnavailable, err)
+	}
+
+	return md, nil
+}
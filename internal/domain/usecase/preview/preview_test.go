@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/preview/errors"
+	"github.com/gururuby/shortener/internal/domain/usecase/preview/mocks"
+	"github.com/gururuby/shortener/pkg/preview"
+	previewErrors "github.com/gururuby/shortener/pkg/preview/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Preview(t *testing.T) {
+	ctx := context.Background()
+	errFiltered := errors.New("domain is blacklisted")
+	metadata := &preview.Metadata{URL: "https://example.com", Title: "Example"}
+
+	tests := []struct {
+		setup   func(scraper *mocks.MockScraper, filter *mocks.MockDomainFilter)
+		want    *preview.Metadata
+		wantErr error
+		name    string
+		url     string
+	}{
+		{
+			name: "when page is fetched",
+			url:  "https://example.com",
+			setup: func(scraper *mocks.MockScraper, filter *mocks.MockDomainFilter) {
+				filter.EXPECT().Allow("https://example.com").Return(nil)
+				scraper.EXPECT().Fetch(ctx, "https://example.com").Return(metadata, nil)
+			},
+			want: metadata,
+		},
+		{
+			name: "when domain is internationalized",
+			url:  "https://münchen.de",
+			setup: func(scraper *mocks.MockScraper, filter *mocks.MockDomainFilter) {
+				filter.EXPECT().Allow("https://xn--mnchen-3ya.de").Return(nil)
+				scraper.EXPECT().Fetch(ctx, "https://xn--mnchen-3ya.de").Return(metadata, nil)
+			},
+			want: metadata,
+		},
+		{
+			name:    "when URL is invalid",
+			url:     "example",
+			wantErr: ucErrors.ErrPreviewInvalidURL,
+		},
+		{
+			name: "when domain is not permitted",
+			url:  "https://example.com",
+			setup: func(_ *mocks.MockScraper, filter *mocks.MockDomainFilter) {
+				filter.EXPECT().Allow("https://example.com").Return(errFiltered)
+			},
+			wantErr: errFiltered,
+		},
+		{
+			name: "when host resolves to private address",
+			url:  "https://internal.example.com",
+			setup: func(scraper *mocks.MockScraper, filter *mocks.MockDomainFilter) {
+				filter.EXPECT().Allow(gomock.Any()).Return(nil)
+				scraper.EXPECT().Fetch(ctx, gomock.Any()).Return(nil, previewErrors.ErrPreviewPrivateAddress)
+			},
+			wantErr: ucErrors.ErrPreviewDomainNotPermitted,
+		},
+		{
+			name: "when page cannot be fetched",
+			url:  "https://example.com",
+			setup: func(scraper *mocks.MockScraper, filter *mocks.MockDomainFilter) {
+				filter.EXPECT().Allow(gomock.Any()).Return(nil)
+				scraper.EXPECT().Fetch(ctx, gomock.Any()).Return(nil, previewErrors.ErrPreviewUnexpectedStatus)
+			},
+			wantErr: ucErrors.ErrPreviewUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			scraper := mocks.NewMockScraper(ctrl)
+			filter := mocks.NewMockDomainFilter(ctrl)
+			if tt.setup != nil {
+				tt.setup(scraper, filter)
+			}
+
+			got, err := NewPreviewUseCase(scraper, filter).Preview(ctx, tt.url)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
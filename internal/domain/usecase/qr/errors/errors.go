@@ -0,0 +1,18 @@
+// Package usecase contains business logic for QR code generation.
+// It defines domain-specific errors for QR code operations.
+package usecase
+
+import "errors"
+
+// Errors list
+var (
+	// ErrQRCannotEncode indicates that the short URL could not be encoded as a QR code.
+	//
+	// Common causes:
+	// - Content is too long to fit the largest QR code version
+	// - Image encoding failure
+	//
+	// Handling recommendations:
+	// - Return HTTP 500 (Internal Server Error) in web handlers
+	ErrQRCannotEncode = errors.New("cannot encode QR code")
+)
@@ -0,0 +1,111 @@
+/*
+Package usecase implements the business logic for QR code generation.
+
+It provides:
+- Encoding of short URLs as PNG and SVG QR codes
+- Normalization of requested image size
+- Error handling specific to QR code operations
+*/
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/qr/errors"
+	"github.com/skip2/go-qrcode"
+)
+
+// Image size limits (in pixels) for generated QR codes.
+const (
+	DefaultSize = 256  // Size used when none is requested
+	MinSize     = 64   // Smallest allowed size
+	MaxSize     = 1024 // Largest allowed size
+)
+
+// QRUseCase implements the business logic for QR code generation.
+type QRUseCase struct {
+	baseURL string // Base URL for shortened links
+}
+
+// NewQRUseCase creates a new instance of QRUseCase.
+// Parameters:
+// - baseURL: Base URL for shortened links
+// Returns:
+// - *QRUseCase: Initialized QR use case
+func NewQRUseCase(baseURL string) *QRUseCase {
+	return &QRUseCase{baseURL: baseURL}
+}
+
+// PNG encodes the short URL of the alias as a PNG QR code.
+// Parameters:
+// - alias: Short URL identifier
+// - size: Requested image size, see ClampSize
+// Returns:
+// - []byte: PNG image
+// - error: ErrQRCannotEncode if encoding fails
+func (u *QRUseCase) PNG(alias string, size int) ([]byte, error) {
+	png, err := qrcode.Encode(u.shortURL(alias), qrcode.Medium, ClampSize(size))
+	if err != nil {
+		return nil, ucErrors.ErrQRCannotEncode
+	}
+	return png, nil
+}
+
+// SVG encodes the short URL of the alias as an SVG QR code.
+// Parameters:
+// - alias: Short URL identifier
+// - size: Requested image size, see ClampSize
+// Returns:
+// - []byte: SVG image
+// - error: ErrQRCannotEncode if encoding fails
+func (u *QRUseCase) SVG(alias string, size int) ([]byte, error) {
+	var buf bytes.Buffer
+
+	code, err := qrcode.New(u.shortURL(alias), qrcode.Medium)
+	if err != nil {
+		return nil, ucErrors.ErrQRCannotEncode
+	}
+
+	bitmap := code.Bitmap()
+	size = ClampSize(size)
+
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		size, size, len(bitmap), len(bitmap))
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#ffffff"/>`, len(bitmap), len(bitmap))
+	buf.WriteString(`<path fill="#000000" d="`)
+	for y, row := range bitmap {
+		for x, black := range row {
+			if black {
+				fmt.Fprintf(&buf, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	buf.WriteString(`"/></svg>`)
+
+	return buf.Bytes(), nil
+}
+
+// ClampSize normalizes requested image size.
+// Zero or negative size becomes DefaultSize, others are clamped to MinSize–MaxSize.
+// Parameters:
+// - size: Requested image size
+// Returns:
+// - int: Size to use
+func ClampSize(size int) int {
+	switch {
+	case size <= 0:
+		return DefaultSize
+	case size < MinSize:
+		return MinSize
+	case size > MaxSize:
+		return MaxSize
+	default:
+		return size
+	}
+}
+
+// shortURL builds full short URL for the alias.
+func (u *QRUseCase) shortURL(alias string) string {
+	return u.baseURL + "/" + alias
+}
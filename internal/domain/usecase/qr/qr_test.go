@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PNG_OK(t *testing.T) {
+	uc := NewQRUseCase("http://localhost:8080")
+
+	tests := []struct {
+		name string
+		size int
+		want int
+	}{
+		{name: "when size is in range", size: 128, want: 128},
+		{name: "when size is not passed", size: 0, want: DefaultSize},
+		{name: "when size is less than min", size: 10, want: MinSize},
+		{name: "when size is greater than max", size: 5000, want: MaxSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := uc.PNG("alias", tt.size)
+			require.NoError(t, err)
+
+			img, err := png.Decode(bytes.NewReader(res))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, img.Bounds().Dx())
+			assert.Equal(t, tt.want, img.Bounds().Dy())
+		})
+	}
+}
+
+func Test_SVG_OK(t *testing.T) {
+	uc := NewQRUseCase("http://localhost:8080")
+
+	res, err := uc.SVG("alias", 300)
+	require.NoError(t, err)
+
+	svg := string(res)
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="300" height="300"`))
+	assert.True(t, strings.HasSuffix(svg, `</svg>`))
+}
+
+func Test_ClampSize(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		want int
+	}{
+		{name: "when size is negative", size: -1, want: DefaultSize},
+		{name: "when size is zero", size: 0, want: DefaultSize},
+		{name: "when size is below min", size: MinSize - 1, want: MinSize},
+		{name: "when size is min", size: MinSize, want: MinSize},
+		{name: "when size is max", size: MaxSize, want: MaxSize},
+		{name: "when size is above max", size: MaxSize + 1, want: MaxSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClampSize(tt.size))
+		})
+	}
+}
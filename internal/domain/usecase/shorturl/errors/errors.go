@@ -52,4 +52,67 @@ var (
 	// - May want to track deletion timestamps
 	// - Could allow recreation after cleanup period
 	ErrShortURLDeleted = errors.New("short URL was deleted")
+
+	// ErrShortURLUnsafeContent indicates the source URL is flagged by URL checker
+	// as hosting malware, phishing or other unwanted content.
+	//
+	// Note: URL is not saved and no short URL is issued
+	ErrShortURLUnsafeContent = errors.New("URL flagged as unsafe")
+
+	// ErrShortURLDomainNotPermitted indicates the source URL domain is blacklisted
+	// or is not whitelisted by the configured domain filter.
+	//
+	// Note: The filter error is wrapped, so its exact reason can be checked with errors.Is
+	ErrShortURLDomainNotPermitted = errors.New("domain is not permitted")
+
+	// ErrShortURLForbidden indicates the requested short URL is private
+	// and the requesting user is not its owner (403 equivalent).
+	//
+	// Note: Anonymous requests to private URLs are rejected the same way
+	ErrShortURLForbidden = errors.New("short URL is private")
+
+	// ErrShortURLOwnerRequired indicates an attempt to create a private short URL
+	// without a user, so nobody would be able to follow it.
+	ErrShortURLOwnerRequired = errors.New("private short URL requires an owner")
+
+	// ErrShortURLNamespaceNotFound indicates an attempt to create a short URL
+	// in a namespace which doesn't exist.
+	//
+	// Resolution: Create the namespace first or omit it to use the default one
+	ErrShortURLNamespaceNotFound = errors.New("namespace not found")
+
+	// ErrShortURLRedirectLoop indicates the source URL redirects in a loop
+	// or through more hops than allowed, so the short URL would never resolve.
+	//
+	// Note: Checked only if redirect chain check is enabled
+	ErrShortURLRedirectLoop = errors.New("source URL redirects in a loop")
+
+	// ErrShortURLDestinationUnreachable indicates the final destination of the source URL
+	// cannot be reached or responds with 4xx/5xx status.
+	//
+	// Note: Checked only if redirect chain check is enabled
+	ErrShortURLDestinationUnreachable = errors.New("source URL destination is unreachable")
+
+	// ErrShortURLInvalidAlias indicates the requested custom alias cannot be used in a short URL path.
+	//
+	// Typical cases:
+	// - Alias contains `/`, spaces or non-latin letters
+	// - Alias is longer than 64 characters
+	ErrShortURLInvalidAlias = errors.New("alias must be 1 to 64 latin letters, digits, - or _")
+
+	// ErrShortURLAliasTaken indicates the requested custom alias is already used
+	// by another short URL in the namespace.
+	//
+	// Resolution: Choose a different alias
+	ErrShortURLAliasTaken = errors.New("alias is already taken")
+
+	// ErrShortURLInvalidExpiration indicates the requested expiration time
+	// of a short URL is not in the future.
+	ErrShortURLInvalidExpiration = errors.New("expiration time must be in the future")
+
+	// ErrUserURLQuotaExceeded indicates the user already owns the maximum number
+	// of short URLs, anonymous URLs are limited separately.
+	//
+	// Resolution: Delete unused short URLs of the user
+	ErrUserURLQuotaExceeded = errors.New("URL quota exceeded")
 )
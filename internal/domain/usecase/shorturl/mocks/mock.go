@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/gururuby/shortener/internal/domain/usecase/shorturl (interfaces: ShortURLStorage)
+// Source: github.com/gururuby/shortener/internal/domain/usecase/shorturl (interfaces: ShortURLStorage,BatchSaver,DuplicateFinder,URLChecker,DomainFilter,EventBus,EventPublisher,UserStorage)
 //
 // Generated by this command:
 //
-//	mockgen -destination=./mocks/mock.go -package=mocks . ShortURLStorage
+//	mockgen -destination=./mocks/mock.go -package=mocks . ShortURLStorage,BatchSaver,DuplicateFinder,URLChecker,DomainFilter,EventBus,EventPublisher,UserStorage
 //
 
 // Package mocks is a generated GoMock package.
@@ -15,14 +15,15 @@ import (
 
 	entity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
 	entity0 "github.com/gururuby/shortener/internal/domain/entity/user"
+	eventbus "github.com/gururuby/shortener/internal/infra/eventbus"
 	gomock "go.uber.org/mock/gomock"
 )
 
 // MockShortURLStorage is a mock of ShortURLStorage interface.
 type MockShortURLStorage struct {
-	isgomock struct{}
 	ctrl     *gomock.Controller
 	recorder *MockShortURLStorageMockRecorder
+	isgomock struct{}
 }
 
 // MockShortURLStorageMockRecorder is the mock recorder for MockShortURLStorage.
@@ -43,31 +44,311 @@ func (m *MockShortURLStorage) EXPECT() *MockShortURLStorageMockRecorder {
 }
 
 // FindShortURL mocks base method.
-func (m *MockShortURLStorage) FindShortURL(ctx context.Context, alias string) (*entity.ShortURL, error) {
+func (m *MockShortURLStorage) FindShortURL(ctx context.Context, namespace, alias string) (*entity.ShortURL, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindShortURL", ctx, alias)
+	ret := m.ctrl.Call(m, "FindShortURL", ctx, namespace, alias)
 	ret0, _ := ret[0].(*entity.ShortURL)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindShortURL indicates an expected call of FindShortURL.
-func (mr *MockShortURLStorageMockRecorder) FindShortURL(ctx, alias any) *gomock.Call {
+func (mr *MockShortURLStorageMockRecorder) FindShortURL(ctx, namespace, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShortURL", reflect.TypeOf((*MockShortURLStorage)(nil).FindShortURL), ctx, namespace, alias)
+}
+
+// MarkURLAsDeleted mocks base method.
+func (m *MockShortURLStorage) MarkURLAsDeleted(ctx context.Context, userID int, namespace string, aliases []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkURLAsDeleted", ctx, userID, namespace, aliases)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkURLAsDeleted indicates an expected call of MarkURLAsDeleted.
+func (mr *MockShortURLStorageMockRecorder) MarkURLAsDeleted(ctx, userID, namespace, aliases any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShortURL", reflect.TypeOf((*MockShortURLStorage)(nil).FindShortURL), ctx, alias)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkURLAsDeleted", reflect.TypeOf((*MockShortURLStorage)(nil).MarkURLAsDeleted), ctx, userID, namespace, aliases)
 }
 
 // SaveShortURL mocks base method.
-func (m *MockShortURLStorage) SaveShortURL(ctx context.Context, user *entity0.User, sourceURL string) (*entity.ShortURL, error) {
+func (m *MockShortURLStorage) SaveShortURL(ctx context.Context, user *entity0.User, sourceURL, normalizedURL string, opts entity.CreateOptions) (*entity.ShortURL, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveShortURL", ctx, user, sourceURL)
+	ret := m.ctrl.Call(m, "SaveShortURL", ctx, user, sourceURL, normalizedURL, opts)
 	ret0, _ := ret[0].(*entity.ShortURL)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SaveShortURL indicates an expected call of SaveShortURL.
-func (mr *MockShortURLStorageMockRecorder) SaveShortURL(ctx, user, sourceURL any) *gomock.Call {
+func (mr *MockShortURLStorageMockRecorder) SaveShortURL(ctx, user, sourceURL, normalizedURL, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveShortURL", reflect.TypeOf((*MockShortURLStorage)(nil).SaveShortURL), ctx, user, sourceURL, normalizedURL, opts)
+}
+
+// MockBatchSaver is a mock of BatchSaver interface.
+type MockBatchSaver struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchSaverMockRecorder
+	isgomock struct{}
+}
+
+// MockBatchSaverMockRecorder is the mock recorder for MockBatchSaver.
+type MockBatchSaverMockRecorder struct {
+	mock *MockBatchSaver
+}
+
+// NewMockBatchSaver creates a new mock instance.
+func NewMockBatchSaver(ctrl *gomock.Controller) *MockBatchSaver {
+	mock := &MockBatchSaver{ctrl: ctrl}
+	mock.recorder = &MockBatchSaverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchSaver) EXPECT() *MockBatchSaverMockRecorder {
+	return m.recorder
+}
+
+// SaveShortURLBatch mocks base method.
+func (m *MockBatchSaver) SaveShortURLBatch(ctx context.Context, user *entity0.User, urls []entity.BatchShortURLInput) (*entity.BatchSaveResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveShortURLBatch", ctx, user, urls)
+	ret0, _ := ret[0].(*entity.BatchSaveResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveShortURLBatch indicates an expected call of SaveShortURLBatch.
+func (mr *MockBatchSaverMockRecorder) SaveShortURLBatch(ctx, user, urls any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveShortURLBatch", reflect.TypeOf((*MockBatchSaver)(nil).SaveShortURLBatch), ctx, user, urls)
+}
+
+// MockDuplicateFinder is a mock of DuplicateFinder interface.
+type MockDuplicateFinder struct {
+	ctrl     *gomock.Controller
+	recorder *MockDuplicateFinderMockRecorder
+	isgomock struct{}
+}
+
+// MockDuplicateFinderMockRecorder is the mock recorder for MockDuplicateFinder.
+type MockDuplicateFinderMockRecorder struct {
+	mock *MockDuplicateFinder
+}
+
+// NewMockDuplicateFinder creates a new mock instance.
+func NewMockDuplicateFinder(ctrl *gomock.Controller) *MockDuplicateFinder {
+	mock := &MockDuplicateFinder{ctrl: ctrl}
+	mock.recorder = &MockDuplicateFinderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDuplicateFinder) EXPECT() *MockDuplicateFinderMockRecorder {
+	return m.recorder
+}
+
+// FindDuplicate mocks base method.
+func (m *MockDuplicateFinder) FindDuplicate(ctx context.Context, user *entity0.User, sourceURL, normalizedURL string, opts entity.CreateOptions) (*entity.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDuplicate", ctx, user, sourceURL, normalizedURL, opts)
+	ret0, _ := ret[0].(*entity.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDuplicate indicates an expected call of FindDuplicate.
+func (mr *MockDuplicateFinderMockRecorder) FindDuplicate(ctx, user, sourceURL, normalizedURL, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDuplicate", reflect.TypeOf((*MockDuplicateFinder)(nil).FindDuplicate), ctx, user, sourceURL, normalizedURL, opts)
+}
+
+// MockURLChecker is a mock of URLChecker interface.
+type MockURLChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockURLCheckerMockRecorder
+	isgomock struct{}
+}
+
+// MockURLCheckerMockRecorder is the mock recorder for MockURLChecker.
+type MockURLCheckerMockRecorder struct {
+	mock *MockURLChecker
+}
+
+// NewMockURLChecker creates a new mock instance.
+func NewMockURLChecker(ctrl *gomock.Controller) *MockURLChecker {
+	mock := &MockURLChecker{ctrl: ctrl}
+	mock.recorder = &MockURLCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockURLChecker) EXPECT() *MockURLCheckerMockRecorder {
+	return m.recorder
+}
+
+// IsUnsafe mocks base method.
+func (m *MockURLChecker) IsUnsafe(ctx context.Context, url string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUnsafe", ctx, url)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUnsafe indicates an expected call of IsUnsafe.
+func (mr *MockURLCheckerMockRecorder) IsUnsafe(ctx, url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUnsafe", reflect.TypeOf((*MockURLChecker)(nil).IsUnsafe), ctx, url)
+}
+
+// MockDomainFilter is a mock of DomainFilter interface.
+type MockDomainFilter struct {
+	ctrl     *gomock.Controller
+	recorder *MockDomainFilterMockRecorder
+	isgomock struct{}
+}
+
+// MockDomainFilterMockRecorder is the mock recorder for MockDomainFilter.
+type MockDomainFilterMockRecorder struct {
+	mock *MockDomainFilter
+}
+
+// NewMockDomainFilter creates a new mock instance.
+func NewMockDomainFilter(ctrl *gomock.Controller) *MockDomainFilter {
+	mock := &MockDomainFilter{ctrl: ctrl}
+	mock.recorder = &MockDomainFilterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDomainFilter) EXPECT() *MockDomainFilterMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockDomainFilter) Allow(rawURL string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", rawURL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockDomainFilterMockRecorder) Allow(rawURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockDomainFilter)(nil).Allow), rawURL)
+}
+
+// MockEventBus is a mock of EventBus interface.
+type MockEventBus struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventBusMockRecorder
+	isgomock struct{}
+}
+
+// MockEventBusMockRecorder is the mock recorder for MockEventBus.
+type MockEventBusMockRecorder struct {
+	mock *MockEventBus
+}
+
+// NewMockEventBus creates a new mock instance.
+func NewMockEventBus(ctrl *gomock.Controller) *MockEventBus {
+	mock := &MockEventBus{ctrl: ctrl}
+	mock.recorder = &MockEventBusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventBus) EXPECT() *MockEventBusMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEventBus) Publish(event eventbus.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", event)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventBusMockRecorder) Publish(event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventBus)(nil).Publish), event)
+}
+
+// MockEventPublisher is a mock of EventPublisher interface.
+type MockEventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockEventPublisherMockRecorder is the mock recorder for MockEventPublisher.
+type MockEventPublisherMockRecorder struct {
+	mock *MockEventPublisher
+}
+
+// NewMockEventPublisher creates a new mock instance.
+func NewMockEventPublisher(ctrl *gomock.Controller) *MockEventPublisher {
+	mock := &MockEventPublisher{ctrl: ctrl}
+	mock.recorder = &MockEventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventPublisher) EXPECT() *MockEventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEventPublisher) Publish(userID int, event *entity.ShortURLCreatedEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", userID, event)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventPublisherMockRecorder) Publish(userID, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventPublisher)(nil).Publish), userID, event)
+}
+
+// MockUserStorage is a mock of UserStorage interface.
+type MockUserStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockUserStorageMockRecorder is the mock recorder for MockUserStorage.
+type MockUserStorageMockRecorder struct {
+	mock *MockUserStorage
+}
+
+// NewMockUserStorage creates a new mock instance.
+func NewMockUserStorage(ctrl *gomock.Controller) *MockUserStorage {
+	mock := &MockUserStorage{ctrl: ctrl}
+	mock.recorder = &MockUserStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserStorage) EXPECT() *MockUserStorageMockRecorder {
+	return m.recorder
+}
+
+// CountURLsByUser mocks base method.
+func (m *MockUserStorage) CountURLsByUser(ctx context.Context, userID int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountURLsByUser", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountURLsByUser indicates an expected call of CountURLsByUser.
+func (mr *MockUserStorageMockRecorder) CountURLsByUser(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveShortURL", reflect.TypeOf((*MockShortURLStorage)(nil).SaveShortURL), ctx, user, sourceURL)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountURLsByUser", reflect.TypeOf((*MockUserStorage)(nil).CountURLsByUser), ctx, userID)
 }
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+)
+
+// UserStorage defines the interface for counting short URLs of users.
+type UserStorage interface {
+	// CountURLsByUser returns the number of not deleted short URLs of a user.
+	// Returns:
+	// - int64: Number of user's short URLs, of anonymous ones if userID is 0
+	// - error: Any error that occurred during count
+	CountURLsByUser(ctx context.Context, userID int) (int64, error)
+}
+
+// Option configures optional capabilities of ShortURLUseCase.
+type Option func(*ShortURLUseCase)
+
+// WithUserStorage enables quotas of short URLs set by WithURLQuota.
+// Parameters:
+// - storage: Implementation of UserStorage
+// Returns:
+// - Option: ShortURLUseCase option
+func WithUserStorage(storage UserStorage) Option {
+	return func(u *ShortURLUseCase) {
+		u.userStorage = storage
+	}
+}
+
+// WithURLQuota limits the number of not deleted short URLs.
+// Limits are applied only if user storage is set with WithUserStorage.
+// Parameters:
+// - maxURLsPerUser: Limit of URLs of a single user, no limit if not positive
+// - maxAnonymousURLs: Limit of all URLs created without a user, shared by all anonymous clients, no limit if not positive
+// Returns:
+// - Option: ShortURLUseCase option
+func WithURLQuota(maxURLsPerUser, maxAnonymousURLs int) Option {
+	return func(u *ShortURLUseCase) {
+		u.maxURLsPerUser = int64(maxURLsPerUser)
+		u.maxAnonymousURLs = int64(maxAnonymousURLs)
+	}
+}
+
+// QuotaExceededError reports the exceeded quota of short URLs.
+// It matches ucErrors.ErrUserURLQuotaExceeded with errors.Is.
+type QuotaExceededError struct {
+	Limit   int64 // Maximum number of short URLs
+	Current int64 // Number of short URLs already created
+}
+
+// Error returns the message of ucErrors.ErrUserURLQuotaExceeded.
+func (e *QuotaExceededError) Error() string {
+	return ucErrors.ErrUserURLQuotaExceeded.Error()
+}
+
+// Unwrap returns ucErrors.ErrUserURLQuotaExceeded.
+func (e *QuotaExceededError) Unwrap() error {
+	return ucErrors.ErrUserURLQuotaExceeded
+}
+
+// URLQuota returns usage of the quota of short URLs of the user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: The user owning short URLs (can be nil for anonymous)
+// Returns:
+// - *entity.URLQuota: Limit, number of created and remaining short URLs
+// - error: Any error that occurred during count
+func (u *ShortURLUseCase) URLQuota(ctx context.Context, user *userEntity.User) (*entity.URLQuota, error) {
+	userID, limit := u.quotaOf(user)
+	if u.userStorage == nil {
+		return &entity.URLQuota{}, nil
+	}
+
+	used, err := u.userStorage.CountURLsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	quota := &entity.URLQuota{Used: used}
+	if limit > 0 {
+		quota.Limit = limit
+		quota.Remaining = max(limit-used, 0)
+	}
+	return quota, nil
+}
+
+// CheckURLQuota checks that the user may create the number of short URLs.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: The user creating short URLs (can be nil for anonymous)
+// - count: Number of short URLs to create
+// Returns:
+// - error: *QuotaExceededError if the limit would be exceeded, error of count otherwise
+func (u *ShortURLUseCase) CheckURLQuota(ctx context.Context, user *userEntity.User, count int) error {
+	userID, limit := u.quotaOf(user)
+	if u.userStorage == nil || limit <= 0 {
+		return nil
+	}
+
+	current, err := u.userStorage.CountURLsByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if current+int64(count) > limit {
+		return &QuotaExceededError{Limit: limit, Current: current}
+	}
+	return nil
+}
+
+// quotaOf returns the owner and the limit of short URLs counted for the user.
+// Parameters:
+// - user: The user creating short URLs (can be nil for anonymous)
+// Returns:
+// - int: ID of the user, 0 for anonymous
+// - int64: Limit of short URLs, not positive if not limited
+func (u *ShortURLUseCase) quotaOf(user *userEntity.User) (int, int64) {
+	if user == nil || user.ID == 0 {
+		return 0, u.maxAnonymousURLs
+	}
+	return user.ID, u.maxURLsPerUser
+}
@@ -0,0 +1,326 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	storageErrors "github.com/gururuby/shortener/internal/domain/storage/errors"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+	"github.com/gururuby/shortener/internal/domain/usecase/shorturl/mocks"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/gururuby/shortener/pkg/domainfilter"
+	"github.com/gururuby/shortener/pkg/safebrowsing"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_CreateShortURL_Quota(t *testing.T) {
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1}
+
+	tests := []struct {
+		user     *userEntity.User
+		err      error
+		countErr error
+		name     string
+		ownerID  int
+		count    int64
+		saved    bool
+	}{
+		{
+			name:    "when quota is not reached",
+			user:    user,
+			ownerID: 1,
+			count:   9,
+			saved:   true,
+		},
+		{
+			name:    "when quota is at limit",
+			user:    user,
+			ownerID: 1,
+			count:   10,
+			err:     &QuotaExceededError{Limit: 10, Current: 10},
+		},
+		{
+			name:    "when quota is exceeded",
+			user:    user,
+			ownerID: 1,
+			count:   12,
+			err:     &QuotaExceededError{Limit: 10, Current: 12},
+		},
+		{
+			name:  "when anonymous quota is not reached",
+			count: 1,
+			saved: true,
+		},
+		{
+			name:  "when anonymous quota is at limit",
+			count: 2,
+			err:   &QuotaExceededError{Limit: 2, Current: 2},
+		},
+		{
+			name:     "when URLs cannot be counted",
+			user:     user,
+			ownerID:  1,
+			countErr: dbErrors.ErrDBQuery,
+			err:      dbErrors.ErrDBQuery,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockShortURLStorage(ctrl)
+			userStorage := mocks.NewMockUserStorage(ctrl)
+
+			userStorage.EXPECT().CountURLsByUser(ctx, tt.ownerID).Return(tt.count, tt.countErr)
+			if tt.saved {
+				storage.EXPECT().SaveShortURL(ctx, tt.user, "https://ya.ru", "https://ya.ru", entity.CreateOptions{}).Return(&entity.ShortURL{Alias: "alias"}, nil)
+			}
+
+			uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "http://localhost:8080",
+				WithUserStorage(userStorage),
+				WithURLQuota(10, 2),
+			)
+			res, err := uc.CreateShortURL(ctx, tt.user, "https://ya.ru", entity.CreateOptions{})
+
+			if tt.err != nil {
+				require.Equal(t, tt.err, err)
+				require.Empty(t, res)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "http://localhost:8080/alias", res)
+		})
+	}
+}
+
+func Test_BatchShortURLs_Quota(t *testing.T) {
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1}
+	urls := []entity.BatchShortURLInput{
+		{CorrelationID: "1", OriginalURL: "https://ya.ru"},
+		{CorrelationID: "2", OriginalURL: "invalid"},
+		{CorrelationID: "3", OriginalURL: "https://google.com"},
+	}
+
+	t.Run("when valid URLs fit the quota", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		storage := batchStorage{mocks.NewMockShortURLStorage(ctrl), mocks.NewMockBatchSaver(ctrl)}
+		userStorage := mocks.NewMockUserStorage(ctrl)
+
+		userStorage.EXPECT().CountURLsByUser(ctx, 1).Return(int64(8), nil)
+		storage.MockBatchSaver.EXPECT().SaveShortURLBatch(ctx, user, gomock.Len(2)).Return(&entity.BatchSaveResult{
+			SucceededIDs: []string{"1", "3"},
+			ShortURLs:    []*entity.ShortURL{{Alias: "alias1"}, {Alias: "alias3"}},
+		}, nil)
+
+		uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "http://localhost:8080",
+			WithUserStorage(userStorage),
+			WithURLQuota(10, 2),
+		)
+		res, err := uc.BatchShortURLs(ctx, user, urls)
+		require.NoError(t, err)
+		require.Len(t, res, 3)
+	})
+
+	t.Run("when valid URLs exceed the quota", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		storage := batchStorage{mocks.NewMockShortURLStorage(ctrl), mocks.NewMockBatchSaver(ctrl)}
+		userStorage := mocks.NewMockUserStorage(ctrl)
+
+		userStorage.EXPECT().CountURLsByUser(ctx, 1).Return(int64(9), nil)
+
+		uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "http://localhost:8080",
+			WithUserStorage(userStorage),
+			WithURLQuota(10, 2),
+		)
+		res, err := uc.BatchShortURLs(ctx, user, urls)
+		require.Equal(t, &QuotaExceededError{Limit: 10, Current: 9}, err)
+		require.Empty(t, res)
+	})
+
+	t.Run("when anonymous batch exceeds the quota", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		storage := mocks.NewMockShortURLStorage(ctrl)
+		userStorage := mocks.NewMockUserStorage(ctrl)
+
+		userStorage.EXPECT().CountURLsByUser(ctx, 0).Return(int64(1), nil)
+
+		uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "http://localhost:8080",
+			WithUserStorage(userStorage),
+			WithURLQuota(10, 2),
+		)
+		_, err := uc.BatchShortURLs(ctx, nil, urls)
+		require.ErrorIs(t, err, ucErrors.ErrUserURLQuotaExceeded)
+	})
+}
+
+func Test_CheckURLQuota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userStorage := mocks.NewMockUserStorage(ctrl)
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1}
+
+	userStorage.EXPECT().CountURLsByUser(ctx, 1).Return(int64(7), nil).Times(2)
+
+	uc := NewShortURLUseCase(mocks.NewMockShortURLStorage(ctrl), safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "http://localhost:8080",
+		WithUserStorage(userStorage),
+		WithURLQuota(10, 2),
+	)
+	require.NoError(t, uc.CheckURLQuota(ctx, user, 3))
+	require.Equal(t, &QuotaExceededError{Limit: 10, Current: 7}, uc.CheckURLQuota(ctx, user, 4))
+}
+
+func Test_CreateShortURL_QuotaExceededError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockShortURLStorage(ctrl)
+	userStorage := mocks.NewMockUserStorage(ctrl)
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1}
+
+	userStorage.EXPECT().CountURLsByUser(ctx, 1).Return(int64(5), nil).Times(5)
+
+	uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "http://localhost:8080",
+		WithUserStorage(userStorage),
+		WithURLQuota(5, 0),
+	)
+	for name, opts := range map[string]entity.CreateOptions{
+		"default":   {},
+		"one-time":  {OneTimeUse: true},
+		"private":   {Visibility: entity.VisibilityPrivate},
+		"permanent": {RedirectType: entity.RedirectPermanent},
+		"tracked":   {Tracked: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := uc.CreateShortURL(ctx, user, "https://ya.ru", opts)
+			require.ErrorIs(t, err, ucErrors.ErrUserURLQuotaExceeded)
+			require.EqualError(t, err, "URL quota exceeded")
+		})
+	}
+}
+
+// duplicateStorage combines ShortURLStorage and DuplicateFinder mocks.
+type duplicateStorage struct {
+	*mocks.MockShortURLStorage
+	*mocks.MockDuplicateFinder
+}
+
+func Test_CreateShortURL_QuotaExistingURL(t *testing.T) {
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1}
+
+	tests := []struct {
+		found   *entity.ShortURL
+		findErr error
+		err     error
+		name    string
+		want    string
+	}{
+		{
+			name:  "when URL is already shortened",
+			found: &entity.ShortURL{Alias: "existing"},
+			want:  "http://localhost:8080/existing",
+			err:   ucErrors.ErrShortURLAlreadyExist,
+		},
+		{
+			name:    "when URL is not shortened yet",
+			findErr: storageErrors.ErrStorageRecordNotFound,
+			err:     &QuotaExceededError{Limit: 10, Current: 10},
+		},
+		{
+			name:    "when lookup fails",
+			findErr: dbErrors.ErrDBQuery,
+			err:     &QuotaExceededError{Limit: 10, Current: 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := duplicateStorage{mocks.NewMockShortURLStorage(ctrl), mocks.NewMockDuplicateFinder(ctrl)}
+			userStorage := mocks.NewMockUserStorage(ctrl)
+
+			userStorage.EXPECT().CountURLsByUser(ctx, 1).Return(int64(10), nil)
+			storage.MockDuplicateFinder.EXPECT().FindDuplicate(ctx, user, "https://ya.ru", "https://ya.ru", entity.CreateOptions{}).Return(tt.found, tt.findErr)
+
+			uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "http://localhost:8080",
+				WithUserStorage(userStorage),
+				WithURLQuota(10, 0),
+			)
+			res, err := uc.CreateShortURL(ctx, user, "https://ya.ru", entity.CreateOptions{})
+
+			require.Equal(t, tt.err, err)
+			require.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func Test_CreateShortURL_QuotaDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockShortURLStorage(ctrl)
+	userStorage := mocks.NewMockUserStorage(ctrl)
+	ctx := context.Background()
+
+	storage.EXPECT().SaveShortURL(ctx, nil, "https://ya.ru", "https://ya.ru", entity.CreateOptions{}).Return(&entity.ShortURL{Alias: "alias"}, nil)
+
+	uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "http://localhost:8080",
+		WithUserStorage(userStorage),
+		WithURLQuota(10, 0),
+	)
+	_, err := uc.CreateShortURL(ctx, nil, "https://ya.ru", entity.CreateOptions{})
+	require.NoError(t, err)
+}
+
+func Test_URLQuota(t *testing.T) {
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1}
+
+	tests := []struct {
+		want     *entity.URLQuota
+		err      error
+		name     string
+		countErr error
+		count    int64
+		maxURLs  int
+	}{
+		{
+			name:    "when quota is not reached",
+			count:   42,
+			maxURLs: 10000,
+			want:    &entity.URLQuota{Limit: 10000, Used: 42, Remaining: 9958},
+		},
+		{
+			name:    "when quota is exceeded",
+			count:   12,
+			maxURLs: 10,
+			want:    &entity.URLQuota{Limit: 10, Used: 12},
+		},
+		{
+			name:  "when URLs are not limited",
+			count: 42,
+			want:  &entity.URLQuota{Used: 42},
+		},
+		{
+			name:     "when URLs cannot be counted",
+			maxURLs:  10,
+			countErr: dbErrors.ErrDBQuery,
+			err:      dbErrors.ErrDBQuery,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userStorage := mocks.NewMockUserStorage(ctrl)
+			userStorage.EXPECT().CountURLsByUser(ctx, 1).Return(tt.count, tt.countErr)
+
+			uc := NewShortURLUseCase(mocks.NewMockShortURLStorage(ctrl), safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "http://localhost:8080",
+				WithUserStorage(userStorage),
+				WithURLQuota(tt.maxURLs, 10),
+			)
+			res, err := uc.URLQuota(ctx, user)
+			require.ErrorIs(t, err, tt.err)
+			require.Equal(t, tt.want, res)
+		})
+	}
+}
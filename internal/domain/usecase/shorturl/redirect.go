@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+	validatorErrors "github.com/gururuby/shortener/pkg/validator/errors"
+)
+
+// RedirectChainChecker follows redirects of the URL up to maxHops and reports
+// loops and unreachable destinations, e.g. validator.CheckRedirectChain.
+type RedirectChainChecker func(ctx context.Context, url string, maxHops int) error
+
+// WithRedirectChainCheck enables the pre-flight check of redirects of source URLs,
+// URLs redirecting in a loop or to unreachable pages are rejected.
+// Parameters:
+// - checker: Function following redirects of the URL
+// - maxHops: Limit of redirects to follow
+// Returns:
+// - Option: ShortURLUseCase option
+func WithRedirectChainCheck(checker RedirectChainChecker, maxHops int) Option {
+	return func(u *ShortURLUseCase) {
+		u.checkRedirects = checker
+		u.maxRedirectHops = maxHops
+	}
+}
+
+// checkRedirectChain checks redirects of source URL if the check is enabled.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - sourceURL: The original URL to check
+// Returns:
+// - error: ErrShortURLRedirectLoop or ErrShortURLDestinationUnreachable wrapping the check error,
+// context error if ctx is done
+func (u *ShortURLUseCase) checkRedirectChain(ctx context.Context, sourceURL string) error {
+	if u.checkRedirects == nil {
+		return nil
+	}
+
+	err := u.checkRedirects(ctx, sourceURL, u.maxRedirectHops)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, validatorErrors.ErrValidatorRedirectLoop),
+		errors.Is(err, validatorErrors.ErrValidatorTooManyRedirects):
+		return fmt.Errorf("%w: %w", ucErrors.ErrShortURLRedirectLoop, err)
+	case errors.Is(err, validatorErrors.ErrValidatorDestinationUnreachable):
+		return fmt.Errorf("%w: %w", ucErrors.ErrShortURLDestinationUnreachable, err)
+	default:
+		return err
+	}
+}
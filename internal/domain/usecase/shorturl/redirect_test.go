@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+	"github.com/gururuby/shortener/internal/domain/usecase/shorturl/mocks"
+	"github.com/gururuby/shortener/pkg/domainfilter"
+	"github.com/gururuby/shortener/pkg/safebrowsing"
+	validatorErrors "github.com/gururuby/shortener/pkg/validator/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_CreateShortURL_RedirectChain(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		checkErr error
+		err      error
+		name     string
+		saved    bool
+	}{
+		{
+			name:  "when redirects reach a page",
+			saved: true,
+		},
+		{
+			name:     "when redirects loop",
+			checkErr: validatorErrors.ErrValidatorRedirectLoop,
+			err:      ucErrors.ErrShortURLRedirectLoop,
+		},
+		{
+			name:     "when there are too many redirects",
+			checkErr: validatorErrors.ErrValidatorTooManyRedirects,
+			err:      ucErrors.ErrShortURLRedirectLoop,
+		},
+		{
+			name:     "when destination is unreachable",
+			checkErr: validatorErrors.ErrValidatorDestinationUnreachable,
+			err:      ucErrors.ErrShortURLDestinationUnreachable,
+		},
+		{
+			name:     "when check is canceled",
+			checkErr: context.Canceled,
+			err:      context.Canceled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockShortURLStorage(ctrl)
+
+			if tt.saved {
+				storage.EXPECT().SaveShortURL(ctx, nil, "https://ya.ru", "https://ya.ru", entity.CreateOptions{}).Return(&entity.ShortURL{Alias: "alias"}, nil)
+			}
+			checker := func(_ context.Context, url string, maxHops int) error {
+				require.Equal(t, "https://ya.ru", url)
+				require.Equal(t, 3, maxHops)
+				return tt.checkErr
+			}
+
+			uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "http://localhost:8080",
+				WithRedirectChainCheck(checker, 3),
+			)
+			res, err := uc.CreateShortURL(ctx, nil, "https://ya.ru", entity.CreateOptions{})
+
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				require.ErrorIs(t, err, tt.checkErr)
+				require.Empty(t, res)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "http://localhost:8080/alias", res)
+		})
+	}
+}
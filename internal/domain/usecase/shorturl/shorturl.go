@@ -1,4 +1,4 @@
-//go:generate mockgen -destination=./mocks/mock.go -package=mocks . ShortURLStorage
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . ShortURLStorage,BatchSaver,DuplicateFinder,URLChecker,DomainFilter,EventBus,EventPublisher,UserStorage
 
 /*
 Package usecase implements the business logic for URL shortening operations.
@@ -7,6 +7,12 @@ It provides:
 - Short URL creation and lookup functionality
 - Batch URL processing
 - Input validation
+- Unsafe URL rejection
+- Optional rejection of URLs redirecting in a loop or to unreachable pages
+- Domain blacklist/whitelist filtering
+- Quotas of short URLs per user and of anonymous ones
+- Lifecycle events about created and deleted URLs for webhooks and other subscribers
+- Real-time events about created URLs
 - Error handling specific to URL operations
 */
 package usecase
@@ -14,126 +20,608 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"regexp"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gururuby/shortener/internal/domain/entity/shorturl"
 	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
 	storageErrors "github.com/gururuby/shortener/internal/domain/storage/errors"
 	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/gururuby/shortener/internal/infra/eventbus"
+	"github.com/gururuby/shortener/pkg/normalizer"
 	"github.com/gururuby/shortener/pkg/validator"
 )
 
+// batchValidationWorkers limits the number of URLs of a batch validated simultaneously.
+const batchValidationWorkers = 8
+
+// aliasRegexp matches custom aliases usable as a single path segment.
+var aliasRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
 // ShortURLStorage defines the interface for short URL persistence operations.
 type ShortURLStorage interface {
-	// FindShortURL retrieves a short URL by its alias.
+	// FindShortURL retrieves a short URL by its namespace and alias.
 	// Returns:
 	// - *entity.ShortURL: The found short URL entity
 	// - error: Any error that occurred during lookup
-	FindShortURL(ctx context.Context, alias string) (*entity.ShortURL, error)
+	FindShortURL(ctx context.Context, namespace, alias string) (*entity.ShortURL, error)
+
+	// SaveShortURL creates and persists a new short URL with the given options.
+	// Returns:
+	// - *entity.ShortURL: The created short URL entity, or the existing one if source URL is already shortened
+	// - error: Any error that occurred during creation
+	SaveShortURL(ctx context.Context, user *userEntity.User, sourceURL, normalizedURL string, opts entity.CreateOptions) (*entity.ShortURL, error)
+
+	// MarkURLAsDeleted soft-deletes short URLs of a namespace of a user, or of any owner if userID is 0.
+	// Returns:
+	// - error: Any error that occurred during deletion
+	MarkURLAsDeleted(ctx context.Context, userID int, namespace string, aliases []string) error
+}
 
-	// SaveShortURL creates and persists a new short URL.
+// BatchSaver defines the optional interface for storages able to save
+// several short URLs at once.
+type BatchSaver interface {
+	// SaveShortURLBatch creates and persists several short URLs.
 	// Returns:
-	// - *entity.ShortURL: The created short URL entity
+	// - *entity.BatchSaveResult: Correlation IDs and saved short URLs
 	// - error: Any error that occurred during creation
-	SaveShortURL(ctx context.Context, user *userEntity.User, sourceURL string) (*entity.ShortURL, error)
+	SaveShortURLBatch(ctx context.Context, user *userEntity.User, urls []entity.BatchShortURLInput) (*entity.BatchSaveResult, error)
+}
+
+// DuplicateFinder defines the optional interface for storages able to find
+// the existing short URL returned instead of creating a new one.
+type DuplicateFinder interface {
+	// FindDuplicate looks up the short URL SaveShortURL would return for the same source URL and options.
+	// Returns:
+	// - *entity.ShortURL: The existing short URL entity
+	// - error: Any error that occurred during lookup, including not found one
+	FindDuplicate(ctx context.Context, user *userEntity.User, sourceURL, normalizedURL string, opts entity.CreateOptions) (*entity.ShortURL, error)
+}
+
+// URLChecker defines the interface for checking source URLs against threat lists.
+type URLChecker interface {
+	// IsUnsafe reports whether URL is known to host malware, phishing or other threats.
+	// Returns:
+	// - bool: true if URL is unsafe
+	// - error: Any error that occurred during check
+	IsUnsafe(ctx context.Context, url string) (bool, error)
+}
+
+// DomainFilter defines the interface for checking source URLs against domain lists.
+type DomainFilter interface {
+	// Allow checks whether the URL domain is permitted.
+	// Returns:
+	// - error: Reason of rejection or nil if the domain is permitted
+	Allow(rawURL string) error
+}
+
+// EventBus defines the interface for publishing short URL lifecycle events to in-process subscribers.
+type EventBus interface {
+	// Publish queues the event for subscribers of its type.
+	// Must not block on slow subscribers.
+	Publish(event eventbus.Event)
+}
+
+// EventPublisher defines the interface for streaming short URL events to connected clients.
+type EventPublisher interface {
+	// Publish sends the event to clients of the user subscribed to events.
+	// Must not block on slow clients.
+	Publish(userID int, event *entity.ShortURLCreatedEvent)
 }
 
 // ShortURLUseCase implements the business logic for URL shortening operations.
 type ShortURLUseCase struct {
-	storage ShortURLStorage
-	baseURL string
+	storage          ShortURLStorage
+	checker          URLChecker
+	filter           DomainFilter
+	bus              EventBus
+	publisher        EventPublisher
+	userStorage      UserStorage // Counter of user URLs, nil disables quotas
+	baseURL          string
+	maxURLsPerUser   int64                // Limit of URLs of a single user, no limit if not positive
+	maxAnonymousURLs int64                // Limit of URLs created without a user, no limit if not positive
+	checkRedirects   RedirectChainChecker // Pre-flight check of redirects, nil disables it
+	maxRedirectHops  int                  // Limit of redirects followed by the check
 }
 
 // NewShortURLUseCase creates a new instance of ShortURLUseCase.
 // Parameters:
 // - storage: Implementation of ShortURLStorage
+// - checker: Implementation of URLChecker
+// - filter: Implementation of DomainFilter
+// - bus: Implementation of EventBus, nil disables lifecycle events
+// - publisher: Implementation of EventPublisher, nil disables real-time events
 // - baseURL: The base URL to use for shortened links
+// - opts: Optional capabilities, e.g. quotas of short URLs
 // Returns:
 // - *ShortURLUseCase: Initialized use case instance
-func NewShortURLUseCase(storage ShortURLStorage, baseURL string) *ShortURLUseCase {
-	return &ShortURLUseCase{
-		storage: storage,
-		baseURL: baseURL,
+func NewShortURLUseCase(storage ShortURLStorage, checker URLChecker, filter DomainFilter, bus EventBus, publisher EventPublisher, baseURL string, opts ...Option) *ShortURLUseCase {
+	u := &ShortURLUseCase{
+		storage:   storage,
+		checker:   checker,
+		filter:    filter,
+		bus:       bus,
+		publisher: publisher,
+		baseURL:   baseURL,
 	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
 }
 
 // CreateShortURL creates a new shortened URL from the source URL.
+// Public short URLs redirecting with 307 are deduplicated, i.e. the existing short URL
+// is returned for an already shortened URL. One-time, private, permanent and tracked
+// short URLs always get a new alias.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: The user creating the short URL (can be nil for anonymous, except for private URLs)
+// - sourceURL: The original URL to shorten, stored as is
+// - opts: Optional properties of the short URL
+// Returns:
+// - string: The full shortened URL (base URL of the user + alias)
+// - error: Specific error for missing owner of private URL, invalid or taken custom alias,
+// expiration not in the future, invalid or unsafe URLs, duplicates, exceeded quota, or storage failures
+func (u *ShortURLUseCase) CreateShortURL(ctx context.Context, user *userEntity.User, sourceURL string, opts entity.CreateOptions) (string, error) {
+	if opts.Visibility == entity.VisibilityPrivate && (user == nil || user.ID == 0) {
+		return "", ucErrors.ErrShortURLOwnerRequired
+	}
+
+	if opts.Alias != "" && !aliasRegexp.MatchString(opts.Alias) {
+		return "", ucErrors.ErrShortURLInvalidAlias
+	}
+
+	if !opts.ExpiresAt.IsZero() && !opts.ExpiresAt.After(time.Now()) {
+		return "", ucErrors.ErrShortURLInvalidExpiration
+	}
+
+	normalizedURL, err := u.prepareSourceURL(ctx, sourceURL)
+	if err != nil {
+		return "", err
+	}
+
+	if err = u.CheckURLQuota(ctx, user, 1); err != nil {
+		// Quota limits new short URLs only, already shortened URL is reported as usual.
+		if errors.Is(err, ucErrors.ErrUserURLQuotaExceeded) {
+			if existing := u.findDuplicate(ctx, user, sourceURL, normalizedURL, opts); existing != nil {
+				return user.BaseURL(u.baseURL) + "/" + existing.Path(), ucErrors.ErrShortURLAlreadyExist
+			}
+		}
+		return "", err
+	}
+
+	result, err := u.storage.SaveShortURL(ctx, user, sourceURL, normalizedURL, opts)
+
+	if err != nil {
+		if errors.Is(err, storageErrors.ErrStorageRecordIsNotUnique) {
+			return user.BaseURL(u.baseURL) + "/" + result.Path(), ucErrors.ErrShortURLAlreadyExist
+		}
+		if opts.Alias != "" && errors.Is(err, dbErrors.ErrDBAliasTaken) {
+			return "", ucErrors.ErrShortURLAliasTaken
+		}
+		return "", saveError(err)
+	}
+
+	shortURL := user.BaseURL(u.baseURL) + "/" + result.Path()
+	u.emit(createdEvent(user, result, sourceURL))
+	u.publishCreated(user, result)
+
+	return shortURL, nil
+}
+
+// findDuplicate looks up the existing short URL of the source URL if the storage implements DuplicateFinder.
 // Parameters:
 // - ctx: Context for cancellation and timeouts
 // - user: The user creating the short URL (can be nil for anonymous)
 // - sourceURL: The original URL to shorten
+// - normalizedURL: Normalized form of sourceURL
+// - opts: Optional properties of the short URL
+// Returns:
+// - *entity.ShortURL: The existing short URL, nil if a new one would be created or lookup fails
+func (u *ShortURLUseCase) findDuplicate(ctx context.Context, user *userEntity.User, sourceURL, normalizedURL string, opts entity.CreateOptions) *entity.ShortURL {
+	finder, ok := u.storage.(DuplicateFinder)
+	if !ok {
+		return nil
+	}
+
+	existing, err := finder.FindDuplicate(ctx, user, sourceURL, normalizedURL, opts)
+	if err != nil {
+		return nil
+	}
+	return existing
+}
+
+// saveError converts error of saving a short URL to the use case error.
+// Parameters:
+// - err: Error returned by storage
 // Returns:
-// - string: The full shortened URL (baseURL + alias)
-// - error: Specific error for invalid URLs, duplicates, or storage failures
-func (u *ShortURLUseCase) CreateShortURL(ctx context.Context, user *userEntity.User, sourceURL string) (string, error) {
+// - error: ucErrors.ErrShortURLNamespaceNotFound if namespace of the user doesn't exist, err otherwise
+func saveError(err error) error {
+	if errors.Is(err, dbErrors.ErrDBReferenceNotFound) {
+		return ucErrors.ErrShortURLNamespaceNotFound
+	}
+	return err
+}
+
+// ValidateSourceURL checks that source URL may be shortened without saving anything.
+// It applies the same validation, domain filter and safety check as short URL creation.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - sourceURL: The original URL to check
+// Returns:
+// - error: Specific error for invalid base or source URL, not permitted domain, unsafe URL, or URL check failure
+func (u *ShortURLUseCase) ValidateSourceURL(ctx context.Context, sourceURL string) error {
+	_, err := u.prepareSourceURL(ctx, sourceURL)
+	return err
+}
+
+// prepareSourceURL validates, normalizes and checks the domain, the safety and the redirects of source URL.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - sourceURL: The original URL to shorten
+// Returns:
+// - string: Normalized form of sourceURL
+// - error: Specific error for invalid base or source URL, not permitted domain, unsafe URL,
+// redirect loop, unreachable destination, or URL check failure
+func (u *ShortURLUseCase) prepareSourceURL(ctx context.Context, sourceURL string) (string, error) {
 	if validator.IsInvalidURL(u.baseURL) {
 		return "", ucErrors.ErrShortURLInvalidBaseURL
 	}
 
-	if validator.IsInvalidURL(sourceURL) {
+	if err := u.validateSourceURL(sourceURL); err != nil {
+		return "", err
+	}
+
+	normalizedURL, err := normalizer.Normalize(sourceURL)
+	if err != nil {
 		return "", ucErrors.ErrShortURLInvalidSourceURL
 	}
 
-	result, err := u.storage.SaveShortURL(ctx, user, sourceURL)
+	unsafe, err := u.checker.IsUnsafe(ctx, sourceURL)
+	if err != nil {
+		return "", err
+	}
+
+	if unsafe {
+		return "", ucErrors.ErrShortURLUnsafeContent
+	}
+
+	if err = u.checkRedirectChain(ctx, sourceURL); err != nil {
+		return "", err
+	}
+
+	return normalizedURL, nil
+}
+
+// validateSourceURL checks the format and the domain of source URL.
+// Parameters:
+// - sourceURL: The original URL to check
+// Returns:
+// - error: ErrShortURLInvalidSourceURL or ErrShortURLDomainNotPermitted wrapping the filter error
+func (u *ShortURLUseCase) validateSourceURL(sourceURL string) error {
+	if validator.IsInvalidURL(sourceURL) {
+		return ucErrors.ErrShortURLInvalidSourceURL
+	}
+
+	if err := u.filter.Allow(sourceURL); err != nil {
+		return fmt.Errorf("%w: %w", ucErrors.ErrShortURLDomainNotPermitted, err)
+	}
+
+	return nil
+}
 
+// FindShortURL retrieves the short URL to redirect to for a given namespace and alias.
+// One-time URLs are deleted on successful lookup, so the next lookup reports them as deleted.
+// Private URLs are found for their owner only.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: The short URL identifier to look up
+// - user: The requesting user (can be nil for anonymous)
+// Returns:
+// - *entity.ShortURL: The found short URL with the original source URL and redirect type
+// - error: Specific error for missing, deleted, forbidden, or invalid aliases
+func (u *ShortURLUseCase) FindShortURL(ctx context.Context, namespace, alias string, user *userEntity.User) (*entity.ShortURL, error) {
+	res, err := u.GetShortURL(ctx, namespace, alias)
 	if err != nil {
-		if errors.Is(err, storageErrors.ErrStorageRecordIsNotUnique) {
-			return u.baseURL + "/" + result.Alias, ucErrors.ErrShortURLAlreadyExist
+		return nil, err
+	}
+
+	if !res.IsAccessibleBy(user) {
+		return nil, ucErrors.ErrShortURLForbidden
+	}
+
+	if res.IsOneTimeUse {
+		if err = u.storage.MarkURLAsDeleted(ctx, 0, res.Namespace, []string{res.Alias}); err != nil {
+			if errors.Is(err, dbErrors.ErrDBRecordIsDeleted) {
+				return nil, ucErrors.ErrShortURLDeleted
+			}
+			return nil, err
 		}
-		return "", err
+		u.emit(eventbus.URLDeleted{Alias: res.Alias, Namespace: res.Namespace, UserID: res.UserID})
 	}
 
-	return u.baseURL + "/" + result.Alias, nil
+	return res, nil
+}
+
+// emit publishes the lifecycle event to the event bus.
+// Nothing is published if lifecycle events are disabled.
+// Parameters:
+// - event: Published event
+func (u *ShortURLUseCase) emit(event eventbus.Event) {
+	if u.bus == nil {
+		return
+	}
+	u.bus.Publish(event)
 }
 
-// FindShortURL retrieves the original URL for a given alias.
+// createdEvent builds the event about a created short URL.
+// Parameters:
+// - user: The user created the short URL (can be nil for anonymous)
+// - shortURL: The created short URL
+// - sourceURL: The original URL passed by the user
+// Returns:
+// - eventbus.URLCreated: Event with owner, namespace and alias of the short URL
+func createdEvent(user *userEntity.User, shortURL *entity.ShortURL, sourceURL string) eventbus.URLCreated {
+	event := eventbus.URLCreated{Alias: shortURL.Alias, OriginalURL: sourceURL, Namespace: shortURL.Namespace}
+	if user != nil {
+		event.UserID = user.ID
+	}
+	return event
+}
+
+// publishCreated streams creation of a short URL to connected clients of its owner.
+// Short URLs of anonymous users are not published.
+// Parameters:
+// - user: The owner of the short URL
+// - shortURL: The created short URL
+func (u *ShortURLUseCase) publishCreated(user *userEntity.User, shortURL *entity.ShortURL) {
+	if u.publisher == nil || user == nil || user.ID == 0 {
+		return
+	}
+
+	createdAt := shortURL.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	u.publisher.Publish(user.ID, &entity.ShortURLCreatedEvent{
+		Alias:       shortURL.Alias,
+		OriginalURL: shortURL.SourceURL,
+		CreatedAt:   createdAt,
+	})
+}
+
+// GetShortURL retrieves the short URL for a given namespace and alias without side effects,
+// i.e. one-time URLs are not deleted.
 // Parameters:
 // - ctx: Context for cancellation and timeouts
+// - namespace: Namespace of the short URL, empty for the default one
 // - alias: The short URL identifier to look up
 // Returns:
-// - string: The original source URL
+// - *entity.ShortURL: The found short URL
 // - error: Specific error for missing, deleted, or invalid aliases
-func (u *ShortURLUseCase) FindShortURL(ctx context.Context, alias string) (string, error) {
+func (u *ShortURLUseCase) GetShortURL(ctx context.Context, namespace, alias string) (*entity.ShortURL, error) {
 	alias = strings.TrimPrefix(alias, "/")
 
 	if alias == "" {
-		return "", ucErrors.ErrShortURLEmptyAlias
+		return nil, ucErrors.ErrShortURLEmptyAlias
 	}
 
-	res, err := u.storage.FindShortURL(ctx, alias)
+	res, err := u.storage.FindShortURL(ctx, namespace, alias)
 	if err != nil {
-		return "", err
+		if errors.Is(err, dbErrors.ErrDBRecordNotFound) || errors.Is(err, storageErrors.ErrStorageRecordNotFound) {
+			return nil, ucErrors.ErrShortURLSourceURLNotFound
+		}
+		return nil, err
 	}
 
 	if res == nil {
-		return "", ucErrors.ErrShortURLSourceURLNotFound
+		return nil, ucErrors.ErrShortURLSourceURLNotFound
 	}
 
 	if res.IsDeleted {
-		return "", ucErrors.ErrShortURLDeleted
+		return nil, ucErrors.ErrShortURLDeleted
 	}
 
-	return res.SourceURL, nil
+	return res, nil
 }
 
 // BatchShortURLs processes multiple URLs in a single operation.
+// URLs are validated concurrently, then the valid ones are saved. If the storage
+// implements BatchSaver, the whole batch is saved at once and nothing is saved on failure.
+// Invalid and unsafe URLs, URLs of not permitted domains and URLs failed to save
+// are reported in the output with the reason instead of the short URL.
 // Parameters:
 // - ctx: Context for cancellation and timeouts
+// - user: The user creating the short URLs (can be nil for anonymous)
 // - urls: List of URLs to shorten with correlation IDs
 // Returns:
-// - []entity.BatchShortURLOutput: List of shortened URLs with correlation IDs
-func (u *ShortURLUseCase) BatchShortURLs(ctx context.Context, urls []entity.BatchShortURLInput) []entity.BatchShortURLOutput {
-	var res []entity.BatchShortURLOutput
+// - []entity.BatchShortURLOutput: Shortened URLs or failure reasons with correlation IDs in input order
+// - error: ErrShortURLInvalidBaseURL, *QuotaExceededError if valid URLs exceed the quota,
+// or any error that occurred during batch save
+func (u *ShortURLUseCase) BatchShortURLs(ctx context.Context, user *userEntity.User, urls []entity.BatchShortURLInput) ([]entity.BatchShortURLOutput, error) {
+	if validator.IsInvalidURL(u.baseURL) {
+		return nil, ucErrors.ErrShortURLInvalidBaseURL
+	}
 
-	for _, url := range urls {
-		shortURL, err := u.CreateShortURL(ctx, nil, url.OriginalURL)
-		if err != nil {
+	valid, failed := u.BatchValidate(ctx, urls)
+
+	saved, err := u.batchSave(ctx, user, valid)
+	if err != nil {
+		return nil, err
+	}
+
+	return assembleBatchOutputs(urls, saved, failed), nil
+}
+
+// BatchValidate validates, normalizes and checks the domain and the safety of
+// source URLs concurrently, limiting the number of simultaneous checks by batchValidationWorkers.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - urls: List of URLs to shorten with correlation IDs
+// Returns:
+// - []entity.BatchShortURLInput: Valid URLs with NormalizedURL filled, in input order
+// - []entity.BatchShortURLOutput: Invalid URLs with empty ShortURL and the failure reason, in input order
+func (u *ShortURLUseCase) BatchValidate(ctx context.Context, urls []entity.BatchShortURLInput) ([]entity.BatchShortURLInput, []entity.BatchShortURLOutput) {
+	var (
+		valid  = make([]entity.BatchShortURLInput, 0, len(urls))
+		failed []entity.BatchShortURLOutput
+	)
+
+	checked, errs := u.prepareBatch(ctx, urls)
+	for i, url := range checked {
+		if errs[i] != nil {
+			failed = append(failed, entity.BatchShortURLOutput{CorrelationID: url.CorrelationID, Error: errs[i].Error()})
 			continue
 		}
+		valid = append(valid, url)
+	}
+
+	return valid, failed
+}
+
+// ValidateBatch checks source URLs of the batch like BatchShortURLs does, without saving anything.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - urls: List of URLs to check with correlation IDs
+// Returns:
+// - []entity.BatchValidationResult: Results with the failure reason of invalid URLs, in input order
+func (u *ShortURLUseCase) ValidateBatch(ctx context.Context, urls []entity.BatchShortURLInput) []entity.BatchValidationResult {
+	_, errs := u.prepareBatch(ctx, urls)
+
+	results := make([]entity.BatchValidationResult, len(urls))
+	for i, url := range urls {
+		results[i] = entity.BatchValidationResult{CorrelationID: url.CorrelationID, Valid: errs[i] == nil}
+		if errs[i] != nil {
+			results[i].Reason = errs[i].Error()
+		}
+	}
+	return results
+}
+
+// prepareBatch prepares source URLs of the batch concurrently,
+// limiting the number of simultaneous checks by batchValidationWorkers.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - urls: List of URLs to check with correlation IDs
+// Returns:
+// - []entity.BatchShortURLInput: Copy of urls with NormalizedURL filled for valid ones
+// - []error: Failure reasons by index of urls, nil for valid ones
+func (u *ShortURLUseCase) prepareBatch(ctx context.Context, urls []entity.BatchShortURLInput) ([]entity.BatchShortURLInput, []error) {
+	var (
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, batchValidationWorkers)
+		errs = make([]error, len(urls))
+	)
+
+	checked := make([]entity.BatchShortURLInput, len(urls))
+	copy(checked, urls)
+
+	for i := range checked {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			checked[i].NormalizedURL, errs[i] = u.prepareSourceURL(ctx, checked[i].OriginalURL)
+		}()
+	}
+	wg.Wait()
+
+	return checked, errs
+}
+
+// batchSave persists validated URLs, at once if the storage implements BatchSaver
+// or one by one otherwise. Lifecycle event is emitted for every saved short URL
+// and it is published to connected clients of the user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: The user creating the short URLs (can be nil for anonymous)
+// - urls: Validated URLs with NormalizedURL filled
+// Returns:
+// - []entity.BatchShortURLOutput: Saved short URLs, and URLs failed to save one by one with the reason
+// - error: *QuotaExceededError if the batch exceeds the quota, any error that occurred during batch save
+func (u *ShortURLUseCase) batchSave(ctx context.Context, user *userEntity.User, urls []entity.BatchShortURLInput) ([]entity.BatchShortURLOutput, error) {
+	res := make([]entity.BatchShortURLOutput, 0, len(urls))
+	baseURL := user.BaseURL(u.baseURL)
+
+	if len(urls) == 0 {
+		return res, nil
+	}
+
+	if err := u.CheckURLQuota(ctx, user, len(urls)); err != nil {
+		return nil, err
+	}
+
+	saver, ok := u.storage.(BatchSaver)
+	if !ok {
+		for _, url := range urls {
+			out := entity.BatchShortURLOutput{CorrelationID: url.CorrelationID}
+			shortURL, err := u.storage.SaveShortURL(ctx, user, url.OriginalURL, url.NormalizedURL, entity.CreateOptions{})
+			switch {
+			case errors.Is(err, storageErrors.ErrStorageRecordIsNotUnique):
+				out.Error = ucErrors.ErrShortURLAlreadyExist.Error()
+			case err != nil:
+				out.Error = err.Error()
+			default:
+				out.ShortURL = baseURL + "/" + shortURL.Path()
+				u.emit(createdEvent(user, shortURL, url.OriginalURL))
+				u.publishCreated(user, shortURL)
+			}
+			res = append(res, out)
+		}
+		return res, nil
+	}
+
+	result, err := saver.SaveShortURLBatch(ctx, user, urls)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, shortURL := range result.ShortURLs {
 		res = append(res, entity.BatchShortURLOutput{
-			CorrelationID: url.CorrelationID,
-			ShortURL:      shortURL,
+			CorrelationID: result.SucceededIDs[i],
+			ShortURL:      baseURL + "/" + shortURL.Path(),
 		})
+		u.emit(createdEvent(user, shortURL, shortURL.SourceURL))
+		u.publishCreated(user, shortURL)
+	}
+
+	return res, nil
+}
+
+// assembleBatchOutputs merges saved and failed URLs in the order of the input.
+// Valid URLs skipped by the storage already exist, they are reported with
+// ucErrors.ErrShortURLAlreadyExist, so every input has an output.
+// Parameters:
+// - urls: Input URLs defining the order
+// - saved: Outputs of saved URLs
+// - failed: Outputs of URLs failed validation
+// Returns:
+// - []entity.BatchShortURLOutput: Outputs ordered as urls, one for every input
+func assembleBatchOutputs(urls []entity.BatchShortURLInput, saved, failed []entity.BatchShortURLOutput) []entity.BatchShortURLOutput {
+	byID := make(map[string]entity.BatchShortURLOutput, len(saved)+len(failed))
+	for _, out := range failed {
+		byID[out.CorrelationID] = out
+	}
+	for _, out := range saved {
+		byID[out.CorrelationID] = out
+	}
+
+	res := make([]entity.BatchShortURLOutput, 0, len(urls))
+	for _, url := range urls {
+		out, ok := byID[url.CorrelationID]
+		if !ok {
+			out = entity.BatchShortURLOutput{CorrelationID: url.CorrelationID, Error: ucErrors.ErrShortURLAlreadyExist.Error()}
+		}
+		res = append(res, out)
 	}
 
 	return res
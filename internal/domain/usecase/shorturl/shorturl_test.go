@@ -2,13 +2,23 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/gururuby/shortener/internal/domain/entity/shorturl"
 	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
 	storageErrors "github.com/gururuby/shortener/internal/domain/storage/errors"
 	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
 	"github.com/gururuby/shortener/internal/domain/usecase/shorturl/mocks"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/gururuby/shortener/internal/infra/eventbus"
+	"github.com/gururuby/shortener/pkg/domainfilter"
+	domainfilterErrors "github.com/gururuby/shortener/pkg/domainfilter/errors"
+	"github.com/gururuby/shortener/pkg/safebrowsing"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -42,13 +52,13 @@ func Test_FindShortURL_OK(t *testing.T) {
 		},
 	}
 	for _, tt := range tests {
-		storage.EXPECT().FindShortURL(ctx, "alias1").Return(tt.storageRes.shortURL, nil).AnyTimes()
-		uc := NewShortURLUseCase(storage, "baseURL")
+		storage.EXPECT().FindShortURL(ctx, "", "alias1").Return(tt.storageRes.shortURL, nil).AnyTimes()
+		uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "baseURL")
 
 		t.Run(tt.name, func(t *testing.T) {
-			res, err := uc.FindShortURL(ctx, tt.alias)
+			res, err := uc.FindShortURL(ctx, "", tt.alias, nil)
 			require.NoError(t, err)
-			require.Equal(t, tt.res, res)
+			require.Equal(t, tt.res, res.SourceURL)
 		})
 	}
 }
@@ -80,28 +90,158 @@ func Test_FindShortURL_Errors(t *testing.T) {
 			storageRes: storageRes{shortURL: nil},
 			err:        ucErrors.ErrShortURLSourceURLNotFound,
 		},
+		{
+			name:       "when alias in db not found",
+			alias:      "alias3",
+			storageRes: storageRes{err: dbErrors.ErrDBRecordNotFound},
+			err:        ucErrors.ErrShortURLSourceURLNotFound,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			storage.EXPECT().FindShortURL(ctx, tt.alias).Return(tt.storageRes.shortURL, tt.storageRes.err).AnyTimes()
-			uc := NewShortURLUseCase(storage, "base")
-			_, err := uc.FindShortURL(ctx, tt.alias)
+			storage.EXPECT().FindShortURL(ctx, "", tt.alias).Return(tt.storageRes.shortURL, tt.storageRes.err).AnyTimes()
+			uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "base")
+			_, err := uc.FindShortURL(ctx, "", tt.alias, nil)
 			require.ErrorIs(t, tt.err, err)
 		})
 	}
 }
 
+func Test_FindShortURL_OneTimeUse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockShortURLStorage(ctrl)
+	ctx := context.Background()
+	uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "baseURL")
+
+	shortURL := &entity.ShortURL{Alias: "alias", SourceURL: "https://ya.ru", IsOneTimeUse: true}
+
+	t.Run("when one-time URL is found first time", func(t *testing.T) {
+		storage.EXPECT().FindShortURL(ctx, "", "alias").Return(shortURL, nil)
+		storage.EXPECT().MarkURLAsDeleted(ctx, 0, "", []string{"alias"}).Return(nil)
+
+		res, err := uc.FindShortURL(ctx, "", "alias", nil)
+		require.NoError(t, err)
+		require.Equal(t, "https://ya.ru", res.SourceURL)
+	})
+
+	t.Run("when one-time URL is deleted by concurrent request", func(t *testing.T) {
+		storage.EXPECT().FindShortURL(ctx, "", "alias").Return(shortURL, nil)
+		storage.EXPECT().MarkURLAsDeleted(ctx, 0, "", []string{"alias"}).Return(dbErrors.ErrDBRecordIsDeleted)
+
+		_, err := uc.FindShortURL(ctx, "", "alias", nil)
+		require.ErrorIs(t, err, ucErrors.ErrShortURLDeleted)
+	})
+
+	t.Run("when one-time URL cannot be deleted", func(t *testing.T) {
+		storage.EXPECT().FindShortURL(ctx, "", "alias").Return(shortURL, nil)
+		storage.EXPECT().MarkURLAsDeleted(ctx, 0, "", []string{"alias"}).Return(dbErrors.ErrDBQuery)
+
+		_, err := uc.FindShortURL(ctx, "", "alias", nil)
+		require.ErrorIs(t, err, dbErrors.ErrDBQuery)
+	})
+
+	t.Run("when one-time URL is found in namespace", func(t *testing.T) {
+		namespaced := &entity.ShortURL{Alias: "alias", Namespace: "team", SourceURL: "https://ya.ru", IsOneTimeUse: true}
+		storage.EXPECT().FindShortURL(ctx, "team", "alias").Return(namespaced, nil)
+		storage.EXPECT().MarkURLAsDeleted(ctx, 0, "team", []string{"alias"}).Return(nil)
+
+		res, err := uc.FindShortURL(ctx, "team", "alias", nil)
+		require.NoError(t, err)
+		require.Equal(t, "https://ya.ru", res.SourceURL)
+	})
+}
+
+func Test_FindShortURL_Private(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockShortURLStorage(ctrl)
+	ctx := context.Background()
+	uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "baseURL")
+
+	private := &entity.ShortURL{Alias: "private", SourceURL: "https://ya.ru", UserID: 1, Visibility: entity.VisibilityPrivate}
+	public := &entity.ShortURL{Alias: "public", SourceURL: "https://ya.ru", UserID: 1, Visibility: entity.VisibilityPublic}
+
+	tests := []struct {
+		shortURL *entity.ShortURL
+		user     *userEntity.User
+		err      error
+		name     string
+	}{
+		{
+			name:     "when owner accesses private URL",
+			shortURL: private,
+			user:     &userEntity.User{ID: 1},
+		},
+		{
+			name:     "when non-owner accesses private URL",
+			shortURL: private,
+			user:     &userEntity.User{ID: 2},
+			err:      ucErrors.ErrShortURLForbidden,
+		},
+		{
+			name:     "when anonymous user accesses private URL",
+			shortURL: private,
+			err:      ucErrors.ErrShortURLForbidden,
+		},
+		{
+			name:     "when non-owner accesses public URL",
+			shortURL: public,
+			user:     &userEntity.User{ID: 2},
+		},
+		{
+			name:     "when anonymous user accesses public URL",
+			shortURL: public,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage.EXPECT().FindShortURL(ctx, "", tt.shortURL.Alias).Return(tt.shortURL, nil)
+
+			res, err := uc.FindShortURL(ctx, "", tt.shortURL.Alias, tt.user)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "https://ya.ru", res.SourceURL)
+		})
+	}
+}
+
+func Test_GetShortURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockShortURLStorage(ctrl)
+	ctx := context.Background()
+	uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "baseURL")
+
+	t.Run("when one-time URL is found it is not deleted", func(t *testing.T) {
+		shortURL := &entity.ShortURL{Alias: "alias", SourceURL: "https://ya.ru", IsOneTimeUse: true}
+		storage.EXPECT().FindShortURL(ctx, "", "alias").Return(shortURL, nil)
+
+		res, err := uc.GetShortURL(ctx, "", "/alias")
+		require.NoError(t, err)
+		require.Equal(t, shortURL, res)
+	})
+
+	t.Run("when short URL is deleted", func(t *testing.T) {
+		storage.EXPECT().FindShortURL(ctx, "", "deleted").Return(&entity.ShortURL{IsDeleted: true}, nil)
+
+		_, err := uc.GetShortURL(ctx, "", "deleted")
+		require.ErrorIs(t, err, ucErrors.ErrShortURLDeleted)
+	})
+}
+
 func Benchmark_FindShortURL(b *testing.B) {
 	ctrl := gomock.NewController(b)
 	storage := mocks.NewMockShortURLStorage(ctrl)
 	ctx := context.Background()
 
-	storage.EXPECT().FindShortURL(ctx, "alias").Return(&entity.ShortURL{}, nil).AnyTimes()
-	uc := NewShortURLUseCase(storage, "baseURL")
+	storage.EXPECT().FindShortURL(ctx, "", "alias").Return(&entity.ShortURL{}, nil).AnyTimes()
+	uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "baseURL")
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = uc.FindShortURL(ctx, "alias")
+		_, _ = uc.FindShortURL(ctx, "", "alias", nil)
 	}
 }
 
@@ -115,27 +255,63 @@ func Test_CreateShortURL_OK(t *testing.T) {
 	}
 
 	tests := []struct {
-		name       string
-		sourceURL  string
-		user       *userEntity.User
-		baseURL    string
-		storageRes storageRes
-		res        string
+		name          string
+		sourceURL     string
+		normalizedURL string
+		user          *userEntity.User
+		baseURL       string
+		storageRes    storageRes
+		res           string
 	}{
 		{
-			name:       "when successfully stored short URL",
-			sourceURL:  "https://ya.ru",
-			baseURL:    "http://localhost:8888",
-			storageRes: storageRes{shortURL: &entity.ShortURL{Alias: "alias"}},
-			res:        "http://localhost:8888/alias",
+			name:          "when successfully stored short URL",
+			sourceURL:     "https://ya.ru",
+			normalizedURL: "https://ya.ru",
+			baseURL:       "http://localhost:8888",
+			storageRes:    storageRes{shortURL: &entity.ShortURL{Alias: "alias"}},
+			res:           "http://localhost:8888/alias",
+		},
+		{
+			name:          "when source URL is not normalized",
+			sourceURL:     "https://Ya.ru:443/?b=2&a=1#top",
+			normalizedURL: "https://ya.ru?a=1&b=2",
+			baseURL:       "http://localhost:8888",
+			storageRes:    storageRes{shortURL: &entity.ShortURL{Alias: "alias"}},
+			res:           "http://localhost:8888/alias",
+		},
+		{
+			name:          "when short URL is stored in custom namespace",
+			sourceURL:     "https://go.dev",
+			normalizedURL: "https://go.dev",
+			baseURL:       "http://localhost:8888",
+			storageRes:    storageRes{shortURL: &entity.ShortURL{Alias: "alias", Namespace: "team-a"}},
+			res:           "http://localhost:8888/team-a/alias",
+		},
+		{
+			name:          "when user has custom domain",
+			sourceURL:     "https://go.dev",
+			normalizedURL: "https://go.dev",
+			user:          &userEntity.User{ID: 1, CustomDomain: "https://short.acme.com"},
+			baseURL:       "http://localhost:8888",
+			storageRes:    storageRes{shortURL: &entity.ShortURL{Alias: "alias", UserID: 1}},
+			res:           "https://short.acme.com/alias",
+		},
+		{
+			name:          "when user has no custom domain",
+			sourceURL:     "https://go.dev",
+			normalizedURL: "https://go.dev",
+			user:          &userEntity.User{ID: 2},
+			baseURL:       "http://localhost:8888",
+			storageRes:    storageRes{shortURL: &entity.ShortURL{Alias: "alias", UserID: 2}},
+			res:           "http://localhost:8888/alias",
 		},
 	}
 	for _, tt := range tests {
-		storage.EXPECT().SaveShortURL(ctx, nil, tt.sourceURL).Return(tt.storageRes.shortURL, nil)
-		uc := NewShortURLUseCase(storage, tt.baseURL)
+		storage.EXPECT().SaveShortURL(ctx, tt.user, tt.sourceURL, tt.normalizedURL, entity.CreateOptions{}).Return(tt.storageRes.shortURL, nil)
+		uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, tt.baseURL)
 
 		t.Run(tt.name, func(t *testing.T) {
-			res, err := uc.CreateShortURL(ctx, nil, tt.sourceURL)
+			res, err := uc.CreateShortURL(ctx, tt.user, tt.sourceURL, entity.CreateOptions{})
 			require.NoError(t, err)
 			require.Equal(t, tt.res, res)
 		})
@@ -185,13 +361,20 @@ func Test_CreateShortURL_Errors(t *testing.T) {
 			},
 			err: ucErrors.ErrShortURLAlreadyExist,
 		},
+		{
+			name:       "when namespace does not exist",
+			sourceURL:  "https://go.dev",
+			baseURL:    "http://localhost:8888",
+			storageRes: storageRes{err: dbErrors.ErrDBReferenceNotFound},
+			err:        ucErrors.ErrShortURLNamespaceNotFound,
+		},
 	}
 	for _, tt := range tests {
-		storage.EXPECT().SaveShortURL(ctx, nil, tt.sourceURL).Return(tt.storageRes.shortURL, tt.storageRes.err).AnyTimes()
-		uc := NewShortURLUseCase(storage, tt.baseURL)
+		storage.EXPECT().SaveShortURL(ctx, nil, tt.sourceURL, tt.sourceURL, entity.CreateOptions{}).Return(tt.storageRes.shortURL, tt.storageRes.err).AnyTimes()
+		uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, tt.baseURL)
 
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := uc.CreateShortURL(ctx, nil, tt.sourceURL)
+			_, err := uc.CreateShortURL(ctx, nil, tt.sourceURL, entity.CreateOptions{})
 			require.ErrorIs(t, tt.err, err)
 		})
 	}
@@ -202,12 +385,12 @@ func Benchmark_CreateShortURL(b *testing.B) {
 	storage := mocks.NewMockShortURLStorage(ctrl)
 	ctx := context.Background()
 
-	storage.EXPECT().SaveShortURL(ctx, nil, "https://example.com").Return(&entity.ShortURL{}, nil).AnyTimes()
-	uc := NewShortURLUseCase(storage, "baseURL")
+	storage.EXPECT().SaveShortURL(ctx, nil, "https://example.com", "https://example.com", entity.CreateOptions{}).Return(&entity.ShortURL{}, nil).AnyTimes()
+	uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "baseURL")
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = uc.CreateShortURL(ctx, nil, "https://example.com")
+		_, _ = uc.CreateShortURL(ctx, nil, "https://example.com", entity.CreateOptions{})
 	}
 }
 
@@ -220,10 +403,13 @@ func Test_BatchShortURLs_OK(t *testing.T) {
 	urls = append(urls,
 		entity.BatchShortURLInput{CorrelationID: "1", OriginalURL: "https://ya.ru"},
 		entity.BatchShortURLInput{CorrelationID: "2", OriginalURL: "https://ya.com"},
+		entity.BatchShortURLInput{CorrelationID: "3", OriginalURL: "invalid"},
+		entity.BatchShortURLInput{CorrelationID: "4", OriginalURL: "https://go.dev"},
 	)
 
-	storage.EXPECT().SaveShortURL(ctx, nil, urls[0].OriginalURL).Return(&entity.ShortURL{Alias: "alias1"}, nil).Times(1)
-	storage.EXPECT().SaveShortURL(ctx, nil, urls[1].OriginalURL).Return(&entity.ShortURL{Alias: "alias2"}, nil).Times(1)
+	storage.EXPECT().SaveShortURL(ctx, nil, urls[0].OriginalURL, urls[0].OriginalURL, entity.CreateOptions{}).Return(&entity.ShortURL{Alias: "alias1"}, nil).Times(1)
+	storage.EXPECT().SaveShortURL(ctx, nil, urls[1].OriginalURL, urls[1].OriginalURL, entity.CreateOptions{}).Return(&entity.ShortURL{Alias: "alias2"}, nil).Times(1)
+	storage.EXPECT().SaveShortURL(ctx, nil, urls[3].OriginalURL, urls[3].OriginalURL, entity.CreateOptions{}).Return(&entity.ShortURL{Alias: "alias4"}, storageErrors.ErrStorageRecordIsNotUnique).Times(1)
 
 	tests := []struct {
 		name    string
@@ -238,19 +424,194 @@ func Test_BatchShortURLs_OK(t *testing.T) {
 			result: []entity.BatchShortURLOutput{
 				{CorrelationID: "1", ShortURL: "http://localhost:8080/alias1"},
 				{CorrelationID: "2", ShortURL: "http://localhost:8080/alias2"},
+				{CorrelationID: "3", Error: ucErrors.ErrShortURLInvalidSourceURL.Error()},
+				{CorrelationID: "4", Error: ucErrors.ErrShortURLAlreadyExist.Error()},
 			},
 		},
 	}
 	for _, tt := range tests {
-		uc := NewShortURLUseCase(storage, tt.baseURL)
+		uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, tt.baseURL)
 
 		t.Run(tt.name, func(t *testing.T) {
-			res := uc.BatchShortURLs(ctx, tt.urls)
+			res, err := uc.BatchShortURLs(ctx, nil, tt.urls)
+			require.NoError(t, err)
 			require.Equal(t, tt.result, res)
 		})
 	}
 }
 
+// batchStorage combines ShortURLStorage and BatchSaver mocks.
+type batchStorage struct {
+	*mocks.MockShortURLStorage
+	*mocks.MockBatchSaver
+}
+
+func Test_BatchShortURLs_BatchSaver_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := batchStorage{mocks.NewMockShortURLStorage(ctrl), mocks.NewMockBatchSaver(ctrl)}
+	ctx := context.Background()
+
+	urls := []entity.BatchShortURLInput{
+		{CorrelationID: "1", OriginalURL: "https://ya.ru/"},
+		{CorrelationID: "2", OriginalURL: "invalid"},
+		{CorrelationID: "3", OriginalURL: "https://ya.com"},
+	}
+
+	storage.MockBatchSaver.EXPECT().SaveShortURLBatch(ctx, nil, []entity.BatchShortURLInput{
+		{CorrelationID: "1", OriginalURL: "https://ya.ru/", NormalizedURL: "https://ya.ru"},
+		{CorrelationID: "3", OriginalURL: "https://ya.com", NormalizedURL: "https://ya.com"},
+	}).Return(&entity.BatchSaveResult{
+		SucceededIDs: []string{"1", "3"},
+		ShortURLs:    []*entity.ShortURL{{Alias: "alias1"}, {Alias: "alias3"}},
+	}, nil)
+
+	uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "http://localhost:8080")
+
+	res, err := uc.BatchShortURLs(ctx, nil, urls)
+	require.NoError(t, err)
+	require.Equal(t, []entity.BatchShortURLOutput{
+		{CorrelationID: "1", ShortURL: "http://localhost:8080/alias1"},
+		{CorrelationID: "2", Error: ucErrors.ErrShortURLInvalidSourceURL.Error()},
+		{CorrelationID: "3", ShortURL: "http://localhost:8080/alias3"},
+	}, res)
+}
+
+func Test_BatchShortURLs_BatchSaver_ExistingURLs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := batchStorage{mocks.NewMockShortURLStorage(ctrl), mocks.NewMockBatchSaver(ctrl)}
+	ctx := context.Background()
+
+	urls := []entity.BatchShortURLInput{
+		{CorrelationID: "1", OriginalURL: "https://ya.ru/"},
+		{CorrelationID: "2", OriginalURL: "https://existing.com"},
+		{CorrelationID: "3", OriginalURL: "invalid"},
+		{CorrelationID: "4", OriginalURL: "https://ya.com"},
+	}
+
+	// Already existing URL is skipped by the storage
+	storage.MockBatchSaver.EXPECT().SaveShortURLBatch(ctx, nil, gomock.Any()).Return(&entity.BatchSaveResult{
+		SucceededIDs: []string{"1", "4"},
+		ShortURLs:    []*entity.ShortURL{{Alias: "alias1"}, {Alias: "alias4"}},
+	}, nil)
+
+	uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "http://localhost:8080")
+
+	res, err := uc.BatchShortURLs(ctx, nil, urls)
+	require.NoError(t, err)
+	require.Len(t, res, len(urls))
+	require.Equal(t, []entity.BatchShortURLOutput{
+		{CorrelationID: "1", ShortURL: "http://localhost:8080/alias1"},
+		{CorrelationID: "2", Error: ucErrors.ErrShortURLAlreadyExist.Error()},
+		{CorrelationID: "3", Error: ucErrors.ErrShortURLInvalidSourceURL.Error()},
+		{CorrelationID: "4", ShortURL: "http://localhost:8080/alias4"},
+	}, res)
+}
+
+func Test_BatchShortURLs_BatchSaver_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := batchStorage{mocks.NewMockShortURLStorage(ctrl), mocks.NewMockBatchSaver(ctrl)}
+	ctx := context.Background()
+
+	urls := []entity.BatchShortURLInput{{CorrelationID: "1", OriginalURL: "https://ya.ru"}}
+
+	t.Run("when storage fails to save batch", func(t *testing.T) {
+		storage.MockBatchSaver.EXPECT().SaveShortURLBatch(ctx, nil, gomock.Any()).
+			Return(&entity.BatchSaveResult{}, dbErrors.ErrDBQuery)
+
+		uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "http://localhost:8080")
+		res, err := uc.BatchShortURLs(ctx, nil, urls)
+		require.ErrorIs(t, err, dbErrors.ErrDBQuery)
+		require.Nil(t, res)
+	})
+
+	t.Run("when base URL is invalid", func(t *testing.T) {
+		uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "")
+		_, err := uc.BatchShortURLs(ctx, nil, urls)
+		require.ErrorIs(t, err, ucErrors.ErrShortURLInvalidBaseURL)
+	})
+}
+
+func Test_BatchValidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockShortURLStorage(ctrl)
+	checker := mocks.NewMockURLChecker(ctrl)
+	ctx := context.Background()
+	checkErr := errors.New("safe browsing is unavailable")
+
+	urls := make([]entity.BatchShortURLInput, 0, 3*batchValidationWorkers)
+	for i := range 3 * batchValidationWorkers {
+		urls = append(urls, entity.BatchShortURLInput{CorrelationID: strconv.Itoa(i), OriginalURL: fmt.Sprintf("https://YA.ru/%d", i)})
+	}
+	urls[1].OriginalURL = "invalid"
+
+	var (
+		inFlight    atomic.Int32
+		maxInFlight atomic.Int32
+	)
+	checker.EXPECT().IsUnsafe(ctx, urls[3].OriginalURL).Return(false, checkErr)
+	checker.EXPECT().IsUnsafe(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, url string) (bool, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		return url == urls[2].OriginalURL, nil
+	}).Times(len(urls) - 2)
+
+	uc := NewShortURLUseCase(storage, checker, &domainfilter.Filter{}, nil, nil, "http://localhost:8080")
+	valid, failed := uc.BatchValidate(ctx, urls)
+
+	require.Equal(t, []entity.BatchShortURLOutput{
+		{CorrelationID: "1", Error: ucErrors.ErrShortURLInvalidSourceURL.Error()},
+		{CorrelationID: "2", Error: ucErrors.ErrShortURLUnsafeContent.Error()},
+		{CorrelationID: "3", Error: checkErr.Error()},
+	}, failed)
+	require.Len(t, valid, len(urls)-3)
+	require.Equal(t, "0", valid[0].CorrelationID)
+	require.Equal(t, "https://ya.ru/0", valid[0].NormalizedURL)
+	require.Equal(t, "4", valid[1].CorrelationID)
+	require.LessOrEqual(t, maxInFlight.Load(), int32(batchValidationWorkers))
+}
+
+func Test_CreateShortURL_UnsafeURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockShortURLStorage(ctrl)
+	checker := mocks.NewMockURLChecker(ctrl)
+	ctx := context.Background()
+	checkErr := errors.New("safe browsing is unavailable")
+
+	tests := []struct {
+		checkErr error
+		err      error
+		name     string
+		unsafe   bool
+	}{
+		{
+			name:   "when URL is flagged as unsafe",
+			unsafe: true,
+			err:    ucErrors.ErrShortURLUnsafeContent,
+		},
+		{
+			name:     "when URL checker fails",
+			checkErr: checkErr,
+			err:      checkErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker.EXPECT().IsUnsafe(ctx, "https://malware.test").Return(tt.unsafe, tt.checkErr)
+			uc := NewShortURLUseCase(storage, checker, &domainfilter.Filter{}, nil, nil, "http://localhost:8080")
+			res, err := uc.CreateShortURL(ctx, nil, "https://malware.test", entity.CreateOptions{})
+			require.ErrorIs(t, err, tt.err)
+			require.Empty(t, res)
+		})
+	}
+}
+
 func Benchmark_BatchShortURLs(b *testing.B) {
 	ctrl := gomock.NewController(b)
 	storage := mocks.NewMockShortURLStorage(ctrl)
@@ -262,13 +623,436 @@ func Benchmark_BatchShortURLs(b *testing.B) {
 		entity.BatchShortURLInput{CorrelationID: "2", OriginalURL: "https://ya.com"},
 	)
 
-	storage.EXPECT().SaveShortURL(ctx, nil, urls[0].OriginalURL).Return(&entity.ShortURL{Alias: "alias1"}, nil).AnyTimes()
-	storage.EXPECT().SaveShortURL(ctx, nil, urls[1].OriginalURL).Return(&entity.ShortURL{Alias: "alias2"}, nil).AnyTimes()
+	storage.EXPECT().SaveShortURL(ctx, nil, urls[0].OriginalURL, urls[0].OriginalURL, entity.CreateOptions{}).Return(&entity.ShortURL{Alias: "alias1"}, nil).AnyTimes()
+	storage.EXPECT().SaveShortURL(ctx, nil, urls[1].OriginalURL, urls[1].OriginalURL, entity.CreateOptions{}).Return(&entity.ShortURL{Alias: "alias2"}, nil).AnyTimes()
 
-	uc := NewShortURLUseCase(storage, "baseURL")
+	uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "baseURL")
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		uc.BatchShortURLs(ctx, urls)
+		_, _ = uc.BatchShortURLs(ctx, nil, urls)
+	}
+}
+
+func Test_BatchShortURLs_ReportsUnsafeURLs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := batchStorage{mocks.NewMockShortURLStorage(ctrl), mocks.NewMockBatchSaver(ctrl)}
+	checker := mocks.NewMockURLChecker(ctrl)
+	ctx := context.Background()
+
+	urls := []entity.BatchShortURLInput{
+		{CorrelationID: "1", OriginalURL: "https://ya.ru"},
+		{CorrelationID: "2", OriginalURL: "https://malware.test"},
+	}
+
+	checker.EXPECT().IsUnsafe(ctx, "https://ya.ru").Return(false, nil)
+	checker.EXPECT().IsUnsafe(ctx, "https://malware.test").Return(true, nil)
+	storage.MockBatchSaver.EXPECT().SaveShortURLBatch(ctx, nil, []entity.BatchShortURLInput{
+		{CorrelationID: "1", OriginalURL: "https://ya.ru", NormalizedURL: "https://ya.ru"},
+	}).Return(&entity.BatchSaveResult{
+		SucceededIDs: []string{"1"},
+		ShortURLs:    []*entity.ShortURL{{Alias: "alias1"}},
+	}, nil)
+
+	uc := NewShortURLUseCase(storage, checker, &domainfilter.Filter{}, nil, nil, "http://localhost:8080")
+
+	res, err := uc.BatchShortURLs(ctx, nil, urls)
+	require.NoError(t, err)
+	require.Equal(t, []entity.BatchShortURLOutput{
+		{CorrelationID: "1", ShortURL: "http://localhost:8080/alias1"},
+		{CorrelationID: "2", Error: ucErrors.ErrShortURLUnsafeContent.Error()},
+	}, res)
+}
+
+func Test_CreateShortURL_Options(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockShortURLStorage(ctrl)
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1}
+
+	tests := []struct {
+		name string
+		user *userEntity.User
+		opts entity.CreateOptions
+	}{
+		{name: "when one-time URL is saved", user: user, opts: entity.CreateOptions{OneTimeUse: true}},
+		{name: "when private URL is saved", user: user, opts: entity.CreateOptions{Visibility: entity.VisibilityPrivate}},
+		{name: "when permanent URL is saved", opts: entity.CreateOptions{RedirectType: entity.RedirectPermanent}},
+		{name: "when tracked URL is saved", opts: entity.CreateOptions{Tracked: true}},
+		{name: "when URL with custom alias is saved", opts: entity.CreateOptions{Alias: "my-alias_1"}},
+		{name: "when expiring URL is saved", opts: entity.CreateOptions{ExpiresAt: time.Now().Add(time.Hour)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shortURL := &entity.ShortURL{Alias: "alias"}
+			tt.opts.Apply(shortURL)
+			storage.EXPECT().SaveShortURL(ctx, tt.user, "https://ya.ru/", "https://ya.ru", tt.opts).Return(shortURL, nil)
+
+			uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "http://localhost:8080")
+			res, err := uc.CreateShortURL(ctx, tt.user, "https://ya.ru/", tt.opts)
+			require.NoError(t, err)
+			require.Equal(t, "http://localhost:8080/"+shortURL.Alias, res)
+		})
+	}
+
+	t.Run("when private URL has no owner", func(t *testing.T) {
+		uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "http://localhost:8080")
+		_, err := uc.CreateShortURL(ctx, nil, "https://ya.ru/", entity.CreateOptions{Visibility: entity.VisibilityPrivate})
+		require.ErrorIs(t, err, ucErrors.ErrShortURLOwnerRequired)
+	})
+
+	t.Run("when source URL is invalid", func(t *testing.T) {
+		uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "http://localhost:8080")
+		_, err := uc.CreateShortURL(ctx, user, "invalid", entity.CreateOptions{OneTimeUse: true})
+		require.ErrorIs(t, err, ucErrors.ErrShortURLInvalidSourceURL)
+	})
+
+	t.Run("when custom alias is invalid", func(t *testing.T) {
+		uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "http://localhost:8080")
+		_, err := uc.CreateShortURL(ctx, user, "https://ya.ru/", entity.CreateOptions{Alias: "my/alias"})
+		require.ErrorIs(t, err, ucErrors.ErrShortURLInvalidAlias)
+	})
+
+	t.Run("when expiration time is in the past", func(t *testing.T) {
+		uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "http://localhost:8080")
+		_, err := uc.CreateShortURL(ctx, user, "https://ya.ru/", entity.CreateOptions{ExpiresAt: time.Now().Add(-time.Minute)})
+		require.ErrorIs(t, err, ucErrors.ErrShortURLInvalidExpiration)
+	})
+
+	t.Run("when custom alias is taken", func(t *testing.T) {
+		opts := entity.CreateOptions{Alias: "my-alias"}
+		storage.EXPECT().SaveShortURL(ctx, user, "https://ya.ru/", "https://ya.ru", opts).Return(nil, dbErrors.ErrDBAliasTaken)
+
+		uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, nil, "http://localhost:8080")
+		_, err := uc.CreateShortURL(ctx, user, "https://ya.ru/", opts)
+		require.ErrorIs(t, err, ucErrors.ErrShortURLAliasTaken)
+	})
+}
+
+func Test_CreateShortURL_DomainFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockShortURLStorage(ctrl)
+	ctx := context.Background()
+
+	tests := []struct {
+		filter *domainfilter.Filter
+		err    error
+		name   string
+	}{
+		{
+			name:   "when domain is blacklisted",
+			filter: domainfilter.New("ya.ru", ""),
+			err:    domainfilterErrors.ErrDomainBlacklisted,
+		},
+		{
+			name:   "when domain is not whitelisted",
+			filter: domainfilter.New("", "ya.com"),
+			err:    domainfilterErrors.ErrDomainNotWhitelisted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, tt.filter, nil, nil, "http://localhost:8080")
+			res, err := uc.CreateShortURL(ctx, nil, "https://ya.ru", entity.CreateOptions{})
+			require.ErrorIs(t, err, ucErrors.ErrShortURLDomainNotPermitted)
+			require.ErrorIs(t, err, tt.err)
+			require.Empty(t, res)
+		})
+	}
+}
+
+func Test_ValidateSourceURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockShortURLStorage(ctrl)
+	checker := mocks.NewMockURLChecker(ctrl)
+	ctx := context.Background()
+
+	tests := []struct {
+		setup     func()
+		err       error
+		name      string
+		sourceURL string
+	}{
+		{
+			name:      "when URL may be shortened",
+			sourceURL: "https://go.dev",
+			setup: func() {
+				checker.EXPECT().IsUnsafe(ctx, "https://go.dev").Return(false, nil)
+			},
+		},
+		{
+			name:      "when URL is invalid",
+			sourceURL: "go.dev",
+			setup:     func() {},
+			err:       ucErrors.ErrShortURLInvalidSourceURL,
+		},
+		{
+			name:      "when domain is blacklisted",
+			sourceURL: "https://ya.ru",
+			setup:     func() {},
+			err:       ucErrors.ErrShortURLDomainNotPermitted,
+		},
+		{
+			name:      "when URL is unsafe",
+			sourceURL: "https://malware.example",
+			setup: func() {
+				checker.EXPECT().IsUnsafe(ctx, "https://malware.example").Return(true, nil)
+			},
+			err: ucErrors.ErrShortURLUnsafeContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			uc := NewShortURLUseCase(storage, checker, domainfilter.New("ya.ru", ""), nil, nil, "http://localhost:8080")
+			err := uc.ValidateSourceURL(ctx, tt.sourceURL)
+			if tt.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.err)
+		})
 	}
 }
+
+func Test_ValidateBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockShortURLStorage(ctrl)
+	checker := mocks.NewMockURLChecker(ctrl)
+	ctx := context.Background()
+	errChecker := errors.New("checker is unavailable")
+
+	checker.EXPECT().IsUnsafe(gomock.Any(), "https://go.dev").Return(false, nil)
+	checker.EXPECT().IsUnsafe(gomock.Any(), "https://malware.example").Return(true, nil)
+	checker.EXPECT().IsUnsafe(gomock.Any(), "https://timeout.example").Return(false, errChecker)
+
+	urls := []entity.BatchShortURLInput{
+		{CorrelationID: "1", OriginalURL: "https://go.dev"},
+		{CorrelationID: "2", OriginalURL: "go.dev"},
+		{CorrelationID: "3", OriginalURL: "https://ya.ru"},
+		{CorrelationID: "4", OriginalURL: "https://malware.example"},
+		{CorrelationID: "5", OriginalURL: "https://timeout.example"},
+	}
+
+	uc := NewShortURLUseCase(storage, checker, domainfilter.New("ya.ru", ""), nil, nil, "http://localhost:8080")
+	res := uc.ValidateBatch(ctx, urls)
+
+	require.Len(t, res, len(urls))
+	require.Equal(t, entity.BatchValidationResult{CorrelationID: "1", Valid: true}, res[0])
+	require.Equal(t, entity.BatchValidationResult{CorrelationID: "2", Reason: ucErrors.ErrShortURLInvalidSourceURL.Error()}, res[1])
+	require.Equal(t, "3", res[2].CorrelationID)
+	require.False(t, res[2].Valid)
+	require.Contains(t, res[2].Reason, ucErrors.ErrShortURLDomainNotPermitted.Error())
+	require.Contains(t, res[2].Reason, domainfilterErrors.ErrDomainBlacklisted.Error())
+	require.Equal(t, entity.BatchValidationResult{CorrelationID: "4", Reason: ucErrors.ErrShortURLUnsafeContent.Error()}, res[3])
+	require.Equal(t, "5", res[4].CorrelationID)
+	require.False(t, res[4].Valid)
+	require.Contains(t, res[4].Reason, errChecker.Error())
+}
+
+func Test_BatchShortURLs_ReportsNotPermittedDomains(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := batchStorage{mocks.NewMockShortURLStorage(ctrl), mocks.NewMockBatchSaver(ctrl)}
+	filter := mocks.NewMockDomainFilter(ctrl)
+	ctx := context.Background()
+
+	urls := []entity.BatchShortURLInput{
+		{CorrelationID: "1", OriginalURL: "https://ya.ru"},
+		{CorrelationID: "2", OriginalURL: "https://blocked.test"},
+	}
+
+	filter.EXPECT().Allow("https://ya.ru").Return(nil)
+	filter.EXPECT().Allow("https://blocked.test").Return(domainfilterErrors.ErrDomainBlacklisted)
+	storage.MockBatchSaver.EXPECT().SaveShortURLBatch(ctx, nil, []entity.BatchShortURLInput{
+		{CorrelationID: "1", OriginalURL: "https://ya.ru", NormalizedURL: "https://ya.ru"},
+	}).Return(&entity.BatchSaveResult{
+		SucceededIDs: []string{"1"},
+		ShortURLs:    []*entity.ShortURL{{Alias: "alias1"}},
+	}, nil)
+
+	uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, filter, nil, nil, "http://localhost:8080")
+
+	res, err := uc.BatchShortURLs(ctx, nil, urls)
+	require.NoError(t, err)
+	require.Equal(t, []entity.BatchShortURLOutput{
+		{CorrelationID: "1", ShortURL: "http://localhost:8080/alias1"},
+		{CorrelationID: "2", Error: fmt.Errorf("%w: %w", ucErrors.ErrShortURLDomainNotPermitted, domainfilterErrors.ErrDomainBlacklisted).Error()},
+	}, res)
+}
+
+func Test_ShortURLUseCase_EmitsLifecycleEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockShortURLStorage(ctrl)
+	bus := mocks.NewMockEventBus(ctrl)
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1}
+	uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, bus, nil, "http://localhost:8080")
+
+	t.Run("when short URL is created", func(t *testing.T) {
+		storage.EXPECT().SaveShortURL(ctx, user, "https://ya.ru/", "https://ya.ru", entity.CreateOptions{}).Return(&entity.ShortURL{Alias: "alias", Namespace: "team"}, nil)
+		bus.EXPECT().Publish(eventbus.URLCreated{Alias: "alias", OriginalURL: "https://ya.ru/", Namespace: "team", UserID: 1})
+
+		_, err := uc.CreateShortURL(ctx, user, "https://ya.ru/", entity.CreateOptions{})
+		require.NoError(t, err)
+	})
+
+	t.Run("when short URL is anonymous", func(t *testing.T) {
+		opts := entity.CreateOptions{RedirectType: entity.RedirectPermanent}
+		storage.EXPECT().SaveShortURL(ctx, nil, "https://ya.ru/", "https://ya.ru", opts).Return(&entity.ShortURL{Alias: "alias"}, nil)
+		bus.EXPECT().Publish(eventbus.URLCreated{Alias: "alias", OriginalURL: "https://ya.ru/"})
+
+		_, err := uc.CreateShortURL(ctx, nil, "https://ya.ru/", opts)
+		require.NoError(t, err)
+	})
+
+	t.Run("when short URL already exists", func(t *testing.T) {
+		storage.EXPECT().SaveShortURL(ctx, user, "https://ya.ru/", "https://ya.ru", entity.CreateOptions{}).
+			Return(&entity.ShortURL{Alias: "alias"}, storageErrors.ErrStorageRecordIsNotUnique)
+
+		_, err := uc.CreateShortURL(ctx, user, "https://ya.ru/", entity.CreateOptions{})
+		require.ErrorIs(t, err, ucErrors.ErrShortURLAlreadyExist)
+	})
+
+	t.Run("when short URLs are created one by one in batch", func(t *testing.T) {
+		storage.EXPECT().SaveShortURL(ctx, user, "https://ya.ru/", "https://ya.ru", entity.CreateOptions{}).Return(&entity.ShortURL{Alias: "alias1", Namespace: "team"}, nil)
+		storage.EXPECT().SaveShortURL(ctx, user, "https://google.com/", "https://google.com", entity.CreateOptions{}).
+			Return(&entity.ShortURL{Alias: "alias2"}, storageErrors.ErrStorageRecordIsNotUnique)
+		bus.EXPECT().Publish(eventbus.URLCreated{Alias: "alias1", OriginalURL: "https://ya.ru/", Namespace: "team", UserID: 1})
+
+		_, err := uc.BatchShortURLs(ctx, user, []entity.BatchShortURLInput{
+			{CorrelationID: "1", OriginalURL: "https://ya.ru/"},
+			{CorrelationID: "2", OriginalURL: "https://google.com/"},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("when short URLs are saved at once in batch", func(t *testing.T) {
+		batch := batchStorage{mocks.NewMockShortURLStorage(ctrl), mocks.NewMockBatchSaver(ctrl)}
+		batchUC := NewShortURLUseCase(batch, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, bus, nil, "http://localhost:8080")
+
+		batch.MockBatchSaver.EXPECT().SaveShortURLBatch(ctx, nil, gomock.Any()).Return(&entity.BatchSaveResult{
+			SucceededIDs: []string{"1", "2"},
+			ShortURLs: []*entity.ShortURL{
+				{Alias: "alias1", SourceURL: "https://ya.ru/"},
+				{Alias: "alias2", SourceURL: "https://google.com/"},
+			},
+		}, nil)
+		bus.EXPECT().Publish(eventbus.URLCreated{Alias: "alias1", OriginalURL: "https://ya.ru/"})
+		bus.EXPECT().Publish(eventbus.URLCreated{Alias: "alias2", OriginalURL: "https://google.com/"})
+
+		_, err := batchUC.BatchShortURLs(ctx, nil, []entity.BatchShortURLInput{
+			{CorrelationID: "1", OriginalURL: "https://ya.ru/"},
+			{CorrelationID: "2", OriginalURL: "https://google.com/"},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("when one-time short URL is found", func(t *testing.T) {
+		storage.EXPECT().FindShortURL(ctx, "", "alias").Return(&entity.ShortURL{Alias: "alias", SourceURL: "https://ya.ru/", UserID: 1, IsOneTimeUse: true}, nil)
+		storage.EXPECT().MarkURLAsDeleted(ctx, 0, "", []string{"alias"}).Return(nil)
+		bus.EXPECT().Publish(eventbus.URLDeleted{Alias: "alias", UserID: 1})
+
+		_, err := uc.FindShortURL(ctx, "", "alias", nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("when short URL is found", func(t *testing.T) {
+		storage.EXPECT().FindShortURL(ctx, "", "alias").Return(&entity.ShortURL{Alias: "alias", SourceURL: "https://ya.ru/", UserID: 1}, nil)
+
+		_, err := uc.FindShortURL(ctx, "", "alias", nil)
+		require.NoError(t, err)
+	})
+}
+
+func Test_ShortURLUseCase_PublishesEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockShortURLStorage(ctrl)
+	publisher := mocks.NewMockEventPublisher(ctrl)
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1}
+	createdAt := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	uc := NewShortURLUseCase(storage, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, publisher, "http://localhost:8080")
+
+	t.Run("when short URL is created", func(t *testing.T) {
+		storage.EXPECT().SaveShortURL(ctx, user, "https://ya.ru/", "https://ya.ru", entity.CreateOptions{}).
+			Return(&entity.ShortURL{Alias: "alias", SourceURL: "https://ya.ru/", CreatedAt: createdAt}, nil)
+		publisher.EXPECT().Publish(1, &entity.ShortURLCreatedEvent{Alias: "alias", OriginalURL: "https://ya.ru/", CreatedAt: createdAt})
+
+		_, err := uc.CreateShortURL(ctx, user, "https://ya.ru/", entity.CreateOptions{})
+		require.NoError(t, err)
+	})
+
+	t.Run("when short URL already exists", func(t *testing.T) {
+		storage.EXPECT().SaveShortURL(ctx, user, "https://ya.ru/", "https://ya.ru", entity.CreateOptions{}).
+			Return(&entity.ShortURL{Alias: "alias", SourceURL: "https://ya.ru/"}, storageErrors.ErrStorageRecordIsNotUnique)
+
+		_, err := uc.CreateShortURL(ctx, user, "https://ya.ru/", entity.CreateOptions{})
+		require.ErrorIs(t, err, ucErrors.ErrShortURLAlreadyExist)
+	})
+
+	t.Run("when short URL is anonymous", func(t *testing.T) {
+		storage.EXPECT().SaveShortURL(ctx, nil, "https://ya.ru/", "https://ya.ru", entity.CreateOptions{}).Return(&entity.ShortURL{Alias: "alias"}, nil)
+
+		_, err := uc.CreateShortURL(ctx, nil, "https://ya.ru/", entity.CreateOptions{})
+		require.NoError(t, err)
+	})
+
+	for name, opts := range map[string]entity.CreateOptions{
+		"one-time":  {OneTimeUse: true},
+		"private":   {Visibility: entity.VisibilityPrivate},
+		"permanent": {RedirectType: entity.RedirectPermanent},
+		"tracked":   {Tracked: true},
+	} {
+		t.Run("when "+name+" short URL is created", func(t *testing.T) {
+			storage.EXPECT().SaveShortURL(ctx, user, "https://ya.ru/", "https://ya.ru", opts).
+				Return(&entity.ShortURL{Alias: name, SourceURL: "https://ya.ru/", CreatedAt: createdAt}, nil)
+			publisher.EXPECT().Publish(1, &entity.ShortURLCreatedEvent{Alias: name, OriginalURL: "https://ya.ru/", CreatedAt: createdAt})
+
+			_, err := uc.CreateShortURL(ctx, user, "https://ya.ru/", opts)
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("when short URLs are created one by one in batch", func(t *testing.T) {
+		storage.EXPECT().SaveShortURL(ctx, user, "https://ya.ru/", "https://ya.ru", entity.CreateOptions{}).
+			Return(&entity.ShortURL{Alias: "alias1", SourceURL: "https://ya.ru/", CreatedAt: createdAt}, nil)
+		storage.EXPECT().SaveShortURL(ctx, user, "https://google.com/", "https://google.com", entity.CreateOptions{}).
+			Return(&entity.ShortURL{Alias: "alias2"}, storageErrors.ErrStorageRecordIsNotUnique)
+		publisher.EXPECT().Publish(1, &entity.ShortURLCreatedEvent{Alias: "alias1", OriginalURL: "https://ya.ru/", CreatedAt: createdAt})
+
+		_, err := uc.BatchShortURLs(ctx, user, []entity.BatchShortURLInput{
+			{CorrelationID: "1", OriginalURL: "https://ya.ru/"},
+			{CorrelationID: "2", OriginalURL: "https://google.com/"},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("when short URLs are saved at once in batch", func(t *testing.T) {
+		batch := batchStorage{mocks.NewMockShortURLStorage(ctrl), mocks.NewMockBatchSaver(ctrl)}
+		batchUC := NewShortURLUseCase(batch, safebrowsing.NoOpChecker{}, &domainfilter.Filter{}, nil, publisher, "http://localhost:8080")
+
+		batch.MockBatchSaver.EXPECT().SaveShortURLBatch(ctx, user, gomock.Any()).Return(&entity.BatchSaveResult{
+			SucceededIDs: []string{"1", "2"},
+			ShortURLs: []*entity.ShortURL{
+				{Alias: "alias1", SourceURL: "https://ya.ru/", CreatedAt: createdAt},
+				{Alias: "alias2", SourceURL: "https://google.com/", CreatedAt: createdAt},
+			},
+		}, nil)
+		publisher.EXPECT().Publish(1, &entity.ShortURLCreatedEvent{Alias: "alias1", OriginalURL: "https://ya.ru/", CreatedAt: createdAt})
+		publisher.EXPECT().Publish(1, &entity.ShortURLCreatedEvent{Alias: "alias2", OriginalURL: "https://google.com/", CreatedAt: createdAt})
+
+		_, err := batchUC.BatchShortURLs(ctx, user, []entity.BatchShortURLInput{
+			{CorrelationID: "1", OriginalURL: "https://ya.ru/"},
+			{CorrelationID: "2", OriginalURL: "https://google.com/"},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("when short URLs are created in anonymous batch", func(t *testing.T) {
+		storage.EXPECT().SaveShortURL(ctx, nil, "https://ya.ru/", "https://ya.ru", entity.CreateOptions{}).Return(&entity.ShortURL{Alias: "alias"}, nil)
+
+		_, err := uc.BatchShortURLs(ctx, nil, []entity.BatchShortURLInput{{CorrelationID: "1", OriginalURL: "https://ya.ru/"}})
+		require.NoError(t, err)
+	})
+}
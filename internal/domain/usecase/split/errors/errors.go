@@ -0,0 +1,97 @@
+// Package usecase contains application business logic and acts as an intermediary
+// between the presentation layer (e.g., HTTP handlers) and the data layer (e.g., database).
+// It defines split-specific errors.
+package usecase
+
+import "errors"
+
+// Errors list
+var (
+	// ErrSplitInvalidAlias indicates the requested alias cannot be used in a short URL path.
+	//
+	// Typical cases:
+	// - Alias is empty or longer than 64 characters
+	// - Alias contains characters other than latin letters, digits, "-" and "_"
+	//
+	// Handling recommendations:
+	// - Return HTTP 422 (Unprocessable Entity) in web handlers
+	ErrSplitInvalidAlias = errors.New("alias must be 1 to 64 latin letters, digits, - or _")
+
+	// ErrSplitInvalidDestinationsCount indicates the split has too few or too many destinations.
+	//
+	// Handling recommendations:
+	// - Return HTTP 422 (Unprocessable Entity) in web handlers
+	ErrSplitInvalidDestinationsCount = errors.New("split must have 2 to 10 destinations")
+
+	// ErrSplitInvalidURL indicates one of destination URLs is not a valid http(s) URL.
+	//
+	// Handling recommendations:
+	// - Return HTTP 422 (Unprocessable Entity) in web handlers
+	ErrSplitInvalidURL = errors.New("invalid destination URL")
+
+	// ErrSplitDuplicateURL indicates the same URL is passed in several destinations.
+	//
+	// Resolution:
+	// - Merge destinations summing their weights
+	//
+	// Handling recommendations:
+	// - Return HTTP 422 (Unprocessable Entity) in web handlers
+	ErrSplitDuplicateURL = errors.New("destination URLs must be unique")
+
+	// ErrSplitInvalidWeight indicates one of destination weights is not positive.
+	//
+	// Handling recommendations:
+	// - Return HTTP 422 (Unprocessable Entity) in web handlers
+	ErrSplitInvalidWeight = errors.New("destination weight must be positive")
+
+	// ErrSplitInvalidWeightsSum indicates destination weights do not sum up to 100.
+	//
+	// Handling recommendations:
+	// - Return HTTP 422 (Unprocessable Entity) in web handlers
+	ErrSplitInvalidWeightsSum = errors.New("destination weights must sum to 100")
+
+	// ErrSplitDomainNotPermitted indicates a destination URL domain is blacklisted
+	// or is not whitelisted by the configured domain filter.
+	//
+	// Note: The filter error is wrapped, so its exact reason can be checked with errors.Is
+	ErrSplitDomainNotPermitted = errors.New("domain is not permitted")
+
+	// ErrSplitUnsafeContent indicates a destination URL is flagged by URL checker
+	// as hosting malware, phishing or other unwanted content.
+	//
+	// Note: Split is not saved
+	ErrSplitUnsafeContent = errors.New("URL flagged as unsafe")
+
+	// ErrSplitAlreadyExist indicates the alias is already used by a short URL or another split.
+	//
+	// Handling recommendations:
+	// - Return HTTP 409 (Conflict) in web handlers
+	ErrSplitAlreadyExist = errors.New("alias is already taken")
+
+	// ErrSplitNamespaceNotFound indicates an attempt to create a split in a namespace which doesn't exist.
+	//
+	// Resolution: Create the namespace first or omit it to use the default one
+	//
+	// Handling recommendations:
+	// - Return HTTP 404 (Not Found) in web handlers
+	ErrSplitNamespaceNotFound = errors.New("namespace not found")
+
+	// ErrSplitNotFound indicates there is no split with the alias.
+	//
+	// Handling recommendations:
+	// - Return HTTP 404 (Not Found) in web handlers
+	ErrSplitNotFound = errors.New("split is not found")
+
+	// ErrSplitForbidden indicates the split belongs to another user.
+	//
+	// Handling recommendations:
+	// - Return HTTP 403 (Forbidden) in web handlers
+	ErrSplitForbidden = errors.New("split belongs to another user")
+
+	// ErrSplitStorageNotWorking indicates failure of the storage holding splits.
+	//
+	// Handling recommendations:
+	// - Return HTTP 500 (Internal Server Error) in web handlers
+	// - Check database logs for the failed query
+	ErrSplitStorageNotWorking = errors.New("split storage is not working")
+)
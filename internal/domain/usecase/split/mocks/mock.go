@@ -0,0 +1,162 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gururuby/shortener/internal/domain/usecase/split (interfaces: Storage,URLChecker,DomainFilter)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock.go -package=mocks . Storage,URLChecker,DomainFilter
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/split"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStorage is a mock of Storage interface.
+type MockStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockStorageMockRecorder is the mock recorder for MockStorage.
+type MockStorageMockRecorder struct {
+	mock *MockStorage
+}
+
+// NewMockStorage creates a new mock instance.
+func NewMockStorage(ctrl *gomock.Controller) *MockStorage {
+	mock := &MockStorage{ctrl: ctrl}
+	mock.recorder = &MockStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
+	return m.recorder
+}
+
+// FindSplit mocks base method.
+func (m *MockStorage) FindSplit(ctx context.Context, namespace, alias string) (*entity.MultiDestination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSplit", ctx, namespace, alias)
+	ret0, _ := ret[0].(*entity.MultiDestination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSplit indicates an expected call of FindSplit.
+func (mr *MockStorageMockRecorder) FindSplit(ctx, namespace, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSplit", reflect.TypeOf((*MockStorage)(nil).FindSplit), ctx, namespace, alias)
+}
+
+// SaveSplit mocks base method.
+func (m *MockStorage) SaveSplit(ctx context.Context, split *entity.MultiDestination) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSplit", ctx, split)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSplit indicates an expected call of SaveSplit.
+func (mr *MockStorageMockRecorder) SaveSplit(ctx, split any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSplit", reflect.TypeOf((*MockStorage)(nil).SaveSplit), ctx, split)
+}
+
+// SaveSplitClick mocks base method.
+func (m *MockStorage) SaveSplitClick(ctx context.Context, namespace, alias, url string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSplitClick", ctx, namespace, alias, url)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSplitClick indicates an expected call of SaveSplitClick.
+func (mr *MockStorageMockRecorder) SaveSplitClick(ctx, namespace, alias, url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSplitClick", reflect.TypeOf((*MockStorage)(nil).SaveSplitClick), ctx, namespace, alias, url)
+}
+
+// MockURLChecker is a mock of URLChecker interface.
+type MockURLChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockURLCheckerMockRecorder
+	isgomock struct{}
+}
+
+// MockURLCheckerMockRecorder is the mock recorder for MockURLChecker.
+type MockURLCheckerMockRecorder struct {
+	mock *MockURLChecker
+}
+
+// NewMockURLChecker creates a new mock instance.
+func NewMockURLChecker(ctrl *gomock.Controller) *MockURLChecker {
+	mock := &MockURLChecker{ctrl: ctrl}
+	mock.recorder = &MockURLCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockURLChecker) EXPECT() *MockURLCheckerMockRecorder {
+	return m.recorder
+}
+
+// IsUnsafe mocks base method.
+func (m *MockURLChecker) IsUnsafe(ctx context.Context, url string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUnsafe", ctx, url)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUnsafe indicates an expected call of IsUnsafe.
+func (mr *MockURLCheckerMockRecorder) IsUnsafe(ctx, url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUnsafe", reflect.TypeOf((*MockURLChecker)(nil).IsUnsafe), ctx, url)
+}
+
+// MockDomainFilter is a mock of DomainFilter interface.
+type MockDomainFilter struct {
+	ctrl     *gomock.Controller
+	recorder *MockDomainFilterMockRecorder
+	isgomock struct{}
+}
+
+// MockDomainFilterMockRecorder is the mock recorder for MockDomainFilter.
+type MockDomainFilterMockRecorder struct {
+	mock *MockDomainFilter
+}
+
+// NewMockDomainFilter creates a new mock instance.
+func NewMockDomainFilter(ctrl *gomock.Controller) *MockDomainFilter {
+	mock := &MockDomainFilter{ctrl: ctrl}
+	mock.recorder = &MockDomainFilterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDomainFilter) EXPECT() *MockDomainFilterMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockDomainFilter) Allow(rawURL string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", rawURL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockDomainFilterMockRecorder) Allow(rawURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockDomainFilter)(nil).Allow), rawURL)
+}
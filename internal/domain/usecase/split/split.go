@@ -0,0 +1,310 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . Storage,URLChecker,DomainFilter
+
+/*
+Package usecase implements the application's business logic layer.
+
+It contains:
+- Creation of short URLs splitting traffic between several original URLs, e.g. for A/B testing
+- Weighted random selection of a destination on every redirect
+- Per destination click statistics for the split owner
+- Error handling specific to split operations
+*/
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	splitEntity "github.com/gururuby/shortener/internal/domain/entity/split"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/split/errors"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/gururuby/shortener/pkg/validator"
+)
+
+// Split limits.
+const (
+	MinDestinations = 2  // Minimum number of destinations of a split
+	MaxDestinations = 10 // Maximum number of destinations of a split
+)
+
+// aliasRegexp matches aliases usable as a single path segment.
+var aliasRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// Storage defines the interface for storage operations required by split use cases.
+type Storage interface {
+	// SaveSplit stores a new split short URL
+	SaveSplit(ctx context.Context, split *splitEntity.MultiDestination) error
+	// FindSplit retrieves a split short URL by its namespace and alias
+	FindSplit(ctx context.Context, namespace, alias string) (*splitEntity.MultiDestination, error)
+	// SaveSplitClick increments click count of a destination of a split short URL
+	SaveSplitClick(ctx context.Context, namespace, alias, url string) error
+}
+
+// URLChecker defines the interface for checking URLs against threat lists.
+type URLChecker interface {
+	// IsUnsafe reports whether URL is known to host malware, phishing or other threats.
+	// Returns:
+	// - bool: true if URL is unsafe
+	// - error: Any error that occurred during check
+	IsUnsafe(ctx context.Context, url string) (bool, error)
+}
+
+// DomainFilter defines the interface for restricting domains of destination URLs.
+type DomainFilter interface {
+	// Allow checks whether the URL domain is permitted.
+	// Returns:
+	// - error: Reason of rejection or nil if the domain is permitted
+	Allow(rawURL string) error
+}
+
+// SplitURLUseCase implements split short URL use cases.
+type SplitURLUseCase struct {
+	storage Storage      // Storage layer interface
+	checker URLChecker   // Checker of destination URLs against threat lists
+	filter  DomainFilter // Filter of destination URL domains
+	baseURL string       // Base URL for shortened links
+}
+
+// SplitURL represents a split short URL with its destinations.
+type SplitURL struct {
+	ShortURL     string                    `json:"short_url"`    // The shortened URL
+	Alias        string                    `json:"alias"`        // Alias of the split
+	Destinations []splitEntity.Destination `json:"destinations"` // Destinations with their click counts
+	TotalClicks  int64                     `json:"total_clicks"` // Number of redirects to all destinations
+}
+
+// NewSplitURLUseCase creates a new instance of SplitURLUseCase.
+// Parameters:
+// - storage: Implementation of the Storage interface
+// - checker: Checker of destination URLs against threat lists
+// - filter: Filter of destination URL domains
+// - baseURL: Base URL for shortened links
+// Returns:
+// - *SplitURLUseCase: Initialized split use case instance
+func NewSplitURLUseCase(storage Storage, checker URLChecker, filter DomainFilter, baseURL string) *SplitURLUseCase {
+	return &SplitURLUseCase{storage: storage, checker: checker, filter: filter, baseURL: baseURL}
+}
+
+// CreateSplit creates a short URL splitting traffic between destinations by their weights.
+// Destination URLs are validated the same way as original URLs of regular short URLs.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: Owner of the split, its namespace is the namespace of the split
+// - alias: Requested alias, must not be used by any short URL or split of the namespace
+// - destinations: Destination URLs with weights summing to 100
+// Returns:
+// - *SplitURL: Created split
+// - error: Validation error, ErrSplitAlreadyExist, ErrSplitNamespaceNotFound or ErrSplitStorageNotWorking
+func (uc *SplitURLUseCase) CreateSplit(ctx context.Context, user *userEntity.User, alias string, destinations []splitEntity.Destination) (*SplitURL, error) {
+	if !aliasRegexp.MatchString(alias) {
+		return nil, ucErrors.ErrSplitInvalidAlias
+	}
+
+	if len(destinations) < MinDestinations || len(destinations) > MaxDestinations {
+		return nil, ucErrors.ErrSplitInvalidDestinationsCount
+	}
+
+	split := &splitEntity.MultiDestination{Alias: alias, Namespace: user.Namespace, UserID: user.ID}
+	if err := uc.prepareDestinations(ctx, split, destinations); err != nil {
+		return nil, err
+	}
+
+	if err := uc.storage.SaveSplit(ctx, split); err != nil {
+		switch {
+		case errors.Is(err, dbErrors.ErrDBIsNotUnique):
+			return nil, ucErrors.ErrSplitAlreadyExist
+		case errors.Is(err, dbErrors.ErrDBReferenceNotFound):
+			return nil, ucErrors.ErrSplitNamespaceNotFound
+		}
+		return nil, ucErrors.ErrSplitStorageNotWorking
+	}
+
+	return uc.toSplitURL(split), nil
+}
+
+// prepareDestinations validates destinations and adds them to the split.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - split: Split to fill
+// - destinations: Requested destinations
+// Returns:
+// - error: Validation error of the first invalid destination
+func (uc *SplitURLUseCase) prepareDestinations(ctx context.Context, split *splitEntity.MultiDestination, destinations []splitEntity.Destination) error {
+	var total int
+
+	seen := make(map[string]struct{}, len(destinations))
+
+	for _, destination := range destinations {
+		url := strings.TrimSpace(destination.URL)
+
+		if validator.IsInvalidURL(url) {
+			return ucErrors.ErrSplitInvalidURL
+		}
+
+		if _, ok := seen[url]; ok {
+			return ucErrors.ErrSplitDuplicateURL
+		}
+		seen[url] = struct{}{}
+
+		if destination.Weight <= 0 {
+			return ucErrors.ErrSplitInvalidWeight
+		}
+		total += destination.Weight
+
+		split.Destinations = append(split.Destinations, splitEntity.Destination{URL: url, Weight: destination.Weight})
+	}
+
+	if total != splitEntity.TotalWeight {
+		return ucErrors.ErrSplitInvalidWeightsSum
+	}
+
+	// Checks calling external services go last, so invalid requests fail fast
+	for _, destination := range split.Destinations {
+		if err := uc.filter.Allow(destination.URL); err != nil {
+			return fmt.Errorf("%w: %w", ucErrors.ErrSplitDomainNotPermitted, err)
+		}
+
+		unsafe, err := uc.checker.IsUnsafe(ctx, destination.URL)
+		if err != nil {
+			return err
+		}
+
+		if unsafe {
+			return ucErrors.ErrSplitUnsafeContent
+		}
+	}
+
+	return nil
+}
+
+// Resolve picks a destination of the split at random according to destination weights.
+// Randomness comes from crypto/rand, so the choice cannot be predicted by clients.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - namespace: Namespace of the split, empty for the default one
+// - alias: Split alias
+// Returns:
+// - string: Destination URL to redirect to
+// - error: ErrSplitNotFound or ErrSplitStorageNotWorking
+func (uc *SplitURLUseCase) Resolve(ctx context.Context, namespace, alias string) (string, error) {
+	split, err := uc.findSplit(ctx, namespace, alias)
+	if err != nil {
+		return "", err
+	}
+
+	return pickDestination(split.Destinations)
+}
+
+// RecordClick counts a redirect to a destination of the split.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - namespace: Namespace of the split, empty for the default one
+// - alias: Split alias
+// - url: Destination URL returned by Resolve
+// Returns:
+// - error: ErrSplitNotFound or ErrSplitStorageNotWorking
+func (uc *SplitURLUseCase) RecordClick(ctx context.Context, namespace, alias, url string) error {
+	if err := uc.storage.SaveSplitClick(ctx, namespace, alias, url); err != nil {
+		if errors.Is(err, dbErrors.ErrDBRecordNotFound) {
+			return ucErrors.ErrSplitNotFound
+		}
+		return ucErrors.ErrSplitStorageNotWorking
+	}
+	return nil
+}
+
+// GetStats retrieves per destination click counts of a split of the user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: Requesting user, must own the split, its namespace is the namespace of the split
+// - alias: Split alias
+// Returns:
+// - *SplitURL: Split with click counts
+// - error: ErrSplitNotFound, ErrSplitForbidden or ErrSplitStorageNotWorking
+func (uc *SplitURLUseCase) GetStats(ctx context.Context, user *userEntity.User, alias string) (*SplitURL, error) {
+	split, err := uc.findSplit(ctx, user.Namespace, alias)
+	if err != nil {
+		return nil, err
+	}
+
+	if split.UserID != user.ID {
+		return nil, ucErrors.ErrSplitForbidden
+	}
+
+	return uc.toSplitURL(split), nil
+}
+
+// findSplit retrieves a split mapping storage errors to use case ones.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - namespace: Namespace of the split, empty for the default one
+// - alias: Split alias
+// Returns:
+// - *splitEntity.MultiDestination: Found split
+// - error: ErrSplitNotFound or ErrSplitStorageNotWorking
+func (uc *SplitURLUseCase) findSplit(ctx context.Context, namespace, alias string) (*splitEntity.MultiDestination, error) {
+	split, err := uc.storage.FindSplit(ctx, namespace, alias)
+	if err != nil {
+		if errors.Is(err, dbErrors.ErrDBRecordNotFound) {
+			return nil, ucErrors.ErrSplitNotFound
+		}
+		return nil, ucErrors.ErrSplitStorageNotWorking
+	}
+	return split, nil
+}
+
+// toSplitURL converts a split to its representation for clients.
+// Parameters:
+// - split: Split to convert
+// Returns:
+// - *SplitURL: Split with its short URL and total click count
+func (uc *SplitURLUseCase) toSplitURL(split *splitEntity.MultiDestination) *SplitURL {
+	res := &SplitURL{
+		ShortURL:     uc.baseURL + "/" + split.Path(),
+		Alias:        split.Alias,
+		Destinations: split.Destinations,
+	}
+	for _, destination := range split.Destinations {
+		res.TotalClicks += destination.Clicks
+	}
+	return res
+}
+
+// pickDestination picks a destination URL with probability proportional to its weight.
+// Parameters:
+// - destinations: Destinations with positive weights
+// Returns:
+// - string: Picked destination URL
+// - error: ErrSplitStorageNotWorking if destinations are empty or random source fails
+func pickDestination(destinations []splitEntity.Destination) (string, error) {
+	var total int64
+
+	for _, destination := range destinations {
+		total += int64(destination.Weight)
+	}
+
+	if total <= 0 {
+		return "", ucErrors.ErrSplitStorageNotWorking
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(total))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ucErrors.ErrSplitStorageNotWorking, err)
+	}
+
+	point := n.Int64()
+	for _, destination := range destinations {
+		point -= int64(destination.Weight)
+		if point < 0 {
+			return destination.URL, nil
+		}
+	}
+
+	return destinations[len(destinations)-1].URL, nil
+}
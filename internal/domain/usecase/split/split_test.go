@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	splitEntity "github.com/gururuby/shortener/internal/domain/entity/split"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/split/errors"
+	"github.com/gururuby/shortener/internal/domain/usecase/split/mocks"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const baseURL = "http://localhost:8080"
+
+// abTest builds destinations of a 70/30 split.
+func abTest() []splitEntity.Destination {
+	return []splitEntity.Destination{
+		{URL: "https://v1.com", Weight: 70},
+		{URL: "https://v2.com", Weight: 30},
+	}
+}
+
+func Test_CreateSplit(t *testing.T) {
+	ctx := context.Background()
+	errFiltered := errors.New("domain is blacklisted")
+
+	tests := []struct {
+		setup        func(storage *mocks.MockStorage, checker *mocks.MockURLChecker, filter *mocks.MockDomainFilter)
+		want         *SplitURL
+		wantErr      error
+		name         string
+		namespace    string
+		alias        string
+		destinations []splitEntity.Destination
+	}{
+		{
+			name:  "when split is created",
+			alias: "ab-test",
+			destinations: []splitEntity.Destination{
+				{URL: " https://v1.com ", Weight: 70},
+				{URL: "https://v2.com", Weight: 30, Clicks: 5},
+			},
+			setup: func(storage *mocks.MockStorage, checker *mocks.MockURLChecker, filter *mocks.MockDomainFilter) {
+				filter.EXPECT().Allow(gomock.Any()).Return(nil).Times(2)
+				checker.EXPECT().IsUnsafe(ctx, gomock.Any()).Return(false, nil).Times(2)
+				storage.EXPECT().SaveSplit(ctx, &splitEntity.MultiDestination{Alias: "ab-test", UserID: 1, Destinations: abTest()}).Return(nil)
+			},
+			want: &SplitURL{ShortURL: baseURL + "/ab-test", Alias: "ab-test", Destinations: abTest()},
+		},
+		{
+			name:         "when split is created in namespace",
+			namespace:    "team",
+			alias:        "ab-test",
+			destinations: abTest(),
+			setup: func(storage *mocks.MockStorage, checker *mocks.MockURLChecker, filter *mocks.MockDomainFilter) {
+				filter.EXPECT().Allow(gomock.Any()).Return(nil).Times(2)
+				checker.EXPECT().IsUnsafe(ctx, gomock.Any()).Return(false, nil).Times(2)
+				storage.EXPECT().SaveSplit(ctx, &splitEntity.MultiDestination{Alias: "ab-test", Namespace: "team", UserID: 1, Destinations: abTest()}).Return(nil)
+			},
+			want: &SplitURL{ShortURL: baseURL + "/team/ab-test", Alias: "ab-test", Destinations: abTest()},
+		},
+		{
+			name:         "when alias is invalid",
+			alias:        "ab/test",
+			destinations: abTest(),
+			wantErr:      ucErrors.ErrSplitInvalidAlias,
+		},
+		{
+			name:         "when there is one destination",
+			alias:        "ab-test",
+			destinations: []splitEntity.Destination{{URL: "https://v1.com", Weight: 100}},
+			wantErr:      ucErrors.ErrSplitInvalidDestinationsCount,
+		},
+		{
+			name:         "when destination URL is invalid",
+			alias:        "ab-test",
+			destinations: []splitEntity.Destination{{URL: "https://v1.com", Weight: 70}, {URL: "v2", Weight: 30}},
+			wantErr:      ucErrors.ErrSplitInvalidURL,
+		},
+		{
+			name:         "when destination URLs are duplicated",
+			alias:        "ab-test",
+			destinations: []splitEntity.Destination{{URL: "https://v1.com", Weight: 70}, {URL: "https://v1.com", Weight: 30}},
+			wantErr:      ucErrors.ErrSplitDuplicateURL,
+		},
+		{
+			name:         "when weight is not positive",
+			alias:        "ab-test",
+			destinations: []splitEntity.Destination{{URL: "https://v1.com", Weight: 110}, {URL: "https://v2.com", Weight: -10}},
+			wantErr:      ucErrors.ErrSplitInvalidWeight,
+		},
+		{
+			name:         "when weights do not sum to 100",
+			alias:        "ab-test",
+			destinations: []splitEntity.Destination{{URL: "https://v1.com", Weight: 70}, {URL: "https://v2.com", Weight: 20}},
+			wantErr:      ucErrors.ErrSplitInvalidWeightsSum,
+		},
+		{
+			name:         "when domain is not permitted",
+			alias:        "ab-test",
+			destinations: abTest(),
+			setup: func(_ *mocks.MockStorage, _ *mocks.MockURLChecker, filter *mocks.MockDomainFilter) {
+				filter.EXPECT().Allow("https://v1.com").Return(errFiltered)
+			},
+			wantErr: ucErrors.ErrSplitDomainNotPermitted,
+		},
+		{
+			name:         "when destination is unsafe",
+			alias:        "ab-test",
+			destinations: abTest(),
+			setup: func(_ *mocks.MockStorage, checker *mocks.MockURLChecker, filter *mocks.MockDomainFilter) {
+				filter.EXPECT().Allow(gomock.Any()).Return(nil).Times(2)
+				checker.EXPECT().IsUnsafe(ctx, "https://v1.com").Return(false, nil)
+				checker.EXPECT().IsUnsafe(ctx, "https://v2.com").Return(true, nil)
+			},
+			wantErr: ucErrors.ErrSplitUnsafeContent,
+		},
+		{
+			name:         "when alias is taken",
+			alias:        "ab-test",
+			destinations: abTest(),
+			setup: func(storage *mocks.MockStorage, checker *mocks.MockURLChecker, filter *mocks.MockDomainFilter) {
+				filter.EXPECT().Allow(gomock.Any()).Return(nil).Times(2)
+				checker.EXPECT().IsUnsafe(ctx, gomock.Any()).Return(false, nil).Times(2)
+				storage.EXPECT().SaveSplit(ctx, gomock.Any()).Return(dbErrors.ErrDBIsNotUnique)
+			},
+			wantErr: ucErrors.ErrSplitAlreadyExist,
+		},
+		{
+			name:         "when namespace doesn't exist",
+			namespace:    "unknown",
+			alias:        "ab-test",
+			destinations: abTest(),
+			setup: func(storage *mocks.MockStorage, checker *mocks.MockURLChecker, filter *mocks.MockDomainFilter) {
+				filter.EXPECT().Allow(gomock.Any()).Return(nil).Times(2)
+				checker.EXPECT().IsUnsafe(ctx, gomock.Any()).Return(false, nil).Times(2)
+				storage.EXPECT().SaveSplit(ctx, gomock.Any()).Return(dbErrors.ErrDBReferenceNotFound)
+			},
+			wantErr: ucErrors.ErrSplitNamespaceNotFound,
+		},
+		{
+			name:         "when split cannot be saved",
+			alias:        "ab-test",
+			destinations: abTest(),
+			setup: func(storage *mocks.MockStorage, checker *mocks.MockURLChecker, filter *mocks.MockDomainFilter) {
+				filter.EXPECT().Allow(gomock.Any()).Return(nil).Times(2)
+				checker.EXPECT().IsUnsafe(ctx, gomock.Any()).Return(false, nil).Times(2)
+				storage.EXPECT().SaveSplit(ctx, gomock.Any()).Return(dbErrors.ErrDBQuery)
+			},
+			wantErr: ucErrors.ErrSplitStorageNotWorking,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockStorage(ctrl)
+			checker := mocks.NewMockURLChecker(ctrl)
+			filter := mocks.NewMockDomainFilter(ctrl)
+			if tt.setup != nil {
+				tt.setup(storage, checker, filter)
+			}
+
+			user := &userEntity.User{ID: 1, Namespace: tt.namespace}
+			split, err := NewSplitURLUseCase(storage, checker, filter, baseURL).CreateSplit(ctx, user, tt.alias, tt.destinations)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, split)
+		})
+	}
+}
+
+func Test_Resolve(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("when destinations are picked by weights", func(t *testing.T) {
+		const calls = 10000
+
+		ctrl := gomock.NewController(t)
+		storage := mocks.NewMockStorage(ctrl)
+		storage.EXPECT().FindSplit(ctx, "", "ab-test").
+			Return(&splitEntity.MultiDestination{Alias: "ab-test", UserID: 1, Destinations: abTest()}, nil).
+			Times(calls)
+
+		uc := NewSplitURLUseCase(storage, nil, nil, baseURL)
+		counts := make(map[string]int)
+		for range calls {
+			url, err := uc.Resolve(ctx, "", "ab-test")
+			require.NoError(t, err)
+			counts[url]++
+		}
+
+		require.Len(t, counts, 2)
+		for _, destination := range abTest() {
+			want := float64(calls * destination.Weight / splitEntity.TotalWeight)
+			assert.InDelta(t, want, counts[destination.URL], calls*0.05, destination.URL)
+		}
+	})
+
+	for _, tt := range []struct {
+		dbErr   error
+		wantErr error
+		name    string
+	}{
+		{name: "when split is not found", dbErr: dbErrors.ErrDBRecordNotFound, wantErr: ucErrors.ErrSplitNotFound},
+		{name: "when storage fails", dbErr: dbErrors.ErrDBQuery, wantErr: ucErrors.ErrSplitStorageNotWorking},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockStorage(ctrl)
+			storage.EXPECT().FindSplit(ctx, "", "ab-test").Return(nil, tt.dbErr)
+
+			url, err := NewSplitURLUseCase(storage, nil, nil, baseURL).Resolve(ctx, "", "ab-test")
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Empty(t, url)
+		})
+	}
+}
+
+func Test_RecordClick(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		dbErr   error
+		wantErr error
+		name    string
+	}{
+		{name: "when click is recorded"},
+		{name: "when destination is not found", dbErr: dbErrors.ErrDBRecordNotFound, wantErr: ucErrors.ErrSplitNotFound},
+		{name: "when storage fails", dbErr: dbErrors.ErrDBQuery, wantErr: ucErrors.ErrSplitStorageNotWorking},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockStorage(ctrl)
+			storage.EXPECT().SaveSplitClick(ctx, "team", "ab-test", "https://v1.com").Return(tt.dbErr)
+
+			err := NewSplitURLUseCase(storage, nil, nil, baseURL).RecordClick(ctx, "team", "ab-test", "https://v1.com")
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_GetStats(t *testing.T) {
+	ctx := context.Background()
+	destinations := []splitEntity.Destination{
+		{URL: "https://v1.com", Weight: 70, Clicks: 7},
+		{URL: "https://v2.com", Weight: 30, Clicks: 3},
+	}
+	split := &splitEntity.MultiDestination{Alias: "ab-test", Namespace: "team", UserID: 1, Destinations: destinations}
+
+	tests := []struct {
+		dbErr   error
+		want    *SplitURL
+		wantErr error
+		name    string
+		userID  int
+	}{
+		{
+			name:   "when owner requests stats",
+			userID: 1,
+			want:   &SplitURL{ShortURL: baseURL + "/team/ab-test", Alias: "ab-test", Destinations: destinations, TotalClicks: 10},
+		},
+		{name: "when another user requests stats", userID: 2, wantErr: ucErrors.ErrSplitForbidden},
+		{name: "when split is not found", userID: 1, dbErr: dbErrors.ErrDBRecordNotFound, wantErr: ucErrors.ErrSplitNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockStorage(ctrl)
+			if tt.dbErr != nil {
+				storage.EXPECT().FindSplit(ctx, "team", "ab-test").Return(nil, tt.dbErr)
+			} else {
+				storage.EXPECT().FindSplit(ctx, "team", "ab-test").Return(split, nil)
+			}
+
+			stats, err := NewSplitURLUseCase(storage, nil, nil, baseURL).GetStats(ctx, &userEntity.User{ID: tt.userID, Namespace: "team"}, "ab-test")
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, stats)
+		})
+	}
+}
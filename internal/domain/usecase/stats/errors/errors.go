@@ -0,0 +1,20 @@
+// Package usecase contains application business logic and acts as an intermediary
+// between the presentation layer (e.g., HTTP handlers) and the data layer (e.g., database).
+// It defines statistics-specific errors.
+package usecase
+
+import "errors"
+
+// Errors list
+var (
+	// ErrStatsCannotGet indicates that service statistics could not be collected.
+	//
+	// This error typically occurs when:
+	// - Database is not available
+	// - One of counting queries failed
+	//
+	// Handling recommendations:
+	// - Return HTTP 500 (Internal Server Error) in web handlers
+	// - Check database logs for the failed query
+	ErrStatsCannotGet = errors.New("cannot get stats")
+)
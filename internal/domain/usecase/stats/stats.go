@@ -0,0 +1,70 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . Storage
+
+/*
+Package usecase implements the application's business logic layer.
+
+It contains:
+- Service statistics collection
+- Error handling specific to statistics operations
+*/
+package usecase
+
+import (
+	"context"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/stats"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/stats/errors"
+)
+
+// Storage defines the interface for storage operations required by statistics use cases.
+type Storage interface {
+	// CountURLs returns the number of all short URLs, including deleted ones
+	CountURLs(ctx context.Context) (int64, error)
+	// CountDeletedURLs returns the number of short URLs marked as deleted
+	CountDeletedURLs(ctx context.Context) (int64, error)
+	// CountUsers returns the number of registered users
+	CountUsers(ctx context.Context) (int64, error)
+}
+
+// StatsUseCase implements service statistics use cases.
+type StatsUseCase struct {
+	storage Storage // Storage layer interface
+}
+
+// NewStatsUseCase creates a new instance of StatsUseCase.
+// Parameters:
+// - storage: Implementation of the Storage interface
+// Returns:
+// - *StatsUseCase: Initialized statistics use case instance
+func NewStatsUseCase(storage Storage) *StatsUseCase {
+	return &StatsUseCase{storage: storage}
+}
+
+// GetStats collects service statistics.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// Returns:
+// - *entity.Stats: Counts of URLs and users
+// - error: ErrStatsCannotGet if any of counters cannot be read
+func (uc *StatsUseCase) GetStats(ctx context.Context) (*entity.Stats, error) {
+	var (
+		stats entity.Stats
+		err   error
+	)
+
+	if stats.URLsCount, err = uc.storage.CountURLs(ctx); err != nil {
+		return nil, ucErrors.ErrStatsCannotGet
+	}
+
+	if stats.DeletedURLsCount, err = uc.storage.CountDeletedURLs(ctx); err != nil {
+		return nil, ucErrors.ErrStatsCannotGet
+	}
+
+	if stats.UsersCount, err = uc.storage.CountUsers(ctx); err != nil {
+		return nil, ucErrors.ErrStatsCannotGet
+	}
+
+	stats.ActiveURLsCount = stats.URLsCount - stats.DeletedURLsCount
+
+	return &stats, nil
+}
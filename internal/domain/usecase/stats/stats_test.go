@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/stats"
+	storageErrors "github.com/gururuby/shortener/internal/domain/storage/errors"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/stats/errors"
+	"github.com/gururuby/shortener/internal/domain/usecase/stats/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_GetStats_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockStorage(ctrl)
+	ctx := context.Background()
+	uc := NewStatsUseCase(storage)
+
+	storage.EXPECT().CountURLs(ctx).Return(int64(10), nil)
+	storage.EXPECT().CountDeletedURLs(ctx).Return(int64(3), nil)
+	storage.EXPECT().CountUsers(ctx).Return(int64(2), nil)
+
+	stats, err := uc.GetStats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &entity.Stats{
+		URLsCount:        10,
+		UsersCount:       2,
+		DeletedURLsCount: 3,
+		ActiveURLsCount:  7,
+	}, stats)
+}
+
+func Test_GetStats_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		setup func(storage *mocks.MockStorage)
+		name  string
+	}{
+		{
+			name: "when cannot count URLs",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().CountURLs(ctx).Return(int64(0), storageErrors.ErrStorageIsNotReadyDB)
+			},
+		},
+		{
+			name: "when cannot count deleted URLs",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().CountURLs(ctx).Return(int64(10), nil)
+				storage.EXPECT().CountDeletedURLs(ctx).Return(int64(0), storageErrors.ErrStorageIsNotReadyDB)
+			},
+		},
+		{
+			name: "when cannot count users",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().CountURLs(ctx).Return(int64(10), nil)
+				storage.EXPECT().CountDeletedURLs(ctx).Return(int64(3), nil)
+				storage.EXPECT().CountUsers(ctx).Return(int64(0), storageErrors.ErrStorageIsNotReadyDB)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockStorage(ctrl)
+			tt.setup(storage)
+
+			_, err := NewStatsUseCase(storage).GetStats(ctx)
+			require.ErrorIs(t, err, ucErrors.ErrStatsCannotGet)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Package usecase contains application business logic and acts as an intermediary
+// between the presentation layer (e.g., HTTP handlers) and the data layer (e.g., database).
+// It defines tag-specific errors.
+package usecase
+
+import "errors"
+
+// Errors list
+var (
+	// ErrTagInvalidName indicates the tag name is empty, too long or contains a slash.
+	//
+	// Typical cases:
+	// - Name consists of spaces only
+	// - Name is longer than 64 characters
+	// - Name contains "/", so it cannot be passed as a path segment
+	//
+	// Handling recommendations:
+	// - Return HTTP 422 (Unprocessable Entity) in web handlers
+	ErrTagInvalidName = errors.New("tag name must be 1 to 64 characters long and must not contain /")
+
+	// ErrTagAlreadyExist indicates the user already has a tag with the same name.
+	//
+	// Handling recommendations:
+	// - Return HTTP 409 (Conflict) in web handlers
+	// - Clients can assign the existing tag instead
+	ErrTagAlreadyExist = errors.New("tag already exist")
+
+	// ErrTagUserLimitExceeded indicates the user already has the maximum number of tags.
+	//
+	// Resolution:
+	// - Reuse one of existing tags
+	//
+	// Handling recommendations:
+	// - Return HTTP 422 (Unprocessable Entity) in web handlers
+	ErrTagUserLimitExceeded = errors.New("user cannot have more than 100 tags")
+
+	// ErrTagURLLimitExceeded indicates the short URL already has the maximum number of tags assigned.
+	//
+	// Resolution:
+	// - Remove one of assigned tags first
+	//
+	// Handling recommendations:
+	// - Return HTTP 422 (Unprocessable Entity) in web handlers
+	ErrTagURLLimitExceeded = errors.New("short URL cannot have more than 20 tags")
+
+	// ErrTagNotFound indicates the user has no tag with the passed name,
+	// or the tag is not assigned to the short URL being untagged.
+	//
+	// Handling recommendations:
+	// - Return HTTP 404 (Not Found) in web handlers
+	ErrTagNotFound = errors.New("tag is not found")
+
+	// ErrTagURLNotFound indicates the short URL doesn't exist or belongs to another user.
+	//
+	// Privacy note:
+	// - Both cases are reported the same way, so aliases of other users cannot be probed
+	//
+	// Handling recommendations:
+	// - Return HTTP 404 (Not Found) in web handlers
+	ErrTagURLNotFound = errors.New("short URL is not found")
+
+	// ErrTagStorageNotWorking indicates failure of the storage holding tags.
+	//
+	// Handling recommendations:
+	// - Return HTTP 500 (Internal Server Error) in web handlers
+	// - Check database logs for the failed query
+	ErrTagStorageNotWorking = errors.New("tag storage is not working")
+)
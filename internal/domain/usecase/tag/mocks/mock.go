@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gururuby/shortener/internal/domain/usecase/tag (interfaces: Storage)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock.go -package=mocks . Storage
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	entity0 "github.com/gururuby/shortener/internal/domain/entity/tag"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStorage is a mock of Storage interface.
+type MockStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockStorageMockRecorder is the mock recorder for MockStorage.
+type MockStorageMockRecorder struct {
+	mock *MockStorage
+}
+
+// NewMockStorage creates a new mock instance.
+func NewMockStorage(ctrl *gomock.Controller) *MockStorage {
+	mock := &MockStorage{ctrl: ctrl}
+	mock.recorder = &MockStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
+	return m.recorder
+}
+
+// AssignTag mocks base method.
+func (m *MockStorage) AssignTag(ctx context.Context, userID int, namespace, alias, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignTag", ctx, userID, namespace, alias, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignTag indicates an expected call of AssignTag.
+func (mr *MockStorageMockRecorder) AssignTag(ctx, userID, namespace, alias, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTag", reflect.TypeOf((*MockStorage)(nil).AssignTag), ctx, userID, namespace, alias, name)
+}
+
+// FindTagsByUser mocks base method.
+func (m *MockStorage) FindTagsByUser(ctx context.Context, userID int) ([]*entity0.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTagsByUser", ctx, userID)
+	ret0, _ := ret[0].([]*entity0.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTagsByUser indicates an expected call of FindTagsByUser.
+func (mr *MockStorageMockRecorder) FindTagsByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTagsByUser", reflect.TypeOf((*MockStorage)(nil).FindTagsByUser), ctx, userID)
+}
+
+// FindURLTags mocks base method.
+func (m *MockStorage) FindURLTags(ctx context.Context, userID int, namespace, alias string) ([]*entity0.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindURLTags", ctx, userID, namespace, alias)
+	ret0, _ := ret[0].([]*entity0.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindURLTags indicates an expected call of FindURLTags.
+func (mr *MockStorageMockRecorder) FindURLTags(ctx, userID, namespace, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindURLTags", reflect.TypeOf((*MockStorage)(nil).FindURLTags), ctx, userID, namespace, alias)
+}
+
+// FindURLsByTag mocks base method.
+func (m *MockStorage) FindURLsByTag(ctx context.Context, userID int, name string) ([]*entity.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindURLsByTag", ctx, userID, name)
+	ret0, _ := ret[0].([]*entity.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindURLsByTag indicates an expected call of FindURLsByTag.
+func (mr *MockStorageMockRecorder) FindURLsByTag(ctx, userID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindURLsByTag", reflect.TypeOf((*MockStorage)(nil).FindURLsByTag), ctx, userID, name)
+}
+
+// RemoveTag mocks base method.
+func (m *MockStorage) RemoveTag(ctx context.Context, userID int, namespace, alias, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTag", ctx, userID, namespace, alias, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTag indicates an expected call of RemoveTag.
+func (mr *MockStorageMockRecorder) RemoveTag(ctx, userID, namespace, alias, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTag", reflect.TypeOf((*MockStorage)(nil).RemoveTag), ctx, userID, namespace, alias, name)
+}
+
+// SaveTag mocks base method.
+func (m *MockStorage) SaveTag(ctx context.Context, tag *entity0.Tag) (*entity0.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTag", ctx, tag)
+	ret0, _ := ret[0].(*entity0.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveTag indicates an expected call of SaveTag.
+func (mr *MockStorageMockRecorder) SaveTag(ctx, tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTag", reflect.TypeOf((*MockStorage)(nil).SaveTag), ctx, tag)
+}
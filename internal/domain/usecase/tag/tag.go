@@ -0,0 +1,279 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . Storage
+
+/*
+Package usecase implements the application's business logic layer.
+
+It contains:
+- Management of user tags
+- Assignment of tags to short URLs and filtering URLs by tag
+- Per user and per URL tag limits
+- Error handling specific to tag operations
+*/
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	tagEntity "github.com/gururuby/shortener/internal/domain/entity/tag"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/tag/errors"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+)
+
+// Tag limits.
+const (
+	MaxTagsPerUser   = 100 // Maximum number of tags a user can create
+	MaxTagsPerURL    = 20  // Maximum number of tags assigned to a single short URL
+	MaxTagNameLength = 64  // Maximum tag name length in characters
+)
+
+// Storage defines the interface for storage operations required by tag use cases.
+type Storage interface {
+	// SaveTag stores a new tag of a user
+	SaveTag(ctx context.Context, tag *tagEntity.Tag) (*tagEntity.Tag, error)
+	// FindTagsByUser retrieves all tags of a user
+	FindTagsByUser(ctx context.Context, userID int) ([]*tagEntity.Tag, error)
+	// FindURLTags retrieves tags assigned to a short URL of a user
+	FindURLTags(ctx context.Context, userID int, namespace, alias string) ([]*tagEntity.Tag, error)
+	// AssignTag associates a tag of a user with a short URL of the same user
+	AssignTag(ctx context.Context, userID int, namespace, alias, name string) error
+	// RemoveTag removes association of a tag of a user with a short URL
+	RemoveTag(ctx context.Context, userID int, namespace, alias, name string) error
+	// FindURLsByTag retrieves short URLs of a user having the tag assigned
+	FindURLsByTag(ctx context.Context, userID int, name string) ([]*shortURLEntity.ShortURL, error)
+}
+
+// TagUseCase implements tag use cases.
+type TagUseCase struct {
+	storage Storage // Storage layer interface
+	baseURL string  // Base URL for shortened links
+}
+
+// TaggedURL represents a shortened URL having the requested tag.
+type TaggedURL struct {
+	ShortURL    string `json:"short_url"`    // The shortened URL
+	OriginalURL string `json:"original_url"` // The original long URL
+}
+
+// TaggedURLs represents all user's shortened URLs having the requested tag.
+type TaggedURLs struct {
+	Items []*TaggedURL `json:"items"` // Tagged URLs ordered by creation
+}
+
+// NewTagUseCase creates a new instance of TagUseCase.
+// Parameters:
+// - storage: Implementation of the Storage interface
+// - baseURL: Base URL for shortened links
+// Returns:
+// - *TagUseCase: Initialized tag use case instance
+func NewTagUseCase(storage Storage, baseURL string) *TagUseCase {
+	return &TagUseCase{storage: storage, baseURL: baseURL}
+}
+
+// CreateTag creates a new tag of a user.
+// Leading and trailing spaces of the name are trimmed.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: Owner of the tag
+// - name: Tag name
+// Returns:
+// - *tagEntity.Tag: Created tag
+// - error: ErrTagInvalidName, ErrTagUserLimitExceeded, ErrTagAlreadyExist or ErrTagStorageNotWorking
+func (uc *TagUseCase) CreateTag(ctx context.Context, user *userEntity.User, name string) (*tagEntity.Tag, error) {
+	var (
+		tags []*tagEntity.Tag
+		tag  *tagEntity.Tag
+		err  error
+	)
+
+	if name, err = normalizeName(name); err != nil {
+		return nil, err
+	}
+
+	if tags, err = uc.storage.FindTagsByUser(ctx, user.ID); err != nil {
+		return nil, ucErrors.ErrTagStorageNotWorking
+	}
+
+	if findTag(tags, name) != nil {
+		return nil, ucErrors.ErrTagAlreadyExist
+	}
+
+	if len(tags) >= MaxTagsPerUser {
+		return nil, ucErrors.ErrTagUserLimitExceeded
+	}
+
+	if tag, err = uc.storage.SaveTag(ctx, &tagEntity.Tag{UserID: user.ID, Name: name}); err != nil {
+		if errors.Is(err, dbErrors.ErrDBIsNotUnique) {
+			return nil, ucErrors.ErrTagAlreadyExist
+		}
+		return nil, ucErrors.ErrTagStorageNotWorking
+	}
+
+	return tag, nil
+}
+
+// GetTags retrieves all tags of a user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: Owner of the tags
+// Returns:
+// - []*tagEntity.Tag: User's tags ordered by name
+// - error: ErrTagStorageNotWorking if tags cannot be read
+func (uc *TagUseCase) GetTags(ctx context.Context, user *userEntity.User) ([]*tagEntity.Tag, error) {
+	tags, err := uc.storage.FindTagsByUser(ctx, user.ID)
+	if err != nil {
+		return nil, ucErrors.ErrTagStorageNotWorking
+	}
+	return tags, nil
+}
+
+// AssignTag associates an existing tag of a user with a short URL of the same user.
+// Assigning already assigned tag succeeds without changes.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: Owner of the URL and the tag, its namespace is the namespace of the URL
+// - alias: Short URL identifier
+// - name: Tag name
+// Returns:
+// - error: ErrTagURLNotFound, ErrTagNotFound, ErrTagURLLimitExceeded or ErrTagStorageNotWorking
+func (uc *TagUseCase) AssignTag(ctx context.Context, user *userEntity.User, alias, name string) error {
+	var (
+		tags []*tagEntity.Tag
+		err  error
+	)
+
+	name = strings.TrimSpace(name)
+
+	if tags, err = uc.findURLTags(ctx, user, alias); err != nil {
+		return err
+	}
+
+	if findTag(tags, name) != nil {
+		return nil
+	}
+
+	if len(tags) >= MaxTagsPerURL {
+		return ucErrors.ErrTagURLLimitExceeded
+	}
+
+	if err = uc.storage.AssignTag(ctx, user.ID, user.Namespace, alias, name); err != nil {
+		// URL existence is already checked, so the tag is missing
+		if errors.Is(err, dbErrors.ErrDBRecordNotFound) {
+			return ucErrors.ErrTagNotFound
+		}
+		return ucErrors.ErrTagStorageNotWorking
+	}
+
+	return nil
+}
+
+// RemoveTag removes association of a tag with a short URL of a user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: Owner of the URL and the tag, its namespace is the namespace of the URL
+// - alias: Short URL identifier
+// - name: Tag name
+// Returns:
+// - error: ErrTagURLNotFound, ErrTagNotFound if tag is not assigned to the URL, or ErrTagStorageNotWorking
+func (uc *TagUseCase) RemoveTag(ctx context.Context, user *userEntity.User, alias, name string) error {
+	var (
+		tags []*tagEntity.Tag
+		err  error
+	)
+
+	name = strings.TrimSpace(name)
+
+	if tags, err = uc.findURLTags(ctx, user, alias); err != nil {
+		return err
+	}
+
+	if findTag(tags, name) == nil {
+		return ucErrors.ErrTagNotFound
+	}
+
+	if err = uc.storage.RemoveTag(ctx, user.ID, user.Namespace, alias, name); err != nil {
+		return ucErrors.ErrTagStorageNotWorking
+	}
+
+	return nil
+}
+
+// GetURLsByTag retrieves all shortened URLs of a user having the tag assigned.
+// Unknown tag results in an empty list.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: Owner of the URLs
+// - name: Tag name
+// Returns:
+// - *TaggedURLs: Tagged URLs with full shortened URLs
+// - error: ErrTagStorageNotWorking if URLs cannot be read
+func (uc *TagUseCase) GetURLsByTag(ctx context.Context, user *userEntity.User, name string) (*TaggedURLs, error) {
+	shortURLs, err := uc.storage.FindURLsByTag(ctx, user.ID, strings.TrimSpace(name))
+	if err != nil {
+		return nil, ucErrors.ErrTagStorageNotWorking
+	}
+
+	result := &TaggedURLs{Items: make([]*TaggedURL, 0, len(shortURLs))}
+	for _, shortURL := range shortURLs {
+		result.Items = append(result.Items, &TaggedURL{
+			ShortURL:    uc.baseURL + "/" + shortURL.Path(),
+			OriginalURL: shortURL.SourceURL,
+		})
+	}
+
+	return result, nil
+}
+
+// findURLTags retrieves tags assigned to a short URL of a user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: Owner of the URL, its namespace is the namespace of the URL
+// - alias: Short URL identifier
+// Returns:
+// - []*tagEntity.Tag: Assigned tags
+// - error: ErrTagURLNotFound or ErrTagStorageNotWorking
+func (uc *TagUseCase) findURLTags(ctx context.Context, user *userEntity.User, alias string) ([]*tagEntity.Tag, error) {
+	tags, err := uc.storage.FindURLTags(ctx, user.ID, user.Namespace, alias)
+	if err != nil {
+		if errors.Is(err, dbErrors.ErrDBRecordNotFound) {
+			return nil, ucErrors.ErrTagURLNotFound
+		}
+		return nil, ucErrors.ErrTagStorageNotWorking
+	}
+	return tags, nil
+}
+
+// normalizeName trims spaces around a tag name and validates it.
+// Parameters:
+// - name: Tag name passed by user
+// Returns:
+// - string: Trimmed name
+// - error: ErrTagInvalidName if name is empty, too long or contains "/"
+func normalizeName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" || utf8.RuneCountInString(name) > MaxTagNameLength || strings.Contains(name, "/") {
+		return "", ucErrors.ErrTagInvalidName
+	}
+
+	return name, nil
+}
+
+// findTag looks up a tag by name.
+// Parameters:
+// - tags: Tags to search in
+// - name: Tag name
+// Returns:
+// - *tagEntity.Tag: Found tag or nil
+func findTag(tags []*tagEntity.Tag, name string) *tagEntity.Tag {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return tag
+		}
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	tagEntity "github.com/gururuby/shortener/internal/domain/entity/tag"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/tag/errors"
+	"github.com/gururuby/shortener/internal/domain/usecase/tag/mocks"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const baseURL = "http://localhost:8080"
+
+// tagsN builds n tags of the user with distinct names.
+func tagsN(n int) []*tagEntity.Tag {
+	tags := make([]*tagEntity.Tag, 0, n)
+	for i := 1; i <= n; i++ {
+		tags = append(tags, &tagEntity.Tag{ID: i, UserID: 1, Name: fmt.Sprintf("tag%d", i)})
+	}
+	return tags
+}
+
+func Test_CreateTag(t *testing.T) {
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1}
+	created := &tagEntity.Tag{ID: 1, UserID: 1, Name: "work"}
+
+	tests := []struct {
+		setup   func(storage *mocks.MockStorage)
+		want    *tagEntity.Tag
+		wantErr error
+		name    string
+		tagName string
+	}{
+		{
+			name:    "when tag is created",
+			tagName: "  work ",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().FindTagsByUser(ctx, 1).Return(tagsN(2), nil)
+				storage.EXPECT().SaveTag(ctx, &tagEntity.Tag{UserID: 1, Name: "work"}).Return(created, nil)
+			},
+			want: created,
+		},
+		{
+			name:    "when name is empty",
+			tagName: "   ",
+			setup:   func(_ *mocks.MockStorage) {},
+			wantErr: ucErrors.ErrTagInvalidName,
+		},
+		{
+			name:    "when name is too long",
+			tagName: strings.Repeat("я", MaxTagNameLength+1),
+			setup:   func(_ *mocks.MockStorage) {},
+			wantErr: ucErrors.ErrTagInvalidName,
+		},
+		{
+			name:    "when name contains slash",
+			tagName: "work/home",
+			setup:   func(_ *mocks.MockStorage) {},
+			wantErr: ucErrors.ErrTagInvalidName,
+		},
+		{
+			name:    "when user already has the tag",
+			tagName: "tag1",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().FindTagsByUser(ctx, 1).Return(tagsN(2), nil)
+			},
+			wantErr: ucErrors.ErrTagAlreadyExist,
+		},
+		{
+			name:    "when user has maximum number of tags",
+			tagName: "work",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().FindTagsByUser(ctx, 1).Return(tagsN(MaxTagsPerUser), nil)
+			},
+			wantErr: ucErrors.ErrTagUserLimitExceeded,
+		},
+		{
+			name:    "when tag is created concurrently",
+			tagName: "work",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().FindTagsByUser(ctx, 1).Return(nil, nil)
+				storage.EXPECT().SaveTag(ctx, gomock.Any()).Return(nil, dbErrors.ErrDBIsNotUnique)
+			},
+			wantErr: ucErrors.ErrTagAlreadyExist,
+		},
+		{
+			name:    "when tags cannot be read",
+			tagName: "work",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().FindTagsByUser(ctx, 1).Return(nil, dbErrors.ErrDBQuery)
+			},
+			wantErr: ucErrors.ErrTagStorageNotWorking,
+		},
+		{
+			name:    "when tag cannot be saved",
+			tagName: "work",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().FindTagsByUser(ctx, 1).Return(nil, nil)
+				storage.EXPECT().SaveTag(ctx, gomock.Any()).Return(nil, dbErrors.ErrDBQuery)
+			},
+			wantErr: ucErrors.ErrTagStorageNotWorking,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockStorage(ctrl)
+			tt.setup(storage)
+
+			tag, err := NewTagUseCase(storage, baseURL).CreateTag(ctx, user, tt.tagName)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, tag)
+		})
+	}
+}
+
+func Test_GetTags(t *testing.T) {
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1}
+
+	t.Run("when tags are found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		storage := mocks.NewMockStorage(ctrl)
+		storage.EXPECT().FindTagsByUser(ctx, 1).Return(tagsN(2), nil)
+
+		tags, err := NewTagUseCase(storage, baseURL).GetTags(ctx, user)
+		require.NoError(t, err)
+		require.Equal(t, tagsN(2), tags)
+	})
+
+	t.Run("when storage fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		storage := mocks.NewMockStorage(ctrl)
+		storage.EXPECT().FindTagsByUser(ctx, 1).Return(nil, dbErrors.ErrDBQuery)
+
+		_, err := NewTagUseCase(storage, baseURL).GetTags(ctx, user)
+		require.ErrorIs(t, err, ucErrors.ErrTagStorageNotWorking)
+	})
+}
+
+func Test_AssignTag(t *testing.T) {
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1, Namespace: "team"}
+
+	tests := []struct {
+		setup   func(storage *mocks.MockStorage)
+		wantErr error
+		name    string
+		tagName string
+	}{
+		{
+			name:    "when tag is assigned",
+			tagName: "work",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().FindURLTags(ctx, 1, "team", "abc").Return(tagsN(2), nil)
+				storage.EXPECT().AssignTag(ctx, 1, "team", "abc", "work").Return(nil)
+			},
+		},
+		{
+			name:    "when tag is already assigned",
+			tagName: "tag2",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().FindURLTags(ctx, 1, "team", "abc").Return(tagsN(MaxTagsPerURL), nil)
+			},
+		},
+		{
+			name:    "when URL has maximum number of tags",
+			tagName: "work",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().FindURLTags(ctx, 1, "team", "abc").Return(tagsN(MaxTagsPerURL), nil)
+			},
+			wantErr: ucErrors.ErrTagURLLimitExceeded,
+		},
+		{
+			name:    "when URL is not found",
+			tagName: "work",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().FindURLTags(ctx, 1, "team", "abc").Return(nil, dbErrors.ErrDBRecordNotFound)
+			},
+			wantErr: ucErrors.ErrTagURLNotFound,
+		},
+		{
+			name:    "when tag is not found",
+			tagName: "work",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().FindURLTags(ctx, 1, "team", "abc").Return(nil, nil)
+				storage.EXPECT().AssignTag(ctx, 1, "team", "abc", "work").Return(dbErrors.ErrDBRecordNotFound)
+			},
+			wantErr: ucErrors.ErrTagNotFound,
+		},
+		{
+			name:    "when storage fails",
+			tagName: "work",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().FindURLTags(ctx, 1, "team", "abc").Return(nil, nil)
+				storage.EXPECT().AssignTag(ctx, 1, "team", "abc", "work").Return(dbErrors.ErrDBQuery)
+			},
+			wantErr: ucErrors.ErrTagStorageNotWorking,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockStorage(ctrl)
+			tt.setup(storage)
+
+			err := NewTagUseCase(storage, baseURL).AssignTag(ctx, user, "abc", tt.tagName)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_RemoveTag(t *testing.T) {
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1, Namespace: "team"}
+
+	tests := []struct {
+		setup   func(storage *mocks.MockStorage)
+		wantErr error
+		name    string
+		tagName string
+	}{
+		{
+			name:    "when tag is removed",
+			tagName: "tag1",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().FindURLTags(ctx, 1, "team", "abc").Return(tagsN(2), nil)
+				storage.EXPECT().RemoveTag(ctx, 1, "team", "abc", "tag1").Return(nil)
+			},
+		},
+		{
+			name:    "when tag is not assigned",
+			tagName: "work",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().FindURLTags(ctx, 1, "team", "abc").Return(tagsN(2), nil)
+			},
+			wantErr: ucErrors.ErrTagNotFound,
+		},
+		{
+			name:    "when URL is not found",
+			tagName: "tag1",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().FindURLTags(ctx, 1, "team", "abc").Return(nil, dbErrors.ErrDBRecordNotFound)
+			},
+			wantErr: ucErrors.ErrTagURLNotFound,
+		},
+		{
+			name:    "when storage fails",
+			tagName: "tag1",
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().FindURLTags(ctx, 1, "team", "abc").Return(tagsN(1), nil)
+				storage.EXPECT().RemoveTag(ctx, 1, "team", "abc", "tag1").Return(dbErrors.ErrDBQuery)
+			},
+			wantErr: ucErrors.ErrTagStorageNotWorking,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockStorage(ctrl)
+			tt.setup(storage)
+
+			err := NewTagUseCase(storage, baseURL).RemoveTag(ctx, user, "abc", tt.tagName)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_GetURLsByTag(t *testing.T) {
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1}
+
+	t.Run("when URLs are found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		storage := mocks.NewMockStorage(ctrl)
+		storage.EXPECT().FindURLsByTag(ctx, 1, "work").Return([]*shortURLEntity.ShortURL{
+			{ID: 1, Alias: "abc", SourceURL: "https://ya.ru"},
+			{ID: 2, Alias: "def", SourceURL: "https://ok.ru"},
+		}, nil)
+
+		urls, err := NewTagUseCase(storage, baseURL).GetURLsByTag(ctx, user, "work")
+		require.NoError(t, err)
+		require.Equal(t, &TaggedURLs{Items: []*TaggedURL{
+			{ShortURL: baseURL + "/abc", OriginalURL: "https://ya.ru"},
+			{ShortURL: baseURL + "/def", OriginalURL: "https://ok.ru"},
+		}}, urls)
+	})
+
+	t.Run("when no URLs have the tag", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		storage := mocks.NewMockStorage(ctrl)
+		storage.EXPECT().FindURLsByTag(ctx, 1, "work").Return(nil, nil)
+
+		urls, err := NewTagUseCase(storage, baseURL).GetURLsByTag(ctx, user, "work")
+		require.NoError(t, err)
+		require.Empty(t, urls.Items)
+	})
+
+	t.Run("when storage fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		storage := mocks.NewMockStorage(ctrl)
+		storage.EXPECT().FindURLsByTag(ctx, 1, "work").Return(nil, dbErrors.ErrDBQuery)
+
+		_, err := NewTagUseCase(storage, baseURL).GetURLsByTag(ctx, user, "work")
+		require.ErrorIs(t, err, ucErrors.ErrTagStorageNotWorking)
+	})
+}
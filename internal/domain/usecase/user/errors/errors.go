@@ -64,4 +64,109 @@ var (
 	// - Should trigger high-priority alerts
 	// - May require manual intervention
 	ErrUserStorageNotWorking = errors.New("user storage is not working")
+
+	// ErrUserInvalidCursor indicates a pagination cursor could not be decoded.
+	//
+	// Typical cases:
+	// - Cursor was modified or built by the client
+	// - Cursor is not valid base64
+	//
+	// Clients should only pass cursors received in `next_cursor`.
+	ErrUserInvalidCursor = errors.New("invalid cursor")
+
+	// ErrUserCannotRevokeToken indicates failure storing token revocation.
+	//
+	// Common root causes:
+	// - Revocation store (e.g. Redis) is unavailable
+	//
+	// Security considerations:
+	// - Token stays valid until expiration, client should retry
+	ErrUserCannotRevokeToken = errors.New("cannot revoke token")
+
+	// ErrUserURLNotFound indicates no short URL exists with the requested alias.
+	//
+	// Typical cases:
+	// - Alias was mistyped
+	// - URL was never created
+	ErrUserURLNotFound = errors.New("short URL is not found")
+
+	// ErrUserURLForbidden indicates the short URL belongs to another user.
+	//
+	// Security considerations:
+	// - Users may change only their own URLs
+	ErrUserURLForbidden = errors.New("short URL belongs to another user")
+
+	// ErrUserURLNotDeleted indicates restoring a short URL which is not deleted.
+	//
+	// Clients may treat it as a no-op, the URL is already active.
+	ErrUserURLNotDeleted = errors.New("short URL is not deleted")
+
+	// ErrUserURLDeleted indicates changing a short URL which is deleted.
+	//
+	// Handling recommendations:
+	// - Restore the short URL before changing it
+	ErrUserURLDeleted = errors.New("short URL is deleted")
+
+	// ErrUserURLAliasTaken indicates restoring an archived short URL whose alias
+	// was given to another short URL after archiving.
+	//
+	// Handling recommendations:
+	// - The archived URL stays in the archive, shorten its original URL again
+	ErrUserURLAliasTaken = errors.New("alias of short URL is taken by another short URL")
+
+	// ErrUserInvalidURL indicates the new original URL of a short URL is not a valid URL.
+	//
+	// Typical cases:
+	// - Scheme or host is missing
+	// - URL is malformed
+	ErrUserInvalidURL = errors.New("invalid URL, please specify valid URL")
+
+	// ErrUserInvalidAPIKey indicates the passed API key is unknown or revoked.
+	//
+	// Typical cases:
+	// - Key was revoked by its owner
+	// - Key was mistyped or truncated
+	//
+	// Handling recommendations:
+	// - Return HTTP 401 (Unauthorized) in web handlers
+	// - Do not register a new user, API key clients are not browsers
+	ErrUserInvalidAPIKey = errors.New("invalid API key")
+
+	// ErrUserAPIKeyNotFound indicates revoking an API key which the user does not have.
+	//
+	// Typical cases:
+	// - Key is already revoked
+	// - Key belongs to another user
+	ErrUserAPIKeyNotFound = errors.New("API key is not found")
+
+	// ErrUserCannotCreateAPIKey indicates failure generating or storing a new API key.
+	//
+	// Common root causes:
+	// - Random source is not available
+	// - Storage is not working
+	ErrUserCannotCreateAPIKey = errors.New("cannot create API key")
+
+	// ErrUserDeactivated indicates authentication of a user deactivated by an administrator.
+	//
+	// Typical cases:
+	// - Account is suspended for abuse
+	//
+	// Handling recommendations:
+	// - Return HTTP 403 (Forbidden) in web handlers
+	// - Do not register a new user instead, short URLs of the user keep redirecting
+	ErrUserDeactivated = errors.New("user is deactivated")
+
+	// ErrUserInvalidCustomDomain indicates a custom domain which cannot be a base URL of short URLs.
+	//
+	// Typical cases:
+	// - Scheme is not HTTPS
+	// - Path, query or fragment is set
+	// - Domain is the configured base URL
+	ErrUserInvalidCustomDomain = errors.New("custom domain must be an HTTPS URL without path, other than the base URL")
+
+	// ErrUserCustomDomainTaken indicates a custom domain claimed by another user.
+	//
+	// Handling recommendations:
+	// - Return HTTP 409 (Conflict) in web handlers
+	ErrUserCustomDomainTaken = errors.New("custom domain is claimed by another user")
 )
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	analyticsEntity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	namespaceEntity "github.com/gururuby/shortener/internal/domain/entity/namespace"
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+)
+
+// UserExport represents all data stored about a user, see ExportUserData.
+type UserExport struct {
+	Profile *ExportedProfile   // Profile of the user
+	URLs    []*ExportedUserURL // All short URLs of the user, including deleted ones
+	Clicks  []*ExportedClick   // Clicks of all short URLs of the user
+}
+
+// ExportedProfile represents the profile of a user in export of user data.
+type ExportedProfile struct {
+	RegisteredAt *time.Time `json:"registered_at,omitempty"` // Registration time, omitted if unknown
+	ID           int        `json:"id"`                      // User ID
+	CustomDomain string     `json:"custom_domain,omitempty"` // Base URL of short URLs of the user, omitted if not set
+	IsActive     bool       `json:"is_active"`               // Whether the user may authenticate
+}
+
+// ExportedUserURL represents a short URL with its metadata in export of user data.
+type ExportedUserURL struct {
+	CreatedAt   time.Time `json:"created_at"`       // Creation time of the short URL
+	Alias       string    `json:"alias"`            // Short URL identifier
+	Namespace   string    `json:"namespace"`        // Namespace scoping the alias
+	ShortURL    string    `json:"short_url"`        // The shortened URL
+	OriginalURL string    `json:"original_url"`     // The original long URL
+	Region      string    `json:"region,omitempty"` // Region where the short URL was created, omitted if unknown
+	Clicks      int64     `json:"clicks"`           // Number of recorded clicks
+	IsDeleted   bool      `json:"is_deleted"`       // Whether the short URL is deleted
+}
+
+// ExportedClick represents a click of a short URL in export of user data.
+type ExportedClick struct {
+	ClickedAt time.Time `json:"clicked_at"`        // Time of the click
+	Alias     string    `json:"alias"`             // Clicked short URL alias
+	IP        string    `json:"ip,omitempty"`      // Client IP with zeroed host part, omitted if unknown
+	Country   string    `json:"country,omitempty"` // ISO 3166-1 alpha-2 country code of the client
+	City      string    `json:"city,omitempty"`    // City of the client
+}
+
+// ExportUserData assembles all data stored about a user for export on request of the user.
+// Clicks contain client IPs anonymized by analyticsEntity.AnonymizeIP only.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: The user whose data to export
+// Returns:
+// - *UserExport: Profile, short URLs and clicks of the user
+// - error: ErrUserNotFound if user doesn't exist, ErrUserStorageNotWorking if data cannot be fetched
+func (u *UserUseCase) ExportUserData(ctx context.Context, user *userEntity.User) (*UserExport, error) {
+	var (
+		shortURLs []*shortURLEntity.ShortURL
+		clicks    []*analyticsEntity.Click
+		cursor    int
+		err       error
+	)
+
+	stored, err := u.FindUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if clicks, err = u.storage.FindClicks(ctx, user.ID); err != nil {
+		return nil, ucErrors.ErrUserStorageNotWorking
+	}
+
+	export := &UserExport{
+		Profile: &ExportedProfile{ID: stored.ID, IsActive: stored.IsActive, CustomDomain: stored.CustomDomain},
+		URLs:    []*ExportedUserURL{},
+		Clicks:  make([]*ExportedClick, 0, len(clicks)),
+	}
+	if !stored.CreatedAt.IsZero() {
+		registeredAt := stored.CreatedAt.UTC()
+		export.Profile.RegisteredAt = &registeredAt
+	}
+
+	clicksByAlias := make(map[string]int64)
+	for _, click := range clicks {
+		clicksByAlias[click.Alias]++
+		export.Clicks = append(export.Clicks, &ExportedClick{
+			ClickedAt: click.ClickedAt.UTC(),
+			Alias:     click.Alias,
+			IP:        analyticsEntity.AnonymizeIP(click.AnonymizedIP),
+			Country:   click.Location.Country,
+			City:      click.Location.City,
+		})
+	}
+
+	for {
+		if shortURLs, cursor, err = u.storage.FindURLsCursor(ctx, user.ID, cursor, exportBatch); err != nil {
+			return nil, ucErrors.ErrUserStorageNotWorking
+		}
+
+		for _, shortURL := range shortURLs {
+			export.URLs = append(export.URLs, &ExportedUserURL{
+				CreatedAt:   shortURL.CreatedAt.UTC(),
+				Alias:       shortURL.Alias,
+				Namespace:   namespaceEntity.OrDefault(shortURL.Namespace),
+				ShortURL:    stored.BaseURL(u.baseURL) + "/" + shortURL.Path(),
+				OriginalURL: shortURL.SourceURL,
+				Region:      shortURL.CreatedInRegion,
+				Clicks:      clicksByAlias[shortURL.Alias],
+				IsDeleted:   shortURL.IsDeleted,
+			})
+		}
+
+		if cursor == 0 {
+			return export, nil
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	analyticsEntity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	"github.com/gururuby/shortener/internal/domain/usecase/user/mocks"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_ExportUserData_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockUserStorage(ctrl)
+	ctx := context.Background()
+	registeredAt := time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC)
+	clickedAt := time.Date(2025, 6, 11, 10, 0, 0, 0, time.UTC)
+
+	storage.EXPECT().FindUser(ctx, 1).Return(&userEntity.User{ID: 1, IsActive: true, CreatedAt: registeredAt}, nil)
+	storage.EXPECT().FindClicks(ctx, 1).Return([]*analyticsEntity.Click{
+		{Alias: "first", ClickedAt: clickedAt, AnonymizedIP: "192.168.1.0", Location: analyticsEntity.GeoLocation{Country: "GB", City: "London"}},
+		{Alias: "first", ClickedAt: clickedAt.Add(time.Hour), AnonymizedIP: "2001:db8:85a3:8d3:1319:8a2e:370:7348"},
+	}, nil)
+	gomock.InOrder(
+		storage.EXPECT().FindURLsCursor(ctx, 1, 0, exportBatch).
+			Return([]*shortURLEntity.ShortURL{{ID: 7, Alias: "first", SourceURL: "https://ya.ru", CreatedAt: createdAt, CreatedInRegion: "eu-west-1"}}, 7, nil),
+		storage.EXPECT().FindURLsCursor(ctx, 1, 7, exportBatch).
+			Return([]*shortURLEntity.ShortURL{{ID: 8, Alias: "second", Namespace: "team", SourceURL: "https://google.com", IsDeleted: true}}, 0, nil),
+	)
+
+	export, err := NewUserUseCase(nil, storage, nil, "http://localhost:8080").ExportUserData(ctx, &userEntity.User{ID: 1})
+	require.NoError(t, err)
+	require.Equal(t, &UserExport{
+		Profile: &ExportedProfile{ID: 1, IsActive: true, RegisteredAt: &registeredAt},
+		URLs: []*ExportedUserURL{
+			{Alias: "first", Namespace: "default", ShortURL: "http://localhost:8080/first", OriginalURL: "https://ya.ru", CreatedAt: createdAt, Region: "eu-west-1", Clicks: 2},
+			{Alias: "second", Namespace: "team", ShortURL: "http://localhost:8080/team/second", OriginalURL: "https://google.com", CreatedAt: time.Time{}.UTC(), IsDeleted: true},
+		},
+		Clicks: []*ExportedClick{
+			{Alias: "first", ClickedAt: clickedAt, IP: "192.168.1.0", Country: "GB", City: "London"},
+			{Alias: "first", ClickedAt: clickedAt.Add(time.Hour), IP: "2001:db8:85a3::"},
+		},
+	}, export)
+}
+
+func Test_ExportUserData_Empty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockUserStorage(ctrl)
+	ctx := context.Background()
+
+	storage.EXPECT().FindUser(ctx, 1).Return(&userEntity.User{ID: 1, IsActive: true}, nil)
+	storage.EXPECT().FindClicks(ctx, 1).Return(nil, nil)
+	storage.EXPECT().FindURLsCursor(ctx, 1, 0, exportBatch).Return(nil, 0, nil)
+
+	export, err := NewUserUseCase(nil, storage, nil, "http://localhost:8080").ExportUserData(ctx, &userEntity.User{ID: 1})
+	require.NoError(t, err)
+	require.Equal(t, &UserExport{
+		Profile: &ExportedProfile{ID: 1, IsActive: true},
+		URLs:    []*ExportedUserURL{},
+		Clicks:  []*ExportedClick{},
+	}, export)
+}
+
+func Test_ExportUserData_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		setup func(storage *mocks.MockUserStorage)
+		err   error
+		name  string
+	}{
+		{
+			name: "when user is not found",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindUser(ctx, 1).Return(nil, dbErrors.ErrDBRecordNotFound)
+			},
+			err: ucErrors.ErrUserNotFound,
+		},
+		{
+			name: "when clicks cannot be fetched",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindUser(ctx, 1).Return(&userEntity.User{ID: 1}, nil)
+				storage.EXPECT().FindClicks(ctx, 1).Return(nil, dbErrors.ErrDBQuery)
+			},
+			err: ucErrors.ErrUserStorageNotWorking,
+		},
+		{
+			name: "when URLs cannot be fetched",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindUser(ctx, 1).Return(&userEntity.User{ID: 1}, nil)
+				storage.EXPECT().FindClicks(ctx, 1).Return(nil, nil)
+				storage.EXPECT().FindURLsCursor(ctx, 1, 0, exportBatch).Return(nil, 0, dbErrors.ErrDBQuery)
+			},
+			err: ucErrors.ErrUserStorageNotWorking,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockUserStorage(ctrl)
+			tt.setup(storage)
+
+			_, err := NewUserUseCase(nil, storage, nil, "http://localhost:8080").ExportUserData(ctx, &userEntity.User{ID: 1})
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/gururuby/shortener/internal/domain/usecase/user (interfaces: UserStorage,Authenticator)
+// Source: github.com/gururuby/shortener/internal/domain/usecase/user (interfaces: UserStorage,Authenticator,WebhookNotifier)
 //
 // Generated by this command:
 //
-//	mockgen -destination=./mocks/mock.go -package=mocks . UserStorage,Authenticator
+//	mockgen -destination=./mocks/mock.go -package=mocks . UserStorage,Authenticator,WebhookNotifier
 //
 
 // Package mocks is a generated GoMock package.
@@ -13,16 +13,17 @@ import (
 	context "context"
 	reflect "reflect"
 
-	entity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
-	entity0 "github.com/gururuby/shortener/internal/domain/entity/user"
+	entity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	entity0 "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	entity1 "github.com/gururuby/shortener/internal/domain/entity/user"
 	gomock "go.uber.org/mock/gomock"
 )
 
 // MockUserStorage is a mock of UserStorage interface.
 type MockUserStorage struct {
-	isgomock struct{}
 	ctrl     *gomock.Controller
 	recorder *MockUserStorageMockRecorder
+	isgomock struct{}
 }
 
 // MockUserStorageMockRecorder is the mock recorder for MockUserStorage.
@@ -42,11 +43,71 @@ func (m *MockUserStorage) EXPECT() *MockUserStorageMockRecorder {
 	return m.recorder
 }
 
+// FindArchivedURLs mocks base method.
+func (m *MockUserStorage) FindArchivedURLs(ctx context.Context, userID, limit, offset int) ([]*entity0.ArchivedURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindArchivedURLs", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]*entity0.ArchivedURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindArchivedURLs indicates an expected call of FindArchivedURLs.
+func (mr *MockUserStorageMockRecorder) FindArchivedURLs(ctx, userID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindArchivedURLs", reflect.TypeOf((*MockUserStorage)(nil).FindArchivedURLs), ctx, userID, limit, offset)
+}
+
+// FindClicks mocks base method.
+func (m *MockUserStorage) FindClicks(ctx context.Context, userID int) ([]*entity.Click, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindClicks", ctx, userID)
+	ret0, _ := ret[0].([]*entity.Click)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindClicks indicates an expected call of FindClicks.
+func (mr *MockUserStorageMockRecorder) FindClicks(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindClicks", reflect.TypeOf((*MockUserStorage)(nil).FindClicks), ctx, userID)
+}
+
+// FindURL mocks base method.
+func (m *MockUserStorage) FindURL(ctx context.Context, namespace, alias string) (*entity0.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindURL", ctx, namespace, alias)
+	ret0, _ := ret[0].(*entity0.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindURL indicates an expected call of FindURL.
+func (mr *MockUserStorageMockRecorder) FindURL(ctx, namespace, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindURL", reflect.TypeOf((*MockUserStorage)(nil).FindURL), ctx, namespace, alias)
+}
+
+// FindURLHistory mocks base method.
+func (m *MockUserStorage) FindURLHistory(ctx context.Context, namespace, alias string) ([]*entity0.HistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindURLHistory", ctx, namespace, alias)
+	ret0, _ := ret[0].([]*entity0.HistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindURLHistory indicates an expected call of FindURLHistory.
+func (mr *MockUserStorageMockRecorder) FindURLHistory(ctx, namespace, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindURLHistory", reflect.TypeOf((*MockUserStorage)(nil).FindURLHistory), ctx, namespace, alias)
+}
+
 // FindURLs mocks base method.
-func (m *MockUserStorage) FindURLs(ctx context.Context, userID int) ([]*entity.ShortURL, error) {
+func (m *MockUserStorage) FindURLs(ctx context.Context, userID int) ([]*entity0.ShortURL, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindURLs", ctx, userID)
-	ret0, _ := ret[0].([]*entity.ShortURL)
+	ret0, _ := ret[0].([]*entity0.ShortURL)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -57,11 +118,43 @@ func (mr *MockUserStorageMockRecorder) FindURLs(ctx, userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindURLs", reflect.TypeOf((*MockUserStorage)(nil).FindURLs), ctx, userID)
 }
 
+// FindURLsCursor mocks base method.
+func (m *MockUserStorage) FindURLsCursor(ctx context.Context, userID, afterID, limit int) ([]*entity0.ShortURL, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindURLsCursor", ctx, userID, afterID, limit)
+	ret0, _ := ret[0].([]*entity0.ShortURL)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindURLsCursor indicates an expected call of FindURLsCursor.
+func (mr *MockUserStorageMockRecorder) FindURLsCursor(ctx, userID, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindURLsCursor", reflect.TypeOf((*MockUserStorage)(nil).FindURLsCursor), ctx, userID, afterID, limit)
+}
+
+// FindURLsPaginated mocks base method.
+func (m *MockUserStorage) FindURLsPaginated(ctx context.Context, userID, offset, limit int) ([]*entity0.ShortURL, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindURLsPaginated", ctx, userID, offset, limit)
+	ret0, _ := ret[0].([]*entity0.ShortURL)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindURLsPaginated indicates an expected call of FindURLsPaginated.
+func (mr *MockUserStorageMockRecorder) FindURLsPaginated(ctx, userID, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindURLsPaginated", reflect.TypeOf((*MockUserStorage)(nil).FindURLsPaginated), ctx, userID, offset, limit)
+}
+
 // FindUser mocks base method.
-func (m *MockUserStorage) FindUser(ctx context.Context, userID int) (*entity0.User, error) {
+func (m *MockUserStorage) FindUser(ctx context.Context, userID int) (*entity1.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindUser", ctx, userID)
-	ret0, _ := ret[0].(*entity0.User)
+	ret0, _ := ret[0].(*entity1.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -72,25 +165,96 @@ func (mr *MockUserStorageMockRecorder) FindUser(ctx, userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUser", reflect.TypeOf((*MockUserStorage)(nil).FindUser), ctx, userID)
 }
 
+// FindUserByAPIKey mocks base method.
+func (m *MockUserStorage) FindUserByAPIKey(ctx context.Context, keyHash string) (*entity1.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserByAPIKey", ctx, keyHash)
+	ret0, _ := ret[0].(*entity1.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserByAPIKey indicates an expected call of FindUserByAPIKey.
+func (mr *MockUserStorageMockRecorder) FindUserByAPIKey(ctx, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByAPIKey", reflect.TypeOf((*MockUserStorage)(nil).FindUserByAPIKey), ctx, keyHash)
+}
+
 // MarkURLAsDeleted mocks base method.
-func (m *MockUserStorage) MarkURLAsDeleted(ctx context.Context, userID int, aliases []string) error {
+func (m *MockUserStorage) MarkURLAsDeleted(ctx context.Context, userID int, namespace string, aliases []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkURLAsDeleted", ctx, userID, aliases)
+	ret := m.ctrl.Call(m, "MarkURLAsDeleted", ctx, userID, namespace, aliases)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // MarkURLAsDeleted indicates an expected call of MarkURLAsDeleted.
-func (mr *MockUserStorageMockRecorder) MarkURLAsDeleted(ctx, userID, aliases any) *gomock.Call {
+func (mr *MockUserStorageMockRecorder) MarkURLAsDeleted(ctx, userID, namespace, aliases any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkURLAsDeleted", reflect.TypeOf((*MockUserStorage)(nil).MarkURLAsDeleted), ctx, userID, aliases)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkURLAsDeleted", reflect.TypeOf((*MockUserStorage)(nil).MarkURLAsDeleted), ctx, userID, namespace, aliases)
+}
+
+// RestoreArchivedURL mocks base method.
+func (m *MockUserStorage) RestoreArchivedURL(ctx context.Context, userID int, namespace, alias string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreArchivedURL", ctx, userID, namespace, alias)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreArchivedURL indicates an expected call of RestoreArchivedURL.
+func (mr *MockUserStorageMockRecorder) RestoreArchivedURL(ctx, userID, namespace, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreArchivedURL", reflect.TypeOf((*MockUserStorage)(nil).RestoreArchivedURL), ctx, userID, namespace, alias)
+}
+
+// RestoreURL mocks base method.
+func (m *MockUserStorage) RestoreURL(ctx context.Context, userID int, namespace, alias string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreURL", ctx, userID, namespace, alias)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreURL indicates an expected call of RestoreURL.
+func (mr *MockUserStorageMockRecorder) RestoreURL(ctx, userID, namespace, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreURL", reflect.TypeOf((*MockUserStorage)(nil).RestoreURL), ctx, userID, namespace, alias)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockUserStorage) RevokeAPIKey(ctx context.Context, userID int, keyHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, userID, keyHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockUserStorageMockRecorder) RevokeAPIKey(ctx, userID, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockUserStorage)(nil).RevokeAPIKey), ctx, userID, keyHash)
+}
+
+// SaveAPIKey mocks base method.
+func (m *MockUserStorage) SaveAPIKey(ctx context.Context, userID int, keyHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAPIKey", ctx, userID, keyHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAPIKey indicates an expected call of SaveAPIKey.
+func (mr *MockUserStorageMockRecorder) SaveAPIKey(ctx, userID, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAPIKey", reflect.TypeOf((*MockUserStorage)(nil).SaveAPIKey), ctx, userID, keyHash)
 }
 
 // SaveUser mocks base method.
-func (m *MockUserStorage) SaveUser(ctx context.Context) (*entity0.User, error) {
+func (m *MockUserStorage) SaveUser(ctx context.Context) (*entity1.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SaveUser", ctx)
-	ret0, _ := ret[0].(*entity0.User)
+	ret0, _ := ret[0].(*entity1.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -101,11 +265,69 @@ func (mr *MockUserStorageMockRecorder) SaveUser(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUser", reflect.TypeOf((*MockUserStorage)(nil).SaveUser), ctx)
 }
 
+// SearchURLs mocks base method.
+func (m *MockUserStorage) SearchURLs(ctx context.Context, userID int, query string, offset, limit int) ([]*entity0.ShortURL, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchURLs", ctx, userID, query, offset, limit)
+	ret0, _ := ret[0].([]*entity0.ShortURL)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchURLs indicates an expected call of SearchURLs.
+func (mr *MockUserStorageMockRecorder) SearchURLs(ctx, userID, query, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchURLs", reflect.TypeOf((*MockUserStorage)(nil).SearchURLs), ctx, userID, query, offset, limit)
+}
+
+// SetCustomDomain mocks base method.
+func (m *MockUserStorage) SetCustomDomain(ctx context.Context, userID int, customDomain string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCustomDomain", ctx, userID, customDomain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCustomDomain indicates an expected call of SetCustomDomain.
+func (mr *MockUserStorageMockRecorder) SetCustomDomain(ctx, userID, customDomain any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCustomDomain", reflect.TypeOf((*MockUserStorage)(nil).SetCustomDomain), ctx, userID, customDomain)
+}
+
+// SetUserActive mocks base method.
+func (m *MockUserStorage) SetUserActive(ctx context.Context, userID int, isActive bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserActive", ctx, userID, isActive)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserActive indicates an expected call of SetUserActive.
+func (mr *MockUserStorageMockRecorder) SetUserActive(ctx, userID, isActive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserActive", reflect.TypeOf((*MockUserStorage)(nil).SetUserActive), ctx, userID, isActive)
+}
+
+// UpdateURLTarget mocks base method.
+func (m *MockUserStorage) UpdateURLTarget(ctx context.Context, userID int, namespace, alias, newURL string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateURLTarget", ctx, userID, namespace, alias, newURL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateURLTarget indicates an expected call of UpdateURLTarget.
+func (mr *MockUserStorageMockRecorder) UpdateURLTarget(ctx, userID, namespace, alias, newURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateURLTarget", reflect.TypeOf((*MockUserStorage)(nil).UpdateURLTarget), ctx, userID, namespace, alias, newURL)
+}
+
 // MockAuthenticator is a mock of Authenticator interface.
 type MockAuthenticator struct {
-	isgomock struct{}
 	ctrl     *gomock.Controller
 	recorder *MockAuthenticatorMockRecorder
+	isgomock struct{}
 }
 
 // MockAuthenticatorMockRecorder is the mock recorder for MockAuthenticator.
@@ -140,6 +362,20 @@ func (mr *MockAuthenticatorMockRecorder) ReadUserID(tokenString any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadUserID", reflect.TypeOf((*MockAuthenticator)(nil).ReadUserID), tokenString)
 }
 
+// RevokeToken mocks base method.
+func (m *MockAuthenticator) RevokeToken(ctx context.Context, tokenString string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, tokenString)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockAuthenticatorMockRecorder) RevokeToken(ctx, tokenString any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockAuthenticator)(nil).RevokeToken), ctx, tokenString)
+}
+
 // SignUserID mocks base method.
 func (m *MockAuthenticator) SignUserID(userID int) (string, error) {
 	m.ctrl.T.Helper()
@@ -154,3 +390,41 @@ func (mr *MockAuthenticatorMockRecorder) SignUserID(userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignUserID", reflect.TypeOf((*MockAuthenticator)(nil).SignUserID), userID)
 }
+
+// MockWebhookNotifier is a mock of WebhookNotifier interface.
+type MockWebhookNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookNotifierMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookNotifierMockRecorder is the mock recorder for MockWebhookNotifier.
+type MockWebhookNotifierMockRecorder struct {
+	mock *MockWebhookNotifier
+}
+
+// NewMockWebhookNotifier creates a new mock instance.
+func NewMockWebhookNotifier(ctrl *gomock.Controller) *MockWebhookNotifier {
+	mock := &MockWebhookNotifier{ctrl: ctrl}
+	mock.recorder = &MockWebhookNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookNotifier) EXPECT() *MockWebhookNotifierMockRecorder {
+	return m.recorder
+}
+
+// Deliver mocks base method.
+func (m *MockWebhookNotifier) Deliver(ctx context.Context, userID int, event string, data any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliver", ctx, userID, event, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deliver indicates an expected call of Deliver.
+func (mr *MockWebhookNotifierMockRecorder) Deliver(ctx, userID, event, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliver", reflect.TypeOf((*MockWebhookNotifier)(nil).Deliver), ctx, userID, event, data)
+}
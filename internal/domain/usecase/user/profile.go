@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+)
+
+// UserProfile represents settings of a user changeable by the user.
+type UserProfile struct {
+	CustomDomain string `json:"custom_domain"` // HTTPS base URL of short URLs of the user, empty to use the configured one
+}
+
+// UpdateProfile changes settings of a user. Custom domain is stored as scheme and lowercased host,
+// so a domain cannot be claimed twice in different case.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: The user to change, its CustomDomain is updated on success
+// - profile: New settings
+// Returns:
+// - error: ucErrors.ErrUserInvalidCustomDomain if custom domain is not an HTTPS URL without path
+// or is the configured base URL, ucErrors.ErrUserCustomDomainTaken if it is claimed by another user,
+// ucErrors.ErrUserNotFound if user doesn't exist, ucErrors.ErrUserStorageNotWorking if storage fails
+func (u *UserUseCase) UpdateProfile(ctx context.Context, user *userEntity.User, profile UserProfile) error {
+	customDomain, err := u.normalizeCustomDomain(profile.CustomDomain)
+	if err != nil {
+		return err
+	}
+
+	if err = u.storage.SetCustomDomain(ctx, user.ID, customDomain); err != nil {
+		switch {
+		case errors.Is(err, dbErrors.ErrDBIsNotUnique):
+			return ucErrors.ErrUserCustomDomainTaken
+		case errors.Is(err, dbErrors.ErrDBRecordNotFound):
+			return ucErrors.ErrUserNotFound
+		default:
+			return ucErrors.ErrUserStorageNotWorking
+		}
+	}
+
+	user.CustomDomain = customDomain
+	return nil
+}
+
+// normalizeCustomDomain validates a custom domain and brings it to the stored form.
+// Parameters:
+// - customDomain: Custom domain passed by the user, empty to remove it
+// Returns:
+// - string: Scheme and lowercased host of the domain, empty if customDomain is empty
+// - error: ucErrors.ErrUserInvalidCustomDomain if domain is not an HTTPS URL without path
+// or is the configured base URL
+func (u *UserUseCase) normalizeCustomDomain(customDomain string) (string, error) {
+	if customDomain == "" {
+		return "", nil
+	}
+
+	parsed, err := url.Parse(customDomain)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" || parsed.User != nil ||
+		strings.Trim(parsed.Path, "/") != "" || parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", ucErrors.ErrUserInvalidCustomDomain
+	}
+
+	if base, baseErr := url.Parse(u.baseURL); baseErr == nil && strings.EqualFold(base.Host, parsed.Host) {
+		return "", ucErrors.ErrUserInvalidCustomDomain
+	}
+
+	return parsed.Scheme + "://" + strings.ToLower(parsed.Host), nil
+}
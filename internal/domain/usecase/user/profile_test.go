@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	"github.com/gururuby/shortener/internal/domain/usecase/user/mocks"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_UpdateProfile(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		storageErr   error
+		err          error
+		name         string
+		customDomain string
+		stored       string // Domain passed to storage
+	}{
+		{name: "when custom domain is set", customDomain: "https://short.acme.com", stored: "https://short.acme.com"},
+		{name: "when custom domain is normalized", customDomain: "https://Short.ACME.com/", stored: "https://short.acme.com"},
+		{name: "when custom domain has port", customDomain: "https://short.acme.com:8443", stored: "https://short.acme.com:8443"},
+		{name: "when custom domain is removed"},
+		{name: "when custom domain is not HTTPS", customDomain: "http://short.acme.com", err: ucErrors.ErrUserInvalidCustomDomain},
+		{name: "when custom domain has no scheme", customDomain: "short.acme.com", err: ucErrors.ErrUserInvalidCustomDomain},
+		{name: "when custom domain has path", customDomain: "https://acme.com/s", err: ucErrors.ErrUserInvalidCustomDomain},
+		{name: "when custom domain has query", customDomain: "https://short.acme.com?a=1", err: ucErrors.ErrUserInvalidCustomDomain},
+		{name: "when custom domain is the base URL", customDomain: "https://Shortener.example", err: ucErrors.ErrUserInvalidCustomDomain},
+		{
+			name:         "when custom domain is claimed by another user",
+			customDomain: "https://short.acme.com",
+			stored:       "https://short.acme.com",
+			storageErr:   dbErrors.ErrDBIsNotUnique,
+			err:          ucErrors.ErrUserCustomDomainTaken,
+		},
+		{
+			name:         "when storage fails",
+			customDomain: "https://short.acme.com",
+			stored:       "https://short.acme.com",
+			storageErr:   dbErrors.ErrDBQuery,
+			err:          ucErrors.ErrUserStorageNotWorking,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockUserStorage(ctrl)
+			if tt.err == nil || tt.storageErr != nil {
+				storage.EXPECT().SetCustomDomain(ctx, 1, tt.stored).Return(tt.storageErr)
+			}
+
+			uc := NewUserUseCase(mocks.NewMockAuthenticator(ctrl), storage, nil, "https://shortener.example")
+			user := &userEntity.User{ID: 1, CustomDomain: "https://old.acme.com"}
+
+			err := uc.UpdateProfile(ctx, user, UserProfile{CustomDomain: tt.customDomain})
+			require.ErrorIs(t, err, tt.err)
+
+			if tt.err != nil {
+				require.Equal(t, "https://old.acme.com", user.CustomDomain)
+				return
+			}
+			require.Equal(t, tt.stored, user.CustomDomain)
+		})
+	}
+}
+
+func Test_GetURLs_CustomDomain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockUserStorage(ctrl)
+	ctx := context.Background()
+	uc := NewUserUseCase(mocks.NewMockAuthenticator(ctrl), storage, nil, "http://localhost:8080")
+
+	storage.EXPECT().FindURLs(ctx, 1).Return([]*shortURLEntity.ShortURL{{Alias: "abc", SourceURL: "https://ya.ru"}}, nil).Times(2)
+
+	res, err := uc.GetURLs(ctx, &userEntity.User{ID: 1, CustomDomain: "https://short.acme.com"})
+	require.NoError(t, err)
+	require.Equal(t, []*UserShortURL{{ShortURL: "https://short.acme.com/abc", OriginalURL: "https://ya.ru"}}, res)
+
+	// Users without custom domain get the configured base URL
+	res, err = uc.GetURLs(ctx, &userEntity.User{ID: 1})
+	require.NoError(t, err)
+	require.Equal(t, []*UserShortURL{{ShortURL: "http://localhost:8080/abc", OriginalURL: "https://ya.ru"}}, res)
+}
@@ -1,4 +1,4 @@
-//go:generate mockgen -destination=./mocks/mock.go -package=mocks . UserStorage,Authenticator
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . UserStorage,Authenticator,WebhookNotifier
 
 /*
 Package usecase implements the business logic for user management operations.
@@ -6,20 +6,44 @@ Package usecase implements the business logic for user management operations.
 It provides:
 - User authentication and registration
 - User URL management
+- Profile settings, e.g. custom domain of short URLs
+- Export of all user data
 - JWT token handling
+- API keys for server-to-server clients
+- Deactivation of users by administrators
+- Webhook notifications about deleted URLs
 - Error handling specific to user operations
 */
 package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"strconv"
+	"time"
 
+	analyticsEntity "github.com/gururuby/shortener/internal/domain/entity/analytics"
 	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
 	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	webhookEntity "github.com/gururuby/shortener/internal/domain/entity/webhook"
 	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
 	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	jwtErrors "github.com/gururuby/shortener/internal/infra/jwt/errors"
 	"github.com/gururuby/shortener/internal/infra/logger"
+	"github.com/gururuby/shortener/pkg/validator"
+)
+
+// Pagination defaults and limits for user URLs listing.
+const (
+	DefaultPage    = 1   // Page returned when none or an invalid one is requested
+	DefaultPerPage = 50  // Page size used when none or an invalid one is requested
+	MaxPerPage     = 200 // Upper bound for the requested page size
+	exportBatch    = 500 // Number of URLs fetched from storage at once during export
+	apiKeyLength   = 32  // Number of random bytes in API key
 )
 
 // UserStorage defines the interface for user persistence operations.
@@ -36,6 +60,33 @@ type UserStorage interface {
 	// - error: If database operation fails
 	FindURLs(ctx context.Context, userID int) ([]*shortURLEntity.ShortURL, error)
 
+	// FindURLsPaginated retrieves a page of short URLs belonging to a user.
+	// Returns:
+	// - []*shortURLEntity.ShortURL: Requested page of user's short URLs
+	// - int64: Total number of user's short URLs
+	// - error: If database operation fails
+	FindURLsPaginated(ctx context.Context, userID, offset, limit int) ([]*shortURLEntity.ShortURL, int64, error)
+
+	// SearchURLs retrieves a page of short URLs of a user whose original URL contains the query.
+	// Returns:
+	// - []*shortURLEntity.ShortURL: Requested page of found short URLs
+	// - int64: Total number of found short URLs
+	// - error: If database operation fails
+	SearchURLs(ctx context.Context, userID int, query string, offset, limit int) ([]*shortURLEntity.ShortURL, int64, error)
+
+	// FindClicks retrieves clicks of all short URLs of a user.
+	// Returns:
+	// - []*analyticsEntity.Click: Clicks ordered by click time
+	// - error: If database operation fails
+	FindClicks(ctx context.Context, userID int) ([]*analyticsEntity.Click, error)
+
+	// FindURLsCursor retrieves short URLs belonging to a user with ID greater than afterID.
+	// Returns:
+	// - []*shortURLEntity.ShortURL: Requested page of user's short URLs ordered by ID
+	// - int: ID of the last returned URL, 0 if there are no more URLs
+	// - error: If database operation fails
+	FindURLsCursor(ctx context.Context, userID, afterID, limit int) ([]*shortURLEntity.ShortURL, int, error)
+
 	// SaveUser creates and persists a new user.
 	// Returns:
 	// - *userEntity.User: The created user
@@ -45,7 +96,66 @@ type UserStorage interface {
 	// MarkURLAsDeleted soft-deletes the specified URLs for a user.
 	// Returns:
 	// - error: If database operation fails or URLs don't belong to user
-	MarkURLAsDeleted(ctx context.Context, userID int, aliases []string) error
+	MarkURLAsDeleted(ctx context.Context, userID int, namespace string, aliases []string) error
+
+	// FindURL retrieves a short URL of any owner by its namespace and alias, including deleted one.
+	// Returns:
+	// - *shortURLEntity.ShortURL: The found short URL
+	// - error: If URL is not found or database operation fails
+	FindURL(ctx context.Context, namespace, alias string) (*shortURLEntity.ShortURL, error)
+
+	// RestoreURL clears the deletion mark of a short URL of a user.
+	// Returns:
+	// - error: If database operation fails or URL doesn't belong to user
+	RestoreURL(ctx context.Context, userID int, namespace, alias string) error
+
+	// FindArchivedURLs retrieves a page of archived short URLs of a user.
+	// Returns:
+	// - []*shortURLEntity.ArchivedURL: Archived URLs, the most recently archived first
+	// - error: If database operation fails
+	FindArchivedURLs(ctx context.Context, userID, limit, offset int) ([]*shortURLEntity.ArchivedURL, error)
+
+	// RestoreArchivedURL moves an archived short URL of a user back to active short URLs as not deleted.
+	// Returns:
+	// - error: If URL is not archived, its alias is taken or database operation fails
+	RestoreArchivedURL(ctx context.Context, userID int, namespace, alias string) error
+
+	// UpdateURLTarget replaces the original URL of a short URL of a user saving the previous one to history.
+	// Returns:
+	// - error: If database operation fails or URL doesn't belong to user
+	UpdateURLTarget(ctx context.Context, userID int, namespace, alias, newURL string) error
+
+	// FindURLHistory retrieves previous original URLs of a short URL.
+	// Returns:
+	// - []*shortURLEntity.HistoryEntry: Previous original URLs, the most recent first
+	// - error: If database operation fails
+	FindURLHistory(ctx context.Context, namespace, alias string) ([]*shortURLEntity.HistoryEntry, error)
+
+	// SaveAPIKey stores SHA-256 hash of a new API key of a user.
+	// Returns:
+	// - error: If database operation fails
+	SaveAPIKey(ctx context.Context, userID int, keyHash string) error
+
+	// FindUserByAPIKey retrieves the owner of a not revoked API key by the key hash.
+	// Returns:
+	// - *userEntity.User: The found user
+	// - error: If key is not found, revoked or database operation fails
+	FindUserByAPIKey(ctx context.Context, keyHash string) (*userEntity.User, error)
+
+	// RevokeAPIKey marks an API key of a user as revoked.
+	// Returns:
+	// - error: If key is not found, already revoked or database operation fails
+	RevokeAPIKey(ctx context.Context, userID int, keyHash string) error
+
+	// SetUserActive activates or deactivates a user.
+	// Returns:
+	// - error: If user is not found or database operation fails
+	SetUserActive(ctx context.Context, userID int, isActive bool) error
+
+	// SetCustomDomain sets the custom domain of a user, empty to remove it.
+	// Returns:
+	// - error: If user is not found, domain belongs to another user or database operation fails
+	SetCustomDomain(ctx context.Context, userID int, customDomain string) error
 }
 
 // Authenticator defines the interface for user authentication operations.
@@ -61,33 +171,116 @@ type Authenticator interface {
 	// - int: The user ID from the token
 	// - error: If token is invalid or expired
 	ReadUserID(tokenString string) (int, error)
+
+	// RevokeToken invalidates a JWT token before its expiration.
+	// Returns:
+	// - error: If token is invalid or revocation cannot be stored
+	RevokeToken(ctx context.Context, tokenString string) error
+}
+
+// WebhookNotifier defines the interface for delivering URL lifecycle events to user webhooks.
+type WebhookNotifier interface {
+	// Deliver posts the event to all webhooks of a user subscribed to it.
+	// Returns:
+	// - error: Any error that occurred during delivery
+	Deliver(ctx context.Context, userID int, event string, data any) error
 }
 
 // UserUseCase implements the business logic for user management.
 type UserUseCase struct {
-	auth    Authenticator // JWT authentication service
-	storage UserStorage   // User persistence layer
-	baseURL string        // Base URL for shortened links
+	auth     Authenticator   // JWT authentication service
+	storage  UserStorage     // User persistence layer
+	notifier WebhookNotifier // Webhook delivery service, nil disables webhooks
+	baseURL  string          // Base URL for shortened links
 }
 
 // UserShortURL represents a shortened URL with its original URL.
 type UserShortURL struct {
-	ShortURL    string `json:"short_url"`    // The shortened URL
-	OriginalURL string `json:"original_url"` // The original long URL
+	ShortURL    string `json:"short_url"`        // The shortened URL
+	OriginalURL string `json:"original_url"`     // The original long URL
+	Region      string `json:"region,omitempty"` // Region where the short URL was created, omitted if unknown
+}
+
+// ExportedURL represents a user's shortened URL with its metadata for export.
+type ExportedURL struct {
+	CreatedAt   time.Time `json:"created_at"`   // Creation time of the short URL
+	ShortURL    string    `json:"short_url"`    // The shortened URL
+	OriginalURL string    `json:"original_url"` // The original long URL
+	Clicks      int64     `json:"clicks"`       // Number of redirects, always 0 as clicks are not tracked
+	IsDeleted   bool      `json:"is_deleted"`   // Whether the short URL is deleted
+}
+
+// PaginatedURLs represents a single page of user's shortened URLs.
+type PaginatedURLs struct {
+	Items      []*UserShortURL `json:"items"`       // URLs on the requested page
+	Total      int64           `json:"total"`       // Total number of user's URLs
+	Page       int             `json:"page"`        // Current page number (1-based)
+	TotalPages int             `json:"total_pages"` // Total number of pages
+}
+
+// ArchivedShortURL represents a user's archived short URL.
+type ArchivedShortURL struct {
+	ArchivedAt  time.Time `json:"archived_at"`  // Time the short URL was archived
+	ShortURL    string    `json:"short_url"`    // The shortened URL
+	OriginalURL string    `json:"original_url"` // The original long URL
+}
+
+// ArchivedURLs represents a single page of user's archived short URLs.
+type ArchivedURLs struct {
+	Items   []*ArchivedShortURL `json:"items"`    // Archived URLs on the requested page, the most recently archived first
+	Page    int                 `json:"page"`     // Current page number (1-based)
+	PerPage int                 `json:"per_page"` // Page size
+}
+
+// CursorPage represents a page of user's shortened URLs addressed by cursor.
+type CursorPage struct {
+	Items      []*UserShortURL `json:"items"`                 // URLs on the requested page
+	NextCursor string          `json:"next_cursor,omitempty"` // Opaque cursor of the next page, empty on the last page
+}
+
+// EncodeCursor builds an opaque pagination cursor from a short URL ID.
+// Parameters:
+// - id: ID of the last URL on a page
+// Returns:
+// - string: URL-safe base64 encoded cursor
+func EncodeCursor(id int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(id)))
+}
+
+// DecodeCursor extracts a short URL ID from a pagination cursor.
+// Parameters:
+// - cursor: Cursor built by EncodeCursor
+// Returns:
+// - int: ID of the last URL on the previous page
+// - error: ucErrors.ErrUserInvalidCursor if cursor is malformed
+func DecodeCursor(cursor string) (int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ucErrors.ErrUserInvalidCursor
+	}
+
+	id, err := strconv.Atoi(string(data))
+	if err != nil || id < 1 {
+		return 0, ucErrors.ErrUserInvalidCursor
+	}
+
+	return id, nil
 }
 
 // NewUserUseCase creates a new instance of UserUseCase.
 // Parameters:
 // - auth: JWT authentication service
 // - storage: User persistence layer
+// - notifier: Webhook delivery service, nil disables webhooks
 // - baseURL: Base URL for shortened links
 // Returns:
 // - *UserUseCase: Initialized user use case
-func NewUserUseCase(auth Authenticator, storage UserStorage, baseURL string) *UserUseCase {
+func NewUserUseCase(auth Authenticator, storage UserStorage, notifier WebhookNotifier, baseURL string) *UserUseCase {
 	return &UserUseCase{
-		auth:    auth,
-		storage: storage,
-		baseURL: baseURL,
+		auth:     auth,
+		storage:  storage,
+		notifier: notifier,
+		baseURL:  baseURL,
 	}
 }
 
@@ -97,7 +290,7 @@ func NewUserUseCase(auth Authenticator, storage UserStorage, baseURL string) *Us
 // - token: JWT token to authenticate
 // Returns:
 // - *userEntity.User: Authenticated user with token
-// - error: Specific authentication errors
+// - error: Specific authentication errors, ucErrors.ErrUserDeactivated if user is deactivated
 func (u *UserUseCase) Authenticate(ctx context.Context, token string) (*userEntity.User, error) {
 	var (
 		userID int
@@ -113,10 +306,129 @@ func (u *UserUseCase) Authenticate(ctx context.Context, token string) (*userEnti
 		return nil, ucErrors.ErrUserNotFound
 	}
 
+	if !user.IsActive {
+		return nil, ucErrors.ErrUserDeactivated
+	}
+
 	user.AuthToken = token
 	return user, nil
 }
 
+// AuthenticateAPIKey verifies an API key and retrieves its owner.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - key: API key passed by the client
+// Returns:
+// - *userEntity.User: Owner of the key with APIKey set
+// - error: ucErrors.ErrUserInvalidAPIKey if key is unknown or revoked,
+// ucErrors.ErrUserDeactivated if its owner is deactivated
+func (u *UserUseCase) AuthenticateAPIKey(ctx context.Context, key string) (*userEntity.User, error) {
+	user, err := u.storage.FindUserByAPIKey(ctx, hashAPIKey(key))
+	if err != nil {
+		return nil, ucErrors.ErrUserInvalidAPIKey
+	}
+
+	if !user.IsActive {
+		return nil, ucErrors.ErrUserDeactivated
+	}
+
+	user.APIKey = key
+	return user, nil
+}
+
+// CreateAPIKey generates a new API key of a user.
+// Only SHA-256 hash of the key is stored, so the key cannot be shown again.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: Owner of the key
+// Returns:
+// - string: Hex encoded random key
+// - error: ucErrors.ErrUserCannotCreateAPIKey if key cannot be generated or stored
+func (u *UserUseCase) CreateAPIKey(ctx context.Context, user *userEntity.User) (string, error) {
+	b := make([]byte, apiKeyLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", ucErrors.ErrUserCannotCreateAPIKey
+	}
+
+	key := hex.EncodeToString(b)
+	if err := u.storage.SaveAPIKey(ctx, user.ID, hashAPIKey(key)); err != nil {
+		return "", ucErrors.ErrUserCannotCreateAPIKey
+	}
+
+	return key, nil
+}
+
+// RevokeAPIKey revokes an API key of a user, so it cannot be used anymore.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: Owner of the key
+// - key: API key to revoke
+// Returns:
+// - error: ucErrors.ErrUserAPIKeyNotFound if user has no such active key,
+// ucErrors.ErrUserStorageNotWorking if storage fails
+func (u *UserUseCase) RevokeAPIKey(ctx context.Context, user *userEntity.User, key string) error {
+	if err := u.storage.RevokeAPIKey(ctx, user.ID, hashAPIKey(key)); err != nil {
+		if errors.Is(err, dbErrors.ErrDBRecordNotFound) {
+			return ucErrors.ErrUserAPIKeyNotFound
+		}
+		return ucErrors.ErrUserStorageNotWorking
+	}
+
+	return nil
+}
+
+// DeactivateUser prevents a user from authenticating with tokens and API keys.
+// Short URLs of the user are kept and keep redirecting.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: ID of the user to deactivate
+// Returns:
+// - error: ucErrors.ErrUserNotFound if user doesn't exist,
+// ucErrors.ErrUserStorageNotWorking if storage fails
+func (u *UserUseCase) DeactivateUser(ctx context.Context, userID int) error {
+	return u.setUserActive(ctx, userID, false)
+}
+
+// ReactivateUser allows a deactivated user to authenticate again.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: ID of the user to reactivate
+// Returns:
+// - error: ucErrors.ErrUserNotFound if user doesn't exist,
+// ucErrors.ErrUserStorageNotWorking if storage fails
+func (u *UserUseCase) ReactivateUser(ctx context.Context, userID int) error {
+	return u.setUserActive(ctx, userID, true)
+}
+
+// setUserActive stores whether the user may authenticate.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - userID: ID of the user
+// - isActive: Whether the user may authenticate
+// Returns:
+// - error: ucErrors.ErrUserNotFound if user doesn't exist,
+// ucErrors.ErrUserStorageNotWorking if storage fails
+func (u *UserUseCase) setUserActive(ctx context.Context, userID int, isActive bool) error {
+	if err := u.storage.SetUserActive(ctx, userID, isActive); err != nil {
+		if errors.Is(err, dbErrors.ErrDBRecordNotFound) {
+			return ucErrors.ErrUserNotFound
+		}
+		return ucErrors.ErrUserStorageNotWorking
+	}
+
+	return nil
+}
+
+// hashAPIKey computes the hash API keys are stored and looked up by.
+// Parameters:
+// - key: API key
+// Returns:
+// - string: Hex encoded SHA-256 hash of the key
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
 // Register creates a new user account and generates an authentication token.
 // Parameters:
 // - ctx: Context for cancellation and timeouts
@@ -143,6 +455,24 @@ func (u *UserUseCase) Register(ctx context.Context) (*userEntity.User, error) {
 	return user, nil
 }
 
+// RevokeToken terminates the session of a JWT token, so it cannot be used anymore.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - token: JWT token to revoke
+// Returns:
+// - error: ucErrors.ErrUserCannotAuthenticate if token is invalid,
+// ucErrors.ErrUserCannotRevokeToken if revocation fails
+func (u *UserUseCase) RevokeToken(ctx context.Context, token string) error {
+	if err := u.auth.RevokeToken(ctx, token); err != nil {
+		if errors.Is(err, jwtErrors.ErrJWTCannotRevoke) {
+			return ucErrors.ErrUserCannotRevokeToken
+		}
+		return ucErrors.ErrUserCannotAuthenticate
+	}
+
+	return nil
+}
+
 // SaveUser persists a new user record.
 // Parameters:
 // - ctx: Context for cancellation and timeouts
@@ -195,23 +525,418 @@ func (u *UserUseCase) GetURLs(ctx context.Context, user *userEntity.User) ([]*Us
 
 	for _, shortURL := range shortURLs {
 		userURLs = append(userURLs, &UserShortURL{
-			ShortURL:    u.baseURL + "/" + shortURL.Alias,
+			ShortURL:    user.BaseURL(u.baseURL) + "/" + shortURL.Path(),
 			OriginalURL: shortURL.SourceURL,
+			Region:      shortURL.CreatedInRegion,
 		})
 	}
 
 	return userURLs, nil
 }
 
-// DeleteURLs marks the specified URLs as deleted for a user.
+// GetURLsPaginated retrieves a page of shortened URLs belonging to a user.
+// Out of range values are normalized: page below 1 becomes DefaultPage,
+// perPage below 1 becomes DefaultPerPage and perPage above MaxPerPage is capped.
 // Parameters:
 // - ctx: Context for cancellation and timeouts
-// - user: The user owning the URLs
+// - user: The user whose URLs to retrieve
+// - page: Requested page number (1-based)
+// - perPage: Requested page size
+// Returns:
+// - *PaginatedURLs: Requested page with pagination metadata
+// - error: If retrieval operation fails
+func (u *UserUseCase) GetURLsPaginated(ctx context.Context, user *userEntity.User, page, perPage int) (*PaginatedURLs, error) {
+	var (
+		shortURLs []*shortURLEntity.ShortURL
+		total     int64
+		err       error
+	)
+
+	if page < 1 {
+		page = DefaultPage
+	}
+
+	if perPage < 1 {
+		perPage = DefaultPerPage
+	}
+
+	if perPage > MaxPerPage {
+		perPage = MaxPerPage
+	}
+
+	if shortURLs, total, err = u.storage.FindURLsPaginated(ctx, user.ID, (page-1)*perPage, perPage); err != nil {
+		return nil, ucErrors.ErrUserStorageNotWorking
+	}
+
+	result := &PaginatedURLs{
+		Items:      make([]*UserShortURL, 0, len(shortURLs)),
+		Total:      total,
+		Page:       page,
+		TotalPages: int((total + int64(perPage) - 1) / int64(perPage)),
+	}
+
+	for _, shortURL := range shortURLs {
+		result.Items = append(result.Items, &UserShortURL{
+			ShortURL:    user.BaseURL(u.baseURL) + "/" + shortURL.Path(),
+			OriginalURL: shortURL.SourceURL,
+			Region:      shortURL.CreatedInRegion,
+		})
+	}
+
+	return result, nil
+}
+
+// SearchURLs retrieves a page of shortened URLs of a user whose original URL contains the query.
+// The query is matched case-insensitively, page and perPage are normalized as by GetURLsPaginated.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: The user whose URLs to search
+// - query: Substring of original URLs to find
+// - page: Requested page number (1-based)
+// - perPage: Requested page size
+// Returns:
+// - *PaginatedURLs: Requested page of found URLs with pagination metadata
+// - error: If search operation fails
+func (u *UserUseCase) SearchURLs(ctx context.Context, user *userEntity.User, query string, page, perPage int) (*PaginatedURLs, error) {
+	var (
+		shortURLs []*shortURLEntity.ShortURL
+		total     int64
+		err       error
+	)
+
+	if page < 1 {
+		page = DefaultPage
+	}
+
+	if perPage < 1 {
+		perPage = DefaultPerPage
+	}
+
+	if perPage > MaxPerPage {
+		perPage = MaxPerPage
+	}
+
+	if shortURLs, total, err = u.storage.SearchURLs(ctx, user.ID, query, (page-1)*perPage, perPage); err != nil {
+		return nil, ucErrors.ErrUserStorageNotWorking
+	}
+
+	result := &PaginatedURLs{
+		Items:      make([]*UserShortURL, 0, len(shortURLs)),
+		Total:      total,
+		Page:       page,
+		TotalPages: int((total + int64(perPage) - 1) / int64(perPage)),
+	}
+
+	for _, shortURL := range shortURLs {
+		result.Items = append(result.Items, &UserShortURL{
+			ShortURL:    user.BaseURL(u.baseURL) + "/" + shortURL.Path(),
+			OriginalURL: shortURL.SourceURL,
+			Region:      shortURL.CreatedInRegion,
+		})
+	}
+
+	return result, nil
+}
+
+// GetURLsCursor retrieves a page of shortened URLs belonging to a user after the cursor.
+// Unlike GetURLsPaginated it does not skip rows, so pages stay consistent
+// when URLs are created between requests.
+// Limit below 1 becomes DefaultPerPage and limit above MaxPerPage is capped.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: The user whose URLs to retrieve
+// - cursor: ID decoded from the previous page cursor, 0 for the first page
+// - limit: Requested page size
+// Returns:
+// - *CursorPage: Requested page with the next page cursor
+// - error: If retrieval operation fails
+func (u *UserUseCase) GetURLsCursor(ctx context.Context, user *userEntity.User, cursor, limit int) (*CursorPage, error) {
+	var (
+		shortURLs  []*shortURLEntity.ShortURL
+		nextCursor int
+		err        error
+	)
+
+	if limit < 1 {
+		limit = DefaultPerPage
+	}
+
+	if limit > MaxPerPage {
+		limit = MaxPerPage
+	}
+
+	if shortURLs, nextCursor, err = u.storage.FindURLsCursor(ctx, user.ID, cursor, limit); err != nil {
+		return nil, ucErrors.ErrUserStorageNotWorking
+	}
+
+	result := &CursorPage{Items: make([]*UserShortURL, 0, len(shortURLs))}
+
+	if nextCursor > 0 {
+		result.NextCursor = EncodeCursor(nextCursor)
+	}
+
+	for _, shortURL := range shortURLs {
+		result.Items = append(result.Items, &UserShortURL{
+			ShortURL:    user.BaseURL(u.baseURL) + "/" + shortURL.Path(),
+			OriginalURL: shortURL.SourceURL,
+			Region:      shortURL.CreatedInRegion,
+		})
+	}
+
+	return result, nil
+}
+
+// ExportURLs passes all shortened URLs of a user, including deleted ones, to fn batch by batch.
+// URLs are fetched from storage in batches, so the next batch is not fetched
+// until fn returns and all URLs are never held in memory at once.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: The user whose URLs to export
+// - fn: Callback receiving each batch of URLs ordered by creation, its error stops the export
+// Returns:
+// - error: ErrUserStorageNotWorking if a batch cannot be fetched, or error returned by fn
+func (u *UserUseCase) ExportURLs(ctx context.Context, user *userEntity.User, fn func([]*ExportedURL) error) error {
+	var (
+		shortURLs []*shortURLEntity.ShortURL
+		cursor    int
+		err       error
+	)
+
+	for {
+		if shortURLs, cursor, err = u.storage.FindURLsCursor(ctx, user.ID, cursor, exportBatch); err != nil {
+			return ucErrors.ErrUserStorageNotWorking
+		}
+
+		batch := make([]*ExportedURL, 0, len(shortURLs))
+		for _, shortURL := range shortURLs {
+			batch = append(batch, &ExportedURL{
+				ShortURL:    user.BaseURL(u.baseURL) + "/" + shortURL.Path(),
+				OriginalURL: shortURL.SourceURL,
+				CreatedAt:   shortURL.CreatedAt,
+				IsDeleted:   shortURL.IsDeleted,
+			})
+		}
+
+		if len(batch) > 0 {
+			if err = fn(batch); err != nil {
+				return err
+			}
+		}
+
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
+// DeleteURLs marks the specified URLs of the namespace of the user as deleted
+// and notifies user's webhooks.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: The user owning the URLs, its namespace is the namespace of the URLs
 // - aliases: List of URL aliases to delete
 // Note: Errors are logged but not returned to allow batch operations to continue
 func (u *UserUseCase) DeleteURLs(ctx context.Context, user *userEntity.User, aliases []string) {
-	err := u.storage.MarkURLAsDeleted(ctx, user.ID, aliases)
+	err := u.storage.MarkURLAsDeleted(ctx, user.ID, user.Namespace, aliases)
 	if err != nil {
 		logger.Log.Error(err.Error())
+		return
+	}
+
+	if u.notifier != nil {
+		ctx = context.WithoutCancel(ctx)
+		go func() {
+			err := u.notifier.Deliver(ctx, user.ID, webhookEntity.EventURLDeleted, &webhookEntity.URLsDeletedData{Aliases: aliases})
+			if err != nil {
+				logger.Log.Error(err.Error())
+			}
+		}()
+	}
+}
+
+// RestoreURL restores a soft-deleted short URL of a user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: The user owning the URL, its namespace is the namespace of the URL
+// - alias: Short URL identifier
+// Returns:
+// - error: ucErrors.ErrUserURLNotFound if alias does not exist,
+// ucErrors.ErrUserURLForbidden if URL belongs to another user,
+// ucErrors.ErrUserURLNotDeleted if URL is not deleted,
+// ucErrors.ErrUserStorageNotWorking if storage fails
+func (u *UserUseCase) RestoreURL(ctx context.Context, user *userEntity.User, alias string) error {
+	shortURL, err := u.storage.FindURL(ctx, user.Namespace, alias)
+	if err != nil {
+		if errors.Is(err, dbErrors.ErrDBRecordNotFound) {
+			return ucErrors.ErrUserURLNotFound
+		}
+		return ucErrors.ErrUserStorageNotWorking
+	}
+
+	if shortURL.UserID != user.ID {
+		return ucErrors.ErrUserURLForbidden
+	}
+
+	if !shortURL.IsDeleted {
+		return ucErrors.ErrUserURLNotDeleted
+	}
+
+	if err = u.storage.RestoreURL(ctx, user.ID, user.Namespace, alias); err != nil {
+		if errors.Is(err, dbErrors.ErrDBRecordNotFound) {
+			return ucErrors.ErrUserURLNotFound
+		}
+		return ucErrors.ErrUserStorageNotWorking
+	}
+
+	return nil
+}
+
+// GetArchivedURLs retrieves a page of archived short URLs of a user.
+// Page below 1 becomes DefaultPage, per page below 1 becomes DefaultPerPage
+// and per page above MaxPerPage is capped.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: The user owning the URLs
+// - page: Requested page number (1-based)
+// - perPage: Requested page size
+// Returns:
+// - *ArchivedURLs: Requested page of archived URLs
+// - error: ucErrors.ErrUserStorageNotWorking if storage fails
+func (u *UserUseCase) GetArchivedURLs(ctx context.Context, user *userEntity.User, page, perPage int) (*ArchivedURLs, error) {
+	if page < 1 {
+		page = DefaultPage
+	}
+
+	if perPage < 1 {
+		perPage = DefaultPerPage
 	}
+
+	if perPage > MaxPerPage {
+		perPage = MaxPerPage
+	}
+
+	archivedURLs, err := u.storage.FindArchivedURLs(ctx, user.ID, perPage, (page-1)*perPage)
+	if err != nil {
+		return nil, ucErrors.ErrUserStorageNotWorking
+	}
+
+	result := &ArchivedURLs{
+		Items:   make([]*ArchivedShortURL, 0, len(archivedURLs)),
+		Page:    page,
+		PerPage: perPage,
+	}
+
+	for _, archivedURL := range archivedURLs {
+		result.Items = append(result.Items, &ArchivedShortURL{
+			ArchivedAt:  archivedURL.ArchivedAt,
+			ShortURL:    user.BaseURL(u.baseURL) + "/" + archivedURL.ShortURL.Path(),
+			OriginalURL: archivedURL.ShortURL.SourceURL,
+		})
+	}
+
+	return result, nil
+}
+
+// RestoreArchivedURL moves an archived short URL of a user back to active short URLs,
+// restored URL is not deleted.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: The user owning the URL
+// - alias: Short URL identifier
+// Returns:
+// - error: ucErrors.ErrUserURLNotFound if the user has no archived URL with the alias,
+// ucErrors.ErrUserURLAliasTaken if the alias was taken by another URL after archiving,
+// ucErrors.ErrUserStorageNotWorking if storage fails
+func (u *UserUseCase) RestoreArchivedURL(ctx context.Context, user *userEntity.User, alias string) error {
+	if err := u.storage.RestoreArchivedURL(ctx, user.ID, user.Namespace, alias); err != nil {
+		switch {
+		case errors.Is(err, dbErrors.ErrDBRecordNotFound):
+			return ucErrors.ErrUserURLNotFound
+		case errors.Is(err, dbErrors.ErrDBIsNotUnique):
+			return ucErrors.ErrUserURLAliasTaken
+		default:
+			return ucErrors.ErrUserStorageNotWorking
+		}
+	}
+
+	return nil
+}
+
+// UpdateURL replaces the original URL of a short URL of a user keeping its alias.
+// The previous original URL is saved to history.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: The user owning the URL, its namespace is the namespace of the URL
+// - alias: Short URL identifier
+// - newURL: New original URL
+// Returns:
+// - error: ucErrors.ErrUserInvalidURL if newURL is not valid,
+// ucErrors.ErrUserURLNotFound if alias does not exist,
+// ucErrors.ErrUserURLForbidden if URL belongs to another user,
+// ucErrors.ErrUserURLDeleted if URL is deleted,
+// ucErrors.ErrUserStorageNotWorking if storage fails
+func (u *UserUseCase) UpdateURL(ctx context.Context, user *userEntity.User, alias, newURL string) error {
+	if validator.IsInvalidURL(newURL) {
+		return ucErrors.ErrUserInvalidURL
+	}
+
+	shortURL, err := u.storage.FindURL(ctx, user.Namespace, alias)
+	if err != nil {
+		if errors.Is(err, dbErrors.ErrDBRecordNotFound) {
+			return ucErrors.ErrUserURLNotFound
+		}
+		return ucErrors.ErrUserStorageNotWorking
+	}
+
+	if shortURL.UserID != user.ID {
+		return ucErrors.ErrUserURLForbidden
+	}
+
+	if shortURL.IsDeleted {
+		return ucErrors.ErrUserURLDeleted
+	}
+
+	if err = u.storage.UpdateURLTarget(ctx, user.ID, user.Namespace, alias, newURL); err != nil {
+		if errors.Is(err, dbErrors.ErrDBRecordNotFound) {
+			return ucErrors.ErrUserURLNotFound
+		}
+		return ucErrors.ErrUserStorageNotWorking
+	}
+
+	return nil
+}
+
+// GetURLHistory retrieves previous original URLs of a short URL of a user.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: The user owning the URL, its namespace is the namespace of the URL
+// - alias: Short URL identifier
+// Returns:
+// - []*shortURLEntity.HistoryEntry: Previous original URLs, the most recent first, empty if never changed
+// - error: ucErrors.ErrUserURLNotFound if alias does not exist,
+// ucErrors.ErrUserURLForbidden if URL belongs to another user,
+// ucErrors.ErrUserStorageNotWorking if storage fails
+func (u *UserUseCase) GetURLHistory(ctx context.Context, user *userEntity.User, alias string) ([]*shortURLEntity.HistoryEntry, error) {
+	shortURL, err := u.storage.FindURL(ctx, user.Namespace, alias)
+	if err != nil {
+		if errors.Is(err, dbErrors.ErrDBRecordNotFound) {
+			return nil, ucErrors.ErrUserURLNotFound
+		}
+		return nil, ucErrors.ErrUserStorageNotWorking
+	}
+
+	if shortURL.UserID != user.ID {
+		return nil, ucErrors.ErrUserURLForbidden
+	}
+
+	history, err := u.storage.FindURLHistory(ctx, user.Namespace, alias)
+	if err != nil {
+		return nil, ucErrors.ErrUserStorageNotWorking
+	}
+
+	if history == nil {
+		history = make([]*shortURLEntity.HistoryEntry, 0)
+	}
+
+	return history, nil
 }
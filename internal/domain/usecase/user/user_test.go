@@ -2,10 +2,16 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"testing"
+	"time"
 
 	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
 	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	webhookEntity "github.com/gururuby/shortener/internal/domain/entity/webhook"
 	storageErrors "github.com/gururuby/shortener/internal/domain/storage/errors"
 	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
 	"github.com/gururuby/shortener/internal/domain/usecase/user/mocks"
@@ -35,14 +41,14 @@ func Test_Authenticate_OK(t *testing.T) {
 		{
 			name:       "when read ID from token and record exist in db",
 			ID:         1,
-			storageRes: storageRes{user: &userEntity.User{ID: 1}},
-			res:        &userEntity.User{ID: 1},
+			storageRes: storageRes{user: &userEntity.User{ID: 1, IsActive: true}},
+			res:        &userEntity.User{ID: 1, IsActive: true},
 		},
 	}
 	for _, tt := range tests {
 		auth.EXPECT().ReadUserID(tt.token).Return(tt.ID, nil)
 		storage.EXPECT().FindUser(ctx, tt.ID).Return(tt.storageRes.user, nil).AnyTimes()
-		uc := NewUserUseCase(auth, storage, "http://localhost:8080")
+		uc := NewUserUseCase(auth, storage, nil, "http://localhost:8080")
 
 		t.Run(tt.name, func(t *testing.T) {
 			res, err := uc.Authenticate(ctx, tt.token)
@@ -94,7 +100,7 @@ func Test_Authenticate_Errors(t *testing.T) {
 	for _, tt := range tests {
 		auth.EXPECT().ReadUserID(tt.token).Return(tt.authRes.userID, tt.authRes.err).AnyTimes()
 		storage.EXPECT().FindUser(ctx, tt.authRes).Return(tt.storageRes.user, tt.storageRes.err).AnyTimes()
-		uc := NewUserUseCase(auth, storage, "http://localhost:8080")
+		uc := NewUserUseCase(auth, storage, nil, "http://localhost:8080")
 
 		t.Run(tt.name, func(t *testing.T) {
 			_, err := uc.Authenticate(ctx, tt.token)
@@ -103,6 +109,20 @@ func Test_Authenticate_Errors(t *testing.T) {
 	}
 }
 
+func Test_Authenticate_Deactivated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockUserStorage(ctrl)
+	auth := mocks.NewMockAuthenticator(ctrl)
+	ctx := context.Background()
+
+	auth.EXPECT().ReadUserID("token").Return(1, nil)
+	storage.EXPECT().FindUser(ctx, 1).Return(&userEntity.User{ID: 1, IsActive: false}, nil)
+
+	res, err := NewUserUseCase(auth, storage, nil, "http://localhost:8080").Authenticate(ctx, "token")
+	require.ErrorIs(t, err, ucErrors.ErrUserDeactivated)
+	require.Nil(t, res)
+}
+
 func Test_Register_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	storage := mocks.NewMockUserStorage(ctrl)
@@ -137,7 +157,7 @@ func Test_Register_OK(t *testing.T) {
 	for _, tt := range tests {
 		storage.EXPECT().SaveUser(ctx).Return(tt.storageRes.user, nil).Times(1)
 		auth.EXPECT().SignUserID(tt.storageRes.user.ID).Return(tt.authRes.token, nil).Times(1)
-		uc := NewUserUseCase(auth, storage, "http://localhost:8080")
+		uc := NewUserUseCase(auth, storage, nil, "http://localhost:8080")
 
 		t.Run(tt.name, func(t *testing.T) {
 			res, err := uc.Register(ctx)
@@ -190,7 +210,7 @@ func Test_Register_Errors(t *testing.T) {
 			auth.EXPECT().SignUserID(tt.storageRes.user.ID).Return(tt.authRes.token, tt.authRes.err).Times(1)
 		}
 
-		uc := NewUserUseCase(auth, storage, "http://localhost:8080")
+		uc := NewUserUseCase(auth, storage, nil, "http://localhost:8080")
 
 		t.Run(tt.name, func(t *testing.T) {
 			_, err := uc.Register(ctx)
@@ -224,7 +244,7 @@ func Test_FindUser_OK(t *testing.T) {
 	}
 	for _, tt := range tests {
 		storage.EXPECT().FindUser(ctx, tt.ID).Return(tt.storageRes.user, nil).AnyTimes()
-		uc := NewUserUseCase(auth, storage, "http://localhost:8080")
+		uc := NewUserUseCase(auth, storage, nil, "http://localhost:8080")
 
 		t.Run(tt.name, func(t *testing.T) {
 			res, err := uc.FindUser(ctx, tt.ID)
@@ -261,7 +281,7 @@ func Test_FindUser_Errors(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			storage.EXPECT().FindUser(ctx, tt.ID).Return(tt.storageRes.user, tt.storageRes.err).AnyTimes()
-			uc := NewUserUseCase(auth, storage, "http://localhost:8080")
+			uc := NewUserUseCase(auth, storage, nil, "http://localhost:8080")
 			_, err := uc.FindUser(ctx, tt.ID)
 			require.ErrorIs(t, tt.err, err)
 		})
@@ -291,7 +311,7 @@ func Test_SaveUser_OK(t *testing.T) {
 	}
 	for _, tt := range tests {
 		storage.EXPECT().SaveUser(ctx).Return(tt.storageRes.user, nil)
-		uc := NewUserUseCase(auth, storage, "http://localhost:8080")
+		uc := NewUserUseCase(auth, storage, nil, "http://localhost:8080")
 
 		t.Run(tt.name, func(t *testing.T) {
 			res, err := uc.SaveUser(ctx)
@@ -328,7 +348,7 @@ func Test_SaveUser_Errors(t *testing.T) {
 	}
 	for _, tt := range tests {
 		storage.EXPECT().SaveUser(ctx).Return(tt.storageRes.user, tt.storageRes.err).AnyTimes()
-		uc := NewUserUseCase(auth, storage, "http://localhost:8080")
+		uc := NewUserUseCase(auth, storage, nil, "http://localhost:8080")
 
 		t.Run(tt.name, func(t *testing.T) {
 			_, err := uc.SaveUser(ctx)
@@ -345,12 +365,18 @@ func Test_GetURLs_OK(t *testing.T) {
 
 	urls := make([]*shortURLEntity.ShortURL, 0)
 	urls = append(urls, &shortURLEntity.ShortURL{Alias: "alias", SourceURL: "https://ya.ru"})
+	urls = append(urls, &shortURLEntity.ShortURL{Alias: "regional", SourceURL: "https://go.dev", CreatedInRegion: "us-east-1"})
 
 	userURLs := make([]*UserShortURL, 0)
 	userURLs = append(userURLs, &UserShortURL{
 		OriginalURL: "https://ya.ru",
 		ShortURL:    "http://localhost:8080/alias",
 	})
+	userURLs = append(userURLs, &UserShortURL{
+		OriginalURL: "https://go.dev",
+		ShortURL:    "http://localhost:8080/regional",
+		Region:      "us-east-1",
+	})
 
 	type storageRes struct {
 		err  error
@@ -370,7 +396,7 @@ func Test_GetURLs_OK(t *testing.T) {
 	}
 	for _, tt := range tests {
 		storage.EXPECT().FindURLs(ctx, 1).Return(tt.storageRes.urls, tt.storageRes.err).Times(1)
-		uc := NewUserUseCase(auth, storage, "http://localhost:8080")
+		uc := NewUserUseCase(auth, storage, nil, "http://localhost:8080")
 
 		t.Run(tt.name, func(t *testing.T) {
 			res, err := uc.GetURLs(ctx, &userEntity.User{ID: 1})
@@ -407,7 +433,7 @@ func Test_GetURLs_Errors(t *testing.T) {
 	}
 	for _, tt := range tests {
 		storage.EXPECT().FindURLs(ctx, 1).Return(tt.storageRes.urls, tt.storageRes.err).AnyTimes()
-		uc := NewUserUseCase(auth, storage, "http://localhost:8080")
+		uc := NewUserUseCase(auth, storage, nil, "http://localhost:8080")
 
 		t.Run(tt.name, func(t *testing.T) {
 			_, err := uc.GetURLs(ctx, &userEntity.User{ID: 1})
@@ -415,3 +441,820 @@ func Test_GetURLs_Errors(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetURLsPaginated_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockUserStorage(ctrl)
+	auth := mocks.NewMockAuthenticator(ctrl)
+	ctx := context.Background()
+
+	urls := []*shortURLEntity.ShortURL{{Alias: "alias", SourceURL: "https://ya.ru"}}
+	userURLs := []*UserShortURL{{ShortURL: "http://localhost:8080/alias", OriginalURL: "https://ya.ru"}}
+
+	type (
+		storageInput struct {
+			offset int
+			limit  int
+		}
+		storageRes struct {
+			urls  []*shortURLEntity.ShortURL
+			total int64
+		}
+	)
+
+	tests := []struct {
+		name         string
+		page         int
+		perPage      int
+		storageInput storageInput
+		storageRes   storageRes
+		res          *PaginatedURLs
+	}{
+		{
+			name:         "when page and per_page are in range",
+			page:         2,
+			perPage:      10,
+			storageInput: storageInput{offset: 10, limit: 10},
+			storageRes:   storageRes{urls: urls, total: 11},
+			res:          &PaginatedURLs{Items: userURLs, Total: 11, Page: 2, TotalPages: 2},
+		},
+		{
+			name:         "when page is zero",
+			page:         0,
+			perPage:      10,
+			storageInput: storageInput{offset: 0, limit: 10},
+			storageRes:   storageRes{urls: urls, total: 1},
+			res:          &PaginatedURLs{Items: userURLs, Total: 1, Page: DefaultPage, TotalPages: 1},
+		},
+		{
+			name:         "when per_page is not passed",
+			page:         1,
+			perPage:      0,
+			storageInput: storageInput{offset: 0, limit: DefaultPerPage},
+			storageRes:   storageRes{urls: urls, total: 1},
+			res:          &PaginatedURLs{Items: userURLs, Total: 1, Page: 1, TotalPages: 1},
+		},
+		{
+			name:         "when per_page is greater than max",
+			page:         2,
+			perPage:      1000,
+			storageInput: storageInput{offset: MaxPerPage, limit: MaxPerPage},
+			storageRes:   storageRes{urls: urls, total: 201},
+			res:          &PaginatedURLs{Items: userURLs, Total: 201, Page: 2, TotalPages: 2},
+		},
+		{
+			name:         "when user has no urls",
+			page:         1,
+			perPage:      10,
+			storageInput: storageInput{offset: 0, limit: 10},
+			storageRes:   storageRes{urls: nil, total: 0},
+			res:          &PaginatedURLs{Items: []*UserShortURL{}, Total: 0, Page: 1, TotalPages: 0},
+		},
+	}
+	for _, tt := range tests {
+		storage.EXPECT().
+			FindURLsPaginated(ctx, 1, tt.storageInput.offset, tt.storageInput.limit).
+			Return(tt.storageRes.urls, tt.storageRes.total, nil).
+			Times(1)
+		uc := NewUserUseCase(auth, storage, nil, "http://localhost:8080")
+
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := uc.GetURLsPaginated(ctx, &userEntity.User{ID: 1}, tt.page, tt.perPage)
+			require.NoError(t, err)
+			require.Equal(t, tt.res, res)
+		})
+	}
+}
+
+func Test_GetURLsPaginated_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockUserStorage(ctrl)
+	auth := mocks.NewMockAuthenticator(ctrl)
+	ctx := context.Background()
+
+	storage.EXPECT().FindURLsPaginated(ctx, 1, 0, DefaultPerPage).Return(nil, int64(0), storageErrors.ErrStorageIsNotReadyDB)
+	uc := NewUserUseCase(auth, storage, nil, "http://localhost:8080")
+
+	res, err := uc.GetURLsPaginated(ctx, &userEntity.User{ID: 1}, 1, DefaultPerPage)
+	require.ErrorIs(t, err, ucErrors.ErrUserStorageNotWorking)
+	require.Nil(t, res)
+}
+
+func Test_SearchURLs(t *testing.T) {
+	ctx := context.Background()
+	urls := []*shortURLEntity.ShortURL{{Alias: "alias", SourceURL: "https://github.com/gururuby"}}
+
+	tests := []struct {
+		storageErr error
+		err        error
+		res        *PaginatedURLs
+		name       string
+		query      string
+		page       int
+		perPage    int
+		offset     int
+		limit      int
+	}{
+		{
+			name:    "when URLs are found",
+			query:   "github",
+			page:    2,
+			perPage: 10,
+			offset:  10,
+			limit:   10,
+			res: &PaginatedURLs{
+				Items:      []*UserShortURL{{ShortURL: "http://localhost:8080/alias", OriginalURL: "https://github.com/gururuby"}},
+				Total:      11,
+				Page:       2,
+				TotalPages: 2,
+			},
+		},
+		{
+			name:    "when pagination is out of range",
+			query:   "github",
+			perPage: 1000,
+			offset:  0,
+			limit:   MaxPerPage,
+			res: &PaginatedURLs{
+				Items:      []*UserShortURL{{ShortURL: "http://localhost:8080/alias", OriginalURL: "https://github.com/gururuby"}},
+				Total:      11,
+				Page:       DefaultPage,
+				TotalPages: 1,
+			},
+		},
+		{
+			name:       "when storage fails",
+			query:      "github",
+			limit:      DefaultPerPage,
+			storageErr: storageErrors.ErrStorageIsNotReadyDB,
+			err:        ucErrors.ErrUserStorageNotWorking,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockUserStorage(ctrl)
+			storage.EXPECT().SearchURLs(ctx, 1, tt.query, tt.offset, tt.limit).Return(urls, int64(11), tt.storageErr)
+
+			uc := NewUserUseCase(mocks.NewMockAuthenticator(ctrl), storage, nil, "http://localhost:8080")
+			res, err := uc.SearchURLs(ctx, &userEntity.User{ID: 1}, tt.query, tt.page, tt.perPage)
+			require.ErrorIs(t, err, tt.err)
+			require.Equal(t, tt.res, res)
+		})
+	}
+}
+
+func Test_GetURLsCursor_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockUserStorage(ctrl)
+	auth := mocks.NewMockAuthenticator(ctrl)
+	ctx := context.Background()
+
+	urls := []*shortURLEntity.ShortURL{{ID: 7, Alias: "alias", SourceURL: "https://ya.ru"}}
+	userURLs := []*UserShortURL{{ShortURL: "http://localhost:8080/alias", OriginalURL: "https://ya.ru"}}
+
+	tests := []struct {
+		name         string
+		cursor       int
+		limit        int
+		storageLimit int
+		nextCursor   int
+		res          *CursorPage
+	}{
+		{
+			name:         "when there is a next page",
+			cursor:       6,
+			limit:        1,
+			storageLimit: 1,
+			nextCursor:   7,
+			res:          &CursorPage{Items: userURLs, NextCursor: EncodeCursor(7)},
+		},
+		{
+			name:         "when it is the last page",
+			cursor:       6,
+			limit:        10,
+			storageLimit: 10,
+			res:          &CursorPage{Items: userURLs},
+		},
+		{
+			name:         "when limit is not passed",
+			storageLimit: DefaultPerPage,
+			res:          &CursorPage{Items: userURLs},
+		},
+		{
+			name:         "when limit is greater than max",
+			limit:        1000,
+			storageLimit: MaxPerPage,
+			res:          &CursorPage{Items: userURLs},
+		},
+	}
+	for _, tt := range tests {
+		storage.EXPECT().FindURLsCursor(ctx, 1, tt.cursor, tt.storageLimit).Return(urls, tt.nextCursor, nil)
+		uc := NewUserUseCase(auth, storage, nil, "http://localhost:8080")
+
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := uc.GetURLsCursor(ctx, &userEntity.User{ID: 1}, tt.cursor, tt.limit)
+			require.NoError(t, err)
+			require.Equal(t, tt.res, res)
+		})
+	}
+}
+
+func Test_GetURLsCursor_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockUserStorage(ctrl)
+	auth := mocks.NewMockAuthenticator(ctrl)
+	ctx := context.Background()
+
+	storage.EXPECT().FindURLsCursor(ctx, 1, 0, DefaultPerPage).Return(nil, 0, storageErrors.ErrStorageIsNotReadyDB)
+	uc := NewUserUseCase(auth, storage, nil, "http://localhost:8080")
+
+	res, err := uc.GetURLsCursor(ctx, &userEntity.User{ID: 1}, 0, DefaultPerPage)
+	require.ErrorIs(t, err, ucErrors.ErrUserStorageNotWorking)
+	require.Nil(t, res)
+}
+
+func Test_ExportURLs_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockUserStorage(ctrl)
+	auth := mocks.NewMockAuthenticator(ctrl)
+	ctx := context.Background()
+	createdAt := time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC)
+
+	var events []string
+
+	gomock.InOrder(
+		storage.EXPECT().FindURLsCursor(ctx, 1, 0, exportBatch).DoAndReturn(
+			func(context.Context, int, int, int) ([]*shortURLEntity.ShortURL, int, error) {
+				events = append(events, "fetch 1")
+				return []*shortURLEntity.ShortURL{{ID: 7, Alias: "first", SourceURL: "https://ya.ru", CreatedAt: createdAt}}, 7, nil
+			}),
+		storage.EXPECT().FindURLsCursor(ctx, 1, 7, exportBatch).DoAndReturn(
+			func(context.Context, int, int, int) ([]*shortURLEntity.ShortURL, int, error) {
+				events = append(events, "fetch 2")
+				return []*shortURLEntity.ShortURL{{ID: 8, Alias: "second", SourceURL: "https://google.com", IsDeleted: true}}, 0, nil
+			}),
+	)
+
+	uc := NewUserUseCase(auth, storage, nil, "http://localhost:8080")
+
+	var exported []*ExportedURL
+	err := uc.ExportURLs(ctx, &userEntity.User{ID: 1}, func(batch []*ExportedURL) error {
+		events = append(events, "write")
+		exported = append(exported, batch...)
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"fetch 1", "write", "fetch 2", "write"}, events)
+	require.Equal(t, []*ExportedURL{
+		{ShortURL: "http://localhost:8080/first", OriginalURL: "https://ya.ru", CreatedAt: createdAt},
+		{ShortURL: "http://localhost:8080/second", OriginalURL: "https://google.com", IsDeleted: true},
+	}, exported)
+}
+
+func Test_ExportURLs_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockUserStorage(ctrl)
+	auth := mocks.NewMockAuthenticator(ctrl)
+	ctx := context.Background()
+	uc := NewUserUseCase(auth, storage, nil, "http://localhost:8080")
+	writeErr := errors.New("connection closed")
+
+	t.Run("when storage fails", func(t *testing.T) {
+		storage.EXPECT().FindURLsCursor(ctx, 1, 0, exportBatch).Return(nil, 0, storageErrors.ErrStorageIsNotReadyDB)
+
+		err := uc.ExportURLs(ctx, &userEntity.User{ID: 1}, func([]*ExportedURL) error { return nil })
+		require.ErrorIs(t, err, ucErrors.ErrUserStorageNotWorking)
+	})
+
+	t.Run("when callback fails", func(t *testing.T) {
+		storage.EXPECT().FindURLsCursor(ctx, 1, 0, exportBatch).Return([]*shortURLEntity.ShortURL{{ID: 7, Alias: "alias"}}, 7, nil)
+
+		err := uc.ExportURLs(ctx, &userEntity.User{ID: 1}, func([]*ExportedURL) error { return writeErr })
+		require.ErrorIs(t, err, writeErr)
+	})
+}
+
+func Test_DecodeCursor(t *testing.T) {
+	tests := []struct {
+		err    error
+		name   string
+		cursor string
+		id     int
+	}{
+		{
+			name:   "when cursor is built by EncodeCursor",
+			cursor: EncodeCursor(42),
+			id:     42,
+		},
+		{
+			name:   "when cursor is not base64",
+			cursor: "!!!",
+			err:    ucErrors.ErrUserInvalidCursor,
+		},
+		{
+			name:   "when cursor does not wrap an ID",
+			cursor: base64.RawURLEncoding.EncodeToString([]byte("alias")),
+			err:    ucErrors.ErrUserInvalidCursor,
+		},
+		{
+			name:   "when cursor wraps negative ID",
+			cursor: EncodeCursor(-1),
+			err:    ucErrors.ErrUserInvalidCursor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := DecodeCursor(tt.cursor)
+			require.ErrorIs(t, err, tt.err)
+			require.Equal(t, tt.id, id)
+		})
+	}
+}
+
+func Test_RevokeToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockUserStorage(ctrl)
+	auth := mocks.NewMockAuthenticator(ctrl)
+	ctx := context.Background()
+
+	tests := []struct {
+		authErr error
+		err     error
+		name    string
+	}{
+		{
+			name: "when token is revoked",
+		},
+		{
+			name:    "when token is invalid",
+			authErr: jwtErrors.ErrJWTParseError,
+			err:     ucErrors.ErrUserCannotAuthenticate,
+		},
+		{
+			name:    "when revocation cannot be stored",
+			authErr: jwtErrors.ErrJWTCannotRevoke,
+			err:     ucErrors.ErrUserCannotRevokeToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth.EXPECT().RevokeToken(ctx, "token").Return(tt.authErr)
+			uc := NewUserUseCase(auth, storage, nil, "http://localhost:8080")
+
+			err := uc.RevokeToken(ctx, "token")
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func Test_RestoreURL(t *testing.T) {
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1, Namespace: "team"}
+
+	tests := []struct {
+		setup func(storage *mocks.MockUserStorage)
+		err   error
+		name  string
+	}{
+		{
+			name: "when deleted URL is restored",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindURL(ctx, "team", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 1, IsDeleted: true}, nil)
+				storage.EXPECT().RestoreURL(ctx, 1, "team", "abc").Return(nil)
+			},
+		},
+		{
+			name: "when alias does not exist",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindURL(ctx, "team", "abc").Return(nil, dbErrors.ErrDBRecordNotFound)
+			},
+			err: ucErrors.ErrUserURLNotFound,
+		},
+		{
+			name: "when URL belongs to another user",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindURL(ctx, "team", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 2, IsDeleted: true}, nil)
+			},
+			err: ucErrors.ErrUserURLForbidden,
+		},
+		{
+			name: "when URL is not deleted",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindURL(ctx, "team", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 1}, nil)
+			},
+			err: ucErrors.ErrUserURLNotDeleted,
+		},
+		{
+			name: "when storage fails to find URL",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindURL(ctx, "team", "abc").Return(nil, dbErrors.ErrDBQuery)
+			},
+			err: ucErrors.ErrUserStorageNotWorking,
+		},
+		{
+			name: "when storage fails to restore URL",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindURL(ctx, "team", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 1, IsDeleted: true}, nil)
+				storage.EXPECT().RestoreURL(ctx, 1, "team", "abc").Return(dbErrors.ErrDBQuery)
+			},
+			err: ucErrors.ErrUserStorageNotWorking,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockUserStorage(ctrl)
+			tt.setup(storage)
+
+			uc := NewUserUseCase(mocks.NewMockAuthenticator(ctrl), storage, nil, "http://localhost:8080")
+			err := uc.RestoreURL(ctx, user, "abc")
+			if tt.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func Test_GetArchivedURLs(t *testing.T) {
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1}
+	archivedAt := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		setup   func(storage *mocks.MockUserStorage)
+		want    *ArchivedURLs
+		err     error
+		name    string
+		page    int
+		perPage int
+	}{
+		{
+			name:    "when page of archived URLs is requested",
+			page:    2,
+			perPage: 10,
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindArchivedURLs(ctx, 1, 10, 10).Return([]*shortURLEntity.ArchivedURL{
+					{ShortURL: &shortURLEntity.ShortURL{Alias: "abc", SourceURL: "https://ya.ru", IsDeleted: true}, ArchivedAt: archivedAt},
+					{ShortURL: &shortURLEntity.ShortURL{Alias: "def", SourceURL: "https://go.dev", Namespace: "team", IsDeleted: true}, ArchivedAt: archivedAt},
+				}, nil)
+			},
+			want: &ArchivedURLs{
+				Items: []*ArchivedShortURL{
+					{ArchivedAt: archivedAt, ShortURL: "http://localhost:8080/abc", OriginalURL: "https://ya.ru"},
+					{ArchivedAt: archivedAt, ShortURL: "http://localhost:8080/team/def", OriginalURL: "https://go.dev"},
+				},
+				Page:    2,
+				PerPage: 10,
+			},
+		},
+		{
+			name:    "when invalid pagination is requested",
+			page:    0,
+			perPage: MaxPerPage + 1,
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindArchivedURLs(ctx, 1, MaxPerPage, 0).Return(nil, nil)
+			},
+			want: &ArchivedURLs{Items: []*ArchivedShortURL{}, Page: DefaultPage, PerPage: MaxPerPage},
+		},
+		{
+			name: "when storage fails",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindArchivedURLs(ctx, 1, DefaultPerPage, 0).Return(nil, dbErrors.ErrDBQuery)
+			},
+			err: ucErrors.ErrUserStorageNotWorking,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockUserStorage(ctrl)
+			tt.setup(storage)
+
+			uc := NewUserUseCase(mocks.NewMockAuthenticator(ctrl), storage, nil, "http://localhost:8080")
+			got, err := uc.GetArchivedURLs(ctx, user, tt.page, tt.perPage)
+			require.ErrorIs(t, err, tt.err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_RestoreArchivedURL(t *testing.T) {
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1, Namespace: "team"}
+
+	tests := []struct {
+		storageErr error
+		err        error
+		name       string
+	}{
+		{
+			name: "when archived URL is restored",
+		},
+		{
+			name:       "when URL is not archived",
+			storageErr: dbErrors.ErrDBRecordNotFound,
+			err:        ucErrors.ErrUserURLNotFound,
+		},
+		{
+			name:       "when alias is taken",
+			storageErr: dbErrors.ErrDBIsNotUnique,
+			err:        ucErrors.ErrUserURLAliasTaken,
+		},
+		{
+			name:       "when storage fails",
+			storageErr: dbErrors.ErrDBQuery,
+			err:        ucErrors.ErrUserStorageNotWorking,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockUserStorage(ctrl)
+			storage.EXPECT().RestoreArchivedURL(ctx, 1, "team", "abc").Return(tt.storageErr)
+
+			uc := NewUserUseCase(mocks.NewMockAuthenticator(ctrl), storage, nil, "http://localhost:8080")
+			err := uc.RestoreArchivedURL(ctx, user, "abc")
+			if tt.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func Test_UpdateURL(t *testing.T) {
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1}
+
+	tests := []struct {
+		setup  func(storage *mocks.MockUserStorage)
+		err    error
+		name   string
+		newURL string
+	}{
+		{
+			name:   "when URL is updated",
+			newURL: "https://go.dev",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindURL(ctx, "", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 1, SourceURL: "https://ya.ru"}, nil)
+				storage.EXPECT().UpdateURLTarget(ctx, 1, "", "abc", "https://go.dev").Return(nil)
+			},
+		},
+		{
+			name:   "when new URL is invalid",
+			newURL: "go.dev",
+			setup:  func(_ *mocks.MockUserStorage) {},
+			err:    ucErrors.ErrUserInvalidURL,
+		},
+		{
+			name:   "when alias does not exist",
+			newURL: "https://go.dev",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindURL(ctx, "", "abc").Return(nil, dbErrors.ErrDBRecordNotFound)
+			},
+			err: ucErrors.ErrUserURLNotFound,
+		},
+		{
+			name:   "when URL belongs to another user",
+			newURL: "https://go.dev",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindURL(ctx, "", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 2}, nil)
+			},
+			err: ucErrors.ErrUserURLForbidden,
+		},
+		{
+			name:   "when URL is deleted",
+			newURL: "https://go.dev",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindURL(ctx, "", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 1, IsDeleted: true}, nil)
+			},
+			err: ucErrors.ErrUserURLDeleted,
+		},
+		{
+			name:   "when storage fails to update URL",
+			newURL: "https://go.dev",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindURL(ctx, "", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 1}, nil)
+				storage.EXPECT().UpdateURLTarget(ctx, 1, "", "abc", "https://go.dev").Return(dbErrors.ErrDBQuery)
+			},
+			err: ucErrors.ErrUserStorageNotWorking,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockUserStorage(ctrl)
+			tt.setup(storage)
+
+			uc := NewUserUseCase(mocks.NewMockAuthenticator(ctrl), storage, nil, "http://localhost:8080")
+			err := uc.UpdateURL(ctx, user, "abc", tt.newURL)
+			if tt.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func Test_GetURLHistory(t *testing.T) {
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1}
+	changedAt := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	history := []*shortURLEntity.HistoryEntry{{OriginalURL: "https://ya.ru", ChangedAt: changedAt}}
+
+	tests := []struct {
+		setup func(storage *mocks.MockUserStorage)
+		err   error
+		name  string
+		want  []*shortURLEntity.HistoryEntry
+	}{
+		{
+			name: "when URL was changed",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindURL(ctx, "", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 1}, nil)
+				storage.EXPECT().FindURLHistory(ctx, "", "abc").Return(history, nil)
+			},
+			want: history,
+		},
+		{
+			name: "when URL was never changed",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindURL(ctx, "", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 1}, nil)
+				storage.EXPECT().FindURLHistory(ctx, "", "abc").Return(nil, nil)
+			},
+			want: []*shortURLEntity.HistoryEntry{},
+		},
+		{
+			name: "when alias does not exist",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindURL(ctx, "", "abc").Return(nil, dbErrors.ErrDBRecordNotFound)
+			},
+			err: ucErrors.ErrUserURLNotFound,
+		},
+		{
+			name: "when URL belongs to another user",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindURL(ctx, "", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 2}, nil)
+			},
+			err: ucErrors.ErrUserURLForbidden,
+		},
+		{
+			name: "when storage fails to find history",
+			setup: func(storage *mocks.MockUserStorage) {
+				storage.EXPECT().FindURL(ctx, "", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 1}, nil)
+				storage.EXPECT().FindURLHistory(ctx, "", "abc").Return(nil, dbErrors.ErrDBQuery)
+			},
+			err: ucErrors.ErrUserStorageNotWorking,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockUserStorage(ctrl)
+			tt.setup(storage)
+
+			uc := NewUserUseCase(mocks.NewMockAuthenticator(ctrl), storage, nil, "http://localhost:8080")
+			res, err := uc.GetURLHistory(ctx, user, "abc")
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func Test_DeleteURLs_NotifiesWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockUserStorage(ctrl)
+	notifier := mocks.NewMockWebhookNotifier(ctrl)
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1, Namespace: "team"}
+	delivered := make(chan struct{})
+
+	storage.EXPECT().MarkURLAsDeleted(ctx, 1, "team", []string{"abc"}).Return(nil)
+	notifier.EXPECT().
+		Deliver(gomock.Any(), 1, webhookEntity.EventURLDeleted, &webhookEntity.URLsDeletedData{Aliases: []string{"abc"}}).
+		DoAndReturn(func(context.Context, int, string, any) error {
+			close(delivered)
+			return nil
+		})
+
+	NewUserUseCase(mocks.NewMockAuthenticator(ctrl), storage, notifier, "http://localhost:8080").DeleteURLs(ctx, user, []string{"abc"})
+	<-delivered
+}
+
+func Test_APIKeys(t *testing.T) {
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1}
+
+	t.Run("when API key is created", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		storage := mocks.NewMockUserStorage(ctrl)
+
+		var storedHash string
+		storage.EXPECT().SaveAPIKey(ctx, 1, gomock.Any()).DoAndReturn(func(_ context.Context, _ int, keyHash string) error {
+			storedHash = keyHash
+			return nil
+		})
+
+		key, err := NewUserUseCase(mocks.NewMockAuthenticator(ctrl), storage, nil, "http://localhost:8080").CreateAPIKey(ctx, user)
+		require.NoError(t, err)
+		require.Regexp(t, `^[0-9a-f]{64}$`, key)
+
+		sum := sha256.Sum256([]byte(key))
+		require.Equal(t, hex.EncodeToString(sum[:]), storedHash, "only hash of the key is stored")
+	})
+
+	t.Run("when API key cannot be stored", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		storage := mocks.NewMockUserStorage(ctrl)
+		storage.EXPECT().SaveAPIKey(ctx, 1, gomock.Any()).Return(dbErrors.ErrDBQuery)
+
+		_, err := NewUserUseCase(mocks.NewMockAuthenticator(ctrl), storage, nil, "http://localhost:8080").CreateAPIKey(ctx, user)
+		require.ErrorIs(t, err, ucErrors.ErrUserCannotCreateAPIKey)
+	})
+
+	authTests := []struct {
+		storageRes *userEntity.User
+		storageErr error
+		err        error
+		name       string
+	}{
+		{name: "when API key is valid", storageRes: &userEntity.User{ID: 1, IsActive: true}},
+		{name: "when API key is unknown or revoked", storageErr: dbErrors.ErrDBRecordNotFound, err: ucErrors.ErrUserInvalidAPIKey},
+		{name: "when owner of API key is deactivated", storageRes: &userEntity.User{ID: 1}, err: ucErrors.ErrUserDeactivated},
+	}
+	for _, tt := range authTests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockUserStorage(ctrl)
+			storage.EXPECT().FindUserByAPIKey(ctx, hashAPIKey("key")).Return(tt.storageRes, tt.storageErr)
+
+			res, err := NewUserUseCase(mocks.NewMockAuthenticator(ctrl), storage, nil, "http://localhost:8080").AuthenticateAPIKey(ctx, "key")
+			require.ErrorIs(t, err, tt.err)
+			if tt.err == nil {
+				require.Equal(t, &userEntity.User{ID: 1, APIKey: "key", IsActive: true}, res)
+			}
+		})
+	}
+
+	revokeTests := []struct {
+		storageErr error
+		err        error
+		name       string
+	}{
+		{name: "when API key is revoked"},
+		{name: "when API key is not found", storageErr: dbErrors.ErrDBRecordNotFound, err: ucErrors.ErrUserAPIKeyNotFound},
+		{name: "when storage fails on revoke", storageErr: dbErrors.ErrDBQuery, err: ucErrors.ErrUserStorageNotWorking},
+	}
+	for _, tt := range revokeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockUserStorage(ctrl)
+			storage.EXPECT().RevokeAPIKey(ctx, 1, hashAPIKey("key")).Return(tt.storageErr)
+
+			err := NewUserUseCase(mocks.NewMockAuthenticator(ctrl), storage, nil, "http://localhost:8080").RevokeAPIKey(ctx, user, "key")
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func Test_SetUserActive(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		storageErr error
+		err        error
+		name       string
+		isActive   bool
+	}{
+		{name: "when user is deactivated"},
+		{name: "when user is reactivated", isActive: true},
+		{name: "when user is not found", storageErr: dbErrors.ErrDBRecordNotFound, err: ucErrors.ErrUserNotFound},
+		{name: "when storage fails", storageErr: dbErrors.ErrDBQuery, err: ucErrors.ErrUserStorageNotWorking},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockUserStorage(ctrl)
+			storage.EXPECT().SetUserActive(ctx, 1, tt.isActive).Return(tt.storageErr)
+
+			uc := NewUserUseCase(mocks.NewMockAuthenticator(ctrl), storage, nil, "http://localhost:8080")
+			var err error
+			if tt.isActive {
+				err = uc.ReactivateUser(ctx, 1)
+			} else {
+				err = uc.DeactivateUser(ctx, 1)
+			}
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
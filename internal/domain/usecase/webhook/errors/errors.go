@@ -0,0 +1,56 @@
+// Package usecase contains application business logic and acts as an intermediary
+// between the presentation layer (e.g., HTTP handlers) and the data layer (e.g., database).
+// It defines webhook-specific errors.
+package usecase
+
+import "errors"
+
+// Errors list
+var (
+	// ErrWebhookInvalidURL indicates the webhook URL is not a valid HTTP or HTTPS URL.
+	//
+	// Handling recommendations:
+	// - Return HTTP 422 (Unprocessable Entity) in web handlers
+	ErrWebhookInvalidURL = errors.New("webhook URL must be a valid HTTP or HTTPS URL")
+
+	// ErrWebhookInvalidEvents indicates the webhook has no events or an unknown event.
+	//
+	// Supported events:
+	// - url.created
+	// - url.deleted
+	// - url.clicked
+	//
+	// Handling recommendations:
+	// - Return HTTP 422 (Unprocessable Entity) in web handlers
+	ErrWebhookInvalidEvents = errors.New("webhook events must be a non-empty list of url.created, url.deleted, url.clicked")
+
+	// ErrWebhookNotFound indicates the user has no webhook with the passed ID.
+	//
+	// Privacy note:
+	// - Webhooks of other users are reported the same way
+	//
+	// Handling recommendations:
+	// - Return HTTP 404 (Not Found) in web handlers
+	ErrWebhookNotFound = errors.New("webhook is not found")
+
+	// ErrWebhookCannotGenerateSecret indicates failure reading random bytes for webhook secret.
+	//
+	// Handling recommendations:
+	// - Return HTTP 500 (Internal Server Error) in web handlers
+	ErrWebhookCannotGenerateSecret = errors.New("cannot generate webhook secret")
+
+	// ErrWebhookDeliveryFailed indicates the webhook endpoint did not accept an event
+	// after all delivery attempts.
+	//
+	// Common causes:
+	// - Endpoint is unreachable or responds slower than the delivery timeout
+	// - Endpoint responds with non-2xx status
+	ErrWebhookDeliveryFailed = errors.New("webhook delivery failed")
+
+	// ErrWebhookStorageNotWorking indicates failure of the storage holding webhooks.
+	//
+	// Handling recommendations:
+	// - Return HTTP 500 (Internal Server Error) in web handlers
+	// - Check database logs for the failed query
+	ErrWebhookStorageNotWorking = errors.New("webhook storage is not working")
+)
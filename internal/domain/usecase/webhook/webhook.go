@@ -0,0 +1,260 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . Storage
+
+/*
+Package usecase implements the application's business logic layer.
+
+It contains:
+- Registration of user webhooks
+- Signed delivery of short URL lifecycle events with retries
+- Error handling specific to webhook operations
+*/
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	webhookEntity "github.com/gururuby/shortener/internal/domain/entity/webhook"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/webhook/errors"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/gururuby/shortener/pkg/retry"
+	"github.com/gururuby/shortener/pkg/validator"
+)
+
+// Delivery settings and headers.
+const (
+	SignatureHeader  = "X-Signature-256" // Header with HMAC-SHA256 signature of the payload
+	EventHeader      = "X-Webhook-Event" // Header with the event name
+	signaturePrefix  = "sha256="         // Prefix of the hex encoded signature
+	deliveryTimeout  = 5 * time.Second   // Timeout of a single delivery attempt
+	deliveryAttempts = 4                 // The first delivery attempt and up to 3 retries
+	retryBaseDelay   = time.Second       // Delay before the first retry, doubled for the next ones
+	retryMaxDelay    = 10 * time.Second  // Upper bound of delay between retries
+	retryJitter      = 0.2               // Random shift of delay between retries
+	secretLength     = 32                // Number of random bytes in webhook secret
+)
+
+// supportedEvents lists events webhooks can be subscribed to.
+var supportedEvents = []string{webhookEntity.EventURLCreated, webhookEntity.EventURLDeleted, webhookEntity.EventURLClicked}
+
+// Storage defines the interface for storage operations required by webhook use cases.
+type Storage interface {
+	// SaveWebhook stores a new webhook of a user
+	SaveWebhook(ctx context.Context, webhook *webhookEntity.Webhook) (*webhookEntity.Webhook, error)
+	// FindWebhooksByUser retrieves all webhooks of a user
+	FindWebhooksByUser(ctx context.Context, userID int) ([]*webhookEntity.Webhook, error)
+	// DeleteWebhook removes a webhook of a user
+	DeleteWebhook(ctx context.Context, userID, id int) error
+}
+
+// Payload represents the JSON body posted to webhooks.
+type Payload struct {
+	OccurredAt time.Time `json:"occurred_at"` // Time of the event
+	Data       any       `json:"data"`        // Event specific data
+	Event      string    `json:"event"`       // Event name
+}
+
+// WebhookUseCase implements webhook use cases.
+type WebhookUseCase struct {
+	storage    Storage       // Storage layer interface
+	client     *http.Client  // HTTP client posting payloads
+	retryDelay time.Duration // Delay before the first retry
+}
+
+// NewWebhookUseCase creates a new instance of WebhookUseCase.
+// Parameters:
+// - storage: Implementation of the Storage interface
+// Returns:
+// - *WebhookUseCase: Initialized webhook use case instance
+func NewWebhookUseCase(storage Storage) *WebhookUseCase {
+	return &WebhookUseCase{
+		storage:    storage,
+		client:     &http.Client{Timeout: deliveryTimeout},
+		retryDelay: retryBaseDelay,
+	}
+}
+
+// Register creates a new webhook of a user with a random secret.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: Owner of the webhook
+// - url: Endpoint receiving events
+// - events: Events to deliver, duplicates are removed
+// Returns:
+// - *webhookEntity.Webhook: Created webhook with the secret used to sign payloads
+// - error: ErrWebhookInvalidURL, ErrWebhookInvalidEvents, ErrWebhookCannotGenerateSecret or ErrWebhookStorageNotWorking
+func (uc *WebhookUseCase) Register(ctx context.Context, user *userEntity.User, url string, events []string) (*webhookEntity.Webhook, error) {
+	var (
+		webhook *webhookEntity.Webhook
+		secret  string
+		err     error
+	)
+
+	if validator.IsInvalidURL(url) {
+		return nil, ucErrors.ErrWebhookInvalidURL
+	}
+
+	if events, err = normalizeEvents(events); err != nil {
+		return nil, err
+	}
+
+	if secret, err = generateSecret(); err != nil {
+		return nil, ucErrors.ErrWebhookCannotGenerateSecret
+	}
+
+	webhook = &webhookEntity.Webhook{UserID: user.ID, URL: url, Events: events, Secret: secret}
+	if webhook, err = uc.storage.SaveWebhook(ctx, webhook); err != nil {
+		return nil, ucErrors.ErrWebhookStorageNotWorking
+	}
+
+	return webhook, nil
+}
+
+// Unregister removes a webhook of a user, so events are not delivered to it anymore.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - user: Owner of the webhook
+// - id: Webhook ID
+// Returns:
+// - error: ErrWebhookNotFound or ErrWebhookStorageNotWorking
+func (uc *WebhookUseCase) Unregister(ctx context.Context, user *userEntity.User, id int) error {
+	if err := uc.storage.DeleteWebhook(ctx, user.ID, id); err != nil {
+		if errors.Is(err, dbErrors.ErrDBRecordNotFound) {
+			return ucErrors.ErrWebhookNotFound
+		}
+		return ucErrors.ErrWebhookStorageNotWorking
+	}
+	return nil
+}
+
+// Deliver posts the event to all webhooks of a user subscribed to it.
+// Each payload is signed with the webhook secret and passed in SignatureHeader.
+// Delivery to a webhook is retried with exponential backoff while the endpoint
+// fails or responds with non-2xx status.
+// Parameters:
+// - ctx: Context for cancellation, stops pending retries when done
+// - userID: Owner of the webhooks
+// - event: Event name
+// - data: Event specific data
+// Returns:
+// - error: ErrWebhookStorageNotWorking, or ErrWebhookDeliveryFailed joined for every failed webhook
+func (uc *WebhookUseCase) Deliver(ctx context.Context, userID int, event string, data any) error {
+	var errs []error
+
+	webhooks, err := uc.storage.FindWebhooksByUser(ctx, userID)
+	if err != nil {
+		return ucErrors.ErrWebhookStorageNotWorking
+	}
+
+	body, err := json.Marshal(&Payload{Event: event, OccurredAt: time.Now().UTC(), Data: data})
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		if !webhook.IsSubscribed(event) {
+			continue
+		}
+
+		err = utils.ExponentialBackoff(func() error {
+			return uc.post(ctx, webhook, event, body)
+		}, deliveryAttempts, uc.retryDelay, retryMaxDelay, retryJitter, utils.WithContext(ctx))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: webhook %d: %w", ucErrors.ErrWebhookDeliveryFailed, webhook.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// post makes a single delivery attempt of the payload.
+// Parameters:
+// - ctx: Context for cancellation
+// - webhook: Receiver of the payload
+// - event: Event name
+// - body: Serialized payload
+// Returns:
+// - error: If request fails or endpoint responds with non-2xx status
+func (uc *WebhookUseCase) post(ctx context.Context, webhook *webhookEntity.Webhook, event string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+
+	resp, err := uc.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign computes the signature of a payload passed in SignatureHeader.
+// Parameters:
+// - secret: Webhook secret
+// - body: Serialized payload
+// Returns:
+// - string: "sha256=" followed by hex encoded HMAC-SHA256 of body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// normalizeEvents validates webhook events and removes duplicates.
+// Parameters:
+// - events: Events passed by user
+// Returns:
+// - []string: Unique events in passed order
+// - error: ErrWebhookInvalidEvents if events are empty or contain unknown event
+func normalizeEvents(events []string) ([]string, error) {
+	if len(events) == 0 {
+		return nil, ucErrors.ErrWebhookInvalidEvents
+	}
+
+	unique := make([]string, 0, len(events))
+	for _, event := range events {
+		if !slices.Contains(supportedEvents, event) {
+			return nil, ucErrors.ErrWebhookInvalidEvents
+		}
+		if !slices.Contains(unique, event) {
+			unique = append(unique, event)
+		}
+	}
+
+	return unique, nil
+}
+
+// generateSecret builds a random webhook secret.
+// Returns:
+// - string: Hex encoded random bytes
+// - error: If random bytes cannot be read
+func generateSecret() (string, error) {
+	b := make([]byte, secretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	webhookEntity "github.com/gururuby/shortener/internal/domain/entity/webhook"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/webhook/errors"
+	"github.com/gururuby/shortener/internal/domain/usecase/webhook/mocks"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Register(t *testing.T) {
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1}
+
+	tests := []struct {
+		setup      func(storage *mocks.MockStorage)
+		wantErr    error
+		name       string
+		url        string
+		events     []string
+		wantEvents []string
+	}{
+		{
+			name:   "when webhook is registered",
+			url:    "https://example.com/hook",
+			events: []string{webhookEntity.EventURLCreated, webhookEntity.EventURLDeleted, webhookEntity.EventURLCreated},
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().SaveWebhook(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, w *webhookEntity.Webhook) (*webhookEntity.Webhook, error) {
+					w.ID = 1
+					return w, nil
+				})
+			},
+			wantEvents: []string{webhookEntity.EventURLCreated, webhookEntity.EventURLDeleted},
+		},
+		{
+			name:    "when URL is invalid",
+			url:     "ftp://example.com",
+			events:  []string{webhookEntity.EventURLCreated},
+			setup:   func(_ *mocks.MockStorage) {},
+			wantErr: ucErrors.ErrWebhookInvalidURL,
+		},
+		{
+			name:    "when events are empty",
+			url:     "https://example.com/hook",
+			setup:   func(_ *mocks.MockStorage) {},
+			wantErr: ucErrors.ErrWebhookInvalidEvents,
+		},
+		{
+			name:    "when event is unknown",
+			url:     "https://example.com/hook",
+			events:  []string{"url.updated"},
+			setup:   func(_ *mocks.MockStorage) {},
+			wantErr: ucErrors.ErrWebhookInvalidEvents,
+		},
+		{
+			name:   "when storage fails",
+			url:    "https://example.com/hook",
+			events: []string{webhookEntity.EventURLClicked},
+			setup: func(storage *mocks.MockStorage) {
+				storage.EXPECT().SaveWebhook(ctx, gomock.Any()).Return(nil, dbErrors.ErrDBQuery)
+			},
+			wantErr: ucErrors.ErrWebhookStorageNotWorking,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockStorage(ctrl)
+			tt.setup(storage)
+
+			res, err := NewWebhookUseCase(storage).Register(ctx, user, tt.url, tt.events)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, 1, res.UserID)
+			assert.Equal(t, tt.url, res.URL)
+			assert.Equal(t, tt.wantEvents, res.Events)
+			assert.Len(t, res.Secret, 2*secretLength)
+		})
+	}
+}
+
+func Test_Unregister(t *testing.T) {
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1}
+
+	tests := []struct {
+		storageErr error
+		wantErr    error
+		name       string
+	}{
+		{name: "when webhook is removed"},
+		{name: "when webhook is not found", storageErr: dbErrors.ErrDBRecordNotFound, wantErr: ucErrors.ErrWebhookNotFound},
+		{name: "when storage fails", storageErr: dbErrors.ErrDBQuery, wantErr: ucErrors.ErrWebhookStorageNotWorking},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockStorage(ctrl)
+			storage.EXPECT().DeleteWebhook(ctx, 1, 5).Return(tt.storageErr)
+
+			err := NewWebhookUseCase(storage).Unregister(ctx, user, 5)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_Deliver_SignsPayload(t *testing.T) {
+	ctx := context.Background()
+	data := &webhookEntity.URLEventData{ShortURL: "http://localhost:8080/abc", OriginalURL: "https://ya.ru"}
+
+	var (
+		signature string
+		event     string
+		body      []byte
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		signature = r.Header.Get(SignatureHeader)
+		event = r.Header.Get(EventHeader)
+		body, err = io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockStorage(ctrl)
+	storage.EXPECT().FindWebhooksByUser(ctx, 1).Return([]*webhookEntity.Webhook{
+		{ID: 1, UserID: 1, URL: server.URL, Secret: "secret", Events: []string{webhookEntity.EventURLCreated}},
+		{ID: 2, UserID: 1, URL: server.URL + "/unsubscribed", Secret: "secret", Events: []string{webhookEntity.EventURLDeleted}},
+	}, nil)
+
+	require.NoError(t, NewWebhookUseCase(storage).Deliver(ctx, 1, webhookEntity.EventURLCreated, data))
+
+	assert.Equal(t, webhookEntity.EventURLCreated, event)
+	assert.Equal(t, Sign("secret", body), signature)
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, signature)
+
+	var payload struct {
+		Data  webhookEntity.URLEventData `json:"data"`
+		Event string                     `json:"event"`
+	}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, webhookEntity.EventURLCreated, payload.Event)
+	assert.Equal(t, *data, payload.Data)
+}
+
+func Test_Deliver_Retries(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		wantErr      error
+		name         string
+		failures     int32
+		wantRequests int32
+	}{
+		{name: "when endpoint recovers", failures: 2, wantRequests: 3},
+		{name: "when endpoint keeps failing", failures: 10, wantRequests: deliveryAttempts, wantErr: ucErrors.ErrWebhookDeliveryFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if requests.Add(1) <= tt.failures {
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			ctrl := gomock.NewController(t)
+			storage := mocks.NewMockStorage(ctrl)
+			storage.EXPECT().FindWebhooksByUser(ctx, 1).Return([]*webhookEntity.Webhook{
+				{ID: 1, UserID: 1, URL: server.URL, Secret: "secret", Events: []string{webhookEntity.EventURLDeleted}},
+			}, nil)
+
+			uc := NewWebhookUseCase(storage)
+			uc.retryDelay = time.Millisecond
+
+			err := uc.Deliver(ctx, 1, webhookEntity.EventURLDeleted, &webhookEntity.URLsDeletedData{Aliases: []string{"abc"}})
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantRequests, requests.Load())
+		})
+	}
+}
+
+func Test_Deliver_StorageFails(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockStorage(ctrl)
+	storage.EXPECT().FindWebhooksByUser(ctx, 1).Return(nil, errors.New("connection refused"))
+
+	err := NewWebhookUseCase(storage).Deliver(ctx, 1, webhookEntity.EventURLClicked, nil)
+	require.ErrorIs(t, err, ucErrors.ErrWebhookStorageNotWorking)
+}
@@ -0,0 +1,178 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . UserUseCase
+
+/*
+Package handler implements HTTP request handlers for administration of users.
+
+It provides:
+- Deactivation of users, so they cannot authenticate anymore
+- Reactivation of deactivated users
+- Error handling and status code management
+
+Short URLs of deactivated users are kept and keep redirecting.
+Administrators are authenticated by X-Admin-Token header compared with the
+configured token; users cannot be administered if it is not configured.
+*/
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	handlerErrors "github.com/gururuby/shortener/internal/handler/http/api/admin/errors"
+	httpErrors "github.com/gururuby/shortener/internal/handler/http/errors"
+)
+
+// Available constants
+const (
+	DeactivateUserPath = "/api/admin/users/{id}/deactivate" // Path of user deactivation
+	ReactivateUserPath = "/api/admin/users/{id}/reactivate" // Path of user reactivation
+	adminTimeout       = time.Second * 5                    // Timeout for administration operations
+	adminTokenHeader   = "X-Admin-Token"                    // Name of the header with admin token
+)
+
+// Router defines the interface for HTTP request routing.
+type Router interface {
+	// Patch registers a handler for PATCH requests at the specified path
+	Patch(path string, h http.HandlerFunc)
+}
+
+// UserUseCase defines the interface for user business logic.
+type UserUseCase interface {
+	// DeactivateUser prevents the user from authenticating
+	DeactivateUser(ctx context.Context, userID int) error
+	// ReactivateUser allows the deactivated user to authenticate again
+	ReactivateUser(ctx context.Context, userID int) error
+}
+
+// handler implements the HTTP request handlers for administration of users.
+type handler struct {
+	uc         UserUseCase // User business logic service
+	router     Router      // Request router
+	adminToken string      // Token of administrators, empty disables administration
+}
+
+// errorResponse represents an API error response.
+// Code is a stable machine-readable error code, see httpErrors.Code.
+type errorResponse struct {
+	err        error // Error returned to the client, maps to Code
+	Error      string
+	Code       string
+	StatusCode int
+}
+
+// Register sets up the administration routes.
+// Parameters:
+// - router: The HTTP router implementation
+// - uc: User business logic service
+// - adminToken: Token of administrators, empty disables administration of users
+func Register(router Router, uc UserUseCase, adminToken string) {
+	h := handler{router: router, uc: uc, adminToken: adminToken}
+	h.router.Patch(DeactivateUserPath, h.DeactivateUser())
+	h.router.Patch(ReactivateUserPath, h.ReactivateUser())
+}
+
+// DeactivateUser handles PATCH requests to deactivate a user.
+// Returns an HTTP handler function that:
+// - Authenticates the administrator, 403 if admin token is missing or invalid
+// - Deactivates the user, 400 if ID is invalid, 404 if user doesn't exist
+// - Returns 204 on success, also if the user is already deactivated
+func (h *handler) DeactivateUser() http.HandlerFunc {
+	return h.setUserActive(false)
+}
+
+// ReactivateUser handles PATCH requests to reactivate a user.
+// Returns an HTTP handler function that:
+// - Authenticates the administrator, 403 if admin token is missing or invalid
+// - Reactivates the user, 400 if ID is invalid, 404 if user doesn't exist
+// - Returns 204 on success, also if the user is already active
+func (h *handler) ReactivateUser() http.HandlerFunc {
+	return h.setUserActive(true)
+}
+
+// setUserActive builds a handler changing whether the user of the path may authenticate.
+// Parameters:
+// - isActive: Whether the user is reactivated or deactivated
+// Returns:
+// - http.HandlerFunc: Handler of the request
+func (h *handler) setUserActive(isActive bool) http.HandlerFunc {
+	change := h.uc.DeactivateUser
+	if isActive {
+		change = h.uc.ReactivateUser
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), adminTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if !h.isAdmin(r) {
+			returnErrResponse(newErrorResponse(httpErrors.ErrAdminTokenInvalid, http.StatusForbidden), w)
+			return
+		}
+
+		userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil || userID < 1 {
+			returnErrResponse(newErrorResponse(handlerErrors.ErrHandlerInvalidUserID, http.StatusBadRequest), w)
+			return
+		}
+
+		if err = change(ctx, userID); err != nil {
+			if errors.Is(err, ucErrors.ErrUserNotFound) {
+				returnErrResponse(newErrorResponse(handlerErrors.ErrHandlerUserNotFound, http.StatusNotFound), w)
+				return
+			}
+			returnErrResponse(newErrorResponse(err, http.StatusInternalServerError), w)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// isAdmin reports whether the request carries the configured admin token.
+// Tokens are compared in constant time, so they cannot be guessed by response timing.
+// Parameters:
+// - r: HTTP request
+// Returns:
+// - bool: false if the token is missing or invalid, or administration is disabled
+func (h *handler) isAdmin(r *http.Request) bool {
+	if h.adminToken == "" {
+		return false
+	}
+	token := r.Header.Get(adminTokenHeader)
+	return subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) == 1
+}
+
+// newErrorResponse creates an error response for the error.
+// Parameters:
+// - err: Error returned to the client
+// - statusCode: HTTP status code of the response
+// Returns:
+// - errorResponse: Response with the error message, Code is set by returnErrResponse
+func newErrorResponse(err error, statusCode int) errorResponse {
+	return errorResponse{err: err, Error: err.Error(), StatusCode: statusCode}
+}
+
+// returnErrResponse writes error response in JSON.
+// Parameters:
+// - errResp: Error details
+// - w: HTTP response writer
+func returnErrResponse(errResp errorResponse, w http.ResponseWriter) {
+	errResp.Code = httpErrors.Code(errResp.err, errResp.StatusCode)
+	w.WriteHeader(errResp.StatusCode)
+	response, err := json.Marshal(errResp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	if _, err = w.Write(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/admin/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_SetUserActive(t *testing.T) {
+	tests := []struct {
+		setup      func(uc *mocks.MockUserUseCase)
+		name       string
+		path       string
+		adminToken string
+		token      string
+		body       string
+		code       int
+	}{
+		{
+			name:       "when user is deactivated",
+			path:       "/api/admin/users/1/deactivate",
+			adminToken: "secret",
+			token:      "secret",
+			setup: func(uc *mocks.MockUserUseCase) {
+				uc.EXPECT().DeactivateUser(gomock.Any(), 1).Return(nil)
+			},
+			code: http.StatusNoContent,
+		},
+		{
+			name:       "when user is reactivated",
+			path:       "/api/admin/users/1/reactivate",
+			adminToken: "secret",
+			token:      "secret",
+			setup: func(uc *mocks.MockUserUseCase) {
+				uc.EXPECT().ReactivateUser(gomock.Any(), 1).Return(nil)
+			},
+			code: http.StatusNoContent,
+		},
+		{
+			name:       "when admin token is missing",
+			path:       "/api/admin/users/1/deactivate",
+			adminToken: "secret",
+			code:       http.StatusForbidden,
+			body:       `{"Error":"admin token is missing or invalid","Code":"ERR_FORBIDDEN","StatusCode":403}`,
+		},
+		{
+			name:       "when admin token is invalid",
+			path:       "/api/admin/users/1/reactivate",
+			adminToken: "secret",
+			token:      "guess",
+			code:       http.StatusForbidden,
+			body:       `{"Error":"admin token is missing or invalid","Code":"ERR_FORBIDDEN","StatusCode":403}`,
+		},
+		{
+			name:  "when administration is disabled",
+			path:  "/api/admin/users/1/deactivate",
+			token: "secret",
+			code:  http.StatusForbidden,
+			body:  `{"Error":"admin token is missing or invalid","Code":"ERR_FORBIDDEN","StatusCode":403}`,
+		},
+		{
+			name:       "when user ID is invalid",
+			path:       "/api/admin/users/abc/deactivate",
+			adminToken: "secret",
+			token:      "secret",
+			code:       http.StatusBadRequest,
+			body:       `{"Error":"invalid user ID","Code":"ERR_INVALID_REQUEST","StatusCode":400}`,
+		},
+		{
+			name:       "when user is not found",
+			path:       "/api/admin/users/2/deactivate",
+			adminToken: "secret",
+			token:      "secret",
+			setup: func(uc *mocks.MockUserUseCase) {
+				uc.EXPECT().DeactivateUser(gomock.Any(), 2).Return(ucErrors.ErrUserNotFound)
+			},
+			code: http.StatusNotFound,
+			body: `{"Error":"user is not found","Code":"ERR_NOT_FOUND","StatusCode":404}`,
+		},
+		{
+			name:       "when storage fails",
+			path:       "/api/admin/users/1/reactivate",
+			adminToken: "secret",
+			token:      "secret",
+			setup: func(uc *mocks.MockUserUseCase) {
+				uc.EXPECT().ReactivateUser(gomock.Any(), 1).Return(ucErrors.ErrUserStorageNotWorking)
+			},
+			code: http.StatusInternalServerError,
+			body: `{"Error":"user storage is not working","Code":"ERR_INTERNAL","StatusCode":500}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			uc := mocks.NewMockUserUseCase(ctrl)
+			if tt.setup != nil {
+				tt.setup(uc)
+			}
+
+			router := chi.NewRouter()
+			Register(router, uc, tt.adminToken)
+
+			req := httptest.NewRequest(http.MethodPatch, tt.path, strings.NewReader(""))
+			if tt.token != "" {
+				req.Header.Set(adminTokenHeader, tt.token)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			resp := w.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			assert.Equal(t, tt.code, resp.StatusCode)
+			if tt.body != "" {
+				assert.JSONEq(t, tt.body, string(body))
+			} else {
+				assert.Empty(t, body)
+			}
+		})
+	}
+}
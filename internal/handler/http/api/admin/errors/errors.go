@@ -0,0 +1,20 @@
+// Package handler contains HTTP request handlers for administration of users.
+// It defines API-specific errors related to request validation and processing.
+package handler
+
+import "errors"
+
+// Errors list
+var (
+	// ErrHandlerInvalidUserID indicates user ID in the path is not a positive integer.
+	//
+	// Typical cases:
+	// - ID is mistyped or is not a number
+	ErrHandlerInvalidUserID = errors.New("invalid user ID")
+
+	// ErrHandlerUserNotFound indicates administration of a user which doesn't exist.
+	//
+	// Typical cases:
+	// - ID is mistyped
+	ErrHandlerUserNotFound = errors.New("user is not found")
+)
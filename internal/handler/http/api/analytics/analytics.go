@@ -0,0 +1,360 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . UserUseCase,ShortURLUseCase,AnalyticsUseCase
+
+/*
+Package handler implements HTTP request handlers for short URL analytics.
+
+It provides:
+- Click time series endpoint for owners of short URLs
+- Geographic breakdown of clicks for owners of short URLs
+- Request validation and error handling
+*/
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	analyticsEntity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	analyticsErrors "github.com/gururuby/shortener/internal/domain/usecase/analytics/errors"
+	shortURLErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+	handlerErrors "github.com/gururuby/shortener/internal/handler/http/api/analytics/errors"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+	httpErrors "github.com/gururuby/shortener/internal/handler/http/errors"
+)
+
+// Available constants
+const (
+	AnalyticsPath       = "/api/shorturl/{alias}/analytics"     // Path pattern of click time series endpoint
+	GeoAnalyticsPath    = "/api/shorturl/{alias}/analytics/geo" // Path pattern of geographic breakdown endpoint
+	getAnalyticsTimeout = time.Second * 10                      // Timeout for counting clicks
+	defaultPeriod       = 30                                    // Number of days covered when from is not passed
+	namespaceHeader     = "X-Namespace"                         // Name of the header with namespace of the short URL
+)
+
+// Router defines the interface for HTTP request routing.
+type Router interface {
+	// Get registers a handler for GET requests at the specified path
+	Get(path string, h http.HandlerFunc)
+}
+
+// UserUseCase defines the interface for user authentication.
+type UserUseCase interface {
+	// Authenticate verifies a user's credentials
+	Authenticate(ctx context.Context, token string) (*userEntity.User, error)
+	// AuthenticateAPIKey verifies an API key and returns its owner
+	AuthenticateAPIKey(ctx context.Context, key string) (*userEntity.User, error)
+}
+
+// ShortURLUseCase defines the interface for short URL operations.
+type ShortURLUseCase interface {
+	// GetShortURL retrieves the short URL by namespace and alias without side effects
+	GetShortURL(ctx context.Context, namespace, alias string) (*shortURLEntity.ShortURL, error)
+}
+
+// AnalyticsUseCase defines the interface for analytics business logic.
+type AnalyticsUseCase interface {
+	// GetTimeSeries counts clicks of a short URL in the namespace in [from, to) grouped by periods
+	GetTimeSeries(ctx context.Context, namespace, alias string, from, to time.Time, granularity string) (*analyticsEntity.TimeSeries, error)
+	// GetGeoBreakdown counts clicks of a short URL in the namespace in [from, to) grouped by country and city
+	GetGeoBreakdown(ctx context.Context, namespace, alias string, from, to time.Time) (*analyticsEntity.GeoBreakdown, error)
+}
+
+// handler implements the HTTP request handlers for analytics operations.
+type handler struct {
+	userUC      UserUseCase      // User authentication service
+	urlUC       ShortURLUseCase  // URL business logic service
+	analyticsUC AnalyticsUseCase // Analytics business logic service
+	router      Router           // Request router
+}
+
+// errorResponse represents an API error response.
+// Code is a stable machine-readable error code, see httpErrors.Code.
+type errorResponse struct {
+	err        error // Error returned to the client, maps to Code
+	Error      string
+	Code       string
+	StatusCode int
+}
+
+// Register sets up the analytics routes.
+// Parameters:
+// - router: The HTTP router implementation
+// - userUC: User authentication service
+// - urlUC: URL business logic service
+// - analyticsUC: Analytics business logic service
+func Register(router Router, userUC UserUseCase, urlUC ShortURLUseCase, analyticsUC AnalyticsUseCase) {
+	h := handler{router: router, userUC: userUC, urlUC: urlUC, analyticsUC: analyticsUC}
+	h.router.Get(AnalyticsPath, h.GetAnalytics())
+	h.router.Get(GeoAnalyticsPath, h.GetGeoAnalytics())
+}
+
+// GetAnalytics handles requests for click time series of a short URL.
+// Query parameters:
+// - from: Start date, inclusive, 30 days before to by default
+// - to: End date, inclusive, today by default; RFC 3339 times are exclusive
+// - granularity: hour, day (default), week or month
+//
+// Returns an HTTP handler function that:
+// - Authenticates the user, new users are never registered
+// - Looks up the short URL in the namespace from X-Namespace header, the default one if it is missing
+// - Checks that the user owns the short URL
+// - Returns appropriate status codes:
+//   - 200 OK with time series in JSON
+//   - 400 Bad Request for invalid dates, period or granularity
+//   - 401 Unauthorized if credentials are missing or invalid
+//   - 403 Forbidden if the user is deactivated
+//   - 403 Forbidden if the short URL belongs to another user
+//   - 404 Not Found if alias doesn't exist
+//   - 410 Gone if short URL was deleted
+//   - 500 Internal Server Error if clicks cannot be counted
+func (h *handler) GetAnalytics() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err      error
+			user     *userEntity.User
+			shortURL *shortURLEntity.ShortURL
+			series   *analyticsEntity.TimeSeries
+			from, to time.Time
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), getAnalyticsTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if user, err = auth.User(ctx, h.userUC, r); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.ErrStatus(err)), w)
+			return
+		}
+
+		query := r.URL.Query()
+		if from, to, err = parsePeriod(query.Get("from"), query.Get("to")); err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusBadRequest), w)
+			return
+		}
+
+		granularity := query.Get("granularity")
+		if granularity == "" {
+			granularity = analyticsEntity.GranularityDay
+		}
+
+		if shortURL, err = h.findOwnedShortURL(ctx, user, r.Header.Get(namespaceHeader), chi.URLParam(r, "alias")); err != nil {
+			returnErrResponse(newErrorResponse(err, shortURLErrStatus(err)), w)
+			return
+		}
+
+		if series, err = h.analyticsUC.GetTimeSeries(ctx, shortURL.Namespace, shortURL.Alias, from, to, granularity); err != nil {
+			returnErrResponse(newErrorResponse(err, analyticsErrStatus(err)), w)
+			return
+		}
+
+		writeJSON(w, series)
+	}
+}
+
+// GetGeoAnalytics handles requests for geographic breakdown of short URL clicks.
+// Query parameters:
+// - from: Start date, inclusive, 30 days before to by default
+// - to: End date, inclusive, today by default; RFC 3339 times are exclusive
+//
+// Returns an HTTP handler function that:
+// - Authenticates the user, new users are never registered
+// - Looks up the short URL in the namespace from X-Namespace header, the default one if it is missing
+// - Checks that the user owns the short URL
+// - Returns appropriate status codes:
+//   - 200 OK with clicks by country and city in JSON, ordered by clicks descending
+//   - 400 Bad Request for invalid dates or period
+//   - 401 Unauthorized if credentials are missing or invalid
+//   - 403 Forbidden if the user is deactivated
+//   - 403 Forbidden if the short URL belongs to another user
+//   - 404 Not Found if alias doesn't exist
+//   - 410 Gone if short URL was deleted
+//   - 500 Internal Server Error if clicks cannot be counted
+func (h *handler) GetGeoAnalytics() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err       error
+			user      *userEntity.User
+			shortURL  *shortURLEntity.ShortURL
+			breakdown *analyticsEntity.GeoBreakdown
+			from, to  time.Time
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), getAnalyticsTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if user, err = auth.User(ctx, h.userUC, r); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.ErrStatus(err)), w)
+			return
+		}
+
+		query := r.URL.Query()
+		if from, to, err = parsePeriod(query.Get("from"), query.Get("to")); err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusBadRequest), w)
+			return
+		}
+
+		if shortURL, err = h.findOwnedShortURL(ctx, user, r.Header.Get(namespaceHeader), chi.URLParam(r, "alias")); err != nil {
+			returnErrResponse(newErrorResponse(err, shortURLErrStatus(err)), w)
+			return
+		}
+
+		if breakdown, err = h.analyticsUC.GetGeoBreakdown(ctx, shortURL.Namespace, shortURL.Alias, from, to); err != nil {
+			returnErrResponse(newErrorResponse(err, analyticsErrStatus(err)), w)
+			return
+		}
+
+		writeJSON(w, breakdown)
+	}
+}
+
+// findOwnedShortURL finds the short URL and checks that the user owns it.
+// Parameters:
+// - ctx: Context for cancellation/timeout
+// - user: Authenticated user
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL alias
+// Returns:
+// - *shortURLEntity.ShortURL: Short URL of the user
+// - error: ErrShortURLForbidden if the short URL belongs to another user, or lookup failure
+func (h *handler) findOwnedShortURL(ctx context.Context, user *userEntity.User, namespace, alias string) (*shortURLEntity.ShortURL, error) {
+	shortURL, err := h.urlUC.GetShortURL(ctx, namespace, alias)
+	if err != nil {
+		return nil, err
+	}
+
+	if shortURL.UserID != user.ID {
+		return nil, shortURLErrors.ErrShortURLForbidden
+	}
+
+	return shortURL, nil
+}
+
+// parsePeriod converts from and to query parameters into a time range.
+// Dates are taken in UTC, the date passed in to is included into the range.
+// Parameters:
+// - rawFrom: Value of from parameter, may be empty
+// - rawTo: Value of to parameter, may be empty
+// Returns:
+// - time.Time: Start of the range, inclusive
+// - time.Time: End of the range, exclusive
+// - error: ErrHandlerInvalidDate if a value is neither a date nor RFC 3339 time
+func parsePeriod(rawFrom, rawTo string) (time.Time, time.Time, error) {
+	var (
+		from, to time.Time
+		err      error
+	)
+
+	if rawTo == "" {
+		to = analyticsEntity.TruncateTime(time.Now(), analyticsEntity.GranularityDay).AddDate(0, 0, 1)
+	} else if to, err = parseTime(rawTo, 1); err != nil {
+		return from, to, err
+	}
+
+	if rawFrom == "" {
+		from = to.AddDate(0, 0, -defaultPeriod)
+	} else if from, err = parseTime(rawFrom, 0); err != nil {
+		return from, to, err
+	}
+
+	return from, to, nil
+}
+
+// parseTime parses a date or RFC 3339 time.
+// Parameters:
+// - value: Date in YYYY-MM-DD format or RFC 3339 time
+// - days: Number of days added to dates, RFC 3339 times are returned as is
+// Returns:
+// - time.Time: Parsed time
+// - error: ErrHandlerInvalidDate if value has other format
+func parseTime(value string, days int) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t.AddDate(0, 0, days), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, handlerErrors.ErrHandlerInvalidDate
+}
+
+// shortURLErrStatus maps short URL lookup errors to HTTP status codes.
+// Parameters:
+// - err: Error returned by findOwnedShortURL
+// Returns:
+// - int: 403 for short URL of another user, 404 for unknown alias, 410 for deleted URL, 500 otherwise
+func shortURLErrStatus(err error) int {
+	switch {
+	case errors.Is(err, shortURLErrors.ErrShortURLForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, shortURLErrors.ErrShortURLSourceURLNotFound), errors.Is(err, shortURLErrors.ErrShortURLEmptyAlias):
+		return http.StatusNotFound
+	case errors.Is(err, shortURLErrors.ErrShortURLDeleted):
+		return http.StatusGone
+	}
+	return http.StatusInternalServerError
+}
+
+// analyticsErrStatus maps analytics errors to HTTP status codes.
+// Parameters:
+// - err: Error returned by GetTimeSeries
+// Returns:
+// - int: 400 for invalid granularity or period, 500 otherwise
+func analyticsErrStatus(err error) int {
+	if errors.Is(err, analyticsErrors.ErrAnalyticsInvalidGranularity) || errors.Is(err, analyticsErrors.ErrAnalyticsInvalidPeriod) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// writeJSON writes a JSON response with 200 status.
+// Parameters:
+// - w: HTTP response writer
+// - v: Value to encode
+func writeJSON(w http.ResponseWriter, v any) {
+	response, err := json.Marshal(v)
+	if err != nil {
+		returnErrResponse(newErrorResponse(err, http.StatusInternalServerError), w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// newErrorResponse creates an error response for the error.
+// Parameters:
+// - err: Error returned to the client
+// - statusCode: HTTP status code of the response
+// Returns:
+// - errorResponse: Response with the error message, Code is set by returnErrResponse
+func newErrorResponse(err error, statusCode int) errorResponse {
+	return errorResponse{err: err, Error: err.Error(), StatusCode: statusCode}
+}
+
+// returnErrResponse writes error response in JSON.
+// Parameters:
+// - errResp: Error details
+// - w: HTTP response writer
+func returnErrResponse(errResp errorResponse, w http.ResponseWriter) {
+	errResp.Code = httpErrors.Code(errResp.err, errResp.StatusCode)
+	w.WriteHeader(errResp.StatusCode)
+	response, err := json.Marshal(errResp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	if _, err = w.Write(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
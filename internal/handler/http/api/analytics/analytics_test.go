@@ -0,0 +1,341 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	analyticsEntity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	analyticsErrors "github.com/gururuby/shortener/internal/domain/usecase/analytics/errors"
+	shortURLErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+	userErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/analytics/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_GetAnalytics(t *testing.T) {
+	owner := &userEntity.User{ID: 1}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	series := &analyticsEntity.TimeSeries{
+		Alias: "abc",
+		Data:  []analyticsEntity.DataPoint{{Period: "2024-01-01", Clicks: 42}, {Period: "2024-01-02", Clicks: 81}},
+		Total: 123,
+	}
+
+	type mocksSet struct {
+		userUC      *mocks.MockUserUseCase
+		urlUC       *mocks.MockShortURLUseCase
+		analyticsUC *mocks.MockAnalyticsUseCase
+	}
+
+	tests := []struct {
+		setup     func(m mocksSet)
+		name      string
+		query     string
+		token     string
+		apiKey    string
+		namespace string
+		body      string
+		code      int
+	}{
+		{
+			name:  "when owner requests analytics",
+			query: "?from=2024-01-01&to=2024-01-31&granularity=day",
+			token: "owner",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+				m.urlUC.EXPECT().GetShortURL(gomock.Any(), "", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 1}, nil)
+				m.analyticsUC.EXPECT().GetTimeSeries(gomock.Any(), "", "abc", from, to, analyticsEntity.GranularityDay).Return(series, nil)
+			},
+			code: http.StatusOK,
+			body: `{"alias":"abc","data":[{"period":"2024-01-01","clicks":42},{"period":"2024-01-02","clicks":81}],"total":123}`,
+		},
+		{
+			name:   "when owner passes API key and RFC 3339 times",
+			query:  "?from=2024-01-01T00:00:00Z&to=2024-02-01T00:00:00Z&granularity=hour",
+			apiKey: "key",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().AuthenticateAPIKey(gomock.Any(), "key").Return(owner, nil)
+				m.urlUC.EXPECT().GetShortURL(gomock.Any(), "", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 1}, nil)
+				m.analyticsUC.EXPECT().GetTimeSeries(gomock.Any(), "", "abc", from, to, analyticsEntity.GranularityHour).Return(series, nil)
+			},
+			code: http.StatusOK,
+			body: `{"alias":"abc","data":[{"period":"2024-01-01","clicks":42},{"period":"2024-01-02","clicks":81}],"total":123}`,
+		},
+		{
+			name:      "when owner requests analytics of short URL in namespace",
+			query:     "?from=2024-01-01&to=2024-01-31",
+			token:     "owner",
+			namespace: "team",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+				m.urlUC.EXPECT().GetShortURL(gomock.Any(), "team", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", Namespace: "team", UserID: 1}, nil)
+				m.analyticsUC.EXPECT().GetTimeSeries(gomock.Any(), "team", "abc", from, to, analyticsEntity.GranularityDay).Return(series, nil)
+			},
+			code: http.StatusOK,
+			body: `{"alias":"abc","data":[{"period":"2024-01-01","clicks":42},{"period":"2024-01-02","clicks":81}],"total":123}`,
+		},
+		{
+			name:  "when credentials are not passed",
+			setup: func(_ mocksSet) {},
+			code:  http.StatusUnauthorized,
+			body:  `{"Error":"auth token is not passed","Code":"ERR_UNAUTHORIZED","StatusCode":401}`,
+		},
+		{
+			name:  "when token is invalid",
+			token: "invalid",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "invalid").Return(nil, userErrors.ErrUserNotFound)
+			},
+			code: http.StatusUnauthorized,
+			body: `{"Error":"` + userErrors.ErrUserNotFound.Error() + `","Code":"ERR_UNAUTHORIZED","StatusCode":401}`,
+		},
+		{
+			name:  "when date is invalid",
+			query: "?from=01.01.2024",
+			token: "owner",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+			},
+			code: http.StatusBadRequest,
+			body: `{"Error":"from and to must be dates in YYYY-MM-DD or RFC 3339 format","Code":"ERR_INVALID_REQUEST","StatusCode":400}`,
+		},
+		{
+			name:  "when short URL belongs to another user",
+			token: "owner",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+				m.urlUC.EXPECT().GetShortURL(gomock.Any(), "", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 2}, nil)
+			},
+			code: http.StatusForbidden,
+			body: `{"Error":"` + shortURLErrors.ErrShortURLForbidden.Error() + `","Code":"ERR_FORBIDDEN","StatusCode":403}`,
+		},
+		{
+			name:  "when short URL does not exist",
+			token: "owner",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+				m.urlUC.EXPECT().GetShortURL(gomock.Any(), "", "abc").Return(nil, shortURLErrors.ErrShortURLSourceURLNotFound)
+			},
+			code: http.StatusNotFound,
+			body: `{"Error":"` + shortURLErrors.ErrShortURLSourceURLNotFound.Error() + `","Code":"ERR_NOT_FOUND","StatusCode":404}`,
+		},
+		{
+			name:  "when granularity is not supported",
+			query: "?granularity=minute",
+			token: "owner",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+				m.urlUC.EXPECT().GetShortURL(gomock.Any(), "", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 1}, nil)
+				m.analyticsUC.EXPECT().GetTimeSeries(gomock.Any(), "", "abc", gomock.Any(), gomock.Any(), "minute").
+					Return(nil, analyticsErrors.ErrAnalyticsInvalidGranularity)
+			},
+			code: http.StatusBadRequest,
+			body: `{"Error":"granularity must be one of hour, day, week, month","Code":"ERR_INVALID_REQUEST","StatusCode":400}`,
+		},
+		{
+			name:  "when clicks cannot be counted",
+			token: "owner",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+				m.urlUC.EXPECT().GetShortURL(gomock.Any(), "", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 1}, nil)
+				m.analyticsUC.EXPECT().GetTimeSeries(gomock.Any(), "", "abc", gomock.Any(), gomock.Any(), analyticsEntity.GranularityDay).
+					Return(nil, analyticsErrors.ErrAnalyticsCannotGet)
+			},
+			code: http.StatusInternalServerError,
+			body: `{"Error":"cannot get analytics","Code":"ERR_INTERNAL","StatusCode":500}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := mocksSet{
+				userUC:      mocks.NewMockUserUseCase(ctrl),
+				urlUC:       mocks.NewMockShortURLUseCase(ctrl),
+				analyticsUC: mocks.NewMockAnalyticsUseCase(ctrl),
+			}
+			tt.setup(m)
+
+			r := chi.NewRouter()
+			Register(r, m.userUC, m.urlUC, m.analyticsUC)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/shorturl/abc/analytics"+tt.query, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+			if tt.namespace != "" {
+				req.Header.Set("X-Namespace", tt.namespace)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			assert.Equal(t, tt.code, resp.StatusCode)
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.body, string(body))
+		})
+	}
+}
+
+func Test_GetGeoAnalytics(t *testing.T) {
+	owner := &userEntity.User{ID: 1}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	breakdown := &analyticsEntity.GeoBreakdown{
+		Alias: "abc",
+		Data: []analyticsEntity.LocationCount{
+			{Country: "US", City: "New York", Clicks: 10},
+			{Country: "GB", City: "London", Clicks: 3},
+		},
+		Total: 13,
+	}
+
+	type mocksSet struct {
+		userUC      *mocks.MockUserUseCase
+		urlUC       *mocks.MockShortURLUseCase
+		analyticsUC *mocks.MockAnalyticsUseCase
+	}
+
+	tests := []struct {
+		setup func(m mocksSet)
+		name  string
+		query string
+		token string
+		body  string
+		code  int
+	}{
+		{
+			name:  "when owner requests geographic breakdown",
+			query: "?from=2024-01-01&to=2024-01-31",
+			token: "owner",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+				m.urlUC.EXPECT().GetShortURL(gomock.Any(), "", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 1}, nil)
+				m.analyticsUC.EXPECT().GetGeoBreakdown(gomock.Any(), "", "abc", from, to).Return(breakdown, nil)
+			},
+			code: http.StatusOK,
+			body: `{"alias":"abc","data":[{"country":"US","city":"New York","clicks":10},{"country":"GB","city":"London","clicks":3}],"total":13}`,
+		},
+		{
+			name:  "when credentials are not passed",
+			setup: func(_ mocksSet) {},
+			code:  http.StatusUnauthorized,
+			body:  `{"Error":"auth token is not passed","Code":"ERR_UNAUTHORIZED","StatusCode":401}`,
+		},
+		{
+			name:  "when date is invalid",
+			query: "?to=31.01.2024",
+			token: "owner",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+			},
+			code: http.StatusBadRequest,
+			body: `{"Error":"from and to must be dates in YYYY-MM-DD or RFC 3339 format","Code":"ERR_INVALID_REQUEST","StatusCode":400}`,
+		},
+		{
+			name:  "when short URL belongs to another user",
+			token: "owner",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+				m.urlUC.EXPECT().GetShortURL(gomock.Any(), "", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 2}, nil)
+			},
+			code: http.StatusForbidden,
+			body: `{"Error":"` + shortURLErrors.ErrShortURLForbidden.Error() + `","Code":"ERR_FORBIDDEN","StatusCode":403}`,
+		},
+		{
+			name:  "when short URL was deleted",
+			token: "owner",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+				m.urlUC.EXPECT().GetShortURL(gomock.Any(), "", "abc").Return(nil, shortURLErrors.ErrShortURLDeleted)
+			},
+			code: http.StatusGone,
+			body: `{"Error":"` + shortURLErrors.ErrShortURLDeleted.Error() + `","Code":"ERR_GONE","StatusCode":410}`,
+		},
+		{
+			name:  "when from is after to",
+			query: "?from=2024-02-01&to=2024-01-01",
+			token: "owner",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+				m.urlUC.EXPECT().GetShortURL(gomock.Any(), "", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 1}, nil)
+				m.analyticsUC.EXPECT().GetGeoBreakdown(gomock.Any(), "", "abc", gomock.Any(), gomock.Any()).
+					Return(nil, analyticsErrors.ErrAnalyticsInvalidPeriod)
+			},
+			code: http.StatusBadRequest,
+			body: `{"Error":"` + analyticsErrors.ErrAnalyticsInvalidPeriod.Error() + `","Code":"ERR_INVALID_REQUEST","StatusCode":400}`,
+		},
+		{
+			name:  "when clicks cannot be counted",
+			token: "owner",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+				m.urlUC.EXPECT().GetShortURL(gomock.Any(), "", "abc").Return(&shortURLEntity.ShortURL{Alias: "abc", UserID: 1}, nil)
+				m.analyticsUC.EXPECT().GetGeoBreakdown(gomock.Any(), "", "abc", gomock.Any(), gomock.Any()).
+					Return(nil, analyticsErrors.ErrAnalyticsCannotGet)
+			},
+			code: http.StatusInternalServerError,
+			body: `{"Error":"cannot get analytics","Code":"ERR_INTERNAL","StatusCode":500}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := mocksSet{
+				userUC:      mocks.NewMockUserUseCase(ctrl),
+				urlUC:       mocks.NewMockShortURLUseCase(ctrl),
+				analyticsUC: mocks.NewMockAnalyticsUseCase(ctrl),
+			}
+			tt.setup(m)
+
+			r := chi.NewRouter()
+			Register(r, m.userUC, m.urlUC, m.analyticsUC)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/shorturl/abc/analytics/geo"+tt.query, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			assert.Equal(t, tt.code, resp.StatusCode)
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.body, string(body))
+		})
+	}
+}
+
+func Test_parsePeriod_Defaults(t *testing.T) {
+	from, to, err := parsePeriod("", "")
+	require.NoError(t, err)
+
+	today := analyticsEntity.TruncateTime(time.Now(), analyticsEntity.GranularityDay)
+	assert.Equal(t, today.AddDate(0, 0, 1), to)
+	assert.Equal(t, to.AddDate(0, 0, -defaultPeriod), from)
+}
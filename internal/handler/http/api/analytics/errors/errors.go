@@ -0,0 +1,15 @@
+// Package handler contains HTTP request handlers for short URL analytics.
+// It defines API-specific errors related to request validation and processing.
+package handler
+
+import "errors"
+
+// Errors list
+var (
+	// ErrHandlerInvalidDate indicates that `from` or `to` query parameter is not a date.
+	//
+	// Typical cases:
+	// - Date in other format: `?from=01.01.2024`
+	//
+	ErrHandlerInvalidDate = errors.New("from and to must be dates in YYYY-MM-DD or RFC 3339 format")
+)
@@ -0,0 +1,168 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gururuby/shortener/internal/handler/http/api/analytics (interfaces: UserUseCase,ShortURLUseCase,AnalyticsUseCase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock.go -package=mocks . UserUseCase,ShortURLUseCase,AnalyticsUseCase
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	entity0 "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	entity1 "github.com/gururuby/shortener/internal/domain/entity/user"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserUseCase is a mock of UserUseCase interface.
+type MockUserUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockUserUseCaseMockRecorder is the mock recorder for MockUserUseCase.
+type MockUserUseCaseMockRecorder struct {
+	mock *MockUserUseCase
+}
+
+// NewMockUserUseCase creates a new mock instance.
+func NewMockUserUseCase(ctrl *gomock.Controller) *MockUserUseCase {
+	mock := &MockUserUseCase{ctrl: ctrl}
+	mock.recorder = &MockUserUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserUseCase) EXPECT() *MockUserUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockUserUseCase) Authenticate(ctx context.Context, token string) (*entity1.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, token)
+	ret0, _ := ret[0].(*entity1.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockUserUseCaseMockRecorder) Authenticate(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockUserUseCase)(nil).Authenticate), ctx, token)
+}
+
+// AuthenticateAPIKey mocks base method.
+func (m *MockUserUseCase) AuthenticateAPIKey(ctx context.Context, key string) (*entity1.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", ctx, key)
+	ret0, _ := ret[0].(*entity1.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockUserUseCaseMockRecorder) AuthenticateAPIKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockUserUseCase)(nil).AuthenticateAPIKey), ctx, key)
+}
+
+// MockShortURLUseCase is a mock of ShortURLUseCase interface.
+type MockShortURLUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockShortURLUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockShortURLUseCaseMockRecorder is the mock recorder for MockShortURLUseCase.
+type MockShortURLUseCaseMockRecorder struct {
+	mock *MockShortURLUseCase
+}
+
+// NewMockShortURLUseCase creates a new mock instance.
+func NewMockShortURLUseCase(ctrl *gomock.Controller) *MockShortURLUseCase {
+	mock := &MockShortURLUseCase{ctrl: ctrl}
+	mock.recorder = &MockShortURLUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShortURLUseCase) EXPECT() *MockShortURLUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetShortURL mocks base method.
+func (m *MockShortURLUseCase) GetShortURL(ctx context.Context, namespace, alias string) (*entity0.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortURL", ctx, namespace, alias)
+	ret0, _ := ret[0].(*entity0.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortURL indicates an expected call of GetShortURL.
+func (mr *MockShortURLUseCaseMockRecorder) GetShortURL(ctx, namespace, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortURL", reflect.TypeOf((*MockShortURLUseCase)(nil).GetShortURL), ctx, namespace, alias)
+}
+
+// MockAnalyticsUseCase is a mock of AnalyticsUseCase interface.
+type MockAnalyticsUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockAnalyticsUseCaseMockRecorder is the mock recorder for MockAnalyticsUseCase.
+type MockAnalyticsUseCaseMockRecorder struct {
+	mock *MockAnalyticsUseCase
+}
+
+// NewMockAnalyticsUseCase creates a new mock instance.
+func NewMockAnalyticsUseCase(ctrl *gomock.Controller) *MockAnalyticsUseCase {
+	mock := &MockAnalyticsUseCase{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsUseCase) EXPECT() *MockAnalyticsUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetGeoBreakdown mocks base method.
+func (m *MockAnalyticsUseCase) GetGeoBreakdown(ctx context.Context, namespace, alias string, from, to time.Time) (*entity.GeoBreakdown, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeoBreakdown", ctx, namespace, alias, from, to)
+	ret0, _ := ret[0].(*entity.GeoBreakdown)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeoBreakdown indicates an expected call of GetGeoBreakdown.
+func (mr *MockAnalyticsUseCaseMockRecorder) GetGeoBreakdown(ctx, namespace, alias, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeoBreakdown", reflect.TypeOf((*MockAnalyticsUseCase)(nil).GetGeoBreakdown), ctx, namespace, alias, from, to)
+}
+
+// GetTimeSeries mocks base method.
+func (m *MockAnalyticsUseCase) GetTimeSeries(ctx context.Context, namespace, alias string, from, to time.Time, granularity string) (*entity.TimeSeries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeSeries", ctx, namespace, alias, from, to, granularity)
+	ret0, _ := ret[0].(*entity.TimeSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeSeries indicates an expected call of GetTimeSeries.
+func (mr *MockAnalyticsUseCaseMockRecorder) GetTimeSeries(ctx, namespace, alias, from, to, granularity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeSeries", reflect.TypeOf((*MockAnalyticsUseCase)(nil).GetTimeSeries), ctx, namespace, alias, from, to, granularity)
+}
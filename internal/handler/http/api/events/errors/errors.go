@@ -0,0 +1,33 @@
+// Package handler contains HTTP request handlers for real-time events.
+// It defines API-specific errors related to event subscriptions.
+package handler
+
+import "errors"
+
+// Errors list
+var (
+	// ErrHandlerTooManyConnections indicates the user has reached the limit of simultaneous event streams.
+	//
+	// Typical cases:
+	// - Dashboard opened in too many browser tabs
+	// - Client reconnecting without closing previous connections
+	//
+	// Handling suggestions:
+	// - Close unused streams and retry
+	//
+	ErrHandlerTooManyConnections = errors.New("too many event stream connections")
+
+	// ErrHandlerHubClosed indicates the service is shutting down and accepts no new subscriptions.
+	//
+	// Handling suggestions:
+	// - Reconnect to another instance or retry later
+	//
+	ErrHandlerHubClosed = errors.New("events hub is closed")
+
+	// ErrHandlerStreamingUnsupported indicates the response writer cannot flush partial responses.
+	//
+	// Typical cases:
+	// - Middleware wrapping the response writer without http.Flusher support
+	//
+	ErrHandlerStreamingUnsupported = errors.New("streaming is not supported")
+)
@@ -0,0 +1,316 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . UserUseCase
+
+/*
+Package handler implements Server-Sent Events endpoint for real-time notifications.
+
+It provides:
+- Hub fanning out short URL events to subscribed clients of their owner
+- Limit of simultaneous streams per user
+- Event stream endpoint with keepalive comments
+*/
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	handlerErrors "github.com/gururuby/shortener/internal/handler/http/api/events/errors"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+	httpErrors "github.com/gururuby/shortener/internal/handler/http/errors"
+	"github.com/gururuby/shortener/internal/infra/logger"
+)
+
+// Available constants
+const (
+	EventsPath        = "/api/events"    // Path of event stream endpoint
+	authTimeout       = time.Second * 5  // Timeout for user authentication
+	keepAliveInterval = time.Second * 30 // Interval between keepalive comments preventing proxy timeouts
+	subscriberBuffer  = 16               // Events kept for a slow client before new ones are dropped
+)
+
+// Router defines the interface for HTTP request routing.
+type Router interface {
+	// Get registers a handler for GET requests at the specified path
+	Get(path string, h http.HandlerFunc)
+}
+
+// UserUseCase defines the interface for user authentication.
+type UserUseCase interface {
+	// Authenticate verifies a user's credentials
+	Authenticate(ctx context.Context, token string) (*userEntity.User, error)
+	// AuthenticateAPIKey verifies an API key and returns its owner
+	AuthenticateAPIKey(ctx context.Context, key string) (*userEntity.User, error)
+}
+
+// subscriber is a channel receiving events of a single client.
+type subscriber chan *shortURLEntity.ShortURLCreatedEvent
+
+// Hub fans out short URL events to connected clients of their owners.
+// It is safe for concurrent use.
+type Hub struct {
+	mu              sync.Mutex
+	subscribers     map[int]map[subscriber]struct{} // Subscribers by user ID
+	maxConnsPerUser int                             // Limit of simultaneous subscriptions of a user
+	closed          bool                            // No subscriptions are accepted after Close
+}
+
+// NewHub creates a hub of event subscriptions.
+// Parameters:
+// - maxConnsPerUser: Limit of simultaneous subscriptions of a user, not positive disables the limit
+// Returns:
+// - *Hub: Initialized hub
+func NewHub(maxConnsPerUser int) *Hub {
+	return &Hub{
+		subscribers:     make(map[int]map[subscriber]struct{}),
+		maxConnsPerUser: maxConnsPerUser,
+	}
+}
+
+// Subscribe registers a client of the user.
+// Parameters:
+// - userID: ID of the user whose events are received
+// Returns:
+// - <-chan *ShortURLCreatedEvent: Events of the user, closed on unsubscription or Close
+// - func(): Unsubscribes the client, safe to call several times
+// - error: ErrHandlerTooManyConnections if the user has reached the limit, ErrHandlerHubClosed after Close
+func (h *Hub) Subscribe(userID int) (<-chan *shortURLEntity.ShortURLCreatedEvent, func(), error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, nil, handlerErrors.ErrHandlerHubClosed
+	}
+
+	subs := h.subscribers[userID]
+	if h.maxConnsPerUser > 0 && len(subs) >= h.maxConnsPerUser {
+		return nil, nil, handlerErrors.ErrHandlerTooManyConnections
+	}
+	if subs == nil {
+		subs = make(map[subscriber]struct{})
+		h.subscribers[userID] = subs
+	}
+
+	sub := make(subscriber, subscriberBuffer)
+	subs[sub] = struct{}{}
+
+	return sub, func() { h.unsubscribe(userID, sub) }, nil
+}
+
+// Publish sends the event to all clients of the user.
+// Clients which have not read previous events yet miss the event, so a slow client never blocks publishing.
+// Parameters:
+// - userID: ID of the owner of the created short URL
+// - event: Event to send
+func (h *Hub) Publish(userID int, event *shortURLEntity.ShortURLCreatedEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers[userID] {
+		select {
+		case sub <- event:
+		default:
+		}
+	}
+}
+
+// Close unsubscribes all clients, so their streams end, and rejects new subscriptions.
+// Must be called on shutdown, otherwise open streams delay graceful shutdown of the server.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for userID, subs := range h.subscribers {
+		for sub := range subs {
+			close(sub)
+		}
+		delete(h.subscribers, userID)
+	}
+}
+
+// unsubscribe removes the client and closes its channel unless it is already removed.
+// Parameters:
+// - userID: ID of the user
+// - sub: Channel of the client
+func (h *Hub) unsubscribe(userID int, sub subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subs := h.subscribers[userID]
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+	close(sub)
+	if len(subs) == 0 {
+		delete(h.subscribers, userID)
+	}
+}
+
+// handler implements the HTTP request handlers for event streams.
+type handler struct {
+	userUC    UserUseCase   // User authentication service
+	hub       *Hub          // Subscriptions of connected clients
+	router    Router        // Request router
+	keepAlive time.Duration // Interval between keepalive comments
+}
+
+// errorResponse represents an API error response.
+// Code is a stable machine-readable error code, see httpErrors.Code.
+type errorResponse struct {
+	err        error // Error returned to the client, maps to Code
+	Error      string
+	Code       string
+	StatusCode int
+}
+
+// Register sets up the event stream routes.
+// Parameters:
+// - router: The HTTP router implementation
+// - userUC: User authentication service
+// - hub: Hub which events are streamed from
+func Register(router Router, userUC UserUseCase, hub *Hub) {
+	h := handler{router: router, userUC: userUC, hub: hub, keepAlive: keepAliveInterval}
+	h.router.Get(EventsPath, h.GetEvents())
+}
+
+// GetEvents handles requests for the stream of the user's events.
+// Every event is sent as `data: <JSON>` line followed by an empty line,
+// `: ping` comment is sent when there were no events for keepalive interval.
+//
+// Returns an HTTP handler function that:
+// - Authenticates the user, new users are never registered
+// - Streams events until the client disconnects or the service shuts down
+// - Returns appropriate status codes:
+//   - 200 OK with text/event-stream
+//   - 401 Unauthorized if credentials are missing or invalid
+//   - 403 Forbidden if the user is deactivated
+//   - 429 Too Many Requests if the user has too many open streams
+//   - 500 Internal Server Error if streaming is not supported
+//   - 503 Service Unavailable if the service is shutting down
+func (h *handler) GetEvents() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authCtx, cancel := context.WithTimeout(r.Context(), authTimeout)
+		user, err := auth.User(authCtx, h.userUC, r)
+		cancel()
+		if err != nil {
+			returnErrResponse(newErrorResponse(err, auth.ErrStatus(err)), w)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			returnErrResponse(newErrorResponse(handlerErrors.ErrHandlerStreamingUnsupported, http.StatusInternalServerError), w)
+			return
+		}
+
+		events, unsubscribe, err := h.hub.Subscribe(user.ID)
+		if err != nil {
+			returnErrResponse(newErrorResponse(err, subscribeErrStatus(err)), w)
+			return
+		}
+		defer unsubscribe()
+
+		// The stream outlives server write timeout, so the deadline is lifted for this response only
+		if err = http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			logger.With(r.Context()).Warn(fmt.Sprintf("cannot lift write deadline of event stream: %s", err))
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		h.stream(r.Context(), w, flusher, events)
+	}
+}
+
+// stream writes events to the client until ctx is done or events channel is closed.
+// Parameters:
+// - ctx: Request context, done when the client disconnects
+// - w: HTTP response writer
+// - flusher: Flusher of w
+// - events: Events of the user
+func (h *handler) stream(ctx context.Context, w http.ResponseWriter, flusher http.Flusher, events <-chan *shortURLEntity.ShortURLCreatedEvent) {
+	ticker := time.NewTicker(h.keepAlive)
+	defer ticker.Stop()
+
+	for {
+		var message []byte
+
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				logger.With(ctx).Error(err.Error())
+				continue
+			}
+			message = fmt.Appendf(nil, "data: %s\n\n", data)
+		case <-ticker.C:
+			message = []byte(": ping\n\n")
+		}
+
+		if _, err := w.Write(message); err != nil {
+			return
+		}
+		flusher.Flush()
+		ticker.Reset(h.keepAlive)
+	}
+}
+
+// subscribeErrStatus maps subscription error to HTTP status code.
+// Parameters:
+// - err: Error returned by Hub.Subscribe
+// Returns:
+// - int: HTTP status code
+func subscribeErrStatus(err error) int {
+	switch {
+	case errors.Is(err, handlerErrors.ErrHandlerTooManyConnections):
+		return http.StatusTooManyRequests
+	case errors.Is(err, handlerErrors.ErrHandlerHubClosed):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// newErrorResponse creates an error response for the error.
+// Parameters:
+// - err: Error returned to the client
+// - statusCode: HTTP status code of the response
+// Returns:
+// - errorResponse: Response with the error message, Code is set by returnErrResponse
+func newErrorResponse(err error, statusCode int) errorResponse {
+	return errorResponse{err: err, Error: err.Error(), StatusCode: statusCode}
+}
+
+// returnErrResponse writes error response in JSON.
+// Parameters:
+// - errResp: Error details
+// - w: HTTP response writer
+func returnErrResponse(errResp errorResponse, w http.ResponseWriter) {
+	errResp.Code = httpErrors.Code(errResp.err, errResp.StatusCode)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(errResp.StatusCode)
+	response, err := json.Marshal(errResp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	if _, err = w.Write(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
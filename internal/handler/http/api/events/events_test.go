@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	handlerErrors "github.com/gururuby/shortener/internal/handler/http/api/events/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/events/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Hub(t *testing.T) {
+	event := &shortURLEntity.ShortURLCreatedEvent{Alias: "alias", OriginalURL: "https://ya.ru"}
+
+	t.Run("when event is published", func(t *testing.T) {
+		hub := NewHub(0)
+		first, unsubscribeFirst, err := hub.Subscribe(1)
+		require.NoError(t, err)
+		defer unsubscribeFirst()
+		second, unsubscribeSecond, err := hub.Subscribe(1)
+		require.NoError(t, err)
+		defer unsubscribeSecond()
+		other, unsubscribeOther, err := hub.Subscribe(2)
+		require.NoError(t, err)
+		defer unsubscribeOther()
+
+		hub.Publish(1, event)
+
+		assert.Equal(t, event, <-first)
+		assert.Equal(t, event, <-second)
+		assert.Empty(t, other)
+	})
+
+	t.Run("when client does not read events", func(t *testing.T) {
+		hub := NewHub(0)
+		events, unsubscribe, err := hub.Subscribe(1)
+		require.NoError(t, err)
+		defer unsubscribe()
+
+		for range subscriberBuffer + 1 {
+			hub.Publish(1, event)
+		}
+
+		assert.Len(t, events, subscriberBuffer)
+	})
+
+	t.Run("when user has too many connections", func(t *testing.T) {
+		hub := NewHub(1)
+		_, unsubscribe, err := hub.Subscribe(1)
+		require.NoError(t, err)
+
+		_, _, err = hub.Subscribe(1)
+		require.ErrorIs(t, err, handlerErrors.ErrHandlerTooManyConnections)
+
+		_, unsubscribeOther, err := hub.Subscribe(2)
+		require.NoError(t, err)
+		unsubscribeOther()
+
+		unsubscribe()
+		unsubscribe()
+		_, unsubscribe, err = hub.Subscribe(1)
+		require.NoError(t, err)
+		unsubscribe()
+	})
+
+	t.Run("when hub is closed", func(t *testing.T) {
+		hub := NewHub(0)
+		events, unsubscribe, err := hub.Subscribe(1)
+		require.NoError(t, err)
+
+		hub.Close()
+		unsubscribe()
+
+		_, ok := <-events
+		assert.False(t, ok)
+		_, _, err = hub.Subscribe(1)
+		require.ErrorIs(t, err, handlerErrors.ErrHandlerHubClosed)
+	})
+}
+
+func Test_GetEvents_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		authToken  string
+		maxConns   int
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "when credentials are not passed",
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   `{"Error":"auth token is not passed","Code":"ERR_UNAUTHORIZED","StatusCode":401}`,
+		},
+		{
+			name:       "when user has too many connections",
+			authToken:  "token",
+			maxConns:   -1,
+			wantStatus: http.StatusTooManyRequests,
+			wantBody:   `{"Error":"too many event stream connections","Code":"ERR_TOO_MANY_REQUESTS","StatusCode":429}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userUC := mocks.NewMockUserUseCase(ctrl)
+			hub := NewHub(1)
+			if tt.maxConns < 0 {
+				_, unsubscribe, err := hub.Subscribe(1)
+				require.NoError(t, err)
+				defer unsubscribe()
+			}
+			if tt.authToken != "" {
+				userUC.EXPECT().Authenticate(gomock.Any(), tt.authToken).Return(&userEntity.User{ID: 1}, nil)
+			}
+
+			router := chi.NewRouter()
+			Register(router, userUC, hub)
+
+			req := httptest.NewRequest(http.MethodGet, EventsPath, nil)
+			if tt.authToken != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.authToken)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
+
+func Test_GetEvents_Stream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(&userEntity.User{ID: 1}, nil).AnyTimes()
+
+	hub := NewHub(0)
+	h := handler{userUC: userUC, hub: hub, keepAlive: time.Hour}
+	ts := httptest.NewServer(h.GetEvents())
+	defer ts.Close()
+	defer hub.Close()
+
+	t.Run("when event is published to several clients", func(t *testing.T) {
+		first := subscribe(t, ts.URL)
+		second := subscribe(t, ts.URL)
+		waitSubscribers(t, hub, 1, 2)
+
+		hub.Publish(1, &shortURLEntity.ShortURLCreatedEvent{
+			Alias:       "alias",
+			OriginalURL: "https://ya.ru",
+			CreatedAt:   time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC),
+		})
+
+		want := `data: {"alias":"alias","original_url":"https://ya.ru","created_at":"2025-08-01T12:00:00Z"}`
+		assert.Equal(t, want, readMessage(t, first))
+		assert.Equal(t, want, readMessage(t, second))
+	})
+
+	t.Run("when there are no events", func(t *testing.T) {
+		pinging := handler{userUC: userUC, hub: hub, keepAlive: time.Millisecond * 10}
+		pingTS := httptest.NewServer(pinging.GetEvents())
+		t.Cleanup(pingTS.Close)
+
+		assert.Equal(t, ": ping", readMessage(t, subscribe(t, pingTS.URL)))
+	})
+}
+
+// subscribe opens event stream of the user authenticated by "token".
+func subscribe(t *testing.T, url string) *bufio.Reader {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer token")
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = res.Body.Close() })
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	return bufio.NewReader(res.Body)
+}
+
+// waitSubscribers waits until the user has count subscriptions.
+func waitSubscribers(t *testing.T, hub *Hub, userID, count int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+		return len(hub.subscribers[userID]) == count
+	}, time.Second, time.Millisecond*10)
+}
+
+// readMessage reads the next message of event stream within a second.
+func readMessage(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+
+	message := make(chan string, 1)
+	go func() {
+		var lines []string
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil && err != io.EOF {
+				break
+			}
+			line = strings.TrimSuffix(line, "\n")
+			if line == "" {
+				break
+			}
+			lines = append(lines, line)
+		}
+		message <- strings.Join(lines, "\n")
+	}()
+
+	select {
+	case m := <-message:
+		return m
+	case <-time.After(time.Second):
+		require.FailNow(t, "message is not received within a second")
+		return ""
+	}
+}
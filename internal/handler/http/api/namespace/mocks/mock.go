@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gururuby/shortener/internal/handler/http/api/namespace (interfaces: NamespaceUseCase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock.go -package=mocks . NamespaceUseCase
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/namespace"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNamespaceUseCase is a mock of NamespaceUseCase interface.
+type MockNamespaceUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockNamespaceUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockNamespaceUseCaseMockRecorder is the mock recorder for MockNamespaceUseCase.
+type MockNamespaceUseCaseMockRecorder struct {
+	mock *MockNamespaceUseCase
+}
+
+// NewMockNamespaceUseCase creates a new mock instance.
+func NewMockNamespaceUseCase(ctrl *gomock.Controller) *MockNamespaceUseCase {
+	mock := &MockNamespaceUseCase{ctrl: ctrl}
+	mock.recorder = &MockNamespaceUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNamespaceUseCase) EXPECT() *MockNamespaceUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CreateNamespace mocks base method.
+func (m *MockNamespaceUseCase) CreateNamespace(ctx context.Context, name string) (*entity.Namespace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNamespace", ctx, name)
+	ret0, _ := ret[0].(*entity.Namespace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNamespace indicates an expected call of CreateNamespace.
+func (mr *MockNamespaceUseCaseMockRecorder) CreateNamespace(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNamespace", reflect.TypeOf((*MockNamespaceUseCase)(nil).CreateNamespace), ctx, name)
+}
+
+// DeleteNamespace mocks base method.
+func (m *MockNamespaceUseCase) DeleteNamespace(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNamespace", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNamespace indicates an expected call of DeleteNamespace.
+func (mr *MockNamespaceUseCaseMockRecorder) DeleteNamespace(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNamespace", reflect.TypeOf((*MockNamespaceUseCase)(nil).DeleteNamespace), ctx, name)
+}
+
+// GetNamespaces mocks base method.
+func (m *MockNamespaceUseCase) GetNamespaces(ctx context.Context) ([]*entity.Namespace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNamespaces", ctx)
+	ret0, _ := ret[0].([]*entity.Namespace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNamespaces indicates an expected call of GetNamespaces.
+func (mr *MockNamespaceUseCaseMockRecorder) GetNamespaces(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespaces", reflect.TypeOf((*MockNamespaceUseCase)(nil).GetNamespaces), ctx)
+}
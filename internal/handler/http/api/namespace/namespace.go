@@ -0,0 +1,248 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . NamespaceUseCase
+
+/*
+Package handler implements HTTP request handlers for namespaces of short URLs.
+
+It provides:
+- Listing of namespaces
+- Creation and deletion of namespaces restricted to administrators
+- Error handling and status code management
+
+Administrators are authenticated by X-Admin-Token header compared with the
+configured token; namespaces cannot be created or deleted if it is not configured.
+*/
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	entity "github.com/gururuby/shortener/internal/domain/entity/namespace"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/namespace/errors"
+	httpErrors "github.com/gururuby/shortener/internal/handler/http/errors"
+)
+
+// Available constants
+const (
+	NamespacesPath    = "/api/namespaces"        // Path of namespaces
+	NamespacePath     = "/api/namespaces/{name}" // Path of single namespace
+	namespacesTimeout = time.Second * 5          // Timeout for namespace operations
+	adminTokenHeader  = "X-Admin-Token"          // Name of the header with admin token
+)
+
+// Router defines the interface for HTTP request routing.
+type Router interface {
+	// Get registers a handler for GET requests at the specified path
+	Get(path string, h http.HandlerFunc)
+	// Post registers a handler for POST requests at the specified path
+	Post(path string, h http.HandlerFunc)
+	// Delete registers a handler for DELETE requests at the specified path
+	Delete(path string, h http.HandlerFunc)
+}
+
+// NamespaceUseCase defines the interface for namespace business logic.
+type NamespaceUseCase interface {
+	// CreateNamespace creates a new namespace
+	CreateNamespace(ctx context.Context, name string) (*entity.Namespace, error)
+	// GetNamespaces retrieves all namespaces
+	GetNamespaces(ctx context.Context) ([]*entity.Namespace, error)
+	// DeleteNamespace deletes a namespace without short URLs
+	DeleteNamespace(ctx context.Context, name string) error
+}
+
+// handler implements the HTTP request handlers for namespace operations.
+type handler struct {
+	uc         NamespaceUseCase // Namespace business logic service
+	router     Router           // Request router
+	adminToken string           // Token of administrators, empty disables namespace management
+}
+
+// errorResponse represents an API error response.
+// Code is a stable machine-readable error code, see httpErrors.Code.
+type errorResponse struct {
+	err        error // Error returned to the client, maps to Code
+	Error      string
+	Code       string
+	StatusCode int
+}
+
+// createNamespaceRequest represents request body of namespace creation.
+type createNamespaceRequest struct {
+	Name string `json:"name"`
+}
+
+// Register sets up the namespace routes.
+// Parameters:
+// - router: The HTTP router implementation
+// - uc: Namespace business logic service
+// - adminToken: Token of administrators, empty disables creation and deletion of namespaces
+func Register(router Router, uc NamespaceUseCase, adminToken string) {
+	h := handler{router: router, uc: uc, adminToken: adminToken}
+	h.router.Get(NamespacesPath, h.GetNamespaces())
+	h.router.Post(NamespacesPath, h.CreateNamespace())
+	h.router.Delete(NamespacePath, h.DeleteNamespace())
+}
+
+// GetNamespaces handles GET requests to list namespaces.
+// Returns an HTTP handler function that:
+// - Returns namespaces ordered by name with 200 status
+// - Returns 500 if namespaces cannot be loaded
+func (h *handler) GetNamespaces() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), namespacesTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		namespaces, err := h.uc.GetNamespaces(ctx)
+		if err != nil {
+			returnErrResponse(newErrorResponse(err, errStatus(err)), w)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, namespaces)
+	}
+}
+
+// CreateNamespace handles POST requests to create a namespace.
+// Request body is a JSON object with namespace `name`.
+// Returns an HTTP handler function that:
+// - Authenticates the administrator, 403 if admin token is missing or invalid
+// - Creates the namespace, 422 if name is invalid
+// - Returns the created namespace with 201 status, 409 if it already exists
+func (h *handler) CreateNamespace() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req createNamespaceRequest
+
+		ctx, cancel := context.WithTimeout(r.Context(), namespacesTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if !h.isAdmin(r) {
+			returnErrResponse(newErrorResponse(httpErrors.ErrAdminTokenInvalid, http.StatusForbidden), w)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusBadRequest), w)
+			return
+		}
+
+		namespace, err := h.uc.CreateNamespace(ctx, req.Name)
+		if err != nil {
+			returnErrResponse(newErrorResponse(err, errStatus(err)), w)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, namespace)
+	}
+}
+
+// DeleteNamespace handles DELETE requests to delete a namespace.
+// Returns an HTTP handler function that:
+// - Authenticates the administrator, 403 if admin token is missing or invalid
+// - Deletes the namespace, 404 if it doesn't exist, 409 if it has short URLs, 422 if it is default
+// - Returns 204 on success
+func (h *handler) DeleteNamespace() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), namespacesTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if !h.isAdmin(r) {
+			returnErrResponse(newErrorResponse(httpErrors.ErrAdminTokenInvalid, http.StatusForbidden), w)
+			return
+		}
+
+		if err := h.uc.DeleteNamespace(ctx, chi.URLParam(r, "name")); err != nil {
+			returnErrResponse(newErrorResponse(err, errStatus(err)), w)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// isAdmin reports whether the request carries the configured admin token.
+// Tokens are compared in constant time, so they cannot be guessed by response timing.
+// Parameters:
+// - r: HTTP request
+// Returns:
+// - bool: false if the token is missing or invalid, or administration is disabled
+func (h *handler) isAdmin(r *http.Request) bool {
+	if h.adminToken == "" {
+		return false
+	}
+	token := r.Header.Get(adminTokenHeader)
+	return subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) == 1
+}
+
+// errStatus maps namespace use case errors to HTTP status codes.
+// Parameters:
+// - err: Error returned by namespace use case
+// Returns:
+// - int: HTTP status code
+func errStatus(err error) int {
+	switch {
+	case errors.Is(err, ucErrors.ErrNamespaceInvalidName), errors.Is(err, ucErrors.ErrNamespaceDefault):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ucErrors.ErrNamespaceAlreadyExist), errors.Is(err, ucErrors.ErrNamespaceNotEmpty):
+		return http.StatusConflict
+	case errors.Is(err, ucErrors.ErrNamespaceNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeJSON writes a JSON response with the status code.
+// Parameters:
+// - w: HTTP response writer
+// - statusCode: HTTP status code
+// - v: Value to encode
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	response, err := json.Marshal(v)
+	if err != nil {
+		returnErrResponse(newErrorResponse(err, http.StatusInternalServerError), w)
+		return
+	}
+
+	w.WriteHeader(statusCode)
+
+	if _, err = w.Write(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// newErrorResponse creates an error response for the error.
+// Parameters:
+// - err: Error returned to the client
+// - statusCode: HTTP status code of the response
+// Returns:
+// - errorResponse: Response with the error message, Code is set by returnErrResponse
+func newErrorResponse(err error, statusCode int) errorResponse {
+	return errorResponse{err: err, Error: err.Error(), StatusCode: statusCode}
+}
+
+// returnErrResponse writes error response in JSON.
+// Parameters:
+// - errResp: Error details
+// - w: HTTP response writer
+func returnErrResponse(errResp errorResponse, w http.ResponseWriter) {
+	errResp.Code = httpErrors.Code(errResp.err, errResp.StatusCode)
+	w.WriteHeader(errResp.StatusCode)
+	response, err := json.Marshal(errResp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	if _, err = w.Write(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	entity "github.com/gururuby/shortener/internal/domain/entity/namespace"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/namespace/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/namespace/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_GetNamespaces(t *testing.T) {
+	createdAt := time.Date(2025, 8, 15, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		ucErr      error
+		namespaces []*entity.Namespace
+		name       string
+		body       string
+		code       int
+	}{
+		{
+			name:       "when namespaces are listed",
+			namespaces: []*entity.Namespace{{Name: "default", CreatedAt: createdAt}},
+			code:       http.StatusOK,
+			body:       `[{"created_at":"2025-08-15T09:00:00Z","name":"default"}]`,
+		},
+		{
+			name:  "when storage fails",
+			ucErr: ucErrors.ErrNamespaceStorageNotWorking,
+			code:  http.StatusInternalServerError,
+			body:  `{"Error":"namespace storage is not working","Code":"ERR_INTERNAL","StatusCode":500}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			uc := mocks.NewMockNamespaceUseCase(ctrl)
+			uc.EXPECT().GetNamespaces(gomock.Any()).Return(tt.namespaces, tt.ucErr)
+
+			router := chi.NewRouter()
+			Register(router, uc, "secret")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, NamespacesPath, nil))
+
+			resp := w.Result()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			assert.Equal(t, tt.code, resp.StatusCode)
+			assert.JSONEq(t, tt.body, string(body))
+		})
+	}
+}
+
+func Test_CreateNamespace(t *testing.T) {
+	tests := []struct {
+		setup      func(uc *mocks.MockNamespaceUseCase)
+		name       string
+		adminToken string
+		token      string
+		body       string
+		code       int
+	}{
+		{
+			name:       "when namespace is created",
+			adminToken: "secret",
+			token:      "secret",
+			body:       `{"name":"team-a"}`,
+			setup: func(uc *mocks.MockNamespaceUseCase) {
+				uc.EXPECT().CreateNamespace(gomock.Any(), "team-a").Return(&entity.Namespace{Name: "team-a"}, nil)
+			},
+			code: http.StatusCreated,
+		},
+		{
+			name:       "when admin token is missing",
+			adminToken: "secret",
+			body:       `{"name":"team-a"}`,
+			code:       http.StatusForbidden,
+		},
+		{
+			name:       "when admin token is invalid",
+			adminToken: "secret",
+			token:      "guess",
+			body:       `{"name":"team-a"}`,
+			code:       http.StatusForbidden,
+		},
+		{
+			name:  "when administration is disabled",
+			token: "secret",
+			body:  `{"name":"team-a"}`,
+			code:  http.StatusForbidden,
+		},
+		{
+			name:       "when body is invalid",
+			adminToken: "secret",
+			token:      "secret",
+			body:       `{`,
+			code:       http.StatusBadRequest,
+		},
+		{
+			name:       "when name is invalid",
+			adminToken: "secret",
+			token:      "secret",
+			body:       `{"name":"api"}`,
+			setup: func(uc *mocks.MockNamespaceUseCase) {
+				uc.EXPECT().CreateNamespace(gomock.Any(), "api").Return(nil, ucErrors.ErrNamespaceInvalidName)
+			},
+			code: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "when namespace already exists",
+			adminToken: "secret",
+			token:      "secret",
+			body:       `{"name":"team-a"}`,
+			setup: func(uc *mocks.MockNamespaceUseCase) {
+				uc.EXPECT().CreateNamespace(gomock.Any(), "team-a").Return(nil, ucErrors.ErrNamespaceAlreadyExist)
+			},
+			code: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			uc := mocks.NewMockNamespaceUseCase(ctrl)
+			if tt.setup != nil {
+				tt.setup(uc)
+			}
+
+			router := chi.NewRouter()
+			Register(router, uc, tt.adminToken)
+
+			req := httptest.NewRequest(http.MethodPost, NamespacesPath, strings.NewReader(tt.body))
+			req.Header.Set("X-Admin-Token", tt.token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func Test_DeleteNamespace(t *testing.T) {
+	tests := []struct {
+		setup func(uc *mocks.MockNamespaceUseCase)
+		name  string
+		token string
+		code  int
+	}{
+		{
+			name:  "when namespace is deleted",
+			token: "secret",
+			setup: func(uc *mocks.MockNamespaceUseCase) {
+				uc.EXPECT().DeleteNamespace(gomock.Any(), "team-a").Return(nil)
+			},
+			code: http.StatusNoContent,
+		},
+		{
+			name: "when admin token is missing",
+			code: http.StatusForbidden,
+		},
+		{
+			name:  "when namespace does not exist",
+			token: "secret",
+			setup: func(uc *mocks.MockNamespaceUseCase) {
+				uc.EXPECT().DeleteNamespace(gomock.Any(), "team-a").Return(ucErrors.ErrNamespaceNotFound)
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			name:  "when namespace has short URLs",
+			token: "secret",
+			setup: func(uc *mocks.MockNamespaceUseCase) {
+				uc.EXPECT().DeleteNamespace(gomock.Any(), "team-a").Return(ucErrors.ErrNamespaceNotEmpty)
+			},
+			code: http.StatusConflict,
+		},
+		{
+			name:  "when namespace is default",
+			token: "secret",
+			setup: func(uc *mocks.MockNamespaceUseCase) {
+				uc.EXPECT().DeleteNamespace(gomock.Any(), "team-a").Return(ucErrors.ErrNamespaceDefault)
+			},
+			code: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			uc := mocks.NewMockNamespaceUseCase(ctrl)
+			if tt.setup != nil {
+				tt.setup(uc)
+			}
+
+			router := chi.NewRouter()
+			Register(router, uc, "secret")
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/namespaces/team-a", nil)
+			req.Header.Set("X-Admin-Token", tt.token)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gururuby/shortener/internal/handler/http/api/preview (interfaces: PreviewUseCase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock.go -package=mocks . PreviewUseCase
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	preview "github.com/gururuby/shortener/pkg/preview"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPreviewUseCase is a mock of PreviewUseCase interface.
+type MockPreviewUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreviewUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockPreviewUseCaseMockRecorder is the mock recorder for MockPreviewUseCase.
+type MockPreviewUseCaseMockRecorder struct {
+	mock *MockPreviewUseCase
+}
+
+// NewMockPreviewUseCase creates a new mock instance.
+func NewMockPreviewUseCase(ctrl *gomock.Controller) *MockPreviewUseCase {
+	mock := &MockPreviewUseCase{ctrl: ctrl}
+	mock.recorder = &MockPreviewUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreviewUseCase) EXPECT() *MockPreviewUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Preview mocks base method.
+func (m *MockPreviewUseCase) Preview(ctx context.Context, rawURL string) (*preview.Metadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preview", ctx, rawURL)
+	ret0, _ := ret[0].(*preview.Metadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preview indicates an expected call of Preview.
+func (mr *MockPreviewUseCaseMockRecorder) Preview(ctx, rawURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preview", reflect.TypeOf((*MockPreviewUseCase)(nil).Preview), ctx, rawURL)
+}
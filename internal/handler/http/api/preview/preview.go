@@ -0,0 +1,144 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . PreviewUseCase
+
+/*
+Package handler implements HTTP request handlers for page previews.
+
+It provides:
+- Preview endpoint returning title, description, image and favicon of a page
+- Error handling and status code management
+
+The endpoint requires no authentication, it is rate limited per client IP in router.
+*/
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/preview/errors"
+	httpErrors "github.com/gururuby/shortener/internal/handler/http/errors"
+	"github.com/gururuby/shortener/pkg/preview"
+)
+
+// Available constants
+const (
+	PreviewPath    = "/api/preview"   // Path of page preview endpoint
+	urlParam       = "url"            // Query parameter with URL of the page
+	previewTimeout = time.Second * 10 // Timeout for page preview
+)
+
+// Router defines the interface for HTTP request routing.
+type Router interface {
+	// Get registers a handler for GET requests at the specified path
+	Get(path string, h http.HandlerFunc)
+}
+
+// PreviewUseCase defines the interface for page preview business logic.
+type PreviewUseCase interface {
+	// Preview returns metadata of the page at the URL
+	Preview(ctx context.Context, rawURL string) (*preview.Metadata, error)
+}
+
+// handler implements the HTTP request handlers for preview operations.
+type handler struct {
+	uc     PreviewUseCase // Preview business logic service
+	router Router         // Request router
+}
+
+// errorResponse represents an API error response.
+// Code is a stable machine-readable error code, see httpErrors.Code.
+type errorResponse struct {
+	err        error // Error returned to the client, maps to Code
+	Error      string
+	Code       string
+	StatusCode int
+}
+
+// Register sets up the preview routes.
+// Parameters:
+// - router: The HTTP router implementation
+// - uc: Preview business logic service
+func Register(router Router, uc PreviewUseCase) {
+	h := handler{router: router, uc: uc}
+	h.router.Get(PreviewPath, h.GetPreview())
+}
+
+// GetPreview handles requests for a page preview.
+// Returns an HTTP handler function that:
+// - Takes URL of the page from url query parameter
+// - Fetches the page metadata, previews are cached for an hour
+// - Returns appropriate status codes:
+//   - 200 OK with URL, title, description, image and favicon in JSON
+//   - 422 Unprocessable Entity if URL is invalid or its domain is not permitted
+//   - 502 Bad Gateway if the page cannot be fetched
+//   - 500 Internal Server Error for other errors
+func (h *handler) GetPreview() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), previewTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		md, err := h.uc.Preview(ctx, r.URL.Query().Get(urlParam))
+		if err != nil {
+			returnErrResponse(newErrorResponse(err, previewErrStatus(err)), w)
+			return
+		}
+
+		response, err := json.Marshal(md)
+		if err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusInternalServerError), w)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err = w.Write(response); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
+// previewErrStatus maps preview errors to HTTP status codes.
+// Parameters:
+// - err: Error returned by use case
+// Returns:
+// - int: HTTP status code
+func previewErrStatus(err error) int {
+	switch {
+	case errors.Is(err, ucErrors.ErrPreviewInvalidURL),
+		errors.Is(err, ucErrors.ErrPreviewDomainNotPermitted):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ucErrors.ErrPreviewUnavailable):
+		return http.StatusBadGateway
+	}
+	return http.StatusInternalServerError
+}
+
+// newErrorResponse creates an error response for the error.
+// Parameters:
+// - err: Error returned to the client
+// - statusCode: HTTP status code of the response
+// Returns:
+// - errorResponse: Response with the error message, Code is set by returnErrResponse
+func newErrorResponse(err error, statusCode int) errorResponse {
+	return errorResponse{err: err, Error: err.Error(), StatusCode: statusCode}
+}
+
+// returnErrResponse writes error response in JSON.
+// Parameters:
+// - errResp: Error details
+// - w: HTTP response writer
+func returnErrResponse(errResp errorResponse, w http.ResponseWriter) {
+	errResp.Code = httpErrors.Code(errResp.err, errResp.StatusCode)
+	w.WriteHeader(errResp.StatusCode)
+	response, err := json.Marshal(errResp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	if _, err = w.Write(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
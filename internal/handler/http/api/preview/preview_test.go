@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/preview/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/preview/mocks"
+	"github.com/gururuby/shortener/pkg/preview"
+	previewErrors "github.com/gururuby/shortener/pkg/preview/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_GetPreview(t *testing.T) {
+	type useCaseResult struct {
+		metadata *preview.Metadata
+		err      error
+	}
+
+	type response struct {
+		body string
+		code int
+	}
+
+	tests := []struct {
+		useCaseRes useCaseResult
+		name       string
+		url        string
+		response   response
+	}{
+		{
+			name: "when page is previewed",
+			url:  "https://example.com",
+			useCaseRes: useCaseResult{
+				metadata: &preview.Metadata{
+					URL:         "https://example.com",
+					Title:       "Example",
+					Description: "Example page",
+					Image:       "https://example.com/cover.png",
+					Favicon:     "https://example.com/favicon.ico",
+				},
+			},
+			response: response{
+				code: http.StatusOK,
+				body: `{"url":"https://example.com","title":"Example","description":"Example page","image":"https://example.com/cover.png","favicon":"https://example.com/favicon.ico"}`,
+			},
+		},
+		{
+			name:       "when URL is invalid",
+			url:        "example",
+			useCaseRes: useCaseResult{err: ucErrors.ErrPreviewInvalidURL},
+			response: response{
+				code: http.StatusUnprocessableEntity,
+				body: `{"Error":"invalid URL, please specify valid URL","Code":"ERR_INVALID_URL","StatusCode":422}`,
+			},
+		},
+		{
+			name:       "when host resolves to private address",
+			url:        "http://localhost",
+			useCaseRes: useCaseResult{err: fmt.Errorf("%w: %w", ucErrors.ErrPreviewDomainNotPermitted, previewErrors.ErrPreviewPrivateAddress)},
+			response: response{
+				code: http.StatusUnprocessableEntity,
+				body: `{"Error":"URL domain is not permitted: page host resolves to a private address","Code":"ERR_DOMAIN_NOT_PERMITTED","StatusCode":422}`,
+			},
+		},
+		{
+			name:       "when page cannot be fetched",
+			url:        "https://example.com",
+			useCaseRes: useCaseResult{err: fmt.Errorf("%w: %w", ucErrors.ErrPreviewUnavailable, previewErrors.ErrPreviewNotHTML)},
+			response: response{
+				code: http.StatusBadGateway,
+				body: `{"Error":"cannot fetch page preview: page is not an HTML document","Code":"ERR_BAD_GATEWAY","StatusCode":502}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			uc := mocks.NewMockPreviewUseCase(ctrl)
+			uc.EXPECT().Preview(gomock.Any(), tt.url).Return(tt.useCaseRes.metadata, tt.useCaseRes.err)
+
+			h := handler{router: chi.NewRouter(), uc: uc}
+
+			req := httptest.NewRequest(http.MethodGet, PreviewPath+"?url="+url.QueryEscape(tt.url), nil)
+			w := httptest.NewRecorder()
+			h.GetPreview()(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.response.code, resp.StatusCode)
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+			assert.JSONEq(t, tt.response.body, string(body))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gururuby/shortener/internal/handler/http/api/qr (interfaces: ShortURLUseCase,QRUseCase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock.go -package=mocks . ShortURLUseCase,QRUseCase
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockShortURLUseCase is a mock of ShortURLUseCase interface.
+type MockShortURLUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockShortURLUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockShortURLUseCaseMockRecorder is the mock recorder for MockShortURLUseCase.
+type MockShortURLUseCaseMockRecorder struct {
+	mock *MockShortURLUseCase
+}
+
+// NewMockShortURLUseCase creates a new mock instance.
+func NewMockShortURLUseCase(ctrl *gomock.Controller) *MockShortURLUseCase {
+	mock := &MockShortURLUseCase{ctrl: ctrl}
+	mock.recorder = &MockShortURLUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShortURLUseCase) EXPECT() *MockShortURLUseCaseMockRecorder {
+	return m.recorder
+}
+
+// GetShortURL mocks base method.
+func (m *MockShortURLUseCase) GetShortURL(ctx context.Context, namespace, alias string) (*entity.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortURL", ctx, namespace, alias)
+	ret0, _ := ret[0].(*entity.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortURL indicates an expected call of GetShortURL.
+func (mr *MockShortURLUseCaseMockRecorder) GetShortURL(ctx, namespace, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortURL", reflect.TypeOf((*MockShortURLUseCase)(nil).GetShortURL), ctx, namespace, alias)
+}
+
+// MockQRUseCase is a mock of QRUseCase interface.
+type MockQRUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockQRUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockQRUseCaseMockRecorder is the mock recorder for MockQRUseCase.
+type MockQRUseCaseMockRecorder struct {
+	mock *MockQRUseCase
+}
+
+// NewMockQRUseCase creates a new mock instance.
+func NewMockQRUseCase(ctrl *gomock.Controller) *MockQRUseCase {
+	mock := &MockQRUseCase{ctrl: ctrl}
+	mock.recorder = &MockQRUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQRUseCase) EXPECT() *MockQRUseCaseMockRecorder {
+	return m.recorder
+}
+
+// PNG mocks base method.
+func (m *MockQRUseCase) PNG(alias string, size int) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PNG", alias, size)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PNG indicates an expected call of PNG.
+func (mr *MockQRUseCaseMockRecorder) PNG(alias, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PNG", reflect.TypeOf((*MockQRUseCase)(nil).PNG), alias, size)
+}
+
+// SVG mocks base method.
+func (m *MockQRUseCase) SVG(alias string, size int) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SVG", alias, size)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SVG indicates an expected call of SVG.
+func (mr *MockQRUseCaseMockRecorder) SVG(alias, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SVG", reflect.TypeOf((*MockQRUseCase)(nil).SVG), alias, size)
+}
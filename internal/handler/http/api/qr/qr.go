@@ -0,0 +1,150 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . ShortURLUseCase,QRUseCase
+
+/*
+Package handler implements HTTP request handlers for QR code generation.
+
+It provides:
+- QR code endpoint for short URLs
+- PNG and SVG output formats
+- Request validation and error handling
+*/
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+)
+
+// Available constants
+const (
+	qrPath      = "/api/shorturl/{alias}/qr" // Path pattern for QR code endpoint
+	qrTimeout   = time.Second * 30           // Timeout for QR code generation
+	formatPNG   = "png"                      // PNG output format (default)
+	formatSVG   = "svg"                      // SVG output format
+	formatParam = "format"                   // Query parameter with requested format
+	sizeParam   = "size"                     // Query parameter with requested image size
+)
+
+// Router defines the interface for HTTP request routing.
+type Router interface {
+	// Get registers a handler for GET requests at the specified path
+	Get(path string, h http.HandlerFunc)
+}
+
+// ShortURLUseCase defines the interface for short URL operations.
+type ShortURLUseCase interface {
+	// GetShortURL retrieves the short URL by namespace and alias without side effects
+	GetShortURL(ctx context.Context, namespace, alias string) (*shortURLEntity.ShortURL, error)
+}
+
+// QRUseCase defines the interface for QR code generation.
+type QRUseCase interface {
+	// PNG encodes the short URL of the alias as a PNG QR code
+	PNG(alias string, size int) ([]byte, error)
+	// SVG encodes the short URL of the alias as an SVG QR code
+	SVG(alias string, size int) ([]byte, error)
+}
+
+// handler implements the HTTP request handlers for QR code operations.
+type handler struct {
+	urlUC  ShortURLUseCase // URL business logic service
+	qrUC   QRUseCase       // QR code generation service
+	router Router          // Request router
+}
+
+// Register sets up the QR code routes.
+// Parameters:
+// - router: The HTTP router implementation
+// - urlUC: URL business logic service
+// - qrUC: QR code generation service
+func Register(router Router, urlUC ShortURLUseCase, qrUC QRUseCase) {
+	h := handler{router: router, urlUC: urlUC, qrUC: qrUC}
+	h.router.Get(qrPath, h.GetQR())
+}
+
+// GetQR handles requests for a short URL QR code.
+// Returns an HTTP handler function that:
+// - Validates the request method and query parameters
+// - Checks that the short URL exists and is not deleted
+// - Returns the QR code with appropriate status codes:
+//   - 200 OK with PNG (default) or SVG image
+//   - 400 Bad Request for invalid size or format
+//   - 404 Not Found if alias doesn't exist
+//   - 410 Gone if short URL was deleted
+func (h *handler) GetQR() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err    error
+			size   int
+			image  []byte
+			format = formatPNG
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), qrTimeout)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			http.Error(w, fmt.Sprintf("HTTP method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		query := r.URL.Query()
+
+		if v := query.Get(sizeParam); v != "" {
+			if size, err = strconv.Atoi(v); err != nil {
+				http.Error(w, "size must be an integer", http.StatusBadRequest)
+				return
+			}
+		}
+
+		if v := query.Get(formatParam); v != "" {
+			format = v
+		}
+
+		alias := chi.URLParam(r, "alias")
+
+		if _, err = h.urlUC.GetShortURL(ctx, "", alias); err != nil {
+			switch {
+			case errors.Is(err, ucErrors.ErrShortURLDeleted):
+				http.Error(w, err.Error(), http.StatusGone)
+			case errors.Is(err, ucErrors.ErrShortURLSourceURLNotFound), errors.Is(err, ucErrors.ErrShortURLEmptyAlias):
+				http.Error(w, err.Error(), http.StatusNotFound)
+			default:
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+
+		switch format {
+		case formatPNG:
+			w.Header().Set("Content-Type", "image/png")
+			image, err = h.qrUC.PNG(alias, size)
+		case formatSVG:
+			w.Header().Set("Content-Type", "image/svg+xml")
+			image, err = h.qrUC.SVG(alias, size)
+		default:
+			http.Error(w, fmt.Sprintf("format %s is not supported", format), http.StatusBadRequest)
+			return
+		}
+
+		if err != nil {
+			w.Header().Del("Content-Type")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+
+		if _, err = w.Write(image); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
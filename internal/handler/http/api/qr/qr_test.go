@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/qr/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_GetQR_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	qrUC := mocks.NewMockQRUseCase(ctrl)
+
+	r := chi.NewRouter()
+	Register(r, urlUC, qrUC)
+
+	tests := []struct {
+		setupQR     func()
+		name        string
+		path        string
+		contentType string
+		body        string
+	}{
+		{
+			name: "when request png with default size",
+			path: "/api/shorturl/alias/qr",
+			setupQR: func() {
+				qrUC.EXPECT().PNG("alias", 0).Return([]byte("png"), nil)
+			},
+			contentType: "image/png",
+			body:        "png",
+		},
+		{
+			name: "when request png with size",
+			path: "/api/shorturl/alias/qr?size=512",
+			setupQR: func() {
+				qrUC.EXPECT().PNG("alias", 512).Return([]byte("png"), nil)
+			},
+			contentType: "image/png",
+			body:        "png",
+		},
+		{
+			name: "when request svg",
+			path: "/api/shorturl/alias/qr?format=svg&size=128",
+			setupQR: func() {
+				qrUC.EXPECT().SVG("alias", 128).Return([]byte("<svg></svg>"), nil)
+			},
+			contentType: "image/svg+xml",
+			body:        "<svg></svg>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urlUC.EXPECT().GetShortURL(gomock.Any(), "", "alias").Return(&shortURLEntity.ShortURL{Alias: "alias", SourceURL: "https://ya.ru"}, nil)
+			tt.setupQR()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+
+			defer func() {
+				err := resp.Body.Close()
+				require.NoError(t, err)
+			}()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, tt.contentType, resp.Header.Get("Content-Type"))
+			assert.Equal(t, tt.body, string(body))
+		})
+	}
+}
+
+func Test_GetQR_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	qrUC := mocks.NewMockQRUseCase(ctrl)
+
+	r := chi.NewRouter()
+	Register(r, urlUC, qrUC)
+
+	tests := []struct {
+		ucErr  error
+		name   string
+		path   string
+		status int
+	}{
+		{
+			name:   "when alias does not exist",
+			path:   "/api/shorturl/unknown/qr",
+			ucErr:  ucErrors.ErrShortURLSourceURLNotFound,
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "when short URL was deleted",
+			path:   "/api/shorturl/deleted/qr",
+			ucErr:  ucErrors.ErrShortURLDeleted,
+			status: http.StatusGone,
+		},
+		{
+			name:   "when size is not a number",
+			path:   "/api/shorturl/alias/qr?size=big",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "when format is not supported",
+			path:   "/api/shorturl/alias/qr?format=gif",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urlUC.EXPECT().GetShortURL(gomock.Any(), "", gomock.Any()).Return(nil, tt.ucErr).MaxTimes(1)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+
+			defer func() {
+				err := resp.Body.Close()
+				require.NoError(t, err)
+			}()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
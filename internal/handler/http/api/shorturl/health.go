@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+)
+
+const shortURLHealthPath = "/api/shorturl/{alias}/health" // Path pattern for health of short URL's source URL
+
+// shortURLHealthDTO defines the response structure of health of a short URL's source URL.
+type shortURLHealthDTO struct {
+	Alias       string `json:"alias"`
+	LastChecked string `json:"last_checked,omitempty"` // Time of the last check in RFC 3339 format, UTC, empty if never checked
+	Healthy     bool   `json:"healthy"`                // Source URL was reachable on the last check, true if never checked
+}
+
+// newShortURLHealthDTO builds health response of a short URL.
+// Parameters:
+// - shortURL: Short URL entity
+// Returns:
+// - *shortURLHealthDTO: Health of the source URL
+func newShortURLHealthDTO(shortURL *shortURLEntity.ShortURL) *shortURLHealthDTO {
+	dto := &shortURLHealthDTO{
+		Alias:   shortURL.Alias,
+		Healthy: shortURL.IsSourceHealthy(),
+	}
+	if !shortURL.CheckedAt.IsZero() {
+		dto.LastChecked = shortURL.CheckedAt.UTC().Format(time.RFC3339)
+	}
+	return dto
+}
+
+// ShortURLHealth handles GET requests for health of a short URL's source URL.
+// Health is checked in background, see urlvalidator package.
+// Returns an HTTP handler function that:
+// - Looks up the short URL without side effects
+// - Requires authentication of the owner for private short URLs only
+// - Returns appropriate responses:
+//   - 200 OK with alias, health and time of the last check
+//   - 403 Forbidden if short URL is private and requested by anyone but the owner
+//   - 404 Not Found if alias doesn't exist
+//   - 410 Gone if short URL was deleted
+//   - 504 Gateway Timeout if lookup takes longer than configured timeout
+//   - 500 for other errors
+func (h *handler) ShortURLHealth() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var errRes errorResponse
+
+		w.Header().Set("Content-Type", "application/json")
+
+		ctx, cancel := context.WithTimeout(r.Context(), h.cfg.ReadURLTimeout)
+		defer cancel()
+
+		shortURL, err := h.urlUC.GetShortURL(ctx, "", chi.URLParam(r, "alias"))
+		if err != nil {
+			errRes = newErrorResponse(err, http.StatusInternalServerError)
+			switch {
+			case errors.Is(ctx.Err(), context.DeadlineExceeded):
+				returnTimeoutResponse(w)
+				return
+			case errors.Is(err, ucErrors.ErrShortURLDeleted):
+				errRes.StatusCode = http.StatusGone
+			case errors.Is(err, ucErrors.ErrShortURLSourceURLNotFound), errors.Is(err, ucErrors.ErrShortURLEmptyAlias):
+				errRes.StatusCode = http.StatusNotFound
+			}
+			returnErrResponse(errRes, w)
+			return
+		}
+
+		if shortURL.IsPrivate() && !shortURL.IsAccessibleBy(auth.FindUser(h.userUC, r)) {
+			errRes = newErrorResponse(ucErrors.ErrShortURLForbidden, http.StatusForbidden)
+			returnErrResponse(errRes, w)
+			return
+		}
+
+		response, err := jsonIter.Marshal(newShortURLHealthDTO(shortURL))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err = w.Write(response); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
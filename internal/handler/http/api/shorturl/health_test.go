@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	entity "github.com/gururuby/shortener/internal/domain/entity/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/shorturl/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_ShortURLHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	checkedAt := time.Date(2025, 9, 10, 6, 0, 0, 0, time.UTC)
+
+	r := chi.NewRouter()
+	Register(r, userUC, urlUC, nil, testServerCfg)
+
+	tests := []struct {
+		shortURL *shortURLEntity.ShortURL
+		ucErr    error
+		name     string
+		alias    string
+		token    string
+		body     string
+		status   int
+	}{
+		{
+			name:     "when source URL is healthy",
+			alias:    "healthy",
+			shortURL: &shortURLEntity.ShortURL{Alias: "healthy", SourceURL: "https://ya.ru", IsHealthy: true, CheckedAt: checkedAt},
+			status:   http.StatusOK,
+			body:     `{"alias":"healthy","healthy":true,"last_checked":"2025-09-10T06:00:00Z"}`,
+		},
+		{
+			name:     "when source URL is unhealthy",
+			alias:    "dead",
+			shortURL: &shortURLEntity.ShortURL{Alias: "dead", SourceURL: "https://ya.ru/missing", CheckedAt: checkedAt},
+			status:   http.StatusOK,
+			body:     `{"alias":"dead","healthy":false,"last_checked":"2025-09-10T06:00:00Z"}`,
+		},
+		{
+			name:     "when source URL was never checked",
+			alias:    "new",
+			shortURL: &shortURLEntity.ShortURL{Alias: "new", SourceURL: "https://ya.ru"},
+			status:   http.StatusOK,
+			body:     `{"alias":"new","healthy":true}`,
+		},
+		{
+			name:     "when owner requests private alias",
+			alias:    "private",
+			token:    "owner",
+			shortURL: &shortURLEntity.ShortURL{Alias: "private", SourceURL: "https://ya.ru", UserID: 1, Visibility: shortURLEntity.VisibilityPrivate},
+			status:   http.StatusOK,
+			body:     `{"alias":"private","healthy":true}`,
+		},
+		{
+			name:     "when anonymous user requests private alias",
+			alias:    "private",
+			shortURL: &shortURLEntity.ShortURL{Alias: "private", SourceURL: "https://ya.ru", UserID: 1, Visibility: shortURLEntity.VisibilityPrivate},
+			status:   http.StatusForbidden,
+			body:     `{"Error":"short URL is private","Code":"ERR_FORBIDDEN","StatusCode":403}`,
+		},
+		{
+			name:   "when alias was deleted",
+			alias:  "deleted",
+			ucErr:  ucErrors.ErrShortURLDeleted,
+			status: http.StatusGone,
+			body:   `{"Error":"short URL was deleted","Code":"ERR_GONE","StatusCode":410}`,
+		},
+		{
+			name:   "when alias does not exist",
+			alias:  "unknown",
+			ucErr:  ucErrors.ErrShortURLSourceURLNotFound,
+			status: http.StatusNotFound,
+			body:   `{"Error":"source URL not found","Code":"ERR_NOT_FOUND","StatusCode":404}`,
+		},
+	}
+
+	userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(&entity.User{ID: 1}, nil).AnyTimes()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urlUC.EXPECT().GetShortURL(gomock.Any(), "", tt.alias).Return(tt.shortURL, tt.ucErr)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/shorturl/"+tt.alias+"/health", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+			assert.JSONEq(t, tt.body, string(body))
+		})
+	}
+}
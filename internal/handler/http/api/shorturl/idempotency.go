@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+	"github.com/gururuby/shortener/internal/infra/idempotency"
+)
+
+// idempotent wraps a short URL creation handler with replay of requests retried with the same Idempotency-Key.
+// The handler is returned as is if no idempotency store is configured.
+// Parameters:
+// - next: Handler to wrap
+// Returns:
+// - http.HandlerFunc: Wrapped handler
+func (h *handler) idempotent(next http.HandlerFunc) http.HandlerFunc {
+	if h.idempotencyStore == nil {
+		return next
+	}
+	return idempotency.Middleware(h.idempotencyStore, h.cfg.IdempotencyTTL, h.idempotencyScope)(next).ServeHTTP
+}
+
+// idempotencyScope identifies the requester owning idempotency keys.
+// Keys of anonymous requests are not tracked, as such requests register a new user every time.
+// Parameters:
+// - r: HTTP request
+// Returns:
+// - string: User ID or empty string if user is not authenticated
+func (h *handler) idempotencyScope(r *http.Request) string {
+	user := auth.FindUser(h.userUC, r)
+	if user == nil {
+		return ""
+	}
+	return strconv.Itoa(user.ID)
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	entity "github.com/gururuby/shortener/internal/domain/entity/user"
+	"github.com/gururuby/shortener/internal/handler/http/api/shorturl/mocks"
+	"github.com/gururuby/shortener/internal/infra/idempotency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_CreateShortURL_Idempotency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	store := idempotency.NewMemoryStore(time.Minute)
+	t.Cleanup(store.Close)
+
+	r := chi.NewRouter()
+	cfg := testServerCfg
+	cfg.IdempotencyTTL = time.Minute
+	Register(r, userUC, urlUC, store, cfg)
+
+	user1 := &entity.User{ID: 1, AuthToken: "token1"}
+	user2 := &entity.User{ID: 2, AuthToken: "token2"}
+	userUC.EXPECT().Authenticate(gomock.Any(), "token1").Return(user1, nil).AnyTimes()
+	userUC.EXPECT().Authenticate(gomock.Any(), "token2").Return(user2, nil).AnyTimes()
+
+	send := func(token, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/shorten", strings.NewReader(`{"url":"https://ya.ru"}`))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Idempotency-Key", key)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	urlUC.EXPECT().CreateShortURL(gomock.Any(), user1, "https://ya.ru", shortURLEntity.CreateOptions{}).Return("http://localhost:8080/first", nil).Times(1)
+	urlUC.EXPECT().CreateShortURL(gomock.Any(), user2, "https://ya.ru", shortURLEntity.CreateOptions{}).Return("http://localhost:8080/second", nil).Times(1)
+
+	first := send("token1", "key")
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.JSONEq(t, `{"Result":"http://localhost:8080/first"}`, first.Body.String())
+
+	replay := send("token1", "key")
+	require.Equal(t, http.StatusOK, replay.Code)
+	assert.Equal(t, "true", replay.Header().Get("X-Idempotent-Replayed"))
+	assert.JSONEq(t, `{"Result":"http://localhost:8080/first"}`, replay.Body.String())
+
+	other := send("token2", "key")
+	require.Equal(t, http.StatusCreated, other.Code)
+	assert.Empty(t, other.Header().Get("X-Idempotent-Replayed"))
+	assert.JSONEq(t, `{"Result":"http://localhost:8080/second"}`, other.Body.String())
+}
+
+func Test_BatchShortURLs_Idempotency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	store := idempotency.NewMemoryStore(time.Minute)
+	t.Cleanup(store.Close)
+
+	r := chi.NewRouter()
+	cfg := testServerCfg
+	cfg.IdempotencyTTL = time.Minute
+	Register(r, userUC, urlUC, store, cfg)
+
+	userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(&entity.User{ID: 1}, nil).AnyTimes()
+	urlUC.EXPECT().BatchShortURLs(gomock.Any(), gomock.Any(), gomock.Any()).Return([]shortURLEntity.BatchShortURLOutput{
+		{CorrelationID: "1", ShortURL: "http://localhost:8080/alias1"},
+	}, nil).Times(1)
+
+	for _, status := range []int{http.StatusCreated, http.StatusOK} {
+		req := httptest.NewRequest(http.MethodPost, "/api/shorten/batch", strings.NewReader(`[{"correlation_id":"1","original_url":"https://ya.ru"}]`))
+		req.Header.Set("Authorization", "Bearer token")
+		req.Header.Set("Idempotency-Key", "batch-key")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		require.Equal(t, status, w.Code)
+		assert.JSONEq(t, `[{"correlation_id":"1","short_url":"http://localhost:8080/alias1"}]`, w.Body.String())
+	}
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+)
+
+const shortURLMetadataPath = "/api/shorturl/{alias}/metadata" // Path pattern for short URL metadata in JSON
+
+// shortURLMetadataDTO defines the response structure of short URL metadata.
+// Source URL is not exposed, it is returned by redirect only.
+type shortURLMetadataDTO struct {
+	Alias        string `json:"alias"`
+	CreatedAt    string `json:"created_at,omitempty"` // Creation time in RFC 3339 format, UTC
+	Region       string `json:"region"`               // Region where the short URL was created
+	Visibility   string `json:"visibility"`           // public or private
+	RedirectType int    `json:"redirect_type"`        // HTTP status code of the redirect
+}
+
+// newShortURLMetadataDTO builds metadata response of a short URL.
+// Parameters:
+// - shortURL: Short URL entity
+// Returns:
+// - *shortURLMetadataDTO: Metadata with defaults applied to visibility and redirect type
+func newShortURLMetadataDTO(shortURL *shortURLEntity.ShortURL) *shortURLMetadataDTO {
+	dto := &shortURLMetadataDTO{
+		Alias:        shortURL.Alias,
+		Region:       shortURL.CreatedInRegion,
+		Visibility:   shortURLEntity.VisibilityPublic,
+		RedirectType: shortURL.RedirectStatus(),
+	}
+	if shortURL.IsPrivate() {
+		dto.Visibility = shortURLEntity.VisibilityPrivate
+	}
+	if !shortURL.CreatedAt.IsZero() {
+		dto.CreatedAt = shortURL.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	return dto
+}
+
+// ShortURLMetadata handles GET requests for short URL metadata in JSON.
+// Returns an HTTP handler function that:
+// - Looks up the short URL without side effects
+// - Requires authentication of the owner for private short URLs only
+// - Returns appropriate responses:
+//   - 200 OK with alias, creation time, region, redirect type and visibility
+//   - 403 Forbidden if short URL is private and requested by anyone but the owner
+//   - 404 Not Found if alias doesn't exist
+//   - 410 Gone if short URL was deleted
+//   - 504 Gateway Timeout if lookup takes longer than configured timeout
+//   - 500 for other errors
+func (h *handler) ShortURLMetadata() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var errRes errorResponse
+
+		w.Header().Set("Content-Type", "application/json")
+
+		ctx, cancel := context.WithTimeout(r.Context(), h.cfg.ReadURLTimeout)
+		defer cancel()
+
+		shortURL, err := h.urlUC.GetShortURL(ctx, "", chi.URLParam(r, "alias"))
+		if err != nil {
+			errRes = newErrorResponse(err, http.StatusInternalServerError)
+			switch {
+			case errors.Is(ctx.Err(), context.DeadlineExceeded):
+				returnTimeoutResponse(w)
+				return
+			case errors.Is(err, ucErrors.ErrShortURLDeleted):
+				errRes.StatusCode = http.StatusGone
+			case errors.Is(err, ucErrors.ErrShortURLSourceURLNotFound), errors.Is(err, ucErrors.ErrShortURLEmptyAlias):
+				errRes.StatusCode = http.StatusNotFound
+			}
+			returnErrResponse(errRes, w)
+			return
+		}
+
+		if shortURL.IsPrivate() && !shortURL.IsAccessibleBy(auth.FindUser(h.userUC, r)) {
+			errRes = newErrorResponse(ucErrors.ErrShortURLForbidden, http.StatusForbidden)
+			returnErrResponse(errRes, w)
+			return
+		}
+
+		response, err := jsonIter.Marshal(newShortURLMetadataDTO(shortURL))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err = w.Write(response); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
@@ -20,9 +20,9 @@ import (
 
 // MockShortURLUseCase is a mock of ShortURLUseCase interface.
 type MockShortURLUseCase struct {
-	isgomock struct{}
 	ctrl     *gomock.Controller
 	recorder *MockShortURLUseCaseMockRecorder
+	isgomock struct{}
 }
 
 // MockShortURLUseCaseMockRecorder is the mock recorder for MockShortURLUseCase.
@@ -43,54 +43,84 @@ func (m *MockShortURLUseCase) EXPECT() *MockShortURLUseCaseMockRecorder {
 }
 
 // BatchShortURLs mocks base method.
-func (m *MockShortURLUseCase) BatchShortURLs(ctx context.Context, urls []entity.BatchShortURLInput) []entity.BatchShortURLOutput {
+func (m *MockShortURLUseCase) BatchShortURLs(ctx context.Context, user *entity0.User, urls []entity.BatchShortURLInput) ([]entity.BatchShortURLOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BatchShortURLs", ctx, urls)
+	ret := m.ctrl.Call(m, "BatchShortURLs", ctx, user, urls)
 	ret0, _ := ret[0].([]entity.BatchShortURLOutput)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // BatchShortURLs indicates an expected call of BatchShortURLs.
-func (mr *MockShortURLUseCaseMockRecorder) BatchShortURLs(ctx, urls any) *gomock.Call {
+func (mr *MockShortURLUseCaseMockRecorder) BatchShortURLs(ctx, user, urls any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchShortURLs", reflect.TypeOf((*MockShortURLUseCase)(nil).BatchShortURLs), ctx, urls)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchShortURLs", reflect.TypeOf((*MockShortURLUseCase)(nil).BatchShortURLs), ctx, user, urls)
 }
 
 // CreateShortURL mocks base method.
-func (m *MockShortURLUseCase) CreateShortURL(ctx context.Context, user *entity0.User, sourceURL string) (string, error) {
+func (m *MockShortURLUseCase) CreateShortURL(ctx context.Context, user *entity0.User, sourceURL string, opts entity.CreateOptions) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateShortURL", ctx, user, sourceURL)
+	ret := m.ctrl.Call(m, "CreateShortURL", ctx, user, sourceURL, opts)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateShortURL indicates an expected call of CreateShortURL.
-func (mr *MockShortURLUseCaseMockRecorder) CreateShortURL(ctx, user, sourceURL any) *gomock.Call {
+func (mr *MockShortURLUseCaseMockRecorder) CreateShortURL(ctx, user, sourceURL, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShortURL", reflect.TypeOf((*MockShortURLUseCase)(nil).CreateShortURL), ctx, user, sourceURL)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShortURL", reflect.TypeOf((*MockShortURLUseCase)(nil).CreateShortURL), ctx, user, sourceURL, opts)
 }
 
 // FindShortURL mocks base method.
-func (m *MockShortURLUseCase) FindShortURL(ctx context.Context, alias string) (string, error) {
+func (m *MockShortURLUseCase) FindShortURL(ctx context.Context, namespace, alias string, user *entity0.User) (*entity.ShortURL, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindShortURL", ctx, alias)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "FindShortURL", ctx, namespace, alias, user)
+	ret0, _ := ret[0].(*entity.ShortURL)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindShortURL indicates an expected call of FindShortURL.
-func (mr *MockShortURLUseCaseMockRecorder) FindShortURL(ctx, alias any) *gomock.Call {
+func (mr *MockShortURLUseCaseMockRecorder) FindShortURL(ctx, namespace, alias, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShortURL", reflect.TypeOf((*MockShortURLUseCase)(nil).FindShortURL), ctx, namespace, alias, user)
+}
+
+// GetShortURL mocks base method.
+func (m *MockShortURLUseCase) GetShortURL(ctx context.Context, namespace, alias string) (*entity.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortURL", ctx, namespace, alias)
+	ret0, _ := ret[0].(*entity.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortURL indicates an expected call of GetShortURL.
+func (mr *MockShortURLUseCaseMockRecorder) GetShortURL(ctx, namespace, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortURL", reflect.TypeOf((*MockShortURLUseCase)(nil).GetShortURL), ctx, namespace, alias)
+}
+
+// ValidateBatch mocks base method.
+func (m *MockShortURLUseCase) ValidateBatch(ctx context.Context, urls []entity.BatchShortURLInput) []entity.BatchValidationResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateBatch", ctx, urls)
+	ret0, _ := ret[0].([]entity.BatchValidationResult)
+	return ret0
+}
+
+// ValidateBatch indicates an expected call of ValidateBatch.
+func (mr *MockShortURLUseCaseMockRecorder) ValidateBatch(ctx, urls any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShortURL", reflect.TypeOf((*MockShortURLUseCase)(nil).FindShortURL), ctx, alias)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBatch", reflect.TypeOf((*MockShortURLUseCase)(nil).ValidateBatch), ctx, urls)
 }
 
 // MockUserUseCase is a mock of UserUseCase interface.
 type MockUserUseCase struct {
-	isgomock struct{}
 	ctrl     *gomock.Controller
 	recorder *MockUserUseCaseMockRecorder
+	isgomock struct{}
 }
 
 // MockUserUseCaseMockRecorder is the mock recorder for MockUserUseCase.
@@ -125,6 +155,21 @@ func (mr *MockUserUseCaseMockRecorder) Authenticate(ctx, token any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockUserUseCase)(nil).Authenticate), ctx, token)
 }
 
+// AuthenticateAPIKey mocks base method.
+func (m *MockUserUseCase) AuthenticateAPIKey(ctx context.Context, key string) (*entity0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", ctx, key)
+	ret0, _ := ret[0].(*entity0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockUserUseCaseMockRecorder) AuthenticateAPIKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockUserUseCase)(nil).AuthenticateAPIKey), ctx, key)
+}
+
 // Register mocks base method.
 func (m *MockUserUseCase) Register(ctx context.Context) (*entity0.User, error) {
 	m.ctrl.T.Helper()
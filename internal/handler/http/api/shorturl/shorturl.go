@@ -19,40 +19,61 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/gururuby/shortener/internal/config"
 	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
 	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	shortURLUseCase "github.com/gururuby/shortener/internal/domain/usecase/shorturl"
 	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
 	apiErrors "github.com/gururuby/shortener/internal/handler/http/api/shorturl/errors"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+	httpErrors "github.com/gururuby/shortener/internal/handler/http/errors"
+	"github.com/gururuby/shortener/internal/infra/idempotency"
+	"github.com/gururuby/shortener/pkg/validator"
 	"github.com/json-iterator/go"
 )
 
 var jsonIter = jsoniter.ConfigFastest
 
 const (
-	authCookieName        = "Authorization"  // Name of the authentication cookie
-	createShortURLTimeout = time.Second * 30 // Timeout for short URL creation
-	createShortURLPath    = "/api/shorten"   // Path for single URL shortening
+	namespaceHeader    = "X-Namespace"  // Name of the header with namespace of created short URL
+	createShortURLPath = "/api/shorten" // Path for single URL shortening
 
-	batchShortURLsTimeout = time.Second * 60     // Timeout for batch URL processing
-	batchShortURLsPath    = "/api/shorten/batch" // Path for batch URL shortening
+	batchShortURLsPath = "/api/shorten/batch"    // Path for batch URL shortening
+	validateBatchPath  = "/api/shorten/validate" // Path for batch URL validation without shortening
+
+	shortURLInfoPath = "/api/shorturl/{alias}" // Path pattern for short URL metadata
+
+	originalURLHeader = "X-Original-URL" // Response header with original URL
+	createdAtHeader   = "X-Created-At"   // Response header with short URL creation time
 )
 
 // Router defines the interface for HTTP request routing.
 type Router interface {
 	// Post registers a handler for POST requests at the specified path
 	Post(path string, h http.HandlerFunc)
+	// Head registers a handler for HEAD requests at the specified path
+	Head(path string, h http.HandlerFunc)
+	// Get registers a handler for GET requests at the specified path
+	Get(path string, h http.HandlerFunc)
 }
 
 // ShortURLUseCase defines the interface for short URL business logic.
 type ShortURLUseCase interface {
-	// CreateShortURL generates a shortened URL for the given source URL
-	CreateShortURL(ctx context.Context, user *userEntity.User, sourceURL string) (string, error)
+	// CreateShortURL generates a shortened URL with the given options for the source URL
+	CreateShortURL(ctx context.Context, user *userEntity.User, sourceURL string, opts shortURLEntity.CreateOptions) (string, error)
+
+	// FindShortURL retrieves the short URL for a given namespace and alias if user may access it
+	FindShortURL(ctx context.Context, namespace, alias string, user *userEntity.User) (*shortURLEntity.ShortURL, error)
 
-	// FindShortURL retrieves the original URL for a given short alias
-	FindShortURL(ctx context.Context, alias string) (string, error)
+	// GetShortURL retrieves the short URL for a given namespace and alias without side effects
+	GetShortURL(ctx context.Context, namespace, alias string) (*shortURLEntity.ShortURL, error)
 
 	// BatchShortURLs processes multiple URLs in a single operation
-	BatchShortURLs(ctx context.Context, urls []shortURLEntity.BatchShortURLInput) []shortURLEntity.BatchShortURLOutput
+	BatchShortURLs(ctx context.Context, user *userEntity.User, urls []shortURLEntity.BatchShortURLInput) ([]shortURLEntity.BatchShortURLOutput, error)
+
+	// ValidateBatch checks source URLs of the batch without saving anything
+	ValidateBatch(ctx context.Context, urls []shortURLEntity.BatchShortURLInput) []shortURLEntity.BatchValidationResult
 }
 
 // UserUseCase defines the interface for user management operations.
@@ -62,26 +83,53 @@ type UserUseCase interface {
 
 	// Register creates a new user account
 	Register(ctx context.Context) (*userEntity.User, error)
+
+	// AuthenticateAPIKey verifies an API key and returns its owner
+	AuthenticateAPIKey(ctx context.Context, key string) (*userEntity.User, error)
 }
 
 // handler implements the HTTP request handlers for the API.
 type handler struct {
-	userUC UserUseCase     // User management service
-	urlUC  ShortURLUseCase // URL shortening service
-	router Router          // Request router
+	userUC           UserUseCase                  // User management service
+	urlUC            ShortURLUseCase              // URL shortening service
+	router           Router                       // Request router
+	idempotencyStore idempotency.IdempotencyStore // Store of responses to replay, nil disables replay
+	cfg              config.Server                // Endpoint timeouts and time to keep responses to replay
 }
 
 // errorResponse represents an API error response.
+// Code is a stable machine-readable error code, see httpErrors.Code.
+// ValidationErrors lists failed request fields, it is omitted for other errors.
 type errorResponse struct {
-	Error      string
-	StatusCode int
+	err              error // Error returned to the client, maps to Code
+	Error            string
+	Code             string
+	StatusCode       int
+	ValidationErrors []httpErrors.FieldError `json:",omitempty"`
+}
+
+// quotaExceededResponse represents the error response for users who reached the quota of short URLs.
+type quotaExceededResponse struct {
+	errorResponse
+	Limit   int64 `json:"limit"`
+	Current int64 `json:"current"`
+}
+
+// unsafeURLResponse represents the response for URLs flagged by URL checker.
+type unsafeURLResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code"`
 }
 
 type (
 	// createShortURLDTO defines the request/response structure for single URL shortening
 	createShortURLDTO struct {
 		request struct {
-			URL string // Original URL to shorten
+			URL          string // Original URL to shorten
+			OneTimeUse   bool   `json:"one_time_use"`  // Delete short URL after the first redirect
+			Visibility   string `json:"visibility"`    // public (default) or private, i.e. redirects the owner only
+			RedirectType int    `json:"redirect_type"` // 307 (default) or 301, i.e. permanent redirect
+			Tracking     bool   `json:"tracking"`      // Serve redirect as tracking page recording the click by pixel
 		}
 		response struct {
 			Result string // Generated short URL
@@ -93,25 +141,116 @@ type (
 		inputURLs  []shortURLEntity.BatchShortURLInput  // Input URLs to process
 		outputURLs []shortURLEntity.BatchShortURLOutput // Resulting short URLs
 	}
+
+	// validateBatchDTO defines the response structure for batch URL validation,
+	// the request is the same as of batch URL shortening
+	validateBatchDTO struct {
+		response struct {
+			Results []shortURLEntity.BatchValidationResult `json:"results"` // Results in request order
+		}
+	}
 )
 
+// validate checks the request of single URL shortening.
+// Returns:
+// - *httpErrors.ValidationError: Failed fields, empty if request is valid
+func (dto *createShortURLDTO) validate() *httpErrors.ValidationError {
+	verr := httpErrors.NewValidationError()
+	if validator.IsInvalidURL(dto.request.URL) {
+		verr.Add("url", httpErrors.CodeInvalidURL, httpErrors.MessageInvalidURL)
+	}
+	switch dto.request.Visibility {
+	case "", shortURLEntity.VisibilityPublic:
+	case shortURLEntity.VisibilityPrivate:
+		if dto.request.OneTimeUse {
+			verr.Add("visibility", httpErrors.CodeConflictingOption, "private short URL cannot be one-time use")
+		}
+	default:
+		verr.Add("visibility", httpErrors.CodeInvalidVisibility, httpErrors.MessageInvalidVisibility)
+	}
+	// Clients cache permanent redirects, so they would bypass one-time and owner checks
+	switch dto.request.RedirectType {
+	case 0, shortURLEntity.RedirectTemporary:
+	case shortURLEntity.RedirectPermanent:
+		if dto.request.OneTimeUse {
+			verr.Add("redirect_type", httpErrors.CodeConflictingOption, "one-time short URL cannot redirect permanently")
+		}
+		if dto.request.Visibility == shortURLEntity.VisibilityPrivate {
+			verr.Add("redirect_type", httpErrors.CodeConflictingOption, "private short URL cannot redirect permanently")
+		}
+	default:
+		verr.Add("redirect_type", httpErrors.CodeInvalidRedirectType, httpErrors.MessageInvalidRedirectType)
+	}
+	if dto.request.Tracking {
+		if dto.request.OneTimeUse {
+			verr.Add("tracking", httpErrors.CodeConflictingOption, "one-time short URL cannot be tracked")
+		}
+		if dto.request.Visibility == shortURLEntity.VisibilityPrivate {
+			verr.Add("tracking", httpErrors.CodeConflictingOption, "private short URL cannot be tracked")
+		}
+		if dto.request.RedirectType == shortURLEntity.RedirectPermanent {
+			verr.Add("tracking", httpErrors.CodeConflictingOption, "permanent short URL cannot be tracked")
+		}
+	}
+	return verr
+}
+
+// createOptions returns options of the short URL requested to create.
+// Returns:
+// - shortURLEntity.CreateOptions: Options from the request fields
+func (dto *createShortURLDTO) createOptions() shortURLEntity.CreateOptions {
+	return shortURLEntity.CreateOptions{
+		OneTimeUse:   dto.request.OneTimeUse,
+		Visibility:   dto.request.Visibility,
+		RedirectType: dto.request.RedirectType,
+		Tracked:      dto.request.Tracking,
+	}
+}
+
+// validate checks every item of the batch request.
+// Failed fields are addressed by item index, e.g. `[2].original_url`.
+// Returns:
+// - *httpErrors.ValidationError: Failed fields of all items, empty if request is valid
+func (dto *batchShortURLsDTO) validate() *httpErrors.ValidationError {
+	verr := httpErrors.NewValidationError()
+	for i, url := range dto.inputURLs {
+		if validator.IsInvalidURL(url.OriginalURL) {
+			verr.Add(fmt.Sprintf("[%d].original_url", i), httpErrors.CodeInvalidURL, httpErrors.MessageInvalidURL)
+		}
+	}
+	return verr
+}
+
 // Register sets up the API routes and their corresponding handlers.
 // Parameters:
 // - router: The HTTP router implementation
 // - userUC: User management service
 // - urlUC: URL shortening service
-func Register(router Router, userUC UserUseCase, urlUC ShortURLUseCase) {
-	h := handler{router: router, userUC: userUC, urlUC: urlUC}
-	h.router.Post(batchShortURLsPath, h.BatchShortURLs())
-	h.router.Post(createShortURLPath, h.CreateShortURL())
+// - idempotencyStore: Store of responses replayed to creation requests retried with the same
+// Idempotency-Key, nil disables replay
+// - cfg: Server configuration with endpoint timeouts and time to keep responses to replay
+func Register(router Router, userUC UserUseCase, urlUC ShortURLUseCase, idempotencyStore idempotency.IdempotencyStore, cfg config.Server) {
+	h := handler{
+		router:           router,
+		userUC:           userUC,
+		urlUC:            urlUC,
+		idempotencyStore: idempotencyStore,
+		cfg:              cfg,
+	}
+	h.router.Post(batchShortURLsPath, h.idempotent(h.BatchShortURLs()))
+	h.router.Post(validateBatchPath, h.ValidateBatch())
+	h.router.Post(createShortURLPath, h.idempotent(h.CreateShortURL()))
+	h.router.Head(shortURLInfoPath, h.ShortURLInfo())
+	h.router.Get(shortURLMetadataPath, h.ShortURLMetadata())
+	h.router.Get(shortURLHealthPath, h.ShortURLHealth())
 }
 
 // CreateShortURL handles requests to create a single short URL.
 // Returns an HTTP handler function that:
 // - Validates the request
 // - Authenticates/registers the user
-// - Creates the short URL
-// - Returns appropriate responses
+// - Creates the short URL in the namespace passed in X-Namespace header, or in the default one
+// - Returns appropriate responses, 504 if creation takes longer than configured timeout
 func (h *handler) CreateShortURL() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var (
@@ -122,9 +261,10 @@ func (h *handler) CreateShortURL() http.HandlerFunc {
 			response   []byte
 			dto        createShortURLDTO
 			errRes     errorResponse
+			quotaErr   *shortURLUseCase.QuotaExceededError
 		)
 
-		ctx, cancel := context.WithTimeout(r.Context(), createShortURLTimeout)
+		ctx, cancel := context.WithTimeout(r.Context(), h.cfg.CreateURLTimeout)
 		defer cancel()
 
 		w.Header().Set("Content-Type", "application/json")
@@ -137,28 +277,42 @@ func (h *handler) CreateShortURL() http.HandlerFunc {
 		}
 
 		if err = json.NewDecoder(r.Body).Decode(&dto.request); err != nil {
-			errRes.Error = err.Error()
-			errRes.StatusCode = http.StatusBadRequest
+			errRes = newErrorResponse(err, http.StatusBadRequest)
 			returnErrResponse(errRes, w)
 			return
 		}
 
-		user, err = h.authUser(ctx, r, w)
+		if verr := dto.validate(); verr.HasErrors() {
+			returnValidationErrResponse(verr, w)
+			return
+		}
+
+		user, err = auth.UserOrRegister(ctx, h.userUC, r, w)
 		if err != nil {
-			errRes.Error = err.Error()
-			errRes.StatusCode = http.StatusUnprocessableEntity
+			errRes = newErrorResponse(err, auth.RegisterErrStatus(err))
 			returnErrResponse(errRes, w)
 			return
 		}
+		user = withNamespace(user, r)
 
-		shortURL, err = h.urlUC.CreateShortURL(ctx, user, dto.request.URL)
+		shortURL, err = h.urlUC.CreateShortURL(ctx, user, dto.request.URL, dto.createOptions())
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			returnTimeoutResponse(w)
+			return
+		}
 
 		if err != nil {
 			if errors.Is(err, ucErrors.ErrShortURLAlreadyExist) {
 				statusCode = http.StatusConflict
+			} else if errors.Is(err, ucErrors.ErrShortURLUnsafeContent) {
+				returnUnsafeURLResponse(w)
+				return
+			} else if errors.As(err, &quotaErr) {
+				returnQuotaExceededResponse(quotaErr, w)
+				return
 			} else {
-				errRes.Error = err.Error()
-				errRes.StatusCode = http.StatusUnprocessableEntity
+				errRes = newErrorResponse(err, http.StatusUnprocessableEntity)
 				returnErrResponse(errRes, w)
 				return
 			}
@@ -169,8 +323,7 @@ func (h *handler) CreateShortURL() http.HandlerFunc {
 		response, err = jsonIter.Marshal(dto.response)
 
 		if err != nil {
-			errRes.Error = err.Error()
-			errRes.StatusCode = http.StatusInternalServerError
+			errRes = newErrorResponse(err, http.StatusInternalServerError)
 			returnErrResponse(errRes, w)
 			return
 		}
@@ -186,8 +339,9 @@ func (h *handler) CreateShortURL() http.HandlerFunc {
 // BatchShortURLs handles requests to create multiple short URLs in a batch.
 // Returns an HTTP handler function that:
 // - Validates the request
-// - Processes URLs in batch
-// - Returns appropriate responses
+// - Processes URLs in batch, owned by the authenticated user if any
+// - Returns appropriate responses, URLs failed to shorten are listed with the reason,
+// 429 if the batch exceeds the quota, 504 if processing takes longer than configured timeout
 func (h *handler) BatchShortURLs() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var (
@@ -195,9 +349,10 @@ func (h *handler) BatchShortURLs() http.HandlerFunc {
 			response []byte
 			dto      batchShortURLsDTO
 			errRes   errorResponse
+			quotaErr *shortURLUseCase.QuotaExceededError
 		)
 
-		ctx, cancel := context.WithTimeout(r.Context(), batchShortURLsTimeout)
+		ctx, cancel := context.WithTimeout(r.Context(), h.cfg.BatchURLTimeout)
 		defer cancel()
 
 		w.Header().Set("Content-Type", "application/json")
@@ -210,25 +365,43 @@ func (h *handler) BatchShortURLs() http.HandlerFunc {
 		}
 
 		if err = json.NewDecoder(r.Body).Decode(&dto.inputURLs); err != nil {
-			errRes.Error = err.Error()
-			errRes.StatusCode = http.StatusBadRequest
+			errRes = newErrorResponse(err, http.StatusBadRequest)
 			returnErrResponse(errRes, w)
 			return
 		}
 
 		if len(dto.inputURLs) == 0 {
-			errRes.Error = apiErrors.ErrAPIEmptyBatch.Error()
-			errRes.StatusCode = http.StatusBadRequest
+			errRes = newErrorResponse(apiErrors.ErrAPIEmptyBatch, http.StatusBadRequest)
+			returnErrResponse(errRes, w)
+			return
+		}
+
+		if verr := dto.validate(); verr.HasErrors() {
+			returnValidationErrResponse(verr, w)
+			return
+		}
+
+		dto.outputURLs, err = h.urlUC.BatchShortURLs(ctx, auth.FindUser(h.userUC, r), dto.inputURLs)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			returnTimeoutResponse(w)
+			return
+		}
+
+		if errors.As(err, &quotaErr) {
+			returnQuotaExceededResponse(quotaErr, w)
+			return
+		}
+
+		if err != nil {
+			errRes = newErrorResponse(err, http.StatusInternalServerError)
 			returnErrResponse(errRes, w)
 			return
 		}
 
-		dto.outputURLs = h.urlUC.BatchShortURLs(ctx, dto.inputURLs)
 		response, err = jsonIter.Marshal(dto.outputURLs)
 
 		if err != nil {
-			errRes.Error = err.Error()
-			errRes.StatusCode = http.StatusInternalServerError
+			errRes = newErrorResponse(err, http.StatusInternalServerError)
 			returnErrResponse(errRes, w)
 			return
 		}
@@ -241,41 +414,124 @@ func (h *handler) BatchShortURLs() http.HandlerFunc {
 	}
 }
 
-// authUser handles user authentication via cookie or registration.
+// ValidateBatch handles requests to check URLs of a batch without shortening them.
+// No authentication is required, as nothing is created.
+// Returns an HTTP handler function that:
+// - Decodes the same request as BatchShortURLs
+// - Checks format, domain and safety of every URL
+// - Returns 200 with a result per URL, invalid URLs are listed with the reason,
+// 504 if processing takes longer than configured timeout
+func (h *handler) ValidateBatch() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err      error
+			response []byte
+			urls     []shortURLEntity.BatchShortURLInput
+			dto      validateBatchDTO
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), h.cfg.BatchURLTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err = json.NewDecoder(r.Body).Decode(&urls); err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusBadRequest), w)
+			return
+		}
+
+		if len(urls) == 0 {
+			returnErrResponse(newErrorResponse(apiErrors.ErrAPIEmptyBatch, http.StatusBadRequest), w)
+			return
+		}
+
+		dto.response.Results = h.urlUC.ValidateBatch(ctx, urls)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			returnTimeoutResponse(w)
+			return
+		}
+
+		if response, err = jsonIter.Marshal(dto.response); err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusInternalServerError), w)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+
+		if _, err = w.Write(response); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
+// withNamespace returns the user creating short URLs in the namespace passed with the request.
+// The user is copied, so the authenticated user is not modified.
 // Parameters:
-// - ctx: Context for cancellation/timeout
+// - user: Authenticated user
 // - r: HTTP request
-// - w: HTTP response writer
 // Returns:
-// - *userEntity.User: Authenticated user
-// - error: Authentication failure
-func (h *handler) authUser(ctx context.Context, r *http.Request, w http.ResponseWriter) (*userEntity.User, error) {
-	var (
-		authCookie *http.Cookie
-		user       *userEntity.User
-		err        error
-	)
-
-	authCookie, err = r.Cookie(authCookieName)
-	// If auth cookie was not passed
-	if err != nil && errors.Is(err, http.ErrNoCookie) {
-		// Register new User
-		if user, err = h.userUC.Register(ctx); err != nil {
-			return nil, err
-		}
-
-	} else { // If auth cookie exist, try to authenticate User
-		if user, err = h.userUC.Authenticate(ctx, authCookie.Value); err != nil {
-			// If auth cookie is invalid or user not found try to register new user
-			if user, err = h.userUC.Register(ctx); err != nil {
-				return nil, err
+// - *userEntity.User: User with namespace from X-Namespace header, or the user itself if header is missing
+func withNamespace(user *userEntity.User, r *http.Request) *userEntity.User {
+	namespace := r.Header.Get(namespaceHeader)
+	if namespace == "" || user == nil {
+		return user
+	}
+	namespaced := *user
+	namespaced.Namespace = namespace
+	return &namespaced
+}
+
+// ShortURLInfo handles HEAD requests for short URL metadata.
+// Returns an HTTP handler function that:
+// - Looks up the short URL without side effects
+// - Returns metadata in X-Original-URL and X-Created-At headers without body:
+//   - 200 OK if short URL exists
+//   - 403 Forbidden if short URL is private and requested by anyone but the owner
+//   - 404 Not Found if alias doesn't exist
+//   - 410 Gone if short URL was deleted
+//   - 504 Gateway Timeout if lookup takes longer than configured timeout
+//   - 500 for other errors
+func (h *handler) ShortURLInfo() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), h.cfg.ReadURLTimeout)
+		defer cancel()
+
+		shortURL, err := h.urlUC.GetShortURL(ctx, "", chi.URLParam(r, "alias"))
+		if err != nil {
+			switch {
+			case errors.Is(ctx.Err(), context.DeadlineExceeded):
+				w.WriteHeader(http.StatusGatewayTimeout)
+			case errors.Is(err, ucErrors.ErrShortURLDeleted):
+				w.WriteHeader(http.StatusGone)
+			case errors.Is(err, ucErrors.ErrShortURLSourceURLNotFound), errors.Is(err, ucErrors.ErrShortURLEmptyAlias):
+				w.WriteHeader(http.StatusNotFound)
+			default:
+				w.WriteHeader(http.StatusInternalServerError)
 			}
+			return
+		}
+
+		if shortURL.IsPrivate() && !shortURL.IsAccessibleBy(auth.FindUser(h.userUC, r)) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		w.Header().Set(originalURLHeader, shortURL.SourceURL)
+		if !shortURL.CreatedAt.IsZero() {
+			w.Header().Set(createdAtHeader, shortURL.CreatedAt.UTC().Format(time.RFC3339))
 		}
+		w.WriteHeader(http.StatusOK)
 	}
-	// Setup auth cookie
-	http.SetCookie(w, &http.Cookie{Name: authCookieName, Value: user.AuthToken})
+}
 
-	return user, nil
+// newErrorResponse creates an error response for the error.
+// Parameters:
+// - err: Error returned to the client
+// - statusCode: HTTP status code of the response
+// Returns:
+// - errorResponse: Response with the error message, Code is set by returnErrResponse
+func newErrorResponse(err error, statusCode int) errorResponse {
+	return errorResponse{err: err, Error: err.Error(), StatusCode: statusCode}
 }
 
 // returnErrResponse writes an error response in JSON format.
@@ -283,6 +539,7 @@ func (h *handler) authUser(ctx context.Context, r *http.Request, w http.Response
 // - errResp: Error response details
 // - w: HTTP response writer
 func returnErrResponse(errResp errorResponse, w http.ResponseWriter) {
+	errResp.Code = httpErrors.Code(errResp.err, errResp.StatusCode)
 	w.WriteHeader(errResp.StatusCode)
 	response, err := jsonIter.Marshal(errResp)
 	if err != nil {
@@ -292,3 +549,64 @@ func returnErrResponse(errResp errorResponse, w http.ResponseWriter) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
+
+// returnQuotaExceededResponse writes the 429 response with the quota of short URLs of the user.
+// Parameters:
+// - quotaErr: Error with the limit and the number of created short URLs
+// - w: HTTP response writer
+func returnQuotaExceededResponse(quotaErr *shortURLUseCase.QuotaExceededError, w http.ResponseWriter) {
+	errRes := newErrorResponse(quotaErr, http.StatusTooManyRequests)
+	errRes.Code = httpErrors.Code(quotaErr, errRes.StatusCode)
+
+	w.WriteHeader(errRes.StatusCode)
+	response, err := jsonIter.Marshal(quotaExceededResponse{errorResponse: errRes, Limit: quotaErr.Limit, Current: quotaErr.Current})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	if _, err = w.Write(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// returnTimeoutResponse writes the 504 response for requests not processed within the endpoint timeout.
+// Parameters:
+// - w: HTTP response writer
+func returnTimeoutResponse(w http.ResponseWriter) {
+	returnErrResponse(newErrorResponse(httpErrors.ErrRequestTimeout, http.StatusGatewayTimeout), w)
+}
+
+// returnValidationErrResponse writes the 422 response listing failed request fields.
+// Error keeps the invalid source URL message if any URL is invalid,
+// so that clients matching on it keep working.
+// Parameters:
+// - verr: Validation error with failed fields
+// - w: HTTP response writer
+func returnValidationErrResponse(verr *httpErrors.ValidationError, w http.ResponseWriter) {
+	errResp := newErrorResponse(verr, http.StatusUnprocessableEntity)
+	errResp.ValidationErrors = verr.Fields
+	for _, field := range verr.Fields {
+		if field.Code == httpErrors.CodeInvalidURL {
+			errResp.err = ucErrors.ErrShortURLInvalidSourceURL
+			errResp.Error = ucErrors.ErrShortURLInvalidSourceURL.Error()
+			break
+		}
+	}
+	returnErrResponse(errResp, w)
+}
+
+// returnUnsafeURLResponse writes the 422 response for URLs flagged as unsafe.
+// Parameters:
+// - w: HTTP response writer
+func returnUnsafeURLResponse(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	response, err := jsonIter.Marshal(unsafeURLResponse{
+		Error: ucErrors.ErrShortURLUnsafeContent.Error(),
+		Code:  httpErrors.ErrCodeUnsafeURL,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	if _, err = w.Write(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -2,14 +2,20 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/gururuby/shortener/internal/config"
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
 	entity "github.com/gururuby/shortener/internal/domain/entity/user"
+	shortURLUseCase "github.com/gururuby/shortener/internal/domain/usecase/shorturl"
 	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+	userErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
 	"github.com/gururuby/shortener/internal/handler/http/api/shorturl/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -35,6 +41,13 @@ type (
 	}
 )
 
+// testServerCfg contains endpoint timeouts long enough for mocked use cases
+var testServerCfg = config.Server{
+	CreateURLTimeout: time.Second,
+	BatchURLTimeout:  time.Second,
+	ReadURLTimeout:   time.Second,
+}
+
 func Test_CreateShortURL_OK(t *testing.T) {
 	var err error
 	var body []byte
@@ -45,7 +58,7 @@ func Test_CreateShortURL_OK(t *testing.T) {
 	user := &entity.User{ID: 1}
 
 	r := chi.NewRouter()
-	h := handler{router: r, urlUC: urlUC, userUC: userUC}
+	h := handler{router: r, urlUC: urlUC, userUC: userUC, cfg: testServerCfg}
 
 	var tests = []struct {
 		ucOutput ucOutput
@@ -53,6 +66,7 @@ func Test_CreateShortURL_OK(t *testing.T) {
 		name     string
 		ucInput  string
 		response response
+		opts     shortURLEntity.CreateOptions
 	}{
 		{
 			name: "when success create short url",
@@ -71,6 +85,114 @@ func Test_CreateShortURL_OK(t *testing.T) {
 				res: "http://localhost:8080/mock_alias",
 			},
 		},
+		{
+			name: "when success create one-time short url",
+			request: request{
+				body:        bytes.NewBufferString(`{"url":"https://example.com","one_time_use":true}`),
+				contentType: "application/json",
+				method:      http.MethodPost,
+				path:        "/api/shorten",
+			},
+			response: response{
+				status: http.StatusCreated,
+				body:   `{"Result":"http://localhost:8080/mock_alias"}`,
+			},
+			ucInput: "https://example.com",
+			ucOutput: ucOutput{
+				res: "http://localhost:8080/mock_alias",
+			},
+			opts: shortURLEntity.CreateOptions{OneTimeUse: true},
+		},
+		{
+			name: "when success create private short url",
+			request: request{
+				body:        bytes.NewBufferString(`{"url":"https://example.com","visibility":"private"}`),
+				contentType: "application/json",
+				method:      http.MethodPost,
+				path:        "/api/shorten",
+			},
+			response: response{
+				status: http.StatusCreated,
+				body:   `{"Result":"http://localhost:8080/mock_alias"}`,
+			},
+			ucInput: "https://example.com",
+			ucOutput: ucOutput{
+				res: "http://localhost:8080/mock_alias",
+			},
+			opts: shortURLEntity.CreateOptions{Visibility: shortURLEntity.VisibilityPrivate},
+		},
+		{
+			name: "when success create permanent short url",
+			request: request{
+				body:        bytes.NewBufferString(`{"url":"https://example.com","redirect_type":301}`),
+				contentType: "application/json",
+				method:      http.MethodPost,
+				path:        "/api/shorten",
+			},
+			response: response{
+				status: http.StatusCreated,
+				body:   `{"Result":"http://localhost:8080/mock_alias"}`,
+			},
+			ucInput: "https://example.com",
+			ucOutput: ucOutput{
+				res: "http://localhost:8080/mock_alias",
+			},
+			opts: shortURLEntity.CreateOptions{RedirectType: shortURLEntity.RedirectPermanent},
+		},
+		{
+			name: "when success create tracked short url",
+			request: request{
+				body:        bytes.NewBufferString(`{"url":"https://example.com","tracking":true}`),
+				contentType: "application/json",
+				method:      http.MethodPost,
+				path:        "/api/shorten",
+			},
+			response: response{
+				status: http.StatusCreated,
+				body:   `{"Result":"http://localhost:8080/mock_alias"}`,
+			},
+			ucInput: "https://example.com",
+			ucOutput: ucOutput{
+				res: "http://localhost:8080/mock_alias",
+			},
+			opts: shortURLEntity.CreateOptions{Tracked: true},
+		},
+		{
+			name: "when success create explicitly temporary short url",
+			request: request{
+				body:        bytes.NewBufferString(`{"url":"https://example.com","redirect_type":307}`),
+				contentType: "application/json",
+				method:      http.MethodPost,
+				path:        "/api/shorten",
+			},
+			response: response{
+				status: http.StatusCreated,
+				body:   `{"Result":"http://localhost:8080/mock_alias"}`,
+			},
+			ucInput: "https://example.com",
+			ucOutput: ucOutput{
+				res: "http://localhost:8080/mock_alias",
+			},
+			opts: shortURLEntity.CreateOptions{RedirectType: shortURLEntity.RedirectTemporary},
+		},
+		{
+			name: "when success create explicitly public short url",
+			request: request{
+				body:        bytes.NewBufferString(`{"url":"https://example.com","visibility":"public"}`),
+				contentType: "application/json",
+				method:      http.MethodPost,
+				path:        "/api/shorten",
+			},
+			response: response{
+				status: http.StatusCreated,
+				body:   `{"Result":"http://localhost:8080/mock_alias"}`,
+			},
+			ucInput: "https://example.com",
+			ucOutput: ucOutput{
+				res: "http://localhost:8080/mock_alias",
+			},
+			opts: shortURLEntity.CreateOptions{Visibility: shortURLEntity.VisibilityPublic},
+		},
 	}
 
 	for _, tt := range tests {
@@ -79,7 +201,7 @@ func Test_CreateShortURL_OK(t *testing.T) {
 			req.Header.Set("Content-Type", tt.request.contentType)
 			w := httptest.NewRecorder()
 			userUC.EXPECT().Register(gomock.Any()).Return(user, nil).Times(1)
-			urlUC.EXPECT().CreateShortURL(gomock.Any(), user, tt.ucInput).Return(tt.ucOutput.res, tt.ucOutput.err).Times(1)
+			urlUC.EXPECT().CreateShortURL(gomock.Any(), user, tt.ucInput, tt.opts).Return(tt.ucOutput.res, tt.ucOutput.err).Times(1)
 			h.CreateShortURL()(w, req)
 
 			resp := w.Result()
@@ -98,6 +220,121 @@ func Test_CreateShortURL_OK(t *testing.T) {
 	}
 }
 
+func Test_CreateShortURL_Authenticated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	user := &entity.User{ID: 1, AuthToken: "token"}
+	apiKeyUser := &entity.User{ID: 1, APIKey: "key"}
+
+	r := chi.NewRouter()
+	h := handler{router: r, urlUC: urlUC, userUC: userUC, cfg: testServerCfg}
+
+	tests := []struct {
+		setAuth func(r *http.Request)
+		setup   func()
+		name    string
+		status  int
+	}{
+		{
+			name:    "when token passed in bearer header",
+			setAuth: func(r *http.Request) { r.Header.Set("Authorization", "Bearer token") },
+			setup: func() {
+				userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil).Times(1)
+				urlUC.EXPECT().CreateShortURL(gomock.Any(), user, "https://example.com", shortURLEntity.CreateOptions{}).Return("http://localhost:8080/mock_alias", nil).Times(1)
+			},
+			status: http.StatusCreated,
+		},
+		{
+			name:    "when token passed in cookie",
+			setAuth: func(r *http.Request) { r.AddCookie(&http.Cookie{Name: "Authorization", Value: "token"}) },
+			setup: func() {
+				userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil).Times(1)
+				urlUC.EXPECT().CreateShortURL(gomock.Any(), user, "https://example.com", shortURLEntity.CreateOptions{}).Return("http://localhost:8080/mock_alias", nil).Times(1)
+			},
+			status: http.StatusCreated,
+		},
+		{
+			name:    "when API key passed in header",
+			setAuth: func(r *http.Request) { r.Header.Set("X-API-Key", "key") },
+			setup: func() {
+				userUC.EXPECT().AuthenticateAPIKey(gomock.Any(), "key").Return(apiKeyUser, nil).Times(1)
+				urlUC.EXPECT().CreateShortURL(gomock.Any(), apiKeyUser, "https://example.com", shortURLEntity.CreateOptions{}).Return("http://localhost:8080/mock_alias", nil).Times(1)
+			},
+			status: http.StatusCreated,
+		},
+		{
+			name: "when namespace passed in header",
+			setAuth: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer token")
+				r.Header.Set("X-Namespace", "team-a")
+			},
+			setup: func() {
+				userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil).Times(1)
+				urlUC.EXPECT().CreateShortURL(gomock.Any(), &entity.User{ID: 1, AuthToken: "token", Namespace: "team-a"}, "https://example.com", shortURLEntity.CreateOptions{}).Return("http://localhost:8080/team-a/mock_alias", nil).Times(1)
+			},
+			status: http.StatusCreated,
+		},
+		{
+			name: "when namespace passed in header does not exist",
+			setAuth: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer token")
+				r.Header.Set("X-Namespace", "unknown")
+			},
+			setup: func() {
+				userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil).Times(1)
+				urlUC.EXPECT().CreateShortURL(gomock.Any(), &entity.User{ID: 1, AuthToken: "token", Namespace: "unknown"}, "https://example.com", shortURLEntity.CreateOptions{}).Return("", ucErrors.ErrShortURLNamespaceNotFound).Times(1)
+			},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:    "when revoked API key passed in header",
+			setAuth: func(r *http.Request) { r.Header.Set("X-API-Key", "revoked") },
+			setup: func() {
+				userUC.EXPECT().AuthenticateAPIKey(gomock.Any(), "revoked").Return(nil, userErrors.ErrUserInvalidAPIKey).Times(1)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:    "when user of auth token is deactivated",
+			setAuth: func(r *http.Request) { r.Header.Set("Authorization", "Bearer deactivated") },
+			setup: func() {
+				userUC.EXPECT().Authenticate(gomock.Any(), "deactivated").Return(nil, userErrors.ErrUserDeactivated).Times(1)
+			},
+			status: http.StatusForbidden,
+		},
+		{
+			name:    "when owner of API key is deactivated",
+			setAuth: func(r *http.Request) { r.Header.Set("X-API-Key", "deactivated") },
+			setup: func() {
+				userUC.EXPECT().AuthenticateAPIKey(gomock.Any(), "deactivated").Return(nil, userErrors.ErrUserDeactivated).Times(1)
+			},
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/shorten", bytes.NewBufferString(`{"url":"https://example.com"}`))
+			req.Header.Set("Content-Type", "application/json")
+			tt.setAuth(req)
+			tt.setup()
+
+			w := httptest.NewRecorder()
+			h.CreateShortURL()(w, req)
+
+			resp := w.Result()
+
+			defer func() {
+				err := resp.Body.Close()
+				require.NoError(t, err)
+			}()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
+
 func Test_CreateShortURL_Errors(t *testing.T) {
 	var err error
 	var body []byte
@@ -108,7 +345,7 @@ func Test_CreateShortURL_Errors(t *testing.T) {
 	user := &entity.User{ID: 1}
 
 	r := chi.NewRouter()
-	h := handler{router: r, urlUC: urlUC, userUC: userUC}
+	h := handler{router: r, urlUC: urlUC, userUC: userUC, cfg: testServerCfg}
 
 	var tests = []struct {
 		ucOutput ucOutput
@@ -126,7 +363,7 @@ func Test_CreateShortURL_Errors(t *testing.T) {
 				path:        "/api/shorten",
 			},
 			response: response{
-				body:   `{"StatusCode":405,"Error":"HTTP method GET is not allowed"}`,
+				body:   `{"StatusCode":405,"Code":"ERR_METHOD_NOT_ALLOWED","Error":"HTTP method GET is not allowed"}`,
 				status: http.StatusMethodNotAllowed,
 			},
 		},
@@ -139,28 +376,176 @@ func Test_CreateShortURL_Errors(t *testing.T) {
 				path:        "/api/shorten",
 			},
 			response: response{
-				body:   `{"StatusCode":400,"Error":"invalid character '{' looking for beginning of object key string"}`,
+				body:   `{"StatusCode":400,"Code":"ERR_INVALID_REQUEST","Error":"invalid character '{' looking for beginning of object key string"}`,
 				status: http.StatusBadRequest,
 			},
 		},
 		{
-			name:    "when passed url is incorrect",
-			ucInput: "//example.com",
+			name: "when passed url is incorrect",
+			request: request{
+				body:        bytes.NewBufferString(`{"url":"//example.com"}`),
+				contentType: "application/json",
+				method:      http.MethodPost,
+				path:        "/api/shorten",
+			},
+			response: response{
+				body: `{"StatusCode":422,"Code":"ERR_INVALID_URL","Error":"invalid source URL, please specify valid URL",
+					"ValidationErrors":[{"Field":"url","Code":"INVALID_URL","Message":"must be a valid http/https URL"}]}`,
+				status: http.StatusUnprocessableEntity,
+			},
+		},
+		{
+			name: "when url is not passed",
+			request: request{
+				body:        bytes.NewBufferString(`{}`),
+				contentType: "application/json",
+				method:      http.MethodPost,
+				path:        "/api/shorten",
+			},
+			response: response{
+				body: `{"StatusCode":422,"Code":"ERR_INVALID_URL","Error":"invalid source URL, please specify valid URL",
+					"ValidationErrors":[{"Field":"url","Code":"INVALID_URL","Message":"must be a valid http/https URL"}]}`,
+				status: http.StatusUnprocessableEntity,
+			},
+		},
+		{
+			name: "when passed visibility is unknown",
+			request: request{
+				body:        bytes.NewBufferString(`{"url":"https://example.com","visibility":"secret"}`),
+				contentType: "application/json",
+				method:      http.MethodPost,
+				path:        "/api/shorten",
+			},
+			response: response{
+				body: `{"StatusCode":422,"Code":"ERR_VALIDATION","Error":"validation failed: visibility: must be either public or private",
+					"ValidationErrors":[{"Field":"visibility","Code":"INVALID_VISIBILITY","Message":"must be either public or private"}]}`,
+				status: http.StatusUnprocessableEntity,
+			},
+		},
+		{
+			name: "when private url is requested as one-time use",
+			request: request{
+				body:        bytes.NewBufferString(`{"url":"https://example.com","visibility":"private","one_time_use":true}`),
+				contentType: "application/json",
+				method:      http.MethodPost,
+				path:        "/api/shorten",
+			},
+			response: response{
+				body: `{"StatusCode":422,"Code":"ERR_VALIDATION","Error":"validation failed: visibility: private short URL cannot be one-time use",
+					"ValidationErrors":[{"Field":"visibility","Code":"CONFLICTING_OPTION","Message":"private short URL cannot be one-time use"}]}`,
+				status: http.StatusUnprocessableEntity,
+			},
+		},
+		{
+			name: "when passed redirect type is not supported",
+			request: request{
+				body:        bytes.NewBufferString(`{"url":"https://example.com","redirect_type":302}`),
+				contentType: "application/json",
+				method:      http.MethodPost,
+				path:        "/api/shorten",
+			},
+			response: response{
+				body: `{"StatusCode":422,"Code":"ERR_VALIDATION","Error":"validation failed: redirect_type: must be either 301 or 307",
+					"ValidationErrors":[{"Field":"redirect_type","Code":"INVALID_REDIRECT_TYPE","Message":"must be either 301 or 307"}]}`,
+				status: http.StatusUnprocessableEntity,
+			},
+		},
+		{
+			name: "when one-time url is requested as permanent",
+			request: request{
+				body:        bytes.NewBufferString(`{"url":"https://example.com","redirect_type":301,"one_time_use":true}`),
+				contentType: "application/json",
+				method:      http.MethodPost,
+				path:        "/api/shorten",
+			},
+			response: response{
+				body: `{"StatusCode":422,"Code":"ERR_VALIDATION","Error":"validation failed: redirect_type: one-time short URL cannot redirect permanently",
+					"ValidationErrors":[{"Field":"redirect_type","Code":"CONFLICTING_OPTION","Message":"one-time short URL cannot redirect permanently"}]}`,
+				status: http.StatusUnprocessableEntity,
+			},
+		},
+		{
+			name: "when permanent url is requested as tracked",
+			request: request{
+				body:        bytes.NewBufferString(`{"url":"https://example.com","redirect_type":301,"tracking":true}`),
+				contentType: "application/json",
+				method:      http.MethodPost,
+				path:        "/api/shorten",
+			},
+			response: response{
+				body: `{"StatusCode":422,"Code":"ERR_VALIDATION","Error":"validation failed: tracking: permanent short URL cannot be tracked",
+					"ValidationErrors":[{"Field":"tracking","Code":"CONFLICTING_OPTION","Message":"permanent short URL cannot be tracked"}]}`,
+				status: http.StatusUnprocessableEntity,
+			},
+		},
+		{
+			name: "when passed url cannot be parsed",
+			request: request{
+				body:        bytes.NewBufferString(`{"url":"https://example.com/%zz"}`),
+				contentType: "application/json",
+				method:      http.MethodPost,
+				path:        "/api/shorten",
+			},
+			response: response{
+				body: `{"StatusCode":422,"Code":"ERR_INVALID_URL","Error":"invalid source URL, please specify valid URL",
+					"ValidationErrors":[{"Field":"url","Code":"INVALID_URL","Message":"must be a valid http/https URL"}]}`,
+				status: http.StatusUnprocessableEntity,
+			},
+		},
+		{
+			name:    "when use case rejects url",
+			ucInput: "https://example.com/rejected",
 			ucOutput: ucOutput{
 				res: "",
 				err: ucErrors.ErrShortURLInvalidSourceURL,
 			},
 			request: request{
-				body:        bytes.NewBufferString(`{"url":"//example.com"}`),
+				body:        bytes.NewBufferString(`{"url":"https://example.com/rejected"}`),
+				contentType: "application/json",
+				method:      http.MethodPost,
+				path:        "/api/shorten",
+			},
+			response: response{
+				body:   `{"StatusCode":422,"Code":"ERR_INVALID_URL","Error":"invalid source URL, please specify valid URL"}`,
+				status: http.StatusUnprocessableEntity,
+			},
+		},
+		{
+			name:    "when passed url is flagged as unsafe",
+			ucInput: "https://malware.test",
+			ucOutput: ucOutput{
+				res: "",
+				err: ucErrors.ErrShortURLUnsafeContent,
+			},
+			request: request{
+				body:        bytes.NewBufferString(`{"url":"https://malware.test"}`),
 				contentType: "application/json",
 				method:      http.MethodPost,
 				path:        "/api/shorten",
 			},
 			response: response{
-				body:   `{"StatusCode":422,"Error":"invalid source URL, please specify valid URL"}`,
+				body:   `{"error":"URL flagged as unsafe","code":"ERR_UNSAFE_URL"}`,
 				status: http.StatusUnprocessableEntity,
 			},
 		},
+		{
+			name:    "when user reached quota of URLs",
+			ucInput: "https://example.com/quota",
+			ucOutput: ucOutput{
+				res: "",
+				err: &shortURLUseCase.QuotaExceededError{Limit: 10000, Current: 10000},
+			},
+			request: request{
+				body:        bytes.NewBufferString(`{"url":"https://example.com/quota"}`),
+				contentType: "application/json",
+				method:      http.MethodPost,
+				path:        "/api/shorten",
+			},
+			response: response{
+				body:   `{"StatusCode":429,"Code":"ERR_LIMIT_EXCEEDED","Error":"URL quota exceeded","limit":10000,"current":10000}`,
+				status: http.StatusTooManyRequests,
+			},
+		},
 		{
 			name:    "when passed url is not unique",
 			ucInput: "https://example.com",
@@ -188,7 +573,7 @@ func Test_CreateShortURL_Errors(t *testing.T) {
 			w := httptest.NewRecorder()
 			if tt.ucInput != "" {
 				userUC.EXPECT().Register(gomock.Any()).Return(user, nil).Times(1)
-				urlUC.EXPECT().CreateShortURL(gomock.Any(), user, tt.ucInput).Return(tt.ucOutput.res, tt.ucOutput.err).Times(1)
+				urlUC.EXPECT().CreateShortURL(gomock.Any(), user, tt.ucInput, shortURLEntity.CreateOptions{}).Return(tt.ucOutput.res, tt.ucOutput.err).Times(1)
 			}
 			h.CreateShortURL()(w, req)
 
@@ -208,6 +593,75 @@ func Test_CreateShortURL_Errors(t *testing.T) {
 	}
 }
 
+func Test_CreateShortURL_Timeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	user := &entity.User{ID: 1, AuthToken: "token"}
+
+	cfg := testServerCfg
+	cfg.CreateURLTimeout = time.Millisecond
+	cfg.BatchURLTimeout = time.Millisecond
+	r := chi.NewRouter()
+	Register(r, userUC, urlUC, nil, cfg)
+
+	userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil).AnyTimes()
+	urlUC.EXPECT().CreateShortURL(gomock.Any(), user, "https://ya.ru", shortURLEntity.CreateOptions{}).
+		DoAndReturn(func(_ context.Context, _ *entity.User, _ string, _ shortURLEntity.CreateOptions) (string, error) {
+			time.Sleep(10 * time.Millisecond)
+			return "http://localhost:8080/alias", nil
+		})
+	urlUC.EXPECT().BatchShortURLs(gomock.Any(), user, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *entity.User, _ []shortURLEntity.BatchShortURLInput) ([]shortURLEntity.BatchShortURLOutput, error) {
+			time.Sleep(10 * time.Millisecond)
+			return []shortURLEntity.BatchShortURLOutput{{CorrelationID: "1", ShortURL: "http://localhost:8080/alias"}}, nil
+		})
+
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{
+			name: "when single URL creation exceeds timeout",
+			path: "/api/shorten",
+			body: `{"url":"https://ya.ru"}`,
+		},
+		{
+			name: "when batch URL creation exceeds timeout",
+			path: "/api/shorten/batch",
+			body: `[{"correlation_id":"1","original_url":"https://ya.ru"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer token")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+			assert.JSONEq(t, `{"StatusCode":504,"Code":"ERR_TIMEOUT","Error":"request timed out"}`, w.Body.String())
+		})
+	}
+}
+
+func Test_CreateShortURL_ValidationErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	r := chi.NewRouter()
+	Register(r, mocks.NewMockUserUseCase(ctrl), mocks.NewMockShortURLUseCase(ctrl), nil, testServerCfg)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/shorten", bytes.NewBufferString(`{"url":"not-a-url"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), `"ValidationErrors":[{"Field":"url","Code":"INVALID_URL","Message":"must be a valid http/https URL"}]`)
+}
+
 func Test_BatchShortURLs_Errors(t *testing.T) {
 	var err error
 	var body []byte
@@ -216,7 +670,7 @@ func Test_BatchShortURLs_Errors(t *testing.T) {
 	urlUC := mocks.NewMockShortURLUseCase(ctrl)
 
 	r := chi.NewRouter()
-	h := handler{router: r, urlUC: urlUC}
+	h := handler{router: r, urlUC: urlUC, cfg: testServerCfg}
 
 	var tests = []struct {
 		name     string
@@ -232,7 +686,7 @@ func Test_BatchShortURLs_Errors(t *testing.T) {
 				path:        "/api/shorten/batch",
 			},
 			response: response{
-				body:   `{"StatusCode":405,"Error":"HTTP method GET is not allowed"}`,
+				body:   `{"StatusCode":405,"Code":"ERR_METHOD_NOT_ALLOWED","Error":"HTTP method GET is not allowed"}`,
 				status: http.StatusMethodNotAllowed,
 			},
 		},
@@ -245,7 +699,7 @@ func Test_BatchShortURLs_Errors(t *testing.T) {
 				path:        "/api/shorten/batch",
 			},
 			response: response{
-				body:   `{"StatusCode":400,"Error":"invalid character '{' looking for beginning of object key string"}`,
+				body:   `{"StatusCode":400,"Code":"ERR_INVALID_REQUEST","Error":"invalid character '{' looking for beginning of object key string"}`,
 				status: http.StatusBadRequest,
 			},
 		},
@@ -258,10 +712,26 @@ func Test_BatchShortURLs_Errors(t *testing.T) {
 				path:        "/api/shorten/batch",
 			},
 			response: response{
-				body:   `{"StatusCode":400,"Error":"nothing to process, empty batch"}`,
+				body:   `{"StatusCode":400,"Code":"ERR_INVALID_REQUEST","Error":"nothing to process, empty batch"}`,
 				status: http.StatusBadRequest,
 			},
 		},
+		{
+			name: "when batch items have invalid urls",
+			request: request{
+				body:        bytes.NewBufferString(`[{"correlation_id":"1","original_url":"https://example.com"},{"correlation_id":"2","original_url":"not-a-url"},{"correlation_id":"3"}]`),
+				contentType: "application/json",
+				method:      http.MethodPost,
+				path:        "/api/shorten/batch",
+			},
+			response: response{
+				body: `{"StatusCode":422,"Code":"ERR_INVALID_URL","Error":"invalid source URL, please specify valid URL","ValidationErrors":[
+					{"Field":"[1].original_url","Code":"INVALID_URL","Message":"must be a valid http/https URL"},
+					{"Field":"[2].original_url","Code":"INVALID_URL","Message":"must be a valid http/https URL"}
+				]}`,
+				status: http.StatusUnprocessableEntity,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -286,3 +756,224 @@ func Test_BatchShortURLs_Errors(t *testing.T) {
 		})
 	}
 }
+
+func Test_BatchShortURLs_ReportsFailedURLs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	h := handler{router: chi.NewRouter(), urlUC: urlUC, cfg: testServerCfg}
+
+	urlUC.EXPECT().BatchShortURLs(gomock.Any(), gomock.Nil(), []shortURLEntity.BatchShortURLInput{
+		{CorrelationID: "1", OriginalURL: "https://ya.ru"},
+		{CorrelationID: "2", OriginalURL: "https://malware.test"},
+	}).Return([]shortURLEntity.BatchShortURLOutput{
+		{CorrelationID: "1", ShortURL: "http://localhost:8080/alias1"},
+		{CorrelationID: "2", Error: ucErrors.ErrShortURLUnsafeContent.Error()},
+	}, nil)
+
+	body := `[{"correlation_id":"1","original_url":"https://ya.ru"},{"correlation_id":"2","original_url":"https://malware.test"}]`
+	req := httptest.NewRequest(http.MethodPost, "/api/shorten/batch", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.BatchShortURLs()(w, req)
+
+	resp := w.Result()
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	res, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"correlation_id":"1","short_url":"http://localhost:8080/alias1"},
+		{"correlation_id":"2","short_url":"","error":"URL flagged as unsafe"}
+	]`, string(res))
+}
+
+func Test_BatchShortURLs_QuotaExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	h := handler{router: chi.NewRouter(), urlUC: urlUC, userUC: userUC, cfg: testServerCfg}
+	user := &entity.User{ID: 1, AuthToken: "token"}
+
+	userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil)
+	urlUC.EXPECT().BatchShortURLs(gomock.Any(), user, gomock.Any()).
+		Return(nil, &shortURLUseCase.QuotaExceededError{Limit: 10, Current: 9})
+
+	body := `[{"correlation_id":"1","original_url":"https://ya.ru"},{"correlation_id":"2","original_url":"https://google.com"}]`
+	req := httptest.NewRequest(http.MethodPost, "/api/shorten/batch", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer token")
+	w := httptest.NewRecorder()
+	h.BatchShortURLs()(w, req)
+
+	resp := w.Result()
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	res, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"StatusCode":429,"Code":"ERR_LIMIT_EXCEEDED","Error":"URL quota exceeded","limit":10,"current":9}`, string(res))
+}
+
+func Test_ValidateBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	h := handler{router: chi.NewRouter(), urlUC: urlUC, cfg: testServerCfg}
+
+	tests := []struct {
+		setup    func()
+		name     string
+		body     string
+		response response
+	}{
+		{
+			name: "when batch is validated",
+			body: `[{"correlation_id":"1","original_url":"https://ya.ru"},{"correlation_id":"2","original_url":"https://malware.test"}]`,
+			setup: func() {
+				urlUC.EXPECT().ValidateBatch(gomock.Any(), []shortURLEntity.BatchShortURLInput{
+					{CorrelationID: "1", OriginalURL: "https://ya.ru"},
+					{CorrelationID: "2", OriginalURL: "https://malware.test"},
+				}).Return([]shortURLEntity.BatchValidationResult{
+					{CorrelationID: "1", Valid: true},
+					{CorrelationID: "2", Reason: ucErrors.ErrShortURLUnsafeContent.Error()},
+				})
+			},
+			response: response{
+				body: `{"results":[
+					{"correlation_id":"1","valid":true},
+					{"correlation_id":"2","valid":false,"reason":"URL flagged as unsafe"}
+				]}`,
+				status: http.StatusOK,
+			},
+		},
+		{
+			name:  "when invalid json passed",
+			body:  `{{"url":"https://example.com"}`,
+			setup: func() {},
+			response: response{
+				body:   `{"StatusCode":400,"Code":"ERR_INVALID_REQUEST","Error":"invalid character '{' looking for beginning of object key string"}`,
+				status: http.StatusBadRequest,
+			},
+		},
+		{
+			name:  "when passed empty batch",
+			body:  `[]`,
+			setup: func() {},
+			response: response{
+				body:   `{"StatusCode":400,"Code":"ERR_INVALID_REQUEST","Error":"nothing to process, empty batch"}`,
+				status: http.StatusBadRequest,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			req := httptest.NewRequest(http.MethodPost, "/api/shorten/validate", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			h.ValidateBatch()(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			assert.Equal(t, tt.response.status, resp.StatusCode)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.response.body, string(body))
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		})
+	}
+}
+
+func Test_ShortURLInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	r := chi.NewRouter()
+	Register(r, userUC, urlUC, nil, testServerCfg)
+
+	tests := []struct {
+		shortURL    *shortURLEntity.ShortURL
+		ucErr       error
+		name        string
+		alias       string
+		token       string
+		originalURL string
+		createdAt   string
+		status      int
+	}{
+		{
+			name:        "when alias is valid",
+			alias:       "valid",
+			shortURL:    &shortURLEntity.ShortURL{Alias: "valid", SourceURL: "https://ya.ru", CreatedAt: createdAt},
+			status:      http.StatusOK,
+			originalURL: "https://ya.ru",
+			createdAt:   "2025-06-01T12:00:00Z",
+		},
+		{
+			name:   "when alias was deleted",
+			alias:  "deleted",
+			ucErr:  ucErrors.ErrShortURLDeleted,
+			status: http.StatusGone,
+		},
+		{
+			name:   "when alias does not exist",
+			alias:  "unknown",
+			ucErr:  ucErrors.ErrShortURLSourceURLNotFound,
+			status: http.StatusNotFound,
+		},
+		{
+			name:        "when owner requests private alias",
+			alias:       "private",
+			token:       "owner",
+			shortURL:    &shortURLEntity.ShortURL{Alias: "private", SourceURL: "https://ya.ru", UserID: 1, Visibility: shortURLEntity.VisibilityPrivate, CreatedAt: createdAt},
+			status:      http.StatusOK,
+			originalURL: "https://ya.ru",
+			createdAt:   "2025-06-01T12:00:00Z",
+		},
+		{
+			name:     "when anonymous user requests private alias",
+			alias:    "private",
+			shortURL: &shortURLEntity.ShortURL{Alias: "private", SourceURL: "https://ya.ru", UserID: 1, Visibility: shortURLEntity.VisibilityPrivate},
+			status:   http.StatusForbidden,
+		},
+	}
+
+	userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(&entity.User{ID: 1}, nil).AnyTimes()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urlUC.EXPECT().GetShortURL(gomock.Any(), "", tt.alias).Return(tt.shortURL, tt.ucErr)
+
+			req := httptest.NewRequest(http.MethodHead, "/api/shorturl/"+tt.alias, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, tt.originalURL, resp.Header.Get("X-Original-URL"))
+			assert.Equal(t, tt.createdAt, resp.Header.Get("X-Created-At"))
+			assert.Empty(t, body)
+		})
+	}
+}
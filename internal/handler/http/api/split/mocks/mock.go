@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gururuby/shortener/internal/handler/http/api/split (interfaces: UserUseCase,SplitUseCase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock.go -package=mocks . UserUseCase,SplitUseCase
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/split"
+	entity0 "github.com/gururuby/shortener/internal/domain/entity/user"
+	usecase "github.com/gururuby/shortener/internal/domain/usecase/split"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserUseCase is a mock of UserUseCase interface.
+type MockUserUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockUserUseCaseMockRecorder is the mock recorder for MockUserUseCase.
+type MockUserUseCaseMockRecorder struct {
+	mock *MockUserUseCase
+}
+
+// NewMockUserUseCase creates a new mock instance.
+func NewMockUserUseCase(ctrl *gomock.Controller) *MockUserUseCase {
+	mock := &MockUserUseCase{ctrl: ctrl}
+	mock.recorder = &MockUserUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserUseCase) EXPECT() *MockUserUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockUserUseCase) Authenticate(ctx context.Context, token string) (*entity0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, token)
+	ret0, _ := ret[0].(*entity0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockUserUseCaseMockRecorder) Authenticate(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockUserUseCase)(nil).Authenticate), ctx, token)
+}
+
+// AuthenticateAPIKey mocks base method.
+func (m *MockUserUseCase) AuthenticateAPIKey(ctx context.Context, key string) (*entity0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", ctx, key)
+	ret0, _ := ret[0].(*entity0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockUserUseCaseMockRecorder) AuthenticateAPIKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockUserUseCase)(nil).AuthenticateAPIKey), ctx, key)
+}
+
+// MockSplitUseCase is a mock of SplitUseCase interface.
+type MockSplitUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockSplitUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockSplitUseCaseMockRecorder is the mock recorder for MockSplitUseCase.
+type MockSplitUseCaseMockRecorder struct {
+	mock *MockSplitUseCase
+}
+
+// NewMockSplitUseCase creates a new mock instance.
+func NewMockSplitUseCase(ctrl *gomock.Controller) *MockSplitUseCase {
+	mock := &MockSplitUseCase{ctrl: ctrl}
+	mock.recorder = &MockSplitUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSplitUseCase) EXPECT() *MockSplitUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CreateSplit mocks base method.
+func (m *MockSplitUseCase) CreateSplit(ctx context.Context, user *entity0.User, alias string, destinations []entity.Destination) (*usecase.SplitURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSplit", ctx, user, alias, destinations)
+	ret0, _ := ret[0].(*usecase.SplitURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSplit indicates an expected call of CreateSplit.
+func (mr *MockSplitUseCaseMockRecorder) CreateSplit(ctx, user, alias, destinations any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSplit", reflect.TypeOf((*MockSplitUseCase)(nil).CreateSplit), ctx, user, alias, destinations)
+}
+
+// GetStats mocks base method.
+func (m *MockSplitUseCase) GetStats(ctx context.Context, user *entity0.User, alias string) (*usecase.SplitURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", ctx, user, alias)
+	ret0, _ := ret[0].(*usecase.SplitURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockSplitUseCaseMockRecorder) GetStats(ctx, user, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockSplitUseCase)(nil).GetStats), ctx, user, alias)
+}
@@ -0,0 +1,280 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . UserUseCase,SplitUseCase
+
+/*
+Package handler implements HTTP request handlers for split short URLs.
+
+It provides:
+- Creation of short URLs splitting traffic between several original URLs
+- Per destination click statistics for owners of splits
+- Request validation and error handling
+*/
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	splitEntity "github.com/gururuby/shortener/internal/domain/entity/split"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	splitUseCase "github.com/gururuby/shortener/internal/domain/usecase/split"
+	splitErrors "github.com/gururuby/shortener/internal/domain/usecase/split/errors"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+	httpErrors "github.com/gururuby/shortener/internal/handler/http/errors"
+)
+
+// Available constants
+const (
+	SplitPath       = "/api/user/urls/split"              // Path of split creation
+	SplitStatsPath  = "/api/shorturl/{alias}/split-stats" // Path pattern of split click statistics
+	splitTimeout    = time.Second * 10                    // Timeout for split operations, covers checks of destination URLs
+	namespaceHeader = "X-Namespace"                       // Name of the header with namespace of the split
+)
+
+// Router defines the interface for HTTP request routing.
+type Router interface {
+	// Get registers a handler for GET requests at the specified path
+	Get(path string, h http.HandlerFunc)
+	// Post registers a handler for POST requests at the specified path
+	Post(path string, h http.HandlerFunc)
+}
+
+// UserUseCase defines the interface for user authentication.
+type UserUseCase interface {
+	// Authenticate verifies a user's credentials
+	Authenticate(ctx context.Context, token string) (*userEntity.User, error)
+	// AuthenticateAPIKey verifies an API key and returns its owner
+	AuthenticateAPIKey(ctx context.Context, key string) (*userEntity.User, error)
+}
+
+// SplitUseCase defines the interface for split business logic.
+type SplitUseCase interface {
+	// CreateSplit creates a short URL splitting traffic between destinations by their weights
+	CreateSplit(ctx context.Context, user *userEntity.User, alias string, destinations []splitEntity.Destination) (*splitUseCase.SplitURL, error)
+	// GetStats retrieves per destination click counts of a split of the user
+	GetStats(ctx context.Context, user *userEntity.User, alias string) (*splitUseCase.SplitURL, error)
+}
+
+// handler implements the HTTP request handlers for split operations.
+type handler struct {
+	userUC  UserUseCase  // User authentication service
+	splitUC SplitUseCase // Split business logic service
+	router  Router       // Request router
+}
+
+// destinationRequest represents a destination in split creation request.
+type destinationRequest struct {
+	URL    string `json:"url"`
+	Weight int    `json:"weight"`
+}
+
+// createSplitRequest represents the request body of split creation.
+type createSplitRequest struct {
+	Alias        string               `json:"alias"`
+	Destinations []destinationRequest `json:"destinations"`
+}
+
+// errorResponse represents an API error response.
+// Code is a stable machine-readable error code, see httpErrors.Code.
+type errorResponse struct {
+	err        error // Error returned to the client, maps to Code
+	Error      string
+	Code       string
+	StatusCode int
+}
+
+// Register sets up the split routes.
+// Parameters:
+// - router: The HTTP router implementation
+// - userUC: User authentication service
+// - splitUC: Split business logic service
+func Register(router Router, userUC UserUseCase, splitUC SplitUseCase) {
+	h := handler{router: router, userUC: userUC, splitUC: splitUC}
+	h.router.Post(SplitPath, h.CreateSplit())
+	h.router.Get(SplitStatsPath, h.GetSplitStats())
+}
+
+// CreateSplit handles requests for creation of a split short URL.
+// Request body is a JSON object with `alias` and `destinations`, each having `url` and `weight`;
+// weights are percentages of traffic and must sum to 100.
+//
+// Returns an HTTP handler function that:
+// - Authenticates the user, new users are never registered
+// - Creates the split in the namespace from X-Namespace header, the default one if it is missing
+// - Returns appropriate status codes:
+//   - 201 Created with the split in JSON
+//   - 400 Bad Request for malformed JSON
+//   - 401 Unauthorized if credentials are missing or invalid
+//   - 403 Forbidden if the user is deactivated
+//   - 409 Conflict if the alias is already taken
+//   - 422 Unprocessable Entity for invalid alias, destinations or weights, or unknown namespace
+//   - 500 Internal Server Error if the split cannot be saved
+func (h *handler) CreateSplit() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err   error
+			req   createSplitRequest
+			user  *userEntity.User
+			split *splitUseCase.SplitURL
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), splitTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if user, err = auth.User(ctx, h.userUC, r); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.ErrStatus(err)), w)
+			return
+		}
+
+		if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusBadRequest), w)
+			return
+		}
+
+		destinations := make([]splitEntity.Destination, 0, len(req.Destinations))
+		for _, destination := range req.Destinations {
+			destinations = append(destinations, splitEntity.Destination{URL: destination.URL, Weight: destination.Weight})
+		}
+
+		if split, err = h.splitUC.CreateSplit(ctx, withNamespace(user, r), req.Alias, destinations); err != nil {
+			returnErrResponse(newErrorResponse(err, splitErrStatus(err)), w)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, split)
+	}
+}
+
+// GetSplitStats handles requests for per destination click counts of a split.
+//
+// Returns an HTTP handler function that:
+// - Authenticates the user, new users are never registered
+// - Looks up the split in the namespace from X-Namespace header, the default one if it is missing
+// - Checks that the user owns the split
+// - Returns appropriate status codes:
+//   - 200 OK with the split and its click counts in JSON
+//   - 401 Unauthorized if credentials are missing or invalid
+//   - 403 Forbidden if the user is deactivated
+//   - 403 Forbidden if the split belongs to another user
+//   - 404 Not Found if there is no split with the alias
+//   - 500 Internal Server Error if the split cannot be read
+func (h *handler) GetSplitStats() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err   error
+			user  *userEntity.User
+			stats *splitUseCase.SplitURL
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), splitTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if user, err = auth.User(ctx, h.userUC, r); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.ErrStatus(err)), w)
+			return
+		}
+
+		if stats, err = h.splitUC.GetStats(ctx, withNamespace(user, r), chi.URLParam(r, "alias")); err != nil {
+			returnErrResponse(newErrorResponse(err, splitErrStatus(err)), w)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, stats)
+	}
+}
+
+// withNamespace returns the user operating on splits in the namespace passed with the request.
+// The user is copied, so the authenticated user is not modified.
+// Parameters:
+// - user: Authenticated user
+// - r: HTTP request
+// Returns:
+// - *userEntity.User: User with namespace from X-Namespace header, or the user itself if header is missing
+func withNamespace(user *userEntity.User, r *http.Request) *userEntity.User {
+	namespace := r.Header.Get(namespaceHeader)
+	if namespace == "" || user == nil {
+		return user
+	}
+	namespaced := *user
+	namespaced.Namespace = namespace
+	return &namespaced
+}
+
+// splitErrStatus maps split errors to HTTP status codes.
+// Parameters:
+// - err: Error returned by split use case
+// Returns:
+// - int: HTTP status code
+func splitErrStatus(err error) int {
+	switch {
+	case errors.Is(err, splitErrors.ErrSplitAlreadyExist):
+		return http.StatusConflict
+	case errors.Is(err, splitErrors.ErrSplitNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, splitErrors.ErrSplitForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, splitErrors.ErrSplitInvalidAlias),
+		errors.Is(err, splitErrors.ErrSplitInvalidDestinationsCount),
+		errors.Is(err, splitErrors.ErrSplitInvalidURL),
+		errors.Is(err, splitErrors.ErrSplitDuplicateURL),
+		errors.Is(err, splitErrors.ErrSplitInvalidWeight),
+		errors.Is(err, splitErrors.ErrSplitInvalidWeightsSum),
+		errors.Is(err, splitErrors.ErrSplitDomainNotPermitted),
+		errors.Is(err, splitErrors.ErrSplitNamespaceNotFound),
+		errors.Is(err, splitErrors.ErrSplitUnsafeContent):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
+
+// writeJSON writes v as JSON response with the status code.
+// Parameters:
+// - w: HTTP response writer
+// - statusCode: HTTP status code
+// - v: Value to encode
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	response, err := json.Marshal(v)
+	if err != nil {
+		returnErrResponse(newErrorResponse(err, http.StatusInternalServerError), w)
+		return
+	}
+
+	w.WriteHeader(statusCode)
+
+	if _, err = w.Write(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// newErrorResponse creates an error response for the error.
+// Parameters:
+// - err: Error returned to the client
+// - statusCode: HTTP status code of the response
+// Returns:
+// - errorResponse: Response with the error message, Code is set by returnErrResponse
+func newErrorResponse(err error, statusCode int) errorResponse {
+	return errorResponse{err: err, Error: err.Error(), StatusCode: statusCode}
+}
+
+// returnErrResponse writes error response in JSON.
+// Parameters:
+// - errResp: Error details
+// - w: HTTP response writer
+func returnErrResponse(errResp errorResponse, w http.ResponseWriter) {
+	errResp.Code = httpErrors.Code(errResp.err, errResp.StatusCode)
+	w.WriteHeader(errResp.StatusCode)
+	response, err := json.Marshal(errResp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	if _, err = w.Write(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
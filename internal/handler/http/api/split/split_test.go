@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	splitEntity "github.com/gururuby/shortener/internal/domain/entity/split"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	splitUseCase "github.com/gururuby/shortener/internal/domain/usecase/split"
+	splitErrors "github.com/gururuby/shortener/internal/domain/usecase/split/errors"
+	userErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/split/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type mocksSet struct {
+	userUC  *mocks.MockUserUseCase
+	splitUC *mocks.MockSplitUseCase
+}
+
+func Test_CreateSplit(t *testing.T) {
+	owner := &userEntity.User{ID: 1}
+	reqBody := `{"alias":"ab-test","destinations":[{"url":"https://v1.com","weight":70},{"url":"https://v2.com","weight":30}]}`
+	destinations := []splitEntity.Destination{{URL: "https://v1.com", Weight: 70}, {URL: "https://v2.com", Weight: 30}}
+
+	tests := []struct {
+		setup     func(m mocksSet)
+		name      string
+		reqBody   string
+		token     string
+		namespace string
+		body      string
+		code      int
+	}{
+		{
+			name:    "when split is created",
+			reqBody: reqBody,
+			token:   "owner",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+				m.splitUC.EXPECT().CreateSplit(gomock.Any(), owner, "ab-test", destinations).Return(&splitUseCase.SplitURL{
+					ShortURL:     "http://localhost:8080/ab-test",
+					Alias:        "ab-test",
+					Destinations: destinations,
+				}, nil)
+			},
+			code: http.StatusCreated,
+			body: `{"short_url":"http://localhost:8080/ab-test","alias":"ab-test","destinations":[{"url":"https://v1.com","weight":70,"clicks":0},{"url":"https://v2.com","weight":30,"clicks":0}],"total_clicks":0}`,
+		},
+		{
+			name:      "when split is created in namespace",
+			reqBody:   reqBody,
+			token:     "owner",
+			namespace: "team",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+				m.splitUC.EXPECT().CreateSplit(gomock.Any(), &userEntity.User{ID: 1, Namespace: "team"}, "ab-test", destinations).Return(&splitUseCase.SplitURL{
+					ShortURL:     "http://localhost:8080/team/ab-test",
+					Alias:        "ab-test",
+					Destinations: destinations,
+				}, nil)
+			},
+			code: http.StatusCreated,
+			body: `{"short_url":"http://localhost:8080/team/ab-test","alias":"ab-test","destinations":[{"url":"https://v1.com","weight":70,"clicks":0},{"url":"https://v2.com","weight":30,"clicks":0}],"total_clicks":0}`,
+		},
+		{
+			name:      "when namespace doesn't exist",
+			reqBody:   reqBody,
+			token:     "owner",
+			namespace: "missing",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+				m.splitUC.EXPECT().CreateSplit(gomock.Any(), &userEntity.User{ID: 1, Namespace: "missing"}, "ab-test", destinations).Return(nil, splitErrors.ErrSplitNamespaceNotFound)
+			},
+			code: http.StatusUnprocessableEntity,
+			body: `{"Error":"namespace not found","Code":"ERR_NOT_FOUND","StatusCode":422}`,
+		},
+		{
+			name:    "when credentials are not passed",
+			reqBody: reqBody,
+			setup:   func(_ mocksSet) {},
+			code:    http.StatusUnauthorized,
+			body:    `{"Error":"auth token is not passed","Code":"ERR_UNAUTHORIZED","StatusCode":401}`,
+		},
+		{
+			name:    "when user is deactivated",
+			reqBody: reqBody,
+			token:   "deactivated",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "deactivated").Return(nil, userErrors.ErrUserDeactivated)
+			},
+			code: http.StatusForbidden,
+			body: `{"Error":"user is deactivated","Code":"ERR_FORBIDDEN","StatusCode":403}`,
+		},
+		{
+			name:    "when body is not JSON",
+			reqBody: "ab-test",
+			token:   "owner",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+			},
+			code: http.StatusBadRequest,
+			body: `{"Error":"invalid character 'a' looking for beginning of value","Code":"ERR_INVALID_REQUEST","StatusCode":400}`,
+		},
+		{
+			name:    "when weights do not sum to 100",
+			reqBody: reqBody,
+			token:   "owner",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+				m.splitUC.EXPECT().CreateSplit(gomock.Any(), owner, "ab-test", destinations).Return(nil, splitErrors.ErrSplitInvalidWeightsSum)
+			},
+			code: http.StatusUnprocessableEntity,
+			body: `{"Error":"destination weights must sum to 100","Code":"ERR_VALIDATION","StatusCode":422}`,
+		},
+		{
+			name:    "when alias is taken",
+			reqBody: reqBody,
+			token:   "owner",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+				m.splitUC.EXPECT().CreateSplit(gomock.Any(), owner, "ab-test", destinations).Return(nil, splitErrors.ErrSplitAlreadyExist)
+			},
+			code: http.StatusConflict,
+			body: `{"Error":"alias is already taken","Code":"ERR_CONFLICT","StatusCode":409}`,
+		},
+		{
+			name:    "when split cannot be saved",
+			reqBody: reqBody,
+			token:   "owner",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+				m.splitUC.EXPECT().CreateSplit(gomock.Any(), owner, "ab-test", destinations).Return(nil, splitErrors.ErrSplitStorageNotWorking)
+			},
+			code: http.StatusInternalServerError,
+			body: `{"Error":"split storage is not working","Code":"ERR_INTERNAL","StatusCode":500}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, SplitPath, strings.NewReader(tt.reqBody))
+			if tt.namespace != "" {
+				req.Header.Set(namespaceHeader, tt.namespace)
+			}
+			code, body := serve(t, tt.setup, req, tt.token)
+
+			assert.Equal(t, tt.code, code)
+			require.JSONEq(t, tt.body, body)
+		})
+	}
+}
+
+func Test_GetSplitStats(t *testing.T) {
+	owner := &userEntity.User{ID: 1}
+
+	tests := []struct {
+		setup  func(m mocksSet)
+		name   string
+		token  string
+		apiKey string
+		body   string
+		code   int
+	}{
+		{
+			name:   "when owner requests stats",
+			apiKey: "key",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().AuthenticateAPIKey(gomock.Any(), "key").Return(owner, nil)
+				m.splitUC.EXPECT().GetStats(gomock.Any(), owner, "ab-test").Return(&splitUseCase.SplitURL{
+					ShortURL: "http://localhost:8080/ab-test",
+					Alias:    "ab-test",
+					Destinations: []splitEntity.Destination{
+						{URL: "https://v1.com", Weight: 70, Clicks: 7},
+						{URL: "https://v2.com", Weight: 30, Clicks: 3},
+					},
+					TotalClicks: 10,
+				}, nil)
+			},
+			code: http.StatusOK,
+			body: `{"short_url":"http://localhost:8080/ab-test","alias":"ab-test","destinations":[{"url":"https://v1.com","weight":70,"clicks":7},{"url":"https://v2.com","weight":30,"clicks":3}],"total_clicks":10}`,
+		},
+		{
+			name:  "when credentials are not passed",
+			setup: func(_ mocksSet) {},
+			code:  http.StatusUnauthorized,
+			body:  `{"Error":"auth token is not passed","Code":"ERR_UNAUTHORIZED","StatusCode":401}`,
+		},
+		{
+			name:  "when split belongs to another user",
+			token: "owner",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+				m.splitUC.EXPECT().GetStats(gomock.Any(), owner, "ab-test").Return(nil, splitErrors.ErrSplitForbidden)
+			},
+			code: http.StatusForbidden,
+			body: `{"Error":"split belongs to another user","Code":"ERR_FORBIDDEN","StatusCode":403}`,
+		},
+		{
+			name:  "when split does not exist",
+			token: "owner",
+			setup: func(m mocksSet) {
+				m.userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil)
+				m.splitUC.EXPECT().GetStats(gomock.Any(), owner, "ab-test").Return(nil, splitErrors.ErrSplitNotFound)
+			},
+			code: http.StatusNotFound,
+			body: `{"Error":"split is not found","Code":"ERR_NOT_FOUND","StatusCode":404}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/shorturl/ab-test/split-stats", nil)
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+			code, body := serve(t, tt.setup, req, tt.token)
+
+			assert.Equal(t, tt.code, code)
+			require.JSONEq(t, tt.body, body)
+		})
+	}
+}
+
+// serve passes the request with optional bearer token to registered split routes.
+func serve(t *testing.T, setup func(m mocksSet), req *http.Request, token string) (int, string) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	m := mocksSet{userUC: mocks.NewMockUserUseCase(ctrl), splitUC: mocks.NewMockSplitUseCase(ctrl)}
+	setup(m)
+
+	r := chi.NewRouter()
+	Register(r, m.userUC, m.splitUC)
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	resp := w.Result()
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(body)
+}
@@ -0,0 +1,122 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . StatsUseCase
+
+/*
+Package handler implements HTTP request handlers for service statistics.
+
+It provides:
+- Internal statistics endpoint
+- Error handling and status code management
+
+Access to the endpoint is restricted by trusted subnet middleware in router.
+*/
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/stats"
+	httpErrors "github.com/gururuby/shortener/internal/handler/http/errors"
+)
+
+// Available constants
+const (
+	StatsPath       = "/api/internal/stats" // Path of service statistics endpoint
+	getStatsTimeout = time.Second * 10      // Timeout for statistics collection
+)
+
+// Router defines the interface for HTTP request routing.
+type Router interface {
+	// Get registers a handler for GET requests at the specified path
+	Get(path string, h http.HandlerFunc)
+}
+
+// StatsUseCase defines the interface for statistics business logic.
+type StatsUseCase interface {
+	// GetStats collects service statistics
+	GetStats(ctx context.Context) (*entity.Stats, error)
+}
+
+// handler implements the HTTP request handlers for statistics operations.
+type handler struct {
+	uc     StatsUseCase // Statistics business logic service
+	router Router       // Request router
+}
+
+// errorResponse represents an API error response.
+// Code is a stable machine-readable error code, see httpErrors.Code.
+type errorResponse struct {
+	err        error // Error returned to the client, maps to Code
+	Error      string
+	Code       string
+	StatusCode int
+}
+
+// Register sets up the statistics routes.
+// Parameters:
+// - router: The HTTP router implementation
+// - uc: Statistics business logic service
+func Register(router Router, uc StatsUseCase) {
+	h := handler{router: router, uc: uc}
+	h.router.Get(StatsPath, h.GetStats())
+}
+
+// GetStats handles requests for service statistics.
+// Returns an HTTP handler function that:
+// - Collects counts of URLs and users
+// - Returns appropriate status codes:
+//   - 200 OK with statistics in JSON
+//   - 500 Internal Server Error if statistics cannot be collected
+func (h *handler) GetStats() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), getStatsTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		stats, err := h.uc.GetStats(ctx)
+		if err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusInternalServerError), w)
+			return
+		}
+
+		response, err := json.Marshal(stats)
+		if err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusInternalServerError), w)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err = w.Write(response); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
+// newErrorResponse creates an error response for the error.
+// Parameters:
+// - err: Error returned to the client
+// - statusCode: HTTP status code of the response
+// Returns:
+// - errorResponse: Response with the error message, Code is set by returnErrResponse
+func newErrorResponse(err error, statusCode int) errorResponse {
+	return errorResponse{err: err, Error: err.Error(), StatusCode: statusCode}
+}
+
+// returnErrResponse writes error response in JSON.
+// Parameters:
+// - errResp: Error details
+// - w: HTTP response writer
+func returnErrResponse(errResp errorResponse, w http.ResponseWriter) {
+	errResp.Code = httpErrors.Code(errResp.err, errResp.StatusCode)
+	w.WriteHeader(errResp.StatusCode)
+	response, err := json.Marshal(errResp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+	if _, err = w.Write(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
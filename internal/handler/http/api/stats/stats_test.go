@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	entity "github.com/gururuby/shortener/internal/domain/entity/stats"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/stats/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/stats/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_GetStats(t *testing.T) {
+	type useCaseResult struct {
+		stats *entity.Stats
+		err   error
+	}
+
+	type response struct {
+		body string
+		code int
+	}
+
+	tests := []struct {
+		useCaseRes useCaseResult
+		name       string
+		response   response
+	}{
+		{
+			name: "when stats are collected",
+			useCaseRes: useCaseResult{
+				stats: &entity.Stats{URLsCount: 10, UsersCount: 2, DeletedURLsCount: 3, ActiveURLsCount: 7},
+			},
+			response: response{
+				code: http.StatusOK,
+				body: `{"urls":10,"users":2,"deleted_urls":3,"active_urls":7}`,
+			},
+		},
+		{
+			name: "when stats cannot be collected",
+			useCaseRes: useCaseResult{
+				err: ucErrors.ErrStatsCannotGet,
+			},
+			response: response{
+				code: http.StatusInternalServerError,
+				body: `{"Error":"cannot get stats","Code":"ERR_INTERNAL","StatusCode":500}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			uc := mocks.NewMockStatsUseCase(ctrl)
+			uc.EXPECT().GetStats(gomock.Any()).Return(tt.useCaseRes.stats, tt.useCaseRes.err)
+
+			h := handler{router: chi.NewRouter(), uc: uc}
+
+			req := httptest.NewRequest(http.MethodGet, StatsPath, nil)
+			w := httptest.NewRecorder()
+			h.GetStats()(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.response.code, resp.StatusCode)
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+			assert.JSONEq(t, tt.response.body, string(body))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+)
+
+// Available constants
+const (
+	APIKeysPath    = "/api/user/api-keys"       // Path of user API keys
+	APIKeyPath     = "/api/user/api-keys/{key}" // Path of single user API key
+	apiKeysTimeout = time.Second * 5            // Timeout for API key operations
+)
+
+// apiKeyResponse represents response body of API key creation.
+type apiKeyResponse struct {
+	Key string `json:"key"`
+}
+
+// CreateAPIKey handles POST requests to generate a new API key of the user.
+// Returns an HTTP handler function that:
+// - Authenticates the user
+// - Generates the key, which is returned only once
+// - Returns the key with 201 status
+func (h *handler) CreateAPIKey() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err  error
+			key  string
+			user *userEntity.User
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), apiKeysTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if user, err = auth.UserOrRegister(ctx, h.userUC, r, w); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.RegisterErrStatus(err)), w)
+			return
+		}
+
+		if key, err = h.userUC.CreateAPIKey(ctx, user); err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusInternalServerError), w)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, apiKeyResponse{Key: key})
+	}
+}
+
+// RevokeAPIKey handles DELETE requests to revoke an API key of the user.
+// Returns an HTTP handler function that:
+// - Authenticates the user
+// - Revokes the key, 404 if user has no such active key
+// - Returns 204 on success
+func (h *handler) RevokeAPIKey() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err  error
+			user *userEntity.User
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), apiKeysTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if user, err = auth.UserOrRegister(ctx, h.userUC, r, w); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.RegisterErrStatus(err)), w)
+			return
+		}
+
+		if err = h.userUC.RevokeAPIKey(ctx, user, chi.URLParam(r, "key")); err != nil {
+			statusCode := http.StatusInternalServerError
+			if errors.Is(err, ucErrors.ErrUserAPIKeyNotFound) {
+				statusCode = http.StatusNotFound
+			}
+			returnErrResponse(newErrorResponse(err, statusCode), w)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
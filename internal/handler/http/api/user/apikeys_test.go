@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_APIKeys(t *testing.T) {
+	user := &userEntity.User{ID: 1}
+
+	var tests = []struct {
+		setup   func(userUC *mocks.MockUserUseCase)
+		prepare func(req *http.Request)
+		name    string
+		method  string
+		path    string
+		resp    string
+		status  int
+	}{
+		{
+			name:   "when API key is created by cookie authenticated user",
+			method: http.MethodPost,
+			path:   "/api/user/api-keys",
+			prepare: func(req *http.Request) {
+				req.AddCookie(&http.Cookie{Name: "Authorization", Value: "token"})
+			},
+			setup: func(userUC *mocks.MockUserUseCase) {
+				userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(&userEntity.User{ID: 1, AuthToken: "token"}, nil)
+				userUC.EXPECT().CreateAPIKey(gomock.Any(), &userEntity.User{ID: 1, AuthToken: "token"}).Return("key", nil)
+			},
+			status: http.StatusCreated,
+			resp:   `{"key":"key"}`,
+		},
+		{
+			name:   "when API key is created by bearer authenticated user",
+			method: http.MethodPost,
+			path:   "/api/user/api-keys",
+			prepare: func(req *http.Request) {
+				req.Header.Set("Authorization", "Bearer token")
+			},
+			setup: func(userUC *mocks.MockUserUseCase) {
+				userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(&userEntity.User{ID: 1, AuthToken: "token"}, nil)
+				userUC.EXPECT().CreateAPIKey(gomock.Any(), &userEntity.User{ID: 1, AuthToken: "token"}).Return("key", nil)
+			},
+			status: http.StatusCreated,
+			resp:   `{"key":"key"}`,
+		},
+		{
+			name:   "when API key is created by API key authenticated user",
+			method: http.MethodPost,
+			path:   "/api/user/api-keys",
+			prepare: func(req *http.Request) {
+				req.Header.Set("X-API-Key", "old")
+				req.AddCookie(&http.Cookie{Name: "Authorization", Value: "token"})
+			},
+			setup: func(userUC *mocks.MockUserUseCase) {
+				userUC.EXPECT().AuthenticateAPIKey(gomock.Any(), "old").Return(&userEntity.User{ID: 1, APIKey: "old"}, nil)
+				userUC.EXPECT().CreateAPIKey(gomock.Any(), &userEntity.User{ID: 1, APIKey: "old"}).Return("key", nil)
+			},
+			status: http.StatusCreated,
+			resp:   `{"key":"key"}`,
+		},
+		{
+			name:   "when API key is revoked or unknown",
+			method: http.MethodPost,
+			path:   "/api/user/api-keys",
+			prepare: func(req *http.Request) {
+				req.Header.Set("X-API-Key", "revoked")
+			},
+			setup: func(userUC *mocks.MockUserUseCase) {
+				userUC.EXPECT().AuthenticateAPIKey(gomock.Any(), "revoked").Return(nil, ucErrors.ErrUserInvalidAPIKey)
+			},
+			status: http.StatusUnauthorized,
+			resp:   `{"Error":"invalid API key","Code":"ERR_UNAUTHORIZED","StatusCode":401}`,
+		},
+		{
+			name:   "when API key cannot be created",
+			method: http.MethodPost,
+			path:   "/api/user/api-keys",
+			setup: func(userUC *mocks.MockUserUseCase) {
+				userUC.EXPECT().Register(gomock.Any()).Return(user, nil)
+				userUC.EXPECT().CreateAPIKey(gomock.Any(), user).Return("", ucErrors.ErrUserCannotCreateAPIKey)
+			},
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:   "when API key is revoked by its owner",
+			method: http.MethodDelete,
+			path:   "/api/user/api-keys/key",
+			prepare: func(req *http.Request) {
+				req.Header.Set("X-API-Key", "key")
+			},
+			setup: func(userUC *mocks.MockUserUseCase) {
+				userUC.EXPECT().AuthenticateAPIKey(gomock.Any(), "key").Return(&userEntity.User{ID: 1, APIKey: "key"}, nil)
+				userUC.EXPECT().RevokeAPIKey(gomock.Any(), &userEntity.User{ID: 1, APIKey: "key"}, "key").Return(nil)
+			},
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "when revoked API key is not found",
+			method: http.MethodDelete,
+			path:   "/api/user/api-keys/unknown",
+			setup: func(userUC *mocks.MockUserUseCase) {
+				userUC.EXPECT().Register(gomock.Any()).Return(user, nil)
+				userUC.EXPECT().RevokeAPIKey(gomock.Any(), user, "unknown").Return(ucErrors.ErrUserAPIKeyNotFound)
+			},
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userUC := mocks.NewMockUserUseCase(ctrl)
+
+			r := chi.NewRouter()
+			Register(r, userUC, mocks.NewMockShortURLUseCase(ctrl), mocks.NewMockTagUseCase(ctrl), mocks.NewMockWebhookUseCase(ctrl))
+			tt.setup(userUC)
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.prepare != nil {
+				tt.prepare(req)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+			if tt.resp != "" {
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, tt.resp, string(body))
+			}
+		})
+	}
+}
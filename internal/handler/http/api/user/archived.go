@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	"github.com/gururuby/shortener/internal/domain/usecase/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+)
+
+// Available constants
+const (
+	ArchivedURLsPath       = "/api/user/urls/archived"                 // Path of archived user URLs
+	ArchivedURLRestorePath = "/api/user/urls/archived/{alias}/restore" // Path of archived user URL restoration
+)
+
+// GetArchivedURLs handles GET requests to list archived URLs of the user.
+// Supports optional `page` and `per_page` query parameters.
+// Returns an HTTP handler function that:
+// - Authenticates the user
+// - Returns the requested page of archived URLs with archiving times, the most recently archived first
+func (h *handler) GetArchivedURLs() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err      error
+			page     int
+			perPage  int
+			user     *userEntity.User
+			archived *usecase.ArchivedURLs
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), getURLsTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if page, perPage, err = parsePagination(r); err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusBadRequest), w)
+			return
+		}
+
+		if user, err = auth.UserOrRegister(ctx, h.userUC, r, w); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.RegisterErrStatus(err)), w)
+			return
+		}
+
+		if archived, err = h.userUC.GetArchivedURLs(ctx, user, page, perPage); err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusInternalServerError), w)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, archived)
+	}
+}
+
+// RestoreArchivedURL handles PUT requests to move an archived URL of the user back to active URLs.
+// Returns an HTTP handler function that:
+// - Authenticates the user
+// - Restores the URL, 404 if the user has no archived URL with the alias,
+// 409 if the alias was taken by another URL after archiving
+// - Returns 200 on success
+func (h *handler) RestoreArchivedURL() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err  error
+			user *userEntity.User
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), restoreTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if user, err = auth.UserOrRegister(ctx, h.userUC, r, w); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.RegisterErrStatus(err)), w)
+			return
+		}
+
+		if err = h.userUC.RestoreArchivedURL(ctx, user, chi.URLParam(r, "alias")); err != nil {
+			returnErrResponse(newErrorResponse(err, restoreArchivedErrStatus(err)), w)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// restoreArchivedErrStatus maps archived URL restoration errors to HTTP status codes.
+// Parameters:
+// - err: Error returned by user use case
+// Returns:
+// - int: HTTP status code
+func restoreArchivedErrStatus(err error) int {
+	switch {
+	case errors.Is(err, ucErrors.ErrUserURLNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ucErrors.ErrUserURLAliasTaken):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	"github.com/gururuby/shortener/internal/domain/usecase/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_GetArchivedURLs(t *testing.T) {
+	user := &userEntity.User{ID: 1}
+	archivedAt := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		setup  func(userUC *mocks.MockUserUseCase)
+		name   string
+		query  string
+		resp   string
+		status int
+	}{
+		{
+			name:  "when page of archived URLs is requested",
+			query: "?page=2&per_page=10",
+			setup: func(userUC *mocks.MockUserUseCase) {
+				userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil)
+				userUC.EXPECT().GetArchivedURLs(gomock.Any(), user, 2, 10).Return(&usecase.ArchivedURLs{
+					Items:   []*usecase.ArchivedShortURL{{ArchivedAt: archivedAt, ShortURL: "http://localhost:8080/abc", OriginalURL: "https://ya.ru"}},
+					Page:    2,
+					PerPage: 10,
+				}, nil)
+			},
+			status: http.StatusOK,
+			resp:   `{"items":[{"archived_at":"2025-08-01T12:00:00Z","short_url":"http://localhost:8080/abc","original_url":"https://ya.ru"}],"page":2,"per_page":10}`,
+		},
+		{
+			name:   "when pagination is invalid",
+			query:  "?page=abc",
+			setup:  func(_ *mocks.MockUserUseCase) {},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "when storage is not working",
+			setup: func(userUC *mocks.MockUserUseCase) {
+				userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil)
+				userUC.EXPECT().GetArchivedURLs(gomock.Any(), user, 0, 0).Return(nil, ucErrors.ErrUserStorageNotWorking)
+			},
+			status: http.StatusInternalServerError,
+			resp:   `{"Error":"user storage is not working","Code":"ERR_INTERNAL","StatusCode":500}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userUC := mocks.NewMockUserUseCase(ctrl)
+			tt.setup(userUC)
+
+			r := chi.NewRouter()
+			Register(r, userUC, mocks.NewMockShortURLUseCase(ctrl), mocks.NewMockTagUseCase(ctrl), mocks.NewMockWebhookUseCase(ctrl))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/user/urls/archived"+tt.query, nil)
+			req.Header.Set("Authorization", "Bearer token")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+
+			if tt.resp != "" {
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, tt.resp, string(body))
+			}
+		})
+	}
+}
+
+func Test_RestoreArchivedURL(t *testing.T) {
+	user := &userEntity.User{ID: 1}
+
+	var tests = []struct {
+		err    error
+		name   string
+		resp   string
+		status int
+	}{
+		{
+			name:   "when archived URL is restored",
+			status: http.StatusOK,
+		},
+		{
+			name:   "when URL is not archived",
+			err:    ucErrors.ErrUserURLNotFound,
+			status: http.StatusNotFound,
+			resp:   `{"Error":"short URL is not found","Code":"ERR_NOT_FOUND","StatusCode":404}`,
+		},
+		{
+			name:   "when alias is taken",
+			err:    ucErrors.ErrUserURLAliasTaken,
+			status: http.StatusConflict,
+			resp:   `{"Error":"alias of short URL is taken by another short URL","Code":"ERR_CONFLICT","StatusCode":409}`,
+		},
+		{
+			name:   "when storage is not working",
+			err:    ucErrors.ErrUserStorageNotWorking,
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userUC := mocks.NewMockUserUseCase(ctrl)
+
+			r := chi.NewRouter()
+			Register(r, userUC, mocks.NewMockShortURLUseCase(ctrl), mocks.NewMockTagUseCase(ctrl), mocks.NewMockWebhookUseCase(ctrl))
+
+			userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil)
+			userUC.EXPECT().RestoreArchivedURL(gomock.Any(), user, "abc").Return(tt.err)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/user/urls/archived/abc/restore", nil)
+			req.Header.Set("Authorization", "Bearer token")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+
+			if tt.resp != "" {
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, tt.resp, string(body))
+			}
+		})
+	}
+}
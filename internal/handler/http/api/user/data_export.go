@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	"github.com/gururuby/shortener/internal/domain/usecase/user"
+	handlerErrors "github.com/gururuby/shortener/internal/handler/http/api/user/errors"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+	"github.com/gururuby/shortener/internal/infra/logger"
+)
+
+// Available constants
+const (
+	DataExportPath     = "/api/user/export" // Path of export of all user data
+	dataExportTimeout  = time.Minute * 5    // Timeout for data export operation
+	dataExportInterval = time.Hour * 24     // Minimal interval between data exports of the same user
+	profileFilename    = "profile.json"     // Name of the file with user profile in the archive
+	urlsFilename       = "urls.json"        // Name of the file with user URLs in the archive
+	clicksFilename     = "clicks.json"      // Name of the file with clicks of user URLs in the archive
+)
+
+// ExportUserData handles GET requests to export all data stored about the user.
+// The data is returned as ZIP attachment with profile.json, urls.json and clicks.json files,
+// client IPs of clicks are anonymized. Each user can export the data once per 24 hours.
+// Returns an HTTP handler function that:
+// - Authenticates the user
+// - Rejects too frequent exports with 429 and Retry-After header
+// - Streams the archive to the client without buffering it
+func (h *handler) ExportUserData() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err    error
+			errRes errorResponse
+			user   *userEntity.User
+			export *usecase.UserExport
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), dataExportTimeout)
+		defer cancel()
+
+		user, err = auth.UserOrRegister(ctx, h.userUC, r, w)
+		if err != nil {
+			errRes = newErrorResponse(err, auth.RegisterErrStatus(err))
+			returnExportError(errRes, w)
+			return
+		}
+
+		if retryAfter, ok := h.dataExports.Allow(user.ID); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			errRes = newErrorResponse(handlerErrors.ErrHandlerDataExportTooFrequent, http.StatusTooManyRequests)
+			returnExportError(errRes, w)
+			return
+		}
+
+		if export, err = h.userUC.ExportUserData(ctx, user); err != nil {
+			// Nothing is sent, the user may retry at once
+			h.dataExports.Cancel(user.ID)
+			errRes = newErrorResponse(err, http.StatusInternalServerError)
+			returnExportError(errRes, w)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"export_%d.zip\"", user.ID))
+		w.WriteHeader(http.StatusOK)
+
+		if err = writeDataExport(zip.NewWriter(w), export); err != nil {
+			// Status is already sent, abort the connection so the client sees incomplete archive
+			logger.With(r.Context()).Error(err.Error())
+			panic(http.ErrAbortHandler)
+		}
+	}
+}
+
+// writeDataExport writes user data as JSON files of ZIP archive and closes the archive.
+// Parameters:
+// - zw: ZIP writer over the response
+// - export: Data of the user
+// Returns:
+// - error: Any error that occurred during writing
+func writeDataExport(zw *zip.Writer, export *usecase.UserExport) error {
+	files := []struct {
+		data any
+		name string
+	}{
+		{name: profileFilename, data: export.Profile},
+		{name: urlsFilename, data: export.URLs},
+		{name: clicksFilename, data: export.Clicks},
+	}
+
+	modified := time.Now()
+	for _, file := range files {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: modified})
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(fw)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(file.data); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	usecase "github.com/gururuby/shortener/internal/domain/usecase/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_ExportUserData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	user := &userEntity.User{ID: 42}
+	registeredAt := time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC)
+	clickedAt := time.Date(2025, 6, 11, 10, 0, 0, 0, time.UTC)
+
+	userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil)
+	userUC.EXPECT().ExportUserData(gomock.Any(), user).Return(&usecase.UserExport{
+		Profile: &usecase.ExportedProfile{ID: 42, IsActive: true, RegisteredAt: &registeredAt},
+		URLs: []*usecase.ExportedUserURL{
+			{Alias: "abc", Namespace: "default", ShortURL: "http://localhost:8080/abc", OriginalURL: "https://ya.ru", CreatedAt: registeredAt, Clicks: 2},
+		},
+		Clicks: []*usecase.ExportedClick{
+			{Alias: "abc", ClickedAt: clickedAt, IP: "192.168.1.0", Country: "GB", City: "London"},
+			{Alias: "abc", ClickedAt: clickedAt, IP: "2001:db8:85a3::"},
+		},
+	}, nil)
+
+	r := chi.NewRouter()
+	Register(r, userUC, mocks.NewMockShortURLUseCase(ctrl), mocks.NewMockTagUseCase(ctrl), mocks.NewMockWebhookUseCase(ctrl))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/user/export", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	resp := w.Result()
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/zip", resp.Header.Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="export_42.zip"`, resp.Header.Get("Content-Disposition"))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	var names []string
+	for _, file := range archive.File {
+		names = append(names, file.Name)
+
+		rc, openErr := file.Open()
+		require.NoError(t, openErr)
+		content, readErr := io.ReadAll(rc)
+		require.NoError(t, readErr)
+		require.NoError(t, rc.Close())
+		files[file.Name] = string(content)
+	}
+
+	assert.Equal(t, []string{"profile.json", "urls.json", "clicks.json"}, names)
+	assert.JSONEq(t, `{"id":42,"is_active":true,"registered_at":"2025-05-01T08:00:00Z"}`, files["profile.json"])
+	assert.JSONEq(t, `[{"alias":"abc","namespace":"default","short_url":"http://localhost:8080/abc","original_url":"https://ya.ru","created_at":"2025-05-01T08:00:00Z","clicks":2,"is_deleted":false}]`, files["urls.json"])
+	assert.JSONEq(t, `[
+		{"alias":"abc","clicked_at":"2025-06-11T10:00:00Z","ip":"192.168.1.0","country":"GB","city":"London"},
+		{"alias":"abc","clicked_at":"2025-06-11T10:00:00Z","ip":"2001:db8:85a3::"}
+	]`, files["clicks.json"])
+}
+
+func Test_ExportUserData_RateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	now := time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC)
+	empty := &usecase.UserExport{Profile: &usecase.ExportedProfile{}, URLs: []*usecase.ExportedUserURL{}, Clicks: []*usecase.ExportedClick{}}
+
+	limiter := newExportLimiter(dataExportInterval)
+	limiter.now = func() time.Time { return now }
+	h := handler{router: chi.NewRouter(), userUC: userUC, dataExports: limiter}
+
+	export := func(userID int) *http.Response {
+		w := httptest.NewRecorder()
+		userUC.EXPECT().Register(gomock.Any()).Return(&userEntity.User{ID: userID}, nil)
+		h.ExportUserData()(w, httptest.NewRequest(http.MethodGet, "/api/user/export", nil))
+		return w.Result()
+	}
+
+	gomock.InOrder(
+		userUC.EXPECT().ExportUserData(gomock.Any(), gomock.Any()).Return(nil, ucErrors.ErrUserStorageNotWorking),
+		userUC.EXPECT().ExportUserData(gomock.Any(), gomock.Any()).Return(empty, nil).Times(3),
+	)
+
+	// Failed export does not count
+	resp := export(1)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	resp = export(1)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	now = now.Add(23 * time.Hour)
+	resp = export(1)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "3600", resp.Header.Get("Retry-After"))
+	assert.JSONEq(t, `{"Error":"only one data export per day is allowed","Code":"ERR_TOO_MANY_REQUESTS","StatusCode":429}`, string(body))
+
+	resp = export(2)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	now = now.Add(time.Hour)
+	resp = export(1)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -14,4 +14,123 @@ var (
 	// - Malformed input where aliases couldn't be parsed
 	//
 	ErrHandlerNoAliasesForDelete = errors.New("no aliases passed to delete short urls")
+
+	// ErrHandlerInvalidPagination indicates that pagination query parameters
+	// could not be parsed.
+	//
+	// Typical cases:
+	// - Non-numeric `page` or `per_page` value: `?page=first`
+	// - Values overflowing int
+	//
+	ErrHandlerInvalidPagination = errors.New("page and per_page must be integers")
+
+	// ErrHandlerInvalidLimit indicates that the `limit` query parameter
+	// of cursor pagination could not be parsed.
+	//
+	// Typical cases:
+	// - Non-numeric value: `?limit=all`
+	//
+	ErrHandlerInvalidLimit = errors.New("limit must be integer")
+
+	// ErrHandlerEmptySearchQuery indicates that search of user URLs was requested
+	// without text to search.
+	//
+	// Typical cases:
+	// - Missing `q` query parameter: `/api/user/urls/search`
+	// - Blank value: `?q=%20`
+	//
+	ErrHandlerEmptySearchQuery = errors.New("search query must not be empty")
+
+	// ErrHandlerNoAuthToken indicates a request requiring an existing session
+	// was made without auth token in header or cookie.
+	//
+	// Typical cases:
+	// - DELETE /api/user/session without `Authorization` header or cookie
+	//
+	ErrHandlerNoAuthToken = errors.New("auth token is not passed")
+
+	// ErrHandlerImportNoFile indicates that CSV import request has no file to import.
+	//
+	// Typical cases:
+	// - Request body is not `multipart/form-data`
+	// - Multipart form without `file` field
+	//
+	ErrHandlerImportNoFile = errors.New("CSV file is not passed in file field")
+
+	// ErrHandlerImportEmptyFile indicates that imported CSV file has no header row.
+	//
+	// Typical cases:
+	// - Uploaded file of zero size
+	//
+	ErrHandlerImportEmptyFile = errors.New("CSV file is empty")
+
+	// ErrHandlerImportMalformedCSV indicates that imported file could not be parsed as CSV.
+	// Nothing is imported in this case.
+	//
+	// Typical cases:
+	// - Unterminated quoted field: `"https://example.com`
+	// - Bare quote inside unquoted field
+	//
+	ErrHandlerImportMalformedCSV = errors.New("malformed CSV file")
+
+	// ErrHandlerImportNoURLColumn indicates that header of imported CSV file
+	// has no `original_url` column.
+	//
+	// Typical cases:
+	// - File without header row: `https://example.com`
+	// - Misspelled column name: `url`
+	//
+	ErrHandlerImportNoURLColumn = errors.New("CSV header has no original_url column")
+
+	// ErrHandlerImportTooManyRows indicates that imported CSV file exceeds
+	// the limit of 10 000 data rows. Nothing is imported in this case.
+	//
+	// Typical cases:
+	// - Export of the whole database uploaded at once
+	//
+	ErrHandlerImportTooManyRows = errors.New("CSV file has more than 10000 rows")
+
+	// ErrHandlerImportInvalidExpiresIn indicates that a row of imported CSV file
+	// has lifetime of the short URL which is not a positive number of seconds.
+	//
+	// Typical cases:
+	// - Non-numeric value: `1h`
+	// - Zero or negative value
+	//
+	ErrHandlerImportInvalidExpiresIn = errors.New("expires_in_seconds must be positive integer")
+
+	// ErrHandlerInvalidExportFormat indicates that export was requested
+	// in unsupported format.
+	//
+	// Typical cases:
+	// - Missing `format` query parameter
+	// - Unknown format: `?format=xml`
+	//
+	ErrHandlerInvalidExportFormat = errors.New("format must be csv or json")
+
+	// ErrHandlerExportTooFrequent indicates that the user has already started
+	// an export within the last minute.
+	//
+	// Typical cases:
+	// - Repeated clicks on export button
+	// - Script polling export endpoint
+	//
+	ErrHandlerExportTooFrequent = errors.New("only one export per minute is allowed")
+
+	// ErrHandlerDataExportTooFrequent indicates that the user has already
+	// exported their data within the last 24 hours.
+	//
+	// Typical cases:
+	// - Repeated requests of data export
+	// - Script downloading data export periodically
+	//
+	ErrHandlerDataExportTooFrequent = errors.New("only one data export per day is allowed")
+
+	// ErrHandlerInvalidWebhookID indicates that webhook ID in the request path
+	// is not a positive integer.
+	//
+	// Typical cases:
+	// - Non-numeric ID: `/api/user/webhooks/first`
+	//
+	ErrHandlerInvalidWebhookID = errors.New("webhook id must be a positive integer")
 )
@@ -0,0 +1,325 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	"github.com/gururuby/shortener/internal/domain/usecase/user"
+	handlerErrors "github.com/gururuby/shortener/internal/handler/http/api/user/errors"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+	"github.com/gururuby/shortener/internal/infra/logger"
+)
+
+// Available constants
+const (
+	ExportPath        = "/api/user/urls/export" // Path of user URLs export
+	exportTimeout     = time.Minute * 5         // Timeout for export operation
+	exportInterval    = time.Minute             // Minimal interval between exports of the same user
+	formatParam       = "format"                // Query parameter with requested export format
+	csvFormat         = "csv"                   // CSV export format
+	jsonFormat        = "json"                  // JSON export format
+	csvExportFilename = "urls_export.csv"       // Name of the exported CSV file
+)
+
+// csvExportHeader is the header row of exported CSV file.
+var csvExportHeader = []string{"short_url", "original_url", "created_at", "is_deleted", "clicks"}
+
+// exportLimiter allows one export per user within the interval.
+type exportLimiter struct {
+	now      func() time.Time  // Current time source, replaced in tests
+	started  map[int]time.Time // Start time of the last export by user ID
+	interval time.Duration     // Minimal interval between exports of the same user
+	mu       sync.Mutex
+}
+
+// newExportLimiter creates limiter allowing one export per user within the interval.
+// Parameters:
+// - interval: Minimal interval between exports of the same user
+// Returns:
+// - *exportLimiter: Initialized limiter
+func newExportLimiter(interval time.Duration) *exportLimiter {
+	return &exportLimiter{now: time.Now, started: make(map[int]time.Time), interval: interval}
+}
+
+// Allow registers export of the user if the previous one started long enough ago.
+// Parameters:
+// - userID: ID of the exporting user
+// Returns:
+// - time.Duration: Time left until the next export is allowed, 0 if allowed
+// - bool: Whether the export is allowed
+func (l *exportLimiter) Allow(userID int) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	for id, started := range l.started {
+		if now.Sub(started) >= l.interval {
+			delete(l.started, id)
+		}
+	}
+
+	if started, ok := l.started[userID]; ok {
+		return l.interval - now.Sub(started), false
+	}
+
+	l.started[userID] = now
+
+	return 0, true
+}
+
+// Cancel forgets the export of the user registered by Allow, so the user may retry at once.
+// Parameters:
+// - userID: ID of the user whose export failed
+func (l *exportLimiter) Cancel(userID int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.started, userID)
+}
+
+// ExportURLs handles GET requests to export all user's shortened URLs.
+// Format is selected by `format` query parameter, `csv` or `json`.
+// CSV is returned as attachment with `short_url,original_url,created_at,is_deleted,clicks` columns,
+// JSON as an array. Each user can start one export per minute.
+// Returns an HTTP handler function that:
+// - Authenticates the user
+// - Rejects unknown formats and too frequent exports
+// - Streams URLs to the client batch by batch as they are read from storage
+func (h *handler) ExportURLs() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err    error
+			errRes errorResponse
+			user   *userEntity.User
+			writer exportWriter
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), exportTimeout)
+		defer cancel()
+
+		if r.Method != http.MethodGet {
+			errRes.Error = fmt.Sprintf("HTTP method %s is not allowed", r.Method)
+			errRes.StatusCode = http.StatusMethodNotAllowed
+			returnExportError(errRes, w)
+			return
+		}
+
+		switch r.URL.Query().Get(formatParam) {
+		case csvFormat:
+			writer = newCSVExportWriter(w)
+		case jsonFormat:
+			writer = newJSONExportWriter(w)
+		default:
+			errRes = newErrorResponse(handlerErrors.ErrHandlerInvalidExportFormat, http.StatusBadRequest)
+			returnExportError(errRes, w)
+			return
+		}
+
+		user, err = auth.UserOrRegister(ctx, h.userUC, r, w)
+		if err != nil {
+			errRes = newErrorResponse(err, auth.RegisterErrStatus(err))
+			returnExportError(errRes, w)
+			return
+		}
+
+		if retryAfter, ok := h.exports.Allow(user.ID); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			errRes = newErrorResponse(handlerErrors.ErrHandlerExportTooFrequent, http.StatusTooManyRequests)
+			returnExportError(errRes, w)
+			return
+		}
+
+		err = h.userUC.ExportURLs(ctx, user, writer.WriteBatch)
+		if err != nil && !writer.Started() {
+			errRes = newErrorResponse(err, http.StatusInternalServerError)
+			returnExportError(errRes, w)
+			return
+		}
+		if err != nil {
+			// Status is already sent, abort the connection so the client sees incomplete export
+			logger.With(r.Context()).Error(err.Error())
+			panic(http.ErrAbortHandler)
+		}
+
+		if err = writer.Close(); err != nil {
+			logger.With(r.Context()).Error(err.Error())
+		}
+	}
+}
+
+// returnExportError writes JSON error response, export errors are always returned as JSON.
+// Parameters:
+// - errRes: Error response
+// - w: HTTP response writer
+func returnExportError(errRes errorResponse, w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	returnErrResponse(errRes, w)
+}
+
+// exportWriter writes exported URLs to the response in particular format.
+type exportWriter interface {
+	// WriteBatch writes URLs and flushes them to the client, response headers are sent with the first batch
+	WriteBatch(urls []*usecase.ExportedURL) error
+	// Close finishes the export, sending headers if no URLs were written
+	Close() error
+	// Started reports whether response headers are already sent
+	Started() bool
+}
+
+// streamWriter sends response headers once and flushes written data to the client.
+type streamWriter struct {
+	w       http.ResponseWriter
+	header  func(h http.Header)
+	started bool
+}
+
+// start sends response headers if they are not sent yet.
+func (s *streamWriter) start() {
+	if s.started {
+		return
+	}
+	s.started = true
+	s.header(s.w.Header())
+	s.w.WriteHeader(http.StatusOK)
+}
+
+// flush sends buffered data to the client.
+func (s *streamWriter) flush() {
+	if flusher, ok := s.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Started reports whether response headers are already sent.
+func (s *streamWriter) Started() bool {
+	return s.started
+}
+
+// csvExportWriter writes exported URLs as CSV attachment.
+type csvExportWriter struct {
+	streamWriter
+	csv *csv.Writer
+}
+
+// newCSVExportWriter creates CSV export writer.
+// Parameters:
+// - w: HTTP response writer
+// Returns:
+// - *csvExportWriter: Writer sending CSV attachment
+func newCSVExportWriter(w http.ResponseWriter) *csvExportWriter {
+	return &csvExportWriter{
+		streamWriter: streamWriter{w: w, header: func(h http.Header) {
+			h.Set("Content-Type", "text/csv")
+			h.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", csvExportFilename))
+		}},
+		csv: csv.NewWriter(w),
+	}
+}
+
+// WriteBatch writes URLs as CSV rows, the header row is written with the first batch.
+func (c *csvExportWriter) WriteBatch(urls []*usecase.ExportedURL) error {
+	if !c.started {
+		c.start()
+		if err := c.csv.Write(csvExportHeader); err != nil {
+			return err
+		}
+	}
+
+	for _, url := range urls {
+		err := c.csv.Write([]string{
+			url.ShortURL,
+			url.OriginalURL,
+			url.CreatedAt.UTC().Format(time.RFC3339),
+			strconv.FormatBool(url.IsDeleted),
+			strconv.FormatInt(url.Clicks, 10),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	c.csv.Flush()
+	c.flush()
+
+	return c.csv.Error()
+}
+
+// Close writes the header row if the user has no URLs.
+func (c *csvExportWriter) Close() error {
+	if c.started {
+		return nil
+	}
+	return c.WriteBatch(nil)
+}
+
+// jsonExportWriter writes exported URLs as JSON array.
+type jsonExportWriter struct {
+	streamWriter
+	encoder *json.Encoder
+	written int
+}
+
+// newJSONExportWriter creates JSON export writer.
+// Parameters:
+// - w: HTTP response writer
+// Returns:
+// - *jsonExportWriter: Writer sending JSON array
+func newJSONExportWriter(w http.ResponseWriter) *jsonExportWriter {
+	return &jsonExportWriter{
+		streamWriter: streamWriter{w: w, header: func(h http.Header) {
+			h.Set("Content-Type", "application/json")
+		}},
+		encoder: json.NewEncoder(w),
+	}
+}
+
+// WriteBatch writes URLs as JSON array elements, the array is opened with the first batch.
+func (j *jsonExportWriter) WriteBatch(urls []*usecase.ExportedURL) error {
+	if err := j.open(); err != nil {
+		return err
+	}
+
+	for _, url := range urls {
+		if j.written > 0 {
+			if _, err := j.w.Write([]byte(",")); err != nil {
+				return err
+			}
+		}
+		if err := j.encoder.Encode(url); err != nil {
+			return err
+		}
+		j.written++
+	}
+
+	j.flush()
+
+	return nil
+}
+
+// Close closes the JSON array.
+func (j *jsonExportWriter) Close() error {
+	if err := j.open(); err != nil {
+		return err
+	}
+	_, err := j.w.Write([]byte("]"))
+	return err
+}
+
+// open sends response headers and opens the JSON array if it is not opened yet.
+func (j *jsonExportWriter) open() error {
+	if j.started {
+		return nil
+	}
+	j.start()
+	_, err := j.w.Write([]byte("["))
+	return err
+}
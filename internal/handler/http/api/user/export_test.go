@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	usecase "github.com/gururuby/shortener/internal/domain/usecase/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_ExportURLs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	user := &userEntity.User{ID: 1}
+	createdAt := time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC)
+
+	urls := []*usecase.ExportedURL{
+		{ShortURL: "http://localhost/first", OriginalURL: "https://ya.ru", CreatedAt: createdAt},
+		{ShortURL: "http://localhost/second", OriginalURL: "https://google.com?q=a,b", CreatedAt: createdAt, IsDeleted: true},
+	}
+
+	var tests = []struct {
+		ucErr       error
+		urls        []*usecase.ExportedURL
+		name        string
+		path        string
+		contentType string
+		disposition string
+		body        string
+		status      int
+	}{
+		{
+			name:        "when export to CSV",
+			path:        "/api/user/urls/export?format=csv",
+			urls:        urls,
+			status:      http.StatusOK,
+			contentType: "text/csv",
+			disposition: `attachment; filename="urls_export.csv"`,
+			body: "short_url,original_url,created_at,is_deleted,clicks\n" +
+				"http://localhost/first,https://ya.ru,2025-06-10T09:00:00Z,false,0\n" +
+				"http://localhost/second,\"https://google.com?q=a,b\",2025-06-10T09:00:00Z,true,0\n",
+		},
+		{
+			name:        "when export empty list to CSV",
+			path:        "/api/user/urls/export?format=csv",
+			status:      http.StatusOK,
+			contentType: "text/csv",
+			disposition: `attachment; filename="urls_export.csv"`,
+			body:        "short_url,original_url,created_at,is_deleted,clicks\n",
+		},
+		{
+			name:        "when export to JSON",
+			path:        "/api/user/urls/export?format=json",
+			urls:        urls,
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body: `[{"created_at":"2025-06-10T09:00:00Z","short_url":"http://localhost/first","original_url":"https://ya.ru","clicks":0,"is_deleted":false},
+				{"created_at":"2025-06-10T09:00:00Z","short_url":"http://localhost/second","original_url":"https://google.com?q=a,b","clicks":0,"is_deleted":true}]`,
+		},
+		{
+			name:        "when export empty list to JSON",
+			path:        "/api/user/urls/export?format=json",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body:        `[]`,
+		},
+		{
+			name:        "when storage fails",
+			path:        "/api/user/urls/export?format=json",
+			ucErr:       ucErrors.ErrUserStorageNotWorking,
+			status:      http.StatusInternalServerError,
+			contentType: "application/json",
+			body:        `{"Error":"user storage is not working","Code":"ERR_INTERNAL","StatusCode":500}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handler{router: chi.NewRouter(), userUC: userUC, exports: newExportLimiter(exportInterval)}
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			userUC.EXPECT().Register(gomock.Any()).Return(user, nil)
+			userUC.EXPECT().ExportURLs(gomock.Any(), user, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ *userEntity.User, fn func([]*usecase.ExportedURL) error) error {
+					if tt.ucErr != nil {
+						return tt.ucErr
+					}
+					for _, url := range tt.urls {
+						if err := fn([]*usecase.ExportedURL{url}); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+
+			h.ExportURLs()(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, tt.contentType, resp.Header.Get("Content-Type"))
+			assert.Equal(t, tt.disposition, resp.Header.Get("Content-Disposition"))
+			if tt.contentType == "application/json" {
+				assert.JSONEq(t, tt.body, string(body))
+			} else {
+				assert.Equal(t, tt.body, string(body))
+			}
+		})
+	}
+}
+
+func Test_ExportURLs_InvalidFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	h := handler{router: chi.NewRouter(), userUC: userUC, exports: newExportLimiter(exportInterval)}
+
+	for _, path := range []string{"/api/user/urls/export", "/api/user/urls/export?format=xml"} {
+		t.Run(path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ExportURLs()(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			assert.JSONEq(t, `{"Error":"format must be csv or json","Code":"ERR_INVALID_REQUEST","StatusCode":400}`, string(body))
+		})
+	}
+}
+
+func Test_ExportURLs_Streaming(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	user := &userEntity.User{ID: 1}
+	h := handler{router: chi.NewRouter(), userUC: userUC, exports: newExportLimiter(exportInterval)}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/user/urls/export?format=csv", nil)
+	w := httptest.NewRecorder()
+
+	userUC.EXPECT().Register(gomock.Any()).Return(user, nil)
+	userUC.EXPECT().ExportURLs(gomock.Any(), user, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *userEntity.User, fn func([]*usecase.ExportedURL) error) error {
+			require.NoError(t, fn([]*usecase.ExportedURL{{ShortURL: "http://localhost/first", OriginalURL: "https://ya.ru"}}))
+
+			// The second batch is not fetched yet, but the first one must already reach the client
+			assert.True(t, w.Flushed)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Contains(t, w.Body.String(), "http://localhost/first")
+
+			return fn([]*usecase.ExportedURL{{ShortURL: "http://localhost/second", OriginalURL: "https://google.com"}})
+		})
+
+	h.ExportURLs()(w, req)
+
+	assert.Contains(t, w.Body.String(), "http://localhost/second")
+}
+
+func Test_ExportURLs_RateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	now := time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC)
+
+	limiter := newExportLimiter(exportInterval)
+	limiter.now = func() time.Time { return now }
+	h := handler{router: chi.NewRouter(), userUC: userUC, exports: limiter}
+
+	export := func(userID int) *http.Response {
+		w := httptest.NewRecorder()
+		userUC.EXPECT().Register(gomock.Any()).Return(&userEntity.User{ID: userID}, nil)
+		h.ExportURLs()(w, httptest.NewRequest(http.MethodGet, "/api/user/urls/export?format=json", nil))
+		return w.Result()
+	}
+
+	userUC.EXPECT().ExportURLs(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(3)
+
+	resp := export(1)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	now = now.Add(30 * time.Second)
+	resp = export(1)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "30", resp.Header.Get("Retry-After"))
+
+	resp = export(2)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	now = now.Add(30 * time.Second)
+	resp = export(1)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
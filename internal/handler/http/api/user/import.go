@@ -0,0 +1,293 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	shortURLErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+	handlerErrors "github.com/gururuby/shortener/internal/handler/http/api/user/errors"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+	"github.com/gururuby/shortener/pkg/validator"
+)
+
+// Available constants
+const (
+	ImportPath        = "/api/user/urls/import" // Path of CSV import of user URLs
+	importTimeout     = time.Minute * 5         // Timeout for CSV import operation
+	importFileField   = "file"                  // Multipart form field with CSV file
+	importMaxRows     = 10000                   // Maximum number of data rows in imported CSV
+	originalURLColumn = "original_url"          // CSV column with URL to shorten
+	customAliasColumn = "custom_alias"          // Optional CSV column with requested alias
+	expiresInColumn   = "expires_in_seconds"    // Optional CSV column with requested lifetime
+)
+
+// importRow represents a CSV row accepted for import.
+type importRow struct {
+	url       string        // URL to shorten
+	alias     string        // Custom alias, generated if empty
+	expiresIn time.Duration // Lifetime of the short URL, zero if it never expires
+	line      int           // Line of the row in CSV file
+}
+
+// createOptions returns options of the short URL created for the row.
+// Parameters:
+// - now: Time of the import the lifetime is counted from
+// Returns:
+// - shortURLEntity.CreateOptions: Custom alias and expiration time of the row
+func (row importRow) createOptions(now time.Time) shortURLEntity.CreateOptions {
+	opts := shortURLEntity.CreateOptions{Alias: row.alias}
+	if row.expiresIn > 0 {
+		opts.ExpiresAt = now.Add(row.expiresIn)
+	}
+	return opts
+}
+
+// ImportFailure describes a CSV row which was not imported.
+type ImportFailure struct {
+	URL    string `json:"url"`
+	Reason string `json:"reason"`
+	Row    int    `json:"row"`
+}
+
+// ImportResult represents response of CSV import.
+type ImportResult struct {
+	Failed   []ImportFailure `json:"failed"`
+	Imported int             `json:"imported"`
+}
+
+// ImportURLs handles POST requests to import user URLs from CSV file.
+// The file is passed in `file` field of multipart form. Its first line is a header
+// with `original_url` column and optional `custom_alias` and `expires_in_seconds` columns.
+// Returns an HTTP handler function that:
+// - Authenticates the user
+// - Streams and validates CSV rows, nothing is imported if the file is malformed,
+// has more than 10 000 rows or valid rows exceed the quota of short URLs of the user
+// - Creates short URL for each valid row, with the custom alias and the lifetime if they are set
+// - Returns number of imported rows and failed rows with reasons
+func (h *handler) ImportURLs() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err    error
+			errRes errorResponse
+			user   *userEntity.User
+			file   *multipart.Part
+			rows   []importRow
+			result ImportResult
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), importTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method != http.MethodPost {
+			errRes.Error = fmt.Sprintf("HTTP method %s is not allowed", r.Method)
+			errRes.StatusCode = http.StatusMethodNotAllowed
+			returnErrResponse(errRes, w)
+			return
+		}
+
+		user, err = auth.UserOrRegister(ctx, h.userUC, r, w)
+		if err != nil {
+			errRes = newErrorResponse(err, auth.RegisterErrStatus(err))
+			returnErrResponse(errRes, w)
+			return
+		}
+
+		if file, err = importFile(r); err != nil {
+			errRes = newErrorResponse(err, http.StatusBadRequest)
+			returnErrResponse(errRes, w)
+			return
+		}
+
+		rows, result.Failed, err = readImportRows(file)
+		if err != nil {
+			errRes = newErrorResponse(err, http.StatusBadRequest)
+			if errors.Is(err, handlerErrors.ErrHandlerImportTooManyRows) {
+				errRes.StatusCode = http.StatusUnprocessableEntity
+			}
+			returnErrResponse(errRes, w)
+			return
+		}
+
+		if err = h.urlUC.CheckURLQuota(ctx, user, len(rows)); err != nil {
+			errRes = newErrorResponse(err, http.StatusInternalServerError)
+			if errors.Is(err, shortURLErrors.ErrUserURLQuotaExceeded) {
+				errRes.StatusCode = http.StatusTooManyRequests
+			}
+			returnErrResponse(errRes, w)
+			return
+		}
+
+		now := time.Now()
+		for _, row := range rows {
+			if _, err = h.urlUC.CreateShortURL(ctx, user, row.url, row.createOptions(now)); err != nil {
+				result.Failed = append(result.Failed, ImportFailure{Row: row.line, URL: row.url, Reason: err.Error()})
+				continue
+			}
+			result.Imported++
+		}
+
+		if result.Failed == nil {
+			result.Failed = []ImportFailure{}
+		}
+
+		response, err := json.Marshal(result)
+		if err != nil {
+			errRes = newErrorResponse(err, http.StatusInternalServerError)
+			returnErrResponse(errRes, w)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err = w.Write(response); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
+// importFile finds the CSV file part in multipart request body without buffering the body.
+// Parameters:
+// - r: HTTP request
+// Returns:
+// - *multipart.Part: Reader of the file content
+// - error: handlerErrors.ErrHandlerImportNoFile if request is not multipart or has no file field
+func importFile(r *http.Request) (*multipart.Part, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		return nil, handlerErrors.ErrHandlerImportNoFile
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, handlerErrors.ErrHandlerImportNoFile
+	}
+
+	for {
+		part, partErr := reader.NextPart()
+		if partErr != nil {
+			return nil, handlerErrors.ErrHandlerImportNoFile
+		}
+
+		if part.FormName() == importFileField {
+			return part, nil
+		}
+	}
+}
+
+// readImportRows reads CSV rows one by one and validates them.
+// Parameters:
+// - file: CSV file content
+// Returns:
+// - []importRow: Rows to import
+// - []ImportFailure: Rows rejected by validation
+// - error: If file is empty, malformed, has no original_url column or too many rows
+func readImportRows(file io.Reader) ([]importRow, []ImportFailure, error) {
+	var (
+		rows   []importRow
+		failed []ImportFailure
+	)
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, handlerErrors.ErrHandlerImportEmptyFile
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", handlerErrors.ErrHandlerImportMalformedCSV, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+
+	if _, ok := columns[originalURLColumn]; !ok {
+		return nil, nil, handlerErrors.ErrHandlerImportNoURLColumn
+	}
+
+	for count := 1; ; count++ {
+		record, readErr := reader.Read()
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return nil, nil, fmt.Errorf("%w: %w", handlerErrors.ErrHandlerImportMalformedCSV, readErr)
+		}
+
+		if count > importMaxRows {
+			return nil, nil, handlerErrors.ErrHandlerImportTooManyRows
+		}
+
+		line, _ := reader.FieldPos(0)
+		row, rowErr := parseImportRow(record, columns)
+		row.line = line
+
+		if rowErr != nil {
+			failed = append(failed, ImportFailure{Row: line, URL: row.url, Reason: rowErr.Error()})
+			continue
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, failed, nil
+}
+
+// parseImportRow validates CSV row and reads its columns.
+// Parameters:
+// - record: CSV row
+// - columns: Column indexes by name
+// Returns:
+// - importRow: Row to import, without line
+// - error: Reason why row cannot be imported
+func parseImportRow(record []string, columns map[string]int) (importRow, error) {
+	row := importRow{url: column(record, columns[originalURLColumn])}
+
+	if validator.IsInvalidURL(row.url) {
+		return row, shortURLErrors.ErrShortURLInvalidSourceURL
+	}
+
+	if i, ok := columns[customAliasColumn]; ok {
+		row.alias = column(record, i)
+	}
+
+	if i, ok := columns[expiresInColumn]; ok && column(record, i) != "" {
+		seconds, err := strconv.Atoi(column(record, i))
+		if err != nil || seconds <= 0 {
+			return row, handlerErrors.ErrHandlerImportInvalidExpiresIn
+		}
+		row.expiresIn = time.Duration(seconds) * time.Second
+	}
+
+	return row, nil
+}
+
+// column returns trimmed value of CSV row column.
+// Parameters:
+// - record: CSV row
+// - i: Column index
+// Returns:
+// - string: Column value, empty if row is shorter
+func column(record []string, i int) string {
+	if i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	shortURLUseCase "github.com/gururuby/shortener/internal/domain/usecase/shorturl"
+	shortURLErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// multipartCSV builds multipart form body with CSV file in the passed field.
+func multipartCSV(t *testing.T, field, content string) (*bytes.Buffer, string) {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile(field, "urls.csv")
+	require.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	return body, writer.FormDataContentType()
+}
+
+// importOptions matches options of the short URL created for imported row.
+// Expiration time is counted from the import, so it is compared with a tolerance.
+func importOptions(alias string, expiresIn time.Duration) gomock.Matcher {
+	return gomock.Cond(func(opts shortURLEntity.CreateOptions) bool {
+		if opts.Alias != alias {
+			return false
+		}
+		if expiresIn == 0 {
+			return opts.ExpiresAt.IsZero()
+		}
+		lifetime := time.Until(opts.ExpiresAt)
+		return lifetime > expiresIn-time.Minute && lifetime <= expiresIn
+	})
+}
+
+func Test_ImportURLs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	user := &userEntity.User{ID: 1}
+
+	r := chi.NewRouter()
+	h := handler{router: r, userUC: userUC, urlUC: urlUC}
+
+	tooManyRows := "original_url\n" + strings.Repeat("https://example.com\n", importMaxRows+1)
+
+	var tests = []struct {
+		ucErrors  map[string]error
+		aliases   map[string]string
+		expiresIn map[string]time.Duration
+		quotaErr  error
+		name      string
+		field     string
+		csv       string
+		body      string
+		created   []string
+		quotaRows int
+		status    int
+	}{
+		{
+			name:      "when all rows are imported",
+			field:     "file",
+			csv:       "original_url\nhttps://example.com/1\nhttps://example.com/2\n",
+			status:    http.StatusOK,
+			created:   []string{"https://example.com/1", "https://example.com/2"},
+			quotaRows: 2,
+			body:      `{"imported":2,"failed":[]}`,
+		},
+		{
+			name:      "when rows exceed the quota",
+			field:     "file",
+			csv:       "original_url\nhttps://example.com/1\nnot a url\nhttps://example.com/2\n",
+			status:    http.StatusTooManyRequests,
+			quotaRows: 2,
+			quotaErr:  &shortURLUseCase.QuotaExceededError{Limit: 5, Current: 4},
+			body:      `{"Error":"URL quota exceeded","Code":"ERR_LIMIT_EXCEEDED","StatusCode":429}`,
+		},
+		{
+			name:      "when rows have custom alias and lifetime",
+			field:     "file",
+			csv:       "original_url,custom_alias,expires_in_seconds\nhttps://example.com/1,my-alias,\nhttps://example.com/2,,3600\nhttps://example.com/3,promo,60\n",
+			status:    http.StatusOK,
+			created:   []string{"https://example.com/1", "https://example.com/2", "https://example.com/3"},
+			quotaRows: 3,
+			aliases: map[string]string{
+				"https://example.com/1": "my-alias",
+				"https://example.com/3": "promo",
+			},
+			expiresIn: map[string]time.Duration{
+				"https://example.com/2": time.Hour,
+				"https://example.com/3": time.Minute,
+			},
+			body: `{"imported":3,"failed":[]}`,
+		},
+		{
+			name:      "when some rows failed",
+			field:     "file",
+			csv:       "original_url,custom_alias,expires_in_seconds\nhttps://example.com/1,,\nnot a url,,\nhttps://example.com/2,taken,\nhttps://example.com/3,,soon\nhttps://example.com/4,,\nhttps://example.com/5,,-60\n",
+			status:    http.StatusOK,
+			created:   []string{"https://example.com/1", "https://example.com/2", "https://example.com/4"},
+			quotaRows: 3,
+			aliases: map[string]string{
+				"https://example.com/2": "taken",
+			},
+			ucErrors: map[string]error{
+				"https://example.com/2": shortURLErrors.ErrShortURLAliasTaken,
+				"https://example.com/4": shortURLErrors.ErrShortURLAlreadyExist,
+			},
+			body: `{"imported":1,"failed":[
+				{"row":3,"url":"not a url","reason":"invalid source URL, please specify valid URL"},
+				{"row":5,"url":"https://example.com/3","reason":"expires_in_seconds must be positive integer"},
+				{"row":7,"url":"https://example.com/5","reason":"expires_in_seconds must be positive integer"},
+				{"row":4,"url":"https://example.com/2","reason":"alias is already taken"},
+				{"row":6,"url":"https://example.com/4","reason":"short URL already exist"}
+			]}`,
+		},
+		{
+			name:   "when file is empty",
+			field:  "file",
+			csv:    "",
+			status: http.StatusBadRequest,
+			body:   `{"Error":"CSV file is empty","Code":"ERR_INVALID_REQUEST","StatusCode":400}`,
+		},
+		{
+			name:   "when CSV is malformed",
+			field:  "file",
+			csv:    "original_url\n\"https://example.com/1\n",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "when header has no original_url column",
+			field:  "file",
+			csv:    "url\nhttps://example.com/1\n",
+			status: http.StatusBadRequest,
+			body:   `{"Error":"CSV header has no original_url column","Code":"ERR_INVALID_REQUEST","StatusCode":400}`,
+		},
+		{
+			name:   "when file field is missing",
+			field:  "document",
+			csv:    "original_url\nhttps://example.com/1\n",
+			status: http.StatusBadRequest,
+			body:   `{"Error":"CSV file is not passed in file field","Code":"ERR_INVALID_REQUEST","StatusCode":400}`,
+		},
+		{
+			name:   "when file has too many rows",
+			field:  "file",
+			csv:    tooManyRows,
+			status: http.StatusUnprocessableEntity,
+			body:   `{"Error":"CSV file has more than 10000 rows","Code":"ERR_VALIDATION","StatusCode":422}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqBody, contentType := multipartCSV(t, tt.field, tt.csv)
+			req := httptest.NewRequest(http.MethodPost, ImportPath, reqBody)
+			req.Header.Set("Content-Type", contentType)
+			w := httptest.NewRecorder()
+
+			userUC.EXPECT().Register(gomock.Any()).Return(user, nil)
+			if tt.quotaRows > 0 {
+				urlUC.EXPECT().CheckURLQuota(gomock.Any(), user, tt.quotaRows).Return(tt.quotaErr)
+			}
+			for _, sourceURL := range tt.created {
+				urlUC.EXPECT().CreateShortURL(gomock.Any(), user, sourceURL, importOptions(tt.aliases[sourceURL], tt.expiresIn[sourceURL])).
+					Return("http://localhost/alias", tt.ucErrors[sourceURL])
+			}
+
+			h.ImportURLs()(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			if tt.body != "" {
+				assert.JSONEq(t, tt.body, string(body))
+			}
+		})
+	}
+}
+
+func Test_ImportURLs_NotMultipart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+
+	h := handler{router: chi.NewRouter(), userUC: userUC, urlUC: urlUC}
+
+	req := httptest.NewRequest(http.MethodPost, ImportPath, strings.NewReader("original_url\nhttps://example.com\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+
+	userUC.EXPECT().Register(gomock.Any()).Return(&userEntity.User{ID: 1}, nil)
+	h.ImportURLs()(w, req)
+
+	resp := w.Result()
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/gururuby/shortener/internal/handler/http/api/user (interfaces: UserUseCase)
+// Source: github.com/gururuby/shortener/internal/handler/http/api/user (interfaces: UserUseCase,ShortURLUseCase,TagUseCase,WebhookUseCase)
 //
 // Generated by this command:
 //
-//	mockgen -destination=./mocks/mock.go -package=mocks . UserUseCase
+//	mockgen -destination=./mocks/mock.go -package=mocks . UserUseCase,ShortURLUseCase,TagUseCase,WebhookUseCase
 //
 
 // Package mocks is a generated GoMock package.
@@ -13,16 +13,20 @@ import (
 	context "context"
 	reflect "reflect"
 
-	entity "github.com/gururuby/shortener/internal/domain/entity/user"
-	usecase "github.com/gururuby/shortener/internal/domain/usecase/user"
+	entity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	entity0 "github.com/gururuby/shortener/internal/domain/entity/tag"
+	entity1 "github.com/gururuby/shortener/internal/domain/entity/user"
+	entity2 "github.com/gururuby/shortener/internal/domain/entity/webhook"
+	usecase "github.com/gururuby/shortener/internal/domain/usecase/tag"
+	usecase0 "github.com/gururuby/shortener/internal/domain/usecase/user"
 	gomock "go.uber.org/mock/gomock"
 )
 
 // MockUserUseCase is a mock of UserUseCase interface.
 type MockUserUseCase struct {
-	isgomock struct{}
 	ctrl     *gomock.Controller
 	recorder *MockUserUseCaseMockRecorder
+	isgomock struct{}
 }
 
 // MockUserUseCaseMockRecorder is the mock recorder for MockUserUseCase.
@@ -43,10 +47,10 @@ func (m *MockUserUseCase) EXPECT() *MockUserUseCaseMockRecorder {
 }
 
 // Authenticate mocks base method.
-func (m *MockUserUseCase) Authenticate(ctx context.Context, token string) (*entity.User, error) {
+func (m *MockUserUseCase) Authenticate(ctx context.Context, token string) (*entity1.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Authenticate", ctx, token)
-	ret0, _ := ret[0].(*entity.User)
+	ret0, _ := ret[0].(*entity1.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -57,8 +61,38 @@ func (mr *MockUserUseCaseMockRecorder) Authenticate(ctx, token any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockUserUseCase)(nil).Authenticate), ctx, token)
 }
 
+// AuthenticateAPIKey mocks base method.
+func (m *MockUserUseCase) AuthenticateAPIKey(ctx context.Context, key string) (*entity1.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", ctx, key)
+	ret0, _ := ret[0].(*entity1.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockUserUseCaseMockRecorder) AuthenticateAPIKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockUserUseCase)(nil).AuthenticateAPIKey), ctx, key)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockUserUseCase) CreateAPIKey(ctx context.Context, user *entity1.User) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, user)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockUserUseCaseMockRecorder) CreateAPIKey(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockUserUseCase)(nil).CreateAPIKey), ctx, user)
+}
+
 // DeleteURLs mocks base method.
-func (m *MockUserUseCase) DeleteURLs(ctx context.Context, user *entity.User, aliases []string) {
+func (m *MockUserUseCase) DeleteURLs(ctx context.Context, user *entity1.User, aliases []string) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "DeleteURLs", ctx, user, aliases)
 }
@@ -69,26 +103,100 @@ func (mr *MockUserUseCaseMockRecorder) DeleteURLs(ctx, user, aliases any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteURLs", reflect.TypeOf((*MockUserUseCase)(nil).DeleteURLs), ctx, user, aliases)
 }
 
-// GetURLs mocks base method.
-func (m *MockUserUseCase) GetURLs(ctx context.Context, user *entity.User) ([]*usecase.UserShortURL, error) {
+// ExportURLs mocks base method.
+func (m *MockUserUseCase) ExportURLs(ctx context.Context, user *entity1.User, fn func([]*usecase0.ExportedURL) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportURLs", ctx, user, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportURLs indicates an expected call of ExportURLs.
+func (mr *MockUserUseCaseMockRecorder) ExportURLs(ctx, user, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportURLs", reflect.TypeOf((*MockUserUseCase)(nil).ExportURLs), ctx, user, fn)
+}
+
+// ExportUserData mocks base method.
+func (m *MockUserUseCase) ExportUserData(ctx context.Context, user *entity1.User) (*usecase0.UserExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUserData", ctx, user)
+	ret0, _ := ret[0].(*usecase0.UserExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUserData indicates an expected call of ExportUserData.
+func (mr *MockUserUseCaseMockRecorder) ExportUserData(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUserData", reflect.TypeOf((*MockUserUseCase)(nil).ExportUserData), ctx, user)
+}
+
+// GetArchivedURLs mocks base method.
+func (m *MockUserUseCase) GetArchivedURLs(ctx context.Context, user *entity1.User, page, perPage int) (*usecase0.ArchivedURLs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedURLs", ctx, user, page, perPage)
+	ret0, _ := ret[0].(*usecase0.ArchivedURLs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedURLs indicates an expected call of GetArchivedURLs.
+func (mr *MockUserUseCaseMockRecorder) GetArchivedURLs(ctx, user, page, perPage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedURLs", reflect.TypeOf((*MockUserUseCase)(nil).GetArchivedURLs), ctx, user, page, perPage)
+}
+
+// GetURLHistory mocks base method.
+func (m *MockUserUseCase) GetURLHistory(ctx context.Context, user *entity1.User, alias string) ([]*entity.HistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetURLHistory", ctx, user, alias)
+	ret0, _ := ret[0].([]*entity.HistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetURLHistory indicates an expected call of GetURLHistory.
+func (mr *MockUserUseCaseMockRecorder) GetURLHistory(ctx, user, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLHistory", reflect.TypeOf((*MockUserUseCase)(nil).GetURLHistory), ctx, user, alias)
+}
+
+// GetURLsCursor mocks base method.
+func (m *MockUserUseCase) GetURLsCursor(ctx context.Context, user *entity1.User, cursor, limit int) (*usecase0.CursorPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetURLsCursor", ctx, user, cursor, limit)
+	ret0, _ := ret[0].(*usecase0.CursorPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetURLsCursor indicates an expected call of GetURLsCursor.
+func (mr *MockUserUseCaseMockRecorder) GetURLsCursor(ctx, user, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLsCursor", reflect.TypeOf((*MockUserUseCase)(nil).GetURLsCursor), ctx, user, cursor, limit)
+}
+
+// GetURLsPaginated mocks base method.
+func (m *MockUserUseCase) GetURLsPaginated(ctx context.Context, user *entity1.User, page, perPage int) (*usecase0.PaginatedURLs, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetURLs", ctx, user)
-	ret0, _ := ret[0].([]*usecase.UserShortURL)
+	ret := m.ctrl.Call(m, "GetURLsPaginated", ctx, user, page, perPage)
+	ret0, _ := ret[0].(*usecase0.PaginatedURLs)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetURLs indicates an expected call of GetURLs.
-func (mr *MockUserUseCaseMockRecorder) GetURLs(ctx, user any) *gomock.Call {
+// GetURLsPaginated indicates an expected call of GetURLsPaginated.
+func (mr *MockUserUseCaseMockRecorder) GetURLsPaginated(ctx, user, page, perPage any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLs", reflect.TypeOf((*MockUserUseCase)(nil).GetURLs), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLsPaginated", reflect.TypeOf((*MockUserUseCase)(nil).GetURLsPaginated), ctx, user, page, perPage)
 }
 
 // Register mocks base method.
-func (m *MockUserUseCase) Register(ctx context.Context) (*entity.User, error) {
+func (m *MockUserUseCase) Register(ctx context.Context) (*entity1.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Register", ctx)
-	ret0, _ := ret[0].(*entity.User)
+	ret0, _ := ret[0].(*entity1.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -98,3 +206,334 @@ func (mr *MockUserUseCaseMockRecorder) Register(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUserUseCase)(nil).Register), ctx)
 }
+
+// RestoreArchivedURL mocks base method.
+func (m *MockUserUseCase) RestoreArchivedURL(ctx context.Context, user *entity1.User, alias string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreArchivedURL", ctx, user, alias)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreArchivedURL indicates an expected call of RestoreArchivedURL.
+func (mr *MockUserUseCaseMockRecorder) RestoreArchivedURL(ctx, user, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreArchivedURL", reflect.TypeOf((*MockUserUseCase)(nil).RestoreArchivedURL), ctx, user, alias)
+}
+
+// RestoreURL mocks base method.
+func (m *MockUserUseCase) RestoreURL(ctx context.Context, user *entity1.User, alias string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreURL", ctx, user, alias)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreURL indicates an expected call of RestoreURL.
+func (mr *MockUserUseCaseMockRecorder) RestoreURL(ctx, user, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreURL", reflect.TypeOf((*MockUserUseCase)(nil).RestoreURL), ctx, user, alias)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockUserUseCase) RevokeAPIKey(ctx context.Context, user *entity1.User, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, user, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockUserUseCaseMockRecorder) RevokeAPIKey(ctx, user, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockUserUseCase)(nil).RevokeAPIKey), ctx, user, key)
+}
+
+// RevokeToken mocks base method.
+func (m *MockUserUseCase) RevokeToken(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockUserUseCaseMockRecorder) RevokeToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockUserUseCase)(nil).RevokeToken), ctx, token)
+}
+
+// SearchURLs mocks base method.
+func (m *MockUserUseCase) SearchURLs(ctx context.Context, user *entity1.User, query string, page, perPage int) (*usecase0.PaginatedURLs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchURLs", ctx, user, query, page, perPage)
+	ret0, _ := ret[0].(*usecase0.PaginatedURLs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchURLs indicates an expected call of SearchURLs.
+func (mr *MockUserUseCaseMockRecorder) SearchURLs(ctx, user, query, page, perPage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchURLs", reflect.TypeOf((*MockUserUseCase)(nil).SearchURLs), ctx, user, query, page, perPage)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockUserUseCase) UpdateProfile(ctx context.Context, user *entity1.User, profile usecase0.UserProfile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, user, profile)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockUserUseCaseMockRecorder) UpdateProfile(ctx, user, profile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockUserUseCase)(nil).UpdateProfile), ctx, user, profile)
+}
+
+// UpdateURL mocks base method.
+func (m *MockUserUseCase) UpdateURL(ctx context.Context, user *entity1.User, alias, newURL string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateURL", ctx, user, alias, newURL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateURL indicates an expected call of UpdateURL.
+func (mr *MockUserUseCaseMockRecorder) UpdateURL(ctx, user, alias, newURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateURL", reflect.TypeOf((*MockUserUseCase)(nil).UpdateURL), ctx, user, alias, newURL)
+}
+
+// MockShortURLUseCase is a mock of ShortURLUseCase interface.
+type MockShortURLUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockShortURLUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockShortURLUseCaseMockRecorder is the mock recorder for MockShortURLUseCase.
+type MockShortURLUseCaseMockRecorder struct {
+	mock *MockShortURLUseCase
+}
+
+// NewMockShortURLUseCase creates a new mock instance.
+func NewMockShortURLUseCase(ctrl *gomock.Controller) *MockShortURLUseCase {
+	mock := &MockShortURLUseCase{ctrl: ctrl}
+	mock.recorder = &MockShortURLUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShortURLUseCase) EXPECT() *MockShortURLUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CheckURLQuota mocks base method.
+func (m *MockShortURLUseCase) CheckURLQuota(ctx context.Context, user *entity1.User, count int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckURLQuota", ctx, user, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckURLQuota indicates an expected call of CheckURLQuota.
+func (mr *MockShortURLUseCaseMockRecorder) CheckURLQuota(ctx, user, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckURLQuota", reflect.TypeOf((*MockShortURLUseCase)(nil).CheckURLQuota), ctx, user, count)
+}
+
+// CreateShortURL mocks base method.
+func (m *MockShortURLUseCase) CreateShortURL(ctx context.Context, user *entity1.User, sourceURL string, opts entity.CreateOptions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShortURL", ctx, user, sourceURL, opts)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShortURL indicates an expected call of CreateShortURL.
+func (mr *MockShortURLUseCaseMockRecorder) CreateShortURL(ctx, user, sourceURL, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShortURL", reflect.TypeOf((*MockShortURLUseCase)(nil).CreateShortURL), ctx, user, sourceURL, opts)
+}
+
+// URLQuota mocks base method.
+func (m *MockShortURLUseCase) URLQuota(ctx context.Context, user *entity1.User) (*entity.URLQuota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "URLQuota", ctx, user)
+	ret0, _ := ret[0].(*entity.URLQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// URLQuota indicates an expected call of URLQuota.
+func (mr *MockShortURLUseCaseMockRecorder) URLQuota(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URLQuota", reflect.TypeOf((*MockShortURLUseCase)(nil).URLQuota), ctx, user)
+}
+
+// ValidateSourceURL mocks base method.
+func (m *MockShortURLUseCase) ValidateSourceURL(ctx context.Context, sourceURL string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateSourceURL", ctx, sourceURL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateSourceURL indicates an expected call of ValidateSourceURL.
+func (mr *MockShortURLUseCaseMockRecorder) ValidateSourceURL(ctx, sourceURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateSourceURL", reflect.TypeOf((*MockShortURLUseCase)(nil).ValidateSourceURL), ctx, sourceURL)
+}
+
+// MockTagUseCase is a mock of TagUseCase interface.
+type MockTagUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockTagUseCaseMockRecorder is the mock recorder for MockTagUseCase.
+type MockTagUseCaseMockRecorder struct {
+	mock *MockTagUseCase
+}
+
+// NewMockTagUseCase creates a new mock instance.
+func NewMockTagUseCase(ctrl *gomock.Controller) *MockTagUseCase {
+	mock := &MockTagUseCase{ctrl: ctrl}
+	mock.recorder = &MockTagUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagUseCase) EXPECT() *MockTagUseCaseMockRecorder {
+	return m.recorder
+}
+
+// AssignTag mocks base method.
+func (m *MockTagUseCase) AssignTag(ctx context.Context, user *entity1.User, alias, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignTag", ctx, user, alias, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignTag indicates an expected call of AssignTag.
+func (mr *MockTagUseCaseMockRecorder) AssignTag(ctx, user, alias, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTag", reflect.TypeOf((*MockTagUseCase)(nil).AssignTag), ctx, user, alias, name)
+}
+
+// CreateTag mocks base method.
+func (m *MockTagUseCase) CreateTag(ctx context.Context, user *entity1.User, name string) (*entity0.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTag", ctx, user, name)
+	ret0, _ := ret[0].(*entity0.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockTagUseCaseMockRecorder) CreateTag(ctx, user, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockTagUseCase)(nil).CreateTag), ctx, user, name)
+}
+
+// GetTags mocks base method.
+func (m *MockTagUseCase) GetTags(ctx context.Context, user *entity1.User) ([]*entity0.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", ctx, user)
+	ret0, _ := ret[0].([]*entity0.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockTagUseCaseMockRecorder) GetTags(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockTagUseCase)(nil).GetTags), ctx, user)
+}
+
+// GetURLsByTag mocks base method.
+func (m *MockTagUseCase) GetURLsByTag(ctx context.Context, user *entity1.User, name string) (*usecase.TaggedURLs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetURLsByTag", ctx, user, name)
+	ret0, _ := ret[0].(*usecase.TaggedURLs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetURLsByTag indicates an expected call of GetURLsByTag.
+func (mr *MockTagUseCaseMockRecorder) GetURLsByTag(ctx, user, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLsByTag", reflect.TypeOf((*MockTagUseCase)(nil).GetURLsByTag), ctx, user, name)
+}
+
+// RemoveTag mocks base method.
+func (m *MockTagUseCase) RemoveTag(ctx context.Context, user *entity1.User, alias, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTag", ctx, user, alias, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTag indicates an expected call of RemoveTag.
+func (mr *MockTagUseCaseMockRecorder) RemoveTag(ctx, user, alias, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTag", reflect.TypeOf((*MockTagUseCase)(nil).RemoveTag), ctx, user, alias, name)
+}
+
+// MockWebhookUseCase is a mock of WebhookUseCase interface.
+type MockWebhookUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookUseCaseMockRecorder is the mock recorder for MockWebhookUseCase.
+type MockWebhookUseCaseMockRecorder struct {
+	mock *MockWebhookUseCase
+}
+
+// NewMockWebhookUseCase creates a new mock instance.
+func NewMockWebhookUseCase(ctrl *gomock.Controller) *MockWebhookUseCase {
+	mock := &MockWebhookUseCase{ctrl: ctrl}
+	mock.recorder = &MockWebhookUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookUseCase) EXPECT() *MockWebhookUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Register mocks base method.
+func (m *MockWebhookUseCase) Register(ctx context.Context, user *entity1.User, url string, events []string) (*entity2.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, user, url, events)
+	ret0, _ := ret[0].(*entity2.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockWebhookUseCaseMockRecorder) Register(ctx, user, url, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockWebhookUseCase)(nil).Register), ctx, user, url, events)
+}
+
+// Unregister mocks base method.
+func (m *MockWebhookUseCase) Unregister(ctx context.Context, user *entity1.User, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unregister", ctx, user, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unregister indicates an expected call of Unregister.
+func (mr *MockWebhookUseCaseMockRecorder) Unregister(ctx, user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unregister", reflect.TypeOf((*MockWebhookUseCase)(nil).Unregister), ctx, user, id)
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	usecase "github.com/gururuby/shortener/internal/domain/usecase/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+)
+
+// Available constants
+const (
+	ProfilePath    = "/api/user/profile" // Path of settings of the current user
+	profileTimeout = time.Second * 5     // Timeout for profile update
+)
+
+// updateProfileRequest represents request body of profile update.
+type updateProfileRequest struct {
+	CustomDomain string `json:"custom_domain"` // HTTPS base URL of short URLs of the user, empty to remove it
+}
+
+// UpdateProfile handles PATCH requests to change settings of the current user.
+// Returns an HTTP handler function that:
+// - Authenticates the user
+// - Updates the profile, 422 if custom domain is not an HTTPS URL without path or is the base URL,
+// 409 if it is claimed by another user
+// - Returns the updated profile with custom domain in the stored form
+func (h *handler) UpdateProfile() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err  error
+			req  updateProfileRequest
+			user *userEntity.User
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), profileTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if user, err = auth.UserOrRegister(ctx, h.userUC, r, w); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.RegisterErrStatus(err)), w)
+			return
+		}
+
+		if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusBadRequest), w)
+			return
+		}
+
+		if err = h.userUC.UpdateProfile(ctx, user, usecase.UserProfile{CustomDomain: req.CustomDomain}); err != nil {
+			returnErrResponse(newErrorResponse(err, profileErrStatus(err)), w)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, usecase.UserProfile{CustomDomain: user.CustomDomain})
+	}
+}
+
+// profileErrStatus maps profile update errors to HTTP status codes.
+// Parameters:
+// - err: Error returned by the use case
+// Returns:
+// - int: HTTP status code
+func profileErrStatus(err error) int {
+	switch {
+	case errors.Is(err, ucErrors.ErrUserInvalidCustomDomain):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ucErrors.ErrUserCustomDomainTaken):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
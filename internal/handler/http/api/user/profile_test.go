@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	usecase "github.com/gururuby/shortener/internal/domain/usecase/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_UpdateProfile(t *testing.T) {
+	var tests = []struct {
+		ucErr  error
+		name   string
+		body   string
+		resp   string
+		status int
+	}{
+		{
+			name:   "when custom domain is set",
+			body:   `{"custom_domain":"https://Short.acme.com"}`,
+			status: http.StatusOK,
+			resp:   `{"custom_domain":"https://short.acme.com"}`,
+		},
+		{
+			name:   "when body is malformed",
+			body:   `{"custom_domain":`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "when custom domain is invalid",
+			body:   `{"custom_domain":"https://Short.acme.com"}`,
+			ucErr:  ucErrors.ErrUserInvalidCustomDomain,
+			status: http.StatusUnprocessableEntity,
+			resp:   `{"Error":"custom domain must be an HTTPS URL without path, other than the base URL","Code":"ERR_VALIDATION","StatusCode":422}`,
+		},
+		{
+			name:   "when custom domain is claimed by another user",
+			body:   `{"custom_domain":"https://Short.acme.com"}`,
+			ucErr:  ucErrors.ErrUserCustomDomainTaken,
+			status: http.StatusConflict,
+			resp:   `{"Error":"custom domain is claimed by another user","Code":"ERR_CONFLICT","StatusCode":409}`,
+		},
+		{
+			name:   "when storage is not working",
+			body:   `{"custom_domain":"https://Short.acme.com"}`,
+			ucErr:  ucErrors.ErrUserStorageNotWorking,
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userUC := mocks.NewMockUserUseCase(ctrl)
+			user := &userEntity.User{ID: 1}
+
+			r := chi.NewRouter()
+			Register(r, userUC, mocks.NewMockShortURLUseCase(ctrl), mocks.NewMockTagUseCase(ctrl), mocks.NewMockWebhookUseCase(ctrl))
+
+			userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil)
+			if tt.status != http.StatusBadRequest {
+				userUC.EXPECT().UpdateProfile(gomock.Any(), user, usecase.UserProfile{CustomDomain: "https://Short.acme.com"}).
+					DoAndReturn(func(_ context.Context, user *userEntity.User, _ usecase.UserProfile) error {
+						if tt.ucErr == nil {
+							user.CustomDomain = "https://short.acme.com"
+						}
+						return tt.ucErr
+					})
+			}
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/user/profile", strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer token")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+
+			if tt.resp != "" {
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, tt.resp, string(body))
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+)
+
+// Available constants
+const (
+	QuotaPath    = "/api/user/quota" // Path of the quota of user URLs
+	quotaTimeout = time.Second * 5   // Timeout for quota lookup
+)
+
+// GetQuota handles GET requests to get usage of the quota of short URLs of the user.
+// Returns an HTTP handler function that:
+// - Authenticates the user
+// - Returns the limit, the number of created and remaining short URLs,
+// limit and remaining are 0 if URLs are not limited
+func (h *handler) GetQuota() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err   error
+			user  *userEntity.User
+			quota *shortURLEntity.URLQuota
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), quotaTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if user, err = auth.UserOrRegister(ctx, h.userUC, r, w); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.RegisterErrStatus(err)), w)
+			return
+		}
+
+		if quota, err = h.urlUC.URLQuota(ctx, user); err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusInternalServerError), w)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, quota)
+	}
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	"github.com/gururuby/shortener/internal/handler/http/api/user/mocks"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_GetQuota(t *testing.T) {
+	user := &userEntity.User{ID: 1}
+
+	var tests = []struct {
+		setup  func(urlUC *mocks.MockShortURLUseCase)
+		name   string
+		resp   string
+		status int
+	}{
+		{
+			name: "when quota is requested",
+			setup: func(urlUC *mocks.MockShortURLUseCase) {
+				urlUC.EXPECT().URLQuota(gomock.Any(), user).Return(&shortURLEntity.URLQuota{Limit: 10000, Used: 42, Remaining: 9958}, nil)
+			},
+			status: http.StatusOK,
+			resp:   `{"limit":10000,"used":42,"remaining":9958}`,
+		},
+		{
+			name: "when URLs cannot be counted",
+			setup: func(urlUC *mocks.MockShortURLUseCase) {
+				urlUC.EXPECT().URLQuota(gomock.Any(), user).Return(nil, dbErrors.ErrDBQuery)
+			},
+			status: http.StatusInternalServerError,
+			resp:   `{"Error":"query to db is failed","Code":"ERR_INTERNAL","StatusCode":500}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userUC := mocks.NewMockUserUseCase(ctrl)
+			urlUC := mocks.NewMockShortURLUseCase(ctrl)
+			userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil)
+			tt.setup(urlUC)
+
+			r := chi.NewRouter()
+			Register(r, userUC, urlUC, mocks.NewMockTagUseCase(ctrl), mocks.NewMockWebhookUseCase(ctrl))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/user/quota", nil)
+			req.Header.Set("Authorization", "Bearer token")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.resp, string(body))
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+)
+
+// Available constants
+const (
+	RestorePath    = "/api/user/urls/{alias}/restore" // Path of deleted user URL restoration
+	restoreTimeout = time.Second * 5                  // Timeout for URL restoration
+)
+
+// RestoreURL handles PUT requests to restore a soft-deleted URL of the user.
+// Returns an HTTP handler function that:
+// - Authenticates the user
+// - Restores the URL in the namespace from X-Namespace header, 404 if alias does not exist, 403 if URL belongs to another user,
+// 409 if URL is not deleted
+// - Returns 200 on success
+func (h *handler) RestoreURL() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err  error
+			user *userEntity.User
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), restoreTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if user, err = auth.UserOrRegister(ctx, h.userUC, r, w); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.RegisterErrStatus(err)), w)
+			return
+		}
+
+		if err = h.userUC.RestoreURL(ctx, withNamespace(user, r), chi.URLParam(r, "alias")); err != nil {
+			returnErrResponse(newErrorResponse(err, restoreErrStatus(err)), w)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// restoreErrStatus maps URL restoration errors to HTTP status codes.
+// Parameters:
+// - err: Error returned by user use case
+// Returns:
+// - int: HTTP status code
+func restoreErrStatus(err error) int {
+	switch {
+	case errors.Is(err, ucErrors.ErrUserURLNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ucErrors.ErrUserURLForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ucErrors.ErrUserURLNotDeleted):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
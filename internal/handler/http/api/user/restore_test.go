@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_RestoreURL(t *testing.T) {
+	user := &userEntity.User{ID: 1}
+
+	var tests = []struct {
+		err    error
+		name   string
+		resp   string
+		status int
+	}{
+		{
+			name:   "when URL is restored",
+			status: http.StatusOK,
+		},
+		{
+			name:   "when URL belongs to another user",
+			err:    ucErrors.ErrUserURLForbidden,
+			status: http.StatusForbidden,
+			resp:   `{"Error":"short URL belongs to another user","Code":"ERR_FORBIDDEN","StatusCode":403}`,
+		},
+		{
+			name:   "when alias does not exist",
+			err:    ucErrors.ErrUserURLNotFound,
+			status: http.StatusNotFound,
+			resp:   `{"Error":"short URL is not found","Code":"ERR_NOT_FOUND","StatusCode":404}`,
+		},
+		{
+			name:   "when URL is not deleted",
+			err:    ucErrors.ErrUserURLNotDeleted,
+			status: http.StatusConflict,
+			resp:   `{"Error":"short URL is not deleted","Code":"ERR_CONFLICT","StatusCode":409}`,
+		},
+		{
+			name:   "when storage is not working",
+			err:    ucErrors.ErrUserStorageNotWorking,
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userUC := mocks.NewMockUserUseCase(ctrl)
+
+			r := chi.NewRouter()
+			Register(r, userUC, mocks.NewMockShortURLUseCase(ctrl), mocks.NewMockTagUseCase(ctrl), mocks.NewMockWebhookUseCase(ctrl))
+
+			userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil)
+			userUC.EXPECT().RestoreURL(gomock.Any(), user, "abc").Return(tt.err)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/user/urls/abc/restore", nil)
+			req.Header.Set("Authorization", "Bearer token")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+
+			if tt.resp != "" {
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, tt.resp, string(body))
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	"github.com/gururuby/shortener/internal/domain/usecase/user"
+	handlerErrors "github.com/gururuby/shortener/internal/handler/http/api/user/errors"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+)
+
+// Available constants
+const (
+	SearchPath  = "/api/user/urls/search" // Path of search of user URLs
+	searchParam = "q"                     // Query parameter with text to find in original URLs
+)
+
+// SearchURLs handles GET requests to find URLs of the user by original URL.
+// Requires `q` query parameter, supports optional `page` and `per_page` query parameters.
+// Returns an HTTP handler function that:
+// - Validates the query, 400 if it is empty or pagination is invalid
+// - Authenticates the user
+// - Returns the requested page of URLs whose original URL contains the query ignoring case,
+// 204 if there are none
+func (h *handler) SearchURLs() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err     error
+			page    int
+			perPage int
+			user    *userEntity.User
+			found   *usecase.PaginatedURLs
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), getURLsTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		query := strings.TrimSpace(r.URL.Query().Get(searchParam))
+		if query == "" {
+			returnErrResponse(newErrorResponse(handlerErrors.ErrHandlerEmptySearchQuery, http.StatusBadRequest), w)
+			return
+		}
+
+		if page, perPage, err = parsePagination(r); err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusBadRequest), w)
+			return
+		}
+
+		if user, err = auth.UserOrRegister(ctx, h.userUC, r, w); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.RegisterErrStatus(err)), w)
+			return
+		}
+
+		if found, err = h.userUC.SearchURLs(ctx, user, query, page, perPage); err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusInternalServerError), w)
+			return
+		}
+
+		if len(found.Items) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, found)
+	}
+}
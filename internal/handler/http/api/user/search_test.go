@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	"github.com/gururuby/shortener/internal/domain/usecase/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_SearchURLs(t *testing.T) {
+	user := &userEntity.User{ID: 1}
+
+	var tests = []struct {
+		setup  func(userUC *mocks.MockUserUseCase)
+		name   string
+		query  string
+		resp   string
+		status int
+	}{
+		{
+			name:  "when URLs are found",
+			query: "?q=GitHub.com&page=2&per_page=10",
+			setup: func(userUC *mocks.MockUserUseCase) {
+				userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil)
+				userUC.EXPECT().SearchURLs(gomock.Any(), user, "GitHub.com", 2, 10).Return(&usecase.PaginatedURLs{
+					Items:      []*usecase.UserShortURL{{ShortURL: "http://localhost:8080/abc", OriginalURL: "https://github.com/gururuby"}},
+					Total:      11,
+					Page:       2,
+					TotalPages: 2,
+				}, nil)
+			},
+			status: http.StatusOK,
+			resp:   `{"items":[{"short_url":"http://localhost:8080/abc","original_url":"https://github.com/gururuby"}],"total":11,"page":2,"total_pages":2}`,
+		},
+		{
+			name:  "when URLs are not found",
+			query: "?q=gitlab",
+			setup: func(userUC *mocks.MockUserUseCase) {
+				userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil)
+				userUC.EXPECT().SearchURLs(gomock.Any(), user, "gitlab", 0, 0).Return(&usecase.PaginatedURLs{Items: []*usecase.UserShortURL{}}, nil)
+			},
+			status: http.StatusNoContent,
+		},
+		{
+			name:  "when query is SQL injection attempt",
+			query: "?q=%25%27%20OR%20%271%27%3D%271",
+			setup: func(userUC *mocks.MockUserUseCase) {
+				userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil)
+				userUC.EXPECT().SearchURLs(gomock.Any(), user, "%' OR '1'='1", 0, 0).Return(&usecase.PaginatedURLs{Items: []*usecase.UserShortURL{}}, nil)
+			},
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "when query is not passed",
+			setup:  func(_ *mocks.MockUserUseCase) {},
+			status: http.StatusBadRequest,
+			resp:   `{"Error":"search query must not be empty","Code":"ERR_INVALID_REQUEST","StatusCode":400}`,
+		},
+		{
+			name:   "when query is blank",
+			query:  "?q=%20%20",
+			setup:  func(_ *mocks.MockUserUseCase) {},
+			status: http.StatusBadRequest,
+			resp:   `{"Error":"search query must not be empty","Code":"ERR_INVALID_REQUEST","StatusCode":400}`,
+		},
+		{
+			name:   "when pagination is invalid",
+			query:  "?q=github&page=abc",
+			setup:  func(_ *mocks.MockUserUseCase) {},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:  "when storage is not working",
+			query: "?q=github",
+			setup: func(userUC *mocks.MockUserUseCase) {
+				userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil)
+				userUC.EXPECT().SearchURLs(gomock.Any(), user, "github", 0, 0).Return(nil, ucErrors.ErrUserStorageNotWorking)
+			},
+			status: http.StatusInternalServerError,
+			resp:   `{"Error":"user storage is not working","Code":"ERR_INTERNAL","StatusCode":500}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userUC := mocks.NewMockUserUseCase(ctrl)
+			tt.setup(userUC)
+
+			r := chi.NewRouter()
+			Register(r, userUC, mocks.NewMockShortURLUseCase(ctrl), mocks.NewMockTagUseCase(ctrl), mocks.NewMockWebhookUseCase(ctrl))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/user/urls/search"+tt.query, nil)
+			req.Header.Set("Authorization", "Bearer token")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+
+			if tt.resp != "" {
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, tt.resp, string(body))
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	tagEntity "github.com/gururuby/shortener/internal/domain/entity/tag"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	tagErrors "github.com/gururuby/shortener/internal/domain/usecase/tag/errors"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+)
+
+// Available constants
+const (
+	TagsPath    = "/api/user/tags"                    // Path of user tags
+	URLTagsPath = "/api/user/urls/{alias}/tags"       // Path of tags assigned to user URL
+	URLTagPath  = "/api/user/urls/{alias}/tags/{tag}" // Path of single tag assigned to user URL
+	tagsTimeout = time.Second * 5                     // Timeout for tag operations
+	tagParam    = "tag"                               // Query parameter with tag to filter user URLs by
+)
+
+// createTagRequest represents request body of tag creation.
+type createTagRequest struct {
+	Name string `json:"name"`
+}
+
+// assignTagRequest represents request body of tag assignment.
+type assignTagRequest struct {
+	Tag string `json:"tag"`
+}
+
+// GetTags handles GET requests to list tags of the user.
+// Returns an HTTP handler function that:
+// - Authenticates the user
+// - Returns user's tags ordered by name, 204 if there are none
+func (h *handler) GetTags() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err  error
+			user *userEntity.User
+			tags []*tagEntity.Tag
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), tagsTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if user, err = auth.UserOrRegister(ctx, h.userUC, r, w); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.RegisterErrStatus(err)), w)
+			return
+		}
+
+		if tags, err = h.tagUC.GetTags(ctx, user); err != nil {
+			returnErrResponse(newErrorResponse(err, tagErrStatus(err)), w)
+			return
+		}
+
+		if len(tags) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, tags)
+	}
+}
+
+// CreateTag handles POST requests to create a tag of the user.
+// Request body is a JSON object with tag `name`.
+// Returns an HTTP handler function that:
+// - Authenticates the user
+// - Creates the tag, 422 if name is invalid or user has 100 tags already
+// - Returns the created tag with 201 status, 409 if user already has such tag
+func (h *handler) CreateTag() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err  error
+			req  createTagRequest
+			user *userEntity.User
+			tag  *tagEntity.Tag
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), tagsTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if user, err = auth.UserOrRegister(ctx, h.userUC, r, w); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.RegisterErrStatus(err)), w)
+			return
+		}
+
+		if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusBadRequest), w)
+			return
+		}
+
+		if tag, err = h.tagUC.CreateTag(ctx, user, req.Name); err != nil {
+			returnErrResponse(newErrorResponse(err, tagErrStatus(err)), w)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, tag)
+	}
+}
+
+// AssignTag handles POST requests to assign an existing tag to the user URL.
+// Request body is a JSON object with `tag` name.
+// Returns an HTTP handler function that:
+// - Authenticates the user
+// - Assigns the tag to the URL in the namespace from X-Namespace header, 422 if URL has 20 tags already, 404 if URL or tag is not found
+// - Returns 204 on success, also when the tag is already assigned
+func (h *handler) AssignTag() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err  error
+			req  assignTagRequest
+			user *userEntity.User
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), tagsTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if user, err = auth.UserOrRegister(ctx, h.userUC, r, w); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.RegisterErrStatus(err)), w)
+			return
+		}
+
+		if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusBadRequest), w)
+			return
+		}
+
+		if err = h.tagUC.AssignTag(ctx, withNamespace(user, r), chi.URLParam(r, "alias"), req.Tag); err != nil {
+			returnErrResponse(newErrorResponse(err, tagErrStatus(err)), w)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// RemoveTag handles DELETE requests to remove a tag from the user URL.
+// Returns an HTTP handler function that:
+// - Authenticates the user
+// - Removes the tag from the URL in the namespace from X-Namespace header, 404 if URL is not found or tag is not assigned to it
+// - Returns 204 on success
+func (h *handler) RemoveTag() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err  error
+			name string
+			user *userEntity.User
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), tagsTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if user, err = auth.UserOrRegister(ctx, h.userUC, r, w); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.RegisterErrStatus(err)), w)
+			return
+		}
+
+		// Router matches the escaped path if it differs from the default encoding,
+		// then the tag name comes encoded
+		name = chi.URLParam(r, "tag")
+		if r.URL.RawPath != "" {
+			if name, err = url.PathUnescape(name); err != nil {
+				returnErrResponse(newErrorResponse(err, http.StatusBadRequest), w)
+				return
+			}
+		}
+
+		if err = h.tagUC.RemoveTag(ctx, withNamespace(user, r), chi.URLParam(r, "alias"), name); err != nil {
+			returnErrResponse(newErrorResponse(err, tagErrStatus(err)), w)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// getURLsByTag writes all URLs of the user having the tag passed in `tag` query parameter.
+// Parameters:
+// - ctx: Context for cancellation/timeout
+// - w: HTTP response writer
+// - user: Authenticated user
+// - name: Tag name
+func (h *handler) getURLsByTag(ctx context.Context, w http.ResponseWriter, user *userEntity.User, name string) {
+	urls, err := h.tagUC.GetURLsByTag(ctx, user, name)
+	if err != nil {
+		returnErrResponse(newErrorResponse(err, tagErrStatus(err)), w)
+		return
+	}
+
+	if len(urls.Items) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, urls)
+}
+
+// tagErrStatus maps tag use case errors to HTTP status codes.
+// Parameters:
+// - err: Error returned by tag use case
+// Returns:
+// - int: HTTP status code
+func tagErrStatus(err error) int {
+	switch {
+	case errors.Is(err, tagErrors.ErrTagInvalidName),
+		errors.Is(err, tagErrors.ErrTagUserLimitExceeded),
+		errors.Is(err, tagErrors.ErrTagURLLimitExceeded):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, tagErrors.ErrTagAlreadyExist):
+		return http.StatusConflict
+	case errors.Is(err, tagErrors.ErrTagNotFound), errors.Is(err, tagErrors.ErrTagURLNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeJSON writes a JSON response with the status code.
+// Parameters:
+// - w: HTTP response writer
+// - statusCode: HTTP status code
+// - v: Value to encode
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	response, err := json.Marshal(v)
+	if err != nil {
+		returnErrResponse(newErrorResponse(err, http.StatusInternalServerError), w)
+		return
+	}
+
+	w.WriteHeader(statusCode)
+
+	if _, err = w.Write(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
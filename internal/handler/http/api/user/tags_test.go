@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	tagEntity "github.com/gururuby/shortener/internal/domain/entity/tag"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	tagUseCase "github.com/gururuby/shortener/internal/domain/usecase/tag"
+	tagErrors "github.com/gururuby/shortener/internal/domain/usecase/tag/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Tags(t *testing.T) {
+	user := &userEntity.User{ID: 1}
+
+	var tests = []struct {
+		setup  func(tagUC *mocks.MockTagUseCase)
+		name   string
+		method string
+		path   string
+		body   string
+		resp   string
+		status int
+	}{
+		{
+			name:   "when tag is created",
+			method: http.MethodPost,
+			path:   "/api/user/tags",
+			body:   `{"name":"work"}`,
+			setup: func(tagUC *mocks.MockTagUseCase) {
+				tagUC.EXPECT().CreateTag(gomock.Any(), user, "work").Return(&tagEntity.Tag{ID: 3, UserID: 1, Name: "work"}, nil)
+			},
+			status: http.StatusCreated,
+			resp:   `{"id":3,"name":"work"}`,
+		},
+		{
+			name:   "when tag creation body is malformed",
+			method: http.MethodPost,
+			path:   "/api/user/tags",
+			body:   `{"name":`,
+			setup:  func(_ *mocks.MockTagUseCase) {},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "when user has too many tags",
+			method: http.MethodPost,
+			path:   "/api/user/tags",
+			body:   `{"name":"work"}`,
+			setup: func(tagUC *mocks.MockTagUseCase) {
+				tagUC.EXPECT().CreateTag(gomock.Any(), user, "work").Return(nil, tagErrors.ErrTagUserLimitExceeded)
+			},
+			status: http.StatusUnprocessableEntity,
+			resp:   `{"Error":"user cannot have more than 100 tags","Code":"ERR_LIMIT_EXCEEDED","StatusCode":422}`,
+		},
+		{
+			name:   "when tag already exists",
+			method: http.MethodPost,
+			path:   "/api/user/tags",
+			body:   `{"name":"work"}`,
+			setup: func(tagUC *mocks.MockTagUseCase) {
+				tagUC.EXPECT().CreateTag(gomock.Any(), user, "work").Return(nil, tagErrors.ErrTagAlreadyExist)
+			},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "when tags are listed",
+			method: http.MethodGet,
+			path:   "/api/user/tags",
+			setup: func(tagUC *mocks.MockTagUseCase) {
+				tagUC.EXPECT().GetTags(gomock.Any(), user).Return([]*tagEntity.Tag{{ID: 1, UserID: 1, Name: "home"}, {ID: 2, UserID: 1, Name: "work"}}, nil)
+			},
+			status: http.StatusOK,
+			resp:   `[{"id":1,"name":"home"},{"id":2,"name":"work"}]`,
+		},
+		{
+			name:   "when user has no tags",
+			method: http.MethodGet,
+			path:   "/api/user/tags",
+			setup: func(tagUC *mocks.MockTagUseCase) {
+				tagUC.EXPECT().GetTags(gomock.Any(), user).Return(nil, nil)
+			},
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "when tag is assigned",
+			method: http.MethodPost,
+			path:   "/api/user/urls/abc/tags",
+			body:   `{"tag":"work"}`,
+			setup: func(tagUC *mocks.MockTagUseCase) {
+				tagUC.EXPECT().AssignTag(gomock.Any(), user, "abc", "work").Return(nil)
+			},
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "when URL has too many tags",
+			method: http.MethodPost,
+			path:   "/api/user/urls/abc/tags",
+			body:   `{"tag":"work"}`,
+			setup: func(tagUC *mocks.MockTagUseCase) {
+				tagUC.EXPECT().AssignTag(gomock.Any(), user, "abc", "work").Return(tagErrors.ErrTagURLLimitExceeded)
+			},
+			status: http.StatusUnprocessableEntity,
+			resp:   `{"Error":"short URL cannot have more than 20 tags","Code":"ERR_LIMIT_EXCEEDED","StatusCode":422}`,
+		},
+		{
+			name:   "when assigned URL is not found",
+			method: http.MethodPost,
+			path:   "/api/user/urls/abc/tags",
+			body:   `{"tag":"work"}`,
+			setup: func(tagUC *mocks.MockTagUseCase) {
+				tagUC.EXPECT().AssignTag(gomock.Any(), user, "abc", "work").Return(tagErrors.ErrTagURLNotFound)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "when tag is removed",
+			method: http.MethodDelete,
+			path:   "/api/user/urls/abc/tags/work",
+			setup: func(tagUC *mocks.MockTagUseCase) {
+				tagUC.EXPECT().RemoveTag(gomock.Any(), user, "abc", "work").Return(nil)
+			},
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "when removed tag name is escaped",
+			method: http.MethodDelete,
+			path:   "/api/user/urls/abc/tags/100%25%20sure",
+			setup: func(tagUC *mocks.MockTagUseCase) {
+				tagUC.EXPECT().RemoveTag(gomock.Any(), user, "abc", "100% sure").Return(nil)
+			},
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "when removed tag is not assigned",
+			method: http.MethodDelete,
+			path:   "/api/user/urls/abc/tags/work",
+			setup: func(tagUC *mocks.MockTagUseCase) {
+				tagUC.EXPECT().RemoveTag(gomock.Any(), user, "abc", "work").Return(tagErrors.ErrTagNotFound)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "when URLs are filtered by tag",
+			method: http.MethodGet,
+			path:   "/api/user/urls?tag=work&page=2",
+			setup: func(tagUC *mocks.MockTagUseCase) {
+				tagUC.EXPECT().GetURLsByTag(gomock.Any(), user, "work").Return(&tagUseCase.TaggedURLs{Items: []*tagUseCase.TaggedURL{
+					{ShortURL: "http://localhost:8080/abc", OriginalURL: "https://ya.ru"},
+				}}, nil)
+			},
+			status: http.StatusOK,
+			resp:   `{"items":[{"short_url":"http://localhost:8080/abc","original_url":"https://ya.ru"}]}`,
+		},
+		{
+			name:   "when no URLs have the tag",
+			method: http.MethodGet,
+			path:   "/api/user/urls?tag=work",
+			setup: func(tagUC *mocks.MockTagUseCase) {
+				tagUC.EXPECT().GetURLsByTag(gomock.Any(), user, "work").Return(&tagUseCase.TaggedURLs{}, nil)
+			},
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "when tagged URLs cannot be read",
+			method: http.MethodGet,
+			path:   "/api/user/urls?tag=work",
+			setup: func(tagUC *mocks.MockTagUseCase) {
+				tagUC.EXPECT().GetURLsByTag(gomock.Any(), user, "work").Return(nil, tagErrors.ErrTagStorageNotWorking)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userUC := mocks.NewMockUserUseCase(ctrl)
+			tagUC := mocks.NewMockTagUseCase(ctrl)
+
+			r := chi.NewRouter()
+			Register(r, userUC, mocks.NewMockShortURLUseCase(ctrl), tagUC, mocks.NewMockWebhookUseCase(ctrl))
+
+			userUC.EXPECT().Register(gomock.Any()).Return(user, nil)
+			tt.setup(tagUC)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+			if tt.resp != "" {
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, tt.resp, string(body))
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	shortURLErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+)
+
+// Available constants
+const (
+	URLPath        = "/api/user/urls/{alias}"         // Path of single user URL
+	URLHistoryPath = "/api/user/urls/{alias}/history" // Path of previous original URLs of user URL
+	updateTimeout  = time.Second * 30                 // Timeout for URL update, includes URL safety check
+)
+
+// updateURLRequest represents request body of user URL update.
+type updateURLRequest struct {
+	URL string `json:"url"` // New original URL
+}
+
+// UpdateURL handles PATCH requests to change the original URL of a user URL keeping its alias.
+// Request body is a JSON object with the new original `url`.
+// Returns an HTTP handler function that:
+// - Authenticates the user
+// - Checks the new URL like on short URL creation, 422 if it is invalid, not permitted or unsafe
+// - Updates the URL in the namespace from X-Namespace header, 404 if alias does not exist, 403 if URL belongs to another user,
+// 410 if URL is deleted
+// - Returns 200 on success
+func (h *handler) UpdateURL() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err  error
+			req  updateURLRequest
+			user *userEntity.User
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), updateTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if user, err = auth.UserOrRegister(ctx, h.userUC, r, w); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.RegisterErrStatus(err)), w)
+			return
+		}
+
+		if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusBadRequest), w)
+			return
+		}
+
+		if err = h.urlUC.ValidateSourceURL(ctx, req.URL); err != nil {
+			returnErrResponse(newErrorResponse(err, validateURLErrStatus(err)), w)
+			return
+		}
+
+		if err = h.userUC.UpdateURL(ctx, withNamespace(user, r), chi.URLParam(r, "alias"), req.URL); err != nil {
+			returnErrResponse(newErrorResponse(err, updateErrStatus(err)), w)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// GetURLHistory handles GET requests to list previous original URLs of a user URL.
+// Returns an HTTP handler function that:
+// - Authenticates the user
+// - Finds the history of the URL in the namespace from X-Namespace header, 404 if alias does not exist, 403 if URL belongs to another user
+// - Returns previous original URLs with change times, the most recent first
+func (h *handler) GetURLHistory() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err     error
+			user    *userEntity.User
+			history []*shortURLEntity.HistoryEntry
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), getURLsTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if user, err = auth.UserOrRegister(ctx, h.userUC, r, w); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.RegisterErrStatus(err)), w)
+			return
+		}
+
+		if history, err = h.userUC.GetURLHistory(ctx, withNamespace(user, r), chi.URLParam(r, "alias")); err != nil {
+			returnErrResponse(newErrorResponse(err, updateErrStatus(err)), w)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, history)
+	}
+}
+
+// validateURLErrStatus maps errors of the new original URL check to HTTP status codes.
+// Parameters:
+// - err: Error returned by short URL use case
+// Returns:
+// - int: 422 for invalid, not permitted, unsafe or redirecting in a loop URL, 500 otherwise
+func validateURLErrStatus(err error) int {
+	switch {
+	case errors.Is(err, shortURLErrors.ErrShortURLInvalidSourceURL),
+		errors.Is(err, shortURLErrors.ErrShortURLDomainNotPermitted),
+		errors.Is(err, shortURLErrors.ErrShortURLUnsafeContent),
+		errors.Is(err, shortURLErrors.ErrShortURLRedirectLoop),
+		errors.Is(err, shortURLErrors.ErrShortURLDestinationUnreachable):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// updateErrStatus maps URL update and history errors to HTTP status codes.
+// Parameters:
+// - err: Error returned by user use case
+// Returns:
+// - int: HTTP status code
+func updateErrStatus(err error) int {
+	switch {
+	case errors.Is(err, ucErrors.ErrUserInvalidURL):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ucErrors.ErrUserURLNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ucErrors.ErrUserURLForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ucErrors.ErrUserURLDeleted):
+		return http.StatusGone
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	shortURLErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_UpdateURL(t *testing.T) {
+	user := &userEntity.User{ID: 1}
+
+	var tests = []struct {
+		setup  func(userUC *mocks.MockUserUseCase, urlUC *mocks.MockShortURLUseCase)
+		name   string
+		body   string
+		resp   string
+		status int
+	}{
+		{
+			name: "when URL is updated",
+			body: `{"url":"https://go.dev"}`,
+			setup: func(userUC *mocks.MockUserUseCase, urlUC *mocks.MockShortURLUseCase) {
+				urlUC.EXPECT().ValidateSourceURL(gomock.Any(), "https://go.dev").Return(nil)
+				userUC.EXPECT().UpdateURL(gomock.Any(), user, "abc", "https://go.dev").Return(nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "when body is malformed",
+			body:   `{"url":`,
+			setup:  func(_ *mocks.MockUserUseCase, _ *mocks.MockShortURLUseCase) {},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "when new URL is invalid",
+			body: `{"url":"go.dev"}`,
+			setup: func(_ *mocks.MockUserUseCase, urlUC *mocks.MockShortURLUseCase) {
+				urlUC.EXPECT().ValidateSourceURL(gomock.Any(), "go.dev").Return(shortURLErrors.ErrShortURLInvalidSourceURL)
+			},
+			status: http.StatusUnprocessableEntity,
+			resp:   `{"Error":"invalid source URL, please specify valid URL","Code":"ERR_INVALID_URL","StatusCode":422}`,
+		},
+		{
+			name: "when new URL is unsafe",
+			body: `{"url":"https://malware.example"}`,
+			setup: func(_ *mocks.MockUserUseCase, urlUC *mocks.MockShortURLUseCase) {
+				urlUC.EXPECT().ValidateSourceURL(gomock.Any(), "https://malware.example").Return(shortURLErrors.ErrShortURLUnsafeContent)
+			},
+			status: http.StatusUnprocessableEntity,
+			resp:   `{"Error":"URL flagged as unsafe","Code":"ERR_UNSAFE_URL","StatusCode":422}`,
+		},
+		{
+			name: "when URL belongs to another user",
+			body: `{"url":"https://go.dev"}`,
+			setup: func(userUC *mocks.MockUserUseCase, urlUC *mocks.MockShortURLUseCase) {
+				urlUC.EXPECT().ValidateSourceURL(gomock.Any(), "https://go.dev").Return(nil)
+				userUC.EXPECT().UpdateURL(gomock.Any(), user, "abc", "https://go.dev").Return(ucErrors.ErrUserURLForbidden)
+			},
+			status: http.StatusForbidden,
+			resp:   `{"Error":"short URL belongs to another user","Code":"ERR_FORBIDDEN","StatusCode":403}`,
+		},
+		{
+			name: "when alias does not exist",
+			body: `{"url":"https://go.dev"}`,
+			setup: func(userUC *mocks.MockUserUseCase, urlUC *mocks.MockShortURLUseCase) {
+				urlUC.EXPECT().ValidateSourceURL(gomock.Any(), "https://go.dev").Return(nil)
+				userUC.EXPECT().UpdateURL(gomock.Any(), user, "abc", "https://go.dev").Return(ucErrors.ErrUserURLNotFound)
+			},
+			status: http.StatusNotFound,
+			resp:   `{"Error":"short URL is not found","Code":"ERR_NOT_FOUND","StatusCode":404}`,
+		},
+		{
+			name: "when URL is deleted",
+			body: `{"url":"https://go.dev"}`,
+			setup: func(userUC *mocks.MockUserUseCase, urlUC *mocks.MockShortURLUseCase) {
+				urlUC.EXPECT().ValidateSourceURL(gomock.Any(), "https://go.dev").Return(nil)
+				userUC.EXPECT().UpdateURL(gomock.Any(), user, "abc", "https://go.dev").Return(ucErrors.ErrUserURLDeleted)
+			},
+			status: http.StatusGone,
+			resp:   `{"Error":"short URL is deleted","Code":"ERR_GONE","StatusCode":410}`,
+		},
+		{
+			name: "when storage is not working",
+			body: `{"url":"https://go.dev"}`,
+			setup: func(userUC *mocks.MockUserUseCase, urlUC *mocks.MockShortURLUseCase) {
+				urlUC.EXPECT().ValidateSourceURL(gomock.Any(), "https://go.dev").Return(nil)
+				userUC.EXPECT().UpdateURL(gomock.Any(), user, "abc", "https://go.dev").Return(ucErrors.ErrUserStorageNotWorking)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userUC := mocks.NewMockUserUseCase(ctrl)
+			urlUC := mocks.NewMockShortURLUseCase(ctrl)
+
+			r := chi.NewRouter()
+			Register(r, userUC, urlUC, mocks.NewMockTagUseCase(ctrl), mocks.NewMockWebhookUseCase(ctrl))
+
+			userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil)
+			tt.setup(userUC, urlUC)
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/user/urls/abc", strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer token")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+
+			if tt.resp != "" {
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, tt.resp, string(body))
+			}
+		})
+	}
+}
+
+func Test_GetURLHistory(t *testing.T) {
+	user := &userEntity.User{ID: 1}
+	changedAt := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		err     error
+		name    string
+		history []*shortURLEntity.HistoryEntry
+		resp    string
+		status  int
+	}{
+		{
+			name:    "when URL was changed",
+			history: []*shortURLEntity.HistoryEntry{{OriginalURL: "https://ya.ru", ChangedAt: changedAt}},
+			status:  http.StatusOK,
+			resp:    `[{"original_url":"https://ya.ru","changed_at":"2025-07-01T12:00:00Z"}]`,
+		},
+		{
+			name:    "when URL was never changed",
+			history: []*shortURLEntity.HistoryEntry{},
+			status:  http.StatusOK,
+			resp:    `[]`,
+		},
+		{
+			name:   "when URL belongs to another user",
+			err:    ucErrors.ErrUserURLForbidden,
+			status: http.StatusForbidden,
+			resp:   `{"Error":"short URL belongs to another user","Code":"ERR_FORBIDDEN","StatusCode":403}`,
+		},
+		{
+			name:   "when alias does not exist",
+			err:    ucErrors.ErrUserURLNotFound,
+			status: http.StatusNotFound,
+			resp:   `{"Error":"short URL is not found","Code":"ERR_NOT_FOUND","StatusCode":404}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userUC := mocks.NewMockUserUseCase(ctrl)
+
+			r := chi.NewRouter()
+			Register(r, userUC, mocks.NewMockShortURLUseCase(ctrl), mocks.NewMockTagUseCase(ctrl), mocks.NewMockWebhookUseCase(ctrl))
+
+			userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil)
+			userUC.EXPECT().GetURLHistory(gomock.Any(), user, "abc").Return(tt.history, tt.err)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/user/urls/abc/history", nil)
+			req.Header.Set("Authorization", "Bearer token")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			require.JSONEq(t, tt.resp, string(body))
+		})
+	}
+}
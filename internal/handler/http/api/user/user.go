@@ -1,4 +1,4 @@
-//go:generate mockgen -destination=./mocks/mock.go -package=mocks . UserUseCase
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . UserUseCase,ShortURLUseCase,TagUseCase,WebhookUseCase
 
 /*
 Package handler implements HTTP request handlers for user-related operations.
@@ -13,54 +13,148 @@ package handler
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	tagEntity "github.com/gururuby/shortener/internal/domain/entity/tag"
 	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	webhookEntity "github.com/gururuby/shortener/internal/domain/entity/webhook"
+	tagUseCase "github.com/gururuby/shortener/internal/domain/usecase/tag"
 	"github.com/gururuby/shortener/internal/domain/usecase/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
 	handlerErrors "github.com/gururuby/shortener/internal/handler/http/api/user/errors"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+	httpErrors "github.com/gururuby/shortener/internal/handler/http/errors"
 )
 
 // Available constants
 const (
-	authCookieName    = "Authorization"  // Name of the authentication cookie
-	getURLsTimeout    = time.Second * 30 // Timeout for GET URLs operation
-	deleteURLsTimeout = time.Second * 30 // Timeout for DELETE URLs operation
-	URLsPath          = "/api/user/urls" // Base path for user URL operations
+	etagHeaderName    = "ETag"              // Name of the entity tag header
+	ifNoneMatchHeader = "If-None-Match"     // Name of the conditional request header
+	namespaceHeader   = "X-Namespace"       // Name of the header with namespace of the URLs
+	getURLsTimeout    = time.Second * 30    // Timeout for GET URLs operation
+	deleteURLsTimeout = time.Second * 30    // Timeout for DELETE URLs operation
+	URLsPath          = "/api/user/urls"    // Base path for user URL operations
+	SessionPath       = "/api/user/session" // Path of current user session
+	revokeTimeout     = time.Second * 5     // Timeout for session revocation
+	pageParam         = "page"              // Query parameter with requested page number
+	perPageParam      = "per_page"          // Query parameter with requested page size
+	cursorParam       = "cursor"            // Query parameter with cursor of requested page
+	limitParam        = "limit"             // Query parameter with requested page size for cursor pagination
 )
 
 // Router defines the interface for HTTP request routing.
 type Router interface {
 	// Get registers a handler for GET requests at the specified path
 	Get(path string, h http.HandlerFunc)
+	// Post registers a handler for POST requests at the specified path
+	Post(path string, h http.HandlerFunc)
 	// Delete registers a handler for DELETE requests at the specified path
 	Delete(path string, h http.HandlerFunc)
+	// Put registers a handler for PUT requests at the specified path
+	Put(path string, h http.HandlerFunc)
+	// Patch registers a handler for PATCH requests at the specified path
+	Patch(path string, h http.HandlerFunc)
 }
 
 // UserUseCase defines the interface for user-related business logic.
 type UserUseCase interface {
-	// GetURLs retrieves all shortened URLs belonging to a user
-	GetURLs(ctx context.Context, user *userEntity.User) ([]*usecase.UserShortURL, error)
+	// GetURLsPaginated retrieves a page of shortened URLs belonging to a user
+	GetURLsPaginated(ctx context.Context, user *userEntity.User, page, perPage int) (*usecase.PaginatedURLs, error)
+	// GetURLsCursor retrieves a page of shortened URLs belonging to a user after the cursor
+	GetURLsCursor(ctx context.Context, user *userEntity.User, cursor, limit int) (*usecase.CursorPage, error)
+	// SearchURLs retrieves a page of shortened URLs of a user whose original URL contains the query
+	SearchURLs(ctx context.Context, user *userEntity.User, query string, page, perPage int) (*usecase.PaginatedURLs, error)
+	// ExportURLs passes all URLs belonging to a user to fn batch by batch
+	ExportURLs(ctx context.Context, user *userEntity.User, fn func([]*usecase.ExportedURL) error) error
+	// ExportUserData assembles all data stored about a user
+	ExportUserData(ctx context.Context, user *userEntity.User) (*usecase.UserExport, error)
 	// DeleteURLs removes the specified URLs belonging to a user
 	DeleteURLs(ctx context.Context, user *userEntity.User, aliases []string)
+	// RestoreURL restores a soft-deleted URL belonging to a user
+	RestoreURL(ctx context.Context, user *userEntity.User, alias string) error
+	// GetArchivedURLs retrieves a page of archived URLs belonging to a user
+	GetArchivedURLs(ctx context.Context, user *userEntity.User, page, perPage int) (*usecase.ArchivedURLs, error)
+	// RestoreArchivedURL moves an archived URL belonging to a user back to active URLs
+	RestoreArchivedURL(ctx context.Context, user *userEntity.User, alias string) error
+	// UpdateURL replaces the original URL of a URL belonging to a user
+	UpdateURL(ctx context.Context, user *userEntity.User, alias, newURL string) error
+	// UpdateProfile changes settings of a user
+	UpdateProfile(ctx context.Context, user *userEntity.User, profile usecase.UserProfile) error
+	// GetURLHistory retrieves previous original URLs of a URL belonging to a user
+	GetURLHistory(ctx context.Context, user *userEntity.User, alias string) ([]*shortURLEntity.HistoryEntry, error)
 	// Authenticate verifies a user's credentials
 	Authenticate(ctx context.Context, token string) (*userEntity.User, error)
 	// Register creates a new user account
 	Register(ctx context.Context) (*userEntity.User, error)
+	// RevokeToken terminates the session of the token
+	RevokeToken(ctx context.Context, token string) error
+	// AuthenticateAPIKey verifies an API key and returns its owner
+	AuthenticateAPIKey(ctx context.Context, key string) (*userEntity.User, error)
+	// CreateAPIKey generates a new API key of a user
+	CreateAPIKey(ctx context.Context, user *userEntity.User) (string, error)
+	// RevokeAPIKey revokes an API key of a user
+	RevokeAPIKey(ctx context.Context, user *userEntity.User, key string) error
+}
+
+// ShortURLUseCase defines the interface for short URL operations used by import, URL update and quota.
+type ShortURLUseCase interface {
+	// CreateShortURL generates a shortened URL with the given options for the source URL
+	CreateShortURL(ctx context.Context, user *userEntity.User, sourceURL string, opts shortURLEntity.CreateOptions) (string, error)
+	// ValidateSourceURL checks that source URL may be shortened without saving anything
+	ValidateSourceURL(ctx context.Context, sourceURL string) error
+	// URLQuota returns usage of the quota of short URLs of the user
+	URLQuota(ctx context.Context, user *userEntity.User) (*shortURLEntity.URLQuota, error)
+	// CheckURLQuota checks that the user may create the number of short URLs
+	CheckURLQuota(ctx context.Context, user *userEntity.User, count int) error
+}
+
+// TagUseCase defines the interface for tag business logic.
+type TagUseCase interface {
+	// CreateTag creates a new tag of a user
+	CreateTag(ctx context.Context, user *userEntity.User, name string) (*tagEntity.Tag, error)
+	// GetTags retrieves all tags of a user
+	GetTags(ctx context.Context, user *userEntity.User) ([]*tagEntity.Tag, error)
+	// AssignTag associates a tag with a short URL of a user
+	AssignTag(ctx context.Context, user *userEntity.User, alias, name string) error
+	// RemoveTag removes association of a tag with a short URL of a user
+	RemoveTag(ctx context.Context, user *userEntity.User, alias, name string) error
+	// GetURLsByTag retrieves all shortened URLs of a user having the tag assigned
+	GetURLsByTag(ctx context.Context, user *userEntity.User, name string) (*tagUseCase.TaggedURLs, error)
+}
+
+// WebhookUseCase defines the interface for webhook business logic.
+type WebhookUseCase interface {
+	// Register creates a new webhook of a user
+	Register(ctx context.Context, user *userEntity.User, url string, events []string) (*webhookEntity.Webhook, error)
+	// Unregister removes a webhook of a user
+	Unregister(ctx context.Context, user *userEntity.User, id int) error
 }
 
 // handler implements the HTTP request handlers for user operations.
 type handler struct {
-	userUC UserUseCase // User business logic service
-	router Router      // Request router
+	userUC      UserUseCase     // User business logic service
+	urlUC       ShortURLUseCase // Short URL business logic service
+	tagUC       TagUseCase      // Tag business logic service
+	webhookUC   WebhookUseCase  // Webhook business logic service
+	router      Router          // Request router
+	exports     *exportLimiter  // Limiter of user URLs exports
+	dataExports *exportLimiter  // Limiter of user data exports
 }
 
 // errorResponse represents an API error response.
+// Code is a stable machine-readable error code, see httpErrors.Code.
 type errorResponse struct {
+	err        error // Error returned to the client, maps to Code
 	Error      string
+	Code       string
 	StatusCode int
 }
 
@@ -68,16 +162,54 @@ type errorResponse struct {
 // Parameters:
 // - router: The HTTP router implementation
 // - userUC: User business logic service
-func Register(router Router, userUC UserUseCase) {
-	h := handler{router: router, userUC: userUC}
+// - urlUC: Short URL business logic service
+// - tagUC: Tag business logic service
+// - webhookUC: Webhook business logic service
+func Register(router Router, userUC UserUseCase, urlUC ShortURLUseCase, tagUC TagUseCase, webhookUC WebhookUseCase) {
+	h := handler{
+		router:      router,
+		userUC:      userUC,
+		urlUC:       urlUC,
+		tagUC:       tagUC,
+		webhookUC:   webhookUC,
+		exports:     newExportLimiter(exportInterval),
+		dataExports: newExportLimiter(dataExportInterval),
+	}
 	h.router.Get(URLsPath, h.GetURLs())
+	h.router.Get(ExportPath, h.ExportURLs())
+	h.router.Get(DataExportPath, h.ExportUserData())
+	h.router.Get(SearchPath, h.SearchURLs())
+	h.router.Post(ImportPath, h.ImportURLs())
 	h.router.Delete(URLsPath, h.DeleteURLs())
+	h.router.Put(RestorePath, h.RestoreURL())
+	h.router.Get(ArchivedURLsPath, h.GetArchivedURLs())
+	h.router.Put(ArchivedURLRestorePath, h.RestoreArchivedURL())
+	h.router.Patch(URLPath, h.UpdateURL())
+	h.router.Get(URLHistoryPath, h.GetURLHistory())
+	h.router.Delete(SessionPath, h.DeleteSession())
+	h.router.Get(TagsPath, h.GetTags())
+	h.router.Post(TagsPath, h.CreateTag())
+	h.router.Post(URLTagsPath, h.AssignTag())
+	h.router.Delete(URLTagPath, h.RemoveTag())
+	h.router.Post(WebhooksPath, h.CreateWebhook())
+	h.router.Delete(WebhookPath, h.DeleteWebhook())
+	h.router.Post(APIKeysPath, h.CreateAPIKey())
+	h.router.Delete(APIKeyPath, h.RevokeAPIKey())
+	h.router.Get(QuotaPath, h.GetQuota())
+	h.router.Patch(ProfilePath, h.UpdateProfile())
 }
 
 // GetURLs handles GET requests to retrieve a user's shortened URLs.
+// Supports optional `page` and `per_page` query parameters.
+// If `cursor` or `limit` query parameter is passed, cursor pagination is used instead
+// and the response contains `next_cursor` for the next page.
+// If `tag` query parameter is passed, all URLs having the tag are returned
+// in a single page and pagination parameters are ignored.
+// Non-empty page is sent with `ETag` header, 304 is returned with no body
+// if `If-None-Match` header matches it.
 // Returns an HTTP handler function that:
 // - Authenticates the user
-// - Retrieves their URLs
+// - Retrieves the requested page of their URLs
 // - Returns appropriate responses
 func (h *handler) GetURLs() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -86,8 +218,16 @@ func (h *handler) GetURLs() http.HandlerFunc {
 			statusCode int
 			response   []byte
 			errRes     errorResponse
+			page       int
+			perPage    int
+			cursor     int
+			limit      int
+			useCursor  bool
+			itemsCount int
+			result     any
 			user       *userEntity.User
-			userURLs   []*usecase.UserShortURL
+			userURLs   *usecase.PaginatedURLs
+			cursorPage *usecase.CursorPage
 		)
 
 		ctx, cancel := context.WithTimeout(r.Context(), getURLsTimeout)
@@ -102,34 +242,68 @@ func (h *handler) GetURLs() http.HandlerFunc {
 			return
 		}
 
-		user, err = h.authUser(ctx, r, w)
+		if r.URL.Query().Has(tagParam) {
+			if user, err = auth.UserOrRegister(ctx, h.userUC, r, w); err != nil {
+				errRes = newErrorResponse(err, auth.RegisterErrStatus(err))
+				returnErrResponse(errRes, w)
+				return
+			}
+			h.getURLsByTag(ctx, w, user, r.URL.Query().Get(tagParam))
+			return
+		}
+
+		if useCursor = isCursorPagination(r); useCursor {
+			cursor, limit, err = parseCursorPagination(r)
+		} else {
+			page, perPage, err = parsePagination(r)
+		}
 		if err != nil {
-			errRes.Error = err.Error()
-			errRes.StatusCode = http.StatusUnprocessableEntity
+			errRes = newErrorResponse(err, http.StatusBadRequest)
 			returnErrResponse(errRes, w)
 			return
 		}
 
-		userURLs, err = h.userUC.GetURLs(ctx, user)
+		user, err = auth.UserOrRegister(ctx, h.userUC, r, w)
 		if err != nil {
-			errRes.Error = err.Error()
-			errRes.StatusCode = http.StatusInternalServerError
+			errRes = newErrorResponse(err, auth.RegisterErrStatus(err))
 			returnErrResponse(errRes, w)
 			return
 		}
 
-		if len(userURLs) == 0 {
+		if useCursor {
+			if cursorPage, err = h.userUC.GetURLsCursor(ctx, user, cursor, limit); err == nil {
+				result, itemsCount = cursorPage, len(cursorPage.Items)
+			}
+		} else {
+			if userURLs, err = h.userUC.GetURLsPaginated(ctx, user, page, perPage); err == nil {
+				result, itemsCount = userURLs, len(userURLs.Items)
+			}
+		}
+		if err != nil {
+			errRes = newErrorResponse(err, http.StatusInternalServerError)
+			returnErrResponse(errRes, w)
+			return
+		}
+
+		if itemsCount == 0 {
 			statusCode = http.StatusNoContent
 			response = []byte("{}")
 		} else {
 			statusCode = http.StatusOK
-			response, err = json.Marshal(userURLs)
+			response, err = json.Marshal(result)
 			if err != nil {
-				errRes.Error = err.Error()
-				errRes.StatusCode = http.StatusInternalServerError
+				errRes = newErrorResponse(err, http.StatusInternalServerError)
 				returnErrResponse(errRes, w)
 				return
 			}
+
+			etag := computeETag(response)
+			w.Header().Set(etagHeaderName, etag)
+
+			if r.Header.Get(ifNoneMatchHeader) == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
 		}
 
 		w.WriteHeader(statusCode)
@@ -145,7 +319,7 @@ func (h *handler) GetURLs() http.HandlerFunc {
 // Returns an HTTP handler function that:
 // - Authenticates the user
 // - Validates the request
-// - Deletes specified URLs
+// - Deletes specified URLs in the namespace from X-Namespace header, the default one if it is missing
 // - Returns appropriate responses
 func (h *handler) DeleteURLs() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -168,69 +342,175 @@ func (h *handler) DeleteURLs() http.HandlerFunc {
 			return
 		}
 
-		user, err = h.authUser(ctx, r, w)
+		user, err = auth.UserOrRegister(ctx, h.userUC, r, w)
 
 		if err != nil {
-			errRes.Error = err.Error()
-			errRes.StatusCode = http.StatusUnprocessableEntity
+			errRes = newErrorResponse(err, auth.RegisterErrStatus(err))
 			returnErrResponse(errRes, w)
 			return
 		}
 
 		if err = json.NewDecoder(r.Body).Decode(&aliases); err != nil {
-			errRes.Error = err.Error()
-			errRes.StatusCode = http.StatusBadRequest
+			errRes = newErrorResponse(err, http.StatusBadRequest)
 			returnErrResponse(errRes, w)
 			return
 		}
 
 		if len(aliases) == 0 {
-			errRes.Error = handlerErrors.ErrHandlerNoAliasesForDelete.Error()
-			errRes.StatusCode = http.StatusBadRequest
+			errRes = newErrorResponse(handlerErrors.ErrHandlerNoAliasesForDelete, http.StatusBadRequest)
 			returnErrResponse(errRes, w)
 			return
 		}
 
-		h.userUC.DeleteURLs(ctx, user, aliases)
+		h.userUC.DeleteURLs(ctx, withNamespace(user, r), aliases)
 		w.WriteHeader(http.StatusAccepted)
 	}
 }
 
-// authUser handles user authentication via cookie or registration.
+// DeleteSession handles DELETE requests to log out the current user.
+// Returns an HTTP handler function that:
+// - Revokes the passed auth token, so it is rejected even before expiration
+// - Clears the auth cookie
+// - Returns appropriate responses
+func (h *handler) DeleteSession() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var errRes errorResponse
+
+		ctx, cancel := context.WithTimeout(r.Context(), revokeTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		token := auth.Token(r)
+		if token == "" {
+			errRes = newErrorResponse(handlerErrors.ErrHandlerNoAuthToken, http.StatusUnauthorized)
+			returnErrResponse(errRes, w)
+			return
+		}
+
+		if err := h.userUC.RevokeToken(ctx, token); err != nil {
+			errRes = newErrorResponse(err, http.StatusInternalServerError)
+			if errors.Is(err, ucErrors.ErrUserCannotAuthenticate) {
+				errRes.StatusCode = http.StatusUnauthorized
+			}
+			returnErrResponse(errRes, w)
+			return
+		}
+
+		http.SetCookie(w, &http.Cookie{Name: auth.CookieName, Value: "", MaxAge: -1})
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// withNamespace returns the user operating on URLs in the namespace passed with the request.
+// The user is copied, so the authenticated user is not modified.
+// Parameters:
+// - user: Authenticated user
+// - r: HTTP request
+// Returns:
+// - *userEntity.User: User with namespace from X-Namespace header, or the user itself if header is missing
+func withNamespace(user *userEntity.User, r *http.Request) *userEntity.User {
+	namespace := r.Header.Get(namespaceHeader)
+	if namespace == "" || user == nil {
+		return user
+	}
+	namespaced := *user
+	namespaced.Namespace = namespace
+	return &namespaced
+}
+
+// parsePagination extracts pagination parameters from the request query.
+// Missing parameters are returned as zero values so the use case applies its defaults.
 // Parameters:
-// - ctx: Context for cancellation/timeout
 // - r: HTTP request
-// - w: HTTP response writer
 // Returns:
-// - *userEntity.User: Authenticated user
-// - error: Authentication failure
-func (h *handler) authUser(ctx context.Context, r *http.Request, w http.ResponseWriter) (*userEntity.User, error) {
+// - int: Requested page
+// - int: Requested page size
+// - error: handlerErrors.ErrHandlerInvalidPagination if a value is not an integer
+func parsePagination(r *http.Request) (int, int, error) {
 	var (
-		authCookie *http.Cookie
-		user       *userEntity.User
-		err        error
+		page    int
+		perPage int
+		err     error
 	)
 
-	authCookie, err = r.Cookie(authCookieName)
-	// If auth cookie was not passed
-	if err != nil && errors.Is(err, http.ErrNoCookie) {
-		// Register new User
-		if user, err = h.userUC.Register(ctx); err != nil {
-			return nil, err
+	query := r.URL.Query()
+
+	if v := query.Get(pageParam); v != "" {
+		if page, err = strconv.Atoi(v); err != nil {
+			return 0, 0, handlerErrors.ErrHandlerInvalidPagination
 		}
+	}
 
-	} else { // If auth cookie exist, try to authenticate User
-		if user, err = h.userUC.Authenticate(ctx, authCookie.Value); err != nil {
-			// If auth cookie is invalid or user not found try to register new user
-			if user, err = h.userUC.Register(ctx); err != nil {
-				return nil, err
-			}
+	if v := query.Get(perPageParam); v != "" {
+		if perPage, err = strconv.Atoi(v); err != nil {
+			return 0, 0, handlerErrors.ErrHandlerInvalidPagination
 		}
 	}
-	// Setup auth cookie
-	http.SetCookie(w, &http.Cookie{Name: authCookieName, Value: user.AuthToken})
 
-	return user, nil
+	return page, perPage, nil
+}
+
+// isCursorPagination reports whether cursor pagination is requested.
+// Parameters:
+// - r: HTTP request
+// Returns:
+// - bool: true if `cursor` or `limit` query parameter is passed
+func isCursorPagination(r *http.Request) bool {
+	query := r.URL.Query()
+	return query.Has(cursorParam) || query.Has(limitParam)
+}
+
+// parseCursorPagination extracts cursor pagination parameters from the request query.
+// Missing parameters are returned as zero values, so the first page of default size is returned.
+// Parameters:
+// - r: HTTP request
+// Returns:
+// - int: ID decoded from the cursor
+// - int: Requested page size
+// - error: usecase.ErrUserInvalidCursor or handlerErrors.ErrHandlerInvalidLimit
+func parseCursorPagination(r *http.Request) (int, int, error) {
+	var (
+		cursor int
+		limit  int
+		err    error
+	)
+
+	query := r.URL.Query()
+
+	if v := query.Get(cursorParam); v != "" {
+		if cursor, err = usecase.DecodeCursor(v); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if v := query.Get(limitParam); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
+			return 0, 0, handlerErrors.ErrHandlerInvalidLimit
+		}
+	}
+
+	return cursor, limit, nil
+}
+
+// computeETag builds a strong entity tag of the response body.
+// Parameters:
+// - body: Serialized response body
+// Returns:
+// - string: Quoted hex encoded sha256 hash of the body
+func computeETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// newErrorResponse creates an error response for the error.
+// Parameters:
+// - err: Error returned to the client
+// - statusCode: HTTP status code of the response
+// Returns:
+// - errorResponse: Response with the error message, Code is set by returnErrResponse
+func newErrorResponse(err error, statusCode int) errorResponse {
+	return errorResponse{err: err, Error: err.Error(), StatusCode: statusCode}
 }
 
 // returnErrResponse writes an error response in JSON format.
@@ -238,6 +518,7 @@ func (h *handler) authUser(ctx context.Context, r *http.Request, w http.Response
 // - errResp: Error response details
 // - w: HTTP response writer
 func returnErrResponse(errResp errorResponse, w http.ResponseWriter) {
+	errResp.Code = httpErrors.Code(errResp.err, errResp.StatusCode)
 	w.WriteHeader(errResp.StatusCode)
 	response, err := json.Marshal(errResp)
 	if err != nil {
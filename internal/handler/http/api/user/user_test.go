@@ -10,6 +10,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
 	usecase "github.com/gururuby/shortener/internal/domain/usecase/user"
+	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
 	"github.com/gururuby/shortener/internal/handler/http/api/user/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -31,7 +32,7 @@ type (
 
 	ucOutput struct {
 		err error
-		res []*usecase.UserShortURL
+		res *usecase.PaginatedURLs
 	}
 )
 
@@ -56,6 +57,8 @@ func Test_GetURLs_OK(t *testing.T) {
 		ucInput  *userEntity.User
 		name     string
 		response response
+		page     int
+		perPage  int
 	}{
 		{
 			name: "when success receive user urls",
@@ -66,14 +69,50 @@ func Test_GetURLs_OK(t *testing.T) {
 			},
 			response: response{
 				status: http.StatusOK,
-				body:   `[{"short_url":"https://example.com/alias","original_url":"https://ya.ru"}]`,
+				body:   `{"items":[{"short_url":"https://example.com/alias","original_url":"https://ya.ru"}],"total":1,"page":1,"total_pages":1}`,
 			},
 			ucInput: &userEntity.User{ID: 1},
 			ucOutput: ucOutput{
-				res: urls,
+				res: &usecase.PaginatedURLs{Items: urls, Total: 1, Page: 1, TotalPages: 1},
 				err: nil,
 			},
 		},
+		{
+			name: "when page params passed",
+			request: request{
+				contentType: "application/json",
+				method:      http.MethodGet,
+				path:        "/api/user/urls?page=3&per_page=1",
+			},
+			response: response{
+				status: http.StatusOK,
+				body:   `{"items":[{"short_url":"https://example.com/alias","original_url":"https://ya.ru"}],"total":5,"page":3,"total_pages":5}`,
+			},
+			ucInput: &userEntity.User{ID: 1},
+			ucOutput: ucOutput{
+				res: &usecase.PaginatedURLs{Items: urls, Total: 5, Page: 3, TotalPages: 5},
+				err: nil,
+			},
+			page:    3,
+			perPage: 1,
+		},
+		{
+			name: "when user has no urls",
+			request: request{
+				contentType: "application/json",
+				method:      http.MethodGet,
+				path:        "/api/user/urls?page=2",
+			},
+			response: response{
+				status: http.StatusNoContent,
+			},
+			ucInput: &userEntity.User{ID: 1},
+			ucOutput: ucOutput{
+				res: &usecase.PaginatedURLs{Items: []*usecase.UserShortURL{}, Page: 2},
+				err: nil,
+			},
+			page: 2,
+		},
 	}
 
 	for _, tt := range tests {
@@ -83,7 +122,282 @@ func Test_GetURLs_OK(t *testing.T) {
 
 			w := httptest.NewRecorder()
 			userUC.EXPECT().Register(gomock.Any()).Return(tt.ucInput, nil)
-			userUC.EXPECT().GetURLs(gomock.Any(), tt.ucInput).Return(tt.ucOutput.res, tt.ucOutput.err).Times(1)
+			userUC.EXPECT().GetURLsPaginated(gomock.Any(), tt.ucInput, tt.page, tt.perPage).Return(tt.ucOutput.res, tt.ucOutput.err).Times(1)
+			h.GetURLs()(w, req)
+
+			resp := w.Result()
+
+			defer func() {
+				err = resp.Body.Close()
+				require.NoError(t, err)
+			}()
+
+			assert.Equal(t, tt.response.status, resp.StatusCode)
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+			if tt.response.status == http.StatusNoContent {
+				return
+			}
+			body, err = io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.response.body, string(body))
+		})
+	}
+}
+
+func Test_GetURLs_Cursor(t *testing.T) {
+	var (
+		err  error
+		body []byte
+	)
+
+	ctrl := gomock.NewController(t)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	user := &userEntity.User{ID: 1}
+	urls := []*usecase.UserShortURL{{ShortURL: "https://example.com/alias", OriginalURL: "https://ya.ru"}}
+
+	r := chi.NewRouter()
+	h := handler{router: r, userUC: userUC}
+
+	tests := []struct {
+		res      *usecase.CursorPage
+		name     string
+		path     string
+		response response
+		cursor   int
+		limit    int
+	}{
+		{
+			name:  "when first page requested",
+			path:  "/api/user/urls?limit=1",
+			limit: 1,
+			res:   &usecase.CursorPage{Items: urls, NextCursor: usecase.EncodeCursor(5)},
+			response: response{
+				status: http.StatusOK,
+				body:   `{"items":[{"short_url":"https://example.com/alias","original_url":"https://ya.ru"}],"next_cursor":"NQ"}`,
+			},
+		},
+		{
+			name:   "when last page requested",
+			path:   "/api/user/urls?cursor=NQ&limit=50",
+			cursor: 5,
+			limit:  50,
+			res:    &usecase.CursorPage{Items: urls},
+			response: response{
+				status: http.StatusOK,
+				body:   `{"items":[{"short_url":"https://example.com/alias","original_url":"https://ya.ru"}]}`,
+			},
+		},
+		{
+			name:   "when there are no urls after cursor",
+			path:   "/api/user/urls?cursor=NQ",
+			cursor: 5,
+			res:    &usecase.CursorPage{Items: []*usecase.UserShortURL{}},
+			response: response{
+				status: http.StatusNoContent,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+
+			w := httptest.NewRecorder()
+			userUC.EXPECT().Register(gomock.Any()).Return(user, nil)
+			userUC.EXPECT().GetURLsCursor(gomock.Any(), user, tt.cursor, tt.limit).Return(tt.res, nil).Times(1)
+			h.GetURLs()(w, req)
+
+			resp := w.Result()
+
+			defer func() {
+				err = resp.Body.Close()
+				require.NoError(t, err)
+			}()
+
+			assert.Equal(t, tt.response.status, resp.StatusCode)
+			if tt.response.status == http.StatusNoContent {
+				return
+			}
+			body, err = io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.response.body, string(body))
+		})
+	}
+}
+
+func Test_GetURLs_Authenticated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	user := &userEntity.User{ID: 1, AuthToken: "token"}
+	urls := &usecase.PaginatedURLs{
+		Items:      []*usecase.UserShortURL{{ShortURL: "https://example.com/alias", OriginalURL: "https://ya.ru"}},
+		Total:      1,
+		Page:       1,
+		TotalPages: 1,
+	}
+
+	r := chi.NewRouter()
+	h := handler{router: r, userUC: userUC}
+
+	tests := []struct {
+		setAuth func(r *http.Request)
+		name    string
+	}{
+		{
+			name:    "when token passed in bearer header",
+			setAuth: func(r *http.Request) { r.Header.Set("Authorization", "Bearer token") },
+		},
+		{
+			name:    "when token passed in cookie",
+			setAuth: func(r *http.Request) { r.AddCookie(&http.Cookie{Name: "Authorization", Value: "token"}) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/user/urls", nil)
+			tt.setAuth(req)
+
+			userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil).Times(1)
+			userUC.EXPECT().GetURLsPaginated(gomock.Any(), user, 0, 0).Return(urls, nil).Times(1)
+
+			w := httptest.NewRecorder()
+			h.GetURLs()(w, req)
+
+			resp := w.Result()
+
+			defer func() {
+				err := resp.Body.Close()
+				require.NoError(t, err)
+			}()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+}
+
+func Test_GetURLs_ETag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	user := &userEntity.User{ID: 1, AuthToken: "token"}
+	page := func(aliases ...string) *usecase.PaginatedURLs {
+		urls := &usecase.PaginatedURLs{Total: int64(len(aliases)), Page: 1, TotalPages: 1}
+		for _, alias := range aliases {
+			urls.Items = append(urls.Items, &usecase.UserShortURL{ShortURL: "https://example.com/" + alias, OriginalURL: "https://ya.ru"})
+		}
+		return urls
+	}
+
+	h := handler{router: chi.NewRouter(), userUC: userUC}
+	userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil).AnyTimes()
+
+	get := func(ifNoneMatch string) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, "/api/user/urls", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		h.GetURLs()(w, req)
+		resp := w.Result()
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		return resp
+	}
+
+	userUC.EXPECT().GetURLsPaginated(gomock.Any(), user, 0, 0).Return(page("a", "b"), nil).Times(2)
+
+	first := get("")
+	etag := first.Header.Get("ETag")
+	assert.Equal(t, http.StatusOK, first.StatusCode)
+	assert.Regexp(t, `^"[0-9a-f]{64}"$`, etag)
+
+	second := get(etag)
+	assert.Equal(t, http.StatusNotModified, second.StatusCode)
+	assert.Equal(t, etag, second.Header.Get("ETag"))
+	body, err := io.ReadAll(second.Body)
+	require.NoError(t, err)
+	assert.Empty(t, body)
+
+	// URL "b" was deleted
+	userUC.EXPECT().GetURLsPaginated(gomock.Any(), user, 0, 0).Return(page("a"), nil)
+
+	third := get(etag)
+	assert.Equal(t, http.StatusOK, third.StatusCode)
+	assert.NotEqual(t, etag, third.Header.Get("ETag"))
+}
+
+func Test_GetURLs_Errors(t *testing.T) {
+	var (
+		err  error
+		body []byte
+	)
+
+	ctrl := gomock.NewController(t)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+
+	r := chi.NewRouter()
+	h := handler{router: r, userUC: userUC}
+
+	var tests = []struct {
+		name     string
+		request  request
+		response response
+	}{
+		{
+			name: "when page is not a number",
+			request: request{
+				contentType: "application/json",
+				method:      http.MethodGet,
+				path:        "/api/user/urls?page=first",
+			},
+			response: response{
+				body:   `{"StatusCode":400,"Code":"ERR_INVALID_REQUEST","Error":"page and per_page must be integers"}`,
+				status: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "when per_page is not a number",
+			request: request{
+				contentType: "application/json",
+				method:      http.MethodGet,
+				path:        "/api/user/urls?per_page=all",
+			},
+			response: response{
+				body:   `{"StatusCode":400,"Code":"ERR_INVALID_REQUEST","Error":"page and per_page must be integers"}`,
+				status: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "when cursor is malformed",
+			request: request{
+				contentType: "application/json",
+				method:      http.MethodGet,
+				path:        "/api/user/urls?cursor=!!!",
+			},
+			response: response{
+				body:   `{"StatusCode":400,"Code":"ERR_INVALID_REQUEST","Error":"invalid cursor"}`,
+				status: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "when limit is not a number",
+			request: request{
+				contentType: "application/json",
+				method:      http.MethodGet,
+				path:        "/api/user/urls?limit=all",
+			},
+			response: response{
+				body:   `{"StatusCode":400,"Code":"ERR_INVALID_REQUEST","Error":"limit must be integer"}`,
+				status: http.StatusBadRequest,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.request.method, tt.request.path, nil)
+			req.Header.Set("Content-Type", tt.request.contentType)
+			w := httptest.NewRecorder()
 			h.GetURLs()(w, req)
 
 			resp := w.Result()
@@ -97,7 +411,6 @@ func Test_GetURLs_OK(t *testing.T) {
 			body, err = io.ReadAll(resp.Body)
 			require.NoError(t, err)
 			require.JSONEq(t, tt.response.body, string(body))
-			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
 		})
 	}
 }
@@ -183,7 +496,7 @@ func Test_DeleteURLs_Errors(t *testing.T) {
 				body:        bytes.NewBufferString(`["alias1", "alias2"]`),
 			},
 			response: response{
-				body:   `{"StatusCode":405,"Error":"HTTP method POST is not allowed"}`,
+				body:   `{"StatusCode":405,"Code":"ERR_METHOD_NOT_ALLOWED","Error":"HTTP method POST is not allowed"}`,
 				status: http.StatusMethodNotAllowed,
 			},
 		},
@@ -196,7 +509,7 @@ func Test_DeleteURLs_Errors(t *testing.T) {
 				body:        bytes.NewBufferString(`[]`),
 			},
 			response: response{
-				body:   `{"StatusCode":400,"Error":"no aliases passed to delete short urls"}`,
+				body:   `{"StatusCode":400,"Code":"ERR_INVALID_REQUEST","Error":"no aliases passed to delete short urls"}`,
 				status: http.StatusBadRequest,
 			},
 		},
@@ -209,7 +522,7 @@ func Test_DeleteURLs_Errors(t *testing.T) {
 				body:        bytes.NewBufferString(`]`),
 			},
 			response: response{
-				body:   `{"StatusCode":400,"Error":"invalid character ']' looking for beginning of value"}`,
+				body:   `{"StatusCode":400,"Code":"ERR_INVALID_REQUEST","Error":"invalid character ']' looking for beginning of value"}`,
 				status: http.StatusBadRequest,
 			},
 		},
@@ -238,3 +551,88 @@ func Test_DeleteURLs_Errors(t *testing.T) {
 		})
 	}
 }
+
+func Test_DeleteSession(t *testing.T) {
+	var (
+		err  error
+		body []byte
+	)
+
+	tests := []struct {
+		ucErr    error
+		setAuth  func(r *http.Request)
+		name     string
+		response response
+	}{
+		{
+			name:     "when token passed in bearer header",
+			setAuth:  func(r *http.Request) { r.Header.Set("Authorization", "Bearer token") },
+			response: response{status: http.StatusNoContent},
+		},
+		{
+			name:     "when token passed in cookie",
+			setAuth:  func(r *http.Request) { r.AddCookie(&http.Cookie{Name: "Authorization", Value: "token"}) },
+			response: response{status: http.StatusNoContent},
+		},
+		{
+			name:    "when token is not passed",
+			setAuth: func(_ *http.Request) {},
+			response: response{
+				body:   `{"StatusCode":401,"Code":"ERR_UNAUTHORIZED","Error":"auth token is not passed"}`,
+				status: http.StatusUnauthorized,
+			},
+		},
+		{
+			name:    "when token is invalid",
+			setAuth: func(r *http.Request) { r.Header.Set("Authorization", "Bearer token") },
+			ucErr:   ucErrors.ErrUserCannotAuthenticate,
+			response: response{
+				body:   `{"StatusCode":401,"Code":"ERR_UNAUTHORIZED","Error":"cannot authenticate user"}`,
+				status: http.StatusUnauthorized,
+			},
+		},
+		{
+			name:    "when revocation cannot be stored",
+			setAuth: func(r *http.Request) { r.Header.Set("Authorization", "Bearer token") },
+			ucErr:   ucErrors.ErrUserCannotRevokeToken,
+			response: response{
+				body:   `{"StatusCode":500,"Code":"ERR_INTERNAL","Error":"cannot revoke token"}`,
+				status: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userUC := mocks.NewMockUserUseCase(ctrl)
+			h := handler{router: chi.NewRouter(), userUC: userUC}
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/user/session", nil)
+			tt.setAuth(req)
+
+			userUC.EXPECT().RevokeToken(gomock.Any(), "token").Return(tt.ucErr).MaxTimes(1)
+
+			w := httptest.NewRecorder()
+			h.DeleteSession()(w, req)
+
+			resp := w.Result()
+
+			defer func() {
+				err = resp.Body.Close()
+				require.NoError(t, err)
+			}()
+
+			assert.Equal(t, tt.response.status, resp.StatusCode)
+			if tt.response.status == http.StatusNoContent {
+				cookies := resp.Cookies()
+				require.Len(t, cookies, 1)
+				assert.Equal(t, -1, cookies[0].MaxAge)
+				return
+			}
+			body, err = io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.response.body, string(body))
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	webhookEntity "github.com/gururuby/shortener/internal/domain/entity/webhook"
+	webhookErrors "github.com/gururuby/shortener/internal/domain/usecase/webhook/errors"
+	handlerErrors "github.com/gururuby/shortener/internal/handler/http/api/user/errors"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+)
+
+// Available constants
+const (
+	WebhooksPath    = "/api/user/webhooks"      // Path of user webhooks
+	WebhookPath     = "/api/user/webhooks/{id}" // Path of single user webhook
+	webhooksTimeout = time.Second * 5           // Timeout for webhook operations
+)
+
+// createWebhookRequest represents request body of webhook registration.
+type createWebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+// CreateWebhook handles POST requests to register a webhook of the user.
+// Request body is a JSON object with endpoint `url` and subscribed `events`.
+// Returns an HTTP handler function that:
+// - Authenticates the user
+// - Registers the webhook, 422 if URL or events are invalid
+// - Returns the created webhook with 201 status, including the secret used to sign payloads
+func (h *handler) CreateWebhook() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err     error
+			req     createWebhookRequest
+			user    *userEntity.User
+			webhook *webhookEntity.Webhook
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), webhooksTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if user, err = auth.UserOrRegister(ctx, h.userUC, r, w); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.RegisterErrStatus(err)), w)
+			return
+		}
+
+		if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+			returnErrResponse(newErrorResponse(err, http.StatusBadRequest), w)
+			return
+		}
+
+		if webhook, err = h.webhookUC.Register(ctx, user, req.URL, req.Events); err != nil {
+			returnErrResponse(newErrorResponse(err, webhookErrStatus(err)), w)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, webhook)
+	}
+}
+
+// DeleteWebhook handles DELETE requests to unregister a webhook of the user.
+// Returns an HTTP handler function that:
+// - Authenticates the user
+// - Removes the webhook, 400 if ID is malformed, 404 if user has no such webhook
+// - Returns 204 on success
+func (h *handler) DeleteWebhook() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err  error
+			id   int
+			user *userEntity.User
+		)
+
+		ctx, cancel := context.WithTimeout(r.Context(), webhooksTimeout)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if user, err = auth.UserOrRegister(ctx, h.userUC, r, w); err != nil {
+			returnErrResponse(newErrorResponse(err, auth.RegisterErrStatus(err)), w)
+			return
+		}
+
+		if id, err = strconv.Atoi(chi.URLParam(r, "id")); err != nil || id <= 0 {
+			returnErrResponse(newErrorResponse(handlerErrors.ErrHandlerInvalidWebhookID, http.StatusBadRequest), w)
+			return
+		}
+
+		if err = h.webhookUC.Unregister(ctx, user, id); err != nil {
+			returnErrResponse(newErrorResponse(err, webhookErrStatus(err)), w)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// webhookErrStatus maps webhook use case errors to HTTP status codes.
+// Parameters:
+// - err: Error returned by webhook use case
+// Returns:
+// - int: HTTP status code
+func webhookErrStatus(err error) int {
+	switch {
+	case errors.Is(err, webhookErrors.ErrWebhookInvalidURL), errors.Is(err, webhookErrors.ErrWebhookInvalidEvents):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, webhookErrors.ErrWebhookNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
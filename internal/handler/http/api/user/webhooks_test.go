@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	webhookEntity "github.com/gururuby/shortener/internal/domain/entity/webhook"
+	webhookErrors "github.com/gururuby/shortener/internal/domain/usecase/webhook/errors"
+	"github.com/gururuby/shortener/internal/handler/http/api/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Webhooks(t *testing.T) {
+	user := &userEntity.User{ID: 1}
+
+	var tests = []struct {
+		setup  func(webhookUC *mocks.MockWebhookUseCase)
+		name   string
+		method string
+		path   string
+		body   string
+		resp   string
+		status int
+	}{
+		{
+			name:   "when webhook is created",
+			method: http.MethodPost,
+			path:   "/api/user/webhooks",
+			body:   `{"url":"https://example.com/hook","events":["url.created"]}`,
+			setup: func(webhookUC *mocks.MockWebhookUseCase) {
+				webhookUC.EXPECT().Register(gomock.Any(), user, "https://example.com/hook", []string{"url.created"}).
+					Return(&webhookEntity.Webhook{ID: 2, UserID: 1, URL: "https://example.com/hook", Events: []string{"url.created"}, Secret: "secret"}, nil)
+			},
+			status: http.StatusCreated,
+			resp:   `{"id":2,"url":"https://example.com/hook","events":["url.created"],"secret":"secret"}`,
+		},
+		{
+			name:   "when webhook creation body is malformed",
+			method: http.MethodPost,
+			path:   "/api/user/webhooks",
+			body:   `{"url":`,
+			setup:  func(_ *mocks.MockWebhookUseCase) {},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "when webhook events are invalid",
+			method: http.MethodPost,
+			path:   "/api/user/webhooks",
+			body:   `{"url":"https://example.com/hook","events":["url.updated"]}`,
+			setup: func(webhookUC *mocks.MockWebhookUseCase) {
+				webhookUC.EXPECT().Register(gomock.Any(), user, "https://example.com/hook", []string{"url.updated"}).
+					Return(nil, webhookErrors.ErrWebhookInvalidEvents)
+			},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "when webhook storage fails",
+			method: http.MethodPost,
+			path:   "/api/user/webhooks",
+			body:   `{"url":"https://example.com/hook","events":["url.created"]}`,
+			setup: func(webhookUC *mocks.MockWebhookUseCase) {
+				webhookUC.EXPECT().Register(gomock.Any(), user, "https://example.com/hook", []string{"url.created"}).
+					Return(nil, webhookErrors.ErrWebhookStorageNotWorking)
+			},
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:   "when webhook is deleted",
+			method: http.MethodDelete,
+			path:   "/api/user/webhooks/2",
+			setup: func(webhookUC *mocks.MockWebhookUseCase) {
+				webhookUC.EXPECT().Unregister(gomock.Any(), user, 2).Return(nil)
+			},
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "when webhook is not found",
+			method: http.MethodDelete,
+			path:   "/api/user/webhooks/2",
+			setup: func(webhookUC *mocks.MockWebhookUseCase) {
+				webhookUC.EXPECT().Unregister(gomock.Any(), user, 2).Return(webhookErrors.ErrWebhookNotFound)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "when webhook ID is malformed",
+			method: http.MethodDelete,
+			path:   "/api/user/webhooks/first",
+			setup:  func(_ *mocks.MockWebhookUseCase) {},
+			status: http.StatusBadRequest,
+			resp:   `{"Error":"webhook id must be a positive integer","Code":"ERR_INVALID_REQUEST","StatusCode":400}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userUC := mocks.NewMockUserUseCase(ctrl)
+			webhookUC := mocks.NewMockWebhookUseCase(ctrl)
+
+			r := chi.NewRouter()
+			Register(r, userUC, mocks.NewMockShortURLUseCase(ctrl), mocks.NewMockTagUseCase(ctrl), webhookUC)
+
+			userUC.EXPECT().Register(gomock.Any()).Return(user, nil)
+			tt.setup(webhookUC)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+			if tt.resp != "" {
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, tt.resp, string(body))
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ Package handler implements HTTP request handlers for application health checks.
 
 It provides:
 - Health check endpoints
+- Build information endpoint
 - Database connectivity testing
 - Basic request validation
 */
@@ -12,12 +13,20 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+
+	healthEntity "github.com/gururuby/shortener/internal/domain/entity/health"
+	appUseCase "github.com/gururuby/shortener/internal/domain/usecase/app"
 )
 
 const (
-	pingDBPath = "/ping" // Endpoint path for database health check
+	pingDBPath = "/ping"     // Endpoint path for database health check
+	infoPath   = "/api/info" // Endpoint path for build information
+
+	statusOK       = "ok"       // Health status when database is reachable
+	statusDegraded = "degraded" // Health status when database is unreachable
 )
 
 // Router defines the interface for HTTP request routing.
@@ -28,51 +37,155 @@ type Router interface {
 
 // AppUseCase defines the interface for application-level operations.
 type AppUseCase interface {
-	// PingDB checks the database connection status
+	// DBStats pings the database and collects statistics of its connection pool
 	// Returns:
+	// - *appUseCase.DBHealthReport: Database health
 	// - error: If database is unreachable
-	PingDB(ctx context.Context) error
+	DBStats(ctx context.Context) (*appUseCase.DBHealthReport, error)
+
+	// Info returns public information about the running application
+	// Returns:
+	// - appUseCase.AppInfo: Application name, environment and build information
+	Info() appUseCase.AppInfo
 }
 
+type (
+	// healthResponse represents the response of health check
+	healthResponse struct {
+		Status   string   `json:"status"`
+		Database dbHealth `json:"database"`
+	}
+
+	// dbHealth represents database health, pool fields are omitted for databases without connection pool
+	dbHealth struct {
+		Type string `json:"type"`
+		*poolHealth
+		Replica *replicaHealth `json:"replica,omitempty"`
+	}
+
+	// replicaHealth represents availability and connection pool statistics of the read replica
+	replicaHealth struct {
+		Available       bool  `json:"available"`
+		OpenConnections int32 `json:"open_connections"`
+		IdleConnections int32 `json:"idle_connections"`
+		WaitCount       int64 `json:"wait_count"`
+	}
+
+	// poolHealth represents ping timing and connection pool statistics
+	poolHealth struct {
+		PingMs          int64 `json:"ping_ms"`
+		OpenConnections int32 `json:"open_connections"`
+		IdleConnections int32 `json:"idle_connections"`
+		WaitCount       int64 `json:"wait_count"`
+	}
+
+	// infoResponse represents the response of build information request
+	infoResponse struct {
+		Version   string `json:"version"`
+		BuildDate string `json:"build_date"`
+		Commit    string `json:"commit"`
+		Env       string `json:"env"`
+		Name      string `json:"name"`
+	}
+)
+
 // handler implements the HTTP request handlers for application operations.
 type handler struct {
 	uc     AppUseCase // Application use case implementation
 	router Router     // HTTP router
 }
 
-// Register sets up the application health check routes.
+// Register sets up the application health check and build information routes.
 // Parameters:
 // - router: The HTTP router implementation
 // - uc: Application use case implementation
 func Register(router Router, uc AppUseCase) {
 	h := handler{router: router, uc: uc}
 	h.router.Get(pingDBPath, h.PingDB())
+	h.router.Get(infoPath, h.Info())
+}
+
+// Info handles requests for build information of the running application.
+// The endpoint is public, no authentication is required.
+// Returns an HTTP handler function that responds with 200 OK and JSON containing
+// version, build date, commit, environment and name of the application.
+// Build values which were not set during the build are reported as "N/A".
+func (h *handler) Info() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		info := h.uc.Info()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(infoResponse{
+			Version:   info.Version,
+			BuildDate: info.BuildDate,
+			Commit:    info.Commit,
+			Env:       info.Env,
+			Name:      info.Name,
+		}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
 }
 
 // PingDB handles requests to check database connectivity.
 // Returns an HTTP handler function that:
 // - Validates the request method
-// - Checks database status
-// - Returns appropriate status codes:
-//   - 200 OK if database is reachable
-//   - 422 Unprocessable Entity if database is unreachable
+// - Checks database status and collects connection pool statistics of the primary and the read replica
+// - Returns JSON health report with appropriate status codes:
+//   - 200 OK with status "ok" if database is reachable
+//   - 503 Service Unavailable with status "degraded" if database is unreachable
 //   - 405 Method Not Allowed for invalid HTTP methods
 func (h *handler) PingDB() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var err error
-
 		if r.Method != http.MethodGet {
 			http.Error(w, fmt.Sprintf("HTTP method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
 			return
 		}
 
-		err = h.uc.PingDB(r.Context())
+		statusCode := http.StatusOK
+		res := healthResponse{Status: statusOK}
 
+		report, err := h.uc.DBStats(r.Context())
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-			return
+			statusCode = http.StatusServiceUnavailable
+			res.Status = statusDegraded
 		}
 
-		w.WriteHeader(http.StatusOK)
+		if report != nil {
+			res.Database.Type = report.Type
+			if err == nil && report.HasPool {
+				res.Database.poolHealth = &poolHealth{
+					PingMs:          report.PingMs,
+					OpenConnections: report.OpenConnections,
+					IdleConnections: report.IdleConnections,
+					WaitCount:       report.WaitCount,
+				}
+			}
+			if err == nil && report.Replica != nil {
+				res.Database.Replica = newReplicaHealth(report.Replica)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		if err = json.NewEncoder(w).Encode(res); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
+// newReplicaHealth converts health of the read replica to its response representation.
+// Parameters:
+// - replica: Replica health reported by use case
+// Returns:
+// - *replicaHealth: Replica availability with pool statistics, zero if they are unknown
+func newReplicaHealth(replica *healthEntity.DBReplicaHealth) *replicaHealth {
+	res := &replicaHealth{Available: replica.Available}
+	if replica.Pool != nil {
+		res.OpenConnections = replica.Pool.OpenConnections
+		res.IdleConnections = replica.Pool.IdleConnections
+		res.WaitCount = replica.Pool.WaitCount
 	}
+	return res
 }
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/go-chi/chi/v5"
+	healthEntity "github.com/gururuby/shortener/internal/domain/entity/health"
+	appUseCase "github.com/gururuby/shortener/internal/domain/usecase/app"
 	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/app/errors"
 	"github.com/gururuby/shortener/internal/handler/http/app/mocks"
 	"github.com/stretchr/testify/assert"
@@ -15,31 +17,58 @@ import (
 )
 
 func Test_Ping_OK(t *testing.T) {
-	var err error
-
 	ctrl := gomock.NewController(t)
 	uc := mocks.NewMockAppUseCase(ctrl)
 
 	r := chi.NewRouter()
 	h := handler{router: r, uc: uc}
 
-	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
-	uc.EXPECT().PingDB(req.Context()).Return(nil)
+	tests := []struct {
+		report *appUseCase.DBHealthReport
+		name   string
+		body   string
+	}{
+		{
+			name:   "when database works over connection pool",
+			report: &appUseCase.DBHealthReport{Type: "postgresql", PingMs: 12, OpenConnections: 5, IdleConnections: 2, HasPool: true},
+			body:   `{"status":"ok","database":{"type":"postgresql","ping_ms":12,"open_connections":5,"idle_connections":2,"wait_count":0}}`,
+		},
+		{
+			name: "when database has read replica",
+			report: &appUseCase.DBHealthReport{Type: "postgresql", PingMs: 12, OpenConnections: 5, IdleConnections: 2, HasPool: true,
+				Replica: &healthEntity.DBReplicaHealth{Pool: &healthEntity.DBPoolStats{OpenConnections: 3, IdleConnections: 3, WaitCount: 1}}},
+			body: `{"status":"ok","database":{"type":"postgresql","ping_ms":12,"open_connections":5,"idle_connections":2,"wait_count":0,` +
+				`"replica":{"available":false,"open_connections":3,"idle_connections":3,"wait_count":1}}}`,
+		},
+		{
+			name:   "when database has no connection pool",
+			report: &appUseCase.DBHealthReport{Type: "memory"},
+			body:   `{"status":"ok","database":{"type":"memory"}}`,
+		},
+	}
 
-	w := httptest.NewRecorder()
-	h.PingDB()(w, req)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			uc.EXPECT().DBStats(req.Context()).Return(tt.report, nil)
 
-	resp := w.Result()
+			w := httptest.NewRecorder()
+			h.PingDB()(w, req)
+
+			resp := w.Result()
 
-	defer func() {
-		err = resp.Body.Close()
-		require.NoError(t, err)
-	}()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
 
-	assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
 
-	_, err = io.ReadAll(resp.Body)
-	require.NoError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.body, string(body))
+		})
+	}
 }
 
 func Test_Ping_Errors(t *testing.T) {
@@ -58,7 +87,8 @@ func Test_Ping_Errors(t *testing.T) {
 	}
 
 	type useCaseResult struct {
-		err error
+		report *appUseCase.DBHealthReport
+		err    error
 	}
 
 	tests := []struct {
@@ -70,16 +100,17 @@ func Test_Ping_Errors(t *testing.T) {
 		{
 			name: "when use case returns some error",
 			useCaseRes: useCaseResult{
-				err: ucErrors.ErrAppDBIsNotReady,
+				report: &appUseCase.DBHealthReport{Type: "postgresql"},
+				err:    ucErrors.ErrAppDBIsNotReady,
 			},
 			request: request{
 				method: http.MethodGet,
 				path:   "/ping",
 			},
 			response: response{
-				code:        http.StatusUnprocessableEntity,
-				body:        "db is not ready\n",
-				contentType: "text/plain; charset=utf-8",
+				code:        http.StatusServiceUnavailable,
+				body:        `{"status":"degraded","database":{"type":"postgresql"}}` + "\n",
+				contentType: "application/json",
 			},
 		},
 		{
@@ -105,7 +136,7 @@ func Test_Ping_Errors(t *testing.T) {
 			h := handler{router: r, uc: uc}
 
 			req := httptest.NewRequest(tt.request.method, tt.request.path, nil)
-			uc.EXPECT().PingDB(req.Context()).Return(tt.useCaseRes.err).AnyTimes()
+			uc.EXPECT().DBStats(req.Context()).Return(tt.useCaseRes.report, tt.useCaseRes.err).AnyTimes()
 
 			w := httptest.NewRecorder()
 
@@ -119,6 +150,7 @@ func Test_Ping_Errors(t *testing.T) {
 			}()
 
 			assert.Equal(t, tt.response.code, resp.StatusCode)
+			assert.Equal(t, tt.response.contentType, resp.Header.Get("Content-Type"))
 
 			body, err = io.ReadAll(resp.Body)
 			require.NoError(t, err)
@@ -127,3 +159,43 @@ func Test_Ping_Errors(t *testing.T) {
 		})
 	}
 }
+
+func Test_Info(t *testing.T) {
+	tests := []struct {
+		name  string
+		build appUseCase.BuildInfo
+		body  string
+	}{
+		{
+			name:  "when build info is set",
+			build: appUseCase.BuildInfo{Version: "1.2.3", Date: "2024-01-15", Commit: "abc123"},
+			body:  `{"version":"1.2.3","build_date":"2024-01-15","commit":"abc123","env":"production","name":"Shortener"}`,
+		},
+		{
+			name: "when build info is not set",
+			body: `{"version":"N/A","build_date":"N/A","commit":"N/A","env":"production","name":"Shortener"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := chi.NewRouter()
+			Register(r, appUseCase.NewAppUseCase(nil, "memory", "Shortener", "production", tt.build))
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/info", nil))
+
+			resp := w.Result()
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.body, string(body))
+		})
+	}
+}
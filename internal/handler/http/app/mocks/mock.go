@@ -13,14 +13,15 @@ import (
 	context "context"
 	reflect "reflect"
 
+	usecase "github.com/gururuby/shortener/internal/domain/usecase/app"
 	gomock "go.uber.org/mock/gomock"
 )
 
 // MockAppUseCase is a mock of AppUseCase interface.
 type MockAppUseCase struct {
-	isgomock struct{}
 	ctrl     *gomock.Controller
 	recorder *MockAppUseCaseMockRecorder
+	isgomock struct{}
 }
 
 // MockAppUseCaseMockRecorder is the mock recorder for MockAppUseCase.
@@ -40,16 +41,31 @@ func (m *MockAppUseCase) EXPECT() *MockAppUseCaseMockRecorder {
 	return m.recorder
 }
 
-// PingDB mocks base method.
-func (m *MockAppUseCase) PingDB(ctx context.Context) error {
+// DBStats mocks base method.
+func (m *MockAppUseCase) DBStats(ctx context.Context) (*usecase.DBHealthReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DBStats", ctx)
+	ret0, _ := ret[0].(*usecase.DBHealthReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DBStats indicates an expected call of DBStats.
+func (mr *MockAppUseCaseMockRecorder) DBStats(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DBStats", reflect.TypeOf((*MockAppUseCase)(nil).DBStats), ctx)
+}
+
+// Info mocks base method.
+func (m *MockAppUseCase) Info() usecase.AppInfo {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PingDB", ctx)
-	ret0, _ := ret[0].(error)
+	ret := m.ctrl.Call(m, "Info")
+	ret0, _ := ret[0].(usecase.AppInfo)
 	return ret0
 }
 
-// PingDB indicates an expected call of PingDB.
-func (mr *MockAppUseCaseMockRecorder) PingDB(ctx any) *gomock.Call {
+// Info indicates an expected call of Info.
+func (mr *MockAppUseCaseMockRecorder) Info() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingDB", reflect.TypeOf((*MockAppUseCase)(nil).PingDB), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockAppUseCase)(nil).Info))
 }
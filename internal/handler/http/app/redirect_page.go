@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+
+	"github.com/gururuby/shortener/internal/infra/logger"
+)
+
+// Redirect page constants
+const (
+	staticPath     = "/static/*"               // Path pattern of redirect page assets
+	staticPrefix   = "/static/"                // Prefix stripped from asset paths
+	redirectCSS    = "/static/redirect.css"    // Stylesheet of redirect page, pushed over HTTP/2
+	redirectJS     = "/static/redirect.js"     // Countdown script of redirect page
+	redirectDelay  = 3                         // Seconds before the browser follows the redirect
+	redirectLayout = "templates/redirect.html" // Template of redirect page
+)
+
+var (
+	//go:embed static
+	staticFS embed.FS // Assets of redirect page
+
+	//go:embed templates
+	templatesFS embed.FS // HTML templates
+)
+
+// redirectPageData represents values rendered into redirect page.
+type redirectPageData struct {
+	URL     string // Original URL the browser is redirected to
+	Refresh string // Value of refresh meta tag, fallback for browsers without JavaScript
+	CSSPath string // Path of stylesheet
+	JSPath  string // Path of countdown script
+	Delay   int    // Seconds before redirect
+}
+
+// RedirectPage renders interstitial HTML page which redirects the browser after a countdown
+// instead of responding with redirect status.
+type RedirectPage struct {
+	tmpl *template.Template // Parsed page template
+}
+
+// RegisterRedirectPage sets up routes of redirect page assets.
+// Parameters:
+// - router: The HTTP router implementation
+// Returns:
+// - *RedirectPage: Page rendering the registered assets
+func RegisterRedirectPage(router Router) *RedirectPage {
+	assets, err := fs.Sub(staticFS, "static")
+	if err != nil {
+		panic(err)
+	}
+	router.Get(staticPath, http.StripPrefix(staticPrefix, http.FileServerFS(assets)).ServeHTTP)
+
+	return &RedirectPage{tmpl: template.Must(template.ParseFS(templatesFS, redirectLayout))}
+}
+
+// Render writes redirect page leading to targetURL with 200 OK.
+// The stylesheet is pushed when the page is requested over HTTP/2 and the client accepts pushes,
+// so the page renders without another round trip.
+// Parameters:
+// - w: HTTP response writer
+// - r: HTTP request
+// - targetURL: Original URL the browser is redirected to
+func (p *RedirectPage) Render(w http.ResponseWriter, r *http.Request, targetURL string) {
+	if r.ProtoMajor == 2 {
+		if pusher, ok := findPusher(w); ok {
+			if err := pusher.Push(redirectCSS, nil); err != nil && !errors.Is(err, http.ErrNotSupported) {
+				logger.With(r.Context()).Warn(fmt.Sprintf("cannot push %s: %s", redirectCSS, err))
+			}
+		}
+	}
+
+	var page bytes.Buffer
+	err := p.tmpl.Execute(&page, redirectPageData{
+		URL:     targetURL,
+		Refresh: fmt.Sprintf("%d;url=%s", redirectDelay, targetURL),
+		CSSPath: redirectCSS,
+		JSPath:  redirectJS,
+		Delay:   redirectDelay,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(page.Bytes()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// findPusher looks for http.Pusher among the response writer and writers wrapped by middleware.
+// Parameters:
+// - w: HTTP response writer
+// Returns:
+// - http.Pusher: Writer able to push
+// - bool: true if pusher is found
+func findPusher(w http.ResponseWriter) (http.Pusher, bool) {
+	for {
+		if pusher, ok := w.(http.Pusher); ok {
+			return pusher, true
+		}
+		wrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return nil, false
+		}
+		w = wrapper.Unwrap()
+	}
+}
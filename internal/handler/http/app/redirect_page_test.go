@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"golang.org/x/net/http2/hpack"
+)
+
+func Test_RedirectPage_Render(t *testing.T) {
+	r := chi.NewRouter()
+	page := RegisterRedirectPage(r)
+	r.Get("/abc", func(w http.ResponseWriter, r *http.Request) {
+		page.Render(w, r, "https://ya.ru/?q=a&b=<c>")
+	})
+
+	t.Run("when page is requested", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/abc", nil))
+
+		body := w.Body.String()
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, body, `<meta http-equiv="refresh" content="3;url=https://ya.ru/?q=a&amp;b=&lt;c&gt;">`)
+		assert.Contains(t, body, `<link rel="stylesheet" href="/static/redirect.css">`)
+		assert.Contains(t, body, `href="https://ya.ru/?q=a&amp;b=%3cc%3e"`)
+		assert.NotContains(t, body, "<c>")
+	})
+
+	for _, asset := range []struct {
+		path        string
+		contentType string
+	}{
+		{path: "/static/redirect.css", contentType: "text/css; charset=utf-8"},
+		{path: "/static/redirect.js", contentType: "text/javascript; charset=utf-8"},
+	} {
+		t.Run("when "+asset.path+" is requested", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, asset.path, nil))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, asset.contentType, w.Header().Get("Content-Type"))
+			assert.NotEmpty(t, w.Body.String())
+		})
+	}
+}
+
+func Test_RedirectPage_Push(t *testing.T) {
+	r := chi.NewRouter()
+	page := RegisterRedirectPage(r)
+	r.Get("/abc", func(w http.ResponseWriter, r *http.Request) {
+		page.Render(w, r, "https://ya.ru")
+	})
+
+	ts := httptest.NewServer(h2c.NewHandler(r, &http2.Server{}))
+	defer ts.Close()
+
+	conn, err := net.Dial("tcp", ts.Listener.Addr().String())
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+
+	// HTTP/2 with prior knowledge: Go clients disable pushes, so frames are written by hand
+	_, err = io.WriteString(conn, http2.ClientPreface)
+	require.NoError(t, err)
+	framer := http2.NewFramer(conn, conn)
+	require.NoError(t, framer.WriteSettings(http2.Setting{ID: http2.SettingEnablePush, Val: 1}))
+
+	var headers bytes.Buffer
+	encoder := hpack.NewEncoder(&headers)
+	for _, field := range []hpack.HeaderField{
+		{Name: ":method", Value: http.MethodGet},
+		{Name: ":scheme", Value: "http"},
+		{Name: ":authority", Value: ts.Listener.Addr().String()},
+		{Name: ":path", Value: "/abc"},
+	} {
+		require.NoError(t, encoder.WriteField(field))
+	}
+	require.NoError(t, framer.WriteHeaders(http2.HeadersFrameParam{
+		StreamID:      1,
+		BlockFragment: headers.Bytes(),
+		EndStream:     true,
+		EndHeaders:    true,
+	}))
+
+	decoder := hpack.NewDecoder(4096, nil)
+	for {
+		frame, err := framer.ReadFrame()
+		require.NoError(t, err, "push promise is not received")
+
+		switch f := frame.(type) {
+		case *http2.SettingsFrame:
+			if !f.IsAck() {
+				require.NoError(t, framer.WriteSettingsAck())
+			}
+		case *http2.PushPromiseFrame:
+			fields, err := decoder.DecodeFull(f.HeaderBlockFragment())
+			require.NoError(t, err)
+
+			var path string
+			for _, field := range fields {
+				if field.Name == ":path" {
+					path = field.Value
+				}
+			}
+			assert.Equal(t, uint32(1), f.StreamID)
+			assert.Equal(t, redirectCSS, path)
+			return
+		case *http2.HeadersFrame:
+			require.FailNow(t, "page is sent before push promise", "stream %d", f.StreamID)
+		}
+	}
+}
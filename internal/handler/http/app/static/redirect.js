@@ -0,0 +1,19 @@
+(function () {
+  var countdown = document.getElementById("countdown");
+  var target = document.getElementById("target");
+  if (!countdown || !target) {
+    return;
+  }
+
+  var seconds = parseInt(countdown.textContent, 10);
+  var timer = setInterval(function () {
+    seconds -= 1;
+    if (seconds > 0) {
+      countdown.textContent = seconds;
+      return;
+    }
+    clearInterval(timer);
+    countdown.textContent = 0;
+    window.location.replace(target.href);
+  }, 1000);
+})();
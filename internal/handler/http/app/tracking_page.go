@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+)
+
+// Tracking page constants
+const (
+	trackingPixelPrefix = "/t/"                     // Path prefix of tracking pixels, followed by short URL path
+	trackingDelay       = 1                         // Seconds the browser has to load the pixel before redirect
+	trackingLayout      = "templates/tracking.html" // Template of tracking page
+)
+
+// trackingPageData represents values rendered into tracking page.
+type trackingPageData struct {
+	URL       string // Original URL the browser is redirected to
+	Refresh   string // Value of refresh meta tag
+	PixelPath string // Path of tracking pixel recording the click
+}
+
+// TrackingPage renders minimal HTML page which loads tracking pixel and then redirects the browser,
+// so the click is recorded with IP and User-Agent of the pixel request.
+type TrackingPage struct {
+	tmpl *template.Template // Parsed page template
+	all  bool               // Whether redirects of all short URLs are tracked
+}
+
+// NewTrackingPage creates tracking page.
+// Parameters:
+// - all: Serve the page for all short URLs, otherwise only for short URLs created with tracking
+// Returns:
+// - *TrackingPage: Page leading to original URLs
+func NewTrackingPage(all bool) *TrackingPage {
+	return &TrackingPage{tmpl: template.Must(template.ParseFS(templatesFS, trackingLayout)), all: all}
+}
+
+// Tracks reports whether redirect of the short URL is served as tracking page.
+// Parameters:
+// - shortURL: Found short URL
+// Returns:
+// - bool: true if all redirects are tracked or the short URL was created with tracking
+func (p *TrackingPage) Tracks(shortURL *entity.ShortURL) bool {
+	return p.all || shortURL.IsTracked
+}
+
+// Render writes tracking page leading to targetURL with 200 OK.
+// The page is never cached, so every visit loads the pixel again.
+// Parameters:
+// - w: HTTP response writer
+// - r: HTTP request
+// - targetURL: Original URL the browser is redirected to
+// - path: Path of the clicked short URL, alias optionally prefixed with namespace, identifies the pixel
+func (p *TrackingPage) Render(w http.ResponseWriter, _ *http.Request, targetURL, path string) {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	var page bytes.Buffer
+	err := p.tmpl.Execute(&page, trackingPageData{
+		URL:       targetURL,
+		Refresh:   fmt.Sprintf("%d;url=%s", trackingDelay, targetURL),
+		PixelPath: trackingPixelPrefix + strings.Join(segments, "/"),
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(page.Bytes()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TrackingPage_Render(t *testing.T) {
+	page := NewTrackingPage(false)
+
+	w := httptest.NewRecorder()
+	page.Render(w, httptest.NewRequest(http.MethodGet, "/abc", nil), "https://ya.ru/?q=a&b=<c>", "abc")
+
+	body := w.Body.String()
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.Contains(t, body, `<meta http-equiv="refresh" content="1;url=https://ya.ru/?q=a&amp;b=&lt;c&gt;">`)
+	assert.Contains(t, body, `<img src="/t/abc" width="1" height="1" alt="">`)
+	assert.NotContains(t, body, "<c>")
+}
+
+func Test_TrackingPage_Render_Namespace(t *testing.T) {
+	page := NewTrackingPage(false)
+
+	w := httptest.NewRecorder()
+	page.Render(w, httptest.NewRequest(http.MethodGet, "/team/a%20b", nil), "https://ya.ru", "team/a b")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `<img src="/t/team/a%20b" width="1" height="1" alt="">`)
+}
+
+func Test_TrackingPage_Tracks(t *testing.T) {
+	tests := []struct {
+		name     string
+		all      bool
+		shortURL *entity.ShortURL
+		want     bool
+	}{
+		{name: "when short URL is tracked", shortURL: &entity.ShortURL{IsTracked: true}, want: true},
+		{name: "when short URL is not tracked", shortURL: &entity.ShortURL{}},
+		{name: "when all short URLs are tracked", all: true, shortURL: &entity.ShortURL{}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewTrackingPage(tt.all).Tracks(tt.shortURL))
+		})
+	}
+}
@@ -0,0 +1,173 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . Registrar
+
+/*
+Package handler provides authentication of users of HTTP requests shared by all handlers.
+
+It features:
+- Authentication by X-API-Key header, Authorization bearer token or cookie
+- Registration of a new user for requests without valid token
+- Mapping of authentication errors to HTTP status codes
+*/
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	userErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	handlerErrors "github.com/gururuby/shortener/internal/handler/http/errors"
+)
+
+// Authentication credentials passed with requests.
+const (
+	CookieName       = "Authorization" // Name of the authentication cookie
+	HeaderName       = "Authorization" // Name of the authentication header
+	BearerPrefix     = "Bearer "       // Prefix of the bearer token in authentication header
+	APIKeyHeaderName = "X-API-Key"     // Name of the API key header
+)
+
+// Authenticator defines the interface for authenticating users by credentials of requests.
+type Authenticator interface {
+	// Authenticate validates an auth token and returns the user
+	Authenticate(ctx context.Context, token string) (*userEntity.User, error)
+	// AuthenticateAPIKey validates an API key and returns its owner
+	AuthenticateAPIKey(ctx context.Context, key string) (*userEntity.User, error)
+}
+
+// Registrar defines the interface for authenticating users and registering new ones.
+type Registrar interface {
+	Authenticator
+	// Register creates a new user account
+	Register(ctx context.Context) (*userEntity.User, error)
+}
+
+// User authenticates the user of the request via API key, bearer token or cookie.
+// A new user is never registered.
+// Parameters:
+// - ctx: Context for cancellation/timeout
+// - a: User authenticator
+// - r: HTTP request
+// Returns:
+// - *userEntity.User: Authenticated user
+// - error: handlerErrors.ErrNoAuthToken if no credentials are passed, or authentication failure
+func User(ctx context.Context, a Authenticator, r *http.Request) (*userEntity.User, error) {
+	if key := r.Header.Get(APIKeyHeaderName); key != "" {
+		return a.AuthenticateAPIKey(ctx, key)
+	}
+
+	if token := Token(r); token != "" {
+		return a.Authenticate(ctx, token)
+	}
+
+	return nil, handlerErrors.ErrNoAuthToken
+}
+
+// UserOrRegister authenticates the user of the request via API key, bearer token or cookie,
+// registering a new user if the token is missing or invalid. The auth cookie is set to the token of the user.
+// Requests with API key and requests of deactivated users are never registered as new users.
+// Parameters:
+// - ctx: Context for cancellation/timeout
+// - a: User authenticator and registrar
+// - r: HTTP request
+// - w: HTTP response writer
+// Returns:
+// - *userEntity.User: Authenticated or registered user
+// - error: Authentication failure
+func UserOrRegister(ctx context.Context, a Registrar, r *http.Request, w http.ResponseWriter) (*userEntity.User, error) {
+	var (
+		user *userEntity.User
+		err  error
+	)
+
+	if key := r.Header.Get(APIKeyHeaderName); key != "" {
+		return a.AuthenticateAPIKey(ctx, key)
+	}
+
+	token := Token(r)
+	// If auth token was not passed
+	if token == "" {
+		// Register new User
+		if user, err = a.Register(ctx); err != nil {
+			return nil, err
+		}
+
+	} else { // If auth token exist, try to authenticate User
+		if user, err = a.Authenticate(ctx, token); err != nil {
+			// Deactivated user must not get a new account instead
+			if errors.Is(err, userErrors.ErrUserDeactivated) {
+				return nil, err
+			}
+			// If auth token is invalid or user not found try to register new user
+			if user, err = a.Register(ctx); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// Setup auth cookie
+	http.SetCookie(w, &http.Cookie{Name: CookieName, Value: user.AuthToken})
+
+	return user, nil
+}
+
+// FindUser authenticates the user of the request without registering a new one.
+// Parameters:
+// - a: User authenticator
+// - r: HTTP request
+// Returns:
+// - *userEntity.User: Authenticated user or nil if API key or token is missing or invalid
+func FindUser(a Authenticator, r *http.Request) *userEntity.User {
+	user, err := User(r.Context(), a, r)
+	if err != nil {
+		return nil
+	}
+	return user
+}
+
+// Token returns the auth token passed with the request.
+// The `Authorization: Bearer <token>` header takes precedence over the auth cookie.
+// Parameters:
+// - r: HTTP request
+// Returns:
+// - string: Auth token or empty string if none was passed
+func Token(r *http.Request) string {
+	if header := r.Header.Get(HeaderName); strings.HasPrefix(header, BearerPrefix) {
+		return strings.TrimPrefix(header, BearerPrefix)
+	}
+
+	if authCookie, err := r.Cookie(CookieName); err == nil {
+		return authCookie.Value
+	}
+
+	return ""
+}
+
+// ErrStatus maps errors of User to HTTP status codes.
+// Parameters:
+// - err: Error returned by User
+// Returns:
+// - int: 403 for deactivated user, 401 otherwise
+func ErrStatus(err error) int {
+	if errors.Is(err, userErrors.ErrUserDeactivated) {
+		return http.StatusForbidden
+	}
+	return http.StatusUnauthorized
+}
+
+// RegisterErrStatus maps errors of UserOrRegister to HTTP status codes.
+// Parameters:
+// - err: Error returned by UserOrRegister
+// Returns:
+// - int: 401 for invalid API key, 403 for deactivated user, 422 otherwise
+func RegisterErrStatus(err error) int {
+	switch {
+	case errors.Is(err, userErrors.ErrUserInvalidAPIKey):
+		return http.StatusUnauthorized
+	case errors.Is(err, userErrors.ErrUserDeactivated):
+		return http.StatusForbidden
+	default:
+		return http.StatusUnprocessableEntity
+	}
+}
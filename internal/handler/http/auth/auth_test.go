@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	userErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	"github.com/gururuby/shortener/internal/handler/http/auth/mocks"
+	handlerErrors "github.com/gururuby/shortener/internal/handler/http/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_User(t *testing.T) {
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1, AuthToken: "token"}
+
+	tests := []struct {
+		setAuth func(r *http.Request)
+		expect  func(a *mocks.MockRegistrar)
+		want    *userEntity.User
+		wantErr error
+		name    string
+	}{
+		{
+			name:    "when API key passed",
+			setAuth: func(r *http.Request) { r.Header.Set("X-API-Key", "key") },
+			expect: func(a *mocks.MockRegistrar) {
+				a.EXPECT().AuthenticateAPIKey(ctx, "key").Return(user, nil)
+			},
+			want: user,
+		},
+		{
+			name:    "when bearer token passed",
+			setAuth: func(r *http.Request) { r.Header.Set("Authorization", "Bearer token") },
+			expect: func(a *mocks.MockRegistrar) {
+				a.EXPECT().Authenticate(ctx, "token").Return(user, nil)
+			},
+			want: user,
+		},
+		{
+			name:    "when token is invalid",
+			setAuth: func(r *http.Request) { r.AddCookie(&http.Cookie{Name: "Authorization", Value: "invalid"}) },
+			expect: func(a *mocks.MockRegistrar) {
+				a.EXPECT().Authenticate(ctx, "invalid").Return(nil, userErrors.ErrUserCannotAuthenticate)
+			},
+			wantErr: userErrors.ErrUserCannotAuthenticate,
+		},
+		{
+			name:    "when nothing passed",
+			setAuth: func(_ *http.Request) {},
+			expect:  func(_ *mocks.MockRegistrar) {},
+			wantErr: handlerErrors.ErrNoAuthToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			a := mocks.NewMockRegistrar(ctrl)
+			tt.expect(a)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			tt.setAuth(req)
+
+			got, err := User(ctx, a, req)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_UserOrRegister(t *testing.T) {
+	ctx := context.Background()
+	user := &userEntity.User{ID: 1, AuthToken: "token"}
+	registered := &userEntity.User{ID: 2, AuthToken: "new-token"}
+
+	tests := []struct {
+		setAuth    func(r *http.Request)
+		expect     func(a *mocks.MockRegistrar)
+		want       *userEntity.User
+		wantErr    error
+		name       string
+		wantCookie string
+	}{
+		{
+			name:    "when token is valid",
+			setAuth: func(r *http.Request) { r.Header.Set("Authorization", "Bearer token") },
+			expect: func(a *mocks.MockRegistrar) {
+				a.EXPECT().Authenticate(ctx, "token").Return(user, nil)
+			},
+			want:       user,
+			wantCookie: "token",
+		},
+		{
+			name:    "when nothing passed",
+			setAuth: func(_ *http.Request) {},
+			expect: func(a *mocks.MockRegistrar) {
+				a.EXPECT().Register(ctx).Return(registered, nil)
+			},
+			want:       registered,
+			wantCookie: "new-token",
+		},
+		{
+			name:    "when token is invalid",
+			setAuth: func(r *http.Request) { r.Header.Set("Authorization", "Bearer invalid") },
+			expect: func(a *mocks.MockRegistrar) {
+				a.EXPECT().Authenticate(ctx, "invalid").Return(nil, userErrors.ErrUserCannotAuthenticate)
+				a.EXPECT().Register(ctx).Return(registered, nil)
+			},
+			want:       registered,
+			wantCookie: "new-token",
+		},
+		{
+			name:    "when user is deactivated",
+			setAuth: func(r *http.Request) { r.Header.Set("Authorization", "Bearer token") },
+			expect: func(a *mocks.MockRegistrar) {
+				a.EXPECT().Authenticate(ctx, "token").Return(nil, userErrors.ErrUserDeactivated)
+			},
+			wantErr: userErrors.ErrUserDeactivated,
+		},
+		{
+			name:    "when API key is invalid",
+			setAuth: func(r *http.Request) { r.Header.Set("X-API-Key", "revoked") },
+			expect: func(a *mocks.MockRegistrar) {
+				a.EXPECT().AuthenticateAPIKey(ctx, "revoked").Return(nil, userErrors.ErrUserInvalidAPIKey)
+			},
+			wantErr: userErrors.ErrUserInvalidAPIKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			a := mocks.NewMockRegistrar(ctrl)
+			tt.expect(a)
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			tt.setAuth(req)
+			w := httptest.NewRecorder()
+
+			got, err := UserOrRegister(ctx, a, req, w)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+
+			cookies := w.Result().Cookies()
+			if tt.wantCookie == "" {
+				assert.Empty(t, cookies)
+				return
+			}
+			require.Len(t, cookies, 1)
+			assert.Equal(t, tt.wantCookie, cookies[0].Value)
+		})
+	}
+}
+
+func Test_FindUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	a := mocks.NewMockRegistrar(ctrl)
+	user := &userEntity.User{ID: 1}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	assert.Nil(t, FindUser(a, req))
+
+	req.Header.Set("X-API-Key", "key")
+	a.EXPECT().AuthenticateAPIKey(gomock.Any(), "key").Return(user, nil)
+	assert.Equal(t, user, FindUser(a, req))
+
+	a.EXPECT().AuthenticateAPIKey(gomock.Any(), "key").Return(nil, userErrors.ErrUserInvalidAPIKey)
+	assert.Nil(t, FindUser(a, req))
+}
+
+func Test_Token(t *testing.T) {
+	tests := []struct {
+		setAuth func(r *http.Request)
+		name    string
+		want    string
+	}{
+		{
+			name:    "when nothing passed",
+			setAuth: func(_ *http.Request) {},
+			want:    "",
+		},
+		{
+			name:    "when bearer header passed",
+			setAuth: func(r *http.Request) { r.Header.Set("Authorization", "Bearer header-token") },
+			want:    "header-token",
+		},
+		{
+			name:    "when cookie passed",
+			setAuth: func(r *http.Request) { r.AddCookie(&http.Cookie{Name: "Authorization", Value: "cookie-token"}) },
+			want:    "cookie-token",
+		},
+		{
+			name: "when both header and cookie passed",
+			setAuth: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer header-token")
+				r.AddCookie(&http.Cookie{Name: "Authorization", Value: "cookie-token"})
+			},
+			want: "header-token",
+		},
+		{
+			name:    "when header has no bearer prefix",
+			setAuth: func(r *http.Request) { r.Header.Set("Authorization", "Basic dXNlcjpwYXNz") },
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			tt.setAuth(req)
+			assert.Equal(t, tt.want, Token(req))
+		})
+	}
+}
+
+func Test_ErrStatus(t *testing.T) {
+	assert.Equal(t, http.StatusForbidden, ErrStatus(userErrors.ErrUserDeactivated))
+	assert.Equal(t, http.StatusUnauthorized, ErrStatus(userErrors.ErrUserInvalidAPIKey))
+	assert.Equal(t, http.StatusUnauthorized, ErrStatus(handlerErrors.ErrNoAuthToken))
+
+	assert.Equal(t, http.StatusForbidden, RegisterErrStatus(userErrors.ErrUserDeactivated))
+	assert.Equal(t, http.StatusUnauthorized, RegisterErrStatus(userErrors.ErrUserInvalidAPIKey))
+	assert.Equal(t, http.StatusUnprocessableEntity, RegisterErrStatus(userErrors.ErrUserCannotRegister))
+}
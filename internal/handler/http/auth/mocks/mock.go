@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gururuby/shortener/internal/handler/http/auth (interfaces: Registrar)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock.go -package=mocks . Registrar
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/user"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRegistrar is a mock of Registrar interface.
+type MockRegistrar struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegistrarMockRecorder
+	isgomock struct{}
+}
+
+// MockRegistrarMockRecorder is the mock recorder for MockRegistrar.
+type MockRegistrarMockRecorder struct {
+	mock *MockRegistrar
+}
+
+// NewMockRegistrar creates a new mock instance.
+func NewMockRegistrar(ctrl *gomock.Controller) *MockRegistrar {
+	mock := &MockRegistrar{ctrl: ctrl}
+	mock.recorder = &MockRegistrarMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegistrar) EXPECT() *MockRegistrarMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockRegistrar) Authenticate(ctx context.Context, token string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, token)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockRegistrarMockRecorder) Authenticate(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockRegistrar)(nil).Authenticate), ctx, token)
+}
+
+// AuthenticateAPIKey mocks base method.
+func (m *MockRegistrar) AuthenticateAPIKey(ctx context.Context, key string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", ctx, key)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockRegistrarMockRecorder) AuthenticateAPIKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockRegistrar)(nil).AuthenticateAPIKey), ctx, key)
+}
+
+// Register mocks base method.
+func (m *MockRegistrar) Register(ctx context.Context) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockRegistrarMockRecorder) Register(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockRegistrar)(nil).Register), ctx)
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	analyticsErrors "github.com/gururuby/shortener/internal/domain/usecase/analytics/errors"
+	appErrors "github.com/gururuby/shortener/internal/domain/usecase/app/errors"
+	namespaceErrors "github.com/gururuby/shortener/internal/domain/usecase/namespace/errors"
+	previewErrors "github.com/gururuby/shortener/internal/domain/usecase/preview/errors"
+	qrErrors "github.com/gururuby/shortener/internal/domain/usecase/qr/errors"
+	shortURLErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+	splitErrors "github.com/gururuby/shortener/internal/domain/usecase/split/errors"
+	statsErrors "github.com/gururuby/shortener/internal/domain/usecase/stats/errors"
+	tagErrors "github.com/gururuby/shortener/internal/domain/usecase/tag/errors"
+	userErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	webhookErrors "github.com/gururuby/shortener/internal/domain/usecase/webhook/errors"
+)
+
+// Error codes returned to API clients in Code field of error responses.
+// Codes are stable, clients should match on them instead of error messages.
+const (
+	ErrCodeInvalidURL         = "ERR_INVALID_URL"          // URL is malformed or not http/https
+	ErrCodeInvalidRequest     = "ERR_INVALID_REQUEST"      // Request is malformed, e.g. invalid JSON or query parameter
+	ErrCodeValidation         = "ERR_VALIDATION"           // Request is well-formed but fails validation
+	ErrCodeNotFound           = "ERR_NOT_FOUND"            // Requested resource doesn't exist
+	ErrCodeConflict           = "ERR_CONFLICT"             // Resource already exists or is in conflicting state
+	ErrCodeUnauthorized       = "ERR_UNAUTHORIZED"         // Client is not authenticated
+	ErrCodeForbidden          = "ERR_FORBIDDEN"            // Resource belongs to another user or requires admin
+	ErrCodeGone               = "ERR_GONE"                 // Resource was deleted
+	ErrCodeUnsafeURL          = "ERR_UNSAFE_URL"           // URL is flagged as unsafe by URL checker
+	ErrCodeDomainNotPermitted = "ERR_DOMAIN_NOT_PERMITTED" // URL domain is blocked or not allowed
+	ErrCodeLimitExceeded      = "ERR_LIMIT_EXCEEDED"       // Per-user or per-URL limit is reached
+	ErrCodeMethodNotAllowed   = "ERR_METHOD_NOT_ALLOWED"   // HTTP method is not supported by the endpoint
+	ErrCodePayloadTooLarge    = "ERR_PAYLOAD_TOO_LARGE"    // Request body exceeds the size limit
+	ErrCodeTooManyRequests    = "ERR_TOO_MANY_REQUESTS"    // Client sends requests too often
+	ErrCodeTimeout            = "ERR_TIMEOUT"              // Request is not processed within the endpoint timeout
+	ErrCodeUnavailable        = "ERR_UNAVAILABLE"          // Service dependency is not ready
+	ErrCodeBadGateway         = "ERR_BAD_GATEWAY"          // External resource cannot be fetched
+	ErrCodeInternal           = "ERR_INTERNAL"             // Unexpected server error
+)
+
+// codes maps domain errors to error codes.
+var codes = map[error]string{
+	ErrRequestTimeout:    ErrCodeTimeout,
+	ErrAdminTokenInvalid: ErrCodeForbidden,
+	ErrNoAuthToken:       ErrCodeUnauthorized,
+
+	analyticsErrors.ErrAnalyticsInvalidGranularity: ErrCodeInvalidRequest,
+	analyticsErrors.ErrAnalyticsInvalidPeriod:      ErrCodeInvalidRequest,
+	analyticsErrors.ErrAnalyticsCannotGet:          ErrCodeInternal,
+	analyticsErrors.ErrAnalyticsCannotRecord:       ErrCodeInternal,
+
+	appErrors.ErrAppDBIsNotReady: ErrCodeUnavailable,
+
+	namespaceErrors.ErrNamespaceInvalidName:       ErrCodeValidation,
+	namespaceErrors.ErrNamespaceAlreadyExist:      ErrCodeConflict,
+	namespaceErrors.ErrNamespaceNotFound:          ErrCodeNotFound,
+	namespaceErrors.ErrNamespaceNotEmpty:          ErrCodeConflict,
+	namespaceErrors.ErrNamespaceDefault:           ErrCodeValidation,
+	namespaceErrors.ErrNamespaceStorageNotWorking: ErrCodeInternal,
+
+	previewErrors.ErrPreviewInvalidURL:         ErrCodeInvalidURL,
+	previewErrors.ErrPreviewDomainNotPermitted: ErrCodeDomainNotPermitted,
+	previewErrors.ErrPreviewUnavailable:        ErrCodeBadGateway,
+
+	qrErrors.ErrQRCannotEncode: ErrCodeInternal,
+
+	shortURLErrors.ErrShortURLAlreadyExist:           ErrCodeConflict,
+	shortURLErrors.ErrShortURLInvalidBaseURL:         ErrCodeInvalidURL,
+	shortURLErrors.ErrShortURLInvalidSourceURL:       ErrCodeInvalidURL,
+	shortURLErrors.ErrShortURLEmptyAlias:             ErrCodeNotFound,
+	shortURLErrors.ErrShortURLSourceURLNotFound:      ErrCodeNotFound,
+	shortURLErrors.ErrShortURLDeleted:                ErrCodeGone,
+	shortURLErrors.ErrShortURLUnsafeContent:          ErrCodeUnsafeURL,
+	shortURLErrors.ErrShortURLDomainNotPermitted:     ErrCodeDomainNotPermitted,
+	shortURLErrors.ErrShortURLForbidden:              ErrCodeForbidden,
+	shortURLErrors.ErrShortURLOwnerRequired:          ErrCodeUnauthorized,
+	shortURLErrors.ErrShortURLNamespaceNotFound:      ErrCodeNotFound,
+	shortURLErrors.ErrShortURLRedirectLoop:           ErrCodeValidation,
+	shortURLErrors.ErrShortURLDestinationUnreachable: ErrCodeValidation,
+	shortURLErrors.ErrShortURLInvalidAlias:           ErrCodeValidation,
+	shortURLErrors.ErrShortURLAliasTaken:             ErrCodeConflict,
+	shortURLErrors.ErrShortURLInvalidExpiration:      ErrCodeValidation,
+	shortURLErrors.ErrUserURLQuotaExceeded:           ErrCodeLimitExceeded,
+
+	splitErrors.ErrSplitInvalidAlias:             ErrCodeValidation,
+	splitErrors.ErrSplitInvalidDestinationsCount: ErrCodeValidation,
+	splitErrors.ErrSplitInvalidURL:               ErrCodeInvalidURL,
+	splitErrors.ErrSplitDuplicateURL:             ErrCodeValidation,
+	splitErrors.ErrSplitInvalidWeight:            ErrCodeValidation,
+	splitErrors.ErrSplitInvalidWeightsSum:        ErrCodeValidation,
+	splitErrors.ErrSplitDomainNotPermitted:       ErrCodeDomainNotPermitted,
+	splitErrors.ErrSplitUnsafeContent:            ErrCodeUnsafeURL,
+	splitErrors.ErrSplitAlreadyExist:             ErrCodeConflict,
+	splitErrors.ErrSplitNamespaceNotFound:        ErrCodeNotFound,
+	splitErrors.ErrSplitNotFound:                 ErrCodeNotFound,
+	splitErrors.ErrSplitForbidden:                ErrCodeForbidden,
+	splitErrors.ErrSplitStorageNotWorking:        ErrCodeInternal,
+
+	statsErrors.ErrStatsCannotGet: ErrCodeInternal,
+
+	tagErrors.ErrTagInvalidName:       ErrCodeValidation,
+	tagErrors.ErrTagAlreadyExist:      ErrCodeConflict,
+	tagErrors.ErrTagUserLimitExceeded: ErrCodeLimitExceeded,
+	tagErrors.ErrTagURLLimitExceeded:  ErrCodeLimitExceeded,
+	tagErrors.ErrTagNotFound:          ErrCodeNotFound,
+	tagErrors.ErrTagURLNotFound:       ErrCodeNotFound,
+	tagErrors.ErrTagStorageNotWorking: ErrCodeInternal,
+
+	userErrors.ErrUserCannotAuthenticate:  ErrCodeUnauthorized,
+	userErrors.ErrUserNotFound:            ErrCodeUnauthorized,
+	userErrors.ErrUserCannotSave:          ErrCodeInternal,
+	userErrors.ErrUserCannotRegister:      ErrCodeInternal,
+	userErrors.ErrUserStorageNotWorking:   ErrCodeInternal,
+	userErrors.ErrUserInvalidCursor:       ErrCodeInvalidRequest,
+	userErrors.ErrUserCannotRevokeToken:   ErrCodeInternal,
+	userErrors.ErrUserURLNotFound:         ErrCodeNotFound,
+	userErrors.ErrUserURLForbidden:        ErrCodeForbidden,
+	userErrors.ErrUserURLNotDeleted:       ErrCodeConflict,
+	userErrors.ErrUserURLDeleted:          ErrCodeGone,
+	userErrors.ErrUserURLAliasTaken:       ErrCodeConflict,
+	userErrors.ErrUserInvalidURL:          ErrCodeInvalidURL,
+	userErrors.ErrUserInvalidAPIKey:       ErrCodeUnauthorized,
+	userErrors.ErrUserAPIKeyNotFound:      ErrCodeNotFound,
+	userErrors.ErrUserCannotCreateAPIKey:  ErrCodeInternal,
+	userErrors.ErrUserDeactivated:         ErrCodeForbidden,
+	userErrors.ErrUserInvalidCustomDomain: ErrCodeValidation,
+	userErrors.ErrUserCustomDomainTaken:   ErrCodeConflict,
+
+	webhookErrors.ErrWebhookInvalidURL:           ErrCodeInvalidURL,
+	webhookErrors.ErrWebhookInvalidEvents:        ErrCodeValidation,
+	webhookErrors.ErrWebhookNotFound:             ErrCodeNotFound,
+	webhookErrors.ErrWebhookCannotGenerateSecret: ErrCodeInternal,
+	webhookErrors.ErrWebhookDeliveryFailed:       ErrCodeInternal,
+	webhookErrors.ErrWebhookStorageNotWorking:    ErrCodeInternal,
+}
+
+// statusCodes maps HTTP status codes to error codes of errors missing in codes,
+// e.g. request decoding errors or errors defined by handlers.
+var statusCodes = map[int]string{
+	http.StatusBadRequest:            ErrCodeInvalidRequest,
+	http.StatusUnauthorized:          ErrCodeUnauthorized,
+	http.StatusForbidden:             ErrCodeForbidden,
+	http.StatusNotFound:              ErrCodeNotFound,
+	http.StatusMethodNotAllowed:      ErrCodeMethodNotAllowed,
+	http.StatusConflict:              ErrCodeConflict,
+	http.StatusGone:                  ErrCodeGone,
+	http.StatusRequestEntityTooLarge: ErrCodePayloadTooLarge,
+	http.StatusUnprocessableEntity:   ErrCodeValidation,
+	http.StatusTooManyRequests:       ErrCodeTooManyRequests,
+	http.StatusBadGateway:            ErrCodeBadGateway,
+	http.StatusServiceUnavailable:    ErrCodeUnavailable,
+	http.StatusGatewayTimeout:        ErrCodeTimeout,
+}
+
+// Code returns the error code of the error.
+// Domain errors, also wrapped ones, are mapped by codes table,
+// other errors get the code of HTTP status they are returned with.
+// Parameters:
+// - err: Error returned to the client, may be nil
+// - statusCode: HTTP status code of the response
+// Returns:
+// - string: Error code, ErrCodeInternal for unknown errors of unknown statuses
+func Code(err error, statusCode int) string {
+	if err != nil {
+		if code, ok := codes[err]; ok {
+			return code
+		}
+		for target, code := range codes {
+			if errors.Is(err, target) {
+				return code
+			}
+		}
+		var verr *ValidationError
+		if errors.As(err, &verr) {
+			return ErrCodeValidation
+		}
+	}
+
+	if code, ok := statusCodes[statusCode]; ok {
+		return code
+	}
+	return ErrCodeInternal
+}
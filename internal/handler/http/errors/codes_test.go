@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	shortURLErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+	userErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Code(t *testing.T) {
+	tests := []struct {
+		err        error
+		name       string
+		want       string
+		statusCode int
+	}{
+		{
+			name:       "when error is domain error",
+			err:        shortURLErrors.ErrShortURLDeleted,
+			statusCode: http.StatusGone,
+			want:       ErrCodeGone,
+		},
+		{
+			name:       "when domain error is returned with other status",
+			err:        userErrors.ErrUserCannotAuthenticate,
+			statusCode: http.StatusUnprocessableEntity,
+			want:       ErrCodeUnauthorized,
+		},
+		{
+			name:       "when domain error is wrapped",
+			err:        fmt.Errorf("create short URL: %w", shortURLErrors.ErrShortURLAlreadyExist),
+			statusCode: http.StatusConflict,
+			want:       ErrCodeConflict,
+		},
+		{
+			name:       "when error is validation error",
+			err:        NewValidationError(FieldError{Field: "url", Code: CodeInvalidURL, Message: MessageInvalidURL}),
+			statusCode: http.StatusUnprocessableEntity,
+			want:       ErrCodeValidation,
+		},
+		{
+			name:       "when error is unknown",
+			err:        errors.New("unexpected end of JSON input"),
+			statusCode: http.StatusBadRequest,
+			want:       ErrCodeInvalidRequest,
+		},
+		{
+			name:       "when error is missing",
+			statusCode: http.StatusMethodNotAllowed,
+			want:       ErrCodeMethodNotAllowed,
+		},
+		{
+			name:       "when error and status are unknown",
+			err:        errors.New("some error"),
+			statusCode: http.StatusInternalServerError,
+			want:       ErrCodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Code(tt.err, tt.statusCode))
+		})
+	}
+}
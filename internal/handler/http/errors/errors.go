@@ -0,0 +1,112 @@
+// Package handler contains HTTP request handlers and API endpoint logic.
+// It defines errors shared by HTTP handlers, such as request validation errors
+// carrying details about every failed request field.
+package handler
+
+import (
+	"errors"
+	"strings"
+)
+
+// Errors list
+var (
+	// ErrRequestTimeout indicates that the request was not processed within the endpoint timeout.
+	//
+	// Typical cases:
+	// - Slow storage or URL checker
+	// - Endpoint timeout configured too short for the deployment
+	//
+	// Handling recommendations:
+	// - Return HTTP 504 (Gateway Timeout) in web handlers
+	// - Retry the request later
+	ErrRequestTimeout = errors.New("request timed out")
+
+	// ErrAdminTokenInvalid indicates that an administrative endpoint was called
+	// without the configured admin token, or administration is disabled.
+	//
+	// Handling recommendations:
+	// - Return HTTP 403 (Forbidden) in web handlers
+	// - Pass the token configured by AUTH_ADMIN_TOKEN in X-Admin-Token header
+	ErrAdminTokenInvalid = errors.New("admin token is missing or invalid")
+
+	// ErrNoAuthToken indicates that an endpoint available to known users only
+	// was requested without credentials, so a new user is not registered.
+	//
+	// Typical cases:
+	// - Request without `X-API-Key` header, `Authorization` header or cookie
+	//
+	// Handling recommendations:
+	// - Return HTTP 401 (Unauthorized) in web handlers
+	ErrNoAuthToken = errors.New("auth token is not passed")
+)
+
+// Validation error codes
+const (
+	CodeInvalidURL          = "INVALID_URL"           // Field is not a valid http/https URL
+	CodeInvalidVisibility   = "INVALID_VISIBILITY"    // Field is not a supported visibility
+	CodeInvalidRedirectType = "INVALID_REDIRECT_TYPE" // Field is not a supported redirect type
+	CodeConflictingOption   = "CONFLICTING_OPTION"    // Field cannot be combined with another passed field
+)
+
+// Validation error messages
+const (
+	MessageInvalidURL          = "must be a valid http/https URL"
+	MessageInvalidVisibility   = "must be either public or private"
+	MessageInvalidRedirectType = "must be either 301 or 307"
+)
+
+// FieldError describes a request field which failed validation.
+//
+// Field is a path of the field in request body, e.g. `url` or `[2].original_url`
+// for the third item of a batch. Code is a stable machine-readable reason,
+// Message is a human-readable description.
+type FieldError struct {
+	Field   string
+	Code    string
+	Message string
+}
+
+// ValidationError indicates that one or more request fields are invalid.
+//
+// Handling recommendations:
+// - Return HTTP 422 (Unprocessable Entity) in web handlers
+// - Pass Fields to clients, so they can highlight the invalid inputs
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// NewValidationError creates a validation error for the passed fields.
+// Parameters:
+// - fields: Failed fields
+// Returns:
+// - *ValidationError: Validation error
+func NewValidationError(fields ...FieldError) *ValidationError {
+	return &ValidationError{Fields: fields}
+}
+
+// Add appends a failed field.
+// Parameters:
+// - field: Path of the field in request body
+// - code: Validation error code
+// - message: Human-readable description
+func (e *ValidationError) Add(field, code, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Code: code, Message: message})
+}
+
+// HasErrors reports whether any field failed validation.
+// Returns:
+// - bool: true if there is at least one failed field
+func (e *ValidationError) HasErrors() bool {
+	return len(e.Fields) > 0
+}
+
+// Error returns failed fields with their messages.
+// Returns:
+// - string: Failed fields joined with "; ", e.g. "url: must be a valid http/https URL"
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Field+": "+f.Message)
+	}
+	return "validation failed: " + strings.Join(msgs, "; ")
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/gururuby/shortener/internal/handler/http/shorturl (interfaces: UserUseCase,ShortURLUseCase)
+// Source: github.com/gururuby/shortener/internal/handler/http/shorturl (interfaces: UserUseCase,ShortURLUseCase,EventBus,SplitUseCase,RedirectPage,TrackingPage)
 //
 // Generated by this command:
 //
-//	mockgen -destination=./mocks/mock.go -package=mocks . UserUseCase,ShortURLUseCase
+//	mockgen -destination=./mocks/mock.go -package=mocks . UserUseCase,ShortURLUseCase,EventBus,SplitUseCase,RedirectPage,TrackingPage
 //
 
 // Package mocks is a generated GoMock package.
@@ -11,18 +11,20 @@ package mocks
 
 import (
 	context "context"
+	http "net/http"
 	reflect "reflect"
 
 	entity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
 	entity0 "github.com/gururuby/shortener/internal/domain/entity/user"
+	eventbus "github.com/gururuby/shortener/internal/infra/eventbus"
 	gomock "go.uber.org/mock/gomock"
 )
 
 // MockUserUseCase is a mock of UserUseCase interface.
 type MockUserUseCase struct {
-	isgomock struct{}
 	ctrl     *gomock.Controller
 	recorder *MockUserUseCaseMockRecorder
+	isgomock struct{}
 }
 
 // MockUserUseCaseMockRecorder is the mock recorder for MockUserUseCase.
@@ -57,6 +59,21 @@ func (mr *MockUserUseCaseMockRecorder) Authenticate(ctx, token any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockUserUseCase)(nil).Authenticate), ctx, token)
 }
 
+// AuthenticateAPIKey mocks base method.
+func (m *MockUserUseCase) AuthenticateAPIKey(ctx context.Context, key string) (*entity0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", ctx, key)
+	ret0, _ := ret[0].(*entity0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockUserUseCaseMockRecorder) AuthenticateAPIKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockUserUseCase)(nil).AuthenticateAPIKey), ctx, key)
+}
+
 // Register mocks base method.
 func (m *MockUserUseCase) Register(ctx context.Context) (*entity0.User, error) {
 	m.ctrl.T.Helper()
@@ -74,9 +91,9 @@ func (mr *MockUserUseCaseMockRecorder) Register(ctx any) *gomock.Call {
 
 // MockShortURLUseCase is a mock of ShortURLUseCase interface.
 type MockShortURLUseCase struct {
-	isgomock struct{}
 	ctrl     *gomock.Controller
 	recorder *MockShortURLUseCaseMockRecorder
+	isgomock struct{}
 }
 
 // MockShortURLUseCaseMockRecorder is the mock recorder for MockShortURLUseCase.
@@ -97,45 +114,236 @@ func (m *MockShortURLUseCase) EXPECT() *MockShortURLUseCaseMockRecorder {
 }
 
 // BatchShortURLs mocks base method.
-func (m *MockShortURLUseCase) BatchShortURLs(ctx context.Context, urls []entity.BatchShortURLInput) []entity.BatchShortURLOutput {
+func (m *MockShortURLUseCase) BatchShortURLs(ctx context.Context, user *entity0.User, urls []entity.BatchShortURLInput) ([]entity.BatchShortURLOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BatchShortURLs", ctx, urls)
+	ret := m.ctrl.Call(m, "BatchShortURLs", ctx, user, urls)
 	ret0, _ := ret[0].([]entity.BatchShortURLOutput)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // BatchShortURLs indicates an expected call of BatchShortURLs.
-func (mr *MockShortURLUseCaseMockRecorder) BatchShortURLs(ctx, urls any) *gomock.Call {
+func (mr *MockShortURLUseCaseMockRecorder) BatchShortURLs(ctx, user, urls any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchShortURLs", reflect.TypeOf((*MockShortURLUseCase)(nil).BatchShortURLs), ctx, urls)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchShortURLs", reflect.TypeOf((*MockShortURLUseCase)(nil).BatchShortURLs), ctx, user, urls)
 }
 
 // CreateShortURL mocks base method.
-func (m *MockShortURLUseCase) CreateShortURL(ctx context.Context, user *entity0.User, sourceURL string) (string, error) {
+func (m *MockShortURLUseCase) CreateShortURL(ctx context.Context, user *entity0.User, sourceURL string, opts entity.CreateOptions) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateShortURL", ctx, user, sourceURL)
+	ret := m.ctrl.Call(m, "CreateShortURL", ctx, user, sourceURL, opts)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateShortURL indicates an expected call of CreateShortURL.
-func (mr *MockShortURLUseCaseMockRecorder) CreateShortURL(ctx, user, sourceURL any) *gomock.Call {
+func (mr *MockShortURLUseCaseMockRecorder) CreateShortURL(ctx, user, sourceURL, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShortURL", reflect.TypeOf((*MockShortURLUseCase)(nil).CreateShortURL), ctx, user, sourceURL)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShortURL", reflect.TypeOf((*MockShortURLUseCase)(nil).CreateShortURL), ctx, user, sourceURL, opts)
 }
 
 // FindShortURL mocks base method.
-func (m *MockShortURLUseCase) FindShortURL(ctx context.Context, alias string) (string, error) {
+func (m *MockShortURLUseCase) FindShortURL(ctx context.Context, namespace, alias string, user *entity0.User) (*entity.ShortURL, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindShortURL", ctx, alias)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "FindShortURL", ctx, namespace, alias, user)
+	ret0, _ := ret[0].(*entity.ShortURL)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindShortURL indicates an expected call of FindShortURL.
-func (mr *MockShortURLUseCaseMockRecorder) FindShortURL(ctx, alias any) *gomock.Call {
+func (mr *MockShortURLUseCaseMockRecorder) FindShortURL(ctx, namespace, alias, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShortURL", reflect.TypeOf((*MockShortURLUseCase)(nil).FindShortURL), ctx, namespace, alias, user)
+}
+
+// GetShortURL mocks base method.
+func (m *MockShortURLUseCase) GetShortURL(ctx context.Context, namespace, alias string) (*entity.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortURL", ctx, namespace, alias)
+	ret0, _ := ret[0].(*entity.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortURL indicates an expected call of GetShortURL.
+func (mr *MockShortURLUseCaseMockRecorder) GetShortURL(ctx, namespace, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortURL", reflect.TypeOf((*MockShortURLUseCase)(nil).GetShortURL), ctx, namespace, alias)
+}
+
+// MockEventBus is a mock of EventBus interface.
+type MockEventBus struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventBusMockRecorder
+	isgomock struct{}
+}
+
+// MockEventBusMockRecorder is the mock recorder for MockEventBus.
+type MockEventBusMockRecorder struct {
+	mock *MockEventBus
+}
+
+// NewMockEventBus creates a new mock instance.
+func NewMockEventBus(ctrl *gomock.Controller) *MockEventBus {
+	mock := &MockEventBus{ctrl: ctrl}
+	mock.recorder = &MockEventBusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventBus) EXPECT() *MockEventBusMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEventBus) Publish(event eventbus.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", event)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventBusMockRecorder) Publish(event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventBus)(nil).Publish), event)
+}
+
+// MockSplitUseCase is a mock of SplitUseCase interface.
+type MockSplitUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockSplitUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockSplitUseCaseMockRecorder is the mock recorder for MockSplitUseCase.
+type MockSplitUseCaseMockRecorder struct {
+	mock *MockSplitUseCase
+}
+
+// NewMockSplitUseCase creates a new mock instance.
+func NewMockSplitUseCase(ctrl *gomock.Controller) *MockSplitUseCase {
+	mock := &MockSplitUseCase{ctrl: ctrl}
+	mock.recorder = &MockSplitUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSplitUseCase) EXPECT() *MockSplitUseCaseMockRecorder {
+	return m.recorder
+}
+
+// RecordClick mocks base method.
+func (m *MockSplitUseCase) RecordClick(ctx context.Context, namespace, alias, url string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordClick", ctx, namespace, alias, url)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordClick indicates an expected call of RecordClick.
+func (mr *MockSplitUseCaseMockRecorder) RecordClick(ctx, namespace, alias, url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordClick", reflect.TypeOf((*MockSplitUseCase)(nil).RecordClick), ctx, namespace, alias, url)
+}
+
+// Resolve mocks base method.
+func (m *MockSplitUseCase) Resolve(ctx context.Context, namespace, alias string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, namespace, alias)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockSplitUseCaseMockRecorder) Resolve(ctx, namespace, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockSplitUseCase)(nil).Resolve), ctx, namespace, alias)
+}
+
+// MockRedirectPage is a mock of RedirectPage interface.
+type MockRedirectPage struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedirectPageMockRecorder
+	isgomock struct{}
+}
+
+// MockRedirectPageMockRecorder is the mock recorder for MockRedirectPage.
+type MockRedirectPageMockRecorder struct {
+	mock *MockRedirectPage
+}
+
+// NewMockRedirectPage creates a new mock instance.
+func NewMockRedirectPage(ctrl *gomock.Controller) *MockRedirectPage {
+	mock := &MockRedirectPage{ctrl: ctrl}
+	mock.recorder = &MockRedirectPageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedirectPage) EXPECT() *MockRedirectPageMockRecorder {
+	return m.recorder
+}
+
+// Render mocks base method.
+func (m *MockRedirectPage) Render(w http.ResponseWriter, r *http.Request, targetURL string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Render", w, r, targetURL)
+}
+
+// Render indicates an expected call of Render.
+func (mr *MockRedirectPageMockRecorder) Render(w, r, targetURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockRedirectPage)(nil).Render), w, r, targetURL)
+}
+
+// MockTrackingPage is a mock of TrackingPage interface.
+type MockTrackingPage struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrackingPageMockRecorder
+	isgomock struct{}
+}
+
+// MockTrackingPageMockRecorder is the mock recorder for MockTrackingPage.
+type MockTrackingPageMockRecorder struct {
+	mock *MockTrackingPage
+}
+
+// NewMockTrackingPage creates a new mock instance.
+func NewMockTrackingPage(ctrl *gomock.Controller) *MockTrackingPage {
+	mock := &MockTrackingPage{ctrl: ctrl}
+	mock.recorder = &MockTrackingPageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrackingPage) EXPECT() *MockTrackingPageMockRecorder {
+	return m.recorder
+}
+
+// Render mocks base method.
+func (m *MockTrackingPage) Render(w http.ResponseWriter, r *http.Request, targetURL, path string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Render", w, r, targetURL, path)
+}
+
+// Render indicates an expected call of Render.
+func (mr *MockTrackingPageMockRecorder) Render(w, r, targetURL, path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockTrackingPage)(nil).Render), w, r, targetURL, path)
+}
+
+// Tracks mocks base method.
+func (m *MockTrackingPage) Tracks(shortURL *entity.ShortURL) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tracks", shortURL)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Tracks indicates an expected call of Tracks.
+func (mr *MockTrackingPageMockRecorder) Tracks(shortURL any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShortURL", reflect.TypeOf((*MockShortURLUseCase)(nil).FindShortURL), ctx, alias)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tracks", reflect.TypeOf((*MockTrackingPage)(nil).Tracks), shortURL)
 }
@@ -1,4 +1,4 @@
-//go:generate mockgen -destination=./mocks/mock.go -package=mocks . UserUseCase,ShortURLUseCase
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . UserUseCase,ShortURLUseCase,EventBus,SplitUseCase,RedirectPage,TrackingPage
 
 /*
 Package handler implements HTTP request handlers for URL shortening operations.
@@ -8,29 +8,65 @@ It provides:
 - User authentication and session management
 - Request validation and error handling
 - Support for both single and batch URL operations
+- Publishing of served redirects for analytics and webhooks
+- Fallback to split short URLs routing traffic to several original URLs
+- Optional interstitial redirect page instead of redirect status
+- Optional tracking page recording clicks by transparent pixel
+- Original URL in JSON or plain text instead of redirect, negotiated by Accept header
 */
 package handler
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/gururuby/shortener/internal/config"
 	"github.com/gururuby/shortener/internal/domain/entity/shorturl"
 	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
 	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+	splitErrors "github.com/gururuby/shortener/internal/domain/usecase/split/errors"
+	userErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	auth "github.com/gururuby/shortener/internal/handler/http/auth"
+	httpErrors "github.com/gururuby/shortener/internal/handler/http/errors"
+	"github.com/gururuby/shortener/internal/infra/eventbus"
+	"github.com/gururuby/shortener/internal/infra/logger"
+	"github.com/gururuby/shortener/internal/middleware"
 )
 
 const (
-	authCookieName        = "Authorization"  // Name of the authentication cookie
-	createShortURLTimeout = time.Second * 30 // Timeout for URL creation operations
-	shortensPath          = "/"              // Path for URL shortening endpoint
-	shortenPath           = "/{alias}"       // Path pattern for URL redirection
+	shortensPath        = "/"                      // Path for URL shortening endpoint
+	shortenPath         = "/{alias}"               // Path pattern for URL redirection in the default namespace
+	namespacedPath      = "/{namespace}/{alias}"   // Path pattern for URL redirection in a namespace
+	linkHeaderName      = "Link"                   // Name of the header with canonical original URL
+	shortURLHeaderName  = "X-Short-URL"            // Name of the header with full short URL
+	namespaceHeader     = "X-Namespace"            // Name of the header with namespace of created short URL
+	trackingPixelPath   = "/t/{alias}"             // Path pattern of tracking pixel loaded by tracking page
+	namespacedPixelPath = "/t/{namespace}/{alias}" // Path pattern of tracking pixel of a short URL in a namespace
 )
 
+// Media types of short URL lookup responses negotiated by Accept header.
+const (
+	mediaTypeHTML = "text/html"        // Redirect, or redirect or tracking page if enabled
+	mediaTypeJSON = "application/json" // Original URL with alias and creation time
+	mediaTypeText = "text/plain"       // Original URL only
+)
+
+// lookupMediaTypes lists media types of lookup responses in order of preference on equal q-values,
+// so requests accepting anything are redirected.
+var lookupMediaTypes = []string{mediaTypeHTML, mediaTypeJSON, mediaTypeText}
+
+// transparentGIF is 1x1 transparent GIF served as tracking pixel.
+const transparentGIF = "GIF89a\x01\x00\x01\x00\x80\x00\x00\x00\x00\x00\xff\xff\xff" +
+	"\x21\xf9\x04\x01\x00\x00\x00\x00\x2c\x00\x00\x00\x00\x01\x00\x01\x00\x00\x02\x02\x44\x01\x00\x3b"
+
 // Router defines the interface for HTTP request routing.
 type Router interface {
 	// Post registers a handler for POST requests
@@ -41,12 +77,14 @@ type Router interface {
 
 // ShortURLUseCase defines the interface for URL shortening business logic.
 type ShortURLUseCase interface {
-	// CreateShortURL generates a shortened URL for the given original URL
-	CreateShortURL(ctx context.Context, user *userEntity.User, sourceURL string) (string, error)
-	// FindShortURL retrieves the original URL for a given short alias
-	FindShortURL(ctx context.Context, alias string) (string, error)
+	// CreateShortURL generates a shortened URL with the given options for the original URL
+	CreateShortURL(ctx context.Context, user *userEntity.User, sourceURL string, opts entity.CreateOptions) (string, error)
+	// FindShortURL retrieves the short URL for a given namespace and alias if user may access it
+	FindShortURL(ctx context.Context, namespace, alias string, user *userEntity.User) (*entity.ShortURL, error)
+	// GetShortURL retrieves the short URL for a given namespace and alias without side effects
+	GetShortURL(ctx context.Context, namespace, alias string) (*entity.ShortURL, error)
 	// BatchShortURLs processes multiple URLs in a single operation
-	BatchShortURLs(ctx context.Context, urls []entity.BatchShortURLInput) []entity.BatchShortURLOutput
+	BatchShortURLs(ctx context.Context, user *userEntity.User, urls []entity.BatchShortURLInput) ([]entity.BatchShortURLOutput, error)
 }
 
 // UserUseCase defines the interface for user management operations.
@@ -55,13 +93,61 @@ type UserUseCase interface {
 	Authenticate(ctx context.Context, token string) (*userEntity.User, error)
 	// Register creates a new user account
 	Register(ctx context.Context) (*userEntity.User, error)
+	// AuthenticateAPIKey verifies an API key and returns its owner
+	AuthenticateAPIKey(ctx context.Context, key string) (*userEntity.User, error)
+}
+
+// EventBus defines the interface for publishing served redirects to in-process subscribers.
+type EventBus interface {
+	// Publish queues the event for subscribers without waiting for them
+	Publish(event eventbus.Event)
+}
+
+// SplitUseCase defines the interface for resolving split short URLs.
+type SplitUseCase interface {
+	// Resolve picks a destination URL of the split in the namespace according to destination weights
+	Resolve(ctx context.Context, namespace, alias string) (string, error)
+	// RecordClick counts a redirect to a destination of the split in the namespace
+	RecordClick(ctx context.Context, namespace, alias, url string) error
+}
+
+// RedirectPage defines the interface for rendering interstitial page which redirects the browser.
+type RedirectPage interface {
+	// Render writes HTML page leading to targetURL
+	Render(w http.ResponseWriter, r *http.Request, targetURL string)
+}
+
+// TrackingPage defines the interface for rendering page which loads tracking pixel before redirecting the browser.
+type TrackingPage interface {
+	// Tracks reports whether redirect of the short URL is served as tracking page
+	Tracks(shortURL *entity.ShortURL) bool
+	// Render writes HTML page loading tracking pixel of the short URL path and leading to targetURL
+	Render(w http.ResponseWriter, r *http.Request, targetURL, path string)
 }
 
 // handler implements the HTTP request handlers for URL operations.
 type handler struct {
-	userUC UserUseCase     // User management service
-	urlUC  ShortURLUseCase // URL shortening service
-	router Router          // HTTP router
+	userUC   UserUseCase     // User management service
+	urlUC    ShortURLUseCase // URL shortening service
+	bus      EventBus        // Bus of served redirect events, nil disables click events
+	splitUC  SplitUseCase    // Split short URL service, nil disables splits
+	page     RedirectPage    // Redirect page served instead of redirect status, nil disables the page
+	tracking TrackingPage    // Tracking page served instead of redirect for tracked short URLs, nil disables tracking
+	router   Router          // HTTP router
+	baseURL  string          // Base URL of short URLs reported in X-Short-URL header
+	cfg      config.Server   // Endpoint timeouts
+}
+
+// unsafeURLResponse represents the response for URLs flagged by URL checker.
+type unsafeURLResponse struct {
+	Error string `json:"error"`
+}
+
+// originalURLResponse represents the response for short URL lookups accepting JSON.
+type originalURLResponse struct {
+	OriginalURL string `json:"original_url"`
+	Alias       string `json:"alias"`
+	CreatedAt   string `json:"created_at,omitempty"` // Creation time in RFC 3339 format, UTC
 }
 
 // Register initializes and registers all URL shortening handlers.
@@ -69,9 +155,25 @@ type handler struct {
 // - router: The HTTP router implementation
 // - urlUC: URL shortening service
 // - userUC: User management service
-func Register(router Router, urlUC ShortURLUseCase, userUC UserUseCase) {
-	h := handler{router: router, urlUC: urlUC, userUC: userUC}
-	h.router.Get(shortenPath, h.FindShortURL())
+// - bus: Bus of served redirect events, nil disables click events
+// - splitUC: Split short URL service, nil disables splits
+// - page: Redirect page served instead of redirect status, nil disables the page
+// - tracking: Tracking page served instead of redirect for tracked short URLs, nil disables tracking
+// - clickLimit: Middleware limiting clicks of aliases applied to redirects only, nil disables the limit
+// - baseURL: Base URL of short URLs
+// - cfg: Server configuration with endpoint timeouts
+func Register(router Router, urlUC ShortURLUseCase, userUC UserUseCase, bus EventBus, splitUC SplitUseCase, page RedirectPage, tracking TrackingPage, clickLimit func(http.Handler) http.Handler, baseURL string, cfg config.Server) {
+	h := handler{router: router, urlUC: urlUC, userUC: userUC, bus: bus, splitUC: splitUC, page: page, tracking: tracking, baseURL: baseURL, cfg: cfg}
+	if tracking != nil {
+		h.router.Get(trackingPixelPath, h.TrackingPixel())
+		h.router.Get(namespacedPixelPath, h.TrackingPixel())
+	}
+	redirect := h.FindShortURL()
+	if clickLimit != nil {
+		redirect = clickLimit(redirect).ServeHTTP
+	}
+	h.router.Get(shortenPath, redirect)
+	h.router.Get(namespacedPath, redirect)
 	h.router.Post(shortensPath, h.CreateShortURL())
 }
 
@@ -79,11 +181,15 @@ func Register(router Router, urlUC ShortURLUseCase, userUC UserUseCase) {
 // Returns an HTTP handler function that:
 // - Validates the request
 // - Authenticates/registers the user
-// - Creates the short URL
+// - Creates the short URL in the namespace passed in X-Namespace header, or in the default one
 // - Returns appropriate responses:
 //   - 201 Created for successful creation
 //   - 409 Conflict if URL already exists
+//   - 422 with JSON error if URL is flagged as unsafe
+//   - 403 if the user is deactivated
+//   - 429 if the user reached the quota of short URLs
 //   - 400/422 for invalid requests
+//   - 504 Gateway Timeout if creation takes longer than configured timeout
 func (h *handler) CreateShortURL() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var (
@@ -94,7 +200,7 @@ func (h *handler) CreateShortURL() http.HandlerFunc {
 			statusCode = http.StatusCreated
 		)
 
-		ctx, cancel := context.WithTimeout(r.Context(), createShortURLTimeout)
+		ctx, cancel := context.WithTimeout(r.Context(), h.cfg.CreateURLTimeout)
 		defer cancel()
 
 		if r.Method != http.MethodPost {
@@ -117,17 +223,40 @@ func (h *handler) CreateShortURL() http.HandlerFunc {
 			}
 		}(r.Body)
 
-		user, err = h.authUser(ctx, r, w)
+		user, err = auth.UserOrRegister(ctx, h.userUC, r, w)
 		if err != nil {
+			if errors.Is(err, userErrors.ErrUserInvalidAPIKey) {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			if errors.Is(err, userErrors.ErrUserDeactivated) {
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			}
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
-		shortURL, err = h.urlUC.CreateShortURL(r.Context(), user, sourceURL)
+		shortURL, err = h.urlUC.CreateShortURL(ctx, withNamespace(user, r), sourceURL, entity.CreateOptions{})
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			http.Error(w, httpErrors.ErrRequestTimeout.Error(), http.StatusGatewayTimeout)
+			return
+		}
 
 		if err != nil {
 			if errors.Is(err, ucErrors.ErrShortURLAlreadyExist) {
 				statusCode = http.StatusConflict
+			} else if errors.Is(err, ucErrors.ErrShortURLUnsafeContent) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				if err = json.NewEncoder(w).Encode(unsafeURLResponse{Error: err.Error()}); err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+				}
+				return
+			} else if errors.Is(err, ucErrors.ErrUserURLQuotaExceeded) {
+				http.Error(w, err.Error(), http.StatusTooManyRequests)
+				return
 			} else {
 				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 				return
@@ -144,68 +273,323 @@ func (h *handler) CreateShortURL() http.HandlerFunc {
 	}
 }
 
-// FindShortURL handles GET requests to redirect to original URLs.
+// FindShortURL handles GET and HEAD requests to original URLs.
 // Returns an HTTP handler function that:
 // - Validates the request
-// - Looks up the original URL
+// - Looks up the original URL by alias in the namespace from the path, or in the default one
 // - Returns appropriate responses:
-//   - 307 Temporary Redirect for successful lookups
+//   - 307 Temporary Redirect or 301 Moved Permanently for successful GET lookups,
+//     depending on redirect type of the short URL
+//   - 200 OK with tracking page for successful GET lookups of tracked short URLs
+//   - 200 OK with redirect page for successful GET lookups if the page is enabled
+//   - 200 OK with Location header for successful HEAD lookups,
+//     so that clients can check the alias without following the redirect
+//   - 200 OK with original URL in JSON or plain text for successful GET lookups
+//     preferring these types in Accept header, see negotiateResponse
+//   - 403 Forbidden for private URLs requested by anyone but the owner
 //   - 410 Gone for deleted URLs
+//   - 504 Gateway Timeout if lookup takes longer than configured timeout
 //   - 422 for other errors
+//
+// Served GET redirects are published as click events, subscribers record them for analytics and webhooks.
+// Clicks of tracked short URLs are published by TrackingPixel instead, when the browser loads the pixel of tracking page.
+//
+// Aliases unknown to short URLs of the default namespace are looked up among split short URLs, which always redirect
+// with 307 to a destination picked by weights; GET redirects are counted for the destination.
+//
+// HEAD lookups have no side effects, i.e. one-time URLs are not deleted.
+// The requesting user is authenticated if a token is passed, but never registered.
 func (h *handler) FindShortURL() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), h.cfg.ReadURLTimeout)
+		defer cancel()
+		r = r.WithContext(ctx)
+
+		user := auth.FindUser(h.userUC, r)
+		namespace, alias := lookupKey(r)
+
+		if r.Method == http.MethodHead {
+			shortURL, err := h.urlUC.GetShortURL(r.Context(), namespace, alias)
+			if err != nil {
+				if split := h.resolveSplit(r, err); split != nil {
+					h.setRedirectHeaders(w, split)
+					w.WriteHeader(http.StatusOK)
+					return
+				}
+				returnFindErrResponse(w, r, err)
+				return
+			}
+			if !shortURL.IsAccessibleBy(user) {
+				returnFindErrResponse(w, r, ucErrors.ErrShortURLForbidden)
+				return
+			}
+			h.setRedirectHeaders(w, shortURL)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
 		if r.Method != http.MethodGet {
 			http.Error(w, fmt.Sprintf("HTTP method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
 			return
 		}
-		result, err := h.urlUC.FindShortURL(r.Context(), r.URL.Path)
 
+		result, err := h.urlUC.FindShortURL(r.Context(), namespace, alias, user)
 		if err != nil {
-			if errors.Is(err, ucErrors.ErrShortURLDeleted) {
-				http.Error(w, err.Error(), http.StatusGone)
+			if result = h.resolveSplit(r, err); result == nil {
+				returnFindErrResponse(w, r, err)
 				return
 			}
-			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
-			return
+			h.recordSplitClick(r, result)
 		}
-		w.Header().Set("Location", result)
-		w.WriteHeader(http.StatusTemporaryRedirect)
+		h.setRedirectHeaders(w, result)
+		h.negotiateResponse(w, r, result)
 	}
 }
 
-// authUser handles user authentication via cookie or registration.
+// negotiateResponse writes the response of successful GET lookup in the media type preferred by Accept header.
+// Clients accepting JSON or plain text get the original URL in the body instead of the redirect,
+// others, including ones without Accept header, are redirected as usual.
 // Parameters:
-// - ctx: Context for cancellation/timeouts
-// - r: HTTP request
 // - w: HTTP response writer
+// - r: HTTP request of the lookup
+// - shortURL: Found short URL
+func (h *handler) negotiateResponse(w http.ResponseWriter, r *http.Request, shortURL *entity.ShortURL) {
+	w.Header().Add("Vary", "Accept")
+
+	mediaType := negotiateMediaType(r.Header.Get("Accept"))
+	if mediaType == mediaTypeHTML && h.tracking != nil && h.tracking.Tracks(shortURL) {
+		h.tracking.Render(w, r, shortURL.SourceURL, shortURL.Path())
+		return
+	}
+	h.recordClick(r, shortURL)
+
+	switch mediaType {
+	case mediaTypeJSON:
+		res := originalURLResponse{OriginalURL: shortURL.SourceURL, Alias: shortURL.Alias}
+		if !shortURL.CreatedAt.IsZero() {
+			res.CreatedAt = shortURL.CreatedAt.UTC().Format(time.RFC3339)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(res); err != nil {
+			logger.With(r.Context()).Error(err.Error())
+		}
+	case mediaTypeText:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, shortURL.SourceURL); err != nil {
+			logger.With(r.Context()).Error(err.Error())
+		}
+	default:
+		if h.page != nil {
+			h.page.Render(w, r, shortURL.SourceURL)
+			return
+		}
+		w.WriteHeader(shortURL.RedirectStatus())
+	}
+}
+
+// negotiateMediaType selects media type of lookup response from Accept header.
+// Media ranges like "text/*" and "*/*" match types not listed explicitly, types with q=0 are not acceptable.
+// Parameters:
+// - accept: Value of Accept request header
+// Returns:
+// - string: Lookup media type with the highest q-value, mediaTypeHTML if header is empty
+// or no lookup media type is acceptable
+func negotiateMediaType(accept string) string {
+	qValues := make(map[string]float64)
+	for _, part := range strings.Split(accept, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || strings.TrimSpace(key) != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				parsed = 0
+			}
+			q = parsed
+		}
+		qValues[name] = q
+	}
+
+	best, bestQ := mediaTypeHTML, 0.0
+	for _, mediaType := range lookupMediaTypes {
+		if q := mediaTypeQValue(qValues, mediaType); q > bestQ {
+			best, bestQ = mediaType, q
+		}
+	}
+	return best
+}
+
+// mediaTypeQValue returns q-value of the media type, the most specific matching media range wins.
+// Parameters:
+// - qValues: q-values by lower case media range
+// - mediaType: Media type like "text/html"
 // Returns:
-// - *userEntity.User: Authenticated user
-// - error: Authentication failure
-func (h *handler) authUser(ctx context.Context, r *http.Request, w http.ResponseWriter) (*userEntity.User, error) {
-	var (
-		authCookie *http.Cookie
-		user       *userEntity.User
-		err        error
-	)
-
-	authCookie, err = r.Cookie(authCookieName)
-	// If auth cookie was not passed
-	if err != nil && errors.Is(err, http.ErrNoCookie) {
-		// Register new User
-		if user, err = h.userUC.Register(ctx); err != nil {
-			return nil, err
-		}
-
-	} else { // If auth cookie exist, try to authenticate User
-		if user, err = h.userUC.Authenticate(ctx, authCookie.Value); err != nil {
-			// If auth cookie is invalid or user not found try to register new user
-			if user, err = h.userUC.Register(ctx); err != nil {
-				return nil, err
+// - float64: q-value, 0 if no media range matches
+func mediaTypeQValue(qValues map[string]float64, mediaType string) float64 {
+	if q, ok := qValues[mediaType]; ok {
+		return q
+	}
+	mainType, _, _ := strings.Cut(mediaType, "/")
+	if q, ok := qValues[mainType+"/*"]; ok {
+		return q
+	}
+	return qValues["*/*"]
+}
+
+// TrackingPixel handles GET requests of tracking pixel loaded by tracking page.
+// Returns an HTTP handler function that publishes the click of the short URL
+// with IP and User-Agent of the pixel request, and returns 200 OK with 1x1 transparent GIF.
+// The short URL is looked up without side effects to tell its owner to subscribers,
+// the click is published with the namespace and alias only if the lookup fails.
+//
+// The pixel requires no authentication, never redirects and is never cached,
+// so every load of tracking page is recorded.
+func (h *handler) TrackingPixel() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		namespace, alias := chi.URLParam(r, "namespace"), chi.URLParam(r, "alias")
+		if h.bus != nil {
+			ctx, cancel := context.WithTimeout(r.Context(), h.cfg.ReadURLTimeout)
+			shortURL, err := h.urlUC.GetShortURL(ctx, namespace, alias)
+			cancel()
+			if err != nil {
+				shortURL = &entity.ShortURL{Alias: alias, Namespace: namespace}
 			}
+			h.recordClick(r, shortURL)
+		}
+
+		w.Header().Set("Content-Type", "image/gif")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.WriteString(w, transparentGIF); err != nil {
+			logger.With(r.Context()).Error(err.Error())
 		}
 	}
-	// Setup auth cookie
-	http.SetCookie(w, &http.Cookie{Name: authCookieName, Value: user.AuthToken})
+}
+
+// lookupKey returns namespace and alias of the requested short URL.
+// Parameters:
+// - r: HTTP request of the lookup
+// Returns:
+// - string: Namespace from the path, empty for the default namespace
+// - string: Alias, the whole path for the default namespace
+func lookupKey(r *http.Request) (string, string) {
+	if namespace := chi.URLParam(r, "namespace"); namespace != "" {
+		return namespace, chi.URLParam(r, "alias")
+	}
+	return "", r.URL.Path
+}
 
-	return user, nil
+// withNamespace returns the user creating short URLs in the namespace passed with the request.
+// The user is copied, so the authenticated user is not modified.
+// Parameters:
+// - user: Authenticated user
+// - r: HTTP request
+// Returns:
+// - *userEntity.User: User with namespace from X-Namespace header, or the user itself if header is missing
+func withNamespace(user *userEntity.User, r *http.Request) *userEntity.User {
+	namespace := r.Header.Get(namespaceHeader)
+	if namespace == "" || user == nil {
+		return user
+	}
+	namespaced := *user
+	namespaced.Namespace = namespace
+	return &namespaced
+}
+
+// resolveSplit looks up a split short URL if the alias is unknown to short URLs of the namespace.
+// Parameters:
+// - r: HTTP request of the lookup
+// - findErr: Error of the short URL lookup
+// Returns:
+// - *entity.ShortURL: Short URL leading to the picked destination, nil if there is no split to redirect to
+func (h *handler) resolveSplit(r *http.Request, findErr error) *entity.ShortURL {
+	if h.splitUC == nil || !errors.Is(findErr, ucErrors.ErrShortURLSourceURLNotFound) {
+		return nil
+	}
+
+	namespace, alias := lookupKey(r)
+	alias = strings.TrimPrefix(alias, "/")
+	targetURL, err := h.splitUC.Resolve(r.Context(), namespace, alias)
+	if err != nil {
+		if !errors.Is(err, splitErrors.ErrSplitNotFound) {
+			logger.With(r.Context()).Error(err.Error())
+		}
+		return nil
+	}
+
+	return &entity.ShortURL{Alias: alias, Namespace: namespace, SourceURL: targetURL}
+}
+
+// recordSplitClick counts the redirect to a split destination in background.
+// Parameters:
+// - r: HTTP request of the redirect, recording is not cancelled with it
+// - split: Short URL returned by resolveSplit
+func (h *handler) recordSplitClick(r *http.Request, split *entity.ShortURL) {
+	ctx := context.WithoutCancel(r.Context())
+	go func() {
+		if err := h.splitUC.RecordClick(ctx, split.Namespace, split.Alias, split.SourceURL); err != nil {
+			logger.With(ctx).Error(err.Error())
+		}
+	}()
+}
+
+// setRedirectHeaders sets headers describing the short URL and its original URL:
+// Location and canonical Link with the original URL, X-Short-URL with the full short URL.
+// Parameters:
+// - w: HTTP response writer
+// - shortURL: Found short URL
+func (h *handler) setRedirectHeaders(w http.ResponseWriter, shortURL *entity.ShortURL) {
+	w.Header().Set("Location", shortURL.SourceURL)
+	w.Header().Set(linkHeaderName, fmt.Sprintf("<%s>; rel=\"canonical\"", shortURL.SourceURL))
+	w.Header().Set(shortURLHeaderName, h.baseURL+"/"+shortURL.Path())
+}
+
+// recordClick publishes the click of a short URL, subscribers handle it in background,
+// so analytics and webhooks never delay redirects.
+// Parameters:
+// - r: HTTP request of the redirect
+// - shortURL: Clicked short URL
+func (h *handler) recordClick(r *http.Request, shortURL *entity.ShortURL) {
+	if h.bus == nil {
+		return
+	}
+
+	h.bus.Publish(eventbus.URLClicked{
+		Alias:       shortURL.Alias,
+		OriginalURL: shortURL.SourceURL,
+		Namespace:   shortURL.Namespace,
+		IP:          middleware.ClientIP(r),
+		UserAgent:   r.UserAgent(),
+		UserID:      shortURL.UserID,
+	})
+}
+
+// returnFindErrResponse writes the error response for failed short URL lookup.
+// Parameters:
+// - w: HTTP response writer
+// - r: HTTP request of the lookup, its context tells whether the lookup timed out
+// - err: Lookup error
+func returnFindErrResponse(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(r.Context().Err(), context.DeadlineExceeded) {
+		http.Error(w, httpErrors.ErrRequestTimeout.Error(), http.StatusGatewayTimeout)
+		return
+	}
+	if errors.Is(err, ucErrors.ErrShortURLDeleted) {
+		http.Error(w, err.Error(), http.StatusGone)
+		return
+	}
+	if errors.Is(err, ucErrors.ErrShortURLForbidden) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusUnprocessableEntity)
 }
@@ -1,21 +1,38 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/gururuby/shortener/internal/config"
+	"github.com/gururuby/shortener/internal/domain/entity/shorturl"
 	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
 	ucErrors "github.com/gururuby/shortener/internal/domain/usecase/shorturl/errors"
+	splitErrors "github.com/gururuby/shortener/internal/domain/usecase/split/errors"
+	userErrors "github.com/gururuby/shortener/internal/domain/usecase/user/errors"
+	appHandler "github.com/gururuby/shortener/internal/handler/http/app"
 	"github.com/gururuby/shortener/internal/handler/http/shorturl/mocks"
+	"github.com/gururuby/shortener/internal/infra/eventbus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
+// testServerCfg contains endpoint timeouts long enough for mocked use cases
+var testServerCfg = config.Server{
+	CreateURLTimeout: time.Second,
+	BatchURLTimeout:  time.Second,
+	ReadURLTimeout:   time.Second,
+}
+
 func Test_CreateShortURL_OK(t *testing.T) {
 	var err error
 	var body []byte
@@ -27,12 +44,12 @@ func Test_CreateShortURL_OK(t *testing.T) {
 	userUC := mocks.NewMockUserUseCase(ctrl)
 
 	r := chi.NewRouter()
-	h := handler{router: r, urlUC: urlUC, userUC: userUC}
+	h := handler{router: r, urlUC: urlUC, userUC: userUC, cfg: testServerCfg}
 
 	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("https://example.com"))
 
 	userUC.EXPECT().Register(gomock.Any()).Return(user, nil).AnyTimes()
-	urlUC.EXPECT().CreateShortURL(gomock.Any(), user, "https://example.com").Return("http://localhost:8080/mock_alias", nil).Times(1)
+	urlUC.EXPECT().CreateShortURL(gomock.Any(), user, "https://example.com", entity.CreateOptions{}).Return("http://localhost:8080/mock_alias", nil).Times(1)
 
 	w := httptest.NewRecorder()
 	h.CreateShortURL()(w, req)
@@ -53,6 +70,132 @@ func Test_CreateShortURL_OK(t *testing.T) {
 	assert.Equal(t, "text/plain; charset=utf-8", resp.Header.Get("Content-Type"))
 }
 
+func Test_ShortURL_Timeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	user := &userEntity.User{ID: 1, AuthToken: "token"}
+
+	cfg := testServerCfg
+	cfg.CreateURLTimeout = time.Millisecond
+	cfg.ReadURLTimeout = time.Millisecond
+	r := chi.NewRouter()
+	Register(r, urlUC, userUC, nil, nil, nil, nil, nil, "", cfg)
+
+	userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil).AnyTimes()
+	urlUC.EXPECT().CreateShortURL(gomock.Any(), user, "https://example.com", entity.CreateOptions{}).
+		DoAndReturn(func(_ context.Context, _ *userEntity.User, _ string, _ entity.CreateOptions) (string, error) {
+			time.Sleep(10 * time.Millisecond)
+			return "http://localhost:8080/mock_alias", nil
+		})
+	urlUC.EXPECT().FindShortURL(gomock.Any(), "", "/mock_alias", user).
+		DoAndReturn(func(ctx context.Context, _, _ string, _ *userEntity.User) (*entity.ShortURL, error) {
+			time.Sleep(10 * time.Millisecond)
+			return nil, ctx.Err()
+		})
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{
+			name:   "when short URL creation exceeds timeout",
+			method: http.MethodPost,
+			path:   "/",
+			body:   "https://example.com",
+		},
+		{
+			name:   "when short URL lookup exceeds timeout",
+			method: http.MethodGet,
+			path:   "/mock_alias",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer token")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+			assert.Equal(t, "request timed out\n", w.Body.String())
+		})
+	}
+}
+
+func Test_CreateShortURL_Authenticated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+
+	user := &userEntity.User{ID: 1, AuthToken: "token"}
+	userUC := mocks.NewMockUserUseCase(ctrl)
+
+	r := chi.NewRouter()
+	h := handler{router: r, urlUC: urlUC, userUC: userUC, cfg: testServerCfg}
+
+	tests := []struct {
+		setAuth func(r *http.Request)
+		name    string
+	}{
+		{
+			name:    "when token passed in bearer header",
+			setAuth: func(r *http.Request) { r.Header.Set("Authorization", "Bearer token") },
+		},
+		{
+			name:    "when token passed in cookie",
+			setAuth: func(r *http.Request) { r.AddCookie(&http.Cookie{Name: "Authorization", Value: "token"}) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("https://example.com"))
+			tt.setAuth(req)
+
+			userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil).Times(1)
+			urlUC.EXPECT().CreateShortURL(gomock.Any(), user, "https://example.com", entity.CreateOptions{}).Return("http://localhost:8080/mock_alias", nil).Times(1)
+
+			w := httptest.NewRecorder()
+			h.CreateShortURL()(w, req)
+
+			resp := w.Result()
+
+			defer func() {
+				err := resp.Body.Close()
+				require.NoError(t, err)
+			}()
+
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		})
+	}
+}
+
+func Test_CreateShortURL_Deactivated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+
+	r := chi.NewRouter()
+	h := handler{router: r, urlUC: urlUC, userUC: userUC, cfg: testServerCfg}
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("https://example.com"))
+	req.Header.Set("Authorization", "Bearer token")
+
+	userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(nil, userErrors.ErrUserDeactivated).Times(1)
+
+	w := httptest.NewRecorder()
+	h.CreateShortURL()(w, req)
+
+	resp := w.Result()
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Empty(t, resp.Cookies(), "deactivated user must not be registered again")
+}
+
 func Test_CreateShortURL_Errors(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	urlUC := mocks.NewMockShortURLUseCase(ctrl)
@@ -100,6 +243,40 @@ func Test_CreateShortURL_Errors(t *testing.T) {
 				contentType: "text/plain; charset=utf-8",
 			},
 		},
+		{
+			name: "when use case unsafe URL error",
+			useCaseRes: useCaseResult{
+				res: "",
+				err: ucErrors.ErrShortURLUnsafeContent,
+			},
+			request: request{
+				method: http.MethodPost,
+				body:   "https://malware.test",
+				path:   "/",
+			},
+			response: response{
+				code:        http.StatusUnprocessableEntity,
+				body:        "{\"error\":\"URL flagged as unsafe\"}\n",
+				contentType: "application/json",
+			},
+		},
+		{
+			name: "when use case quota error",
+			useCaseRes: useCaseResult{
+				res: "",
+				err: ucErrors.ErrUserURLQuotaExceeded,
+			},
+			request: request{
+				method: http.MethodPost,
+				body:   "https://example.com/quota",
+				path:   "/",
+			},
+			response: response{
+				code:        http.StatusTooManyRequests,
+				body:        "URL quota exceeded\n",
+				contentType: "text/plain; charset=utf-8",
+			},
+		},
 		{
 			name: "when use case conflict error",
 			useCaseRes: useCaseResult{
@@ -137,11 +314,11 @@ func Test_CreateShortURL_Errors(t *testing.T) {
 			var body []byte
 
 			r := chi.NewRouter()
-			h := handler{router: r, urlUC: urlUC, userUC: userUC}
+			h := handler{router: r, urlUC: urlUC, userUC: userUC, cfg: testServerCfg}
 
 			req := httptest.NewRequest(tt.request.method, tt.request.path, strings.NewReader(tt.request.body))
 			userUC.EXPECT().Register(gomock.Any()).Return(user, nil).AnyTimes()
-			urlUC.EXPECT().CreateShortURL(gomock.Any(), user, tt.request.body).Return(tt.useCaseRes.res, tt.useCaseRes.err).AnyTimes()
+			urlUC.EXPECT().CreateShortURL(gomock.Any(), user, tt.request.body, entity.CreateOptions{}).Return(tt.useCaseRes.res, tt.useCaseRes.err).AnyTimes()
 
 			w := httptest.NewRecorder()
 			h.CreateShortURL()(w, req)
@@ -172,10 +349,10 @@ func Test_FindShortURL_OK(t *testing.T) {
 	urlUC := mocks.NewMockShortURLUseCase(ctrl)
 
 	r := chi.NewRouter()
-	h := handler{router: r, urlUC: urlUC}
+	h := handler{router: r, urlUC: urlUC, baseURL: "https://short.example.com", cfg: testServerCfg}
 
 	req := httptest.NewRequest(http.MethodGet, "/some_alias", nil)
-	urlUC.EXPECT().FindShortURL(req.Context(), "/some_alias").Return("https://ya.ru", nil)
+	urlUC.EXPECT().FindShortURL(gomock.Any(), "", "/some_alias", nil).Return(&entity.ShortURL{SourceURL: "https://ya.ru", Alias: "some_alias"}, nil)
 
 	w := httptest.NewRecorder()
 	h.FindShortURL()(w, req)
@@ -193,6 +370,682 @@ func Test_FindShortURL_OK(t *testing.T) {
 
 	require.NoError(t, err)
 	assert.Equal(t, "https://ya.ru", resp.Header.Get("Location"))
+	assert.Equal(t, `<https://ya.ru>; rel="canonical"`, resp.Header.Get("Link"))
+	assert.Equal(t, "https://short.example.com/some_alias", resp.Header.Get("X-Short-URL"))
+}
+
+func Test_FindShortURL_RedirectType(t *testing.T) {
+	tests := []struct {
+		name         string
+		redirectType int
+		code         int
+	}{
+		{
+			name: "when redirect type is not set",
+			code: http.StatusTemporaryRedirect,
+		},
+		{
+			name:         "when redirect is temporary",
+			redirectType: entity.RedirectTemporary,
+			code:         http.StatusTemporaryRedirect,
+		},
+		{
+			name:         "when redirect is permanent",
+			redirectType: entity.RedirectPermanent,
+			code:         http.StatusMovedPermanently,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			urlUC := mocks.NewMockShortURLUseCase(ctrl)
+			h := handler{router: chi.NewRouter(), urlUC: urlUC, cfg: testServerCfg}
+
+			req := httptest.NewRequest(http.MethodGet, "/alias", nil)
+			urlUC.EXPECT().FindShortURL(gomock.Any(), "", "/alias", nil).
+				Return(&entity.ShortURL{SourceURL: "https://ya.ru", RedirectType: tt.redirectType}, nil)
+
+			w := httptest.NewRecorder()
+			h.FindShortURL()(w, req)
+
+			resp := w.Result()
+			require.NoError(t, resp.Body.Close())
+
+			assert.Equal(t, tt.code, resp.StatusCode)
+			assert.Equal(t, "https://ya.ru", resp.Header.Get("Location"))
+		})
+	}
+}
+
+func Test_FindShortURL_ContentNegotiation(t *testing.T) {
+	createdAt := time.Date(2025, 9, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+		body        string
+		code        int
+	}{
+		{
+			name:        "when JSON is accepted",
+			accept:      "application/json",
+			code:        http.StatusOK,
+			contentType: "application/json",
+			body:        `{"original_url":"https://ya.ru","alias":"abc","created_at":"2025-09-01T12:30:00Z"}`,
+		},
+		{
+			name:        "when plain text is accepted",
+			accept:      "text/plain",
+			code:        http.StatusOK,
+			contentType: "text/plain; charset=utf-8",
+			body:        "https://ya.ru",
+		},
+		{
+			name:   "when HTML is accepted",
+			accept: "text/html",
+			code:   http.StatusTemporaryRedirect,
+		},
+		{
+			name: "when Accept header is missing",
+			code: http.StatusTemporaryRedirect,
+		},
+		{
+			name:   "when any type is accepted",
+			accept: "*/*",
+			code:   http.StatusTemporaryRedirect,
+		},
+		{
+			name:   "when HTML is preferred to JSON",
+			accept: "application/json;q=0.9, text/html;q=1.0",
+			code:   http.StatusTemporaryRedirect,
+		},
+		{
+			name:        "when JSON is preferred to HTML",
+			accept:      "text/html;q=0.5, application/json",
+			code:        http.StatusOK,
+			contentType: "application/json",
+			body:        `{"original_url":"https://ya.ru","alias":"abc","created_at":"2025-09-01T12:30:00Z"}`,
+		},
+		{
+			name:        "when HTML is not acceptable",
+			accept:      "text/*, text/html;q=0",
+			code:        http.StatusOK,
+			contentType: "text/plain; charset=utf-8",
+			body:        "https://ya.ru",
+		},
+		{
+			name:   "when no lookup type is accepted",
+			accept: "image/png",
+			code:   http.StatusTemporaryRedirect,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			urlUC := mocks.NewMockShortURLUseCase(ctrl)
+			h := handler{router: chi.NewRouter(), urlUC: urlUC, cfg: testServerCfg}
+
+			req := httptest.NewRequest(http.MethodGet, "/abc", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			urlUC.EXPECT().FindShortURL(gomock.Any(), "", "/abc", nil).
+				Return(&entity.ShortURL{Alias: "abc", SourceURL: "https://ya.ru", CreatedAt: createdAt}, nil)
+
+			w := httptest.NewRecorder()
+			h.FindShortURL()(w, req)
+
+			assert.Equal(t, tt.code, w.Code)
+			assert.Equal(t, "Accept", w.Header().Get("Vary"))
+			if tt.code != http.StatusOK {
+				assert.Equal(t, "https://ya.ru", w.Header().Get("Location"))
+				return
+			}
+			assert.Equal(t, tt.contentType, w.Header().Get("Content-Type"))
+			if tt.contentType == "application/json" {
+				assert.JSONEq(t, tt.body, w.Body.String())
+			} else {
+				assert.Equal(t, tt.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func Test_FindShortURL_HEAD(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+
+	r := chi.NewRouter()
+	r.Use(chiMiddleware.GetHead)
+	Register(r, urlUC, userUC, nil, nil, nil, nil, nil, "", testServerCfg)
+
+	type response struct {
+		location string
+		code     int
+	}
+
+	tests := []struct {
+		ucErr error
+		name  string
+		alias string
+		get   response
+		head  response
+	}{
+		{
+			name:  "when alias is valid",
+			alias: "valid",
+			get:   response{code: http.StatusTemporaryRedirect, location: "https://ya.ru"},
+			head:  response{code: http.StatusOK, location: "https://ya.ru"},
+		},
+		{
+			name:  "when alias was deleted",
+			alias: "deleted",
+			ucErr: ucErrors.ErrShortURLDeleted,
+			get:   response{code: http.StatusGone},
+			head:  response{code: http.StatusGone},
+		},
+		{
+			name:  "when alias does not exist",
+			alias: "unknown",
+			ucErr: ucErrors.ErrShortURLSourceURLNotFound,
+			get:   response{code: http.StatusUnprocessableEntity},
+			head:  response{code: http.StatusUnprocessableEntity},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var shortURL *entity.ShortURL
+			if tt.ucErr == nil {
+				shortURL = &entity.ShortURL{Alias: tt.alias, SourceURL: "https://ya.ru"}
+			}
+
+			urlUC.EXPECT().FindShortURL(gomock.Any(), "", "/"+tt.alias, nil).Return(shortURL, tt.ucErr)
+			urlUC.EXPECT().GetShortURL(gomock.Any(), "", "/"+tt.alias).Return(shortURL, tt.ucErr)
+
+			for method, want := range map[string]response{http.MethodGet: tt.get, http.MethodHead: tt.head} {
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, httptest.NewRequest(method, "/"+tt.alias, nil))
+
+				resp := w.Result()
+				require.NoError(t, resp.Body.Close())
+
+				assert.Equal(t, want.code, resp.StatusCode, method)
+				assert.Equal(t, want.location, resp.Header.Get("Location"), method)
+			}
+		})
+	}
+}
+
+func Test_ShortURL_Namespace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	user := &userEntity.User{ID: 1, AuthToken: "token"}
+
+	r := chi.NewRouter()
+	Register(r, urlUC, userUC, nil, nil, nil, nil, nil, "http://localhost:8080", testServerCfg)
+
+	t.Run("when short URL is created in namespace from header", func(t *testing.T) {
+		userUC.EXPECT().Authenticate(gomock.Any(), "token").Return(user, nil)
+		urlUC.EXPECT().CreateShortURL(gomock.Any(), &userEntity.User{ID: 1, AuthToken: "token", Namespace: "team-a"}, "https://example.com", entity.CreateOptions{}).
+			Return("http://localhost:8080/team-a/abc", nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("https://example.com"))
+		req.Header.Set("Authorization", "Bearer token")
+		req.Header.Set("X-Namespace", "team-a")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "http://localhost:8080/team-a/abc", w.Body.String())
+		assert.Empty(t, user.Namespace)
+	})
+
+	t.Run("when short URL is found in namespace from path", func(t *testing.T) {
+		urlUC.EXPECT().FindShortURL(gomock.Any(), "team-a", "abc", nil).
+			Return(&entity.ShortURL{Alias: "abc", SourceURL: "https://go.dev", Namespace: "team-a"}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/team-a/abc", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+		assert.Equal(t, "https://go.dev", w.Header().Get("Location"))
+		assert.Equal(t, "http://localhost:8080/team-a/abc", w.Header().Get("X-Short-URL"))
+	})
+
+	t.Run("when alias is unknown in namespace", func(t *testing.T) {
+		urlUC.EXPECT().FindShortURL(gomock.Any(), "team-a", "missing", nil).Return(nil, ucErrors.ErrShortURLSourceURLNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/team-a/missing", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+}
+
+func Test_FindShortURL_RecordsClick(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	bus := mocks.NewMockEventBus(ctrl)
+
+	r := chi.NewRouter()
+	r.Use(chiMiddleware.GetHead)
+	Register(r, urlUC, userUC, bus, nil, nil, nil, nil, "", testServerCfg)
+
+	shortURL := &entity.ShortURL{Alias: "abc", SourceURL: "https://ya.ru", Namespace: "team-a", UserID: 1}
+	urlUC.EXPECT().FindShortURL(gomock.Any(), "", "/abc", nil).Return(shortURL, nil)
+	urlUC.EXPECT().GetShortURL(gomock.Any(), "", "/abc").Return(shortURL, nil)
+	bus.EXPECT().Publish(eventbus.URLClicked{
+		Alias:       "abc",
+		OriginalURL: "https://ya.ru",
+		Namespace:   "team-a",
+		IP:          "203.0.113.7",
+		UserAgent:   "curl/8.0",
+		UserID:      1,
+	})
+
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		req := httptest.NewRequest(method, "/abc", nil)
+		req.Header.Set("X-Real-IP", "203.0.113.7")
+		req.Header.Set("User-Agent", "curl/8.0")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		resp := w.Result()
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, "https://ya.ru", resp.Header.Get("Location"), method)
+	}
+}
+
+func Test_FindShortURL_OmitsRegion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+
+	r := chi.NewRouter()
+	Register(r, urlUC, userUC, nil, nil, nil, nil, nil, "", testServerCfg)
+
+	urlUC.EXPECT().FindShortURL(gomock.Any(), "", "/abc", nil).Return(&entity.ShortURL{Alias: "abc", SourceURL: "https://ya.ru", CreatedInRegion: "us-east-1"}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/abc", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	resp := w.Result()
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode)
+	assert.Equal(t, "https://ya.ru", resp.Header.Get("Location"))
+	assert.NotContains(t, string(body), "us-east-1")
+	for name, values := range resp.Header {
+		assert.NotContains(t, values, "us-east-1", name)
+	}
+}
+
+func Test_FindShortURL_RedirectPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	page := mocks.NewMockRedirectPage(ctrl)
+
+	r := chi.NewRouter()
+	r.Use(chiMiddleware.GetHead)
+	Register(r, urlUC, userUC, nil, nil, page, nil, nil, "", testServerCfg)
+
+	shortURL := &entity.ShortURL{Alias: "abc", SourceURL: "https://ya.ru", RedirectType: entity.RedirectPermanent}
+
+	t.Run("when short URL is followed", func(t *testing.T) {
+		urlUC.EXPECT().FindShortURL(gomock.Any(), "", "/abc", nil).Return(shortURL, nil)
+		page.EXPECT().Render(gomock.Any(), gomock.Any(), "https://ya.ru").Do(func(w http.ResponseWriter, _ *http.Request, _ string) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/abc", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "/abc", w.Header().Get("X-Short-URL"))
+	})
+
+	t.Run("when short URL is checked", func(t *testing.T) {
+		urlUC.EXPECT().GetShortURL(gomock.Any(), "", "/abc").Return(shortURL, nil)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodHead, "/abc", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "https://ya.ru", w.Header().Get("Location"))
+	})
+
+	t.Run("when short URL is not found", func(t *testing.T) {
+		urlUC.EXPECT().FindShortURL(gomock.Any(), "", "/abc", nil).Return(nil, ucErrors.ErrShortURLSourceURLNotFound)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/abc", nil))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+}
+
+func Test_FindShortURL_TrackingPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	bus := mocks.NewMockEventBus(ctrl)
+
+	r := chi.NewRouter()
+	Register(r, urlUC, userUC, bus, nil, nil, appHandler.NewTrackingPage(false), nil, "", testServerCfg)
+
+	t.Run("when tracked short URL is followed", func(t *testing.T) {
+		urlUC.EXPECT().FindShortURL(gomock.Any(), "", "/abc", nil).Return(&entity.ShortURL{Alias: "abc", SourceURL: "https://ya.ru", IsTracked: true}, nil)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/abc", nil))
+
+		body := w.Body.String()
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, body, `<meta http-equiv="refresh" content="1;url=https://ya.ru">`)
+		assert.Contains(t, body, `<img src="/t/abc" width="1" height="1" alt="">`)
+	})
+
+	t.Run("when tracked short URL in namespace is followed", func(t *testing.T) {
+		urlUC.EXPECT().FindShortURL(gomock.Any(), "team", "abc", nil).Return(&entity.ShortURL{Alias: "abc", Namespace: "team", SourceURL: "https://ya.ru", IsTracked: true}, nil)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/team/abc", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `<img src="/t/team/abc" width="1" height="1" alt="">`)
+	})
+
+	t.Run("when tracked short URL is looked up in JSON", func(t *testing.T) {
+		urlUC.EXPECT().FindShortURL(gomock.Any(), "", "/abc", nil).Return(&entity.ShortURL{Alias: "abc", SourceURL: "https://ya.ru", IsTracked: true}, nil)
+		bus.EXPECT().Publish(gomock.AssignableToTypeOf(eventbus.URLClicked{}))
+
+		req := httptest.NewRequest(http.MethodGet, "/abc", nil)
+		req.Header.Set("Accept", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"original_url":"https://ya.ru","alias":"abc"}`, w.Body.String())
+	})
+
+	t.Run("when short URL is not tracked", func(t *testing.T) {
+		urlUC.EXPECT().FindShortURL(gomock.Any(), "", "/abc", nil).Return(&entity.ShortURL{Alias: "abc", SourceURL: "https://ya.ru"}, nil)
+		bus.EXPECT().Publish(gomock.AssignableToTypeOf(eventbus.URLClicked{}))
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/abc", nil))
+
+		assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+		assert.Equal(t, "https://ya.ru", w.Header().Get("Location"))
+	})
+}
+
+func Test_TrackingPixel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	bus := mocks.NewMockEventBus(ctrl)
+
+	r := chi.NewRouter()
+	Register(r, urlUC, userUC, bus, nil, nil, appHandler.NewTrackingPage(false), nil, "", testServerCfg)
+
+	tests := []struct {
+		lookupErr error
+		shortURL  *entity.ShortURL
+		event     eventbus.URLClicked
+		name      string
+		path      string
+		namespace string
+	}{
+		{
+			name:     "when short URL is found",
+			path:     "/t/abc",
+			shortURL: &entity.ShortURL{Alias: "abc", SourceURL: "https://ya.ru", UserID: 1, IsTracked: true},
+			event:    eventbus.URLClicked{Alias: "abc", OriginalURL: "https://ya.ru", IP: "203.0.113.7", UserAgent: "Mozilla/5.0", UserID: 1},
+		},
+		{
+			name:      "when short URL in namespace is found",
+			path:      "/t/team/abc",
+			namespace: "team",
+			shortURL:  &entity.ShortURL{Alias: "abc", Namespace: "team", SourceURL: "https://ya.ru", UserID: 1, IsTracked: true},
+			event:     eventbus.URLClicked{Alias: "abc", Namespace: "team", OriginalURL: "https://ya.ru", IP: "203.0.113.7", UserAgent: "Mozilla/5.0", UserID: 1},
+		},
+		{
+			name:      "when short URL lookup fails",
+			path:      "/t/team/abc",
+			namespace: "team",
+			lookupErr: ucErrors.ErrShortURLDeleted,
+			event:     eventbus.URLClicked{Alias: "abc", Namespace: "team", IP: "203.0.113.7", UserAgent: "Mozilla/5.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urlUC.EXPECT().GetShortURL(gomock.Any(), tt.namespace, "abc").Return(tt.shortURL, tt.lookupErr)
+			bus.EXPECT().Publish(tt.event)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("X-Real-IP", "203.0.113.7")
+			req.Header.Set("User-Agent", "Mozilla/5.0")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "image/gif", w.Header().Get("Content-Type"))
+			assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+			assert.Empty(t, w.Header().Get("Location"))
+			assert.Len(t, w.Body.Bytes(), 43)
+			assert.Equal(t, []byte(transparentGIF), w.Body.Bytes())
+		})
+	}
+}
+
+func Test_FindShortURL_Split(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+	splitUC := mocks.NewMockSplitUseCase(ctrl)
+
+	r := chi.NewRouter()
+	r.Use(chiMiddleware.GetHead)
+	Register(r, urlUC, userUC, nil, splitUC, nil, nil, nil, "http://localhost:8080", testServerCfg)
+
+	t.Run("when split is followed", func(t *testing.T) {
+		recorded := make(chan struct{})
+		urlUC.EXPECT().FindShortURL(gomock.Any(), "", "/ab-test", nil).Return(nil, ucErrors.ErrShortURLSourceURLNotFound)
+		splitUC.EXPECT().Resolve(gomock.Any(), "", "ab-test").Return("https://v2.com", nil)
+		splitUC.EXPECT().RecordClick(gomock.Any(), "", "ab-test", "https://v2.com").DoAndReturn(func(_ context.Context, _, _, _ string) error {
+			close(recorded)
+			return nil
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ab-test", nil))
+
+		assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+		assert.Equal(t, "https://v2.com", w.Header().Get("Location"))
+		assert.Equal(t, "http://localhost:8080/ab-test", w.Header().Get("X-Short-URL"))
+
+		select {
+		case <-recorded:
+		case <-time.After(time.Second):
+			require.FailNow(t, "click is not recorded")
+		}
+	})
+
+	t.Run("when split in namespace is followed", func(t *testing.T) {
+		recorded := make(chan struct{})
+		urlUC.EXPECT().FindShortURL(gomock.Any(), "team", "ab-test", nil).Return(nil, ucErrors.ErrShortURLSourceURLNotFound)
+		splitUC.EXPECT().Resolve(gomock.Any(), "team", "ab-test").Return("https://v1.com", nil)
+		splitUC.EXPECT().RecordClick(gomock.Any(), "team", "ab-test", "https://v1.com").DoAndReturn(func(_ context.Context, _, _, _ string) error {
+			close(recorded)
+			return nil
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/team/ab-test", nil))
+
+		assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+		assert.Equal(t, "https://v1.com", w.Header().Get("Location"))
+		assert.Equal(t, "http://localhost:8080/team/ab-test", w.Header().Get("X-Short-URL"))
+
+		select {
+		case <-recorded:
+		case <-time.After(time.Second):
+			require.FailNow(t, "click is not recorded")
+		}
+	})
+
+	t.Run("when split is checked", func(t *testing.T) {
+		urlUC.EXPECT().GetShortURL(gomock.Any(), "", "/ab-test").Return(nil, ucErrors.ErrShortURLSourceURLNotFound)
+		splitUC.EXPECT().Resolve(gomock.Any(), "", "ab-test").Return("https://v1.com", nil)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodHead, "/ab-test", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "https://v1.com", w.Header().Get("Location"))
+	})
+
+	t.Run("when alias is unknown", func(t *testing.T) {
+		urlUC.EXPECT().FindShortURL(gomock.Any(), "", "/unknown", nil).Return(nil, ucErrors.ErrShortURLSourceURLNotFound)
+		splitUC.EXPECT().Resolve(gomock.Any(), "", "unknown").Return("", splitErrors.ErrSplitNotFound)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("when short URL is deleted", func(t *testing.T) {
+		urlUC.EXPECT().FindShortURL(gomock.Any(), "", "/deleted", nil).Return(nil, ucErrors.ErrShortURLDeleted)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/deleted", nil))
+
+		assert.Equal(t, http.StatusGone, w.Code)
+	})
+}
+
+func Test_FindShortURL_Private(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+
+	r := chi.NewRouter()
+	r.Use(chiMiddleware.GetHead)
+	Register(r, urlUC, userUC, nil, nil, nil, nil, nil, "", testServerCfg)
+
+	owner := &userEntity.User{ID: 1}
+	stranger := &userEntity.User{ID: 2}
+
+	tests := []struct {
+		user     *userEntity.User
+		ucErr    error
+		name     string
+		alias    string
+		token    string
+		location string
+		code     int
+	}{
+		{
+			name:     "when owner accesses private URL",
+			alias:    "private",
+			token:    "owner",
+			user:     owner,
+			location: "https://ya.ru",
+			code:     http.StatusTemporaryRedirect,
+		},
+		{
+			name:  "when non-owner accesses private URL",
+			alias: "private",
+			token: "stranger",
+			user:  stranger,
+			ucErr: ucErrors.ErrShortURLForbidden,
+			code:  http.StatusForbidden,
+		},
+		{
+			name:  "when unauthenticated user accesses private URL",
+			alias: "private",
+			ucErr: ucErrors.ErrShortURLForbidden,
+			code:  http.StatusForbidden,
+		},
+		{
+			name:  "when token is invalid",
+			alias: "private",
+			token: "invalid",
+			ucErr: ucErrors.ErrShortURLForbidden,
+			code:  http.StatusForbidden,
+		},
+		{
+			name:     "when anyone accesses public URL",
+			alias:    "public",
+			token:    "stranger",
+			user:     stranger,
+			location: "https://ya.ru",
+			code:     http.StatusTemporaryRedirect,
+		},
+	}
+
+	userUC.EXPECT().Authenticate(gomock.Any(), "owner").Return(owner, nil).AnyTimes()
+	userUC.EXPECT().Authenticate(gomock.Any(), "stranger").Return(stranger, nil).AnyTimes()
+	userUC.EXPECT().Authenticate(gomock.Any(), "invalid").Return(nil, errors.New("invalid token")).AnyTimes()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/"+tt.alias, nil)
+			if tt.token != "" {
+				req.AddCookie(&http.Cookie{Name: "Authorization", Value: tt.token})
+			}
+
+			var shortURL *entity.ShortURL
+			if tt.ucErr == nil {
+				shortURL = &entity.ShortURL{Alias: tt.alias, SourceURL: tt.location}
+			}
+			urlUC.EXPECT().FindShortURL(gomock.Any(), "", "/"+tt.alias, tt.user).Return(shortURL, tt.ucErr)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			require.NoError(t, resp.Body.Close())
+
+			assert.Equal(t, tt.code, resp.StatusCode)
+			assert.Equal(t, tt.location, resp.Header.Get("Location"))
+			assert.Empty(t, resp.Cookies(), "lookup must not register users")
+		})
+	}
+
+	t.Run("when non-owner checks private URL via HEAD", func(t *testing.T) {
+		shortURL := &entity.ShortURL{Alias: "private", SourceURL: "https://ya.ru", UserID: owner.ID, Visibility: entity.VisibilityPrivate}
+		urlUC.EXPECT().GetShortURL(gomock.Any(), "", "/private").Return(shortURL, nil)
+
+		req := httptest.NewRequest(http.MethodHead, "/private", nil)
+		req.Header.Set("Authorization", "Bearer stranger")
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		resp := w.Result()
+		require.NoError(t, resp.Body.Close())
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get("Location"))
+	})
 }
 
 func Test_FindShortURLErrors(t *testing.T) {
@@ -212,7 +1065,7 @@ func Test_FindShortURLErrors(t *testing.T) {
 
 	type useCaseResult struct {
 		err error
-		res string
+		res *entity.ShortURL
 	}
 
 	tests := []struct {
@@ -224,7 +1077,7 @@ func Test_FindShortURLErrors(t *testing.T) {
 		{
 			name: "when use case returns some error",
 			useCaseRes: useCaseResult{
-				res: "",
+				res: nil,
 				err: ucErrors.ErrShortURLEmptyAlias,
 			},
 			request: request{
@@ -252,7 +1105,7 @@ func Test_FindShortURLErrors(t *testing.T) {
 		{
 			name: "when short url was deleted",
 			useCaseRes: useCaseResult{
-				res: "",
+				res: nil,
 				err: ucErrors.ErrShortURLDeleted,
 			},
 			request: request{
@@ -273,10 +1126,10 @@ func Test_FindShortURLErrors(t *testing.T) {
 			var body []byte
 
 			r := chi.NewRouter()
-			h := handler{router: r, urlUC: urlUC}
+			h := handler{router: r, urlUC: urlUC, cfg: testServerCfg}
 
 			req := httptest.NewRequest(tt.request.method, tt.request.path, nil)
-			urlUC.EXPECT().FindShortURL(req.Context(), tt.request.path).Return(tt.useCaseRes.res, tt.useCaseRes.err).AnyTimes()
+			urlUC.EXPECT().FindShortURL(gomock.Any(), "", tt.request.path, nil).Return(tt.useCaseRes.res, tt.useCaseRes.err).AnyTimes()
 
 			w := httptest.NewRecorder()
 
@@ -295,6 +1148,41 @@ func Test_FindShortURLErrors(t *testing.T) {
 			require.NoError(t, err)
 
 			assert.Equal(t, tt.response.body, string(body))
+			assert.Empty(t, resp.Header.Get("Location"))
+			assert.Empty(t, resp.Header.Get("Link"))
+			assert.Empty(t, resp.Header.Get("X-Short-URL"))
 		})
 	}
 }
+
+func Test_FindShortURL_ClickLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	urlUC := mocks.NewMockShortURLUseCase(ctrl)
+	userUC := mocks.NewMockUserUseCase(ctrl)
+
+	var limited []string
+	clickLimit := func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limited = append(limited, r.URL.Path)
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+	}
+
+	r := chi.NewRouter()
+	Register(r, urlUC, userUC, nil, nil, nil, appHandler.NewTrackingPage(false), clickLimit, "", testServerCfg)
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{path: "/abc", want: http.StatusTooManyRequests},
+		{path: "/team-a/abc", want: http.StatusTooManyRequests},
+		{path: "/t/abc", want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		assert.Equal(t, tt.want, w.Code, tt.path)
+	}
+	assert.Equal(t, []string{"/abc", "/team-a/abc"}, limited)
+}
@@ -15,10 +15,16 @@ package db
 import (
 	"context"
 	"log"
+	"time"
 
 	"github.com/gururuby/shortener/internal/config"
+	analyticsEntity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	namespaceEntity "github.com/gururuby/shortener/internal/domain/entity/namespace"
 	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	splitEntity "github.com/gururuby/shortener/internal/domain/entity/split"
+	tagEntity "github.com/gururuby/shortener/internal/domain/entity/tag"
 	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	webhookEntity "github.com/gururuby/shortener/internal/domain/entity/webhook"
 	fileDB "github.com/gururuby/shortener/internal/infra/db/file"
 	memoryDB "github.com/gururuby/shortener/internal/infra/db/memory"
 	nullDB "github.com/gururuby/shortener/internal/infra/db/null"
@@ -28,8 +34,8 @@ import (
 // DB defines the interface for all database operations in the application.
 // Implementations must provide these methods for different storage backends.
 type DB interface {
-	// FindShortURL retrieves a short URL by its alias
-	FindShortURL(ctx context.Context, alias string) (*shortURLEntity.ShortURL, error)
+	// FindShortURL retrieves a short URL by its namespace and alias
+	FindShortURL(ctx context.Context, namespace, alias string) (*shortURLEntity.ShortURL, error)
 
 	// SaveShortURL stores a new short URL
 	SaveShortURL(ctx context.Context, shortURL *shortURLEntity.ShortURL) (*shortURLEntity.ShortURL, error)
@@ -40,12 +46,114 @@ type DB interface {
 	// FindUserURLs retrieves all short URLs belonging to a user
 	FindUserURLs(ctx context.Context, id int) ([]*shortURLEntity.ShortURL, error)
 
+	// FindUserURLsPaginated retrieves a page of short URLs belonging to a user and their total count
+	FindUserURLsPaginated(ctx context.Context, id, offset, limit int) ([]*shortURLEntity.ShortURL, int64, error)
+
+	// SearchUserURLs retrieves a page of user's short URLs whose original URL contains the query and their total count
+	SearchUserURLs(ctx context.Context, userID int, query string, offset, limit int) ([]*shortURLEntity.ShortURL, int64, error)
+
+	// CountUserURLs returns the number of not deleted short URLs of a user, 0 for anonymous ones
+	CountUserURLs(ctx context.Context, userID int) (int64, error)
+
+	// FindUserURLsCursor retrieves user's short URLs with ID greater than afterID and the next cursor
+	FindUserURLsCursor(ctx context.Context, id, afterID, limit int) ([]*shortURLEntity.ShortURL, int, error)
+
 	// MarkURLAsDeleted marks the specified URLs as deleted for a user
-	MarkURLAsDeleted(ctx context.Context, userID int, aliases []string) error
+	MarkURLAsDeleted(ctx context.Context, userID int, namespace string, aliases []string) error
+
+	// RestoreURL clears the deletion mark of a short URL of a user
+	RestoreURL(ctx context.Context, userID int, namespace, alias string) error
+
+	// UpdateURLTarget replaces the original URL of a short URL of a user saving the previous one to history
+	UpdateURLTarget(ctx context.Context, userID int, namespace, alias, newURL string) error
+
+	// FindURLHistory retrieves previous original URLs of a short URL, the most recent first
+	FindURLHistory(ctx context.Context, namespace, alias string) ([]*shortURLEntity.HistoryEntry, error)
 
 	// SaveUser creates and stores a new user
 	SaveUser(ctx context.Context) (*userEntity.User, error)
 
+	// SetUserActive activates or deactivates a user
+	SetUserActive(ctx context.Context, userID int, isActive bool) error
+
+	// SetUserCustomDomain sets the custom domain of a user, empty to remove it
+	SetUserCustomDomain(ctx context.Context, userID int, customDomain string) error
+
+	// CountURLs returns the number of all short URLs, including deleted ones
+	CountURLs(ctx context.Context) (int64, error)
+
+	// CountDeletedURLs returns the number of short URLs marked as deleted
+	CountDeletedURLs(ctx context.Context) (int64, error)
+
+	// CountUsers returns the number of registered users
+	CountUsers(ctx context.Context) (int64, error)
+
+	// SaveTag stores a new tag of a user
+	SaveTag(ctx context.Context, tag *tagEntity.Tag) (*tagEntity.Tag, error)
+
+	// FindTagsByUser retrieves all tags of a user
+	FindTagsByUser(ctx context.Context, userID int) ([]*tagEntity.Tag, error)
+
+	// FindURLTags retrieves tags assigned to a short URL of a user
+	FindURLTags(ctx context.Context, userID int, namespace, alias string) ([]*tagEntity.Tag, error)
+
+	// AssignTag associates a tag of a user with a short URL of the same user
+	AssignTag(ctx context.Context, userID int, namespace, alias, name string) error
+
+	// RemoveTag removes association of a tag of a user with a short URL
+	RemoveTag(ctx context.Context, userID int, namespace, alias, name string) error
+
+	// FindURLsByTag retrieves short URLs of a user having the tag assigned
+	FindURLsByTag(ctx context.Context, userID int, name string) ([]*shortURLEntity.ShortURL, error)
+
+	// SaveWebhook stores a new webhook of a user
+	SaveWebhook(ctx context.Context, webhook *webhookEntity.Webhook) (*webhookEntity.Webhook, error)
+
+	// FindWebhooksByUser retrieves all webhooks of a user
+	FindWebhooksByUser(ctx context.Context, userID int) ([]*webhookEntity.Webhook, error)
+
+	// DeleteWebhook removes a webhook of a user
+	DeleteWebhook(ctx context.Context, userID, id int) error
+
+	// SaveAPIKey stores hash of a new API key of a user
+	SaveAPIKey(ctx context.Context, userID int, keyHash string) error
+
+	// FindUserByAPIKey retrieves the owner of a not revoked API key by the key hash
+	FindUserByAPIKey(ctx context.Context, keyHash string) (*userEntity.User, error)
+
+	// RevokeAPIKey marks an API key of a user as revoked
+	RevokeAPIKey(ctx context.Context, userID int, keyHash string) error
+
+	// SaveClick stores a served redirect of a short URL
+	SaveClick(ctx context.Context, click *analyticsEntity.Click) error
+
+	// FindUserClicks retrieves clicks of all short URLs of a user
+	FindUserClicks(ctx context.Context, userID int) ([]*analyticsEntity.Click, error)
+
+	// CountClicks counts clicks of a short URL in [from, to) grouped by periods of the granularity in UTC
+	CountClicks(ctx context.Context, namespace, alias string, from, to time.Time, granularity string) ([]analyticsEntity.ClickCount, error)
+
+	// CountClicksByLocation counts clicks of a short URL in [from, to) grouped by country and city
+	CountClicksByLocation(ctx context.Context, namespace, alias string, from, to time.Time) ([]analyticsEntity.LocationCount, error)
+
+	// SaveSplit stores a new short URL splitting traffic between several original URLs
+	SaveSplit(ctx context.Context, split *splitEntity.MultiDestination) error
+
+	// FindSplit retrieves a split short URL by its namespace and alias
+	FindSplit(ctx context.Context, namespace, alias string) (*splitEntity.MultiDestination, error)
+
+	// SaveSplitClick increments click count of a destination of a split short URL
+	SaveSplitClick(ctx context.Context, namespace, alias, url string) error
+
+	// SaveNamespace stores a new namespace
+	SaveNamespace(ctx context.Context, namespace *namespaceEntity.Namespace) (*namespaceEntity.Namespace, error)
+
+	// FindNamespaces retrieves all namespaces ordered by name
+	FindNamespaces(ctx context.Context) ([]*namespaceEntity.Namespace, error)
+
+	// DeleteNamespace removes a namespace without short URLs
+	DeleteNamespace(ctx context.Context, name string) error
+
 	// Ping checks if the database is available
 	Ping(ctx context.Context) error
 
@@ -34,6 +34,16 @@ var (
 	// - Consider upsert operations where appropriate
 	ErrDBRecordNotFound = errors.New("record not found")
 
+	// ErrDBRecordIsDeleted indicates a record was already marked as deleted,
+	// e.g. by a concurrent request.
+	//
+	// Common scenarios:
+	// - One-time short URL was already used
+	//
+	// Handling suggestions:
+	// - Return HTTP 410 for API responses
+	ErrDBRecordIsDeleted = errors.New("record is already deleted")
+
 	// ErrDBQuery indicates a database query failed to execute.
 	//
 	// Possible reasons:
@@ -60,6 +70,19 @@ var (
 	// - Check for race conditions
 	ErrDBIsNotUnique = errors.New("record is not unique")
 
+	// ErrDBAliasTaken indicates a short URL cannot be saved because its alias
+	// is already used in the namespace by another short URL.
+	//
+	// Unlike ErrDBIsNotUnique, it is not caused by a duplicate source URL,
+	// so there is no existing short URL to return instead.
+	//
+	// Common scenarios:
+	// - Generated alias collides with an existing one
+	//
+	// Handling suggestions:
+	// - Generate another alias and save again
+	ErrDBAliasTaken = errors.New("alias is already taken")
+
 	// ErrDBRestoreFromFile indicates failure during database restoration
 	// from a backup file.
 	//
@@ -71,4 +94,66 @@ var (
 	// - File permissions
 	// - Schema version mismatch
 	ErrDBRestoreFromFile = errors.New("cannot restore records from file %s")
+
+	// ErrDBIsClosed indicates a write to database which was already shut down.
+	//
+	// Common scenarios:
+	// - Request is still being handled during graceful shutdown
+	//
+	// Handling suggestions:
+	// - Return HTTP 500 for API responses
+	// - Make sure database is shut down after HTTP server
+	ErrDBIsClosed = errors.New("db is closed")
+
+	// ErrDBContextDone indicates an operation was not performed because its context
+	// was already cancelled or its deadline exceeded.
+	//
+	// Format note:
+	// - Wraps the context error, so errors.Is matches context.Canceled or context.DeadlineExceeded
+	//
+	// Common scenarios:
+	// - Client disconnected before the request was handled
+	// - Request timeout elapsed
+	//
+	// Handling suggestions:
+	// - Do not retry, the caller is not waiting for the result anymore
+	ErrDBContextDone = errors.New("db operation context is done")
+
+	// ErrDBReferenceNotFound indicates a record refers to another record which doesn't exist.
+	//
+	// Common scenarios:
+	// - Short URL is saved to a namespace which was not created
+	//
+	// Handling suggestions:
+	// - Create the referenced record first
+	// - Return HTTP 422 for API responses
+	ErrDBReferenceNotFound = errors.New("referenced record not found")
+
+	// ErrDBRecordIsReferenced indicates a record cannot be deleted while other records refer to it.
+	//
+	// Common scenarios:
+	// - Namespace still contains short URLs
+	//
+	// Handling suggestions:
+	// - Delete the referring records first
+	// - Return HTTP 409 for API responses
+	ErrDBRecordIsReferenced = errors.New("record is referenced by other records")
+
+	// ErrDBInvalidMigrationSteps indicates a rollback of a non-positive number of migrations.
+	//
+	// Common scenarios:
+	// - --migrate-down is passed 0 or a negative number
+	//
+	// Handling suggestions:
+	// - Use --migrate-reset to roll back all migrations
+	ErrDBInvalidMigrationSteps = errors.New("number of migrations to roll back must be positive")
+
+	// ErrDBReplicaDSNMissing indicates the read replica is enabled without its connection string.
+	//
+	// Common scenarios:
+	// - DATABASE_READ_REPLICA_ENABLED is set but DATABASE_READ_REPLICA_DSN is not
+	//
+	// Handling suggestions:
+	// - Set the replica DSN or disable the read replica
+	ErrDBReplicaDSNMissing = errors.New("read replica is enabled but its DSN is not set")
 )
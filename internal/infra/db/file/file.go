@@ -3,6 +3,9 @@ Package db implements a file-based database for the URL shortener service.
 
 It provides:
 - Persistent storage using JSON files
+- Write-ahead log of changes, replayed and compacted on startup
+- Atomic compaction via write-and-rename
+- Recovery from partially written files
 - In-memory caching for fast access
 - Thread-safe operations with mutex locks
 - Basic CRUD operations for users and short URLs
@@ -14,33 +17,78 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
+	analyticsEntity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	namespaceEntity "github.com/gururuby/shortener/internal/domain/entity/namespace"
 	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	splitEntity "github.com/gururuby/shortener/internal/domain/entity/split"
+	tagEntity "github.com/gururuby/shortener/internal/domain/entity/tag"
 	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	webhookEntity "github.com/gururuby/shortener/internal/domain/entity/webhook"
 	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/gururuby/shortener/internal/infra/logger"
 	"github.com/json-iterator/go"
+	"go.uber.org/zap"
 )
 
 var json = jsoniter.ConfigFastest
 
 // FileDB represents a file-based database implementation.
 // It maintains in-memory maps synchronized with a persistent file.
+// Changes of short URLs are appended to the write-ahead log, see walRecord,
+// which is merged into the file on compaction.
 type FileDB struct {
-	file      *os.File
-	shortURLs map[string]*shortURLEntity.ShortURL
-	users     map[int]*userEntity.User
-	mutex     sync.RWMutex
+	file          *os.File
+	wal           *os.File // Write-ahead log of changes made since the last compaction
+	path          string
+	shortURLs     map[string]*shortURLEntity.ShortURL   // Map of short URL keys, see urlKey, to entities
+	namespaces    map[string]*namespaceEntity.Namespace // Namespaces, kept in memory only and restored from short URLs
+	users         map[int]*userEntity.User
+	tags          map[int]*tagEntity.Tag                    // Tags of users, kept in memory only
+	urlTags       map[string]map[int]struct{}               // Map of short URL keys to IDs of assigned tags
+	webhooks      map[int]*webhookEntity.Webhook            // Webhooks of users, kept in memory only
+	apiKeys       map[string]*apiKey                        // API keys of users by key hash, kept in memory only
+	clicks        map[string][]*analyticsEntity.Click       // Clicks by short URL key, kept in memory only
+	urlHistory    map[string][]*shortURLEntity.HistoryEntry // Previous original URLs by short URL key, kept in memory only
+	splits        map[string]*splitEntity.MultiDestination  // Splits by key, see urlKey, kept in memory only
+	lastURLID     int                                       // ID of the last saved short URL
+	lastTagID     int                                       // ID of the last saved tag
+	lastWebhookID int                                       // ID of the last saved webhook
+	mutex         sync.RWMutex
+}
+
+// apiKey represents a stored API key of a user.
+type apiKey struct {
+	userID  int  // Owner of the key
+	revoked bool // Whether the key is revoked
 }
 
 // fileDTO is the data transfer object for file storage.
 // It defines the JSON structure for persisted short URLs.
 type fileDTO struct {
-	UUID        string `json:"uuid"`
-	ShortURL    string `json:"short_url"`
-	OriginalURL string `json:"original_url"`
-	UserID      int    `json:"user_id"`
-	IsDeleted   bool   `json:"is_deleted"`
+	ID            int        `json:"id,omitempty"`
+	UUID          string     `json:"uuid"`
+	ShortURL      string     `json:"short_url"`
+	OriginalURL   string     `json:"original_url"`
+	NormalizedURL string     `json:"normalized_url,omitempty"`
+	UserID        int        `json:"user_id"`
+	IsDeleted     bool       `json:"is_deleted"`
+	IsOneTimeUse  bool       `json:"is_one_time_use,omitempty"`
+	Visibility    string     `json:"visibility,omitempty"`
+	RedirectType  int        `json:"redirect_type,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	Region        string     `json:"region,omitempty"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	Namespace     string     `json:"namespace,omitempty"`
+	IsTracked     bool       `json:"is_tracked,omitempty"`
+	IsHealthy     bool       `json:"is_healthy,omitempty"`
+	CheckedAt     *time.Time `json:"checked_at,omitempty"`
 }
 
 // New creates and initializes a new FileDB instance.
@@ -55,40 +103,144 @@ func New(filePath string) (*FileDB, error) {
 		users     = make(map[int]*userEntity.User)
 	)
 
-	f, err := os.OpenFile(filePath, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
+	f, err := os.OpenFile(filePath, os.O_RDWR|os.O_CREATE, 0666)
 	if err != nil {
 		return nil, err
 	}
 
 	err = restoreShortURLs(f, shortURLs)
 	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	wal, err := openWAL(filePath)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	err = replayWAL(wal, shortURLs)
+	if err != nil {
+		_ = f.Close()
+		_ = wal.Close()
+		return nil, err
+	}
+
+	db := &FileDB{
+		file:       f,
+		wal:        wal,
+		path:       filePath,
+		shortURLs:  shortURLs,
+		users:      users,
+		tags:       make(map[int]*tagEntity.Tag),
+		urlTags:    make(map[string]map[int]struct{}),
+		webhooks:   make(map[int]*webhookEntity.Webhook),
+		apiKeys:    make(map[string]*apiKey),
+		clicks:     make(map[string][]*analyticsEntity.Click),
+		urlHistory: make(map[string][]*shortURLEntity.HistoryEntry),
+		splits:     make(map[string]*splitEntity.MultiDestination),
+		namespaces: restoreNamespaces(shortURLs),
+		lastURLID:  assignMissingIDs(shortURLs),
+	}
+
+	// Replayed changes are merged into the file, so the log is not replayed again
+	if err = db.compact(); err != nil {
+		_ = db.file.Close()
+		_ = wal.Close()
 		return nil, err
 	}
 
-	return &FileDB{
-		file:      f,
-		shortURLs: shortURLs,
-		users:     users,
-	}, nil
+	return db, nil
+}
+
+// restoreNamespaces builds namespaces of restored short URLs.
+// The default namespace always exists; namespaces without short URLs are not persisted.
+// Parameters:
+// - shortURLs: Restored short URLs
+// Returns:
+// - map[string]*namespaceEntity.Namespace: Namespaces by name
+func restoreNamespaces(shortURLs map[string]*shortURLEntity.ShortURL) map[string]*namespaceEntity.Namespace {
+	now := time.Now()
+	namespaces := map[string]*namespaceEntity.Namespace{
+		namespaceEntity.Default: {Name: namespaceEntity.Default, CreatedAt: now},
+	}
+
+	for _, url := range shortURLs {
+		if _, ok := namespaces[url.Namespace]; !ok {
+			namespaces[url.Namespace] = &namespaceEntity.Namespace{Name: url.Namespace, CreatedAt: now}
+		}
+	}
+
+	return namespaces
+}
+
+// urlKey returns the key of a short URL in shortURLs map.
+// Short URLs of the default namespace are keyed by alias, the rest by namespace and alias.
+// Parameters:
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// Returns:
+// - string: Key of the short URL
+func urlKey(namespace, alias string) string {
+	if namespace = namespaceEntity.OrDefault(namespace); namespace == namespaceEntity.Default {
+		return alias
+	}
+	return namespace + "/" + alias
+}
+
+// assignMissingIDs sets IDs for records saved before IDs were introduced.
+// Such records get IDs following the greatest existing one, in alias order.
+// Parameters:
+// - shortURLs: Restored short URLs
+// Returns:
+// - int: Greatest short URL ID
+func assignMissingIDs(shortURLs map[string]*shortURLEntity.ShortURL) int {
+	var (
+		lastID  int
+		missing []*shortURLEntity.ShortURL
+	)
+
+	for _, url := range shortURLs {
+		if url.ID == 0 {
+			missing = append(missing, url)
+		} else if url.ID > lastID {
+			lastID = url.ID
+		}
+	}
+
+	sort.Slice(missing, func(i, j int) bool { return missing[i].Alias < missing[j].Alias })
+
+	for _, url := range missing {
+		lastID++
+		url.ID = lastID
+	}
+
+	return lastID
 }
 
 // restoreShortURLs loads existing short URLs from file into memory.
+// Malformed lines, e.g. partially written before a crash, are skipped with a warning.
 // Parameters:
 // - f: File to read from
 // - shortURLs: Map to populate with restored data
 // Returns:
-// - error: If reading or parsing fails
+// - error: If reading fails
 func restoreShortURLs(f *os.File, shortURLs map[string]*shortURLEntity.ShortURL) error {
 	scanner := bufio.NewScanner(f)
 
-	for scanner.Scan() {
+	for line := 1; scanner.Scan(); line++ {
 		dto := &fileDTO{}
-		err := json.Unmarshal([]byte(scanner.Text()), dto)
+		err := json.Unmarshal(scanner.Bytes(), dto)
 		if err != nil {
-			return fmt.Errorf(dbErrors.ErrDBRestoreFromFile.Error(), err.Error())
+			logger.Log.Warn("skipping malformed record",
+				zap.String("file", f.Name()),
+				zap.Int("line", line),
+				zap.Error(fmt.Errorf(dbErrors.ErrDBRestoreFromFile.Error(), err.Error())))
+			continue
 		}
 		shortURL := toShortURL(dto)
-		shortURLs[shortURL.Alias] = shortURL
+		shortURLs[urlKey(shortURL.Namespace, shortURL.Alias)] = shortURL
 	}
 
 	return scanner.Err()
@@ -100,13 +252,32 @@ func restoreShortURLs(f *os.File, shortURLs map[string]*shortURLEntity.ShortURL)
 // Returns:
 // - *fileDTO: Data transfer object for storage
 func toFileDTO(shortURL *shortURLEntity.ShortURL) *fileDTO {
-	return &fileDTO{
-		UserID:      shortURL.UserID,
-		UUID:        shortURL.UUID,
-		ShortURL:    shortURL.Alias,
-		OriginalURL: shortURL.SourceURL,
-		IsDeleted:   shortURL.IsDeleted,
+	dto := &fileDTO{
+		ID:            shortURL.ID,
+		UserID:        shortURL.UserID,
+		UUID:          shortURL.UUID,
+		ShortURL:      shortURL.Alias,
+		OriginalURL:   shortURL.SourceURL,
+		NormalizedURL: shortURL.NormalizedURL,
+		IsDeleted:     shortURL.IsDeleted,
+		IsOneTimeUse:  shortURL.IsOneTimeUse,
+		Visibility:    shortURL.Visibility,
+		RedirectType:  shortURL.RedirectType,
+		CreatedAt:     shortURL.CreatedAt,
+		Region:        shortURL.CreatedInRegion,
+		IsTracked:     shortURL.IsTracked,
+		IsHealthy:     shortURL.IsHealthy,
+	}
+	if shortURL.Namespace != namespaceEntity.Default {
+		dto.Namespace = shortURL.Namespace
 	}
+	if !shortURL.ExpiresAt.IsZero() {
+		dto.ExpiresAt = &shortURL.ExpiresAt
+	}
+	if !shortURL.CheckedAt.IsZero() {
+		dto.CheckedAt = &shortURL.CheckedAt
+	}
+	return dto
 }
 
 // toShortURL converts a fileDTO to ShortURL entity.
@@ -115,13 +286,42 @@ func toFileDTO(shortURL *shortURLEntity.ShortURL) *fileDTO {
 // Returns:
 // - *shortURLEntity.ShortURL: Domain entity
 func toShortURL(dto *fileDTO) *shortURLEntity.ShortURL {
-	return &shortURLEntity.ShortURL{
-		UserID:    dto.UserID,
-		UUID:      dto.UUID,
-		Alias:     dto.ShortURL,
-		SourceURL: dto.OriginalURL,
-		IsDeleted: dto.IsDeleted,
+	shortURL := &shortURLEntity.ShortURL{
+		ID:              dto.ID,
+		UserID:          dto.UserID,
+		UUID:            dto.UUID,
+		Alias:           dto.ShortURL,
+		SourceURL:       dto.OriginalURL,
+		NormalizedURL:   dto.NormalizedURL,
+		IsDeleted:       dto.IsDeleted,
+		IsOneTimeUse:    dto.IsOneTimeUse,
+		Visibility:      dto.Visibility,
+		RedirectType:    dto.RedirectType,
+		CreatedAt:       dto.CreatedAt,
+		CreatedInRegion: dto.Region,
+		Namespace:       namespaceEntity.OrDefault(dto.Namespace),
+		IsTracked:       dto.IsTracked,
+		IsHealthy:       dto.IsHealthy,
+	}
+	if dto.ExpiresAt != nil {
+		shortURL.ExpiresAt = *dto.ExpiresAt
+	}
+	if dto.CheckedAt != nil {
+		shortURL.CheckedAt = *dto.CheckedAt
 	}
+	return shortURL
+}
+
+// checkContext reports whether an operation may proceed with ctx.
+// Parameters:
+// - ctx: Context of the operation
+// Returns:
+// - error: dbErrors.ErrDBContextDone wrapping ctx.Err() if ctx is cancelled or its deadline exceeded
+func checkContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %w", dbErrors.ErrDBContextDone, err)
+	}
+	return nil
 }
 
 // FindUser retrieves a user by ID.
@@ -131,7 +331,11 @@ func toShortURL(dto *fileDTO) *shortURLEntity.ShortURL {
 // Returns:
 // - *userEntity.User: Found user
 // - error: If user not found
-func (db *FileDB) FindUser(_ context.Context, id int) (*userEntity.User, error) {
+func (db *FileDB) FindUser(ctx context.Context, id int) (*userEntity.User, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	user, ok := db.users[id]
 	if !ok {
 		return nil, dbErrors.ErrDBRecordNotFound
@@ -146,7 +350,11 @@ func (db *FileDB) FindUser(_ context.Context, id int) (*userEntity.User, error)
 // Returns:
 // - []*shortURLEntity.ShortURL: List of user's URLs
 // - error: Never returns error (empty slice for no results)
-func (db *FileDB) FindUserURLs(_ context.Context, userID int) ([]*shortURLEntity.ShortURL, error) {
+func (db *FileDB) FindUserURLs(ctx context.Context, userID int) ([]*shortURLEntity.ShortURL, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	var urls []*shortURLEntity.ShortURL
 
 	for _, url := range db.shortURLs {
@@ -158,31 +366,238 @@ func (db *FileDB) FindUserURLs(_ context.Context, userID int) ([]*shortURLEntity
 	return urls, nil
 }
 
+// FindUserURLsPaginated retrieves a page of short URLs belonging to a user.
+// URLs are ordered by alias so that pages are stable between requests.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - offset: Number of URLs to skip
+// - limit: Maximum number of URLs to return
+// Returns:
+// - []*shortURLEntity.ShortURL: Requested page of user's URLs (empty if offset is out of range)
+// - int64: Total number of user's URLs
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *FileDB) FindUserURLsPaginated(ctx context.Context, userID, offset, limit int) ([]*shortURLEntity.ShortURL, int64, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	var urls []*shortURLEntity.ShortURL
+
+	for _, url := range db.shortURLs {
+		if url.UserID == userID {
+			urls = append(urls, url)
+		}
+	}
+
+	sort.Slice(urls, func(i, j int) bool { return urls[i].Alias < urls[j].Alias })
+
+	total := int64(len(urls))
+
+	if offset >= len(urls) {
+		return nil, total, nil
+	}
+
+	end := offset + limit
+	if end > len(urls) {
+		end = len(urls)
+	}
+
+	return urls[offset:end], total, nil
+}
+
+// SearchUserURLs retrieves a page of short URLs of a user whose original URL contains the query.
+// The query is matched case-insensitively, URLs are ordered by alias.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - query: Substring of original URLs to find
+// - offset: Number of URLs to skip
+// - limit: Maximum number of URLs to return
+// Returns:
+// - []*shortURLEntity.ShortURL: Requested page of found URLs (empty if offset is out of range)
+// - int64: Total number of found URLs
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *FileDB) SearchUserURLs(ctx context.Context, userID int, query string, offset, limit int) ([]*shortURLEntity.ShortURL, int64, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	var urls []*shortURLEntity.ShortURL
+
+	query = strings.ToLower(query)
+
+	for _, url := range db.shortURLs {
+		if url.UserID == userID && strings.Contains(strings.ToLower(url.SourceURL), query) {
+			urls = append(urls, url)
+		}
+	}
+
+	sort.Slice(urls, func(i, j int) bool { return urls[i].Alias < urls[j].Alias })
+
+	total := int64(len(urls))
+
+	if offset >= len(urls) {
+		return nil, total, nil
+	}
+
+	end := offset + limit
+	if end > len(urls) {
+		end = len(urls)
+	}
+
+	return urls[offset:end], total, nil
+}
+
+// CountUserURLs returns the number of not deleted short URLs of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID, 0 for URLs created anonymously
+// Returns:
+// - int64: Number of user's URLs
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *FileDB) CountUserURLs(ctx context.Context, userID int) (int64, error) {
+	if err := checkContext(ctx); err != nil {
+		return 0, err
+	}
+
+	var count int64
+
+	for _, url := range db.shortURLs {
+		if url.UserID == userID && !url.IsDeleted {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// FindUserURLsCursor retrieves short URLs belonging to a user created after the cursor.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - afterID: ID of the last URL on the previous page, 0 for the first page
+// - limit: Maximum number of URLs to return
+// Returns:
+// - []*shortURLEntity.ShortURL: User's URLs with ID greater than afterID ordered by ID
+// - int: ID of the last returned URL, 0 if there are no more URLs
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *FileDB) FindUserURLsCursor(ctx context.Context, userID, afterID, limit int) ([]*shortURLEntity.ShortURL, int, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	var urls []*shortURLEntity.ShortURL
+
+	db.mutex.RLock()
+	for _, url := range db.shortURLs {
+		if url.UserID == userID && url.ID > afterID {
+			urls = append(urls, url)
+		}
+	}
+	db.mutex.RUnlock()
+
+	sort.Slice(urls, func(i, j int) bool { return urls[i].ID < urls[j].ID })
+
+	if len(urls) <= limit {
+		return urls, 0, nil
+	}
+
+	urls = urls[:limit]
+
+	return urls, urls[limit-1].ID, nil
+}
+
 // SaveUser creates and stores a new user.
 // Parameters:
 // - ctx: Context for cancellation/timeouts
 // Returns:
 // - *userEntity.User: Created user
 // - error: Never returns error
-func (db *FileDB) SaveUser(_ context.Context) (*userEntity.User, error) {
+func (db *FileDB) SaveUser(ctx context.Context) (*userEntity.User, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	id := len(db.users) + 1
-	user := &userEntity.User{ID: id}
+	user := &userEntity.User{ID: id, IsActive: true, CreatedAt: time.Now()}
 	db.users[id] = user
 	return user, nil
 }
 
-// FindShortURL retrieves a short URL by its alias.
+// SetUserActive activates or deactivates a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: User ID
+// - isActive: Whether the user may authenticate
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if user doesn't exist
+func (db *FileDB) SetUserActive(ctx context.Context, userID int, isActive bool) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	user, ok := db.users[userID]
+	if !ok {
+		return dbErrors.ErrDBRecordNotFound
+	}
+	user.IsActive = isActive
+	return nil
+}
+
+// SetUserCustomDomain sets the custom domain of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: User ID
+// - customDomain: Base URL of short URLs of the user, empty to remove it
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if user doesn't exist,
+// dbErrors.ErrDBIsNotUnique if the domain belongs to another user
+func (db *FileDB) SetUserCustomDomain(ctx context.Context, userID int, customDomain string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	user, ok := db.users[userID]
+	if !ok {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	if customDomain != "" {
+		for id, other := range db.users {
+			if id != userID && other.CustomDomain == customDomain {
+				return dbErrors.ErrDBIsNotUnique
+			}
+		}
+	}
+
+	user.CustomDomain = customDomain
+	return nil
+}
+
+// FindShortURL retrieves a short URL by its namespace and alias.
 // Parameters:
 // - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the short URL, empty for the default one
 // - alias: Short URL identifier
 // Returns:
 // - *shortURLEntity.ShortURL: Found short URL
-// - error: If URL not found
-func (db *FileDB) FindShortURL(_ context.Context, alias string) (*shortURLEntity.ShortURL, error) {
+// - error: If URL not found in the namespace
+func (db *FileDB) FindShortURL(ctx context.Context, namespace, alias string) (*shortURLEntity.ShortURL, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	db.mutex.RLock()
 	defer db.mutex.RUnlock()
 
-	shortURL, ok := db.shortURLs[alias]
+	shortURL, ok := db.shortURLs[urlKey(namespace, alias)]
 
 	if !ok {
 		return nil, dbErrors.ErrDBRecordNotFound
@@ -191,14 +606,20 @@ func (db *FileDB) FindShortURL(_ context.Context, alias string) (*shortURLEntity
 	return shortURL, nil
 }
 
-// findShortURLBySourceURL looks up a short URL by its original URL.
+// FindShortURLBySourceURL looks up a short URL of a namespace by deduplication key of its original URL.
+// Short URLs which are never deduplicated, see ShortURL.IsDeduplicated, are skipped.
 // Parameters:
 // - ctx: Context for cancellation/timeouts
-// - sourceURL: Original long URL
+// - namespace: Namespace of the short URL, empty for the default one
+// - sourceURL: Deduplication key of original long URL, see ShortURL.DeduplicationKey
 // Returns:
 // - *shortURLEntity.ShortURL: Found short URL
 // - error: If URL not found
-func (db *FileDB) findShortURLBySourceURL(_ context.Context, sourceURL string) (*shortURLEntity.ShortURL, error) {
+func (db *FileDB) FindShortURLBySourceURL(ctx context.Context, namespace, sourceURL string) (*shortURLEntity.ShortURL, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	var (
 		shortURL  *shortURLEntity.ShortURL
 		noRecords = true
@@ -208,7 +629,7 @@ func (db *FileDB) findShortURLBySourceURL(_ context.Context, sourceURL string) (
 	defer db.mutex.RUnlock()
 
 	for _, url := range db.shortURLs {
-		if url.SourceURL == sourceURL {
+		if url.IsDeduplicated() && url.DeduplicationKey() == sourceURL && url.Namespace == namespaceEntity.OrDefault(namespace) {
 			shortURL = url
 			noRecords = false
 			break
@@ -222,88 +643,1228 @@ func (db *FileDB) findShortURLBySourceURL(_ context.Context, sourceURL string) (
 	return shortURL, nil
 }
 
+// unsafeFindURL looks up a short URL by namespace and alias without locking.
+// Caller must hold the read or write lock.
+// Parameters:
+// - userID: Owner's user ID, or 0 for any owner
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// Returns:
+// - *shortURLEntity.ShortURL: Found short URL or nil
+func (db *FileDB) unsafeFindURL(userID int, namespace, alias string) *shortURLEntity.ShortURL {
+	if url, ok := db.shortURLs[urlKey(namespace, alias)]; ok && (userID == 0 || url.UserID == userID) {
+		return url
+	}
+	return nil
+}
+
 // SaveShortURL stores a new short URL.
 // Parameters:
 // - ctx: Context for cancellation/timeouts
 // - shortURL: URL to save
 // Returns:
 // - *shortURLEntity.ShortURL: Saved URL
-// - error: If URL already exists or file operation fails
+// - error: If URL already exists in the namespace, dbErrors.ErrDBAliasTaken if the alias is used
+// by another URL in the namespace, other error if namespace doesn't exist, context is done,
+// database is shut down or file operation fails
+//
+// One-time and private URLs are never deduplicated, each of them gets its own alias.
 func (db *FileDB) SaveShortURL(ctx context.Context, shortURL *shortURLEntity.ShortURL) (*shortURLEntity.ShortURL, error) {
-	var (
-		err    error
-		record *shortURLEntity.ShortURL
-		data   []byte
-	)
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	var record *shortURLEntity.ShortURL
 
-	if record, _ = db.findShortURLBySourceURL(ctx, shortURL.SourceURL); record != nil {
-		return record, dbErrors.ErrDBIsNotUnique
+	shortURL.Namespace = namespaceEntity.OrDefault(shortURL.Namespace)
+	if shortURL.IsDeduplicated() {
+		if record, _ = db.FindShortURLBySourceURL(ctx, shortURL.Namespace, shortURL.DeduplicationKey()); record != nil {
+			return record, dbErrors.ErrDBIsNotUnique
+		}
 	}
 
 	db.mutex.Lock()
 	defer db.mutex.Unlock()
 
-	db.shortURLs[shortURL.Alias] = shortURL
+	if db.file == nil {
+		return nil, dbErrors.ErrDBIsClosed
+	}
 
-	data, err = json.Marshal(toFileDTO(shortURL))
-	if err != nil {
-		return nil, err
+	// Waiting for the lock may outlast the request, so nothing is logged for a cancelled one
+	select {
+	case <-ctx.Done():
+		return nil, checkContext(ctx)
+	default:
+	}
+
+	if _, ok := db.namespaces[shortURL.Namespace]; !ok {
+		return nil, dbErrors.ErrDBReferenceNotFound
+	}
+
+	key := urlKey(shortURL.Namespace, shortURL.Alias)
+	if _, ok := db.shortURLs[key]; ok {
+		return nil, dbErrors.ErrDBAliasTaken
+	}
+
+	if shortURL.CreatedAt.IsZero() {
+		shortURL.CreatedAt = time.Now()
 	}
 
-	if _, err = db.file.WriteString(string(data) + "\n"); err != nil {
+	db.lastURLID++
+	shortURL.ID = db.lastURLID
+	db.shortURLs[key] = shortURL
+
+	if err := db.appendWAL(newSaveRecord(shortURL)); err != nil {
+		delete(db.shortURLs, key)
 		return nil, err
 	}
 
 	return shortURL, nil
 }
 
-// MarkURLAsDeleted marks URLs as deleted (not implemented).
+// MarkURLAsDeleted marks URLs as deleted.
 // Parameters:
 // - ctx: Context for cancellation/timeouts
-// - userID: Owner's user ID
+// - userID: Owner's user ID, or 0 for any owner
+// - namespace: Namespace of the short URLs, empty for the default one
 // - aliases: URLs to mark as deleted
 // Returns:
-// - error: Always returns nil (not implemented)
-func (db *FileDB) MarkURLAsDeleted(_ context.Context, _ int, _ []string) error {
+// - error: dbErrors.ErrDBRecordIsDeleted if userID is 0 and all URLs are already deleted,
+// dbErrors.ErrDBIsClosed if database is shut down, other error if file operation fails
+func (db *FileDB) MarkURLAsDeleted(ctx context.Context, userID int, namespace string, aliases []string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	var (
+		marked  []*shortURLEntity.ShortURL
+		records []*walRecord
+	)
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.file == nil {
+		return dbErrors.ErrDBIsClosed
+	}
+
+	for _, alias := range aliases {
+		url := db.unsafeFindURL(userID, namespace, alias)
+		if url == nil || url.IsDeleted {
+			continue
+		}
+
+		url.IsDeleted = true
+		marked = append(marked, url)
+		records = append(records, &walRecord{Op: walOpDelete, Alias: url.Alias, Namespace: url.Namespace, UserID: userID})
+	}
+
+	if len(marked) == 0 {
+		if userID == 0 {
+			return dbErrors.ErrDBRecordIsDeleted
+		}
+		return nil
+	}
+
+	if err := db.appendWAL(records...); err != nil {
+		for _, url := range marked {
+			url.IsDeleted = false
+		}
+		return err
+	}
+
 	return nil
 }
 
-// Ping checks if the database is accessible.
+// RestoreURL clears the deletion mark of a short URL of a user and logs the change.
 // Parameters:
 // - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
 // Returns:
-// - error: If file stat operation fails
-func (db *FileDB) Ping(_ context.Context) error {
-	_, err := db.file.Stat()
-	return err
+// - error: dbErrors.ErrDBRecordNotFound if the user has no URL with the alias,
+// or error of writing the file
+func (db *FileDB) RestoreURL(ctx context.Context, userID int, namespace, alias string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.file == nil {
+		return dbErrors.ErrDBIsClosed
+	}
+
+	url := db.unsafeFindURL(userID, namespace, alias)
+	if url == nil {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	if !url.IsDeleted {
+		return nil
+	}
+	url.IsDeleted = false
+
+	if err := db.appendWAL(newSaveRecord(url)); err != nil {
+		url.IsDeleted = true
+		return err
+	}
+
+	return nil
 }
 
-// Shutdown gracefully closes the database connection and flushes any pending writes.
-// It ensures all data is persisted to disk before closing.
+// UpdateURLTarget replaces the original URL of a short URL of a user and logs the change.
+// The previous original URL is saved to the history of the short URL, which is kept in memory only.
+// The deduplication key is reset, so the short URL is not reused for the previous target.
 // Parameters:
 // - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// - newURL: New original URL
 // Returns:
-// - error: If shutdown fails (e.g. file sync error)
-func (db *FileDB) Shutdown(_ context.Context) error {
+// - error: dbErrors.ErrDBRecordNotFound if the user has no URL with the alias,
+// or error of writing the file
+func (db *FileDB) UpdateURLTarget(ctx context.Context, userID int, namespace, alias, newURL string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
 	db.mutex.Lock()
 	defer db.mutex.Unlock()
 
 	if db.file == nil {
-		return nil // Already closed
+		return dbErrors.ErrDBIsClosed
 	}
 
-	// 1. Flush any buffered data to disk
-	if err := db.file.Sync(); err != nil {
-		return fmt.Errorf("failed to sync file: %w", err)
+	url := db.unsafeFindURL(userID, namespace, alias)
+	if url == nil {
+		return dbErrors.ErrDBRecordNotFound
 	}
 
-	// 2. Close the file handle
-	if err := db.file.Close(); err != nil {
-		return fmt.Errorf("failed to close file: %w", err)
+	entry := &shortURLEntity.HistoryEntry{OriginalURL: url.SourceURL, ChangedAt: time.Now()}
+	prevNormalizedURL := url.NormalizedURL
+	url.SourceURL = newURL
+	url.NormalizedURL = ""
+
+	if err := db.appendWAL(newSaveRecord(url)); err != nil {
+		url.SourceURL = entry.OriginalURL
+		url.NormalizedURL = prevNormalizedURL
+		return err
 	}
 
-	// 3. Clear the reference to prevent double-close
+	key := urlKey(namespace, alias)
+	db.urlHistory[key] = append(db.urlHistory[key], entry)
+	return nil
+}
+
+// FindURLHistory retrieves previous original URLs of a short URL.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// Returns:
+// - []*shortURLEntity.HistoryEntry: Previous original URLs, the most recent first
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *FileDB) FindURLHistory(ctx context.Context, namespace, alias string) ([]*shortURLEntity.HistoryEntry, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	history := slices.Clone(db.urlHistory[urlKey(namespace, alias)])
+	slices.Reverse(history)
+
+	return history, nil
+}
+
+// DeleteExpiredURLs removes short URLs whose expiration time has passed
+// together with their tag assignments, and logs the removal.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - int64: Number of removed short URLs
+// - error: dbErrors.ErrDBIsClosed if database is shut down, other error if file operation fails
+func (db *FileDB) DeleteExpiredURLs(ctx context.Context) (int64, error) {
+	if err := checkContext(ctx); err != nil {
+		return 0, err
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.file == nil {
+		return 0, dbErrors.ErrDBIsClosed
+	}
+
+	now := time.Now()
+	expired := make(map[string]*shortURLEntity.ShortURL)
+	var records []*walRecord
+	for key, url := range db.shortURLs {
+		if url.IsExpired(now) {
+			expired[key] = url
+			records = append(records, &walRecord{Op: walOpRemove, Alias: url.Alias, Namespace: url.Namespace})
+			delete(db.shortURLs, key)
+		}
+	}
+
+	if len(expired) == 0 {
+		return 0, nil
+	}
+
+	if err := db.appendWAL(records...); err != nil {
+		for key, url := range expired {
+			db.shortURLs[key] = url
+		}
+		return 0, err
+	}
+
+	for key := range expired {
+		delete(db.urlTags, key)
+	}
+
+	return int64(len(expired)), nil
+}
+
+// FindURLsToCheck retrieves not deleted and not expired short URLs whose source URLs
+// were not checked since the given time.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - checkedBefore: URLs checked at or after this time are skipped
+// - afterID: ID of the last URL of the previous batch, 0 for the first batch
+// - limit: Maximum number of URLs to return
+// Returns:
+// - []*shortURLEntity.ShortURL: URLs with ID greater than afterID ordered by ID
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *FileDB) FindURLsToCheck(ctx context.Context, checkedBefore time.Time, afterID, limit int) ([]*shortURLEntity.ShortURL, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	var urls []*shortURLEntity.ShortURL
+
+	now := time.Now()
+	db.mutex.RLock()
+	for _, url := range db.shortURLs {
+		if url.ID > afterID && !url.IsDeleted && !url.IsExpired(now) && url.CheckedAt.Before(checkedBefore) {
+			urls = append(urls, url)
+		}
+	}
+	db.mutex.RUnlock()
+
+	sort.Slice(urls, func(i, j int) bool { return urls[i].ID < urls[j].ID })
+
+	if len(urls) > limit {
+		urls = urls[:limit]
+	}
+
+	return urls, nil
+}
+
+// SaveURLHealth stores result of health check of a short URL's source URL and logs the change.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// - healthy: Whether the source URL was reachable
+// - checkedAt: Time of the check
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if short URL doesn't exist,
+// dbErrors.ErrDBIsClosed if database is shut down, other error if file operation fails
+func (db *FileDB) SaveURLHealth(ctx context.Context, namespace, alias string, healthy bool, checkedAt time.Time) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.file == nil {
+		return dbErrors.ErrDBIsClosed
+	}
+
+	url, ok := db.shortURLs[urlKey(namespace, alias)]
+	if !ok {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	prevHealthy, prevCheckedAt := url.IsHealthy, url.CheckedAt
+	url.IsHealthy, url.CheckedAt = healthy, checkedAt
+
+	if err := db.appendWAL(newSaveRecord(url)); err != nil {
+		url.IsHealthy, url.CheckedAt = prevHealthy, prevCheckedAt
+		return err
+	}
+
+	return nil
+}
+
+// persist atomically replaces the file with all short URL records.
+// Records are written to a temporary file in the same directory, which is then
+// renamed over the original, so a crash never leaves a partially written file.
+// Caller must hold the write lock.
+// Returns:
+// - error: If marshaling or any file operation fails
+func (db *FileDB) persist() error {
+	tmp, err := os.CreateTemp(filepath.Dir(db.path), filepath.Base(db.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	// Removes leftovers on failure, fails silently after successful rename
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if err = writeShortURLs(tmp, db.shortURLs); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmp.Name(), db.path); err != nil {
+		return err
+	}
+
+	return db.reopen()
+}
+
+// writeShortURLs writes short URL records as JSON lines ordered by key, see urlKey.
+// Parameters:
+// - f: File to write to
+// - shortURLs: Records to write
+// Returns:
+// - error: If marshaling or write fails
+func writeShortURLs(f *os.File, shortURLs map[string]*shortURLEntity.ShortURL) error {
+	aliases := make([]string, 0, len(shortURLs))
+	for alias := range shortURLs {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	w := bufio.NewWriter(f)
+	for _, alias := range aliases {
+		data, err := json.Marshal(toFileDTO(shortURLs[alias]))
+		if err != nil {
+			return err
+		}
+
+		if _, err = w.Write(append(data, '\n')); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+// reopen replaces the file handle with a new one pointing to the renamed file.
+// Caller must hold the write lock.
+// Returns:
+// - error: If file cannot be opened
+func (db *FileDB) reopen() error {
+	f, err := os.OpenFile(db.path, os.O_RDWR, 0666)
+	if err != nil {
+		return err
+	}
+
+	if db.file != nil {
+		_ = db.file.Close()
+	}
+	db.file = f
+
+	return nil
+}
+
+// CountURLs returns the number of all short URLs, including deleted ones.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - int64: Number of short URLs
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *FileDB) CountURLs(ctx context.Context) (int64, error) {
+	if err := checkContext(ctx); err != nil {
+		return 0, err
+	}
+
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	return int64(len(db.shortURLs)), nil
+}
+
+// CountDeletedURLs returns the number of short URLs marked as deleted.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - int64: Number of deleted short URLs
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *FileDB) CountDeletedURLs(ctx context.Context) (int64, error) {
+	if err := checkContext(ctx); err != nil {
+		return 0, err
+	}
+
+	var n int64
+
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	for _, url := range db.shortURLs {
+		if url.IsDeleted {
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+// CountUsers returns the number of users registered since start.
+// Users are not persisted to file, so the count is reset on restart.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - int64: Number of users
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *FileDB) CountUsers(ctx context.Context) (int64, error) {
+	if err := checkContext(ctx); err != nil {
+		return 0, err
+	}
+
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	return int64(len(db.users)), nil
+}
+
+// SaveTag stores a new tag of a user.
+// Tags are not persisted to file, so they are reset on restart like users.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - tag: Tag to save
+// Returns:
+// - *tagEntity.Tag: Saved tag with auto-incremented ID
+// - error: dbErrors.ErrDBIsNotUnique if user already has a tag with the same name
+func (db *FileDB) SaveTag(ctx context.Context, tag *tagEntity.Tag) (*tagEntity.Tag, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.unsafeFindTag(tag.UserID, tag.Name) != nil {
+		return nil, dbErrors.ErrDBIsNotUnique
+	}
+
+	db.lastTagID++
+	tag.ID = db.lastTagID
+
+	db.tags[tag.ID] = tag
+	return tag, nil
+}
+
+// FindTagsByUser retrieves all tags of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// Returns:
+// - []*tagEntity.Tag: User's tags ordered by name
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *FileDB) FindTagsByUser(ctx context.Context, userID int) ([]*tagEntity.Tag, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	var tags []*tagEntity.Tag
+
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	for _, tag := range db.tags {
+		if tag.UserID == userID {
+			tags = append(tags, tag)
+		}
+	}
+
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+
+	return tags, nil
+}
+
+// FindURLTags retrieves tags assigned to a short URL of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// Returns:
+// - []*tagEntity.Tag: Assigned tags ordered by name
+// - error: dbErrors.ErrDBRecordNotFound if user has no URL with such alias
+func (db *FileDB) FindURLTags(ctx context.Context, userID int, namespace, alias string) ([]*tagEntity.Tag, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	var tags []*tagEntity.Tag
+
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if db.unsafeFindURL(userID, namespace, alias) == nil {
+		return nil, dbErrors.ErrDBRecordNotFound
+	}
+
+	for id := range db.urlTags[urlKey(namespace, alias)] {
+		tags = append(tags, db.tags[id])
+	}
+
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+
+	return tags, nil
+}
+
+// AssignTag associates a tag of a user with a short URL of the same user.
+// Assigning already assigned tag has no effect.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// - name: Tag name
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if user has no such URL or tag
+func (db *FileDB) AssignTag(ctx context.Context, userID int, namespace, alias, name string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.unsafeFindURL(userID, namespace, alias) == nil {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	tag := db.unsafeFindTag(userID, name)
+	if tag == nil {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	key := urlKey(namespace, alias)
+	if db.urlTags[key] == nil {
+		db.urlTags[key] = make(map[int]struct{})
+	}
+	db.urlTags[key][tag.ID] = struct{}{}
+
+	return nil
+}
+
+// RemoveTag removes association of a tag of a user with a short URL.
+// Removing not assigned tag has no effect.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// - name: Tag name
+// Returns:
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *FileDB) RemoveTag(ctx context.Context, userID int, namespace, alias, name string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if tag := db.unsafeFindTag(userID, name); tag != nil {
+		delete(db.urlTags[urlKey(namespace, alias)], tag.ID)
+	}
+
+	return nil
+}
+
+// FindURLsByTag retrieves short URLs of a user having the tag assigned.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - name: Tag name
+// Returns:
+// - []*shortURLEntity.ShortURL: Tagged URLs ordered by ID, empty if tag doesn't exist
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *FileDB) FindURLsByTag(ctx context.Context, userID int, name string) ([]*shortURLEntity.ShortURL, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	var urls []*shortURLEntity.ShortURL
+
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	tag := db.unsafeFindTag(userID, name)
+	if tag == nil {
+		return nil, nil
+	}
+
+	for key, tagIDs := range db.urlTags {
+		if _, ok := tagIDs[tag.ID]; ok {
+			if url, ok := db.shortURLs[key]; ok && url.UserID == userID {
+				urls = append(urls, url)
+			}
+		}
+	}
+
+	sort.Slice(urls, func(i, j int) bool { return urls[i].ID < urls[j].ID })
+
+	return urls, nil
+}
+
+// unsafeFindTag looks up a tag of a user by name without locking.
+// Caller must hold the read or write lock.
+// Parameters:
+// - userID: Owner's user ID
+// - name: Tag name
+// Returns:
+// - *tagEntity.Tag: Found tag or nil
+func (db *FileDB) unsafeFindTag(userID int, name string) *tagEntity.Tag {
+	for _, tag := range db.tags {
+		if tag.UserID == userID && tag.Name == name {
+			return tag
+		}
+	}
+	return nil
+}
+
+// SaveWebhook stores a new webhook of a user.
+// Webhooks are not persisted to file, so they are reset on restart like users.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - webhook: Webhook to save
+// Returns:
+// - *webhookEntity.Webhook: Saved webhook with auto-incremented ID
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *FileDB) SaveWebhook(ctx context.Context, webhook *webhookEntity.Webhook) (*webhookEntity.Webhook, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	db.lastWebhookID++
+	webhook.ID = db.lastWebhookID
+
+	db.webhooks[webhook.ID] = webhook
+	return webhook, nil
+}
+
+// FindWebhooksByUser retrieves all webhooks of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// Returns:
+// - []*webhookEntity.Webhook: User's webhooks ordered by ID
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *FileDB) FindWebhooksByUser(ctx context.Context, userID int) ([]*webhookEntity.Webhook, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	var webhooks []*webhookEntity.Webhook
+
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	for _, webhook := range db.webhooks {
+		if webhook.UserID == userID {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].ID < webhooks[j].ID })
+
+	return webhooks, nil
+}
+
+// DeleteWebhook removes a webhook of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - id: Webhook ID
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if user has no webhook with such ID
+func (db *FileDB) DeleteWebhook(ctx context.Context, userID, id int) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if webhook, ok := db.webhooks[id]; !ok || webhook.UserID != userID {
+		return dbErrors.ErrDBRecordNotFound
+	}
+	delete(db.webhooks, id)
+
+	return nil
+}
+
+// SaveAPIKey stores hash of a new API key of a user.
+// API keys are not persisted to file, so they are reset on restart like users.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - keyHash: Hash of the key
+// Returns:
+// - error: dbErrors.ErrDBIsNotUnique if the key hash is already stored
+func (db *FileDB) SaveAPIKey(ctx context.Context, userID int, keyHash string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if _, ok := db.apiKeys[keyHash]; ok {
+		return dbErrors.ErrDBIsNotUnique
+	}
+	db.apiKeys[keyHash] = &apiKey{userID: userID}
+
+	return nil
+}
+
+// FindUserByAPIKey retrieves the owner of a not revoked API key.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - keyHash: Hash of the key
+// Returns:
+// - *userEntity.User: Owner of the key
+// - error: dbErrors.ErrDBRecordNotFound if key is unknown or revoked
+func (db *FileDB) FindUserByAPIKey(ctx context.Context, keyHash string) (*userEntity.User, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	key, ok := db.apiKeys[keyHash]
+	if !ok || key.revoked {
+		return nil, dbErrors.ErrDBRecordNotFound
+	}
+
+	user := &userEntity.User{ID: key.userID, IsActive: db.isUserActive(key.userID)}
+	if owner, ok := db.users[key.userID]; ok {
+		user.CustomDomain = owner.CustomDomain
+	}
+
+	return user, nil
+}
+
+// isUserActive reports whether the user may authenticate.
+// Must be called with db.mutex held.
+// Parameters:
+// - userID: User ID
+// Returns:
+// - bool: false only if the user is deactivated
+func (db *FileDB) isUserActive(userID int) bool {
+	user, ok := db.users[userID]
+	return !ok || user.IsActive
+}
+
+// RevokeAPIKey marks an API key of a user as revoked.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - keyHash: Hash of the key
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if user has no such not revoked key
+func (db *FileDB) RevokeAPIKey(ctx context.Context, userID int, keyHash string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	key, ok := db.apiKeys[keyHash]
+	if !ok || key.revoked || key.userID != userID {
+		return dbErrors.ErrDBRecordNotFound
+	}
+	key.revoked = true
+
+	return nil
+}
+
+// SaveClick stores a served redirect of a short URL.
+// Clicks are not persisted to file, so they are reset on restart like users.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - click: Click to save
+// Returns:
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *FileDB) SaveClick(ctx context.Context, click *analyticsEntity.Click) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	key := urlKey(click.Namespace, click.Alias)
+	db.clicks[key] = append(db.clicks[key], click)
+
+	return nil
+}
+
+// FindUserClicks retrieves clicks of all short URLs of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// Returns:
+// - []*analyticsEntity.Click: Clicks ordered by click time
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *FileDB) FindUserClicks(ctx context.Context, userID int) ([]*analyticsEntity.Click, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	var clicks []*analyticsEntity.Click
+
+	db.mutex.RLock()
+	for key, url := range db.shortURLs {
+		if url.UserID == userID {
+			clicks = append(clicks, db.clicks[key]...)
+		}
+	}
+	db.mutex.RUnlock()
+
+	sort.SliceStable(clicks, func(i, j int) bool { return clicks[i].ClickedAt.Before(clicks[j].ClickedAt) })
+
+	return clicks, nil
+}
+
+// CountClicks counts clicks of a short URL in [from, to) grouped by periods of the granularity in UTC.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL alias
+// - from: Start of the time range, inclusive
+// - to: End of the time range, exclusive
+// - granularity: Period length, one of hour, day, week or month
+// Returns:
+// - []analyticsEntity.ClickCount: Non-zero counts ordered by period start
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *FileDB) CountClicks(ctx context.Context, namespace, alias string, from, to time.Time, granularity string) ([]analyticsEntity.ClickCount, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	var counts []analyticsEntity.ClickCount
+
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	byPeriod := make(map[time.Time]int64)
+	for _, click := range db.clicks[urlKey(namespace, alias)] {
+		if click.ClickedAt.Before(from) || !click.ClickedAt.Before(to) {
+			continue
+		}
+		byPeriod[analyticsEntity.TruncateTime(click.ClickedAt, granularity)]++
+	}
+
+	for start, clicks := range byPeriod {
+		counts = append(counts, analyticsEntity.ClickCount{PeriodStart: start, Clicks: clicks})
+	}
+
+	sort.Slice(counts, func(i, j int) bool { return counts[i].PeriodStart.Before(counts[j].PeriodStart) })
+
+	return counts, nil
+}
+
+// CountClicksByLocation counts clicks of a short URL in [from, to) grouped by country and city.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL alias
+// - from: Start of the time range, inclusive
+// - to: End of the time range, exclusive
+// Returns:
+// - []analyticsEntity.LocationCount: Non-zero counts ordered by clicks descending
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *FileDB) CountClicksByLocation(ctx context.Context, namespace, alias string, from, to time.Time) ([]analyticsEntity.LocationCount, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	var counts []analyticsEntity.LocationCount
+
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	byLocation := make(map[[2]string]int64)
+	for _, click := range db.clicks[urlKey(namespace, alias)] {
+		if click.ClickedAt.Before(from) || !click.ClickedAt.Before(to) {
+			continue
+		}
+		byLocation[[2]string{click.Location.Country, click.Location.City}]++
+	}
+
+	for location, clicks := range byLocation {
+		counts = append(counts, analyticsEntity.LocationCount{Country: location[0], City: location[1], Clicks: clicks})
+	}
+
+	analyticsEntity.SortLocationCounts(counts)
+
+	return counts, nil
+}
+
+// SaveSplit stores a new split short URL in memory.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - split: Split to save
+// Returns:
+// - error: dbErrors.ErrDBIsNotUnique if a short URL or a split with the alias already exists in the namespace,
+// dbErrors.ErrDBReferenceNotFound if the namespace doesn't exist
+func (db *FileDB) SaveSplit(ctx context.Context, split *splitEntity.MultiDestination) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	split.Namespace = namespaceEntity.OrDefault(split.Namespace)
+	if _, ok := db.namespaces[split.Namespace]; !ok {
+		return dbErrors.ErrDBReferenceNotFound
+	}
+
+	key := urlKey(split.Namespace, split.Alias)
+	if _, ok := db.shortURLs[key]; ok {
+		return dbErrors.ErrDBIsNotUnique
+	}
+	if _, ok := db.splits[key]; ok {
+		return dbErrors.ErrDBIsNotUnique
+	}
+
+	db.splits[key] = copySplit(split)
+
+	return nil
+}
+
+// FindSplit retrieves a split short URL by its namespace and alias.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the split, empty for the default one
+// - alias: Split alias
+// Returns:
+// - *splitEntity.MultiDestination: Copy of the split with destination click counts
+// - error: dbErrors.ErrDBRecordNotFound if split doesn't exist
+func (db *FileDB) FindSplit(ctx context.Context, namespace, alias string) (*splitEntity.MultiDestination, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	split, ok := db.splits[urlKey(namespace, alias)]
+	if !ok {
+		return nil, dbErrors.ErrDBRecordNotFound
+	}
+
+	return copySplit(split), nil
+}
+
+// SaveSplitClick increments click count of a destination of a split short URL.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the split, empty for the default one
+// - alias: Split alias
+// - url: Original URL of the destination
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if split has no such destination
+func (db *FileDB) SaveSplitClick(ctx context.Context, namespace, alias, url string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if split, ok := db.splits[urlKey(namespace, alias)]; ok {
+		for i := range split.Destinations {
+			if split.Destinations[i].URL == url {
+				split.Destinations[i].Clicks++
+				return nil
+			}
+		}
+	}
+
+	return dbErrors.ErrDBRecordNotFound
+}
+
+// copySplit copies a split, so stored destinations are not shared with callers.
+// Parameters:
+// - split: Split to copy
+// Returns:
+// - *splitEntity.MultiDestination: Copy of the split
+func copySplit(split *splitEntity.MultiDestination) *splitEntity.MultiDestination {
+	res := *split
+	res.Destinations = slices.Clone(split.Destinations)
+	return &res
+}
+
+// SaveNamespace stores a new namespace in memory.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace to save
+// Returns:
+// - *namespaceEntity.Namespace: Saved namespace with creation time
+// - error: dbErrors.ErrDBIsNotUnique if namespace with the name already exists
+func (db *FileDB) SaveNamespace(ctx context.Context, namespace *namespaceEntity.Namespace) (*namespaceEntity.Namespace, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if _, ok := db.namespaces[namespace.Name]; ok {
+		return nil, dbErrors.ErrDBIsNotUnique
+	}
+
+	saved := &namespaceEntity.Namespace{Name: namespace.Name, CreatedAt: time.Now()}
+	db.namespaces[saved.Name] = saved
+
+	res := *saved
+	return &res, nil
+}
+
+// FindNamespaces retrieves all namespaces.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - []*namespaceEntity.Namespace: Copies of namespaces ordered by name
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *FileDB) FindNamespaces(ctx context.Context) ([]*namespaceEntity.Namespace, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	namespaces := make([]*namespaceEntity.Namespace, 0, len(db.namespaces))
+	for _, namespace := range db.namespaces {
+		res := *namespace
+		namespaces = append(namespaces, &res)
+	}
+
+	sort.Slice(namespaces, func(i, j int) bool { return namespaces[i].Name < namespaces[j].Name })
+
+	return namespaces, nil
+}
+
+// DeleteNamespace removes a namespace without short URLs.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - name: Namespace name
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if namespace doesn't exist,
+// dbErrors.ErrDBRecordIsReferenced if namespace has short URLs or splits
+func (db *FileDB) DeleteNamespace(ctx context.Context, name string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if _, ok := db.namespaces[name]; !ok {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	for _, url := range db.shortURLs {
+		if url.Namespace == name {
+			return dbErrors.ErrDBRecordIsReferenced
+		}
+	}
+	for _, split := range db.splits {
+		if split.Namespace == name {
+			return dbErrors.ErrDBRecordIsReferenced
+		}
+	}
+
+	delete(db.namespaces, name)
+
+	return nil
+}
+
+// Ping checks if the database is accessible.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - error: If file stat operation fails
+func (db *FileDB) Ping(ctx context.Context) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	_, err := db.file.Stat()
+	return err
+}
+
+// Shutdown gracefully closes the database connection and flushes any pending writes.
+// It ensures all data is persisted to disk before closing: the write-ahead log is
+// compacted into the file and removed.
+// Writes after shutdown fail with dbErrors.ErrDBIsClosed.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - error: If shutdown fails (e.g. file sync error)
+func (db *FileDB) Shutdown(_ context.Context) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.file == nil {
+		return nil // Already closed
+	}
+
+	// 1. Merge logged changes into the file
+	if err := db.compact(); err != nil {
+		return fmt.Errorf("failed to compact file: %w", err)
+	}
+
+	// 2. Flush any buffered data to disk
+	if err := db.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync file: %w", err)
+	}
+
+	// 3. Close the file handles
+	if err := db.file.Close(); err != nil {
+		return fmt.Errorf("failed to close file: %w", err)
+	}
+	if err := db.wal.Close(); err != nil {
+		return fmt.Errorf("failed to close write-ahead log: %w", err)
+	}
+
+	// 4. Clear the reference to prevent double-close
 	db.file = nil
 
+	// 5. Remove the empty log, it is created again on startup
+	if err := os.Remove(db.path + walSuffix); err != nil {
+		return fmt.Errorf("failed to remove write-ahead log: %w", err)
+	}
+
 	return nil
 }
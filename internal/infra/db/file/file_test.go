@@ -0,0 +1,547 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	namespaceEntity "github.com/gururuby/shortener/internal/domain/entity/namespace"
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	splitEntity "github.com/gururuby/shortener/internal/domain/entity/split"
+	tagEntity "github.com/gururuby/shortener/internal/domain/entity/tag"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/gururuby/shortener/internal/infra/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FileDB_RestorePartiallyWrittenFile(t *testing.T) {
+	ctx := context.Background()
+	logger.Setup("test", "error")
+
+	path := filepath.Join(t.TempDir(), "storage.json")
+
+	db, err := New(path)
+	require.NoError(t, err)
+
+	for i := 1; i <= 5; i++ {
+		_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{
+			UUID:      fmt.Sprintf("uuid-%d", i),
+			SourceURL: fmt.Sprintf("https://example.com/%d", i),
+			Alias:     fmt.Sprintf("alias%d", i),
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.Shutdown(ctx))
+
+	// No temporary files must be left after writes
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// Simulate crash in the middle of writing the last record
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-10))
+
+	db, err = New(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Shutdown(ctx) })
+
+	for i := 1; i <= 4; i++ {
+		shortURL, findErr := db.FindShortURL(ctx, "", fmt.Sprintf("alias%d", i))
+		require.NoError(t, findErr)
+		assert.Equal(t, fmt.Sprintf("https://example.com/%d", i), shortURL.SourceURL)
+	}
+
+	_, err = db.FindShortURL(ctx, "", "alias5")
+	require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+}
+
+func Test_FileDB_FindUserURLsCursor_TraversesAllRecords(t *testing.T) {
+	ctx := context.Background()
+	logger.Setup("test", "error")
+
+	db, err := New(filepath.Join(t.TempDir(), "storage.json"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Shutdown(ctx) })
+
+	saveURL := func(i int) {
+		_, saveErr := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{
+			SourceURL: fmt.Sprintf("https://example.com/%d", i),
+			Alias:     fmt.Sprintf("alias%d", i),
+			UserID:    1,
+		})
+		require.NoError(t, saveErr)
+	}
+
+	for i := 1; i <= 10; i++ {
+		saveURL(i)
+	}
+
+	var (
+		seen    = make(map[string]bool)
+		cursor  int
+		created = 10
+	)
+
+	for {
+		urls, nextCursor, findErr := db.FindUserURLsCursor(ctx, 1, cursor, 3)
+		require.NoError(t, findErr)
+
+		for _, url := range urls {
+			require.False(t, seen[url.Alias], "duplicate %s", url.Alias)
+			seen[url.Alias] = true
+		}
+
+		if nextCursor == 0 {
+			break
+		}
+		cursor = nextCursor
+
+		// New URLs created between pages must not shift already returned ones
+		created++
+		saveURL(created)
+	}
+
+	assert.Len(t, seen, created)
+	for i := 1; i <= created; i++ {
+		assert.True(t, seen[fmt.Sprintf("alias%d", i)], "missing alias%d", i)
+	}
+}
+
+func Test_FileDB_WriteAfterShutdown(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "storage.json")
+
+	db, err := New(path)
+	require.NoError(t, err)
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://example.com/1", Alias: "alias1"})
+	require.NoError(t, err)
+
+	require.NoError(t, db.Shutdown(ctx))
+	require.NoError(t, db.Shutdown(ctx))
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://example.com/2", Alias: "alias2"})
+	require.ErrorIs(t, err, dbErrors.ErrDBIsClosed)
+
+	err = db.MarkURLAsDeleted(ctx, 0, "", []string{"alias1"})
+	require.ErrorIs(t, err, dbErrors.ErrDBIsClosed)
+
+	// File must keep only the record saved before shutdown
+	restored, err := New(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = restored.Shutdown(ctx) })
+
+	shortURL, err := restored.FindShortURL(ctx, "", "alias1")
+	require.NoError(t, err)
+	assert.False(t, shortURL.IsDeleted)
+
+	_, err = restored.FindShortURL(ctx, "", "alias2")
+	require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+}
+
+func Test_FileDB_RestoreURL(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "storage.json")
+
+	db, err := New(path)
+	require.NoError(t, err)
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://example.com/1", Alias: "alias1", UserID: 1})
+	require.NoError(t, err)
+	require.NoError(t, db.MarkURLAsDeleted(ctx, 1, "", []string{"alias1"}))
+
+	require.ErrorIs(t, db.RestoreURL(ctx, 2, "", "alias1"), dbErrors.ErrDBRecordNotFound)
+	require.NoError(t, db.RestoreURL(ctx, 1, "", "alias1"))
+	require.NoError(t, db.Shutdown(ctx))
+
+	// Restoration must be persisted to the file
+	restored, err := New(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = restored.Shutdown(ctx) })
+
+	shortURL, err := restored.FindShortURL(ctx, "", "alias1")
+	require.NoError(t, err)
+	assert.False(t, shortURL.IsDeleted)
+}
+
+func Test_FileDB_SearchUserURLs(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := New(filepath.Join(t.TempDir(), "storage.json"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Shutdown(ctx) })
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://GitHub.com/gururuby", Alias: "alias1", UserID: 1})
+	require.NoError(t, err)
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://ya.ru", Alias: "alias2", UserID: 1})
+	require.NoError(t, err)
+
+	urls, total, err := db.SearchUserURLs(ctx, 1, "github.COM", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, urls, 1)
+	assert.Equal(t, "alias1", urls[0].Alias)
+}
+
+func Test_FileDB_SaveShortURL_AliasTaken(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := New(filepath.Join(t.TempDir(), "storage.json"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Shutdown(ctx) })
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://ya.ru", Alias: "abc"})
+	require.NoError(t, err)
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://example.com", Alias: "abc"})
+	require.ErrorIs(t, err, dbErrors.ErrDBAliasTaken)
+
+	found, err := db.FindShortURL(ctx, "", "abc")
+	require.NoError(t, err)
+	require.Equal(t, "https://ya.ru", found.SourceURL, "taken alias must not be overwritten")
+}
+
+func Test_FileDB_Namespaces(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "storage.json")
+
+	db, err := New(path)
+	require.NoError(t, err)
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://a.com", Alias: "abc", Namespace: "team-a"})
+	require.ErrorIs(t, err, dbErrors.ErrDBReferenceNotFound)
+
+	_, err = db.SaveNamespace(ctx, &namespaceEntity.Namespace{Name: "team-a"})
+	require.NoError(t, err)
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://a.com", Alias: "abc", Namespace: "team-a"})
+	require.NoError(t, err)
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://default.com", Alias: "abc"})
+	require.NoError(t, err)
+	require.ErrorIs(t, db.DeleteNamespace(ctx, "team-a"), dbErrors.ErrDBRecordIsReferenced)
+	require.NoError(t, db.Shutdown(ctx))
+
+	// Namespaces of short URLs must be restored from the file
+	restored, err := New(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = restored.Shutdown(ctx) })
+
+	shortURL, err := restored.FindShortURL(ctx, "team-a", "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "https://a.com", shortURL.SourceURL)
+
+	shortURL, err = restored.FindShortURL(ctx, "", "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "https://default.com", shortURL.SourceURL)
+
+	namespaces, err := restored.FindNamespaces(ctx)
+	require.NoError(t, err)
+	require.Len(t, namespaces, 2)
+	assert.Equal(t, "default", namespaces[0].Name)
+	assert.Equal(t, "team-a", namespaces[1].Name)
+}
+
+func Test_FileDB_NamespaceIsolation(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "storage.json")
+
+	db, err := New(path)
+	require.NoError(t, err)
+
+	_, err = db.SaveNamespace(ctx, &namespaceEntity.Namespace{Name: "team"})
+	require.NoError(t, err)
+	for _, url := range []*shortURLEntity.ShortURL{
+		{SourceURL: "https://default.com", Alias: "abc", UserID: 1},
+		{SourceURL: "https://team.com", Alias: "abc", Namespace: "team", UserID: 1},
+		{SourceURL: "https://team.com/one-time", Alias: "def", Namespace: "team", UserID: 1},
+		{SourceURL: "https://default.com/one-time", Alias: "def", UserID: 1},
+	} {
+		_, err = db.SaveShortURL(ctx, url)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, db.MarkURLAsDeleted(ctx, 0, "team", []string{"def"}))
+	require.NoError(t, db.UpdateURLTarget(ctx, 1, "team", "abc", "https://go.dev"))
+	history, err := db.FindURLHistory(ctx, "", "abc")
+	require.NoError(t, err)
+	assert.Empty(t, history)
+
+	_, err = db.SaveTag(ctx, &tagEntity.Tag{UserID: 1, Name: "work"})
+	require.NoError(t, err)
+	require.NoError(t, db.AssignTag(ctx, 1, "team", "abc", "work"))
+	tags, err := db.FindURLTags(ctx, 1, "", "abc")
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+
+	split := &splitEntity.MultiDestination{
+		Alias:        "ab-test",
+		Namespace:    "team",
+		UserID:       1,
+		Destinations: []splitEntity.Destination{{URL: "https://v1.com", Weight: 50}, {URL: "https://v2.com", Weight: 50}},
+	}
+	require.NoError(t, db.SaveSplit(ctx, split))
+	_, err = db.FindSplit(ctx, "", "ab-test")
+	require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+	require.NoError(t, db.Shutdown(ctx))
+
+	// Changes of the namespace must be persisted without touching the default one
+	restored, err := New(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = restored.Shutdown(ctx) })
+
+	for _, tt := range []struct {
+		namespace, alias, sourceURL string
+		deleted                     bool
+	}{
+		{namespace: "", alias: "abc", sourceURL: "https://default.com"},
+		{namespace: "team", alias: "abc", sourceURL: "https://go.dev"},
+		{namespace: "", alias: "def", sourceURL: "https://default.com/one-time"},
+		{namespace: "team", alias: "def", sourceURL: "https://team.com/one-time", deleted: true},
+	} {
+		shortURL, findErr := restored.FindShortURL(ctx, tt.namespace, tt.alias)
+		require.NoError(t, findErr)
+		assert.Equal(t, tt.sourceURL, shortURL.SourceURL, tt.namespace+"/"+tt.alias)
+		assert.Equal(t, tt.deleted, shortURL.IsDeleted, tt.namespace+"/"+tt.alias)
+	}
+}
+
+func Test_FileDB_UpdateURLTarget(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "storage.json")
+
+	db, err := New(path)
+	require.NoError(t, err)
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://example.com/1", Alias: "alias1", UserID: 1})
+	require.NoError(t, err)
+
+	require.ErrorIs(t, db.UpdateURLTarget(ctx, 2, "", "alias1", "https://example.com/2"), dbErrors.ErrDBRecordNotFound)
+	require.NoError(t, db.UpdateURLTarget(ctx, 1, "", "alias1", "https://example.com/2"))
+
+	history, err := db.FindURLHistory(ctx, "", "alias1")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "https://example.com/1", history[0].OriginalURL)
+	require.NoError(t, db.Shutdown(ctx))
+
+	// New original URL must be persisted to the file
+	restored, err := New(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = restored.Shutdown(ctx) })
+
+	shortURL, err := restored.FindShortURL(ctx, "", "alias1")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/2", shortURL.SourceURL)
+}
+
+func Test_FileDB_DeleteExpiredURLs(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "storage.json")
+
+	db, err := New(path)
+	require.NoError(t, err)
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://example.com/1", Alias: "expired", ExpiresAt: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://example.com/2", Alias: "active", ExpiresAt: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://example.com/3", Alias: "permanent"})
+	require.NoError(t, err)
+
+	n, err := db.DeleteExpiredURLs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	require.NoError(t, db.Shutdown(ctx))
+
+	// Removal and expiration times must be persisted to the file
+	restored, err := New(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = restored.Shutdown(ctx) })
+
+	_, err = restored.FindShortURL(ctx, "", "expired")
+	require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+
+	active, err := restored.FindShortURL(ctx, "", "active")
+	require.NoError(t, err)
+	assert.False(t, active.ExpiresAt.IsZero())
+
+	permanent, err := restored.FindShortURL(ctx, "", "permanent")
+	require.NoError(t, err)
+	assert.True(t, permanent.ExpiresAt.IsZero())
+
+	n, err = restored.DeleteExpiredURLs(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func Test_FileDB_URLHealth(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "storage.json")
+	checkedAt := time.Date(2025, 9, 10, 6, 0, 0, 0, time.UTC)
+
+	db, err := New(path)
+	require.NoError(t, err)
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://example.com/1", Alias: "dead"})
+	require.NoError(t, err)
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://example.com/2", Alias: "expired", ExpiresAt: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://example.com/3", Alias: "unchecked"})
+	require.NoError(t, err)
+
+	require.NoError(t, db.SaveURLHealth(ctx, "", "dead", false, checkedAt))
+	require.ErrorIs(t, db.SaveURLHealth(ctx, "", "unknown", false, checkedAt), dbErrors.ErrDBRecordNotFound)
+	require.NoError(t, db.Shutdown(ctx))
+
+	// Results of checks must be persisted to the file
+	restored, err := New(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = restored.Shutdown(ctx) })
+
+	dead, err := restored.FindShortURL(ctx, "", "dead")
+	require.NoError(t, err)
+	assert.False(t, dead.IsSourceHealthy())
+	assert.True(t, checkedAt.Equal(dead.CheckedAt))
+
+	unchecked, err := restored.FindShortURL(ctx, "", "unchecked")
+	require.NoError(t, err)
+	assert.True(t, unchecked.IsSourceHealthy())
+
+	urls, err := restored.FindURLsToCheck(ctx, checkedAt, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, urls, 1)
+	assert.Equal(t, "unchecked", urls[0].Alias)
+
+	urls, err = restored.FindURLsToCheck(ctx, checkedAt.Add(time.Second), 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, urls, 2)
+}
+
+func Test_FileDB_SaveShortURL_CancelledContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "storage.json")
+
+	db, err := New(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Shutdown(context.Background()) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://example.com/1", Alias: "alias1"})
+	require.ErrorIs(t, err, dbErrors.ErrDBContextDone)
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Empty(t, data, "file must not be written with cancelled context")
+}
+
+// simulateCrash closes files of the database without compaction, as if the process was killed.
+func simulateCrash(t *testing.T, db *FileDB) {
+	t.Helper()
+	require.NoError(t, db.file.Close())
+	require.NoError(t, db.wal.Close())
+}
+
+func Test_FileDB_ReplayWAL(t *testing.T) {
+	ctx := context.Background()
+	logger.Setup("test", "error")
+	path := filepath.Join(t.TempDir(), "storage.json")
+
+	db, err := New(path)
+	require.NoError(t, err)
+
+	for i := 1; i <= 3; i++ {
+		_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: fmt.Sprintf("https://example.com/%d", i), Alias: fmt.Sprintf("alias%d", i), UserID: 1})
+		require.NoError(t, err)
+	}
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://example.com/expired", Alias: "expired", ExpiresAt: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+	require.NoError(t, db.MarkURLAsDeleted(ctx, 1, "", []string{"alias1"}))
+	require.NoError(t, db.UpdateURLTarget(ctx, 1, "", "alias2", "https://example.com/updated"))
+	removed, err := db.DeleteExpiredURLs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), removed)
+	require.NoError(t, db.MarkURLAsDeleted(ctx, 1, "", []string{"alias3"}))
+	simulateCrash(t, db)
+
+	// Changes are only logged, the file keeps the state of the last compaction
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+
+	// Simulate crash in the middle of writing the last record
+	info, err = os.Stat(path + walSuffix)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path+walSuffix, info.Size()-10))
+
+	restored, err := New(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = restored.Shutdown(ctx) })
+
+	deleted, err := restored.FindShortURL(ctx, "", "alias1")
+	require.NoError(t, err)
+	assert.True(t, deleted.IsDeleted)
+
+	updated, err := restored.FindShortURL(ctx, "", "alias2")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/updated", updated.SourceURL)
+
+	notDeleted, err := restored.FindShortURL(ctx, "", "alias3")
+	require.NoError(t, err)
+	assert.False(t, notDeleted.IsDeleted)
+
+	_, err = restored.FindShortURL(ctx, "", "expired")
+	require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+
+	// Replayed changes are compacted into the file on startup
+	info, err = os.Stat(path + walSuffix)
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+
+	_, err = restored.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://example.com/4", Alias: "alias4"})
+	require.NoError(t, err)
+	assert.Equal(t, 4, restored.lastURLID)
+}
+
+func Test_FileDB_Compact(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "storage.json")
+
+	db, err := New(path)
+	require.NoError(t, err)
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://example.com/1", Alias: "alias1"})
+	require.NoError(t, err)
+	require.NoError(t, db.Compact(ctx))
+
+	info, err := os.Stat(path + walSuffix)
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://example.com/2", Alias: "alias2"})
+	require.NoError(t, err)
+	simulateCrash(t, db)
+
+	// Both the compacted and the logged records must be restored
+	restored, err := New(path)
+	require.NoError(t, err)
+
+	for _, alias := range []string{"alias1", "alias2"} {
+		_, err = restored.FindShortURL(ctx, "", alias)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, restored.Shutdown(ctx))
+	require.ErrorIs(t, restored.Compact(ctx), dbErrors.ErrDBIsClosed)
+
+	// Log is removed on shutdown
+	_, err = os.Stat(path + walSuffix)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
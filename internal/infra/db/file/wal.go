@@ -0,0 +1,170 @@
+package db
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/gururuby/shortener/internal/infra/logger"
+	"go.uber.org/zap"
+)
+
+// walSuffix is appended to the database file path to get the path of the write-ahead log.
+const walSuffix = ".wal"
+
+// Operations of write-ahead log records.
+const (
+	walOpSave   = "save"   // Creates or replaces a short URL
+	walOpDelete = "delete" // Marks a short URL as deleted
+	walOpRemove = "remove" // Removes a short URL, e.g. expired one
+)
+
+// walRecord is a single mutation of short URLs stored in the write-ahead log.
+type walRecord struct {
+	Op        string   `json:"op"`
+	Alias     string   `json:"alias"`
+	Namespace string   `json:"namespace,omitempty"`
+	UserID    int      `json:"user_id,omitempty"` // Owner required by delete, 0 for any owner
+	Data      *fileDTO `json:"data,omitempty"`    // Short URL written by save
+}
+
+// newSaveRecord creates a log record replacing the short URL with its current state.
+// Parameters:
+// - shortURL: Saved or changed short URL
+// Returns:
+// - *walRecord: Save record
+func newSaveRecord(shortURL *shortURLEntity.ShortURL) *walRecord {
+	dto := toFileDTO(shortURL)
+	return &walRecord{Op: walOpSave, Alias: dto.ShortURL, Namespace: dto.Namespace, Data: dto}
+}
+
+// openWAL opens the write-ahead log of the database file, creating it if needed.
+// Parameters:
+// - filePath: Path to the database file
+// Returns:
+// - *os.File: Log opened for appending
+// - error: If file cannot be opened
+func openWAL(filePath string) (*os.File, error) {
+	return os.OpenFile(filePath+walSuffix, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
+}
+
+// replayWAL applies logged mutations to short URLs restored from the database file.
+// Records are applied in order up to the first malformed one, e.g. partially written
+// before a crash, the rest of the log is skipped with a warning.
+// Parameters:
+// - f: Log to read from
+// - shortURLs: Restored short URLs to apply mutations to
+// Returns:
+// - error: If reading fails
+func replayWAL(f *os.File, shortURLs map[string]*shortURLEntity.ShortURL) error {
+	scanner := bufio.NewScanner(f)
+
+	for line := 1; scanner.Scan(); line++ {
+		record := &walRecord{}
+		err := json.Unmarshal(scanner.Bytes(), record)
+		if err == nil {
+			err = applyWALRecord(shortURLs, record)
+		}
+		if err != nil {
+			logger.Log.Warn("stopping replay at malformed record",
+				zap.String("file", f.Name()),
+				zap.Int("line", line),
+				zap.Error(fmt.Errorf(dbErrors.ErrDBRestoreFromFile.Error(), err.Error())))
+			return nil
+		}
+	}
+
+	return scanner.Err()
+}
+
+// applyWALRecord applies a single logged mutation. Applying a record again has no effect,
+// so a log left after a crash during compaction is safely replayed once more.
+// Parameters:
+// - shortURLs: Short URLs to apply the mutation to
+// - record: Logged mutation
+// Returns:
+// - error: If the record has unknown operation or no data to save
+func applyWALRecord(shortURLs map[string]*shortURLEntity.ShortURL, record *walRecord) error {
+	key := urlKey(record.Namespace, record.Alias)
+
+	switch record.Op {
+	case walOpSave:
+		if record.Data == nil {
+			return fmt.Errorf("no data to save for %s", record.Alias)
+		}
+		shortURLs[key] = toShortURL(record.Data)
+	case walOpDelete:
+		if url, ok := shortURLs[key]; ok && (record.UserID == 0 || url.UserID == record.UserID) {
+			url.IsDeleted = true
+		}
+	case walOpRemove:
+		delete(shortURLs, key)
+	default:
+		return fmt.Errorf("unknown operation %q", record.Op)
+	}
+
+	return nil
+}
+
+// appendWAL writes mutations to the write-ahead log and syncs it to disk.
+// Caller must hold the write lock.
+// Parameters:
+// - records: Mutations to log
+// Returns:
+// - error: If marshaling or any file operation fails
+func (db *FileDB) appendWAL(records ...*walRecord) error {
+	var buf []byte
+	for _, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, data...), '\n')
+	}
+
+	if _, err := db.wal.Write(buf); err != nil {
+		return err
+	}
+
+	return db.wal.Sync()
+}
+
+// Compact writes all short URLs to the database file and truncates the write-ahead log.
+// It is done on startup and shutdown, calling it in between bounds the size of the log.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - error: dbErrors.ErrDBIsClosed if database is shut down, other error if file operation fails
+func (db *FileDB) Compact(ctx context.Context) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.file == nil {
+		return dbErrors.ErrDBIsClosed
+	}
+
+	return db.compact()
+}
+
+// compact atomically rewrites the database file and truncates the write-ahead log.
+// Caller must hold the write lock.
+// Returns:
+// - error: If any file operation fails
+func (db *FileDB) compact() error {
+	if err := db.persist(); err != nil {
+		return err
+	}
+
+	if err := db.wal.Truncate(0); err != nil {
+		return err
+	}
+
+	return db.wal.Sync()
+}
@@ -2,26 +2,55 @@
 Package db implements an in-memory database for the URL shortener service.
 
 It provides:
-- Fast in-memory storage for users and short URLs
+- Fast in-memory storage for users, API keys, namespaces, short URLs, splits, tags, webhooks and clicks
 - Basic CRUD operations without persistence
 - Simple interface matching the database requirements
-- Thread-unsafe operations (caller must handle synchronization)
+- Thread-safe operations with read/write mutex
 */
 package db
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	analyticsEntity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	namespaceEntity "github.com/gururuby/shortener/internal/domain/entity/namespace"
 	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	splitEntity "github.com/gururuby/shortener/internal/domain/entity/split"
+	tagEntity "github.com/gururuby/shortener/internal/domain/entity/tag"
 	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	webhookEntity "github.com/gururuby/shortener/internal/domain/entity/webhook"
 	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
 )
 
 // MemoryDB represents an in-memory database implementation.
 // It stores data in maps without persistence to disk.
 type MemoryDB struct {
-	shortURLs map[string]*shortURLEntity.ShortURL // Map of short URL aliases to entities
-	users     map[int]*userEntity.User            // Map of user IDs to user entities
+	shortURLs     map[string]*shortURLEntity.ShortURL       // Map of short URL keys, see urlKey, to entities
+	namespaces    map[string]*namespaceEntity.Namespace     // Map of namespace names to namespaces
+	users         map[int]*userEntity.User                  // Map of user IDs to user entities
+	tags          map[int]*tagEntity.Tag                    // Map of tag IDs to tag entities
+	urlTags       map[string]map[int]struct{}               // Map of short URL keys to IDs of assigned tags
+	webhooks      map[int]*webhookEntity.Webhook            // Map of webhook IDs to webhook entities
+	apiKeys       map[string]*apiKey                        // Map of API key hashes to keys
+	clicks        map[string][]*analyticsEntity.Click       // Map of short URL keys to their clicks
+	urlHistory    map[string][]*shortURLEntity.HistoryEntry // Map of short URL keys to their previous original URLs
+	splits        map[string]*splitEntity.MultiDestination  // Map of split keys, see urlKey, to splits
+	lastURLID     int                                       // ID of the last saved short URL
+	lastTagID     int                                       // ID of the last saved tag
+	lastWebhookID int                                       // ID of the last saved webhook
+	mu            sync.RWMutex                              // Protects all fields above
+}
+
+// apiKey represents a stored API key of a user.
+type apiKey struct {
+	userID  int  // Owner of the key
+	revoked bool // Whether the key is revoked
 }
 
 // New creates and initializes a new MemoryDB instance.
@@ -29,19 +58,63 @@ type MemoryDB struct {
 // - *MemoryDB: Empty initialized in-memory database
 func New() *MemoryDB {
 	return &MemoryDB{
-		shortURLs: make(map[string]*shortURLEntity.ShortURL),
-		users:     make(map[int]*userEntity.User),
+		shortURLs:  make(map[string]*shortURLEntity.ShortURL),
+		users:      make(map[int]*userEntity.User),
+		tags:       make(map[int]*tagEntity.Tag),
+		urlTags:    make(map[string]map[int]struct{}),
+		webhooks:   make(map[int]*webhookEntity.Webhook),
+		apiKeys:    make(map[string]*apiKey),
+		clicks:     make(map[string][]*analyticsEntity.Click),
+		urlHistory: make(map[string][]*shortURLEntity.HistoryEntry),
+		splits:     make(map[string]*splitEntity.MultiDestination),
+		namespaces: map[string]*namespaceEntity.Namespace{
+			namespaceEntity.Default: {Name: namespaceEntity.Default, CreatedAt: time.Now()},
+		},
+	}
+}
+
+// urlKey returns the key of a short URL in shortURLs map.
+// Short URLs of the default namespace are keyed by alias, the rest by namespace and alias.
+// Parameters:
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// Returns:
+// - string: Key of the short URL
+func urlKey(namespace, alias string) string {
+	if namespace = namespaceEntity.OrDefault(namespace); namespace == namespaceEntity.Default {
+		return alias
 	}
+	return namespace + "/" + alias
+}
+
+// checkContext reports whether an operation may proceed with ctx.
+// Operations are fast, so context is checked once before the operation only.
+// Parameters:
+// - ctx: Context of the operation
+// Returns:
+// - error: dbErrors.ErrDBContextDone wrapping ctx.Err() if ctx is cancelled or its deadline exceeded
+func checkContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %w", dbErrors.ErrDBContextDone, err)
+	}
+	return nil
 }
 
 // FindUser retrieves a user by ID from memory.
 // Parameters:
-// - ctx: Context for cancellation/timeouts (unused)
+// - ctx: Context for cancellation/timeouts
 // - id: User ID to find
 // Returns:
 // - *userEntity.User: Found user entity
 // - error: dbErrors.ErrDBRecordNotFound if user doesn't exist
-func (db *MemoryDB) FindUser(_ context.Context, id int) (*userEntity.User, error) {
+func (db *MemoryDB) FindUser(ctx context.Context, id int) (*userEntity.User, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
 	user, ok := db.users[id]
 	if !ok {
 		return nil, dbErrors.ErrDBRecordNotFound
@@ -51,14 +124,21 @@ func (db *MemoryDB) FindUser(_ context.Context, id int) (*userEntity.User, error
 
 // FindUserURLs retrieves all short URLs belonging to a user.
 // Parameters:
-// - ctx: Context for cancellation/timeouts (unused)
+// - ctx: Context for cancellation/timeouts
 // - userID: Owner's user ID
 // Returns:
 // - []*shortURLEntity.ShortURL: List of user's URLs (empty slice if none)
-// - error: Always nil
-func (db *MemoryDB) FindUserURLs(_ context.Context, userID int) ([]*shortURLEntity.ShortURL, error) {
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) FindUserURLs(ctx context.Context, userID int) ([]*shortURLEntity.ShortURL, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	var urls []*shortURLEntity.ShortURL
 
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
 	for _, url := range db.shortURLs {
 		if url.UserID == userID {
 			urls = append(urls, url)
@@ -68,28 +148,251 @@ func (db *MemoryDB) FindUserURLs(_ context.Context, userID int) ([]*shortURLEnti
 	return urls, nil
 }
 
+// FindUserURLsPaginated retrieves a page of short URLs belonging to a user.
+// URLs are ordered by alias so that pages are stable between requests.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - offset: Number of URLs to skip
+// - limit: Maximum number of URLs to return
+// Returns:
+// - []*shortURLEntity.ShortURL: Requested page of user's URLs (empty if offset is out of range)
+// - int64: Total number of user's URLs
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) FindUserURLsPaginated(ctx context.Context, userID, offset, limit int) ([]*shortURLEntity.ShortURL, int64, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	var urls []*shortURLEntity.ShortURL
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	for _, url := range db.shortURLs {
+		if url.UserID == userID {
+			urls = append(urls, url)
+		}
+	}
+
+	sort.Slice(urls, func(i, j int) bool { return urls[i].Alias < urls[j].Alias })
+
+	total := int64(len(urls))
+
+	if offset >= len(urls) {
+		return nil, total, nil
+	}
+
+	end := offset + limit
+	if end > len(urls) {
+		end = len(urls)
+	}
+
+	return urls[offset:end], total, nil
+}
+
+// SearchUserURLs retrieves a page of short URLs of a user whose original URL contains the query.
+// The query is matched case-insensitively, URLs are ordered by alias.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - query: Substring of original URLs to find
+// - offset: Number of URLs to skip
+// - limit: Maximum number of URLs to return
+// Returns:
+// - []*shortURLEntity.ShortURL: Requested page of found URLs (empty if offset is out of range)
+// - int64: Total number of found URLs
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) SearchUserURLs(ctx context.Context, userID int, query string, offset, limit int) ([]*shortURLEntity.ShortURL, int64, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	var urls []*shortURLEntity.ShortURL
+
+	query = strings.ToLower(query)
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	for _, url := range db.shortURLs {
+		if url.UserID == userID && strings.Contains(strings.ToLower(url.SourceURL), query) {
+			urls = append(urls, url)
+		}
+	}
+
+	sort.Slice(urls, func(i, j int) bool { return urls[i].Alias < urls[j].Alias })
+
+	total := int64(len(urls))
+
+	if offset >= len(urls) {
+		return nil, total, nil
+	}
+
+	end := offset + limit
+	if end > len(urls) {
+		end = len(urls)
+	}
+
+	return urls[offset:end], total, nil
+}
+
+// CountUserURLs returns the number of not deleted short URLs of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID, 0 for URLs created anonymously
+// Returns:
+// - int64: Number of user's URLs
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) CountUserURLs(ctx context.Context, userID int) (int64, error) {
+	if err := checkContext(ctx); err != nil {
+		return 0, err
+	}
+
+	var count int64
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	for _, url := range db.shortURLs {
+		if url.UserID == userID && !url.IsDeleted {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// FindUserURLsCursor retrieves short URLs belonging to a user created after the cursor.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - afterID: ID of the last URL on the previous page, 0 for the first page
+// - limit: Maximum number of URLs to return
+// Returns:
+// - []*shortURLEntity.ShortURL: User's URLs with ID greater than afterID ordered by ID
+// - int: ID of the last returned URL, 0 if there are no more URLs
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) FindUserURLsCursor(ctx context.Context, userID, afterID, limit int) ([]*shortURLEntity.ShortURL, int, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	var urls []*shortURLEntity.ShortURL
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	for _, url := range db.shortURLs {
+		if url.UserID == userID && url.ID > afterID {
+			urls = append(urls, url)
+		}
+	}
+
+	sort.Slice(urls, func(i, j int) bool { return urls[i].ID < urls[j].ID })
+
+	if len(urls) <= limit {
+		return urls, 0, nil
+	}
+
+	urls = urls[:limit]
+
+	return urls, urls[limit-1].ID, nil
+}
+
 // SaveUser creates and stores a new user in memory.
 // Parameters:
-// - ctx: Context for cancellation/timeouts (unused)
+// - ctx: Context for cancellation/timeouts
 // Returns:
 // - *userEntity.User: Created user with auto-incremented ID
-// - error: Always nil
-func (db *MemoryDB) SaveUser(_ context.Context) (*userEntity.User, error) {
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) SaveUser(ctx context.Context) (*userEntity.User, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
 	id := len(db.users) + 1
-	user := &userEntity.User{ID: id}
+	user := &userEntity.User{ID: id, IsActive: true, CreatedAt: time.Now()}
 	db.users[id] = user
 	return user, nil
 }
 
-// FindShortURL retrieves a short URL by its alias.
+// SetUserActive activates or deactivates a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: User ID
+// - isActive: Whether the user may authenticate
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if user doesn't exist
+func (db *MemoryDB) SetUserActive(ctx context.Context, userID int, isActive bool) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	user, ok := db.users[userID]
+	if !ok {
+		return dbErrors.ErrDBRecordNotFound
+	}
+	user.IsActive = isActive
+	return nil
+}
+
+// SetUserCustomDomain sets the custom domain of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: User ID
+// - customDomain: Base URL of short URLs of the user, empty to remove it
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if user doesn't exist,
+// dbErrors.ErrDBIsNotUnique if the domain belongs to another user
+func (db *MemoryDB) SetUserCustomDomain(ctx context.Context, userID int, customDomain string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	user, ok := db.users[userID]
+	if !ok {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	if customDomain != "" {
+		for id, other := range db.users {
+			if id != userID && other.CustomDomain == customDomain {
+				return dbErrors.ErrDBIsNotUnique
+			}
+		}
+	}
+
+	user.CustomDomain = customDomain
+	return nil
+}
+
+// FindShortURL retrieves a short URL by its namespace and alias.
 // Parameters:
-// - ctx: Context for cancellation/timeouts (unused)
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the short URL, empty for the default one
 // - alias: Short URL identifier
 // Returns:
 // - *shortURLEntity.ShortURL: Found short URL entity
-// - error: dbErrors.ErrDBRecordNotFound if alias doesn't exist
-func (db *MemoryDB) FindShortURL(_ context.Context, alias string) (*shortURLEntity.ShortURL, error) {
-	shortURL, ok := db.shortURLs[alias]
+// - error: dbErrors.ErrDBRecordNotFound if alias doesn't exist in the namespace
+func (db *MemoryDB) FindShortURL(ctx context.Context, namespace, alias string) (*shortURLEntity.ShortURL, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	shortURL, ok := db.shortURLs[urlKey(namespace, alias)]
 	if !ok {
 		return nil, dbErrors.ErrDBRecordNotFound
 	}
@@ -97,32 +400,170 @@ func (db *MemoryDB) FindShortURL(_ context.Context, alias string) (*shortURLEnti
 	return shortURL, nil
 }
 
-// MarkURLAsDeleted marks URLs as deleted (not implemented).
+// MarkURLAsDeleted marks URLs as deleted.
 // Parameters:
-// - ctx: Context for cancellation/timeouts (unused)
-// - userID: Owner's user ID
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID, or 0 for any owner
+// - namespace: Namespace of the short URLs, empty for the default one
 // - aliases: URLs to mark as deleted
 // Returns:
-// - error: Always nil (not implemented)
-func (db *MemoryDB) MarkURLAsDeleted(_ context.Context, _ int, _ []string) error {
+// - error: dbErrors.ErrDBRecordIsDeleted if userID is 0 and all URLs are already deleted
+func (db *MemoryDB) MarkURLAsDeleted(ctx context.Context, userID int, namespace string, aliases []string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	var marked bool
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, alias := range aliases {
+		url := db.unsafeFindURL(userID, namespace, alias)
+		if url == nil || url.IsDeleted {
+			continue
+		}
+		url.IsDeleted = true
+		marked = true
+	}
+
+	if userID == 0 && !marked {
+		return dbErrors.ErrDBRecordIsDeleted
+	}
+
+	return nil
+}
+
+// RestoreURL clears the deletion mark of a short URL of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if the user has no URL with the alias
+func (db *MemoryDB) RestoreURL(ctx context.Context, userID int, namespace, alias string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	url := db.unsafeFindURL(userID, namespace, alias)
+	if url == nil {
+		return dbErrors.ErrDBRecordNotFound
+	}
+	url.IsDeleted = false
+
+	return nil
+}
+
+// UpdateURLTarget replaces the original URL of a short URL of a user.
+// The previous original URL is saved to the history of the short URL.
+// The deduplication key is reset, so the short URL is not reused for the previous target.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// - newURL: New original URL
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if the user has no URL with the alias
+func (db *MemoryDB) UpdateURLTarget(ctx context.Context, userID int, namespace, alias, newURL string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	url := db.unsafeFindURL(userID, namespace, alias)
+	if url == nil {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	key := urlKey(namespace, alias)
+	db.urlHistory[key] = append(db.urlHistory[key], &shortURLEntity.HistoryEntry{OriginalURL: url.SourceURL, ChangedAt: time.Now()})
+	url.SourceURL = newURL
+	url.NormalizedURL = ""
+
+	return nil
+}
+
+// FindURLHistory retrieves previous original URLs of a short URL.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// Returns:
+// - []*shortURLEntity.HistoryEntry: Previous original URLs, the most recent first
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) FindURLHistory(ctx context.Context, namespace, alias string) ([]*shortURLEntity.HistoryEntry, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	history := slices.Clone(db.urlHistory[urlKey(namespace, alias)])
+	slices.Reverse(history)
+
+	return history, nil
+}
+
+// unsafeFindURL looks up a short URL by namespace and alias without locking.
+// Caller must hold the read or write lock.
+// Parameters:
+// - userID: Owner's user ID, or 0 for any owner
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// Returns:
+// - *shortURLEntity.ShortURL: Found short URL or nil
+func (db *MemoryDB) unsafeFindURL(userID int, namespace, alias string) *shortURLEntity.ShortURL {
+	if url, ok := db.shortURLs[urlKey(namespace, alias)]; ok && (userID == 0 || url.UserID == userID) {
+		return url
+	}
 	return nil
 }
 
-// findShortURLBySourceURL looks up a short URL by its original URL.
+// FindShortURLBySourceURL looks up a short URL of a namespace by deduplication key of its original URL.
+// Short URLs which are never deduplicated, see ShortURL.IsDeduplicated, are skipped.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the short URL, empty for the default one
+// - sourceURL: Deduplication key of original long URL, see ShortURL.DeduplicationKey
+// Returns:
+// - *shortURLEntity.ShortURL: Found short URL
+// - error: dbErrors.ErrDBRecordNotFound if URL doesn't exist
+func (db *MemoryDB) FindShortURLBySourceURL(ctx context.Context, namespace, sourceURL string) (*shortURLEntity.ShortURL, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return db.unsafeFindBySourceURL(namespace, sourceURL)
+}
+
+// unsafeFindBySourceURL looks up a short URL of a namespace by deduplication key without locking.
+// Caller must hold the read or write lock.
 // Parameters:
-// - ctx: Context for cancellation/timeouts (unused)
-// - sourceURL: Original long URL
+// - namespace: Namespace of the short URL, empty for the default one
+// - sourceURL: Deduplication key of original long URL
 // Returns:
 // - *shortURLEntity.ShortURL: Found short URL
 // - error: dbErrors.ErrDBRecordNotFound if URL doesn't exist
-func (db *MemoryDB) findShortURLBySourceURL(_ context.Context, sourceURL string) (*shortURLEntity.ShortURL, error) {
+func (db *MemoryDB) unsafeFindBySourceURL(namespace, sourceURL string) (*shortURLEntity.ShortURL, error) {
 	var (
 		shortURL  *shortURLEntity.ShortURL
 		noRecords = true
 	)
 
 	for _, url := range db.shortURLs {
-		if url.SourceURL == sourceURL {
+		if url.IsDeduplicated() && url.DeduplicationKey() == sourceURL && url.Namespace == namespaceEntity.OrDefault(namespace) {
 			shortURL = url
 			noRecords = false
 			break
@@ -138,27 +579,872 @@ func (db *MemoryDB) findShortURLBySourceURL(_ context.Context, sourceURL string)
 
 // SaveShortURL stores a new short URL in memory.
 // Parameters:
-// - ctx: Context for cancellation/timeouts (unused)
+// - ctx: Context for cancellation/timeouts
 // - shortURL: URL entity to save
 // Returns:
 // - *shortURLEntity.ShortURL: Saved URL entity
-// - error: dbErrors.ErrDBIsNotUnique if URL already exists
+// - error: dbErrors.ErrDBIsNotUnique if URL already exists in the namespace,
+// dbErrors.ErrDBAliasTaken if the alias is used by another URL in the namespace,
+// dbErrors.ErrDBReferenceNotFound if the namespace doesn't exist
+//
+// One-time and private URLs are never deduplicated, each of them gets its own alias.
 func (db *MemoryDB) SaveShortURL(ctx context.Context, shortURL *shortURLEntity.ShortURL) (*shortURLEntity.ShortURL, error) {
-	existRecord, _ := db.findShortURLBySourceURL(ctx, shortURL.SourceURL)
-	if existRecord != nil {
-		return existRecord, dbErrors.ErrDBIsNotUnique
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	shortURL.Namespace = namespaceEntity.OrDefault(shortURL.Namespace)
+	if _, ok := db.namespaces[shortURL.Namespace]; !ok {
+		return nil, dbErrors.ErrDBReferenceNotFound
+	}
+
+	if shortURL.IsDeduplicated() {
+		existRecord, _ := db.unsafeFindBySourceURL(shortURL.Namespace, shortURL.DeduplicationKey())
+		if existRecord != nil {
+			return existRecord, dbErrors.ErrDBIsNotUnique
+		}
+	}
+
+	key := urlKey(shortURL.Namespace, shortURL.Alias)
+	if _, ok := db.shortURLs[key]; ok {
+		return nil, dbErrors.ErrDBAliasTaken
 	}
 
-	db.shortURLs[shortURL.Alias] = shortURL
+	if shortURL.CreatedAt.IsZero() {
+		shortURL.CreatedAt = time.Now()
+	}
+
+	db.lastURLID++
+	shortURL.ID = db.lastURLID
+
+	db.shortURLs[key] = shortURL
 	return shortURL, nil
 }
 
-// Ping checks if the database is available (always succeeds for in-memory).
+// DeleteExpiredURLs removes short URLs whose expiration time has passed
+// together with their tag assignments.
 // Parameters:
-// - ctx: Context for cancellation/timeouts (unused)
+// - ctx: Context for cancellation/timeouts
 // Returns:
-// - error: Always nil
-func (db *MemoryDB) Ping(_ context.Context) error {
+// - int64: Number of removed short URLs
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) DeleteExpiredURLs(ctx context.Context) (int64, error) {
+	if err := checkContext(ctx); err != nil {
+		return 0, err
+	}
+
+	var n int64
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	now := time.Now()
+	for key, url := range db.shortURLs {
+		if url.IsExpired(now) {
+			delete(db.shortURLs, key)
+			delete(db.urlTags, key)
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+// FindURLsToCheck retrieves not deleted and not expired short URLs whose source URLs
+// were not checked since the given time.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - checkedBefore: URLs checked at or after this time are skipped
+// - afterID: ID of the last URL of the previous batch, 0 for the first batch
+// - limit: Maximum number of URLs to return
+// Returns:
+// - []*shortURLEntity.ShortURL: URLs with ID greater than afterID ordered by ID
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) FindURLsToCheck(ctx context.Context, checkedBefore time.Time, afterID, limit int) ([]*shortURLEntity.ShortURL, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	var urls []*shortURLEntity.ShortURL
+
+	now := time.Now()
+	db.mu.RLock()
+	for _, url := range db.shortURLs {
+		if url.ID > afterID && !url.IsDeleted && !url.IsExpired(now) && url.CheckedAt.Before(checkedBefore) {
+			urls = append(urls, url)
+		}
+	}
+	db.mu.RUnlock()
+
+	sort.Slice(urls, func(i, j int) bool { return urls[i].ID < urls[j].ID })
+
+	if len(urls) > limit {
+		urls = urls[:limit]
+	}
+
+	return urls, nil
+}
+
+// SaveURLHealth stores result of health check of a short URL's source URL.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// - healthy: Whether the source URL was reachable
+// - checkedAt: Time of the check
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if short URL doesn't exist
+func (db *MemoryDB) SaveURLHealth(ctx context.Context, namespace, alias string, healthy bool, checkedAt time.Time) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	url, ok := db.shortURLs[urlKey(namespace, alias)]
+	if !ok {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	url.IsHealthy, url.CheckedAt = healthy, checkedAt
+	return nil
+}
+
+// CountURLs returns the number of all short URLs, including deleted ones.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - int64: Number of short URLs
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) CountURLs(ctx context.Context) (int64, error) {
+	if err := checkContext(ctx); err != nil {
+		return 0, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return int64(len(db.shortURLs)), nil
+}
+
+// CountDeletedURLs returns the number of short URLs marked as deleted.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - int64: Number of deleted short URLs
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) CountDeletedURLs(ctx context.Context) (int64, error) {
+	if err := checkContext(ctx); err != nil {
+		return 0, err
+	}
+
+	var n int64
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	for _, url := range db.shortURLs {
+		if url.IsDeleted {
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+// CountUsers returns the number of registered users.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - int64: Number of users
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) CountUsers(ctx context.Context) (int64, error) {
+	if err := checkContext(ctx); err != nil {
+		return 0, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return int64(len(db.users)), nil
+}
+
+// SaveTag stores a new tag of a user in memory.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - tag: Tag to save
+// Returns:
+// - *tagEntity.Tag: Saved tag with auto-incremented ID
+// - error: dbErrors.ErrDBIsNotUnique if user already has a tag with the same name
+func (db *MemoryDB) SaveTag(ctx context.Context, tag *tagEntity.Tag) (*tagEntity.Tag, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.unsafeFindTag(tag.UserID, tag.Name) != nil {
+		return nil, dbErrors.ErrDBIsNotUnique
+	}
+
+	db.lastTagID++
+	tag.ID = db.lastTagID
+
+	db.tags[tag.ID] = tag
+	return tag, nil
+}
+
+// FindTagsByUser retrieves all tags of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// Returns:
+// - []*tagEntity.Tag: User's tags ordered by name
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) FindTagsByUser(ctx context.Context, userID int) ([]*tagEntity.Tag, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	var tags []*tagEntity.Tag
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	for _, tag := range db.tags {
+		if tag.UserID == userID {
+			tags = append(tags, tag)
+		}
+	}
+
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+
+	return tags, nil
+}
+
+// FindURLTags retrieves tags assigned to a short URL of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// Returns:
+// - []*tagEntity.Tag: Assigned tags ordered by name
+// - error: dbErrors.ErrDBRecordNotFound if user has no URL with such alias
+func (db *MemoryDB) FindURLTags(ctx context.Context, userID int, namespace, alias string) ([]*tagEntity.Tag, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	var tags []*tagEntity.Tag
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if db.unsafeFindURL(userID, namespace, alias) == nil {
+		return nil, dbErrors.ErrDBRecordNotFound
+	}
+
+	for id := range db.urlTags[urlKey(namespace, alias)] {
+		tags = append(tags, db.tags[id])
+	}
+
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+
+	return tags, nil
+}
+
+// AssignTag associates a tag of a user with a short URL of the same user.
+// Assigning already assigned tag has no effect.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// - name: Tag name
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if user has no such URL or tag
+func (db *MemoryDB) AssignTag(ctx context.Context, userID int, namespace, alias, name string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.unsafeFindURL(userID, namespace, alias) == nil {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	tag := db.unsafeFindTag(userID, name)
+	if tag == nil {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	key := urlKey(namespace, alias)
+	if db.urlTags[key] == nil {
+		db.urlTags[key] = make(map[int]struct{})
+	}
+	db.urlTags[key][tag.ID] = struct{}{}
+
+	return nil
+}
+
+// RemoveTag removes association of a tag of a user with a short URL.
+// Removing not assigned tag has no effect.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// - name: Tag name
+// Returns:
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) RemoveTag(ctx context.Context, userID int, namespace, alias, name string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if tag := db.unsafeFindTag(userID, name); tag != nil {
+		delete(db.urlTags[urlKey(namespace, alias)], tag.ID)
+	}
+
+	return nil
+}
+
+// FindURLsByTag retrieves short URLs of a user having the tag assigned.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - name: Tag name
+// Returns:
+// - []*shortURLEntity.ShortURL: Tagged URLs ordered by ID, empty if tag doesn't exist
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) FindURLsByTag(ctx context.Context, userID int, name string) ([]*shortURLEntity.ShortURL, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	var urls []*shortURLEntity.ShortURL
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	tag := db.unsafeFindTag(userID, name)
+	if tag == nil {
+		return nil, nil
+	}
+
+	for key, tagIDs := range db.urlTags {
+		if _, ok := tagIDs[tag.ID]; ok {
+			if url, ok := db.shortURLs[key]; ok && url.UserID == userID {
+				urls = append(urls, url)
+			}
+		}
+	}
+
+	sort.Slice(urls, func(i, j int) bool { return urls[i].ID < urls[j].ID })
+
+	return urls, nil
+}
+
+// unsafeFindTag looks up a tag of a user by name without locking.
+// Caller must hold the read or write lock.
+// Parameters:
+// - userID: Owner's user ID
+// - name: Tag name
+// Returns:
+// - *tagEntity.Tag: Found tag or nil
+func (db *MemoryDB) unsafeFindTag(userID int, name string) *tagEntity.Tag {
+	for _, tag := range db.tags {
+		if tag.UserID == userID && tag.Name == name {
+			return tag
+		}
+	}
+	return nil
+}
+
+// SaveWebhook stores a new webhook of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - webhook: Webhook to save
+// Returns:
+// - *webhookEntity.Webhook: Saved webhook with auto-incremented ID
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) SaveWebhook(ctx context.Context, webhook *webhookEntity.Webhook) (*webhookEntity.Webhook, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.lastWebhookID++
+	webhook.ID = db.lastWebhookID
+
+	db.webhooks[webhook.ID] = webhook
+	return webhook, nil
+}
+
+// FindWebhooksByUser retrieves all webhooks of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// Returns:
+// - []*webhookEntity.Webhook: User's webhooks ordered by ID
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) FindWebhooksByUser(ctx context.Context, userID int) ([]*webhookEntity.Webhook, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	var webhooks []*webhookEntity.Webhook
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	for _, webhook := range db.webhooks {
+		if webhook.UserID == userID {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].ID < webhooks[j].ID })
+
+	return webhooks, nil
+}
+
+// DeleteWebhook removes a webhook of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - id: Webhook ID
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if user has no webhook with such ID
+func (db *MemoryDB) DeleteWebhook(ctx context.Context, userID, id int) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if webhook, ok := db.webhooks[id]; !ok || webhook.UserID != userID {
+		return dbErrors.ErrDBRecordNotFound
+	}
+	delete(db.webhooks, id)
+
+	return nil
+}
+
+// SaveAPIKey stores hash of a new API key of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - keyHash: Hash of the key
+// Returns:
+// - error: dbErrors.ErrDBIsNotUnique if the key hash is already stored
+func (db *MemoryDB) SaveAPIKey(ctx context.Context, userID int, keyHash string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, ok := db.apiKeys[keyHash]; ok {
+		return dbErrors.ErrDBIsNotUnique
+	}
+	db.apiKeys[keyHash] = &apiKey{userID: userID}
+
+	return nil
+}
+
+// FindUserByAPIKey retrieves the owner of a not revoked API key.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - keyHash: Hash of the key
+// Returns:
+// - *userEntity.User: Owner of the key
+// - error: dbErrors.ErrDBRecordNotFound if key is unknown or revoked
+func (db *MemoryDB) FindUserByAPIKey(ctx context.Context, keyHash string) (*userEntity.User, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	key, ok := db.apiKeys[keyHash]
+	if !ok || key.revoked {
+		return nil, dbErrors.ErrDBRecordNotFound
+	}
+
+	user := &userEntity.User{ID: key.userID, IsActive: db.isUserActive(key.userID)}
+	if owner, ok := db.users[key.userID]; ok {
+		user.CustomDomain = owner.CustomDomain
+	}
+
+	return user, nil
+}
+
+// isUserActive reports whether the user may authenticate.
+// Must be called with db.mu held.
+// Parameters:
+// - userID: User ID
+// Returns:
+// - bool: false only if the user is deactivated
+func (db *MemoryDB) isUserActive(userID int) bool {
+	user, ok := db.users[userID]
+	return !ok || user.IsActive
+}
+
+// RevokeAPIKey marks an API key of a user as revoked.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - keyHash: Hash of the key
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if user has no such not revoked key
+func (db *MemoryDB) RevokeAPIKey(ctx context.Context, userID int, keyHash string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	key, ok := db.apiKeys[keyHash]
+	if !ok || key.revoked || key.userID != userID {
+		return dbErrors.ErrDBRecordNotFound
+	}
+	key.revoked = true
+
+	return nil
+}
+
+// SaveClick stores a served redirect of a short URL.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - click: Click to save
+// Returns:
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) SaveClick(ctx context.Context, click *analyticsEntity.Click) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	key := urlKey(click.Namespace, click.Alias)
+	db.clicks[key] = append(db.clicks[key], click)
+
+	return nil
+}
+
+// FindUserClicks retrieves clicks of all short URLs of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// Returns:
+// - []*analyticsEntity.Click: Clicks ordered by click time
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) FindUserClicks(ctx context.Context, userID int) ([]*analyticsEntity.Click, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	var clicks []*analyticsEntity.Click
+
+	db.mu.RLock()
+	for key, url := range db.shortURLs {
+		if url.UserID == userID {
+			clicks = append(clicks, db.clicks[key]...)
+		}
+	}
+	db.mu.RUnlock()
+
+	sort.SliceStable(clicks, func(i, j int) bool { return clicks[i].ClickedAt.Before(clicks[j].ClickedAt) })
+
+	return clicks, nil
+}
+
+// CountClicks counts clicks of a short URL in [from, to) grouped by periods of the granularity in UTC.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL alias
+// - from: Start of the time range, inclusive
+// - to: End of the time range, exclusive
+// - granularity: Period length, one of hour, day, week or month
+// Returns:
+// - []analyticsEntity.ClickCount: Non-zero counts ordered by period start
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) CountClicks(ctx context.Context, namespace, alias string, from, to time.Time, granularity string) ([]analyticsEntity.ClickCount, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	var counts []analyticsEntity.ClickCount
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	byPeriod := make(map[time.Time]int64)
+	for _, click := range db.clicks[urlKey(namespace, alias)] {
+		if click.ClickedAt.Before(from) || !click.ClickedAt.Before(to) {
+			continue
+		}
+		byPeriod[analyticsEntity.TruncateTime(click.ClickedAt, granularity)]++
+	}
+
+	for start, clicks := range byPeriod {
+		counts = append(counts, analyticsEntity.ClickCount{PeriodStart: start, Clicks: clicks})
+	}
+
+	sort.Slice(counts, func(i, j int) bool { return counts[i].PeriodStart.Before(counts[j].PeriodStart) })
+
+	return counts, nil
+}
+
+// CountClicksByLocation counts clicks of a short URL in [from, to) grouped by country and city.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL alias
+// - from: Start of the time range, inclusive
+// - to: End of the time range, exclusive
+// Returns:
+// - []analyticsEntity.LocationCount: Non-zero counts ordered by clicks descending
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) CountClicksByLocation(ctx context.Context, namespace, alias string, from, to time.Time) ([]analyticsEntity.LocationCount, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	var counts []analyticsEntity.LocationCount
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	byLocation := make(map[[2]string]int64)
+	for _, click := range db.clicks[urlKey(namespace, alias)] {
+		if click.ClickedAt.Before(from) || !click.ClickedAt.Before(to) {
+			continue
+		}
+		byLocation[[2]string{click.Location.Country, click.Location.City}]++
+	}
+
+	for location, clicks := range byLocation {
+		counts = append(counts, analyticsEntity.LocationCount{Country: location[0], City: location[1], Clicks: clicks})
+	}
+
+	analyticsEntity.SortLocationCounts(counts)
+
+	return counts, nil
+}
+
+// SaveSplit stores a new split short URL in memory.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - split: Split to save
+// Returns:
+// - error: dbErrors.ErrDBIsNotUnique if a short URL or a split with the alias already exists in the namespace,
+// dbErrors.ErrDBReferenceNotFound if the namespace doesn't exist
+func (db *MemoryDB) SaveSplit(ctx context.Context, split *splitEntity.MultiDestination) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	split.Namespace = namespaceEntity.OrDefault(split.Namespace)
+	if _, ok := db.namespaces[split.Namespace]; !ok {
+		return dbErrors.ErrDBReferenceNotFound
+	}
+
+	key := urlKey(split.Namespace, split.Alias)
+	if _, ok := db.shortURLs[key]; ok {
+		return dbErrors.ErrDBIsNotUnique
+	}
+	if _, ok := db.splits[key]; ok {
+		return dbErrors.ErrDBIsNotUnique
+	}
+
+	db.splits[key] = copySplit(split)
+
+	return nil
+}
+
+// FindSplit retrieves a split short URL by its namespace and alias.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the split, empty for the default one
+// - alias: Split alias
+// Returns:
+// - *splitEntity.MultiDestination: Copy of the split with destination click counts
+// - error: dbErrors.ErrDBRecordNotFound if split doesn't exist
+func (db *MemoryDB) FindSplit(ctx context.Context, namespace, alias string) (*splitEntity.MultiDestination, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	split, ok := db.splits[urlKey(namespace, alias)]
+	if !ok {
+		return nil, dbErrors.ErrDBRecordNotFound
+	}
+
+	return copySplit(split), nil
+}
+
+// SaveSplitClick increments click count of a destination of a split short URL.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the split, empty for the default one
+// - alias: Split alias
+// - url: Original URL of the destination
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if split has no such destination
+func (db *MemoryDB) SaveSplitClick(ctx context.Context, namespace, alias, url string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if split, ok := db.splits[urlKey(namespace, alias)]; ok {
+		for i := range split.Destinations {
+			if split.Destinations[i].URL == url {
+				split.Destinations[i].Clicks++
+				return nil
+			}
+		}
+	}
+
+	return dbErrors.ErrDBRecordNotFound
+}
+
+// copySplit copies a split, so stored destinations are not shared with callers.
+// Parameters:
+// - split: Split to copy
+// Returns:
+// - *splitEntity.MultiDestination: Copy of the split
+func copySplit(split *splitEntity.MultiDestination) *splitEntity.MultiDestination {
+	res := *split
+	res.Destinations = slices.Clone(split.Destinations)
+	return &res
+}
+
+// SaveNamespace stores a new namespace in memory.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace to save
+// Returns:
+// - *namespaceEntity.Namespace: Saved namespace with creation time
+// - error: dbErrors.ErrDBIsNotUnique if namespace with the name already exists
+func (db *MemoryDB) SaveNamespace(ctx context.Context, namespace *namespaceEntity.Namespace) (*namespaceEntity.Namespace, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, ok := db.namespaces[namespace.Name]; ok {
+		return nil, dbErrors.ErrDBIsNotUnique
+	}
+
+	saved := &namespaceEntity.Namespace{Name: namespace.Name, CreatedAt: time.Now()}
+	db.namespaces[saved.Name] = saved
+
+	res := *saved
+	return &res, nil
+}
+
+// FindNamespaces retrieves all namespaces.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - []*namespaceEntity.Namespace: Copies of namespaces ordered by name
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) FindNamespaces(ctx context.Context) ([]*namespaceEntity.Namespace, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	namespaces := make([]*namespaceEntity.Namespace, 0, len(db.namespaces))
+	for _, namespace := range db.namespaces {
+		res := *namespace
+		namespaces = append(namespaces, &res)
+	}
+
+	sort.Slice(namespaces, func(i, j int) bool { return namespaces[i].Name < namespaces[j].Name })
+
+	return namespaces, nil
+}
+
+// DeleteNamespace removes a namespace without short URLs.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - name: Namespace name
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if namespace doesn't exist,
+// dbErrors.ErrDBRecordIsReferenced if namespace has short URLs or splits
+func (db *MemoryDB) DeleteNamespace(ctx context.Context, name string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, ok := db.namespaces[name]; !ok {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	for _, url := range db.shortURLs {
+		if url.Namespace == name {
+			return dbErrors.ErrDBRecordIsReferenced
+		}
+	}
+	for _, split := range db.splits {
+		if split.Namespace == name {
+			return dbErrors.ErrDBRecordIsReferenced
+		}
+	}
+
+	delete(db.namespaces, name)
+
+	return nil
+}
+
+// Ping checks if the database is available (always succeeds for in-memory).
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - error: dbErrors.ErrDBContextDone if ctx is done
+func (db *MemoryDB) Ping(ctx context.Context) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+
 	return nil
 }
 
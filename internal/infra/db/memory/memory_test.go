@@ -0,0 +1,746 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	analyticsEntity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	namespaceEntity "github.com/gururuby/shortener/internal/domain/entity/namespace"
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	splitEntity "github.com/gururuby/shortener/internal/domain/entity/split"
+	tagEntity "github.com/gururuby/shortener/internal/domain/entity/tag"
+	webhookEntity "github.com/gururuby/shortener/internal/domain/entity/webhook"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryDB_DataRace(t *testing.T) {
+	const goroutines = 500
+
+	db := New()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			alias := fmt.Sprintf("alias%d", i)
+			sourceURL := fmt.Sprintf("https://example.com/%d", i)
+
+			_, err := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: alias, SourceURL: sourceURL, UserID: i%10 + 1})
+			assert.NoError(t, err)
+
+			shortURL, err := db.FindShortURL(ctx, "", alias)
+			if assert.NoError(t, err) {
+				assert.Equal(t, sourceURL, shortURL.SourceURL)
+			}
+
+			_, err = db.FindShortURLBySourceURL(ctx, "", sourceURL)
+			assert.NoError(t, err)
+
+			_, err = db.SaveUser(ctx)
+			assert.NoError(t, err)
+
+			_, err = db.FindUserURLs(ctx, i%10+1)
+			assert.NoError(t, err)
+		}(i)
+	}
+
+	wg.Wait()
+
+	urls, _, err := db.FindUserURLsPaginated(ctx, 1, 0, goroutines)
+	require.NoError(t, err)
+	assert.Len(t, urls, goroutines/10)
+	assert.Len(t, db.users, goroutines)
+}
+
+func TestMemoryDB_Counts(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+
+	user, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+
+	for i := 1; i <= 3; i++ {
+		_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{
+			SourceURL: fmt.Sprintf("https://example.com/%d", i),
+			Alias:     fmt.Sprintf("alias%d", i),
+			UserID:    user.ID,
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.MarkURLAsDeleted(ctx, user.ID, "", []string{"alias1"}))
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{SourceURL: "https://example.com/anonymous", Alias: "anonymous"})
+	require.NoError(t, err)
+
+	urls, err := db.CountURLs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), urls)
+
+	userURLs, err := db.CountUserURLs(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), userURLs, "deleted URLs must not be counted")
+
+	anonymousURLs, err := db.CountUserURLs(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), anonymousURLs)
+
+	deleted, err := db.CountDeletedURLs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	users, err := db.CountUsers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), users)
+}
+
+func TestMemoryDB_PrivateURLsAreNotDeduplicated(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+
+	private := &shortURLEntity.ShortURL{Alias: "private", SourceURL: "https://ya.ru", UserID: 1, Visibility: shortURLEntity.VisibilityPrivate}
+	_, err := db.SaveShortURL(ctx, private)
+	require.NoError(t, err)
+
+	public := &shortURLEntity.ShortURL{Alias: "public", SourceURL: "https://ya.ru"}
+	saved, err := db.SaveShortURL(ctx, public)
+	require.NoError(t, err, "public URL must not reuse alias of private one")
+	assert.Equal(t, "public", saved.Alias)
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "private2", SourceURL: "https://ya.ru", UserID: 1, Visibility: shortURLEntity.VisibilityPrivate})
+	require.NoError(t, err, "private URL must not reuse alias of public one")
+
+	found, err := db.FindShortURL(ctx, "", "private")
+	require.NoError(t, err)
+	assert.True(t, found.IsPrivate())
+}
+
+func TestMemoryDB_SaveShortURL_AliasTaken(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+
+	_, err := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "abc", SourceURL: "https://ya.ru"})
+	require.NoError(t, err)
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "abc", SourceURL: "https://example.com"})
+	require.ErrorIs(t, err, dbErrors.ErrDBAliasTaken)
+
+	found, err := db.FindShortURL(ctx, "", "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "https://ya.ru", found.SourceURL, "taken alias must not be overwritten")
+}
+
+func TestMemoryDB_PermanentURLsAreNotDeduplicated(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+
+	_, err := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "temporary", SourceURL: "https://ya.ru"})
+	require.NoError(t, err)
+
+	saved, err := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "permanent", SourceURL: "https://ya.ru", RedirectType: shortURLEntity.RedirectPermanent})
+	require.NoError(t, err, "permanent URL must not reuse alias of temporary one")
+	assert.Equal(t, "permanent", saved.Alias)
+
+	found, err := db.FindShortURL(ctx, "", "permanent")
+	require.NoError(t, err)
+	assert.Equal(t, shortURLEntity.RedirectPermanent, found.RedirectStatus())
+}
+
+func TestMemoryDB_RestoreURL(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+
+	_, err := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "abc", SourceURL: "https://ya.ru", UserID: 1})
+	require.NoError(t, err)
+	require.NoError(t, db.MarkURLAsDeleted(ctx, 1, "", []string{"abc"}))
+
+	require.ErrorIs(t, db.RestoreURL(ctx, 2, "", "abc"), dbErrors.ErrDBRecordNotFound)
+	require.ErrorIs(t, db.RestoreURL(ctx, 1, "", "unknown"), dbErrors.ErrDBRecordNotFound)
+
+	require.NoError(t, db.RestoreURL(ctx, 1, "", "abc"))
+
+	found, err := db.FindShortURL(ctx, "", "abc")
+	require.NoError(t, err)
+	assert.False(t, found.IsDeleted)
+}
+
+func TestMemoryDB_UpdateURLTarget(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+
+	_, err := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "abc", SourceURL: "https://ya.ru", NormalizedURL: "https://ya.ru", UserID: 1})
+	require.NoError(t, err)
+
+	require.ErrorIs(t, db.UpdateURLTarget(ctx, 2, "", "abc", "https://go.dev"), dbErrors.ErrDBRecordNotFound)
+	require.ErrorIs(t, db.UpdateURLTarget(ctx, 1, "", "unknown", "https://go.dev"), dbErrors.ErrDBRecordNotFound)
+
+	require.NoError(t, db.UpdateURLTarget(ctx, 1, "", "abc", "https://go.dev"))
+	require.NoError(t, db.UpdateURLTarget(ctx, 1, "", "abc", "https://ok.ru"))
+
+	found, err := db.FindShortURL(ctx, "", "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "https://ok.ru", found.SourceURL)
+
+	_, err = db.FindShortURLBySourceURL(ctx, "", "https://ya.ru")
+	require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound, "URL must not be reused for the previous target")
+
+	history, err := db.FindURLHistory(ctx, "", "abc")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "https://go.dev", history[0].OriginalURL)
+	assert.Equal(t, "https://ya.ru", history[1].OriginalURL)
+	assert.False(t, history[0].ChangedAt.Before(history[1].ChangedAt))
+}
+
+func TestMemoryDB_DeleteExpiredURLs(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+
+	_, err := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "expired", SourceURL: "https://ya.ru", UserID: 1, ExpiresAt: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "active", SourceURL: "https://go.dev", ExpiresAt: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	_, err = db.SaveTag(ctx, &tagEntity.Tag{UserID: 1, Name: "news"})
+	require.NoError(t, err)
+	require.NoError(t, db.AssignTag(ctx, 1, "", "expired", "news"))
+
+	n, err := db.DeleteExpiredURLs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	_, err = db.FindShortURL(ctx, "", "expired")
+	require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+	_, err = db.FindShortURL(ctx, "", "active")
+	require.NoError(t, err)
+
+	urls, err := db.FindURLsByTag(ctx, 1, "news")
+	require.NoError(t, err)
+	assert.Empty(t, urls)
+}
+
+func TestMemoryDB_URLHealth(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, url := range []*shortURLEntity.ShortURL{
+		{Alias: "unchecked", SourceURL: "https://ya.ru", UserID: 1},
+		{Alias: "expired", SourceURL: "https://go.dev", ExpiresAt: now.Add(-time.Hour)},
+		{Alias: "deleted", SourceURL: "https://ok.ru", UserID: 1},
+		{Alias: "recent", SourceURL: "https://vk.com"},
+		{Alias: "stale", SourceURL: "https://mail.ru"},
+	} {
+		_, err := db.SaveShortURL(ctx, url)
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.MarkURLAsDeleted(ctx, 1, "", []string{"deleted"}))
+	require.NoError(t, db.SaveURLHealth(ctx, "", "recent", true, now))
+	require.NoError(t, db.SaveURLHealth(ctx, "", "stale", false, now.Add(-48*time.Hour)))
+
+	urls, err := db.FindURLsToCheck(ctx, now.Add(-24*time.Hour), 0, 10)
+	require.NoError(t, err)
+	require.Len(t, urls, 2)
+	assert.Equal(t, "unchecked", urls[0].Alias)
+	assert.Equal(t, "stale", urls[1].Alias)
+
+	urls, err = db.FindURLsToCheck(ctx, now.Add(-24*time.Hour), urls[0].ID, 1)
+	require.NoError(t, err)
+	require.Len(t, urls, 1)
+	assert.Equal(t, "stale", urls[0].Alias)
+
+	require.NoError(t, db.SaveURLHealth(ctx, "", "unchecked", false, now))
+	url, err := db.FindShortURL(ctx, "", "unchecked")
+	require.NoError(t, err)
+	assert.False(t, url.IsSourceHealthy())
+	assert.Equal(t, now, url.CheckedAt)
+
+	require.ErrorIs(t, db.SaveURLHealth(ctx, "", "unknown", true, now), dbErrors.ErrDBRecordNotFound)
+}
+
+func TestMemoryDB_Tags(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+
+	_, err := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "abc", SourceURL: "https://ya.ru", UserID: 1})
+	require.NoError(t, err)
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "def", SourceURL: "https://ok.ru", UserID: 2})
+	require.NoError(t, err)
+
+	work, err := db.SaveTag(ctx, &tagEntity.Tag{UserID: 1, Name: "work"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, work.ID)
+
+	_, err = db.SaveTag(ctx, &tagEntity.Tag{UserID: 1, Name: "work"})
+	require.ErrorIs(t, err, dbErrors.ErrDBIsNotUnique)
+
+	_, err = db.SaveTag(ctx, &tagEntity.Tag{UserID: 2, Name: "work"})
+	require.NoError(t, err, "tag names are unique per user")
+
+	require.NoError(t, db.AssignTag(ctx, 1, "", "abc", "work"))
+	require.NoError(t, db.AssignTag(ctx, 1, "", "abc", "work"), "assignment must be idempotent")
+	require.ErrorIs(t, db.AssignTag(ctx, 1, "", "def", "work"), dbErrors.ErrDBRecordNotFound, "URL of another user")
+	require.ErrorIs(t, db.AssignTag(ctx, 1, "", "abc", "home"), dbErrors.ErrDBRecordNotFound, "unknown tag")
+
+	tags, err := db.FindURLTags(ctx, 1, "", "abc")
+	require.NoError(t, err)
+	assert.Equal(t, []*tagEntity.Tag{work}, tags)
+
+	_, err = db.FindURLTags(ctx, 1, "", "def")
+	require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+
+	urls, err := db.FindURLsByTag(ctx, 1, "work")
+	require.NoError(t, err)
+	require.Len(t, urls, 1)
+	assert.Equal(t, "abc", urls[0].Alias)
+
+	urls, err = db.FindURLsByTag(ctx, 2, "work")
+	require.NoError(t, err)
+	assert.Empty(t, urls)
+
+	require.NoError(t, db.RemoveTag(ctx, 1, "", "abc", "work"))
+
+	tags, err = db.FindURLTags(ctx, 1, "", "abc")
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+}
+
+func TestMemoryDB_Webhooks(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+
+	created, err := db.SaveWebhook(ctx, &webhookEntity.Webhook{UserID: 1, URL: "https://example.com/a", Events: []string{webhookEntity.EventURLCreated}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, created.ID)
+
+	deleted, err := db.SaveWebhook(ctx, &webhookEntity.Webhook{UserID: 1, URL: "https://example.com/b", Events: []string{webhookEntity.EventURLDeleted}})
+	require.NoError(t, err)
+
+	_, err = db.SaveWebhook(ctx, &webhookEntity.Webhook{UserID: 2, URL: "https://example.com/c", Events: []string{webhookEntity.EventURLClicked}})
+	require.NoError(t, err)
+
+	webhooks, err := db.FindWebhooksByUser(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []*webhookEntity.Webhook{created, deleted}, webhooks)
+
+	require.ErrorIs(t, db.DeleteWebhook(ctx, 2, created.ID), dbErrors.ErrDBRecordNotFound, "webhook of another user")
+	require.NoError(t, db.DeleteWebhook(ctx, 1, created.ID))
+	require.ErrorIs(t, db.DeleteWebhook(ctx, 1, created.ID), dbErrors.ErrDBRecordNotFound)
+
+	webhooks, err = db.FindWebhooksByUser(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []*webhookEntity.Webhook{deleted}, webhooks)
+}
+
+func TestMemoryDB_APIKeys(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+
+	require.NoError(t, db.SaveAPIKey(ctx, 1, "hash"))
+	require.ErrorIs(t, db.SaveAPIKey(ctx, 2, "hash"), dbErrors.ErrDBIsNotUnique)
+
+	user, err := db.FindUserByAPIKey(ctx, "hash")
+	require.NoError(t, err)
+	assert.Equal(t, 1, user.ID)
+
+	_, err = db.FindUserByAPIKey(ctx, "unknown")
+	require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+
+	require.ErrorIs(t, db.RevokeAPIKey(ctx, 2, "hash"), dbErrors.ErrDBRecordNotFound, "key of another user")
+	require.NoError(t, db.RevokeAPIKey(ctx, 1, "hash"))
+	require.ErrorIs(t, db.RevokeAPIKey(ctx, 1, "hash"), dbErrors.ErrDBRecordNotFound)
+
+	_, err = db.FindUserByAPIKey(ctx, "hash")
+	require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+}
+
+func TestMemoryDB_SetUserActive(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+
+	saved, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+	assert.True(t, saved.IsActive)
+	require.NoError(t, db.SaveAPIKey(ctx, saved.ID, "hash"))
+
+	require.NoError(t, db.SetUserActive(ctx, saved.ID, false))
+
+	user, err := db.FindUser(ctx, saved.ID)
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+
+	user, err = db.FindUserByAPIKey(ctx, "hash")
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+
+	require.NoError(t, db.SetUserActive(ctx, saved.ID, true))
+
+	user, err = db.FindUser(ctx, saved.ID)
+	require.NoError(t, err)
+	assert.True(t, user.IsActive)
+
+	require.ErrorIs(t, db.SetUserActive(ctx, saved.ID+1, false), dbErrors.ErrDBRecordNotFound)
+}
+
+func TestMemoryDB_SetUserCustomDomain(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+
+	first, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+	second, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveAPIKey(ctx, first.ID, "hash"))
+
+	require.NoError(t, db.SetUserCustomDomain(ctx, first.ID, "https://short.acme.com"))
+
+	user, err := db.FindUser(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://short.acme.com", user.CustomDomain)
+
+	user, err = db.FindUserByAPIKey(ctx, "hash")
+	require.NoError(t, err)
+	assert.Equal(t, "https://short.acme.com", user.CustomDomain)
+
+	// Domain can be claimed by a single user only
+	require.ErrorIs(t, db.SetUserCustomDomain(ctx, second.ID, "https://short.acme.com"), dbErrors.ErrDBIsNotUnique)
+	require.NoError(t, db.SetUserCustomDomain(ctx, first.ID, "https://short.acme.com"))
+
+	// Removed domains are free, users without domain do not conflict
+	require.NoError(t, db.SetUserCustomDomain(ctx, first.ID, ""))
+	require.NoError(t, db.SetUserCustomDomain(ctx, second.ID, ""))
+	require.NoError(t, db.SetUserCustomDomain(ctx, second.ID, "https://short.acme.com"))
+
+	user, err = db.FindUser(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Empty(t, user.CustomDomain)
+
+	require.ErrorIs(t, db.SetUserCustomDomain(ctx, second.ID+1, "https://other.acme.com"), dbErrors.ErrDBRecordNotFound)
+}
+
+func TestMemoryDB_SearchUserURLs(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+
+	for alias, url := range map[string]string{
+		"alias1": "https://GitHub.com/gururuby",
+		"alias2": "https://github.com/golang/go",
+		"alias3": "https://ya.ru/100%_real",
+		"alias4": "https://ya.ru/100abreal",
+	} {
+		_, err := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: alias, SourceURL: url, UserID: 1})
+		require.NoError(t, err)
+	}
+
+	urls, total, err := db.SearchUserURLs(ctx, 1, "github.com", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, urls, 1)
+	assert.Equal(t, "alias2", urls[0].Alias)
+
+	urls, total, err = db.SearchUserURLs(ctx, 1, "100%_", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "alias3", urls[0].Alias)
+
+	_, total, err = db.SearchUserURLs(ctx, 2, "github.com", 0, 10)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
+
+func TestMemoryDB_Splits(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+
+	_, err := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "taken", SourceURL: "https://ya.ru"})
+	require.NoError(t, err)
+
+	split := &splitEntity.MultiDestination{
+		Alias:  "ab-test",
+		UserID: 1,
+		Destinations: []splitEntity.Destination{
+			{URL: "https://v1.com", Weight: 70},
+			{URL: "https://v2.com", Weight: 30},
+		},
+	}
+	require.NoError(t, db.SaveSplit(ctx, split))
+	require.ErrorIs(t, db.SaveSplit(ctx, split), dbErrors.ErrDBIsNotUnique)
+	require.ErrorIs(t, db.SaveSplit(ctx, &splitEntity.MultiDestination{Alias: "taken"}), dbErrors.ErrDBIsNotUnique, "alias of short URL")
+
+	require.NoError(t, db.SaveSplitClick(ctx, "", "ab-test", "https://v2.com"))
+	require.NoError(t, db.SaveSplitClick(ctx, "", "ab-test", "https://v2.com"))
+	require.ErrorIs(t, db.SaveSplitClick(ctx, "", "ab-test", "https://v3.com"), dbErrors.ErrDBRecordNotFound)
+	require.ErrorIs(t, db.SaveSplitClick(ctx, "", "unknown", "https://v1.com"), dbErrors.ErrDBRecordNotFound)
+
+	found, err := db.FindSplit(ctx, "", "ab-test")
+	require.NoError(t, err)
+	assert.Equal(t, 1, found.UserID)
+	assert.Equal(t, []splitEntity.Destination{
+		{URL: "https://v1.com", Weight: 70},
+		{URL: "https://v2.com", Weight: 30, Clicks: 2},
+	}, found.Destinations)
+	assert.Zero(t, split.Destinations[1].Clicks, "saved split is not shared")
+
+	_, err = db.FindSplit(ctx, "", "unknown")
+	require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+}
+
+func TestMemoryDB_Namespaces(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+
+	_, err := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "abc", SourceURL: "https://ya.ru", Namespace: "team-a"})
+	require.ErrorIs(t, err, dbErrors.ErrDBReferenceNotFound, "namespace is not created")
+
+	for _, name := range []string{"team-b", "team-a"} {
+		saved, saveErr := db.SaveNamespace(ctx, &namespaceEntity.Namespace{Name: name})
+		require.NoError(t, saveErr)
+		assert.False(t, saved.CreatedAt.IsZero())
+	}
+	_, err = db.SaveNamespace(ctx, &namespaceEntity.Namespace{Name: "team-a"})
+	require.ErrorIs(t, err, dbErrors.ErrDBIsNotUnique)
+
+	namespaces, err := db.FindNamespaces(ctx)
+	require.NoError(t, err)
+	require.Len(t, namespaces, 3)
+	assert.Equal(t, []string{"default", "team-a", "team-b"}, []string{namespaces[0].Name, namespaces[1].Name, namespaces[2].Name})
+
+	for _, url := range []*shortURLEntity.ShortURL{
+		{Alias: "abc", SourceURL: "https://default.com"},
+		{Alias: "abc", SourceURL: "https://a.com", Namespace: "team-a"},
+		{Alias: "abc", SourceURL: "https://b.com", Namespace: "team-b"},
+	} {
+		_, err = db.SaveShortURL(ctx, url)
+		require.NoError(t, err)
+	}
+
+	for namespace, want := range map[string]string{"": "https://default.com", "default": "https://default.com", "team-a": "https://a.com", "team-b": "https://b.com"} {
+		found, findErr := db.FindShortURL(ctx, namespace, "abc")
+		require.NoError(t, findErr)
+		assert.Equal(t, want, found.SourceURL, namespace)
+	}
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "def", SourceURL: "https://a.com", Namespace: "team-b"})
+	require.NoError(t, err, "URLs are deduplicated within namespace only")
+	existing, err := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "ghi", SourceURL: "https://a.com", Namespace: "team-a"})
+	require.ErrorIs(t, err, dbErrors.ErrDBIsNotUnique)
+	assert.Equal(t, "team-a", existing.Namespace)
+
+	require.ErrorIs(t, db.DeleteNamespace(ctx, "team-a"), dbErrors.ErrDBRecordIsReferenced)
+	require.ErrorIs(t, db.DeleteNamespace(ctx, "unknown"), dbErrors.ErrDBRecordNotFound)
+
+	_, err = db.SaveNamespace(ctx, &namespaceEntity.Namespace{Name: "empty"})
+	require.NoError(t, err)
+	require.NoError(t, db.DeleteNamespace(ctx, "empty"))
+}
+
+func TestMemoryDB_NamespaceIsolation(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+
+	_, err := db.SaveNamespace(ctx, &namespaceEntity.Namespace{Name: "team"})
+	require.NoError(t, err)
+	for _, url := range []*shortURLEntity.ShortURL{
+		{Alias: "abc", SourceURL: "https://default.com", UserID: 1},
+		{Alias: "abc", SourceURL: "https://team.com", Namespace: "team", UserID: 1},
+	} {
+		_, err = db.SaveShortURL(ctx, url)
+		require.NoError(t, err)
+	}
+	_, err = db.SaveTag(ctx, &tagEntity.Tag{UserID: 1, Name: "work"})
+	require.NoError(t, err)
+
+	require.NoError(t, db.MarkURLAsDeleted(ctx, 0, "team", []string{"abc"}))
+	found, err := db.FindShortURL(ctx, "", "abc")
+	require.NoError(t, err)
+	assert.False(t, found.IsDeleted, "URL of another namespace must not be deleted")
+	require.NoError(t, db.RestoreURL(ctx, 1, "team", "abc"))
+
+	require.NoError(t, db.UpdateURLTarget(ctx, 1, "team", "abc", "https://go.dev"))
+	found, err = db.FindShortURL(ctx, "", "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "https://default.com", found.SourceURL)
+	history, err := db.FindURLHistory(ctx, "", "abc")
+	require.NoError(t, err)
+	assert.Empty(t, history)
+	history, err = db.FindURLHistory(ctx, "team", "abc")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+
+	require.NoError(t, db.AssignTag(ctx, 1, "team", "abc", "work"))
+	tags, err := db.FindURLTags(ctx, 1, "", "abc")
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+	tagged, err := db.FindURLsByTag(ctx, 1, "work")
+	require.NoError(t, err)
+	require.Len(t, tagged, 1)
+	assert.Equal(t, "team", tagged[0].Namespace)
+
+	clickedAt := time.Now()
+	require.NoError(t, db.SaveClick(ctx, &analyticsEntity.Click{Namespace: "team", Alias: "abc", ClickedAt: clickedAt}))
+	counts, err := db.CountClicksByLocation(ctx, "", "abc", clickedAt.Add(-time.Hour), clickedAt.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+	counts, err = db.CountClicksByLocation(ctx, "team", "abc", clickedAt.Add(-time.Hour), clickedAt.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, counts, 1)
+
+	split := &splitEntity.MultiDestination{
+		Alias:        "ab-test",
+		Namespace:    "team",
+		UserID:       1,
+		Destinations: []splitEntity.Destination{{URL: "https://v1.com", Weight: 50}, {URL: "https://v2.com", Weight: 50}},
+	}
+	require.NoError(t, db.SaveSplit(ctx, split))
+	_, err = db.FindSplit(ctx, "", "ab-test")
+	require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+	require.ErrorIs(t, db.SaveSplitClick(ctx, "", "ab-test", "https://v1.com"), dbErrors.ErrDBRecordNotFound)
+	foundSplit, err := db.FindSplit(ctx, "team", "ab-test")
+	require.NoError(t, err)
+	assert.Equal(t, "team", foundSplit.Namespace)
+	require.ErrorIs(t, db.SaveSplit(ctx, &splitEntity.MultiDestination{Alias: "ab-test", Namespace: "unknown"}), dbErrors.ErrDBReferenceNotFound)
+	require.NoError(t, db.SaveSplit(ctx, &splitEntity.MultiDestination{Alias: "ab-test", UserID: 1}), "alias is free in default namespace")
+}
+
+func TestMemoryDB_CountClicks(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	clicks := []time.Time{
+		time.Date(2024, 3, 31, 0, 30, 0, 0, berlin), // 2024-03-30 23:30 UTC
+		time.Date(2024, 3, 31, 3, 30, 0, 0, berlin), // 2024-03-31 01:30 UTC, after DST transition
+		time.Date(2024, 4, 1, 0, 30, 0, 0, berlin),  // 2024-03-31 22:30 UTC
+		time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC),
+	}
+	for _, clickedAt := range clicks {
+		require.NoError(t, db.SaveClick(ctx, &analyticsEntity.Click{Alias: "abc", ClickedAt: clickedAt}))
+	}
+	require.NoError(t, db.SaveClick(ctx, &analyticsEntity.Click{Alias: "other", ClickedAt: clicks[0]}))
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		granularity string
+		want        []analyticsEntity.ClickCount
+	}{
+		{
+			granularity: analyticsEntity.GranularityHour,
+			want: []analyticsEntity.ClickCount{
+				{PeriodStart: time.Date(2024, 3, 30, 23, 0, 0, 0, time.UTC), Clicks: 1},
+				{PeriodStart: time.Date(2024, 3, 31, 1, 0, 0, 0, time.UTC), Clicks: 1},
+				{PeriodStart: time.Date(2024, 3, 31, 22, 0, 0, 0, time.UTC), Clicks: 1},
+			},
+		},
+		{
+			granularity: analyticsEntity.GranularityDay,
+			want: []analyticsEntity.ClickCount{
+				{PeriodStart: time.Date(2024, 3, 30, 0, 0, 0, 0, time.UTC), Clicks: 1},
+				{PeriodStart: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), Clicks: 2},
+			},
+		},
+		{
+			granularity: analyticsEntity.GranularityWeek,
+			want:        []analyticsEntity.ClickCount{{PeriodStart: time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC), Clicks: 3}},
+		},
+		{
+			granularity: analyticsEntity.GranularityMonth,
+			want:        []analyticsEntity.ClickCount{{PeriodStart: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Clicks: 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.granularity, func(t *testing.T) {
+			counts, countErr := db.CountClicks(ctx, "", "abc", from, to, tt.granularity)
+			require.NoError(t, countErr)
+			assert.Equal(t, tt.want, counts)
+		})
+	}
+}
+
+func TestMemoryDB_FindUserClicks(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+	clickedAt := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	user, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+	assert.False(t, user.CreatedAt.IsZero())
+
+	for alias, userID := range map[string]int{"mine1": user.ID, "mine2": user.ID, "other": user.ID + 1} {
+		_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: alias, SourceURL: "https://ya.ru/" + alias, UserID: userID})
+		require.NoError(t, err)
+	}
+	for _, click := range []*analyticsEntity.Click{
+		{Alias: "mine2", ClickedAt: clickedAt.Add(time.Hour), AnonymizedIP: "10.0.0.0"},
+		{Alias: "other", ClickedAt: clickedAt},
+		{Alias: "mine1", ClickedAt: clickedAt},
+	} {
+		require.NoError(t, db.SaveClick(ctx, click))
+	}
+
+	clicks, err := db.FindUserClicks(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, clicks, 2)
+	assert.Equal(t, "mine1", clicks[0].Alias)
+	assert.Equal(t, "mine2", clicks[1].Alias)
+	assert.Equal(t, "10.0.0.0", clicks[1].AnonymizedIP)
+
+	clicks, err = db.FindUserClicks(ctx, user.ID+2)
+	require.NoError(t, err)
+	assert.Empty(t, clicks)
+}
+
+func TestMemoryDB_CountClicksByLocation(t *testing.T) {
+	db := New()
+	ctx := context.Background()
+	clickedAt := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	london := analyticsEntity.GeoLocation{Country: "GB", City: "London", Lat: "51.5142", Lon: "-0.0931"}
+
+	for _, click := range []*analyticsEntity.Click{
+		{Alias: "abc", ClickedAt: clickedAt, Location: london},
+		{Alias: "abc", ClickedAt: clickedAt, Location: london},
+		{Alias: "abc", ClickedAt: clickedAt, Location: analyticsEntity.GeoLocation{Country: "US", City: "New York"}},
+		{Alias: "abc", ClickedAt: clickedAt},
+		{Alias: "abc", ClickedAt: clickedAt.AddDate(0, -1, 0), Location: london},
+		{Alias: "other", ClickedAt: clickedAt, Location: london},
+	} {
+		require.NoError(t, db.SaveClick(ctx, click))
+	}
+
+	counts, err := db.CountClicksByLocation(ctx, "", "abc", clickedAt.AddDate(0, 0, -1), clickedAt.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Equal(t, []analyticsEntity.LocationCount{
+		{Country: "GB", City: "London", Clicks: 2},
+		{Clicks: 1},
+		{Country: "US", City: "New York", Clicks: 1},
+	}, counts)
+}
+
+func TestMemoryDB_CancelledContext(t *testing.T) {
+	db := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "abc", SourceURL: "https://ya.ru"})
+	require.ErrorIs(t, err, dbErrors.ErrDBContextDone)
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	_, err = db.FindShortURL(context.Background(), "", "abc")
+	require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound, "URL must not be saved with cancelled context")
+
+	deadlineCtx, deadlineCancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	t.Cleanup(deadlineCancel)
+
+	_, err = db.FindShortURL(deadlineCtx, "", "abc")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -11,9 +11,16 @@ package db
 
 import (
 	"context"
+	"time"
 
+	analyticsEntity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	namespaceEntity "github.com/gururuby/shortener/internal/domain/entity/namespace"
 	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	splitEntity "github.com/gururuby/shortener/internal/domain/entity/split"
+	tagEntity "github.com/gururuby/shortener/internal/domain/entity/tag"
 	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	webhookEntity "github.com/gururuby/shortener/internal/domain/entity/webhook"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
 )
 
 // NullDB is a no-op database implementation that satisfies the database interface
@@ -49,6 +56,82 @@ func (db *NullDB) FindUserURLs(_ context.Context, _ int) ([]*shortURLEntity.Shor
 	return nil, nil
 }
 
+// FindUserURLsPaginated is a no-op implementation that always returns nil.
+// Parameters:
+// - ctx: Context (ignored)
+// - userID: User ID (ignored)
+// - offset: Number of URLs to skip (ignored)
+// - limit: Maximum number of URLs to return (ignored)
+// Returns:
+// - []*shortURLEntity.ShortURL: Always nil
+// - int64: Always 0
+// - error: Always nil
+func (db *NullDB) FindUserURLsPaginated(_ context.Context, _, _, _ int) ([]*shortURLEntity.ShortURL, int64, error) {
+	return nil, 0, nil
+}
+
+// SearchUserURLs is a no-op implementation that always returns nil.
+// Parameters:
+// - ctx: Context (ignored)
+// - userID: User ID (ignored)
+// - query: Substring of original URLs (ignored)
+// - offset: Number of URLs to skip (ignored)
+// - limit: Maximum number of URLs to return (ignored)
+// Returns:
+// - []*shortURLEntity.ShortURL: Always nil
+// - int64: Always 0
+// - error: Always nil
+func (db *NullDB) SearchUserURLs(_ context.Context, _ int, _ string, _, _ int) ([]*shortURLEntity.ShortURL, int64, error) {
+	return nil, 0, nil
+}
+
+// CountUserURLs is a no-op implementation that always returns 0.
+// Parameters:
+// - ctx: Context (ignored)
+// - userID: User ID (ignored)
+// Returns:
+// - int64: Always 0
+// - error: Always nil
+func (db *NullDB) CountUserURLs(_ context.Context, _ int) (int64, error) {
+	return 0, nil
+}
+
+// FindUserURLsCursor is a no-op implementation that always returns nil.
+// Parameters:
+// - ctx: Context (ignored)
+// - userID: User ID (ignored)
+// - afterID: Cursor (ignored)
+// - limit: Maximum number of URLs to return (ignored)
+// Returns:
+// - []*shortURLEntity.ShortURL: Always nil
+// - int: Always 0
+// - error: Always nil
+func (db *NullDB) FindUserURLsCursor(_ context.Context, _, _, _ int) ([]*shortURLEntity.ShortURL, int, error) {
+	return nil, 0, nil
+}
+
+// SetUserActive is a no-op implementation that always succeeds.
+// Parameters:
+// - ctx: Context (ignored)
+// - userID: User ID (ignored)
+// - isActive: Whether the user may authenticate (ignored)
+// Returns:
+// - error: Always nil
+func (db *NullDB) SetUserActive(_ context.Context, _ int, _ bool) error {
+	return nil
+}
+
+// SetUserCustomDomain is a no-op implementation that always succeeds.
+// Parameters:
+// - ctx: Context (ignored)
+// - userID: User ID (ignored)
+// - customDomain: Custom domain (ignored)
+// Returns:
+// - error: Always nil
+func (db *NullDB) SetUserCustomDomain(_ context.Context, _ int, _ string) error {
+	return nil
+}
+
 // SaveUser is a no-op implementation that always returns nil.
 // Parameters:
 // - ctx: Context (ignored)
@@ -62,23 +145,25 @@ func (db *NullDB) SaveUser(_ context.Context) (*userEntity.User, error) {
 // FindShortURL is a no-op implementation that always returns nil.
 // Parameters:
 // - ctx: Context (ignored)
+// - namespace: Namespace of the short URL (ignored)
 // - alias: Short URL alias (ignored)
 // Returns:
 // - *shortURLEntity.ShortURL: Always nil
 // - error: Always nil
-func (db *NullDB) FindShortURL(_ context.Context, _ string) (*shortURLEntity.ShortURL, error) {
+func (db *NullDB) FindShortURL(_ context.Context, _, _ string) (*shortURLEntity.ShortURL, error) {
 	return nil, nil
 }
 
-// findShortURLBySourceURL is a no-op implementation that always returns nil.
+// FindShortURLBySourceURL is a no-op implementation that never finds a short URL.
 // Parameters:
 // - ctx: Context (ignored)
+// - namespace: Namespace of the short URL (ignored)
 // - sourceURL: Original URL (ignored)
 // Returns:
 // - *shortURLEntity.ShortURL: Always nil
-// - error: Always nil
-func (db *NullDB) findShortURLBySourceURL(_ context.Context, _ string) (*shortURLEntity.ShortURL, error) {
-	return nil, nil
+// - error: Always dbErrors.ErrDBRecordNotFound
+func (db *NullDB) FindShortURLBySourceURL(_ context.Context, _, _ string) (*shortURLEntity.ShortURL, error) {
+	return nil, dbErrors.ErrDBRecordNotFound
 }
 
 // SaveShortURL is a no-op implementation that returns the input unchanged.
@@ -99,10 +184,321 @@ func (db *NullDB) SaveShortURL(_ context.Context, shortURL *shortURLEntity.Short
 // - aliases: URLs to delete (ignored)
 // Returns:
 // - error: Always nil
-func (db *NullDB) MarkURLAsDeleted(_ context.Context, _ int, _ []string) error {
+func (db *NullDB) MarkURLAsDeleted(_ context.Context, _ int, _ string, _ []string) error {
 	return nil
 }
 
+// RestoreURL is a no-op implementation that always succeeds.
+// Parameters:
+// - ctx: Context (ignored)
+// - userID: User ID (ignored)
+// - alias: URL to restore (ignored)
+// Returns:
+// - error: Always nil
+func (db *NullDB) RestoreURL(_ context.Context, _ int, _, _ string) error {
+	return nil
+}
+
+// UpdateURLTarget is a no-op implementation that always succeeds.
+// Parameters:
+// - ctx: Context (ignored)
+// - userID: User ID (ignored)
+// - alias: URL to update (ignored)
+// - newURL: New original URL (ignored)
+// Returns:
+// - error: Always nil
+func (db *NullDB) UpdateURLTarget(_ context.Context, _ int, _, _, _ string) error {
+	return nil
+}
+
+// FindURLHistory is a no-op implementation that always returns empty history.
+// Parameters:
+// - ctx: Context (ignored)
+// - alias: Short URL identifier (ignored)
+// Returns:
+// - []*shortURLEntity.HistoryEntry: Always nil
+// - error: Always nil
+func (db *NullDB) FindURLHistory(_ context.Context, _, _ string) ([]*shortURLEntity.HistoryEntry, error) {
+	return nil, nil
+}
+
+// CountURLs is a no-op implementation that always returns zero.
+// Parameters:
+// - ctx: Context (ignored)
+// Returns:
+// - int64: Always 0
+// - error: Always nil
+func (db *NullDB) CountURLs(_ context.Context) (int64, error) {
+	return 0, nil
+}
+
+// CountDeletedURLs is a no-op implementation that always returns zero.
+// Parameters:
+// - ctx: Context (ignored)
+// Returns:
+// - int64: Always 0
+// - error: Always nil
+func (db *NullDB) CountDeletedURLs(_ context.Context) (int64, error) {
+	return 0, nil
+}
+
+// CountUsers is a no-op implementation that always returns zero.
+// Parameters:
+// - ctx: Context (ignored)
+// Returns:
+// - int64: Always 0
+// - error: Always nil
+func (db *NullDB) CountUsers(_ context.Context) (int64, error) {
+	return 0, nil
+}
+
+// SaveTag is a no-op implementation that returns the input unchanged.
+// Parameters:
+// - ctx: Context (ignored)
+// - tag: Tag to "save"
+// Returns:
+// - *tagEntity.Tag: Returns the input tag
+// - error: Always nil
+func (db *NullDB) SaveTag(_ context.Context, tag *tagEntity.Tag) (*tagEntity.Tag, error) {
+	return tag, nil
+}
+
+// FindTagsByUser is a no-op implementation that always returns nil.
+// Parameters:
+// - ctx: Context (ignored)
+// - userID: User ID (ignored)
+// Returns:
+// - []*tagEntity.Tag: Always nil
+// - error: Always nil
+func (db *NullDB) FindTagsByUser(_ context.Context, _ int) ([]*tagEntity.Tag, error) {
+	return nil, nil
+}
+
+// FindURLTags is a no-op implementation that always returns nil.
+// Parameters:
+// - ctx: Context (ignored)
+// - userID: User ID (ignored)
+// - alias: URL alias (ignored)
+// Returns:
+// - []*tagEntity.Tag: Always nil
+// - error: Always nil
+func (db *NullDB) FindURLTags(_ context.Context, _ int, _, _ string) ([]*tagEntity.Tag, error) {
+	return nil, nil
+}
+
+// AssignTag is a no-op implementation that always succeeds.
+// Parameters:
+// - ctx: Context (ignored)
+// - userID: User ID (ignored)
+// - alias: URL alias (ignored)
+// - name: Tag name (ignored)
+// Returns:
+// - error: Always nil
+func (db *NullDB) AssignTag(_ context.Context, _ int, _, _, _ string) error {
+	return nil
+}
+
+// RemoveTag is a no-op implementation that always succeeds.
+// Parameters:
+// - ctx: Context (ignored)
+// - userID: User ID (ignored)
+// - alias: URL alias (ignored)
+// - name: Tag name (ignored)
+// Returns:
+// - error: Always nil
+func (db *NullDB) RemoveTag(_ context.Context, _ int, _, _, _ string) error {
+	return nil
+}
+
+// FindURLsByTag is a no-op implementation that always returns nil.
+// Parameters:
+// - ctx: Context (ignored)
+// - userID: User ID (ignored)
+// - name: Tag name (ignored)
+// Returns:
+// - []*shortURLEntity.ShortURL: Always nil
+// - error: Always nil
+func (db *NullDB) FindURLsByTag(_ context.Context, _ int, _ string) ([]*shortURLEntity.ShortURL, error) {
+	return nil, nil
+}
+
+// SaveWebhook is a no-op implementation that returns the input unchanged.
+// Parameters:
+// - ctx: Context (ignored)
+// - webhook: Webhook to "save"
+// Returns:
+// - *webhookEntity.Webhook: Returns the input webhook
+// - error: Always nil
+func (db *NullDB) SaveWebhook(_ context.Context, webhook *webhookEntity.Webhook) (*webhookEntity.Webhook, error) {
+	return webhook, nil
+}
+
+// FindWebhooksByUser is a no-op implementation that always returns nil.
+// Parameters:
+// - ctx: Context (ignored)
+// - userID: User ID (ignored)
+// Returns:
+// - []*webhookEntity.Webhook: Always nil
+// - error: Always nil
+func (db *NullDB) FindWebhooksByUser(_ context.Context, _ int) ([]*webhookEntity.Webhook, error) {
+	return nil, nil
+}
+
+// DeleteWebhook is a no-op implementation that always succeeds.
+// Parameters:
+// - ctx: Context (ignored)
+// - userID: User ID (ignored)
+// - id: Webhook ID (ignored)
+// Returns:
+// - error: Always nil
+func (db *NullDB) DeleteWebhook(_ context.Context, _, _ int) error {
+	return nil
+}
+
+// SaveAPIKey is a no-op implementation that always succeeds.
+// Parameters:
+// - ctx: Context (ignored)
+// - userID: User ID (ignored)
+// - keyHash: Key hash (ignored)
+// Returns:
+// - error: Always nil
+func (db *NullDB) SaveAPIKey(_ context.Context, _ int, _ string) error {
+	return nil
+}
+
+// FindUserByAPIKey always fails as no API keys are stored.
+// Parameters:
+// - ctx: Context (ignored)
+// - keyHash: Key hash (ignored)
+// Returns:
+// - *userEntity.User: Always nil
+// - error: Always dbErrors.ErrDBRecordNotFound
+func (db *NullDB) FindUserByAPIKey(_ context.Context, _ string) (*userEntity.User, error) {
+	return nil, dbErrors.ErrDBRecordNotFound
+}
+
+// RevokeAPIKey is a no-op implementation that always succeeds.
+// Parameters:
+// - ctx: Context (ignored)
+// - userID: User ID (ignored)
+// - keyHash: Key hash (ignored)
+// Returns:
+// - error: Always nil
+func (db *NullDB) RevokeAPIKey(_ context.Context, _ int, _ string) error {
+	return nil
+}
+
+// SaveClick is a no-op implementation that always succeeds.
+// Parameters:
+// - ctx: Context (ignored)
+// - click: Click (ignored)
+// Returns:
+// - error: Always nil
+func (db *NullDB) SaveClick(_ context.Context, _ *analyticsEntity.Click) error {
+	return nil
+}
+
+// FindUserClicks is a no-op implementation that always returns nil.
+// Parameters:
+// - ctx: Context (ignored)
+// - userID: User ID (ignored)
+// Returns:
+// - []*analyticsEntity.Click: Always nil
+// - error: Always nil
+func (db *NullDB) FindUserClicks(_ context.Context, _ int) ([]*analyticsEntity.Click, error) {
+	return nil, nil
+}
+
+// CountClicks is a no-op implementation that always returns nil.
+// Parameters:
+// - ctx: Context (ignored)
+// - alias: Short URL alias (ignored)
+// - from: Start of the time range (ignored)
+// - to: End of the time range (ignored)
+// - granularity: Period length (ignored)
+// Returns:
+// - []analyticsEntity.ClickCount: Always nil
+// - error: Always nil
+func (db *NullDB) CountClicks(_ context.Context, _, _ string, _, _ time.Time, _ string) ([]analyticsEntity.ClickCount, error) {
+	return nil, nil
+}
+
+// CountClicksByLocation is a no-op implementation that always returns nil.
+// Parameters:
+// - ctx: Context (ignored)
+// - alias: Short URL alias (ignored)
+// - from: Start of the time range (ignored)
+// - to: End of the time range (ignored)
+// Returns:
+// - []analyticsEntity.LocationCount: Always nil
+// - error: Always nil
+func (db *NullDB) CountClicksByLocation(_ context.Context, _, _ string, _, _ time.Time) ([]analyticsEntity.LocationCount, error) {
+	return nil, nil
+}
+
+// SaveSplit is a no-op implementation that always succeeds.
+// Parameters:
+// - ctx: Context (ignored)
+// - split: Split (ignored)
+// Returns:
+// - error: Always nil
+func (db *NullDB) SaveSplit(_ context.Context, _ *splitEntity.MultiDestination) error {
+	return nil
+}
+
+// FindSplit always fails as no splits are stored.
+// Parameters:
+// - ctx: Context (ignored)
+// - alias: Split alias (ignored)
+// Returns:
+// - *splitEntity.MultiDestination: Always nil
+// - error: Always dbErrors.ErrDBRecordNotFound
+func (db *NullDB) FindSplit(_ context.Context, _, _ string) (*splitEntity.MultiDestination, error) {
+	return nil, dbErrors.ErrDBRecordNotFound
+}
+
+// SaveSplitClick is a no-op implementation that always succeeds.
+// Parameters:
+// - ctx: Context (ignored)
+// - alias: Split alias (ignored)
+// - url: Destination URL (ignored)
+// Returns:
+// - error: Always nil
+func (db *NullDB) SaveSplitClick(_ context.Context, _, _, _ string) error {
+	return nil
+}
+
+// SaveNamespace is a no-op implementation that returns the input unchanged.
+// Parameters:
+// - ctx: Context (ignored)
+// - namespace: Namespace to "save"
+// Returns:
+// - *namespaceEntity.Namespace: Returns the input namespace
+// - error: Always nil
+func (db *NullDB) SaveNamespace(_ context.Context, namespace *namespaceEntity.Namespace) (*namespaceEntity.Namespace, error) {
+	return namespace, nil
+}
+
+// FindNamespaces is a no-op implementation that always returns nil.
+// Parameters:
+// - ctx: Context (ignored)
+// Returns:
+// - []*namespaceEntity.Namespace: Always nil
+// - error: Always nil
+func (db *NullDB) FindNamespaces(_ context.Context) ([]*namespaceEntity.Namespace, error) {
+	return nil, nil
+}
+
+// DeleteNamespace always fails as no namespaces are stored.
+// Parameters:
+// - ctx: Context (ignored)
+// - name: Namespace name (ignored)
+// Returns:
+// - error: Always dbErrors.ErrDBRecordNotFound
+func (db *NullDB) DeleteNamespace(_ context.Context, _ string) error {
+	return dbErrors.ErrDBRecordNotFound
+}
+
 // Ping is a no-op implementation that always succeeds.
 // Parameters:
 // - ctx: Context (ignored)
@@ -0,0 +1,264 @@
+package db
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"io/fs"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gururuby/shortener/internal/config"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/gururuby/shortener/internal/infra/logger"
+	"github.com/gururuby/shortener/pkg/retry"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+)
+
+// Migration annotations of goose SQL files
+const (
+	migrationsDir        = "migrations"          // Directory of embedded migration files
+	gooseUpAnnotation    = "-- +goose Up"        // Starts statements applying a migration
+	gooseDownAnnotation  = "-- +goose Down"      // Starts statements rolling back a migration
+	gooseStatementPrefix = "-- +goose Statement" // Prefix of StatementBegin and StatementEnd annotations
+)
+
+// Migration describes a database migration and whether it is applied.
+type Migration struct {
+	Version   int64     // Version from the file name
+	Name      string    // File name
+	Applied   bool      // Whether the migration is applied
+	AppliedAt time.Time // Time the migration was applied, zero for pending migrations
+	SQL       string    // Statements applying the migration, set by DryRunMigrations only
+}
+
+// OpenMigrationDB connects to the database for running migrations.
+// Connection is retried the same way as for connection pool of the application.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - cfg: Database configuration
+// Returns:
+// - *sql.DB: Database handle, the caller must close it
+// - error: If DSN is invalid or connection fails after retries
+func OpenMigrationDB(ctx context.Context, cfg config.Database) (*sql.DB, error) {
+	poolCfg, err := newDBPoolConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	db := stdlib.OpenDB(*poolCfg.ConnConfig)
+	err = utils.ExponentialBackoff(func() error {
+		attemptCtx, cancel := context.WithTimeout(ctx, cfg.ConnTryDelay)
+		defer cancel()
+
+		if pingErr := db.PingContext(attemptCtx); pingErr != nil {
+			logger.Log.Error(pingErr.Error())
+			return pingErr
+		}
+		return nil
+	}, cfg.ConnTryTimes, cfg.ConnTryDelay, connRetryMaxDelay, connRetryJitter, utils.WithContext(ctx))
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// RunMigrations applies all pending migrations.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - db: Database handle
+// Returns:
+// - []*Migration: Applied migrations in the order they were applied
+// - error: If a migration fails, migrations applied before it stay applied
+func RunMigrations(ctx context.Context, db *sql.DB) ([]*Migration, error) {
+	provider, err := newMigrationProvider(db)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := provider.Up(ctx)
+	return fromResults(results, true), err
+}
+
+// RollbackMigrations rolls back the last n applied migrations.
+// Rollback stops early when no applied migrations are left.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - db: Database handle
+// - n: Number of migrations to roll back
+// Returns:
+// - []*Migration: Rolled back migrations in the order they were rolled back
+// - error: ErrDBInvalidMigrationSteps if n is not positive, or error of the failed migration
+func RollbackMigrations(ctx context.Context, db *sql.DB, n int) ([]*Migration, error) {
+	if n <= 0 {
+		return nil, dbErrors.ErrDBInvalidMigrationSteps
+	}
+
+	provider, err := newMigrationProvider(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var rolledBack []*Migration
+	for range n {
+		result, downErr := provider.Down(ctx)
+		if errors.Is(downErr, goose.ErrNoNextVersion) {
+			break
+		}
+		if downErr != nil {
+			return rolledBack, downErr
+		}
+		rolledBack = append(rolledBack, fromResults([]*goose.MigrationResult{result}, false)...)
+	}
+
+	return rolledBack, nil
+}
+
+// ResetMigrations rolls back all applied migrations.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - db: Database handle
+// Returns:
+// - []*Migration: Rolled back migrations in the order they were rolled back
+// - error: If a migration fails, migrations rolled back before it stay rolled back
+func ResetMigrations(ctx context.Context, db *sql.DB) ([]*Migration, error) {
+	provider, err := newMigrationProvider(db)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := provider.DownTo(ctx, 0)
+	return fromResults(results, false), err
+}
+
+// MigrationStatus lists all known migrations with their state.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - db: Database handle
+// Returns:
+// - []*Migration: Migrations ordered by version
+// - error: If the state cannot be read
+func MigrationStatus(ctx context.Context, db *sql.DB) ([]*Migration, error) {
+	provider, err := newMigrationProvider(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := provider.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*Migration, 0, len(statuses))
+	for _, status := range statuses {
+		list = append(list, &Migration{
+			Version:   status.Source.Version,
+			Name:      path.Base(status.Source.Path),
+			Applied:   status.State == goose.StateApplied,
+			AppliedAt: status.AppliedAt,
+		})
+	}
+
+	return list, nil
+}
+
+// DryRunMigrations lists pending migrations with statements applying them, nothing is applied.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - db: Database handle
+// Returns:
+// - []*Migration: Pending migrations ordered by version, with SQL set
+// - error: If the state cannot be read or a migration file cannot be parsed
+func DryRunMigrations(ctx context.Context, db *sql.DB) ([]*Migration, error) {
+	list, err := MigrationStatus(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*Migration
+	for _, migration := range list {
+		if migration.Applied {
+			continue
+		}
+		content, readErr := migrations.ReadFile(path.Join(migrationsDir, migration.Name))
+		if readErr != nil {
+			return nil, readErr
+		}
+		migration.SQL = upStatements(string(content))
+		pending = append(pending, migration)
+	}
+
+	return pending, nil
+}
+
+// newMigrationProvider creates goose provider of embedded migrations.
+// Parameters:
+// - db: Database handle
+// Returns:
+// - *goose.Provider: Provider running migrations
+// - error: If migration files cannot be collected
+func newMigrationProvider(db *sql.DB) (*goose.Provider, error) {
+	fsys, err := fs.Sub(migrations, migrationsDir)
+	if err != nil {
+		return nil, err
+	}
+	return goose.NewProvider(goose.DialectPostgres, db, fsys)
+}
+
+// fromResults converts goose migration results.
+// Parameters:
+// - results: Results of applied or rolled back migrations
+// - applied: Whether migrations were applied, false if they were rolled back
+// Returns:
+// - []*Migration: Migrations in the order of results
+func fromResults(results []*goose.MigrationResult, applied bool) []*Migration {
+	list := make([]*Migration, 0, len(results))
+	for _, result := range results {
+		list = append(list, &Migration{
+			Version: result.Source.Version,
+			Name:    path.Base(result.Source.Path),
+			Applied: applied,
+		})
+	}
+	return list
+}
+
+// upStatements extracts statements applying the migration from goose SQL file.
+// Goose annotations are dropped, other comments are kept.
+// Parameters:
+// - content: Content of the migration file
+// Returns:
+// - string: Statements between Up and Down annotations
+func upStatements(content string) string {
+	var (
+		statements strings.Builder
+		up         bool
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, gooseUpAnnotation):
+			up = true
+			continue
+		case strings.HasPrefix(trimmed, gooseDownAnnotation):
+			up = false
+			continue
+		case strings.HasPrefix(trimmed, gooseStatementPrefix):
+			continue
+		}
+		if up {
+			statements.WriteString(line)
+			statements.WriteByte('\n')
+		}
+	}
+
+	return strings.TrimSpace(statements.String())
+}
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN normalized_url varchar(255);
+UPDATE urls SET normalized_url = original_url;
+CREATE INDEX ON urls (normalized_url);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE urls DROP COLUMN normalized_url;
+-- +goose StatementEnd
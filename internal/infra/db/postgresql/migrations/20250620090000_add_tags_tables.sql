@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE UNIQUE INDEX ON urls (id);
+CREATE TABLE tags (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL,
+    UNIQUE (user_id, name)
+);
+CREATE TABLE url_tags (
+    url_id BIGINT NOT NULL REFERENCES urls (id) ON DELETE CASCADE,
+    tag_id INT NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (url_id, tag_id)
+);
+CREATE INDEX ON url_tags (tag_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE url_tags;
+DROP TABLE tags;
+DROP INDEX urls_id_idx;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE clicks (
+    id BIGSERIAL PRIMARY KEY,
+    alias VARCHAR(255) NOT NULL,
+    clicked_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ip_hash VARCHAR(64) NOT NULL,
+    user_agent_hash VARCHAR(64) NOT NULL
+);
+CREATE INDEX clicks_alias_clicked_at_idx ON clicks (alias, clicked_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE clicks;
+-- +goose StatementEnd
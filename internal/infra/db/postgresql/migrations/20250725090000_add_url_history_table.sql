@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN updated_at TIMESTAMPTZ;
+CREATE TABLE url_history (
+    id BIGSERIAL PRIMARY KEY,
+    alias VARCHAR(255) NOT NULL,
+    original_url TEXT NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX url_history_alias_changed_at_idx ON url_history (alias, changed_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE url_history;
+ALTER TABLE urls DROP COLUMN updated_at;
+-- +goose StatementEnd
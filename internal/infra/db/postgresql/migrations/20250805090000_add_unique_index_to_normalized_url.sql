@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Keep the oldest of duplicated deduplicable URLs reusable, the rest keep their aliases but are not reused
+UPDATE urls SET normalized_url = NULL
+WHERE NOT is_one_time_use AND visibility = 'public' AND redirect_type = 307
+  AND id NOT IN (
+    SELECT MIN(id) FROM urls
+    WHERE NOT is_one_time_use AND visibility = 'public' AND redirect_type = 307
+    GROUP BY normalized_url
+  );
+CREATE UNIQUE INDEX urls_normalized_url_dedup_idx ON urls (normalized_url)
+WHERE NOT is_one_time_use AND visibility = 'public' AND redirect_type = 307;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX urls_normalized_url_dedup_idx;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE multi_destinations (
+    alias VARCHAR(255) NOT NULL,
+    url TEXT NOT NULL,
+    weight SMALLINT NOT NULL CHECK (weight > 0 AND weight <= 100),
+    position SMALLINT NOT NULL,
+    user_id INTEGER NOT NULL,
+    clicks BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (alias, url)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE multi_destinations;
+-- +goose StatementEnd
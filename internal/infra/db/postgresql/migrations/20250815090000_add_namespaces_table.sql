@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE namespaces (
+    name VARCHAR(64) PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+INSERT INTO namespaces (name) VALUES ('default');
+ALTER TABLE urls ADD COLUMN namespace VARCHAR(64) NOT NULL DEFAULT 'default' REFERENCES namespaces (name);
+CREATE UNIQUE INDEX urls_namespace_alias_idx ON urls (namespace, alias);
+-- Deduplication of URLs is scoped to a namespace
+DROP INDEX urls_normalized_url_dedup_idx;
+CREATE UNIQUE INDEX urls_normalized_url_dedup_idx ON urls (namespace, normalized_url)
+WHERE NOT is_one_time_use AND visibility = 'public' AND redirect_type = 307;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX urls_normalized_url_dedup_idx;
+CREATE UNIQUE INDEX urls_normalized_url_dedup_idx ON urls (normalized_url)
+WHERE NOT is_one_time_use AND visibility = 'public' AND redirect_type = 307;
+DROP INDEX urls_namespace_alias_idx;
+ALTER TABLE urls DROP COLUMN namespace;
+DROP TABLE namespaces;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE clicks
+    ADD COLUMN country VARCHAR(2) NOT NULL DEFAULT '',
+    ADD COLUMN city VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN lat VARCHAR(32) NOT NULL DEFAULT '',
+    ADD COLUMN lon VARCHAR(32) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE clicks
+    DROP COLUMN country,
+    DROP COLUMN city,
+    DROP COLUMN lat,
+    DROP COLUMN lon;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN deleted_at TIMESTAMPTZ;
+-- Deletion time of URLs deleted before is unknown, they are archived as if deleted now
+UPDATE urls SET deleted_at = NOW() WHERE is_deleted;
+CREATE INDEX urls_deleted_at_idx ON urls (deleted_at) WHERE is_deleted;
+CREATE TABLE archived_urls (
+    id BIGINT PRIMARY KEY,
+    uuid uuid NOT NULL,
+    alias VARCHAR(255) NOT NULL,
+    original_url VARCHAR(255) NOT NULL,
+    normalized_url VARCHAR(255),
+    user_id INT REFERENCES users (id) ON DELETE CASCADE,
+    is_deleted BOOLEAN NOT NULL DEFAULT true,
+    is_one_time_use BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ,
+    visibility VARCHAR(10) NOT NULL DEFAULT 'public',
+    redirect_type SMALLINT NOT NULL DEFAULT 307,
+    created_in_region VARCHAR(64),
+    updated_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,
+    namespace VARCHAR(64) NOT NULL DEFAULT 'default',
+    deleted_at TIMESTAMPTZ,
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX archived_urls_user_id_archived_at_idx ON archived_urls (user_id, archived_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE archived_urls;
+DROP INDEX urls_deleted_at_idx;
+ALTER TABLE urls DROP COLUMN deleted_at;
+-- +goose StatementEnd
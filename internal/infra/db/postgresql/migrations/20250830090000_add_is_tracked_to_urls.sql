@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN is_tracked BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE archived_urls ADD COLUMN is_tracked BOOLEAN NOT NULL DEFAULT false;
+-- Tracked URLs are never deduplicated
+DROP INDEX urls_normalized_url_dedup_idx;
+CREATE UNIQUE INDEX urls_normalized_url_dedup_idx ON urls (namespace, normalized_url)
+WHERE NOT is_one_time_use AND visibility = 'public' AND redirect_type = 307 AND NOT is_tracked;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX urls_normalized_url_dedup_idx;
+-- Tracked URLs may duplicate deduplicated ones, they keep their aliases but are not reused
+UPDATE urls SET normalized_url = NULL WHERE is_tracked;
+CREATE UNIQUE INDEX urls_normalized_url_dedup_idx ON urls (namespace, normalized_url)
+WHERE NOT is_one_time_use AND visibility = 'public' AND redirect_type = 307;
+ALTER TABLE archived_urls DROP COLUMN is_tracked;
+ALTER TABLE urls DROP COLUMN is_tracked;
+-- +goose StatementEnd
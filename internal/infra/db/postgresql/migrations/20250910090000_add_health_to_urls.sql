@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN is_healthy BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE urls ADD COLUMN checked_at TIMESTAMPTZ;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE urls DROP COLUMN checked_at;
+ALTER TABLE urls DROP COLUMN is_healthy;
+-- +goose StatementEnd
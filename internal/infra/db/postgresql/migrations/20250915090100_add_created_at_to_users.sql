@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Registration time of existing users is unknown, it is left empty
+ALTER TABLE users ADD COLUMN created_at TIMESTAMPTZ;
+ALTER TABLE users ALTER COLUMN created_at SET DEFAULT NOW();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN created_at;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Users without custom domain keep NULL, so the unique index does not apply to them
+ALTER TABLE users ADD COLUMN custom_domain VARCHAR(255);
+CREATE UNIQUE INDEX users_custom_domain_idx ON users (custom_domain);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX users_custom_domain_idx;
+ALTER TABLE users DROP COLUMN custom_domain;
+-- +goose StatementEnd
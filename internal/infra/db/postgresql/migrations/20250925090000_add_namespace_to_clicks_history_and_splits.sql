@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE clicks ADD COLUMN namespace VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE url_history ADD COLUMN namespace VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE multi_destinations ADD COLUMN namespace VARCHAR(64) NOT NULL DEFAULT 'default' REFERENCES namespaces (name);
+-- Rows of aliases existing only outside of the default namespace belong to that namespace
+UPDATE clicks SET namespace = urls.namespace FROM urls
+WHERE urls.alias = clicks.alias
+  AND NOT EXISTS (SELECT 1 FROM urls AS other WHERE other.namespace = 'default' AND other.alias = clicks.alias);
+UPDATE url_history SET namespace = urls.namespace FROM urls
+WHERE urls.alias = url_history.alias
+  AND NOT EXISTS (SELECT 1 FROM urls AS other WHERE other.namespace = 'default' AND other.alias = url_history.alias);
+DROP INDEX clicks_alias_clicked_at_idx;
+CREATE INDEX clicks_namespace_alias_clicked_at_idx ON clicks (namespace, alias, clicked_at);
+DROP INDEX url_history_alias_changed_at_idx;
+CREATE INDEX url_history_namespace_alias_changed_at_idx ON url_history (namespace, alias, changed_at);
+ALTER TABLE multi_destinations DROP CONSTRAINT multi_destinations_pkey;
+ALTER TABLE multi_destinations ADD PRIMARY KEY (namespace, alias, url);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE multi_destinations DROP CONSTRAINT multi_destinations_pkey;
+ALTER TABLE multi_destinations ADD PRIMARY KEY (alias, url);
+ALTER TABLE multi_destinations DROP COLUMN namespace;
+DROP INDEX url_history_namespace_alias_changed_at_idx;
+CREATE INDEX url_history_alias_changed_at_idx ON url_history (alias, changed_at);
+ALTER TABLE url_history DROP COLUMN namespace;
+DROP INDEX clicks_namespace_alias_clicked_at_idx;
+CREATE INDEX clicks_alias_clicked_at_idx ON clicks (alias, clicked_at);
+ALTER TABLE clicks DROP COLUMN namespace;
+-- +goose StatementEnd
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_upStatements(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name: "when statements are wrapped into StatementBegin and StatementEnd",
+			content: `-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE urls ADD COLUMN is_tracked BOOLEAN NOT NULL DEFAULT false;
+-- Tracked URLs are never deduplicated
+DROP INDEX urls_normalized_url_dedup_idx;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE urls DROP COLUMN is_tracked;
+-- +goose StatementEnd
+`,
+			want: "ALTER TABLE urls ADD COLUMN is_tracked BOOLEAN NOT NULL DEFAULT false;\n" +
+				"-- Tracked URLs are never deduplicated\n" +
+				"DROP INDEX urls_normalized_url_dedup_idx;",
+		},
+		{
+			name:    "when migration has no Down section",
+			content: "-- +goose Up\nCREATE TABLE tags (id SERIAL PRIMARY KEY);\n",
+			want:    "CREATE TABLE tags (id SERIAL PRIMARY KEY);",
+		},
+		{
+			name:    "when migration has no Up section",
+			content: "-- +goose Down\nDROP TABLE tags;\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, upStatements(tt.content))
+		})
+	}
+}
+
+func Test_upStatements_EmbeddedMigrations(t *testing.T) {
+	entries, err := migrations.ReadDir(migrationsDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+
+	for _, entry := range entries {
+		content, readErr := migrations.ReadFile(migrationsDir + "/" + entry.Name())
+		require.NoError(t, readErr)
+		require.NotEmpty(t, upStatements(string(content)), entry.Name())
+	}
+}
+
+func Test_RollbackMigrations_InvalidSteps(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		_, err := RollbackMigrations(context.Background(), nil, n)
+		require.ErrorIs(t, err, dbErrors.ErrDBInvalidMigrationSteps)
+	}
+}
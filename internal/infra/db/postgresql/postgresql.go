@@ -7,6 +7,7 @@ It provides:
 - Persistent storage using PostgreSQL
 - Database migrations using Goose
 - Connection pooling for performance
+- Optional read replica for read-heavy queries
 - Comprehensive error handling
 - Support for all required database operations
 */
@@ -16,11 +17,18 @@ import (
 	"context"
 	"embed"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/gururuby/shortener/internal/config"
+	analyticsEntity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	healthEntity "github.com/gururuby/shortener/internal/domain/entity/health"
+	namespaceEntity "github.com/gururuby/shortener/internal/domain/entity/namespace"
 	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	splitEntity "github.com/gururuby/shortener/internal/domain/entity/split"
+	tagEntity "github.com/gururuby/shortener/internal/domain/entity/tag"
 	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	webhookEntity "github.com/gururuby/shortener/internal/domain/entity/webhook"
 	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
 	"github.com/gururuby/shortener/internal/infra/logger"
 	"github.com/gururuby/shortener/pkg/retry"
@@ -29,24 +37,88 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
-	"github.com/pressly/goose/v3"
 	"go.uber.org/zap"
 )
 
 //go:embed migrations/*.sql
 var migrations embed.FS
 
+// likeEscaper escapes LIKE wildcards with the default escape character.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 const (
+	// upsertShortURLClause returns the existing short URL instead of inserting a duplicate of a deduplicated one.
+	// The conflict target matches partial unique index urls_normalized_url_dedup_idx, so URLs are deduplicated
+	// within a namespace only; the no-op update
+	// makes RETURNING yield the existing row, xmax is 0 for inserted rows only.
+	upsertShortURLClause = ` ON CONFLICT (namespace, normalized_url) WHERE NOT is_one_time_use AND visibility = 'public' AND redirect_type = 307 AND NOT is_tracked DO UPDATE SET normalized_url = EXCLUDED.normalized_url RETURNING alias, original_url, uuid, is_deleted, xmax = 0`
+
+	// aliasIndexName is the unique index of aliases within a namespace, its violation means the alias is taken.
+	aliasIndexName = "urls_namespace_alias_idx"
+
 	waitConnectionCloseTimeout = 5 * time.Second
+	connRetryMaxDelay          = time.Minute // Upper bound of delay between connection attempts
+	connRetryJitter            = 0.2         // Random shift of delay between connection attempts
+
+	archiveDeletedURLsAfter = 30 * 24 * time.Hour // Time deleted short URLs stay in urls before archiving
 
-	findShortURLQuery            = `SELECT original_url, uuid, is_deleted FROM urls WHERE urls.alias = $1`
-	findUserQuery                = `SELECT id FROM users WHERE users.id = $1`
-	findUserURLsQuery            = `SELECT alias, original_url FROM urls WHERE urls.user_id = $1`
-	findShortURLBySourceURLQuery = `SELECT alias FROM urls WHERE urls.original_url = $1`
-	saveShortURLQuery            = `INSERT INTO urls (alias, original_url) VALUES ($1, $2)`
-	saveShortURLQueryWithUser    = `INSERT INTO urls (alias, original_url, user_id) VALUES ($1, $2, $3)`
-	saveUserQuery                = `INSERT INTO users DEFAULT VALUES RETURNING id`
-	markURLsAsDeletedQuery       = "UPDATE urls SET is_deleted = true WHERE user_id = $1 AND alias = ANY($2)"
+	findShortURLQuery          = `SELECT original_url, uuid, is_deleted, is_one_time_use, COALESCE(visibility, 'public'), redirect_type, COALESCE(user_id, 0), created_at, COALESCE(created_in_region, ''), expires_at, is_tracked, is_healthy, checked_at FROM urls WHERE urls.namespace = $1 AND urls.alias = $2`
+	findShortURLBySourceQuery  = `SELECT alias, original_url, uuid, is_deleted FROM urls WHERE namespace = $1 AND normalized_url = $2 AND NOT is_one_time_use AND visibility = 'public' AND redirect_type = 307 AND NOT is_tracked`
+	findUserQuery              = `SELECT id, is_active, created_at, COALESCE(custom_domain, '') FROM users WHERE users.id = $1`
+	findUserURLsQuery          = `SELECT alias, original_url, COALESCE(created_in_region, ''), namespace FROM urls WHERE urls.user_id = $1`
+	findUserURLsPaginatedQuery = `SELECT alias, original_url, COALESCE(created_in_region, ''), namespace FROM urls WHERE urls.user_id = $1 ORDER BY alias LIMIT $2 OFFSET $3`
+	findUserURLsCursorQuery    = `SELECT id, alias, original_url, is_deleted, created_at, COALESCE(created_in_region, ''), namespace FROM urls WHERE urls.user_id = $1 AND urls.id > $2 ORDER BY urls.id LIMIT $3`
+	countUserURLsQuery         = `SELECT COUNT(*) FROM urls WHERE urls.user_id = $1`
+	searchUserURLsQuery        = `SELECT alias, original_url, COALESCE(created_in_region, ''), namespace FROM urls WHERE urls.user_id = $1 AND urls.original_url ILIKE $2 ESCAPE '\' ORDER BY alias LIMIT $3 OFFSET $4`
+	countSearchUserURLsQuery   = `SELECT COUNT(*) FROM urls WHERE urls.user_id = $1 AND urls.original_url ILIKE $2 ESCAPE '\'`
+	countActiveUserURLsQuery   = `SELECT COUNT(*) FROM urls WHERE urls.user_id IS NOT DISTINCT FROM NULLIF($1, 0) AND NOT urls.is_deleted`
+	saveShortURLQuery          = `INSERT INTO urls (alias, original_url, normalized_url, is_one_time_use, visibility, redirect_type, created_in_region, expires_at, namespace, is_tracked) VALUES ($1, $2, $3, $4, COALESCE(NULLIF($5, ''), 'public'), $6, NULLIF($7, ''), $8, $9, $10)` + upsertShortURLClause
+	saveShortURLQueryWithUser  = `INSERT INTO urls (alias, original_url, normalized_url, is_one_time_use, visibility, redirect_type, created_in_region, expires_at, namespace, is_tracked, user_id) VALUES ($1, $2, $3, $4, COALESCE(NULLIF($5, ''), 'public'), $6, NULLIF($7, ''), $8, $9, $10, $11)` + upsertShortURLClause
+	saveUserQuery              = `INSERT INTO users DEFAULT VALUES RETURNING id, is_active, created_at`
+	setUserActiveQuery         = `UPDATE users SET is_active = $1 WHERE id = $2`
+	setUserCustomDomainQuery   = `UPDATE users SET custom_domain = NULLIF($1, '') WHERE id = $2`
+	markURLsAsDeletedQuery     = "UPDATE urls SET is_deleted = true, deleted_at = COALESCE(deleted_at, NOW()) WHERE user_id = $1 AND namespace = $2 AND alias = ANY($3)"
+	lockNotDeletedURLsQuery    = "SELECT alias FROM urls WHERE namespace = $1 AND alias = ANY($2) AND NOT is_deleted FOR UPDATE"
+	markAnyURLsAsDeletedQuery  = "UPDATE urls SET is_deleted = true, deleted_at = COALESCE(deleted_at, NOW()) WHERE namespace = $1 AND alias = ANY($2)"
+	deleteExpiredURLsQuery     = "DELETE FROM urls WHERE expires_at IS NOT NULL AND expires_at < NOW() RETURNING alias"
+	restoreURLQuery            = "UPDATE urls SET is_deleted = false, deleted_at = NULL WHERE namespace = $1 AND alias = $2 AND user_id = $3"
+	lockUserURLTargetQuery     = "SELECT original_url FROM urls WHERE namespace = $1 AND alias = $2 AND user_id = $3 FOR UPDATE"
+	saveURLHistoryQuery        = "INSERT INTO url_history (namespace, alias, original_url) VALUES ($1, $2, $3)"
+	updateURLTargetQuery       = "UPDATE urls SET original_url = $1, normalized_url = CASE WHEN EXISTS (SELECT 1 FROM urls AS other WHERE other.normalized_url = $1 AND other.namespace = urls.namespace AND other.alias <> urls.alias AND NOT other.is_one_time_use AND other.visibility = 'public' AND other.redirect_type = 307 AND NOT other.is_tracked) THEN NULL ELSE $1 END, updated_at = NOW() WHERE namespace = $2 AND alias = $3 AND user_id = $4"
+	findURLHistoryQuery        = `SELECT original_url, changed_at FROM url_history WHERE url_history.namespace = $1 AND url_history.alias = $2 ORDER BY url_history.changed_at DESC, url_history.id DESC`
+	countURLsQuery             = `SELECT COUNT(*) FROM urls`
+	countDeletedURLsQuery      = `SELECT COUNT(*) FROM urls WHERE urls.is_deleted`
+	countUsersQuery            = `SELECT COUNT(*) FROM users`
+	saveTagQuery               = `INSERT INTO tags (user_id, name) VALUES ($1, $2) RETURNING id`
+	findTagsByUserQuery        = `SELECT id, name FROM tags WHERE tags.user_id = $1 ORDER BY tags.name`
+	findUserURLIDQuery         = `SELECT id FROM urls WHERE urls.user_id = $1 AND urls.namespace = $2 AND urls.alias = $3`
+	findURLTagsQuery           = `SELECT tags.id, tags.name FROM tags JOIN url_tags ON url_tags.tag_id = tags.id WHERE url_tags.url_id = $1 ORDER BY tags.name`
+	assignTagQuery             = `WITH pair AS (SELECT urls.id AS url_id, tags.id AS tag_id FROM urls JOIN tags ON tags.user_id = urls.user_id WHERE urls.user_id = $1 AND urls.namespace = $2 AND urls.alias = $3 AND tags.name = $4), inserted AS (INSERT INTO url_tags (url_id, tag_id) SELECT url_id, tag_id FROM pair ON CONFLICT DO NOTHING) SELECT COUNT(*) FROM pair`
+	removeTagQuery             = `DELETE FROM url_tags USING urls, tags WHERE url_tags.url_id = urls.id AND url_tags.tag_id = tags.id AND urls.user_id = $1 AND urls.namespace = $2 AND urls.alias = $3 AND tags.user_id = $1 AND tags.name = $4`
+	findURLsByTagQuery         = `SELECT urls.id, urls.alias, urls.original_url, urls.namespace FROM urls JOIN url_tags ON url_tags.url_id = urls.id JOIN tags ON tags.id = url_tags.tag_id WHERE tags.user_id = $1 AND tags.name = $2 ORDER BY urls.id`
+	saveWebhookQuery           = `INSERT INTO webhooks (user_id, url, events, secret) VALUES ($1, $2, $3, $4) RETURNING id`
+	findWebhooksByUserQuery    = `SELECT id, url, events, secret FROM webhooks WHERE webhooks.user_id = $1 ORDER BY webhooks.id`
+	deleteWebhookQuery         = `DELETE FROM webhooks WHERE id = $1 AND user_id = $2`
+	saveAPIKeyQuery            = `INSERT INTO api_keys (user_id, key_hash) VALUES ($1, $2)`
+	findUserByAPIKeyQuery      = `SELECT users.id, users.is_active, COALESCE(users.custom_domain, '') FROM api_keys JOIN users ON users.id = api_keys.user_id WHERE api_keys.key_hash = $1 AND api_keys.revoked_at IS NULL`
+	revokeAPIKeyQuery          = `UPDATE api_keys SET revoked_at = NOW() WHERE key_hash = $1 AND user_id = $2 AND revoked_at IS NULL`
+	saveClickQuery             = `INSERT INTO clicks (namespace, alias, clicked_at, ip_hash, user_agent_hash, country, city, lat, lon, anonymized_ip) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	findUserClicksQuery        = `SELECT namespace, alias, clicked_at, anonymized_ip, country, city, lat, lon FROM clicks WHERE (clicks.namespace, clicks.alias) IN (SELECT namespace, alias FROM urls WHERE urls.user_id = $1 UNION SELECT namespace, alias FROM archived_urls WHERE archived_urls.user_id = $1) ORDER BY clicks.clicked_at, clicks.id`
+	countClicksQuery           = `SELECT DATE_TRUNC($1, clicked_at AT TIME ZONE 'UTC') AS period, COUNT(*) FROM clicks WHERE namespace = $2 AND alias = $3 AND clicked_at >= $4 AND clicked_at < $5 GROUP BY period ORDER BY period`
+	countClicksByLocationQuery = `SELECT country, city, COUNT(*) AS clicks FROM clicks WHERE namespace = $1 AND alias = $2 AND clicked_at >= $3 AND clicked_at < $4 GROUP BY country, city ORDER BY clicks DESC, country, city`
+	lockSplitAliasQuery        = `SELECT pg_advisory_xact_lock(hashtext($1))`
+	splitAliasExistsQuery      = `SELECT EXISTS (SELECT 1 FROM urls WHERE urls.namespace = $1 AND urls.alias = $2) OR EXISTS (SELECT 1 FROM multi_destinations WHERE multi_destinations.namespace = $1 AND multi_destinations.alias = $2)`
+	saveSplitDestinationQuery  = `INSERT INTO multi_destinations (namespace, alias, url, weight, position, user_id) VALUES ($1, $2, $3, $4, $5, $6)`
+	findSplitQuery             = `SELECT url, weight, clicks, user_id FROM multi_destinations WHERE multi_destinations.namespace = $1 AND multi_destinations.alias = $2 ORDER BY multi_destinations.position`
+	saveSplitClickQuery        = `UPDATE multi_destinations SET clicks = clicks + 1 WHERE namespace = $1 AND alias = $2 AND url = $3`
+	saveNamespaceQuery         = `INSERT INTO namespaces (name) VALUES ($1) RETURNING created_at`
+	findNamespacesQuery        = `SELECT name, created_at FROM namespaces ORDER BY namespaces.name`
+	deleteNamespaceQuery       = `DELETE FROM namespaces WHERE name = $1`
+	archiveDeletedURLsQuery    = `WITH archived AS (DELETE FROM urls WHERE is_deleted AND deleted_at < $1 RETURNING id, uuid, alias, original_url, normalized_url, user_id, is_one_time_use, created_at, visibility, redirect_type, created_in_region, updated_at, expires_at, namespace, deleted_at, is_tracked) INSERT INTO archived_urls (id, uuid, alias, original_url, normalized_url, user_id, is_one_time_use, created_at, visibility, redirect_type, created_in_region, updated_at, expires_at, namespace, deleted_at, is_tracked) SELECT id, uuid, alias, original_url, normalized_url, user_id, COALESCE(is_one_time_use, false), created_at, COALESCE(visibility, 'public'), redirect_type, created_in_region, updated_at, expires_at, namespace, deleted_at, is_tracked FROM archived`
+	findURLsToCheckQuery       = `SELECT id, alias, original_url, namespace FROM urls WHERE urls.id > $1 AND NOT urls.is_deleted AND (urls.expires_at IS NULL OR urls.expires_at > NOW()) AND (urls.checked_at IS NULL OR urls.checked_at < $2) ORDER BY urls.id LIMIT $3`
+	saveURLHealthQuery         = `UPDATE urls SET is_healthy = $1, checked_at = $2 WHERE namespace = $3 AND alias = $4`
+	findArchivedURLsQuery      = `SELECT alias, original_url, namespace, COALESCE(created_in_region, ''), archived_at FROM archived_urls WHERE archived_urls.user_id = $1 ORDER BY archived_urls.archived_at DESC, archived_urls.id DESC LIMIT $2 OFFSET $3`
+	restoreArchivedURLQuery    = `WITH restored AS (DELETE FROM archived_urls WHERE id = (SELECT id FROM archived_urls WHERE archived_urls.user_id = $1 AND archived_urls.namespace = $2 AND archived_urls.alias = $3 ORDER BY archived_urls.archived_at DESC, archived_urls.id DESC LIMIT 1) RETURNING id, uuid, alias, original_url, normalized_url, user_id, is_one_time_use, created_at, visibility, redirect_type, created_in_region, updated_at, expires_at, namespace, is_tracked) INSERT INTO urls (id, uuid, alias, original_url, normalized_url, user_id, is_deleted, is_one_time_use, created_at, visibility, redirect_type, created_in_region, updated_at, expires_at, namespace, is_tracked) SELECT id, uuid, alias, original_url, CASE WHEN EXISTS (SELECT 1 FROM urls AS other WHERE other.normalized_url = restored.normalized_url AND other.namespace = restored.namespace AND NOT other.is_one_time_use AND other.visibility = 'public' AND other.redirect_type = 307 AND NOT other.is_tracked) THEN NULL ELSE normalized_url END, user_id, false, is_one_time_use, created_at, visibility, redirect_type, created_in_region, updated_at, expires_at, namespace, is_tracked FROM restored`
 )
 
 // PGDBPool defines the interface for PostgreSQL database operations.
@@ -58,20 +130,45 @@ type PGDBPool interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	// QueryRow executes a SQL query expected to return at most one row
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	// Begin starts a transaction
+	Begin(ctx context.Context) (pgx.Tx, error)
 	// Ping checks if the database is available
 	Ping(ctx context.Context) error
 	Close()
-	Stat() *pgxpool.Stat
+	// PoolStats returns statistics of pool connections
+	PoolStats() *healthEntity.DBPoolStats
+}
+
+// pgxPool adapts pgxpool.Pool to PGDBPool interface.
+type pgxPool struct {
+	*pgxpool.Pool
+}
+
+// PoolStats returns statistics of pool connections.
+// Returns:
+// - *healthEntity.DBPoolStats: Pool size, open, idle and acquired connections, number of waited acquires
+func (p *pgxPool) PoolStats() *healthEntity.DBPoolStats {
+	stat := p.Stat()
+	return &healthEntity.DBPoolStats{
+		MaxConnections:      stat.MaxConns(),
+		OpenConnections:     stat.TotalConns(),
+		IdleConnections:     stat.IdleConns(),
+		AcquiredConnections: stat.AcquiredConns(),
+		WaitCount:           stat.EmptyAcquireCount(),
+	}
 }
 
 // PGDB implements the database interface using PostgreSQL as the backend.
 type PGDB struct {
-	pool    PGDBPool // Connection pool for database operations
-	closing chan struct{}
+	pool     PGDBPool // Connection pool of the primary, used for writes and reads without replica
+	readPool PGDBPool // Connection pool of the read replica, nil if it is disabled
+	closing  chan struct{}
 }
 
 // New creates and initializes a new PGDB instance.
 // It establishes a connection pool and runs database migrations.
+// If the read replica is enabled, a second pool is created for it, migrations are
+// applied to the primary only.
 // Parameters:
 // - ctx: Context for cancellation/timeouts
 // - cfg: Database configuration
@@ -84,18 +181,13 @@ func New(ctx context.Context, cfg *config.Config) (*PGDB, error) {
 		pool *pgxpool.Pool
 	)
 
-	goose.SetBaseFS(migrations)
-	if err = goose.SetDialect("postgres"); err != nil {
-		return nil, err
-	}
-
 	pool, err = newDBPool(ctx, cfg.Database)
 	if err != nil {
 		return nil, err
 	}
 
 	dbFromPool := stdlib.OpenDBFromPool(pool)
-	if err = goose.Up(dbFromPool, "migrations"); err != nil {
+	if _, err = RunMigrations(ctx, dbFromPool); err != nil {
 		return nil, err
 	}
 
@@ -103,13 +195,26 @@ func New(ctx context.Context, cfg *config.Config) (*PGDB, error) {
 		return nil, err
 	}
 
-	return &PGDB{
-		pool:    pool,
+	db := &PGDB{
+		pool:    newTimeoutPool(&pgxPool{Pool: pool}, cfg.Database.QueryTimeout),
 		closing: make(chan struct{}),
-	}, nil
+	}
+
+	if cfg.Database.ReadReplicaEnabled {
+		readPool, replicaErr := newReplicaPool(ctx, cfg.Database)
+		if replicaErr != nil {
+			pool.Close()
+			return nil, replicaErr
+		}
+		db.readPool = newTimeoutPool(&pgxPool{Pool: readPool}, cfg.Database.QueryTimeout)
+	}
+
+	return db, nil
 }
 
 // newDBPool creates a new PostgreSQL connection pool with retry logic.
+// Pool size and connection lifetimes are taken from cfg, zero values keep pgxpool defaults.
+// Delay between attempts starts from cfg.ConnTryDelay and doubles up to connRetryMaxDelay.
 // Parameters:
 // - ctx: Context for cancellation/timeouts
 // - cfg: Database configuration
@@ -118,16 +223,20 @@ func New(ctx context.Context, cfg *config.Config) (*PGDB, error) {
 // - error: If connection fails after retries
 func newDBPool(ctx context.Context, cfg config.Database) (*pgxpool.Pool, error) {
 	var (
-		pool   *pgxpool.Pool
-		err    error
-		cancel context.CancelFunc
+		pool *pgxpool.Pool
+		err  error
 	)
 
-	err = utils.Retry(func() error {
-		ctx, cancel = context.WithTimeout(ctx, cfg.ConnTryDelay)
+	poolCfg, err := newDBPoolConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	err = utils.ExponentialBackoff(func() error {
+		attemptCtx, cancel := context.WithTimeout(ctx, cfg.ConnTryDelay)
 		defer cancel()
 
-		pool, err = pgxpool.New(ctx, cfg.DSN)
+		pool, err = pgxpool.NewWithConfig(attemptCtx, poolCfg)
 
 		if err != nil {
 			logger.Log.Error(err.Error())
@@ -136,11 +245,42 @@ func newDBPool(ctx context.Context, cfg config.Database) (*pgxpool.Pool, error)
 
 		return nil
 
-	}, cfg.ConnTryTimes, cfg.ConnTryDelay)
+	}, cfg.ConnTryTimes, cfg.ConnTryDelay, connRetryMaxDelay, connRetryJitter, utils.WithContext(ctx))
 
 	return pool, err
 }
 
+// newDBPoolConfig builds connection pool configuration from DSN and pool settings.
+// Parameters:
+// - cfg: Database configuration
+// Returns:
+// - *pgxpool.Config: Pool configuration
+// - error: If DSN cannot be parsed
+func newDBPoolConfig(cfg config.Database) (*pgxpool.Config, error) {
+	poolCfg, err := pgxpool.ParseConfig(cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxConns > 0 {
+		poolCfg.MaxConns = cfg.MaxConns
+	}
+	if cfg.MinConns > 0 {
+		poolCfg.MinConns = min(cfg.MinConns, poolCfg.MaxConns)
+	}
+	if cfg.MaxConnLifetime > 0 {
+		poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	}
+	if cfg.MaxConnIdleTime > 0 {
+		poolCfg.MaxConnIdleTime = cfg.MaxConnIdleTime
+	}
+	if cfg.HealthCheckPeriod > 0 {
+		poolCfg.HealthCheckPeriod = cfg.HealthCheckPeriod
+	}
+
+	return poolCfg, nil
+}
+
 // FindUser retrieves a user by ID from the database.
 // Parameters:
 // - ctx: Context for cancellation/timeouts
@@ -149,8 +289,12 @@ func newDBPool(ctx context.Context, cfg config.Database) (*pgxpool.Pool, error)
 // - *userEntity.User: Found user
 // - error: dbErrors.ErrDBRecordNotFound if user doesn't exist
 func (db *PGDB) FindUser(ctx context.Context, id int) (*userEntity.User, error) {
+	var createdAt *time.Time
+
 	user := userEntity.User{ID: id}
-	err := db.pool.QueryRow(ctx, findUserQuery, id).Scan(&user.ID)
+	err := db.read(ctx, func(pool PGDBPool) error {
+		return pool.QueryRow(ctx, findUserQuery, id).Scan(&user.ID, &user.IsActive, &createdAt, &user.CustomDomain)
+	})
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -161,6 +305,10 @@ func (db *PGDB) FindUser(ctx context.Context, id int) (*userEntity.User, error)
 		}
 	}
 
+	if createdAt != nil {
+		user.CreatedAt = *createdAt
+	}
+
 	return &user, nil
 }
 
@@ -175,26 +323,242 @@ func (db *PGDB) FindUserURLs(ctx context.Context, userID int) ([]*shortURLEntity
 	var (
 		alias       string
 		originalURL string
+		region      string
+		namespace   string
 		urls        []*shortURLEntity.ShortURL
 	)
 
-	rows, err := db.pool.Query(ctx, findUserURLsQuery, userID)
+	err := db.read(ctx, func(pool PGDBPool) error {
+		urls = nil
+
+		rows, err := pool.Query(ctx, findUserURLsQuery, userID)
+		if err != nil {
+			return err
+		}
+
+		_, err = pgx.ForEachRow(rows, []any{&alias, &originalURL, &region, &namespace}, func() error {
+			urls = append(urls, &shortURLEntity.ShortURL{Alias: alias, SourceURL: originalURL, CreatedInRegion: region, Namespace: namespace})
+			return nil
+		})
+		return err
+	})
+
 	if err != nil {
 		logger.Log.Error(err.Error())
 		return nil, dbErrors.ErrDBQuery
 	}
 
-	_, err = pgx.ForEachRow(rows, []any{&alias, &originalURL}, func() error {
-		urls = append(urls, &shortURLEntity.ShortURL{Alias: alias, SourceURL: originalURL})
+	return urls, nil
+}
+
+// CountUserURLs returns the number of not deleted short URLs of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID, 0 for URLs created anonymously
+// Returns:
+// - int64: Number of user's URLs
+// - error: If query fails
+func (db *PGDB) CountUserURLs(ctx context.Context, userID int) (int64, error) {
+	var count int64
+
+	if err := db.pool.QueryRow(ctx, countActiveUserURLsQuery, userID).Scan(&count); err != nil {
+		logger.Log.Error(err.Error())
+		return 0, dbErrors.ErrDBQuery
+	}
+
+	return count, nil
+}
+
+// FindUserURLsPaginated retrieves a page of short URLs belonging to a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - offset: Number of URLs to skip
+// - limit: Maximum number of URLs to return
+// Returns:
+// - []*shortURLEntity.ShortURL: Requested page of user's URLs ordered by alias
+// - int64: Total number of user's URLs
+// - error: If query fails
+func (db *PGDB) FindUserURLsPaginated(ctx context.Context, userID, offset, limit int) ([]*shortURLEntity.ShortURL, int64, error) {
+	var (
+		alias       string
+		originalURL string
+		region      string
+		namespace   string
+		total       int64
+		urls        []*shortURLEntity.ShortURL
+	)
+
+	if err := db.pool.QueryRow(ctx, countUserURLsQuery, userID).Scan(&total); err != nil {
+		logger.Log.Error(err.Error())
+		return nil, 0, dbErrors.ErrDBQuery
+	}
+
+	rows, err := db.pool.Query(ctx, findUserURLsPaginatedQuery, userID, limit, offset)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, 0, dbErrors.ErrDBQuery
+	}
+
+	_, err = pgx.ForEachRow(rows, []any{&alias, &originalURL, &region, &namespace}, func() error {
+		urls = append(urls, &shortURLEntity.ShortURL{Alias: alias, SourceURL: originalURL, CreatedInRegion: region, Namespace: namespace})
 		return nil
 	})
 
 	if err != nil {
 		logger.Log.Error(err.Error())
-		return nil, dbErrors.ErrDBQuery
+		return nil, 0, dbErrors.ErrDBQuery
 	}
 
-	return urls, nil
+	return urls, total, nil
+}
+
+// SearchUserURLs retrieves a page of short URLs of a user whose original URL contains the query.
+// The query is matched case-insensitively, LIKE wildcards in it are matched literally.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - query: Substring of original URLs to find
+// - offset: Number of URLs to skip
+// - limit: Maximum number of URLs to return
+// Returns:
+// - []*shortURLEntity.ShortURL: Requested page of found URLs ordered by alias
+// - int64: Total number of found URLs
+// - error: If query fails
+func (db *PGDB) SearchUserURLs(ctx context.Context, userID int, query string, offset, limit int) ([]*shortURLEntity.ShortURL, int64, error) {
+	var (
+		alias       string
+		originalURL string
+		region      string
+		namespace   string
+		total       int64
+		urls        []*shortURLEntity.ShortURL
+	)
+
+	pattern := "%" + escapeLike(query) + "%"
+
+	if err := db.pool.QueryRow(ctx, countSearchUserURLsQuery, userID, pattern).Scan(&total); err != nil {
+		logger.Log.Error(err.Error())
+		return nil, 0, dbErrors.ErrDBQuery
+	}
+
+	rows, err := db.pool.Query(ctx, searchUserURLsQuery, userID, pattern, limit, offset)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, 0, dbErrors.ErrDBQuery
+	}
+
+	_, err = pgx.ForEachRow(rows, []any{&alias, &originalURL, &region, &namespace}, func() error {
+		urls = append(urls, &shortURLEntity.ShortURL{Alias: alias, SourceURL: originalURL, CreatedInRegion: region, Namespace: namespace})
+		return nil
+	})
+
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, 0, dbErrors.ErrDBQuery
+	}
+
+	return urls, total, nil
+}
+
+// escapeLike escapes LIKE wildcards and the escape character, so the string is matched literally.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// FindUserURLsCursor retrieves short URLs belonging to a user created after the cursor.
+// One extra row is requested to find out whether there is a next page.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - afterID: ID of the last URL on the previous page, 0 for the first page
+// - limit: Maximum number of URLs to return
+// Returns:
+// - []*shortURLEntity.ShortURL: User's URLs with ID greater than afterID ordered by ID
+// - int: ID of the last returned URL, 0 if there are no more URLs
+// - error: If query fails
+func (db *PGDB) FindUserURLsCursor(ctx context.Context, userID, afterID, limit int) ([]*shortURLEntity.ShortURL, int, error) {
+	var (
+		id          int
+		alias       string
+		originalURL string
+		region      string
+		namespace   string
+		isDeleted   bool
+		createdAt   time.Time
+		urls        []*shortURLEntity.ShortURL
+	)
+
+	rows, err := db.pool.Query(ctx, findUserURLsCursorQuery, userID, afterID, limit+1)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, 0, dbErrors.ErrDBQuery
+	}
+
+	_, err = pgx.ForEachRow(rows, []any{&id, &alias, &originalURL, &isDeleted, &createdAt, &region, &namespace}, func() error {
+		urls = append(urls, &shortURLEntity.ShortURL{ID: id, Alias: alias, SourceURL: originalURL, IsDeleted: isDeleted, CreatedAt: createdAt, CreatedInRegion: region, Namespace: namespace})
+		return nil
+	})
+
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, 0, dbErrors.ErrDBQuery
+	}
+
+	if len(urls) <= limit {
+		return urls, 0, nil
+	}
+
+	urls = urls[:limit]
+
+	return urls, urls[limit-1].ID, nil
+}
+
+// SetUserActive activates or deactivates a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: User ID
+// - isActive: Whether the user may authenticate
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if user doesn't exist, or query error
+func (db *PGDB) SetUserActive(ctx context.Context, userID int, isActive bool) error {
+	tag, err := db.pool.Exec(ctx, setUserActiveQuery, isActive, userID)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	if tag.RowsAffected() == 0 {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	return nil
+}
+
+// SetUserCustomDomain sets the custom domain of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: User ID
+// - customDomain: Base URL of short URLs of the user, empty to remove it
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if user doesn't exist,
+// dbErrors.ErrDBIsNotUnique if the domain belongs to another user, or query error
+func (db *PGDB) SetUserCustomDomain(ctx context.Context, userID int, customDomain string) error {
+	tag, err := db.pool.Exec(ctx, setUserCustomDomainQuery, customDomain, userID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return dbErrors.ErrDBIsNotUnique
+		}
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	if tag.RowsAffected() == 0 {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	return nil
 }
 
 // SaveUser creates a new user in the database.
@@ -205,7 +569,7 @@ func (db *PGDB) FindUserURLs(ctx context.Context, userID int) ([]*shortURLEntity
 // - error: If insert fails
 func (db *PGDB) SaveUser(ctx context.Context) (*userEntity.User, error) {
 	user := userEntity.User{}
-	err := db.pool.QueryRow(ctx, saveUserQuery).Scan(&user.ID)
+	err := db.pool.QueryRow(ctx, saveUserQuery).Scan(&user.ID, &user.IsActive, &user.CreatedAt)
 	if err != nil {
 		logger.Log.Error(err.Error())
 		return nil, dbErrors.ErrDBQuery
@@ -214,129 +578,1207 @@ func (db *PGDB) SaveUser(ctx context.Context) (*userEntity.User, error) {
 	return &user, nil
 }
 
-// FindShortURL retrieves a short URL by its alias.
+// FindShortURL retrieves a short URL by its namespace and alias.
 // Parameters:
 // - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the URL, empty for the default one
 // - alias: Short URL identifier
 // Returns:
 // - *shortURLEntity.ShortURL: Found short URL
 // - error: If URL doesn't exist or query fails
-func (db *PGDB) FindShortURL(ctx context.Context, alias string) (*shortURLEntity.ShortURL, error) {
-	shortURL := shortURLEntity.ShortURL{Alias: alias}
-	err := db.pool.QueryRow(ctx, findShortURLQuery, alias).Scan(&shortURL.SourceURL, &shortURL.UUID, &shortURL.IsDeleted)
+func (db *PGDB) FindShortURL(ctx context.Context, namespace, alias string) (*shortURLEntity.ShortURL, error) {
+	var expiresAt, checkedAt *time.Time
+
+	namespace = namespaceEntity.OrDefault(namespace)
+	shortURL := shortURLEntity.ShortURL{Alias: alias, Namespace: namespace}
+	err := db.read(ctx, func(pool PGDBPool) error {
+		return pool.QueryRow(ctx, findShortURLQuery, namespace, alias).Scan(&shortURL.SourceURL, &shortURL.UUID, &shortURL.IsDeleted, &shortURL.IsOneTimeUse, &shortURL.Visibility, &shortURL.RedirectType, &shortURL.UserID, &shortURL.CreatedAt, &shortURL.CreatedInRegion, &expiresAt, &shortURL.IsTracked, &shortURL.IsHealthy, &checkedAt)
+	})
 
 	if err != nil {
 		logger.Log.Error(err.Error())
 		return nil, dbErrors.ErrDBRecordNotFound
 	}
 
+	if expiresAt != nil {
+		shortURL.ExpiresAt = *expiresAt
+	}
+	if checkedAt != nil {
+		shortURL.CheckedAt = *checkedAt
+	}
+
+	return &shortURL, nil
+}
+
+// FindShortURLBySourceURL retrieves the deduplicated short URL of a namespace by its normalized URL,
+// the one an upsert of the same normalized URL would conflict with.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the URL, empty for the default one
+// - sourceURL: Deduplication key of original long URL, see ShortURL.DeduplicationKey
+// Returns:
+// - *shortURLEntity.ShortURL: Found short URL
+// - error: dbErrors.ErrDBRecordNotFound if URL doesn't exist, dbErrors.ErrDBQuery if query fails
+func (db *PGDB) FindShortURLBySourceURL(ctx context.Context, namespace, sourceURL string) (*shortURLEntity.ShortURL, error) {
+	namespace = namespaceEntity.OrDefault(namespace)
+	shortURL := shortURLEntity.ShortURL{NormalizedURL: sourceURL, Namespace: namespace}
+
+	err := db.pool.QueryRow(ctx, findShortURLBySourceQuery, namespace, sourceURL).Scan(&shortURL.Alias, &shortURL.SourceURL, &shortURL.UUID, &shortURL.IsDeleted)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, dbErrors.ErrDBRecordNotFound
+		}
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
 	return &shortURL, nil
 }
 
+// expiresAt returns expiration time of a short URL as a nullable query argument.
+// Parameters:
+// - shortURL: Short URL to save
+// Returns:
+// - *time.Time: Expiration time, nil if the short URL never expires
+func expiresAt(shortURL *shortURLEntity.ShortURL) *time.Time {
+	if shortURL.ExpiresAt.IsZero() {
+		return nil
+	}
+	return &shortURL.ExpiresAt
+}
+
 // SaveShortURL stores a new short URL in the database.
+// Existence check and insert are done by a single upsert, so concurrent saves
+// of the same URL get the same alias.
 // Parameters:
 // - ctx: Context for cancellation/timeouts
 // - shortURL: URL to save
 // Returns:
-// - *shortURLEntity.ShortURL: Saved URL
-// - error: If URL already exists or insert fails
+// - *shortURLEntity.ShortURL: Saved URL, or the existing one if URL is already shortened
+// - error: dbErrors.ErrDBIsNotUnique if URL already exists, dbErrors.ErrDBAliasTaken if the alias
+// is used by another URL, dbErrors.ErrDBReferenceNotFound if namespace doesn't exist,
+// other error if insert fails
+//
+// One-time, private, permanent and tracked URLs are never deduplicated, each of them gets its own alias.
 func (db *PGDB) SaveShortURL(ctx context.Context, shortURL *shortURLEntity.ShortURL) (*shortURLEntity.ShortURL, error) {
-	var (
-		err              error
-		pgErr            *pgconn.PgError
-		existingShortURL *shortURLEntity.ShortURL
-	)
-
-	if existingShortURL, err = db.findShortURLBySourceURL(ctx, shortURL.SourceURL); err == nil {
-		return existingShortURL, dbErrors.ErrDBIsNotUnique
-	}
+	var pgErr *pgconn.PgError
 
-	if errors.Is(err, dbErrors.ErrDBRecordNotFound) {
-		if shortURL.UserID == 0 {
-			if _, err = db.pool.Exec(ctx, saveShortURLQuery, shortURL.Alias, shortURL.SourceURL); err == nil {
-				return shortURL, nil
-			}
-		} else {
-			if _, err = db.pool.Exec(ctx, saveShortURLQueryWithUser, shortURL.Alias, shortURL.SourceURL, shortURL.UserID); err == nil {
-				return shortURL, nil
-			}
+	existing, inserted, err := upsertShortURL(ctx, db.pool, shortURL)
+	if err == nil {
+		if inserted {
+			return shortURL, nil
 		}
+		return existing, dbErrors.ErrDBIsNotUnique
+	}
 
-		if errors.As(err, &pgErr) {
-			if pgErr.Code == pgerrcode.UniqueViolation {
-				return shortURL, dbErrors.ErrDBIsNotUnique
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.UniqueViolation:
+			if pgErr.ConstraintName == aliasIndexName {
+				return nil, dbErrors.ErrDBAliasTaken
 			}
-			logger.Log.Error(err.Error())
-			return nil, dbErrors.ErrDBQuery
+			return shortURL, dbErrors.ErrDBIsNotUnique
+		case pgerrcode.ForeignKeyViolation:
+			return nil, dbErrors.ErrDBReferenceNotFound
 		}
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
 	}
 
 	return nil, err
 }
 
-// MarkURLAsDeleted marks the specified URLs as deleted for a user.
+// rowQuerier is implemented by both connection pool and transaction.
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// upsertShortURL inserts a short URL unless a deduplicated one with the same normalized URL exists.
 // Parameters:
 // - ctx: Context for cancellation/timeouts
-// - userID: Owner's user ID
-// - aliases: URLs to mark as deleted
+// - q: Connection pool or transaction
+// - shortURL: URL to save
 // Returns:
-// - error: If update fails
-func (db *PGDB) MarkURLAsDeleted(ctx context.Context, userID int, aliases []string) error {
-	_, err := db.pool.Exec(ctx, markURLsAsDeletedQuery, userID, aliases)
-	return err
+// - *shortURLEntity.ShortURL: Existing URL if it was not inserted
+// - bool: true if URL was inserted
+// - error: If query fails
+func upsertShortURL(ctx context.Context, q rowQuerier, shortURL *shortURLEntity.ShortURL) (*shortURLEntity.ShortURL, bool, error) {
+	var (
+		row      pgx.Row
+		inserted bool
+	)
+
+	shortURL.Namespace = namespaceEntity.OrDefault(shortURL.Namespace)
+	if shortURL.UserID == 0 {
+		row = q.QueryRow(ctx, saveShortURLQuery, shortURL.Alias, shortURL.SourceURL, shortURL.DeduplicationKey(), shortURL.IsOneTimeUse, shortURL.Visibility, shortURL.RedirectStatus(), shortURL.CreatedInRegion, expiresAt(shortURL), shortURL.Namespace, shortURL.IsTracked)
+	} else {
+		row = q.QueryRow(ctx, saveShortURLQueryWithUser, shortURL.Alias, shortURL.SourceURL, shortURL.DeduplicationKey(), shortURL.IsOneTimeUse, shortURL.Visibility, shortURL.RedirectStatus(), shortURL.CreatedInRegion, expiresAt(shortURL), shortURL.Namespace, shortURL.IsTracked, shortURL.UserID)
+	}
+
+	existing := &shortURLEntity.ShortURL{NormalizedURL: shortURL.DeduplicationKey(), Namespace: shortURL.Namespace}
+	if err := row.Scan(&existing.Alias, &existing.SourceURL, &existing.UUID, &existing.IsDeleted, &inserted); err != nil {
+		return nil, false, err
+	}
+
+	if inserted {
+		return nil, true, nil
+	}
+	return existing, false, nil
 }
 
-// findShortURLBySourceURL looks up a short URL by its original URL.
+// BatchSaveShortURLs stores several short URLs in a single transaction.
+// URLs which already exist are skipped. If any insert fails, the whole
+// transaction is rolled back.
 // Parameters:
 // - ctx: Context for cancellation/timeouts
-// - sourceURL: Original long URL
+// - shortURLs: URLs to save
 // Returns:
-// - *shortURLEntity.ShortURL: Found short URL
-// - error: If URL doesn't exist or query fails
-func (db *PGDB) findShortURLBySourceURL(ctx context.Context, sourceURL string) (*shortURLEntity.ShortURL, error) {
-	shortURL := shortURLEntity.ShortURL{SourceURL: sourceURL}
-	err := db.pool.QueryRow(ctx, findShortURLBySourceURLQuery, sourceURL).Scan(&shortURL.Alias)
+// - []*shortURLEntity.ShortURL: Saved URLs in input order; on failure, URLs inserted before it
+// - error: If any query fails
+func (db *PGDB) BatchSaveShortURLs(ctx context.Context, shortURLs []*shortURLEntity.ShortURL) ([]*shortURLEntity.ShortURL, error) {
+	var (
+		err      error
+		tx       pgx.Tx
+		inserted bool
+		saved    = make([]*shortURLEntity.ShortURL, 0, len(shortURLs))
+	)
 
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, dbErrors.ErrDBRecordNotFound
+	if tx, err = db.pool.Begin(ctx); err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	defer func() {
+		if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			logger.Log.Error(rbErr.Error())
+		}
+	}()
+
+	for _, shortURL := range shortURLs {
+		if _, inserted, err = upsertShortURL(ctx, tx, shortURL); err != nil {
+			logger.Log.Error(err.Error())
+			return saved, dbErrors.ErrDBQuery
 		}
 
+		if inserted {
+			saved = append(saved, shortURL)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
 		logger.Log.Error(err.Error())
-		return nil, dbErrors.ErrDBQuery
+		return saved, dbErrors.ErrDBQuery
 	}
 
-	return &shortURL, nil
+	return saved, nil
 }
 
-// Ping checks if the database is available.
+// MarkURLAsDeleted marks the specified URLs as deleted for a user.
+// If userID is 0, URLs are deleted regardless of owner: rows are locked with
+// SELECT ... FOR UPDATE, so only one of concurrent callers deletes them.
 // Parameters:
 // - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID, or 0 for any owner
+// - namespace: Namespace of the short URLs, empty for the default one
+// - aliases: URLs to mark as deleted
 // Returns:
-// - error: If database is unreachable
-func (db *PGDB) Ping(ctx context.Context) error {
-	return db.pool.Ping(ctx)
+// - error: dbErrors.ErrDBRecordIsDeleted if userID is 0 and all URLs are already deleted,
+// other error if update fails
+func (db *PGDB) MarkURLAsDeleted(ctx context.Context, userID int, namespace string, aliases []string) error {
+	namespace = namespaceEntity.OrDefault(namespace)
+	if userID == 0 {
+		return db.markAnyURLsAsDeleted(ctx, namespace, aliases)
+	}
+
+	_, err := db.pool.Exec(ctx, markURLsAsDeletedQuery, userID, namespace, aliases)
+	return err
 }
 
-// Shutdown gracefully closes the database connection pool.
-// It waits for all connections to finish their work before closing.
+// RestoreURL clears the deletion mark of a short URL of a user.
 // Parameters:
 // - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
 // Returns:
-// - error: If shutdown fails or context expires
-func (db *PGDB) Shutdown(ctx context.Context) error {
-	if pool, ok := db.pool.(*pgxpool.Pool); ok {
-		logger.Log.Info("Closing database connection pool...")
-		pool.Close()
+// - error: dbErrors.ErrDBRecordNotFound if the user has no URL with the alias,
+// dbErrors.ErrDBQuery if query fails
+func (db *PGDB) RestoreURL(ctx context.Context, userID int, namespace, alias string) error {
+	tag, err := db.pool.Exec(ctx, restoreURLQuery, namespaceEntity.OrDefault(namespace), alias, userID)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	if tag.RowsAffected() == 0 {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	return nil
+}
+
+// UpdateURLTarget replaces the original URL of a short URL of a user in a single transaction.
+// The row is locked with SELECT ... FOR UPDATE and its original URL is saved to
+// url_history before the update. The deduplication key is set to the new URL,
+// so the short URL is not reused for the previous target.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// - newURL: New original URL
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if the user has no URL with the alias,
+// dbErrors.ErrDBQuery if any query fails
+func (db *PGDB) UpdateURLTarget(ctx context.Context, userID int, namespace, alias, newURL string) error {
+	var (
+		err         error
+		tx          pgx.Tx
+		originalURL string
+	)
+
+	namespace = namespaceEntity.OrDefault(namespace)
+
+	if tx, err = db.pool.Begin(ctx); err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	defer func() {
+		if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			logger.Log.Error(rbErr.Error())
+		}
+	}()
+
+	if err = tx.QueryRow(ctx, lockUserURLTargetQuery, namespace, alias, userID).Scan(&originalURL); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dbErrors.ErrDBRecordNotFound
+		}
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	if _, err = tx.Exec(ctx, saveURLHistoryQuery, namespace, alias, originalURL); err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	if _, err = tx.Exec(ctx, updateURLTargetQuery, newURL, namespace, alias, userID); err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	return nil
+}
+
+// FindURLHistory retrieves previous original URLs of a short URL.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// Returns:
+// - []*shortURLEntity.HistoryEntry: Previous original URLs, the most recent first
+// - error: If query fails
+func (db *PGDB) FindURLHistory(ctx context.Context, namespace, alias string) ([]*shortURLEntity.HistoryEntry, error) {
+	var (
+		originalURL string
+		changedAt   time.Time
+		history     []*shortURLEntity.HistoryEntry
+	)
+
+	rows, err := db.pool.Query(ctx, findURLHistoryQuery, namespaceEntity.OrDefault(namespace), alias)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	_, err = pgx.ForEachRow(rows, []any{&originalURL, &changedAt}, func() error {
+		history = append(history, &shortURLEntity.HistoryEntry{OriginalURL: originalURL, ChangedAt: changedAt})
+		return nil
+	})
+
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	return history, nil
+}
+
+// markAnyURLsAsDeleted marks the specified URLs as deleted regardless of owner
+// in a single transaction.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the short URLs
+// - aliases: URLs to mark as deleted
+// Returns:
+// - error: dbErrors.ErrDBRecordIsDeleted if all URLs are already deleted,
+// dbErrors.ErrDBQuery if any query fails
+func (db *PGDB) markAnyURLsAsDeleted(ctx context.Context, namespace string, aliases []string) error {
+	var (
+		err    error
+		tx     pgx.Tx
+		rows   pgx.Rows
+		alias  string
+		locked []string
+	)
+
+	if tx, err = db.pool.Begin(ctx); err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	defer func() {
+		if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			logger.Log.Error(rbErr.Error())
+		}
+	}()
+
+	if rows, err = tx.Query(ctx, lockNotDeletedURLsQuery, namespace, aliases); err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	_, err = pgx.ForEachRow(rows, []any{&alias}, func() error {
+		locked = append(locked, alias)
+		return nil
+	})
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	if len(locked) == 0 {
+		return dbErrors.ErrDBRecordIsDeleted
+	}
+
+	if _, err = tx.Exec(ctx, markAnyURLsAsDeletedQuery, namespace, locked); err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	return nil
+}
+
+// DeleteExpiredURLs removes short URLs whose expiration time has passed.
+// Tag assignments of removed URLs are removed by cascade.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - int64: Number of removed short URLs
+// - error: If query fails
+func (db *PGDB) DeleteExpiredURLs(ctx context.Context) (int64, error) {
+	var alias string
+
+	rows, err := db.pool.Query(ctx, deleteExpiredURLsQuery)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return 0, dbErrors.ErrDBQuery
+	}
+
+	tag, err := pgx.ForEachRow(rows, []any{&alias}, func() error { return nil })
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return 0, dbErrors.ErrDBQuery
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// ArchiveDeletedURLs moves short URLs deleted more than 30 days ago to archived_urls
+// in a single statement. Tag assignments of archived URLs are removed by cascade.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - int64: Number of archived short URLs
+// - error: If query fails
+func (db *PGDB) ArchiveDeletedURLs(ctx context.Context) (int64, error) {
+	tag, err := db.pool.Exec(ctx, archiveDeletedURLsQuery, time.Now().Add(-archiveDeletedURLsAfter))
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return 0, dbErrors.ErrDBQuery
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// FindURLsToCheck retrieves not deleted and not expired short URLs whose source URLs
+// were not checked since the given time.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - checkedBefore: URLs checked at or after this time are skipped
+// - afterID: ID of the last URL of the previous batch, 0 for the first batch
+// - limit: Maximum number of URLs to return
+// Returns:
+// - []*shortURLEntity.ShortURL: URLs with ID, alias, source URL and namespace, ordered by ID
+// - error: If query fails
+func (db *PGDB) FindURLsToCheck(ctx context.Context, checkedBefore time.Time, afterID, limit int) ([]*shortURLEntity.ShortURL, error) {
+	var url shortURLEntity.ShortURL
+
+	rows, err := db.pool.Query(ctx, findURLsToCheckQuery, afterID, checkedBefore, limit)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	urls := make([]*shortURLEntity.ShortURL, 0, limit)
+	_, err = pgx.ForEachRow(rows, []any{&url.ID, &url.Alias, &url.SourceURL, &url.Namespace}, func() error {
+		found := url
+		urls = append(urls, &found)
+		return nil
+	})
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	return urls, nil
+}
+
+// SaveURLHealth stores result of health check of a short URL's source URL.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// - healthy: Whether the source URL was reachable
+// - checkedAt: Time of the check
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if short URL doesn't exist, dbErrors.ErrDBQuery if query fails
+func (db *PGDB) SaveURLHealth(ctx context.Context, namespace, alias string, healthy bool, checkedAt time.Time) error {
+	tag, err := db.pool.Exec(ctx, saveURLHealthQuery, healthy, checkedAt, namespaceEntity.OrDefault(namespace), alias)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	if tag.RowsAffected() == 0 {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	return nil
+}
+
+// FindArchivedURLs retrieves a page of archived short URLs of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - limit: Maximum number of URLs to return
+// - offset: Number of URLs to skip
+// Returns:
+// - []*shortURLEntity.ArchivedURL: Archived URLs, the most recently archived first
+// - error: If query fails
+func (db *PGDB) FindArchivedURLs(ctx context.Context, userID, limit, offset int) ([]*shortURLEntity.ArchivedURL, error) {
+	var (
+		alias       string
+		originalURL string
+		namespace   string
+		region      string
+		archivedAt  time.Time
+		archived    []*shortURLEntity.ArchivedURL
+	)
+
+	rows, err := db.pool.Query(ctx, findArchivedURLsQuery, userID, limit, offset)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	_, err = pgx.ForEachRow(rows, []any{&alias, &originalURL, &namespace, &region, &archivedAt}, func() error {
+		archived = append(archived, &shortURLEntity.ArchivedURL{
+			ShortURL: &shortURLEntity.ShortURL{
+				Alias:           alias,
+				SourceURL:       originalURL,
+				UserID:          userID,
+				IsDeleted:       true,
+				CreatedInRegion: region,
+				Namespace:       namespace,
+			},
+			ArchivedAt: archivedAt,
+		})
+		return nil
+	})
+
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	return archived, nil
+}
+
+// RestoreArchivedURL moves the most recently archived short URL of a user with the alias
+// back to urls as not deleted, in a single statement.
+// The deduplication key is cleared if another URL was created for the same target meanwhile.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - namespace: Namespace of the URL, empty for the default one
+// - alias: Short URL identifier
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if the user has no archived URL with the alias,
+// dbErrors.ErrDBIsNotUnique if the alias was taken by another URL after archiving,
+// dbErrors.ErrDBQuery if query fails
+func (db *PGDB) RestoreArchivedURL(ctx context.Context, userID int, namespace, alias string) error {
+	var pgErr *pgconn.PgError
+
+	tag, err := db.pool.Exec(ctx, restoreArchivedURLQuery, userID, namespaceEntity.OrDefault(namespace), alias)
+	if err != nil {
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return dbErrors.ErrDBIsNotUnique
+		}
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	if tag.RowsAffected() == 0 {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	return nil
+}
+
+// CountURLs returns the number of all short URLs, including deleted ones.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - int64: Number of short URLs
+// - error: If query fails
+func (db *PGDB) CountURLs(ctx context.Context) (int64, error) {
+	return db.count(ctx, countURLsQuery)
+}
+
+// CountDeletedURLs returns the number of short URLs marked as deleted.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - int64: Number of deleted short URLs
+// - error: If query fails
+func (db *PGDB) CountDeletedURLs(ctx context.Context) (int64, error) {
+	return db.count(ctx, countDeletedURLsQuery)
+}
+
+// CountUsers returns the number of registered users.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - int64: Number of users
+// - error: If query fails
+func (db *PGDB) CountUsers(ctx context.Context) (int64, error) {
+	return db.count(ctx, countUsersQuery)
+}
+
+// count runs query returning a single number.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - query: COUNT query without arguments
+// Returns:
+// - int64: Query result
+// - error: If query fails
+func (db *PGDB) count(ctx context.Context, query string) (int64, error) {
+	var n int64
+	if err := db.pool.QueryRow(ctx, query).Scan(&n); err != nil {
+		logger.Log.Error(err.Error())
+		return 0, dbErrors.ErrDBQuery
+	}
+	return n, nil
+}
+
+// SaveTag stores a new tag of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - tag: Tag to save
+// Returns:
+// - *tagEntity.Tag: Saved tag with ID
+// - error: dbErrors.ErrDBIsNotUnique if user already has a tag with the same name
+func (db *PGDB) SaveTag(ctx context.Context, tag *tagEntity.Tag) (*tagEntity.Tag, error) {
+	var pgErr *pgconn.PgError
+
+	err := db.pool.QueryRow(ctx, saveTagQuery, tag.UserID, tag.Name).Scan(&tag.ID)
+	if err != nil {
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, dbErrors.ErrDBIsNotUnique
+		}
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	return tag, nil
+}
+
+// FindTagsByUser retrieves all tags of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// Returns:
+// - []*tagEntity.Tag: User's tags ordered by name
+// - error: If query fails
+func (db *PGDB) FindTagsByUser(ctx context.Context, userID int) ([]*tagEntity.Tag, error) {
+	return db.findTags(ctx, userID, findTagsByUserQuery, userID)
+}
+
+// FindURLTags retrieves tags assigned to a short URL of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// Returns:
+// - []*tagEntity.Tag: Assigned tags ordered by name
+// - error: dbErrors.ErrDBRecordNotFound if user has no URL with such alias
+func (db *PGDB) FindURLTags(ctx context.Context, userID int, namespace, alias string) ([]*tagEntity.Tag, error) {
+	var urlID int
+
+	if err := db.pool.QueryRow(ctx, findUserURLIDQuery, userID, namespaceEntity.OrDefault(namespace), alias).Scan(&urlID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, dbErrors.ErrDBRecordNotFound
+		}
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	return db.findTags(ctx, userID, findURLTagsQuery, urlID)
+}
+
+// findTags runs query returning tag IDs and names.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner of the tags
+// - query: Query selecting id and name of tags
+// - args: Query arguments
+// Returns:
+// - []*tagEntity.Tag: Found tags
+// - error: If query fails
+func (db *PGDB) findTags(ctx context.Context, userID int, query string, args ...any) ([]*tagEntity.Tag, error) {
+	var (
+		id   int
+		name string
+		tags []*tagEntity.Tag
+	)
+
+	rows, err := db.pool.Query(ctx, query, args...)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	_, err = pgx.ForEachRow(rows, []any{&id, &name}, func() error {
+		tags = append(tags, &tagEntity.Tag{ID: id, UserID: userID, Name: name})
+		return nil
+	})
+
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	return tags, nil
+}
+
+// AssignTag associates a tag of a user with a short URL of the same user.
+// Assigning already assigned tag has no effect.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// - name: Tag name
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if user has no such URL or tag
+func (db *PGDB) AssignTag(ctx context.Context, userID int, namespace, alias, name string) error {
+	var found int
+
+	if err := db.pool.QueryRow(ctx, assignTagQuery, userID, namespaceEntity.OrDefault(namespace), alias, name).Scan(&found); err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	if found == 0 {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	return nil
+}
+
+// RemoveTag removes association of a tag of a user with a short URL.
+// Removing not assigned tag has no effect.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL identifier
+// - name: Tag name
+// Returns:
+// - error: If query fails
+func (db *PGDB) RemoveTag(ctx context.Context, userID int, namespace, alias, name string) error {
+	if _, err := db.pool.Exec(ctx, removeTagQuery, userID, namespaceEntity.OrDefault(namespace), alias, name); err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+	return nil
+}
+
+// FindURLsByTag retrieves short URLs of a user having the tag assigned.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - name: Tag name
+// Returns:
+// - []*shortURLEntity.ShortURL: Tagged URLs ordered by ID, empty if tag doesn't exist
+// - error: If query fails
+func (db *PGDB) FindURLsByTag(ctx context.Context, userID int, name string) ([]*shortURLEntity.ShortURL, error) {
+	var (
+		id          int
+		alias       string
+		originalURL string
+		namespace   string
+		urls        []*shortURLEntity.ShortURL
+	)
+
+	rows, err := db.pool.Query(ctx, findURLsByTagQuery, userID, name)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	_, err = pgx.ForEachRow(rows, []any{&id, &alias, &originalURL, &namespace}, func() error {
+		urls = append(urls, &shortURLEntity.ShortURL{ID: id, Alias: alias, SourceURL: originalURL, UserID: userID, Namespace: namespace})
+		return nil
+	})
+
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	return urls, nil
+}
+
+// SaveWebhook stores a new webhook of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - webhook: Webhook to save
+// Returns:
+// - *webhookEntity.Webhook: Saved webhook with ID
+// - error: If query fails
+func (db *PGDB) SaveWebhook(ctx context.Context, webhook *webhookEntity.Webhook) (*webhookEntity.Webhook, error) {
+	err := db.pool.QueryRow(ctx, saveWebhookQuery, webhook.UserID, webhook.URL, webhook.Events, webhook.Secret).Scan(&webhook.ID)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	return webhook, nil
+}
+
+// FindWebhooksByUser retrieves all webhooks of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// Returns:
+// - []*webhookEntity.Webhook: User's webhooks ordered by ID
+// - error: If query fails
+func (db *PGDB) FindWebhooksByUser(ctx context.Context, userID int) ([]*webhookEntity.Webhook, error) {
+	var (
+		id       int
+		url      string
+		events   []string
+		secret   string
+		webhooks []*webhookEntity.Webhook
+	)
+
+	rows, err := db.pool.Query(ctx, findWebhooksByUserQuery, userID)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	_, err = pgx.ForEachRow(rows, []any{&id, &url, &events, &secret}, func() error {
+		webhooks = append(webhooks, &webhookEntity.Webhook{ID: id, UserID: userID, URL: url, Events: events, Secret: secret})
+		return nil
+	})
+
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	return webhooks, nil
+}
+
+// DeleteWebhook removes a webhook of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - id: Webhook ID
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if user has no webhook with such ID,
+// dbErrors.ErrDBQuery if query fails
+func (db *PGDB) DeleteWebhook(ctx context.Context, userID, id int) error {
+	tag, err := db.pool.Exec(ctx, deleteWebhookQuery, id, userID)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	if tag.RowsAffected() == 0 {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	return nil
+}
+
+// SaveAPIKey stores hash of a new API key of a user.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - keyHash: Hash of the key
+// Returns:
+// - error: If query fails
+func (db *PGDB) SaveAPIKey(ctx context.Context, userID int, keyHash string) error {
+	if _, err := db.pool.Exec(ctx, saveAPIKeyQuery, userID, keyHash); err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	return nil
+}
+
+// FindUserByAPIKey retrieves the owner of a not revoked API key.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - keyHash: Hash of the key
+// Returns:
+// - *userEntity.User: Owner of the key
+// - error: dbErrors.ErrDBRecordNotFound if key is unknown or revoked, or query error
+func (db *PGDB) FindUserByAPIKey(ctx context.Context, keyHash string) (*userEntity.User, error) {
+	var user userEntity.User
+
+	if err := db.pool.QueryRow(ctx, findUserByAPIKeyQuery, keyHash).Scan(&user.ID, &user.IsActive, &user.CustomDomain); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, dbErrors.ErrDBRecordNotFound
+		}
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	return &user, nil
+}
+
+// RevokeAPIKey marks an API key of a user as revoked.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// - keyHash: Hash of the key
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if user has no such not revoked key, or query error
+func (db *PGDB) RevokeAPIKey(ctx context.Context, userID int, keyHash string) error {
+	tag, err := db.pool.Exec(ctx, revokeAPIKeyQuery, keyHash, userID)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	if tag.RowsAffected() == 0 {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	return nil
+}
+
+// SaveClick stores a served redirect of a short URL.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - click: Click to save
+// Returns:
+// - error: If query fails
+func (db *PGDB) SaveClick(ctx context.Context, click *analyticsEntity.Click) error {
+	location := click.Location
+	if _, err := db.pool.Exec(ctx, saveClickQuery, namespaceEntity.OrDefault(click.Namespace), click.Alias, click.ClickedAt, click.IPHash, click.UserAgentHash, location.Country, location.City, location.Lat, location.Lon, click.AnonymizedIP); err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	return nil
+}
+
+// FindUserClicks retrieves clicks of all short URLs of a user, including archived ones.
+// Hashes of client IP and User-Agent are not loaded.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - userID: Owner's user ID
+// Returns:
+// - []*analyticsEntity.Click: Clicks ordered by click time
+// - error: If query fails
+func (db *PGDB) FindUserClicks(ctx context.Context, userID int) ([]*analyticsEntity.Click, error) {
+	var click analyticsEntity.Click
+
+	rows, err := db.pool.Query(ctx, findUserClicksQuery, userID)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	var clicks []*analyticsEntity.Click
+	_, err = pgx.ForEachRow(rows, []any{&click.Namespace, &click.Alias, &click.ClickedAt, &click.AnonymizedIP, &click.Location.Country, &click.Location.City, &click.Location.Lat, &click.Location.Lon}, func() error {
+		found := click
+		clicks = append(clicks, &found)
+		return nil
+	})
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	return clicks, nil
+}
+
+// CountClicks counts clicks of a short URL in [from, to) grouped by periods of the granularity in UTC.
+// Periods are truncated in UTC, so they do not depend on session time zone and its DST transitions.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL alias
+// - from: Start of the time range, inclusive
+// - to: End of the time range, exclusive
+// - granularity: Period length, one of hour, day, week or month
+// Returns:
+// - []analyticsEntity.ClickCount: Non-zero counts ordered by period start
+// - error: If query fails
+func (db *PGDB) CountClicks(ctx context.Context, namespace, alias string, from, to time.Time, granularity string) ([]analyticsEntity.ClickCount, error) {
+	var (
+		periodStart time.Time
+		clicks      int64
+		counts      []analyticsEntity.ClickCount
+	)
+
+	rows, err := db.pool.Query(ctx, countClicksQuery, granularity, namespaceEntity.OrDefault(namespace), alias, from, to)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	_, err = pgx.ForEachRow(rows, []any{&periodStart, &clicks}, func() error {
+		counts = append(counts, analyticsEntity.ClickCount{PeriodStart: periodStart.UTC(), Clicks: clicks})
+		return nil
+	})
+
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	return counts, nil
+}
+
+// CountClicksByLocation counts clicks of a short URL in [from, to) grouped by country and city.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the short URL, empty for the default one
+// - alias: Short URL alias
+// - from: Start of the time range, inclusive
+// - to: End of the time range, exclusive
+// Returns:
+// - []analyticsEntity.LocationCount: Non-zero counts ordered by clicks descending
+// - error: If query fails
+func (db *PGDB) CountClicksByLocation(ctx context.Context, namespace, alias string, from, to time.Time) ([]analyticsEntity.LocationCount, error) {
+	var (
+		count  analyticsEntity.LocationCount
+		counts []analyticsEntity.LocationCount
+	)
+
+	rows, err := db.pool.Query(ctx, countClicksByLocationQuery, namespaceEntity.OrDefault(namespace), alias, from, to)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	_, err = pgx.ForEachRow(rows, []any{&count.Country, &count.City, &count.Clicks}, func() error {
+		counts = append(counts, count)
+		return nil
+	})
+
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	return counts, nil
+}
+
+// SaveSplit stores a new split short URL, one row per destination.
+// Concurrent saves of the same alias are serialized by a transaction-level advisory lock,
+// so the alias is checked against both short URLs and splits reliably.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - split: Split to save
+// Returns:
+// - error: dbErrors.ErrDBIsNotUnique if a short URL or a split with the alias already exists in the namespace,
+// dbErrors.ErrDBReferenceNotFound if the namespace doesn't exist, or query error
+func (db *PGDB) SaveSplit(ctx context.Context, split *splitEntity.MultiDestination) error {
+	var (
+		err    error
+		tx     pgx.Tx
+		exists bool
+		pgErr  *pgconn.PgError
+	)
+
+	split.Namespace = namespaceEntity.OrDefault(split.Namespace)
+
+	if tx, err = db.pool.Begin(ctx); err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	defer func() {
+		if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			logger.Log.Error(rbErr.Error())
+		}
+	}()
+
+	if _, err = tx.Exec(ctx, lockSplitAliasQuery, split.Path()); err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	if err = tx.QueryRow(ctx, splitAliasExistsQuery, split.Namespace, split.Alias).Scan(&exists); err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	if exists {
+		return dbErrors.ErrDBIsNotUnique
+	}
+
+	for i, destination := range split.Destinations {
+		if _, err = tx.Exec(ctx, saveSplitDestinationQuery, split.Namespace, split.Alias, destination.URL, destination.Weight, i, split.UserID); err != nil {
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+				return dbErrors.ErrDBReferenceNotFound
+			}
+			logger.Log.Error(err.Error())
+			return dbErrors.ErrDBQuery
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	return nil
+}
+
+// FindSplit retrieves a split short URL by its namespace and alias.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the split, empty for the default one
+// - alias: Split alias
+// Returns:
+// - *splitEntity.MultiDestination: Split with destinations in the saved order and their click counts
+// - error: dbErrors.ErrDBRecordNotFound if split doesn't exist, or query error
+func (db *PGDB) FindSplit(ctx context.Context, namespace, alias string) (*splitEntity.MultiDestination, error) {
+	var (
+		destination splitEntity.Destination
+		userID      int
+	)
+
+	split := &splitEntity.MultiDestination{Alias: alias, Namespace: namespaceEntity.OrDefault(namespace)}
+
+	rows, err := db.pool.Query(ctx, findSplitQuery, split.Namespace, alias)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	_, err = pgx.ForEachRow(rows, []any{&destination.URL, &destination.Weight, &destination.Clicks, &userID}, func() error {
+		split.Destinations = append(split.Destinations, destination)
+		split.UserID = userID
+		return nil
+	})
+
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	if len(split.Destinations) == 0 {
+		return nil, dbErrors.ErrDBRecordNotFound
+	}
+
+	return split, nil
+}
+
+// SaveSplitClick increments click count of a destination of a split short URL.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace of the split, empty for the default one
+// - alias: Split alias
+// - url: Original URL of the destination
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if split has no such destination, or query error
+func (db *PGDB) SaveSplitClick(ctx context.Context, namespace, alias, url string) error {
+	tag, err := db.pool.Exec(ctx, saveSplitClickQuery, namespaceEntity.OrDefault(namespace), alias, url)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	if tag.RowsAffected() == 0 {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	return nil
+}
+
+// Ping checks if the database is available.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - error: If database is unreachable
+func (db *PGDB) Ping(ctx context.Context) error {
+	return db.pool.Ping(ctx)
+}
+
+// PoolStats returns statistics of database connection pool.
+// Returns:
+// - *healthEntity.DBPoolStats: Open and idle connections, number of waited acquires
+func (db *PGDB) PoolStats() *healthEntity.DBPoolStats {
+	return db.pool.PoolStats()
+}
+
+// Shutdown gracefully closes the database connection pools of the primary and the read replica.
+// It waits for all connections to finish their work before closing.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - error: If shutdown fails or context expires
+func (db *PGDB) Shutdown(ctx context.Context) error {
+	if pools := db.pgxPools(); len(pools) > 0 {
+		logger.Log.Info("Closing database connection pool...")
+		for _, pool := range pools {
+			pool.Close()
+		}
 
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-time.After(waitConnectionCloseTimeout):
-			stats := pool.Stat()
-			if stats.TotalConns() > 0 {
+			var active int32
+			for _, pool := range pools {
+				active += pool.Stat().TotalConns()
+			}
+			if active > 0 {
 				logger.Log.Warn("Database connections still active during shutdown",
-					zap.Int32("active_connections", stats.TotalConns()))
+					zap.Int32("active_connections", active))
 				return errors.New("not all database connections were closed")
 			}
 		}
@@ -344,3 +1786,99 @@ func (db *PGDB) Shutdown(ctx context.Context) error {
 	logger.Log.Info("Database connection pool closed successfully")
 	return nil
 }
+
+// pgxPools returns pgx pools of the primary and the read replica, unwrapping query timeouts.
+// Returns:
+// - []*pgxPool: Pools backed by pgxpool, empty if pools are mocked
+func (db *PGDB) pgxPools() []*pgxPool {
+	var pools []*pgxPool
+	for _, pool := range []PGDBPool{db.pool, db.readPool} {
+		if timeout, ok := pool.(*timeoutPool); ok {
+			pool = timeout.PGDBPool
+		}
+		if p, ok := pool.(*pgxPool); ok {
+			pools = append(pools, p)
+		}
+	}
+	return pools
+}
+
+// SaveNamespace stores a new namespace.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - namespace: Namespace to save
+// Returns:
+// - *namespaceEntity.Namespace: Saved namespace with creation time
+// - error: dbErrors.ErrDBIsNotUnique if namespace already exists, or query error
+func (db *PGDB) SaveNamespace(ctx context.Context, namespace *namespaceEntity.Namespace) (*namespaceEntity.Namespace, error) {
+	var pgErr *pgconn.PgError
+
+	err := db.pool.QueryRow(ctx, saveNamespaceQuery, namespace.Name).Scan(&namespace.CreatedAt)
+	if err != nil {
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, dbErrors.ErrDBIsNotUnique
+		}
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	return namespace, nil
+}
+
+// FindNamespaces retrieves all namespaces.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - []*namespaceEntity.Namespace: Namespaces ordered by name
+// - error: If query fails
+func (db *PGDB) FindNamespaces(ctx context.Context) ([]*namespaceEntity.Namespace, error) {
+	var (
+		name       string
+		createdAt  time.Time
+		namespaces []*namespaceEntity.Namespace
+	)
+
+	rows, err := db.pool.Query(ctx, findNamespacesQuery)
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	_, err = pgx.ForEachRow(rows, []any{&name, &createdAt}, func() error {
+		namespaces = append(namespaces, &namespaceEntity.Namespace{Name: name, CreatedAt: createdAt})
+		return nil
+	})
+
+	if err != nil {
+		logger.Log.Error(err.Error())
+		return nil, dbErrors.ErrDBQuery
+	}
+
+	return namespaces, nil
+}
+
+// DeleteNamespace removes a namespace without short URLs.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - name: Namespace name
+// Returns:
+// - error: dbErrors.ErrDBRecordNotFound if namespace doesn't exist,
+// dbErrors.ErrDBRecordIsReferenced if it still has short URLs or splits, or query error
+func (db *PGDB) DeleteNamespace(ctx context.Context, name string) error {
+	var pgErr *pgconn.PgError
+
+	tag, err := db.pool.Exec(ctx, deleteNamespaceQuery, name)
+	if err != nil {
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return dbErrors.ErrDBRecordIsReferenced
+		}
+		logger.Log.Error(err.Error())
+		return dbErrors.ErrDBQuery
+	}
+
+	if tag.RowsAffected() == 0 {
+		return dbErrors.ErrDBRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,1148 @@
+//go:build integration
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gururuby/shortener/internal/config"
+	analyticsEntity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	namespaceEntity "github.com/gururuby/shortener/internal/domain/entity/namespace"
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	splitEntity "github.com/gururuby/shortener/internal/domain/entity/split"
+	tagEntity "github.com/gururuby/shortener/internal/domain/entity/tag"
+	webhookEntity "github.com/gururuby/shortener/internal/domain/entity/webhook"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/gururuby/shortener/internal/infra/janitor"
+	"github.com/stretchr/testify/require"
+)
+
+// setupPGDB creates a database in the shared PostgreSQL container and returns it migrated.
+func setupPGDB(t *testing.T) *PGDB {
+	t.Helper()
+
+	ctx := context.Background()
+	db, err := New(ctx, &config.Config{Database: config.Database{
+		DSN:          newPGDatabase(t),
+		ConnTryDelay: 5 * time.Second,
+		ConnTryTimes: 3,
+	}})
+	require.NoError(t, err)
+	t.Cleanup(db.pool.Close)
+
+	return db
+}
+
+func Test_Migrations(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := OpenMigrationDB(ctx, config.Database{
+		DSN:          newPGDatabase(t),
+		ConnTryDelay: 5 * time.Second,
+		ConnTryTimes: 3,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	columnExists := func(table, column string) bool {
+		var exists bool
+		require.NoError(t, db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = $1 AND column_name = $2)`, table, column).Scan(&exists))
+		return exists
+	}
+
+	entries, err := migrations.ReadDir(migrationsDir)
+	require.NoError(t, err)
+	last := entries[len(entries)-1].Name()
+
+	pending, err := DryRunMigrations(ctx, db)
+	require.NoError(t, err)
+	require.Len(t, pending, len(entries))
+	require.Contains(t, pending[0].SQL, "CREATE TABLE")
+	require.False(t, columnExists("urls", "alias"), "dry run must not apply migrations")
+
+	applied, err := RunMigrations(ctx, db)
+	require.NoError(t, err)
+	require.Len(t, applied, len(entries))
+	require.True(t, columnExists("urls", "is_tracked"))
+
+	status, err := MigrationStatus(ctx, db)
+	require.NoError(t, err)
+	require.Len(t, status, len(entries))
+	for _, migration := range status {
+		require.True(t, migration.Applied, migration.Name)
+		require.False(t, migration.AppliedAt.IsZero(), migration.Name)
+	}
+
+	rolledBack, err := RollbackMigrations(ctx, db, 1)
+	require.NoError(t, err)
+	require.Len(t, rolledBack, 1)
+	require.Equal(t, last, rolledBack[0].Name)
+	require.False(t, columnExists("urls", "is_tracked"))
+	require.True(t, columnExists("urls", "alias"))
+
+	status, err = MigrationStatus(ctx, db)
+	require.NoError(t, err)
+	require.False(t, status[len(status)-1].Applied)
+
+	pending, err = DryRunMigrations(ctx, db)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, last, pending[0].Name)
+
+	applied, err = RunMigrations(ctx, db)
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	require.True(t, columnExists("urls", "is_tracked"))
+
+	rolledBack, err = ResetMigrations(ctx, db)
+	require.NoError(t, err)
+	require.Len(t, rolledBack, len(entries))
+	require.False(t, columnExists("urls", "alias"))
+
+	rolledBack, err = RollbackMigrations(ctx, db, 1)
+	require.NoError(t, err)
+	require.Empty(t, rolledBack, "nothing is left to roll back")
+}
+
+func Test_PGDB_PoolMaxConns(t *testing.T) {
+	ctx := context.Background()
+
+	pool, err := newDBPool(ctx, config.Database{
+		DSN:          newPGDatabase(t),
+		ConnTryDelay: 5 * time.Second,
+		ConnTryTimes: 3,
+		MaxConns:     2,
+		MinConns:     1,
+	})
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	var (
+		wg            sync.WaitGroup
+		running       atomic.Int32
+		maxRunning    atomic.Int32
+		failedQueries atomic.Int32
+	)
+
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			conn, err := pool.Acquire(ctx)
+			if err != nil {
+				failedQueries.Add(1)
+				return
+			}
+			defer conn.Release()
+
+			current := running.Add(1)
+			defer running.Add(-1)
+			for {
+				observed := maxRunning.Load()
+				if current <= observed || maxRunning.CompareAndSwap(observed, current) {
+					break
+				}
+			}
+
+			if _, err = conn.Exec(ctx, "SELECT pg_sleep(0.1)"); err != nil {
+				failedQueries.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Zero(t, failedQueries.Load())
+	require.Equal(t, int32(2), maxRunning.Load())
+	require.Equal(t, int32(2), (&pgxPool{Pool: pool}).PoolStats().MaxConnections)
+}
+
+func Test_PGDB_BatchSaveShortURLs(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	t.Run("when all URLs are saved", func(t *testing.T) {
+		shortURLs := []*shortURLEntity.ShortURL{
+			{Alias: "ok1", SourceURL: "https://ok.ru/1"},
+			{Alias: "ok2", SourceURL: "https://ok.ru/2"},
+			{Alias: "ok3", SourceURL: "https://ok.ru/1"},
+		}
+
+		saved, err := db.BatchSaveShortURLs(ctx, shortURLs)
+		require.NoError(t, err)
+		require.Equal(t, shortURLs[:2], saved)
+
+		for _, shortURL := range saved {
+			_, err = db.FindShortURL(ctx, "", shortURL.Alias)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("when third URL fails the batch is rolled back", func(t *testing.T) {
+		shortURLs := []*shortURLEntity.ShortURL{
+			{Alias: "rb1", SourceURL: "https://rb.ru/1"},
+			{Alias: "rb2", SourceURL: "https://rb.ru/2"},
+			// Exceeds varchar(255) of original_url column
+			{Alias: "rb3", SourceURL: "https://rb.ru/" + strings.Repeat("a", 256)},
+			{Alias: "rb4", SourceURL: "https://rb.ru/4"},
+			{Alias: "rb5", SourceURL: "https://rb.ru/5"},
+		}
+
+		saved, err := db.BatchSaveShortURLs(ctx, shortURLs)
+		require.ErrorIs(t, err, dbErrors.ErrDBQuery)
+		require.Equal(t, shortURLs[:2], saved)
+
+		for _, shortURL := range shortURLs {
+			_, err = db.FindShortURL(ctx, "", shortURL.Alias)
+			require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+		}
+	})
+}
+
+func Test_PGDB_MarkURLAsDeleted_OneTimeUse(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	shortURL := &shortURLEntity.ShortURL{Alias: "once", SourceURL: "https://once.ru", IsOneTimeUse: true}
+	_, err := db.SaveShortURL(ctx, shortURL)
+	require.NoError(t, err)
+
+	var (
+		wg      sync.WaitGroup
+		deleted atomic.Int32
+	)
+
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if db.MarkURLAsDeleted(ctx, 0, "", []string{"once"}) == nil {
+				deleted.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(1), deleted.Load())
+
+	found, err := db.FindShortURL(ctx, "", "once")
+	require.NoError(t, err)
+	require.True(t, found.IsDeleted)
+	require.True(t, found.IsOneTimeUse)
+}
+
+func Test_PGDB_SaveShortURL_Concurrent(t *testing.T) {
+	const goroutines = 50
+
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		inserted atomic.Int32
+		aliases  = make(map[string]struct{})
+	)
+
+	for i := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			saved, err := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: fmt.Sprintf("alias%d", i), SourceURL: "https://race.ru"})
+			if err == nil {
+				inserted.Add(1)
+			} else if !errors.Is(err, dbErrors.ErrDBIsNotUnique) {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			mu.Lock()
+			aliases[saved.Alias] = struct{}{}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(1), inserted.Load())
+	require.Len(t, aliases, 1, "all saves must return the same alias")
+
+	count, err := db.CountURLs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
+
+func Test_PGDB_SaveShortURL_Private(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	user, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+
+	private := &shortURLEntity.ShortURL{Alias: "private", SourceURL: "https://ya.ru", UserID: user.ID, Visibility: shortURLEntity.VisibilityPrivate}
+	_, err = db.SaveShortURL(ctx, private)
+	require.NoError(t, err)
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "public", SourceURL: "https://ya.ru"})
+	require.NoError(t, err, "public URL must not reuse alias of private one")
+
+	found, err := db.FindShortURL(ctx, "", "private")
+	require.NoError(t, err)
+	require.Equal(t, shortURLEntity.VisibilityPrivate, found.Visibility)
+	require.Equal(t, user.ID, found.UserID)
+
+	found, err = db.FindShortURL(ctx, "", "public")
+	require.NoError(t, err)
+	require.Equal(t, shortURLEntity.VisibilityPublic, found.Visibility)
+}
+
+func Test_PGDB_SaveShortURL_Permanent(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	_, err := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "permanent", SourceURL: "https://ya.ru", RedirectType: shortURLEntity.RedirectPermanent})
+	require.NoError(t, err)
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "temporary", SourceURL: "https://ya.ru"})
+	require.NoError(t, err, "temporary URL must not reuse alias of permanent one")
+
+	found, err := db.FindShortURL(ctx, "", "permanent")
+	require.NoError(t, err)
+	require.Equal(t, shortURLEntity.RedirectPermanent, found.RedirectType)
+
+	found, err = db.FindShortURL(ctx, "", "temporary")
+	require.NoError(t, err)
+	require.Equal(t, shortURLEntity.RedirectTemporary, found.RedirectType)
+}
+
+func Test_PGDB_SaveShortURL_Tracked(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	_, err := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "plain", SourceURL: "https://ya.ru"})
+	require.NoError(t, err)
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "tracked", SourceURL: "https://ya.ru", IsTracked: true})
+	require.NoError(t, err, "tracked URL must not reuse alias of plain one")
+
+	found, err := db.FindShortURL(ctx, "", "tracked")
+	require.NoError(t, err)
+	require.True(t, found.IsTracked)
+
+	found, err = db.FindShortURL(ctx, "", "plain")
+	require.NoError(t, err)
+	require.False(t, found.IsTracked)
+}
+
+func Test_PGDB_DeleteExpiredURLs(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	user, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+
+	expired := []string{"expired1", "expired2", "expired3"}
+	for i, alias := range expired {
+		_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: alias, SourceURL: fmt.Sprintf("https://ya.ru/%d", i), UserID: user.ID, ExpiresAt: time.Now().Add(-time.Hour)})
+		require.NoError(t, err)
+	}
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "active", SourceURL: "https://go.dev", ExpiresAt: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "permanent", SourceURL: "https://ok.ru"})
+	require.NoError(t, err)
+
+	n, err := janitor.New(db, time.Hour).RunOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), n)
+
+	for _, alias := range expired {
+		_, err = db.FindShortURL(ctx, "", alias)
+		require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound, alias)
+	}
+
+	active, err := db.FindShortURL(ctx, "", "active")
+	require.NoError(t, err)
+	require.False(t, active.ExpiresAt.IsZero())
+
+	permanent, err := db.FindShortURL(ctx, "", "permanent")
+	require.NoError(t, err)
+	require.True(t, permanent.ExpiresAt.IsZero())
+
+	count, err := db.CountURLs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
+
+func Test_PGDB_URLHealth(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	user, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+
+	for _, url := range []*shortURLEntity.ShortURL{
+		{Alias: "unchecked", SourceURL: "https://ya.ru"},
+		{Alias: "expired", SourceURL: "https://go.dev", ExpiresAt: now.Add(-time.Hour)},
+		{Alias: "deleted", SourceURL: "https://ok.ru", UserID: user.ID},
+		{Alias: "recent", SourceURL: "https://vk.com"},
+		{Alias: "stale", SourceURL: "https://mail.ru"},
+	} {
+		_, err = db.SaveShortURL(ctx, url)
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.MarkURLAsDeleted(ctx, user.ID, "", []string{"deleted"}))
+	require.NoError(t, db.SaveURLHealth(ctx, "", "recent", true, now))
+	require.NoError(t, db.SaveURLHealth(ctx, "", "stale", false, now.Add(-48*time.Hour)))
+	require.ErrorIs(t, db.SaveURLHealth(ctx, "", "unknown", true, now), dbErrors.ErrDBRecordNotFound)
+
+	urls, err := db.FindURLsToCheck(ctx, now.Add(-24*time.Hour), 0, 10)
+	require.NoError(t, err)
+	require.Len(t, urls, 2)
+	require.Equal(t, "unchecked", urls[0].Alias)
+	require.Equal(t, "https://ya.ru", urls[0].SourceURL)
+	require.Equal(t, "stale", urls[1].Alias)
+
+	stale, err := db.FindShortURL(ctx, "", "stale")
+	require.NoError(t, err)
+	require.False(t, stale.IsSourceHealthy())
+	require.True(t, now.Add(-48*time.Hour).Equal(stale.CheckedAt))
+
+	unchecked, err := db.FindShortURL(ctx, "", "unchecked")
+	require.NoError(t, err)
+	require.True(t, unchecked.IsSourceHealthy())
+	require.True(t, unchecked.CheckedAt.IsZero())
+}
+
+func Test_PGDB_RestoreURL(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	user, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "alias1", SourceURL: "https://ya.ru", UserID: user.ID})
+	require.NoError(t, err)
+	require.NoError(t, db.MarkURLAsDeleted(ctx, user.ID, "", []string{"alias1"}))
+
+	require.ErrorIs(t, db.RestoreURL(ctx, user.ID+1, "", "alias1"), dbErrors.ErrDBRecordNotFound)
+	require.NoError(t, db.RestoreURL(ctx, user.ID, "", "alias1"))
+
+	found, err := db.FindShortURL(ctx, "", "alias1")
+	require.NoError(t, err)
+	require.False(t, found.IsDeleted)
+}
+
+func Test_PGDB_ArchiveDeletedURLs(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	user, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+
+	for i, alias := range []string{"old1", "old2", "recent", "active"} {
+		_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: alias, SourceURL: fmt.Sprintf("https://ya.ru/%d", i), UserID: user.ID})
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.MarkURLAsDeleted(ctx, user.ID, "", []string{"old1", "old2", "recent"}))
+
+	_, err = db.pool.Exec(ctx, "UPDATE urls SET deleted_at = NOW() - INTERVAL '31 days' WHERE alias IN ('old1', 'old2')")
+	require.NoError(t, err)
+
+	n, err := janitor.New(db, time.Hour).ArchiveOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	var stale int64
+	require.NoError(t, db.pool.QueryRow(ctx, "SELECT COUNT(*) FROM urls WHERE is_deleted AND deleted_at < NOW() - INTERVAL '30 days'").Scan(&stale))
+	require.Zero(t, stale)
+
+	for _, alias := range []string{"old1", "old2"} {
+		_, err = db.FindShortURL(ctx, "", alias)
+		require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound, alias)
+	}
+
+	recent, err := db.FindShortURL(ctx, "", "recent")
+	require.NoError(t, err)
+	require.True(t, recent.IsDeleted)
+
+	archived, err := db.FindArchivedURLs(ctx, user.ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, archived, 2)
+	for _, archivedURL := range archived {
+		require.Contains(t, []string{"old1", "old2"}, archivedURL.ShortURL.Alias)
+		require.False(t, archivedURL.ArchivedAt.IsZero())
+	}
+
+	page, err := db.FindArchivedURLs(ctx, user.ID, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+
+	require.ErrorIs(t, db.RestoreArchivedURL(ctx, user.ID+1, "", "old1"), dbErrors.ErrDBRecordNotFound)
+	require.NoError(t, db.RestoreArchivedURL(ctx, user.ID, "", "old1"))
+	require.ErrorIs(t, db.RestoreArchivedURL(ctx, user.ID, "", "old1"), dbErrors.ErrDBRecordNotFound)
+
+	restored, err := db.FindShortURL(ctx, "", "old1")
+	require.NoError(t, err)
+	require.False(t, restored.IsDeleted)
+	require.Equal(t, "https://ya.ru/0", restored.SourceURL)
+	require.Equal(t, user.ID, restored.UserID)
+
+	t.Run("when alias is taken after archiving", func(t *testing.T) {
+		_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "old2", SourceURL: "https://go.dev"})
+		require.NoError(t, err)
+
+		require.ErrorIs(t, db.RestoreArchivedURL(ctx, user.ID, "", "old2"), dbErrors.ErrDBIsNotUnique)
+
+		archived, err = db.FindArchivedURLs(ctx, user.ID, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, archived, 1)
+	})
+}
+
+func Test_PGDB_UpdateURLTarget(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	user, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "alias1", SourceURL: "https://ya.ru", UserID: user.ID})
+	require.NoError(t, err)
+
+	require.ErrorIs(t, db.UpdateURLTarget(ctx, user.ID+1, "", "alias1", "https://go.dev"), dbErrors.ErrDBRecordNotFound)
+	require.NoError(t, db.UpdateURLTarget(ctx, user.ID, "", "alias1", "https://go.dev"))
+	require.NoError(t, db.UpdateURLTarget(ctx, user.ID, "", "alias1", "https://ok.ru"))
+
+	found, err := db.FindShortURL(ctx, "", "alias1")
+	require.NoError(t, err)
+	require.Equal(t, "https://ok.ru", found.SourceURL)
+
+	history, err := db.FindURLHistory(ctx, "", "alias1")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, "https://go.dev", history[0].OriginalURL)
+	require.Equal(t, "https://ya.ru", history[1].OriginalURL)
+}
+
+func Test_PGDB_FindUserURLsCursor_TraversesAllRecords(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	user, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+
+	saveURL := func(i int) {
+		_, saveErr := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{
+			SourceURL: fmt.Sprintf("https://example.com/%d", i),
+			Alias:     fmt.Sprintf("alias%d", i),
+			UserID:    user.ID,
+		})
+		require.NoError(t, saveErr)
+	}
+
+	for i := 1; i <= 10; i++ {
+		saveURL(i)
+	}
+
+	var (
+		seen    = make(map[string]bool)
+		cursor  int
+		created = 10
+	)
+
+	for {
+		urls, nextCursor, findErr := db.FindUserURLsCursor(ctx, user.ID, cursor, 3)
+		require.NoError(t, findErr)
+
+		for _, url := range urls {
+			require.False(t, seen[url.Alias], "duplicate %s", url.Alias)
+			require.False(t, url.CreatedAt.IsZero(), "created_at is not loaded for %s", url.Alias)
+			seen[url.Alias] = true
+		}
+
+		if nextCursor == 0 {
+			break
+		}
+		cursor = nextCursor
+
+		// New URLs created between pages must not shift already returned ones
+		created++
+		saveURL(created)
+	}
+
+	require.Len(t, seen, created)
+}
+
+func Test_PGDB_Counts(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	user, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+	_, err = db.SaveUser(ctx)
+	require.NoError(t, err)
+
+	for i := 1; i <= 5; i++ {
+		_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{
+			SourceURL: fmt.Sprintf("https://example.com/%d", i),
+			Alias:     fmt.Sprintf("alias%d", i),
+			UserID:    user.ID,
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.MarkURLAsDeleted(ctx, user.ID, "", []string{"alias1", "alias2"}))
+
+	urls, err := db.CountURLs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), urls)
+
+	deleted, err := db.CountDeletedURLs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), deleted)
+
+	users, err := db.CountUsers(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), users)
+}
+
+func Test_PGDB_Tags(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	owner, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+	other, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "abc", SourceURL: "https://ya.ru", UserID: owner.ID})
+	require.NoError(t, err)
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "def", SourceURL: "https://ok.ru", UserID: other.ID})
+	require.NoError(t, err)
+
+	work, err := db.SaveTag(ctx, &tagEntity.Tag{UserID: owner.ID, Name: "work"})
+	require.NoError(t, err)
+
+	_, err = db.SaveTag(ctx, &tagEntity.Tag{UserID: owner.ID, Name: "work"})
+	require.ErrorIs(t, err, dbErrors.ErrDBIsNotUnique)
+
+	require.NoError(t, db.AssignTag(ctx, owner.ID, "", "abc", "work"))
+	require.NoError(t, db.AssignTag(ctx, owner.ID, "", "abc", "work"))
+	require.ErrorIs(t, db.AssignTag(ctx, owner.ID, "", "def", "work"), dbErrors.ErrDBRecordNotFound)
+	require.ErrorIs(t, db.AssignTag(ctx, owner.ID, "", "abc", "home"), dbErrors.ErrDBRecordNotFound)
+
+	tags, err := db.FindURLTags(ctx, owner.ID, "", "abc")
+	require.NoError(t, err)
+	require.Equal(t, []*tagEntity.Tag{work}, tags)
+
+	_, err = db.FindURLTags(ctx, owner.ID, "", "def")
+	require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+
+	urls, err := db.FindURLsByTag(ctx, owner.ID, "work")
+	require.NoError(t, err)
+	require.Len(t, urls, 1)
+	require.Equal(t, "abc", urls[0].Alias)
+
+	require.NoError(t, db.RemoveTag(ctx, owner.ID, "", "abc", "work"))
+
+	tags, err = db.FindURLTags(ctx, owner.ID, "", "abc")
+	require.NoError(t, err)
+	require.Empty(t, tags)
+}
+
+func Test_PGDB_Webhooks(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	owner, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+	other, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+
+	webhook, err := db.SaveWebhook(ctx, &webhookEntity.Webhook{
+		UserID: owner.ID,
+		URL:    "https://example.com/hook",
+		Events: []string{webhookEntity.EventURLCreated, webhookEntity.EventURLDeleted},
+		Secret: "secret",
+	})
+	require.NoError(t, err)
+	require.NotZero(t, webhook.ID)
+
+	webhooks, err := db.FindWebhooksByUser(ctx, owner.ID)
+	require.NoError(t, err)
+	require.Equal(t, []*webhookEntity.Webhook{webhook}, webhooks)
+
+	require.ErrorIs(t, db.DeleteWebhook(ctx, other.ID, webhook.ID), dbErrors.ErrDBRecordNotFound)
+	require.NoError(t, db.DeleteWebhook(ctx, owner.ID, webhook.ID))
+
+	webhooks, err = db.FindWebhooksByUser(ctx, owner.ID)
+	require.NoError(t, err)
+	require.Empty(t, webhooks)
+}
+
+func Test_PGDB_APIKeys(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	owner, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+	other, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, db.SaveAPIKey(ctx, owner.ID, "hash"))
+
+	user, err := db.FindUserByAPIKey(ctx, "hash")
+	require.NoError(t, err)
+	require.Equal(t, owner.ID, user.ID)
+
+	require.ErrorIs(t, db.RevokeAPIKey(ctx, other.ID, "hash"), dbErrors.ErrDBRecordNotFound)
+	require.NoError(t, db.RevokeAPIKey(ctx, owner.ID, "hash"))
+	require.ErrorIs(t, db.RevokeAPIKey(ctx, owner.ID, "hash"), dbErrors.ErrDBRecordNotFound)
+
+	_, err = db.FindUserByAPIKey(ctx, "hash")
+	require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+}
+
+func Test_PGDB_SetUserActive(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	saved, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+	require.True(t, saved.IsActive)
+	require.NoError(t, db.SaveAPIKey(ctx, saved.ID, "hash"))
+
+	require.NoError(t, db.SetUserActive(ctx, saved.ID, false))
+
+	user, err := db.FindUser(ctx, saved.ID)
+	require.NoError(t, err)
+	require.False(t, user.IsActive)
+
+	user, err = db.FindUserByAPIKey(ctx, "hash")
+	require.NoError(t, err)
+	require.False(t, user.IsActive)
+
+	require.NoError(t, db.SetUserActive(ctx, saved.ID, true))
+
+	user, err = db.FindUser(ctx, saved.ID)
+	require.NoError(t, err)
+	require.True(t, user.IsActive)
+
+	require.ErrorIs(t, db.SetUserActive(ctx, saved.ID+1, false), dbErrors.ErrDBRecordNotFound)
+}
+
+func Test_PGDB_SetUserCustomDomain(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	first, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+	second, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveAPIKey(ctx, first.ID, "hash"))
+
+	require.NoError(t, db.SetUserCustomDomain(ctx, first.ID, "https://short.acme.com"))
+
+	user, err := db.FindUser(ctx, first.ID)
+	require.NoError(t, err)
+	require.Equal(t, "https://short.acme.com", user.CustomDomain)
+
+	user, err = db.FindUserByAPIKey(ctx, "hash")
+	require.NoError(t, err)
+	require.Equal(t, "https://short.acme.com", user.CustomDomain)
+
+	// Domain can be claimed by a single user only
+	require.ErrorIs(t, db.SetUserCustomDomain(ctx, second.ID, "https://short.acme.com"), dbErrors.ErrDBIsNotUnique)
+	require.NoError(t, db.SetUserCustomDomain(ctx, first.ID, "https://short.acme.com"))
+
+	// Removed domains are free, users without domain do not conflict
+	require.NoError(t, db.SetUserCustomDomain(ctx, first.ID, ""))
+	require.NoError(t, db.SetUserCustomDomain(ctx, second.ID, ""))
+	require.NoError(t, db.SetUserCustomDomain(ctx, second.ID, "https://short.acme.com"))
+
+	user, err = db.FindUser(ctx, first.ID)
+	require.NoError(t, err)
+	require.Empty(t, user.CustomDomain)
+
+	require.ErrorIs(t, db.SetUserCustomDomain(ctx, second.ID+1, "https://other.acme.com"), dbErrors.ErrDBRecordNotFound)
+}
+
+func Test_PGDB_SearchUserURLs(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	user, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+
+	for alias, url := range map[string]string{
+		"alias1": "https://GitHub.com/gururuby",
+		"alias2": "https://github.com/golang/go",
+		"alias3": "https://ya.ru/100%_real",
+		"alias4": "https://ya.ru/100abreal",
+	} {
+		_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: alias, SourceURL: url, UserID: user.ID})
+		require.NoError(t, err)
+	}
+
+	urls, total, err := db.SearchUserURLs(ctx, user.ID, "github.com", 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+	require.Len(t, urls, 1)
+	require.Equal(t, "alias2", urls[0].Alias)
+
+	urls, total, err = db.SearchUserURLs(ctx, user.ID, "100%_", 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Equal(t, "alias3", urls[0].Alias)
+
+	urls, total, err = db.SearchUserURLs(ctx, user.ID, "'; DROP TABLE urls; --", 0, 10)
+	require.NoError(t, err)
+	require.Zero(t, total)
+	require.Empty(t, urls)
+
+	_, total, err = db.SearchUserURLs(ctx, user.ID+1, "github.com", 0, 10)
+	require.NoError(t, err)
+	require.Zero(t, total)
+}
+
+func Test_PGDB_Splits(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	owner, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "taken", SourceURL: "https://ya.ru"})
+	require.NoError(t, err)
+
+	split := &splitEntity.MultiDestination{
+		Alias:  "ab-test",
+		UserID: owner.ID,
+		Destinations: []splitEntity.Destination{
+			{URL: "https://v2.com", Weight: 70},
+			{URL: "https://v1.com", Weight: 30},
+		},
+	}
+	require.NoError(t, db.SaveSplit(ctx, split))
+	require.ErrorIs(t, db.SaveSplit(ctx, split), dbErrors.ErrDBIsNotUnique)
+	require.ErrorIs(t, db.SaveSplit(ctx, &splitEntity.MultiDestination{Alias: "taken", UserID: owner.ID, Destinations: split.Destinations}), dbErrors.ErrDBIsNotUnique)
+
+	require.NoError(t, db.SaveSplitClick(ctx, "", "ab-test", "https://v1.com"))
+	require.ErrorIs(t, db.SaveSplitClick(ctx, "", "ab-test", "https://v3.com"), dbErrors.ErrDBRecordNotFound)
+
+	found, err := db.FindSplit(ctx, "", "ab-test")
+	require.NoError(t, err)
+	require.Equal(t, &splitEntity.MultiDestination{
+		Alias:     "ab-test",
+		Namespace: "default",
+		UserID:    owner.ID,
+		Destinations: []splitEntity.Destination{
+			{URL: "https://v2.com", Weight: 70},
+			{URL: "https://v1.com", Weight: 30, Clicks: 1},
+		},
+	}, found)
+
+	_, err = db.FindSplit(ctx, "", "unknown")
+	require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+}
+
+func Test_PGDB_CountClicks_AcrossDST(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	// Europe/Berlin switches to summer time at 2024-03-31 01:00 UTC, local days differ from UTC ones
+	_, err := db.pool.Exec(ctx, `ALTER DATABASE shortener SET timezone TO 'Europe/Berlin'`)
+	require.NoError(t, err)
+	// Reconnect, so sessions use the new time zone
+	db.pool.(*pgxPool).Reset()
+
+	clicks := []time.Time{
+		time.Date(2024, 3, 30, 23, 30, 0, 0, time.UTC), // 2024-03-31 00:30 CET
+		time.Date(2024, 3, 31, 0, 30, 0, 0, time.UTC),  // 2024-03-31 01:30 CET
+		time.Date(2024, 3, 31, 1, 30, 0, 0, time.UTC),  // 2024-03-31 03:30 CEST
+		time.Date(2024, 3, 31, 22, 30, 0, 0, time.UTC), // 2024-04-01 00:30 CEST
+		time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC),
+	}
+	for _, clickedAt := range clicks {
+		require.NoError(t, db.SaveClick(ctx, &analyticsEntity.Click{Alias: "abc", ClickedAt: clickedAt, IPHash: "ip", UserAgentHash: "ua"}))
+	}
+	require.NoError(t, db.SaveClick(ctx, &analyticsEntity.Click{Alias: "other", ClickedAt: clicks[0], IPHash: "ip", UserAgentHash: "ua"}))
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		granularity string
+		want        []analyticsEntity.ClickCount
+	}{
+		{
+			granularity: analyticsEntity.GranularityHour,
+			want: []analyticsEntity.ClickCount{
+				{PeriodStart: time.Date(2024, 3, 30, 23, 0, 0, 0, time.UTC), Clicks: 1},
+				{PeriodStart: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), Clicks: 1},
+				{PeriodStart: time.Date(2024, 3, 31, 1, 0, 0, 0, time.UTC), Clicks: 1},
+				{PeriodStart: time.Date(2024, 3, 31, 22, 0, 0, 0, time.UTC), Clicks: 1},
+				{PeriodStart: time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC), Clicks: 1},
+			},
+		},
+		{
+			granularity: analyticsEntity.GranularityDay,
+			want: []analyticsEntity.ClickCount{
+				{PeriodStart: time.Date(2024, 3, 30, 0, 0, 0, 0, time.UTC), Clicks: 1},
+				{PeriodStart: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), Clicks: 3},
+				{PeriodStart: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), Clicks: 1},
+			},
+		},
+		{
+			granularity: analyticsEntity.GranularityWeek,
+			want: []analyticsEntity.ClickCount{
+				{PeriodStart: time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC), Clicks: 4},
+				{PeriodStart: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), Clicks: 1},
+			},
+		},
+		{
+			granularity: analyticsEntity.GranularityMonth,
+			want: []analyticsEntity.ClickCount{
+				{PeriodStart: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Clicks: 4},
+				{PeriodStart: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), Clicks: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.granularity, func(t *testing.T) {
+			counts, countErr := db.CountClicks(ctx, "", "abc", from, to, tt.granularity)
+			require.NoError(t, countErr)
+			require.Equal(t, tt.want, counts)
+		})
+	}
+
+	t.Run("when range excludes clicks", func(t *testing.T) {
+		counts, countErr := db.CountClicks(ctx, "", "abc", clicks[1], clicks[3], analyticsEntity.GranularityDay)
+		require.NoError(t, countErr)
+		require.Equal(t, []analyticsEntity.ClickCount{{PeriodStart: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), Clicks: 2}}, counts)
+	})
+}
+
+func Test_PGDB_FindUserClicks(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+	clickedAt := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	user, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+	require.False(t, user.CreatedAt.IsZero())
+
+	found, err := db.FindUser(ctx, user.ID)
+	require.NoError(t, err)
+	require.False(t, found.CreatedAt.IsZero())
+
+	other, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+
+	for alias, userID := range map[string]int{"mine1": user.ID, "mine2": user.ID, "other": other.ID} {
+		_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: alias, SourceURL: "https://ya.ru/" + alias, UserID: userID})
+		require.NoError(t, err)
+	}
+	for _, click := range []*analyticsEntity.Click{
+		{Alias: "mine2", ClickedAt: clickedAt.Add(time.Hour), IPHash: "ip", AnonymizedIP: "10.0.0.0", UserAgentHash: "ua"},
+		{Alias: "other", ClickedAt: clickedAt, IPHash: "ip", UserAgentHash: "ua"},
+		{Alias: "mine1", ClickedAt: clickedAt, IPHash: "ip", UserAgentHash: "ua"},
+	} {
+		require.NoError(t, db.SaveClick(ctx, click))
+	}
+
+	clicks, err := db.FindUserClicks(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, clicks, 2)
+	require.Equal(t, "mine1", clicks[0].Alias)
+	require.Equal(t, "mine2", clicks[1].Alias)
+	require.Equal(t, "10.0.0.0", clicks[1].AnonymizedIP)
+}
+
+func Test_PGDB_CountClicksByLocation(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+	clickedAt := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	london := analyticsEntity.GeoLocation{Country: "GB", City: "London", Lat: "51.5142", Lon: "-0.0931"}
+
+	for _, click := range []*analyticsEntity.Click{
+		{Alias: "abc", ClickedAt: clickedAt, IPHash: "ip", UserAgentHash: "ua", Location: london},
+		{Alias: "abc", ClickedAt: clickedAt, IPHash: "ip", UserAgentHash: "ua", Location: london},
+		{Alias: "abc", ClickedAt: clickedAt, IPHash: "ip", UserAgentHash: "ua", Location: analyticsEntity.GeoLocation{Country: "US", City: "New York"}},
+		{Alias: "abc", ClickedAt: clickedAt, IPHash: "ip", UserAgentHash: "ua"},
+		{Alias: "abc", ClickedAt: clickedAt.AddDate(0, -1, 0), IPHash: "ip", UserAgentHash: "ua", Location: london},
+		{Alias: "other", ClickedAt: clickedAt, IPHash: "ip", UserAgentHash: "ua", Location: london},
+	} {
+		require.NoError(t, db.SaveClick(ctx, click))
+	}
+
+	counts, err := db.CountClicksByLocation(ctx, "", "abc", clickedAt.AddDate(0, 0, -1), clickedAt.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Equal(t, []analyticsEntity.LocationCount{
+		{Country: "GB", City: "London", Clicks: 2},
+		{Clicks: 1},
+		{Country: "US", City: "New York", Clicks: 1},
+	}, counts)
+}
+
+func Test_PGDB_Namespaces(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+
+	_, err := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "abc", SourceURL: "https://ya.ru", Namespace: "team-a"})
+	require.ErrorIs(t, err, dbErrors.ErrDBReferenceNotFound)
+
+	saved, err := db.SaveNamespace(ctx, &namespaceEntity.Namespace{Name: "team-a"})
+	require.NoError(t, err)
+	require.False(t, saved.CreatedAt.IsZero())
+
+	_, err = db.SaveNamespace(ctx, &namespaceEntity.Namespace{Name: "team-a"})
+	require.ErrorIs(t, err, dbErrors.ErrDBIsNotUnique)
+
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "abc", SourceURL: "https://ya.ru"})
+	require.NoError(t, err)
+	_, err = db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "abc", SourceURL: "https://go.dev", Namespace: "team-a"})
+	require.NoError(t, err)
+
+	t.Run("when same URL is shortened in another namespace", func(t *testing.T) {
+		_, saveErr := db.SaveShortURL(ctx, &shortURLEntity.ShortURL{Alias: "dup", SourceURL: "https://ya.ru", Namespace: "team-a"})
+		require.NoError(t, saveErr)
+	})
+
+	found, err := db.FindShortURL(ctx, "", "abc")
+	require.NoError(t, err)
+	require.Equal(t, "https://ya.ru", found.SourceURL)
+	require.Equal(t, "default", found.Namespace)
+
+	found, err = db.FindShortURL(ctx, "team-a", "abc")
+	require.NoError(t, err)
+	require.Equal(t, "https://go.dev", found.SourceURL)
+
+	_, err = db.FindShortURL(ctx, "team-a", "missing")
+	require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+
+	namespaces, err := db.FindNamespaces(ctx)
+	require.NoError(t, err)
+	require.Len(t, namespaces, 2)
+	require.Equal(t, "default", namespaces[0].Name)
+	require.Equal(t, "team-a", namespaces[1].Name)
+
+	require.ErrorIs(t, db.DeleteNamespace(ctx, "team-a"), dbErrors.ErrDBRecordIsReferenced)
+	require.ErrorIs(t, db.DeleteNamespace(ctx, "missing"), dbErrors.ErrDBRecordNotFound)
+
+	_, err = db.SaveNamespace(ctx, &namespaceEntity.Namespace{Name: "empty"})
+	require.NoError(t, err)
+	require.NoError(t, db.DeleteNamespace(ctx, "empty"))
+}
+
+func Test_PGDB_NamespaceIsolation(t *testing.T) {
+	db := setupPGDB(t)
+	ctx := context.Background()
+	clickedAt := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	user, err := db.SaveUser(ctx)
+	require.NoError(t, err)
+	_, err = db.SaveNamespace(ctx, &namespaceEntity.Namespace{Name: "team-a"})
+	require.NoError(t, err)
+
+	for _, url := range []*shortURLEntity.ShortURL{
+		{Alias: "abc", SourceURL: "https://ya.ru", UserID: user.ID},
+		{Alias: "abc", SourceURL: "https://go.dev", Namespace: "team-a", UserID: user.ID},
+		{Alias: "once", SourceURL: "https://ya.ru/once", UserID: user.ID},
+		{Alias: "once", SourceURL: "https://go.dev/once", Namespace: "team-a", UserID: user.ID},
+	} {
+		_, err = db.SaveShortURL(ctx, url)
+		require.NoError(t, err)
+	}
+
+	t.Run("when one-time URL is consumed in namespace", func(t *testing.T) {
+		require.NoError(t, db.MarkURLAsDeleted(ctx, 0, "team-a", []string{"once"}))
+
+		found, findErr := db.FindShortURL(ctx, "", "once")
+		require.NoError(t, findErr)
+		require.False(t, found.IsDeleted)
+	})
+
+	t.Run("when URL is deleted and restored in namespace", func(t *testing.T) {
+		require.NoError(t, db.MarkURLAsDeleted(ctx, user.ID, "team-a", []string{"abc"}))
+
+		found, findErr := db.FindShortURL(ctx, "", "abc")
+		require.NoError(t, findErr)
+		require.False(t, found.IsDeleted)
+
+		require.NoError(t, db.RestoreURL(ctx, user.ID, "team-a", "abc"))
+	})
+
+	t.Run("when URL target is updated in namespace", func(t *testing.T) {
+		require.NoError(t, db.UpdateURLTarget(ctx, user.ID, "team-a", "abc", "https://go.dev/doc"))
+
+		found, findErr := db.FindShortURL(ctx, "", "abc")
+		require.NoError(t, findErr)
+		require.Equal(t, "https://ya.ru", found.SourceURL)
+
+		history, findErr := db.FindURLHistory(ctx, "", "abc")
+		require.NoError(t, findErr)
+		require.Empty(t, history)
+
+		history, findErr = db.FindURLHistory(ctx, "team-a", "abc")
+		require.NoError(t, findErr)
+		require.Len(t, history, 1)
+	})
+
+	t.Run("when tag is assigned in namespace", func(t *testing.T) {
+		_, saveErr := db.SaveTag(ctx, &tagEntity.Tag{UserID: user.ID, Name: "work"})
+		require.NoError(t, saveErr)
+		require.NoError(t, db.AssignTag(ctx, user.ID, "team-a", "abc", "work"))
+
+		tags, findErr := db.FindURLTags(ctx, user.ID, "", "abc")
+		require.NoError(t, findErr)
+		require.Empty(t, tags)
+
+		tags, findErr = db.FindURLTags(ctx, user.ID, "team-a", "abc")
+		require.NoError(t, findErr)
+		require.Len(t, tags, 1)
+	})
+
+	t.Run("when URL is clicked in namespace", func(t *testing.T) {
+		require.NoError(t, db.SaveClick(ctx, &analyticsEntity.Click{Alias: "abc", Namespace: "team-a", ClickedAt: clickedAt, IPHash: "ip", UserAgentHash: "ua"}))
+
+		counts, countErr := db.CountClicks(ctx, "", "abc", clickedAt.AddDate(0, 0, -1), clickedAt.AddDate(0, 0, 1), analyticsEntity.GranularityDay)
+		require.NoError(t, countErr)
+		require.Empty(t, counts)
+
+		counts, countErr = db.CountClicks(ctx, "team-a", "abc", clickedAt.AddDate(0, 0, -1), clickedAt.AddDate(0, 0, 1), analyticsEntity.GranularityDay)
+		require.NoError(t, countErr)
+		require.Len(t, counts, 1)
+	})
+
+	t.Run("when split is created in namespace", func(t *testing.T) {
+		split := &splitEntity.MultiDestination{
+			Alias:     "ab-test",
+			Namespace: "team-a",
+			UserID:    user.ID,
+			Destinations: []splitEntity.Destination{
+				{URL: "https://v1.com", Weight: 50},
+				{URL: "https://v2.com", Weight: 50},
+			},
+		}
+		require.NoError(t, db.SaveSplit(ctx, split))
+
+		_, findErr := db.FindSplit(ctx, "", "ab-test")
+		require.ErrorIs(t, findErr, dbErrors.ErrDBRecordNotFound)
+
+		found, findErr := db.FindSplit(ctx, "team-a", "ab-test")
+		require.NoError(t, findErr)
+		require.Equal(t, "team-a", found.Namespace)
+
+		require.ErrorIs(t, db.SaveSplit(ctx, &splitEntity.MultiDestination{Alias: "ab-test", Namespace: "missing", UserID: user.ID, Destinations: split.Destinations}), dbErrors.ErrDBReferenceNotFound)
+	})
+}
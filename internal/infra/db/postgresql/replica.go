@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gururuby/shortener/internal/config"
+	healthEntity "github.com/gururuby/shortener/internal/domain/entity/health"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/gururuby/shortener/internal/infra/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// newReplicaPool creates a connection pool of the read replica.
+// Pool settings and connection retries are the same as for the primary.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - cfg: Database configuration with the read replica enabled
+// Returns:
+// - *pgxpool.Pool: Connection pool of the replica
+// - error: dbErrors.ErrDBReplicaDSNMissing if replica DSN is empty, other error if connection fails
+func newReplicaPool(ctx context.Context, cfg config.Database) (*pgxpool.Pool, error) {
+	if cfg.ReadReplicaDSN == "" {
+		return nil, dbErrors.ErrDBReplicaDSNMissing
+	}
+
+	cfg.DSN = cfg.ReadReplicaDSN
+	return newDBPool(ctx, cfg)
+}
+
+// read runs a read-only query on the read replica, or on the primary if the replica is disabled.
+// If the replica fails, the query is retried on the primary and the failure is logged as a warning.
+// Missing rows and done context are returned as is, they would fail on the primary too.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - query: Function running the query on the given pool, it may be called twice
+// Returns:
+// - error: Error of the last query attempt
+func (db *PGDB) read(ctx context.Context, query func(pool PGDBPool) error) error {
+	if db.readPool == nil {
+		return query(db.pool)
+	}
+
+	err := query(db.readPool)
+	if err == nil || errors.Is(err, pgx.ErrNoRows) || ctx.Err() != nil {
+		return err
+	}
+
+	logger.Log.Warn("Read replica query failed, falling back to primary", zap.Error(err))
+	return query(db.pool)
+}
+
+// HealthCheck pings the primary and the read replica separately.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - write: Whether the primary is available
+// - read: Whether reads can be served, by the replica or, if it is disabled, by the primary
+func (db *PGDB) HealthCheck(ctx context.Context) (write bool, read bool) {
+	write = db.pool.Ping(ctx) == nil
+	if db.readPool == nil {
+		return write, write
+	}
+	return write, db.readPool.Ping(ctx) == nil
+}
+
+// ReplicaHealth pings the read replica and collects statistics of its connection pool.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - *healthEntity.DBReplicaHealth: Replica availability and pool statistics, nil if the replica is disabled
+func (db *PGDB) ReplicaHealth(ctx context.Context) *healthEntity.DBReplicaHealth {
+	if db.readPool == nil {
+		return nil
+	}
+
+	return &healthEntity.DBReplicaHealth{
+		Available: db.readPool.Ping(ctx) == nil,
+		Pool:      db.readPool.PoolStats(),
+	}
+}
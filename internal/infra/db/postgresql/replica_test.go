@@ -0,0 +1,208 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gururuby/shortener/internal/config"
+	healthEntity "github.com/gururuby/shortener/internal/domain/entity/health"
+	dbErrors "github.com/gururuby/shortener/internal/infra/db/errors"
+	"github.com/gururuby/shortener/internal/infra/db/postgresql/mocks"
+	"github.com/gururuby/shortener/internal/infra/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// stubRow returns the error from Scan without filling destinations.
+type stubRow struct {
+	err error
+}
+
+func (r stubRow) Scan(...any) error {
+	return r.err
+}
+
+var errReplicaDown = errors.New("connection refused")
+
+// newReplicatedPGDB creates PGDB over mocked primary and replica pools.
+func newReplicatedPGDB(t *testing.T) (*PGDB, *mocks.MockPGDBPool, *mocks.MockPGDBPool) {
+	t.Helper()
+
+	logger.Setup("test", "error")
+
+	ctrl := gomock.NewController(t)
+	writePool := mocks.NewMockPGDBPool(ctrl)
+	readPool := mocks.NewMockPGDBPool(ctrl)
+
+	return &PGDB{pool: writePool, readPool: readPool}, writePool, readPool
+}
+
+func Test_PGDB_ReadsUseReplica(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("when FindShortURL is called", func(t *testing.T) {
+		db, _, readPool := newReplicatedPGDB(t)
+		readPool.EXPECT().QueryRow(ctx, findShortURLQuery, "default", "alias").Return(stubRow{})
+
+		_, err := db.FindShortURL(ctx, "", "alias")
+		require.NoError(t, err)
+	})
+
+	t.Run("when FindUser is called", func(t *testing.T) {
+		db, _, readPool := newReplicatedPGDB(t)
+		readPool.EXPECT().QueryRow(ctx, findUserQuery, 1).Return(stubRow{})
+
+		_, err := db.FindUser(ctx, 1)
+		require.NoError(t, err)
+	})
+
+	t.Run("when short URL is not found on replica", func(t *testing.T) {
+		db, _, readPool := newReplicatedPGDB(t)
+		readPool.EXPECT().QueryRow(ctx, findShortURLQuery, "default", "alias").Return(stubRow{err: pgx.ErrNoRows})
+
+		_, err := db.FindShortURL(ctx, "", "alias")
+		require.ErrorIs(t, err, dbErrors.ErrDBRecordNotFound)
+	})
+}
+
+func Test_PGDB_WritesUsePrimary(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("when SaveUser is called", func(t *testing.T) {
+		db, writePool, _ := newReplicatedPGDB(t)
+		writePool.EXPECT().QueryRow(ctx, saveUserQuery).Return(stubRow{})
+
+		_, err := db.SaveUser(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("when MarkURLAsDeleted is called", func(t *testing.T) {
+		db, writePool, _ := newReplicatedPGDB(t)
+		writePool.EXPECT().Exec(ctx, markURLsAsDeletedQuery, 1, "default", []string{"alias"}).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+
+		require.NoError(t, db.MarkURLAsDeleted(ctx, 1, "", []string{"alias"}))
+	})
+}
+
+func Test_PGDB_ReplicaFallback(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("when replica fails on FindShortURL", func(t *testing.T) {
+		db, writePool, readPool := newReplicatedPGDB(t)
+		gomock.InOrder(
+			readPool.EXPECT().QueryRow(ctx, findShortURLQuery, "default", "alias").Return(stubRow{err: errReplicaDown}),
+			writePool.EXPECT().QueryRow(ctx, findShortURLQuery, "default", "alias").Return(stubRow{}),
+		)
+
+		_, err := db.FindShortURL(ctx, "", "alias")
+		require.NoError(t, err)
+	})
+
+	t.Run("when replica fails on FindUser", func(t *testing.T) {
+		db, writePool, readPool := newReplicatedPGDB(t)
+		gomock.InOrder(
+			readPool.EXPECT().QueryRow(ctx, findUserQuery, 1).Return(stubRow{err: errReplicaDown}),
+			writePool.EXPECT().QueryRow(ctx, findUserQuery, 1).Return(stubRow{}),
+		)
+
+		_, err := db.FindUser(ctx, 1)
+		require.NoError(t, err)
+	})
+
+	t.Run("when replica and primary fail on FindUserURLs", func(t *testing.T) {
+		db, writePool, readPool := newReplicatedPGDB(t)
+		gomock.InOrder(
+			readPool.EXPECT().Query(ctx, findUserURLsQuery, 1).Return(nil, errReplicaDown),
+			writePool.EXPECT().Query(ctx, findUserURLsQuery, 1).Return(nil, errReplicaDown),
+		)
+
+		_, err := db.FindUserURLs(ctx, 1)
+		require.ErrorIs(t, err, dbErrors.ErrDBQuery)
+	})
+
+	t.Run("when context is done", func(t *testing.T) {
+		db, _, readPool := newReplicatedPGDB(t)
+		canceledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		readPool.EXPECT().QueryRow(canceledCtx, findUserQuery, 1).Return(stubRow{err: context.Canceled})
+
+		_, err := db.FindUser(canceledCtx, 1)
+		require.ErrorIs(t, err, dbErrors.ErrDBQuery)
+	})
+}
+
+func Test_PGDB_HealthCheck(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		writeErr  error
+		readErr   error
+		name      string
+		wantWrite bool
+		wantRead  bool
+	}{
+		{
+			name:      "when both pools are available",
+			wantWrite: true,
+			wantRead:  true,
+		},
+		{
+			name:      "when replica is down",
+			readErr:   errReplicaDown,
+			wantWrite: true,
+		},
+		{
+			name:     "when primary is down",
+			writeErr: errReplicaDown,
+			wantRead: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, writePool, readPool := newReplicatedPGDB(t)
+			writePool.EXPECT().Ping(ctx).Return(tt.writeErr)
+			readPool.EXPECT().Ping(ctx).Return(tt.readErr)
+
+			write, read := db.HealthCheck(ctx)
+			require.Equal(t, tt.wantWrite, write)
+			require.Equal(t, tt.wantRead, read)
+		})
+	}
+
+	t.Run("when replica is disabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		writePool := mocks.NewMockPGDBPool(ctrl)
+		db := &PGDB{pool: writePool}
+		writePool.EXPECT().Ping(ctx).Return(nil)
+
+		write, read := db.HealthCheck(ctx)
+		require.True(t, write)
+		require.True(t, read)
+	})
+}
+
+func Test_PGDB_ReplicaHealth(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("when replica is enabled", func(t *testing.T) {
+		db, _, readPool := newReplicatedPGDB(t)
+		stats := &healthEntity.DBPoolStats{OpenConnections: 3, IdleConnections: 1}
+		readPool.EXPECT().Ping(ctx).Return(errReplicaDown)
+		readPool.EXPECT().PoolStats().Return(stats)
+
+		require.Equal(t, &healthEntity.DBReplicaHealth{Pool: stats}, db.ReplicaHealth(ctx))
+	})
+
+	t.Run("when replica is disabled", func(t *testing.T) {
+		require.Nil(t, (&PGDB{}).ReplicaHealth(ctx))
+	})
+}
+
+func Test_newReplicaPool_DSNMissing(t *testing.T) {
+	_, err := newReplicaPool(context.Background(), config.Database{DSN: "postgres://localhost/shortener", ReadReplicaEnabled: true})
+	require.ErrorIs(t, err, dbErrors.ErrDBReplicaDSNMissing)
+}
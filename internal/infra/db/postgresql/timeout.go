@@ -0,0 +1,164 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// timeoutPool wraps PGDBPool limiting duration of every database call,
+// so storage operations do not hang if the PostgreSQL server stalls.
+type timeoutPool struct {
+	PGDBPool
+	queryTimeout time.Duration // Maximum duration of a single database call
+}
+
+// newTimeoutPool wraps the pool limiting duration of every database call.
+// Parameters:
+// - pool: Connection pool to wrap
+// - queryTimeout: Maximum duration of a single database call, calls are not limited if not positive
+// Returns:
+// - PGDBPool: Pool applying the timeout
+func newTimeoutPool(pool PGDBPool, queryTimeout time.Duration) PGDBPool {
+	if queryTimeout <= 0 {
+		return pool
+	}
+	return &timeoutPool{PGDBPool: pool, queryTimeout: queryTimeout}
+}
+
+// Exec executes a SQL command within the query timeout.
+func (p *timeoutPool) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	ctx, cancel := withQueryTimeout(ctx, p.queryTimeout)
+	defer cancel()
+	return p.PGDBPool.Exec(ctx, sql, arguments...)
+}
+
+// Query executes a SQL query, the timeout covers reading of rows until they are closed.
+func (p *timeoutPool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	ctx, cancel := withQueryTimeout(ctx, p.queryTimeout)
+	rows, err := p.PGDBPool.Query(ctx, sql, args...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &timeoutRows{Rows: rows, cancel: cancel}, nil
+}
+
+// QueryRow executes a SQL query, the timeout covers scanning of the row.
+func (p *timeoutPool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	ctx, cancel := withQueryTimeout(ctx, p.queryTimeout)
+	return &timeoutRow{row: p.PGDBPool.QueryRow(ctx, sql, args...), cancel: cancel}
+}
+
+// Begin starts a transaction, every statement of which is executed within the query timeout.
+func (p *timeoutPool) Begin(ctx context.Context) (pgx.Tx, error) {
+	ctx, cancel := withQueryTimeout(ctx, p.queryTimeout)
+	defer cancel()
+
+	tx, err := p.PGDBPool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &timeoutTx{Tx: tx, queryTimeout: p.queryTimeout}, nil
+}
+
+// Ping checks if the database is available within the query timeout.
+func (p *timeoutPool) Ping(ctx context.Context) error {
+	ctx, cancel := withQueryTimeout(ctx, p.queryTimeout)
+	defer cancel()
+	return p.PGDBPool.Ping(ctx)
+}
+
+// timeoutTx wraps pgx.Tx limiting duration of its statements.
+type timeoutTx struct {
+	pgx.Tx
+	queryTimeout time.Duration // Maximum duration of a single statement
+}
+
+// Exec executes a SQL command within the query timeout.
+func (tx *timeoutTx) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	ctx, cancel := withQueryTimeout(ctx, tx.queryTimeout)
+	defer cancel()
+	return tx.Tx.Exec(ctx, sql, arguments...)
+}
+
+// Query executes a SQL query, the timeout covers reading of rows until they are closed.
+func (tx *timeoutTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	ctx, cancel := withQueryTimeout(ctx, tx.queryTimeout)
+	rows, err := tx.Tx.Query(ctx, sql, args...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &timeoutRows{Rows: rows, cancel: cancel}, nil
+}
+
+// QueryRow executes a SQL query, the timeout covers scanning of the row.
+func (tx *timeoutTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	ctx, cancel := withQueryTimeout(ctx, tx.queryTimeout)
+	return &timeoutRow{row: tx.Tx.QueryRow(ctx, sql, args...), cancel: cancel}
+}
+
+// Commit commits the transaction within the query timeout.
+func (tx *timeoutTx) Commit(ctx context.Context) error {
+	ctx, cancel := withQueryTimeout(ctx, tx.queryTimeout)
+	defer cancel()
+	return tx.Tx.Commit(ctx)
+}
+
+// Rollback rolls back the transaction within the query timeout.
+func (tx *timeoutTx) Rollback(ctx context.Context) error {
+	ctx, cancel := withQueryTimeout(ctx, tx.queryTimeout)
+	defer cancel()
+	return tx.Tx.Rollback(ctx)
+}
+
+// timeoutRows releases the query timeout when rows are read or closed.
+type timeoutRows struct {
+	pgx.Rows
+	cancel context.CancelFunc // Releases the query timeout
+}
+
+// Next prepares the next row for reading, the timeout is released after the last one.
+func (r *timeoutRows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+	r.cancel()
+	return false
+}
+
+// Close closes the rows and releases the query timeout.
+func (r *timeoutRows) Close() {
+	r.Rows.Close()
+	r.cancel()
+}
+
+// timeoutRow releases the query timeout when the row is scanned.
+type timeoutRow struct {
+	row    pgx.Row            // Row of the query
+	cancel context.CancelFunc // Releases the query timeout
+}
+
+// Scan reads values of the row and releases the query timeout.
+func (r *timeoutRow) Scan(dest ...any) error {
+	defer r.cancel()
+	return r.row.Scan(dest...)
+}
+
+// withQueryTimeout limits the context by the query timeout.
+// The context is kept as is if its own deadline is earlier.
+// Parameters:
+// - ctx: Context of the database call
+// - queryTimeout: Maximum duration of the call
+// Returns:
+// - context.Context: Context with deadline not later than queryTimeout from now
+// - context.CancelFunc: Releases resources of the context
+func withQueryTimeout(ctx context.Context, queryTimeout time.Duration) (context.Context, context.CancelFunc) {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= queryTimeout {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, queryTimeout)
+}
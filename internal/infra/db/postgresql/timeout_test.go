@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gururuby/shortener/internal/infra/db/postgresql/mocks"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// deadlineRow captures the context of QueryRow to check it after Scan.
+type deadlineRow struct {
+	ctx context.Context
+}
+
+func (r deadlineRow) Scan(...any) error {
+	return r.ctx.Err()
+}
+
+func Test_timeoutPool(t *testing.T) {
+	const queryTimeout = 100 * time.Millisecond
+
+	tests := []struct {
+		name         string
+		ctxTimeout   time.Duration
+		wantDeadline time.Duration
+	}{
+		{
+			name:         "when context has later deadline",
+			ctxTimeout:   5 * time.Minute,
+			wantDeadline: queryTimeout,
+		},
+		{
+			name:         "when context has no deadline",
+			wantDeadline: queryTimeout,
+		},
+		{
+			name:         "when context has earlier deadline",
+			ctxTimeout:   50 * time.Millisecond,
+			wantDeadline: 50 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			pool := mocks.NewMockPGDBPool(ctrl)
+			db := &PGDB{pool: newTimeoutPool(pool, queryTimeout)}
+
+			ctx := context.Background()
+			if tt.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.ctxTimeout)
+				defer cancel()
+			}
+
+			var deadline time.Time
+			pool.EXPECT().Exec(gomock.Any(), markURLsAsDeletedQuery, 1, "default", []string{"abc"}).DoAndReturn(
+				func(ctx context.Context, _ string, _ ...any) (pgconn.CommandTag, error) {
+					var ok bool
+					deadline, ok = ctx.Deadline()
+					require.True(t, ok)
+					return pgconn.NewCommandTag("UPDATE 1"), nil
+				})
+
+			calledAt := time.Now()
+			require.NoError(t, db.MarkURLAsDeleted(ctx, 1, "", []string{"abc"}))
+
+			require.False(t, deadline.After(calledAt.Add(tt.wantDeadline+10*time.Millisecond)))
+			require.True(t, deadline.After(calledAt.Add(tt.wantDeadline-10*time.Millisecond)))
+		})
+	}
+}
+
+func Test_timeoutPool_QueryRow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	pool := mocks.NewMockPGDBPool(ctrl)
+	p := newTimeoutPool(pool, time.Minute)
+
+	var queryCtx context.Context
+	pool.EXPECT().QueryRow(gomock.Any(), findUserQuery, 1).DoAndReturn(
+		func(ctx context.Context, _ string, _ ...any) pgx.Row {
+			queryCtx = ctx
+			return deadlineRow{ctx: ctx}
+		})
+
+	row := p.QueryRow(context.Background(), findUserQuery, 1)
+	require.NoError(t, queryCtx.Err(), "timeout must not be released before scan")
+	require.NoError(t, row.Scan())
+	require.ErrorIs(t, queryCtx.Err(), context.Canceled, "timeout must be released after scan")
+}
+
+func Test_newTimeoutPool_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	pool := mocks.NewMockPGDBPool(ctrl)
+
+	require.Equal(t, PGDBPool(pool), newTimeoutPool(pool, 0))
+}
@@ -0,0 +1,127 @@
+/*
+Package eventbus provides in-process delivery of short URL lifecycle events.
+
+It features:
+- Fire-and-forget publishing which never blocks the publisher
+- Buffered queue and worker goroutine per subscriber, so slow subscribers don't delay others
+- Counting of events dropped because a subscriber queue is full
+- Draining of queued events on close
+*/
+package eventbus
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultQueueSize is the number of events queued for a subscriber before new events are dropped.
+const DefaultQueueSize = 100
+
+// Event is a message published to the bus.
+type Event interface {
+	// Type returns the name subscribers subscribe to
+	Type() string
+}
+
+// subscriber is a handler with its queue of undelivered events.
+type subscriber struct {
+	handler func(Event) // Handler called for every event of the queue
+	queue   chan Event  // Events waiting for the handler
+}
+
+// EventBus delivers published events to subscribers of their type.
+type EventBus struct {
+	subscribers map[string][]*subscriber // Subscribers by event type
+	mu          sync.RWMutex             // Guards subscribers and closed
+	wg          sync.WaitGroup           // Running subscriber workers
+	dropped     atomic.Uint64            // Number of events dropped on full queues
+	queueSize   int                      // Capacity of subscriber queues
+	closed      bool                     // Whether the bus is closed
+}
+
+// New creates an event bus.
+// Parameters:
+// - queueSize: Capacity of subscriber queues, DefaultQueueSize if not positive
+// Returns:
+// - *EventBus: Bus ready for subscriptions, Close must be called to stop its workers
+func New(queueSize int) *EventBus {
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+	return &EventBus{
+		subscribers: make(map[string][]*subscriber),
+		queueSize:   queueSize,
+	}
+}
+
+// Subscribe registers the handler for events of the type.
+// Handlers of a subscriber are called one by one in its own goroutine,
+// so a handler doing slow work should start it in background.
+// Subscriptions to a closed bus are ignored.
+// Parameters:
+// - eventType: Type of events to handle
+// - handler: Function called for every published event of the type
+func (b *EventBus) Subscribe(eventType string, handler func(Event)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	sub := &subscriber{handler: handler, queue: make(chan Event, b.queueSize)}
+	b.subscribers[eventType] = append(b.subscribers[eventType], sub)
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		for event := range sub.queue {
+			sub.handler(event)
+		}
+	}()
+}
+
+// Publish queues the event for all subscribers of its type without waiting for them.
+// The event is dropped for subscribers whose queue is full, and after the bus is closed.
+// Parameters:
+// - event: Published event
+func (b *EventBus) Publish(event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return
+	}
+
+	for _, sub := range b.subscribers[event.Type()] {
+		select {
+		case sub.queue <- event:
+		default:
+			b.dropped.Add(1)
+		}
+	}
+}
+
+// Dropped returns the number of events dropped because subscriber queues were full.
+// Returns:
+// - uint64: Number of dropped events since the bus was created
+func (b *EventBus) Dropped() uint64 {
+	return b.dropped.Load()
+}
+
+// Close stops accepting events and waits until subscribers handle all queued events.
+// Calling Close more than once is safe.
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		for _, subs := range b.subscribers {
+			for _, sub := range subs {
+				close(sub.queue)
+			}
+		}
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
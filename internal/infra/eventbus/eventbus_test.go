@@ -0,0 +1,92 @@
+package eventbus
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EventBus_Publish(t *testing.T) {
+	t.Run("when events are published", func(t *testing.T) {
+		bus := New(DefaultQueueSize)
+
+		var (
+			wg       sync.WaitGroup
+			mu       sync.Mutex
+			received = map[string][]Event{}
+		)
+		subscribe := func(name, eventType string) {
+			bus.Subscribe(eventType, func(event Event) {
+				defer wg.Done()
+				mu.Lock()
+				defer mu.Unlock()
+				received[name] = append(received[name], event)
+			})
+		}
+		subscribe("webhooks", TypeURLCreated)
+		subscribe("webhooks", TypeURLClicked)
+		subscribe("clicks", TypeURLClicked)
+
+		events := []Event{
+			URLCreated{Alias: "abc", OriginalURL: "https://ya.ru", UserID: 1},
+			URLClicked{Alias: "abc", IP: "203.0.113.7", UserAgent: "curl/8.0"},
+			URLDeleted{Alias: "abc", UserID: 1},
+		}
+		wg.Add(3)
+		for _, event := range events {
+			bus.Publish(event)
+		}
+		wg.Wait()
+		bus.Close()
+
+		assert.ElementsMatch(t, events[:2], received["webhooks"])
+		assert.Equal(t, []Event{events[1]}, received["clicks"])
+		assert.Zero(t, bus.Dropped())
+	})
+
+	t.Run("when bus is closed", func(t *testing.T) {
+		bus := New(DefaultQueueSize)
+
+		var received []Event
+		bus.Subscribe(TypeURLCreated, func(event Event) {
+			received = append(received, event)
+		})
+		for range 10 {
+			bus.Publish(URLCreated{Alias: "abc"})
+		}
+		bus.Close()
+		bus.Publish(URLCreated{Alias: "def"})
+		bus.Subscribe(TypeURLCreated, func(Event) {
+			t.Error("subscriber of closed bus is called")
+		})
+		bus.Close()
+
+		assert.Len(t, received, 10)
+	})
+
+	t.Run("when subscriber queue is full", func(t *testing.T) {
+		bus := New(2)
+
+		started, release := make(chan struct{}), make(chan struct{})
+		var calls int
+		bus.Subscribe(TypeURLClicked, func(Event) {
+			if calls == 0 {
+				close(started)
+				<-release
+			}
+			calls++
+		})
+
+		bus.Publish(URLClicked{Alias: "abc"})
+		<-started
+		for range 5 {
+			bus.Publish(URLClicked{Alias: "abc"})
+		}
+		close(release)
+		bus.Close()
+
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, uint64(3), bus.Dropped())
+	})
+}
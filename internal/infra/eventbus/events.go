@@ -0,0 +1,48 @@
+package eventbus
+
+// Types of short URL lifecycle events
+const (
+	TypeURLCreated = "url.created" // Short URL is created
+	TypeURLDeleted = "url.deleted" // Short URL is deleted
+	TypeURLClicked = "url.clicked" // Short URL redirect is served
+)
+
+// URLCreated is published when a short URL is created.
+type URLCreated struct {
+	Alias       string // Alias of the short URL
+	OriginalURL string // URL the short URL redirects to
+	Namespace   string // Namespace of the short URL, empty for the default one
+	UserID      int    // Owner of the short URL, 0 for anonymous
+}
+
+// Type returns TypeURLCreated.
+func (URLCreated) Type() string {
+	return TypeURLCreated
+}
+
+// URLDeleted is published when a short URL is deleted.
+type URLDeleted struct {
+	Alias     string // Alias of the short URL
+	Namespace string // Namespace of the short URL, empty for the default one
+	UserID    int    // Owner of the short URL, 0 for anonymous
+}
+
+// Type returns TypeURLDeleted.
+func (URLDeleted) Type() string {
+	return TypeURLDeleted
+}
+
+// URLClicked is published when a short URL redirect is served.
+type URLClicked struct {
+	Alias       string // Alias of the short URL
+	OriginalURL string // URL the client is redirected to, empty if unknown
+	Namespace   string // Namespace of the short URL, empty for the default one
+	IP          string // IP of the client
+	UserAgent   string // User-Agent of the client
+	UserID      int    // Owner of the short URL, 0 for anonymous or unknown
+}
+
+// Type returns TypeURLClicked.
+func (URLClicked) Type() string {
+	return TypeURLClicked
+}
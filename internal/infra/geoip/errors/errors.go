@@ -0,0 +1,38 @@
+// Package errors defines errors of IP geolocation.
+package errors
+
+import "errors"
+
+// Error definitions for IP geolocation.
+var (
+	// ErrGeoIPInvalidDatabase indicates that the geolocation database cannot be read.
+	//
+	// Typical cases:
+	// - File is not a MaxMind DB, e.g. it is truncated or compressed
+	// - Database is not a City database, e.g. GeoLite2-ASN
+	// - Search tree points outside of the data section
+	//
+	// Handling recommendations:
+	// - Check ANALYTICS_GEOIP_DB points to unpacked .mmdb file
+	// - Download the database again
+	ErrGeoIPInvalidDatabase = errors.New("invalid geolocation database")
+
+	// ErrGeoIPInvalidIP indicates that the client IP cannot be parsed.
+	//
+	// Typical cases:
+	// - Empty IP of a request received over a unix socket
+	// - Forwarding headers set to a host name by a proxy
+	//
+	// Handling recommendations:
+	// - Treat the location as unknown
+	ErrGeoIPInvalidIP = errors.New("invalid IP")
+
+	// ErrGeoIPClosed indicates a lookup after the database was closed.
+	//
+	// Typical cases:
+	// - Click recorded in background while the service shuts down
+	//
+	// Handling recommendations:
+	// - Treat the location as unknown
+	ErrGeoIPClosed = errors.New("geolocation database is closed")
+)
@@ -0,0 +1,127 @@
+/*
+Package geoip resolves locations of clients by their IP addresses.
+
+It provides:
+- Resolver reading MaxMind GeoLite2/GeoIP2 City databases with geoip2 reader
+- Null resolver used when no database is configured
+
+The database file is memory-mapped once on startup, lookups do not read the file
+and are safe for concurrent use, also with Close.
+*/
+package geoip
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	geoErrors "github.com/gururuby/shortener/internal/infra/geoip/errors"
+	"github.com/oschwald/geoip2-golang"
+)
+
+// Resolver defines the interface for resolving client locations.
+type Resolver interface {
+	// Resolve returns the location of the IP, empty if it is unknown
+	Resolve(ip string) (*entity.GeoLocation, error)
+}
+
+// NullResolver is a Resolver for instances without geolocation database.
+type NullResolver struct{}
+
+// Resolve always returns empty location.
+// Parameters:
+// - ip: Client IP (ignored)
+// Returns:
+// - *entity.GeoLocation: Empty location
+// - error: Always nil
+func (NullResolver) Resolve(_ string) (*entity.GeoLocation, error) {
+	return &entity.GeoLocation{}, nil
+}
+
+// Close does nothing, there is no database to release.
+// Returns:
+// - error: Always nil
+func (NullResolver) Close() error {
+	return nil
+}
+
+// MaxMindResolver resolves locations with MaxMind City database.
+type MaxMindResolver struct {
+	reader *geoip2.Reader // Reader of the mapped database
+	mu     sync.RWMutex   // Prevents unmapping of the file during lookups
+	closed bool           // Whether the file is unmapped
+}
+
+// Open maps the MaxMind DB file into memory and creates a resolver reading it.
+// Parameters:
+// - path: Path to GeoLite2-City or GeoIP2-City database in .mmdb format
+// Returns:
+// - *MaxMindResolver: Resolver, Close must be called to unmap the file
+// - error: If the file cannot be opened, or ErrGeoIPInvalidDatabase
+func Open(path string) (*MaxMindResolver, error) {
+	reader, err := geoip2.Open(path)
+	if err != nil {
+		if _, statErr := os.Stat(path); statErr != nil {
+			return nil, statErr
+		}
+		return nil, fmt.Errorf("%w: %w", geoErrors.ErrGeoIPInvalidDatabase, err)
+	}
+
+	return &MaxMindResolver{reader: reader}, nil
+}
+
+// Resolve looks up the location of the IP.
+// Parameters:
+// - ip: IPv4 or IPv6 address of the client
+// Returns:
+// - *entity.GeoLocation: Country, city and coordinates, empty if the IP is not in the database
+// - error: ErrGeoIPInvalidIP, ErrGeoIPInvalidDatabase or ErrGeoIPClosed
+func (m *MaxMindResolver) Resolve(ip string) (*entity.GeoLocation, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return nil, geoErrors.ErrGeoIPInvalidIP
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.closed {
+		return nil, geoErrors.ErrGeoIPClosed
+	}
+
+	record, err := m.reader.City(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", geoErrors.ErrGeoIPInvalidDatabase, err)
+	}
+
+	location := &entity.GeoLocation{
+		Country: record.Country.IsoCode,
+		City:    record.City.Names["en"],
+	}
+	// Records of IPs located by country only have no coordinates, which are decoded as zeros
+	if record.Location.Latitude != 0 || record.Location.Longitude != 0 {
+		location.Lat = strconv.FormatFloat(record.Location.Latitude, 'f', -1, 64)
+		location.Lon = strconv.FormatFloat(record.Location.Longitude, 'f', -1, 64)
+	}
+
+	return location, nil
+}
+
+// Close unmaps the database file after running lookups finish.
+// Later lookups fail with ErrGeoIPClosed, so clicks recorded during shutdown get unknown location.
+// Returns:
+// - error: If the file cannot be unmapped
+func (m *MaxMindResolver) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil
+	}
+	m.closed = true
+
+	return m.reader.Close()
+}
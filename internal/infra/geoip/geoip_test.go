@@ -0,0 +1,112 @@
+package geoip
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/analytics"
+	geoErrors "github.com/gururuby/shortener/internal/infra/geoip/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDBPath is City database with the same records as MaxMind GeoIP2-City-Test.mmdb has for its IPs.
+const testDBPath = "testdata/GeoIP2-City-Test.mmdb"
+
+func Test_MaxMindResolver_Resolve(t *testing.T) {
+	resolver, err := Open(testDBPath)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, resolver.Close())
+	}()
+
+	tests := []struct {
+		want    *entity.GeoLocation
+		wantErr error
+		name    string
+		ip      string
+	}{
+		{
+			name: "when IP is in London",
+			ip:   "81.2.69.142",
+			want: &entity.GeoLocation{Country: "GB", City: "London", Lat: "51.5142", Lon: "-0.0931"},
+		},
+		{
+			name: "when IP is in Milton",
+			ip:   "216.160.83.60",
+			want: &entity.GeoLocation{Country: "US", City: "Milton", Lat: "47.2513", Lon: "-122.3149"},
+		},
+		{
+			name: "when IPv4-mapped IPv6 address is in London",
+			ip:   "::ffff:81.2.69.143",
+			want: &entity.GeoLocation{Country: "GB", City: "London", Lat: "51.5142", Lon: "-0.0931"},
+		},
+		{
+			name: "when only country of IPv6 address is known",
+			ip:   "2001:218::1",
+			want: &entity.GeoLocation{Country: "JP"},
+		},
+		{
+			name: "when IP is not in database",
+			ip:   "127.0.0.1",
+			want: &entity.GeoLocation{},
+		},
+		{
+			name:    "when IP is invalid",
+			ip:      "localhost",
+			wantErr: geoErrors.ErrGeoIPInvalidIP,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, resolveErr := resolver.Resolve(tt.ip)
+			require.ErrorIs(t, resolveErr, tt.wantErr)
+			assert.Equal(t, tt.want, location)
+		})
+	}
+}
+
+func Test_Open(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("when file doesn't exist", func(t *testing.T) {
+		_, err := Open(filepath.Join(dir, "missing.mmdb"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("when file is empty", func(t *testing.T) {
+		path := filepath.Join(dir, "empty.mmdb")
+		require.NoError(t, os.WriteFile(path, nil, 0o600))
+
+		_, err := Open(path)
+		require.ErrorIs(t, err, geoErrors.ErrGeoIPInvalidDatabase)
+	})
+
+	t.Run("when file is not MaxMind DB", func(t *testing.T) {
+		path := filepath.Join(dir, "GeoLite2-City.tar.gz")
+		require.NoError(t, os.WriteFile(path, []byte("\x1f\x8b\x08\x00"), 0o600))
+
+		_, err := Open(path)
+		require.ErrorIs(t, err, geoErrors.ErrGeoIPInvalidDatabase)
+	})
+
+	t.Run("when resolver is closed", func(t *testing.T) {
+		resolver, err := Open(testDBPath)
+		require.NoError(t, err)
+
+		require.NoError(t, resolver.Close())
+		require.NoError(t, resolver.Close())
+
+		_, err = resolver.Resolve("81.2.69.142")
+		require.ErrorIs(t, err, geoErrors.ErrGeoIPClosed)
+	})
+}
+
+func Test_NullResolver_Resolve(t *testing.T) {
+	location, err := NullResolver{}.Resolve("81.2.69.142")
+	require.NoError(t, err)
+	assert.Equal(t, &entity.GeoLocation{}, location)
+	require.NoError(t, NullResolver{}.Close())
+}
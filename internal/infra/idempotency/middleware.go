@@ -0,0 +1,142 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gururuby/shortener/internal/infra/logger"
+)
+
+// Available constants
+const (
+	KeyHeader      = "Idempotency-Key"       // Request header with client-provided idempotency key
+	ReplayedHeader = "X-Idempotent-Replayed" // Response header marking replayed responses
+	maxKeyLength   = 255                     // Longest accepted idempotency key
+)
+
+// Middleware creates HTTP middleware replaying responses to requests retried with the same Idempotency-Key.
+// The first request reserves the key before it is processed, its response is stored for ttl unless it is a server error.
+// Duplicate requests get the stored body with 200 OK and X-Idempotent-Replayed: true header,
+// or 409 Conflict while the first request is still in flight.
+// If the store is unavailable requests are processed normally.
+// Keys are scoped by the requester, requests without key or scope are processed normally.
+// Parameters:
+// - store: Store of responses
+// - ttl: Time to keep responses
+// - scope: Function identifying the requester, e.g. by user ID, empty string disables replay
+// Returns:
+// - func(http.Handler) http.Handler: Middleware for handler wrapping
+func Middleware(store IdempotencyStore, ttl time.Duration, scope func(r *http.Request) string) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(KeyHeader)
+			if key == "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxKeyLength {
+				http.Error(w, "Idempotency-Key is too long", http.StatusBadRequest)
+				return
+			}
+
+			requester := scope(r)
+			if requester == "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			storeKey := requester + ":" + key
+			if cached, ok := store.Get(r.Context(), storeKey); ok {
+				writeReplay(w, cached)
+				return
+			}
+
+			reserved, err := store.Reserve(r.Context(), storeKey, ttl)
+			if err != nil {
+				logger.With(r.Context()).Error(err.Error())
+			} else if !reserved {
+				// The response may have been stored since the check above
+				if cached, ok := store.Get(r.Context(), storeKey); ok {
+					writeReplay(w, cached)
+					return
+				}
+				http.Error(w, "request with the same Idempotency-Key is in progress", http.StatusConflict)
+				return
+			}
+
+			stored := false
+			defer func() {
+				if reserved && !stored {
+					release(r, store, storeKey)
+				}
+			}()
+
+			rw := &recordingResponseWriter{ResponseWriter: w, status: http.StatusOK}
+			h.ServeHTTP(rw, r)
+
+			if rw.status >= http.StatusInternalServerError {
+				return
+			}
+
+			resp := &CachedResponse{
+				StatusCode:  rw.status,
+				ContentType: w.Header().Get("Content-Type"),
+				Body:        rw.body.Bytes(),
+			}
+			if err = store.Set(r.Context(), storeKey, resp, ttl); err != nil {
+				logger.With(r.Context()).Error(err.Error())
+				return
+			}
+			stored = true
+		})
+	}
+}
+
+// release removes reservation of a key whose response is not stored, so the request can be retried.
+// The request context may be canceled already, so reservation is released without its cancellation.
+// Parameters:
+// - r: HTTP request
+// - store: Store of responses
+// - storeKey: Scoped idempotency key
+func release(r *http.Request, store IdempotencyStore, storeKey string) {
+	if err := store.Release(context.WithoutCancel(r.Context()), storeKey); err != nil {
+		logger.With(r.Context()).Error(err.Error())
+	}
+}
+
+// writeReplay writes the stored response to a duplicate request.
+// Parameters:
+// - w: HTTP response writer
+// - cached: Stored response
+func writeReplay(w http.ResponseWriter, cached *CachedResponse) {
+	if cached.ContentType != "" {
+		w.Header().Set("Content-Type", cached.ContentType)
+	}
+	w.Header().Set(ReplayedHeader, "true")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(cached.Body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// recordingResponseWriter wraps http.ResponseWriter to capture response status code and body.
+type recordingResponseWriter struct {
+	http.ResponseWriter              // Embedded original ResponseWriter
+	body                bytes.Buffer // Copy of written body
+	status              int          // HTTP status code
+}
+
+// WriteHeader captures the status code while writing headers.
+func (w *recordingResponseWriter) WriteHeader(statusCode int) {
+	w.status = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write copies the body while writing it to the original ResponseWriter.
+func (w *recordingResponseWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
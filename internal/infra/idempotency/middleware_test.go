@@ -0,0 +1,170 @@
+package idempotency
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingHandler responds with sequential results and counts its calls.
+type countingHandler struct {
+	status int
+	calls  int
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	h.calls++
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(h.status)
+	_, _ = w.Write([]byte(`{"call":` + strconv.Itoa(h.calls) + `}`))
+}
+
+// blockingHandler responds once unblock is closed and counts its calls.
+type blockingHandler struct {
+	started chan struct{}
+	unblock chan struct{}
+	calls   atomic.Int32
+}
+
+func (h *blockingHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	h.calls.Add(1)
+	close(h.started)
+	<-h.unblock
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write([]byte(`{"call":1}`))
+}
+
+// userScope scopes keys by X-User header.
+func userScope(r *http.Request) string {
+	return r.Header.Get("X-User")
+}
+
+func doRequest(h http.Handler, user, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/shorten", nil)
+	if user != "" {
+		req.Header.Set("X-User", user)
+	}
+	if key != "" {
+		req.Header.Set(KeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Run("when request is retried with the same key", func(t *testing.T) {
+		next := &countingHandler{status: http.StatusCreated}
+		h := Middleware(newMemoryStore(t), time.Minute, userScope)(next)
+
+		first := doRequest(h, "1", "key")
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Empty(t, first.Header().Get(ReplayedHeader))
+
+		replay := doRequest(h, "1", "key")
+		assert.Equal(t, http.StatusOK, replay.Code)
+		assert.Equal(t, "true", replay.Header().Get(ReplayedHeader))
+		assert.Equal(t, "application/json", replay.Header().Get("Content-Type"))
+		assert.Equal(t, first.Body.String(), replay.Body.String())
+		assert.Equal(t, 1, next.calls)
+	})
+
+	t.Run("when request with the same key is in flight", func(t *testing.T) {
+		next := &blockingHandler{started: make(chan struct{}), unblock: make(chan struct{})}
+		h := Middleware(newMemoryStore(t), time.Minute, userScope)(next)
+
+		first := make(chan *httptest.ResponseRecorder)
+		go func() { first <- doRequest(h, "1", "key") }()
+		<-next.started
+
+		var wg sync.WaitGroup
+		duplicates := make([]*httptest.ResponseRecorder, 10)
+		for i := range duplicates {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				duplicates[i] = doRequest(h, "1", "key")
+			}()
+		}
+		wg.Wait()
+
+		for _, duplicate := range duplicates {
+			assert.Equal(t, http.StatusConflict, duplicate.Code)
+		}
+
+		close(next.unblock)
+		assert.Equal(t, http.StatusCreated, (<-first).Code)
+
+		replay := doRequest(h, "1", "key")
+		assert.Equal(t, http.StatusOK, replay.Code)
+		assert.Equal(t, "true", replay.Header().Get(ReplayedHeader))
+		assert.Equal(t, int32(1), next.calls.Load())
+	})
+
+	t.Run("when different users send the same key", func(t *testing.T) {
+		next := &countingHandler{status: http.StatusCreated}
+		h := Middleware(newMemoryStore(t), time.Minute, userScope)(next)
+
+		first := doRequest(h, "1", "key")
+		second := doRequest(h, "2", "key")
+
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.Empty(t, second.Header().Get(ReplayedHeader))
+		assert.NotEqual(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, 2, next.calls)
+	})
+
+	t.Run("when stored response expired", func(t *testing.T) {
+		next := &countingHandler{status: http.StatusCreated}
+		h := Middleware(newMemoryStore(t), 50*time.Millisecond, userScope)(next)
+
+		doRequest(h, "1", "key")
+		time.Sleep(100 * time.Millisecond)
+		retry := doRequest(h, "1", "key")
+
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Empty(t, retry.Header().Get(ReplayedHeader))
+		assert.Equal(t, 2, next.calls)
+	})
+
+	t.Run("when first response is a server error", func(t *testing.T) {
+		next := &countingHandler{status: http.StatusInternalServerError}
+		h := Middleware(newMemoryStore(t), time.Minute, userScope)(next)
+
+		doRequest(h, "1", "key")
+		retry := doRequest(h, "1", "key")
+
+		assert.Equal(t, http.StatusInternalServerError, retry.Code)
+		assert.Equal(t, 2, next.calls)
+	})
+
+	t.Run("when key or requester is missing", func(t *testing.T) {
+		next := &countingHandler{status: http.StatusCreated}
+		h := Middleware(newMemoryStore(t), time.Minute, userScope)(next)
+
+		doRequest(h, "1", "")
+		doRequest(h, "1", "")
+		doRequest(h, "", "key")
+		doRequest(h, "", "key")
+
+		assert.Equal(t, 4, next.calls)
+	})
+
+	t.Run("when key is too long", func(t *testing.T) {
+		next := &countingHandler{status: http.StatusCreated}
+		h := Middleware(newMemoryStore(t), time.Minute, userScope)(next)
+
+		w := doRequest(h, "1", strings.Repeat("k", maxKeyLength+1))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Zero(t, next.calls)
+	})
+}
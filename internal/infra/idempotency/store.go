@@ -0,0 +1,248 @@
+/*
+Package idempotency provides replay of responses to retried requests.
+
+It features:
+- Stores of responses keyed by client-provided Idempotency-Key
+- In-memory store for a single instance and Redis store shared between instances
+- Atomic reservation of keys, so duplicates of an in-flight request are not processed
+- HTTP middleware returning the stored response to duplicate requests
+*/
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Available constants
+const (
+	redisKeyPrefix = "idempotency:" // Key prefix of stored responses in Redis
+	redisInFlight  = "in-flight"    // Value of reserved keys in Redis while the request is processed
+)
+
+// CachedResponse represents a stored response to a request with idempotency key.
+type CachedResponse struct {
+	StatusCode  int    `json:"status_code"`  // Status code of the original response
+	ContentType string `json:"content_type"` // Content-Type of the original response
+	Body        []byte `json:"body"`         // Body of the original response
+}
+
+// IdempotencyStore defines the interface for storing responses by idempotency key.
+// Responses are kept for TTL only, after that a request with the same key is processed again.
+// A key is reserved while its request is processed, so concurrent duplicates can be rejected.
+type IdempotencyStore interface {
+	// Get retrieves a stored response by key, reserved keys have no response yet
+	Get(ctx context.Context, key string) (*CachedResponse, bool)
+	// Reserve atomically marks key as in flight for ttl, false if it is reserved or has a response
+	Reserve(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Release removes reservation of a key whose response is not stored
+	Release(ctx context.Context, key string) error
+	// Set stores a response by key for ttl, replacing its reservation
+	Set(ctx context.Context, key string, resp *CachedResponse, ttl time.Duration) error
+}
+
+// memoryEntry is a stored response with its expiration time.
+// Entries of reserved keys have no response.
+type memoryEntry struct {
+	resp   *CachedResponse
+	expiry time.Time
+}
+
+// MemoryStore keeps responses in memory of the current instance.
+// Expired entries are removed periodically.
+type MemoryStore struct {
+	entries sync.Map      // Key to memoryEntry
+	done    chan struct{} // Stops cleanup goroutine
+	once    sync.Once     // Guards done channel close
+}
+
+// NewMemoryStore creates in-memory store and starts cleanup of expired entries.
+// Parameters:
+// - cleanupInterval: Interval between removals of expired entries
+// Returns:
+// - *MemoryStore: Initialized store, Close must be called to stop cleanup
+func NewMemoryStore(cleanupInterval time.Duration) *MemoryStore {
+	s := &MemoryStore{done: make(chan struct{})}
+	go s.runCleanup(cleanupInterval)
+	return s
+}
+
+// Get retrieves a stored response which has not expired yet.
+// Parameters:
+// - ctx: Context (unused)
+// - key: Scoped idempotency key
+// Returns:
+// - *CachedResponse: Stored response
+// - bool: true if response is found
+func (s *MemoryStore) Get(_ context.Context, key string) (*CachedResponse, bool) {
+	entry, ok := s.entries.Load(key)
+	if !ok || entry.(memoryEntry).resp == nil || !time.Now().Before(entry.(memoryEntry).expiry) {
+		return nil, false
+	}
+	return entry.(memoryEntry).resp, true
+}
+
+// Reserve marks key as in flight unless it has an entry which has not expired yet.
+// Parameters:
+// - ctx: Context (unused)
+// - key: Scoped idempotency key
+// - ttl: Time to keep the reservation if it is never released
+// Returns:
+// - bool: true if key is reserved by this call
+// - error: Always nil
+func (s *MemoryStore) Reserve(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	reserved := memoryEntry{expiry: time.Now().Add(ttl)}
+	for {
+		entry, loaded := s.entries.LoadOrStore(key, reserved)
+		if !loaded {
+			return true, nil
+		}
+		if time.Now().Before(entry.(memoryEntry).expiry) {
+			return false, nil
+		}
+		if s.entries.CompareAndSwap(key, entry, reserved) {
+			return true, nil
+		}
+	}
+}
+
+// Release removes reservation of a key, stored responses are kept.
+// Parameters:
+// - ctx: Context (unused)
+// - key: Scoped idempotency key
+// Returns:
+// - error: Always nil
+func (s *MemoryStore) Release(_ context.Context, key string) error {
+	if entry, ok := s.entries.Load(key); ok && entry.(memoryEntry).resp == nil {
+		s.entries.CompareAndDelete(key, entry)
+	}
+	return nil
+}
+
+// Set stores a response for ttl.
+// Parameters:
+// - ctx: Context (unused)
+// - key: Scoped idempotency key
+// - resp: Response to store
+// - ttl: Time to keep the response
+// Returns:
+// - error: Always nil
+func (s *MemoryStore) Set(_ context.Context, key string, resp *CachedResponse, ttl time.Duration) error {
+	s.entries.Store(key, memoryEntry{resp: resp, expiry: time.Now().Add(ttl)})
+	return nil
+}
+
+// Close stops cleanup of expired entries.
+func (s *MemoryStore) Close() {
+	s.once.Do(func() { close(s.done) })
+}
+
+// runCleanup removes expired entries every interval until Close is called.
+// Parameters:
+// - interval: Interval between removals
+func (s *MemoryStore) runCleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case now := <-ticker.C:
+			s.removeExpired(now)
+		}
+	}
+}
+
+// removeExpired removes entries expired before now.
+// Parameters:
+// - now: Current time
+func (s *MemoryStore) removeExpired(now time.Time) {
+	s.entries.Range(func(key, entry any) bool {
+		if !now.Before(entry.(memoryEntry).expiry) {
+			s.entries.Delete(key)
+		}
+		return true
+	})
+}
+
+// releaseScript deletes the key only while it holds the in-flight mark.
+var releaseScript = redis.NewScript(`if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) end return 0`)
+
+// RedisStore keeps responses in Redis, so retries are recognized by any service instance.
+// Entries expire via Redis key TTL.
+type RedisStore struct {
+	client redis.UniversalClient // Redis client
+}
+
+// NewRedisStore creates Redis-backed store.
+// Parameters:
+// - client: Redis client
+// Returns:
+// - *RedisStore: Initialized store
+func NewRedisStore(client redis.UniversalClient) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// Get retrieves a stored response.
+// If Redis is unavailable the response is treated as not stored, so the request is processed.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - key: Scoped idempotency key
+// Returns:
+// - *CachedResponse: Stored response
+// - bool: true if response is found
+func (s *RedisStore) Get(ctx context.Context, key string) (*CachedResponse, bool) {
+	data, err := s.client.Get(ctx, redisKeyPrefix+key).Bytes()
+	if err != nil || string(data) == redisInFlight {
+		return nil, false
+	}
+
+	var resp CachedResponse
+	if err = json.Unmarshal(data, &resp); err != nil {
+		return nil, false
+	}
+	return &resp, true
+}
+
+// Reserve marks key as in flight with SET NX, so only one instance processes the request.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - key: Scoped idempotency key
+// - ttl: Time to keep the reservation if it is never released
+// Returns:
+// - bool: true if key is reserved by this call
+// - error: If Redis command fails
+func (s *RedisStore) Reserve(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, redisKeyPrefix+key, redisInFlight, ttl).Result()
+}
+
+// Release removes reservation of a key, stored responses are kept.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - key: Scoped idempotency key
+// Returns:
+// - error: If Redis command fails
+func (s *RedisStore) Release(ctx context.Context, key string) error {
+	return releaseScript.Run(ctx, s.client, []string{redisKeyPrefix + key}, redisInFlight).Err()
+}
+
+// Set stores a response for ttl.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - key: Scoped idempotency key
+// - resp: Response to store
+// - ttl: Time to keep the response
+// Returns:
+// - error: If response cannot be encoded or Redis command fails
+func (s *RedisStore) Set(ctx context.Context, key string, resp *CachedResponse, ttl time.Duration) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, redisKeyPrefix+key, data, ttl).Err()
+}
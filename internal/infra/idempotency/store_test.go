@@ -0,0 +1,97 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMemoryStore(t *testing.T) *MemoryStore {
+	t.Helper()
+	store := NewMemoryStore(time.Hour)
+	t.Cleanup(store.Close)
+	return store
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore(t)
+	resp := &CachedResponse{StatusCode: 201, ContentType: "application/json", Body: []byte(`{"Result":"http://localhost/abc"}`)}
+
+	require.NoError(t, store.Set(ctx, "1:active", resp, time.Hour))
+	require.NoError(t, store.Set(ctx, "1:expired", resp, -time.Second))
+
+	cached, ok := store.Get(ctx, "1:active")
+	require.True(t, ok)
+	assert.Equal(t, resp, cached)
+
+	_, ok = store.Get(ctx, "1:expired")
+	assert.False(t, ok)
+	_, ok = store.Get(ctx, "2:active")
+	assert.False(t, ok)
+
+	store.removeExpired(time.Now())
+
+	_, ok = store.entries.Load("1:expired")
+	assert.False(t, ok)
+	_, ok = store.Get(ctx, "1:active")
+	assert.True(t, ok)
+}
+
+func TestMemoryStore_Reserve(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore(t)
+
+	reserved, err := store.Reserve(ctx, "1:key", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+
+	reserved, err = store.Reserve(ctx, "1:key", time.Hour)
+	require.NoError(t, err)
+	assert.False(t, reserved, "key in flight must not be reserved twice")
+
+	_, ok := store.Get(ctx, "1:key")
+	assert.False(t, ok, "reserved key has no response")
+
+	require.NoError(t, store.Release(ctx, "1:key"))
+	reserved, err = store.Reserve(ctx, "1:key", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, reserved, "released key must be reserved again")
+
+	require.NoError(t, store.Set(ctx, "1:key", &CachedResponse{StatusCode: 201}, time.Hour))
+	require.NoError(t, store.Release(ctx, "1:key"))
+	_, ok = store.Get(ctx, "1:key")
+	assert.True(t, ok, "release must keep stored response")
+
+	reserved, err = store.Reserve(ctx, "1:key", time.Hour)
+	require.NoError(t, err)
+	assert.False(t, reserved, "key with stored response must not be reserved")
+
+	reserved, err = store.Reserve(ctx, "1:expired", -time.Second)
+	require.NoError(t, err)
+	require.True(t, reserved)
+	reserved, err = store.Reserve(ctx, "1:expired", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, reserved, "expired reservation must be replaced")
+}
+
+func TestRedisStore_Unavailable(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+	store := NewRedisStore(client)
+
+	assert.Error(t, store.Set(ctx, "1:key", &CachedResponse{StatusCode: 201}, time.Minute))
+
+	_, err := store.Reserve(ctx, "1:key", time.Minute)
+	assert.Error(t, err)
+
+	_, ok := store.Get(ctx, "1:key")
+	assert.False(t, ok, "request must be processed if stored responses cannot be checked")
+}
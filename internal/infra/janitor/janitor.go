@@ -0,0 +1,110 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . Storage
+
+/*
+Package janitor provides background removal of expired short URLs.
+
+It features:
+- Periodic removal of short URLs whose expiration time has passed
+- Periodic archiving of short URLs deleted long ago
+- Logging of the number of removed and archived short URLs
+- Clean stop on context cancellation
+*/
+package janitor
+
+import (
+	"context"
+	"time"
+
+	"github.com/gururuby/shortener/internal/infra/logger"
+	"go.uber.org/zap"
+)
+
+// Storage defines the interface for removal of expired and archiving of deleted short URLs.
+type Storage interface {
+	// DeleteExpiredURLs removes short URLs whose expiration time has passed.
+	// Returns:
+	// - int64: Number of removed short URLs
+	// - error: Any error that occurred during removal
+	DeleteExpiredURLs(ctx context.Context) (int64, error)
+
+	// ArchiveDeletedURLs moves short URLs deleted long ago to the archive.
+	// Returns:
+	// - int64: Number of archived short URLs
+	// - error: Any error that occurred during archiving
+	ArchiveDeletedURLs(ctx context.Context) (int64, error)
+}
+
+// Janitor periodically removes expired short URLs from storage.
+type Janitor struct {
+	storage  Storage       // Storage of short URLs
+	interval time.Duration // Interval between removals
+}
+
+// New creates janitor of expired short URLs.
+// Parameters:
+// - storage: Storage of short URLs
+// - interval: Interval between removals
+// Returns:
+// - *Janitor: Initialized janitor, Run must be called to start removals
+func New(storage Storage, interval time.Duration) *Janitor {
+	return &Janitor{storage: storage, interval: interval}
+}
+
+// Run removes expired and archives deleted short URLs every interval until ctx is cancelled.
+// Errors are logged and do not stop the janitor.
+// Parameters:
+// - ctx: Context whose cancellation stops the janitor
+func (j *Janitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := j.RunOnce(ctx); err != nil && ctx.Err() == nil {
+				logger.Log.Error("cannot delete expired short URLs", zap.Error(err))
+			}
+			if _, err := j.ArchiveOnce(ctx); err != nil && ctx.Err() == nil {
+				logger.Log.Error("cannot archive deleted short URLs", zap.Error(err))
+			}
+		}
+	}
+}
+
+// RunOnce removes expired short URLs and logs their number.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - int64: Number of removed short URLs
+// - error: Any error that occurred during removal
+func (j *Janitor) RunOnce(ctx context.Context) (int64, error) {
+	n, err := j.storage.DeleteExpiredURLs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if n > 0 {
+		logger.Log.Info("expired short URLs deleted", zap.Int64("count", n))
+	}
+	return n, nil
+}
+
+// ArchiveOnce archives short URLs deleted long ago and logs their number.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// Returns:
+// - int64: Number of archived short URLs
+// - error: Any error that occurred during archiving
+func (j *Janitor) ArchiveOnce(ctx context.Context) (int64, error) {
+	n, err := j.storage.ArchiveDeletedURLs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if n > 0 {
+		logger.Log.Info("deleted short URLs archived", zap.Int64("count", n))
+	}
+	return n, nil
+}
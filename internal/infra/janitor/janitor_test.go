@@ -0,0 +1,126 @@
+package janitor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gururuby/shortener/internal/infra/janitor/mocks"
+	"github.com/gururuby/shortener/internal/infra/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Janitor_RunOnce(t *testing.T) {
+	logger.Setup("test", "error")
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	errStorage := errors.New("storage error")
+
+	tests := []struct {
+		name    string
+		deleted int64
+		err     error
+		want    int64
+	}{
+		{
+			name:    "when expired URLs are deleted",
+			deleted: 3,
+			want:    3,
+		},
+		{
+			name: "when there are no expired URLs",
+		},
+		{
+			name: "when storage fails",
+			err:  errStorage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := mocks.NewMockStorage(ctrl)
+			storage.EXPECT().DeleteExpiredURLs(ctx).Return(tt.deleted, tt.err)
+
+			n, err := New(storage, time.Hour).RunOnce(ctx)
+			require.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, n)
+		})
+	}
+}
+
+func Test_Janitor_ArchiveOnce(t *testing.T) {
+	logger.Setup("test", "error")
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	errStorage := errors.New("storage error")
+
+	tests := []struct {
+		name     string
+		archived int64
+		err      error
+		want     int64
+	}{
+		{
+			name:     "when deleted URLs are archived",
+			archived: 2,
+			want:     2,
+		},
+		{
+			name: "when there are no URLs to archive",
+		},
+		{
+			name: "when storage fails",
+			err:  errStorage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := mocks.NewMockStorage(ctrl)
+			storage.EXPECT().ArchiveDeletedURLs(ctx).Return(tt.archived, tt.err)
+
+			n, err := New(storage, time.Hour).ArchiveOnce(ctx)
+			require.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, n)
+		})
+	}
+}
+
+func Test_Janitor_Run(t *testing.T) {
+	logger.Setup("test", "error")
+	ctrl := gomock.NewController(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	storage := mocks.NewMockStorage(ctrl)
+	calls := make(chan struct{}, 1)
+	storage.EXPECT().DeleteExpiredURLs(gomock.Any()).Return(int64(1), nil).MinTimes(1)
+	storage.EXPECT().ArchiveDeletedURLs(gomock.Any()).DoAndReturn(func(context.Context) (int64, error) {
+		select {
+		case calls <- struct{}{}:
+		default:
+		}
+		return 1, nil
+	}).MinTimes(1)
+
+	done := make(chan struct{})
+	go func() {
+		New(storage, time.Millisecond).Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-calls:
+	case <-time.After(time.Second):
+		t.Fatal("janitor did not delete expired and archive deleted URLs")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("janitor did not stop after context cancellation")
+	}
+}
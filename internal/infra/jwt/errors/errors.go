@@ -57,4 +57,22 @@ var (
 	// - Check algorithm compatibility
 	// - Ensure proper key initialization
 	ErrJWTCannotSignData = errors.New("cannot sign data")
+
+	// ErrJWTTokenRevoked indicates the token was revoked before its expiration.
+	//
+	// Typical scenarios:
+	// - User logged out via DELETE /api/user/session
+	// - Session forcibly terminated after security incident
+	//
+	// Handling guidance:
+	// - Treat the same way as invalid token
+	// - Require new authentication
+	ErrJWTTokenRevoked = errors.New("token is revoked")
+
+	// ErrJWTCannotRevoke indicates failure to store token revocation.
+	//
+	// Possible reasons:
+	// - Revocation store is unavailable
+	// - Token has no ID or expiration claims
+	ErrJWTCannotRevoke = errors.New("cannot revoke token")
 )
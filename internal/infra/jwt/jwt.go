@@ -5,19 +5,22 @@ It provides:
 - JWT generation with user claims
 - Token signing and verification
 - Configurable token expiration
+- Token revocation before expiration
 - Custom error handling for JWT operations
 */
 package jwt
 
 import (
+	"context"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	jwtErrors "github.com/gururuby/shortener/internal/infra/jwt/errors"
 )
 
 // claims contains the JWT claims structure including registered claims
-// and custom user ID field.
+// (token ID is stored in `jti`) and custom user ID field.
 type claims struct {
 	jwt.RegisteredClaims
 	UserID int `json:"user_id"` // User ID to be stored in the token
@@ -25,21 +28,23 @@ type claims struct {
 
 // JWT provides methods for creating and validating JWT tokens.
 type JWT struct {
-	secret   []byte        // Secret key used for signing tokens
-	tokenTTL time.Duration // Token time-to-live duration
+	store    RevocationStore // Storage of revoked token IDs
+	secret   []byte          // Secret key used for signing tokens
+	tokenTTL time.Duration   // Token time-to-live duration
 }
 
 // New creates a new JWT instance with the given secret and token TTL.
 // Parameters:
 // - secret: Secret key for signing tokens
 // - ttl: Duration until token expiration
+// - store: Storage of revoked token IDs
 // Returns:
 // - *JWT: Initialized JWT instance
-func New(secret string, ttl time.Duration) *JWT {
-	return &JWT{secret: []byte(secret), tokenTTL: ttl}
+func New(secret string, ttl time.Duration, store RevocationStore) *JWT {
+	return &JWT{secret: []byte(secret), tokenTTL: ttl, store: store}
 }
 
-// SignUserID creates a new JWT token containing the user ID.
+// SignUserID creates a new JWT token containing the user ID and a unique token ID.
 // Parameters:
 // - userID: User ID to embed in the token
 // Returns:
@@ -48,6 +53,7 @@ func New(secret string, ttl time.Duration) *JWT {
 func (j *JWT) SignUserID(userID int) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.tokenTTL)),
 		},
 		UserID: userID,
@@ -66,8 +72,52 @@ func (j *JWT) SignUserID(userID int) (string, error) {
 // - tokenString: JWT token to validate
 // Returns:
 // - int: User ID extracted from the token
-// - error: Various JWT validation errors if token is invalid
+// - error: Various JWT validation errors if token is invalid,
+// jwtErrors.ErrJWTTokenRevoked if token was revoked
 func (j *JWT) ReadUserID(tokenString string) (int, error) {
+	clms, err := j.parse(tokenString)
+	if err != nil {
+		return 0, err
+	}
+
+	if j.store.IsRevoked(context.Background(), clms.ID) {
+		return 0, jwtErrors.ErrJWTTokenRevoked
+	}
+
+	return clms.UserID, nil
+}
+
+// RevokeToken revokes a JWT token until its expiration.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - tokenString: JWT token to revoke
+// Returns:
+// - error: Various JWT validation errors if token is invalid,
+// jwtErrors.ErrJWTCannotRevoke if revocation cannot be stored
+func (j *JWT) RevokeToken(ctx context.Context, tokenString string) error {
+	clms, err := j.parse(tokenString)
+	if err != nil {
+		return err
+	}
+
+	if clms.ID == "" || clms.ExpiresAt == nil {
+		return jwtErrors.ErrJWTCannotRevoke
+	}
+
+	if err = j.store.Revoke(ctx, clms.ID, clms.ExpiresAt.Time); err != nil {
+		return jwtErrors.ErrJWTCannotRevoke
+	}
+
+	return nil
+}
+
+// parse validates a JWT token and extracts its claims.
+// Parameters:
+// - tokenString: JWT token to validate
+// Returns:
+// - *claims: Token claims
+// - error: Various JWT validation errors if token is invalid
+func (j *JWT) parse(tokenString string) (*claims, error) {
 	clms := &claims{}
 	token, err := jwt.ParseWithClaims(tokenString, clms,
 		func(t *jwt.Token) (interface{}, error) {
@@ -77,12 +127,12 @@ func (j *JWT) ReadUserID(tokenString string) (int, error) {
 			return j.secret, nil
 		})
 	if err != nil {
-		return 0, jwtErrors.ErrJWTParseError
+		return nil, jwtErrors.ErrJWTParseError
 	}
 
 	if !token.Valid {
-		return 0, jwtErrors.ErrJWTTokenInvalid
+		return nil, jwtErrors.ErrJWTTokenInvalid
 	}
 
-	return clms.UserID, nil
+	return clms, nil
 }
@@ -1,6 +1,7 @@
 package jwt
 
 import (
+	"context"
 	"regexp"
 	"testing"
 	"time"
@@ -25,7 +26,7 @@ func TestJWT_SignUserID(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			jwt := New(tt.secret, tt.expTime)
+			jwt := New(tt.secret, tt.expTime, newRevocationStore(t))
 			token, err := jwt.SignUserID(1)
 			require.NoError(t, err)
 			assert.Regexp(t, regexp.MustCompile(`.+\..+\..+`), token)
@@ -55,7 +56,7 @@ func TestJWT_ReadUserID_OK(t *testing.T) {
 				token string
 				id    int
 			)
-			jwt := New(tt.secret, tt.expTime)
+			jwt := New(tt.secret, tt.expTime, newRevocationStore(t))
 			token, err = jwt.SignUserID(tt.userID)
 			require.NoError(t, err)
 			id, err = jwt.ReadUserID(token)
@@ -86,9 +87,47 @@ func TestJWT_ReadUserID_Errors(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			jwt := New(tt.secret, tt.expTime)
+			jwt := New(tt.secret, tt.expTime, newRevocationStore(t))
 			_, err := jwt.ReadUserID(tt.token)
 			require.ErrorIs(t, err, tt.err)
 		})
 	}
 }
+
+func newRevocationStore(t *testing.T) *MemoryRevocationStore {
+	t.Helper()
+
+	store := NewMemoryRevocationStore(time.Minute)
+	t.Cleanup(store.Close)
+
+	return store
+}
+
+func TestJWT_RevokeToken(t *testing.T) {
+	ctx := context.Background()
+	jwt := New("secret", 10*time.Minute, newRevocationStore(t))
+
+	token, err := jwt.SignUserID(1)
+	require.NoError(t, err)
+
+	otherToken, err := jwt.SignUserID(1)
+	require.NoError(t, err)
+
+	require.NoError(t, jwt.RevokeToken(ctx, token))
+
+	// Token is rejected although its exp claim has not elapsed yet
+	_, err = jwt.ReadUserID(token)
+	require.ErrorIs(t, err, jwtErrors.ErrJWTTokenRevoked)
+
+	// Other sessions of the same user are not affected
+	id, err := jwt.ReadUserID(otherToken)
+	require.NoError(t, err)
+	assert.Equal(t, 1, id)
+}
+
+func TestJWT_RevokeToken_Errors(t *testing.T) {
+	jwt := New("secret", 10*time.Minute, newRevocationStore(t))
+
+	err := jwt.RevokeToken(context.Background(), "incorrect token")
+	require.ErrorIs(t, err, jwtErrors.ErrJWTParseError)
+}
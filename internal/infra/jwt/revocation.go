@@ -0,0 +1,144 @@
+package jwt
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Available constants
+const (
+	redisRevokedKeyPrefix = "jwt:revoked:" // Key prefix of revoked token IDs in Redis
+)
+
+// RevocationStore defines the interface for storing revoked token IDs.
+// Token IDs are kept until the token expires, after that the token is rejected anyway.
+type RevocationStore interface {
+	// Revoke marks token ID as revoked until expiry
+	Revoke(ctx context.Context, tokenID string, expiry time.Time) error
+	// IsRevoked reports whether token ID was revoked
+	IsRevoked(ctx context.Context, tokenID string) bool
+}
+
+// MemoryRevocationStore keeps revoked token IDs in memory.
+// Expired entries are removed periodically.
+type MemoryRevocationStore struct {
+	tokens sync.Map      // Token ID to expiry time
+	done   chan struct{} // Stops cleanup goroutine
+	once   sync.Once     // Guards done channel close
+}
+
+// NewMemoryRevocationStore creates in-memory revocation store and starts cleanup of expired entries.
+// Parameters:
+// - cleanupInterval: Interval between removals of expired entries
+// Returns:
+// - *MemoryRevocationStore: Initialized store, Close must be called to stop cleanup
+func NewMemoryRevocationStore(cleanupInterval time.Duration) *MemoryRevocationStore {
+	s := &MemoryRevocationStore{done: make(chan struct{})}
+	go s.runCleanup(cleanupInterval)
+	return s
+}
+
+// Revoke marks token ID as revoked until expiry.
+// Parameters:
+// - ctx: Context (unused)
+// - tokenID: Value of token `jti` claim
+// - expiry: Token expiration time
+// Returns:
+// - error: Always nil
+func (s *MemoryRevocationStore) Revoke(_ context.Context, tokenID string, expiry time.Time) error {
+	s.tokens.Store(tokenID, expiry)
+	return nil
+}
+
+// IsRevoked reports whether token ID was revoked and has not expired yet.
+// Parameters:
+// - ctx: Context (unused)
+// - tokenID: Value of token `jti` claim
+// Returns:
+// - bool: true if token was revoked
+func (s *MemoryRevocationStore) IsRevoked(_ context.Context, tokenID string) bool {
+	expiry, ok := s.tokens.Load(tokenID)
+	return ok && time.Now().Before(expiry.(time.Time))
+}
+
+// Close stops cleanup of expired entries.
+func (s *MemoryRevocationStore) Close() {
+	s.once.Do(func() { close(s.done) })
+}
+
+// runCleanup removes expired entries every interval until Close is called.
+// Parameters:
+// - interval: Interval between removals
+func (s *MemoryRevocationStore) runCleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case now := <-ticker.C:
+			s.removeExpired(now)
+		}
+	}
+}
+
+// removeExpired removes entries expired before now.
+// Parameters:
+// - now: Current time
+func (s *MemoryRevocationStore) removeExpired(now time.Time) {
+	s.tokens.Range(func(key, expiry any) bool {
+		if !now.Before(expiry.(time.Time)) {
+			s.tokens.Delete(key)
+		}
+		return true
+	})
+}
+
+// RedisRevocationStore keeps revoked token IDs in Redis,
+// so revocation is shared between service instances.
+// Entries expire together with tokens via Redis key TTL.
+type RedisRevocationStore struct {
+	client redis.UniversalClient // Redis client
+}
+
+// NewRedisRevocationStore creates Redis-backed revocation store.
+// Parameters:
+// - client: Redis client
+// Returns:
+// - *RedisRevocationStore: Initialized store
+func NewRedisRevocationStore(client redis.UniversalClient) *RedisRevocationStore {
+	return &RedisRevocationStore{client: client}
+}
+
+// Revoke marks token ID as revoked until expiry.
+// Already expired tokens are not stored.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - tokenID: Value of token `jti` claim
+// - expiry: Token expiration time
+// Returns:
+// - error: If Redis command fails
+func (s *RedisRevocationStore) Revoke(ctx context.Context, tokenID string, expiry time.Time) error {
+	ttl := time.Until(expiry)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return s.client.Set(ctx, redisRevokedKeyPrefix+tokenID, 1, ttl).Err()
+}
+
+// IsRevoked reports whether token ID was revoked.
+// If Redis is unavailable the token is treated as revoked.
+// Parameters:
+// - ctx: Context for cancellation/timeouts
+// - tokenID: Value of token `jti` claim
+// Returns:
+// - bool: true if token was revoked or revocation cannot be checked
+func (s *RedisRevocationStore) IsRevoked(ctx context.Context, tokenID string) bool {
+	n, err := s.client.Exists(ctx, redisRevokedKeyPrefix+tokenID).Result()
+	return err != nil || n > 0
+}
@@ -0,0 +1,28 @@
+package jwt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRevocationStore(t *testing.T) {
+	ctx := context.Background()
+	store := newRevocationStore(t)
+
+	require.NoError(t, store.Revoke(ctx, "active", time.Now().Add(time.Hour)))
+	require.NoError(t, store.Revoke(ctx, "expired", time.Now().Add(-time.Second)))
+
+	assert.True(t, store.IsRevoked(ctx, "active"))
+	assert.False(t, store.IsRevoked(ctx, "expired"))
+	assert.False(t, store.IsRevoked(ctx, "unknown"))
+
+	store.removeExpired(time.Now())
+
+	_, ok := store.tokens.Load("expired")
+	assert.False(t, ok)
+	assert.True(t, store.IsRevoked(ctx, "active"))
+}
@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// requestIDKey is the context key for request ID.
+type requestIDKey struct{}
+
+// loggerKey is the context key for request scoped logger.
+type loggerKey struct{}
+
+// Log field names
+const (
+	RequestIDField  = "request_id"  // Name of log field with request ID
+	RemoteAddrField = "remote_addr" // Name of log field with client address
+)
+
+// WithRequestID returns a copy of the context carrying request ID.
+//
+// Parameters:
+//   - ctx: Parent context
+//   - requestID: Request identifier
+//
+// Returns:
+//   - context.Context: Context with request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID extracts request ID from the context.
+//
+// Parameters:
+//   - ctx: Context possibly carrying request ID
+//
+// Returns:
+//   - string: Request ID or empty string if it is absent
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// StoreLogger returns a copy of the context carrying the logger.
+//
+// Parameters:
+//   - ctx: Parent context
+//   - l: Logger to use while handling the request
+//
+// Returns:
+//   - context.Context: Context with logger
+func StoreLogger(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// With returns the logger stored in the context by StoreLogger with extra fields.
+// Falls back to FromContext if no logger is stored.
+//
+// Parameters:
+//   - ctx: Request context
+//   - fields: Fields added to every entry of the returned logger
+//
+// Returns:
+//   - *zap.Logger: Logger to use while handling the request
+func With(ctx context.Context, fields ...zap.Field) *zap.Logger {
+	l, ok := ctx.Value(loggerKey{}).(*zap.Logger)
+	if !ok || l == nil {
+		l = FromContext(ctx)
+	}
+
+	if len(fields) == 0 {
+		return l
+	}
+
+	return l.With(fields...)
+}
+
+// Middleware creates HTTP middleware storing request scoped logger in the request context.
+// The logger is a child of base with request_id and remote_addr fields, so every entry
+// logged via With while handling the request can be correlated with it.
+// Request ID is taken from the context, so the middleware must be registered after
+// the one assigning it.
+//
+// Parameters:
+//   - base: Parent logger, the global logger is used if nil
+//
+// Returns:
+//   - func(http.Handler) http.Handler: Middleware for router registration
+func Middleware(base *zap.Logger) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			l := base
+			if l == nil {
+				l = Log
+			}
+			if l == nil {
+				l = zap.NewNop()
+			}
+
+			l = l.With(
+				zap.String(RequestIDField, RequestID(r.Context())),
+				zap.String(RemoteAddrField, r.RemoteAddr),
+			)
+
+			h.ServeHTTP(w, r.WithContext(StoreLogger(r.Context(), l)))
+		})
+	}
+}
+
+// FromContext returns the global logger enriched with request scoped fields.
+// If the context carries request ID, it is added as request_id field.
+// A no-op logger is returned if the global logger is not initialized.
+//
+// Parameters:
+//   - ctx: Request context
+//
+// Returns:
+//   - *zap.Logger: Logger to use while handling the request
+func FromContext(ctx context.Context) *zap.Logger {
+	l := Log
+	if l == nil {
+		l = zap.NewNop()
+	}
+
+	if requestID := RequestID(ctx); requestID != "" {
+		return l.With(zap.String(RequestIDField, requestID))
+	}
+
+	return l
+}
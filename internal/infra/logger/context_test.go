@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func Test_Middleware(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	base := zap.New(zapcore.NewTee(zaptest.NewLogger(t).Core(), core))
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		With(r.Context()).Info("looking up short URL")
+		With(r.Context(), zap.String("alias", "abc")).Warn("short URL is not found")
+		With(context.WithoutCancel(r.Context())).Error("cannot record click")
+		w.WriteHeader(http.StatusNotFound)
+	})
+	withRequestID := func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r.WithContext(WithRequestID(r.Context(), "req-1")))
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/abc", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	withRequestID(Middleware(base)(handler)).ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := logs.All()
+	require.Len(t, entries, 3)
+	for _, entry := range entries {
+		fields := entry.ContextMap()
+		assert.Equal(t, "req-1", fields[RequestIDField], entry.Message)
+		assert.Equal(t, "192.0.2.1:1234", fields[RemoteAddrField], entry.Message)
+	}
+	assert.Equal(t, "abc", entries[1].ContextMap()["alias"])
+}
+
+func Test_With(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	origLog := Log
+	Log = zap.New(core)
+	t.Cleanup(func() { Log = origLog })
+
+	t.Run("when logger is stored in context", func(t *testing.T) {
+		storedCore, storedLogs := observer.New(zapcore.InfoLevel)
+		ctx := StoreLogger(context.Background(), zap.New(storedCore))
+
+		With(ctx, zap.Int("attempt", 1)).Info("stored")
+
+		require.Len(t, storedLogs.All(), 1)
+		assert.Equal(t, int64(1), storedLogs.All()[0].ContextMap()["attempt"])
+		assert.Empty(t, logs.TakeAll())
+	})
+
+	t.Run("when logger is not stored in context", func(t *testing.T) {
+		With(WithRequestID(context.Background(), "req-2")).Info("global")
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 1)
+		assert.Equal(t, "req-2", entries[0].ContextMap()[RequestIDField])
+	})
+
+	t.Run("when global logger is not initialized", func(t *testing.T) {
+		Log = nil
+
+		assert.NotPanics(t, func() { With(context.Background()).Info("dropped") })
+	})
+}
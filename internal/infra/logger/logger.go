@@ -4,9 +4,11 @@ Package logger provides centralized logging functionality for the application.
 It features:
 - Thread-safe singleton logger initialization
 - Environment-specific logging configurations
-- Configurable log levels
+- Configurable log levels, also changed at runtime
 - Structured logging via zap logger
 - Production and development logging presets
+- JSON or console format and output to file
+- Request scoped loggers carried in context
 */
 package logger
 
@@ -14,43 +16,167 @@ import (
 	"log"
 	"sync"
 
+	"github.com/gururuby/shortener/internal/config"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // Log is the global logger instance that should be used throughout the application.
 // It is initialized by calling Setup() and provides structured logging methods.
 var Log *zap.Logger
 
+// level is the level of the global logger, shared with it so it can be changed at runtime.
+var level = zap.NewAtomicLevel()
+
+// Available log formats
+const (
+	FormatJSON    = "json"    // Structured JSON logs with application fields
+	FormatConsole = "console" // Human-readable colored logs with caller
+)
+
+// Option configures the logger built by Setup.
+type Option func(*options)
+
+// options contains logger settings.
+type options struct {
+	format     string // Log format, depends on environment if empty
+	outputPath string // Path of log output, stderr if empty
+	appName    string // Application name added to JSON logs
+	appVersion string // Application version added to JSON logs
+}
+
+// WithFormat sets the log format.
+//
+// Parameters:
+//   - format: FormatJSON or FormatConsole, any other value keeps the environment preset
+//
+// Returns:
+//   - Option: Setup option
+func WithFormat(format string) Option {
+	return func(o *options) {
+		o.format = format
+	}
+}
+
+// WithOutputPath redirects logs to the file, e.g. one rotated by logrotate.
+//
+// Parameters:
+//   - path: File path, "stdout" or "stderr"
+//
+// Returns:
+//   - Option: Setup option
+func WithOutputPath(path string) Option {
+	return func(o *options) {
+		o.outputPath = path
+	}
+}
+
+// WithAppInfo adds application name and version to JSON logs.
+//
+// Parameters:
+//   - name: Application name
+//   - version: Application version
+//
+// Returns:
+//   - Option: Setup option
+func WithAppInfo(name, version string) Option {
+	return func(o *options) {
+		o.appName = name
+		o.appVersion = version
+	}
+}
+
 // Setup initializes the global logger with the specified environment and log level.
 // This function is safe for concurrent use and will only initialize the logger once.
 //
 // Parameters:
 //   - appENV: Application environment ("production" or any other value for development)
 //   - logLevel: Desired log level ("debug", "info", "warn", "error")
+//   - opts: Format and output of logs, by default they depend on appENV
 //
 // Note: If initialization fails, the function will log the error and exit the program.
-func Setup(appENV, logLevel string) {
+func Setup(appENV, logLevel string, opts ...Option) {
 	var initLogger sync.Once
 
 	initLogger.Do(func() {
-		var cfg zap.Config
 		var err error
 
-		switch appENV {
-		case "production":
-			cfg = zap.NewProductionConfig()
-		default:
-			cfg = zap.NewDevelopmentConfig()
-		}
-
-		cfg.Level = buildLogLevel(logLevel)
+		level.SetLevel(buildLogLevel(logLevel).Level())
 
-		if Log, err = cfg.Build(); err != nil {
+		if Log, err = build(appENV, level, opts...); err != nil {
 			log.Fatalf("cannot init logger: %s", err)
 		}
 	})
 }
 
+// build creates a logger with format and output set by options.
+//
+// Parameters:
+//   - appENV: Application environment added to JSON logs and choosing format if it is not set
+//   - lvl: Level of the logger
+//   - opts: Format and output of logs
+//
+// Returns:
+//   - *zap.Logger: Configured logger
+//   - error: If output cannot be opened
+func build(appENV string, lvl zap.AtomicLevel, opts ...Option) (*zap.Logger, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	format := o.format
+	if format != FormatJSON && format != FormatConsole {
+		format = FormatConsole
+		if appENV == "production" {
+			format = FormatJSON
+		}
+	}
+
+	var cfg zap.Config
+	switch format {
+	case FormatJSON:
+		cfg = zap.NewProductionConfig()
+		cfg.InitialFields = map[string]any{
+			"app_name":    o.appName,
+			"app_version": o.appVersion,
+			"app_env":     appENV,
+		}
+	default:
+		cfg = zap.NewDevelopmentConfig()
+		cfg.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	}
+
+	cfg.Level = lvl
+	if o.outputPath != "" {
+		cfg.OutputPaths = []string{o.outputPath}
+	}
+
+	return cfg.Build(zap.AddCaller())
+}
+
+// SetLevel changes the level of the global logger without rebuilding it.
+// Parameters:
+//   - logLevel: Desired log level ("debug", "info", "warn", "error"), InfoLevel for invalid inputs
+func SetLevel(logLevel string) {
+	level.SetLevel(buildLogLevel(logLevel).Level())
+}
+
+// Reloader applies the log level of reloaded configuration to the global logger.
+type Reloader struct{}
+
+// Reload changes the level of the global logger to the configured one.
+//
+// Parameters:
+//   - cfg: Reloaded configuration
+//
+// Returns:
+//   - error: Always nil
+func (Reloader) Reload(cfg *config.Config) error {
+	SetLevel(cfg.Log.Level)
+	return nil
+}
+
 // buildLogLevel converts a string log level to zap's AtomicLevel.
 // This is an internal helper function used during logger setup.
 //
@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gururuby/shortener/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func Test_build(t *testing.T) {
+	tests := []struct {
+		check  func(t *testing.T, out string)
+		name   string
+		appENV string
+		format string
+	}{
+		{
+			name:   "when format is json",
+			appENV: "development",
+			format: FormatJSON,
+			check: func(t *testing.T, out string) {
+				var entry map[string]any
+				require.NoError(t, json.Unmarshal([]byte(out), &entry))
+				assert.Equal(t, "Shortener", entry["app_name"])
+				assert.Equal(t, "1.2.3", entry["app_version"])
+				assert.Equal(t, "development", entry["app_env"])
+				assert.Equal(t, "short URL is created", entry["msg"])
+				assert.Equal(t, "abc", entry["alias"])
+			},
+		},
+		{
+			name:   "when format is console",
+			appENV: "production",
+			format: FormatConsole,
+			check: func(t *testing.T, out string) {
+				assert.False(t, json.Valid([]byte(out)))
+				assert.NotContains(t, out, "app_name")
+				assert.Contains(t, out, "short URL is created")
+				assert.Contains(t, out, "logger/logger_test.go")
+			},
+		},
+		{
+			name:   "when format is not set in production",
+			appENV: "production",
+			check: func(t *testing.T, out string) {
+				assert.True(t, json.Valid([]byte(out)))
+				assert.Contains(t, out, "app_name")
+			},
+		},
+		{
+			name:   "when format is not set in development",
+			appENV: "development",
+			check: func(t *testing.T, out string) {
+				assert.False(t, json.Valid([]byte(out)))
+				assert.NotContains(t, out, "app_name")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "shortener.log")
+
+			l, err := build(tt.appENV, zap.NewAtomicLevelAt(zap.InfoLevel),
+				WithFormat(tt.format),
+				WithOutputPath(path),
+				WithAppInfo("Shortener", "1.2.3"),
+			)
+			require.NoError(t, err)
+
+			l.Info("short URL is created", zap.String("alias", "abc"))
+			require.NoError(t, l.Sync())
+
+			out, err := os.ReadFile(path)
+			require.NoError(t, err)
+			tt.check(t, string(out))
+		})
+	}
+}
+
+func Test_Reloader_Reload(t *testing.T) {
+	SetLevel("info")
+	t.Cleanup(func() { SetLevel("info") })
+
+	require.NoError(t, Reloader{}.Reload(&config.Config{Log: config.Log{Level: "debug"}}))
+
+	assert.Equal(t, zap.DebugLevel, level.Level())
+}
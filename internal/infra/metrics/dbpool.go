@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	healthEntity "github.com/gururuby/shortener/internal/domain/entity/health"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Database pool metric names
+const (
+	dbPoolMaxConnsName      = "db_pool_max_connections"
+	dbPoolOpenConnsName     = "db_pool_open_connections"
+	dbPoolIdleConnsName     = "db_pool_idle_connections"
+	dbPoolAcquiredConnsName = "db_pool_acquired_connections"
+	dbPoolWaitCountName     = "db_pool_wait_count_total"
+)
+
+// dbPoolCollector exports live statistics of database connection pool.
+// Statistics are read once per scrape, so all metrics describe the same moment.
+type dbPoolCollector struct {
+	stats         func() *healthEntity.DBPoolStats // Source of pool statistics
+	maxConns      *prometheus.Desc
+	openConns     *prometheus.Desc
+	idleConns     *prometheus.Desc
+	acquiredConns *prometheus.Desc
+	waitCount     *prometheus.Desc
+}
+
+// RegisterDBPoolStats registers collector of database connection pool statistics:
+// - db_pool_max_connections: Maximum size of the pool
+// - db_pool_open_connections: Connections open, both idle and in use
+// - db_pool_idle_connections: Idle connections
+// - db_pool_acquired_connections: Connections in use
+// - db_pool_wait_count_total: Acquires which had to wait for a free connection
+// Parameters:
+// - reg: Registry to register collector in
+// - stats: Function returning current pool statistics, nil skips the scrape
+func RegisterDBPoolStats(reg *prometheus.Registry, stats func() *healthEntity.DBPoolStats) {
+	reg.MustRegister(&dbPoolCollector{
+		stats:         stats,
+		maxConns:      prometheus.NewDesc(dbPoolMaxConnsName, "Maximum size of database connection pool.", nil, nil),
+		openConns:     prometheus.NewDesc(dbPoolOpenConnsName, "Number of open database connections.", nil, nil),
+		idleConns:     prometheus.NewDesc(dbPoolIdleConnsName, "Number of idle database connections.", nil, nil),
+		acquiredConns: prometheus.NewDesc(dbPoolAcquiredConnsName, "Number of database connections in use.", nil, nil),
+		waitCount:     prometheus.NewDesc(dbPoolWaitCountName, "Total number of acquires which waited for a free database connection.", nil, nil),
+	})
+}
+
+// Describe sends descriptors of pool metrics.
+func (c *dbPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.maxConns
+	ch <- c.openConns
+	ch <- c.idleConns
+	ch <- c.acquiredConns
+	ch <- c.waitCount
+}
+
+// Collect reads pool statistics and sends them as metrics.
+func (c *dbPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.stats()
+	if stats == nil {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stats.MaxConnections))
+	ch <- prometheus.MustNewConstMetric(c.openConns, prometheus.GaugeValue, float64(stats.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stats.IdleConnections))
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stats.AcquiredConnections))
+	ch <- prometheus.MustNewConstMetric(c.waitCount, prometheus.CounterValue, float64(stats.WaitCount))
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	healthEntity "github.com/gururuby/shortener/internal/domain/entity/health"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RegisterDBPoolStats(t *testing.T) {
+	t.Run("when database has a pool", func(t *testing.T) {
+		reg := prometheus.NewRegistry()
+		stats := &healthEntity.DBPoolStats{
+			MaxConnections:      10,
+			OpenConnections:     4,
+			IdleConnections:     1,
+			AcquiredConnections: 3,
+			WaitCount:           7,
+		}
+		RegisterDBPoolStats(reg, func() *healthEntity.DBPoolStats { return stats })
+
+		expected := `
+# HELP db_pool_acquired_connections Number of database connections in use.
+# TYPE db_pool_acquired_connections gauge
+db_pool_acquired_connections 3
+# HELP db_pool_idle_connections Number of idle database connections.
+# TYPE db_pool_idle_connections gauge
+db_pool_idle_connections 1
+# HELP db_pool_max_connections Maximum size of database connection pool.
+# TYPE db_pool_max_connections gauge
+db_pool_max_connections 10
+# HELP db_pool_open_connections Number of open database connections.
+# TYPE db_pool_open_connections gauge
+db_pool_open_connections 4
+# HELP db_pool_wait_count_total Total number of acquires which waited for a free database connection.
+# TYPE db_pool_wait_count_total counter
+db_pool_wait_count_total 7
+`
+		require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected)))
+	})
+
+	t.Run("when database has no pool", func(t *testing.T) {
+		reg := prometheus.NewRegistry()
+		RegisterDBPoolStats(reg, func() *healthEntity.DBPoolStats { return nil })
+
+		count, err := testutil.GatherAndCount(reg)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+}
@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// eventBusDroppedName is the name of the dropped events metric.
+const eventBusDroppedName = "eventbus_dropped_events_total"
+
+// RegisterEventBusStats registers statistics of the in-process event bus:
+// - eventbus_dropped_events_total: Events dropped because a subscriber queue was full
+// Parameters:
+// - reg: Registry to register metrics in
+// - dropped: Function returning the number of dropped events
+func RegisterEventBusStats(reg *prometheus.Registry, dropped func() uint64) {
+	reg.MustRegister(prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Name: eventBusDroppedName,
+		Help: "Total number of events dropped because a subscriber queue was full.",
+	}, func() float64 {
+		return float64(dropped())
+	}))
+}
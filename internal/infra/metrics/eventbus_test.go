@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RegisterEventBusStats(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	RegisterEventBusStats(reg, func() uint64 { return 5 })
+
+	expected := `
+# HELP eventbus_dropped_events_total Total number of events dropped because a subscriber queue was full.
+# TYPE eventbus_dropped_events_total counter
+eventbus_dropped_events_total 5
+`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected)))
+}
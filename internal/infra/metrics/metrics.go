@@ -0,0 +1,109 @@
+/*
+Package metrics provides Prometheus instrumentation for the application.
+
+It features:
+- Application metrics registry with Go runtime and process collectors
+- HTTP middleware recording request counts and per-endpoint timing
+- Use case decorators tracking business operations timing independently of HTTP overhead
+*/
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// Metric names
+const (
+	requestsTotalName      = "shorturl_requests_total"
+	requestDurationName    = "shorturl_request_duration_seconds"
+	creationDurationName   = "shorturl_creation_duration_seconds"
+	redirectDurationName   = "shorturl_redirect_duration_seconds"
+	usersRegisteredName    = "users_registered_total"
+	batchURLsProcessedName = "batch_urls_processed_total"
+)
+
+// unknownRoute labels requests which did not match any route.
+const unknownRoute = "unknown"
+
+// NewRegistry creates a new Prometheus registry with Go runtime and process collectors.
+// Returns:
+// - *prometheus.Registry: Registry for application metrics
+func NewRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return reg
+}
+
+// Middleware creates HTTP middleware recording request metrics:
+// - shorturl_requests_total{method,status}: Number of handled requests
+// - shorturl_request_duration_seconds{method,route}: Request timing per endpoint
+//
+// Routes are labeled by chi route pattern to keep label cardinality bounded.
+// Parameters:
+// - reg: Registry to register collectors in
+// Returns:
+// - func(http.Handler) http.Handler: Middleware for router registration
+func Middleware(reg *prometheus.Registry) func(http.Handler) http.Handler {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: requestsTotalName,
+		Help: "Total number of HTTP requests by method and status code.",
+	}, []string{"method", "status"})
+
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    requestDurationName,
+		Help:    "HTTP request duration in seconds by method and route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	reg.MustRegister(requests, duration)
+
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			sw := &statusResponseWriter{ResponseWriter: w, status: http.StatusOK}
+
+			h.ServeHTTP(sw, r)
+
+			route := unknownRoute
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				route = rctx.RoutePattern()
+			}
+
+			requests.WithLabelValues(r.Method, strconv.Itoa(sw.status)).Inc()
+			duration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+// statusResponseWriter wraps http.ResponseWriter to capture response status code.
+type statusResponseWriter struct {
+	http.ResponseWriter     // Embedded original ResponseWriter
+	status              int // HTTP status code
+}
+
+// WriteHeader captures the status code while writing headers.
+func (w *statusResponseWriter) WriteHeader(statusCode int) {
+	w.status = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Flush sends buffered data to the client if the original ResponseWriter supports it.
+func (w *statusResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the original ResponseWriter, so http.ResponseController reaches the connection.
+func (w *statusResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
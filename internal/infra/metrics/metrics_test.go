@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Middleware(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	r := chi.NewRouter()
+	r.Use(Middleware(reg))
+	r.Get("/{alias}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTemporaryRedirect)
+	})
+	r.Post("/api/shorten", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	r.Get("/ping", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("pong"))
+	})
+	r.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+
+	requests := []struct {
+		method string
+		path   string
+	}{
+		{method: http.MethodGet, path: "/alias1"},
+		{method: http.MethodGet, path: "/alias2"},
+		{method: http.MethodPost, path: "/api/shorten"},
+		{method: http.MethodGet, path: "/ping"},
+	}
+	for _, req := range requests {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(req.method, req.path, nil))
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	resp := w.Result()
+	defer func() {
+		err := resp.Body.Close()
+		require.NoError(t, err)
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	assert.Contains(t, string(body), `shorturl_requests_total{method="GET",status="307"} 2`)
+	assert.Contains(t, string(body), `shorturl_requests_total{method="POST",status="201"} 1`)
+	assert.Contains(t, string(body), `shorturl_requests_total{method="GET",status="200"} 1`)
+	assert.Contains(t, string(body), `shorturl_request_duration_seconds_count{method="GET",route="/{alias}"} 2`)
+	assert.Contains(t, string(body), `shorturl_request_duration_seconds_count{method="POST",route="/api/shorten"} 1`)
+	assert.Contains(t, string(body), `shorturl_request_duration_seconds_count{method="GET",route="/ping"} 1`)
+
+	// The scrape itself is recorded after the response is written
+	count, err := testutil.GatherAndCount(reg, requestsTotalName)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	count, err = testutil.GatherAndCount(reg, requestDurationName)
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+}
+
+func Test_Middleware_UnknownRoute(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	r := chi.NewRouter()
+	r.Use(Middleware(reg))
+	r.Get("/ping", func(_ http.ResponseWriter, _ *http.Request) {})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+	count, err := testutil.GatherAndCount(reg, requestDurationName)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != requestDurationName {
+			continue
+		}
+		for _, label := range family.GetMetric()[0].GetLabel() {
+			if label.GetName() == "route" {
+				assert.Equal(t, unknownRoute, label.GetValue())
+			}
+		}
+	}
+}
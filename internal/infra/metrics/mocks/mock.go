@@ -0,0 +1,473 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gururuby/shortener/internal/infra/metrics (interfaces: ShortURLUseCase,UserUseCase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock.go -package=mocks . ShortURLUseCase,UserUseCase
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	entity0 "github.com/gururuby/shortener/internal/domain/entity/user"
+	usecase "github.com/gururuby/shortener/internal/domain/usecase/user"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockShortURLUseCase is a mock of ShortURLUseCase interface.
+type MockShortURLUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockShortURLUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockShortURLUseCaseMockRecorder is the mock recorder for MockShortURLUseCase.
+type MockShortURLUseCaseMockRecorder struct {
+	mock *MockShortURLUseCase
+}
+
+// NewMockShortURLUseCase creates a new mock instance.
+func NewMockShortURLUseCase(ctrl *gomock.Controller) *MockShortURLUseCase {
+	mock := &MockShortURLUseCase{ctrl: ctrl}
+	mock.recorder = &MockShortURLUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShortURLUseCase) EXPECT() *MockShortURLUseCaseMockRecorder {
+	return m.recorder
+}
+
+// BatchShortURLs mocks base method.
+func (m *MockShortURLUseCase) BatchShortURLs(ctx context.Context, user *entity0.User, urls []entity.BatchShortURLInput) ([]entity.BatchShortURLOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchShortURLs", ctx, user, urls)
+	ret0, _ := ret[0].([]entity.BatchShortURLOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchShortURLs indicates an expected call of BatchShortURLs.
+func (mr *MockShortURLUseCaseMockRecorder) BatchShortURLs(ctx, user, urls any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchShortURLs", reflect.TypeOf((*MockShortURLUseCase)(nil).BatchShortURLs), ctx, user, urls)
+}
+
+// CheckURLQuota mocks base method.
+func (m *MockShortURLUseCase) CheckURLQuota(ctx context.Context, user *entity0.User, count int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckURLQuota", ctx, user, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckURLQuota indicates an expected call of CheckURLQuota.
+func (mr *MockShortURLUseCaseMockRecorder) CheckURLQuota(ctx, user, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckURLQuota", reflect.TypeOf((*MockShortURLUseCase)(nil).CheckURLQuota), ctx, user, count)
+}
+
+// CreateShortURL mocks base method.
+func (m *MockShortURLUseCase) CreateShortURL(ctx context.Context, user *entity0.User, sourceURL string, opts entity.CreateOptions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShortURL", ctx, user, sourceURL, opts)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShortURL indicates an expected call of CreateShortURL.
+func (mr *MockShortURLUseCaseMockRecorder) CreateShortURL(ctx, user, sourceURL, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShortURL", reflect.TypeOf((*MockShortURLUseCase)(nil).CreateShortURL), ctx, user, sourceURL, opts)
+}
+
+// FindShortURL mocks base method.
+func (m *MockShortURLUseCase) FindShortURL(ctx context.Context, namespace, alias string, user *entity0.User) (*entity.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindShortURL", ctx, namespace, alias, user)
+	ret0, _ := ret[0].(*entity.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindShortURL indicates an expected call of FindShortURL.
+func (mr *MockShortURLUseCaseMockRecorder) FindShortURL(ctx, namespace, alias, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShortURL", reflect.TypeOf((*MockShortURLUseCase)(nil).FindShortURL), ctx, namespace, alias, user)
+}
+
+// GetShortURL mocks base method.
+func (m *MockShortURLUseCase) GetShortURL(ctx context.Context, namespace, alias string) (*entity.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortURL", ctx, namespace, alias)
+	ret0, _ := ret[0].(*entity.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortURL indicates an expected call of GetShortURL.
+func (mr *MockShortURLUseCaseMockRecorder) GetShortURL(ctx, namespace, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortURL", reflect.TypeOf((*MockShortURLUseCase)(nil).GetShortURL), ctx, namespace, alias)
+}
+
+// URLQuota mocks base method.
+func (m *MockShortURLUseCase) URLQuota(ctx context.Context, user *entity0.User) (*entity.URLQuota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "URLQuota", ctx, user)
+	ret0, _ := ret[0].(*entity.URLQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// URLQuota indicates an expected call of URLQuota.
+func (mr *MockShortURLUseCaseMockRecorder) URLQuota(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URLQuota", reflect.TypeOf((*MockShortURLUseCase)(nil).URLQuota), ctx, user)
+}
+
+// ValidateBatch mocks base method.
+func (m *MockShortURLUseCase) ValidateBatch(ctx context.Context, urls []entity.BatchShortURLInput) []entity.BatchValidationResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateBatch", ctx, urls)
+	ret0, _ := ret[0].([]entity.BatchValidationResult)
+	return ret0
+}
+
+// ValidateBatch indicates an expected call of ValidateBatch.
+func (mr *MockShortURLUseCaseMockRecorder) ValidateBatch(ctx, urls any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBatch", reflect.TypeOf((*MockShortURLUseCase)(nil).ValidateBatch), ctx, urls)
+}
+
+// ValidateSourceURL mocks base method.
+func (m *MockShortURLUseCase) ValidateSourceURL(ctx context.Context, sourceURL string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateSourceURL", ctx, sourceURL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateSourceURL indicates an expected call of ValidateSourceURL.
+func (mr *MockShortURLUseCaseMockRecorder) ValidateSourceURL(ctx, sourceURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateSourceURL", reflect.TypeOf((*MockShortURLUseCase)(nil).ValidateSourceURL), ctx, sourceURL)
+}
+
+// MockUserUseCase is a mock of UserUseCase interface.
+type MockUserUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserUseCaseMockRecorder
+	isgomock struct{}
+}
+
+// MockUserUseCaseMockRecorder is the mock recorder for MockUserUseCase.
+type MockUserUseCaseMockRecorder struct {
+	mock *MockUserUseCase
+}
+
+// NewMockUserUseCase creates a new mock instance.
+func NewMockUserUseCase(ctrl *gomock.Controller) *MockUserUseCase {
+	mock := &MockUserUseCase{ctrl: ctrl}
+	mock.recorder = &MockUserUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserUseCase) EXPECT() *MockUserUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockUserUseCase) Authenticate(ctx context.Context, token string) (*entity0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, token)
+	ret0, _ := ret[0].(*entity0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockUserUseCaseMockRecorder) Authenticate(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockUserUseCase)(nil).Authenticate), ctx, token)
+}
+
+// AuthenticateAPIKey mocks base method.
+func (m *MockUserUseCase) AuthenticateAPIKey(ctx context.Context, key string) (*entity0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", ctx, key)
+	ret0, _ := ret[0].(*entity0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockUserUseCaseMockRecorder) AuthenticateAPIKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockUserUseCase)(nil).AuthenticateAPIKey), ctx, key)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockUserUseCase) CreateAPIKey(ctx context.Context, user *entity0.User) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, user)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockUserUseCaseMockRecorder) CreateAPIKey(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockUserUseCase)(nil).CreateAPIKey), ctx, user)
+}
+
+// DeactivateUser mocks base method.
+func (m *MockUserUseCase) DeactivateUser(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateUser indicates an expected call of DeactivateUser.
+func (mr *MockUserUseCaseMockRecorder) DeactivateUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateUser", reflect.TypeOf((*MockUserUseCase)(nil).DeactivateUser), ctx, userID)
+}
+
+// DeleteURLs mocks base method.
+func (m *MockUserUseCase) DeleteURLs(ctx context.Context, user *entity0.User, aliases []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteURLs", ctx, user, aliases)
+}
+
+// DeleteURLs indicates an expected call of DeleteURLs.
+func (mr *MockUserUseCaseMockRecorder) DeleteURLs(ctx, user, aliases any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteURLs", reflect.TypeOf((*MockUserUseCase)(nil).DeleteURLs), ctx, user, aliases)
+}
+
+// ExportURLs mocks base method.
+func (m *MockUserUseCase) ExportURLs(ctx context.Context, user *entity0.User, fn func([]*usecase.ExportedURL) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportURLs", ctx, user, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportURLs indicates an expected call of ExportURLs.
+func (mr *MockUserUseCaseMockRecorder) ExportURLs(ctx, user, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportURLs", reflect.TypeOf((*MockUserUseCase)(nil).ExportURLs), ctx, user, fn)
+}
+
+// ExportUserData mocks base method.
+func (m *MockUserUseCase) ExportUserData(ctx context.Context, user *entity0.User) (*usecase.UserExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUserData", ctx, user)
+	ret0, _ := ret[0].(*usecase.UserExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUserData indicates an expected call of ExportUserData.
+func (mr *MockUserUseCaseMockRecorder) ExportUserData(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUserData", reflect.TypeOf((*MockUserUseCase)(nil).ExportUserData), ctx, user)
+}
+
+// GetArchivedURLs mocks base method.
+func (m *MockUserUseCase) GetArchivedURLs(ctx context.Context, user *entity0.User, page, perPage int) (*usecase.ArchivedURLs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedURLs", ctx, user, page, perPage)
+	ret0, _ := ret[0].(*usecase.ArchivedURLs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedURLs indicates an expected call of GetArchivedURLs.
+func (mr *MockUserUseCaseMockRecorder) GetArchivedURLs(ctx, user, page, perPage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedURLs", reflect.TypeOf((*MockUserUseCase)(nil).GetArchivedURLs), ctx, user, page, perPage)
+}
+
+// GetURLHistory mocks base method.
+func (m *MockUserUseCase) GetURLHistory(ctx context.Context, user *entity0.User, alias string) ([]*entity.HistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetURLHistory", ctx, user, alias)
+	ret0, _ := ret[0].([]*entity.HistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetURLHistory indicates an expected call of GetURLHistory.
+func (mr *MockUserUseCaseMockRecorder) GetURLHistory(ctx, user, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLHistory", reflect.TypeOf((*MockUserUseCase)(nil).GetURLHistory), ctx, user, alias)
+}
+
+// GetURLsCursor mocks base method.
+func (m *MockUserUseCase) GetURLsCursor(ctx context.Context, user *entity0.User, cursor, limit int) (*usecase.CursorPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetURLsCursor", ctx, user, cursor, limit)
+	ret0, _ := ret[0].(*usecase.CursorPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetURLsCursor indicates an expected call of GetURLsCursor.
+func (mr *MockUserUseCaseMockRecorder) GetURLsCursor(ctx, user, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLsCursor", reflect.TypeOf((*MockUserUseCase)(nil).GetURLsCursor), ctx, user, cursor, limit)
+}
+
+// GetURLsPaginated mocks base method.
+func (m *MockUserUseCase) GetURLsPaginated(ctx context.Context, user *entity0.User, page, perPage int) (*usecase.PaginatedURLs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetURLsPaginated", ctx, user, page, perPage)
+	ret0, _ := ret[0].(*usecase.PaginatedURLs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetURLsPaginated indicates an expected call of GetURLsPaginated.
+func (mr *MockUserUseCaseMockRecorder) GetURLsPaginated(ctx, user, page, perPage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLsPaginated", reflect.TypeOf((*MockUserUseCase)(nil).GetURLsPaginated), ctx, user, page, perPage)
+}
+
+// ReactivateUser mocks base method.
+func (m *MockUserUseCase) ReactivateUser(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactivateUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReactivateUser indicates an expected call of ReactivateUser.
+func (mr *MockUserUseCaseMockRecorder) ReactivateUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateUser", reflect.TypeOf((*MockUserUseCase)(nil).ReactivateUser), ctx, userID)
+}
+
+// Register mocks base method.
+func (m *MockUserUseCase) Register(ctx context.Context) (*entity0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx)
+	ret0, _ := ret[0].(*entity0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockUserUseCaseMockRecorder) Register(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUserUseCase)(nil).Register), ctx)
+}
+
+// RestoreArchivedURL mocks base method.
+func (m *MockUserUseCase) RestoreArchivedURL(ctx context.Context, user *entity0.User, alias string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreArchivedURL", ctx, user, alias)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreArchivedURL indicates an expected call of RestoreArchivedURL.
+func (mr *MockUserUseCaseMockRecorder) RestoreArchivedURL(ctx, user, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreArchivedURL", reflect.TypeOf((*MockUserUseCase)(nil).RestoreArchivedURL), ctx, user, alias)
+}
+
+// RestoreURL mocks base method.
+func (m *MockUserUseCase) RestoreURL(ctx context.Context, user *entity0.User, alias string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreURL", ctx, user, alias)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreURL indicates an expected call of RestoreURL.
+func (mr *MockUserUseCaseMockRecorder) RestoreURL(ctx, user, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreURL", reflect.TypeOf((*MockUserUseCase)(nil).RestoreURL), ctx, user, alias)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockUserUseCase) RevokeAPIKey(ctx context.Context, user *entity0.User, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, user, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockUserUseCaseMockRecorder) RevokeAPIKey(ctx, user, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockUserUseCase)(nil).RevokeAPIKey), ctx, user, key)
+}
+
+// RevokeToken mocks base method.
+func (m *MockUserUseCase) RevokeToken(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockUserUseCaseMockRecorder) RevokeToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockUserUseCase)(nil).RevokeToken), ctx, token)
+}
+
+// SearchURLs mocks base method.
+func (m *MockUserUseCase) SearchURLs(ctx context.Context, user *entity0.User, query string, page, perPage int) (*usecase.PaginatedURLs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchURLs", ctx, user, query, page, perPage)
+	ret0, _ := ret[0].(*usecase.PaginatedURLs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchURLs indicates an expected call of SearchURLs.
+func (mr *MockUserUseCaseMockRecorder) SearchURLs(ctx, user, query, page, perPage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchURLs", reflect.TypeOf((*MockUserUseCase)(nil).SearchURLs), ctx, user, query, page, perPage)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockUserUseCase) UpdateProfile(ctx context.Context, user *entity0.User, profile usecase.UserProfile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, user, profile)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockUserUseCaseMockRecorder) UpdateProfile(ctx, user, profile any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockUserUseCase)(nil).UpdateProfile), ctx, user, profile)
+}
+
+// UpdateURL mocks base method.
+func (m *MockUserUseCase) UpdateURL(ctx context.Context, user *entity0.User, alias, newURL string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateURL", ctx, user, alias, newURL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateURL indicates an expected call of UpdateURL.
+func (mr *MockUserUseCaseMockRecorder) UpdateURL(ctx, user, alias, newURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateURL", reflect.TypeOf((*MockUserUseCase)(nil).UpdateURL), ctx, user, alias, newURL)
+}
@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// panicsTotalName is the name of the recovered panics metric.
+const panicsTotalName = "panics_total"
+
+// RegisterPanics registers the counter of panics recovered in HTTP handlers:
+// - panics_total: Number of recovered panics
+// Parameters:
+// - reg: Registry to register metrics in
+// Returns:
+// - prometheus.Counter: Counter to increment on every recovered panic
+func RegisterPanics(reg *prometheus.Registry) prometheus.Counter {
+	panics := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: panicsTotalName,
+		Help: "Total number of panics recovered in HTTP handlers.",
+	})
+	reg.MustRegister(panics)
+	return panics
+}
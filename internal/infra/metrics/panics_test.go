@@ -0,0 +1,24 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RegisterPanics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	panics := RegisterPanics(reg)
+	panics.Inc()
+	panics.Inc()
+
+	expected := `
+# HELP panics_total Total number of panics recovered in HTTP handlers.
+# TYPE panics_total counter
+panics_total 2
+`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected)))
+}
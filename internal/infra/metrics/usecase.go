@@ -0,0 +1,178 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . ShortURLUseCase,UserUseCase
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	userUseCase "github.com/gururuby/shortener/internal/domain/usecase/user"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ShortURLUseCase defines the interface for short URL business logic.
+type ShortURLUseCase interface {
+	// CreateShortURL generates a shortened URL with the given options for the original URL
+	CreateShortURL(ctx context.Context, user *userEntity.User, sourceURL string, opts shortURLEntity.CreateOptions) (string, error)
+	// FindShortURL retrieves the short URL for a given namespace and alias if user may access it
+	FindShortURL(ctx context.Context, namespace, alias string, user *userEntity.User) (*shortURLEntity.ShortURL, error)
+	// GetShortURL retrieves the short URL for a given namespace and alias without side effects
+	GetShortURL(ctx context.Context, namespace, alias string) (*shortURLEntity.ShortURL, error)
+	// BatchShortURLs processes multiple URLs in a single operation
+	BatchShortURLs(ctx context.Context, user *userEntity.User, urls []shortURLEntity.BatchShortURLInput) ([]shortURLEntity.BatchShortURLOutput, error)
+	// ValidateSourceURL checks that source URL may be shortened without saving anything
+	ValidateSourceURL(ctx context.Context, sourceURL string) error
+	// ValidateBatch checks source URLs of the batch without saving anything
+	ValidateBatch(ctx context.Context, urls []shortURLEntity.BatchShortURLInput) []shortURLEntity.BatchValidationResult
+	// URLQuota returns usage of the quota of short URLs of the user
+	URLQuota(ctx context.Context, user *userEntity.User) (*shortURLEntity.URLQuota, error)
+	// CheckURLQuota checks that the user may create the number of short URLs
+	CheckURLQuota(ctx context.Context, user *userEntity.User, count int) error
+}
+
+// UserUseCase defines the interface for user business logic.
+type UserUseCase interface {
+	// Authenticate verifies user token and returns the user
+	Authenticate(ctx context.Context, token string) (*userEntity.User, error)
+	// Register creates a new user
+	Register(ctx context.Context) (*userEntity.User, error)
+	// AuthenticateAPIKey verifies API key and returns its owner
+	AuthenticateAPIKey(ctx context.Context, key string) (*userEntity.User, error)
+	// CreateAPIKey generates a new API key of the user
+	CreateAPIKey(ctx context.Context, user *userEntity.User) (string, error)
+	// RevokeAPIKey revokes an API key of the user
+	RevokeAPIKey(ctx context.Context, user *userEntity.User, key string) error
+	// GetURLsPaginated retrieves a page of URLs belonging to the user
+	GetURLsPaginated(ctx context.Context, user *userEntity.User, page, perPage int) (*userUseCase.PaginatedURLs, error)
+	// GetURLsCursor retrieves a page of URLs belonging to the user after the cursor
+	GetURLsCursor(ctx context.Context, user *userEntity.User, cursor, limit int) (*userUseCase.CursorPage, error)
+	// SearchURLs retrieves a page of URLs of the user whose original URL contains the query
+	SearchURLs(ctx context.Context, user *userEntity.User, query string, page, perPage int) (*userUseCase.PaginatedURLs, error)
+	// ExportURLs passes all URLs belonging to the user to fn batch by batch
+	ExportURLs(ctx context.Context, user *userEntity.User, fn func([]*userUseCase.ExportedURL) error) error
+	// ExportUserData assembles all data stored about the user
+	ExportUserData(ctx context.Context, user *userEntity.User) (*userUseCase.UserExport, error)
+	// RevokeToken terminates the session of the token
+	RevokeToken(ctx context.Context, token string) error
+	// DeleteURLs marks user URLs as deleted
+	DeleteURLs(ctx context.Context, user *userEntity.User, aliases []string)
+	// RestoreURL restores a deleted URL belonging to the user
+	RestoreURL(ctx context.Context, user *userEntity.User, alias string) error
+	// GetArchivedURLs retrieves a page of archived URLs belonging to the user
+	GetArchivedURLs(ctx context.Context, user *userEntity.User, page, perPage int) (*userUseCase.ArchivedURLs, error)
+	// RestoreArchivedURL moves an archived URL belonging to the user back to active URLs
+	RestoreArchivedURL(ctx context.Context, user *userEntity.User, alias string) error
+	// UpdateURL replaces the original URL of a URL belonging to the user
+	UpdateURL(ctx context.Context, user *userEntity.User, alias, newURL string) error
+	// UpdateProfile changes settings of the user
+	UpdateProfile(ctx context.Context, user *userEntity.User, profile userUseCase.UserProfile) error
+	// GetURLHistory retrieves previous original URLs of a URL belonging to the user
+	GetURLHistory(ctx context.Context, user *userEntity.User, alias string) ([]*shortURLEntity.HistoryEntry, error)
+	// DeactivateUser prevents the user from authenticating
+	DeactivateUser(ctx context.Context, userID int) error
+	// ReactivateUser allows the deactivated user to authenticate again
+	ReactivateUser(ctx context.Context, userID int) error
+}
+
+// InstrumentedShortURLUseCase decorates ShortURLUseCase with metrics:
+// - shorturl_creation_duration_seconds: Timing of short URL creation of any kind
+// - shorturl_redirect_duration_seconds: FindShortURL timing
+// - batch_urls_processed_total: Number of URLs shortened in batches
+type InstrumentedShortURLUseCase struct {
+	ShortURLUseCase                       // Decorated use case
+	creationDuration prometheus.Histogram // Short URL creation timing
+	redirectDuration prometheus.Histogram // Short URL lookup timing
+	batchProcessed   prometheus.Counter   // Number of URLs shortened in batches
+}
+
+// InstrumentShortURLUseCase wraps short URL use case with metrics.
+// Parameters:
+// - uc: Use case to decorate
+// - reg: Registry to register collectors in
+// Returns:
+// - *InstrumentedShortURLUseCase: Decorated use case
+func InstrumentShortURLUseCase(uc ShortURLUseCase, reg *prometheus.Registry) *InstrumentedShortURLUseCase {
+	i := &InstrumentedShortURLUseCase{
+		ShortURLUseCase: uc,
+		creationDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    creationDurationName,
+			Help:    "Short URL creation duration in seconds.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		redirectDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    redirectDurationName,
+			Help:    "Short URL lookup duration in seconds.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		batchProcessed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: batchURLsProcessedName,
+			Help: "Total number of URLs shortened in batches.",
+		}),
+	}
+	reg.MustRegister(i.creationDuration, i.redirectDuration, i.batchProcessed)
+	return i
+}
+
+// CreateShortURL records creation timing of the decorated use case.
+func (i *InstrumentedShortURLUseCase) CreateShortURL(ctx context.Context, user *userEntity.User, sourceURL string, opts shortURLEntity.CreateOptions) (string, error) {
+	defer observeDuration(i.creationDuration, time.Now())
+	return i.ShortURLUseCase.CreateShortURL(ctx, user, sourceURL, opts)
+}
+
+// FindShortURL records lookup timing of the decorated use case.
+func (i *InstrumentedShortURLUseCase) FindShortURL(ctx context.Context, namespace, alias string, user *userEntity.User) (*shortURLEntity.ShortURL, error) {
+	defer observeDuration(i.redirectDuration, time.Now())
+	return i.ShortURLUseCase.FindShortURL(ctx, namespace, alias, user)
+}
+
+// BatchShortURLs counts URLs shortened by the decorated use case, failed URLs are not counted.
+func (i *InstrumentedShortURLUseCase) BatchShortURLs(ctx context.Context, user *userEntity.User, urls []shortURLEntity.BatchShortURLInput) ([]shortURLEntity.BatchShortURLOutput, error) {
+	res, err := i.ShortURLUseCase.BatchShortURLs(ctx, user, urls)
+	for _, out := range res {
+		if out.Error == "" {
+			i.batchProcessed.Inc()
+		}
+	}
+	return res, err
+}
+
+// InstrumentedUserUseCase decorates UserUseCase with metrics:
+// - users_registered_total: Number of registered users
+type InstrumentedUserUseCase struct {
+	UserUseCase                        // Decorated use case
+	usersRegistered prometheus.Counter // Number of registered users
+}
+
+// InstrumentUserUseCase wraps user use case with metrics.
+// Parameters:
+// - uc: Use case to decorate
+// - reg: Registry to register collectors in
+// Returns:
+// - *InstrumentedUserUseCase: Decorated use case
+func InstrumentUserUseCase(uc UserUseCase, reg *prometheus.Registry) *InstrumentedUserUseCase {
+	i := &InstrumentedUserUseCase{
+		UserUseCase: uc,
+		usersRegistered: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: usersRegisteredName,
+			Help: "Total number of registered users.",
+		}),
+	}
+	reg.MustRegister(i.usersRegistered)
+	return i
+}
+
+// Register counts users registered by the decorated use case.
+func (i *InstrumentedUserUseCase) Register(ctx context.Context) (*userEntity.User, error) {
+	user, err := i.UserUseCase.Register(ctx)
+	if err == nil {
+		i.usersRegistered.Inc()
+	}
+	return user, err
+}
+
+// observeDuration records time elapsed since start.
+func observeDuration(h prometheus.Histogram, start time.Time) {
+	h.Observe(time.Since(start).Seconds())
+}
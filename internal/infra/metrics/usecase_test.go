@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	userEntity "github.com/gururuby/shortener/internal/domain/entity/user"
+	"github.com/gururuby/shortener/internal/infra/metrics/mocks"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_InstrumentedShortURLUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	uc := mocks.NewMockShortURLUseCase(ctrl)
+	ctx := context.Background()
+	reg := prometheus.NewRegistry()
+
+	i := InstrumentShortURLUseCase(uc, reg)
+	user := &userEntity.User{ID: 1}
+
+	uc.EXPECT().CreateShortURL(ctx, nil, "https://ya.ru", shortURLEntity.CreateOptions{}).Return("http://localhost/alias", nil)
+	uc.EXPECT().CreateShortURL(ctx, nil, "https://ya.ru", shortURLEntity.CreateOptions{OneTimeUse: true}).Return("http://localhost/once", nil)
+	uc.EXPECT().CreateShortURL(ctx, user, "https://ya.ru", shortURLEntity.CreateOptions{Visibility: shortURLEntity.VisibilityPrivate}).Return("http://localhost/private", nil)
+	uc.EXPECT().CreateShortURL(ctx, nil, "https://ya.ru", shortURLEntity.CreateOptions{RedirectType: shortURLEntity.RedirectPermanent}).Return("http://localhost/permanent", nil)
+	uc.EXPECT().CreateShortURL(ctx, nil, "https://ya.ru", shortURLEntity.CreateOptions{Tracked: true}).Return("http://localhost/tracked", nil)
+	uc.EXPECT().FindShortURL(ctx, "", "alias", nil).Return(&shortURLEntity.ShortURL{SourceURL: "https://ya.ru"}, nil).Times(2)
+	uc.EXPECT().BatchShortURLs(ctx, nil, gomock.Any()).Return([]shortURLEntity.BatchShortURLOutput{
+		{CorrelationID: "1"}, {CorrelationID: "2"}, {CorrelationID: "3", Error: "invalid source URL"},
+	}, nil)
+
+	res, err := i.CreateShortURL(ctx, nil, "https://ya.ru", shortURLEntity.CreateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost/alias", res)
+
+	res, err = i.CreateShortURL(ctx, nil, "https://ya.ru", shortURLEntity.CreateOptions{OneTimeUse: true})
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost/once", res)
+
+	res, err = i.CreateShortURL(ctx, user, "https://ya.ru", shortURLEntity.CreateOptions{Visibility: shortURLEntity.VisibilityPrivate})
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost/private", res)
+
+	res, err = i.CreateShortURL(ctx, nil, "https://ya.ru", shortURLEntity.CreateOptions{RedirectType: shortURLEntity.RedirectPermanent})
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost/permanent", res)
+
+	res, err = i.CreateShortURL(ctx, nil, "https://ya.ru", shortURLEntity.CreateOptions{Tracked: true})
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost/tracked", res)
+
+	for range 2 {
+		found, findErr := i.FindShortURL(ctx, "", "alias", nil)
+		require.NoError(t, findErr)
+		assert.Equal(t, "https://ya.ru", found.SourceURL)
+	}
+
+	batch, err := i.BatchShortURLs(ctx, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, batch, 3)
+
+	assert.Equal(t, 2.0, histogramCount(t, i.redirectDuration))
+	assert.Equal(t, 5.0, histogramCount(t, i.creationDuration))
+	assert.Equal(t, 2.0, testutil.ToFloat64(i.batchProcessed))
+}
+
+func Test_InstrumentedUserUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	uc := mocks.NewMockUserUseCase(ctrl)
+	ctx := context.Background()
+	reg := prometheus.NewRegistry()
+
+	i := InstrumentUserUseCase(uc, reg)
+
+	uc.EXPECT().Register(ctx).Return(&userEntity.User{ID: 1}, nil)
+	uc.EXPECT().Register(ctx).Return(nil, errors.New("cannot register"))
+	uc.EXPECT().Authenticate(ctx, "token").Return(&userEntity.User{ID: 1}, nil)
+
+	_, err := i.Register(ctx)
+	require.NoError(t, err)
+
+	_, err = i.Register(ctx)
+	require.Error(t, err)
+
+	user, err := i.Authenticate(ctx, "token")
+	require.NoError(t, err)
+	assert.Equal(t, 1, user.ID)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(i.usersRegistered))
+}
+
+// histogramCount returns the number of observations of histogram.
+func histogramCount(t *testing.T, h prometheus.Histogram) float64 {
+	t.Helper()
+
+	metrics := make(chan prometheus.Metric, 1)
+	h.Collect(metrics)
+
+	pb := &dto.Metric{}
+	require.NoError(t, (<-metrics).Write(pb))
+
+	return float64(pb.GetHistogram().GetSampleCount())
+}
@@ -0,0 +1,79 @@
+/*
+Package openapi provides the OpenAPI 3.0 specification of the HTTP API.
+
+It features:
+- Hand-authored specification embedded into the binary
+- Specification loading and validation
+- Specification serving in JSON format
+- Swagger UI page for interactive API exploration
+*/
+package openapi
+
+import (
+	"context"
+	_ "embed"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Available constants
+const (
+	SpecPath = "/api/docs/openapi.json" // Path of the specification in JSON format
+	UIPath   = "/api/docs"              // Path of Swagger UI page
+)
+
+//go:embed openapi.yaml
+var specYAML []byte
+
+//go:embed swagger.html
+var swaggerHTML []byte
+
+// Load parses and validates the embedded specification.
+// Returns:
+// - *openapi3.T: Loaded specification
+// - error: If specification is malformed or invalid
+func Load() (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(specYAML)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = doc.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// SpecHandler returns handler serving the specification in JSON format.
+// Parameters:
+// - doc: Loaded specification
+// Returns:
+// - http.HandlerFunc: Handler for router registration
+// - error: If specification cannot be encoded
+func SpecHandler(doc *openapi3.T) (http.HandlerFunc, error) {
+	spec, err := doc.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(spec); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}, nil
+}
+
+// UIHandler returns handler serving Swagger UI page for the specification.
+// Returns:
+// - http.HandlerFunc: Handler for router registration
+func UIHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if _, err := w.Write(swaggerHTML); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	doc, err := Load()
+	require.NoError(t, err)
+
+	assert.NotNil(t, doc.Paths.Find("/api/shorten"))
+	assert.NotNil(t, doc.Paths.Find("/api/user/urls"))
+}
+
+func TestSpecHandler(t *testing.T) {
+	doc, err := Load()
+	require.NoError(t, err)
+
+	handler, err := SpecHandler(doc)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, SpecPath, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spec))
+	assert.Equal(t, "3.0.3", spec["openapi"])
+}
+
+func TestUIHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	UIHandler()(rec, httptest.NewRequest(http.MethodGet, UIPath, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), SpecPath)
+}
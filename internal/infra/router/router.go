@@ -5,17 +5,52 @@ It features:
 - Chi router implementation with common middleware
 - Standardized HTTP method routing
 - Debug profiling endpoint
+- Prometheus metrics endpoint
+- OpenAPI specification and Swagger UI endpoints
 - Interface for router abstraction
 */
 package router
 
 import (
+	"log"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/gururuby/shortener/internal/config"
+	"github.com/gururuby/shortener/internal/infra/logger"
+	"github.com/gururuby/shortener/internal/infra/metrics"
+	"github.com/gururuby/shortener/internal/infra/openapi"
 	"github.com/gururuby/shortener/internal/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// Available constants
+const (
+	metricsPath        = "/metrics"      // Path of Prometheus metrics endpoint
+	previewPath        = "/api/preview"  // Path of page preview endpoint fetching external pages
+	internalPathPrefix = "/api/internal" // Path prefix of endpoints available only from trusted subnet
+)
+
+// Rate limits applied to incoming requests
+const (
+	globalRequestsPerSecond  = 100 // Requests per second allowed for a single IP
+	globalBurstSize          = 200 // Burst size allowed for a single IP
+	shortenRequestsPerSecond = 10  // Requests per second allowed for a single IP on URL shortening
+	shortenBurstSize         = 20  // Burst size allowed for a single IP on URL shortening
+	previewRequestsPerMinute = 10  // Page previews per minute allowed for a single IP
+)
+
+// corsAllowedMethods lists HTTP methods exposed to cross-origin clients.
+var corsAllowedMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// shortenPaths lists paths which create short URLs and are limited more strictly.
+var shortenPaths = []string{"/", "/api/shorten"}
+
 // Router defines the interface for HTTP request routing.
 // Implementations should provide methods for registering route handlers
 // and serving HTTP requests.
@@ -29,22 +64,159 @@ type Router interface {
 	// Delete registers a handler for HTTP DELETE requests at the specified path
 	Delete(path string, h http.HandlerFunc)
 
+	// Head registers a handler for HTTP HEAD requests at the specified path
+	Head(path string, h http.HandlerFunc)
+
+	// Put registers a handler for HTTP PUT requests at the specified path
+	Put(path string, h http.HandlerFunc)
+
+	// Patch registers a handler for HTTP PATCH requests at the specified path
+	Patch(path string, h http.HandlerFunc)
+
 	// ServeHTTP dispatches the request to the handler whose pattern matches
 	ServeHTTP(writer http.ResponseWriter, request *http.Request)
 }
 
 // Setup creates and configures a new router instance with default middleware.
 // The returned router includes:
-// - Request logging middleware
+// - Panic recovery middleware, covering all the others
+// - Request ID middleware
+// - Request tracing middleware
+// - HEAD requests routing to GET handlers if no HEAD handler is registered
+// - Access log middleware (sampling successful requests when configured)
+// - Request metrics middleware
+// - Per-IP rate limiting (stricter for URL shortening and page preview endpoints)
+// - CORS middleware (when allowed origins are configured)
 // - Response compression middleware
+// - Request body size limit (except metrics endpoint)
+// - Trusted subnet check for internal endpoints under /api/internal
+// - Request validation against OpenAPI specification (when enabled)
 // - Debug profiling endpoint at /debug
+// - Prometheus metrics endpoint at /metrics
+// - OpenAPI specification at /api/docs/openapi.json and Swagger UI at /api/docs
+//
+// Parameters:
+// - cfg: Application configuration
+// - reg: Prometheus registry with application metrics
+// - tp: Tracer provider creating request spans
+// - bodyLimiter: Limiter of request body size, nil limits bodies to cfg.Server.MaxBodyBytes
 //
 // Returns:
 // - Router: Configured router instance ready for route registration
-func Setup() Router {
+func Setup(cfg *config.Config, reg *prometheus.Registry, tp trace.TracerProvider, bodyLimiter *middleware.BodyLimiter) Router {
 	router := chi.NewRouter()
-	router.Use(middleware.Logging)
-	router.Use(middleware.Compression)
+	router.Use(middleware.Recovery(logger.Log, middleware.RecoverWithCounter(metrics.RegisterPanics(reg))))
+	router.Use(middleware.RequestID)
+	router.Use(logger.Middleware(logger.Log))
+	router.Use(middleware.TLSAudit(cfg.Server.ExposeClientCertFingerprint))
+	router.Use(middleware.Tracing(tp))
+	router.Use(chiMiddleware.GetHead)
+	router.Use(middleware.AccessLog(logger.Log, middleware.WithSamplingRate(cfg.Log.AccessSamplingRate)))
+	router.Use(metrics.Middleware(reg))
+	router.Use(middleware.RateLimit(middleware.RateLimitConfig{
+		RequestsPerSecond: globalRequestsPerSecond,
+		BurstSize:         globalBurstSize,
+		KeyFunc:           middleware.RemoteIP,
+	}))
+	router.Use(onlyFor(http.MethodPost, shortenPaths, middleware.RateLimit(middleware.RateLimitConfig{
+		RequestsPerSecond: shortenRequestsPerSecond,
+		BurstSize:         shortenBurstSize,
+		KeyFunc:           middleware.RemoteIP,
+	})))
+	router.Use(onlyFor(http.MethodGet, []string{previewPath}, middleware.RateLimit(middleware.RateLimitConfig{
+		RequestsPerSecond: previewRequestsPerMinute / 60.0,
+		BurstSize:         previewRequestsPerMinute,
+		KeyFunc:           middleware.RemoteIP,
+	})))
+	if len(cfg.Server.AllowedOrigins) > 0 {
+		router.Use(middleware.CORS(cfg.Server.AllowedOrigins, corsAllowedMethods))
+	}
+	router.Use(middleware.PreferredCompression(cfg.Server.PreferredEncoding))
+	if bodyLimiter == nil {
+		bodyLimiter = middleware.NewBodyLimiter(cfg.Server.MaxBodyBytes)
+	}
+	router.Use(except([]string{metricsPath}, bodyLimiter.Middleware))
+	router.Use(withPrefix(internalPathPrefix, middleware.TrustedSubnet(cfg.Server.TrustedSubnet, cfg.Server.TrustedProxy)))
+
+	doc, err := openapi.Load()
+	if err != nil {
+		log.Fatalf("cannot load OpenAPI specification: %s", err)
+	}
+
+	if cfg.Server.ValidateRequests {
+		validate, validateErr := middleware.ValidateRequests(doc)
+		if validateErr != nil {
+			log.Fatalf("cannot setup request validation: %s", validateErr)
+		}
+		router.Use(validate)
+	}
+
+	specHandler, err := openapi.SpecHandler(doc)
+	if err != nil {
+		log.Fatalf("cannot encode OpenAPI specification: %s", err)
+	}
+
+	router.Handle(metricsPath, promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg}))
+	router.Get(openapi.SpecPath, specHandler)
+	router.Get(openapi.UIPath, openapi.UIHandler())
 
 	return router
 }
+
+// except applies middleware to all requests except ones with given paths.
+// Parameters:
+// - paths: Request paths to skip
+// - mw: Middleware to apply
+// Returns:
+// - func(http.Handler) http.Handler: Middleware for router registration
+func except(paths []string, mw func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		wrapped := mw(h)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if slices.Contains(paths, r.URL.Path) {
+				h.ServeHTTP(w, r)
+				return
+			}
+			wrapped.ServeHTTP(w, r)
+		})
+	}
+}
+
+// withPrefix applies middleware only to requests with paths under given prefix.
+// Parameters:
+// - prefix: Request path prefix to match
+// - mw: Middleware to apply
+// Returns:
+// - func(http.Handler) http.Handler: Middleware for router registration
+func withPrefix(prefix string, mw func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		wrapped := mw(h)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == prefix || strings.HasPrefix(r.URL.Path, prefix+"/") {
+				wrapped.ServeHTTP(w, r)
+				return
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// onlyFor applies middleware only to requests with given method and paths.
+// Parameters:
+// - method: HTTP method to match
+// - paths: Request paths to match
+// - mw: Middleware to apply
+// Returns:
+// - func(http.Handler) http.Handler: Middleware for router registration
+func onlyFor(method string, paths []string, mw func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		wrapped := mw(h)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == method && slices.Contains(paths, r.URL.Path) {
+				wrapped.ServeHTTP(w, r)
+				return
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+}
@@ -16,4 +16,8 @@ var (
 	// - Certificate files are not readable
 	// - Certificate and key don't match
 	ErrServerInvalidTLSConfig = errors.New("invalid TLS configuration")
+
+	// ErrTLSAmbiguousConfig indicates that certificate or key is configured both as a file and as PEM,
+	// so it is unclear which one should be used.
+	ErrTLSAmbiguousConfig = errors.New("ambiguous TLS configuration: both file and PEM are set")
 )
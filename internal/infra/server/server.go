@@ -1,17 +1,25 @@
 /*
 Package server provides HTTP server implementation with:
-- Configurable HTTP/HTTPS support
-- Graceful shutdown handling
+- Configurable HTTP/HTTPS support, certificates are loaded from files or environment variables
+- Graceful shutdown handling with draining of in-flight requests
 - Proper timeout management
 - Signal handling for termination
 */
 package server
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
+	"sync/atomic"
 	"syscall"
 
 	"github.com/gururuby/shortener/internal/config"
@@ -37,10 +45,13 @@ type DB interface {
 // Server represents an HTTP server with graceful shutdown capabilities.
 // It manages the server lifecycle including startup, shutdown and error handling.
 type Server struct {
-	config  *config.Config // Application configuration including server settings
-	router  Router         // HTTP request router implementation
-	backend *http.Server   // Underlying HTTP server instance
-	db      DB             // Database interface for graceful shutdown
+	config         *config.Config     // Application configuration including server settings
+	router         Router             // HTTP request router implementation
+	backend        *http.Server       // Underlying HTTP server instance
+	db             DB                 // Database interface for graceful shutdown
+	requests       sync.WaitGroup     // In-flight requests awaited before database shutdown
+	active         atomic.Int64       // Number of in-flight requests
+	cancelRequests context.CancelFunc // Cancels contexts of in-flight requests on forced shutdown
 }
 
 // New creates and configures a new Server instance.
@@ -52,12 +63,53 @@ type Server struct {
 // Returns:
 //   - *Server: Configured server instance ready to run
 func New(router Router, cfg *config.Config, db DB) *Server {
-	return &Server{
-		router:  router,
-		config:  cfg,
-		backend: createHTTPServer(router, cfg),
-		db:      db,
+	s := &Server{
+		router: router,
+		config: cfg,
+		db:     db,
 	}
+
+	baseCtx, cancel := context.WithCancel(context.Background())
+	s.cancelRequests = cancel
+	s.backend = createHTTPServer(s.trackRequests(router), cfg)
+	s.backend.BaseContext = func(net.Listener) context.Context { return baseCtx }
+
+	return s
+}
+
+// ActiveConnections returns the number of connections serving requests at the moment.
+// Idle keep-alive connections are not counted.
+// Returns:
+//   - int: Number of in-flight requests
+func (s *Server) ActiveConnections() int {
+	return int(s.active.Load())
+}
+
+// trackRequests wraps the router with middleware counting in-flight requests.
+// Parameters:
+//   - next: Router serving requests
+//
+// Returns:
+//   - http.Handler: Router wrapped with request tracking
+func (s *Server) trackRequests(next Router) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+		s.active.Add(1)
+		defer func() {
+			s.active.Add(-1)
+			s.requests.Done()
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// OnShutdown registers a function called when graceful shutdown starts.
+// It is used to end long-lived responses, e.g. event streams, which would otherwise delay shutdown.
+// Parameters:
+//   - f: Function to call, must not block
+func (s *Server) OnShutdown(f func()) {
+	s.backend.RegisterOnShutdown(f)
 }
 
 // Run starts the HTTP/HTTPS server and blocks until shutdown.
@@ -81,15 +133,15 @@ func (s *Server) Run() {
 
 // createHTTPServer initializes the http.Server with configured timeouts.
 // Parameters:
-//   - router: HTTP request router
+//   - handler: HTTP request handler
 //   - cfg: Configuration containing timeout settings
 //
 // Returns:
 //   - *http.Server: Configured HTTP server instance
-func createHTTPServer(router Router, cfg *config.Config) *http.Server {
+func createHTTPServer(handler http.Handler, cfg *config.Config) *http.Server {
 	return &http.Server{
 		Addr:         cfg.Server.Address,
-		Handler:      router,
+		Handler:      handler,
 		ReadTimeout:  cfg.Server.ReadTimeout,
 		WriteTimeout: cfg.Server.WriteTimeout,
 		IdleTimeout:  cfg.Server.IdleTimeout,
@@ -104,6 +156,16 @@ func (s *Server) startHTTPS() error {
 		return err
 	}
 
+	tlsConfig, err := createTLSCredentials(s.config)
+	if err != nil {
+		return err
+	}
+	if tlsConfig != nil {
+		logger.Log.Info("HTTPS server starting with certificate from environment")
+		s.backend.TLSConfig = tlsConfig
+		return s.backend.ListenAndServeTLS("", "")
+	}
+
 	logger.Log.Info("HTTPS server starting",
 		zap.String("certFile", s.config.Server.HTTPS.CertFile),
 		zap.String("keyFile", s.config.Server.HTTPS.KeyFile),
@@ -123,13 +185,28 @@ func (s *Server) startHTTP() error {
 }
 
 // validateTLSConfig verifies HTTPS configuration is valid.
+// Certificate and key must be both configured either as PEM or as files.
 // Parameters:
 //   - cfg: Configuration containing TLS settings
 //
 // Returns:
-//   - error: If certificate or key files are not specified
+//   - error: ErrTLSAmbiguousConfig if file and PEM are both set, ErrServerInvalidTLSConfig if certificate or key is missing
 func validateTLSConfig(cfg *config.Config) error {
-	if cfg.Server.HTTPS.CertFile == "" || cfg.Server.HTTPS.KeyFile == "" {
+	https := cfg.Server.HTTPS
+	if (https.CertFile != "" && https.CertPEM != "") || (https.KeyFile != "" && https.KeyPEM != "") {
+		logger.Log.Error("Ambiguous TLS configuration, certificate and key must be set either as files or as PEM")
+		return errors.ErrTLSAmbiguousConfig
+	}
+
+	if https.CertPEM != "" || https.KeyPEM != "" {
+		if https.CertPEM == "" || https.KeyPEM == "" {
+			logger.Log.Error("Invalid TLS configuration, both certificate and key PEM must be set")
+			return errors.ErrServerInvalidTLSConfig
+		}
+		return nil
+	}
+
+	if https.CertFile == "" || https.KeyFile == "" {
 		logger.Log.Error("Invalid TLS configuration",
 			zap.String("certFile", cfg.Server.HTTPS.CertFile),
 			zap.String("keyFile", cfg.Server.HTTPS.KeyFile),
@@ -139,6 +216,61 @@ func validateTLSConfig(cfg *config.Config) error {
 	return nil
 }
 
+// createTLSCredentials builds TLS configuration from certificate and key PEM set in environment.
+// Parameters:
+//   - cfg: Configuration containing TLS settings
+//
+// Returns:
+//   - *tls.Config: Configuration with the certificate, nil if certificate is loaded from files
+//   - error: ErrServerInvalidTLSConfig if PEM cannot be decoded or certificate doesn't match the key
+func createTLSCredentials(cfg *config.Config) (*tls.Config, error) {
+	https := cfg.Server.HTTPS
+	if https.CertPEM == "" {
+		return nil, nil
+	}
+
+	certPEM, err := decodePEM(https.CertPEM, "CERTIFICATE")
+	if err != nil {
+		return nil, fmt.Errorf("%w: certificate: %w", errors.ErrServerInvalidTLSConfig, err)
+	}
+	keyPEM, err := decodePEM(https.KeyPEM, "PRIVATE KEY")
+	if err != nil {
+		return nil, fmt.Errorf("%w: key: %w", errors.ErrServerInvalidTLSConfig, err)
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrServerInvalidTLSConfig, err)
+	}
+	return &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}, nil
+}
+
+// decodePEM converts value of environment variable to PEM.
+// The value may be raw PEM, base64-encoded PEM or base64-encoded DER.
+// Parameters:
+//   - value: Value of environment variable
+//   - blockType: PEM block type used to wrap DER
+//
+// Returns:
+//   - []byte: PEM data
+//   - error: If value is neither PEM nor valid base64
+func decodePEM(value, blockType string) ([]byte, error) {
+	pemPrefix := []byte("-----BEGIN")
+	data := []byte(value)
+	if bytes.Contains(data, pemPrefix) {
+		return data, nil
+	}
+
+	der, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Contains(der, pemPrefix) {
+		return der, nil
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), nil
+}
+
 // waitForShutdown listens for server errors or termination signals.
 // Parameters:
 //   - serverErr: Channel receiving server startup/run errors
@@ -155,21 +287,28 @@ func (s *Server) waitForShutdown(serverErr <-chan error) {
 }
 
 // handleGracefulShutdown performs graceful server shutdown.
+// New connections are refused at once, in-flight requests are given MaxDrainDuration to complete,
+// then remaining connections are closed and the database is shut down.
 // Parameters:
 //   - sig: Received termination signal
 func (s *Server) handleGracefulShutdown(sig os.Signal) {
 	logger.Log.Info("Initiating graceful shutdown",
 		zap.String("signal", sig.String()),
+		zap.Duration("drain", s.config.App.MaxDrainDuration),
 		zap.Duration("timeout", s.config.App.ShutdownTimeout),
 	)
 
+	s.drain()
+
 	ctx, cancel := context.WithTimeout(context.Background(), s.config.App.ShutdownTimeout)
 	defer cancel()
 
-	// Shutdown HTTP server
-	if err := s.backend.Shutdown(ctx); err != nil {
-		logger.Log.Error("Graceful shutdown failed, forcing exit", zap.Error(err))
-		s.forceShutdown()
+	// Handlers of forcibly closed connections may still be running and using database
+	if err := s.waitRequests(ctx); err != nil {
+		logger.Log.Error("In-flight requests did not complete",
+			zap.Int("active", s.ActiveConnections()),
+			zap.Error(err),
+		)
 	}
 
 	// Shutdown database
@@ -180,9 +319,46 @@ func (s *Server) handleGracefulShutdown(sig os.Signal) {
 	logger.Log.Info("Server shutdown completed")
 }
 
-// forceShutdown immediately terminates all server connections.
+// drain stops accepting new connections and waits up to MaxDrainDuration for in-flight requests.
+// Connections still active after that are closed forcibly.
+func (s *Server) drain() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.App.MaxDrainDuration)
+	defer cancel()
+
+	if err := s.backend.Shutdown(ctx); err != nil {
+		logger.Log.Warn("Connections draining timed out, closing remaining connections",
+			zap.Int("active", s.ActiveConnections()),
+			zap.Error(err),
+		)
+		s.forceShutdown()
+	}
+}
+
+// waitRequests waits until all in-flight requests are completed.
+// Parameters:
+//   - ctx: Context for cancellation/timeouts
+//
+// Returns:
+//   - error: Context error if requests are not completed in time
+func (s *Server) waitRequests(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.requests.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// forceShutdown immediately terminates all server connections and cancels contexts of in-flight requests.
 // Used as fallback when graceful shutdown fails.
 func (s *Server) forceShutdown() {
+	s.cancelRequests()
 	if err := s.backend.Close(); err != nil {
 		logger.Log.Error("Forced shutdown error", zap.Error(err))
 	}
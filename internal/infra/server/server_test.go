@@ -0,0 +1,357 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gururuby/shortener/internal/config"
+	"github.com/gururuby/shortener/internal/infra/logger"
+	"github.com/gururuby/shortener/internal/infra/server/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testCert is a self-signed certificate for 127.0.0.1 with its key.
+type testCert struct {
+	certDER []byte
+	keyDER  []byte
+}
+
+func newTestCert(t *testing.T) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "shortener"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return &testCert{certDER: certDER, keyDER: keyDER}
+}
+
+func (c *testCert) certPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.certDER})
+}
+
+func (c *testCert) keyPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: c.keyDER})
+}
+
+func setupTestLogger(t *testing.T) {
+	origLog := logger.Log
+	logger.Log = zap.NewNop()
+	t.Cleanup(func() { logger.Log = origLog })
+}
+
+func Test_validateTLSConfig(t *testing.T) {
+	setupTestLogger(t)
+
+	tests := []struct {
+		wantErr error
+		name    string
+		https   config.HTTPS
+	}{
+		{
+			name:  "when certificate is set as files",
+			https: config.HTTPS{CertFile: "cert.pem", KeyFile: "key.pem"},
+		},
+		{
+			name:  "when certificate is set as PEM",
+			https: config.HTTPS{CertPEM: "cert", KeyPEM: "key"},
+		},
+		{
+			name:    "when certificate is set both as file and PEM",
+			https:   config.HTTPS{CertFile: "cert.pem", CertPEM: "cert", KeyPEM: "key"},
+			wantErr: errors.ErrTLSAmbiguousConfig,
+		},
+		{
+			name:    "when key is set both as file and PEM",
+			https:   config.HTTPS{CertPEM: "cert", KeyFile: "key.pem", KeyPEM: "key"},
+			wantErr: errors.ErrTLSAmbiguousConfig,
+		},
+		{
+			name:    "when key PEM is missing",
+			https:   config.HTTPS{CertPEM: "cert"},
+			wantErr: errors.ErrServerInvalidTLSConfig,
+		},
+		{
+			name:    "when key file is missing",
+			https:   config.HTTPS{CertFile: "cert.pem"},
+			wantErr: errors.ErrServerInvalidTLSConfig,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Server: config.Server{HTTPS: tt.https}}
+			assert.ErrorIs(t, validateTLSConfig(cfg), tt.wantErr)
+		})
+	}
+}
+
+func Test_createTLSCredentials(t *testing.T) {
+	cert := newTestCert(t)
+
+	tests := []struct {
+		wantErr error
+		name    string
+		https   config.HTTPS
+		wantNil bool
+	}{
+		{
+			name:  "when PEM is raw",
+			https: config.HTTPS{CertPEM: string(cert.certPEM()), KeyPEM: string(cert.keyPEM())},
+		},
+		{
+			name: "when PEM is base64-encoded",
+			https: config.HTTPS{
+				CertPEM: base64.StdEncoding.EncodeToString(cert.certPEM()),
+				KeyPEM:  base64.StdEncoding.EncodeToString(cert.keyPEM()),
+			},
+		},
+		{
+			name: "when DER is base64-encoded",
+			https: config.HTTPS{
+				CertPEM: base64.StdEncoding.EncodeToString(cert.certDER),
+				KeyPEM:  base64.StdEncoding.EncodeToString(cert.keyDER),
+			},
+		},
+		{
+			name:    "when certificate is set as files",
+			https:   config.HTTPS{CertFile: "cert.pem", KeyFile: "key.pem"},
+			wantNil: true,
+		},
+		{
+			name:    "when PEM is not base64",
+			https:   config.HTTPS{CertPEM: "not base64!", KeyPEM: string(cert.keyPEM())},
+			wantErr: errors.ErrServerInvalidTLSConfig,
+		},
+		{
+			name: "when key doesn't match certificate",
+			https: config.HTTPS{
+				CertPEM: string(cert.certPEM()),
+				KeyPEM:  string(newTestCert(t).keyPEM()),
+			},
+			wantErr: errors.ErrServerInvalidTLSConfig,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Server: config.Server{HTTPS: tt.https}}
+
+			got, err := createTLSCredentials(cfg)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantNil {
+				assert.Nil(t, got)
+				return
+			}
+			require.Len(t, got.Certificates, 1)
+			assert.Equal(t, cert.certDER, got.Certificates[0].Certificate[0])
+		})
+	}
+}
+
+func Test_Server_StartHTTPS(t *testing.T) {
+	setupTestLogger(t)
+	cert := newTestCert(t)
+
+	tests := []struct {
+		env  func(t *testing.T) map[string]string
+		name string
+	}{
+		{
+			name: "when certificate is set in environment",
+			env: func(*testing.T) map[string]string {
+				return map[string]string{
+					"HTTPS_CERT_PEM": base64.StdEncoding.EncodeToString(cert.certPEM()),
+					"HTTPS_KEY_PEM":  base64.StdEncoding.EncodeToString(cert.keyPEM()),
+				}
+			},
+		},
+		{
+			name: "when certificate is set as files",
+			env: func(t *testing.T) map[string]string {
+				dir := t.TempDir()
+				certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+				require.NoError(t, os.WriteFile(certFile, cert.certPEM(), 0o600))
+				require.NoError(t, os.WriteFile(keyFile, cert.keyPEM(), 0o600))
+				return map[string]string{"HTTPS_CERT_FILE": certFile, "HTTPS_KEY_FILE": keyFile}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			addr := l.Addr().String()
+			require.NoError(t, l.Close())
+
+			t.Setenv("SERVER_ADDRESS", addr)
+			t.Setenv("ENABLE_HTTPS", "true")
+			for key, value := range tt.env(t) {
+				t.Setenv(key, value)
+			}
+			cfg, err := config.New()
+			require.NoError(t, err)
+
+			srv := New(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}), cfg, nil)
+			serverErr := make(chan error, 1)
+			go func() { serverErr <- srv.startHTTPS() }()
+			t.Cleanup(func() {
+				require.NoError(t, srv.backend.Close())
+				assert.ErrorIs(t, <-serverErr, http.ErrServerClosed)
+			})
+
+			roots := x509.NewCertPool()
+			roots.AppendCertsFromPEM(cert.certPEM())
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}}}
+
+			require.EventuallyWithT(t, func(c *assert.CollectT) {
+				resp, err := client.Get("https://" + addr)
+				if !assert.NoError(c, err) {
+					return
+				}
+				defer func() { _ = resp.Body.Close() }()
+				assert.Equal(c, http.StatusNoContent, resp.StatusCode)
+			}, 2*time.Second, 10*time.Millisecond)
+		})
+	}
+}
+
+type stubDB struct {
+	shutdown atomic.Bool
+}
+
+func (db *stubDB) Shutdown(context.Context) error {
+	db.shutdown.Store(true)
+	return nil
+}
+
+func Test_Server_handleGracefulShutdown(t *testing.T) {
+	setupTestLogger(t)
+
+	tests := []struct {
+		name         string
+		handlerDelay time.Duration
+		wantStatus   int
+		wantCanceled bool
+	}{
+		{
+			name:         "when in-flight request completes during draining",
+			handlerDelay: 200 * time.Millisecond,
+			wantStatus:   http.StatusNoContent,
+		},
+		{
+			name:         "when in-flight request outlasts draining",
+			handlerDelay: 20 * time.Second,
+			wantCanceled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+
+			cfg, err := config.New()
+			require.NoError(t, err)
+			cfg.App.MaxDrainDuration = time.Second
+			cfg.App.ShutdownTimeout = 2 * time.Second
+
+			canceled := make(chan time.Time, 1)
+			db := &stubDB{}
+			srv := New(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(tt.handlerDelay):
+					w.WriteHeader(http.StatusNoContent)
+				case <-r.Context().Done():
+					canceled <- time.Now()
+				}
+			}), cfg, db)
+			serverErr := make(chan error, 1)
+			go func() { serverErr <- srv.backend.Serve(l) }()
+
+			url := "http://" + l.Addr().String()
+			respStatus := make(chan int, 1)
+			go func() {
+				resp, err := http.Get(url)
+				if err != nil {
+					respStatus <- 0
+					return
+				}
+				_ = resp.Body.Close()
+				respStatus <- resp.StatusCode
+			}()
+			require.Eventually(t, func() bool { return srv.ActiveConnections() == 1 }, 2*time.Second, 10*time.Millisecond)
+
+			start := time.Now()
+			shutdownDone := make(chan struct{})
+			go func() {
+				srv.handleGracefulShutdown(syscall.SIGTERM)
+				close(shutdownDone)
+			}()
+
+			require.Eventually(t, func() bool {
+				resp, err := http.Get(url)
+				if err == nil {
+					_ = resp.Body.Close()
+				}
+				return err != nil
+			}, time.Second, 10*time.Millisecond, "new connections must be refused")
+
+			select {
+			case <-shutdownDone:
+			case <-time.After(5 * time.Second):
+				t.Fatal("shutdown did not complete")
+			}
+			assert.ErrorIs(t, <-serverErr, http.ErrServerClosed)
+			assert.Equal(t, tt.wantStatus, <-respStatus)
+			assert.Zero(t, srv.ActiveConnections())
+			assert.True(t, db.shutdown.Load())
+
+			if tt.wantCanceled {
+				select {
+				case at := <-canceled:
+					assert.Less(t, at.Sub(start), cfg.App.MaxDrainDuration+500*time.Millisecond)
+				default:
+					t.Fatal("handler context was not canceled")
+				}
+			} else {
+				assert.Empty(t, canceled)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Package telemetry provides distributed tracing setup based on OpenTelemetry.
+
+It features:
+- OTLP over HTTP span exporter
+- Tracer provider with service name and version resource
+- W3C Trace Context propagation
+- No-op tracer provider when tracing is disabled
+*/
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gururuby/shortener/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// Provider is a tracer provider which has to be shut down to flush pending spans.
+type Provider interface {
+	trace.TracerProvider
+	// Shutdown flushes pending spans and stops the exporter
+	Shutdown(ctx context.Context) error
+}
+
+// noopProvider is a tracer provider used when tracing is disabled.
+type noopProvider struct {
+	noop.TracerProvider
+}
+
+// Shutdown does nothing, there are no spans to flush.
+func (noopProvider) Shutdown(context.Context) error {
+	return nil
+}
+
+// Setup creates tracer provider according to telemetry configuration.
+// When tracing is enabled, spans are exported in batches to OTLP collector at cfg.Telemetry.OTLPEndpoint,
+// the provider and W3C Trace Context propagator are also registered globally.
+// When tracing is disabled, a no-op provider is returned.
+// Parameters:
+// - ctx: Context for exporter creation
+// - cfg: Application configuration
+// Returns:
+// - Provider: Tracer provider to be shut down on application exit
+// - error: If exporter or resource cannot be created
+func Setup(ctx context.Context, cfg *config.Config) (Provider, error) {
+	if !cfg.Telemetry.Enabled {
+		return noopProvider{}, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.Telemetry.OTLPEndpoint))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create OTLP exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.App.Name),
+		semconv.ServiceVersion(cfg.App.Version),
+		semconv.DeploymentEnvironment(cfg.App.Env),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create telemetry resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return tp, nil
+}
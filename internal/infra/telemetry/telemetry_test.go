@@ -0,0 +1,45 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gururuby/shortener/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestSetup(t *testing.T) {
+	t.Run("when telemetry is disabled", func(t *testing.T) {
+		cfg := &config.Config{}
+
+		tp, err := Setup(context.Background(), cfg)
+		require.NoError(t, err)
+
+		_, span := tp.Tracer("test").Start(context.Background(), "span")
+		assert.False(t, span.SpanContext().IsValid(), "disabled telemetry must not record spans")
+		span.End()
+
+		assert.NoError(t, tp.Shutdown(context.Background()))
+	})
+
+	t.Run("when telemetry is enabled", func(t *testing.T) {
+		cfg := &config.Config{Telemetry: config.Telemetry{Enabled: true, OTLPEndpoint: "http://127.0.0.1:1"}}
+		cfg.App.Name = "shortener"
+
+		tp, err := Setup(context.Background(), cfg)
+		require.NoError(t, err)
+		assert.IsType(t, &sdktrace.TracerProvider{}, tp)
+
+		_, span := tp.Tracer("test").Start(context.Background(), "span")
+		assert.True(t, span.SpanContext().IsValid())
+		span.End()
+
+		// Collector is not running, shutdown must still return within the timeout
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = tp.Shutdown(ctx)
+	})
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gururuby/shortener/internal/infra/urlvalidator (interfaces: Storage)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/mock.go -package=mocks . Storage
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStorage is a mock of Storage interface.
+type MockStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockStorageMockRecorder is the mock recorder for MockStorage.
+type MockStorageMockRecorder struct {
+	mock *MockStorage
+}
+
+// NewMockStorage creates a new mock instance.
+func NewMockStorage(ctrl *gomock.Controller) *MockStorage {
+	mock := &MockStorage{ctrl: ctrl}
+	mock.recorder = &MockStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
+	return m.recorder
+}
+
+// FindURLsToCheck mocks base method.
+func (m *MockStorage) FindURLsToCheck(ctx context.Context, checkedBefore time.Time, afterID, limit int) ([]*entity.ShortURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindURLsToCheck", ctx, checkedBefore, afterID, limit)
+	ret0, _ := ret[0].([]*entity.ShortURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindURLsToCheck indicates an expected call of FindURLsToCheck.
+func (mr *MockStorageMockRecorder) FindURLsToCheck(ctx, checkedBefore, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindURLsToCheck", reflect.TypeOf((*MockStorage)(nil).FindURLsToCheck), ctx, checkedBefore, afterID, limit)
+}
+
+// SaveURLHealth mocks base method.
+func (m *MockStorage) SaveURLHealth(ctx context.Context, namespace, alias string, healthy bool, checkedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveURLHealth", ctx, namespace, alias, healthy, checkedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveURLHealth indicates an expected call of SaveURLHealth.
+func (mr *MockStorageMockRecorder) SaveURLHealth(ctx, namespace, alias, healthy, checkedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveURLHealth", reflect.TypeOf((*MockStorage)(nil).SaveURLHealth), ctx, namespace, alias, healthy, checkedAt)
+}
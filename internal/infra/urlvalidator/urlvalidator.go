@@ -0,0 +1,208 @@
+//go:generate mockgen -destination=./mocks/mock.go -package=mocks . Storage
+
+/*
+Package urlvalidator provides background health checks of source URLs of short URLs.
+
+It features:
+- Periodic HEAD requests to source URLs of not deleted and not expired short URLs
+- Marking of source URLs responding 404, 410 or not responding at all as unhealthy
+- Skipping of source URLs checked during the last 24 hours
+- Limits of concurrent and per second requests to avoid overwhelming target servers
+- Clean stop on context cancellation
+*/
+package urlvalidator
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	"github.com/gururuby/shortener/internal/infra/logger"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// Available constants
+const (
+	checkTimeout      = 5 * time.Second // Timeout of a single HEAD request
+	recheckAfter      = 24 * time.Hour  // Source URLs checked more recently are skipped
+	batchSize         = 100             // Number of short URLs loaded from storage at once
+	maxConcurrency    = 10              // Limit of simultaneous HEAD requests
+	requestsPerSecond = 5               // Limit of HEAD requests started per second
+)
+
+// Storage defines the interface for lookup of short URLs to check and saving of check results.
+type Storage interface {
+	// FindURLsToCheck retrieves not deleted and not expired short URLs not checked since checkedBefore.
+	// Returns:
+	// - []*shortURLEntity.ShortURL: Up to limit URLs with ID greater than afterID, ordered by ID
+	// - error: Any error that occurred during lookup
+	FindURLsToCheck(ctx context.Context, checkedBefore time.Time, afterID, limit int) ([]*shortURLEntity.ShortURL, error)
+
+	// SaveURLHealth stores result of health check of a short URL's source URL.
+	// Returns:
+	// - error: Any error that occurred during save
+	SaveURLHealth(ctx context.Context, namespace, alias string, healthy bool, checkedAt time.Time) error
+}
+
+// URLValidator periodically checks whether source URLs of short URLs are reachable.
+type URLValidator struct {
+	storage  Storage       // Storage of short URLs
+	client   *http.Client  // Client sending HEAD requests
+	limiter  *rate.Limiter // Limits rate of HEAD requests
+	interval time.Duration // Interval between checks
+}
+
+// New creates validator of source URLs.
+// Parameters:
+// - storage: Storage of short URLs
+// - interval: Interval between checks
+// Returns:
+// - *URLValidator: Initialized validator, Run must be called to start checks
+func New(storage Storage, interval time.Duration) *URLValidator {
+	return &URLValidator{
+		storage:  storage,
+		client:   &http.Client{Timeout: checkTimeout},
+		limiter:  rate.NewLimiter(requestsPerSecond, 1),
+		interval: interval,
+	}
+}
+
+// Run checks source URLs every interval until ctx is cancelled.
+// Errors are logged and do not stop the validator.
+// Parameters:
+// - ctx: Context whose cancellation stops the validator
+func (v *URLValidator) Run(ctx context.Context) {
+	ticker := time.NewTicker(v.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := v.RunOnce(ctx); err != nil && ctx.Err() == nil {
+				logger.Log.Error("cannot check source URLs", zap.Error(err))
+			}
+		}
+	}
+}
+
+// RunOnce checks source URLs not checked during the last 24 hours and logs the number of unhealthy ones.
+// At most 10 requests are sent simultaneously. All started requests are finished before return.
+// Parameters:
+// - ctx: Context for cancellation, stops the check of the remaining URLs
+// Returns:
+// - int64: Number of source URLs found unhealthy
+// - error: Error of lookup of short URLs to check, or ctx error if the check was cancelled
+func (v *URLValidator) RunOnce(ctx context.Context) (int64, error) {
+	var (
+		wg        sync.WaitGroup
+		checked   atomic.Int64
+		unhealthy atomic.Int64
+		afterID   int
+	)
+
+	err := func() error {
+		sem := make(chan struct{}, maxConcurrency)
+		checkedBefore := time.Now().Add(-recheckAfter)
+
+		for {
+			urls, err := v.storage.FindURLsToCheck(ctx, checkedBefore, afterID, batchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, url := range urls {
+				if err = v.limiter.Wait(ctx); err != nil {
+					return ctx.Err()
+				}
+
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case sem <- struct{}{}:
+				}
+
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer func() { <-sem }()
+
+					healthy, saved := v.check(ctx, url)
+					if !saved {
+						return
+					}
+					checked.Add(1)
+					if !healthy {
+						unhealthy.Add(1)
+					}
+				}()
+			}
+
+			if len(urls) < batchSize {
+				return nil
+			}
+			afterID = urls[len(urls)-1].ID
+		}
+	}()
+	wg.Wait()
+	if err == nil {
+		// Requests in flight are abandoned on cancellation, their results are not saved
+		err = ctx.Err()
+	}
+
+	if checked.Load() > 0 {
+		logger.Log.Info("source URLs checked", zap.Int64("count", checked.Load()), zap.Int64("unhealthy", unhealthy.Load()))
+	}
+	return unhealthy.Load(), err
+}
+
+// check sends HEAD request to the source URL of a short URL and saves the result.
+// Parameters:
+// - ctx: Context for cancellation
+// - url: Short URL whose source URL is checked
+// Returns:
+// - bool: Whether the source URL is healthy
+// - bool: Whether the result was saved, false if the check was cancelled or saving failed
+func (v *URLValidator) check(ctx context.Context, url *shortURLEntity.ShortURL) (bool, bool) {
+	healthy := v.isReachable(ctx, url.SourceURL)
+	if ctx.Err() != nil {
+		return false, false
+	}
+
+	if err := v.storage.SaveURLHealth(ctx, url.Namespace, url.Alias, healthy, time.Now()); err != nil {
+		if ctx.Err() == nil {
+			logger.Log.Error("cannot save health of source URL", zap.String("alias", url.Alias), zap.Error(err))
+		}
+		return false, false
+	}
+	return healthy, true
+}
+
+// isReachable sends HEAD request to a URL.
+// Parameters:
+// - ctx: Context for cancellation
+// - url: URL to request
+// Returns:
+// - bool: false if the request fails or the response is 404 Not Found or 410 Gone
+func (v *URLValidator) isReachable(ctx context.Context, url string) bool {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+
+	return resp.StatusCode != http.StatusNotFound && resp.StatusCode != http.StatusGone
+}
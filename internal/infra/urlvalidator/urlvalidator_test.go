@@ -0,0 +1,192 @@
+package urlvalidator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	shortURLEntity "github.com/gururuby/shortener/internal/domain/entity/shorturl"
+	"github.com/gururuby/shortener/internal/infra/logger"
+	"github.com/gururuby/shortener/internal/infra/urlvalidator/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/time/rate"
+)
+
+// newTestValidator returns validator without rate limit.
+func newTestValidator(storage Storage) *URLValidator {
+	v := New(storage, time.Hour)
+	v.limiter = rate.NewLimiter(rate.Inf, 1)
+	return v
+}
+
+func Test_URLValidator_RunOnce(t *testing.T) {
+	logger.Setup("test", "error")
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/gone":
+			w.WriteHeader(http.StatusGone)
+		case "/error":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer ts.Close()
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name      string
+		sourceURL string
+		healthy   bool
+	}{
+		{name: "when source URL is available", sourceURL: ts.URL + "/ok", healthy: true},
+		{name: "when source URL is not found", sourceURL: ts.URL + "/missing"},
+		{name: "when source URL is gone", sourceURL: ts.URL + "/gone"},
+		{name: "when source URL fails with server error", sourceURL: ts.URL + "/error", healthy: true},
+		{name: "when connection is refused", sourceURL: closed.URL},
+		{name: "when source URL is invalid", sourceURL: "://invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := mocks.NewMockStorage(ctrl)
+			storage.EXPECT().FindURLsToCheck(ctx, gomock.Any(), 0, batchSize).
+				Return([]*shortURLEntity.ShortURL{{ID: 1, Alias: "abc", Namespace: "team", SourceURL: tt.sourceURL}}, nil)
+			storage.EXPECT().SaveURLHealth(ctx, "team", "abc", tt.healthy, gomock.Any()).Return(nil)
+
+			n, err := newTestValidator(storage).RunOnce(ctx)
+			require.NoError(t, err)
+			if tt.healthy {
+				assert.Zero(t, n)
+			} else {
+				assert.Equal(t, int64(1), n)
+			}
+		})
+	}
+}
+
+func Test_URLValidator_RunOnce_Batches(t *testing.T) {
+	logger.Setup("test", "error")
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	var inFlight, maxInFlight atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			current := maxInFlight.Load()
+			if n <= current || maxInFlight.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		if r.URL.Query().Has("dead") {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	first := make([]*shortURLEntity.ShortURL, batchSize)
+	for i := range first {
+		first[i] = &shortURLEntity.ShortURL{ID: i + 1, Alias: "ok", SourceURL: ts.URL}
+	}
+	second := []*shortURLEntity.ShortURL{{ID: batchSize + 1, Alias: "dead", SourceURL: ts.URL + "?dead"}}
+
+	storage := mocks.NewMockStorage(ctrl)
+	gomock.InOrder(
+		storage.EXPECT().FindURLsToCheck(ctx, gomock.Any(), 0, batchSize).DoAndReturn(
+			func(_ context.Context, checkedBefore time.Time, _, _ int) ([]*shortURLEntity.ShortURL, error) {
+				assert.WithinDuration(t, time.Now().Add(-recheckAfter), checkedBefore, time.Minute)
+				return first, nil
+			}),
+		storage.EXPECT().FindURLsToCheck(ctx, gomock.Any(), batchSize, batchSize).Return(second, nil),
+	)
+	storage.EXPECT().SaveURLHealth(ctx, "", "ok", true, gomock.Any()).Return(nil).Times(batchSize)
+	storage.EXPECT().SaveURLHealth(ctx, "", "dead", false, gomock.Any()).Return(nil)
+
+	n, err := newTestValidator(storage).RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(maxConcurrency))
+}
+
+func Test_URLValidator_RunOnce_Errors(t *testing.T) {
+	logger.Setup("test", "error")
+	ctrl := gomock.NewController(t)
+	errStorage := errors.New("storage error")
+
+	t.Run("when lookup fails", func(t *testing.T) {
+		storage := mocks.NewMockStorage(ctrl)
+		storage.EXPECT().FindURLsToCheck(gomock.Any(), gomock.Any(), 0, batchSize).Return(nil, errStorage)
+
+		_, err := newTestValidator(storage).RunOnce(context.Background())
+		require.ErrorIs(t, err, errStorage)
+	})
+
+	t.Run("when save fails", func(t *testing.T) {
+		storage := mocks.NewMockStorage(ctrl)
+		storage.EXPECT().FindURLsToCheck(gomock.Any(), gomock.Any(), 0, batchSize).
+			Return([]*shortURLEntity.ShortURL{{ID: 1, Alias: "abc", SourceURL: "://invalid"}}, nil)
+		storage.EXPECT().SaveURLHealth(gomock.Any(), "", "abc", false, gomock.Any()).Return(errStorage)
+
+		n, err := newTestValidator(storage).RunOnce(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, n)
+	})
+
+	t.Run("when context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ts := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			cancel()
+			<-r.Context().Done()
+		}))
+		defer ts.Close()
+
+		storage := mocks.NewMockStorage(ctrl)
+		storage.EXPECT().FindURLsToCheck(gomock.Any(), gomock.Any(), 0, batchSize).
+			Return([]*shortURLEntity.ShortURL{{ID: 1, Alias: "abc", SourceURL: ts.URL}, {ID: 2, Alias: "def", SourceURL: ts.URL}}, nil)
+
+		_, err := newTestValidator(storage).RunOnce(ctx)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func Test_URLValidator_Run(t *testing.T) {
+	logger.Setup("test", "error")
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockStorage(ctrl)
+	storage.EXPECT().FindURLsToCheck(gomock.Any(), gomock.Any(), 0, batchSize).Return(nil, nil).MinTimes(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	v := newTestValidator(storage)
+	v.interval = 10 * time.Millisecond
+
+	done := make(chan struct{})
+	go func() {
+		v.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("validator did not stop after context cancellation")
+	}
+}
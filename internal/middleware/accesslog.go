@@ -0,0 +1,158 @@
+/*
+Package middleware provides HTTP middleware components for request logging.
+
+It features:
+- Structured access log of requests with duration, response status and size
+- Log level chosen by response status class
+- Sampling of successful requests for high-traffic endpoints, e.g. redirects
+*/
+package middleware
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// accessLogMsg is the message of access log entries.
+const accessLogMsg = "access"
+
+// accessLogOptions configures AccessLog middleware.
+type accessLogOptions struct {
+	samplingRate float64        // Share of 2xx requests which are logged
+	sample       func() float64 // Source of random numbers in [0, 1)
+}
+
+// AccessLogOption configures AccessLog middleware.
+type AccessLogOption func(*accessLogOptions)
+
+// WithSamplingRate sets the share of successful (2xx) requests which are logged,
+// the rest of them is skipped. Requests with other statuses are always logged.
+// Parameters:
+// - rate: Share in [0, 1], 1 logs every request, 0 skips all successful ones
+// Returns:
+// - AccessLogOption: Option for AccessLog
+func WithSamplingRate(rate float64) AccessLogOption {
+	return func(o *accessLogOptions) {
+		o.samplingRate = min(max(rate, 0), 1)
+	}
+}
+
+// AccessLog creates middleware writing a structured log entry for every request.
+// Entry fields are method, path, status, duration_ms, bytes, request_id, user_agent
+// and remote_ip, plus tls_client_fingerprint if TLSAudit found a client certificate. Responses with 5xx status are logged at Error level, 4xx at Warn,
+// others at Info. Request ID is taken from the context, so RequestID middleware
+// must be registered before.
+// Parameters:
+// - log: Logger to write entries to
+// - opts: Optional settings, e.g. sampling of successful requests
+// Returns:
+// - func(http.Handler) http.Handler: Middleware function
+func AccessLog(log *zap.Logger, opts ...AccessLogOption) func(http.Handler) http.Handler {
+	o := &accessLogOptions{samplingRate: 1, sample: rand.Float64}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(h http.Handler) http.Handler {
+		accessLogFn := func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			resp := &responseData{}
+			lw := loggingResponseWriter{
+				ResponseWriter: w,
+				responseData:   resp,
+			}
+
+			h.ServeHTTP(&lw, r)
+
+			status := resp.statusCode()
+			if isSuccessful(status) && o.sample() >= o.samplingRate {
+				return
+			}
+
+			fields := []zap.Field{
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+				zap.Int("status", status),
+				zap.Float64("duration_ms", float64(time.Since(start))/float64(time.Millisecond)),
+				zap.Int("bytes", resp.size),
+				zap.String("request_id", GetRequestID(r.Context())),
+				zap.String("user_agent", r.UserAgent()),
+				zap.String("remote_ip", RemoteIP(r)),
+			}
+			if fingerprint := GetClientCertFingerprint(r.Context()); fingerprint != "" {
+				fields = append(fields, zap.String(clientCertFingerprintField, fingerprint))
+			}
+
+			switch {
+			case status >= http.StatusInternalServerError:
+				log.Error(accessLogMsg, fields...)
+			case status >= http.StatusBadRequest:
+				log.Warn(accessLogMsg, fields...)
+			default:
+				log.Info(accessLogMsg, fields...)
+			}
+		}
+		return http.HandlerFunc(accessLogFn)
+	}
+}
+
+// isSuccessful reports whether the status is 2xx.
+func isSuccessful(status int) bool {
+	return status >= http.StatusOK && status < http.StatusMultipleChoices
+}
+
+// responseData holds captured response metrics for logging.
+type responseData struct {
+	status int // HTTP status code, 0 until the header is written
+	size   int // Response body size in bytes
+}
+
+// statusCode returns the status of the response, 200 if the handler never wrote the header.
+func (d *responseData) statusCode() int {
+	if d.status == 0 {
+		return http.StatusOK
+	}
+	return d.status
+}
+
+// loggingResponseWriter wraps http.ResponseWriter to capture response data.
+type loggingResponseWriter struct {
+	http.ResponseWriter               // Embedded original ResponseWriter
+	responseData        *responseData // Pointer to shared response metrics
+}
+
+// Write captures the response size while writing to the underlying ResponseWriter.
+// Implements the io.Writer interface.
+func (r *loggingResponseWriter) Write(b []byte) (int, error) {
+	if r.responseData.status == 0 {
+		r.responseData.status = http.StatusOK
+	}
+	size, err := r.ResponseWriter.Write(b)
+	r.responseData.size += size
+	return size, err
+}
+
+// WriteHeader captures the status code while writing headers, informational ones are skipped.
+// Overrides the http.ResponseWriter interface method.
+func (r *loggingResponseWriter) WriteHeader(statusCode int) {
+	r.ResponseWriter.WriteHeader(statusCode)
+	if r.responseData.status == 0 && statusCode >= http.StatusOK {
+		r.responseData.status = statusCode
+	}
+}
+
+// Flush sends buffered data to the client if the original ResponseWriter supports it.
+func (r *loggingResponseWriter) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the original ResponseWriter, so http.ResponseController reaches the connection.
+func (r *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestAccessLog(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		level  zapcore.Level
+	}{
+		{
+			name:   "when response is successful",
+			status: http.StatusOK,
+			body:   "ok",
+			level:  zapcore.InfoLevel,
+		},
+		{
+			name:   "when resource is not found",
+			status: http.StatusNotFound,
+			body:   "not found",
+			level:  zapcore.WarnLevel,
+		},
+		{
+			name:   "when server fails",
+			status: http.StatusInternalServerError,
+			body:   "internal error",
+			level:  zapcore.ErrorLevel,
+		},
+		{
+			name:   "when response is redirect",
+			status: http.StatusTemporaryRedirect,
+			level:  zapcore.InfoLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+
+			h := RequestID(AccessLog(zap.New(core))(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})))
+
+			req := httptest.NewRequest(http.MethodGet, "/path?q=1", nil)
+			req.Header.Set("User-Agent", "test-agent")
+			req.Header.Set(requestIDHeader, "req-1")
+			req.RemoteAddr = "192.0.2.1:1234"
+			h.ServeHTTP(httptest.NewRecorder(), req)
+
+			entries := logs.All()
+			require.Len(t, entries, 1)
+			assert.Equal(t, tt.level, entries[0].Level)
+			assert.Equal(t, accessLogMsg, entries[0].Message)
+
+			fields := entries[0].ContextMap()
+			assert.Equal(t, http.MethodGet, fields["method"])
+			assert.Equal(t, "/path", fields["path"])
+			assert.Equal(t, int64(tt.status), fields["status"])
+			assert.Equal(t, int64(len(tt.body)), fields["bytes"])
+			assert.Equal(t, "req-1", fields["request_id"])
+			assert.Equal(t, "test-agent", fields["user_agent"])
+			assert.Equal(t, "192.0.2.1", fields["remote_ip"])
+			assert.Contains(t, fields, "duration_ms")
+		})
+	}
+}
+
+func TestAccessLog_ImplicitStatus(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	h := AccessLog(zap.New(core))(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, int64(http.StatusOK), entries[0].ContextMap()["status"])
+}
+
+func TestAccessLog_SamplingRate(t *testing.T) {
+	tests := []struct {
+		name   string
+		rate   float64
+		status int
+		logged int
+	}{
+		{
+			name:   "when all successful requests are logged",
+			rate:   1,
+			status: http.StatusOK,
+			logged: 10,
+		},
+		{
+			name:   "when successful requests are skipped",
+			rate:   0,
+			status: http.StatusOK,
+			logged: 0,
+		},
+		{
+			name:   "when client errors are not sampled",
+			rate:   0,
+			status: http.StatusNotFound,
+			logged: 10,
+		},
+		{
+			name:   "when server errors are not sampled",
+			rate:   0,
+			status: http.StatusInternalServerError,
+			logged: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+
+			h := AccessLog(zap.New(core), WithSamplingRate(tt.rate))(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			for range 10 {
+				h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+			}
+
+			assert.Equal(t, tt.logged, logs.Len())
+		})
+	}
+}
+
+func TestAccessLog_PartialSampling(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	samples := []float64{0.1, 0.5, 0.9, 0.2}
+	h := AccessLog(zap.New(core), WithSamplingRate(0.3), func(o *accessLogOptions) {
+		o.sample = func() float64 {
+			s := samples[0]
+			samples = samples[1:]
+			return s
+		}
+	})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	for range 4 {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	assert.Equal(t, 2, logs.Len())
+}
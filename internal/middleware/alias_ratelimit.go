@@ -0,0 +1,190 @@
+/*
+Package middleware provides HTTP middleware components for per-alias click rate limiting.
+
+It features:
+- Token bucket per short URL alias, refilled every second
+- JSON response with retry delay and Retry-After header for throttled clients
+- Eviction of limiters of aliases which are no longer clicked
+- Limit changes at runtime, e.g. on configuration reload
+*/
+package middleware
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gururuby/shortener/internal/config"
+	"golang.org/x/time/rate"
+)
+
+// Available constants
+const (
+	aliasLimiterTTL       = time.Minute           // Limiters of aliases not clicked for this period are evicted
+	aliasRateLimitedError = "rate limit exceeded" // Error message of throttled clicks
+	aliasParam            = "alias"               // Name of the route parameter with alias
+	namespaceParam        = "namespace"           // Name of the route parameter with namespace of alias
+	aliasParamSeparator   = "/"                   // Separator of namespace and alias in limiting key
+	minAliasRetryAfter    = time.Millisecond      // Minimal retry delay reported to throttled clients
+)
+
+// aliasRateLimitResponse represents the response for throttled clicks.
+type aliasRateLimitResponse struct {
+	Error        string `json:"error"`
+	RetryAfterMS int64  `json:"retry_after_ms"`
+}
+
+// AliasRateLimiter limits clicks of every short URL alias.
+type AliasRateLimiter struct {
+	limiters  sync.Map         // Limiters by alias, map of alias to *limiterEntry
+	now       func() time.Time // Clock of the limiters
+	maxClicks atomic.Int64     // Clicks of a single alias allowed per second, no limit if not positive
+}
+
+// NewAliasRateLimiter creates a limiter of clicks and starts eviction of limiters
+// of aliases not clicked for a minute.
+// Parameters:
+// - maxClicksPerSecond: Clicks of a single alias allowed per second, limiting is disabled if not positive
+//
+// Returns:
+// - *AliasRateLimiter: Limiter ready to wrap redirect routes
+func NewAliasRateLimiter(maxClicksPerSecond int) *AliasRateLimiter {
+	l := newAliasRateLimiter(maxClicksPerSecond, time.Now)
+	go cleanupLimiters(&l.limiters, limiterCleanupInterval, aliasLimiterTTL)
+	return l
+}
+
+// newAliasRateLimiter creates a limiter of clicks with the clock.
+// Parameters:
+// - maxClicksPerSecond: Clicks of a single alias allowed per second
+// - now: Clock of the limiters
+//
+// Returns:
+// - *AliasRateLimiter: Limiter without eviction of stale limiters
+func newAliasRateLimiter(maxClicksPerSecond int, now func() time.Time) *AliasRateLimiter {
+	l := &AliasRateLimiter{now: now}
+	l.maxClicks.Store(int64(maxClicksPerSecond))
+	return l
+}
+
+// AliasRateLimit returns middleware that limits clicks of every short URL alias.
+// It is a shortcut for NewAliasRateLimiter(maxClicksPerSecond).Middleware.
+// Parameters:
+// - maxClicksPerSecond: Clicks of a single alias allowed per second, limiting is disabled if not positive
+//
+// Returns:
+// - func(http.Handler) http.Handler: Middleware for route registration
+func AliasRateLimit(maxClicksPerSecond int) func(http.Handler) http.Handler {
+	return NewAliasRateLimiter(maxClicksPerSecond).Middleware
+}
+
+// Reload applies the click rate limit of the reloaded configuration.
+// Parameters:
+// - cfg: Reloaded configuration
+// Returns:
+// - error: Always nil
+func (l *AliasRateLimiter) Reload(cfg *config.Config) error {
+	l.SetLimit(cfg.Analytics.MaxClicksPerSecond)
+	return nil
+}
+
+// SetLimit changes the number of clicks allowed per second, also for aliases clicked before.
+// Parameters:
+// - maxClicksPerSecond: Clicks of a single alias allowed per second, limiting is disabled if not positive
+func (l *AliasRateLimiter) SetLimit(maxClicksPerSecond int) {
+	l.maxClicks.Store(int64(maxClicksPerSecond))
+	if maxClicksPerSecond <= 0 {
+		return
+	}
+
+	now := l.now()
+	l.limiters.Range(func(_, value any) bool {
+		limiter := value.(*limiterEntry).limiter
+		limiter.SetLimitAt(now, rate.Limit(maxClicksPerSecond))
+		limiter.SetBurstAt(now, maxClicksPerSecond)
+		return true
+	})
+}
+
+// Middleware wraps the redirect handler.
+// Requests above the limit within a second are rejected with 429 Too Many Requests
+// before they reach the redirect handler, so their clicks are not recorded.
+// It must wrap redirect routes only, the alias is taken from their {alias} parameter
+// or from the request path if the route has no parameters.
+//
+// Parameters:
+// - h: Redirect handler
+//
+// Returns:
+// - http.Handler: Handler limiting clicks
+func (l *AliasRateLimiter) Middleware(h http.Handler) http.Handler {
+	limitFn := func(w http.ResponseWriter, r *http.Request) {
+		maxClicks := int(l.maxClicks.Load())
+		if maxClicks <= 0 {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		t := l.now()
+
+		entry, _ := l.limiters.LoadOrStore(aliasKey(r), &limiterEntry{
+			limiter: rate.NewLimiter(rate.Limit(maxClicks), maxClicks),
+		})
+		le := entry.(*limiterEntry)
+		le.lastSeen.Store(t.UnixNano())
+
+		if !le.limiter.AllowN(t, 1) {
+			reservation := le.limiter.ReserveN(t, 1)
+			delay := reservation.DelayFrom(t)
+			reservation.CancelAt(t)
+
+			returnAliasRateLimited(w, delay)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	}
+
+	return http.HandlerFunc(limitFn)
+}
+
+// aliasKey returns the limiting key of the clicked alias.
+// Aliases of different namespaces are limited separately.
+// Parameters:
+// - r: HTTP request of the redirect
+// Returns:
+// - string: Alias, prefixed with its namespace if the route has one
+func aliasKey(r *http.Request) string {
+	alias := chi.URLParam(r, aliasParam)
+	if alias == "" {
+		return strings.Trim(r.URL.Path, aliasParamSeparator)
+	}
+	if namespace := chi.URLParam(r, namespaceParam); namespace != "" {
+		return namespace + aliasParamSeparator + alias
+	}
+	return alias
+}
+
+// returnAliasRateLimited writes JSON response for the throttled click.
+// Parameters:
+// - w: HTTP response writer
+// - delay: Time until the next click of the alias is allowed
+func returnAliasRateLimited(w http.ResponseWriter, delay time.Duration) {
+	if delay < minAliasRetryAfter || delay == rate.InfDuration {
+		delay = minAliasRetryAfter
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(delay)))
+	w.WriteHeader(http.StatusTooManyRequests)
+	_ = json.NewEncoder(w).Encode(aliasRateLimitResponse{
+		Error:        aliasRateLimitedError,
+		RetryAfterMS: int64(math.Ceil(float64(delay) / float64(time.Millisecond))),
+	})
+}
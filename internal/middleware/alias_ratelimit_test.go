@@ -0,0 +1,204 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gururuby/shortener/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAliasRateLimit(t *testing.T) {
+	const maxClicks = 1000
+
+	setup := func() (http.Handler, *atomic.Int32, *time.Time) {
+		var served atomic.Int32
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		r := chi.NewRouter()
+		r.With(newAliasRateLimiter(maxClicks, func() time.Time { return now }).Middleware).Get("/{alias}", func(w http.ResponseWriter, _ *http.Request) {
+			served.Add(1)
+			w.WriteHeader(http.StatusTemporaryRedirect)
+		})
+		return r, &served, &now
+	}
+
+	click := func(h http.Handler, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	clickConcurrently := func(h http.Handler, path string, clicks int) (ok, limited int32) {
+		var (
+			wg              sync.WaitGroup
+			okCnt, limitCnt atomic.Int32
+		)
+		for range clicks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				switch click(h, path).Code {
+				case http.StatusTemporaryRedirect:
+					okCnt.Add(1)
+				case http.StatusTooManyRequests:
+					limitCnt.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		return okCnt.Load(), limitCnt.Load()
+	}
+
+	t.Run("when clicks exceed the limit", func(t *testing.T) {
+		h, served, _ := setup()
+
+		assert.Equal(t, http.StatusTemporaryRedirect, click(h, "/abc").Code)
+		ok, limited := clickConcurrently(h, "/abc", maxClicks-1)
+		assert.Equal(t, int32(maxClicks-1), ok)
+		assert.Zero(t, limited)
+
+		w := click(h, "/abc")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.Equal(t, "1", w.Header().Get("Retry-After"))
+		assert.JSONEq(t, `{"error":"rate limit exceeded","retry_after_ms":1}`, w.Body.String())
+		assert.Equal(t, int32(maxClicks), served.Load())
+	})
+
+	t.Run("when concurrent clicks exceed the limit", func(t *testing.T) {
+		h, served, _ := setup()
+
+		ok, limited := clickConcurrently(h, "/abc", maxClicks+1)
+
+		assert.Equal(t, int32(maxClicks), ok)
+		assert.Equal(t, int32(1), limited)
+		assert.Equal(t, int32(maxClicks), served.Load())
+	})
+
+	t.Run("when the window passes", func(t *testing.T) {
+		h, _, now := setup()
+
+		clickConcurrently(h, "/abc", maxClicks+1)
+		*now = now.Add(time.Second)
+
+		ok, limited := clickConcurrently(h, "/abc", maxClicks+1)
+		assert.Equal(t, int32(maxClicks), ok)
+		assert.Equal(t, int32(1), limited)
+	})
+
+	t.Run("when other alias is clicked", func(t *testing.T) {
+		h, _, _ := setup()
+
+		clickConcurrently(h, "/abc", maxClicks+1)
+
+		assert.Equal(t, http.StatusTemporaryRedirect, click(h, "/def").Code)
+	})
+}
+
+func TestAliasRateLimiter_SetLimit(t *testing.T) {
+	now := time.Now()
+	l := newAliasRateLimiter(2, func() time.Time { return now })
+	h := l.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTemporaryRedirect)
+	}))
+	click := func() int {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/abc", nil))
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusTemporaryRedirect, click())
+	assert.Equal(t, http.StatusTemporaryRedirect, click())
+	assert.Equal(t, http.StatusTooManyRequests, click())
+
+	l.SetLimit(0)
+	assert.Equal(t, http.StatusTemporaryRedirect, click())
+
+	l.SetLimit(10)
+	now = now.Add(time.Second)
+	for range 10 {
+		require.Equal(t, http.StatusTemporaryRedirect, click())
+	}
+	assert.Equal(t, http.StatusTooManyRequests, click())
+
+	require.NoError(t, l.Reload(&config.Config{Analytics: config.Analytics{MaxClicksPerSecond: 0}}))
+	assert.Equal(t, http.StatusTemporaryRedirect, click())
+}
+
+func TestAliasRateLimit_Disabled(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTemporaryRedirect)
+	})
+	h := AliasRateLimit(0)(next)
+
+	for range 10 {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/abc", nil))
+		require.Equal(t, http.StatusTemporaryRedirect, w.Code)
+	}
+}
+
+func Test_aliasKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		path    string
+		want    string
+	}{
+		{
+			name:    "when route has alias",
+			pattern: "/{alias}",
+			path:    "/abc",
+			want:    "abc",
+		},
+		{
+			name:    "when route has namespace",
+			pattern: "/{namespace}/{alias}",
+			path:    "/team-a/abc",
+			want:    "team-a/abc",
+		},
+		{
+			name:    "when route has no parameters",
+			pattern: "/abc",
+			path:    "/abc",
+			want:    "abc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			r := chi.NewRouter()
+			r.Get(tt.pattern, func(_ http.ResponseWriter, req *http.Request) {
+				got = aliasKey(req)
+			})
+
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_evictStaleAliasLimiters(t *testing.T) {
+	now := time.Now()
+	l := newAliasRateLimiter(1, func() time.Time { return now })
+	h := l.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/abc", nil))
+	now = now.Add(aliasLimiterTTL / 2)
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/def", nil))
+
+	evictStaleLimiters(&l.limiters, now.Add(aliasLimiterTTL/2+time.Second), aliasLimiterTTL)
+
+	_, abcFound := l.limiters.Load("abc")
+	_, defFound := l.limiters.Load("def")
+	assert.False(t, abcFound)
+	assert.True(t, defFound)
+}
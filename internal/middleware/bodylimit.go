@@ -0,0 +1,137 @@
+/*
+Package middleware provides HTTP middleware components for request body limiting.
+
+It features:
+- Rejection of request bodies exceeding configured size
+- Detection of oversized streaming (chunked) bodies
+- Bounded memory usage regardless of declared Content-Length
+- Limit changes at runtime, e.g. on configuration reload
+*/
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gururuby/shortener/internal/config"
+	httpErrors "github.com/gururuby/shortener/internal/handler/http/errors"
+)
+
+// Available constants
+const (
+	bodyTooLargeMsg  = "request body too large"
+	bodyReadErrorMsg = "cannot read request body"
+)
+
+// bodyLimitErrorResponse represents response for requests with rejected body.
+type bodyLimitErrorResponse struct {
+	StatusCode int
+	Code       string
+	Error      string
+}
+
+// BodyLimiter rejects requests with body larger than its limit.
+type BodyLimiter struct {
+	maxBytes atomic.Int64 // Maximum allowed body size in bytes
+}
+
+// NewBodyLimiter creates a limiter of request body size.
+// Parameters:
+// - maxBytes: Maximum allowed body size in bytes
+//
+// Returns:
+// - *BodyLimiter: Limiter ready for router registration
+func NewBodyLimiter(maxBytes int64) *BodyLimiter {
+	l := &BodyLimiter{}
+	l.maxBytes.Store(maxBytes)
+	return l
+}
+
+// SetMaxBytes changes the limit for requests received after the call.
+// Parameters:
+// - maxBytes: Maximum allowed body size in bytes
+func (l *BodyLimiter) SetMaxBytes(maxBytes int64) {
+	l.maxBytes.Store(maxBytes)
+}
+
+// Reload applies the body size limit of the reloaded configuration.
+// Parameters:
+// - cfg: Reloaded configuration
+// Returns:
+// - error: Always nil
+func (l *BodyLimiter) Reload(cfg *config.Config) error {
+	l.SetMaxBytes(cfg.Server.MaxBodyBytes)
+	return nil
+}
+
+// BodyLimit returns middleware that rejects requests with body larger than maxBytes.
+// It is a shortcut for NewBodyLimiter(maxBytes).Middleware.
+//
+// Parameters:
+// - maxBytes: Maximum allowed body size in bytes
+//
+// Returns:
+// - func(http.Handler) http.Handler: Middleware for router registration
+func BodyLimit(maxBytes int64) func(http.Handler) http.Handler {
+	return NewBodyLimiter(maxBytes).Middleware
+}
+
+// Middleware wraps the handler with the body size check.
+// Body is read up to the limit before the next handler is called,
+// so oversized requests never reach it, even if they are streamed without Content-Length.
+// Rejected requests get 413 Request Entity Too Large.
+//
+// Parameters:
+// - h: Next handler
+//
+// Returns:
+// - http.Handler: Handler limiting request bodies
+func (l *BodyLimiter) Middleware(h http.Handler) http.Handler {
+	limitFn := func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil || r.Body == http.NoBody {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		maxBytes := l.maxBytes.Load()
+		if r.ContentLength > maxBytes {
+			returnBodyLimitError(w, http.StatusRequestEntityTooLarge, bodyTooLargeMsg)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBytes))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				returnBodyLimitError(w, http.StatusRequestEntityTooLarge, bodyTooLargeMsg)
+				return
+			}
+			returnBodyLimitError(w, http.StatusBadRequest, bodyReadErrorMsg)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		h.ServeHTTP(w, r)
+	}
+
+	return http.HandlerFunc(limitFn)
+}
+
+// returnBodyLimitError writes JSON error response for rejected body.
+// Parameters:
+// - w: HTTP response writer
+// - statusCode: HTTP status code
+// - msg: Error message
+func returnBodyLimitError(w http.ResponseWriter, statusCode int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	resp := bodyLimitErrorResponse{StatusCode: statusCode, Code: httpErrors.Code(nil, statusCode), Error: msg}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gururuby/shortener/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streamReader hides length of the wrapped reader, so request body is sent chunked.
+type streamReader struct {
+	io.Reader
+}
+
+func TestBodyLimit(t *testing.T) {
+	const maxBytes = 16
+
+	tests := []struct {
+		name           string
+		body           io.Reader
+		expectedStatus int
+		expectedBody   string
+		expectNext     bool
+	}{
+		{
+			name:           "body exactly at limit",
+			body:           strings.NewReader(strings.Repeat("a", maxBytes)),
+			expectedStatus: http.StatusOK,
+			expectedBody:   strings.Repeat("a", maxBytes),
+			expectNext:     true,
+		},
+		{
+			name:           "body one byte over limit",
+			body:           strings.NewReader(strings.Repeat("a", maxBytes+1)),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectNext:     false,
+		},
+		{
+			name:           "streaming body within limit",
+			body:           streamReader{strings.NewReader(strings.Repeat("a", maxBytes-1))},
+			expectedStatus: http.StatusOK,
+			expectedBody:   strings.Repeat("a", maxBytes-1),
+			expectNext:     true,
+		},
+		{
+			name:           "streaming body exceeding limit mid-read",
+			body:           streamReader{strings.NewReader(strings.Repeat("a", 10*maxBytes))},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectNext:     false,
+		},
+		{
+			name:           "request without body",
+			expectedStatus: http.StatusOK,
+			expectNext:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextCalled := false
+			var nextBody string
+			handler := BodyLimit(maxBytes)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				nextBody = string(body)
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/shorten/batch", tt.body)
+			if _, ok := tt.body.(streamReader); ok {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectNext, nextCalled)
+			if tt.expectNext {
+				assert.Equal(t, tt.expectedBody, nextBody)
+			} else {
+				assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+				assert.JSONEq(t, `{"StatusCode":413,"Code":"ERR_PAYLOAD_TOO_LARGE","Error":"request body too large"}`, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestBodyLimiter_SetMaxBytes(t *testing.T) {
+	l := NewBodyLimiter(4)
+	h := l.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	post := func() int {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("12345678")))
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post())
+
+	l.SetMaxBytes(8)
+	assert.Equal(t, http.StatusOK, post())
+
+	require.NoError(t, l.Reload(&config.Config{Server: config.Server{MaxBodyBytes: 4}}))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post())
+}
@@ -2,7 +2,8 @@
 Package middleware provides HTTP middleware components for the application.
 
 It includes:
-- Response compression using gzip
+- Response compression using Brotli, gzip, deflate or Zstandard negotiated by Accept-Encoding
+- Server preferred encoding overriding client preference
 - Request body decompression
 - Content type aware compression
 - Error handling for compression operations
@@ -11,12 +12,56 @@ package middleware
 
 import (
 	"compress/gzip"
+	"compress/zlib"
 	"io"
 	"net/http"
 	"slices"
+	"strconv"
 	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Supported response content encodings
+const (
+	encodingBrotli   = "br"
+	encodingGzip     = "gzip"
+	encodingDeflate  = "deflate"
+	encodingZstd     = "zstd"
+	encodingIdentity = "identity"
 )
 
+// Values of preferred encoding of PreferredCompression
+const (
+	PreferredEncodingAuto   = "auto"   // Encoding is negotiated by client preference
+	PreferredEncodingGzip   = "gzip"   // gzip is used if the client accepts it
+	PreferredEncodingBrotli = "brotli" // Brotli is used if the client accepts it
+	PreferredEncodingZstd   = "zstd"   // Zstandard is used if the client accepts it
+)
+
+// preferredEncodings maps values of preferred encoding to response encodings.
+var preferredEncodings = map[string]string{
+	PreferredEncodingGzip:   encodingGzip,
+	PreferredEncodingBrotli: encodingBrotli,
+	PreferredEncodingZstd:   encodingZstd,
+}
+
+// supportedEncodings lists response encodings in order of server preference.
+// Zstandard is the last one as browsers support it the least.
+var supportedEncodings = []string{encodingBrotli, encodingGzip, encodingDeflate, encodingZstd}
+
+// apiEncodings lists response encodings in order of server preference for API clients,
+// Zstandard compresses JSON as well as Brotli at a fraction of CPU time.
+var apiEncodings = []string{encodingZstd, encodingBrotli, encodingGzip, encodingDeflate}
+
+// responseCompressor is a response writer compressing the written body.
+type responseCompressor interface {
+	http.ResponseWriter
+	http.Flusher
+	io.Closer
+}
+
 // compressWriter wraps http.ResponseWriter to provide gzip compression
 // for supported content types.
 type compressWriter struct {
@@ -24,54 +69,213 @@ type compressWriter struct {
 	zw *gzip.Writer        // Gzip writer for compression
 }
 
+// compressDeflateWriter wraps http.ResponseWriter to provide deflate compression
+// for supported content types.
+type compressDeflateWriter struct {
+	w  http.ResponseWriter // Original response writer
+	zw *zlib.Writer        // Zlib writer for compression
+}
+
+// compressBrotliWriter wraps http.ResponseWriter to provide Brotli compression
+// for supported content types.
+type compressBrotliWriter struct {
+	w  http.ResponseWriter // Original response writer
+	bw *brotli.Writer      // Brotli writer for compression
+}
+
+// compressZstdWriter wraps http.ResponseWriter to provide Zstandard compression
+// for supported content types.
+type compressZstdWriter struct {
+	w  http.ResponseWriter // Original response writer
+	zw *zstd.Encoder       // Zstandard encoder for compression
+}
+
 // Compression is middleware that handles request/response compression.
 // It supports:
-// - Compressing responses with gzip for clients that accept it
-// - Decompressing gzip-encoded request bodies
+// - Compressing responses with Brotli, gzip, deflate or Zstandard, whichever of the encodings
+// accepted by the client has the highest q-value (Brotli > gzip > deflate > Zstandard on ties,
+// Zstandard > Brotli > gzip > deflate for clients accepting application/json)
+// - Decompressing gzip and Zstandard encoded request bodies
 // - Automatic handling of supported content types
 //
 // Supported content types: application/json, text/html
 func Compression(h http.Handler) http.Handler {
-	compressFn := func(w http.ResponseWriter, r *http.Request) {
-		var err error
-		var cr *compressReader
-		ow := w
-
-		acceptEncoding := r.Header.Get("Accept-Encoding")
-		supportContentTypes := []string{"application/json", "text/html"}
-		isSupportGzip := strings.Contains(acceptEncoding, "gzip")
-		if isSupportGzip && slices.Contains(supportContentTypes, r.Header.Get("Content-Type")) {
-			cw := newCompressWriter(w)
-			ow = cw
-			defer func(cw *compressWriter) {
-				err = cw.Close()
-				if err != nil {
-					w.WriteHeader(http.StatusInternalServerError)
-				}
-			}(cw)
+	return PreferredCompression(PreferredEncodingAuto)(h)
+}
+
+// PreferredCompression returns Compression middleware using the preferred encoding
+// whenever the client accepts it, regardless of q-values of other encodings.
+// Parameters:
+// - preferred: One of PreferredEncoding values, unknown or empty value is treated as auto
+// Returns:
+// - func(http.Handler) http.Handler: Middleware for router registration
+func PreferredCompression(preferred string) func(http.Handler) http.Handler {
+	preferredEncoding := preferredEncodings[preferred]
+
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			compress(h, w, r, selectEncoding(r, preferredEncoding))
+		})
+	}
+}
+
+// compress serves the request compressing the response with the encoding
+// and decompressing the request body.
+// Parameters:
+// - h: Handler serving the request
+// - w: Original response writer
+// - r: HTTP request
+// - encoding: Negotiated response encoding, identity disables compression
+func compress(h http.Handler, w http.ResponseWriter, r *http.Request, encoding string) {
+	var err error
+	ow := w
+
+	supportContentTypes := []string{"application/json", "text/html"}
+	if encoding != encodingIdentity && slices.Contains(supportContentTypes, r.Header.Get("Content-Type")) {
+		cw, cwErr := newResponseCompressor(w, encoding)
+		if cwErr != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
 		}
+		ow = cw
+		defer func(cw responseCompressor) {
+			err = cw.Close()
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}(cw)
+	}
 
-		contentEncoding := r.Header.Get("Content-Encoding")
-		isReceivedGzip := strings.Contains(contentEncoding, "gzip")
-		if isReceivedGzip {
+	contentEncoding := r.Header.Get("Content-Encoding")
+	if strings.Contains(contentEncoding, encodingGzip) || strings.Contains(contentEncoding, encodingZstd) {
+		var cr io.ReadCloser
+		if strings.Contains(contentEncoding, encodingZstd) {
+			cr, err = newDecompressZstdReader(r.Body)
+		} else {
 			cr, err = newCompressReader(r.Body)
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		r.Body = cr
+		defer func(cr io.ReadCloser) {
+			err = cr.Close()
 			if err != nil {
 				w.WriteHeader(http.StatusInternalServerError)
-				return
 			}
-			r.Body = cr
-			defer func(cr *compressReader) {
-				err = cr.Close()
-				if err != nil {
-					w.WriteHeader(http.StatusInternalServerError)
-				}
-			}(cr)
+		}(cr)
+	}
+
+	h.ServeHTTP(ow, r)
+}
+
+// selectEncoding selects response encoding for the request.
+// Parameters:
+// - r: HTTP request
+// - preferred: Encoding used whenever the client accepts it, empty for none
+// Returns:
+// - string: Response encoding, identity if no supported encoding is acceptable
+func selectEncoding(r *http.Request, preferred string) string {
+	qValues := parseAcceptEncoding(r.Header.Get("Accept-Encoding"))
+
+	if preferred != "" && acceptQValue(qValues, preferred) > 0 {
+		return preferred
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), "application/json") {
+		return bestEncoding(qValues, apiEncodings)
+	}
+	return bestEncoding(qValues, supportedEncodings)
+}
+
+// negotiateEncoding selects response encoding from Accept-Encoding header.
+// Encodings with q=0 are not acceptable, "*" matches any encoding not listed explicitly.
+// Parameters:
+// - acceptEncoding: Value of Accept-Encoding request header
+// Returns:
+// - string: Supported encoding with the highest q-value, identity if none is acceptable
+// or identity is preferred
+func negotiateEncoding(acceptEncoding string) string {
+	return bestEncoding(parseAcceptEncoding(acceptEncoding), supportedEncodings)
+}
+
+// parseAcceptEncoding parses q-values of encodings listed in Accept-Encoding header.
+// Parameters:
+// - acceptEncoding: Value of Accept-Encoding request header
+// Returns:
+// - map[string]float64: q-values by lower case encoding name, 1 if q-value is not set
+func parseAcceptEncoding(acceptEncoding string) map[string]float64 {
+	qValues := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || strings.TrimSpace(key) != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				parsed = 0
+			}
+			q = parsed
 		}
+		qValues[name] = q
+	}
+	return qValues
+}
 
-		h.ServeHTTP(ow, r)
+// acceptQValue returns q-value of the encoding, "*" matches any encoding not listed explicitly.
+func acceptQValue(qValues map[string]float64, encoding string) float64 {
+	if q, ok := qValues[encoding]; ok {
+		return q
 	}
+	return qValues["*"]
+}
 
-	return http.HandlerFunc(compressFn)
+// bestEncoding selects the encoding with the highest q-value.
+// Parameters:
+// - qValues: q-values parsed by parseAcceptEncoding
+// - encodings: Supported encodings in order of server preference on equal q-values
+// Returns:
+// - string: Supported encoding with the highest q-value, identity if none is acceptable
+// or identity is preferred
+func bestEncoding(qValues map[string]float64, encodings []string) string {
+	best, bestQ := encodingIdentity, 0.0
+	for _, encoding := range encodings {
+		if q := acceptQValue(qValues, encoding); q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	if q, ok := qValues[encodingIdentity]; ok && q > bestQ {
+		return encodingIdentity
+	}
+	return best
+}
+
+// newResponseCompressor creates a compressing response writer for the encoding.
+// Parameters:
+// - w: Original http.ResponseWriter to wrap
+// - encoding: Negotiated encoding, one of supportedEncodings
+// Returns:
+// - responseCompressor: Initialized compression writer, gzip one for unknown encoding
+// - error: If Zstandard encoder creation fails
+func newResponseCompressor(w http.ResponseWriter, encoding string) (responseCompressor, error) {
+	switch encoding {
+	case encodingBrotli:
+		return newCompressBrotliWriter(w), nil
+	case encodingDeflate:
+		return newCompressDeflateWriter(w), nil
+	case encodingZstd:
+		return newCompressZstdWriter(w)
+	default:
+		return newCompressWriter(w), nil
+	}
 }
 
 // newCompressWriter creates a new compressWriter instance.
@@ -100,17 +304,172 @@ func (c *compressWriter) Write(p []byte) (int, error) {
 // Sets Content-Encoding header for successful responses (status < 300).
 func (c *compressWriter) WriteHeader(statusCode int) {
 	if statusCode < 300 {
-		c.w.Header().Set("Content-Encoding", "gzip")
-		c.w.Header().Set("Accept-Encoding", "gzip")
+		c.w.Header().Set("Content-Encoding", encodingGzip)
+		c.w.Header().Set("Accept-Encoding", encodingGzip)
 	}
 	c.w.WriteHeader(statusCode)
 }
 
+// Flush writes pending compressed data and sends it to the client.
+func (c *compressWriter) Flush() {
+	if err := c.zw.Flush(); err != nil {
+		return
+	}
+	if flusher, ok := c.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
 // Close closes the gzip writer and flushes any pending compressed data.
 func (c *compressWriter) Close() error {
 	return c.zw.Close()
 }
 
+// newCompressDeflateWriter creates a new compressDeflateWriter instance.
+// Parameters:
+// - w: Original http.ResponseWriter to wrap
+// Returns:
+// - *compressDeflateWriter: Initialized compression writer
+func newCompressDeflateWriter(w http.ResponseWriter) *compressDeflateWriter {
+	return &compressDeflateWriter{
+		w:  w,
+		zw: zlib.NewWriter(w),
+	}
+}
+
+// Header returns the header map from the original ResponseWriter.
+func (c *compressDeflateWriter) Header() http.Header {
+	return c.w.Header()
+}
+
+// Write compresses and writes the data to the underlying connection.
+func (c *compressDeflateWriter) Write(p []byte) (int, error) {
+	return c.zw.Write(p)
+}
+
+// WriteHeader sends an HTTP response header with the provided status code.
+// Sets Content-Encoding header for successful responses (status < 300).
+func (c *compressDeflateWriter) WriteHeader(statusCode int) {
+	if statusCode < 300 {
+		c.w.Header().Set("Content-Encoding", encodingDeflate)
+	}
+	c.w.WriteHeader(statusCode)
+}
+
+// Flush writes pending compressed data and sends it to the client.
+func (c *compressDeflateWriter) Flush() {
+	if err := c.zw.Flush(); err != nil {
+		return
+	}
+	if flusher, ok := c.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Close closes the zlib writer and flushes any pending compressed data.
+func (c *compressDeflateWriter) Close() error {
+	return c.zw.Close()
+}
+
+// newCompressBrotliWriter creates a new compressBrotliWriter instance.
+// Parameters:
+// - w: Original http.ResponseWriter to wrap
+// Returns:
+// - *compressBrotliWriter: Initialized compression writer
+func newCompressBrotliWriter(w http.ResponseWriter) *compressBrotliWriter {
+	return &compressBrotliWriter{
+		w:  w,
+		bw: brotli.NewWriter(w),
+	}
+}
+
+// Header returns the header map from the original ResponseWriter.
+func (c *compressBrotliWriter) Header() http.Header {
+	return c.w.Header()
+}
+
+// Write compresses and writes the data to the underlying connection.
+func (c *compressBrotliWriter) Write(p []byte) (int, error) {
+	return c.bw.Write(p)
+}
+
+// WriteHeader sends an HTTP response header with the provided status code.
+// Sets Content-Encoding header for successful responses (status < 300).
+func (c *compressBrotliWriter) WriteHeader(statusCode int) {
+	if statusCode < 300 {
+		c.w.Header().Set("Content-Encoding", encodingBrotli)
+	}
+	c.w.WriteHeader(statusCode)
+}
+
+// Flush writes pending compressed data and sends it to the client.
+func (c *compressBrotliWriter) Flush() {
+	if err := c.bw.Flush(); err != nil {
+		return
+	}
+	if flusher, ok := c.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Close closes the Brotli writer and flushes any pending compressed data.
+func (c *compressBrotliWriter) Close() error {
+	return c.bw.Close()
+}
+
+// newCompressZstdWriter creates a new compressZstdWriter instance.
+// The encoder compresses in the calling goroutine with reduced memory, as responses are small.
+// Parameters:
+// - w: Original http.ResponseWriter to wrap
+// Returns:
+// - *compressZstdWriter: Initialized compression writer
+// - error: If encoder creation fails
+func newCompressZstdWriter(w http.ResponseWriter) (*compressZstdWriter, error) {
+	zw, err := zstd.NewWriter(w, zstd.WithEncoderConcurrency(1), zstd.WithLowerEncoderMem(true))
+	if err != nil {
+		return nil, err
+	}
+
+	return &compressZstdWriter{
+		w:  w,
+		zw: zw,
+	}, nil
+}
+
+// Header returns the header map from the original ResponseWriter.
+func (c *compressZstdWriter) Header() http.Header {
+	return c.w.Header()
+}
+
+// Write compresses and writes the data to the underlying connection.
+func (c *compressZstdWriter) Write(p []byte) (int, error) {
+	return c.zw.Write(p)
+}
+
+// WriteHeader sends an HTTP response header with the provided status code.
+// Sets Content-Encoding header for successful responses (status < 300).
+func (c *compressZstdWriter) WriteHeader(statusCode int) {
+	if statusCode < 300 {
+		c.w.Header().Set("Content-Encoding", encodingZstd)
+	}
+	c.w.WriteHeader(statusCode)
+}
+
+// Flush writes pending compressed data and sends it to the client.
+func (c *compressZstdWriter) Flush() {
+	if err := c.zw.Flush(); err != nil {
+		return
+	}
+	if flusher, ok := c.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Close closes the Zstandard encoder and flushes any pending compressed data.
+func (c *compressZstdWriter) Close() error {
+	return c.zw.Close()
+}
+
 // compressReader wraps io.ReadCloser to provide gzip decompression
 // for incoming request bodies.
 type compressReader struct {
@@ -148,3 +507,39 @@ func (c compressReader) Close() error {
 	}
 	return c.zr.Close()
 }
+
+// decompressZstdReader wraps io.ReadCloser to provide Zstandard decompression
+// for incoming request bodies.
+type decompressZstdReader struct {
+	r  io.ReadCloser // Original reader
+	zr *zstd.Decoder // Zstandard decoder for decompression
+}
+
+// newDecompressZstdReader creates a new decompressZstdReader instance.
+// Parameters:
+// - r: Original io.ReadCloser to wrap
+// Returns:
+// - *decompressZstdReader: Initialized decompression reader
+// - error: If decoder creation fails
+func newDecompressZstdReader(r io.ReadCloser) (*decompressZstdReader, error) {
+	zr, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+
+	return &decompressZstdReader{
+		r:  r,
+		zr: zr,
+	}, nil
+}
+
+// Read decompresses and reads data from the underlying connection.
+func (c *decompressZstdReader) Read(p []byte) (int, error) {
+	return c.zr.Read(p)
+}
+
+// Close releases the decoder and closes the original reader.
+func (c *decompressZstdReader) Close() error {
+	c.zr.Close()
+	return c.r.Close()
+}
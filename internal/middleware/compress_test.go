@@ -3,12 +3,16 @@ package middleware
 import (
 	"bytes"
 	"compress/gzip"
+	"compress/zlib"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -17,9 +21,11 @@ func TestCompressionMiddleware(t *testing.T) {
 	tests := []struct {
 		name               string
 		contentType        string
+		accept             string
 		acceptEncoding     string
 		contentEncoding    string
 		requestBody        string
+		expectedEncoding   string
 		expectedStatus     int
 		expectCompressed   bool
 		expectDecompressed bool
@@ -28,6 +34,7 @@ func TestCompressionMiddleware(t *testing.T) {
 			name:               "compress json response",
 			contentType:        "application/json",
 			acceptEncoding:     "gzip",
+			expectedEncoding:   "gzip",
 			expectedStatus:     http.StatusOK,
 			expectCompressed:   true,
 			expectDecompressed: false,
@@ -36,10 +43,75 @@ func TestCompressionMiddleware(t *testing.T) {
 			name:               "compress html response",
 			contentType:        "text/html",
 			acceptEncoding:     "gzip",
+			expectedEncoding:   "gzip",
 			expectedStatus:     http.StatusOK,
 			expectCompressed:   true,
 			expectDecompressed: false,
 		},
+		{
+			name:             "compress response with brotli",
+			contentType:      "application/json",
+			acceptEncoding:   "br",
+			expectedEncoding: "br",
+			expectedStatus:   http.StatusOK,
+			expectCompressed: true,
+		},
+		{
+			name:             "compress response with deflate",
+			contentType:      "application/json",
+			acceptEncoding:   "deflate",
+			expectedEncoding: "deflate",
+			expectedStatus:   http.StatusOK,
+			expectCompressed: true,
+		},
+		{
+			name:             "prefer brotli when all encodings are accepted",
+			contentType:      "application/json",
+			acceptEncoding:   "gzip, deflate, br",
+			expectedEncoding: "br",
+			expectedStatus:   http.StatusOK,
+			expectCompressed: true,
+		},
+		{
+			name:             "prefer encoding with the highest q-value",
+			contentType:      "application/json",
+			acceptEncoding:   "br;q=0.5, gzip;q=0.8, deflate",
+			expectedEncoding: "deflate",
+			expectedStatus:   http.StatusOK,
+			expectCompressed: true,
+		},
+		{
+			name:             "compress response with zstd",
+			contentType:      "application/json",
+			acceptEncoding:   "zstd",
+			expectedEncoding: "zstd",
+			expectedStatus:   http.StatusOK,
+			expectCompressed: true,
+		},
+		{
+			name:             "prefer zstd for API clients",
+			contentType:      "application/json",
+			accept:           "application/json",
+			acceptEncoding:   "zstd, gzip",
+			expectedEncoding: "zstd",
+			expectedStatus:   http.StatusOK,
+			expectCompressed: true,
+		},
+		{
+			name:             "prefer gzip over zstd for browsers",
+			contentType:      "application/json",
+			accept:           "text/html",
+			acceptEncoding:   "zstd, gzip",
+			expectedEncoding: "gzip",
+			expectedStatus:   http.StatusOK,
+			expectCompressed: true,
+		},
+		{
+			name:           "do not compress when identity is requested",
+			contentType:    "application/json",
+			acceptEncoding: "identity",
+			expectedStatus: http.StatusOK,
+		},
 		{
 			name:               "do not compress unsupported content type",
 			contentType:        "text/plain",
@@ -65,6 +137,14 @@ func TestCompressionMiddleware(t *testing.T) {
 			expectCompressed:   false,
 			expectDecompressed: true,
 		},
+		{
+			name:               "decompress zstd request",
+			contentType:        "application/json",
+			contentEncoding:    "zstd",
+			requestBody:        "test request body",
+			expectedStatus:     http.StatusOK,
+			expectDecompressed: true,
+		},
 		{
 			name:               "error on invalid gzip request",
 			contentType:        "application/json",
@@ -92,7 +172,11 @@ func TestCompressionMiddleware(t *testing.T) {
 			})
 
 			var body io.Reader
-			if tt.contentEncoding == "gzip" && tt.requestBody != "" {
+			if tt.contentEncoding == "zstd" {
+				zw, err := zstd.NewWriter(nil)
+				require.NoError(t, err)
+				body = bytes.NewReader(zw.EncodeAll([]byte(tt.requestBody), nil))
+			} else if tt.contentEncoding == "gzip" && tt.requestBody != "" {
 				if tt.requestBody == "invalid gzip data" {
 					body = strings.NewReader(tt.requestBody)
 				} else {
@@ -108,6 +192,7 @@ func TestCompressionMiddleware(t *testing.T) {
 			}
 
 			req := httptest.NewRequest("GET", "https://example.com", body)
+			req.Header.Set("Accept", tt.accept)
 			req.Header.Set("Accept-Encoding", tt.acceptEncoding)
 			req.Header.Set("Content-Encoding", tt.contentEncoding)
 			req.Header.Set("Content-Type", tt.contentType)
@@ -120,19 +205,27 @@ func TestCompressionMiddleware(t *testing.T) {
 			assert.Equal(t, tt.expectedStatus, rr.Code, "unexpected status code")
 
 			if tt.expectCompressed {
-				assert.Equal(t, "gzip", rr.Header().Get("Content-Encoding"), "expected Content-Encoding header")
+				assert.Equal(t, tt.expectedEncoding, rr.Header().Get("Content-Encoding"), "expected Content-Encoding header")
 
-				reader, err := gzip.NewReader(rr.Body)
-				require.NoError(t, err, "failed to create gzip reader")
-				defer func(reader *gzip.Reader) {
-					err = reader.Close()
-					if err != nil {
-						require.Error(t, err, "failed to close gzip reader")
-					}
-				}(reader)
+				var (
+					reader io.Reader
+					err    error
+				)
+				switch tt.expectedEncoding {
+				case "br":
+					reader = brotli.NewReader(rr.Body)
+				case "deflate":
+					reader, err = zlib.NewReader(rr.Body)
+				case "zstd":
+					reader, err = zstd.NewReader(rr.Body)
+				default:
+					reader, err = gzip.NewReader(rr.Body)
+				}
+				require.NoError(t, err, "failed to create decompression reader")
 
-				_, err = io.ReadAll(reader)
+				data, err := io.ReadAll(reader)
 				assert.NoError(t, err, "failed to decompress response")
+				assert.Equal(t, "test response", string(data), "unexpected decompressed response")
 			} else {
 				assert.Empty(t, rr.Header().Get("Content-Encoding"), "unexpected Content-Encoding header")
 			}
@@ -183,6 +276,111 @@ func TestCompressWriter(t *testing.T) {
 	}
 }
 
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		expected       string
+	}{
+		{name: "empty header", acceptEncoding: "", expected: "identity"},
+		{name: "single gzip", acceptEncoding: "gzip", expected: "gzip"},
+		{name: "brotli preferred on equal q-values", acceptEncoding: "deflate, gzip, br", expected: "br"},
+		{name: "gzip preferred over deflate", acceptEncoding: "deflate, gzip", expected: "gzip"},
+		{name: "highest q-value wins", acceptEncoding: "br;q=0.2, gzip;q=0.9", expected: "gzip"},
+		{name: "q=0 is not acceptable", acceptEncoding: "br;q=0, deflate", expected: "deflate"},
+		{name: "case and spaces are ignored", acceptEncoding: " GZIP ; q=0.7 ", expected: "gzip"},
+		{name: "wildcard matches any encoding", acceptEncoding: "*", expected: "br"},
+		{name: "wildcard does not override explicit q=0", acceptEncoding: "br;q=0, *;q=0.5", expected: "gzip"},
+		{name: "identity only", acceptEncoding: "identity", expected: "identity"},
+		{name: "identity preferred by q-value", acceptEncoding: "gzip;q=0.3, identity", expected: "identity"},
+		{name: "unsupported encoding", acceptEncoding: "compress", expected: "identity"},
+		{name: "single zstd", acceptEncoding: "zstd", expected: "zstd"},
+		{name: "zstd has the lowest priority on equal q-values", acceptEncoding: "zstd, deflate", expected: "deflate"},
+		{name: "zstd with the highest q-value wins", acceptEncoding: "zstd, br;q=0.9", expected: "zstd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, negotiateEncoding(tt.acceptEncoding))
+		})
+	}
+}
+
+func TestResponseCompressors(t *testing.T) {
+	tests := []struct {
+		decompress func(r io.Reader) (io.Reader, error)
+		name       string
+		encoding   string
+	}{
+		{
+			name:       "gzip",
+			encoding:   "gzip",
+			decompress: func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		},
+		{
+			name:       "deflate",
+			encoding:   "deflate",
+			decompress: func(r io.Reader) (io.Reader, error) { return zlib.NewReader(r) },
+		},
+		{
+			name:       "brotli",
+			encoding:   "br",
+			decompress: func(r io.Reader) (io.Reader, error) { return brotli.NewReader(r), nil },
+		},
+		{
+			name:       "zstd",
+			encoding:   "zstd",
+			decompress: func(r io.Reader) (io.Reader, error) { return zstd.NewReader(r) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			cw, err := newResponseCompressor(rr, tt.encoding)
+			require.NoError(t, err)
+
+			cw.WriteHeader(http.StatusOK)
+			assert.Equal(t, tt.encoding, rr.Header().Get("Content-Encoding"), "expected Content-Encoding header")
+
+			_, err = cw.Write([]byte("first part"))
+			require.NoError(t, err)
+			cw.Flush()
+			assert.True(t, rr.Flushed, "underlying writer is not flushed")
+
+			_, err = cw.Write([]byte(" second part"))
+			require.NoError(t, err)
+			require.NoError(t, cw.Close())
+
+			reader, err := tt.decompress(rr.Body)
+			require.NoError(t, err)
+			data, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			assert.Equal(t, "first part second part", string(data))
+		})
+	}
+}
+
+func TestCompressWriterFlush(t *testing.T) {
+	rr := httptest.NewRecorder()
+	cw := newCompressWriter(rr)
+
+	cw.WriteHeader(http.StatusOK)
+	_, err := cw.Write([]byte("first part"))
+	require.NoError(t, err)
+
+	cw.Flush()
+
+	assert.True(t, rr.Flushed, "underlying writer is not flushed")
+
+	zr, err := gzip.NewReader(bytes.NewReader(rr.Body.Bytes()))
+	require.NoError(t, err)
+	buf := make([]byte, len("first part"))
+	_, err = io.ReadFull(zr, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "first part", string(buf), "flushed data is not readable before Close()")
+}
+
 func TestCompressReader(t *testing.T) {
 	var buf bytes.Buffer
 	gz := gzip.NewWriter(&buf)
@@ -211,3 +409,71 @@ func TestCompressReaderInvalidData(t *testing.T) {
 	_, err := newCompressReader(io.NopCloser(strings.NewReader("invalid gzip data")))
 	assert.Error(t, err, "expected error for invalid gzip data")
 }
+
+func TestPreferredCompression(t *testing.T) {
+	tests := []struct {
+		name           string
+		preferred      string
+		accept         string
+		acceptEncoding string
+		expected       string
+	}{
+		{name: "auto negotiates by client preference", preferred: PreferredEncodingAuto, acceptEncoding: "gzip, zstd", expected: "gzip"},
+		{name: "empty negotiates by client preference", acceptEncoding: "br, gzip", expected: "br"},
+		{name: "API client gets zstd over brotli", preferred: PreferredEncodingAuto, accept: "application/json", acceptEncoding: "br, zstd", expected: "zstd"},
+		{name: "preferred encoding overrides q-values", preferred: PreferredEncodingGzip, acceptEncoding: "br, gzip;q=0.1", expected: "gzip"},
+		{name: "preferred brotli", preferred: PreferredEncodingBrotli, accept: "application/json", acceptEncoding: "zstd, br", expected: "br"},
+		{name: "preferred zstd matched by wildcard", preferred: PreferredEncodingZstd, acceptEncoding: "*", expected: "zstd"},
+		{name: "preferred encoding not accepted", preferred: PreferredEncodingZstd, acceptEncoding: "gzip, zstd;q=0", expected: "gzip"},
+		{name: "unknown preferred encoding", preferred: "lzma", acceptEncoding: "gzip", expected: "gzip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := PreferredCompression(tt.preferred)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte("test response"))
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Accept", tt.accept)
+			req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expected, rr.Header().Get("Content-Encoding"))
+		})
+	}
+}
+
+func BenchmarkCompression(b *testing.B) {
+	var payload bytes.Buffer
+	payload.WriteString("[")
+	for i := 0; payload.Len() < 10<<10; i++ {
+		if i > 0 {
+			payload.WriteString(",")
+		}
+		fmt.Fprintf(&payload, `{"short_url":"http://localhost:8080/alias%d","original_url":"https://example.com/articles/%d?utm_source=newsletter"}`, i, i)
+	}
+	payload.WriteString("]")
+
+	h := Compression(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(payload.Bytes())
+	}))
+
+	for _, encoding := range []string{encodingGzip, encodingDeflate, encodingBrotli, encodingZstd} {
+		b.Run(encoding, func(b *testing.B) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Accept-Encoding", encoding)
+
+			b.SetBytes(int64(payload.Len()))
+			b.ReportAllocs()
+			for b.Loop() {
+				h.ServeHTTP(httptest.NewRecorder(), req)
+			}
+		})
+	}
+}
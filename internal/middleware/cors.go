@@ -0,0 +1,79 @@
+/*
+Package middleware provides HTTP middleware components for cross-origin requests.
+
+It features:
+- Handling of CORS preflight (OPTIONS) requests
+- Origin allow-listing with wildcard support
+- Credentialed requests support
+- Rejection of requests from not allowed origins
+*/
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Available constants
+const (
+	corsAllowAnyOrigin = "*" // Allowed origins entry matching any origin
+	corsMaxAge         = 600 // How long (in seconds) preflight results can be cached
+
+	// Request headers allowed for cross-origin requests
+	corsAllowedHeaders = "Accept, Accept-Encoding, Authorization, Content-Encoding, Content-Type, Idempotency-Key, X-Request-ID"
+
+	// Response headers readable by cross-origin clients
+	corsExposedHeaders = "Link, X-Idempotent-Replayed, X-Request-ID, X-Short-URL"
+)
+
+// CORS returns middleware that handles cross-origin resource sharing.
+// Requests without Origin header are passed through untouched.
+// Requests from not allowed origins are rejected with 403 Forbidden.
+// Preflight requests from allowed origins are answered with 204 No Content.
+//
+// Parameters:
+// - allowedOrigins: List of allowed origins, "*" allows any origin
+// - allowedMethods: List of HTTP methods allowed for cross-origin requests
+//
+// Returns:
+// - func(http.Handler) http.Handler: Middleware for router registration
+func CORS(allowedOrigins []string, allowedMethods []string) func(http.Handler) http.Handler {
+	allowAny := slices.Contains(allowedOrigins, corsAllowAnyOrigin)
+	methods := strings.Join(allowedMethods, ", ")
+
+	return func(h http.Handler) http.Handler {
+		corsFn := func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Origin")
+
+			if !allowAny && !slices.Contains(allowedOrigins, origin) {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			// Origin is echoed instead of "*" because wildcard is not allowed with credentials
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+			w.Header().Set("Access-Control-Expose-Headers", corsExposedHeaders)
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				w.Header().Set("Access-Control-Allow-Methods", methods)
+				w.Header().Set("Access-Control-Allow-Headers", corsAllowedHeaders)
+				w.Header().Set("Access-Control-Max-Age", strconv.Itoa(corsMaxAge))
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			h.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(corsFn)
+	}
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	allowedOrigins := []string{"https://app.example.com"}
+	allowedMethods := []string{http.MethodGet, http.MethodPost, http.MethodDelete}
+
+	tests := []struct {
+		name            string
+		method          string
+		origin          string
+		requestMethod   string
+		allowedOrigins  []string
+		expectedStatus  int
+		expectedOrigin  string
+		expectedMethods string
+		expectedMaxAge  string
+		expectedCreds   string
+		expectNext      bool
+	}{
+		{
+			name:            "valid preflight request",
+			method:          http.MethodOptions,
+			origin:          "https://app.example.com",
+			requestMethod:   http.MethodPost,
+			allowedOrigins:  allowedOrigins,
+			expectedStatus:  http.StatusNoContent,
+			expectedOrigin:  "https://app.example.com",
+			expectedMethods: "GET, POST, DELETE",
+			expectedMaxAge:  "600",
+			expectedCreds:   "true",
+			expectNext:      false,
+		},
+		{
+			name:           "preflight from not allowed origin",
+			method:         http.MethodOptions,
+			origin:         "https://evil.example.com",
+			requestMethod:  http.MethodPost,
+			allowedOrigins: allowedOrigins,
+			expectedStatus: http.StatusForbidden,
+			expectNext:     false,
+		},
+		{
+			name:           "request from not allowed origin",
+			method:         http.MethodGet,
+			origin:         "https://evil.example.com",
+			allowedOrigins: allowedOrigins,
+			expectedStatus: http.StatusForbidden,
+			expectNext:     false,
+		},
+		{
+			name:           "credentialed request from allowed origin",
+			method:         http.MethodGet,
+			origin:         "https://app.example.com",
+			allowedOrigins: allowedOrigins,
+			expectedStatus: http.StatusOK,
+			expectedOrigin: "https://app.example.com",
+			expectedCreds:  "true",
+			expectNext:     true,
+		},
+		{
+			name:           "request from any origin when wildcard allowed",
+			method:         http.MethodPost,
+			origin:         "https://other.example.com",
+			allowedOrigins: []string{"*"},
+			expectedStatus: http.StatusOK,
+			expectedOrigin: "https://other.example.com",
+			expectedCreds:  "true",
+			expectNext:     true,
+		},
+		{
+			name:           "same origin request without Origin header",
+			method:         http.MethodGet,
+			allowedOrigins: allowedOrigins,
+			expectedStatus: http.StatusOK,
+			expectNext:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextCalled := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, "/api/shorten", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.requestMethod != "" {
+				req.Header.Set("Access-Control-Request-Method", tt.requestMethod)
+			}
+
+			w := httptest.NewRecorder()
+			CORS(tt.allowedOrigins, allowedMethods)(next).ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer func() {
+				err := resp.Body.Close()
+				require.NoError(t, err)
+			}()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tt.expectNext, nextCalled)
+			assert.Equal(t, tt.expectedOrigin, resp.Header.Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tt.expectedMethods, resp.Header.Get("Access-Control-Allow-Methods"))
+			assert.Equal(t, tt.expectedMaxAge, resp.Header.Get("Access-Control-Max-Age"))
+			assert.Equal(t, tt.expectedCreds, resp.Header.Get("Access-Control-Allow-Credentials"))
+		})
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Package middleware provides HTTP middleware components for request rate limiting.
+
+It features:
+- Token bucket rate limiting based on golang.org/x/time/rate
+- Pluggable limiting key (client IP, user token, etc.)
+- Retry-After hint for throttled clients
+- Periodic eviction of stale limiters
+*/
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Available constants
+const (
+	limiterTTL             = 10 * time.Minute // Limiters unused for this period are evicted
+	limiterCleanupInterval = time.Minute      // How often stale limiters are looked for
+)
+
+// RateLimitConfig contains settings of the rate limiting middleware.
+type RateLimitConfig struct {
+	KeyFunc           func(*http.Request) string // Extracts limiting key from request
+	RequestsPerSecond float64                    // Token bucket refill rate
+	BurstSize         int                        // Token bucket capacity
+}
+
+// limiterEntry holds limiter of a single key with its last usage time.
+type limiterEntry struct {
+	limiter  *rate.Limiter // Token bucket for the key
+	lastSeen atomic.Int64  // Unix nano time of the last request
+}
+
+// RateLimit returns middleware that limits the request rate per key.
+// Every key gets its own token bucket, requests exceeding it are rejected
+// with 429 Too Many Requests and Retry-After header.
+// If KeyFunc is not set RemoteIP is used.
+//
+// Parameters:
+// - cfg: Rate limiting settings
+//
+// Returns:
+// - func(http.Handler) http.Handler: Middleware for router registration
+func RateLimit(cfg RateLimitConfig) func(http.Handler) http.Handler {
+	var limiters sync.Map
+
+	keyFn := cfg.KeyFunc
+	if keyFn == nil {
+		keyFn = RemoteIP
+	}
+
+	go cleanupLimiters(&limiters, limiterCleanupInterval, limiterTTL)
+
+	return func(h http.Handler) http.Handler {
+		limitFn := func(w http.ResponseWriter, r *http.Request) {
+			now := time.Now()
+
+			entry, _ := limiters.LoadOrStore(keyFn(r), &limiterEntry{
+				limiter: rate.NewLimiter(rate.Limit(cfg.RequestsPerSecond), cfg.BurstSize),
+			})
+			le := entry.(*limiterEntry)
+			le.lastSeen.Store(now.UnixNano())
+
+			if !le.limiter.AllowN(now, 1) {
+				reservation := le.limiter.ReserveN(now, 1)
+				delay := reservation.DelayFrom(now)
+				reservation.CancelAt(now)
+
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(delay)))
+				http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+				return
+			}
+
+			h.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(limitFn)
+	}
+}
+
+// RemoteIP is a rate limiting key function returning the client IP address.
+// Parameters:
+// - r: HTTP request
+// Returns:
+// - string: Client IP without port
+func RemoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// retryAfterSeconds converts limiter delay into Retry-After header value.
+// Parameters:
+// - delay: Time until the next token is available
+// Returns:
+// - int: Whole seconds to wait, at least 1
+func retryAfterSeconds(delay time.Duration) int {
+	seconds := int(math.Ceil(delay.Seconds()))
+	if seconds < 1 || delay == rate.InfDuration {
+		return 1
+	}
+	return seconds
+}
+
+// cleanupLimiters periodically evicts limiters which were not used for ttl.
+// Parameters:
+// - limiters: Map of key to *limiterEntry
+// - interval: How often to look for stale limiters
+// - ttl: Maximum idle time of a limiter
+func cleanupLimiters(limiters *sync.Map, interval, ttl time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		evictStaleLimiters(limiters, now, ttl)
+	}
+}
+
+// evictStaleLimiters removes limiters which were not used for ttl.
+// Parameters:
+// - limiters: Map of key to *limiterEntry
+// - now: Current time
+// - ttl: Maximum idle time of a limiter
+func evictStaleLimiters(limiters *sync.Map, now time.Time, ttl time.Duration) {
+	limiters.Range(func(key, value any) bool {
+		if now.Sub(time.Unix(0, value.(*limiterEntry).lastSeen.Load())) > ttl {
+			limiters.Delete(key)
+		}
+		return true
+	})
+}
@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	tests := []struct {
+		name              string
+		keyFunc           func(*http.Request) string
+		requestsPerSecond float64
+		burstSize         int
+		requests          int
+		expectedOK        int32
+		expectedLimited   int32
+	}{
+		{
+			name:              "limit requests above burst size",
+			keyFunc:           RemoteIP,
+			requestsPerSecond: 0.001,
+			burstSize:         5,
+			requests:          20,
+			expectedOK:        5,
+			expectedLimited:   15,
+		},
+		{
+			name:              "pass all requests within burst size",
+			keyFunc:           RemoteIP,
+			requestsPerSecond: 0.001,
+			burstSize:         20,
+			requests:          20,
+			expectedOK:        20,
+			expectedLimited:   0,
+		},
+		{
+			name:              "use remote IP when key function is not set",
+			requestsPerSecond: 0.001,
+			burstSize:         3,
+			requests:          10,
+			expectedOK:        3,
+			expectedLimited:   7,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				wg      sync.WaitGroup
+				ok      atomic.Int32
+				limited atomic.Int32
+			)
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			h := RateLimit(RateLimitConfig{
+				RequestsPerSecond: tt.requestsPerSecond,
+				BurstSize:         tt.burstSize,
+				KeyFunc:           tt.keyFunc,
+			})(next)
+
+			for i := 0; i < tt.requests; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+
+					req := httptest.NewRequest(http.MethodPost, "/", nil)
+					req.RemoteAddr = "192.0.2.1:1234"
+					w := httptest.NewRecorder()
+					h.ServeHTTP(w, req)
+
+					switch w.Code {
+					case http.StatusOK:
+						ok.Add(1)
+					case http.StatusTooManyRequests:
+						limited.Add(1)
+						retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+						assert.NoError(t, err)
+						assert.GreaterOrEqual(t, retryAfter, 1)
+					}
+				}()
+			}
+			wg.Wait()
+
+			assert.Equal(t, tt.expectedOK, ok.Load())
+			assert.Equal(t, tt.expectedLimited, limited.Load())
+		})
+	}
+}
+
+func TestRateLimitMiddleware_SeparateKeys(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	h := RateLimit(RateLimitConfig{
+		RequestsPerSecond: 0.001,
+		BurstSize:         1,
+		KeyFunc:           RemoteIP,
+	})(next)
+
+	for _, addr := range []string{"192.0.2.1:1234", "192.0.2.2:1234"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = addr
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "192.0.2.1:4321"
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+}
+
+func TestEvictStaleLimiters(t *testing.T) {
+	var limiters sync.Map
+
+	now := time.Now()
+
+	stale := &limiterEntry{}
+	stale.lastSeen.Store(now.Add(-11 * time.Minute).UnixNano())
+	fresh := &limiterEntry{}
+	fresh.lastSeen.Store(now.Add(-time.Minute).UnixNano())
+
+	limiters.Store("stale", stale)
+	limiters.Store("fresh", fresh)
+
+	evictStaleLimiters(&limiters, now, limiterTTL)
+
+	_, ok := limiters.Load("stale")
+	assert.False(t, ok)
+	_, ok = limiters.Load("fresh")
+	assert.True(t, ok)
+}
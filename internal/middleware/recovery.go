@@ -0,0 +1,165 @@
+/*
+Package middleware provides HTTP middleware components for panic recovery.
+
+It features:
+- Structured log of recovered panics with truncated stack trace and request attributes
+- JSON 500 response with request ID to report
+- Counter of recovered panics and custom panic handling, e.g. alerting
+*/
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	httpErrors "github.com/gururuby/shortener/internal/handler/http/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// Available constants
+const (
+	maxStackTraceBytes = 4 << 10 // Longest stack trace logged and reported for a panic
+	panicErrorMsg      = "internal server error"
+)
+
+// PanicInfo describes a panic recovered in an HTTP handler.
+type PanicInfo struct {
+	Value      any    // Value passed to panic
+	StackTrace []byte // Stack of the panicking goroutine, truncated to 4 KB
+	RequestID  string // ID of the request, empty if it is not assigned yet
+	Method     string // HTTP method of the request
+	Path       string // URL path of the request
+}
+
+// panicErrorResponse represents response for requests whose handler panicked.
+type panicErrorResponse struct {
+	StatusCode int
+	Code       string
+	Error      string
+	RequestID  string `json:"request_id,omitempty"`
+}
+
+// recoveryOptions configures Recovery middleware.
+type recoveryOptions struct {
+	counter prometheus.Counter               // Counter of recovered panics
+	notify  func(context.Context, PanicInfo) // Custom panic handler, e.g. alerting
+}
+
+// RecoveryOption configures Recovery middleware.
+type RecoveryOption func(*recoveryOptions)
+
+// RecoverWithCounter sets counter incremented on every recovered panic.
+// Parameters:
+// - counter: Counter of recovered panics, e.g. created by metrics.RegisterPanics
+// Returns:
+// - RecoveryOption: Option for Recovery
+func RecoverWithCounter(counter prometheus.Counter) RecoveryOption {
+	return func(o *recoveryOptions) {
+		o.counter = counter
+	}
+}
+
+// RecoverAndNotify sets function called on every recovered panic after it is logged.
+// Parameters:
+// - fn: Custom panic handler, e.g. sending alerts, it must not panic
+// Returns:
+// - RecoveryOption: Option for Recovery
+func RecoverAndNotify(fn func(context.Context, PanicInfo)) RecoveryOption {
+	return func(o *recoveryOptions) {
+		o.notify = fn
+	}
+}
+
+// Recovery creates middleware recovering panics of HTTP handlers.
+// A recovered panic is logged with its stack trace and request attributes,
+// and the client gets 500 response with the request ID to report.
+// http.ErrAbortHandler is not recovered, so the server aborts the response as usual.
+// The middleware should be registered before all others to cover them as well,
+// the request ID is then taken from the response header set by RequestID middleware.
+// Parameters:
+// - log: Logger for recovered panics
+// - opts: Options adding panic counter and custom panic handling
+// Returns:
+// - func(http.Handler) http.Handler: Middleware for router registration
+func Recovery(log *zap.Logger, opts ...RecoveryOption) func(http.Handler) http.Handler {
+	options := &recoveryOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				value := recover()
+				if value == nil {
+					return
+				}
+				if err, ok := value.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(value)
+				}
+
+				info := PanicInfo{
+					Value:      value,
+					StackTrace: truncateStackTrace(debug.Stack()),
+					RequestID:  w.Header().Get(requestIDHeader),
+					Method:     r.Method,
+					Path:       r.URL.Path,
+				}
+
+				log.Error("panic recovered",
+					zap.String("error", fmt.Sprint(info.Value)),
+					zap.ByteString("stack_trace", info.StackTrace),
+					zap.String("request_id", info.RequestID),
+					zap.String("path", info.Path),
+					zap.String("method", info.Method),
+				)
+				if options.counter != nil {
+					options.counter.Inc()
+				}
+				if options.notify != nil {
+					options.notify(r.Context(), info)
+				}
+
+				returnPanicError(w, info.RequestID)
+			}()
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// truncateStackTrace limits stack trace to maxStackTraceBytes.
+// Parameters:
+// - stack: Full stack trace
+// Returns:
+// - []byte: Stack trace not longer than maxStackTraceBytes
+func truncateStackTrace(stack []byte) []byte {
+	if len(stack) > maxStackTraceBytes {
+		return stack[:maxStackTraceBytes]
+	}
+	return stack
+}
+
+// returnPanicError writes JSON error response for request whose handler panicked.
+// Parameters:
+// - w: HTTP response writer
+// - requestID: ID of the request to report
+func returnPanicError(w http.ResponseWriter, requestID string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusInternalServerError)
+
+	resp := panicErrorResponse{
+		StatusCode: http.StatusInternalServerError,
+		Code:       httpErrors.Code(nil, http.StatusInternalServerError),
+		Error:      panicErrorMsg,
+		RequestID:  requestID,
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRecovery(t *testing.T) {
+	tests := []struct {
+		name       string
+		panicValue any
+		wantError  string
+	}{
+		{
+			name:       "when handler panics with string",
+			panicValue: "something went wrong",
+			wantError:  "something went wrong",
+		},
+		{
+			name:       "when handler panics with error",
+			panicValue: errors.New("nil pointer dereference"),
+			wantError:  "nil pointer dereference",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.InfoLevel)
+			counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "panics_total"})
+
+			var notified []PanicInfo
+			mw := Recovery(zap.New(core),
+				RecoverWithCounter(counter),
+				RecoverAndNotify(func(_ context.Context, info PanicInfo) {
+					notified = append(notified, info)
+				}),
+			)
+			h := mw(RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/panic" {
+					panic(tt.panicValue)
+				}
+				w.WriteHeader(http.StatusNoContent)
+			})))
+
+			req := httptest.NewRequest(http.MethodPost, "/panic", nil)
+			req.Header.Set(requestIDHeader, "panic-request")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			assert.JSONEq(t, `{"StatusCode":500,"Code":"ERR_INTERNAL","Error":"internal server error","request_id":"panic-request"}`, rec.Body.String())
+
+			require.Equal(t, 1, logs.Len())
+			entry := logs.All()[0]
+			assert.Equal(t, "panic recovered", entry.Message)
+			assert.Equal(t, zapcore.ErrorLevel, entry.Level)
+			fields := entry.ContextMap()
+			assert.Equal(t, tt.wantError, fields["error"])
+			assert.Equal(t, "panic-request", fields["request_id"])
+			assert.Equal(t, "/panic", fields["path"])
+			assert.Equal(t, http.MethodPost, fields["method"])
+			assert.Contains(t, fields["stack_trace"], "runtime/debug.Stack")
+			assert.LessOrEqual(t, len(fields["stack_trace"].(string)), maxStackTraceBytes)
+
+			assert.InDelta(t, 1, testutil.ToFloat64(counter), 0)
+			require.Len(t, notified, 1)
+			assert.Equal(t, tt.panicValue, notified[0].Value)
+			assert.Equal(t, "panic-request", notified[0].RequestID)
+
+			// Subsequent requests are served as usual
+			rec = httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ok", nil))
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			assert.Equal(t, 1, logs.Len())
+			assert.InDelta(t, 1, testutil.ToFloat64(counter), 0)
+		})
+	}
+}
+
+func TestRecovery_AbortHandler(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	h := Recovery(zap.New(core))(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+	assert.Zero(t, logs.Len())
+}
+
+func Test_truncateStackTrace(t *testing.T) {
+	short := []byte("goroutine 1 [running]:")
+	assert.Equal(t, short, truncateStackTrace(short))
+
+	long := []byte(strings.Repeat("a", maxStackTraceBytes+1))
+	assert.Len(t, truncateStackTrace(long), maxStackTraceBytes)
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gururuby/shortener/internal/infra/logger"
+)
+
+// Available constants
+const (
+	requestIDHeader    = "X-Request-ID" // Header carrying request ID
+	maxRequestIDLength = 128            // Longest accepted client-provided request ID
+)
+
+// RequestID is middleware that assigns an ID to every request.
+// The ID is taken from X-Request-ID request header or generated as UUID v4
+// if the header is absent or invalid. It is stored in the request context,
+// added to logs by logger.FromContext and returned in X-Request-ID response header.
+func RequestID(h http.Handler) http.Handler {
+	requestIDFn := func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(requestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		w.Header().Set(requestIDHeader, requestID)
+
+		h.ServeHTTP(w, r.WithContext(logger.WithRequestID(r.Context(), requestID)))
+	}
+	return http.HandlerFunc(requestIDFn)
+}
+
+// GetRequestID returns ID of the request handled within the context.
+// Parameters:
+// - ctx: Request context
+// Returns:
+// - string: Request ID or empty string outside of RequestID middleware
+func GetRequestID(ctx context.Context) string {
+	return logger.RequestID(ctx)
+}
+
+// isValidRequestID checks that client-provided request ID is safe to log and echo.
+// Only non-empty printable ASCII values up to maxRequestIDLength are accepted.
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < 0x21 || requestID[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
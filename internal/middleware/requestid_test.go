@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gururuby/shortener/internal/infra/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+		want      string
+	}{
+		{
+			name:      "when request ID is passed by client",
+			requestID: "client-request-id",
+			want:      "client-request-id",
+		},
+		{
+			name: "when request ID is not passed",
+		},
+		{
+			name:      "when request ID is too long",
+			requestID: strings.Repeat("a", maxRequestIDLength+1),
+		},
+		{
+			name:      "when request ID contains control characters",
+			requestID: "id\nforged log line",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ctxRequestID string
+
+			h := RequestID(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				ctxRequestID = GetRequestID(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.requestID != "" {
+				req.Header.Set(requestIDHeader, tt.requestID)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			respRequestID := w.Header().Get(requestIDHeader)
+			assert.Equal(t, ctxRequestID, respRequestID)
+
+			if tt.want != "" {
+				assert.Equal(t, tt.want, respRequestID)
+			} else {
+				assert.Regexp(t, "^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[0-9a-f]{4}-[0-9a-f]{12}$", respRequestID)
+			}
+		})
+	}
+}
+
+func TestRequestIDMiddleware_Logging(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	// Both requests are held in handler until they run simultaneously
+	var started, release sync.WaitGroup
+	started.Add(2)
+	release.Add(1)
+
+	h := RequestID(AccessLog(zap.New(core))(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		started.Done()
+		release.Wait()
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		}()
+	}
+
+	started.Wait()
+	release.Done()
+	wg.Wait()
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+
+	ids := make(map[string]struct{})
+	for _, entry := range entries {
+		requestID, ok := entry.ContextMap()[logger.RequestIDField].(string)
+		require.True(t, ok)
+		require.NotEmpty(t, requestID)
+		ids[requestID] = struct{}{}
+	}
+	assert.Len(t, ids, 2)
+}
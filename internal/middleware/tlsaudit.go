@@ -0,0 +1,71 @@
+/*
+Package middleware provides HTTP middleware components for auditing of TLS clients.
+
+It features:
+- SHA-256 fingerprint of the client certificate in request logs
+- Optional echo of the fingerprint in response header for debugging
+*/
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/gururuby/shortener/internal/infra/logger"
+	"go.uber.org/zap"
+)
+
+// Available constants
+const (
+	clientCertFingerprintHeader = "X-TLS-Client-Fingerprint" // Header echoing fingerprint of client certificate
+	clientCertFingerprintField  = "tls_client_fingerprint"   // Name of log field with fingerprint of client certificate
+)
+
+// clientCertFingerprintKey is the context key for fingerprint of client certificate.
+type clientCertFingerprintKey struct{}
+
+// TLSAudit returns middleware recording which TLS client certificate was used for the request.
+// SHA-256 fingerprint of the leaf client certificate is stored in the request context and
+// added as tls_client_fingerprint field to the request scoped logger, so the middleware must
+// be registered after logger.Middleware. Requests without TLS or client certificate pass unchanged.
+//
+// Parameters:
+// - exposeFingerprint: Return the fingerprint in X-TLS-Client-Fingerprint response header
+//
+// Returns:
+// - func(http.Handler) http.Handler: Middleware for router registration
+func TLSAudit(exposeFingerprint bool) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		auditFn := func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			sum := sha256.Sum256(r.TLS.PeerCertificates[0].Raw)
+			fingerprint := hex.EncodeToString(sum[:])
+
+			if exposeFingerprint {
+				w.Header().Set(clientCertFingerprintHeader, fingerprint)
+			}
+
+			ctx := context.WithValue(r.Context(), clientCertFingerprintKey{}, fingerprint)
+			ctx = logger.StoreLogger(ctx, logger.With(ctx, zap.String(clientCertFingerprintField, fingerprint)))
+
+			h.ServeHTTP(w, r.WithContext(ctx))
+		}
+		return http.HandlerFunc(auditFn)
+	}
+}
+
+// GetClientCertFingerprint returns fingerprint of the client certificate of the request.
+// Parameters:
+// - ctx: Request context
+// Returns:
+// - string: Hex-encoded SHA-256 of the certificate or empty string if client didn't present one
+func GetClientCertFingerprint(ctx context.Context) string {
+	fingerprint, _ := ctx.Value(clientCertFingerprintKey{}).(string)
+	return fingerprint
+}
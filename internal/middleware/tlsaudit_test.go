@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestTLSAudit(t *testing.T) {
+	clientCert := newClientCert(t)
+	sum := sha256.Sum256(clientCert.Certificate[0])
+	fingerprint := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name        string
+		tls         bool
+		clientCert  bool
+		expose      bool
+		fingerprint string
+		header      string
+	}{
+		{
+			name:        "when client presents certificate",
+			tls:         true,
+			clientCert:  true,
+			fingerprint: fingerprint,
+		},
+		{
+			name:        "when fingerprint is exposed",
+			tls:         true,
+			clientCert:  true,
+			expose:      true,
+			fingerprint: fingerprint,
+			header:      fingerprint,
+		},
+		{
+			name:   "when client presents no certificate",
+			tls:    true,
+			expose: true,
+		},
+		{
+			name:   "when request is not over TLS",
+			expose: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.InfoLevel)
+
+			var ctxFingerprint string
+			h := TLSAudit(tt.expose)(AccessLog(zap.New(core))(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					ctxFingerprint = GetClientCertFingerprint(r.Context())
+					w.WriteHeader(http.StatusOK)
+				}),
+			))
+
+			ts := httptest.NewUnstartedServer(h)
+			client := http.DefaultClient
+			if tt.tls {
+				ts.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+				ts.StartTLS()
+				client = ts.Client()
+				if tt.clientCert {
+					client.Transport.(*http.Transport).TLSClientConfig.Certificates = []tls.Certificate{clientCert}
+				}
+			} else {
+				ts.Start()
+			}
+			defer ts.Close()
+
+			resp, err := client.Get(ts.URL)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, tt.header, resp.Header.Get(clientCertFingerprintHeader))
+			assert.Equal(t, tt.fingerprint, ctxFingerprint)
+
+			entries := logs.All()
+			require.Len(t, entries, 1)
+			logged, ok := entries[0].ContextMap()[clientCertFingerprintField]
+			if tt.fingerprint == "" {
+				assert.False(t, ok)
+				return
+			}
+			assert.Equal(t, tt.fingerprint, logged)
+		})
+	}
+}
+
+// newClientCert generates a self-signed TLS client certificate.
+func newClientCert(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "auditor"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
@@ -0,0 +1,92 @@
+/*
+Package middleware provides HTTP middleware components for distributed tracing.
+
+It features:
+- Server span per request
+- Continuation of incoming W3C Trace Context (traceparent header)
+- Request method, route and status code span attributes
+- Span context propagation to handlers and use cases through request context
+*/
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Available constants
+const (
+	tracerName      = "github.com/gururuby/shortener/internal/middleware" // Name of the tracer creating request spans
+	requestSpanName = "http.request"                                      // Name of request span
+)
+
+// Tracing returns middleware that starts a span for every request.
+// If request has W3C traceparent header, the span continues the incoming trace.
+// Span context is put into request context, so spans started by handlers,
+// use cases and storages are nested under the request span.
+// Span has http.method, http.route and http.status_code attributes,
+// responses with 5xx status mark the span as failed.
+//
+// Parameters:
+// - tp: Tracer provider creating spans
+//
+// Returns:
+// - func(http.Handler) http.Handler: Middleware for router registration
+func Tracing(tp trace.TracerProvider) func(http.Handler) http.Handler {
+	tracer := tp.Tracer(tracerName)
+	propagator := propagation.TraceContext{}
+
+	return func(h http.Handler) http.Handler {
+		traceFn := func(w http.ResponseWriter, r *http.Request) {
+			ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := tracer.Start(ctx, requestSpanName,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(attribute.String("http.method", r.Method)),
+			)
+			defer span.End()
+
+			tw := &tracingResponseWriter{ResponseWriter: w, status: http.StatusOK}
+			h.ServeHTTP(tw, r.WithContext(ctx))
+
+			if rctx := chi.RouteContext(ctx); rctx != nil && rctx.RoutePattern() != "" {
+				span.SetAttributes(attribute.String("http.route", rctx.RoutePattern()))
+			}
+			span.SetAttributes(attribute.Int("http.status_code", tw.status))
+
+			if tw.status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(tw.status))
+			}
+		}
+
+		return http.HandlerFunc(traceFn)
+	}
+}
+
+// tracingResponseWriter wraps http.ResponseWriter to capture response status code.
+type tracingResponseWriter struct {
+	http.ResponseWriter     // Embedded original ResponseWriter
+	status              int // HTTP status code
+}
+
+// WriteHeader captures the status code while writing headers.
+func (w *tracingResponseWriter) WriteHeader(statusCode int) {
+	w.status = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Flush sends buffered data to the client if the original ResponseWriter supports it.
+func (w *tracingResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the original ResponseWriter, so http.ResponseController reaches the connection.
+func (w *tracingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
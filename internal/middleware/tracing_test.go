@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		traceparent string
+		route       string
+		status      int
+		spanStatus  codes.Code
+	}{
+		{
+			name:   "when request is handled",
+			path:   "/api/shorturl/abc",
+			route:  "/api/shorturl/{alias}",
+			status: http.StatusOK,
+		},
+		{
+			name:       "when handler fails",
+			path:       "/fail",
+			route:      "/fail",
+			status:     http.StatusInternalServerError,
+			spanStatus: codes.Error,
+		},
+		{
+			name:        "when request continues incoming trace",
+			path:        "/api/shorturl/abc",
+			route:       "/api/shorturl/{alias}",
+			traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			status:      http.StatusOK,
+		},
+		{
+			name:   "when route is not found",
+			path:   "/unknown/path",
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+			var handlerSpan trace.SpanContext
+
+			r := chi.NewRouter()
+			r.Use(Tracing(tp))
+			r.Get("/api/shorturl/{alias}", func(w http.ResponseWriter, r *http.Request) {
+				handlerSpan = trace.SpanContextFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+			r.Get("/fail", func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.traceparent != "" {
+				req.Header.Set("traceparent", tt.traceparent)
+			}
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tt.status, w.Code)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			span := spans[0]
+
+			assert.Equal(t, "http.request", span.Name())
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+			assert.Equal(t, tt.spanStatus, span.Status().Code)
+
+			attrs := make(map[attribute.Key]attribute.Value)
+			for _, attr := range span.Attributes() {
+				attrs[attr.Key] = attr.Value
+			}
+			assert.Equal(t, http.MethodGet, attrs["http.method"].AsString())
+			assert.Equal(t, int64(tt.status), attrs["http.status_code"].AsInt64())
+			if tt.route != "" {
+				assert.Equal(t, tt.route, attrs["http.route"].AsString())
+			} else {
+				assert.NotContains(t, attrs, attribute.Key("http.route"))
+			}
+
+			if tt.traceparent != "" {
+				assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+				assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+				assert.True(t, span.Parent().IsRemote())
+			} else {
+				assert.False(t, span.Parent().IsValid())
+			}
+
+			if handlerSpan.IsValid() {
+				assert.Equal(t, span.SpanContext().SpanID(), handlerSpan.SpanID(), "span context is not passed to handler")
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Package middleware provides HTTP middleware components for client IP verification.
+
+It features:
+- Restriction of access to clients from trusted subnet
+- Client IP detection behind trusted reverse proxy (X-Real-IP, X-Forwarded-For)
+- Fail-closed behavior on misconfiguration
+*/
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// TrustedSubnet returns middleware that allows only requests from clients within cidr subnet.
+// Client IP is taken from the connection remote address. Only requests coming from the
+// trustedProxy subnet take X-Real-IP header, then the first address of X-Forwarded-For
+// header into account, since those headers are set by the client otherwise.
+// Requests from other clients are rejected with 403 Forbidden.
+// Empty cidr disables the check. Invalid cidr or trustedProxy rejects all requests.
+//
+// Parameters:
+// - cidr: Trusted subnet in CIDR notation, e.g. 192.168.0.0/24
+// - trustedProxy: Reverse proxy subnet in CIDR notation, forwarding headers are ignored if empty
+//
+// Returns:
+// - func(http.Handler) http.Handler: Middleware for router registration
+func TrustedSubnet(cidr, trustedProxy string) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		if cidr == "" {
+			return h
+		}
+
+		_, subnet, err := net.ParseCIDR(cidr)
+
+		var proxy *net.IPNet
+		if err == nil && trustedProxy != "" {
+			_, proxy, err = net.ParseCIDR(trustedProxy)
+		}
+
+		checkFn := func(w http.ResponseWriter, r *http.Request) {
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+
+			ip := net.ParseIP(subnetClientIP(r, proxy))
+			if ip == nil || !subnet.Contains(ip) {
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+
+			h.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(checkFn)
+	}
+}
+
+// subnetClientIP returns the client IP address for trusted subnet check.
+// Parameters:
+// - r: HTTP request
+// - proxy: Trusted reverse proxy subnet or nil
+// Returns:
+// - string: ClientIP for requests from trusted proxy, remote address without port otherwise
+func subnetClientIP(r *http.Request, proxy *net.IPNet) string {
+	remote := RemoteIP(r)
+	if proxy == nil {
+		return remote
+	}
+
+	if ip := net.ParseIP(remote); ip == nil || !proxy.Contains(ip) {
+		return remote
+	}
+
+	return ClientIP(r)
+}
+
+// ClientIP returns the client IP address, taking reverse proxy headers into account.
+// Parameters:
+// - r: HTTP request
+// Returns:
+// - string: Value of X-Real-IP header, first address of X-Forwarded-For header or
+// remote address without port
+func ClientIP(r *http.Request) string {
+	if ip := strings.TrimSpace(r.Header.Get("X-Real-IP")); ip != "" {
+		return ip
+	}
+
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		first, _, _ := strings.Cut(forwarded, ",")
+		if ip := strings.TrimSpace(first); ip != "" {
+			return ip
+		}
+	}
+
+	return RemoteIP(r)
+}
@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrustedSubnet(t *testing.T) {
+	tests := []struct {
+		name           string
+		cidr           string
+		proxy          string
+		remoteAddr     string
+		realIP         string
+		forwardedFor   string
+		expectedStatus int
+		expectNext     bool
+	}{
+		{
+			name:           "X-Real-IP is ignored without trusted proxy",
+			cidr:           "192.168.1.0/24",
+			remoteAddr:     "10.0.0.1:1234",
+			realIP:         "192.168.1.10",
+			expectedStatus: http.StatusForbidden,
+			expectNext:     false,
+		},
+		{
+			name:           "X-Forwarded-For is ignored without trusted proxy",
+			cidr:           "192.168.1.0/24",
+			remoteAddr:     "10.0.0.1:1234",
+			forwardedFor:   "192.168.1.10",
+			expectedStatus: http.StatusForbidden,
+			expectNext:     false,
+		},
+		{
+			name:           "X-Real-IP is ignored from untrusted proxy",
+			cidr:           "192.168.1.0/24",
+			proxy:          "10.0.0.0/24",
+			remoteAddr:     "10.0.1.1:1234",
+			realIP:         "192.168.1.10",
+			expectedStatus: http.StatusForbidden,
+			expectNext:     false,
+		},
+		{
+			name:           "X-Real-IP inside subnet from trusted proxy",
+			cidr:           "192.168.1.0/24",
+			proxy:          "10.0.0.0/24",
+			remoteAddr:     "10.0.0.1:1234",
+			realIP:         "192.168.1.10",
+			expectedStatus: http.StatusOK,
+			expectNext:     true,
+		},
+		{
+			name:           "X-Real-IP outside subnet from trusted proxy",
+			cidr:           "192.168.1.0/24",
+			proxy:          "192.168.1.1/32",
+			remoteAddr:     "192.168.1.1:1234",
+			realIP:         "192.168.2.10",
+			expectedStatus: http.StatusForbidden,
+			expectNext:     false,
+		},
+		{
+			name:           "X-Forwarded-For chain with client inside subnet from trusted proxy",
+			cidr:           "192.168.1.0/24",
+			proxy:          "10.0.0.0/24",
+			remoteAddr:     "10.0.0.1:1234",
+			forwardedFor:   "192.168.1.10, 10.0.0.2, 10.0.0.3",
+			expectedStatus: http.StatusOK,
+			expectNext:     true,
+		},
+		{
+			name:           "X-Forwarded-For chain with client outside subnet from trusted proxy",
+			cidr:           "192.168.1.0/24",
+			proxy:          "10.0.0.0/24",
+			remoteAddr:     "10.0.0.1:1234",
+			forwardedFor:   "172.16.0.1, 192.168.1.10",
+			expectedStatus: http.StatusForbidden,
+			expectNext:     false,
+		},
+		{
+			name:           "remote address inside subnet",
+			cidr:           "192.168.1.0/24",
+			remoteAddr:     "192.168.1.20:1234",
+			expectedStatus: http.StatusOK,
+			expectNext:     true,
+		},
+		{
+			name:           "remote address outside subnet",
+			cidr:           "192.168.1.0/24",
+			remoteAddr:     "10.0.0.1:1234",
+			expectedStatus: http.StatusForbidden,
+			expectNext:     false,
+		},
+		{
+			name:           "malformed IP in header",
+			cidr:           "192.168.1.0/24",
+			proxy:          "192.168.1.20/32",
+			remoteAddr:     "192.168.1.20:1234",
+			realIP:         "not-an-ip",
+			expectedStatus: http.StatusForbidden,
+			expectNext:     false,
+		},
+		{
+			name:           "invalid CIDR rejects all requests",
+			cidr:           "192.168.1.0/33",
+			remoteAddr:     "192.168.1.20:1234",
+			expectedStatus: http.StatusForbidden,
+			expectNext:     false,
+		},
+		{
+			name:           "invalid trusted proxy rejects all requests",
+			cidr:           "192.168.1.0/24",
+			proxy:          "10.0.0.0/33",
+			remoteAddr:     "192.168.1.20:1234",
+			expectedStatus: http.StatusForbidden,
+			expectNext:     false,
+		},
+		{
+			name:           "empty CIDR disables check",
+			remoteAddr:     "10.0.0.1:1234",
+			expectedStatus: http.StatusOK,
+			expectNext:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextCalled := false
+			handler := TrustedSubnet(tt.cidr, tt.proxy)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				nextCalled = true
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/internal/stats", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.realIP != "" {
+				req.Header.Set("X-Real-IP", tt.realIP)
+			}
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectNext, nextCalled)
+		})
+	}
+}
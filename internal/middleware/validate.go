@@ -0,0 +1,89 @@
+/*
+Package middleware provides HTTP middleware components for request validation.
+
+It features:
+- Validation of requests against OpenAPI specification
+- Rejection of malformed requests before they reach handlers
+- Pass-through of requests to paths not described by specification
+*/
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	httpErrors "github.com/gururuby/shortener/internal/handler/http/errors"
+)
+
+// validationErrorResponse represents response for requests not matching specification.
+type validationErrorResponse struct {
+	Error      string
+	Code       string
+	StatusCode int
+}
+
+// ValidateRequests returns middleware that validates requests against OpenAPI specification.
+// Requests with invalid parameters or body are rejected with 400 Bad Request.
+// Requests to paths or methods not described by specification are passed through untouched.
+// Authentication is not checked, handlers are responsible for it.
+//
+// Parameters:
+// - doc: OpenAPI specification
+//
+// Returns:
+// - func(http.Handler) http.Handler: Middleware for router registration
+// - error: If routes cannot be built from specification
+func ValidateRequests(doc *openapi3.T) (func(http.Handler) http.Handler, error) {
+	router, err := legacy.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc}
+
+	return func(h http.Handler) http.Handler {
+		validateFn := func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			err = openapi3filter.ValidateRequest(r.Context(), &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			})
+			if err != nil {
+				returnValidationError(w, err)
+				return
+			}
+
+			h.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(validateFn)
+	}, nil
+}
+
+// returnValidationError writes 400 Bad Request response with validation error.
+// Parameters:
+// - w: HTTP response writer
+// - err: Validation error
+func returnValidationError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+
+	resp := validationErrorResponse{
+		Error:      err.Error(),
+		Code:       httpErrors.Code(err, http.StatusBadRequest),
+		StatusCode: http.StatusBadRequest,
+	}
+	if err = json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gururuby/shortener/internal/infra/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRequests(t *testing.T) {
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+
+	validate, err := ValidateRequests(doc)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		contentType    string
+		body           string
+		expectedStatus int
+		expectNext     bool
+	}{
+		{
+			name:           "valid request",
+			method:         http.MethodPost,
+			path:           "/api/shorten",
+			contentType:    "application/json",
+			body:           `{"url":"https://example.com"}`,
+			expectedStatus: http.StatusOK,
+			expectNext:     true,
+		},
+		{
+			name:           "request without required field",
+			method:         http.MethodPost,
+			path:           "/api/shorten",
+			contentType:    "application/json",
+			body:           `{"one_time_use":true}`,
+			expectedStatus: http.StatusBadRequest,
+			expectNext:     false,
+		},
+		{
+			name:           "request with invalid query parameter",
+			method:         http.MethodGet,
+			path:           "/api/user/urls?page=first",
+			expectedStatus: http.StatusBadRequest,
+			expectNext:     false,
+		},
+		{
+			name:           "request to undocumented path",
+			method:         http.MethodGet,
+			path:           "/debug/pprof/",
+			expectedStatus: http.StatusOK,
+			expectNext:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextCalled := false
+			handler := validate(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				nextCalled = true
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectNext, nextCalled)
+			if !tt.expectNext {
+				assert.Contains(t, rec.Body.String(), `"StatusCode":400`)
+				assert.Contains(t, rec.Body.String(), `"Code":"ERR_INVALID_REQUEST"`)
+			}
+		})
+	}
+}
@@ -0,0 +1,410 @@
+/*
+Package client provides a Go client of the shortener HTTP API.
+
+It features:
+- Creation of single and batches of short URLs
+- Resolution of aliases to original URLs
+- Listing and deletion of short URLs of the API key owner
+- Optional per-request timeout and retries of failed requests with exponential backoff
+
+Requests are authenticated by X-API-Key header. The package depends on the standard
+library and pkg/retry only, so it can be imported by services outside the shortener.
+*/
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	clientErrors "github.com/gururuby/shortener/pkg/client/errors"
+	utils "github.com/gururuby/shortener/pkg/retry"
+)
+
+// Available constants
+const (
+	apiKeyHeader    = "X-API-Key"            // Name of the API key header
+	createPath      = "/api/shorten"         // Path for single URL shortening
+	batchPath       = "/api/shorten/batch"   // Path for batch URL shortening
+	userURLsPath    = "/api/user/urls"       // Path for listing and deletion of user URLs
+	userURLsPerPage = 200                    // Page size of listing of user URLs
+	retryBaseDelay  = 100 * time.Millisecond // Delay before the first retry
+	retryMaxDelay   = 2 * time.Second        // Upper bound of delay between retries
+	retryJitter     = 0.2                    // Fraction of delay used as random shift
+)
+
+// Option configures optional capabilities of Client.
+type Option func(*Client)
+
+// WithTimeout limits duration of every request attempt.
+// Parameters:
+// - d: Maximum duration of a request, no limit if not positive
+// Returns:
+// - Option: Client option
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithRetry retries requests failed with clientErrors.ErrClientUnavailable
+// with exponential backoff.
+// Parameters:
+// - maxAttempts: Maximum number of attempts of a request, no retries if below 2
+// Returns:
+// - Option: Client option
+func WithRetry(maxAttempts int) Option {
+	return func(c *Client) {
+		c.maxAttempts = max(maxAttempts, 1)
+	}
+}
+
+// WithHTTPClient sets HTTP client sending requests.
+// Parameters:
+// - hc: HTTP client, http.DefaultClient is used if nil
+// Returns:
+// - Option: Client option
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		if hc != nil {
+			c.httpClient = hc
+		}
+	}
+}
+
+// Client sends requests to the shortener HTTP API.
+type Client struct {
+	httpClient  *http.Client  // HTTP client sending requests
+	baseURL     string        // Address of the shortener without trailing slash
+	apiKey      string        // API key of the user, empty for anonymous requests
+	timeout     time.Duration // Maximum duration of a request attempt, not positive for no limit
+	maxAttempts int           // Maximum number of attempts of a request
+}
+
+// ShortURL represents the result of URL shortening.
+type ShortURL struct {
+	URL           string // Short URL
+	AlreadyExists bool   // Whether the URL was shortened before and URL is the existing short URL
+}
+
+// BatchInput represents a URL to shorten in a batch.
+type BatchInput struct {
+	CorrelationID string `json:"correlation_id"` // Client-provided ID for matching inputs to outputs
+	OriginalURL   string `json:"original_url"`   // URL to be shortened
+}
+
+// BatchOutput represents the result of shortening of a URL in a batch.
+type BatchOutput struct {
+	CorrelationID string `json:"correlation_id"`  // Correlation ID of the input
+	ShortURL      string `json:"short_url"`       // Short URL, empty on failure
+	Error         string `json:"error,omitempty"` // Reason the URL was not shortened
+}
+
+// UserURL represents a short URL of the API key owner.
+type UserURL struct {
+	ShortURL    string `json:"short_url"`    // The shortened URL
+	OriginalURL string `json:"original_url"` // The original long URL
+}
+
+// errorResponse represents an error response of the API.
+type errorResponse struct {
+	Error string
+}
+
+// NewClient creates a new shortener API client.
+// Parameters:
+// - baseURL: Address of the shortener, e.g. http://localhost:8080
+// - apiKey: API key of the user, empty for anonymous requests
+// - opts: WithTimeout, WithRetry, WithHTTPClient options
+// Returns:
+// - *Client: Initialized client
+func NewClient(baseURL, apiKey string, opts ...Option) *Client {
+	c := &Client{
+		httpClient:  http.DefaultClient,
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		apiKey:      apiKey,
+		maxAttempts: 1,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Create shortens the URL.
+// Shortening of an already shortened URL is not an error, the existing short URL
+// is returned with AlreadyExists set.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - originalURL: URL to shorten
+// Returns:
+// - ShortURL: Created or existing short URL
+// - error: Error of the request, see package errors
+func (c *Client) Create(ctx context.Context, originalURL string) (ShortURL, error) {
+	var res struct {
+		Result string
+	}
+
+	body, err := json.Marshal(struct{ URL string }{URL: originalURL})
+	if err != nil {
+		return ShortURL{}, err
+	}
+
+	status, err := c.doJSON(ctx, http.MethodPost, createPath, body, &res, http.StatusCreated, http.StatusConflict)
+	if err != nil {
+		return ShortURL{}, err
+	}
+
+	return ShortURL{URL: res.Result, AlreadyExists: status == http.StatusConflict}, nil
+}
+
+// CreateBatch shortens several URLs at once.
+// URLs which cannot be shortened are reported by Error of their outputs.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - inputs: URLs to shorten with correlation IDs
+// Returns:
+// - []BatchOutput: Short URLs in order of inputs
+// - error: Error of the request, see package errors
+func (c *Client) CreateBatch(ctx context.Context, inputs []BatchInput) ([]BatchOutput, error) {
+	var res []BatchOutput
+
+	body, err := json.Marshal(inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = c.doJSON(ctx, http.MethodPost, batchPath, body, &res, http.StatusCreated); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Find resolves the alias to the original URL without following the redirect.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - alias: Alias of the short URL
+// Returns:
+// - string: Original URL
+// - error: clientErrors.ErrClientNotFound if the alias doesn't exist, other request errors
+func (c *Client) Find(ctx context.Context, alias string) (string, error) {
+	// Redirect is the result, so it must not be followed
+	hc := *c.httpClient
+	hc.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	var location string
+	err := c.retry(ctx, func(ctx context.Context) error {
+		resp, err := c.send(ctx, &hc, http.MethodGet, "/"+url.PathEscape(alias), nil)
+		if err != nil {
+			return err
+		}
+		defer closeBody(resp)
+
+		switch resp.StatusCode {
+		case http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+			location = resp.Header.Get("Location")
+			return nil
+		default:
+			return statusErr(resp)
+		}
+	})
+
+	return location, err
+}
+
+// Delete deletes short URLs of the API key owner.
+// Deletion is asynchronous, short URLs may keep redirecting for a while.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - aliases: Aliases of short URLs to delete
+// Returns:
+// - error: Error of the request, see package errors
+func (c *Client) Delete(ctx context.Context, aliases []string) error {
+	body, err := json.Marshal(aliases)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doJSON(ctx, http.MethodDelete, userURLsPath, body, nil, http.StatusAccepted)
+	return err
+}
+
+// GetUserURLs returns all short URLs of the API key owner, requesting them page by page.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// Returns:
+// - []UserURL: Short URLs of the user, empty if there are none
+// - error: Error of the request, see package errors
+func (c *Client) GetUserURLs(ctx context.Context) ([]UserURL, error) {
+	var urls []UserURL
+
+	for page := 1; ; page++ {
+		var res struct {
+			Items      []UserURL `json:"items"`
+			TotalPages int       `json:"total_pages"`
+		}
+
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(page))
+		query.Set("per_page", strconv.Itoa(userURLsPerPage))
+
+		status, err := c.doJSON(ctx, http.MethodGet, userURLsPath+"?"+query.Encode(), nil, &res, http.StatusOK, http.StatusNoContent)
+		if err != nil {
+			return nil, err
+		}
+		if status == http.StatusNoContent {
+			return urls, nil
+		}
+
+		urls = append(urls, res.Items...)
+		if page >= res.TotalPages {
+			return urls, nil
+		}
+	}
+}
+
+// doJSON sends the request and decodes JSON response.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - method: HTTP method
+// - path: Path with query of the request
+// - body: JSON request body, nil for requests without body
+// - res: Destination of decoded response, nil to skip decoding
+// - expected: Status codes of successful responses, response bodies of other codes are not decoded
+// Returns:
+// - int: Status code of the successful response
+// - error: Error of the request, see package errors
+func (c *Client) doJSON(ctx context.Context, method, path string, body []byte, res any, expected ...int) (int, error) {
+	var status int
+
+	err := c.retry(ctx, func(ctx context.Context) error {
+		resp, err := c.send(ctx, c.httpClient, method, path, body)
+		if err != nil {
+			return err
+		}
+		defer closeBody(resp)
+
+		if !slices.Contains(expected, resp.StatusCode) {
+			return statusErr(resp)
+		}
+
+		status = resp.StatusCode
+		if res == nil || status == http.StatusNoContent {
+			return nil
+		}
+
+		if err = json.NewDecoder(resp.Body).Decode(res); err != nil {
+			return fmt.Errorf("%w: %w", clientErrors.ErrClientUnexpectedResponse, err)
+		}
+		return nil
+	})
+
+	return status, err
+}
+
+// retry calls fn up to maxAttempts times while it fails with clientErrors.ErrClientUnavailable.
+// Every call gets its own context limited by timeout.
+// Parameters:
+// - ctx: Context for cancellation
+// - fn: Request attempt
+// Returns:
+// - error: nil on success, error of the last attempt otherwise
+func (c *Client) retry(ctx context.Context, fn func(ctx context.Context) error) error {
+	attempt := func() error {
+		attemptCtx := ctx
+		if c.timeout > 0 {
+			var cancel context.CancelFunc
+			attemptCtx, cancel = context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+		}
+		return fn(attemptCtx)
+	}
+
+	return utils.ExponentialBackoff(attempt, c.maxAttempts, retryBaseDelay, retryMaxDelay, retryJitter,
+		utils.WithContext(ctx),
+		utils.RetryOn(clientErrors.ErrClientUnavailable),
+	)
+}
+
+// send sends the request authenticated with API key.
+// Parameters:
+// - ctx: Context of the request
+// - hc: HTTP client sending the request
+// - method: HTTP method
+// - path: Path with query of the request
+// - body: JSON request body, nil for requests without body
+// Returns:
+// - *http.Response: Response, its body must be closed
+// - error: clientErrors.ErrClientUnavailable if the request cannot be sent
+func (c *Client) send(ctx context.Context, hc *http.Client, method, path string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set(apiKeyHeader, c.apiKey)
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", clientErrors.ErrClientUnavailable, err)
+	}
+	return resp, nil
+}
+
+// statusErr converts the unsuccessful response to error.
+// Parameters:
+// - resp: Response with unexpected status code
+// Returns:
+// - error: Error of package errors matching the status code with message of the response
+func statusErr(resp *http.Response) error {
+	var sentinel error
+
+	switch {
+	case resp.StatusCode >= http.StatusInternalServerError:
+		sentinel = clientErrors.ErrClientUnavailable
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+		sentinel = clientErrors.ErrClientUnauthorized
+	case resp.StatusCode == http.StatusNotFound, resp.StatusCode == http.StatusGone:
+		sentinel = clientErrors.ErrClientNotFound
+	case resp.StatusCode >= http.StatusBadRequest:
+		sentinel = clientErrors.ErrClientBadRequest
+	default:
+		sentinel = clientErrors.ErrClientUnexpectedResponse
+	}
+
+	var errRes errorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&errRes); err == nil && errRes.Error != "" {
+		return fmt.Errorf("%w: %d %s", sentinel, resp.StatusCode, errRes.Error)
+	}
+	return fmt.Errorf("%w: %d", sentinel, resp.StatusCode)
+}
+
+// closeBody drains and closes the response body, so the connection can be reused.
+func closeBody(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,289 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	clientErrors "github.com/gururuby/shortener/pkg/client/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stub returns a server checking the request and responding with status and body.
+func stub(t *testing.T, method, path, reqBody string, status int, respBody string) *httptest.Server {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, method, r.Method)
+		assert.Equal(t, path, r.URL.RequestURI())
+		assert.Equal(t, "secret", r.Header.Get(apiKeyHeader))
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		if reqBody != "" {
+			assert.JSONEq(t, reqBody, string(body))
+		}
+
+		if status == http.StatusTemporaryRedirect {
+			w.Header().Set("Location", respBody)
+			respBody = ""
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(respBody))
+	}))
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func Test_Create(t *testing.T) {
+	tests := []struct {
+		err    error
+		name   string
+		body   string
+		want   ShortURL
+		status int
+	}{
+		{
+			name:   "when URL is shortened",
+			status: http.StatusCreated,
+			body:   `{"Result":"http://localhost:8080/abc"}`,
+			want:   ShortURL{URL: "http://localhost:8080/abc"},
+		},
+		{
+			name:   "when URL is already shortened",
+			status: http.StatusConflict,
+			body:   `{"Result":"http://localhost:8080/abc"}`,
+			want:   ShortURL{URL: "http://localhost:8080/abc", AlreadyExists: true},
+		},
+		{
+			name:   "when API key is invalid",
+			status: http.StatusUnauthorized,
+			body:   `{"Error":"invalid API key","Code":"ERR_UNAUTHORIZED","StatusCode":401}`,
+			err:    clientErrors.ErrClientUnauthorized,
+		},
+		{
+			name:   "when URL is invalid",
+			status: http.StatusUnprocessableEntity,
+			body:   `{"Error":"invalid URL","Code":"ERR_INVALID_URL","StatusCode":422}`,
+			err:    clientErrors.ErrClientBadRequest,
+		},
+		{
+			name:   "when response is invalid",
+			status: http.StatusCreated,
+			body:   `not json`,
+			err:    clientErrors.ErrClientUnexpectedResponse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := stub(t, http.MethodPost, createPath, `{"URL":"https://ya.ru"}`, tt.status, tt.body)
+
+			res, err := NewClient(ts.URL, "secret").Create(context.Background(), "https://ya.ru")
+			require.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func Test_CreateBatch(t *testing.T) {
+	ts := stub(t, http.MethodPost, batchPath,
+		`[{"correlation_id":"1","original_url":"https://ya.ru"},{"correlation_id":"2","original_url":"ya"}]`,
+		http.StatusCreated,
+		`[{"correlation_id":"1","short_url":"http://localhost:8080/abc"},{"correlation_id":"2","short_url":"","error":"invalid URL"}]`,
+	)
+
+	res, err := NewClient(ts.URL, "secret").CreateBatch(context.Background(), []BatchInput{
+		{CorrelationID: "1", OriginalURL: "https://ya.ru"},
+		{CorrelationID: "2", OriginalURL: "ya"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []BatchOutput{
+		{CorrelationID: "1", ShortURL: "http://localhost:8080/abc"},
+		{CorrelationID: "2", Error: "invalid URL"},
+	}, res)
+}
+
+func Test_Find(t *testing.T) {
+	tests := []struct {
+		err    error
+		name   string
+		body   string
+		want   string
+		status int
+	}{
+		{
+			name:   "when alias exists",
+			status: http.StatusTemporaryRedirect,
+			body:   "https://ya.ru",
+			want:   "https://ya.ru",
+		},
+		{
+			name:   "when alias doesn't exist",
+			status: http.StatusNotFound,
+			body:   `{"Error":"short URL is not found","Code":"ERR_NOT_FOUND","StatusCode":404}`,
+			err:    clientErrors.ErrClientNotFound,
+		},
+		{
+			name:   "when short URL is deleted",
+			status: http.StatusGone,
+			err:    clientErrors.ErrClientNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := stub(t, http.MethodGet, "/abc", "", tt.status, tt.body)
+
+			res, err := NewClient(ts.URL, "secret").Find(context.Background(), "abc")
+			require.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func Test_Delete(t *testing.T) {
+	tests := []struct {
+		err    error
+		name   string
+		status int
+	}{
+		{
+			name:   "when URLs are deleted",
+			status: http.StatusAccepted,
+		},
+		{
+			name:   "when user is deactivated",
+			status: http.StatusForbidden,
+			err:    clientErrors.ErrClientUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := stub(t, http.MethodDelete, userURLsPath, `["abc","def"]`, tt.status, "")
+
+			err := NewClient(ts.URL, "secret").Delete(context.Background(), []string{"abc", "def"})
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func Test_GetUserURLs(t *testing.T) {
+	t.Run("when user has several pages of URLs", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, userURLsPath, r.URL.Path)
+			assert.Equal(t, "200", r.URL.Query().Get("per_page"))
+
+			page := r.URL.Query().Get("page")
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+				"items":       []UserURL{{ShortURL: "http://localhost:8080/" + page, OriginalURL: "https://ya.ru/" + page}},
+				"page":        page,
+				"total_pages": 2,
+			}))
+		}))
+		defer ts.Close()
+
+		res, err := NewClient(ts.URL, "secret").GetUserURLs(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []UserURL{
+			{ShortURL: "http://localhost:8080/1", OriginalURL: "https://ya.ru/1"},
+			{ShortURL: "http://localhost:8080/2", OriginalURL: "https://ya.ru/2"},
+		}, res)
+	})
+
+	t.Run("when user has no URLs", func(t *testing.T) {
+		ts := stub(t, http.MethodGet, userURLsPath+"?page=1&per_page=200", "", http.StatusNoContent, "")
+
+		res, err := NewClient(ts.URL, "secret").GetUserURLs(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+}
+
+func Test_Retry(t *testing.T) {
+	tests := []struct {
+		err      error
+		name     string
+		failWith int
+		failures int32
+		attempts int
+		calls    int32
+	}{
+		{
+			name:     "when request succeeds after retries",
+			failWith: http.StatusServiceUnavailable,
+			failures: 2,
+			attempts: 3,
+			calls:    3,
+		},
+		{
+			name:     "when attempts are exhausted",
+			failWith: http.StatusInternalServerError,
+			failures: 3,
+			attempts: 2,
+			calls:    2,
+			err:      clientErrors.ErrClientUnavailable,
+		},
+		{
+			name:     "when retries are disabled",
+			failWith: http.StatusBadGateway,
+			failures: 1,
+			calls:    1,
+			err:      clientErrors.ErrClientUnavailable,
+		},
+		{
+			name:     "when request is rejected",
+			failWith: http.StatusBadRequest,
+			failures: 1,
+			attempts: 3,
+			calls:    1,
+			err:      clientErrors.ErrClientBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if calls.Add(1) <= tt.failures {
+					w.WriteHeader(tt.failWith)
+					return
+				}
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer ts.Close()
+
+			err := NewClient(ts.URL, "secret", WithRetry(tt.attempts)).Delete(context.Background(), []string{"abc"})
+			require.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.calls, calls.Load())
+		})
+	}
+}
+
+func Test_WithTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	err := NewClient(ts.URL, "secret", WithTimeout(10*time.Millisecond)).Delete(context.Background(), []string{"abc"})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func Test_WithHTTPClient(t *testing.T) {
+	ts := stub(t, http.MethodGet, "/abc", "", http.StatusTemporaryRedirect, "https://ya.ru")
+
+	hc := &http.Client{}
+	res, err := NewClient(ts.URL+"/", "secret", WithHTTPClient(hc)).Find(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "https://ya.ru", res)
+	assert.Nil(t, hc.CheckRedirect)
+}
@@ -0,0 +1,46 @@
+// Package errors defines error conditions for the shortener API client.
+package errors
+
+import "errors"
+
+// Errors list
+var (
+	// ErrClientUnavailable indicates that the shortener cannot serve the request at the moment.
+	//
+	// This error occurs when:
+	// - The request cannot be sent or the response cannot be read
+	// - The server responds with 5xx status code
+	//
+	// Handling recommendation:
+	// Retry later, requests failed with this error are retried if WithRetry is set.
+	ErrClientUnavailable = errors.New("shortener is unavailable")
+
+	// ErrClientUnauthorized indicates that the request is rejected for the API key.
+	//
+	// This error occurs when:
+	// - The API key is invalid or revoked
+	// - The owner of the API key is deactivated
+	ErrClientUnauthorized = errors.New("shortener rejected the API key")
+
+	// ErrClientNotFound indicates that the short URL doesn't exist.
+	//
+	// This error occurs when:
+	// - The alias was never created
+	// - The short URL is deleted or expired
+	ErrClientNotFound = errors.New("short URL is not found")
+
+	// ErrClientBadRequest indicates that the shortener rejected the request as invalid.
+	//
+	// This error occurs when:
+	// - The URL is invalid, unsafe or its domain is not allowed
+	// - The list of URLs or aliases is empty
+	// - The quota of short URLs is exceeded
+	ErrClientBadRequest = errors.New("shortener rejected the request")
+
+	// ErrClientUnexpectedResponse indicates that the response cannot be interpreted.
+	//
+	// This error occurs when:
+	// - The response has unexpected status code
+	// - The response body is not valid JSON of expected structure
+	ErrClientUnexpectedResponse = errors.New("unexpected response of shortener")
+)
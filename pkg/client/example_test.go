@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+func ExampleClient() {
+	// Stub of the shortener keeping a single short URL
+	urls := map[string]string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/shorten":
+			var req struct{ URL string }
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			status := http.StatusCreated
+			if _, ok := urls["abc"]; ok {
+				status = http.StatusConflict
+			}
+			urls["abc"] = req.URL
+			w.WriteHeader(status)
+			_, _ = fmt.Fprintf(w, `{"Result":"http://%s/abc"}`, r.Host)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/user/urls":
+			var aliases []string
+			_ = json.NewDecoder(r.Body).Decode(&aliases)
+			for _, alias := range aliases {
+				delete(urls, alias)
+			}
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodGet:
+			if url, ok := urls[strings.TrimPrefix(r.URL.Path, "/")]; ok {
+				http.Redirect(w, r, url, http.StatusTemporaryRedirect)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	c := NewClient(ts.URL, "api-key", WithRetry(3))
+
+	shortURL, _ := c.Create(ctx, "https://ya.ru")
+	fmt.Println(strings.TrimPrefix(shortURL.URL, ts.URL), shortURL.AlreadyExists)
+
+	shortURL, _ = c.Create(ctx, "https://ya.ru")
+	fmt.Println(strings.TrimPrefix(shortURL.URL, ts.URL), shortURL.AlreadyExists)
+
+	originalURL, _ := c.Find(ctx, "abc")
+	fmt.Println(originalURL)
+
+	_ = c.Delete(ctx, []string{"abc"})
+
+	_, err := c.Find(ctx, "abc")
+	fmt.Println(err)
+
+	// Output:
+	// /abc false
+	// /abc true
+	// https://ya.ru
+	// short URL is not found: 404
+}
@@ -0,0 +1,19 @@
+// Package errors defines error conditions for domain filtering of URLs.
+package errors
+
+import "errors"
+
+// Errors list
+var (
+	// ErrDomainBlacklisted indicates the URL host matches a blacklisted domain.
+	//
+	// Note: Blacklist takes precedence over whitelist
+	ErrDomainBlacklisted = errors.New("domain is blacklisted")
+
+	// ErrDomainNotWhitelisted indicates the whitelist is configured
+	// and the URL host matches none of its domains.
+	ErrDomainNotWhitelisted = errors.New("domain is not whitelisted")
+
+	// ErrDomainInvalidURL indicates the URL cannot be parsed or has no host.
+	ErrDomainInvalidURL = errors.New("cannot filter URL without host")
+)
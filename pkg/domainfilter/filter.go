@@ -0,0 +1,135 @@
+/*
+Package domainfilter provides filtering of URLs by their domain.
+
+Domains are matched case-insensitively. A pattern is either an exact domain,
+e.g. `example.com`, or a wildcard matching any subdomain, e.g. `*.example.com`
+matches `a.example.com` and `a.b.example.com`, but not `example.com` itself.
+*/
+package domainfilter
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/gururuby/shortener/internal/config"
+	"github.com/gururuby/shortener/pkg/domainfilter/errors"
+)
+
+// wildcardPrefix marks patterns matching any subdomain.
+const wildcardPrefix = "*."
+
+// Filter allows or rejects URLs by the domain of their host.
+// When both lists are empty, all domains are allowed.
+// When both lists match the domain, blacklist wins.
+// Lists of a filter in use must be changed by Update only.
+type Filter struct {
+	Blacklist []string     // Patterns of rejected domains
+	Whitelist []string     // Patterns of allowed domains, any domain is allowed if empty
+	mu        sync.RWMutex // Guards lists against concurrent Update
+}
+
+// New creates a filter from comma-separated lists of domain patterns.
+// Blank items are skipped and patterns are lowercased.
+// Parameters:
+// - blacklist: Comma-separated patterns of rejected domains
+// - whitelist: Comma-separated patterns of allowed domains
+// Returns:
+// - *Filter: Initialized filter
+func New(blacklist, whitelist string) *Filter {
+	return &Filter{
+		Blacklist: splitPatterns(blacklist),
+		Whitelist: splitPatterns(whitelist),
+	}
+}
+
+// Allow checks whether the URL domain is permitted.
+// Parameters:
+// - rawURL: Absolute URL to check
+// Returns:
+// - error: errors.ErrDomainBlacklisted, errors.ErrDomainNotWhitelisted,
+// errors.ErrDomainInvalidURL or nil if the domain is permitted
+func (f *Filter) Allow(rawURL string) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if len(f.Blacklist) == 0 && len(f.Whitelist) == 0 {
+		return nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return errors.ErrDomainInvalidURL
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+
+	if matchAny(host, f.Blacklist) {
+		return errors.ErrDomainBlacklisted
+	}
+
+	if len(f.Whitelist) > 0 && !matchAny(host, f.Whitelist) {
+		return errors.ErrDomainNotWhitelisted
+	}
+
+	return nil
+}
+
+// Update replaces lists of the filter, e.g. when configuration is reloaded.
+// It is safe to call concurrently with Allow.
+// Parameters:
+// - blacklist: Comma-separated patterns of rejected domains
+// - whitelist: Comma-separated patterns of allowed domains
+func (f *Filter) Update(blacklist, whitelist string) {
+	blacklistPatterns, whitelistPatterns := splitPatterns(blacklist), splitPatterns(whitelist)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Blacklist, f.Whitelist = blacklistPatterns, whitelistPatterns
+}
+
+// Reload applies domain lists of the reloaded configuration.
+// Parameters:
+// - cfg: Reloaded configuration
+// Returns:
+// - error: Always nil
+func (f *Filter) Reload(cfg *config.Config) error {
+	f.Update(cfg.App.DomainBlacklist, cfg.App.DomainWhitelist)
+	return nil
+}
+
+// matchAny reports whether host matches any of the patterns.
+// Parameters:
+// - host: Lowercased host without port
+// - patterns: Exact or wildcard domain patterns
+// Returns:
+// - bool: true if any pattern matches
+func matchAny(host string, patterns []string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if domain, ok := strings.CutPrefix(pattern, wildcardPrefix); ok {
+			if strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+			continue
+		}
+		if host == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// splitPatterns splits comma-separated list of patterns skipping blank items.
+// Parameters:
+// - list: Comma-separated patterns
+// Returns:
+// - []string: Trimmed lowercased patterns
+func splitPatterns(list string) []string {
+	var patterns []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			patterns = append(patterns, item)
+		}
+	}
+	return patterns
+}
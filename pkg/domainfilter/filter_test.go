@@ -0,0 +1,120 @@
+package domainfilter
+
+import (
+	"testing"
+
+	"github.com/gururuby/shortener/internal/config"
+	"github.com/gururuby/shortener/pkg/domainfilter/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Filter_Allow(t *testing.T) {
+	tests := []struct {
+		err       error
+		name      string
+		blacklist string
+		whitelist string
+		url       string
+	}{
+		{
+			name: "when both lists are empty",
+			url:  "https://evil.com/path",
+		},
+		{
+			name:      "when domain is blacklisted exactly",
+			blacklist: "evil.com",
+			url:       "https://evil.com/path",
+			err:       errors.ErrDomainBlacklisted,
+		},
+		{
+			name:      "when domain is blacklisted in other case",
+			blacklist: "Evil.COM",
+			url:       "https://EVIL.com:8080/path",
+			err:       errors.ErrDomainBlacklisted,
+		},
+		{
+			name:      "when subdomain is not blacklisted by exact pattern",
+			blacklist: "evil.com",
+			url:       "https://www.evil.com",
+		},
+		{
+			name:      "when subdomain is blacklisted by wildcard",
+			blacklist: "*.evil.com",
+			url:       "https://a.b.evil.com",
+			err:       errors.ErrDomainBlacklisted,
+		},
+		{
+			name:      "when parent domain is not matched by wildcard",
+			blacklist: "*.evil.com",
+			url:       "https://evil.com",
+		},
+		{
+			name:      "when domain only ends with blacklisted one",
+			blacklist: "*.evil.com",
+			url:       "https://notevil.com",
+		},
+		{
+			name:      "when domain is whitelisted",
+			whitelist: "example.com, *.example.org",
+			url:       "https://docs.example.org",
+		},
+		{
+			name:      "when domain is not whitelisted",
+			whitelist: "example.com",
+			url:       "https://ya.ru",
+			err:       errors.ErrDomainNotWhitelisted,
+		},
+		{
+			name:      "when domain is both whitelisted and blacklisted",
+			blacklist: "*.example.com",
+			whitelist: "*.example.com",
+			url:       "https://internal.example.com",
+			err:       errors.ErrDomainBlacklisted,
+		},
+		{
+			name:      "when URL has no host",
+			blacklist: "evil.com",
+			url:       "/path",
+			err:       errors.ErrDomainInvalidURL,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := New(tt.blacklist, tt.whitelist).Allow(tt.url)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_New(t *testing.T) {
+	f := New(" Evil.com, ,*.Bad.org ", "")
+	assert.Equal(t, []string{"evil.com", "*.bad.org"}, f.Blacklist)
+	assert.Empty(t, f.Whitelist)
+}
+
+func Test_Filter_Update(t *testing.T) {
+	f := New("evil.com", "")
+	require.ErrorIs(t, f.Allow("https://evil.com"), errors.ErrDomainBlacklisted)
+
+	f.Update("", " Good.com ")
+
+	require.NoError(t, f.Allow("https://good.com"))
+	require.ErrorIs(t, f.Allow("https://evil.com"), errors.ErrDomainNotWhitelisted)
+	assert.Empty(t, f.Blacklist)
+	assert.Equal(t, []string{"good.com"}, f.Whitelist)
+}
+
+func Test_Filter_Reload(t *testing.T) {
+	f := New("", "")
+
+	require.NoError(t, f.Reload(&config.Config{App: config.App{DomainBlacklist: "evil.com", DomainWhitelist: "good.com"}}))
+
+	assert.Equal(t, []string{"evil.com"}, f.Blacklist)
+	assert.Equal(t, []string{"good.com"}, f.Whitelist)
+}
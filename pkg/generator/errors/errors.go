@@ -5,11 +5,11 @@ import "errors"
 
 // Errors list
 var (
-	// ErrGeneratorEmptyAliasLength indicates an invalid configuration where
-	// the requested alias length is zero or unset.
+	// ErrGeneratorInvalidLength indicates an invalid configuration where
+	// the requested alias length is zero, negative or unset.
 	//
 	// This error occurs when:
-	// - The alias generation configuration specifies length = 0
+	// - The alias generation configuration specifies length < 1
 	// - No default length is provided
 	// - Configuration loading fails to set this value
 	//
@@ -20,5 +20,21 @@ var (
 	//
 	// Example valid configuration:
 	//   alias_length: 7  # Must be positive integer
-	ErrGeneratorEmptyAliasLength = errors.New("alias length is zero, please configure correct value")
+	ErrGeneratorInvalidLength = errors.New("alias length must be positive, please configure correct value")
+
+	// ErrGeneratorEmptyAlphabet indicates that aliases cannot be generated
+	// because the generator has no characters to pick from.
+	ErrGeneratorEmptyAlphabet = errors.New("alias alphabet is empty, please configure correct value")
+
+	// ErrGeneratorInvalidAlphabet indicates that the configured alphabet
+	// is shorter than MinAlphabetLength or contains duplicate characters.
+	ErrGeneratorInvalidAlphabet = errors.New("alias alphabet must contain at least 10 unique characters")
+
+	// ErrGeneratorEmptyWordList indicates that word-based aliases cannot be
+	// generated because the generator has no words to pick from.
+	ErrGeneratorEmptyWordList = errors.New("alias word list is empty, please configure correct value")
+
+	// ErrGeneratorUnknownStrategy indicates that the configured alias generation
+	// strategy is not one of random, word or sequential.
+	ErrGeneratorUnknownStrategy = errors.New("unknown alias strategy, please use random, word or sequential")
 )
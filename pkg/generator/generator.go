@@ -2,73 +2,162 @@
 Package generator provides utilities for generating unique identifiers.
 
 It includes:
-- UUID generation using google/uuid
-- Custom alias generation with configurable length
-- Random string generation with alphanumeric characters
+- UUID generation using google/uuid, random (v4) or time-ordered (v7)
+- Custom alias generation with configurable length and alphabet
+- Alias generation strategies: random, word-based and sequential
+- Predefined alphanumeric, URL-safe and numeric alphabets
 - Error handling for invalid configurations
 */
 package generator
 
 import (
-	"math/rand"
-	"time"
+	"math/rand/v2"
 
 	"github.com/google/uuid"
 	"github.com/gururuby/shortener/pkg/generator/errors"
 )
 
+// Predefined alphabets for alias generation.
+const (
+	// DefaultAlphabet contains latin letters and digits
+	DefaultAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ" +
+		"abcdefghijklmnopqrstuvwxyz" +
+		"0123456789"
+	// URLSafeAlphabet contains latin letters, digits, underscore and hyphen
+	URLSafeAlphabet = DefaultAlphabet + "_-"
+	// NumericAlphabet contains digits only
+	NumericAlphabet = "0123456789"
+	// MinAlphabetLength is the smallest allowed alphabet size
+	MinAlphabetLength = 10
+)
+
 // Generator provides methods for generating unique identifiers.
 // It can produce both UUIDs and custom aliases of specified length.
 type Generator struct {
-	aliasLength int // Length of generated aliases
+	strategy        AliasStrategy // Strategy producing aliases, random characters of alphabet if nil
+	alphabet        []rune        // Characters used in generated aliases
+	aliasLength     int           // Length of generated aliases
+	timeOrderedUUID bool          // Generate UUID v7 instead of UUID v4
 }
 
-// New creates a new Generator instance with the specified alias length.
+// New creates a new Generator instance with the specified alias length
+// and DefaultAlphabet.
 // Parameters:
 // - aliasLength: Desired length for generated aliases (must be positive)
 // Returns:
 // - *Generator: Initialized generator instance
 func New(aliasLength int) *Generator {
 	return &Generator{
+		alphabet:    []rune(DefaultAlphabet),
+		aliasLength: aliasLength,
+	}
+}
+
+// NewWithAlphabet creates a new Generator instance with custom alphabet.
+// Parameters:
+// - aliasLength: Desired length for generated aliases (must be positive)
+// - alphabet: Characters used in aliases, at least MinAlphabetLength unique ones
+// Returns:
+// - *Generator: Initialized generator instance
+// - error: errors.ErrGeneratorInvalidAlphabet if alphabet is too short or has duplicates
+func NewWithAlphabet(aliasLength int, alphabet string) (*Generator, error) {
+	chars := []rune(alphabet)
+
+	if len(chars) < MinAlphabetLength {
+		return nil, errors.ErrGeneratorInvalidAlphabet
+	}
+
+	seen := make(map[rune]struct{}, len(chars))
+	for _, c := range chars {
+		if _, ok := seen[c]; ok {
+			return nil, errors.ErrGeneratorInvalidAlphabet
+		}
+		seen[c] = struct{}{}
+	}
+
+	return &Generator{
+		alphabet:    chars,
+		aliasLength: aliasLength,
+	}, nil
+}
+
+// NewWithStrategy creates a new Generator instance producing aliases with the strategy.
+// Parameters:
+// - strategy: Strategy producing aliases
+// Returns:
+// - *Generator: Initialized generator instance
+func NewWithStrategy(strategy AliasStrategy) *Generator {
+	return &Generator{strategy: strategy}
+}
+
+// NewURLSafe creates a new Generator instance using URLSafeAlphabet.
+// Parameters:
+// - aliasLength: Desired length for generated aliases (must be positive)
+// Returns:
+// - *Generator: Initialized generator instance
+func NewURLSafe(aliasLength int) *Generator {
+	return &Generator{
+		alphabet:    []rune(URLSafeAlphabet),
+		aliasLength: aliasLength,
+	}
+}
+
+// NewNumeric creates a new Generator instance using NumericAlphabet.
+// Parameters:
+// - aliasLength: Desired length for generated aliases (must be positive)
+// Returns:
+// - *Generator: Initialized generator instance
+func NewNumeric(aliasLength int) *Generator {
+	return &Generator{
+		alphabet:    []rune(NumericAlphabet),
 		aliasLength: aliasLength,
 	}
 }
 
-// Alias generates a random alphanumeric string of the configured length.
+// Alias generates an alias with the strategy of the generator,
+// or a random string of the configured length and alphabet if it has none.
 // Returns:
 // - string: Generated alias
-// - error: errors.ErrGeneratorEmptyAliasLength if length is invalid
+// - error: errors.ErrGeneratorInvalidLength if length is invalid,
+// errors.ErrGeneratorEmptyAlphabet if alphabet is empty, or error of the strategy
 func (g *Generator) Alias() (string, error) {
-	return generateAlias(g.aliasLength)
+	if g.strategy != nil {
+		return g.strategy.Generate()
+	}
+	return generateAlias(g.aliasLength, g.alphabet)
 }
 
-// UUID generates a universally unique identifier (UUID v4).
+// UUID generates a universally unique identifier, UUID v7 if the generator
+// was created by NewWithUUIDv7 and UUID v4 otherwise.
 // Returns:
 // - string: Generated UUID in string format
 func (g *Generator) UUID() string {
+	if g.timeOrderedUUID {
+		return UUIDv7()
+	}
 	return uuid.NewString()
 }
 
-// generateAlias creates a random alphanumeric string of specified length.
+// generateAlias creates a random string of specified length.
 // Parameters:
 // - length: Desired length of the alias
+// - alphabet: Characters to pick from
 // Returns:
 // - string: Generated alias
-// - error: errors.ErrGeneratorEmptyAliasLength if length is invalid
-func generateAlias(length int) (string, error) {
+// - error: errors.ErrGeneratorInvalidLength if length is invalid,
+// errors.ErrGeneratorEmptyAlphabet if alphabet is empty
+func generateAlias(length int, alphabet []rune) (string, error) {
 	if length < 1 {
-		return "", errors.ErrGeneratorEmptyAliasLength
+		return "", errors.ErrGeneratorInvalidLength
 	}
 
-	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
-
-	chars := []byte("ABCDEFGHIJKLMNOPQRSTUVWXYZ" +
-		"abcdefghijklmnopqrstuvwxyz" +
-		"0123456789")
+	if len(alphabet) == 0 {
+		return "", errors.ErrGeneratorEmptyAlphabet
+	}
 
-	b := make([]byte, length)
+	b := make([]rune, length)
 	for i := range b {
-		b[i] = chars[rnd.Intn(len(chars))]
+		b[i] = alphabet[rand.IntN(len(alphabet))]
 	}
 
 	return string(b), nil
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"math"
 	"regexp"
 	"testing"
 
@@ -36,6 +37,7 @@ func TestGenerator_UUID(t *testing.T) {
 
 func TestGenerator_Alias(t *testing.T) {
 	type fields struct {
+		alphabet    string
 		aliasLength int
 	}
 	tests := []struct {
@@ -45,26 +47,38 @@ func TestGenerator_Alias(t *testing.T) {
 	}{
 		{
 			name:   "generate alias",
-			fields: fields{aliasLength: 8},
-			want:   regexp.MustCompile(".{8}"),
+			fields: fields{aliasLength: 8, alphabet: DefaultAlphabet},
+			want:   regexp.MustCompile(`\A[A-Za-z0-9]{8}\z`),
 		},
 		{
 			name:   "generate alias with 3 chars",
-			fields: fields{aliasLength: 3},
-			want:   regexp.MustCompile(".{3}"),
+			fields: fields{aliasLength: 3, alphabet: DefaultAlphabet},
+			want:   regexp.MustCompile(`\A[A-Za-z0-9]{3}\z`),
 		},
 		{
-			name:   "when alias length is zero",
-			fields: fields{aliasLength: 0},
-			want:   regexp.MustCompile(""),
+			name:   "generate URL-safe alias",
+			fields: fields{aliasLength: 16, alphabet: URLSafeAlphabet},
+			want:   regexp.MustCompile(`\A[A-Za-z0-9_-]{16}\z`),
+		},
+		{
+			name:   "generate numeric alias",
+			fields: fields{aliasLength: 6, alphabet: NumericAlphabet},
+			want:   regexp.MustCompile(`\A[0-9]{6}\z`),
+		},
+		{
+			name:   "generate alias with unicode alphabet",
+			fields: fields{aliasLength: 4, alphabet: "абвгдежзий"},
+			want:   regexp.MustCompile(`\A[а-й]{4}\z`),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := &Generator{
+				alphabet:    []rune(tt.fields.alphabet),
 				aliasLength: tt.fields.aliasLength,
 			}
-			res, _ := g.Alias()
+			res, err := g.Alias()
+			require.NoError(t, err)
 			assert.Regexp(t, tt.want, res)
 		})
 	}
@@ -72,6 +86,7 @@ func TestGenerator_Alias(t *testing.T) {
 
 func TestGenerator_Alias_Errors(t *testing.T) {
 	type fields struct {
+		alphabet    string
 		aliasLength int
 	}
 	tests := []struct {
@@ -81,22 +96,83 @@ func TestGenerator_Alias_Errors(t *testing.T) {
 	}{
 		{
 			name:   "when alias length is zero",
-			fields: fields{aliasLength: 0},
-			want:   errors.ErrGeneratorEmptyAliasLength,
+			fields: fields{aliasLength: 0, alphabet: DefaultAlphabet},
+			want:   errors.ErrGeneratorInvalidLength,
 		},
 		{
 			name:   "when alias length is negative",
-			fields: fields{aliasLength: -1},
-			want:   errors.ErrGeneratorEmptyAliasLength,
+			fields: fields{aliasLength: -1, alphabet: DefaultAlphabet},
+			want:   errors.ErrGeneratorInvalidLength,
+		},
+		{
+			name:   "when alphabet is empty",
+			fields: fields{aliasLength: 5},
+			want:   errors.ErrGeneratorEmptyAlphabet,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := &Generator{
+				alphabet:    []rune(tt.fields.alphabet),
 				aliasLength: tt.fields.aliasLength,
 			}
 			_, err := g.Alias()
-			require.Error(t, err)
+			require.ErrorIs(t, err, tt.want)
 		})
 	}
 }
+
+func TestNewWithAlphabet(t *testing.T) {
+	tests := []struct {
+		err      error
+		name     string
+		alphabet string
+	}{
+		{name: "default alphabet", alphabet: DefaultAlphabet},
+		{name: "URL-safe alphabet", alphabet: URLSafeAlphabet},
+		{name: "numeric alphabet", alphabet: NumericAlphabet},
+		{name: "unicode alphabet", alphabet: "абвгдежзий"},
+		{name: "empty alphabet", alphabet: "", err: errors.ErrGeneratorInvalidAlphabet},
+		{name: "too short alphabet", alphabet: "abcdefghi", err: errors.ErrGeneratorInvalidAlphabet},
+		{name: "alphabet with duplicates", alphabet: "abcdefghija", err: errors.ErrGeneratorInvalidAlphabet},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewWithAlphabet(5, tt.alphabet)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				assert.Nil(t, g)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []rune(tt.alphabet), g.alphabet)
+		})
+	}
+}
+
+func TestGenerator_Alias_Collisions(t *testing.T) {
+	const (
+		count  = 10_000
+		length = 8
+	)
+
+	g := NewURLSafe(length)
+	seen := make(map[string]struct{}, count)
+	collisions := 0
+
+	for range count {
+		alias, err := g.Alias()
+		require.NoError(t, err)
+
+		if _, ok := seen[alias]; ok {
+			collisions++
+		}
+		seen[alias] = struct{}{}
+	}
+
+	// Collision rate must stay below the probability of guessing a single alias,
+	// i.e. 1/(len(alphabet)^length), which for 10 000 aliases means no repeats.
+	rate := float64(collisions) / count
+	assert.Less(t, rate, 1/math.Pow(float64(len(URLSafeAlphabet)), length))
+	assert.Zero(t, collisions)
+}
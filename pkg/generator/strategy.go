@@ -0,0 +1,112 @@
+package generator
+
+import (
+	_ "embed"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gururuby/shortener/pkg/generator/errors"
+)
+
+// Names of alias generation strategies.
+const (
+	StrategyRandom     = "random"     // Random characters of an alphabet, e.g. aB3xZ
+	StrategyWord       = "word"       // Words of the word list and a number, e.g. blue-fish-42
+	StrategySequential = "sequential" // Increasing numbers, e.g. 1042
+)
+
+// Word strategy settings.
+const (
+	DefaultWordCount = 2   // Number of words in aliases generated by WordStrategy by default
+	wordNumberLimit  = 100 // Numbers appended to words are below the limit
+	wordSeparator    = "-" // Separator of words and number in aliases
+)
+
+//go:embed wordlist.txt
+var wordList string
+
+// AliasStrategy defines the interface for producing aliases of short URLs.
+type AliasStrategy interface {
+	// Generate produces a new alias.
+	// Returns:
+	// - string: The generated alias
+	// - error: Any generation error
+	Generate() (string, error)
+}
+
+// RandomStrategy generates aliases of random characters of an alphabet.
+type RandomStrategy struct {
+	Length   int    // Length of generated aliases
+	Alphabet string // Characters used in generated aliases
+}
+
+// Generate produces a random string of the configured length and alphabet.
+// Returns:
+// - string: Generated alias
+// - error: errors.ErrGeneratorInvalidLength if length is invalid,
+// errors.ErrGeneratorEmptyAlphabet if alphabet is empty
+func (s *RandomStrategy) Generate() (string, error) {
+	return generateAlias(s.Length, []rune(s.Alphabet))
+}
+
+// WordStrategy generates human-readable aliases of random words
+// followed by a number below 100, e.g. blue-fish-42.
+type WordStrategy struct {
+	WordList  []string // Words aliases are made of
+	WordCount int      // Number of words in an alias
+}
+
+// NewWordStrategy creates a WordStrategy using the embedded word list.
+// Parameters:
+// - wordCount: Number of words in an alias (must be positive)
+// Returns:
+// - *WordStrategy: Initialized strategy
+func NewWordStrategy(wordCount int) *WordStrategy {
+	return &WordStrategy{WordList: Words(), WordCount: wordCount}
+}
+
+// Generate produces an alias of random words and a number joined with hyphens.
+// Returns:
+// - string: Generated alias
+// - error: errors.ErrGeneratorInvalidLength if word count is invalid,
+// errors.ErrGeneratorEmptyWordList if word list is empty
+func (s *WordStrategy) Generate() (string, error) {
+	if s.WordCount < 1 {
+		return "", errors.ErrGeneratorInvalidLength
+	}
+	if len(s.WordList) == 0 {
+		return "", errors.ErrGeneratorEmptyWordList
+	}
+
+	parts := make([]string, 0, s.WordCount+1)
+	for range s.WordCount {
+		parts = append(parts, s.WordList[rand.IntN(len(s.WordList))])
+	}
+	parts = append(parts, strconv.Itoa(rand.IntN(wordNumberLimit)))
+
+	return strings.Join(parts, wordSeparator), nil
+}
+
+// SequentialStrategy generates aliases of increasing numbers.
+// The counter may be shared by several strategies, each number is used once.
+type SequentialStrategy struct {
+	Counter *atomic.Int64 // Last used number
+	Prefix  string        // Prefix of generated aliases
+}
+
+// Generate produces an alias of the prefix and the next number of the counter.
+// Returns:
+// - string: Generated alias
+// - error: Always nil
+func (s *SequentialStrategy) Generate() (string, error) {
+	return s.Prefix + strconv.FormatInt(s.Counter.Add(1), 10), nil
+}
+
+// Words returns the embedded list of words used by WordStrategy.
+// Returns:
+// - []string: Lowercase latin words, a new slice on every call
+func Words() []string {
+	return strings.Fields(wordList)
+}
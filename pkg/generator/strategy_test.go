@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gururuby/shortener/pkg/generator/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomStrategy_Generate(t *testing.T) {
+	tests := []struct {
+		want     *regexp.Regexp
+		name     string
+		alphabet string
+		length   int
+	}{
+		{
+			name:     "generate alias of default alphabet",
+			length:   8,
+			alphabet: DefaultAlphabet,
+			want:     regexp.MustCompile(`\A[A-Za-z0-9]{8}\z`),
+		},
+		{
+			name:     "generate numeric alias",
+			length:   3,
+			alphabet: NumericAlphabet,
+			want:     regexp.MustCompile(`\A[0-9]{3}\z`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alias, err := NewWithStrategy(&RandomStrategy{Length: tt.length, Alphabet: tt.alphabet}).Alias()
+			require.NoError(t, err)
+			assert.Regexp(t, tt.want, alias)
+		})
+	}
+
+	t.Run("when length is invalid", func(t *testing.T) {
+		_, err := (&RandomStrategy{Alphabet: DefaultAlphabet}).Generate()
+		require.ErrorIs(t, err, errors.ErrGeneratorInvalidLength)
+	})
+}
+
+func TestWordStrategy_Generate(t *testing.T) {
+	words := Words()
+	require.NotEmpty(t, words)
+
+	known := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		require.Regexp(t, `\A[a-z]+\z`, word)
+		known[word] = struct{}{}
+	}
+
+	pattern := regexp.MustCompile(`\A([a-z]+)-([a-z]+)-([0-9]{1,2})\z`)
+	strategy := NewWordStrategy(DefaultWordCount)
+	for range 100 {
+		alias, err := strategy.Generate()
+		require.NoError(t, err)
+
+		match := pattern.FindStringSubmatch(alias)
+		require.NotNil(t, match, alias)
+		assert.Contains(t, known, match[1])
+		assert.Contains(t, known, match[2])
+	}
+
+	t.Run("when word count is configured", func(t *testing.T) {
+		alias, err := (&WordStrategy{WordList: []string{"blue"}, WordCount: 3}).Generate()
+		require.NoError(t, err)
+		assert.Regexp(t, `\Ablue-blue-blue-[0-9]{1,2}\z`, alias)
+	})
+
+	t.Run("when word count is invalid", func(t *testing.T) {
+		_, err := (&WordStrategy{WordList: words}).Generate()
+		require.ErrorIs(t, err, errors.ErrGeneratorInvalidLength)
+	})
+
+	t.Run("when word list is empty", func(t *testing.T) {
+		_, err := (&WordStrategy{WordCount: 2}).Generate()
+		require.ErrorIs(t, err, errors.ErrGeneratorEmptyWordList)
+	})
+}
+
+func TestSequentialStrategy_Generate(t *testing.T) {
+	const (
+		workers = 8
+		perWork = 200
+	)
+
+	counter := &atomic.Int64{}
+	counter.Store(1000)
+	strategy := &SequentialStrategy{Counter: counter, Prefix: "id"}
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		seen  = make(map[int64]struct{}, workers*perWork)
+		order = make([][]int64, workers)
+	)
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perWork {
+				alias, _ := strategy.Generate()
+				n, err := strconv.ParseInt(strings.TrimPrefix(alias, "id"), 10, 64)
+				if !assert.NoError(t, err) || !assert.Regexp(t, `\Aid[0-9]+\z`, alias) {
+					return
+				}
+				order[w] = append(order[w], n)
+
+				mu.Lock()
+				seen[n] = struct{}{}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Every number is used once and each goroutine gets strictly increasing numbers
+	require.Len(t, seen, workers*perWork)
+	for n := int64(1001); n <= 1000+workers*perWork; n++ {
+		require.Contains(t, seen, n)
+	}
+	for _, numbers := range order {
+		for i := 1; i < len(numbers); i++ {
+			require.Greater(t, numbers[i], numbers[i-1])
+		}
+	}
+}
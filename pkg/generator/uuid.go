@@ -0,0 +1,24 @@
+package generator
+
+import "github.com/google/uuid"
+
+// UUIDv7 generates a time-ordered universally unique identifier (UUID v7).
+// UUIDs generated by the process are strictly increasing, so inserting them
+// into B-tree indexes appends to the end instead of splitting random pages.
+// Returns:
+// - string: Generated UUID in string format
+func UUIDv7() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+// NewWithUUIDv7 creates a new Generator instance with DefaultAlphabet
+// generating time-ordered UUIDs (UUID v7).
+// Parameters:
+// - aliasLength: Desired length for generated aliases (must be positive)
+// Returns:
+// - *Generator: Initialized generator instance
+func NewWithUUIDv7(aliasLength int) *Generator {
+	g := New(aliasLength)
+	g.timeOrderedUUID = true
+	return g
+}
@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUUIDv7_Monotonic(t *testing.T) {
+	const count = 1000
+
+	ids := make([]string, count)
+	for i := range ids {
+		ids[i] = UUIDv7()
+	}
+
+	require.True(t, sort.StringsAreSorted(ids))
+	for i := 1; i < count; i++ {
+		require.Less(t, ids[i-1], ids[i])
+	}
+}
+
+func TestUUIDv7_Time(t *testing.T) {
+	generatedAt := time.Now()
+	id, err := uuid.Parse(UUIDv7())
+	require.NoError(t, err)
+
+	assert.Equal(t, uuid.Version(7), id.Version())
+	sec, nsec := id.Time().UnixTime()
+	assert.WithinDuration(t, generatedAt, time.Unix(sec, nsec), time.Second)
+}
+
+func TestNewWithUUIDv7(t *testing.T) {
+	g := NewWithUUIDv7(8)
+
+	id, err := uuid.Parse(g.UUID())
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(7), id.Version())
+
+	id, err = uuid.Parse(New(8).UUID())
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(4), id.Version())
+
+	alias, err := g.Alias()
+	require.NoError(t, err)
+	assert.Regexp(t, `\A[A-Za-z0-9]{8}\z`, alias)
+}
@@ -0,0 +1,15 @@
+// Package errors defines error conditions for URL normalization.
+package errors
+
+import "errors"
+
+// Errors list
+var (
+	// ErrNormalizerInvalidURL indicates that the passed string is not an absolute URL
+	// and cannot be normalized.
+	//
+	// This error occurs when:
+	// - The URL cannot be parsed
+	// - Scheme or host is missing (e.g. "example.com/path" or "/path")
+	ErrNormalizerInvalidURL = errors.New("cannot normalize URL, absolute URL expected")
+)
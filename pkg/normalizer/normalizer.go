@@ -0,0 +1,122 @@
+/*
+Package normalizer provides URL normalization utilities.
+
+Normalization makes equivalent URLs comparable, e.g. `HTTP://Example.com:80/`,
+`http://example.com/?` and `http://example.com#top` all become `http://example.com`.
+*/
+package normalizer
+
+import (
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/gururuby/shortener/pkg/normalizer/errors"
+)
+
+// defaultPorts maps schemes to their default ports which are dropped during normalization.
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// Normalize converts an absolute URL to its normalized form.
+// It performs the following steps:
+//   - Lowercases the scheme and the host (including Unicode hosts)
+//   - Removes the default port (80 for http, 443 for https)
+//   - Removes the trailing slash of an empty path
+//   - Sorts query parameters by name keeping the order of repeated ones
+//   - Drops an empty query and the fragment
+//   - Percent-encodes non-ASCII characters of the path and the host (RFC 3986)
+//
+// Parameters:
+//   - rawURL: The URL string to normalize
+//
+// Returns:
+//   - string: Normalized URL
+//   - error: errors.ErrNormalizerInvalidURL if the URL is not absolute
+//
+// Example:
+//
+//	normalized, err := normalizer.Normalize("HTTP://Example.com:80/?b=2&a=1#top")
+//	// normalized == "http://example.com?a=1&b=2"
+func Normalize(rawURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", errors.ErrNormalizerInvalidURL
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = normalizeHost(u.Scheme, u.Host)
+
+	if u.Path == "/" {
+		u.Path = ""
+		u.RawPath = ""
+	}
+
+	u.RawQuery = sortQuery(u.RawQuery)
+	u.ForceQuery = false
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	return u.String(), nil
+}
+
+// normalizeHost lowercases the host and drops the default port of the scheme.
+// Parameters:
+//   - scheme: Lowercased URL scheme
+//   - hostport: Host with optional port
+//
+// Returns:
+//   - string: Normalized host with optional port
+func normalizeHost(scheme, hostport string) string {
+	host, port, err := net.SplitHostPort(hostport)
+	if err != nil {
+		// No port in the host
+		return strings.ToLower(hostport)
+	}
+
+	host = strings.ToLower(host)
+
+	if port == "" || defaultPorts[scheme] == port {
+		if strings.Contains(host, ":") {
+			return "[" + host + "]"
+		}
+		return host
+	}
+
+	return net.JoinHostPort(host, port)
+}
+
+// sortQuery sorts raw query parameters by name.
+// Parameters keep their original encoding, empty parameters are dropped.
+// Parameters:
+//   - rawQuery: Query string without leading "?"
+//
+// Returns:
+//   - string: Sorted query string
+func sortQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+
+	params := make([]string, 0, strings.Count(rawQuery, "&")+1)
+	for _, param := range strings.Split(rawQuery, "&") {
+		if param != "" {
+			params = append(params, param)
+		}
+	}
+
+	sort.SliceStable(params, func(i, j int) bool {
+		return queryKey(params[i]) < queryKey(params[j])
+	})
+
+	return strings.Join(params, "&")
+}
+
+// queryKey returns the name part of a raw query parameter.
+func queryKey(param string) string {
+	key, _, _ := strings.Cut(param, "=")
+	return key
+}
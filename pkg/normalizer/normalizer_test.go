@@ -0,0 +1,83 @@
+package normalizer
+
+import (
+	"testing"
+
+	"github.com/gururuby/shortener/pkg/normalizer/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name   string
+		rawURL string
+		want   string
+	}{
+		// Scheme and host
+		{name: "already normalized", rawURL: "http://example.com", want: "http://example.com"},
+		{name: "uppercase scheme", rawURL: "HTTP://example.com", want: "http://example.com"},
+		{name: "mixed case scheme", rawURL: "HtTpS://example.com", want: "https://example.com"},
+		{name: "uppercase host", rawURL: "http://EXAMPLE.COM", want: "http://example.com"},
+		{name: "path case is preserved", rawURL: "http://Example.com/Path", want: "http://example.com/Path"},
+		{name: "surrounding spaces", rawURL: "  http://example.com  ", want: "http://example.com"},
+
+		// Ports
+		{name: "default http port", rawURL: "http://example.com:80/path", want: "http://example.com/path"},
+		{name: "default https port", rawURL: "https://example.com:443/path", want: "https://example.com/path"},
+		{name: "https port on http", rawURL: "http://example.com:443", want: "http://example.com:443"},
+		{name: "custom port", rawURL: "http://example.com:8080", want: "http://example.com:8080"},
+		{name: "empty port", rawURL: "http://example.com:", want: "http://example.com"},
+		{name: "ipv6 with default port", rawURL: "http://[::1]:80/", want: "http://[::1]"},
+		{name: "ipv6 with custom port", rawURL: "http://[::1]:8080/", want: "http://[::1]:8080"},
+
+		// Trailing slash
+		{name: "trailing slash of empty path", rawURL: "http://example.com/", want: "http://example.com"},
+		{name: "trailing slash of non-empty path", rawURL: "http://example.com/path/", want: "http://example.com/path/"},
+		{name: "empty query", rawURL: "http://example.com/?", want: "http://example.com"},
+
+		// Query
+		{name: "sorted query", rawURL: "http://example.com/?a=1&b=2", want: "http://example.com?a=1&b=2"},
+		{name: "unsorted query", rawURL: "http://example.com/p?c=3&a=1&b=2", want: "http://example.com/p?a=1&b=2&c=3"},
+		{name: "repeated query keys keep order", rawURL: "http://example.com/p?b=2&a=2&a=1", want: "http://example.com/p?a=2&a=1&b=2"},
+		{name: "empty query params", rawURL: "http://example.com/p?b=2&&a=1", want: "http://example.com/p?a=1&b=2"},
+		{name: "query encoding preserved", rawURL: "http://example.com/p?q=a%20b&a=x+y", want: "http://example.com/p?a=x+y&q=a%20b"},
+
+		// Fragment
+		{name: "fragment", rawURL: "http://example.com/page#section", want: "http://example.com/page"},
+		{name: "fragment with query", rawURL: "http://example.com/?b=1&a=2#top", want: "http://example.com?a=2&b=1"},
+
+		// Unicode
+		{name: "unicode path", rawURL: "http://example.com/путь", want: "http://example.com/%D0%BF%D1%83%D1%82%D1%8C"},
+		{name: "encoded path preserved", rawURL: "http://example.com/a%2Fb", want: "http://example.com/a%2Fb"},
+		{name: "IDN host", rawURL: "https://MÜNCHEN.de/", want: "https://m%C3%BCnchen.de"},
+		{name: "IDN host equals lowercase IDN host", rawURL: "https://münchen.de", want: "https://m%C3%BCnchen.de"},
+		{name: "IDN host with default port", rawURL: "http://Пример.РФ:80/Путь", want: "http://%D0%BF%D1%80%D0%B8%D0%BC%D0%B5%D1%80.%D1%80%D1%84/%D0%9F%D1%83%D1%82%D1%8C"},
+		{name: "punycode host", rawURL: "https://XN--MNCHEN-3YA.de", want: "https://xn--mnchen-3ya.de"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.rawURL)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNormalize_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		rawURL string
+	}{
+		{name: "empty string", rawURL: ""},
+		{name: "no scheme", rawURL: "example.com/path"},
+		{name: "relative path", rawURL: "/path"},
+		{name: "invalid URL", rawURL: "http://exa mple.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Normalize(tt.rawURL)
+			require.ErrorIs(t, err, errors.ErrNormalizerInvalidURL)
+		})
+	}
+}
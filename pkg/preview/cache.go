@@ -0,0 +1,51 @@
+package preview
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+// CachedScraper keeps metadata fetched by another scraper in memory.
+// The least recently used entries are evicted when the cache is full,
+// errors are not cached, so failed pages are fetched again on next request.
+type CachedScraper struct {
+	next  Scraper                           // Scraper fetching pages missing in cache
+	cache *expirable.LRU[string, *Metadata] // Cached metadata by URL, expiring after TTL
+}
+
+// NewCachedScraper creates a new caching scraper.
+// Parameters:
+// - next: Scraper fetching pages missing in cache
+// - maxEntries: Maximum number of cached pages
+// - ttl: Time to keep metadata of a page
+// Returns:
+// - *CachedScraper: Initialized scraper
+func NewCachedScraper(next Scraper, maxEntries int, ttl time.Duration) *CachedScraper {
+	return &CachedScraper{
+		next:  next,
+		cache: expirable.NewLRU[string, *Metadata](maxEntries, nil, ttl),
+	}
+}
+
+// Fetch returns cached metadata of the page or fetches it with the wrapped scraper.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - url: URL of the page
+// Returns:
+// - *Metadata: Copy of page metadata
+// - error: Error of the wrapped scraper
+func (s *CachedScraper) Fetch(ctx context.Context, url string) (*Metadata, error) {
+	md, ok := s.cache.Get(url)
+	if !ok {
+		var err error
+		if md, err = s.next.Fetch(ctx, url); err != nil {
+			return nil, err
+		}
+		s.cache.Add(url, md)
+	}
+
+	copied := *md
+	return &copied, nil
+}
@@ -0,0 +1,70 @@
+package preview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingScraper returns metadata with title counting fetches of the URL.
+type countingScraper struct {
+	fetches map[string]int
+	err     error
+}
+
+func (s *countingScraper) Fetch(_ context.Context, url string) (*Metadata, error) {
+	s.fetches[url]++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &Metadata{URL: url, Title: fmt.Sprintf("fetch %d", s.fetches[url])}, nil
+}
+
+func Test_CachedScraper_Fetch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("when page is cached", func(t *testing.T) {
+		next := &countingScraper{fetches: map[string]int{}}
+		s := NewCachedScraper(next, 10, time.Hour)
+
+		first, err := s.Fetch(ctx, "https://a.ru")
+		require.NoError(t, err)
+		first.Title = "changed by caller"
+
+		second, err := s.Fetch(ctx, "https://a.ru")
+		require.NoError(t, err)
+		assert.Equal(t, "fetch 1", second.Title, "cached metadata must not be changed through returned copy")
+		assert.Equal(t, 1, next.fetches["https://a.ru"])
+	})
+
+	t.Run("when cached page is expired", func(t *testing.T) {
+		next := &countingScraper{fetches: map[string]int{}}
+		s := NewCachedScraper(next, 10, 50*time.Millisecond)
+
+		_, err := s.Fetch(ctx, "https://a.ru")
+		require.NoError(t, err)
+
+		time.Sleep(100 * time.Millisecond)
+		got, err := s.Fetch(ctx, "https://a.ru")
+		require.NoError(t, err)
+		assert.Equal(t, "fetch 2", got.Title)
+	})
+
+	t.Run("when fetch fails the error is not cached", func(t *testing.T) {
+		fetchErr := errors.New("fetch failed")
+		next := &countingScraper{fetches: map[string]int{}, err: fetchErr}
+		s := NewCachedScraper(next, 10, time.Hour)
+
+		for range 2 {
+			_, err := s.Fetch(ctx, "https://a.ru")
+			require.ErrorIs(t, err, fetchErr)
+		}
+		assert.Equal(t, 2, next.fetches["https://a.ru"])
+		assert.Zero(t, s.cache.Len())
+	})
+}
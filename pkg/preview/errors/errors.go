@@ -0,0 +1,20 @@
+// Package errors defines error conditions for fetching of page previews.
+package errors
+
+import "errors"
+
+// Errors list
+var (
+	// ErrPreviewRequest indicates the page could not be fetched or read.
+	ErrPreviewRequest = errors.New("cannot fetch page")
+
+	// ErrPreviewUnexpectedStatus indicates the page responded with a non-200 status code.
+	ErrPreviewUnexpectedStatus = errors.New("unexpected page response status")
+
+	// ErrPreviewNotHTML indicates the page is not an HTML document.
+	ErrPreviewNotHTML = errors.New("page is not an HTML document")
+
+	// ErrPreviewPrivateAddress indicates the page host resolves to a private, loopback
+	// or link-local address, which is never fetched to protect internal services.
+	ErrPreviewPrivateAddress = errors.New("page host resolves to a private address")
+)
@@ -0,0 +1,313 @@
+/*
+Package preview provides fetching of page metadata for link previews.
+
+It includes:
+- Scraper interface for fetching title, description, image and favicon of a page
+- HTTP implementation reading Open Graph tags with fallback to <title> and <meta name="description">
+- LRU cache of fetched metadata
+- Null implementation for tests
+*/
+package preview
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gururuby/shortener/pkg/preview/errors"
+	"golang.org/x/net/html"
+)
+
+// Available constants
+const (
+	defaultTimeout = 5 * time.Second // Timeout of fetching a page, including redirects
+	maxPageBytes   = 1 << 20         // Only the beginning of a page is read, metadata is in its head
+	defaultFavicon = "/favicon.ico"  // Favicon path used if the page declares none
+)
+
+// Metadata describes a page for its preview.
+type Metadata struct {
+	URL         string `json:"url"`                   // URL of the page as requested
+	Title       string `json:"title,omitempty"`       // og:title or <title>
+	Description string `json:"description,omitempty"` // og:description or <meta name="description">
+	Image       string `json:"image,omitempty"`       // Absolute URL of og:image
+	Favicon     string `json:"favicon,omitempty"`     // Absolute URL of the page icon
+}
+
+// Scraper defines the interface for fetching page metadata.
+type Scraper interface {
+	// Fetch returns metadata of the page at the URL.
+	Fetch(ctx context.Context, url string) (*Metadata, error)
+}
+
+// NullScraper is a Scraper returning metadata with URL only, without fetching the page.
+type NullScraper struct{}
+
+// Fetch returns metadata without any page details.
+// Returns:
+// - *Metadata: Metadata with URL only
+// - error: Always nil
+func (NullScraper) Fetch(_ context.Context, url string) (*Metadata, error) {
+	return &Metadata{URL: url}, nil
+}
+
+// HTTPScraper fetches pages over HTTP and reads metadata from their head.
+// Hosts resolving to private, loopback or link-local addresses are never connected to.
+type HTTPScraper struct {
+	client *http.Client // HTTP client fetching pages
+}
+
+// NewHTTPScraper creates a new scraper fetching pages with 5 seconds timeout.
+// Returns:
+// - *HTTPScraper: Initialized scraper
+func NewHTTPScraper() *HTTPScraper {
+	return newHTTPScraper(false)
+}
+
+// newHTTPScraper creates a new scraper.
+// Parameters:
+// - allowPrivate: Whether private addresses may be connected to, e.g. test servers
+// Returns:
+// - *HTTPScraper: Initialized scraper
+func newHTTPScraper(allowPrivate bool) *HTTPScraper {
+	dialer := &net.Dialer{Timeout: defaultTimeout}
+	if !allowPrivate {
+		// Checked on connect, so hosts are not re-resolved to a private address after validation
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isPrivateIP(ip) {
+				return errors.ErrPreviewPrivateAddress
+			}
+			return nil
+		}
+	}
+
+	return &HTTPScraper{
+		client: &http.Client{
+			Timeout:   defaultTimeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext, TLSHandshakeTimeout: defaultTimeout},
+		},
+	}
+}
+
+// isPrivateIP reports whether the address is not publicly routable.
+// Parameters:
+// - ip: Resolved address of a host
+// Returns:
+// - bool: true for private, loopback, link-local, multicast and unspecified addresses
+func isPrivateIP(ip net.IP) bool {
+	return ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified()
+}
+
+// Fetch downloads the page and reads its metadata.
+// Open Graph tags take precedence over <title> and <meta name="description">,
+// relative image and favicon URLs are resolved against the final URL of the page.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - rawURL: Absolute http/https URL of the page
+// Returns:
+// - *Metadata: Page metadata
+// - error: errors.ErrPreviewPrivateAddress, errors.ErrPreviewUnexpectedStatus,
+// errors.ErrPreviewNotHTML or errors.ErrPreviewRequest
+func (s *HTTPScraper) Fetch(ctx context.Context, rawURL string) (*Metadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrPreviewRequest, err)
+	}
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrPreviewRequest, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %d", errors.ErrPreviewUnexpectedStatus, resp.StatusCode)
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, fmt.Errorf("%w: %s", errors.ErrPreviewNotHTML, mediaType)
+	}
+
+	tags, err := readTags(io.LimitReader(resp.Body, maxPageBytes))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrPreviewRequest, err)
+	}
+
+	return tags.metadata(rawURL, resp.Request.URL), nil
+}
+
+// pageTags holds metadata tags found in the page head.
+type pageTags struct {
+	title         string // Text of <title>
+	description   string // Content of <meta name="description">
+	ogTitle       string // Content of og:title
+	ogDescription string // Content of og:description
+	ogImage       string // Content of og:image
+	icon          string // Href of <link rel="icon">
+}
+
+// readTags tokenizes the page up to the end of its head collecting metadata tags.
+// Parameters:
+// - r: Page body
+// Returns:
+// - *pageTags: Found tags
+// - error: If reading the page fails
+func readTags(r io.Reader) (*pageTags, error) {
+	tags := &pageTags{}
+	z := html.NewTokenizer(r)
+
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			if z.Err() == io.EOF {
+				return tags, nil
+			}
+			return tags, z.Err()
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == "head" {
+				return tags, nil
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch string(name) {
+			case "body":
+				return tags, nil
+			case "title":
+				if tags.title == "" && z.Next() == html.TextToken {
+					tags.title = strings.TrimSpace(string(z.Text()))
+				}
+			case "meta":
+				if hasAttr {
+					tags.readMeta(tagAttrs(z))
+				}
+			case "link":
+				if hasAttr {
+					tags.readLink(tagAttrs(z))
+				}
+			}
+		}
+	}
+}
+
+// tagAttrs reads attributes of the current tag with lowercased names.
+// Parameters:
+// - z: Tokenizer positioned at a tag with attributes
+// Returns:
+// - map[string]string: Attribute values by name, the first one wins for repeated names
+func tagAttrs(z *html.Tokenizer) map[string]string {
+	attrs := make(map[string]string)
+	for {
+		key, value, more := z.TagAttr()
+		name := strings.ToLower(string(key))
+		if _, ok := attrs[name]; !ok {
+			attrs[name] = strings.TrimSpace(string(value))
+		}
+		if !more {
+			return attrs
+		}
+	}
+}
+
+// readMeta stores the meta tag if it is a known metadata tag and the value is not set yet.
+// Parameters:
+// - attrs: Attributes of the meta tag
+func (t *pageTags) readMeta(attrs map[string]string) {
+	content := attrs["content"]
+	if content == "" {
+		return
+	}
+
+	// Some sites put Open Graph properties into name attribute
+	key := attrs["property"]
+	if key == "" {
+		key = attrs["name"]
+	}
+
+	var target *string
+	switch strings.ToLower(key) {
+	case "og:title":
+		target = &t.ogTitle
+	case "og:description":
+		target = &t.ogDescription
+	case "og:image", "og:image:url":
+		target = &t.ogImage
+	case "description":
+		target = &t.description
+	default:
+		return
+	}
+	if *target == "" {
+		*target = content
+	}
+}
+
+// readLink stores the link tag if it declares the page icon and the icon is not set yet.
+// Parameters:
+// - attrs: Attributes of the link tag
+func (t *pageTags) readLink(attrs map[string]string) {
+	if t.icon != "" || attrs["href"] == "" {
+		return
+	}
+	for _, rel := range strings.Fields(strings.ToLower(attrs["rel"])) {
+		if rel == "icon" {
+			t.icon = attrs["href"]
+			return
+		}
+	}
+}
+
+// metadata builds page metadata from the tags.
+// Parameters:
+// - rawURL: URL of the page as requested
+// - base: Final URL of the page after redirects
+// Returns:
+// - *Metadata: Metadata with absolute image and favicon URLs
+func (t *pageTags) metadata(rawURL string, base *url.URL) *Metadata {
+	return &Metadata{
+		URL:         rawURL,
+		Title:       firstNonEmpty(t.ogTitle, t.title),
+		Description: firstNonEmpty(t.ogDescription, t.description),
+		Image:       resolveURL(base, t.ogImage),
+		Favicon:     resolveURL(base, firstNonEmpty(t.icon, defaultFavicon)),
+	}
+}
+
+// firstNonEmpty returns the first non-empty value.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// resolveURL converts a reference found in the page to absolute http/https URL.
+// Parameters:
+// - base: Final URL of the page
+// - ref: Absolute or relative reference
+// Returns:
+// - string: Absolute URL, empty if the reference is empty, invalid or not http/https
+func resolveURL(base *url.URL, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	u, err := base.Parse(ref)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	return u.String()
+}
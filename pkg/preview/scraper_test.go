@@ -0,0 +1,159 @@
+package preview
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gururuby/shortener/pkg/preview/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_HTTPScraper_Fetch(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		page        string
+		status      int
+		want        Metadata
+		err         error
+	}{
+		{
+			name:        "when page has Open Graph tags",
+			contentType: "text/html; charset=utf-8",
+			status:      http.StatusOK,
+			page: `<!DOCTYPE html><html><head>
+<title>Fallback title</title>
+<meta name="description" content="Fallback description">
+<meta property="og:title" content="Example &amp; Co">
+<meta property="og:description" content="Example description">
+<meta property="og:image" content="/img/cover.png">
+<link rel="shortcut icon" href="https://cdn.example.com/icon.png">
+</head><body><meta property="og:title" content="Ignored in body"></body></html>`,
+			want: Metadata{
+				Title:       "Example & Co",
+				Description: "Example description",
+				Image:       "{server}/img/cover.png",
+				Favicon:     "https://cdn.example.com/icon.png",
+			},
+		},
+		{
+			name:        "when page has no Open Graph tags",
+			contentType: "text/html",
+			status:      http.StatusOK,
+			page: `<html><head>
+<TITLE> Plain page </TITLE>
+<META NAME="Description" CONTENT="Plain description">
+</head><body></body></html>`,
+			want: Metadata{
+				Title:       "Plain page",
+				Description: "Plain description",
+				Favicon:     "{server}/favicon.ico",
+			},
+		},
+		{
+			name:        "when Open Graph tags are set in name attribute",
+			contentType: "text/html",
+			status:      http.StatusOK,
+			page:        `<head><meta name="og:title" content="Named"><meta name="og:image" content="javascript:alert(1)"><link rel="icon" href="icons/fav.svg"></head>`,
+			want: Metadata{
+				Title:   "Named",
+				Favicon: "{server}/icons/fav.svg",
+			},
+		},
+		{
+			name:        "when page is not HTML",
+			contentType: "application/json",
+			status:      http.StatusOK,
+			page:        `{}`,
+			err:         errors.ErrPreviewNotHTML,
+		},
+		{
+			name:        "when page responds with error status",
+			contentType: "text/html",
+			status:      http.StatusNotFound,
+			err:         errors.ErrPreviewUnexpectedStatus,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.page))
+			}))
+			defer srv.Close()
+
+			got, err := newHTTPScraper(true).Fetch(context.Background(), srv.URL+"/page")
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+
+			want := tt.want
+			want.URL = srv.URL + "/page"
+			want.Image = strings.Replace(want.Image, "{server}", srv.URL, 1)
+			want.Favicon = strings.Replace(want.Favicon, "{server}", srv.URL, 1)
+			assert.Equal(t, &want, got)
+		})
+	}
+}
+
+func Test_HTTPScraper_Fetch_Redirect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new/page", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/new/page", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<head><meta property="og:image" content="cover.png"></head>`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	got, err := newHTTPScraper(true).Fetch(context.Background(), srv.URL+"/old")
+	require.NoError(t, err)
+	assert.Equal(t, srv.URL+"/old", got.URL)
+	assert.Equal(t, srv.URL+"/new/cover.png", got.Image, "relative URLs are resolved against final URL")
+}
+
+func Test_HTTPScraper_Fetch_PrivateAddress(t *testing.T) {
+	var requested bool
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		requested = true
+	}))
+	defer srv.Close()
+
+	_, err := NewHTTPScraper().Fetch(context.Background(), srv.URL)
+	require.ErrorIs(t, err, errors.ErrPreviewPrivateAddress)
+	assert.False(t, requested)
+}
+
+func Test_isPrivateIP(t *testing.T) {
+	for ip, want := range map[string]bool{
+		"127.0.0.1":   true,
+		"10.1.2.3":    true,
+		"172.16.0.1":  true,
+		"192.168.1.1": true,
+		"169.254.1.1": true,
+		"0.0.0.0":     true,
+		"::1":         true,
+		"fc00::1":     true,
+		"fe80::1":     true,
+		"8.8.8.8":     false,
+		"2001:4860::": false,
+	} {
+		assert.Equal(t, want, isPrivateIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func Test_NullScraper_Fetch(t *testing.T) {
+	got, err := NullScraper{}.Fetch(context.Background(), "https://example.com")
+	require.NoError(t, err)
+	assert.Equal(t, &Metadata{URL: "https://example.com"}, got)
+}
@@ -1,11 +1,18 @@
 /*
 Package utils provides general utility functions for the application.
 
-It includes helper functions for common operations like retry logic.
+It includes helper functions for common operations like retry logic
+with fixed delay or exponential backoff with jitter.
 */
 package utils
 
-import "time"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
 
 // Retry executes a function and retries on failure with exponential backoff.
 //
@@ -39,3 +46,132 @@ func Retry(f func() error, retryTimes int, delay time.Duration) error {
 
 	return nil
 }
+
+// Option configures ExponentialBackoff.
+type Option func(*options)
+
+// options contains ExponentialBackoff settings.
+type options struct {
+	ctx     context.Context // Context stopping retries when done
+	retryOn []error         // Errors worth retrying, any error if empty
+}
+
+// WithContext stops retries as soon as ctx is done.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//
+// Returns:
+//   - Option: ExponentialBackoff option
+func WithContext(ctx context.Context) Option {
+	return func(o *options) {
+		o.ctx = ctx
+	}
+}
+
+// RetryOn restricts retries to errors matching err with errors.Is.
+// The option can be passed several times to retry on any of the errors.
+//
+// Parameters:
+//   - err: Error worth retrying
+//
+// Returns:
+//   - Option: ExponentialBackoff option
+func RetryOn(err error) Option {
+	return func(o *options) {
+		o.retryOn = append(o.retryOn, err)
+	}
+}
+
+// ExponentialBackoff executes a function and retries on failure with growing delays.
+// Delay before retry number n (starting with 0) is min(baseDelay * 2^n, maxDelay)
+// randomly shifted by up to ±jitter*delay, so that concurrent callers don't retry in lockstep.
+//
+// Parameters:
+//   - fn: The function to execute that returns an error
+//   - maxAttempts: Maximum number of calls of fn
+//   - baseDelay: Delay before the first retry
+//   - maxDelay: Upper bound of delay before jitter is applied
+//   - jitter: Fraction of delay used as random shift, from 0 to 1
+//   - opts: WithContext, RetryOn options
+//
+// Returns:
+//   - error: nil if fn succeeds within maxAttempts, the last error of fn if attempts are exhausted
+//     or the error is not worth retrying, context error wrapping the last error if context is done
+//
+// Example:
+//
+//	err := ExponentialBackoff(func() error {
+//	    return SomeOperation()
+//	}, 5, 100*time.Millisecond, 5*time.Second, 0.2, WithContext(ctx))
+func ExponentialBackoff(fn func() error, maxAttempts int, baseDelay, maxDelay time.Duration, jitter float64, opts ...Option) error {
+	var err error
+
+	o := &options{ctx: context.Background()}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if ctxErr := o.ctx.Err(); ctxErr != nil {
+			return wrapContextErr(ctxErr, err)
+		}
+
+		if err = fn(); err == nil {
+			return nil
+		}
+
+		if !o.isRetryable(err) || attempt == maxAttempts-1 {
+			return err
+		}
+
+		timer := time.NewTimer(backoffDelay(attempt, baseDelay, maxDelay, jitter))
+		select {
+		case <-o.ctx.Done():
+			timer.Stop()
+			return wrapContextErr(o.ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+
+	return err
+}
+
+// isRetryable reports whether err matches any of RetryOn errors.
+func (o *options) isRetryable(err error) bool {
+	if len(o.retryOn) == 0 {
+		return true
+	}
+
+	for _, target := range o.retryOn {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoffDelay computes delay before retry number attempt.
+func backoffDelay(attempt int, baseDelay, maxDelay time.Duration, jitter float64) time.Duration {
+	delay := maxDelay
+	// Shift only while it cannot overflow or exceed maxDelay
+	if attempt < 63 && baseDelay <= maxDelay>>attempt {
+		delay = baseDelay << attempt
+	}
+
+	delay += time.Duration((rand.Float64()*2 - 1) * jitter * float64(delay))
+	if delay < 0 {
+		return 0
+	}
+
+	return delay
+}
+
+// wrapContextErr returns context error wrapping the last error of retried function.
+func wrapContextErr(ctxErr, lastErr error) error {
+	if lastErr == nil {
+		return ctxErr
+	}
+	return fmt.Errorf("%w: %w", ctxErr, lastErr)
+}
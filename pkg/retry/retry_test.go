@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errTemporary = errors.New("temporary error")
+	errPermanent = errors.New("permanent error")
+)
+
+// failingFn returns function failing with err the first failures calls and counter of its calls.
+func failingFn(failures int, err error) (func() error, *int) {
+	calls := 0
+	return func() error {
+		calls++
+		if calls <= failures {
+			return err
+		}
+		return nil
+	}, &calls
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	tests := []struct {
+		err         error
+		fnErr       error
+		name        string
+		opts        []Option
+		failures    int
+		maxAttempts int
+		wantCalls   int
+	}{
+		{
+			name:        "when function succeeds at once",
+			maxAttempts: 3,
+			wantCalls:   1,
+		},
+		{
+			name:        "when function succeeds after failures",
+			fnErr:       errTemporary,
+			failures:    2,
+			maxAttempts: 3,
+			wantCalls:   3,
+		},
+		{
+			name:        "when attempts are exhausted",
+			fnErr:       errTemporary,
+			failures:    5,
+			maxAttempts: 3,
+			wantCalls:   3,
+			err:         errTemporary,
+		},
+		{
+			name:        "when error matches RetryOn",
+			fnErr:       errTemporary,
+			failures:    2,
+			maxAttempts: 3,
+			wantCalls:   3,
+			opts:        []Option{RetryOn(errPermanent), RetryOn(errTemporary)},
+		},
+		{
+			name:        "when error does not match RetryOn",
+			fnErr:       errPermanent,
+			failures:    2,
+			maxAttempts: 3,
+			wantCalls:   1,
+			opts:        []Option{RetryOn(errTemporary)},
+			err:         errPermanent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn, calls := failingFn(tt.failures, tt.fnErr)
+
+			err := ExponentialBackoff(fn, tt.maxAttempts, time.Millisecond, 4*time.Millisecond, 0.5, tt.opts...)
+			require.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.wantCalls, *calls)
+		})
+	}
+}
+
+func TestExponentialBackoff_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	fn, calls := failingFn(10, errTemporary)
+
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := ExponentialBackoff(fn, 10, time.Hour, time.Hour, 0, WithContext(ctx))
+
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorIs(t, err, errTemporary)
+	assert.Equal(t, 1, *calls)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestExponentialBackoff_ContextCanceledBeforeStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	fn, calls := failingFn(0, nil)
+
+	err := ExponentialBackoff(fn, 3, time.Millisecond, time.Millisecond, 0, WithContext(ctx))
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, *calls)
+}
+
+func Test_backoffDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt int
+		jitter  float64
+		min     time.Duration
+		max     time.Duration
+	}{
+		{
+			name:    "when delay grows exponentially",
+			attempt: 3,
+			min:     800 * time.Millisecond,
+			max:     800 * time.Millisecond,
+		},
+		{
+			name:    "when delay is capped",
+			attempt: 10,
+			min:     5 * time.Second,
+			max:     5 * time.Second,
+		},
+		{
+			name:    "when shift would overflow",
+			attempt: 100,
+			min:     5 * time.Second,
+			max:     5 * time.Second,
+		},
+		{
+			name:    "when jitter is applied",
+			attempt: 1,
+			jitter:  0.5,
+			min:     100 * time.Millisecond,
+			max:     300 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 100 {
+				delay := backoffDelay(tt.attempt, 100*time.Millisecond, 5*time.Second, tt.jitter)
+				assert.GreaterOrEqual(t, delay, tt.min)
+				assert.LessOrEqual(t, delay, tt.max)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Package safebrowsing provides URL safety checks.
+
+It includes:
+- URLChecker interface for checking URLs against threat lists
+- Google Safe Browsing API v4 implementation
+- No-op implementation for tests and unconfigured environments
+*/
+package safebrowsing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gururuby/shortener/pkg/safebrowsing/errors"
+)
+
+// Available constants
+const (
+	defaultEndpoint = "https://safebrowsing.googleapis.com/v4/threatMatches:find" // Safe Browsing Lookup API
+	defaultTimeout  = 5 * time.Second                                             // HTTP client timeout
+	clientID        = "shortener"                                                 // Client ID reported to the API
+	clientVersion   = "1.0.0"                                                     // Client version reported to the API
+)
+
+// URLChecker defines the interface for checking URL safety.
+type URLChecker interface {
+	// IsUnsafe reports whether URL is known to host malware, phishing or other threats.
+	IsUnsafe(ctx context.Context, url string) (bool, error)
+}
+
+// NoOpChecker is a URLChecker treating every URL as safe.
+type NoOpChecker struct{}
+
+// IsUnsafe always reports URL as safe.
+// Returns:
+// - bool: Always false
+// - error: Always nil
+func (NoOpChecker) IsUnsafe(_ context.Context, _ string) (bool, error) {
+	return false, nil
+}
+
+// GoogleSafeBrowsingChecker checks URLs with Google Safe Browsing Lookup API v4.
+type GoogleSafeBrowsingChecker struct {
+	client   *http.Client // HTTP client for API requests
+	apiKey   string       // Google API key
+	endpoint string       // threatMatches:find endpoint URL
+}
+
+// NewGoogleSafeBrowsingChecker creates a new Safe Browsing checker.
+// Parameters:
+// - apiKey: Google API key with Safe Browsing API enabled
+// Returns:
+// - *GoogleSafeBrowsingChecker: Initialized checker
+func NewGoogleSafeBrowsingChecker(apiKey string) *GoogleSafeBrowsingChecker {
+	return &GoogleSafeBrowsingChecker{
+		client:   &http.Client{Timeout: defaultTimeout},
+		apiKey:   apiKey,
+		endpoint: defaultEndpoint,
+	}
+}
+
+// threatEntry is a URL to check.
+type threatEntry struct {
+	URL string `json:"url"`
+}
+
+// findRequest is the threatMatches:find request body.
+type findRequest struct {
+	Client struct {
+		ClientID      string `json:"clientId"`
+		ClientVersion string `json:"clientVersion"`
+	} `json:"client"`
+	ThreatInfo struct {
+		ThreatTypes      []string      `json:"threatTypes"`
+		PlatformTypes    []string      `json:"platformTypes"`
+		ThreatEntryTypes []string      `json:"threatEntryTypes"`
+		ThreatEntries    []threatEntry `json:"threatEntries"`
+	} `json:"threatInfo"`
+}
+
+// findResponse is the threatMatches:find response body.
+// It has no matches if URL is not on any threat list.
+type findResponse struct {
+	Matches []struct {
+		ThreatType string      `json:"threatType"`
+		Threat     threatEntry `json:"threat"`
+	} `json:"matches"`
+}
+
+// IsUnsafe checks URL against malware, social engineering and unwanted software lists.
+// The request is bound to ctx, so cancellation and timeouts propagate to the API call.
+// Parameters:
+// - ctx: Context for cancellation and timeouts
+// - url: URL to check
+// Returns:
+// - bool: true if URL matches any threat list
+// - error: errors.ErrSafeBrowsingRequest or errors.ErrSafeBrowsingUnexpectedStatus
+func (c *GoogleSafeBrowsingChecker) IsUnsafe(ctx context.Context, url string) (bool, error) {
+	var (
+		body   bytes.Buffer
+		reqDTO findRequest
+		resDTO findResponse
+	)
+
+	reqDTO.Client.ClientID = clientID
+	reqDTO.Client.ClientVersion = clientVersion
+	reqDTO.ThreatInfo.ThreatTypes = []string{"MALWARE", "SOCIAL_ENGINEERING", "UNWANTED_SOFTWARE", "POTENTIALLY_HARMFUL_APPLICATION"}
+	reqDTO.ThreatInfo.PlatformTypes = []string{"ANY_PLATFORM"}
+	reqDTO.ThreatInfo.ThreatEntryTypes = []string{"URL"}
+	reqDTO.ThreatInfo.ThreatEntries = []threatEntry{{URL: url}}
+
+	if err := json.NewEncoder(&body).Encode(reqDTO); err != nil {
+		return false, fmt.Errorf("%w: %w", errors.ErrSafeBrowsingRequest, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"?key="+c.apiKey, &body)
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", errors.ErrSafeBrowsingRequest, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", errors.ErrSafeBrowsingRequest, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("%w: %d", errors.ErrSafeBrowsingUnexpectedStatus, resp.StatusCode)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&resDTO); err != nil {
+		return false, fmt.Errorf("%w: %w", errors.ErrSafeBrowsingRequest, err)
+	}
+
+	return len(resDTO.Matches) > 0, nil
+}
@@ -0,0 +1,91 @@
+package safebrowsing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gururuby/shortener/pkg/safebrowsing/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GoogleSafeBrowsingChecker_IsUnsafe(t *testing.T) {
+	tests := []struct {
+		err      error
+		name     string
+		response string
+		status   int
+		unsafe   bool
+	}{
+		{
+			name:     "when URL is not on threat lists",
+			status:   http.StatusOK,
+			response: `{}`,
+		},
+		{
+			name:     "when URL matches threat list",
+			status:   http.StatusOK,
+			response: `{"matches":[{"threatType":"MALWARE","threat":{"url":"https://malware.test"}}]}`,
+			unsafe:   true,
+		},
+		{
+			name:   "when API responds with error status",
+			status: http.StatusForbidden,
+			err:    errors.ErrSafeBrowsingUnexpectedStatus,
+		},
+		{
+			name:     "when API responds with malformed body",
+			status:   http.StatusOK,
+			response: `{"matches":`,
+			err:      errors.ErrSafeBrowsingRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req findRequest
+
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "api-key", r.URL.Query().Get("key"))
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, []threatEntry{{URL: "https://malware.test"}}, req.ThreatInfo.ThreatEntries)
+
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer srv.Close()
+
+			c := NewGoogleSafeBrowsingChecker("api-key")
+			c.endpoint = srv.URL
+
+			unsafe, err := c.IsUnsafe(context.Background(), "https://malware.test")
+			require.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.unsafe, unsafe)
+		})
+	}
+}
+
+func Test_GoogleSafeBrowsingChecker_IsUnsafe_ContextCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := NewGoogleSafeBrowsingChecker("api-key")
+	c.endpoint = srv.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.IsUnsafe(ctx, "https://ya.ru")
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_NoOpChecker_IsUnsafe(t *testing.T) {
+	unsafe, err := NoOpChecker{}.IsUnsafe(context.Background(), "https://malware.test")
+	require.NoError(t, err)
+	assert.False(t, unsafe)
+}
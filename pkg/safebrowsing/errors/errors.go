@@ -0,0 +1,15 @@
+// Package errors defines error conditions for URL safety checks.
+package errors
+
+import "errors"
+
+// Errors list
+var (
+	// ErrSafeBrowsingRequest indicates the Safe Browsing API could not be reached
+	// or returned a malformed response.
+	ErrSafeBrowsingRequest = errors.New("cannot check URL safety, Safe Browsing request failed")
+
+	// ErrSafeBrowsingUnexpectedStatus indicates the Safe Browsing API responded
+	// with a non-200 status code, e.g. because of invalid API key or exceeded quota.
+	ErrSafeBrowsingUnexpectedStatus = errors.New("cannot check URL safety, unexpected Safe Browsing response status")
+)
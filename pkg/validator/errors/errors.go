@@ -0,0 +1,45 @@
+// Package errors defines error conditions for input validation.
+package errors
+
+import "errors"
+
+// Errors list
+var (
+	// ErrValidatorInvalidURL indicates that the passed string is not an absolute URL.
+	//
+	// This error occurs when:
+	// - The URL cannot be parsed
+	// - Scheme or host is missing (e.g. "example.com/path" or "/path")
+	ErrValidatorInvalidURL = errors.New("invalid URL, absolute URL expected")
+
+	// ErrValidatorInvalidIDN indicates that the host of the URL is not a valid
+	// internationalized domain name and cannot be converted to ASCII-compatible encoding.
+	//
+	// This error occurs when:
+	// - A label contains disallowed Unicode characters (e.g. zero width joiner, spaces)
+	// - A label starts with a combining mark or a hyphen
+	// - A Punycode label (xn--) cannot be decoded
+	//
+	// Handling recommendation:
+	// Reject the URL as invalid, it cannot be resolved by DNS.
+	ErrValidatorInvalidIDN = errors.New("invalid internationalized domain name")
+
+	// ErrValidatorRedirectLoop indicates that redirects of the URL lead back
+	// to a URL already visited, so following them never reaches a page.
+	//
+	// Handling recommendation:
+	// Reject the URL, a short link to it would never resolve.
+	ErrValidatorRedirectLoop = errors.New("redirect loop detected")
+
+	// ErrValidatorTooManyRedirects indicates that the URL redirects more times
+	// than allowed before reaching the final destination.
+	ErrValidatorTooManyRedirects = errors.New("too many redirects")
+
+	// ErrValidatorDestinationUnreachable indicates that the final destination
+	// of the URL cannot be reached.
+	//
+	// This error occurs when:
+	// - The host cannot be resolved or connected to
+	// - The final destination responds with 4xx or 5xx status
+	ErrValidatorDestinationUnreachable = errors.New("destination is unreachable")
+)
@@ -0,0 +1,102 @@
+package validator
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gururuby/shortener/pkg/validator/errors"
+	"golang.org/x/net/idna"
+)
+
+// acePrefix marks labels already converted to ASCII-compatible encoding.
+const acePrefix = "xn--"
+
+// NormalizeIDN converts a URL to the form used for validation:
+//   - Unicode labels of the host are converted to ASCII-compatible encoding (Punycode)
+//   - Punycode labels are checked to decode to a valid domain name
+//   - Non-ASCII characters of the path, query and fragment are percent-encoded
+//
+// Hosts consisting of plain ASCII labels and IP addresses are kept as is.
+// The result is meant for validation only, the original URL should be stored.
+//
+// Parameters:
+//   - rawURL: The URL string to normalize
+//
+// Returns:
+//   - string: URL with ASCII-only host and percent-encoded non-ASCII characters
+//   - error: errors.ErrValidatorInvalidURL if the URL is not absolute,
+//     errors.ErrValidatorInvalidIDN if the host is not a valid domain name
+//
+// Example:
+//
+//	normalized, err := validator.NormalizeIDN("https://münchen.de/straße")
+//	// normalized == "https://xn--mnchen-3ya.de/stra%C3%9Fe"
+func NormalizeIDN(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", errors.ErrValidatorInvalidURL
+	}
+
+	host := u.Hostname()
+	if isIDN(host) {
+		if host, err = idna.Lookup.ToASCII(host); err != nil {
+			return "", errors.ErrValidatorInvalidIDN
+		}
+		if port := u.Port(); port != "" {
+			host = net.JoinHostPort(host, port)
+		}
+		u.Host = host
+	}
+
+	u.RawQuery = escapeNonASCII(u.RawQuery)
+
+	return u.String(), nil
+}
+
+// isIDN reports whether the host needs IDNA processing, i.e. it is not an IP address
+// and contains non-ASCII characters or Punycode labels.
+// Parameters:
+//   - host: Host without port
+//
+// Returns:
+//   - bool: true if the host is an internationalized domain name
+func isIDN(host string) bool {
+	if net.ParseIP(host) != nil {
+		return false
+	}
+	for _, label := range strings.Split(host, ".") {
+		if strings.HasPrefix(strings.ToLower(label), acePrefix) {
+			return true
+		}
+	}
+	for i := 0; i < len(host); i++ {
+		if host[i] >= utf8.RuneSelf {
+			return true
+		}
+	}
+	return false
+}
+
+// escapeNonASCII percent-encodes non-ASCII bytes keeping the rest of the string as is.
+// Parameters:
+//   - s: Raw URL component, e.g. query
+//
+// Returns:
+//   - string: Component with non-ASCII bytes percent-encoded
+func escapeNonASCII(s string) string {
+	const hex = "0123456789ABCDEF"
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c >= utf8.RuneSelf {
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&0x0f])
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
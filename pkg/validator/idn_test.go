@@ -0,0 +1,106 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/gururuby/shortener/pkg/validator/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeIDN(t *testing.T) {
+	tests := []struct {
+		err  error
+		name string
+		url  string
+		want string
+	}{
+		{
+			name: "pure ASCII hostname",
+			url:  "https://example.com/path?q=1",
+			want: "https://example.com/path?q=1",
+		},
+		{
+			name: "valid IDN",
+			url:  "https://münchen.de",
+			want: "https://xn--mnchen-3ya.de",
+		},
+		{
+			name: "valid IDN with port",
+			url:  "http://пример.рф:8080",
+			want: "http://xn--e1afmkfd.xn--p1ai:8080",
+		},
+		{
+			name: "uppercase IDN",
+			url:  "https://MÜNCHEN.de",
+			want: "https://xn--mnchen-3ya.de",
+		},
+		{
+			name: "Punycode input already encoded",
+			url:  "https://xn--mnchen-3ya.de",
+			want: "https://xn--mnchen-3ya.de",
+		},
+		{
+			name: "path and query with non-ASCII characters",
+			url:  "https://münchen.de/straße?q=ä#ö",
+			want: "https://xn--mnchen-3ya.de/stra%C3%9Fe?q=%C3%A4#%C3%B6",
+		},
+		{
+			name: "IP address host",
+			url:  "http://[::1]:8080/path",
+			want: "http://[::1]:8080/path",
+		},
+		{
+			name: "invalid Unicode label",
+			url:  "https://a\u200db.com",
+			err:  errors.ErrValidatorInvalidIDN,
+		},
+		{
+			name: "label starting with combining mark",
+			url:  "https://\u0301a.de",
+			err:  errors.ErrValidatorInvalidIDN,
+		},
+		{
+			name: "invalid Punycode label",
+			url:  "https://xn--a.com",
+			err:  errors.ErrValidatorInvalidIDN,
+		},
+		{
+			name: "relative URL",
+			url:  "münchen.de/path",
+			err:  errors.ErrValidatorInvalidURL,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeIDN(tt.url)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsInvalidURL_IDN(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want bool
+	}{
+		{name: "pure ASCII hostname", url: "https://example.com", want: false},
+		{name: "valid IDN", url: "https://münchen.de", want: false},
+		{name: "invalid Unicode label", url: "https://a\u200db.com", want: true},
+		{name: "Punycode input already encoded", url: "https://xn--mnchen-3ya.de", want: false},
+		{name: "path with non-ASCII characters", url: "https://münchen.de/straße", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsInvalidURL(tt.url))
+		})
+	}
+}
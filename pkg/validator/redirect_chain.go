@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	validatorErrors "github.com/gururuby/shortener/pkg/validator/errors"
+)
+
+// redirectChainTimeout bounds the whole check in case context has no deadline.
+const redirectChainTimeout = 10 * time.Second
+
+// CheckRedirectChain follows HTTP redirects of the URL and checks that they reach a page.
+// Every hop is recorded, a URL visited twice means the chain is a loop.
+//
+// Parameters:
+//   - ctx: Context for cancellation, the check stops as soon as it is done
+//   - rawURL: Absolute http/https URL to check
+//   - maxHops: Maximum number of redirects to follow
+//
+// Returns:
+//   - error: errors.ErrValidatorRedirectLoop if redirects lead back to a visited URL,
+//     errors.ErrValidatorTooManyRedirects if there are more than maxHops redirects,
+//     errors.ErrValidatorDestinationUnreachable if the final destination cannot be
+//     fetched or responds with 4xx/5xx status, context error if ctx is done
+//
+// Example:
+//
+//	if err := validator.CheckRedirectChain(ctx, "https://example.com/a", 5); err != nil {
+//		// reject the URL
+//	}
+func CheckRedirectChain(ctx context.Context, rawURL string, maxHops int) error {
+	ctx, cancel := context.WithTimeout(ctx, redirectChainTimeout)
+	defer cancel()
+
+	visited := map[string]struct{}{rawURL: {}}
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			hop := req.URL.String()
+			if _, ok := visited[hop]; ok {
+				return validatorErrors.ErrValidatorRedirectLoop
+			}
+			if len(via) > maxHops {
+				return validatorErrors.ErrValidatorTooManyRedirects
+			}
+			visited[hop] = struct{}{}
+			return nil
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("%w: %w", validatorErrors.ErrValidatorDestinationUnreachable, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		switch {
+		case errors.Is(err, validatorErrors.ErrValidatorRedirectLoop),
+			errors.Is(err, validatorErrors.ErrValidatorTooManyRedirects):
+			return errors.Unwrap(err)
+		case ctx.Err() != nil:
+			return ctx.Err()
+		default:
+			return fmt.Errorf("%w: %w", validatorErrors.ErrValidatorDestinationUnreachable, err)
+		}
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%w: %s responded with %d",
+			validatorErrors.ErrValidatorDestinationUnreachable, resp.Request.URL, resp.StatusCode)
+	}
+
+	return nil
+}
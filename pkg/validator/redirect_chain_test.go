@@ -0,0 +1,134 @@
+package validator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gururuby/shortener/pkg/validator/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newRedirectServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	redirect := func(to string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, to, http.StatusFound)
+		}
+	}
+	mux.HandleFunc("/hop1", redirect("/hop2"))
+	mux.HandleFunc("/hop2", redirect("/hop3"))
+	mux.HandleFunc("/hop3", redirect("/page"))
+	mux.HandleFunc("/page", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/loop1", redirect("/loop2"))
+	mux.HandleFunc("/loop2", redirect("/loop1"))
+	mux.HandleFunc("/self", redirect("/self"))
+	mux.HandleFunc("/dead", redirect("/missing"))
+	mux.HandleFunc("/missing", http.NotFound)
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCheckRedirectChain(t *testing.T) {
+	srv := newRedirectServer(t)
+
+	tests := []struct {
+		err     error
+		name    string
+		path    string
+		maxHops int
+	}{
+		{
+			name:    "page without redirects",
+			path:    "/page",
+			maxHops: 5,
+		},
+		{
+			name:    "3-hop chain",
+			path:    "/hop1",
+			maxHops: 5,
+		},
+		{
+			name:    "3-hop chain at hop limit",
+			path:    "/hop1",
+			maxHops: 3,
+		},
+		{
+			name:    "3-hop chain over hop limit",
+			path:    "/hop1",
+			maxHops: 2,
+			err:     errors.ErrValidatorTooManyRedirects,
+		},
+		{
+			name:    "loop",
+			path:    "/loop1",
+			maxHops: 5,
+			err:     errors.ErrValidatorRedirectLoop,
+		},
+		{
+			name:    "redirect to itself",
+			path:    "/self",
+			maxHops: 5,
+			err:     errors.ErrValidatorRedirectLoop,
+		},
+		{
+			name:    "dead end",
+			path:    "/dead",
+			maxHops: 5,
+			err:     errors.ErrValidatorDestinationUnreachable,
+		},
+		{
+			name:    "server error",
+			path:    "/broken",
+			maxHops: 5,
+			err:     errors.ErrValidatorDestinationUnreachable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckRedirectChain(context.Background(), srv.URL+tt.path, tt.maxHops)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCheckRedirectChain_Unreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	err := CheckRedirectChain(context.Background(), srv.URL, 5)
+	require.ErrorIs(t, err, errors.ErrValidatorDestinationUnreachable)
+}
+
+func TestCheckRedirectChain_Canceled(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(block) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := CheckRedirectChain(ctx, srv.URL, 5)
+	require.ErrorIs(t, err, context.Canceled)
+}
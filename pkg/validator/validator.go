@@ -7,8 +7,13 @@ package validator
 
 import "regexp"
 
+// urlRegexp matches HTTP/HTTPS URLs with ASCII-only host.
+var urlRegexp = regexp.MustCompile(`\Ahttps?://(www\.)?\w+(:\d{1,5})?\.?(\w+)?.*\z`)
+
 // IsInvalidURL checks if a string is not a valid HTTP/HTTPS URL.
-// It validates the URL format using a regular expression that matches:
+// Internationalized domain names are converted to ASCII-compatible encoding
+// by NormalizeIDN before the check, so `https://münchen.de` is valid.
+// It validates the normalized URL format using a regular expression that matches:
 //   - http:// or https:// protocols
 //   - Optional www. subdomain
 //   - Domain names with word characters
@@ -27,6 +32,9 @@ import "regexp"
 //	    // handle invalid URL
 //	}
 func IsInvalidURL(rawURL string) bool {
-	reg := regexp.MustCompile(`\Ahttps?://(www\.)?\w+(:\d{1,5})?\.?(\w+)?.*\z`)
-	return !reg.MatchString(rawURL)
+	normalized, err := NormalizeIDN(rawURL)
+	if err != nil {
+		return true
+	}
+	return !urlRegexp.MatchString(normalized)
 }